@@ -47,6 +47,19 @@ func (m *MockAuthorizer) AgentWrite(segment string, ctx *AuthorizerContext) Enfo
 	return ret.Get(0).(EnforcementDecision)
 }
 
+// ConfigEntryRead checks for permission to read a given config entry.
+func (m *MockAuthorizer) ConfigEntryRead(segment string, ctx *AuthorizerContext) EnforcementDecision {
+	ret := m.Called(segment, ctx)
+	return ret.Get(0).(EnforcementDecision)
+}
+
+// ConfigEntryWrite checks for permission to create, update or delete a given
+// config entry.
+func (m *MockAuthorizer) ConfigEntryWrite(segment string, ctx *AuthorizerContext) EnforcementDecision {
+	ret := m.Called(segment, ctx)
+	return ret.Get(0).(EnforcementDecision)
+}
+
 // EventRead determines if a specific event can be queried.
 func (m *MockAuthorizer) EventRead(segment string, ctx *AuthorizerContext) EnforcementDecision {
 	ret := m.Called(segment, ctx)
@@ -185,6 +198,13 @@ func (m *MockAuthorizer) PeeringWrite(ctx *AuthorizerContext) EnforcementDecisio
 	return ret.Get(0).(EnforcementDecision)
 }
 
+// SecretsRead determines if the values hidden by the redaction settings
+// can be read.
+func (m *MockAuthorizer) SecretsRead(ctx *AuthorizerContext) EnforcementDecision {
+	ret := m.Called(ctx)
+	return ret.Get(0).(EnforcementDecision)
+}
+
 // OperatorRead determines if the read-only Consul operator functions
 // can be used.	ret := m.Called(segment, ctx)
 func (m *MockAuthorizer) OperatorRead(ctx *AuthorizerContext) EnforcementDecision {
@@ -32,6 +32,14 @@ func checkAllowAgentWrite(t *testing.T, authz Authorizer, prefix string, entCtx
 	require.Equal(t, Allow, authz.AgentWrite(prefix, entCtx))
 }
 
+func checkAllowConfigEntryRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Allow, authz.ConfigEntryRead(prefix, entCtx))
+}
+
+func checkAllowConfigEntryWrite(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Allow, authz.ConfigEntryWrite(prefix, entCtx))
+}
+
 func checkAllowEventRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Allow, authz.EventRead(prefix, entCtx))
 }
@@ -120,6 +128,10 @@ func checkAllowPeeringWrite(t *testing.T, authz Authorizer, prefix string, entCt
 	require.Equal(t, Allow, authz.PeeringWrite(entCtx))
 }
 
+func checkAllowSecretsRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Allow, authz.SecretsRead(entCtx))
+}
+
 func checkAllowOperatorRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Allow, authz.OperatorRead(entCtx))
 }
@@ -188,6 +200,14 @@ func checkDenyAgentWrite(t *testing.T, authz Authorizer, prefix string, entCtx *
 	require.Equal(t, Deny, authz.AgentWrite(prefix, entCtx))
 }
 
+func checkDenyConfigEntryRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Deny, authz.ConfigEntryRead(prefix, entCtx))
+}
+
+func checkDenyConfigEntryWrite(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Deny, authz.ConfigEntryWrite(prefix, entCtx))
+}
+
 func checkDenyEventRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Deny, authz.EventRead(prefix, entCtx))
 }
@@ -276,6 +296,10 @@ func checkDenyPeeringWrite(t *testing.T, authz Authorizer, prefix string, entCtx
 	require.Equal(t, Deny, authz.PeeringWrite(entCtx))
 }
 
+func checkDenySecretsRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Deny, authz.SecretsRead(entCtx))
+}
+
 func checkDenyOperatorRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Deny, authz.OperatorRead(entCtx))
 }
@@ -352,6 +376,14 @@ func checkDefaultAgentWrite(t *testing.T, authz Authorizer, prefix string, entCt
 	require.Equal(t, Default, authz.AgentWrite(prefix, entCtx))
 }
 
+func checkDefaultConfigEntryRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Default, authz.ConfigEntryRead(prefix, entCtx))
+}
+
+func checkDefaultConfigEntryWrite(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Default, authz.ConfigEntryWrite(prefix, entCtx))
+}
+
 func checkDefaultEventRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Default, authz.EventRead(prefix, entCtx))
 }
@@ -440,6 +472,10 @@ func checkDefaultPeeringWrite(t *testing.T, authz Authorizer, prefix string, ent
 	require.Equal(t, Default, authz.PeeringWrite(entCtx))
 }
 
+func checkDefaultSecretsRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
+	require.Equal(t, Default, authz.SecretsRead(entCtx))
+}
+
 func checkDefaultOperatorRead(t *testing.T, authz Authorizer, prefix string, entCtx *AuthorizerContext) {
 	require.Equal(t, Default, authz.OperatorRead(entCtx))
 }
@@ -531,6 +567,7 @@ func TestACL(t *testing.T) {
 				{name: "DenyMeshWrite", check: checkDenyMeshWrite},
 				{name: "DenyPeeringRead", check: checkDenyPeeringRead},
 				{name: "DenyPeeringWrite", check: checkDenyPeeringWrite},
+				{name: "DenySecretsRead", check: checkDenySecretsRead},
 				{name: "DenyOperatorRead", check: checkDenyOperatorRead},
 				{name: "DenyOperatorWrite", check: checkDenyOperatorWrite},
 				{name: "DenyPreparedQueryRead", check: checkDenyPreparedQueryRead},
@@ -572,6 +609,7 @@ func TestACL(t *testing.T) {
 				{name: "AllowMeshWrite", check: checkAllowMeshWrite},
 				{name: "AllowPeeringRead", check: checkAllowPeeringRead},
 				{name: "AllowPeeringWrite", check: checkAllowPeeringWrite},
+				{name: "AllowSecretsRead", check: checkAllowSecretsRead},
 				{name: "AllowOperatorRead", check: checkAllowOperatorRead},
 				{name: "AllowOperatorWrite", check: checkAllowOperatorWrite},
 				{name: "AllowPreparedQueryRead", check: checkAllowPreparedQueryRead},
@@ -615,6 +653,7 @@ func TestACL(t *testing.T) {
 				{name: "AllowMeshWrite", check: checkAllowMeshWrite},
 				{name: "AllowPeeringRead", check: checkAllowPeeringRead},
 				{name: "AllowPeeringWrite", check: checkAllowPeeringWrite},
+				{name: "AllowSecretsRead", check: checkAllowSecretsRead},
 				{name: "AllowOperatorRead", check: checkAllowOperatorRead},
 				{name: "AllowOperatorWrite", check: checkAllowOperatorWrite},
 				{name: "AllowPreparedQueryRead", check: checkAllowPreparedQueryRead},
@@ -1555,6 +1594,49 @@ func TestACL(t *testing.T) {
 				{name: "WriteAllowed", check: checkAllowMeshWrite},
 			},
 		},
+		{
+			name:          "SecretsDefaultAllowPolicyDeny",
+			defaultPolicy: AllowAll(),
+			policyStack: []*Policy{
+				{
+					PolicyRules: PolicyRules{
+						Secrets: PolicyDeny,
+					},
+				},
+			},
+			checks: []aclCheck{
+				{name: "ReadDenied", check: checkDenySecretsRead},
+			},
+		},
+		{
+			name:          "SecretsDefaultDenyPolicyRead",
+			defaultPolicy: DenyAll(),
+			policyStack: []*Policy{
+				{
+					PolicyRules: PolicyRules{
+						Secrets: PolicyRead,
+					},
+				},
+			},
+			checks: []aclCheck{
+				{name: "ReadAllowed", check: checkAllowSecretsRead},
+			},
+		},
+		{
+			// operator:write doesn't grant secrets:read
+			name:          "SecretsDefaultDenyOperatorWrite",
+			defaultPolicy: DenyAll(),
+			policyStack: []*Policy{
+				{
+					PolicyRules: PolicyRules{
+						Operator: PolicyWrite,
+					},
+				},
+			},
+			checks: []aclCheck{
+				{name: "ReadDenied", check: checkDenySecretsRead},
+			},
+		},
 		{
 			name:          "PeeringDefaultAllowPolicyDeny",
 			defaultPolicy: AllowAll(),
@@ -42,6 +42,7 @@ type Resource string
 const (
 	ResourceACL       Resource = "acl"
 	ResourceAgent     Resource = "agent"
+	ResourceConfig    Resource = "config_entry"
 	ResourceEvent     Resource = "event"
 	ResourceIdentity  Resource = "identity"
 	ResourceIntention Resource = "intention"
@@ -54,6 +55,7 @@ const (
 	ResourceService   Resource = "service"
 	ResourceSession   Resource = "session"
 	ResourcePeering   Resource = "peering"
+	ResourceSecrets   Resource = "secrets"
 )
 
 // Authorizer is the interface for policy enforcement.
@@ -72,6 +74,16 @@ type Authorizer interface {
 	// for a given node.
 	AgentWrite(string, *AuthorizerContext) EnforcementDecision
 
+	// ConfigEntryRead checks for permission to read a given config entry,
+	// named "<kind>/<name>". It returns Default when no rule matches the
+	// config entry, the permissions of its kind then apply.
+	ConfigEntryRead(string, *AuthorizerContext) EnforcementDecision
+
+	// ConfigEntryWrite checks for permission to create, update or delete a
+	// given config entry, named "<kind>/<name>". It returns Default when no
+	// rule matches the config entry, the permissions of its kind then apply.
+	ConfigEntryWrite(string, *AuthorizerContext) EnforcementDecision
+
 	// EventRead determines if a specific event can be queried.
 	EventRead(string, *AuthorizerContext) EnforcementDecision
 
@@ -143,6 +155,10 @@ type Authorizer interface {
 	// functions can be used.
 	PeeringWrite(*AuthorizerContext) EnforcementDecision
 
+	// SecretsRead determines if the values hidden by the redaction
+	// settings of the servers can be read.
+	SecretsRead(*AuthorizerContext) EnforcementDecision
+
 	// NodeRead checks for permission to read (discover) a given node.
 	NodeRead(string, *AuthorizerContext) EnforcementDecision
 
@@ -424,6 +440,15 @@ func (a AllowAuthorizer) PeeringWriteAllowed(ctx *AuthorizerContext) error {
 	return nil
 }
 
+// SecretsReadAllowed determines if the values hidden by the redaction
+// settings of the servers can be read.
+func (a AllowAuthorizer) SecretsReadAllowed(ctx *AuthorizerContext) error {
+	if a.Authorizer.SecretsRead(ctx) != Allow {
+		return PermissionDeniedByACLUnnamed(a, ctx, ResourceSecrets, AccessRead)
+	}
+	return nil
+}
+
 // NodeReadAllowed checks for permission to read (discover) a given node.
 func (a AllowAuthorizer) NodeReadAllowed(name string, ctx *AuthorizerContext) error {
 	if a.Authorizer.NodeRead(name, ctx) != Allow {
@@ -572,6 +597,13 @@ func Enforce(authz Authorizer, rsc Resource, segment string, access string, ctx
 		case "write":
 			return authz.AgentWrite(segment, ctx), nil
 		}
+	case ResourceConfig:
+		switch lowerAccess {
+		case "read":
+			return authz.ConfigEntryRead(segment, ctx), nil
+		case "write":
+			return authz.ConfigEntryWrite(segment, ctx), nil
+		}
 	case ResourceEvent:
 		switch lowerAccess {
 		case "read":
@@ -660,6 +692,11 @@ func Enforce(authz Authorizer, rsc Resource, segment string, access string, ctx
 		case "write":
 			return authz.PeeringWrite(ctx), nil
 		}
+	case ResourceSecrets:
+		switch lowerAccess {
+		case "read":
+			return authz.SecretsRead(ctx), nil
+		}
 	default:
 		if processed, decision, err := enforceEnterprise(authz, rsc, segment, lowerAccess, ctx); processed {
 			return decision, err
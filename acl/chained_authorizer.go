@@ -36,6 +36,19 @@ func (c *ChainedAuthorizer) executeChain(enforce func(authz Authorizer) Enforcem
 	return Deny
 }
 
+// executeChainOrDefault is like executeChain but returns Default when no
+// Authorizer in the chain renders a decision, for the permissions which
+// callers fall back from onto other permissions.
+func (c *ChainedAuthorizer) executeChainOrDefault(enforce func(authz Authorizer) EnforcementDecision) EnforcementDecision {
+	for _, authz := range c.chain {
+		decision := enforce(authz)
+		if decision != Default {
+			return decision
+		}
+	}
+	return Default
+}
+
 // ACLRead checks for permission to list all the ACLs
 func (c *ChainedAuthorizer) ACLRead(entCtx *AuthorizerContext) EnforcementDecision {
 	return c.executeChain(func(authz Authorizer) EnforcementDecision {
@@ -66,6 +79,21 @@ func (c *ChainedAuthorizer) AgentWrite(node string, entCtx *AuthorizerContext) E
 	})
 }
 
+// ConfigEntryRead checks for permission to read a given config entry.
+func (c *ChainedAuthorizer) ConfigEntryRead(name string, entCtx *AuthorizerContext) EnforcementDecision {
+	return c.executeChainOrDefault(func(authz Authorizer) EnforcementDecision {
+		return authz.ConfigEntryRead(name, entCtx)
+	})
+}
+
+// ConfigEntryWrite checks for permission to create, update or delete a given
+// config entry.
+func (c *ChainedAuthorizer) ConfigEntryWrite(name string, entCtx *AuthorizerContext) EnforcementDecision {
+	return c.executeChainOrDefault(func(authz Authorizer) EnforcementDecision {
+		return authz.ConfigEntryWrite(name, entCtx)
+	})
+}
+
 // EventRead determines if a specific event can be queried.
 func (c *ChainedAuthorizer) EventRead(name string, entCtx *AuthorizerContext) EnforcementDecision {
 	return c.executeChain(func(authz Authorizer) EnforcementDecision {
@@ -210,6 +238,14 @@ func (c *ChainedAuthorizer) PeeringWrite(entCtx *AuthorizerContext) EnforcementD
 	})
 }
 
+// SecretsRead determines if the values hidden by the redaction settings
+// can be read.
+func (c *ChainedAuthorizer) SecretsRead(entCtx *AuthorizerContext) EnforcementDecision {
+	return c.executeChain(func(authz Authorizer) EnforcementDecision {
+		return authz.SecretsRead(entCtx)
+	})
+}
+
 // NodeRead checks for permission to read (discover) a given node.
 func (c *ChainedAuthorizer) NodeRead(node string, entCtx *AuthorizerContext) EnforcementDecision {
 	return c.executeChain(func(authz Authorizer) EnforcementDecision {
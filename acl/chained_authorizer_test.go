@@ -23,6 +23,12 @@ func (authz testAuthorizer) AgentRead(string, *AuthorizerContext) EnforcementDec
 func (authz testAuthorizer) AgentWrite(string, *AuthorizerContext) EnforcementDecision {
 	return EnforcementDecision(authz)
 }
+func (authz testAuthorizer) ConfigEntryRead(string, *AuthorizerContext) EnforcementDecision {
+	return EnforcementDecision(authz)
+}
+func (authz testAuthorizer) ConfigEntryWrite(string, *AuthorizerContext) EnforcementDecision {
+	return EnforcementDecision(authz)
+}
 func (authz testAuthorizer) EventRead(string, *AuthorizerContext) EnforcementDecision {
 	return EnforcementDecision(authz)
 }
@@ -89,6 +95,9 @@ func (authz testAuthorizer) PeeringRead(*AuthorizerContext) EnforcementDecision
 func (authz testAuthorizer) PeeringWrite(*AuthorizerContext) EnforcementDecision {
 	return EnforcementDecision(authz)
 }
+func (authz testAuthorizer) SecretsRead(*AuthorizerContext) EnforcementDecision {
+	return EnforcementDecision(authz)
+}
 func (authz testAuthorizer) OperatorRead(*AuthorizerContext) EnforcementDecision {
 	return EnforcementDecision(authz)
 }
@@ -59,6 +59,8 @@ type PolicyRules struct {
 	ACL                   string               `hcl:"acl,expand"`
 	Agents                []*AgentRule         `hcl:"agent,expand"`
 	AgentPrefixes         []*AgentRule         `hcl:"agent_prefix,expand"`
+	ConfigEntries         []*ConfigEntryRule   `hcl:"config_entry,expand"`
+	ConfigEntryPrefixes   []*ConfigEntryRule   `hcl:"config_entry_prefix,expand"`
 	Identities            []*IdentityRule      `hcl:"identity,expand"`
 	IdentityPrefixes      []*IdentityRule      `hcl:"identity_prefix,expand"`
 	Keys                  []*KeyRule           `hcl:"key,expand"`
@@ -77,6 +79,7 @@ type PolicyRules struct {
 	Operator              string               `hcl:"operator"`
 	Mesh                  string               `hcl:"mesh"`
 	Peering               string               `hcl:"peering"`
+	Secrets               string               `hcl:"secrets"`
 }
 
 // Policy is used to represent the policy specified by an ACL configuration.
@@ -92,6 +95,22 @@ type AgentRule struct {
 	Policy string
 }
 
+// ConfigEntryRule represents a rule for the config entries of a kind, named
+// "<kind>/<name>", e.g. "service-defaults/web". A prefix rule can match all
+// the kinds (""), all the entries of a kind ("service-defaults/") or the
+// entries of a kind whose name starts with a prefix
+// ("service-defaults/team-a-").
+//
+// A matching rule takes precedence over the permissions each kind otherwise
+// requires, e.g. service:write for service-defaults, so it can both delegate
+// and restrict the management of the config entries.
+type ConfigEntryRule struct {
+	Name   string `hcl:",key"`
+	Policy string
+
+	EnterpriseRule `hcl:",squash"`
+}
+
 // IdentityRule represents a policy for a workload identity
 type IdentityRule struct {
 	Name   string `hcl:",key"`
@@ -110,6 +129,12 @@ type KeyRule struct {
 	Prefix string `hcl:",key"`
 	Policy string
 
+	// DenyValueRead denies reading the values of the keys, while the "list"
+	// policy still allows listing the keys and the "write" policy still
+	// allows writing them. It allows list-only access to the keys and
+	// write-only drop-box prefixes.
+	DenyValueRead bool `hcl:"deny_value_read"`
+
 	EnterpriseRule `hcl:",squash"`
 }
 
@@ -165,6 +190,12 @@ func isPolicyValid(policy string, allowList bool) bool {
 	return true
 }
 
+// isKeyValueReadValid makes sure deny_value_read is only set along with a
+// policy which grants more than reading the values.
+func isKeyValueReadValid(kp *KeyRule) bool {
+	return !kp.DenyValueRead || kp.Policy == PolicyList || kp.Policy == PolicyWrite
+}
+
 func (pr *PolicyRules) Validate(conf *Config) error {
 	// Validate the acl policy - this one is allowed to be empty
 	if pr.ACL != "" && !isPolicyValid(pr.ACL, false) {
@@ -212,6 +243,9 @@ func (pr *PolicyRules) Validate(conf *Config) error {
 		if !isPolicyValid(kp.Policy, true) {
 			return fmt.Errorf("Invalid key policy: %#v", kp)
 		}
+		if !isKeyValueReadValid(kp) {
+			return fmt.Errorf("Invalid key policy: %#v, deny_value_read requires the list or write policy", kp)
+		}
 		if err := kp.EnterpriseRule.Validate(kp.Policy, conf); err != nil {
 			return fmt.Errorf("Invalid key enterprise policy: %#v, got error: %v", kp, err)
 		}
@@ -220,11 +254,35 @@ func (pr *PolicyRules) Validate(conf *Config) error {
 		if !isPolicyValid(kp.Policy, true) {
 			return fmt.Errorf("Invalid key_prefix policy: %#v", kp)
 		}
+		if !isKeyValueReadValid(kp) {
+			return fmt.Errorf("Invalid key_prefix policy: %#v, deny_value_read requires the list or write policy", kp)
+		}
 		if err := kp.EnterpriseRule.Validate(kp.Policy, conf); err != nil {
 			return fmt.Errorf("Invalid key_prefix enterprise policy: %#v, got error: %v", kp, err)
 		}
 	}
 
+	// Validate the config entry policies
+	for _, cp := range pr.ConfigEntries {
+		if !isPolicyValid(cp.Policy, false) {
+			return fmt.Errorf("Invalid config_entry policy: %#v", cp)
+		}
+		if !strings.Contains(cp.Name, "/") {
+			return fmt.Errorf("Invalid config_entry policy: %#v, the name must be of the form <kind>/<name>", cp)
+		}
+		if err := cp.EnterpriseRule.Validate(cp.Policy, conf); err != nil {
+			return fmt.Errorf("Invalid config_entry enterprise policy: %#v, got error: %v", cp, err)
+		}
+	}
+	for _, cp := range pr.ConfigEntryPrefixes {
+		if !isPolicyValid(cp.Policy, false) {
+			return fmt.Errorf("Invalid config_entry_prefix policy: %#v", cp)
+		}
+		if err := cp.EnterpriseRule.Validate(cp.Policy, conf); err != nil {
+			return fmt.Errorf("Invalid config_entry_prefix enterprise policy: %#v, got error: %v", cp, err)
+		}
+	}
+
 	// Validate the node policies
 	for _, np := range pr.Nodes {
 		if !isPolicyValid(np.Policy, false) {
@@ -322,6 +380,11 @@ func (pr *PolicyRules) Validate(conf *Config) error {
 	if pr.Peering != "" && !isPolicyValid(pr.Peering, false) {
 		return fmt.Errorf("Invalid peering policy: %#v", pr.Peering)
 	}
+
+	// Validate the secrets policy - this one is allowed to be empty
+	if pr.Secrets != "" && !isPolicyValid(pr.Secrets, false) {
+		return fmt.Errorf("Invalid secrets policy: %#v", pr.Secrets)
+	}
 	return nil
 }
 
@@ -386,3 +449,26 @@ func takesPrecedenceOver(a, b string) bool {
 
 	return false
 }
+
+// allowsValueRead returns whether the key rule allows reading the values.
+func (kp *KeyRule) allowsValueRead() bool {
+	return (kp.Policy == PolicyRead || kp.Policy == PolicyList || kp.Policy == PolicyWrite) && !kp.DenyValueRead
+}
+
+// mergeKeyRules returns the merge of the key rules a and b for the same
+// prefix, a being nil for the first one. The policy taking precedence wins,
+// but reading the values is only denied when none of the rules allows it, so
+// that a rule denying it doesn't revoke the read access granted by another
+// policy. The rules are not modified.
+func mergeKeyRules(a, b *KeyRule) *KeyRule {
+	if a == nil {
+		return b
+	}
+	merged := *a
+	if takesPrecedenceOver(b.Policy, a.Policy) {
+		merged = *b
+	}
+	merged.DenyValueRead = (merged.Policy == PolicyList || merged.Policy == PolicyWrite) &&
+		!a.allowsValueRead() && !b.allowsValueRead()
+	return &merged
+}
@@ -23,6 +23,9 @@ type policyAuthorizer struct {
 	// trafficPermissionsRules contains the service intention exact-match policies
 	trafficPermissionsRules *radix.Tree
 
+	// configEntryRules contains the config entry exact-match policies
+	configEntryRules *radix.Tree
+
 	// keyRules contains the key exact-match policies
 	keyRules *radix.Tree
 
@@ -55,6 +58,9 @@ type policyAuthorizer struct {
 	// peeringRule contains the peering policies.
 	peeringRule *policyAuthorizerRule
 
+	// secretsRule contains the secrets policies.
+	secretsRule *policyAuthorizerRule
+
 	// embedded enterprise policy authorizer
 	enterprisePolicyAuthorizer
 }
@@ -65,6 +71,10 @@ type policyAuthorizerRule struct {
 	// decision is the enforcement decision for this rule
 	access AccessLevel
 
+	// denyValueRead denies reading the values of the keys whatever the
+	// access level, it is only set for key rules
+	denyValueRead bool
+
 	// Embedded Consul Enterprise specific policy
 	EnterpriseRule
 }
@@ -133,6 +143,22 @@ func insertPolicyIntoRadix(segment string, policy string, ent *EnterpriseRule, t
 	return nil
 }
 
+// insertKeyPolicyIntoRadix inserts a key rule into the radix tree, along with
+// its restrictions on reading the values of the keys.
+func insertKeyPolicyIntoRadix(kp *KeyRule, tree *radix.Tree, prefix bool) error {
+	if err := insertPolicyIntoRadix(kp.Prefix, kp.Policy, &kp.EnterpriseRule, tree, prefix); err != nil {
+		return err
+	}
+	leaf, _ := tree.Get(kp.Prefix)
+	policyLeaf := leaf.(*policyAuthorizerRadixLeaf)
+	if prefix {
+		policyLeaf.prefix.denyValueRead = kp.DenyValueRead
+	} else {
+		policyLeaf.exact.denyValueRead = kp.DenyValueRead
+	}
+	return nil
+}
+
 // enforce is a convenience function to
 func enforce(rule AccessLevel, requiredPermission AccessLevel) EnforcementDecision {
 	switch rule {
@@ -230,14 +256,28 @@ func (p *policyAuthorizer) loadRules(policy *PolicyRules) error {
 
 	// Load the key policy (exact matches)
 	for _, kp := range policy.Keys {
-		if err := insertPolicyIntoRadix(kp.Prefix, kp.Policy, &kp.EnterpriseRule, p.keyRules, false); err != nil {
+		if err := insertKeyPolicyIntoRadix(kp, p.keyRules, false); err != nil {
 			return err
 		}
 	}
 
 	// Load the key policy (prefix matches)
 	for _, kp := range policy.KeyPrefixes {
-		if err := insertPolicyIntoRadix(kp.Prefix, kp.Policy, &kp.EnterpriseRule, p.keyRules, true); err != nil {
+		if err := insertKeyPolicyIntoRadix(kp, p.keyRules, true); err != nil {
+			return err
+		}
+	}
+
+	// Load the config entry policy (exact matches)
+	for _, cp := range policy.ConfigEntries {
+		if err := insertPolicyIntoRadix(cp.Name, cp.Policy, &cp.EnterpriseRule, p.configEntryRules, false); err != nil {
+			return err
+		}
+	}
+
+	// Load the config entry policy (prefix matches)
+	for _, cp := range policy.ConfigEntryPrefixes {
+		if err := insertPolicyIntoRadix(cp.Name, cp.Policy, &cp.EnterpriseRule, p.configEntryRules, true); err != nil {
 			return err
 		}
 	}
@@ -385,6 +425,15 @@ func (p *policyAuthorizer) loadRules(policy *PolicyRules) error {
 		p.peeringRule = &policyAuthorizerRule{access: access}
 	}
 
+	// Load the secrets policy
+	if policy.Secrets != "" {
+		access, err := AccessLevelFromString(policy.Secrets)
+		if err != nil {
+			return err
+		}
+		p.secretsRule = &policyAuthorizerRule{access: access}
+	}
+
 	return nil
 }
 
@@ -400,6 +449,7 @@ func newPolicyAuthorizerFromRules(rules *PolicyRules, ent *Config) (*policyAutho
 		identityRules:           radix.New(),
 		intentionRules:          radix.New(),
 		trafficPermissionsRules: radix.New(),
+		configEntryRules:        radix.New(),
 		keyRules:                radix.New(),
 		nodeRules:               radix.New(),
 		serviceRules:            radix.New(),
@@ -662,9 +712,34 @@ func (p *policyAuthorizer) TrafficPermissionsWrite(prefix string, _ *AuthorizerC
 	return Default
 }
 
+// ConfigEntryRead checks for permission to read a given config entry, named
+// "<kind>/<name>".
+func (p *policyAuthorizer) ConfigEntryRead(name string, _ *AuthorizerContext) EnforcementDecision {
+	if rule, ok := getPolicy(name, p.configEntryRules); ok {
+		return enforce(rule.access, AccessRead)
+	}
+	return Default
+}
+
+// ConfigEntryWrite checks for permission to create, update or delete a given
+// config entry, named "<kind>/<name>".
+func (p *policyAuthorizer) ConfigEntryWrite(name string, entCtx *AuthorizerContext) EnforcementDecision {
+	if rule, ok := getPolicy(name, p.configEntryRules); ok {
+		decision := enforce(rule.access, AccessWrite)
+		if decision == Allow {
+			return defaultIsAllow(p.enterprisePolicyAuthorizer.enforce(&rule.EnterpriseRule, entCtx))
+		}
+		return decision
+	}
+	return Default
+}
+
 // KeyRead returns if a key is allowed to be read
 func (p *policyAuthorizer) KeyRead(key string, _ *AuthorizerContext) EnforcementDecision {
 	if rule, ok := getPolicy(key, p.keyRules); ok {
+		if rule.denyValueRead {
+			return Deny
+		}
 		return enforce(rule.access, AccessRead)
 	}
 	return Default
@@ -827,6 +902,16 @@ func (p *policyAuthorizer) PeeringWrite(ctx *AuthorizerContext) EnforcementDecis
 	return p.OperatorWrite(ctx)
 }
 
+// SecretsRead determines if the values hidden by the redaction settings of
+// the servers can be read. Unlike the other global rules, it doesn't default
+// to the operator access.
+func (p *policyAuthorizer) SecretsRead(*AuthorizerContext) EnforcementDecision {
+	if p.secretsRule != nil {
+		return enforce(p.secretsRule.access, AccessRead)
+	}
+	return Default
+}
+
 // OperatorRead determines if the read-only operator functions are allowed.
 func (p *policyAuthorizer) OperatorRead(*AuthorizerContext) EnforcementDecision {
 	if p.operatorRule != nil {
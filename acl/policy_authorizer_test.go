@@ -59,6 +59,7 @@ func TestPolicyAuthorizer(t *testing.T) {
 				{name: "DefaultMeshWrite", prefix: "foo", check: checkDefaultMeshWrite},
 				{name: "DefaultPeeringRead", prefix: "foo", check: checkDefaultPeeringRead},
 				{name: "DefaultPeeringWrite", prefix: "foo", check: checkDefaultPeeringWrite},
+				{name: "DefaultSecretsRead", prefix: "foo", check: checkDefaultSecretsRead},
 				{name: "DefaultOperatorRead", prefix: "foo", check: checkDefaultOperatorRead},
 				{name: "DefaultOperatorWrite", prefix: "foo", check: checkDefaultOperatorWrite},
 				{name: "DefaultPreparedQueryRead", prefix: "foo", check: checkDefaultPreparedQueryRead},
@@ -477,6 +478,68 @@ func TestPolicyAuthorizer(t *testing.T) {
 				{name: "PreparedQueryWriteDenied", prefix: "football", check: checkDenyPreparedQueryWrite},
 			},
 		},
+		"Key Deny Value Read": {
+			policy: &Policy{PolicyRules: PolicyRules{
+				Keys: []*KeyRule{
+					{
+						Prefix:        "metadata/readable",
+						Policy:        PolicyRead,
+						DenyValueRead: false,
+					},
+				},
+				KeyPrefixes: []*KeyRule{
+					{
+						Prefix:        "metadata/",
+						Policy:        PolicyList,
+						DenyValueRead: true,
+					},
+					{
+						Prefix:        "dropbox/",
+						Policy:        PolicyWrite,
+						DenyValueRead: true,
+					},
+				},
+			}},
+			checks: []aclCheck{
+				{name: "ListOnlyKeyList", prefix: "metadata/foo", check: checkAllowKeyList},
+				{name: "ListOnlyKeyRead", prefix: "metadata/foo", check: checkDenyKeyRead},
+				{name: "ListOnlyKeyWrite", prefix: "metadata/foo", check: checkDenyKeyWrite},
+				{name: "ExactMatchKeyRead", prefix: "metadata/readable", check: checkAllowKeyRead},
+				{name: "DropBoxKeyWrite", prefix: "dropbox/foo", check: checkAllowKeyWrite},
+				{name: "DropBoxKeyWritePrefix", prefix: "dropbox/", check: checkAllowKeyWritePrefix},
+				{name: "DropBoxKeyList", prefix: "dropbox/foo", check: checkAllowKeyList},
+				{name: "DropBoxKeyRead", prefix: "dropbox/foo", check: checkDenyKeyRead},
+			},
+		},
+		"Config Entries": {
+			policy: &Policy{PolicyRules: PolicyRules{
+				ConfigEntries: []*ConfigEntryRule{
+					{
+						Name:   "service-defaults/team-a-db",
+						Policy: PolicyDeny,
+					},
+				},
+				ConfigEntryPrefixes: []*ConfigEntryRule{
+					{
+						Name:   "service-defaults/team-a-",
+						Policy: PolicyWrite,
+					},
+					{
+						Name:   "service-router/",
+						Policy: PolicyRead,
+					},
+				},
+			}},
+			checks: []aclCheck{
+				{name: "PrefixWriteAllowed", prefix: "service-defaults/team-a-web", check: checkAllowConfigEntryWrite},
+				{name: "PrefixReadAllowed", prefix: "service-defaults/team-a-web", check: checkAllowConfigEntryRead},
+				{name: "ExactDenied", prefix: "service-defaults/team-a-db", check: checkDenyConfigEntryRead},
+				{name: "OtherNameDefault", prefix: "service-defaults/team-b-web", check: checkDefaultConfigEntryWrite},
+				{name: "KindReadAllowed", prefix: "service-router/web", check: checkAllowConfigEntryRead},
+				{name: "KindWriteDenied", prefix: "service-router/web", check: checkDenyConfigEntryWrite},
+				{name: "OtherKindDefault", prefix: "service-resolver/web", check: checkDefaultConfigEntryRead},
+			},
+		},
 		"Intention Wildcards - prefix denied": {
 			policy: &Policy{PolicyRules: PolicyRules{
 				Services: []*ServiceRule{
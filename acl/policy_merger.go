@@ -16,6 +16,9 @@ type policyRulesMergeContext struct {
 	keyPrefixRules           map[string]*KeyRule
 	meshRule                 string
 	peeringRule              string
+	secretsRule              string
+	configEntryRules         map[string]*ConfigEntryRule
+	configEntryPrefixRules   map[string]*ConfigEntryRule
 	nodeRules                map[string]*NodeRule
 	nodePrefixRules          map[string]*NodeRule
 	operatorRule             string
@@ -42,6 +45,9 @@ func (p *policyRulesMergeContext) init() {
 	p.keyPrefixRules = make(map[string]*KeyRule)
 	p.meshRule = ""
 	p.peeringRule = ""
+	p.secretsRule = ""
+	p.configEntryRules = make(map[string]*ConfigEntryRule)
+	p.configEntryPrefixRules = make(map[string]*ConfigEntryRule)
 	p.nodeRules = make(map[string]*NodeRule)
 	p.nodePrefixRules = make(map[string]*NodeRule)
 	p.operatorRule = ""
@@ -143,35 +149,43 @@ func (p *policyRulesMergeContext) merge(policy *PolicyRules) {
 	}
 
 	for _, kp := range policy.Keys {
+		p.keyRules[kp.Prefix] = mergeKeyRules(p.keyRules[kp.Prefix], kp)
+	}
+
+	for _, kp := range policy.KeyPrefixes {
+		p.keyPrefixRules[kp.Prefix] = mergeKeyRules(p.keyPrefixRules[kp.Prefix], kp)
+	}
+
+	for _, np := range policy.Nodes {
 		update := true
-		if permission, found := p.keyRules[kp.Prefix]; found {
-			update = takesPrecedenceOver(kp.Policy, permission.Policy)
+		if permission, found := p.nodeRules[np.Name]; found {
+			update = takesPrecedenceOver(np.Policy, permission.Policy)
 		}
 
 		if update {
-			p.keyRules[kp.Prefix] = kp
+			p.nodeRules[np.Name] = np
 		}
 	}
 
-	for _, kp := range policy.KeyPrefixes {
+	for _, cp := range policy.ConfigEntries {
 		update := true
-		if permission, found := p.keyPrefixRules[kp.Prefix]; found {
-			update = takesPrecedenceOver(kp.Policy, permission.Policy)
+		if permission, found := p.configEntryRules[cp.Name]; found {
+			update = takesPrecedenceOver(cp.Policy, permission.Policy)
 		}
 
 		if update {
-			p.keyPrefixRules[kp.Prefix] = kp
+			p.configEntryRules[cp.Name] = cp
 		}
 	}
 
-	for _, np := range policy.Nodes {
+	for _, cp := range policy.ConfigEntryPrefixes {
 		update := true
-		if permission, found := p.nodeRules[np.Name]; found {
-			update = takesPrecedenceOver(np.Policy, permission.Policy)
+		if permission, found := p.configEntryPrefixRules[cp.Name]; found {
+			update = takesPrecedenceOver(cp.Policy, permission.Policy)
 		}
 
 		if update {
-			p.nodeRules[np.Name] = np
+			p.configEntryPrefixRules[cp.Name] = cp
 		}
 	}
 
@@ -194,6 +208,10 @@ func (p *policyRulesMergeContext) merge(policy *PolicyRules) {
 		p.peeringRule = policy.Peering
 	}
 
+	if takesPrecedenceOver(policy.Secrets, p.secretsRule) {
+		p.secretsRule = policy.Secrets
+	}
+
 	if takesPrecedenceOver(policy.Operator, p.operatorRule) {
 		p.operatorRule = policy.Operator
 	}
@@ -285,6 +303,7 @@ func (p *policyRulesMergeContext) fill(merged *PolicyRules) {
 	merged.Operator = p.operatorRule
 	merged.Mesh = p.meshRule
 	merged.Peering = p.peeringRule
+	merged.Secrets = p.secretsRule
 
 	// All the for loop appends are ugly but Go doesn't have a way to get
 	// a slice of all values within a map so this is necessary
@@ -334,6 +353,16 @@ func (p *policyRulesMergeContext) fill(merged *PolicyRules) {
 		merged.Nodes = append(merged.Nodes, policy)
 	}
 
+	merged.ConfigEntries = []*ConfigEntryRule{}
+	for _, policy := range p.configEntryRules {
+		merged.ConfigEntries = append(merged.ConfigEntries, policy)
+	}
+
+	merged.ConfigEntryPrefixes = []*ConfigEntryRule{}
+	for _, policy := range p.configEntryPrefixRules {
+		merged.ConfigEntryPrefixes = append(merged.ConfigEntryPrefixes, policy)
+	}
+
 	merged.NodePrefixes = []*NodeRule{}
 	for _, policy := range p.nodePrefixRules {
 		merged.NodePrefixes = append(merged.NodePrefixes, policy)
@@ -397,6 +397,63 @@ func TestPolicySourceParse(t *testing.T) {
 			RulesJSON: `{ "service": { "foo": { "policy": "write", "intentions": "foo" }}}`,
 			Err:       "Invalid service intentions policy",
 		},
+		{
+			Name:      "Key Deny Value Read",
+			Rules:     `key_prefix "dropbox/" { policy = "write" deny_value_read = true }`,
+			RulesJSON: `{ "key_prefix": { "dropbox/": { "policy": "write", "deny_value_read": true }}}`,
+			Expected: &Policy{PolicyRules: PolicyRules{
+				KeyPrefixes: []*KeyRule{
+					{
+						Prefix:        "dropbox/",
+						Policy:        "write",
+						DenyValueRead: true,
+					},
+				},
+			}},
+		},
+		{
+			Name:      "Bad Policy - Key Deny Value Read",
+			Rules:     `key "foo" { policy = "read" deny_value_read = true }`,
+			RulesJSON: `{ "key": { "foo": { "policy": "read", "deny_value_read": true }}}`,
+			Err:       "Invalid key policy",
+		},
+		{
+			Name:      "Bad Policy - Key Prefix Deny Value Read",
+			Rules:     `key_prefix "foo" { policy = "deny" deny_value_read = true }`,
+			RulesJSON: `{ "key_prefix": { "foo": { "policy": "deny", "deny_value_read": true }}}`,
+			Err:       "Invalid key_prefix policy",
+		},
+		{
+			Name:      "Config Entries",
+			Rules:     `config_entry_prefix "service-defaults/team-a-" { policy = "write" } config_entry "mesh/mesh" { policy = "read" }`,
+			RulesJSON: `{ "config_entry_prefix": { "service-defaults/team-a-": { "policy": "write" }}, "config_entry": { "mesh/mesh": { "policy": "read" }}}`,
+			Expected: &Policy{PolicyRules: PolicyRules{
+				ConfigEntries: []*ConfigEntryRule{
+					{
+						Name:   "mesh/mesh",
+						Policy: "read",
+					},
+				},
+				ConfigEntryPrefixes: []*ConfigEntryRule{
+					{
+						Name:   "service-defaults/team-a-",
+						Policy: "write",
+					},
+				},
+			}},
+		},
+		{
+			Name:      "Bad Policy - Config Entry",
+			Rules:     `config_entry "mesh/mesh" { policy = "list" }`,
+			RulesJSON: `{ "config_entry": { "mesh/mesh": { "policy": "list" }}}`,
+			Err:       "Invalid config_entry policy",
+		},
+		{
+			Name:      "Bad Policy - Config Entry Name",
+			Rules:     `config_entry "mesh" { policy = "read" }`,
+			RulesJSON: `{ "config_entry": { "mesh": { "policy": "read" }}}`,
+			Err:       "Invalid config_entry policy",
+		},
 		{
 			Name:      "Bad Policy - ACL",
 			Rules:     `acl = "list"`,      // there is no list policy but this helps to exercise another check in isPolicyValid
@@ -523,6 +580,18 @@ func TestPolicySourceParse(t *testing.T) {
 			RulesJSON: `{ "peering": "nope" }`,
 			Err:       "Invalid peering policy",
 		},
+		{
+			Name:      "Bad Policy - Secrets",
+			Rules:     `secrets = "nope"`,
+			RulesJSON: `{ "secrets": "nope" }`,
+			Err:       "Invalid secrets policy",
+		},
+		{
+			Name:      "Secrets Read",
+			Rules:     `secrets = "read"`,
+			RulesJSON: `{ "secrets": "read" }`,
+			Expected:  &Policy{PolicyRules: PolicyRules{Secrets: PolicyRead}},
+		},
 		{
 			Name:      "Keyring Empty",
 			Rules:     `keyring = ""`,
@@ -1059,6 +1128,145 @@ func TestMergePolicies(t *testing.T) {
 				},
 			}},
 		},
+		{
+			// Reading the values is only denied when none of the rules
+			// allows it.
+			name: "Keys Deny Value Read",
+			input: []*Policy{
+				{PolicyRules: PolicyRules{
+					Keys: []*KeyRule{
+						{
+							Prefix:        "dropbox/inbox",
+							Policy:        PolicyWrite,
+							DenyValueRead: true,
+						},
+					},
+					KeyPrefixes: []*KeyRule{
+						{
+							Prefix:        "dropbox/",
+							Policy:        PolicyWrite,
+							DenyValueRead: true,
+						},
+						{
+							Prefix:        "metadata/",
+							Policy:        PolicyList,
+							DenyValueRead: true,
+						},
+						{
+							Prefix:        "shared/",
+							Policy:        PolicyWrite,
+							DenyValueRead: true,
+						},
+						{
+							Prefix:        "uploads/",
+							Policy:        PolicyWrite,
+							DenyValueRead: true,
+						},
+					},
+				}},
+				{PolicyRules: PolicyRules{
+					Keys: []*KeyRule{
+						{
+							Prefix: "dropbox/inbox",
+							Policy: PolicyRead,
+						},
+					},
+					KeyPrefixes: []*KeyRule{
+						{
+							Prefix:        "dropbox/",
+							Policy:        PolicyList,
+							DenyValueRead: true,
+						},
+						{
+							Prefix: "metadata/",
+							Policy: PolicyWrite,
+						},
+						{
+							Prefix: "shared/",
+							Policy: PolicyRead,
+						},
+						{
+							Prefix: "uploads/",
+							Policy: PolicyList,
+						},
+					},
+				}},
+			},
+			expected: &Policy{PolicyRules: PolicyRules{
+				Keys: []*KeyRule{
+					{
+						Prefix: "dropbox/inbox",
+						Policy: PolicyWrite,
+					},
+				},
+				KeyPrefixes: []*KeyRule{
+					{
+						Prefix:        "dropbox/",
+						Policy:        PolicyWrite,
+						DenyValueRead: true,
+					},
+					{
+						Prefix: "metadata/",
+						Policy: PolicyWrite,
+					},
+					{
+						Prefix: "shared/",
+						Policy: PolicyWrite,
+					},
+					{
+						Prefix: "uploads/",
+						Policy: PolicyWrite,
+					},
+				},
+			}},
+		},
+		{
+			name: "Config Entries",
+			input: []*Policy{
+				{PolicyRules: PolicyRules{
+					ConfigEntries: []*ConfigEntryRule{
+						{
+							Name:   "mesh/mesh",
+							Policy: PolicyRead,
+						},
+					},
+					ConfigEntryPrefixes: []*ConfigEntryRule{
+						{
+							Name:   "service-defaults/team-a-",
+							Policy: PolicyWrite,
+						},
+					},
+				}},
+				{PolicyRules: PolicyRules{
+					ConfigEntries: []*ConfigEntryRule{
+						{
+							Name:   "mesh/mesh",
+							Policy: PolicyDeny,
+						},
+					},
+					ConfigEntryPrefixes: []*ConfigEntryRule{
+						{
+							Name:   "service-defaults/team-a-",
+							Policy: PolicyRead,
+						},
+					},
+				}},
+			},
+			expected: &Policy{PolicyRules: PolicyRules{
+				ConfigEntries: []*ConfigEntryRule{
+					{
+						Name:   "mesh/mesh",
+						Policy: PolicyDeny,
+					},
+				},
+				ConfigEntryPrefixes: []*ConfigEntryRule{
+					{
+						Name:   "service-defaults/team-a-",
+						Policy: PolicyWrite,
+					},
+				},
+			}},
+		},
 		{
 			name: "Services",
 			input: []*Policy{
@@ -1346,6 +1554,7 @@ func TestMergePolicies(t *testing.T) {
 						Operator: PolicyRead,
 						Mesh:     PolicyRead,
 						Peering:  PolicyRead,
+						Secrets:  PolicyRead,
 					},
 				},
 				{
@@ -1355,6 +1564,7 @@ func TestMergePolicies(t *testing.T) {
 						Operator: PolicyWrite,
 						Mesh:     PolicyWrite,
 						Peering:  PolicyWrite,
+						Secrets:  PolicyWrite,
 					},
 				},
 			},
@@ -1365,6 +1575,7 @@ func TestMergePolicies(t *testing.T) {
 					Operator: PolicyWrite,
 					Mesh:     PolicyWrite,
 					Peering:  PolicyWrite,
+					Secrets:  PolicyWrite,
 				},
 			},
 		},
@@ -1378,6 +1589,7 @@ func TestMergePolicies(t *testing.T) {
 						Operator: PolicyWrite,
 						Mesh:     PolicyWrite,
 						Peering:  PolicyWrite,
+						Secrets:  PolicyWrite,
 					},
 				},
 				{
@@ -1387,6 +1599,7 @@ func TestMergePolicies(t *testing.T) {
 						Operator: PolicyDeny,
 						Mesh:     PolicyDeny,
 						Peering:  PolicyDeny,
+						Secrets:  PolicyDeny,
 					},
 				},
 			},
@@ -1397,6 +1610,7 @@ func TestMergePolicies(t *testing.T) {
 					Operator: PolicyDeny,
 					Mesh:     PolicyDeny,
 					Peering:  PolicyDeny,
+					Secrets:  PolicyDeny,
 				},
 			},
 		},
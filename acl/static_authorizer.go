@@ -61,6 +61,24 @@ func (s *staticAuthorizer) AgentWrite(string, *AuthorizerContext) EnforcementDec
 	return Deny
 }
 
+func (s *staticAuthorizer) ConfigEntryRead(string, *AuthorizerContext) EnforcementDecision {
+	// The config entries otherwise fall back onto the permissions of
+	// their kind, which already apply the default policy.
+	if s.allowManage {
+		return Allow
+	}
+	return Default
+}
+
+func (s *staticAuthorizer) ConfigEntryWrite(string, *AuthorizerContext) EnforcementDecision {
+	// The config entries otherwise fall back onto the permissions of
+	// their kind, which already apply the default policy.
+	if s.allowManage {
+		return Allow
+	}
+	return Default
+}
+
 func (s *staticAuthorizer) EventRead(string, *AuthorizerContext) EnforcementDecision {
 	if s.defaultAllow {
 		return Allow
@@ -215,6 +233,13 @@ func (s *staticAuthorizer) PeeringWrite(*AuthorizerContext) EnforcementDecision
 	return Deny
 }
 
+func (s *staticAuthorizer) SecretsRead(*AuthorizerContext) EnforcementDecision {
+	if s.defaultAllow {
+		return Allow
+	}
+	return Deny
+}
+
 func (s *staticAuthorizer) OperatorRead(*AuthorizerContext) EnforcementDecision {
 	if s.defaultAllow {
 		return Allow
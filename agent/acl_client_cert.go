@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// loginTokenRefreshMargin is how long before a token obtained by logging in on
+// behalf of an API client expires that the agent stops using it and logs in
+// again.
+const loginTokenRefreshMargin = time.Minute
+
+// loginFailureCacheTTL is how long a failed login on behalf of an API client is
+// cached, so that requests repeatedly presenting the same credentials are not
+// each turned into a login RPC to the servers.
+const loginFailureCacheTTL = 30 * time.Second
+
+// loginTokens caches the tokens obtained by logging in on behalf of API
+// clients, like with the client certificates presented to the HTTPS API, so
+// that each request does not create a new token.
+type loginTokens struct {
+	lock   sync.Mutex
+	tokens map[string]loginToken
+}
+
+type loginToken struct {
+	secretID  string
+	expiresAt time.Time
+
+	// err is the error of a failed login.
+	err error
+}
+
+func (c *loginTokens) get(key string, now time.Time) (loginToken, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	token, ok := c.tokens[key]
+	if !ok || !now.Before(token.expiresAt) {
+		return loginToken{}, false
+	}
+	return token, true
+}
+
+func (c *loginTokens) set(key string, token loginToken, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.tokens == nil {
+		c.tokens = make(map[string]loginToken)
+	}
+	for k, t := range c.tokens {
+		if !now.Before(t.expiresAt) {
+			delete(c.tokens, k)
+		}
+	}
+	c.tokens[key] = token
+}
+
+// clientCertLoginToken returns the token for the verified client certificate
+// presented with req, logging in to the configured auth method if there is no
+// cached token or failure for it. It returns an empty string when the request has no
+// verified client certificate or certificate logins are not configured.
+func (a *Agent) clientCertLoginToken(req *http.Request) (string, error) {
+	method := a.config.HTTPClientCertAuthMethod
+	if method == "" || !a.config.ACLsEnabled {
+		return "", nil
+	}
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return "", nil
+	}
+
+	chain := req.TLS.VerifiedChains[0]
+	leaf := chain[0]
+	fingerprint := sha256.Sum256(leaf.Raw)
+	key := method + "/" + hex.EncodeToString(fingerprint[:])
+
+	now := time.Now()
+	if token, ok := a.clientCertTokens.get(key, now); ok {
+		return token.secretID, token.err
+	}
+
+	var bearer strings.Builder
+	for _, cert := range chain {
+		if err := pem.Encode(&bearer, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return "", err
+		}
+	}
+
+	args := structs.ACLClientCertLoginRequest{
+		Auth: &structs.ACLLoginParams{
+			AuthMethod:  method,
+			BearerToken: bearer.String(),
+		},
+		Node:         a.config.NodeName,
+		Datacenter:   a.config.Datacenter,
+		WriteRequest: structs.WriteRequest{Token: a.tokens.AgentToken()},
+	}
+	var out structs.ACLToken
+	if err := a.RPC(req.Context(), "ACL.ClientCertLogin", &args, &out); err != nil {
+		a.clientCertTokens.set(key, loginToken{
+			expiresAt: now.Add(loginFailureCacheTTL),
+			err:       err,
+		}, now)
+		return "", err
+	}
+
+	// Stop using the token before either it or the certificate expires.
+	expiresAt := leaf.NotAfter
+	if out.ExpirationTime != nil && out.ExpirationTime.Before(expiresAt) {
+		expiresAt = *out.ExpirationTime
+	}
+	a.clientCertTokens.set(key, loginToken{
+		secretID:  out.SecretID,
+		expiresAt: expiresAt.Add(-loginTokenRefreshMargin),
+	}, now)
+
+	return out.SecretID, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod/certauth"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestAgent_ClientCertLoginToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, TestACLConfigWithParams(nil)+`
+		tls {
+			defaults {
+				ca_file   = "../test/ca/root.cer"
+				cert_file = "../test/key/ourdomain.cer"
+				key_file  = "../test/key/ourdomain.key"
+			}
+			https {
+				verify_incoming = true
+			}
+		}
+		http_config {
+			client_cert_auth_method = "certs"
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1", testrpc.WithToken(TestDefaultInitialManagementToken))
+
+	ca := certauth.NewTestCA(t)
+
+	methodReq := structs.ACLAuthMethodSetRequest{
+		Datacenter: "dc1",
+		AuthMethod: structs.ACLAuthMethod{
+			Name:        "certs",
+			Type:        certauth.AuthMethodType,
+			MaxTokenTTL: time.Hour,
+			Config: map[string]interface{}{
+				"TrustedCACerts": []string{ca.CertPEM},
+			},
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}
+	var method structs.ACLAuthMethod
+	require.NoError(t, a.RPC(context.Background(), "ACL.AuthMethodSet", &methodReq, &method))
+
+	ruleReq := structs.ACLBindingRuleSetRequest{
+		Datacenter: "dc1",
+		BindingRule: structs.ACLBindingRule{
+			AuthMethod: "certs",
+			BindType:   structs.BindingRuleBindTypeService,
+			BindName:   "${common_name}",
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}
+	var rule structs.ACLBindingRule
+	require.NoError(t, a.RPC(context.Background(), "ACL.BindingRuleSet", &ruleReq, &rule))
+
+	newRequest := func(t *testing.T, certPEM string) *http.Request {
+		req, err := http.NewRequest("GET", "/v1/catalog/nodes", nil)
+		require.NoError(t, err)
+
+		var chain []*x509.Certificate
+		for _, raw := range []string{certPEM, ca.CertPEM} {
+			block, _ := pem.Decode([]byte(raw))
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+			chain = append(chain, cert)
+		}
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{chain}}
+		return req
+	}
+
+	cert := ca.IssueClientCert(t, pkix.Name{CommonName: "deployer"})
+
+	var token string
+	a.srv.parseToken(newRequest(t, cert.CertPEM), &token)
+	require.NotEmpty(t, token)
+	require.NotEqual(t, a.tokens.UserToken(), token)
+
+	readReq := structs.ACLTokenGetRequest{
+		Datacenter:   "dc1",
+		TokenID:      token,
+		TokenIDType:  structs.ACLTokenSecret,
+		QueryOptions: structs.QueryOptions{Token: TestDefaultInitialManagementToken},
+	}
+	var resp structs.ACLTokenResponse
+	require.NoError(t, a.RPC(context.Background(), "ACL.TokenRead", &readReq, &resp))
+	require.NotNil(t, resp.Token)
+	require.Equal(t, "certs", resp.Token.AuthMethod)
+	require.Len(t, resp.Token.ServiceIdentities, 1)
+	require.Equal(t, "deployer", resp.Token.ServiceIdentities[0].ServiceName)
+
+	t.Run("token is cached", func(t *testing.T) {
+		var again string
+		a.srv.parseToken(newRequest(t, cert.CertPEM), &again)
+		require.Equal(t, token, again)
+	})
+
+	t.Run("explicit token takes precedence", func(t *testing.T) {
+		req := newRequest(t, cert.CertPEM)
+		req.Header.Set("X-Consul-Token", "explicit")
+
+		var explicit string
+		a.srv.parseToken(req, &explicit)
+		require.Equal(t, "explicit", explicit)
+	})
+
+	t.Run("untrusted certificate falls back to the default token", func(t *testing.T) {
+		untrusted := certauth.NewTestCA(t).IssueClientCert(t, pkix.Name{CommonName: "deployer"})
+
+		var fallback string
+		a.srv.parseToken(newRequest(t, untrusted.CertPEM), &fallback)
+		require.Equal(t, a.tokens.UserToken(), fallback)
+
+		// The failed login is cached, so presenting the certificate again
+		// doesn't make another login RPC.
+		block, _ := pem.Decode([]byte(untrusted.CertPEM))
+		fingerprint := sha256.Sum256(block.Bytes)
+		cached, ok := a.clientCertTokens.get("certs/"+hex.EncodeToString(fingerprint[:]), time.Now())
+		require.True(t, ok)
+		require.Error(t, cached.err)
+		require.Empty(t, cached.secretID)
+
+		_, err := a.clientCertLoginToken(newRequest(t, untrusted.CertPEM))
+		require.Equal(t, cached.err, err)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/v1/catalog/nodes", nil)
+		require.NoError(t, err)
+
+		var fallback string
+		a.srv.parseToken(req, &fallback)
+		require.Equal(t, a.tokens.UserToken(), fallback)
+	})
+}
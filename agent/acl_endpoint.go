@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
@@ -23,6 +24,10 @@ type aclBootstrapResponse struct {
 
 var aclDisabled = HTTPError{StatusCode: http.StatusUnauthorized, Reason: "ACL support disabled"}
 
+// defaultStaleTokensUnusedFor is how long a token must have gone unused to be
+// listed by the stale tokens endpoint, when unused-for is not given.
+const defaultStaleTokensUnusedFor = 30 * 24 * time.Hour
+
 // checkACLDisabled will return a standard response if ACLs are disabled. This
 // returns true if they are disabled and we should not continue.
 func (s *HTTPHandlers) checkACLDisabled() bool {
@@ -85,6 +90,81 @@ func (s *HTTPHandlers) ACLReplicationStatus(resp http.ResponseWriter, req *http.
 	return out, nil
 }
 
+// ACLBreakGlassInit initializes the break-glass seal and returns the key
+// shares to hand to the operators.
+func (s *HTTPHandlers) ACLBreakGlassInit(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.checkACLDisabled() {
+		return nil, aclDisabled
+	}
+
+	var body api.ACLBreakGlassInitRequest
+	if err := decodeBody(req.Body, &body); err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Request decoding failed: %v", err)}
+	}
+
+	args := structs.ACLBreakGlassInitRequest{
+		Datacenter: s.agent.config.Datacenter,
+		Shares:     body.Shares,
+		Threshold:  body.Threshold,
+		TTL:        body.TTL,
+	}
+	s.parseToken(req, &args.Token)
+
+	var out structs.ACLBreakGlassInitResponse
+	if err := s.agent.RPC(req.Context(), "ACL.BreakGlassInit", &args, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ACLBreakGlassUnseal submits a key share of the break-glass seal, and
+// returns the break-glass token once a threshold of shares was submitted.
+func (s *HTTPHandlers) ACLBreakGlassUnseal(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.checkACLDisabled() {
+		return nil, aclDisabled
+	}
+
+	var body api.ACLBreakGlassUnsealRequest
+	if err := decodeBody(req.Body, &body); err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Request decoding failed: %v", err)}
+	}
+	if body.Share == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing share"}
+	}
+
+	args := structs.ACLBreakGlassUnsealRequest{
+		Datacenter: s.agent.config.Datacenter,
+		Share:      body.Share,
+	}
+
+	var out structs.ACLBreakGlassUnsealResponse
+	if err := s.agent.RPC(req.Context(), "ACL.BreakGlassUnseal", &args, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ACLBreakGlassStatus returns the status of the break-glass seal.
+func (s *HTTPHandlers) ACLBreakGlassStatus(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.checkACLDisabled() {
+		return nil, aclDisabled
+	}
+
+	args := structs.ACLBreakGlassStatusRequest{
+		Datacenter: s.agent.config.Datacenter,
+	}
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.ACLBreakGlassStatusResponse
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ACL.BreakGlassStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	return &out.ACLBreakGlassStatus, nil
+}
+
 func (s *HTTPHandlers) ACLPolicyList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if s.checkACLDisabled() {
 		return nil, aclDisabled
@@ -334,6 +414,10 @@ func (s *HTTPHandlers) ACLTokenCRUD(resp http.ResponseWriter, req *http.Request)
 		tokenAccessorID = tokenAccessorID[:len(tokenAccessorID)-6]
 		fn = s.ACLTokenClone
 	}
+	if strings.HasSuffix(tokenAccessorID, "/usage") && req.Method == "GET" {
+		tokenAccessorID = tokenAccessorID[:len(tokenAccessorID)-6]
+		fn = s.ACLTokenUsage
+	}
 	if tokenAccessorID == "" && req.Method != "PUT" {
 		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing token AccessorID"}
 	}
@@ -341,6 +425,78 @@ func (s *HTTPHandlers) ACLTokenCRUD(resp http.ResponseWriter, req *http.Request)
 	return fn(resp, req, tokenAccessorID)
 }
 
+func (s *HTTPHandlers) ACLTokenUsage(resp http.ResponseWriter, req *http.Request, tokenAccessorID string) (interface{}, error) {
+	args := structs.ACLTokenUsageRequest{
+		Datacenter: s.agent.config.Datacenter,
+		AccessorID: tokenAccessorID,
+	}
+
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+
+	if args.Datacenter == "" {
+		args.Datacenter = s.agent.config.Datacenter
+	}
+
+	var out structs.ACLTokenUsageResponse
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ACL.TokenUsage", &args, &out); err != nil {
+		if acl.IsErrNotFound(err) {
+			msg := acl.ACLResourceNotExistError("token", args.EnterpriseMeta)
+			return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: msg.Error()}
+		}
+		return nil, err
+	}
+
+	return out.ACLTokenUsageReport, nil
+}
+
+// ACLStaleTokens lists the tokens not used for the duration of the
+// unused-for query parameter, which defaults to defaultStaleTokensUnusedFor.
+func (s *HTTPHandlers) ACLStaleTokens(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.checkACLDisabled() {
+		return nil, aclDisabled
+	}
+
+	args := structs.ACLStaleTokensRequest{
+		Datacenter: s.agent.config.Datacenter,
+		UnusedFor:  defaultStaleTokensUnusedFor,
+	}
+
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+
+	if raw := req.URL.Query().Get("unused-for"); raw != "" {
+		unusedFor, err := time.ParseDuration(raw)
+		if err != nil || unusedFor <= 0 {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Invalid unused-for %q: must be a positive duration", raw)}
+		}
+		args.UnusedFor = unusedFor
+	}
+
+	if args.Datacenter == "" {
+		args.Datacenter = s.agent.config.Datacenter
+	}
+
+	var out structs.ACLStaleTokensResponse
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ACL.StaleTokens", &args, &out); err != nil {
+		return nil, err
+	}
+
+	return out.ACLStaleTokensReport, nil
+}
+
 func (s *HTTPHandlers) ACLTokenSelf(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if s.checkACLDisabled() {
 		return nil, aclDisabled
@@ -1407,7 +1407,7 @@ func TestACL_HTTP(t *testing.T) {
 
 			var list map[string]api.ACLTemplatedPolicyResponse
 			require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
-			require.Len(t, list, 7)
+			require.Len(t, list, 8)
 
 			require.Equal(t, api.ACLTemplatedPolicyResponse{
 				TemplateName: api.ACLTemplatedPolicyServiceName,
@@ -2791,3 +2791,90 @@ func TestHTTPHandlers_ACLReplicationStatus(t *testing.T) {
 		t.Fatalf("should work")
 	}
 }
+
+func TestHTTPHandlers_ACLTokenUsage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		primary_datacenter = "dc1"
+
+		acl {
+			enabled = true
+			default_policy = "deny"
+			enable_token_usage_tracking = true
+
+			tokens {
+				initial_management = "root"
+				agent = "root"
+			}
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1", testrpc.WithToken("root"))
+
+	// A request made with the token is tracked by the agent.
+	req, _ := http.NewRequest("GET", "/v1/acl/token/self", nil)
+	req.Header.Add("X-Consul-Token", "root")
+	req.RemoteAddr = "10.0.0.1:51234"
+	resp := httptest.NewRecorder()
+	a.srv.handler().ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var self structs.ACLToken
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&self))
+	usage, ok := a.tokenUsage.Get(self.AccessorID)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), usage.Count)
+	require.Equal(t, []string{"10.0.0.1"}, usage.SourceAddrs)
+
+	a.reportTokenUsage()
+
+	t.Run("usage", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/acl/token/"+self.AccessorID+"/usage", nil)
+		req.Header.Add("X-Consul-Token", "root")
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.ACLTokenCRUD(resp, req)
+		require.NoError(t, err)
+		report, ok := obj.(structs.ACLTokenUsageReport)
+		require.True(t, ok)
+		require.Equal(t, self.AccessorID, report.AccessorID)
+		require.GreaterOrEqual(t, report.Count, uint64(1))
+		require.Contains(t, report.SourceAddrs, "10.0.0.1")
+	})
+
+	t.Run("usage of a missing token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/acl/token/b1e0c8d4-3f9a-4d3e-9b1a-2c5e6f7a8b9c/usage", nil)
+		req.Header.Add("X-Consul-Token", "root")
+		resp := httptest.NewRecorder()
+		_, err := a.srv.ACLTokenCRUD(resp, req)
+		require.Error(t, err)
+		httpErr, ok := err.(HTTPError)
+		require.True(t, ok)
+		require.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+	})
+
+	t.Run("stale tokens", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/acl/tokens/stale?unused-for=1ns", nil)
+		req.Header.Add("X-Consul-Token", "root")
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.ACLStaleTokens(resp, req)
+		require.NoError(t, err)
+		report, ok := obj.(structs.ACLStaleTokensReport)
+		require.True(t, ok)
+		require.False(t, report.TrackingSince.IsZero())
+	})
+
+	t.Run("stale tokens with an invalid duration", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/acl/tokens/stale?unused-for=-1h", nil)
+		req.Header.Add("X-Consul-Token", "root")
+		resp := httptest.NewRecorder()
+		_, err := a.srv.ACLStaleTokens(resp, req)
+		require.Error(t, err)
+		httpErr, ok := err.(HTTPError)
+		require.True(t, ok)
+		require.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os/user"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+type socketCredContextKey struct{}
+
+// socketCred holds the peer credentials of the client of an HTTP API
+// connection accepted on a Unix domain socket, or the error which prevented
+// reading them.
+type socketCred struct {
+	creds structs.ACLSocketCredentials
+	err   error
+}
+
+// socketCredConnContext is the http.Server ConnContext of the HTTP API
+// servers. It records the peer credentials of the connections accepted on
+// Unix domain sockets in their context.
+func socketCredConnContext(ctx context.Context, conn net.Conn) context.Context {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+
+	creds, err := peerCredentials(unixConn)
+	if err == nil {
+		// The names are only informational, the binding rules can match on
+		// the IDs when they can't be resolved.
+		if u, err := user.LookupId(strconv.FormatUint(uint64(creds.UID), 10)); err == nil {
+			creds.User = u.Username
+		}
+		if g, err := user.LookupGroupId(strconv.FormatUint(uint64(creds.GID), 10)); err == nil {
+			creds.Group = g.Name
+		}
+	}
+	return context.WithValue(ctx, socketCredContextKey{}, &socketCred{creds: creds, err: err})
+}
+
+// socketCredLoginToken returns the token for the peer credentials of the
+// client which sent req over a Unix domain socket, logging in to the
+// configured auth method if there is no cached token for them. It returns an
+// empty string when the request was not received on a Unix domain socket or
+// socket credential logins are not configured.
+func (a *Agent) socketCredLoginToken(req *http.Request) (string, error) {
+	method := a.config.UnixSocketAuthMethod
+	if method == "" || !a.config.ACLsEnabled {
+		return "", nil
+	}
+	cred, ok := req.Context().Value(socketCredContextKey{}).(*socketCred)
+	if !ok {
+		return "", nil
+	}
+	if cred.err != nil {
+		return "", fmt.Errorf("failed to read the peer credentials: %w", cred.err)
+	}
+
+	creds := cred.creds
+	key := fmt.Sprintf("%s/%d:%d/%s:%s", method, creds.UID, creds.GID, creds.User, creds.Group)
+
+	now := time.Now()
+	if token, ok := a.socketCredTokens.get(key, now); ok {
+		return token.secretID, token.err
+	}
+
+	args := structs.ACLSocketCredLoginRequest{
+		Auth: &structs.ACLLoginParams{
+			AuthMethod: method,
+		},
+		Credentials:  creds,
+		Node:         a.config.NodeName,
+		Datacenter:   a.config.Datacenter,
+		WriteRequest: structs.WriteRequest{Token: a.tokens.AgentToken()},
+	}
+	var out structs.ACLToken
+	if err := a.RPC(req.Context(), "ACL.SocketCredLogin", &args, &out); err != nil {
+		return "", err
+	}
+
+	// The auth method requires a MaxTokenTTL, so the token always expires.
+	if out.ExpirationTime != nil {
+		a.socketCredTokens.set(key, loginToken{
+			secretID:  out.SecretID,
+			expiresAt: out.ExpirationTime.Add(-loginTokenRefreshMargin),
+		}, now)
+	}
+
+	return out.SecretID, nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package agent
+
+import (
+	"errors"
+	"net"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func peerCredentials(*net.UnixConn) (structs.ACLSocketCredentials, error) {
+	return structs.ACLSocketCredentials{}, errors.New("peer credentials of Unix domain sockets are only supported on Linux")
+}
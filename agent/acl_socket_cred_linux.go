@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package agent
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// peerCredentials returns the user and group of the process which connected
+// to conn, as recorded by the kernel when it called connect.
+func peerCredentials(conn *net.UnixConn) (structs.ACLSocketCredentials, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return structs.ACLSocketCredentials{}, err
+	}
+
+	var ucred *syscall.Ucred
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		ucred, sockErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return structs.ACLSocketCredentials{}, err
+	}
+	if sockErr != nil {
+		return structs.ACLSocketCredentials{}, sockErr
+	}
+	return structs.ACLSocketCredentials{UID: ucred.Uid, GID: ucred.Gid}, nil
+}
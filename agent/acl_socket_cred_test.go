@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod/socketauth"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestAgent_SocketCredLoginToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+
+	t.Parallel()
+
+	socket := filepath.Join(testutil.TempDir(t, "consul"), "http.sock")
+	a := NewTestAgent(t, TestACLConfigWithParams(nil)+`
+		addresses {
+			http = "unix://`+socket+`"
+		}
+		unix_sockets {
+			auth_method = "local"
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1", testrpc.WithToken(TestDefaultInitialManagementToken))
+
+	methodReq := structs.ACLAuthMethodSetRequest{
+		Datacenter: "dc1",
+		AuthMethod: structs.ACLAuthMethod{
+			Name:        "local",
+			Type:        socketauth.AuthMethodType,
+			MaxTokenTTL: time.Hour,
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}
+	var method structs.ACLAuthMethod
+	require.NoError(t, a.RPC(context.Background(), "ACL.AuthMethodSet", &methodReq, &method))
+
+	uid := strconv.Itoa(os.Getuid())
+	ruleReq := structs.ACLBindingRuleSetRequest{
+		Datacenter: "dc1",
+		BindingRule: structs.ACLBindingRule{
+			AuthMethod: "local",
+			Selector:   "uid == " + uid,
+			BindType:   structs.BindingRuleBindTypeService,
+			BindName:   "local-${uid}",
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}
+	var rule structs.ACLBindingRule
+	require.NoError(t, a.RPC(context.Background(), "ACL.BindingRuleSet", &ruleReq, &rule))
+
+	trans := cleanhttp.DefaultTransport()
+	trans.DialContext = func(_ context.Context, _, _ string) (net.Conn, error) {
+		return net.Dial("unix", socket)
+	}
+	client := &http.Client{Transport: trans}
+
+	readSelf := func(t *testing.T) structs.ACLToken {
+		resp, err := client.Get("http://127.0.0.1/v1/acl/token/self")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var token structs.ACLToken
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+		return token
+	}
+
+	token := readSelf(t)
+	require.Equal(t, "local", token.AuthMethod)
+	require.Len(t, token.ServiceIdentities, 1)
+	require.Equal(t, "local-"+uid, token.ServiceIdentities[0].ServiceName)
+
+	t.Run("token is cached", func(t *testing.T) {
+		require.Equal(t, token.SecretID, readSelf(t).SecretID)
+	})
+
+	t.Run("request without peer credentials", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/v1/catalog/nodes", nil)
+		require.NoError(t, err)
+
+		var fallback string
+		a.srv.parseToken(req, &fallback)
+		require.Equal(t, a.tokens.UserToken(), fallback)
+	})
+}
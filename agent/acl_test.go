@@ -141,6 +141,9 @@ func (a *TestACLAgent) LANMembersInAgentPartition() []serf.Member {
 func (a *TestACLAgent) LANMembers(f consul.LANMemberFilter) ([]serf.Member, error) {
 	return nil, fmt.Errorf("Unimplemented")
 }
+func (a *TestACLAgent) MemberEvents(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{}) {
+	return nil, 0, nil
+}
 func (a *TestACLAgent) AgentLocalMember() serf.Member {
 	return serf.Member{}
 }
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/tokenusage"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// tokenUsageReportInterval is how often the agent reports the usage of the
+// ACL tokens presented to its HTTP API to the servers.
+const tokenUsageReportInterval = 30 * time.Second
+
+// startTokenUsage starts tracking the usage of the ACL tokens when
+// acl.enable_token_usage_tracking is set.
+func (a *Agent) startTokenUsage() {
+	if !a.config.ACLsEnabled || !a.config.ACLTokenUsageTracking {
+		return
+	}
+	a.tokenUsage = tokenusage.NewTracker(a.config.ACLTokenUsageMaxTokens)
+	go a.sendTokenUsage()
+}
+
+// recordTokenUsage records a request made to the HTTP API with token. The
+// anonymous token and the tokens which cannot be resolved are not tracked.
+func (a *Agent) recordTokenUsage(req *http.Request, token string) {
+	if a.tokenUsage == nil {
+		return
+	}
+	accessorID := a.aclAccessorID(token)
+	if accessorID == "" || accessorID == acl.AnonymousTokenID {
+		return
+	}
+	a.tokenUsage.Record(accessorID, sourceAddrFromRequest(req), time.Now())
+}
+
+// sendTokenUsage periodically reports the usage of the tokens to the servers,
+// which aggregate the usage reported by all the agents.
+func (a *Agent) sendTokenUsage() {
+	ticker := time.NewTicker(tokenUsageReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.reportTokenUsage()
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// reportTokenUsage reports the usage tracked since the previous report. The
+// usage which could not be reported is kept for the next report.
+func (a *Agent) reportTokenUsage() {
+	usage := a.tokenUsage.Drain()
+	if len(usage) == 0 {
+		return
+	}
+
+	agentToken := a.tokens.AgentToken()
+	req := structs.ACLTokenUsageReportRequest{
+		Datacenter:     a.config.Datacenter,
+		Node:           a.config.NodeName,
+		Usage:          usage,
+		EnterpriseMeta: *a.AgentEnterpriseMeta(),
+		WriteRequest:   structs.WriteRequest{Token: agentToken},
+	}
+	var reply struct{}
+	if err := a.RPC(context.Background(), "ACL.ReportTokenUsage", &req, &reply); err != nil {
+		a.tokenUsage.Merge(usage)
+		if acl.IsErrPermissionDenied(err) {
+			accessorID := a.aclAccessorID(agentToken)
+			a.logger.Warn("Token usage report blocked by ACLs", "accessorID", acl.AliasIfAnonymousToken(accessorID))
+		} else {
+			a.logger.Error("Token usage report error", "error", err)
+		}
+	}
+}
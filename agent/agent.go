@@ -44,16 +44,22 @@ import (
 	"github.com/hashicorp/consul/agent/cache"
 	cachetype "github.com/hashicorp/consul/agent/cache-types"
 	"github.com/hashicorp/consul/agent/checks"
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
 	"github.com/hashicorp/consul/agent/config"
 	"github.com/hashicorp/consul/agent/consul"
 	rpcRate "github.com/hashicorp/consul/agent/consul/rate"
 	"github.com/hashicorp/consul/agent/consul/servercert"
+	"github.com/hashicorp/consul/agent/consul/tokenusage"
 	"github.com/hashicorp/consul/agent/discovery"
 	"github.com/hashicorp/consul/agent/dns"
+	"github.com/hashicorp/consul/agent/envoymetrics"
 	external "github.com/hashicorp/consul/agent/grpc-external"
 	grpcDNS "github.com/hashicorp/consul/agent/grpc-external/services/dns"
+	grpcExec "github.com/hashicorp/consul/agent/grpc-external/services/exec"
+	grpcHealth "github.com/hashicorp/consul/agent/grpc-external/services/health"
 	middleware "github.com/hashicorp/consul/agent/grpc-middleware"
 	"github.com/hashicorp/consul/agent/hcp/scada"
+	"github.com/hashicorp/consul/agent/hostresolver"
 	"github.com/hashicorp/consul/agent/leafcert"
 	"github.com/hashicorp/consul/agent/local"
 	"github.com/hashicorp/consul/agent/proxycfg"
@@ -176,6 +182,11 @@ type delegate interface {
 	// This is limited to segments and partitions that the node is a member of.
 	LANMembers(f consul.LANMemberFilter) ([]serf.Member, error)
 
+	// MemberEvents returns the changes of the membership of the agent's
+	// canonical serf pool observed after index, the index of the last change
+	// and a channel closed on the next change.
+	MemberEvents(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{})
+
 	// GetLANCoordinate returns the coordinate of the node in the LAN gossip
 	// pool.
 	//
@@ -273,9 +284,43 @@ type Agent struct {
 	// cache is the in-memory cache for data the Agent requests.
 	cache *cache.Cache
 
+	// clientCertTokens caches the tokens obtained for client certificates
+	// presented to the HTTPS API.
+	clientCertTokens loginTokens
+
+	// socketCredTokens caches the tokens obtained for the peer credentials
+	// of the clients of the HTTP API Unix domain sockets.
+	socketCredTokens loginTokens
+
 	// leafCertManager issues and caches leaf certs as needed.
 	leafCertManager *leafcert.Manager
 
+	// authorizeDecisions counts the decisions of the authorize endpoint
+	// until they are reported to the servers.
+	authorizeDecisions *authorizeDecisions
+
+	// tokenUsage tracks the usage of the ACL tokens presented to the HTTP API
+	// until it is reported to the servers. It is nil unless
+	// acl.enable_token_usage_tracking is set.
+	tokenUsage *tokenusage.Tracker
+
+	// meshGatewayWANAddress is the WAN address of the local mesh gateways
+	// discovered from the cloud metadata, when
+	// connect.mesh_gateway_wan_address_provider is set.
+	meshGatewayWANAddress meshGatewayWANAddress
+
+	// agentDefaults holds the agent-defaults config entry applied to the
+	// agent.
+	agentDefaults agentDefaults
+
+	// nodeAttestation holds the attestation evidence of the node. It is nil
+	// unless node_attestation.type is set.
+	nodeAttestation *nodeAttestation
+
+	// gitops applies the config entries stored in a Git repository when
+	// gitops.enabled is set.
+	gitops *gitOpsReceiver
+
 	// checkReapAfter maps the check ID to a timeout after which we should
 	// reap its associated service
 	checkReapAfter map[structs.CheckID]time.Duration
@@ -310,6 +355,10 @@ type Agent struct {
 	// checkOSServices maps the check ID to an associated OS Service check
 	checkOSServices map[structs.CheckID]*checks.CheckOSService
 
+	// checkPlugins maps the check ID to an associated check run by a check
+	// plugin
+	checkPlugins map[structs.CheckID]*checks.CheckPlugin
+
 	// exposedPorts tracks listener ports for checks exposed through a proxy
 	exposedPorts map[string]int
 
@@ -322,6 +371,9 @@ type Agent struct {
 	// osServiceClient is the client for performing OS service checks.
 	osServiceClient *checks.OSServiceClient
 
+	// checkPluginManager launches the check plugins.
+	checkPluginManager *checkplugin.Manager
+
 	// eventCh is used to receive user events
 	eventCh chan serf.UserEvent
 
@@ -338,6 +390,10 @@ type Agent struct {
 	shutdownCh   chan struct{}
 	shutdownLock sync.Mutex
 
+	// envoyMetrics scrapes the Envoy metrics of the local proxies, it is
+	// nil unless telemetry.envoy_metrics_allowlist is set.
+	envoyMetrics *envoymetrics.Aggregator
+
 	// joinLANNotifier is called after a successful JoinLAN.
 	joinLANNotifier notifier
 
@@ -354,6 +410,10 @@ type Agent struct {
 	// dnsServer provides the DNS API
 	dnsServers []dnsServer
 
+	// hostResolver forwards the queries of the host for the DNS domains of
+	// the agent to its DNS servers, when dns_config.host_resolver is enabled.
+	hostResolver *hostresolver.Manager
+
 	// catalogDataFetcher is used as an interface to the catalog for service discovery
 	// (aka DNS). Only applicable to the V2 DNS server (agent/dns).
 	catalogDataFetcher discovery.CatalogDataFetcher
@@ -471,24 +531,26 @@ func New(bd BaseDeps) (*Agent, error) {
 		return nil, errors.New("NetRPC is required")
 	}
 	a := Agent{
-		checkReapAfter:  make(map[structs.CheckID]time.Duration),
-		checkMonitors:   make(map[structs.CheckID]*checks.CheckMonitor),
-		checkTTLs:       make(map[structs.CheckID]*checks.CheckTTL),
-		checkHTTPs:      make(map[structs.CheckID]*checks.CheckHTTP),
-		checkH2PINGs:    make(map[structs.CheckID]*checks.CheckH2PING),
-		checkTCPs:       make(map[structs.CheckID]*checks.CheckTCP),
-		checkUDPs:       make(map[structs.CheckID]*checks.CheckUDP),
-		checkGRPCs:      make(map[structs.CheckID]*checks.CheckGRPC),
-		checkDockers:    make(map[structs.CheckID]*checks.CheckDocker),
-		checkAliases:    make(map[structs.CheckID]*checks.CheckAlias),
-		checkOSServices: make(map[structs.CheckID]*checks.CheckOSService),
-		eventCh:         make(chan serf.UserEvent, 1024),
-		eventBuf:        make([]*UserEvent, 256),
-		joinLANNotifier: &systemd.Notifier{},
-		retryJoinCh:     make(chan error),
-		shutdownCh:      make(chan struct{}),
-		endpoints:       make(map[string]string),
-		stateLock:       mutex.New(),
+		authorizeDecisions: newAuthorizeDecisions(),
+		checkReapAfter:     make(map[structs.CheckID]time.Duration),
+		checkMonitors:      make(map[structs.CheckID]*checks.CheckMonitor),
+		checkTTLs:          make(map[structs.CheckID]*checks.CheckTTL),
+		checkHTTPs:         make(map[structs.CheckID]*checks.CheckHTTP),
+		checkH2PINGs:       make(map[structs.CheckID]*checks.CheckH2PING),
+		checkTCPs:          make(map[structs.CheckID]*checks.CheckTCP),
+		checkUDPs:          make(map[structs.CheckID]*checks.CheckUDP),
+		checkGRPCs:         make(map[structs.CheckID]*checks.CheckGRPC),
+		checkDockers:       make(map[structs.CheckID]*checks.CheckDocker),
+		checkAliases:       make(map[structs.CheckID]*checks.CheckAlias),
+		checkOSServices:    make(map[structs.CheckID]*checks.CheckOSService),
+		checkPlugins:       make(map[structs.CheckID]*checks.CheckPlugin),
+		eventCh:            make(chan serf.UserEvent, 1024),
+		eventBuf:           make([]*UserEvent, 256),
+		joinLANNotifier:    &systemd.Notifier{},
+		retryJoinCh:        make(chan error),
+		shutdownCh:         make(chan struct{}),
+		endpoints:          make(map[string]string),
+		stateLock:          mutex.New(),
 
 		baseDeps:        bd,
 		tokens:          bd.Tokens,
@@ -500,6 +562,7 @@ func New(bd BaseDeps) (*Agent, error) {
 		routineManager:  routine.NewManager(bd.Logger),
 		scadaProvider:   bd.HCP.Provider,
 	}
+	a.checkPluginManager = checkplugin.NewManager(bd.RuntimeConfig.CheckPlugins, bd.Logger.Named(logging.CheckPlugins))
 
 	// TODO: create rpcClientHealth in BaseDeps once NetRPC is available without Agent
 	conn, err := bd.GRPCConnPool.ClientConn(bd.RuntimeConfig.Datacenter)
@@ -793,6 +856,10 @@ func (a *Agent) Start(ctx context.Context) error {
 	a.State.Delegate = a.delegate
 	a.State.TriggerSyncChanges = a.sync.SyncChanges.Trigger
 
+	// Start collecting the attestation evidence of the node, attached to its
+	// registrations.
+	a.startNodeAttestation()
+
 	if err := a.baseDeps.AutoConfig.Start(&lib.StopChannelContext{StopCh: a.shutdownCh}); err != nil {
 		return fmt.Errorf("AutoConf failed to start certificate monitor: %w", err)
 	}
@@ -873,6 +940,25 @@ func (a *Agent) Start(ctx context.Context) error {
 		go a.sendCoordinate()
 	}
 
+	// Start reporting the decisions of the authorize endpoint to the servers.
+	if c.ConnectEnabled {
+		go a.sendAuthorizeDecisions()
+	}
+
+	// Start scraping the Envoy metrics of the local proxies.
+	a.startEnvoyMetrics()
+
+	// Start tracking the usage of the ACL tokens.
+	a.startTokenUsage()
+
+	// Start discovering the WAN address of the mesh gateways.
+	a.startMeshGatewayWANAddressDiscovery()
+
+	// Start applying the agent-defaults config entry.
+	if err := a.startAgentDefaults(); err != nil {
+		return err
+	}
+
 	// Write out the PID file if necessary.
 	if err := a.storePid(); err != nil {
 		return err
@@ -894,6 +980,13 @@ func (a *Agent) Start(ctx context.Context) error {
 		MaxConnsPerClientIP: a.config.HTTPMaxConnsPerClient,
 	})
 
+	if a.config.GitOps.Enabled {
+		a.gitops, err = newGitOpsReceiver(a)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create listeners and unstarted servers; see comment on listenHTTP why
 	// we are doing this.
 	servers, err := a.listenHTTP()
@@ -919,6 +1012,14 @@ func (a *Agent) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Configure the host resolver last, so it is cleaned up by
+	// ShutdownEndpoints which only runs once the agent started.
+	if a.config.DNSHostResolverEnabled {
+		if err := a.startHostResolver(); err != nil {
+			return err
+		}
+	}
+
 	// start retry join
 	go a.retryJoinLAN()
 	if a.config.ServerMode {
@@ -1016,6 +1117,30 @@ func (a *Agent) listenAndServeGRPC(proxyTracker *proxytracker.ProxyTracker, serv
 
 	a.configureXDSServer(proxyWatcher, server)
 
+	// Client libraries subscribe to the instances of their upstreams through
+	// the agent, so that they are served by its streaming cache.
+	if !a.baseDeps.UseV2Resources() {
+		grpcHealth.NewSubscriptionServer(grpcHealth.SubscriptionConfig{
+			Notifier:   a.rpcClientHealth,
+			Logger:     a.logger.Named("grpc-api.health"),
+			Datacenter: a.config.Datacenter,
+			TokenFunc:  a.tokens.UserToken,
+		}).Register(a.externalGRPCServer)
+	}
+
+	// consul exec runs commands on the targeted agents over gRPC, so that
+	// each of them can authorize the caller and stream the output back.
+	if !a.config.DisableRemoteExec {
+		grpcExec.NewServer(grpcExec.Config{
+			ACLResolver:    a.delegate,
+			Logger:         a.logger.Named("grpc-api.exec"),
+			NodeName:       a.config.NodeName,
+			EnterpriseMeta: a.AgentEnterpriseMeta(),
+			ACLsEnabled:    a.config.ACLsEnabled,
+			Audit:          a.writeAuditExecEvent,
+		}).Register(a.externalGRPCServer)
+	}
+
 	// Attempt to spawn listeners
 	var listeners []net.Listener
 	start := func(port_name string, addrs []net.Addr, protocol middleware.Protocol) error {
@@ -1117,6 +1242,30 @@ func (a *Agent) listenAndServeV1DNS() error {
 	return merr.ErrorOrNil()
 }
 
+// startHostResolver configures the resolver of the host to forward the queries
+// for the DNS domains of the agent to its DNS servers.
+func (a *Agent) startHostResolver() error {
+	domains := []string{a.config.DNSDomain}
+	if a.config.DNSAltDomain != "" {
+		domains = append(domains, a.config.DNSAltDomain)
+	}
+
+	m, err := hostresolver.New(hostresolver.Config{
+		Mode:    a.config.DNSHostResolverMode,
+		Domains: domains,
+		Addrs:   a.config.DNSAddrs,
+		Logger:  a.logger.Named(logging.DNS),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure the host resolver: %w", err)
+	}
+	if err := m.Start(); err != nil {
+		return err
+	}
+	a.hostResolver = m
+	return nil
+}
+
 func (a *Agent) listenAndServeV2DNS() error {
 
 	// Check the catalog version and decide which implementation of the data fetcher to implement
@@ -1227,6 +1376,13 @@ func (a *Agent) startListeners(addrs []net.Addr) ([]net.Listener, error) {
 				return nil, err
 			}
 
+		case *config.NamedPipeAddr:
+			l, err = a.listenNamedPipe(x.Name)
+			if err != nil {
+				closeAll()
+				return nil, err
+			}
+
 		case *net.TCPAddr:
 			l, err = net.Listen("tcp", x.String())
 			if err != nil {
@@ -1280,7 +1436,8 @@ func (a *Agent) listenHTTP() ([]apiServer, error) {
 			var tlscfg *tls.Config
 			_, isTCP := l.(*tcpKeepAliveListener)
 			isUnix := l.Addr().Network() == "unix"
-			if (isTCP || isUnix) && proto == "https" {
+			isNamedPipe := l.Addr().Network() == "pipe"
+			if (isTCP || isUnix || isNamedPipe) && proto == "https" {
 				tlscfg = a.tlsConfigurator.IncomingHTTPSConfig()
 				l = tls.NewListener(l, tlscfg)
 			}
@@ -1298,6 +1455,9 @@ func (a *Agent) listenHTTP() ([]apiServer, error) {
 				Handler:        srv.handler(),
 				MaxHeaderBytes: a.config.HTTPMaxHeaderBytes,
 			}
+			if isUnix {
+				httpServer.ConnContext = socketCredConnContext
+			}
 
 			if scada.IsCapability(l.Addr()) {
 				// wrap in http2 server handler
@@ -1510,6 +1670,12 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	cfg.CoordinateUpdateMaxBatches = runtimeCfg.ConsulCoordinateUpdateMaxBatches
 	cfg.CoordinateUpdatePeriod = runtimeCfg.ConsulCoordinateUpdatePeriod
 	cfg.CheckOutputMaxSize = runtimeCfg.CheckOutputMaxSize
+	cfg.CheckUpdateBatchInterval = runtimeCfg.CheckUpdateBatchInterval
+	cfg.Webhooks = runtimeCfg.Webhooks
+	cfg.AdmissionWebhooks = runtimeCfg.AdmissionWebhooks
+	cfg.ACME = runtimeCfg.ACME
+	cfg.RaftArchive = runtimeCfg.RaftArchive
+	cfg.RaftSnapshot = runtimeCfg.RaftSnapshot
 
 	cfg.RaftConfig.HeartbeatTimeout = runtimeCfg.ConsulRaftHeartbeatTimeout
 	cfg.RaftConfig.LeaderLeaseTimeout = runtimeCfg.ConsulRaftLeaderLeaseTimeout
@@ -1529,6 +1695,7 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	cfg.SerfLANConfig.MemberlistConfig.ProbeTimeout = runtimeCfg.GossipLANProbeTimeout
 	cfg.SerfLANConfig.MemberlistConfig.SuspicionMult = runtimeCfg.GossipLANSuspicionMult
 	cfg.SerfLANConfig.MemberlistConfig.RetransmitMult = runtimeCfg.GossipLANRetransmitMult
+	cfg.SerfLANTCPOnly = runtimeCfg.GossipLANTransport == config.GossipTransportTCP
 	if runtimeCfg.ReconnectTimeoutLAN != 0 {
 		cfg.SerfLANConfig.ReconnectTimeout = runtimeCfg.ReconnectTimeoutLAN
 	}
@@ -1546,6 +1713,7 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 		cfg.SerfWANConfig.MemberlistConfig.ProbeTimeout = runtimeCfg.GossipWANProbeTimeout
 		cfg.SerfWANConfig.MemberlistConfig.SuspicionMult = runtimeCfg.GossipWANSuspicionMult
 		cfg.SerfWANConfig.MemberlistConfig.RetransmitMult = runtimeCfg.GossipWANRetransmitMult
+		cfg.SerfWANTCPOnly = runtimeCfg.GossipWANTransport == config.GossipTransportTCP
 		if runtimeCfg.ReconnectTimeoutWAN != 0 {
 			cfg.SerfWANConfig.ReconnectTimeout = runtimeCfg.ReconnectTimeoutWAN
 		}
@@ -1605,6 +1773,8 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	if runtimeCfg.ACLEnableKeyListPolicy {
 		cfg.ACLEnableKeyListPolicy = runtimeCfg.ACLEnableKeyListPolicy
 	}
+	cfg.ACLTokenUsageTracking = runtimeCfg.ACLTokenUsageTracking
+	cfg.ACLTokenUsageMaxTokens = runtimeCfg.ACLTokenUsageMaxTokens
 	if runtimeCfg.SessionTTLMin != 0 {
 		cfg.SessionTTLMin = runtimeCfg.SessionTTLMin
 	}
@@ -1643,6 +1813,20 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	if runtimeCfg.RPCMaxConnsPerClient > 0 {
 		cfg.RPCMaxConnsPerClient = runtimeCfg.RPCMaxConnsPerClient
 	}
+	if runtimeCfg.KVMaxValueSize > 0 {
+		cfg.KVMaxValueSize = runtimeCfg.KVMaxValueSize
+	}
+	if runtimeCfg.TxnMaxReqLen > 0 {
+		cfg.TxnMaxReqLen = runtimeCfg.TxnMaxReqLen
+	}
+	cfg.Quotas = runtimeCfg.Quotas
+	cfg.Redaction = runtimeCfg.Redaction
+	cfg.MetaIndexes = runtimeCfg.MetaIndexes
+	cfg.KVEncryption = runtimeCfg.KVEncryption
+	cfg.KVReplicationPrefixes = runtimeCfg.KVReplicationPrefixes
+	cfg.CatalogProviders = runtimeCfg.CatalogProviders
+	cfg.NodeAttestationAuthMethod = runtimeCfg.NodeAttestation.AuthMethod
+	cfg.NodeAttestationEnforce = runtimeCfg.NodeAttestation.Enforce
 
 	// RPC-related performance configs. We allow explicit zero value to disable so
 	// copy it whatever the value.
@@ -1679,6 +1863,8 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	if runtimeCfg.ConnectEnabled {
 		cfg.ConnectEnabled = true
 		cfg.ConnectMeshGatewayWANFederationEnabled = runtimeCfg.ConnectMeshGatewayWANFederationEnabled
+		cfg.DisableExpiredIntentions = runtimeCfg.ConnectDisableExpiredIntentions
+		cfg.DisableIntentionsAfterReview = runtimeCfg.ConnectDisableIntentionsAfterReview
 
 		ca, err := runtimeCfg.ConnectCAConfiguration()
 		if err != nil {
@@ -1747,6 +1933,31 @@ func segmentConfig(config *config.RuntimeConfig) ([]consul.NetworkSegment, error
 		serfConf.MemberlistConfig.AdvertisePort = s.Advertise.Port
 		serfConf.MemberlistConfig.CIDRsAllowed = config.SerfAllowedCIDRsLAN
 
+		serfConf.MemberlistConfig.GossipInterval = config.GossipLANGossipInterval
+		serfConf.MemberlistConfig.GossipNodes = config.GossipLANGossipNodes
+		serfConf.MemberlistConfig.ProbeInterval = config.GossipLANProbeInterval
+		serfConf.MemberlistConfig.ProbeTimeout = config.GossipLANProbeTimeout
+		serfConf.MemberlistConfig.SuspicionMult = config.GossipLANSuspicionMult
+		serfConf.MemberlistConfig.RetransmitMult = config.GossipLANRetransmitMult
+		if s.GossipInterval != 0 {
+			serfConf.MemberlistConfig.GossipInterval = s.GossipInterval
+		}
+		if s.GossipNodes != 0 {
+			serfConf.MemberlistConfig.GossipNodes = s.GossipNodes
+		}
+		if s.ProbeInterval != 0 {
+			serfConf.MemberlistConfig.ProbeInterval = s.ProbeInterval
+		}
+		if s.ProbeTimeout != 0 {
+			serfConf.MemberlistConfig.ProbeTimeout = s.ProbeTimeout
+		}
+		if s.SuspicionMult != 0 {
+			serfConf.MemberlistConfig.SuspicionMult = s.SuspicionMult
+		}
+		if s.RetransmitMult != 0 {
+			serfConf.MemberlistConfig.RetransmitMult = s.RetransmitMult
+		}
+
 		if config.ReconnectTimeoutLAN != 0 {
 			serfConf.ReconnectTimeout = config.ReconnectTimeoutLAN
 		}
@@ -1883,6 +2094,10 @@ func (a *Agent) ShutdownAgent() error {
 	for _, chk := range a.checkH2PINGs {
 		chk.Stop()
 	}
+	for _, chk := range a.checkPlugins {
+		chk.Stop()
+	}
+	a.checkPluginManager.Close()
 
 	// Stop gRPC
 	if a.externalGRPCServer != nil {
@@ -1937,6 +2152,15 @@ func (a *Agent) ShutdownEndpoints() {
 
 	ctx := context.TODO()
 
+	// Stop forwarding the queries of the host before the DNS servers stop
+	// answering them.
+	if a.hostResolver != nil {
+		if err := a.hostResolver.Stop(); err != nil {
+			a.logger.Error("Failed to restore the host resolver", "error", err)
+		}
+		a.hostResolver = nil
+	}
+
 	for _, srv := range a.dnsServers {
 		srv.Shutdown()
 	}
@@ -2587,6 +2811,9 @@ func (a *Agent) addServiceInternal(req addServiceInternalRequest) error {
 		service.TaggedAddresses[structs.TaggedAddressWANIPv6] = structs.ServiceAddress{Address: service.Address, Port: service.Port}
 	}
 
+	// Advertise the WAN address discovered from the cloud metadata.
+	a.applyMeshGatewayWANAddress(service)
+
 	var checks []*structs.HealthCheck
 
 	// all the checks must be associated with the same enterprise meta of the service
@@ -2824,10 +3051,10 @@ func (a *Agent) validateService(service *structs.NodeService, chkTypes []*struct
 				return fmt.Errorf("Service tagged address %q must be a valid ipv6 address", structs.TaggedAddressLANIPv6)
 			}
 		}
-		if sa, ok := service.TaggedAddresses[structs.TaggedAddressLANIPv6]; ok {
+		if sa, ok := service.TaggedAddresses[structs.TaggedAddressWANIPv6]; ok {
 			ip := net.ParseIP(sa.Address)
 			if ip == nil || ip.To4() != nil {
-				return fmt.Errorf("Service tagged address %q must be a valid ipv6 address", structs.TaggedAddressLANIPv6)
+				return fmt.Errorf("Service tagged address %q must be a valid ipv6 address", structs.TaggedAddressWANIPv6)
 			}
 		}
 	}
@@ -3044,6 +3271,12 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 				return fmt.Errorf("Scripts are disabled on this agent from remote calls; to enable, configure 'enable_script_checks' to true")
 			}
 		}
+
+		if chkType.IsPlugin() {
+			if err := a.validatePluginCheck(chkType); err != nil {
+				return err
+			}
+		}
 	}
 
 	if check.ServiceID != "" {
@@ -3062,6 +3295,13 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 			maxOutputSize = chkType.OutputMaxSize
 		}
 
+		// Checks which set no thresholds default to the consecutive results
+		// required by the flap detection of the agent.
+		if chkType.SuccessBeforePassing == 0 && chkType.FailuresBeforeCritical == 0 {
+			chkType.SuccessBeforePassing = a.config.CheckFlapConsecutiveResults
+			chkType.FailuresBeforeCritical = a.config.CheckFlapConsecutiveResults
+		}
+
 		// FailuresBeforeWarning has to default to same value as FailuresBeforeCritical
 		if chkType.FailuresBeforeWarning == 0 {
 			chkType.FailuresBeforeWarning = chkType.FailuresBeforeCritical
@@ -3082,6 +3322,9 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 		}
 
 		statusHandler := checks.NewStatusHandler(a.State, a.logger, chkType.SuccessBeforePassing, chkType.FailuresBeforeWarning, chkType.FailuresBeforeCritical)
+		if a.config.CheckFlapWindowSize > 0 {
+			statusHandler.WithFlapDetection(a.config.CheckFlapWindowSize, a.config.CheckFlapMinRatio)
+		}
 		sid := check.CompoundServiceID()
 
 		cid := check.CompoundCheckID()
@@ -3335,6 +3578,33 @@ func (a *Agent) addCheck(check *structs.HealthCheck, chkType *structs.CheckType,
 			osServiceCheck.Start()
 			a.checkOSServices[cid] = osServiceCheck
 
+		case chkType.IsPlugin():
+			if existing, ok := a.checkPlugins[cid]; ok {
+				existing.Stop()
+				delete(a.checkPlugins, cid)
+			}
+			if chkType.Interval < checks.MinInterval {
+				a.logger.Warn("check has interval below minimum",
+					"check", cid.String(),
+					"minimum_interval", checks.MinInterval,
+				)
+				chkType.Interval = checks.MinInterval
+			}
+
+			pluginCheck := &checks.CheckPlugin{
+				CheckID:       cid,
+				ServiceID:     sid,
+				Plugin:        chkType.Plugin,
+				Config:        chkType.PluginConfig,
+				Interval:      chkType.Interval,
+				Timeout:       chkType.Timeout,
+				Logger:        a.logger,
+				Providers:     a.checkPluginManager,
+				StatusHandler: statusHandler,
+			}
+			pluginCheck.Start()
+			a.checkPlugins[cid] = pluginCheck
+
 		case chkType.IsMonitor():
 			if existing, ok := a.checkMonitors[cid]; ok {
 				existing.Stop()
@@ -3573,6 +3843,33 @@ func (a *Agent) cancelCheckMonitors(checkID structs.CheckID) {
 		check.Stop()
 		delete(a.checkAliases, checkID)
 	}
+	if check, ok := a.checkPlugins[checkID]; ok {
+		check.Stop()
+		delete(a.checkPlugins, checkID)
+	}
+}
+
+// validatePluginCheck returns an error if the plugin of a check is not
+// configured or rejects the configuration of the check.
+func (a *Agent) validatePluginCheck(chkType *structs.CheckType) error {
+	if !a.checkPluginManager.Has(chkType.Plugin) {
+		return fmt.Errorf("Check plugin %q is not configured on this agent; configure it in 'check_plugins'", chkType.Plugin)
+	}
+	provider, err := a.checkPluginManager.Provider(chkType.Plugin)
+	if err != nil {
+		return err
+	}
+
+	timeout := chkType.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := provider.Validate(ctx, chkType.PluginConfig); err != nil {
+		return fmt.Errorf("Check is not valid: %v", err)
+	}
+	return nil
 }
 
 // updateTTLCheck is used to update the status of a TTL check via the Agent API.
@@ -4332,7 +4629,7 @@ func (a *Agent) reloadConfig(autoReload bool) error {
 		}
 	}
 
-	return a.reloadConfigInternal(newCfg)
+	return a.reloadWithAgentDefaults(newCfg)
 }
 
 func revertStaticConfig(oldCfg tlsutil.ProtocolConfig, newCfg tlsutil.ProtocolConfig) bool {
@@ -4440,6 +4737,7 @@ func (a *Agent) reloadConfigInternal(newCfg *config.RuntimeConfig) error {
 		HeartbeatTimeout:      newCfg.ConsulRaftHeartbeatTimeout,
 		ElectionTimeout:       newCfg.ConsulRaftElectionTimeout,
 		RaftTrailingLogs:      newCfg.RaftTrailingLogs,
+		RaftSnapshot:          newCfg.RaftSnapshot,
 		Reporting: consul.Reporting{
 			License: consul.License{
 				Enabled: newCfg.Reporting.License.Enabled,
@@ -4459,6 +4757,9 @@ func (a *Agent) reloadConfigInternal(newCfg *config.RuntimeConfig) error {
 	// Update filtered metrics
 	metrics.UpdateFilter(newCfg.Telemetry.AllowedPrefixes,
 		newCfg.Telemetry.BlockedPrefixes)
+	if err := a.baseDeps.MetricsConfig.ReloadAddrSinks(newCfg.Telemetry); err != nil {
+		a.logger.Warn("Failed reloading the metrics sinks", "error", err)
+	}
 
 	a.State.SetDiscardCheckOutput(newCfg.DiscardCheckOutput)
 
@@ -4614,6 +4915,8 @@ func (a *Agent) registerCache() {
 
 	a.cache.RegisterType(cachetype.PeeringListName, &cachetype.Peerings{Client: a.rpcClientPeering})
 
+	a.cache.RegisterType(cachetype.VaultClientCertificateName, &cachetype.VaultClientCertificate{})
+
 	a.registerEntCache()
 }
 
@@ -4735,6 +5038,7 @@ func (a *Agent) proxyDataSources(server *consul.Server) proxycfg.DataSources {
 		TrustBundle:                     proxycfgglue.CacheTrustBundle(a.cache),
 		TrustBundleList:                 proxycfgglue.CacheTrustBundleList(a.cache),
 		ExportedPeeredServices:          proxycfgglue.CacheExportedPeeredServices(a.cache),
+		VaultClientCertificate:          proxycfgglue.CacheVaultClientCertificate(a.cache),
 	}
 
 	if server != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/config"
 	"github.com/hashicorp/consul/agent/consul"
+	grpcExec "github.com/hashicorp/consul/agent/grpc-external/services/exec"
 	"github.com/hashicorp/consul/agent/proxycfg"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
@@ -72,3 +73,5 @@ func (*Agent) fillEnterpriseProxyDataSources(*proxycfg.DataSources) {}
 func (a *Agent) writeAuditRPCEvent(_ string, _ string) interface{} {
 	return nil
 }
+
+func (a *Agent) writeAuditExecEvent(_ grpcExec.AuditEvent) {}
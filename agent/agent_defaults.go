@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/consul/agent/cache"
+	cachetype "github.com/hashicorp/consul/agent/cache-types"
+	"github.com/hashicorp/consul/agent/config"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/logging"
+)
+
+// agentDefaults holds the agent-defaults config entry of the partition of the
+// agent and the local configuration it is applied to.
+type agentDefaults struct {
+	lock  sync.Mutex
+	entry *structs.AgentDefaultsConfigEntry
+	local *config.RuntimeConfig
+}
+
+// startAgentDefaults starts watching the agent-defaults config entry, whose
+// settings are applied to the agent unless they are set in the local
+// configuration.
+func (a *Agent) startAgentDefaults() error {
+	a.agentDefaults.lock.Lock()
+	a.agentDefaults.local = a.config
+	a.agentDefaults.lock.Unlock()
+
+	req := &structs.ConfigEntryQuery{
+		Kind:           structs.AgentDefaults,
+		Name:           structs.AgentDefaultsGlobal,
+		Datacenter:     a.config.Datacenter,
+		QueryOptions:   structs.QueryOptions{Token: a.tokens.AgentToken()},
+		EnterpriseMeta: *a.AgentEnterpriseMeta(),
+	}
+	updateCh := make(chan cache.UpdateEvent, 1)
+	ctx := &lib.StopChannelContext{StopCh: a.shutdownCh}
+	if err := a.cache.Notify(ctx, cachetype.ConfigEntryName, req, structs.AgentDefaults, updateCh); err != nil {
+		return err
+	}
+	go a.watchAgentDefaults(updateCh)
+	return nil
+}
+
+func (a *Agent) watchAgentDefaults(updateCh chan cache.UpdateEvent) {
+	logger := a.logger.Named(logging.AgentDefaults)
+	for {
+		select {
+		case <-a.shutdownCh:
+			return
+		case u := <-updateCh:
+			if u.Err != nil {
+				logger.Warn("Failed to fetch the agent-defaults config entry", "error", u.Err)
+				continue
+			}
+			resp, ok := u.Result.(*structs.ConfigEntryResponse)
+			if !ok {
+				logger.Error("Invalid agent-defaults config entry response", "type", fmt.Sprintf("%T", u.Result))
+				continue
+			}
+
+			var entry *structs.AgentDefaultsConfigEntry
+			if resp.Entry != nil {
+				entry, ok = resp.Entry.(*structs.AgentDefaultsConfigEntry)
+				if !ok {
+					logger.Error("Invalid agent-defaults config entry", "type", fmt.Sprintf("%T", resp.Entry))
+					continue
+				}
+			}
+			if a.updateAgentDefaults(entry) {
+				logger.Info("Applied the agent-defaults config entry", "removed", entry == nil)
+			}
+		}
+	}
+}
+
+// updateAgentDefaults applies a new version of the config entry to the
+// agent and returns whether it changed. A nil entry reverts the settings to
+// their local values.
+func (a *Agent) updateAgentDefaults(entry *structs.AgentDefaultsConfigEntry) bool {
+	a.agentDefaults.lock.Lock()
+	defer a.agentDefaults.lock.Unlock()
+
+	if agentDefaultsHash(a.agentDefaults.entry) == agentDefaultsHash(entry) {
+		return false
+	}
+	a.agentDefaults.entry = entry
+
+	cfg := applyAgentDefaults(a.agentDefaults.local, entry)
+
+	// Only the settings of the config entry are reloaded, unlike when the
+	// local configuration is reloaded.
+	if logging.ValidateLogLevel(cfg.Logging.LogLevel) {
+		a.logger.SetLevel(logging.LevelFromString(cfg.Logging.LogLevel))
+	}
+	for _, s := range a.dnsServers {
+		if err := s.ReloadConfig(cfg); err != nil {
+			a.logger.Error("Failed reloading dns config", "error", err)
+		}
+	}
+	if a.catalogDataFetcher != nil {
+		a.catalogDataFetcher.LoadConfig(cfg)
+	}
+	if err := a.baseDeps.MetricsConfig.ReloadAddrSinks(cfg.Telemetry); err != nil {
+		a.logger.Warn("Failed reloading the metrics sinks", "error", err)
+	}
+
+	a.displayOnlyConfigCopyLock.Lock()
+	a.displayOnlyConfigCopy = cfg
+	a.displayOnlyConfigCopyLock.Unlock()
+
+	return true
+}
+
+// reloadWithAgentDefaults reloads the local configuration with the settings
+// of the config entry applied.
+func (a *Agent) reloadWithAgentDefaults(newCfg *config.RuntimeConfig) error {
+	a.agentDefaults.lock.Lock()
+	defer a.agentDefaults.lock.Unlock()
+
+	a.agentDefaults.local = newCfg
+	return a.reloadConfigInternal(applyAgentDefaults(newCfg, a.agentDefaults.entry))
+}
+
+func agentDefaultsHash(entry *structs.AgentDefaultsConfigEntry) uint64 {
+	if entry == nil {
+		return 0
+	}
+	return entry.Hash
+}
+
+// applyAgentDefaults returns a copy of rt with the settings of the config
+// entry applied, except the ones set in the local configuration.
+func applyAgentDefaults(rt *config.RuntimeConfig, entry *structs.AgentDefaultsConfigEntry) *config.RuntimeConfig {
+	cfg := rt.DeepCopy()
+	if entry == nil {
+		return cfg
+	}
+
+	local := make(map[string]struct{}, len(rt.AgentDefaultsLocalKeys))
+	for _, k := range rt.AgentDefaultsLocalKeys {
+		local[k] = struct{}{}
+	}
+	apply := func(key string, set bool, fn func()) {
+		if _, ok := local[key]; !ok && set {
+			fn()
+		}
+	}
+
+	apply("log_level", entry.LogLevel != "", func() {
+		cfg.Logging.LogLevel = entry.LogLevel
+	})
+	apply("recursors", len(entry.DNSRecursors) > 0, func() {
+		cfg.DNSRecursors = append([]string(nil), entry.DNSRecursors...)
+	})
+	apply("telemetry.statsd_address", entry.Telemetry.StatsdAddr != "", func() {
+		cfg.Telemetry.StatsdAddr = entry.Telemetry.StatsdAddr
+	})
+	apply("telemetry.statsite_address", entry.Telemetry.StatsiteAddr != "", func() {
+		cfg.Telemetry.StatsiteAddr = entry.Telemetry.StatsiteAddr
+	})
+	apply("telemetry.dogstatsd_addr", entry.Telemetry.DogstatsdAddr != "", func() {
+		cfg.Telemetry.DogstatsdAddr = entry.Telemetry.DogstatsdAddr
+	})
+	apply("telemetry.dogstatsd_tags", len(entry.Telemetry.DogstatsdTags) > 0, func() {
+		cfg.Telemetry.DogstatsdTags = append([]string(nil), entry.Telemetry.DogstatsdTags...)
+	})
+	return cfg
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/config"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestApplyAgentDefaults(t *testing.T) {
+	rt := &config.RuntimeConfig{
+		DNSRecursors:           []string{"10.0.0.1"},
+		AgentDefaultsLocalKeys: []string{"recursors"},
+	}
+	rt.Logging.LogLevel = "INFO"
+
+	// No entry keeps the local configuration.
+	require.Equal(t, rt, applyAgentDefaults(rt, nil))
+
+	entry := &structs.AgentDefaultsConfigEntry{
+		LogLevel:     "DEBUG",
+		DNSRecursors: []string{"8.8.8.8"},
+		Telemetry: structs.AgentDefaultsTelemetry{
+			StatsdAddr:    "statsd.example.com:8125",
+			DogstatsdTags: []string{"env:prod"},
+		},
+	}
+	cfg := applyAgentDefaults(rt, entry)
+	require.Equal(t, "DEBUG", cfg.Logging.LogLevel)
+	require.Equal(t, []string{"10.0.0.1"}, cfg.DNSRecursors)
+	require.Equal(t, "statsd.example.com:8125", cfg.Telemetry.StatsdAddr)
+	require.Equal(t, []string{"env:prod"}, cfg.Telemetry.DogstatsdTags)
+	require.Empty(t, cfg.Telemetry.StatsiteAddr)
+
+	// The local configuration is left untouched.
+	require.Equal(t, "INFO", rt.Logging.LogLevel)
+	require.Equal(t, lib.TelemetryConfig{}, rt.Telemetry)
+}
+
+func TestAgent_AgentDefaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		log_level = "INFO"
+		telemetry {
+			statsite_address = "127.0.0.1:8125"
+		}
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	entry := &structs.AgentDefaultsConfigEntry{
+		LogLevel:     "TRACE",
+		DNSRecursors: []string{"8.8.8.8"},
+		Telemetry: structs.AgentDefaultsTelemetry{
+			StatsiteAddr: "127.0.0.1:9125",
+			StatsdAddr:   "127.0.0.1:8126",
+		},
+	}
+	var applied bool
+	require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry:      entry,
+	}, &applied))
+	require.True(t, applied)
+
+	retry.Run(t, func(r *retry.R) {
+		cfg := a.getRuntimeConfigForDisplay()
+		require.Equal(r, []string{"8.8.8.8"}, cfg.DNSRecursors)
+		require.Equal(r, "127.0.0.1:8126", cfg.Telemetry.StatsdAddr)
+	})
+
+	// The settings of the local configuration take precedence.
+	cfg := a.getRuntimeConfigForDisplay()
+	require.Equal(t, "INFO", cfg.Logging.LogLevel)
+	require.Equal(t, "127.0.0.1:8125", cfg.Telemetry.StatsiteAddr)
+
+	// A reload of the local configuration keeps the settings of the entry.
+	require.NoError(t, a.reloadWithAgentDefaults(a.config))
+	require.Equal(t, []string{"8.8.8.8"}, a.getRuntimeConfigForDisplay().DNSRecursors)
+
+	// Deleting the entry reverts to the local configuration.
+	require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Delete", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Op:         structs.ConfigEntryDelete,
+		Entry:      &structs.AgentDefaultsConfigEntry{Name: structs.AgentDefaultsGlobal},
+	}, &structs.ConfigEntryDeleteResponse{}))
+
+	retry.Run(t, func(r *retry.R) {
+		cfg := a.getRuntimeConfigForDisplay()
+		require.Empty(r, cfg.DNSRecursors)
+		require.Empty(r, cfg.Telemetry.StatsdAddr)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/consul/agent/leafcert"
 	"github.com/hashicorp/consul/agent/structs"
 	token_store "github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/agent/xds"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
 	"github.com/hashicorp/consul/internal/gossip/librtt"
@@ -92,6 +93,15 @@ func (s *HTTPHandlers) AgentSelf(resp http.ResponseWriter, req *http.Request) (i
 
 	displayConfig := s.agent.getRuntimeConfigForDisplay()
 
+	// Hide the redacted fields of the bootstrap config entries, which end up
+	// in the debug bundles, unless the caller is allowed to read them.
+	if displayConfig.Redaction.Enabled() && authz.SecretsRead(nil) != acl.Allow {
+		if entries, ok := displayConfig.Redaction.RedactConfigEntries(displayConfig.ConfigEntryBootstrap); ok {
+			displayConfig = displayConfig.DeepCopy()
+			displayConfig.ConfigEntryBootstrap = entries
+		}
+	}
+
 	var xds *XDSSelf
 	if s.agent.xdsServer != nil {
 		xds = &XDSSelf{
@@ -201,7 +211,11 @@ func (s *HTTPHandlers) AgentMetrics(resp http.ResponseWriter, req *http.Request)
 			ErrorHandling: promhttp.ContinueOnError,
 		}
 
-		handler := promhttp.HandlerFor(prometheus.DefaultGatherer, handlerOptions)
+		var gatherer prometheus.Gatherer = prometheus.DefaultGatherer
+		if s.agent.envoyMetrics != nil {
+			gatherer = prometheus.Gatherers{gatherer, s.agent.envoyMetrics}
+		}
+		handler := promhttp.HandlerFor(gatherer, handlerOptions)
 		handler.ServeHTTP(resp, req)
 		return nil, nil
 	}
@@ -280,6 +294,82 @@ func (s *HTTPHandlers) AgentReload(resp http.ResponseWriter, req *http.Request)
 	return nil, s.agent.ReloadConfig()
 }
 
+// AgentCache returns the usage of each of the agent's cache types.
+func (s *HTTPHandlers) AgentCache(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Fetch the ACL token, if any, and enforce agent policy.
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorize using the agent's own enterprise meta, not the token.
+	var authzContext acl.AuthorizerContext
+	s.agent.AgentEnterpriseMeta().FillAuthzContext(&authzContext)
+	if err := authz.ToAllowAuthorizer().AgentReadAllowed(s.agent.config.NodeName, &authzContext); err != nil {
+		return nil, err
+	}
+
+	stats := s.agent.cache.Stats()
+	out := make([]api.AgentCacheType, 0, len(stats))
+	for _, stat := range stats {
+		out = append(out, api.AgentCacheType{
+			Name:            stat.Name,
+			Entries:         stat.Entries,
+			Hits:            stat.Hits,
+			Misses:          stat.Misses,
+			HitRate:         stat.HitRate,
+			AgeDistribution: stat.AgeDistribution,
+			OldestAge:       stat.OldestAge,
+		})
+	}
+	return out, nil
+}
+
+// AgentCacheInvalidate evicts the agent's cache entries of a type, optionally
+// scoped to a datacenter or peer, so that they are fetched from the servers
+// again on their next use.
+func (s *HTTPHandlers) AgentCacheInvalidate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Fetch the ACL token, if any, and enforce agent policy.
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorize using the agent's own enterprise meta, not the token.
+	var authzContext acl.AuthorizerContext
+	s.agent.AgentEnterpriseMeta().FillAuthzContext(&authzContext)
+	if err := authz.ToAllowAuthorizer().AgentWriteAllowed(s.agent.config.NodeName, &authzContext); err != nil {
+		return nil, err
+	}
+
+	cacheType := strings.TrimPrefix(req.URL.Path, "/v1/agent/cache/")
+	if cacheType == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing cache type"}
+	}
+
+	query := req.URL.Query()
+	dc, peer := query.Get("dc"), query.Get("peer")
+	if dc != "" && peer != "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "dc and peer are mutually exclusive"}
+	}
+
+	n, err := s.agent.cache.Invalidate(cacheType, dc, peer)
+	if err != nil {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: err.Error()}
+	}
+	s.agent.logger.Info("Invalidated agent cache entries",
+		"type", cacheType,
+		"datacenter", dc,
+		"peer", peer,
+		"count", n,
+	)
+	return api.AgentCacheInvalidateResponse{Invalidated: n}, nil
+}
+
 func buildAgentService(s *structs.NodeService, dc string) api.AgentService {
 	weights := api.AgentWeights{Passing: 1, Warning: 1}
 	if s.Weights != nil {
@@ -658,6 +748,86 @@ func (s *HTTPHandlers) AgentMembers(resp http.ResponseWriter, req *http.Request)
 	return members, nil
 }
 
+// AgentMemberEvents returns the changes of the membership of the LAN gossip
+// pool observed by the agent after the index of the query, blocking until
+// there is one.
+func (s *HTTPHandlers) AgentMemberEvents(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var queryOpts structs.QueryOptions
+	if parseWait(resp, req, &queryOpts) {
+		// parseWait returns an error itself
+		return nil, nil
+	}
+
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *bexpr.Filter
+	var filterExpression string
+	s.parseFilter(req, &filterExpression)
+	if filterExpression != "" {
+		filter, err = bexpr.CreateFilter(filterExpression, nil, []structs.MemberEvent{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The index is reset when the agent restarts, the events after a newer
+	// index are then all the events.
+	minIndex := queryOpts.MinQueryIndex
+	var hash string
+	if minIndex > 0 {
+		hash = strconv.FormatUint(minIndex, 10)
+	}
+
+	var lastIndex uint64
+	var total int
+	_, result, err := s.agent.LocalBlockingQuery(false, hash, queryOpts.MaxQueryTime,
+		func(ws memdb.WatchSet) (string, interface{}, error) {
+			index := minIndex
+			events, last, watchCh := s.agent.delegate.MemberEvents(index)
+			if index > last {
+				events, last, watchCh = s.agent.delegate.MemberEvents(0)
+			}
+			ws.Add(watchCh)
+			lastIndex = last
+
+			if filter != nil {
+				raw, err := filter.Execute(events)
+				if err != nil {
+					return "", nil, err
+				}
+				events = raw.([]structs.MemberEvent)
+			}
+
+			total = len(events)
+			var authzContext acl.AuthorizerContext
+			filtered := make([]structs.MemberEvent, 0, len(events))
+			for _, e := range events {
+				member := serf.Member{Name: e.Name, Tags: e.Tags}
+				serfMemberFillAuthzContext(&member, &authzContext)
+				if authz.NodeRead(e.Name, &authzContext) == acl.Allow {
+					filtered = append(filtered, e)
+				}
+			}
+			return strconv.FormatUint(last, 10), filtered, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, lastIndex)
+	// See the comment in AgentMembers.
+	if token != "" {
+		setResultsFilteredByACLs(resp, total != len(result.([]structs.MemberEvent)))
+	}
+	return result, nil
+}
+
 func (s *HTTPHandlers) AgentJoin(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Fetch the ACL token, if any, and enforce agent policy.
 	var token string
@@ -1442,6 +1612,13 @@ func (s *HTTPHandlers) AgentMonitor(resp http.ResponseWriter, req *http.Request)
 		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Unknown log level: %s", logLevel)}
 	}
 
+	subsystem := req.URL.Query().Get("subsystem")
+
+	var recent bool
+	if _, ok := req.URL.Query()["recent"]; ok {
+		recent = true
+	}
+
 	flusher, ok := resp.(http.Flusher)
 	if !ok {
 		return nil, fmt.Errorf("Streaming not supported")
@@ -1454,6 +1631,7 @@ func (s *HTTPHandlers) AgentMonitor(resp http.ResponseWriter, req *http.Request)
 			Level:      logging.LevelFromString(logLevel),
 			JSONFormat: logJSON,
 		},
+		Subsystem: subsystem,
 	})
 	logsCh := monitor.Start()
 
@@ -1463,6 +1641,14 @@ func (s *HTTPHandlers) AgentMonitor(resp http.ResponseWriter, req *http.Request)
 	// 0 byte write is needed before the Flush call so that if we are using
 	// a gzip stream it will go ahead and write out the HTTP response header
 	resp.Write([]byte(""))
+
+	// The recent logs are read after the monitor started so that no log is
+	// missed, at the cost of possibly repeating the logs in between.
+	if recent && s.agent.baseDeps.LogSubsystems != nil {
+		for _, line := range s.agent.baseDeps.LogSubsystems.Recent(subsystem, logging.LevelFromString(logLevel), logJSON) {
+			resp.Write(line)
+		}
+	}
 	flusher.Flush()
 	const flushDelay = 200 * time.Millisecond
 	flushTicker := time.NewTicker(flushDelay)
@@ -1488,6 +1674,69 @@ func (s *HTTPHandlers) AgentMonitor(resp http.ResponseWriter, req *http.Request)
 	}
 }
 
+// AgentLogLevel returns the log level of the agent and the levels set for its
+// subsystems, or sets the level of a subsystem at runtime.
+func (s *HTTPHandlers) AgentLogLevel(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Fetch the ACL token, if any, and enforce agent policy.
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorize using the agent's own enterprise meta, not the token.
+	var authzContext acl.AuthorizerContext
+	s.agent.AgentEnterpriseMeta().FillAuthzContext(&authzContext)
+	if req.Method == "PUT" {
+		err = authz.ToAllowAuthorizer().AgentWriteAllowed(s.agent.config.NodeName, &authzContext)
+	} else {
+		err = authz.ToAllowAuthorizer().AgentReadAllowed(s.agent.config.NodeName, &authzContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subsystems := s.agent.baseDeps.LogSubsystems
+	if subsystems == nil {
+		return nil, HTTPError{StatusCode: http.StatusNotImplemented, Reason: "Runtime log levels are not supported by this agent"}
+	}
+
+	if req.Method == "PUT" {
+		var args api.AgentLogLevelRequest
+		if err := decodeBody(req.Body, &args); err != nil {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Request decode failed: %v", err)}
+		}
+
+		level := hclog.NoLevel
+		switch {
+		case args.Level != "":
+			if !logging.ValidateLogLevel(args.Level) {
+				return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Unknown log level: %s", args.Level)}
+			}
+			level = logging.LevelFromString(args.Level)
+		case args.Subsystem == "":
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing log level"}
+		}
+
+		subsystems.SetLevel(args.Subsystem, level)
+		s.agent.logger.Info("Updated log level",
+			"subsystem", args.Subsystem,
+			"level", args.Level,
+		)
+	}
+
+	global, levels := subsystems.Levels()
+	out := api.AgentLogLevel{
+		Level:      strings.ToUpper(global.String()),
+		Subsystems: make(map[string]string, len(levels)),
+	}
+	for name, level := range levels {
+		out.Subsystems[name] = strings.ToUpper(level.String())
+	}
+	return out, nil
+}
+
 func (s *HTTPHandlers) AgentToken(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if s.checkACLDisabled() {
 		return nil, HTTPError{StatusCode: http.StatusUnauthorized, Reason: "ACL support disabled"}
@@ -1588,6 +1837,48 @@ func (s *HTTPHandlers) AgentConnectCARoots(resp http.ResponseWriter, req *http.R
 	return *reply, nil
 }
 
+// AgentConnectProxies returns the proxies connected to the agent's xDS server
+// along with their Envoy version, so that proxy upgrades can be planned.
+func (s *HTTPHandlers) AgentConnectProxies(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorize using the agent's own enterprise meta, not the token.
+	var authzContext acl.AuthorizerContext
+	s.agent.AgentEnterpriseMeta().FillAuthzContext(&authzContext)
+	if err := authz.ToAllowAuthorizer().AgentReadAllowed(s.agent.config.NodeName, &authzContext); err != nil {
+		return nil, err
+	}
+
+	out := &connectProxiesResp{
+		SupportedVersions:  xdscommon.EnvoyVersions,
+		RecommendedVersion: xdscommon.EnvoyVersions[0],
+		Proxies:            []xds.ConnectedProxy{},
+	}
+	if s.agent.xdsServer != nil {
+		out.Proxies = s.agent.xdsServer.ConnectedProxies()
+	}
+	return out, nil
+}
+
+// connectProxiesResp is the response format/structure for the
+// /v1/agent/connect/proxies endpoint.
+type connectProxiesResp struct {
+	// SupportedVersions lists the supported Envoy versions, most recent first.
+	SupportedVersions []string
+
+	// RecommendedVersion is the Envoy version new proxies should run, for
+	// example to pick the matching Envoy image.
+	RecommendedVersion string
+
+	// Proxies are the proxies connected to the agent, sorted by service.
+	Proxies []xds.ConnectedProxy
+}
+
 // AgentConnectCALeafCert returns the certificate bundle for a service
 // instance. This endpoint ignores all "Cache-Control" attributes.
 // This supports blocking queries to update the returned bundle.
@@ -1769,6 +2060,9 @@ func (s *HTTPHandlers) AgentConnectAuthorize(resp http.ResponseWriter, req *http
 		authorized = authz.IntentionDefaultAllow(nil) == acl.Allow
 	}
 
+	destination := structs.NewServiceName(authReq.Target, &authReq.EnterpriseMeta)
+	s.agent.recordAuthorizeDecision(uriService, destination, authorized, reason)
+
 	setCacheMeta(resp, &meta)
 
 	return &connectAuthorizeResp{
@@ -31,6 +31,7 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
+	cachetype "github.com/hashicorp/consul/agent/cache-types"
 	"github.com/hashicorp/consul/agent/config"
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/connect/ca"
@@ -43,6 +44,7 @@ import (
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
 	"github.com/hashicorp/consul/testrpc"
@@ -1603,6 +1605,39 @@ func TestAgent_Self_ACLDeny(t *testing.T) {
 	})
 }
 
+func TestAgentConnectProxies_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, TestACLConfig())
+	defer a.Shutdown()
+
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+	t.Run("no token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/connect/proxies", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("read-only token", func(t *testing.T) {
+		ro := createACLTokenWithAgentReadPolicy(t, a.srv)
+		req, _ := http.NewRequest("GET", "/v1/agent/connect/proxies", nil)
+		req.Header.Add("X-Consul-Token", ro)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var out connectProxiesResp
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		require.Equal(t, xdscommon.EnvoyVersions, out.SupportedVersions)
+		require.Equal(t, xdscommon.EnvoyVersions[0], out.RecommendedVersion)
+		require.Empty(t, out.Proxies)
+	})
+}
+
 func TestAgent_Metrics_ACLDeny(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -2015,6 +2050,111 @@ func TestAgent_Reload_ACLDeny(t *testing.T) {
 	// repeating again here.
 }
 
+func TestAgent_Cache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Populate the catalog services cache.
+	_, _, err := a.cache.Get(context.Background(), cachetype.CatalogServicesName, &structs.ServiceSpecificRequest{
+		Datacenter:  "dc1",
+		ServiceName: "consul",
+	})
+	require.NoError(t, err)
+
+	findType := func(t *testing.T, name string) api.AgentCacheType {
+		req, _ := http.NewRequest("GET", "/v1/agent/cache", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var out []api.AgentCacheType
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		for _, typ := range out {
+			if typ.Name == name {
+				return typ
+			}
+		}
+		t.Fatalf("cache type %q not listed", name)
+		return api.AgentCacheType{}
+	}
+
+	typ := findType(t, cachetype.CatalogServicesName)
+	require.Equal(t, 1, typ.Entries)
+	require.Equal(t, uint64(1), typ.Misses)
+	require.Equal(t, 1, typ.AgeDistribution["1m"])
+
+	t.Run("unknown type", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/v1/agent/cache/not-a-type", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("other datacenter", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/v1/agent/cache/"+cachetype.CatalogServicesName+"?dc=dc2", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var out api.AgentCacheInvalidateResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		require.Equal(t, 0, out.Invalidated)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/v1/agent/cache/"+cachetype.CatalogServicesName+"?dc=dc1", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var out api.AgentCacheInvalidateResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		require.Equal(t, 1, out.Invalidated)
+
+		require.Equal(t, 0, findType(t, cachetype.CatalogServicesName).Entries)
+	})
+}
+
+func TestAgent_Cache_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, TestACLConfig())
+	defer a.Shutdown()
+
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+	t.Run("no token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/agent/cache", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("read-only token", func(t *testing.T) {
+		ro := createACLTokenWithAgentReadPolicy(t, a.srv)
+
+		req, _ := http.NewRequest("GET", "/v1/agent/cache", nil)
+		req.Header.Add("X-Consul-Token", ro)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		req, _ = http.NewRequest("DELETE", "/v1/agent/cache/"+cachetype.CatalogServicesName, nil)
+		req.Header.Add("X-Consul-Token", ro)
+		resp = httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusForbidden, resp.Code)
+	})
+}
+
 func TestAgent_Members(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -2073,6 +2213,81 @@ func TestAgent_Members_WAN(t *testing.T) {
 	}
 }
 
+func TestAgent_MemberEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, TestACLConfig())
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	get := func(t *testing.T, url, token string) ([]structs.MemberEvent, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Add("X-Consul-Token", token)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		var events []structs.MemberEvent
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+		return events, resp
+	}
+
+	// The agent observed its own join.
+	events, resp := get(t, "/v1/agent/members/events", "root")
+	require.NotEmpty(t, events)
+	require.Equal(t, a.Config.NodeName, events[0].Name)
+	require.Equal(t, structs.MemberEventJoin, events[0].Type)
+	require.Equal(t, structs.MemberEventReasonJoined, events[0].Reason)
+	require.Equal(t, a.Config.NodeName, events[0].DetectedBy)
+	require.NotNil(t, events[0].DetectedByCoordinate)
+	index := resp.Header().Get("X-Consul-Index")
+	require.Equal(t, strconv.FormatUint(events[len(events)-1].Index, 10), index)
+
+	t.Run("filter", func(t *testing.T) {
+		events, _ := get(t, `/v1/agent/members/events?filter=Type+!%3D+"join"`, "root")
+		require.Empty(t, events)
+	})
+
+	t.Run("acl", func(t *testing.T) {
+		events, resp := get(t, "/v1/agent/members/events", "")
+		require.Empty(t, events)
+		require.Empty(t, resp.Header().Get("X-Consul-Results-Filtered-By-ACLs"))
+	})
+
+	t.Run("blocking", func(t *testing.T) {
+		b := NewTestAgent(t, TestACLConfig())
+		defer b.Shutdown()
+
+		doneCh := make(chan []structs.MemberEvent, 1)
+		go func() {
+			req := httptest.NewRequest("GET", "/v1/agent/members/events?wait=10s&index="+index, nil)
+			req.Header.Add("X-Consul-Token", "root")
+			resp := httptest.NewRecorder()
+			a.srv.h.ServeHTTP(resp, req)
+
+			var events []structs.MemberEvent
+			json.NewDecoder(resp.Body).Decode(&events)
+			doneCh <- events
+		}()
+
+		_, err := a.JoinLAN([]string{fmt.Sprintf("127.0.0.1:%d", b.Config.SerfPortLAN)}, nil)
+		require.NoError(t, err)
+
+		select {
+		case events := <-doneCh:
+			require.NotEmpty(t, events)
+			require.Equal(t, b.Config.NodeName, events[0].Name)
+			require.Equal(t, structs.MemberEventJoin, events[0].Type)
+		case <-time.After(10 * time.Second):
+			t.Fatal("the query did not return")
+		}
+	})
+}
+
 func TestAgent_Members_ACLFilter(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -6207,6 +6422,102 @@ func TestAgent_Monitor_ACLDeny(t *testing.T) {
 	// here.
 }
 
+func TestAgent_LogLevel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	setLevel := func(t *testing.T, args *api.AgentLogLevelRequest) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PUT", "/v1/agent/log-level", jsonReader(args))
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		return resp
+	}
+	getLevels := func(t *testing.T) api.AgentLogLevel {
+		req, _ := http.NewRequest("GET", "/v1/agent/log-level", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		var out api.AgentLogLevel
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out
+	}
+
+	dns := a.logger.Named(logging.DNS)
+
+	t.Run("set subsystem level", func(t *testing.T) {
+		resp := setLevel(t, &api.AgentLogLevelRequest{Subsystem: logging.DNS, Level: "trace"})
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		require.True(t, dns.IsTrace())
+		require.Equal(t, api.AgentLogLevel{
+			Level:      strings.ToUpper(a.logger.GetLevel().String()),
+			Subsystems: map[string]string{logging.DNS: "TRACE"},
+		}, getLevels(t))
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		resp := setLevel(t, &api.AgentLogLevelRequest{Subsystem: logging.DNS, Level: "loud"})
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Contains(t, resp.Body.String(), "Unknown log level")
+
+		resp = setLevel(t, &api.AgentLogLevelRequest{})
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.Contains(t, resp.Body.String(), "Missing log level")
+	})
+
+	t.Run("recent logs of subsystem", func(t *testing.T) {
+		dns.Trace("recent dns log")
+		a.logger.Named(logging.HTTP).Error("recent http log")
+
+		// The request is cancelled so the monitor only returns the recent
+		// logs instead of streaming.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, _ := http.NewRequestWithContext(ctx, "GET", "/v1/agent/monitor?recent&subsystem=dns&loglevel=trace", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Contains(t, resp.Body.String(), "recent dns log")
+		require.NotContains(t, resp.Body.String(), "recent http log")
+	})
+
+	t.Run("reset subsystem level", func(t *testing.T) {
+		resp := setLevel(t, &api.AgentLogLevelRequest{Subsystem: logging.DNS})
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		require.Equal(t, a.logger.GetLevel(), dns.GetLevel())
+		require.Empty(t, getLevels(t).Subsystems)
+	})
+}
+
+func TestAgent_LogLevel_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, TestACLConfig())
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	req, _ := http.NewRequest("PUT", "/v1/agent/log-level", jsonReader(&api.AgentLogLevelRequest{Level: "debug"}))
+	resp := httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/v1/agent/log-level", nil)
+	resp = httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusForbidden, resp.Code)
+}
+
 func TestAgent_TokenTriggersFullSync(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -7701,6 +8012,50 @@ func TestAgentConnectAuthorize_idNotService(t *testing.T) {
 }
 
 // Test when there is an intention allowing the connection
+func TestAgentConnectAuthorize_recordsDecisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	req := structs.IntentionRequest{
+		Datacenter: "dc1",
+		Op:         structs.IntentionOpCreate,
+		Intention:  structs.TestIntention(t),
+	}
+	req.Intention.SourceName = "web"
+	req.Intention.DestinationName = "db"
+	req.Intention.Action = structs.IntentionActionDeny
+	var ixnID string
+	require.NoError(t, a.RPC(context.Background(), "Intention.Apply", &req, &ixnID))
+
+	authorize := func(source, target string) {
+		args := &structs.ConnectAuthorizeRequest{
+			Target:        target,
+			ClientCertURI: connect.TestSpiffeIDService(t, source).URI().String(),
+		}
+		req, _ := http.NewRequest("POST", "/v1/agent/connect/authorize", jsonReader(args))
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, 200, resp.Code)
+	}
+	authorize("web", "db")
+	authorize("api", "db")
+	authorize("web", "api")
+
+	require.Equal(t, []structs.IntentionDecisionCount{
+		{Destination: structs.NewServiceName("api", nil), Allowed: 1},
+		{Destination: structs.NewServiceName("db", nil), Allowed: 1, Denied: 1},
+	}, a.authorizeDecisions.drain())
+	require.Empty(t, a.authorizeDecisions.drain())
+}
+
 func TestAgentConnectAuthorize_allow(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -61,6 +61,7 @@ import (
 	"github.com/hashicorp/consul/internal/gossip/librtt"
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/ipaddr"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
 	"github.com/hashicorp/consul/proto/private/pbautoconf"
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -1864,6 +1865,49 @@ func TestAgent_AddCheck_GRPC(t *testing.T) {
 	requireCheckExistsMap(t, a.checkGRPCs, "grpchealth")
 }
 
+func TestAgent_AddCheck_Plugin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		check_plugins = [{ name = "test" path = "/nonexistent/consul-check-test" }]
+	`)
+	defer a.Shutdown()
+
+	health := &structs.HealthCheck{
+		Node:    "foo",
+		CheckID: "plugin",
+		Name:    "plugin check",
+		Status:  api.HealthCritical,
+	}
+
+	chk := &structs.CheckType{
+		Plugin:       "other",
+		PluginConfig: map[string]string{"query": "SELECT 1"},
+		Interval:     15 * time.Second,
+	}
+	err := a.AddCheck(health, chk, false, "", ConfigSourceLocal)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Check plugin "other" is not configured on this agent`)
+
+	chk.Plugin = "test"
+	err = a.AddCheck(health, chk, false, "", ConfigSourceLocal)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `failed to launch check plugin "test"`)
+
+	require.Nil(t, a.State.Check(structs.NewCheckID("plugin", nil)))
+	require.Empty(t, a.checkPlugins)
+
+	err = a.AddCheck(health, &structs.CheckType{
+		PluginConfig: map[string]string{"query": "SELECT 1"},
+		TTL:          15 * time.Second,
+	}, false, "", ConfigSourceLocal)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "PluginConfig can only be set for Plugin checks")
+}
+
 func TestAgent_RestoreServiceWithAliasCheck(t *testing.T) {
 	// t.Parallel() don't even think about making this parallel
 
@@ -6468,3 +6512,51 @@ func assertDeepEqual(t *testing.T, x, y interface{}, opts ...cmp.Option) {
 		t.Fatalf("assertion failed: values are not equal\n--- expected\n+++ actual\n%v", diff)
 	}
 }
+
+func TestAgent_HealthSubscription(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), a.config.GRPCAddrs[0].String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := pbhealth.NewHealthSubscriptionServiceClient(conn).SubscribeServices(ctx, &pbhealth.SubscribeServicesRequest{
+		Services: []string{"web"},
+	})
+	require.NoError(t, err)
+
+	rsp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "web", rsp.Service)
+	require.Empty(t, rsp.Entries)
+
+	args := &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "node1",
+		Address:    "127.0.0.2",
+		Service: &structs.NodeService{
+			ID:      "web1",
+			Service: "web",
+			Port:    8080,
+		},
+	}
+	var out struct{}
+	require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+
+	rsp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "web", rsp.Service)
+	require.Len(t, rsp.Entries, 1)
+	require.Equal(t, "node1", rsp.Entries[0].Node.Name)
+	require.Equal(t, int32(8080), rsp.Entries[0].Service.Port)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cachetype
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/mitchellh/hashstructure"
+
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// Recommended name for registration.
+const VaultClientCertificateName = "vault-client-certificate"
+
+// VaultClientCertificateRequest is the cache.Request implementation for the
+// VaultClientCertificate cache type. This is implemented here and not in
+// structs since this is only used for cache-related requests and not
+// forwarded to the Consul servers.
+type VaultClientCertificateRequest struct {
+	structs.LinkedServiceVaultPKI
+	MinQueryIndex uint64
+	MaxQueryTime  time.Duration
+}
+
+func (r *VaultClientCertificateRequest) CacheInfo() cache.RequestInfo {
+	info := cache.RequestInfo{
+		MinIndex: r.MinQueryIndex,
+		Timeout:  r.MaxQueryTime,
+	}
+
+	v, err := hashstructure.Hash([]interface{}{
+		r.Path,
+		r.CommonName,
+		r.TTL,
+	}, nil)
+	if err == nil {
+		// If there is an error, we don't set the key. A blank key forces
+		// no cache for this request.
+		info.Key = strconv.FormatUint(v, 10)
+	}
+
+	return info
+}
+
+// VaultClientCertificate issues client certificates from a Vault PKI secrets
+// engine. Blocking queries return once the last certificate is two-thirds of
+// the way through its lifetime, with a new certificate.
+type VaultClientCertificate struct {
+	RegisterOptionsBlockingRefresh
+
+	// Client is the Vault client used to issue the certificates. When nil, a
+	// client is configured with the VAULT_* environment variables of the
+	// agent the first time a certificate is requested.
+	Client *vaultapi.Client
+
+	lock sync.Mutex
+}
+
+func (c *VaultClientCertificate) Fetch(opts cache.FetchOptions, req cache.Request) (cache.FetchResult, error) {
+	var result cache.FetchResult
+
+	reqReal, ok := req.(*VaultClientCertificateRequest)
+	if !ok {
+		return result, fmt.Errorf(
+			"Internal cache failure: got wrong request type: %T, want: VaultClientCertificateRequest", req)
+	}
+
+	if opts.LastResult != nil && opts.MinIndex > 0 {
+		last, ok := opts.LastResult.Value.(*structs.IssuedCert)
+		if !ok {
+			return result, fmt.Errorf(
+				"Internal cache failure: last value in cache of wrong type: %T, want: IssuedCert", opts.LastResult.Value)
+		}
+
+		// Wait until the certificate should be renewed, returning the last
+		// result unchanged if the query times out first.
+		lifetime := last.ValidBefore.Sub(last.ValidAfter)
+		renewAt := last.ValidAfter.Add(lifetime * 2 / 3)
+		if wait := time.Until(renewAt); wait > 0 {
+			if opts.Timeout > 0 && opts.Timeout < wait {
+				time.Sleep(opts.Timeout)
+				return *opts.LastResult, nil
+			}
+			time.Sleep(wait)
+		}
+	}
+
+	client, err := c.client()
+	if err != nil {
+		return result, err
+	}
+
+	ctx := context.Background()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	cert, err := issueVaultClientCertificate(ctx, client, reqReal.LinkedServiceVaultPKI)
+	if err != nil {
+		return result, err
+	}
+
+	result.Value = cert
+	result.Index = 1
+	if opts.LastResult != nil {
+		result.Index = opts.LastResult.Index + 1
+	}
+	return result, nil
+}
+
+func (c *VaultClientCertificate) client() (*vaultapi.Client, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.Client != nil {
+		return c.Client, nil
+	}
+
+	config := vaultapi.DefaultConfig()
+	if config.Error != nil {
+		return nil, fmt.Errorf("failed to configure the Vault client: %w", config.Error)
+	}
+	client, err := vaultapi.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the Vault client: %w", err)
+	}
+	c.Client = client
+	return client, nil
+}
+
+// issueVaultClientCertificate requests a certificate from the issue endpoint
+// of a Vault PKI secrets engine.
+func issueVaultClientCertificate(ctx context.Context, client *vaultapi.Client, config structs.LinkedServiceVaultPKI) (*structs.IssuedCert, error) {
+	data := map[string]interface{}{
+		"common_name": config.CommonName,
+	}
+	if config.TTL > 0 {
+		data["ttl"] = config.TTL.String()
+	}
+
+	secret, err := client.Logical().WriteWithContext(ctx, config.Path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate from Vault path %q: %w", config.Path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("Vault path %q did not return a certificate", config.Path)
+	}
+
+	certPEM, _ := secret.Data["certificate"].(string)
+	keyPEM, _ := secret.Data["private_key"].(string)
+	if certPEM == "" || keyPEM == "" {
+		return nil, fmt.Errorf("Vault path %q did not return a certificate and private key", config.Path)
+	}
+
+	cert, err := connect.ParseCert(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate issued by Vault: %w", err)
+	}
+
+	// Present the intermediate certificates along with the leaf so that the
+	// linked service can build the chain to the root.
+	chain := []string{strings.TrimSpace(certPEM)}
+	if caChain, ok := secret.Data["ca_chain"].([]interface{}); ok {
+		for _, ca := range caChain {
+			if pem, ok := ca.(string); ok {
+				chain = append(chain, strings.TrimSpace(pem))
+			}
+		}
+	} else if issuingCA, ok := secret.Data["issuing_ca"].(string); ok && issuingCA != "" {
+		chain = append(chain, strings.TrimSpace(issuingCA))
+	}
+
+	return &structs.IssuedCert{
+		SerialNumber:  connect.HexString(cert.SerialNumber.Bytes()),
+		CertPEM:       strings.Join(chain, "\n") + "\n",
+		PrivateKeyPEM: keyPEM,
+		ValidAfter:    cert.NotBefore,
+		ValidBefore:   cert.NotAfter,
+	}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cachetype
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestVaultClientCertificate_Fetch(t *testing.T) {
+	root := connect.TestCA(t, nil)
+	certPEM, keyPEM := connect.TestLeaf(t, "web", root)
+
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/pki/issue/gateway", r.URL.Path)
+		require.Equal(t, "root-token", r.Header.Get("X-Vault-Token"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"certificate": certPEM,
+				"private_key": keyPEM,
+				"issuing_ca":  root.RootCert,
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := vaultapi.NewClient(&vaultapi.Config{Address: server.URL})
+	require.NoError(t, err)
+	client.SetToken("root-token")
+	typ := &VaultClientCertificate{Client: client}
+
+	req := &VaultClientCertificateRequest{
+		LinkedServiceVaultPKI: structs.LinkedServiceVaultPKI{
+			Path:       "pki/issue/gateway",
+			CommonName: "gateway.example.com",
+			TTL:        time.Hour,
+		},
+	}
+	result, err := typ.Fetch(cache.FetchOptions{}, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), result.Index)
+	require.Equal(t, []map[string]interface{}{
+		{"common_name": "gateway.example.com", "ttl": "1h0m0s"},
+	}, requests)
+
+	cert := result.Value.(*structs.IssuedCert)
+	require.Equal(t, keyPEM, cert.PrivateKeyPEM)
+	require.Equal(t, strings.TrimSpace(certPEM)+"\n"+strings.TrimSpace(root.RootCert)+"\n", cert.CertPEM)
+	leaf, err := connect.ParseCert(certPEM)
+	require.NoError(t, err)
+	require.Equal(t, leaf.NotAfter, cert.ValidBefore)
+
+	// The certificate is not due for renewal, the blocking query times out
+	// with the last result.
+	result, err = typ.Fetch(cache.FetchOptions{
+		MinIndex:   result.Index,
+		Timeout:    10 * time.Millisecond,
+		LastResult: &result,
+	}, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), result.Index)
+	require.Len(t, requests, 1)
+
+	// The certificate is due for renewal, a new one is issued.
+	result.Value = &structs.IssuedCert{
+		ValidAfter:  time.Now().Add(-time.Hour),
+		ValidBefore: time.Now().Add(time.Minute),
+	}
+	result, err = typ.Fetch(cache.FetchOptions{
+		MinIndex:   result.Index,
+		Timeout:    time.Second,
+		LastResult: &result,
+	}, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), result.Index)
+	require.Len(t, requests, 2)
+}
+
+func TestVaultClientCertificate_FetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := vaultapi.NewClient(&vaultapi.Config{Address: server.URL})
+	require.NoError(t, err)
+	typ := &VaultClientCertificate{Client: client}
+
+	_, err = typ.Fetch(cache.FetchOptions{}, &VaultClientCertificateRequest{
+		LinkedServiceVaultPKI: structs.LinkedServiceVaultPKI{
+			Path:       "pki/issue/gateway",
+			CommonName: "gateway.example.com",
+		},
+	})
+	require.ErrorContains(t, err, "permission denied")
+}
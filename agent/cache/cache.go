@@ -83,6 +83,10 @@ var Counters = []prometheus.CounterDefinition{
 		Name: []string{"cache", "evict_expired"},
 		Help: "Counts the number of expired entries that are evicted.",
 	},
+	{
+		Name: []string{"cache", "invalidate"},
+		Help: "Counts the number of entries that are evicted by an explicit invalidation, labeled by cache type.",
+	},
 }
 
 // Constants related to refresh backoff. We probably don't ever need to
@@ -169,6 +173,10 @@ type typeEntry struct {
 	Name string
 	Type Type
 	Opts *RegisterOptions
+
+	// Counters tracks the hits and misses for the type. It is a pointer
+	// so that it's shared by every copy of the typeEntry.
+	Counters *typeCounters
 }
 
 // ResultMeta is returned from Get calls along with the value and can be used
@@ -281,7 +289,7 @@ func (c *Cache) RegisterType(n string, typ Type) {
 
 	c.typesLock.Lock()
 	defer c.typesLock.Unlock()
-	c.types[n] = typeEntry{Name: n, Type: typ, Opts: &opts}
+	c.types[n] = typeEntry{Name: n, Type: typ, Opts: &opts, Counters: &typeCounters{}}
 }
 
 // ReloadOptions updates the cache with the new options
@@ -454,6 +462,7 @@ RETRY_GET:
 		if first {
 			metrics.IncrCounter([]string{"consul", "cache", r.TypeEntry.Name, "hit"}, 1)
 			metrics.IncrCounter([]string{"cache", r.TypeEntry.Name, "hit"}, 1)
+			r.TypeEntry.Counters.hits.Add(1)
 			meta.Hit = true
 		}
 
@@ -508,6 +517,7 @@ RETRY_GET:
 		}
 		metrics.IncrCounter([]string{"consul", "cache", r.TypeEntry.Name, missKey}, 1)
 		metrics.IncrCounter([]string{"cache", r.TypeEntry.Name, missKey}, 1)
+		r.TypeEntry.Counters.misses.Add(1)
 	}
 
 	// Set our timeout channel if we must
@@ -665,14 +675,6 @@ func (c *Cache) fetch(key string, r getOptions, allowNew bool, attempt uint, ign
 			connectedTimer.Stop()
 		}
 
-		// If we were stopped while waiting on a blocking query now would be a
-		// good time to detect that.
-		select {
-		case <-handle.stopCh:
-			return
-		default:
-		}
-
 		// Copy the existing entry to start.
 		newEntry := entry
 		newEntry.Fetching = false
@@ -783,7 +785,18 @@ func (c *Cache) fetch(key string, r getOptions, allowNew bool, attempt uint, ign
 		// Set our entry
 		c.entriesLock.Lock()
 
-		if _, ok := c.entries[key]; !ok {
+		// If we were stopped while waiting on a blocking query, because the
+		// entry was invalidated or another fetch replaced ours, now would be a
+		// good time to detect that. It is checked while holding entriesLock so
+		// that the result can't be written after Invalidate returns.
+		stopped := false
+		select {
+		case <-handle.stopCh:
+			stopped = true
+		default:
+		}
+
+		if _, ok := c.entries[key]; !ok || stopped {
 			// This entry was evicted during our fetch. DON'T re-insert it or fall
 			// through to the refresh loop below otherwise it will live forever! In
 			// theory there should not be any Get calls waiting on entry.Waiter since
@@ -870,6 +883,18 @@ func (c *Cache) getOrReplaceFetchHandle(key string) fetchHandle {
 	return handle
 }
 
+// stopFetchHandle stops the in-flight fetch and the background refresh of
+// the key, if any. Their results are discarded.
+func (c *Cache) stopFetchHandle(key string) {
+	c.fetchLock.Lock()
+	defer c.fetchLock.Unlock()
+
+	if handle, ok := c.fetchHandles[key]; ok {
+		close(handle.stopCh)
+		delete(c.fetchHandles, key)
+	}
+}
+
 func (c *Cache) deleteFetchHandle(key string, fetchID uint64) {
 	c.fetchLock.Lock()
 	defer c.fetchLock.Unlock()
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cache
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/hashicorp/consul/lib/ttlcache"
+)
+
+// typeCounters holds the running hit and miss counts for a cache type.
+type typeCounters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// Age buckets used for the age distribution of TypeStats. An entry is
+// counted in the first bucket whose bound is greater than its age.
+var statsAgeBuckets = []struct {
+	Label string
+	Bound time.Duration
+}{
+	{Label: "1m", Bound: time.Minute},
+	{Label: "10m", Bound: 10 * time.Minute},
+	{Label: "1h", Bound: time.Hour},
+	{Label: "+Inf"},
+}
+
+// TypeStats describes the current usage of a single registered cache type.
+type TypeStats struct {
+	// Name the type was registered with.
+	Name string
+
+	// Entries is the number of entries currently held for the type, including
+	// those which are still being fetched for the first time.
+	Entries int
+
+	// Hits and Misses are the number of Get calls served from the cache and
+	// the number which had to wait for a fetch, since the agent started.
+	Hits   uint64
+	Misses uint64
+
+	// HitRate is Hits divided by the total number of Get calls, or zero if
+	// there have been none.
+	HitRate float64
+
+	// AgeDistribution counts the entries by the time since they were last
+	// fetched, keyed by the upper bound of each bucket ("1m", "10m", "1h"
+	// and "+Inf"). Entries which have not been fetched yet are not counted.
+	AgeDistribution map[string]int
+
+	// OldestAge is the time since the least recently fetched entry was
+	// fetched.
+	OldestAge time.Duration
+}
+
+// Stats returns the usage of each registered cache type, sorted by name.
+func (c *Cache) Stats() []TypeStats {
+	c.typesLock.RLock()
+	stats := make(map[string]*TypeStats, len(c.types))
+	for name, tEntry := range c.types {
+		s := &TypeStats{
+			Name:            name,
+			Hits:            tEntry.Counters.hits.Load(),
+			Misses:          tEntry.Counters.misses.Load(),
+			AgeDistribution: make(map[string]int, len(statsAgeBuckets)),
+		}
+		if total := s.Hits + s.Misses; total > 0 {
+			s.HitRate = float64(s.Hits) / float64(total)
+		}
+		for _, bucket := range statsAgeBuckets {
+			s.AgeDistribution[bucket.Label] = 0
+		}
+		stats[name] = s
+	}
+	c.typesLock.RUnlock()
+
+	now := time.Now()
+	c.entriesLock.RLock()
+	for key, entry := range c.entries {
+		s, ok := stats[entryKeyType(key)]
+		if !ok {
+			continue
+		}
+		s.Entries++
+
+		if entry.FetchedAt.IsZero() {
+			continue
+		}
+		age := now.Sub(entry.FetchedAt)
+		if age > s.OldestAge {
+			s.OldestAge = age
+		}
+		for _, bucket := range statsAgeBuckets {
+			if bucket.Bound == 0 || age < bucket.Bound {
+				s.AgeDistribution[bucket.Label]++
+				break
+			}
+		}
+	}
+	c.entriesLock.RUnlock()
+
+	result := make([]TypeStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Invalidate evicts the entries of the given type so that the next Get for
+// each of them fetches a fresh value from the servers. If datacenter or
+// peerName are non-empty, only the entries for that datacenter or peer are
+// evicted. It returns the number of evicted entries, or an error if the type
+// is not registered.
+//
+// Any in-flight fetch for an evicted entry is stopped and its result is
+// discarded once it completes, even if the entry was requested again in the
+// meantime, and background refreshing stops.
+func (c *Cache) Invalidate(t, datacenter, peerName string) (int, error) {
+	c.typesLock.RLock()
+	_, ok := c.types[t]
+	c.typesLock.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("unknown type in cache: %s", t)
+	}
+
+	scope := ""
+	switch {
+	case peerName != "":
+		scope = "peer:" + peerName
+	case datacenter != "":
+		scope = datacenter
+	}
+
+	c.entriesLock.Lock()
+	var evicted int
+	for key, entry := range c.entries {
+		kt, kscope := splitEntryKey(key)
+		if kt != t || (scope != "" && kscope != scope) {
+			continue
+		}
+
+		if closer, ok := entry.State.(io.Closer); ok {
+			closer.Close()
+		}
+		delete(c.entries, key)
+		c.stopFetchHandle(key)
+		if entry.Expiry != nil && entry.Expiry.Index() != ttlcache.NotIndexed {
+			c.entriesExpiryHeap.Remove(entry.Expiry.Index())
+		}
+		evicted++
+	}
+	metrics.SetGauge([]string{"consul", "cache", "entries_count"}, float32(len(c.entries)))
+	metrics.SetGauge([]string{"cache", "entries_count"}, float32(len(c.entries)))
+	c.entriesLock.Unlock()
+
+	metrics.IncrCounterWithLabels([]string{"cache", "invalidate"}, float32(evicted),
+		[]metrics.Label{{Name: "type", Value: t}})
+
+	return evicted, nil
+}
+
+// entryKeyType returns the type name component of a key built by
+// makeEntryKey.
+func entryKeyType(key string) string {
+	t, _ := splitEntryKey(key)
+	return t
+}
+
+// splitEntryKey returns the type name and the datacenter (or "peer:" prefixed
+// peer name) components of a key built by makeEntryKey.
+func splitEntryKey(key string) (string, string) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
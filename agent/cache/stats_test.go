@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheStats(t *testing.T) {
+	t.Parallel()
+
+	typ := TestType(t)
+	defer typ.AssertExpectations(t)
+	c := New(Options{})
+	c.RegisterType("t", typ)
+	c.RegisterType("unused", TestType(t))
+
+	typ.Static(FetchResult{Value: 42}, nil).Times(2)
+
+	// One miss followed by two hits for the first key, and a miss for the
+	// second.
+	for i := 0; i < 3; i++ {
+		_, _, err := c.Get(context.Background(), "t", TestRequest(t, RequestInfo{Key: "hello"}))
+		require.NoError(t, err)
+	}
+	_, _, err := c.Get(context.Background(), "t", TestRequest(t, RequestInfo{Key: "goodbye"}))
+	require.NoError(t, err)
+
+	// Age one of the entries.
+	c.entriesLock.Lock()
+	key := makeEntryKey("t", "", "", "", "goodbye")
+	entry := c.entries[key]
+	entry.FetchedAt = time.Now().Add(-30 * time.Minute)
+	c.entries[key] = entry
+	c.entriesLock.Unlock()
+
+	stats := c.Stats()
+	require.Len(t, stats, 2)
+
+	require.Equal(t, "t", stats[0].Name)
+	require.Equal(t, 2, stats[0].Entries)
+	require.Equal(t, uint64(2), stats[0].Hits)
+	require.Equal(t, uint64(2), stats[0].Misses)
+	require.Equal(t, 0.5, stats[0].HitRate)
+	require.Equal(t, map[string]int{"1m": 1, "10m": 0, "1h": 1, "+Inf": 0}, stats[0].AgeDistribution)
+	require.GreaterOrEqual(t, stats[0].OldestAge, 30*time.Minute)
+
+	require.Equal(t, TypeStats{
+		Name:            "unused",
+		AgeDistribution: map[string]int{"1m": 0, "10m": 0, "1h": 0, "+Inf": 0},
+	}, stats[1])
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	t.Parallel()
+
+	typ := TestType(t)
+	defer typ.AssertExpectations(t)
+	c := New(Options{})
+	c.RegisterType("t", typ)
+
+	typ.Static(FetchResult{Value: 42, Index: 1}, nil).Times(5)
+
+	dc1 := TestRequest(t, RequestInfo{Key: "hello", Datacenter: "dc1"})
+	dc2 := TestRequest(t, RequestInfo{Key: "hello", Datacenter: "dc2"})
+	peer := TestRequest(t, RequestInfo{Key: "hello", PeerName: "peer1"})
+	for _, req := range []Request{dc1, dc2, peer} {
+		_, meta, err := c.Get(context.Background(), "t", req)
+		require.NoError(t, err)
+		require.False(t, meta.Hit)
+	}
+
+	_, err := c.Invalidate("other", "", "")
+	require.Error(t, err)
+
+	// Unknown scopes evict nothing.
+	n, err := c.Invalidate("t", "dc3", "")
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	// Scoped to a datacenter.
+	n, err = c.Invalidate("t", "dc1", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	_, meta, err := c.Get(context.Background(), "t", dc1)
+	require.NoError(t, err)
+	require.False(t, meta.Hit)
+
+	_, meta, err = c.Get(context.Background(), "t", dc2)
+	require.NoError(t, err)
+	require.True(t, meta.Hit)
+
+	// Everything for the type.
+	n, err = c.Invalidate("t", "", "")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, 0, c.Stats()[0].Entries)
+
+	_, meta, err = c.Get(context.Background(), "t", peer)
+	require.NoError(t, err)
+	require.False(t, meta.Hit)
+}
+
+func TestCacheInvalidate_InFlightFetch(t *testing.T) {
+	t.Parallel()
+
+	typ := TestType(t)
+	defer typ.AssertExpectations(t)
+	c := New(Options{})
+	c.RegisterType("t", typ)
+
+	// The first fetch blocks until it is released, and would return a value
+	// which is stale by the time it completes.
+	startedCh := make(chan struct{})
+	releaseCh := make(chan struct{})
+	typ.Static(FetchResult{Value: "stale", Index: 1}, nil).
+		Run(func(mock.Arguments) {
+			close(startedCh)
+			<-releaseCh
+		}).
+		Once()
+	typ.Static(FetchResult{Value: "fresh", Index: 2}, nil).Once()
+
+	req := TestRequest(t, RequestInfo{Key: "hello"})
+	type getResult struct {
+		value interface{}
+		err   error
+	}
+	blockedCh := make(chan getResult, 1)
+	go func() {
+		value, _, err := c.Get(context.Background(), "t", req)
+		blockedCh <- getResult{value, err}
+	}()
+
+	select {
+	case <-startedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch did not start")
+	}
+
+	n, err := c.Invalidate("t", "", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	value, meta, err := c.Get(context.Background(), "t", req)
+	require.NoError(t, err)
+	require.False(t, meta.Hit)
+	require.Equal(t, "fresh", value)
+
+	// Once released, the result of the invalidated fetch is discarded and the
+	// Get waiting on it is served the fresh value.
+	close(releaseCh)
+	select {
+	case res := <-blockedCh:
+		require.NoError(t, res.err)
+		require.Equal(t, "fresh", res.value)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get waiting on the invalidated fetch did not return")
+	}
+
+	value, meta, err = c.Get(context.Background(), "t", req)
+	require.NoError(t, err)
+	require.True(t, meta.Hit)
+	require.Equal(t, "fresh", value)
+	require.Equal(t, 1, c.Stats()[0].Entries)
+}
@@ -238,6 +238,9 @@ func (s *HTTPHandlers) CatalogNodes(resp http.ResponseWriter, req *http.Request)
 	if err := s.parseEntMetaPartition(req, &args.EnterpriseMeta); err != nil {
 		return nil, err
 	}
+	if err := parseSort(req, &args.Sort, false); err != nil {
+		return nil, err
+	}
 	args.NodeMetaFilters = s.parseMetaFilter(req)
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
 		metrics.IncrCounterWithLabels([]string{"client", "rpc", "error", "catalog_nodes"}, 1,
@@ -349,6 +352,9 @@ func (s *HTTPHandlers) catalogServiceNodes(resp http.ResponseWriter, req *http.R
 	}
 
 	s.parseSource(req, &args.Source)
+	if err := parseSort(req, &args.Sort, false); err != nil {
+		return nil, err
+	}
 	args.NodeMetaFilters = s.parseMetaFilter(req)
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
 		return nil, nil
@@ -569,6 +569,49 @@ func TestCatalogNodes_DistanceSort(t *testing.T) {
 	require.Equal(t, a.Config.NodeName, nodes[2].Node)
 }
 
+func TestCatalogNodes_Sort(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Register nodes.
+	var out struct{}
+	for _, node := range []string{"foo", "bar"} {
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+		}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	// The most recently modified node comes first.
+	req, _ := http.NewRequest("GET", "/v1/catalog/nodes?dc=dc1&sort=-modify-index", nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.CatalogNodes(resp, req)
+	require.NoError(t, err)
+
+	assertIndex(t, resp)
+	nodes := obj.(structs.Nodes)
+	require.Len(t, nodes, 3)
+	require.Equal(t, "bar", nodes[0].Node)
+	require.Equal(t, "foo", nodes[1].Node)
+	require.Equal(t, a.Config.NodeName, nodes[2].Node)
+
+	// Sorting by status is only supported by the health endpoints.
+	for _, sort := range []string{"status", "address"} {
+		req, _ = http.NewRequest("GET", "/v1/catalog/nodes?dc=dc1&sort="+sort, nil)
+		_, err = a.srv.CatalogNodes(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.True(t, isHTTPBadRequest(err), "expected a bad request error, got %v", err)
+	}
+}
+
 func TestCatalogServices(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
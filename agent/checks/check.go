@@ -1194,6 +1194,15 @@ type StatusHandler struct {
 	failuresBeforeWarning  int
 	failuresBeforeCritical int
 	failuresCounter        int
+
+	// flapWindow holds whether the most recent results were successful, up
+	// to flapWindowSize of them. When flap detection is enabled, a change of
+	// the published status requires at least flapMinRatio of the results of
+	// the window to agree with it.
+	flapWindowSize  int
+	flapMinRatio    float64
+	flapWindow      []bool
+	publishedStatus string
 }
 
 // NewStatusHandler set counters values to threshold in order to immediatly update status after first check.
@@ -1209,17 +1218,30 @@ func NewStatusHandler(inner CheckNotifier, logger hclog.Logger, successBeforePas
 	}
 }
 
+// WithFlapDetection enables flap detection: once a status is published, a
+// different one is only published when at least minRatio of the last
+// windowSize results agree with it.
+func (s *StatusHandler) WithFlapDetection(windowSize int, minRatio float64) *StatusHandler {
+	s.flapWindowSize = windowSize
+	s.flapMinRatio = minRatio
+	s.flapWindow = make([]bool, 0, windowSize)
+	return s
+}
+
 func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output string) {
+	success := status == api.HealthPassing || status == api.HealthWarning
+	if s.flapWindowSize > 0 {
+		if len(s.flapWindow) == s.flapWindowSize {
+			s.flapWindow = append(s.flapWindow[:0], s.flapWindow[1:]...)
+		}
+		s.flapWindow = append(s.flapWindow, success)
+	}
 
-	if status == api.HealthPassing || status == api.HealthWarning {
+	if success {
 		s.successCounter++
 		s.failuresCounter = 0
 		if s.successCounter >= s.successBeforePassing {
-			s.logger.Debug("Check status updated",
-				"check", checkID.String(),
-				"status", status,
-			)
-			s.inner.UpdateCheck(checkID, status, output)
+			s.publish(checkID, status, output, true)
 			return
 		}
 		s.logger.Warn("Check passed but has not reached success threshold",
@@ -1232,14 +1254,12 @@ func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output stri
 		s.failuresCounter++
 		s.successCounter = 0
 		if s.failuresCounter >= s.failuresBeforeCritical {
-			s.logger.Warn("Check is now critical", "check", checkID.String())
-			s.inner.UpdateCheck(checkID, status, output)
+			s.publish(checkID, status, output, false)
 			return
 		}
 		// Defaults to same value as failuresBeforeCritical if not set.
 		if s.failuresCounter >= s.failuresBeforeWarning {
-			s.logger.Warn("Check is now warning", "check", checkID.String())
-			s.inner.UpdateCheck(checkID, api.HealthWarning, output)
+			s.publish(checkID, api.HealthWarning, output, false)
 			return
 		}
 		s.logger.Warn("Check failed but has not reached warning/failure threshold",
@@ -1251,3 +1271,41 @@ func (s *StatusHandler) updateCheck(checkID structs.CheckID, status, output stri
 		)
 	}
 }
+
+// publish updates the status of the check, unless flap detection is enabled
+// and not enough of the recent results agree with a change of status.
+func (s *StatusHandler) publish(checkID structs.CheckID, status, output string, success bool) {
+	if s.flapWindowSize > 0 && s.publishedStatus != "" && status != s.publishedStatus {
+		agreeing := 0
+		for _, result := range s.flapWindow {
+			if result == success {
+				agreeing++
+			}
+		}
+		ratio := float64(agreeing) / float64(len(s.flapWindow))
+		if ratio < s.flapMinRatio {
+			s.logger.Warn("Check is flapping, status change suppressed",
+				"check", checkID.String(),
+				"status", status,
+				"published_status", s.publishedStatus,
+				"ratio", ratio,
+				"min_ratio", s.flapMinRatio,
+			)
+			return
+		}
+	}
+	s.publishedStatus = status
+
+	switch {
+	case success:
+		s.logger.Debug("Check status updated",
+			"check", checkID.String(),
+			"status", status,
+		)
+	case status == api.HealthWarning:
+		s.logger.Warn("Check is now warning", "check", checkID.String())
+	default:
+		s.logger.Warn("Check is now critical", "check", checkID.String())
+	}
+	s.inner.UpdateCheck(checkID, status, output)
+}
@@ -1126,6 +1126,42 @@ func TestStatusHandlerMaintainWarningStatusWhenCheckIsFlapping(t *testing.T) {
 	})
 }
 
+func TestStatusHandlerSuppressStatusChangesOfFlappingCheck(t *testing.T) {
+	t.Parallel()
+	cid := structs.NewCheckID("foo", nil)
+	notif := mock.NewNotify()
+	logger := testutil.Logger(t)
+	statusHandler := NewStatusHandler(notif, logger, 0, 0, 0).WithFlapDetection(4, 0.75)
+
+	// The first result is published right away.
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	require.Equal(t, 1, notif.Updates(cid))
+	require.Equal(t, api.HealthPassing, notif.State(cid))
+
+	// Alternating results do not change the status, but the results agreeing
+	// with it still update its output.
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+	statusHandler.updateCheck(cid, api.HealthPassing, "baz")
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+	require.Equal(t, 2, notif.Updates(cid))
+	require.Equal(t, api.HealthPassing, notif.State(cid))
+	require.Equal(t, "baz", notif.Output(cid))
+
+	// The status becomes critical once 3 of the last 4 results failed.
+	statusHandler.updateCheck(cid, api.HealthCritical, "bar")
+	require.Equal(t, 3, notif.Updates(cid))
+	require.Equal(t, api.HealthCritical, notif.State(cid))
+
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	require.Equal(t, 3, notif.Updates(cid))
+	require.Equal(t, api.HealthCritical, notif.State(cid))
+
+	statusHandler.updateCheck(cid, api.HealthPassing, "bar")
+	require.Equal(t, 4, notif.Updates(cid))
+	require.Equal(t, api.HealthPassing, notif.State(cid))
+}
+
 func TestCheckTCPCritical(t *testing.T) {
 	t.Parallel()
 	var (
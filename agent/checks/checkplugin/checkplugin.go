@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package checkplugin implements the protocol between the agent and the check
+// plugins, the external programs providing custom check types. Plugins are
+// launched by the agent with go-plugin and serve the CheckProviderService
+// over gRPC, so they can be written in any language. Plugins written in Go
+// implement Provider and call Serve from their main function.
+package checkplugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/agent/checks/checkplugin/pbcheckplugin"
+	"github.com/hashicorp/consul/api"
+)
+
+// Handshake is the handshake configuration shared by the agent and the check
+// plugins. A plugin launched outside of the agent exits with a message
+// instead of waiting for a connection.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "CONSUL_CHECK_PLUGIN",
+	MagicCookieValue: "8b5b8a1c-2e7a-4b0c-9a8e-6f6f1b3c2d4e",
+}
+
+// PluginName is the name the provider is dispensed under.
+const PluginName = "check"
+
+// Request is a request to run a check.
+type Request struct {
+	CheckID   string
+	ServiceID string
+
+	// Config is the plugin_config of the check.
+	Config map[string]string
+}
+
+// Result is the result of a check.
+type Result struct {
+	// Status is api.HealthPassing, api.HealthWarning or api.HealthCritical.
+	Status string
+
+	// Output is reported as the output of the check.
+	Output string
+}
+
+// Provider runs the checks of a custom type.
+type Provider interface {
+	// Validate returns an error if the configuration of a check is invalid.
+	// It is called when the check is registered.
+	Validate(ctx context.Context, config map[string]string) error
+
+	// Check runs a check once. The context is canceled when the timeout of
+	// the check expires. An error makes the check critical with the error as
+	// its output.
+	Check(ctx context.Context, req *Request) (*Result, error)
+}
+
+// Serve serves the provider to the agent. It is called from the main
+// function of a plugin and does not return.
+func Serve(p Provider) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         PluginSet(p),
+		GRPCServer:      plugin.DefaultGRPCServer,
+	})
+}
+
+// PluginSet returns the plugins served by a check plugin, or dispensed by the
+// agent when p is nil.
+func PluginSet(p Provider) plugin.PluginSet {
+	return plugin.PluginSet{PluginName: &GRPCPlugin{Provider: p}}
+}
+
+// GRPCPlugin serves a Provider over gRPC.
+type GRPCPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	// Provider is the served provider. It is only set in plugins.
+	Provider Provider
+}
+
+var _ plugin.GRPCPlugin = (*GRPCPlugin)(nil)
+
+func (p *GRPCPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	pbcheckplugin.RegisterCheckProviderServiceServer(s, &grpcServer{provider: p.Provider})
+	return nil
+}
+
+func (p *GRPCPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{client: pbcheckplugin.NewCheckProviderServiceClient(conn)}, nil
+}
+
+type grpcServer struct {
+	provider Provider
+}
+
+func (s *grpcServer) Validate(ctx context.Context, req *pbcheckplugin.ValidateRequest) (*pbcheckplugin.ValidateResponse, error) {
+	if err := s.provider.Validate(ctx, req.Config); err != nil {
+		return nil, err
+	}
+	return &pbcheckplugin.ValidateResponse{}, nil
+}
+
+func (s *grpcServer) Check(ctx context.Context, req *pbcheckplugin.CheckRequest) (*pbcheckplugin.CheckResponse, error) {
+	result, err := s.provider.Check(ctx, &Request{
+		CheckID:   req.CheckId,
+		ServiceID: req.ServiceId,
+		Config:    req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status, err := statusToProto(result.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcheckplugin.CheckResponse{Status: status, Output: result.Output}, nil
+}
+
+type grpcClient struct {
+	client pbcheckplugin.CheckProviderServiceClient
+}
+
+func (c *grpcClient) Validate(ctx context.Context, config map[string]string) error {
+	_, err := c.client.Validate(ctx, &pbcheckplugin.ValidateRequest{Config: config})
+	return err
+}
+
+func (c *grpcClient) Check(ctx context.Context, req *Request) (*Result, error) {
+	resp, err := c.client.Check(ctx, &pbcheckplugin.CheckRequest{
+		CheckId:   req.CheckID,
+		ServiceId: req.ServiceID,
+		Config:    req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	status, err := statusFromProto(resp.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Status: status, Output: resp.Output}, nil
+}
+
+func statusToProto(status string) (pbcheckplugin.Status, error) {
+	switch status {
+	case api.HealthPassing:
+		return pbcheckplugin.Status_STATUS_PASSING, nil
+	case api.HealthWarning:
+		return pbcheckplugin.Status_STATUS_WARNING, nil
+	case api.HealthCritical:
+		return pbcheckplugin.Status_STATUS_CRITICAL, nil
+	default:
+		return pbcheckplugin.Status_STATUS_UNSPECIFIED, fmt.Errorf("invalid check status %q", status)
+	}
+}
+
+func statusFromProto(status pbcheckplugin.Status) (string, error) {
+	switch status {
+	case pbcheckplugin.Status_STATUS_PASSING:
+		return api.HealthPassing, nil
+	case pbcheckplugin.Status_STATUS_WARNING:
+		return api.HealthWarning, nil
+	case pbcheckplugin.Status_STATUS_CRITICAL:
+		return api.HealthCritical, nil
+	default:
+		return "", fmt.Errorf("invalid check status %s", status)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package checkplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+// testPluginEnv makes the test binary serve testProvider, so the tests can
+// launch it as a plugin.
+const testPluginEnv = "CONSUL_TEST_CHECK_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnv) != "" {
+		Serve(&testProvider{})
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// testProvider reports the status set in the "status" key of the config.
+type testProvider struct{}
+
+func (p *testProvider) Validate(_ context.Context, config map[string]string) error {
+	if config["status"] == "" {
+		return errors.New("status is required")
+	}
+	return nil
+}
+
+func (p *testProvider) Check(_ context.Context, req *Request) (*Result, error) {
+	if req.Config["status"] == "error" {
+		return nil, errors.New("check failed")
+	}
+	if req.Config["status"] == "exit" {
+		os.Exit(1)
+	}
+	return &Result{
+		Status: req.Config["status"],
+		Output: fmt.Sprintf("%s of %s is %s", req.CheckID, req.ServiceID, req.Config["status"]),
+	}, nil
+}
+
+func TestGRPCPlugin(t *testing.T) {
+	client, _ := plugin.TestPluginGRPCConn(t, PluginSet(&testProvider{}))
+	defer client.Close()
+
+	raw, err := client.Dispense(PluginName)
+	require.NoError(t, err)
+	provider := raw.(Provider)
+	ctx := context.Background()
+
+	require.NoError(t, provider.Validate(ctx, map[string]string{"status": api.HealthPassing}))
+	err = provider.Validate(ctx, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status is required")
+
+	for _, status := range []string{api.HealthPassing, api.HealthWarning, api.HealthCritical} {
+		result, err := provider.Check(ctx, &Request{
+			CheckID:   "db",
+			ServiceID: "web",
+			Config:    map[string]string{"status": status},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &Result{Status: status, Output: "db of web is " + status}, result)
+	}
+
+	_, err = provider.Check(ctx, &Request{Config: map[string]string{"status": "error"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check failed")
+
+	_, err = provider.Check(ctx, &Request{Config: map[string]string{"status": "unknown"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `invalid check status "unknown"`)
+}
+
+func TestManager(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Setenv(testPluginEnv, "1")
+	m := NewManager([]Config{{Name: "test", Path: os.Args[0]}}, testutil.Logger(t))
+	defer m.Close()
+
+	require.True(t, m.Has("test"))
+	require.False(t, m.Has("other"))
+	_, err := m.Provider("other")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown check plugin "other"`)
+
+	check := func(status string) (*Result, error) {
+		provider, err := m.Provider("test")
+		if err != nil {
+			return nil, err
+		}
+		return provider.Check(context.Background(), &Request{CheckID: "db", Config: map[string]string{"status": status}})
+	}
+
+	result, err := check(api.HealthWarning)
+	require.NoError(t, err)
+	require.Equal(t, api.HealthWarning, result.Status)
+
+	// The plugin is relaunched after it exits.
+	_, err = check("exit")
+	require.Error(t, err)
+	retry.Run(t, func(r *retry.R) {
+		result, err := check(api.HealthPassing)
+		require.NoError(r, err)
+		require.Equal(r, api.HealthPassing, result.Status)
+	})
+
+	m.Close()
+	_, err = m.Provider("test")
+	require.Error(t, err)
+}
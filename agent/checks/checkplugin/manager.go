@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package checkplugin
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+)
+
+// Config configures a check plugin.
+type Config struct {
+	// Name is the check type provided by the plugin, which checks refer to
+	// with their plugin field.
+	Name string
+
+	// Path is the executable of the plugin.
+	Path string
+
+	// Args are the arguments the plugin is launched with.
+	Args []string
+}
+
+// Manager launches the check plugins when they are first used, and
+// relaunches them when they exit.
+type Manager struct {
+	logger  hclog.Logger
+	configs map[string]Config
+
+	lock    sync.Mutex
+	clients map[string]*plugin.Client
+	closed  bool
+}
+
+// NewManager returns a manager of the plugins.
+func NewManager(configs []Config, logger hclog.Logger) *Manager {
+	m := &Manager{
+		logger:  logger,
+		configs: make(map[string]Config),
+		clients: make(map[string]*plugin.Client),
+	}
+	for _, c := range configs {
+		m.configs[c.Name] = c
+	}
+	return m
+}
+
+// Has returns whether a plugin is configured with the name.
+func (m *Manager) Has(name string) bool {
+	_, ok := m.configs[name]
+	return ok
+}
+
+// Provider returns the provider of the named plugin, launching the plugin if
+// it is not running.
+func (m *Manager) Provider(name string) (Provider, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.closed {
+		return nil, errors.New("check plugins are shut down")
+	}
+	config, ok := m.configs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown check plugin %q", name)
+	}
+
+	client := m.clients[name]
+	if client != nil && client.Exited() {
+		m.logger.Warn("check plugin exited, relaunching it", "plugin", name)
+		client.Kill()
+		client = nil
+	}
+	if client == nil {
+		client = plugin.NewClient(&plugin.ClientConfig{
+			HandshakeConfig:  Handshake,
+			Plugins:          PluginSet(nil),
+			Cmd:              exec.Command(config.Path, config.Args...),
+			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			Logger:           m.logger.Named(name),
+		})
+		m.clients[name] = client
+	}
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		delete(m.clients, name)
+		return nil, fmt.Errorf("failed to launch check plugin %q: %w", name, err)
+	}
+	raw, err := rpcClient.Dispense(PluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dispense check plugin %q: %w", name, err)
+	}
+	provider, ok := raw.(Provider)
+	if !ok {
+		return nil, fmt.Errorf("check plugin %q does not implement the check provider", name)
+	}
+	return provider, nil
+}
+
+// Close kills the plugins.
+func (m *Manager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.closed = true
+	for name, client := range m.clients {
+		client.Kill()
+		delete(m.clients, name)
+	}
+}
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: check_plugin.proto
+
+package pbcheckplugin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CheckProviderServiceClient is the client API for CheckProviderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CheckProviderServiceClient interface {
+	// Validate checks the configuration of a check when it is registered.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+	// Check runs a check once and returns its status. The deadline of the call
+	// is the timeout of the check.
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+}
+
+type checkProviderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCheckProviderServiceClient(cc grpc.ClientConnInterface) CheckProviderServiceClient {
+	return &checkProviderServiceClient{cc}
+}
+
+func (c *checkProviderServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.checkplugin.CheckProviderService/Validate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkProviderServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.checkplugin.CheckProviderService/Check", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CheckProviderServiceServer is the server API for CheckProviderService service.
+// All implementations should embed UnimplementedCheckProviderServiceServer
+// for forward compatibility
+type CheckProviderServiceServer interface {
+	// Validate checks the configuration of a check when it is registered.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	// Check runs a check once and returns its status. The deadline of the call
+	// is the timeout of the check.
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+}
+
+// UnimplementedCheckProviderServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedCheckProviderServiceServer struct {
+}
+
+func (UnimplementedCheckProviderServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedCheckProviderServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+
+// UnsafeCheckProviderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CheckProviderServiceServer will
+// result in compilation errors.
+type UnsafeCheckProviderServiceServer interface {
+	mustEmbedUnimplementedCheckProviderServiceServer()
+}
+
+func RegisterCheckProviderServiceServer(s grpc.ServiceRegistrar, srv CheckProviderServiceServer) {
+	s.RegisterService(&CheckProviderService_ServiceDesc, srv)
+}
+
+func _CheckProviderService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckProviderServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.checkplugin.CheckProviderService/Validate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckProviderServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckProviderService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckProviderServiceServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.checkplugin.CheckProviderService/Check",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckProviderServiceServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CheckProviderService_ServiceDesc is the grpc.ServiceDesc for CheckProviderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CheckProviderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.consul.checkplugin.CheckProviderService",
+	HandlerType: (*CheckProviderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Validate",
+			Handler:    _CheckProviderService_Validate_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _CheckProviderService_Check_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "check_plugin.proto",
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/lib"
+)
+
+// PluginProviders returns the providers of the check plugins, launching the
+// plugins as needed. It is implemented by checkplugin.Manager.
+type PluginProviders interface {
+	Provider(name string) (checkplugin.Provider, error)
+}
+
+// CheckPlugin is used to periodically run a check provided by a check
+// plugin. The plugin reports the status and the output of the check.
+// The check is critical if the plugin can't be launched or returns an error.
+// Supports failures_before_critical and success_before_passing.
+type CheckPlugin struct {
+	CheckID       structs.CheckID
+	ServiceID     structs.ServiceID
+	Plugin        string
+	Config        map[string]string
+	Interval      time.Duration
+	Timeout       time.Duration
+	Logger        hclog.Logger
+	Providers     PluginProviders
+	StatusHandler *StatusHandler
+
+	stop     bool
+	stopCh   chan struct{}
+	stopLock sync.Mutex
+	stopWg   sync.WaitGroup
+}
+
+func (c *CheckPlugin) CheckType() structs.CheckType {
+	return structs.CheckType{
+		CheckID:      c.CheckID.ID,
+		Plugin:       c.Plugin,
+		PluginConfig: c.Config,
+		Interval:     c.Interval,
+		Timeout:      c.Timeout,
+	}
+}
+
+// Start is used to start a plugin check.
+// The check runs until stop is called
+func (c *CheckPlugin) Start() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	c.stop = false
+	c.stopCh = make(chan struct{})
+	c.stopWg.Add(1)
+	go c.run()
+}
+
+// Stop is used to stop a plugin check.
+func (c *CheckPlugin) Stop() {
+	c.stopLock.Lock()
+	defer c.stopLock.Unlock()
+	if !c.stop {
+		c.stop = true
+		close(c.stopCh)
+	}
+
+	// Wait for the c.run() goroutine to complete before returning.
+	c.stopWg.Wait()
+}
+
+// run is invoked by a goroutine to run until Stop() is called
+func (c *CheckPlugin) run() {
+	defer c.stopWg.Done()
+	// Get the randomized initial pause time
+	initialPauseTime := lib.RandomStagger(c.Interval)
+	next := time.After(initialPauseTime)
+	for {
+		select {
+		case <-next:
+			c.check()
+			next = time.After(c.Interval)
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+// check is invoked periodically to ask the plugin for the status of the check
+func (c *CheckPlugin) check() {
+	provider, err := c.Providers.Provider(c.Plugin)
+	if err != nil {
+		c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	result, err := provider.Check(ctx, &checkplugin.Request{
+		CheckID:   string(c.CheckID.ID),
+		ServiceID: c.ServiceID.ID,
+		Config:    c.Config,
+	})
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			c.Logger.Warn("Timed out running check",
+				"check", c.CheckID.String(),
+				"timeout", c.Timeout.String(),
+			)
+			c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, fmt.Sprintf("Timed out (%s) running check", c.Timeout))
+			return
+		}
+		c.StatusHandler.updateCheck(c.CheckID, api.HealthCritical, fmt.Sprintf("Check plugin %q failed: %s", c.Plugin, err))
+		return
+	}
+	c.StatusHandler.updateCheck(c.CheckID, result.Status, result.Output)
+}
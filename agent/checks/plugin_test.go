@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
+	"github.com/hashicorp/consul/agent/mock"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+// testPluginProviders serves a provider reporting the status set in the
+// "status" key of the config.
+type testPluginProviders struct{}
+
+func (testPluginProviders) Provider(name string) (checkplugin.Provider, error) {
+	if name != "test" {
+		return nil, fmt.Errorf("unknown check plugin %q", name)
+	}
+	return testPluginProvider{}, nil
+}
+
+type testPluginProvider struct{}
+
+func (testPluginProvider) Validate(context.Context, map[string]string) error {
+	return nil
+}
+
+func (testPluginProvider) Check(ctx context.Context, req *checkplugin.Request) (*checkplugin.Result, error) {
+	switch req.Config["status"] {
+	case "error":
+		return nil, errors.New("connection refused")
+	case "hang":
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &checkplugin.Result{Status: req.Config["status"], Output: "output of " + req.CheckID}, nil
+}
+
+func TestCheckPlugin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		plugin string
+		config string
+		state  string
+		output string
+	}{
+		{desc: "passing", plugin: "test", config: api.HealthPassing, state: api.HealthPassing, output: "output of foo"},
+		{desc: "warning", plugin: "test", config: api.HealthWarning, state: api.HealthWarning, output: "output of foo"},
+		{desc: "error", plugin: "test", config: "error", state: api.HealthCritical, output: `Check plugin "test" failed: connection refused`},
+		{desc: "timeout", plugin: "test", config: "hang", state: api.HealthCritical, output: "Timed out (50ms) running check"},
+		{desc: "unknown plugin", plugin: "other", config: api.HealthPassing, state: api.HealthCritical, output: `unknown check plugin "other"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			notif := mock.NewNotify()
+			logger := testutil.Logger(t)
+			statusHandler := NewStatusHandler(notif, logger, 0, 0, 0)
+			cid := structs.NewCheckID("foo", nil)
+
+			check := &CheckPlugin{
+				CheckID:       cid,
+				Plugin:        tt.plugin,
+				Config:        map[string]string{"status": tt.config},
+				Interval:      10 * time.Millisecond,
+				Timeout:       50 * time.Millisecond,
+				Logger:        logger,
+				Providers:     testPluginProviders{},
+				StatusHandler: statusHandler,
+			}
+			check.Start()
+			defer check.Stop()
+
+			retry.Run(t, func(r *retry.R) {
+				if got := notif.State(cid); got != tt.state {
+					r.Fatalf("got state %q want %q", got, tt.state)
+				}
+				if got := notif.Output(cid); got != tt.output {
+					r.Fatalf("got output %q want %q", got, tt.output)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package cloudmeta queries the instance metadata service of the cloud
+// provider the agent runs on, to discover the public address of the instance
+// without any further configuration.
+package cloudmeta
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ProviderAWS queries the EC2 instance metadata service (IMDSv2).
+	ProviderAWS = "aws"
+
+	// ProviderGCP queries the Compute Engine metadata server.
+	ProviderGCP = "gcp"
+
+	// ProviderAzure queries the Azure instance metadata service.
+	ProviderAzure = "azure"
+)
+
+// Providers lists the supported cloud providers.
+var Providers = []string{ProviderAWS, ProviderGCP, ProviderAzure}
+
+const (
+	defaultAWSEndpoint   = "http://169.254.169.254"
+	defaultGCPEndpoint   = "http://metadata.google.internal"
+	defaultAzureEndpoint = "http://169.254.169.254"
+
+	// awsTokenTTL is the lifetime requested for the IMDSv2 session tokens,
+	// each lookup uses a new token so it only needs to outlive the request.
+	awsTokenTTL = "60"
+
+	azureAPIVersion = "2021-02-01"
+
+	// maxResponseSize bounds the responses read from the metadata service,
+	// an address is only a few bytes long and an instance identity document
+	// less than a kilobyte.
+	maxResponseSize = 4096
+)
+
+// Client looks up the public address of the instance from the metadata
+// service of a cloud provider.
+type Client struct {
+	// Provider is one of ProviderAWS, ProviderGCP or ProviderAzure.
+	Provider string
+
+	// Endpoint overrides the base URL of the metadata service, it defaults
+	// to the well-known address of the provider.
+	Endpoint string
+
+	// HTTPClient is used to query the metadata service, it defaults to a
+	// client with a short timeout.
+	HTTPClient *http.Client
+}
+
+// IsValidProvider returns whether provider is supported.
+func IsValidProvider(provider string) bool {
+	for _, p := range Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicAddress returns the public IP address currently assigned to the
+// instance.
+func (c *Client) PublicAddress(ctx context.Context) (string, error) {
+	var (
+		address string
+		err     error
+	)
+	switch c.Provider {
+	case ProviderAWS:
+		address, err = c.awsPublicAddress(ctx)
+	case ProviderGCP:
+		address, err = c.get(ctx, c.endpoint(defaultGCPEndpoint)+
+			"/computeMetadata/v1/instance/network-interfaces/0/access-configs/0/external-ip",
+			map[string]string{"Metadata-Flavor": "Google"})
+	case ProviderAzure:
+		address, err = c.get(ctx, c.endpoint(defaultAzureEndpoint)+
+			"/metadata/instance/network/interface/0/ipv4/ipAddress/0/publicIpAddress?api-version="+azureAPIVersion+"&format=text",
+			map[string]string{"Metadata": "true"})
+	default:
+		return "", fmt.Errorf("unsupported cloud provider %q", c.Provider)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query the %s instance metadata: %w", c.Provider, err)
+	}
+
+	if net.ParseIP(address) == nil {
+		return "", fmt.Errorf("the %s instance metadata returned an invalid public address %q", c.Provider, address)
+	}
+	return address, nil
+}
+
+// AWSInstanceIdentity returns the instance identity document of the EC2
+// instance and its base64 encoded RSA-SHA256 signature by AWS.
+func (c *Client) AWSInstanceIdentity(ctx context.Context) (string, string, error) {
+	endpoint := c.endpoint(defaultAWSEndpoint)
+	token, err := c.awsToken(ctx, endpoint)
+	if err != nil {
+		return "", "", err
+	}
+
+	headers := map[string]string{"X-aws-ec2-metadata-token": token}
+	document, err := c.get(ctx, endpoint+"/latest/dynamic/instance-identity/document", headers)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query the aws instance identity document: %w", err)
+	}
+	signature, err := c.get(ctx, endpoint+"/latest/dynamic/instance-identity/signature", headers)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query the aws instance identity signature: %w", err)
+	}
+	return document, signature, nil
+}
+
+func (c *Client) awsPublicAddress(ctx context.Context) (string, error) {
+	endpoint := c.endpoint(defaultAWSEndpoint)
+	token, err := c.awsToken(ctx, endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	return c.get(ctx, endpoint+"/latest/meta-data/public-ipv4",
+		map[string]string{"X-aws-ec2-metadata-token": token})
+}
+
+func (c *Client) awsToken(ctx context.Context, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", awsTokenTTL)
+	token, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a session token: %w", err)
+	}
+	return token, nil
+}
+
+func (c *Client) get(ctx context.Context, url string, headers map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) (string, error) {
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response code %d from %s", resp.StatusCode, req.URL.Path)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (c *Client) endpoint(def string) string {
+	if c.Endpoint != "" {
+		return strings.TrimSuffix(c.Endpoint, "/")
+	}
+	return def
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cloudmeta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PublicAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			require.Equal(t, awsTokenTTL, r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+			w.Write([]byte("aws-token"))
+		case r.URL.Path == "/latest/meta-data/public-ipv4":
+			if r.Header.Get("X-aws-ec2-metadata-token") != "aws-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("198.51.100.1"))
+		case r.URL.Path == "/computeMetadata/v1/instance/network-interfaces/0/access-configs/0/external-ip":
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte("198.51.100.2\n"))
+		case r.URL.Path == "/metadata/instance/network/interface/0/ipv4/ipAddress/0/publicIpAddress":
+			if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("format") != "text" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("198.51.100.3"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	cases := map[string]string{
+		ProviderAWS:   "198.51.100.1",
+		ProviderGCP:   "198.51.100.2",
+		ProviderAzure: "198.51.100.3",
+	}
+	for provider, expected := range cases {
+		t.Run(provider, func(t *testing.T) {
+			client := &Client{Provider: provider, Endpoint: server.URL}
+			address, err := client.PublicAddress(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, expected, address)
+		})
+	}
+}
+
+func TestClient_PublicAddress_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/computeMetadata/v1/instance/network-interfaces/0/access-configs/0/external-ip" {
+			// Instances without an external IP return an empty body.
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := (&Client{Provider: "digitalocean"}).PublicAddress(context.Background())
+	require.ErrorContains(t, err, `unsupported cloud provider "digitalocean"`)
+
+	_, err = (&Client{Provider: ProviderAWS, Endpoint: server.URL}).PublicAddress(context.Background())
+	require.ErrorContains(t, err, "failed to get a session token: unexpected response code 404")
+
+	_, err = (&Client{Provider: ProviderGCP, Endpoint: server.URL}).PublicAddress(context.Background())
+	require.ErrorContains(t, err, `invalid public address ""`)
+}
@@ -29,24 +29,35 @@ import (
 	"github.com/hashicorp/memberlist"
 	"golang.org/x/time/rate"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/cache"
 	"github.com/hashicorp/consul/agent/checks"
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
+	"github.com/hashicorp/consul/agent/cloudmeta"
 	"github.com/hashicorp/consul/agent/connect/ca"
 	"github.com/hashicorp/consul/agent/consul"
+	"github.com/hashicorp/consul/agent/consul/acme"
+	"github.com/hashicorp/consul/agent/consul/attestation"
 	"github.com/hashicorp/consul/agent/consul/authmethod/ssoauth"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
 	consulrate "github.com/hashicorp/consul/agent/consul/rate"
 	hcpconfig "github.com/hashicorp/consul/agent/hcp/config"
+	"github.com/hashicorp/consul/agent/hostresolver"
 	"github.com/hashicorp/consul/agent/rpc/middleware"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
 	"github.com/hashicorp/consul/internal/dnsutil"
 	"github.com/hashicorp/consul/ipaddr"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/lib/stringslice"
 	libtempl "github.com/hashicorp/consul/lib/template"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/tlsutil"
 	"github.com/hashicorp/consul/types"
+	"github.com/hashicorp/consul/version"
 )
 
 type FlagValuesTarget = decodeTarget
@@ -73,6 +84,11 @@ type LoadOpts struct {
 	// mode. This cannot be configured in a config file.
 	DevMode *bool
 
+	// DevPortOffset shifts the default ports of the development mode by the
+	// given offset, so several dev agents can run side by side on ports known
+	// in advance. It requires DevMode.
+	DevPortOffset *int
+
 	// HCL is a slice of config data in hcl format. Each one will be loaded as
 	// if it were the source of a config file. Values from HCL will override
 	// values from ConfigFiles and FlagValues.
@@ -176,6 +192,14 @@ func newBuilder(opts LoadOpts) (*builder, error) {
 
 	if boolVal(opts.DevMode) {
 		b.Head = append(b.Head, DevSource())
+		if offset := intVal(opts.DevPortOffset); offset != 0 {
+			if offset < 0 || offset > MaxDevPortOffset {
+				return nil, fmt.Errorf("config: -dev-port-offset must be between 0 and %d", MaxDevPortOffset)
+			}
+			b.Head = append(b.Head, DevPortsSource(offset))
+		}
+	} else if opts.DevPortOffset != nil {
+		return nil, fmt.Errorf("config: -dev-port-offset can only be used with -dev")
 	}
 
 	cfg, warns := applyDeprecatedFlags(&opts.FlagValues)
@@ -286,6 +310,50 @@ func (b *builder) sourcesFromPath(path string, format string) ([]Source, error)
 	return sources, nil
 }
 
+// isBuiltinSource returns whether the source holds builtin values rather than
+// values configured by the user.
+func isBuiltinSource(s Source) bool {
+	switch s.Source() {
+	case "default", "enterprise-defaults", "dev", "dev-ports", "non-user", "consul", "enterprise-overrides", "version", "consul-dev":
+		return true
+	}
+	return false
+}
+
+// agentDefaultsKeys returns the keys of the settings of the agent-defaults
+// config entry which are set in c.
+func agentDefaultsKeys(c Config) []string {
+	var keys []string
+	if c.LogLevel != nil {
+		keys = append(keys, "log_level")
+	}
+	if len(c.DNSRecursors) > 0 {
+		keys = append(keys, "recursors")
+	}
+	if c.Telemetry.StatsdAddr != nil {
+		keys = append(keys, "telemetry.statsd_address")
+	}
+	if c.Telemetry.StatsiteAddr != nil {
+		keys = append(keys, "telemetry.statsite_address")
+	}
+	if c.Telemetry.DogstatsdAddr != nil {
+		keys = append(keys, "telemetry.dogstatsd_addr")
+	}
+	if len(c.Telemetry.DogstatsdTags) > 0 {
+		keys = append(keys, "telemetry.dogstatsd_tags")
+	}
+	return keys
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // newSourceFromFile creates a Source from the contents of the file at path.
 func newSourceFromFile(path string, format string) (Source, error) {
 	data, err := os.ReadFile(path)
@@ -337,6 +405,7 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 
 	// parse the config sources into a configuration
 	var c Config
+	agentDefaultsLocalKeys := make(map[string]struct{})
 	for _, s := range srcs {
 
 		c2, md, err := s.Parse()
@@ -379,6 +448,11 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 			c2.Services = append(c2.Services, *c2.Service)
 			c2.Service = nil
 		}
+		if !isBuiltinSource(s) {
+			for _, k := range agentDefaultsKeys(c2) {
+				agentDefaultsLocalKeys[k] = struct{}{}
+			}
+		}
 		c = Merge(c, c2)
 	}
 
@@ -500,6 +574,9 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 	if isUnixAddr(bindAddrs[0]) {
 		return RuntimeConfig{}, fmt.Errorf("bind_addr cannot be a unix socket")
 	}
+	if isNamedPipeAddr(bindAddrs[0]) {
+		return RuntimeConfig{}, fmt.Errorf("bind_addr cannot be a named pipe")
+	}
 	if !isIPAddr(bindAddrs[0]) {
 		return RuntimeConfig{}, fmt.Errorf("bind_addr must be an ip address")
 	}
@@ -650,6 +727,9 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		if port <= 0 {
 			return RuntimeConfig{}, fmt.Errorf("Port for segment %q cannot be <= 0", name)
 		}
+		if s.Gossip.Transport != nil {
+			return RuntimeConfig{}, fmt.Errorf("segments[%s].gossip.transport cannot be set, segments use gossip_lan.transport", name)
+		}
 
 		bind := b.makeTCPAddr(
 			b.expandFirstIP(fmt.Sprintf("segments[%s].bind", name), s.Bind),
@@ -664,10 +744,16 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		)
 
 		segments = append(segments, structs.NetworkSegment{
-			Name:        name,
-			Bind:        bind,
-			Advertise:   advertise,
-			RPCListener: boolVal(s.RPCListener),
+			Name:           name,
+			Bind:           bind,
+			Advertise:      advertise,
+			RPCListener:    boolVal(s.RPCListener),
+			GossipNodes:    intVal(s.Gossip.GossipNodes),
+			GossipInterval: b.durationVal(fmt.Sprintf("segments[%s].gossip.gossip_interval", name), s.Gossip.GossipInterval),
+			ProbeInterval:  b.durationVal(fmt.Sprintf("segments[%s].gossip.probe_interval", name), s.Gossip.ProbeInterval),
+			ProbeTimeout:   b.durationVal(fmt.Sprintf("segments[%s].gossip.probe_timeout", name), s.Gossip.ProbeTimeout),
+			SuspicionMult:  intVal(s.Gossip.SuspicionMult),
+			RetransmitMult: intVal(s.Gossip.RetransmitMult),
 		})
 	}
 
@@ -852,12 +938,14 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		GossipLANProbeTimeout:   b.durationVal("gossip_lan..probe_timeout", c.GossipLAN.ProbeTimeout),
 		GossipLANSuspicionMult:  intVal(c.GossipLAN.SuspicionMult),
 		GossipLANRetransmitMult: intVal(c.GossipLAN.RetransmitMult),
+		GossipLANTransport:      stringVal(c.GossipLAN.Transport),
 		GossipWANGossipInterval: b.durationVal("gossip_wan..gossip_interval", c.GossipWAN.GossipInterval),
 		GossipWANGossipNodes:    intVal(c.GossipWAN.GossipNodes),
 		GossipWANProbeInterval:  b.durationVal("gossip_wan..probe_interval", c.GossipWAN.ProbeInterval),
 		GossipWANProbeTimeout:   b.durationVal("gossip_wan..probe_timeout", c.GossipWAN.ProbeTimeout),
 		GossipWANSuspicionMult:  intVal(c.GossipWAN.SuspicionMult),
 		GossipWANRetransmitMult: intVal(c.GossipWAN.RetransmitMult),
+		GossipWANTransport:      stringVal(c.GossipWAN.Transport),
 
 		// ACL
 		ACLsEnabled: aclsEnabled,
@@ -877,6 +965,11 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 
 		ACLTokenReplication: boolVal(c.ACL.TokenReplication),
 
+		ACLTokenUsageTracking:  boolVal(c.ACL.TokenUsageTracking),
+		ACLTokenUsageMaxTokens: intVal(c.ACL.TokenUsageMaxTokens),
+
+		AgentDefaultsLocalKeys: sortedKeys(agentDefaultsLocalKeys),
+
 		ACLTokens: token.Config{
 			DataDir:                        dataDir,
 			EnablePersistence:              boolValWithDefault(c.ACL.EnableTokenPersistence, false),
@@ -920,16 +1013,28 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		DNSUseCache:           boolVal(c.DNS.UseCache),
 		DNSCacheMaxAge:        b.durationVal("dns_config.cache_max_age", c.DNS.CacheMaxAge),
 
+		// DNS recursor cache
+		DNSRecursorCacheSize:         intVal(c.DNS.RecursorCacheSize),
+		DNSRecursorCacheStaleIfError: b.durationVal("dns_config.recursor_cache_stale_if_error", c.DNS.RecursorCacheStaleIfError),
+
+		// Dual-stack addresses
+		AddressFamilyPreference: stringValWithDefault(c.AddressFamilyPreference, structs.AddressFamilyIPv6),
+
+		// DNS host resolver
+		DNSHostResolverEnabled: boolVal(c.DNS.HostResolver.Enabled),
+		DNSHostResolverMode:    stringValWithDefault(c.DNS.HostResolver.Mode, hostresolver.ModeAuto),
+
 		// HTTP
-		HTTPPort:            httpPort,
-		HTTPSPort:           httpsPort,
-		HTTPAddrs:           httpAddrs,
-		HTTPSAddrs:          httpsAddrs,
-		HTTPBlockEndpoints:  c.HTTPConfig.BlockEndpoints,
-		HTTPMaxHeaderBytes:  intVal(c.HTTPConfig.MaxHeaderBytes),
-		HTTPResponseHeaders: c.HTTPConfig.ResponseHeaders,
-		AllowWriteHTTPFrom:  b.cidrsVal("allow_write_http_from", c.HTTPConfig.AllowWriteHTTPFrom),
-		HTTPUseCache:        boolValWithDefault(c.HTTPConfig.UseCache, true),
+		HTTPPort:                 httpPort,
+		HTTPSPort:                httpsPort,
+		HTTPAddrs:                httpAddrs,
+		HTTPSAddrs:               httpsAddrs,
+		HTTPBlockEndpoints:       c.HTTPConfig.BlockEndpoints,
+		HTTPMaxHeaderBytes:       intVal(c.HTTPConfig.MaxHeaderBytes),
+		HTTPResponseHeaders:      c.HTTPConfig.ResponseHeaders,
+		AllowWriteHTTPFrom:       b.cidrsVal("allow_write_http_from", c.HTTPConfig.AllowWriteHTTPFrom),
+		HTTPUseCache:             boolValWithDefault(c.HTTPConfig.UseCache, true),
+		HTTPClientCertAuthMethod: stringVal(c.HTTPConfig.ClientCertAuthMethod),
 
 		// Telemetry
 		Telemetry: lib.TelemetryConfig{
@@ -950,6 +1055,8 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 			DisablePerTenancyUsageMetrics:      boolVal(c.Telemetry.DisablePerTenancyUsageMetrics),
 			DogstatsdAddr:                      stringVal(c.Telemetry.DogstatsdAddr),
 			DogstatsdTags:                      c.Telemetry.DogstatsdTags,
+			EnvoyMetricsAllowlist:              c.Telemetry.EnvoyMetricsAllowlist,
+			EnvoyMetricsScrapeInterval:         b.durationValWithDefault("telemetry.envoy_metrics_scrape_interval", c.Telemetry.EnvoyMetricsScrapeInterval, 30*time.Second),
 			RetryFailedConfiguration:           boolVal(c.Telemetry.RetryFailedConfiguration),
 			FilterDefault:                      boolVal(c.Telemetry.FilterDefault),
 			AllowedPrefixes:                    telemetryAllowedPrefixes,
@@ -978,9 +1085,19 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 				c.Cache.EntryFetchMaxBurst, cache.DefaultEntryFetchMaxBurst,
 			),
 		},
+		Redaction: structs.RedactionConfig{
+			KVPrefixes:        c.Redaction.KVPrefixes,
+			ConfigEntryFields: c.Redaction.ConfigEntryFields,
+		},
 		AutoReloadConfig:                       boolVal(c.AutoReloadConfig),
+		CatalogProviders:                       b.catalogProvidersVal(c.CatalogProviders),
 		CheckUpdateInterval:                    b.durationVal("check_update_interval", c.CheckUpdateInterval),
+		CheckFlapConsecutiveResults:            intVal(c.CheckFlapDetection.ConsecutiveResults),
+		CheckFlapWindowSize:                    intVal(c.CheckFlapDetection.WindowSize),
+		CheckFlapMinRatio:                      float64Val(c.CheckFlapDetection.MinRatio),
 		CheckOutputMaxSize:                     intValWithDefault(c.CheckOutputMaxSize, 4096),
+		CheckPlugins:                           checkPluginsVal(c.CheckPlugins),
+		CheckUpdateBatchInterval:               b.durationVal("performance.check_update_batch_interval", c.Performance.CheckUpdateBatchInterval),
 		Checks:                                 checks,
 		ClientAddrs:                            clientAddrs,
 		ConfigEntryBootstrap:                   configEntries,
@@ -994,32 +1111,40 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		ConnectCAProvider:                      connectCAProvider,
 		ConnectCAConfig:                        connectCAConfig,
 		ConnectMeshGatewayWANFederationEnabled: connectMeshGatewayWANFederationEnabled,
-		ConnectSidecarMinPort:                  sidecarMinPort,
-		ConnectSidecarMaxPort:                  sidecarMaxPort,
-		ConnectTestCALeafRootChangeSpread:      b.durationVal("connect.test_ca_leaf_root_change_spread", c.Connect.TestCALeafRootChangeSpread),
-		ExposeMinPort:                          exposeMinPort,
-		ExposeMaxPort:                          exposeMaxPort,
-		DataDir:                                dataDir,
-		Datacenter:                             datacenter,
-		DefaultQueryTime:                       b.durationVal("default_query_time", c.DefaultQueryTime),
-		DefaultIntentionPolicy:                 stringVal(c.DefaultIntentionPolicy),
-		DevMode:                                boolVal(b.opts.DevMode),
-		DisableAnonymousSignature:              boolVal(c.DisableAnonymousSignature),
-		DisableCoordinates:                     boolVal(c.DisableCoordinates),
-		DisableHostNodeID:                      boolVal(c.DisableHostNodeID),
-		DisableHTTPUnprintableCharFilter:       boolVal(c.DisableHTTPUnprintableCharFilter),
-		DisableKeyringFile:                     boolVal(c.DisableKeyringFile),
-		DisableRemoteExec:                      boolVal(c.DisableRemoteExec),
-		DisableUpdateCheck:                     boolVal(c.DisableUpdateCheck),
-		DiscardCheckOutput:                     boolVal(c.DiscardCheckOutput),
+		ConnectAuthorizeLogSampleRate:          float64Val(c.Connect.AuthorizeLogSampleRate),
+		ConnectMeshGatewayWANAddressProvider:   stringVal(c.Connect.MeshGatewayWANAddressProvider),
+		ConnectMeshGatewayWANAddressPort:       intVal(c.Connect.MeshGatewayWANAddressPort),
+		ConnectMeshGatewayWANAddressRefreshInterval: b.durationVal("connect.mesh_gateway_wan_address_refresh_interval", c.Connect.MeshGatewayWANAddressRefreshInterval),
+		ConnectDisableExpiredIntentions:             boolVal(c.Connect.DisableExpiredIntentions),
+		ConnectDisableIntentionsAfterReview:         boolVal(c.Connect.DisableIntentionsAfterReview),
+		ConnectSidecarMinPort:                       sidecarMinPort,
+		ConnectSidecarMaxPort:                       sidecarMaxPort,
+		ConnectTestCALeafRootChangeSpread:           b.durationVal("connect.test_ca_leaf_root_change_spread", c.Connect.TestCALeafRootChangeSpread),
+		ExposeMinPort:                               exposeMinPort,
+		ExposeMaxPort:                               exposeMaxPort,
+		DataDir:                                     dataDir,
+		Datacenter:                                  datacenter,
+		DefaultQueryTime:                            b.durationVal("default_query_time", c.DefaultQueryTime),
+		DefaultIntentionPolicy:                      stringVal(c.DefaultIntentionPolicy),
+		DevMode:                                     boolVal(b.opts.DevMode),
+		DisableAnonymousSignature:                   boolVal(c.DisableAnonymousSignature),
+		DisableCoordinates:                          boolVal(c.DisableCoordinates),
+		DisableHostNodeID:                           boolVal(c.DisableHostNodeID),
+		DisableHTTPUnprintableCharFilter:            boolVal(c.DisableHTTPUnprintableCharFilter),
+		DisableKeyringFile:                          boolVal(c.DisableKeyringFile),
+		DisableRemoteExec:                           boolVal(c.DisableRemoteExec),
+		DisableUpdateCheck:                          boolVal(c.DisableUpdateCheck),
+		DiscardCheckOutput:                          boolVal(c.DiscardCheckOutput),
 
 		DiscoveryMaxStale:          b.durationVal("discovery_max_stale", c.DiscoveryMaxStale),
+		EgressProxy:                b.egressProxyVal(c.EgressProxy),
 		EnableAgentTLSForChecks:    boolVal(c.EnableAgentTLSForChecks),
 		EnableCentralServiceConfig: boolVal(c.EnableCentralServiceConfig),
 		EnableDebug:                boolVal(c.EnableDebug),
 		EnableRemoteScriptChecks:   enableRemoteScriptChecks,
 		EnableLocalScriptChecks:    enableLocalScriptChecks,
 		EncryptKey:                 stringVal(c.EncryptKey),
+		GitOps:                     b.gitOpsVal(c.GitOps),
 		GRPCAddrs:                  grpcAddrs,
 		GRPCPort:                   grpcPort,
 		GRPCTLSAddrs:               grpcTlsAddrs,
@@ -1045,9 +1170,20 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 			LogRotateDuration: b.durationVal("log_rotate_duration", c.LogRotateDuration),
 			LogRotateBytes:    intVal(c.LogRotateBytes),
 			LogRotateMaxFiles: intVal(c.LogRotateMaxFiles),
+			LogRotateCompress: boolVal(c.Logging.Rotation.Compress),
+			SyslogAddress:     stringVal(c.Logging.Syslog.Address),
+			SyslogTLS:         loggingTLSVal(c.Logging.Syslog.TLS),
+			KafkaBrokers:      c.Logging.Kafka.Brokers,
+			KafkaTopic:        stringVal(c.Logging.Kafka.Topic),
+			KafkaTLS:          loggingTLSVal(c.Logging.Kafka.TLS),
+		},
+		MaxQueryTime: b.durationVal("max_query_time", c.MaxQueryTime),
+		MetaIndexes: structs.MetaIndexConfig{
+			NodeKeys:    c.MetaIndexes.NodeMetaKeys,
+			ServiceKeys: c.MetaIndexes.ServiceMetaKeys,
 		},
-		MaxQueryTime:                      b.durationVal("max_query_time", c.MaxQueryTime),
 		NodeID:                            types.NodeID(stringVal(c.NodeID)),
+		NodeAttestation:                   nodeAttestationVal(c.NodeAttestation),
 		NodeMeta:                          c.NodeMeta,
 		NodeName:                          b.nodeName(c.NodeName),
 		ReadReplica:                       boolVal(c.ReadReplica),
@@ -1057,6 +1193,9 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		PrimaryDatacenter:                 primaryDatacenter,
 		PrimaryGateways:                   b.expandAllOptionalAddrs("primary_gateways", c.PrimaryGateways),
 		PrimaryGatewaysInterval:           b.durationVal("primary_gateways_interval", c.PrimaryGatewaysInterval),
+		Quotas:                            b.quotasVal(c.Quotas),
+		KVEncryption:                      kvEncryptionVal(c.KVEncryption),
+		KVReplicationPrefixes:             c.KVReplication.Prefixes,
 		RPCAdvertiseAddr:                  rpcAdvertiseAddr,
 		RPCBindAddr:                       rpcBindAddr,
 		RPCHandshakeTimeout:               b.durationVal("limits.rpc_handshake_timeout", c.Limits.RPCHandshakeTimeout),
@@ -1066,12 +1205,14 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		RPCMaxConnsPerClient:              intVal(c.Limits.RPCMaxConnsPerClient),
 		RPCProtocol:                       intVal(c.RPCProtocol),
 		RPCRateLimit:                      limitVal(c.Limits.RPCRate),
-		RPCConfig:                         consul.RPCConfig{EnableStreaming: boolValWithDefault(c.RPC.EnableStreaming, serverMode)},
+		RPCConfig:                         b.rpcConfigVal(c.RPC, serverMode),
 		RaftProtocol:                      intVal(c.RaftProtocol),
 		RaftSnapshotThreshold:             intVal(c.RaftSnapshotThreshold),
 		RaftSnapshotInterval:              b.durationVal("raft_snapshot_interval", c.RaftSnapshotInterval),
 		RaftTrailingLogs:                  intVal(c.RaftTrailingLogs),
 		RaftLogStoreConfig:                b.raftLogStoreConfigVal(&c.RaftLogStore),
+		RaftArchive:                       b.raftArchiveVal(&c.RaftArchive),
+		RaftSnapshot:                      b.raftSnapshotVal(&c.RaftSnapshot, intVal(c.RaftSnapshotThreshold)),
 		ReconnectTimeoutLAN:               b.durationVal("reconnect_timeout", c.ReconnectTimeoutLAN),
 		ReconnectTimeoutWAN:               b.durationVal("reconnect_timeout_wan", c.ReconnectTimeoutWAN),
 		RejoinAfterLeave:                  boolVal(c.RejoinAfterLeave),
@@ -1109,7 +1250,12 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 		UnixSocketGroup:                   stringVal(c.UnixSocket.Group),
 		UnixSocketMode:                    stringVal(c.UnixSocket.Mode),
 		UnixSocketUser:                    stringVal(c.UnixSocket.User),
+		UnixSocketAuthMethod:              stringVal(c.UnixSocket.AuthMethod),
+		NamedPipeAllowedSIDs:              c.NamedPipes.AllowedSIDs,
 		Watches:                           c.Watches,
+		Webhooks:                          b.webhooksVal(c.Webhooks),
+		AdmissionWebhooks:                 b.admissionWebhooksVal(c.AdmissionWebhooks),
+		ACME:                              b.acmeVal(c.ACME),
 		XDSUpdateRateLimit:                limitVal(c.XDS.UpdateMaxPerSecond),
 		AutoReloadConfigCoalesceInterval:  1 * time.Second,
 		LocalProxyConfigResyncInterval:    30 * time.Second,
@@ -1221,7 +1367,191 @@ func validateBasicName(field, value string, allowEmpty bool) error {
 	return nil
 }
 
+func (b *builder) catalogProvidersVal(v []CatalogProvider) []catalogprovider.Config {
+	var providers []catalogprovider.Config
+	for i, p := range v {
+		providers = append(providers, catalogprovider.Config{
+			Name:         stringVal(p.Name),
+			Type:         stringVal(p.Type),
+			Partition:    stringVal(p.Partition),
+			Namespace:    stringVal(p.Namespace),
+			SyncInterval: b.durationVal(fmt.Sprintf("catalog_providers[%d].sync_interval", i), p.SyncInterval),
+			AWSCloudMap: catalogprovider.AWSCloudMapConfig{
+				NamespaceID: stringVal(p.AWSCloudMap.NamespaceID),
+				Region:      stringVal(p.AWSCloudMap.Region),
+				Endpoint:    stringVal(p.AWSCloudMap.Endpoint),
+			},
+			DNS: catalogprovider.DNSConfig{
+				Zone:        stringVal(p.DNS.Zone),
+				Server:      stringVal(p.DNS.Server),
+				TSIGKeyName: stringVal(p.DNS.TSIGKeyName),
+				TSIGSecret:  stringVal(p.DNS.TSIGSecret),
+			},
+			File: catalogprovider.FileConfig{
+				Path: stringVal(p.File.Path),
+			},
+			Etcd: catalogprovider.EtcdConfig{
+				Address:  stringVal(p.Etcd.Address),
+				Prefix:   stringVal(p.Etcd.Prefix),
+				Username: stringVal(p.Etcd.Username),
+				Password: stringVal(p.Etcd.Password),
+			},
+		})
+	}
+	return providers
+}
+
+func validateCatalogProviders(providers []catalogprovider.Config) error {
+	names := make(map[string]struct{})
+	for i, p := range providers {
+		if err := validateBasicName(fmt.Sprintf("catalog_providers[%d].name", i), p.Name, false); err != nil {
+			return err
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("catalog_providers[%d].name %q is used by several catalog providers", i, p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("catalog_providers[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func kvEncryptionVal(e KVEncryption) kvencrypt.Config {
+	return kvencrypt.Config{
+		Provider: stringVal(e.Provider),
+		VaultTransit: kvencrypt.VaultTransitConfig{
+			Address:       stringVal(e.VaultTransit.Address),
+			Token:         stringVal(e.VaultTransit.Token),
+			Namespace:     stringVal(e.VaultTransit.Namespace),
+			MountPath:     stringVal(e.VaultTransit.MountPath),
+			KeyName:       stringVal(e.VaultTransit.KeyName),
+			CAFile:        stringVal(e.VaultTransit.CAFile),
+			CAPath:        stringVal(e.VaultTransit.CAPath),
+			CertFile:      stringVal(e.VaultTransit.CertFile),
+			KeyFile:       stringVal(e.VaultTransit.KeyFile),
+			TLSServerName: stringVal(e.VaultTransit.TLSServerName),
+			TLSSkipVerify: boolVal(e.VaultTransit.TLSSkipVerify),
+		},
+		AWSKMS: kvencrypt.AWSKMSConfig{
+			KeyID:    stringVal(e.AWSKMS.KeyID),
+			Region:   stringVal(e.AWSKMS.Region),
+			Endpoint: stringVal(e.AWSKMS.Endpoint),
+		},
+		Static: kvencrypt.StaticConfig{
+			Key: stringVal(e.Static.Key),
+		},
+	}
+}
+
+func (b *builder) quotasVal(q Quotas) structs.QuotaConfig {
+	cfg := structs.QuotaConfig{
+		QuotaLimits: structs.QuotaLimits{
+			MaxServices:   intVal(q.MaxServices),
+			MaxKVBytes:    intVal(q.MaxKVBytes),
+			MaxIntentions: intVal(q.MaxIntentions),
+			MaxACLTokens:  intVal(q.MaxACLTokens),
+		},
+	}
+	for _, o := range q.Overrides {
+		cfg.Overrides = append(cfg.Overrides, structs.QuotaOverride{
+			Partition: stringVal(o.Partition),
+			Namespace: stringVal(o.Namespace),
+			QuotaLimits: structs.QuotaLimits{
+				MaxServices:   intVal(o.MaxServices),
+				MaxKVBytes:    intVal(o.MaxKVBytes),
+				MaxIntentions: intVal(o.MaxIntentions),
+				MaxACLTokens:  intVal(o.MaxACLTokens),
+			},
+		})
+	}
+	return cfg
+}
+
+func validateQuotaLimits(name string, limits structs.QuotaLimits) error {
+	switch {
+	case limits.MaxServices < 0:
+		return fmt.Errorf("%s.max_services cannot be negative", name)
+	case limits.MaxKVBytes < 0:
+		return fmt.Errorf("%s.max_kv_bytes cannot be negative", name)
+	case limits.MaxIntentions < 0:
+		return fmt.Errorf("%s.max_intentions cannot be negative", name)
+	case limits.MaxACLTokens < 0:
+		return fmt.Errorf("%s.max_acl_tokens cannot be negative", name)
+	}
+	return nil
+}
+
+func validateQuotas(cfg structs.QuotaConfig) error {
+	if err := validateQuotaLimits("quotas", cfg.QuotaLimits); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{})
+	for i, o := range cfg.Overrides {
+		name := fmt.Sprintf("quotas.overrides[%d]", i)
+		entMeta := acl.NewEnterpriseMetaWithPartition(o.Partition, o.Namespace)
+		key := entMeta.PartitionOrDefault() + "/" + entMeta.NamespaceOrDefault()
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%s: duplicate override for partition %q namespace %q", name, entMeta.PartitionOrDefault(), entMeta.NamespaceOrDefault())
+		}
+		seen[key] = struct{}{}
+		if err := validateQuotaLimits(name, o.QuotaLimits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) validateDNSHostResolver(rt RuntimeConfig) error {
+	if !stringslice.Contains(hostresolver.Modes, rt.DNSHostResolverMode) {
+		return fmt.Errorf("dns_config.host_resolver.mode must be one of %q, got %q",
+			hostresolver.Modes, rt.DNSHostResolverMode)
+	}
+	if !rt.DNSHostResolverEnabled {
+		return nil
+	}
+	if rt.DNSPort <= 0 {
+		return fmt.Errorf("dns_config.host_resolver requires the DNS server to be enabled")
+	}
+	if rt.DNSHostResolverMode == hostresolver.ModeResolvconf {
+		// resolv.conf can't set the port of a nameserver.
+		if rt.DNSPort != 53 {
+			return fmt.Errorf("dns_config.host_resolver.mode = %q requires ports.dns to be 53, got %d",
+				hostresolver.ModeResolvconf, rt.DNSPort)
+		}
+		if len(rt.DNSRecursors) == 0 {
+			b.warn("dns_config.host_resolver.mode = %q sends every DNS query of the host to the agent, "+
+				"configure recursors to resolve the other domains", hostresolver.ModeResolvconf)
+		}
+	}
+	return nil
+}
+
+func validateMetaIndexKeys(name string, keys []string) error {
+	seen := make(map[string]struct{}, len(keys))
+	for i, k := range keys {
+		if k == "" {
+			return fmt.Errorf("%s[%d] must not be empty", name, i)
+		}
+		if _, ok := seen[k]; ok {
+			return fmt.Errorf("%s: duplicate key %q", name, k)
+		}
+		seen[k] = struct{}{}
+	}
+	return nil
+}
+
 // validate performs semantic validation of the runtime configuration.
+func validateGossipTransport(name, transport string) error {
+	switch transport {
+	case GossipTransportUDP, GossipTransportTCP:
+		return nil
+	default:
+		return fmt.Errorf("%s must be %q or %q, got %q", name, GossipTransportUDP, GossipTransportTCP, transport)
+	}
+}
+
 func (b *builder) validate(rt RuntimeConfig) error {
 	// validContentPath defines a regexp for a valid content path name.
 	validContentPath := regexp.MustCompile(`^[A-Za-z0-9/_-]+$`)
@@ -1249,6 +1579,17 @@ func (b *builder) validate(rt RuntimeConfig) error {
 		return fmt.Errorf("ui-content-path cannot have 'v[0-9]'. received: %q", rt.UIConfig.ContentPath)
 	}
 
+	if err := rt.EgressProxy.Validate(); err != nil {
+		return fmt.Errorf("egress_proxy: %w", err)
+	}
+
+	if rt.Logging.SyslogAddress != "" && !rt.Logging.EnableSyslog {
+		b.warn("logging.syslog.address has no effect unless enable_syslog is true")
+	}
+	if len(rt.Logging.KafkaBrokers) > 0 && rt.Logging.KafkaTopic == "" {
+		return fmt.Errorf("logging.kafka.topic is required when logging.kafka.brokers is set")
+	}
+
 	if err := validateBasicName("ui_config.metrics_provider", rt.UIConfig.MetricsProvider, true); err != nil {
 		return err
 	}
@@ -1328,10 +1669,60 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	if err := b.validateSegments(rt); err != nil {
 		return err
 	}
+	if err := validateQuotas(rt.Quotas); err != nil {
+		return err
+	}
+	if err := validateMetaIndexKeys("meta_indexes.node_meta_keys", rt.MetaIndexes.NodeKeys); err != nil {
+		return err
+	}
+	if err := validateMetaIndexKeys("meta_indexes.service_meta_keys", rt.MetaIndexes.ServiceKeys); err != nil {
+		return err
+	}
+	if err := rt.Redaction.Validate(); err != nil {
+		return fmt.Errorf("redaction.%w", err)
+	}
+	if rt.Redaction.Enabled() && !rt.ACLsEnabled {
+		b.warn("redaction has no effect when ACLs are disabled, since every caller is allowed to read the hidden values")
+	}
+	if err := rt.KVEncryption.Validate(); err != nil {
+		return fmt.Errorf("kv_encryption: %w", err)
+	}
+	if rt.KVEncryption.Enabled() && !rt.ServerMode {
+		b.warn("kv_encryption is only used by servers and will be ignored")
+	}
+	if err := validateKVReplicationPrefixes(rt.KVReplicationPrefixes); err != nil {
+		return err
+	}
+	if len(rt.KVReplicationPrefixes) > 0 && !rt.ServerMode {
+		b.warn("kv_replication is only used by servers and will be ignored")
+	}
+	if err := validateCatalogProviders(rt.CatalogProviders); err != nil {
+		return err
+	}
+	if len(rt.CatalogProviders) > 0 && !rt.ServerMode {
+		b.warn("catalog_providers are only used by servers and will be ignored")
+	}
+	if err := validateGossipTransport("gossip_lan.transport", rt.GossipLANTransport); err != nil {
+		return err
+	}
+	if err := validateGossipTransport("gossip_wan.transport", rt.GossipWANTransport); err != nil {
+		return err
+	}
 	for _, a := range rt.DNSAddrs {
 		if _, ok := a.(*net.UnixAddr); ok {
 			return fmt.Errorf("DNS address cannot be a unix socket")
 		}
+		if isNamedPipeAddr(a) {
+			return fmt.Errorf("DNS address cannot be a named pipe")
+		}
+	}
+	if hasNamedPipeAddr(rt.GRPCAddrs) || hasNamedPipeAddr(rt.GRPCTLSAddrs) {
+		return fmt.Errorf("gRPC address cannot be a named pipe")
+	}
+	for _, sid := range rt.NamedPipeAllowedSIDs {
+		if !validSID.MatchString(sid) {
+			return fmt.Errorf("named_pipes.allowed_sids: invalid SID %q", sid)
+		}
 	}
 	for _, a := range rt.DNSRecursors {
 		if ipaddr.IsAny(a) {
@@ -1359,6 +1750,30 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	if rt.CheckOutputMaxSize < 1 {
 		return fmt.Errorf("check_output_max_size must be positive, to discard check output use the discard_check_output flag")
 	}
+	if err := validateCheckPlugins(rt.CheckPlugins); err != nil {
+		return err
+	}
+	if len(rt.Telemetry.EnvoyMetricsAllowlist) > 0 && rt.Telemetry.PrometheusOpts.Expiration <= 0 {
+		return fmt.Errorf("telemetry.envoy_metrics_allowlist requires telemetry.prometheus_retention_time to be set")
+	}
+	if rt.Telemetry.EnvoyMetricsScrapeInterval <= 0 {
+		return fmt.Errorf("telemetry.envoy_metrics_scrape_interval must be positive")
+	}
+	if rt.ACLTokenUsageTracking && rt.ACLTokenUsageMaxTokens <= 0 {
+		return fmt.Errorf("acl.token_usage_max_tokens must be positive")
+	}
+	if rt.CheckFlapConsecutiveResults < 0 {
+		return fmt.Errorf("check_flap_detection.consecutive_results cannot be %d. Must be greater than or equal to zero", rt.CheckFlapConsecutiveResults)
+	}
+	if rt.CheckFlapWindowSize < 0 {
+		return fmt.Errorf("check_flap_detection.window_size cannot be %d. Must be greater than or equal to zero", rt.CheckFlapWindowSize)
+	}
+	if rt.CheckFlapWindowSize > 0 && (rt.CheckFlapMinRatio <= 0 || rt.CheckFlapMinRatio > 1) {
+		return fmt.Errorf("check_flap_detection.min_ratio must be greater than 0 and at most 1 when check_flap_detection.window_size is set")
+	}
+	if rt.CheckUpdateBatchInterval < 0 {
+		return fmt.Errorf("performance.check_update_batch_interval cannot be %s. Must be greater than or equal to zero", rt.CheckUpdateBatchInterval)
+	}
 	if rt.AEInterval <= 0 {
 		return fmt.Errorf("ae_interval cannot be %s. Must be positive", rt.AEInterval)
 	}
@@ -1381,6 +1796,21 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	if rt.DNSARecordLimit < 0 {
 		return fmt.Errorf("dns_config.a_record_limit cannot be %d. Must be greater than or equal to zero", rt.DNSARecordLimit)
 	}
+	if rt.DNSRecursorCacheSize < 0 {
+		return fmt.Errorf("dns_config.recursor_cache_size cannot be %d. Must be greater than or equal to zero", rt.DNSRecursorCacheSize)
+	}
+	if rt.DNSRecursorCacheStaleIfError < 0 {
+		return fmt.Errorf("dns_config.recursor_cache_stale_if_error cannot be %s. Must be greater than or equal to zero", rt.DNSRecursorCacheStaleIfError)
+	}
+	if err := b.validateDNSHostResolver(rt); err != nil {
+		return err
+	}
+	switch rt.AddressFamilyPreference {
+	case structs.AddressFamilyIPv4, structs.AddressFamilyIPv6:
+	default:
+		return fmt.Errorf("address_family_preference must be %q or %q, got %q",
+			structs.AddressFamilyIPv4, structs.AddressFamilyIPv6, rt.AddressFamilyPreference)
+	}
 	if err := structs.ValidateNodeMetadata(rt.NodeMeta, false); err != nil {
 		return fmt.Errorf("node_meta invalid: %v", err)
 	}
@@ -1390,6 +1820,24 @@ func (b *builder) validate(rt RuntimeConfig) error {
 		}
 	}
 
+	if rt.ConnectAuthorizeLogSampleRate < 0 || rt.ConnectAuthorizeLogSampleRate > 1 {
+		return fmt.Errorf("connect.authorize_log_sample_rate must be between 0 and 1, got %v", rt.ConnectAuthorizeLogSampleRate)
+	}
+	if rt.ConnectMeshGatewayWANAddressProvider != "" {
+		if !cloudmeta.IsValidProvider(rt.ConnectMeshGatewayWANAddressProvider) {
+			return fmt.Errorf("connect.mesh_gateway_wan_address_provider must be one of %s, got %q",
+				strings.Join(cloudmeta.Providers, ", "), rt.ConnectMeshGatewayWANAddressProvider)
+		}
+		if !rt.ConnectEnabled {
+			return fmt.Errorf("'connect.mesh_gateway_wan_address_provider' requires 'connect.enabled = true'")
+		}
+	}
+	if rt.ConnectMeshGatewayWANAddressPort < 0 || rt.ConnectMeshGatewayWANAddressPort > 65535 {
+		return fmt.Errorf("connect.mesh_gateway_wan_address_port must be between 0 and 65535, got %d", rt.ConnectMeshGatewayWANAddressPort)
+	}
+	if rt.ConnectMeshGatewayWANAddressRefreshInterval < 0 {
+		return fmt.Errorf("connect.mesh_gateway_wan_address_refresh_interval cannot be negative")
+	}
 	if rt.ConnectMeshGatewayWANFederationEnabled && !rt.ServerMode {
 		return fmt.Errorf("'connect.enable_mesh_gateway_wan_federation = true' requires 'server = true'")
 	}
@@ -1551,6 +1999,18 @@ func (b *builder) validate(rt RuntimeConfig) error {
 		b.warn("if auto_encrypt.allow_tls is turned on, tls.internal_rpc.verify_incoming should be enabled (either explicitly or via tls.defaults.verify_incoming). It is necessary to turn it off during a migration to TLS, but it should definitely be turned on afterwards.")
 	}
 
+	if rt.HTTPClientCertAuthMethod != "" && !rt.TLS.HTTPS.VerifyIncoming {
+		return fmt.Errorf("http_config.client_cert_auth_method requires tls.https.verify_incoming to be enabled (either explicitly or via tls.defaults.verify_incoming)")
+	}
+
+	if rt.UnixSocketAuthMethod != "" && !hasUnixAddr(rt.HTTPAddrs) && !hasUnixAddr(rt.HTTPSAddrs) {
+		b.warn("unix_sockets.auth_method has no effect unless addresses.http or addresses.https is a Unix domain socket")
+	}
+
+	if len(rt.NamedPipeAllowedSIDs) > 0 && !hasNamedPipeAddr(rt.HTTPAddrs) && !hasNamedPipeAddr(rt.HTTPSAddrs) {
+		b.warn("named_pipes.allowed_sids has no effect unless addresses.http or addresses.https is a named pipe")
+	}
+
 	if err := checkLimitsFromMaxConnsPerClient(rt.HTTPMaxConnsPerClient); err != nil {
 		return err
 	}
@@ -1563,6 +2023,65 @@ func (b *builder) validate(rt RuntimeConfig) error {
 		return err
 	}
 
+	if err := validateGitOps(rt.GitOps); err != nil {
+		return err
+	}
+
+	if err := validateNodeAttestation(rt); err != nil {
+		return err
+	}
+
+	if err := validateWebhooks(rt.Webhooks); err != nil {
+		return err
+	}
+	if len(rt.Webhooks) > 0 && !rt.ServerMode {
+		b.warn("webhooks are only used by servers and will be ignored")
+	}
+
+	if err := validateAdmissionWebhooks(rt.AdmissionWebhooks); err != nil {
+		return err
+	}
+	if len(rt.AdmissionWebhooks) > 0 && !rt.ServerMode {
+		b.warn("admission_webhooks are only used by servers and will be ignored")
+	}
+
+	if err := validateRaftArchive(rt.RaftArchive); err != nil {
+		return err
+	}
+	if rt.RaftArchive.Enabled() && !rt.ServerMode {
+		b.warn("raft_archive is only used by servers and will be ignored")
+	}
+
+	if err := rt.ACME.Validate(); err != nil {
+		return err
+	}
+	if rt.ACME.Enabled && !rt.ServerMode {
+		b.warn("acme is only used by servers and will be ignored")
+	}
+
+	if rt.RPCConfig.CompressionThreshold < 0 {
+		return fmt.Errorf("rpc.compression_threshold cannot be negative")
+	}
+	if rt.RPCConfig.Compression != compression.None && !rt.ServerMode {
+		b.warn("rpc.compression is only used by servers and will be ignored")
+	}
+	if rt.RPCConfig.CrossDCCacheSize < 0 {
+		return fmt.Errorf("rpc.cross_dc_cache_size cannot be negative")
+	}
+	if rt.RPCConfig.CrossDCCacheTTL < 0 {
+		return fmt.Errorf("rpc.cross_dc_cache_ttl cannot be negative")
+	}
+	if rt.RPCConfig.CrossDCCacheSize > 0 && !rt.ServerMode {
+		b.warn("rpc.cross_dc_cache_size is only used by servers and will be ignored")
+	}
+
+	if err := validateRaftSnapshot(rt.RaftSnapshot, rt.RaftSnapshotThreshold); err != nil {
+		return err
+	}
+	if (rt.RaftSnapshot.IOLimit > 0 || rt.RaftSnapshot.WindowEnabled()) && !rt.ServerMode {
+		b.warn("raft_snapshot is only used by servers and will be ignored")
+	}
+
 	if err := validateRemoteScriptsChecks(rt); err != nil {
 		// TODO: make this an error in a future version
 		b.warn(err.Error())
@@ -1662,6 +2181,8 @@ func (b *builder) checkVal(v *CheckDefinition) *structs.CheckDefinition {
 		H2PING:                         stringVal(v.H2PING),
 		H2PingUseTLS:                   H2PingUseTLSVal,
 		OSService:                      stringVal(v.OSService),
+		Plugin:                         stringVal(v.Plugin),
+		PluginConfig:                   v.PluginConfig,
 		DeregisterCriticalServiceAfter: b.durationVal(fmt.Sprintf("check[%s].deregister_critical_service_after", id), v.DeregisterCriticalServiceAfter),
 		OutputMaxSize:                  intValWithDefault(v.OutputMaxSize, checks.DefaultBufSize),
 		EnterpriseMeta:                 v.EnterpriseMeta.ToStructs(),
@@ -1972,6 +2493,226 @@ func (b *builder) uiMetricsProxyVal(v RawUIMetricsProxy) UIMetricsProxy {
 	}
 }
 
+func (b *builder) gitOpsVal(v GitOpsRaw) GitOpsConfig {
+	provider := stringValWithDefault(v.Provider, "github")
+	apiAddress := stringVal(v.APIAddress)
+	if apiAddress == "" {
+		switch provider {
+		case "github":
+			apiAddress = "https://api.github.com"
+		case "gitlab":
+			apiAddress = "https://gitlab.com/api/v4"
+		}
+	}
+
+	return GitOpsConfig{
+		Enabled:       boolVal(v.Enabled),
+		Provider:      provider,
+		WebhookSecret: stringVal(v.WebhookSecret),
+		Repository:    stringVal(v.Repository),
+		Branch:        stringValWithDefault(v.Branch, "main"),
+		Path:          strings.Trim(stringVal(v.Path), "/"),
+		APIAddress:    strings.TrimSuffix(apiAddress, "/"),
+		APIToken:      stringVal(v.APIToken),
+		ACLToken:      stringVal(v.ACLToken),
+	}
+}
+
+func validateGitOps(gitops GitOpsConfig) error {
+	if !gitops.Enabled {
+		return nil
+	}
+
+	switch gitops.Provider {
+	case "github", "gitlab":
+	default:
+		return fmt.Errorf("gitops.provider must be one of \"github\" or \"gitlab\", got %q", gitops.Provider)
+	}
+	if gitops.WebhookSecret == "" {
+		return fmt.Errorf("gitops.webhook_secret must be set when gitops.enabled is true")
+	}
+	if gitops.Repository == "" {
+		return fmt.Errorf("gitops.repository must be set when gitops.enabled is true")
+	}
+	if _, err := url.Parse(gitops.APIAddress); err != nil {
+		return fmt.Errorf("gitops.api_address is not a valid URL: %v", err)
+	}
+	return nil
+}
+
+func nodeAttestationVal(v NodeAttestationRaw) NodeAttestationConfig {
+	return NodeAttestationConfig{
+		Type:       stringVal(v.Type),
+		TPMCommand: v.TPMCommand,
+		AuthMethod: stringVal(v.AuthMethod),
+		Enforce:    boolVal(v.Enforce),
+	}
+}
+
+func validateNodeAttestation(rt RuntimeConfig) error {
+	na := rt.NodeAttestation
+	if na.Type != "" && !attestation.IsValidType(na.Type) {
+		return fmt.Errorf("node_attestation.type must be one of %q, got %q", attestation.Types, na.Type)
+	}
+	if na.Type == attestation.TypeTPM && len(na.TPMCommand) == 0 {
+		return fmt.Errorf("node_attestation.tpm_command must be set when node_attestation.type is %q", attestation.TypeTPM)
+	}
+	if na.AuthMethod != "" && !rt.ACLsEnabled {
+		return fmt.Errorf("node_attestation.auth_method requires acl.enabled")
+	}
+	if na.Enforce {
+		if !rt.ServerMode {
+			return fmt.Errorf("node_attestation.enforce can only be set on servers")
+		}
+		if na.AuthMethod == "" {
+			return fmt.Errorf("node_attestation.auth_method must be set when node_attestation.enforce is true")
+		}
+	} else if na.AuthMethod != "" && na.Type == "" {
+		return fmt.Errorf("node_attestation.type must be set when node_attestation.auth_method is set")
+	} else if na.AuthMethod != "" && !attestation.CanLogin(na.Type) {
+		return fmt.Errorf("node_attestation.auth_method cannot be set when node_attestation.type is %q, the evidence cannot be used to log in", na.Type)
+	}
+	return nil
+}
+
+func checkPluginsVal(v []CheckPlugin) []checkplugin.Config {
+	var plugins []checkplugin.Config
+	for _, p := range v {
+		plugins = append(plugins, checkplugin.Config{
+			Name: stringVal(p.Name),
+			Path: stringVal(p.Path),
+			Args: p.Args,
+		})
+	}
+	return plugins
+}
+
+func validateCheckPlugins(plugins []checkplugin.Config) error {
+	names := make(map[string]struct{})
+	for i, p := range plugins {
+		if p.Name == "" {
+			return fmt.Errorf("check_plugins[%d].name is required", i)
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("check_plugins[%d].name %q is used by several check plugins", i, p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if p.Path == "" {
+			return fmt.Errorf("check_plugins[%d].path is required", i)
+		}
+	}
+	return nil
+}
+
+func (b *builder) webhooksVal(v []Webhook) []consul.WebhookConfig {
+	var webhooks []consul.WebhookConfig
+	for i, w := range v {
+		webhooks = append(webhooks, consul.WebhookConfig{
+			Name:       stringValWithDefault(w.Name, stringVal(w.URL)),
+			URL:        stringVal(w.URL),
+			Secret:     stringVal(w.Secret),
+			Events:     w.Events,
+			Services:   w.Services,
+			Timeout:    b.durationVal(fmt.Sprintf("webhooks[%d].timeout", i), w.Timeout),
+			MaxRetries: intValWithDefault(w.MaxRetries, 5),
+		})
+	}
+	return webhooks
+}
+
+func (b *builder) acmeVal(v ACME) acme.Config {
+	renewBefore := acme.DefaultRenewBefore
+	if v.RenewBefore != nil {
+		renewBefore = b.durationVal("acme.renew_before", v.RenewBefore)
+	}
+	return acme.Config{
+		Enabled:           boolVal(v.Enabled),
+		DirectoryURL:      stringValWithDefault(v.DirectoryURL, acme.DefaultDirectoryURL),
+		Email:             stringVal(v.Email),
+		Challenge:         stringValWithDefault(v.Challenge, acme.ChallengeHTTP01),
+		RenewBefore:       renewBefore,
+		DNSProvider:       stringVal(v.DNSProvider),
+		DNSProviderConfig: v.DNSProviderConfig,
+	}
+}
+
+func validateWebhooks(webhooks []consul.WebhookConfig) error {
+	names := make(map[string]struct{})
+	for i, w := range webhooks {
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhooks[%d].url must be an http or https URL, got %q", i, w.URL)
+		}
+		if _, ok := names[w.Name]; ok {
+			return fmt.Errorf("webhooks[%d].name %q is used by several webhooks", i, w.Name)
+		}
+		names[w.Name] = struct{}{}
+		for _, event := range w.Events {
+			switch event {
+			case consul.WebhookEventServiceRegistered, consul.WebhookEventServiceDeregistered,
+				consul.WebhookEventHealthChanged, consul.WebhookEventConfigEntryChanged:
+			default:
+				return fmt.Errorf("webhooks[%d].events contains unknown event %q", i, event)
+			}
+		}
+		if w.Timeout < 0 {
+			return fmt.Errorf("webhooks[%d].timeout cannot be %s. Must be greater than or equal to zero", i, w.Timeout)
+		}
+		if w.MaxRetries < 0 {
+			return fmt.Errorf("webhooks[%d].max_retries cannot be %d. Must be greater than or equal to zero", i, w.MaxRetries)
+		}
+	}
+	return nil
+}
+
+func (b *builder) admissionWebhooksVal(v []AdmissionWebhook) []consul.AdmissionWebhookConfig {
+	var webhooks []consul.AdmissionWebhookConfig
+	for i, w := range v {
+		webhooks = append(webhooks, consul.AdmissionWebhookConfig{
+			Name:          stringValWithDefault(w.Name, stringVal(w.URL)),
+			URL:           stringVal(w.URL),
+			Secret:        stringVal(w.Secret),
+			Operations:    w.Operations,
+			Mutating:      boolVal(w.Mutating),
+			FailurePolicy: stringValWithDefault(w.FailurePolicy, consul.AdmissionFailClosed),
+			Timeout:       b.durationVal(fmt.Sprintf("admission_webhooks[%d].timeout", i), w.Timeout),
+		})
+	}
+	return webhooks
+}
+
+func validateAdmissionWebhooks(webhooks []consul.AdmissionWebhookConfig) error {
+	names := make(map[string]struct{})
+	for i, w := range webhooks {
+		u, err := url.Parse(w.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("admission_webhooks[%d].url must be an http or https URL, got %q", i, w.URL)
+		}
+		if _, ok := names[w.Name]; ok {
+			return fmt.Errorf("admission_webhooks[%d].name %q is used by several admission webhooks", i, w.Name)
+		}
+		names[w.Name] = struct{}{}
+		for _, op := range w.Operations {
+			switch op {
+			case consul.AdmissionOperationCatalogRegister, consul.AdmissionOperationCatalogDeregister,
+				consul.AdmissionOperationConfigEntryUpsert, consul.AdmissionOperationConfigEntryDelete:
+			default:
+				return fmt.Errorf("admission_webhooks[%d].operations contains unknown operation %q", i, op)
+			}
+		}
+		switch w.FailurePolicy {
+		case consul.AdmissionFailClosed, consul.AdmissionFailOpen:
+		default:
+			return fmt.Errorf("admission_webhooks[%d].failure_policy must be %q or %q, got %q",
+				i, consul.AdmissionFailClosed, consul.AdmissionFailOpen, w.FailurePolicy)
+		}
+		if w.Timeout < 0 {
+			return fmt.Errorf("admission_webhooks[%d].timeout cannot be %s. Must be greater than or equal to zero", i, w.Timeout)
+		}
+	}
+	return nil
+}
+
 func boolValWithDefault(v *bool, defaultVal bool) bool {
 	if v == nil {
 		return defaultVal
@@ -2184,6 +2925,31 @@ func (b *builder) tlsCipherSuites(name string, v *string, tlsMinVersion types.TL
 	return a
 }
 
+// tlsCurves parses curve preferences from a comma-separated string into a
+// recognized slice
+func (b *builder) tlsCurves(name string, v *string) []types.TLSCurve {
+	if v == nil {
+		return nil
+	}
+
+	s := strings.TrimSpace(*v)
+	if s == "" {
+		return []types.TLSCurve{}
+	}
+	curves := strings.Split(s, ",")
+
+	a := make([]types.TLSCurve, len(curves))
+	for i, curve := range curves {
+		a[i] = types.TLSCurve(strings.TrimSpace(curve))
+	}
+
+	if err := types.ValidateTLSCurves(a); err != nil {
+		b.err = multierror.Append(b.err, fmt.Errorf("%s: invalid TLS curve preferences: %s", name, err))
+		return []types.TLSCurve{}
+	}
+	return a
+}
+
 func (b *builder) nodeName(v *string) string {
 	nodeName := stringVal(v)
 	if nodeName == "" {
@@ -2219,6 +2985,13 @@ func (b *builder) expandAddrs(name string, s *string) []net.Addr {
 		switch {
 		case strings.HasPrefix(a, "unix://"):
 			addrs = append(addrs, &net.UnixAddr{Name: a[len("unix://"):], Net: "unix"})
+		case strings.HasPrefix(a, "npipe://"):
+			pipe, err := parseNamedPipeAddr(a[len("npipe://"):])
+			if err != nil {
+				b.err = multierror.Append(b.err, fmt.Errorf("%s: %s", name, err))
+				return nil
+			}
+			addrs = append(addrs, pipe)
 		default:
 			// net.ParseIP does not like '[::]'
 			ip := net.ParseIP(a)
@@ -2290,6 +3063,9 @@ func (b *builder) expandIPs(name string, s *string) []*net.IPAddr {
 		case *net.UnixAddr:
 			b.err = multierror.Append(b.err, fmt.Errorf("%s cannot be a unix socket", name))
 			return nil
+		case *NamedPipeAddr:
+			b.err = multierror.Append(b.err, fmt.Errorf("%s cannot be a named pipe", name))
+			return nil
 		default:
 			b.err = multierror.Append(b.err, fmt.Errorf("%s has invalid address type %T", name, a))
 			return nil
@@ -2340,6 +3116,9 @@ func (b *builder) expandFirstIP(name string, s *string) *net.IPAddr {
 	case *net.UnixAddr:
 		b.err = multierror.Append(b.err, fmt.Errorf("%s cannot be a unix socket", name))
 		return nil
+	case *NamedPipeAddr:
+		b.err = multierror.Append(b.err, fmt.Errorf("%s cannot be a named pipe", name))
+		return nil
 	default:
 		b.err = multierror.Append(b.err, fmt.Errorf("%s has invalid address type %T", name, a))
 		return nil
@@ -2385,7 +3164,7 @@ func (b *builder) makeAddr(pri, sec net.Addr, port int) net.Addr {
 	switch a := addr.(type) {
 	case *net.IPAddr:
 		return &net.TCPAddr{IP: a.IP, Port: port}
-	case *net.UnixAddr:
+	case *net.UnixAddr, *NamedPipeAddr:
 		return a
 	default:
 		panic(fmt.Sprintf("invalid address type %T", a))
@@ -2582,6 +3361,29 @@ func validateAutoConfigAuthorizer(rt RuntimeConfig) error {
 	return nil
 }
 
+func loggingTLSVal(v LoggingTLS) logging.TLSConfig {
+	return logging.TLSConfig{
+		Enabled:            boolVal(v.Enabled),
+		CAFile:             stringVal(v.CAFile),
+		CertFile:           stringVal(v.CertFile),
+		KeyFile:            stringVal(v.KeyFile),
+		ServerName:         stringVal(v.ServerName),
+		InsecureSkipVerify: boolVal(v.InsecureSkipVerify),
+	}
+}
+
+func (b *builder) egressProxyVal(v EgressProxy) *egressproxy.Config {
+	val := &egressproxy.Config{
+		HTTPProxy:  stringVal(v.HTTPProxy),
+		HTTPSProxy: stringVal(v.HTTPSProxy),
+		NoProxy:    v.NoProxy,
+	}
+	if val.IsZero() {
+		return nil
+	}
+	return val
+}
+
 func (b *builder) cloudConfigVal(v Config) hcpconfig.CloudConfig {
 	// Load the same environment variables expected by hcp-sdk-go
 	envHostname, ok := os.LookupEnv("HCP_API_ADDRESS")
@@ -2666,6 +3468,24 @@ func isUnixAddr(a net.Addr) bool {
 	return ok
 }
 
+func hasUnixAddr(addrs []net.Addr) bool {
+	for _, a := range addrs {
+		if isUnixAddr(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNamedPipeAddr(addrs []net.Addr) bool {
+	for _, a := range addrs {
+		if isNamedPipeAddr(a) {
+			return true
+		}
+	}
+	return false
+}
+
 // isValidAltDomain returns true if the given domain is not prefixed
 // by keywords used when dispatching DNS requests
 func isValidAltDomain(domain, datacenter string) bool {
@@ -2747,6 +3567,7 @@ func (b *builder) buildTLSConfig(rt RuntimeConfig, t TLS) (tlsutil.Config, error
 
 	defaultTLSMinVersion := b.tlsVersion("tls.defaults.tls_min_version", t.Defaults.TLSMinVersion)
 	defaultCipherSuites := b.tlsCipherSuites("tls.defaults.tls_cipher_suites", t.Defaults.TLSCipherSuites, defaultTLSMinVersion)
+	defaultCurves := b.tlsCurves("tls.defaults.tls_curve_preferences", t.Defaults.TLSCurvePreferences)
 
 	mapCommon := func(name string, src TLSProtocolConfig, dst *tlsutil.ProtocolConfig) {
 		dst.CAPath = stringValWithDefault(src.CAPath, stringVal(t.Defaults.CAPath))
@@ -2784,6 +3605,24 @@ func (b *builder) buildTLSConfig(rt RuntimeConfig, t TLS) (tlsutil.Config, error
 				dst.TLSMinVersion,
 			)
 		}
+
+		if src.TLSCurvePreferences == nil {
+			dst.CurvePreferences = defaultCurves
+		} else {
+			dst.CurvePreferences = b.tlsCurves(
+				fmt.Sprintf("tls.%s.tls_curve_preferences", name),
+				src.TLSCurvePreferences,
+			)
+		}
+
+		// FIPS builds only allow approved algorithms, so reject settings which
+		// would enable anything else rather than failing at handshake time.
+		if version.IsFIPS() && dst.TLSMinVersion != types.TLSVersionInvalid {
+			err := types.ValidateFIPSTLSSettings(dst.TLSMinVersion, dst.CipherSuites, dst.CurvePreferences)
+			if err != nil {
+				b.err = multierror.Append(b.err, fmt.Errorf("tls.%s: %s", name, err))
+			}
+		}
 	}
 
 	mapCommon("internal_rpc", t.InternalRPC, &c.InternalRPC)
@@ -2849,6 +3688,112 @@ func (b *builder) parsePrefixFilter(telemetry *Telemetry) ([]string, []string) {
 	return telemetryAllowedPrefixes, telemetryBlockedPrefixes
 }
 
+func (b *builder) raftArchiveVal(raw *RaftArchiveRaw) consul.RaftArchiveConfig {
+	return consul.RaftArchiveConfig{
+		Types:      raw.Types,
+		Interval:   b.durationVal("raft_archive.interval", raw.Interval),
+		FilePath:   stringVal(raw.File.Path),
+		S3Bucket:   stringVal(raw.S3.Bucket),
+		S3Prefix:   stringVal(raw.S3.Prefix),
+		S3Region:   stringVal(raw.S3.Region),
+		S3Endpoint: stringVal(raw.S3.Endpoint),
+	}
+}
+
+// validateKVReplicationPrefixes rejects the empty prefixes and the prefixes
+// nested in another one, which would be replicated twice.
+func validateKVReplicationPrefixes(prefixes []string) error {
+	for i, prefix := range prefixes {
+		if prefix == "" {
+			return fmt.Errorf("kv_replication.prefixes[%d] cannot be empty", i)
+		}
+	}
+	for i, prefix := range prefixes {
+		for j, other := range prefixes {
+			if i != j && strings.HasPrefix(prefix, other) {
+				return fmt.Errorf("kv_replication.prefixes[%d] %q overlaps with %q", i, prefix, other)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRaftArchive(cfg consul.RaftArchiveConfig) error {
+	if cfg.FilePath != "" && cfg.S3Bucket != "" {
+		return fmt.Errorf("raft_archive.file and raft_archive.s3 cannot both be set")
+	}
+	if cfg.Interval < 0 {
+		return fmt.Errorf("raft_archive.interval cannot be %s. Must be greater than or equal to zero", cfg.Interval)
+	}
+	if err := consul.ValidateRaftArchiveTypes(cfg.Types); err != nil {
+		return fmt.Errorf("raft_archive.types is invalid: %w", err)
+	}
+	return nil
+}
+
+func (b *builder) rpcConfigVal(raw RPC, serverMode bool) consul.RPCConfig {
+	cfg := consul.RPCConfig{
+		EnableStreaming:      boolValWithDefault(raw.EnableStreaming, serverMode),
+		CompressionThreshold: intVal(raw.CompressionThreshold),
+		CrossDCCacheSize:     intVal(raw.CrossDCCacheSize),
+		CrossDCCacheTTL:      b.durationVal("rpc.cross_dc_cache_ttl", raw.CrossDCCacheTTL),
+	}
+	alg, err := compression.Parse(stringVal(raw.Compression))
+	if err != nil {
+		b.err = multierror.Append(b.err, fmt.Errorf("rpc.compression: %w", err))
+	}
+	cfg.Compression = alg
+	return cfg
+}
+
+// raftSnapshotPeakThresholdMultiplier sets the default peak snapshot
+// threshold relative to raft_snapshot_threshold.
+const raftSnapshotPeakThresholdMultiplier = 4
+
+func (b *builder) raftSnapshotVal(raw *RaftSnapshotRaw, threshold int) consul.RaftSnapshotConfig {
+	cfg := consul.RaftSnapshotConfig{
+		IOLimit:       intVal(raw.IOLimitMB) * 1024 * 1024,
+		WindowStart:   b.timeOfDayVal("raft_snapshot.window.start", raw.Window.Start),
+		WindowEnd:     b.timeOfDayVal("raft_snapshot.window.end", raw.Window.End),
+		PeakThreshold: intVal(raw.Window.PeakThreshold),
+	}
+	if (raw.Window.Start == nil) != (raw.Window.End == nil) {
+		b.err = multierror.Append(b.err, fmt.Errorf("raft_snapshot.window.start and raft_snapshot.window.end must be set together"))
+	}
+	if cfg.WindowEnabled() && cfg.PeakThreshold == 0 {
+		cfg.PeakThreshold = threshold * raftSnapshotPeakThresholdMultiplier
+	}
+	return cfg
+}
+
+// timeOfDayVal parses a "HH:MM" time of the day into its offset from
+// midnight.
+func (b *builder) timeOfDayVal(name string, v *string) time.Duration {
+	if v == nil {
+		return 0
+	}
+	t, err := time.Parse("15:04", *v)
+	if err != nil {
+		b.err = multierror.Append(b.err, fmt.Errorf("%s: invalid time of the day %q, must be formatted as HH:MM", name, *v))
+		return 0
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+func validateRaftSnapshot(cfg consul.RaftSnapshotConfig, threshold int) error {
+	if cfg.IOLimit < 0 {
+		return fmt.Errorf("raft_snapshot.io_limit_mb cannot be negative")
+	}
+	if cfg.PeakThreshold < 0 {
+		return fmt.Errorf("raft_snapshot.window.peak_threshold cannot be negative")
+	}
+	if cfg.WindowEnabled() && cfg.PeakThreshold < threshold {
+		return fmt.Errorf("raft_snapshot.window.peak_threshold (%d) cannot be lower than raft_snapshot_threshold (%d)",
+			cfg.PeakThreshold, threshold)
+	}
+	return nil
+}
+
 func (b *builder) raftLogStoreConfigVal(raw *RaftLogStoreRaw) consul.RaftLogStoreConfig {
 	var cfg consul.RaftLogStoreConfig
 	if raw != nil {
@@ -22,12 +22,6 @@ func validateEnterpriseConfigKeys(config *Config) []error {
 	if config.ReadReplica != nil {
 		add(`read_replica (or the deprecated non_voting_server)`)
 	}
-	if stringVal(config.SegmentName) != "" {
-		add("segment")
-	}
-	if len(config.Segments) > 0 {
-		add("segments")
-	}
 	if stringVal(config.Partition) != "" {
 		add("partition")
 	}
@@ -59,6 +53,17 @@ func validateEnterpriseConfigKeys(config *Config) []error {
 		add("license_path")
 		config.LicensePath = nil
 	}
+	for i := range config.CatalogProviders {
+		p := &config.CatalogProviders[i]
+		if stringVal(p.Partition) != "" {
+			add(fmt.Sprintf("catalog_providers[%d].partition", i))
+			p.Partition = nil
+		}
+		if stringVal(p.Namespace) != "" {
+			add(fmt.Sprintf("catalog_providers[%d].namespace", i))
+			p.Namespace = nil
+		}
+	}
 	if config.Reporting.License.Enabled != nil {
 		add("reporting.license.enabled")
 		config.Reporting.License.Enabled = nil
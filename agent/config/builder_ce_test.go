@@ -29,18 +29,6 @@ func TestValidateEnterpriseConfigKeys(t *testing.T) {
 			},
 			badKeys: []string{"read_replica (or the deprecated non_voting_server)"},
 		},
-		"segment": {
-			config: Config{
-				SegmentName: &stringVal,
-			},
-			badKeys: []string{"segment"},
-		},
-		"segments": {
-			config: Config{
-				Segments: []Segment{{Name: &stringVal}},
-			},
-			badKeys: []string{"segments"},
-		},
 		"autopilot.redundancy_zone_tag": {
 			config: Config{
 				Autopilot: Autopilot{
@@ -122,17 +110,30 @@ func TestValidateEnterpriseConfigKeys(t *testing.T) {
 				require.Nil(t, c.Reporting.License.Enabled)
 			},
 		},
+		"catalog_providers partition and namespace": {
+			config: Config{
+				CatalogProviders: []CatalogProvider{
+					{Name: &stringVal},
+					{Name: &stringVal, Partition: &stringVal, Namespace: &stringVal},
+				},
+			},
+			badKeys: []string{"catalog_providers[1].partition", "catalog_providers[1].namespace"},
+			check: func(t *testing.T, c *Config) {
+				require.Nil(t, c.CatalogProviders[1].Partition)
+				require.Nil(t, c.CatalogProviders[1].Namespace)
+			},
+		},
 		"multi": {
 			config: Config{
 				ReadReplica: &boolVal,
-				SegmentName: &stringVal,
+				Partition:   &stringVal,
 				ACL: ACL{
 					Tokens: Tokens{
 						DeprecatedTokens: DeprecatedTokens{AgentMaster: &stringVal},
 					},
 				},
 			},
-			badKeys: []string{"read_replica (or the deprecated non_voting_server)", "segment"},
+			badKeys: []string{"read_replica (or the deprecated non_voting_server)", "partition"},
 		},
 	}
 
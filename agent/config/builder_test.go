@@ -299,6 +299,44 @@ func TestLoad_EmptyClientAddr(t *testing.T) {
 	}
 }
 
+func TestLoad_DevPortOffset(t *testing.T) {
+	devMode := true
+	load := func(offset int, hcl string) (*RuntimeConfig, error) {
+		opts := LoadOpts{
+			DevMode:       &devMode,
+			DevPortOffset: &offset,
+			HCL:           []string{hcl},
+		}
+		patchLoadOptsShims(&opts)
+		result, err := Load(opts)
+		if err != nil {
+			return nil, err
+		}
+		return result.RuntimeConfig, nil
+	}
+
+	rt, err := load(100, `ports { dns = 9000 }`)
+	require.NoError(t, err)
+	require.Equal(t, 8600, rt.HTTPPort)
+	require.Equal(t, 8602, rt.GRPCPort)
+	require.Equal(t, 8603, rt.GRPCTLSPort)
+	require.Equal(t, 8401, rt.SerfPortLAN)
+	require.Equal(t, 8402, rt.SerfPortWAN)
+	require.Equal(t, 8400, rt.ServerPort)
+	require.Equal(t, 21100, rt.ConnectSidecarMinPort)
+	require.Equal(t, 21855, rt.ExposeMaxPort)
+	require.Equal(t, -1, rt.HTTPSPort)
+	// Ports set explicitly are not shifted.
+	require.Equal(t, 9000, rt.DNSPort)
+
+	_, err = load(MaxDevPortOffset+1, ``)
+	require.ErrorContains(t, err, "-dev-port-offset must be between 0 and")
+
+	offset := 100
+	_, err = Load(LoadOpts{DevPortOffset: &offset})
+	require.ErrorContains(t, err, "-dev-port-offset can only be used with -dev")
+}
+
 func TestBuilder_DurationVal_InvalidDuration(t *testing.T) {
 	b := builder{}
 	badDuration1 := "not-a-duration"
@@ -9,7 +9,9 @@ import (
 	"encoding/asn1"
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/types"
 	"math/big"
 	"net"
@@ -20,6 +22,10 @@ import (
 // DeepCopy generates a deep copy of *RuntimeConfig
 func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 	var cp RuntimeConfig = *o
+	if o.AgentDefaultsLocalKeys != nil {
+		cp.AgentDefaultsLocalKeys = make([]string, len(o.AgentDefaultsLocalKeys))
+		copy(cp.AgentDefaultsLocalKeys, o.AgentDefaultsLocalKeys)
+	}
 	if o.Cloud.TLSConfig != nil {
 		cp.Cloud.TLSConfig = new(tls.Config)
 		*cp.Cloud.TLSConfig = *o.Cloud.TLSConfig
@@ -845,6 +851,10 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 			copy(cp.BindAddr.IP, o.BindAddr.IP)
 		}
 	}
+	if o.CatalogProviders != nil {
+		cp.CatalogProviders = make([]catalogprovider.Config, len(o.CatalogProviders))
+		copy(cp.CatalogProviders, o.CatalogProviders)
+	}
 	if o.Checks != nil {
 		cp.Checks = make([]*structs.CheckDefinition, len(o.Checks))
 		copy(cp.Checks, o.Checks)
@@ -940,6 +950,14 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 		cp.DNSAddrs = make([]net.Addr, len(o.DNSAddrs))
 		copy(cp.DNSAddrs, o.DNSAddrs)
 	}
+	if o.EgressProxy != nil {
+		cp.EgressProxy = new(egressproxy.Config)
+		*cp.EgressProxy = *o.EgressProxy
+		if o.EgressProxy.NoProxy != nil {
+			cp.EgressProxy.NoProxy = make([]string, len(o.EgressProxy.NoProxy))
+			copy(cp.EgressProxy.NoProxy, o.EgressProxy.NoProxy)
+		}
+	}
 	if o.GRPCAddrs != nil {
 		cp.GRPCAddrs = make([]net.Addr, len(o.GRPCAddrs))
 		copy(cp.GRPCAddrs, o.GRPCAddrs)
@@ -956,6 +974,10 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 		cp.HTTPSAddrs = make([]net.Addr, len(o.HTTPSAddrs))
 		copy(cp.HTTPSAddrs, o.HTTPSAddrs)
 	}
+	if o.KVReplicationPrefixes != nil {
+		cp.KVReplicationPrefixes = make([]string, len(o.KVReplicationPrefixes))
+		copy(cp.KVReplicationPrefixes, o.KVReplicationPrefixes)
+	}
 	if o.Locality != nil {
 		cp.Locality = new(Locality)
 		*cp.Locality = *o.Locality
@@ -968,6 +990,22 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 			*cp.Locality.Zone = *o.Locality.Zone
 		}
 	}
+	if o.Logging.KafkaBrokers != nil {
+		cp.Logging.KafkaBrokers = make([]string, len(o.Logging.KafkaBrokers))
+		copy(cp.Logging.KafkaBrokers, o.Logging.KafkaBrokers)
+	}
+	if o.MetaIndexes.NodeKeys != nil {
+		cp.MetaIndexes.NodeKeys = make([]string, len(o.MetaIndexes.NodeKeys))
+		copy(cp.MetaIndexes.NodeKeys, o.MetaIndexes.NodeKeys)
+	}
+	if o.MetaIndexes.ServiceKeys != nil {
+		cp.MetaIndexes.ServiceKeys = make([]string, len(o.MetaIndexes.ServiceKeys))
+		copy(cp.MetaIndexes.ServiceKeys, o.MetaIndexes.ServiceKeys)
+	}
+	if o.NodeAttestation.TPMCommand != nil {
+		cp.NodeAttestation.TPMCommand = make([]string, len(o.NodeAttestation.TPMCommand))
+		copy(cp.NodeAttestation.TPMCommand, o.NodeAttestation.TPMCommand)
+	}
 	if o.NodeMeta != nil {
 		cp.NodeMeta = make(map[string]string, len(o.NodeMeta))
 		for k2, v2 := range o.NodeMeta {
@@ -978,6 +1016,18 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 		cp.PrimaryGateways = make([]string, len(o.PrimaryGateways))
 		copy(cp.PrimaryGateways, o.PrimaryGateways)
 	}
+	if o.Quotas.Overrides != nil {
+		cp.Quotas.Overrides = make([]structs.QuotaOverride, len(o.Quotas.Overrides))
+		copy(cp.Quotas.Overrides, o.Quotas.Overrides)
+	}
+	if o.Redaction.KVPrefixes != nil {
+		cp.Redaction.KVPrefixes = make([]string, len(o.Redaction.KVPrefixes))
+		copy(cp.Redaction.KVPrefixes, o.Redaction.KVPrefixes)
+	}
+	if o.Redaction.ConfigEntryFields != nil {
+		cp.Redaction.ConfigEntryFields = make([]string, len(o.Redaction.ConfigEntryFields))
+		copy(cp.Redaction.ConfigEntryFields, o.Redaction.ConfigEntryFields)
+	}
 	if o.RPCAdvertiseAddr != nil {
 		cp.RPCAdvertiseAddr = new(net.TCPAddr)
 		*cp.RPCAdvertiseAddr = *o.RPCAdvertiseAddr
@@ -994,6 +1044,10 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 			copy(cp.RPCBindAddr.IP, o.RPCBindAddr.IP)
 		}
 	}
+	if o.RaftArchive.Types != nil {
+		cp.RaftArchive.Types = make([]string, len(o.RaftArchive.Types))
+		copy(cp.RaftArchive.Types, o.RaftArchive.Types)
+	}
 	if o.RetryJoinLAN != nil {
 		cp.RetryJoinLAN = make([]string, len(o.RetryJoinLAN))
 		copy(cp.RetryJoinLAN, o.RetryJoinLAN)
@@ -1097,14 +1151,26 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 		cp.TLS.InternalRPC.CipherSuites = make([]types.TLSCipherSuite, len(o.TLS.InternalRPC.CipherSuites))
 		copy(cp.TLS.InternalRPC.CipherSuites, o.TLS.InternalRPC.CipherSuites)
 	}
+	if o.TLS.InternalRPC.CurvePreferences != nil {
+		cp.TLS.InternalRPC.CurvePreferences = make([]types.TLSCurve, len(o.TLS.InternalRPC.CurvePreferences))
+		copy(cp.TLS.InternalRPC.CurvePreferences, o.TLS.InternalRPC.CurvePreferences)
+	}
 	if o.TLS.GRPC.CipherSuites != nil {
 		cp.TLS.GRPC.CipherSuites = make([]types.TLSCipherSuite, len(o.TLS.GRPC.CipherSuites))
 		copy(cp.TLS.GRPC.CipherSuites, o.TLS.GRPC.CipherSuites)
 	}
+	if o.TLS.GRPC.CurvePreferences != nil {
+		cp.TLS.GRPC.CurvePreferences = make([]types.TLSCurve, len(o.TLS.GRPC.CurvePreferences))
+		copy(cp.TLS.GRPC.CurvePreferences, o.TLS.GRPC.CurvePreferences)
+	}
 	if o.TLS.HTTPS.CipherSuites != nil {
 		cp.TLS.HTTPS.CipherSuites = make([]types.TLSCipherSuite, len(o.TLS.HTTPS.CipherSuites))
 		copy(cp.TLS.HTTPS.CipherSuites, o.TLS.HTTPS.CipherSuites)
 	}
+	if o.TLS.HTTPS.CurvePreferences != nil {
+		cp.TLS.HTTPS.CurvePreferences = make([]types.TLSCurve, len(o.TLS.HTTPS.CurvePreferences))
+		copy(cp.TLS.HTTPS.CurvePreferences, o.TLS.HTTPS.CurvePreferences)
+	}
 	if o.TaggedAddresses != nil {
 		cp.TaggedAddresses = make(map[string]string, len(o.TaggedAddresses))
 		for k2, v2 := range o.TaggedAddresses {
@@ -1129,6 +1195,10 @@ func (o *RuntimeConfig) DeepCopy() *RuntimeConfig {
 			cp.UIConfig.DashboardURLTemplates[k3] = v3
 		}
 	}
+	if o.NamedPipeAllowedSIDs != nil {
+		cp.NamedPipeAllowedSIDs = make([]string, len(o.NamedPipeAllowedSIDs))
+		copy(cp.NamedPipeAllowedSIDs, o.NamedPipeAllowedSIDs)
+	}
 	if o.Watches != nil {
 		cp.Watches = make([]map[string]interface{}, len(o.Watches))
 		copy(cp.Watches, o.Watches)
@@ -124,6 +124,65 @@ type Cache struct {
 	EntryFetchRate *float64 `mapstructure:"entry_fetch_rate"`
 }
 
+// ACME configures the client the servers use to obtain and renew the
+// certificates of the API gateway listeners.
+type ACME struct {
+	Enabled           *bool             `mapstructure:"enabled"`
+	DirectoryURL      *string           `mapstructure:"directory_url"`
+	Email             *string           `mapstructure:"email"`
+	Challenge         *string           `mapstructure:"challenge"`
+	RenewBefore       *string           `mapstructure:"renew_before"`
+	DNSProvider       *string           `mapstructure:"dns_provider"`
+	DNSProviderConfig map[string]string `mapstructure:"dns_provider_config"`
+}
+
+// Webhook configures an HTTP endpoint the leader posts the changes of the
+// catalog and of the config entries to.
+type Webhook struct {
+	Name       *string  `mapstructure:"name"`
+	URL        *string  `mapstructure:"url"`
+	Secret     *string  `mapstructure:"secret"`
+	Events     []string `mapstructure:"events"`
+	Services   []string `mapstructure:"services"`
+	Timeout    *string  `mapstructure:"timeout"`
+	MaxRetries *int     `mapstructure:"max_retries"`
+}
+
+// AdmissionWebhook configures an HTTP endpoint the servers call to validate,
+// and optionally mutate, the catalog registrations and the config entries
+// before they are written.
+type AdmissionWebhook struct {
+	Name          *string  `mapstructure:"name"`
+	URL           *string  `mapstructure:"url"`
+	Secret        *string  `mapstructure:"secret"`
+	Operations    []string `mapstructure:"operations"`
+	Mutating      *bool    `mapstructure:"mutating"`
+	FailurePolicy *string  `mapstructure:"failure_policy"`
+	Timeout       *string  `mapstructure:"timeout"`
+}
+
+// CheckFlapDetection configures how the agent suppresses the status changes
+// of flapping checks.
+type CheckFlapDetection struct {
+	// ConsecutiveResults is the number of consecutive passing or failing
+	// results required before the status of a check which does not set
+	// success_before_passing or failures_before_critical changes.
+	ConsecutiveResults *int `mapstructure:"consecutive_results"`
+	// WindowSize is the number of recent results of a check kept to compute
+	// how many of them agree with a new status.
+	WindowSize *int `mapstructure:"window_size"`
+	// MinRatio is the share of the results of the window which must agree
+	// with a new status before it is published.
+	MinRatio *float64 `mapstructure:"min_ratio"`
+}
+
+// CheckPlugin configures an external program providing a custom check type.
+type CheckPlugin struct {
+	Name *string  `mapstructure:"name"`
+	Path *string  `mapstructure:"path"`
+	Args []string `mapstructure:"args"`
+}
+
 // Config defines the format of a configuration file in either JSON or
 // HCL format.
 //
@@ -135,7 +194,9 @@ type Cache struct {
 // changed and refactored at will since this will break existing setups.
 type Config struct {
 	ACL                              ACL                 `mapstructure:"acl" json:"-"`
+	ACME                             ACME                `mapstructure:"acme" json:"-"`
 	Addresses                        Addresses           `mapstructure:"addresses" json:"-"`
+	AddressFamilyPreference          *string             `mapstructure:"address_family_preference" json:"address_family_preference,omitempty"`
 	AdvertiseAddrLAN                 *string             `mapstructure:"advertise_addr" json:"advertise_addr,omitempty"`
 	AdvertiseAddrLANIPv4             *string             `mapstructure:"advertise_addr_ipv4" json:"advertise_addr_ipv4,omitempty"`
 	AdvertiseAddrLANIPv6             *string             `mapstructure:"advertise_addr_ipv6" json:"advertise_addr_ipv6,omitempty"`
@@ -149,8 +210,11 @@ type Config struct {
 	Bootstrap                        *bool               `mapstructure:"bootstrap" json:"bootstrap,omitempty"`
 	BootstrapExpect                  *int                `mapstructure:"bootstrap_expect" json:"bootstrap_expect,omitempty"`
 	Cache                            Cache               `mapstructure:"cache" json:"-"`
+	CatalogProviders                 []CatalogProvider   `mapstructure:"catalog_providers" json:"-"`
 	Check                            *CheckDefinition    `mapstructure:"check" json:"-"` // needs to be a pointer to avoid partial merges
+	CheckFlapDetection               CheckFlapDetection  `mapstructure:"check_flap_detection" json:"-"`
 	CheckOutputMaxSize               *int                `mapstructure:"check_output_max_size" json:"check_output_max_size,omitempty"`
+	CheckPlugins                     []CheckPlugin       `mapstructure:"check_plugins" json:"-"`
 	CheckUpdateInterval              *string             `mapstructure:"check_update_interval" json:"check_update_interval,omitempty"`
 	Checks                           []CheckDefinition   `mapstructure:"checks" json:"-"`
 	ClientAddr                       *string             `mapstructure:"client_addr" json:"client_addr,omitempty"`
@@ -175,6 +239,7 @@ type Config struct {
 	DisableUpdateCheck               *bool               `mapstructure:"disable_update_check" json:"disable_update_check,omitempty"`
 	DiscardCheckOutput               *bool               `mapstructure:"discard_check_output" json:"discard_check_output,omitempty"`
 	DiscoveryMaxStale                *string             `mapstructure:"discovery_max_stale" json:"discovery_max_stale,omitempty"`
+	EgressProxy                      EgressProxy         `mapstructure:"egress_proxy" json:"-"`
 	EnableAgentTLSForChecks          *bool               `mapstructure:"enable_agent_tls_for_checks" json:"enable_agent_tls_for_checks,omitempty"`
 	EnableCentralServiceConfig       *bool               `mapstructure:"enable_central_service_config" json:"enable_central_service_config,omitempty"`
 	EnableDebug                      *bool               `mapstructure:"enable_debug" json:"enable_debug,omitempty"`
@@ -185,13 +250,17 @@ type Config struct {
 	EncryptVerifyIncoming            *bool               `mapstructure:"encrypt_verify_incoming" json:"encrypt_verify_incoming,omitempty"`
 	EncryptVerifyOutgoing            *bool               `mapstructure:"encrypt_verify_outgoing" json:"encrypt_verify_outgoing,omitempty"`
 	Experiments                      []string            `mapstructure:"experiments" json:"experiments,omitempty"`
+	GitOps                           GitOpsRaw           `mapstructure:"gitops" json:"-"`
 	GossipLAN                        GossipLANConfig     `mapstructure:"gossip_lan" json:"-"`
 	GossipWAN                        GossipWANConfig     `mapstructure:"gossip_wan" json:"-"`
 	HTTPConfig                       HTTPConfig          `mapstructure:"http_config" json:"-"`
+	KVEncryption                     KVEncryption        `mapstructure:"kv_encryption" json:"-"`
+	KVReplication                    KVReplication       `mapstructure:"kv_replication" json:"-"`
 	LeaveOnTerm                      *bool               `mapstructure:"leave_on_terminate" json:"leave_on_terminate,omitempty"`
 	LicensePath                      *string             `mapstructure:"license_path" json:"license_path,omitempty"`
 	Limits                           Limits              `mapstructure:"limits" json:"-"`
 	Locality                         *Locality           `mapstructure:"locality" json:"-"`
+	Logging                          Logging             `mapstructure:"logging" json:"-"`
 	LogLevel                         *string             `mapstructure:"log_level" json:"log_level,omitempty"`
 	LogJSON                          *bool               `mapstructure:"log_json" json:"log_json,omitempty"`
 	LogFile                          *string             `mapstructure:"log_file" json:"log_file,omitempty"`
@@ -199,6 +268,8 @@ type Config struct {
 	LogRotateBytes                   *int                `mapstructure:"log_rotate_bytes" json:"log_rotate_bytes,omitempty"`
 	LogRotateMaxFiles                *int                `mapstructure:"log_rotate_max_files" json:"log_rotate_max_files,omitempty"`
 	MaxQueryTime                     *string             `mapstructure:"max_query_time" json:"max_query_time,omitempty"`
+	MetaIndexes                      MetaIndexes         `mapstructure:"meta_indexes" json:"-"`
+	NodeAttestation                  NodeAttestationRaw  `mapstructure:"node_attestation" json:"-"`
 	NodeID                           *string             `mapstructure:"node_id" json:"node_id,omitempty"`
 	NodeMeta                         map[string]string   `mapstructure:"node_meta" json:"node_meta,omitempty"`
 	NodeName                         *string             `mapstructure:"node_name" json:"node_name,omitempty"`
@@ -209,6 +280,8 @@ type Config struct {
 	PrimaryDatacenter                *string             `mapstructure:"primary_datacenter" json:"primary_datacenter,omitempty"`
 	PrimaryGateways                  []string            `mapstructure:"primary_gateways" json:"primary_gateways,omitempty"`
 	PrimaryGatewaysInterval          *string             `mapstructure:"primary_gateways_interval" json:"primary_gateways_interval,omitempty"`
+	Quotas                           Quotas              `mapstructure:"quotas" json:"-"`
+	Redaction                        Redaction           `mapstructure:"redaction" json:"-"`
 	RPCProtocol                      *int                `mapstructure:"protocol" json:"protocol,omitempty"`
 	RaftProtocol                     *int                `mapstructure:"raft_protocol" json:"raft_protocol,omitempty"`
 	RaftSnapshotThreshold            *int                `mapstructure:"raft_snapshot_threshold" json:"raft_snapshot_threshold,omitempty"`
@@ -250,13 +323,20 @@ type Config struct {
 	UIDir    *string     `mapstructure:"ui_dir" json:"-"`
 	UIConfig RawUIConfig `mapstructure:"ui_config" json:"-"`
 
-	UnixSocket UnixSocket               `mapstructure:"unix_sockets" json:"-"`
-	Watches    []map[string]interface{} `mapstructure:"watches" json:"-"`
+	UnixSocket        UnixSocket               `mapstructure:"unix_sockets" json:"-"`
+	NamedPipes        NamedPipes               `mapstructure:"named_pipes" json:"-"`
+	Watches           []map[string]interface{} `mapstructure:"watches" json:"-"`
+	Webhooks          []Webhook                `mapstructure:"webhooks" json:"-"`
+	AdmissionWebhooks []AdmissionWebhook       `mapstructure:"admission_webhooks" json:"-"`
 
 	RPC RPC `mapstructure:"rpc" json:"-"`
 
 	RaftLogStore RaftLogStoreRaw `mapstructure:"raft_logstore" json:"raft_logstore,omitempty"`
 
+	RaftArchive RaftArchiveRaw `mapstructure:"raft_archive" json:"raft_archive,omitempty"`
+
+	RaftSnapshot RaftSnapshotRaw `mapstructure:"raft_snapshot" json:"raft_snapshot,omitempty"`
+
 	// UseStreamingBackend instead of blocking queries for service health and
 	// any other endpoints which support streaming.
 	UseStreamingBackend *bool `mapstructure:"use_streaming_backend" json:"-"`
@@ -310,6 +390,7 @@ type GossipLANConfig struct {
 	ProbeTimeout   *string `mapstructure:"probe_timeout"`
 	SuspicionMult  *int    `mapstructure:"suspicion_mult"`
 	RetransmitMult *int    `mapstructure:"retransmit_mult"`
+	Transport      *string `mapstructure:"transport"`
 }
 
 type GossipWANConfig struct {
@@ -319,9 +400,42 @@ type GossipWANConfig struct {
 	ProbeTimeout   *string `mapstructure:"probe_timeout"`
 	SuspicionMult  *int    `mapstructure:"suspicion_mult"`
 	RetransmitMult *int    `mapstructure:"retransmit_mult"`
+	Transport      *string `mapstructure:"transport"`
 }
 
 // Locality identifies where a given entity is running.
+// Logging configures where the agent logs are forwarded to, in addition to
+// the outputs set with log_file and enable_syslog.
+type Logging struct {
+	Syslog   LoggingSyslog   `mapstructure:"syslog"`
+	Kafka    LoggingKafka    `mapstructure:"kafka"`
+	Rotation LoggingRotation `mapstructure:"rotation"`
+}
+
+type LoggingSyslog struct {
+	Address *string    `mapstructure:"address"`
+	TLS     LoggingTLS `mapstructure:"tls"`
+}
+
+type LoggingKafka struct {
+	Brokers []string   `mapstructure:"brokers"`
+	Topic   *string    `mapstructure:"topic"`
+	TLS     LoggingTLS `mapstructure:"tls"`
+}
+
+type LoggingRotation struct {
+	Compress *bool `mapstructure:"compress"`
+}
+
+type LoggingTLS struct {
+	Enabled            *bool   `mapstructure:"enabled"`
+	CAFile             *string `mapstructure:"ca_file"`
+	CertFile           *string `mapstructure:"cert_file"`
+	KeyFile            *string `mapstructure:"key_file"`
+	ServerName         *string `mapstructure:"server_name"`
+	InsecureSkipVerify *bool   `mapstructure:"insecure_skip_verify"`
+}
+
 type Locality struct {
 	// Region is region the zone belongs to.
 	Region *string `mapstructure:"region"`
@@ -441,6 +555,8 @@ type CheckDefinition struct {
 	H2PING                         *string             `mapstructure:"h2ping"`
 	H2PingUseTLS                   *bool               `mapstructure:"h2ping_use_tls"`
 	OSService                      *string             `mapstructure:"os_service"`
+	Plugin                         *string             `mapstructure:"plugin"`
+	PluginConfig                   map[string]string   `mapstructure:"plugin_config"`
 	SuccessBeforePassing           *int                `mapstructure:"success_before_passing"`
 	FailuresBeforeWarning          *int                `mapstructure:"failures_before_warning"`
 	FailuresBeforeCritical         *int                `mapstructure:"failures_before_critical"`
@@ -630,6 +746,18 @@ type Connect struct {
 	CAProvider                      *string                `mapstructure:"ca_provider" json:"ca_provider,omitempty"`
 	CAConfig                        map[string]interface{} `mapstructure:"ca_config" json:"ca_config,omitempty"`
 	MeshGatewayWANFederationEnabled *bool                  `mapstructure:"enable_mesh_gateway_wan_federation" json:"enable_mesh_gateway_wan_federation,omitempty"`
+	AuthorizeLogSampleRate          *float64               `mapstructure:"authorize_log_sample_rate" json:"authorize_log_sample_rate,omitempty"`
+
+	// MeshGatewayWANAddressProvider is the cloud provider whose instance
+	// metadata is queried for the public address of the local mesh gateways.
+	MeshGatewayWANAddressProvider        *string `mapstructure:"mesh_gateway_wan_address_provider" json:"mesh_gateway_wan_address_provider,omitempty"`
+	MeshGatewayWANAddressPort            *int    `mapstructure:"mesh_gateway_wan_address_port" json:"mesh_gateway_wan_address_port,omitempty"`
+	MeshGatewayWANAddressRefreshInterval *string `mapstructure:"mesh_gateway_wan_address_refresh_interval" json:"mesh_gateway_wan_address_refresh_interval,omitempty"`
+
+	// DisableExpiredIntentions and DisableIntentionsAfterReview opt into the
+	// leader disabling the intentions past their ExpiresAt or ReviewBy time.
+	DisableExpiredIntentions     *bool `mapstructure:"disable_expired_intentions" json:"disable_expired_intentions,omitempty"`
+	DisableIntentionsAfterReview *bool `mapstructure:"disable_intentions_after_review" json:"disable_intentions_after_review,omitempty"`
 
 	// TestCALeafRootChangeSpread controls how long after a CA roots change before new leaf certs will be generated.
 	// This is only tuned in tests, generally set to 1ns to make tests deterministic with when to expect updated leaf
@@ -661,25 +789,36 @@ type DNS struct {
 	SOA                *SOA              `mapstructure:"soa"`
 	UseCache           *bool             `mapstructure:"use_cache"`
 	CacheMaxAge        *string           `mapstructure:"cache_max_age"`
+	HostResolver       DNSHostResolver   `mapstructure:"host_resolver"`
+
+	RecursorCacheSize         *int    `mapstructure:"recursor_cache_size"`
+	RecursorCacheStaleIfError *string `mapstructure:"recursor_cache_stale_if_error"`
 
 	// Enterprise Only
 	PreferNamespace *bool `mapstructure:"prefer_namespace"`
 }
 
+type DNSHostResolver struct {
+	Enabled *bool   `mapstructure:"enabled"`
+	Mode    *string `mapstructure:"mode"`
+}
+
 type HTTPConfig struct {
-	BlockEndpoints     []string          `mapstructure:"block_endpoints"`
-	AllowWriteHTTPFrom []string          `mapstructure:"allow_write_http_from"`
-	ResponseHeaders    map[string]string `mapstructure:"response_headers"`
-	UseCache           *bool             `mapstructure:"use_cache"`
-	MaxHeaderBytes     *int              `mapstructure:"max_header_bytes"`
+	BlockEndpoints       []string          `mapstructure:"block_endpoints"`
+	AllowWriteHTTPFrom   []string          `mapstructure:"allow_write_http_from"`
+	ResponseHeaders      map[string]string `mapstructure:"response_headers"`
+	UseCache             *bool             `mapstructure:"use_cache"`
+	MaxHeaderBytes       *int              `mapstructure:"max_header_bytes"`
+	ClientCertAuthMethod *string           `mapstructure:"client_cert_auth_method"`
 }
 
 type Performance struct {
-	LeaveDrainTime        *string `mapstructure:"leave_drain_time"`
-	RaftMultiplier        *int    `mapstructure:"raft_multiplier"` // todo(fs): validate as uint
-	RPCHoldTimeout        *string `mapstructure:"rpc_hold_timeout"`
-	GRPCKeepaliveInterval *string `mapstructure:"grpc_keepalive_interval"`
-	GRPCKeepaliveTimeout  *string `mapstructure:"grpc_keepalive_timeout"`
+	LeaveDrainTime           *string `mapstructure:"leave_drain_time"`
+	RaftMultiplier           *int    `mapstructure:"raft_multiplier"` // todo(fs): validate as uint
+	RPCHoldTimeout           *string `mapstructure:"rpc_hold_timeout"`
+	GRPCKeepaliveInterval    *string `mapstructure:"grpc_keepalive_interval"`
+	GRPCKeepaliveTimeout     *string `mapstructure:"grpc_keepalive_timeout"`
+	CheckUpdateBatchInterval *string `mapstructure:"check_update_batch_interval"`
 }
 
 type Telemetry struct {
@@ -699,6 +838,8 @@ type Telemetry struct {
 	DisableHostname                    *bool    `mapstructure:"disable_hostname" json:"disable_hostname,omitempty"`
 	DisablePerTenancyUsageMetrics      *bool    `mapstructure:"disable_per_tenancy_usage_metrics" json:"disable_per_tenancy_usage_metrics,omitempty"`
 	EnableHostMetrics                  *bool    `mapstructure:"enable_host_metrics" json:"enable_host_metrics,omitempty"`
+	EnvoyMetricsAllowlist              []string `mapstructure:"envoy_metrics_allowlist" json:"envoy_metrics_allowlist,omitempty"`
+	EnvoyMetricsScrapeInterval         *string  `mapstructure:"envoy_metrics_scrape_interval" json:"envoy_metrics_scrape_interval,omitempty"`
 	DogstatsdAddr                      *string  `mapstructure:"dogstatsd_addr" json:"dogstatsd_addr,omitempty"`
 	DogstatsdTags                      []string `mapstructure:"dogstatsd_tags" json:"dogstatsd_tags,omitempty"`
 	RetryFailedConfiguration           *bool    `mapstructure:"retry_failed_connection" json:"retry_failed_connection,omitempty"`
@@ -728,9 +869,14 @@ type Ports struct {
 }
 
 type UnixSocket struct {
-	Group *string `mapstructure:"group"`
-	Mode  *string `mapstructure:"mode"`
-	User  *string `mapstructure:"user"`
+	Group      *string `mapstructure:"group"`
+	Mode       *string `mapstructure:"mode"`
+	User       *string `mapstructure:"user"`
+	AuthMethod *string `mapstructure:"auth_method"`
+}
+
+type NamedPipes struct {
+	AllowedSIDs []string `mapstructure:"allowed_sids"`
 }
 
 type RequestLimits struct {
@@ -752,12 +898,117 @@ type Limits struct {
 	TxnMaxReqLen          *uint64       `mapstructure:"txn_max_req_len"`
 }
 
+type KVEncryption struct {
+	Provider     *string                  `mapstructure:"provider"`
+	VaultTransit KVEncryptionVaultTransit `mapstructure:"vault_transit"`
+	AWSKMS       KVEncryptionAWSKMS       `mapstructure:"aws_kms"`
+	Static       KVEncryptionStatic       `mapstructure:"static"`
+}
+
+type KVEncryptionVaultTransit struct {
+	Address       *string `mapstructure:"address"`
+	Token         *string `mapstructure:"token"`
+	Namespace     *string `mapstructure:"namespace"`
+	MountPath     *string `mapstructure:"mount_path"`
+	KeyName       *string `mapstructure:"key_name"`
+	CAFile        *string `mapstructure:"ca_file"`
+	CAPath        *string `mapstructure:"ca_path"`
+	CertFile      *string `mapstructure:"cert_file"`
+	KeyFile       *string `mapstructure:"key_file"`
+	TLSServerName *string `mapstructure:"tls_server_name"`
+	TLSSkipVerify *bool   `mapstructure:"tls_skip_verify"`
+}
+
+type KVEncryptionAWSKMS struct {
+	KeyID    *string `mapstructure:"key_id"`
+	Region   *string `mapstructure:"region"`
+	Endpoint *string `mapstructure:"endpoint"`
+}
+
+type KVEncryptionStatic struct {
+	Key *string `mapstructure:"key"`
+}
+
+type KVReplication struct {
+	Prefixes []string `mapstructure:"prefixes"`
+}
+
+// CatalogProvider configures an external source of service instances the
+// leader syncs into the catalog.
+type CatalogProvider struct {
+	Name         *string                    `mapstructure:"name"`
+	Type         *string                    `mapstructure:"type"`
+	Partition    *string                    `mapstructure:"partition"`
+	Namespace    *string                    `mapstructure:"namespace"`
+	SyncInterval *string                    `mapstructure:"sync_interval"`
+	AWSCloudMap  CatalogProviderAWSCloudMap `mapstructure:"aws_cloud_map"`
+	DNS          CatalogProviderDNS         `mapstructure:"dns"`
+	File         CatalogProviderFile        `mapstructure:"file"`
+	Etcd         CatalogProviderEtcd        `mapstructure:"etcd"`
+}
+
+type CatalogProviderAWSCloudMap struct {
+	NamespaceID *string `mapstructure:"namespace_id"`
+	Region      *string `mapstructure:"region"`
+	Endpoint    *string `mapstructure:"endpoint"`
+}
+
+type CatalogProviderDNS struct {
+	Zone        *string `mapstructure:"zone"`
+	Server      *string `mapstructure:"server"`
+	TSIGKeyName *string `mapstructure:"tsig_key_name"`
+	TSIGSecret  *string `mapstructure:"tsig_secret"`
+}
+
+type CatalogProviderFile struct {
+	Path *string `mapstructure:"path"`
+}
+
+type CatalogProviderEtcd struct {
+	Address  *string `mapstructure:"address"`
+	Prefix   *string `mapstructure:"prefix"`
+	Username *string `mapstructure:"username"`
+	Password *string `mapstructure:"password"`
+}
+
+type MetaIndexes struct {
+	NodeMetaKeys    []string `mapstructure:"node_meta_keys"`
+	ServiceMetaKeys []string `mapstructure:"service_meta_keys"`
+}
+
+type Quotas struct {
+	MaxServices   *int `mapstructure:"max_services"`
+	MaxKVBytes    *int `mapstructure:"max_kv_bytes"`
+	MaxIntentions *int `mapstructure:"max_intentions"`
+	MaxACLTokens  *int `mapstructure:"max_acl_tokens"`
+
+	// Overrides replace the limits above for specific namespaces.
+	Overrides []QuotaOverride `mapstructure:"overrides"`
+}
+
+type QuotaOverride struct {
+	Partition     *string `mapstructure:"partition"`
+	Namespace     *string `mapstructure:"namespace"`
+	MaxServices   *int    `mapstructure:"max_services"`
+	MaxKVBytes    *int    `mapstructure:"max_kv_bytes"`
+	MaxIntentions *int    `mapstructure:"max_intentions"`
+	MaxACLTokens  *int    `mapstructure:"max_acl_tokens"`
+}
+
+type Redaction struct {
+	KVPrefixes        []string `mapstructure:"kv_prefixes"`
+	ConfigEntryFields []string `mapstructure:"config_entry_fields"`
+}
+
 type Segment struct {
 	Advertise   *string `mapstructure:"advertise"`
 	Bind        *string `mapstructure:"bind"`
 	Name        *string `mapstructure:"name"`
 	Port        *int    `mapstructure:"port"`
 	RPCListener *bool   `mapstructure:"rpc_listener"`
+
+	// Gossip overrides the gossip_lan settings for this segment.
+	Gossip GossipLANConfig `mapstructure:"gossip"`
 }
 
 type ACL struct {
@@ -771,6 +1022,8 @@ type ACL struct {
 	EnableKeyListPolicy    *bool   `mapstructure:"enable_key_list_policy"`
 	Tokens                 Tokens  `mapstructure:"tokens"`
 	EnableTokenPersistence *bool   `mapstructure:"enable_token_persistence"`
+	TokenUsageTracking     *bool   `mapstructure:"enable_token_usage_tracking"`
+	TokenUsageMaxTokens    *int    `mapstructure:"token_usage_max_tokens"`
 
 	// Enterprise Only
 	MSPDisableBootstrap *bool `mapstructure:"msp_disable_bootstrap"`
@@ -830,6 +1083,26 @@ type AuditSink struct {
 	RotateBytes       *int    `mapstructure:"rotate_bytes"`
 	RotateDuration    *string `mapstructure:"rotate_duration"`
 	RotateMaxFiles    *int    `mapstructure:"rotate_max_files"`
+	RotateCompress    *bool   `mapstructure:"rotate_compress"`
+}
+
+type GitOpsRaw struct {
+	Enabled       *bool   `mapstructure:"enabled"`
+	Provider      *string `mapstructure:"provider"`
+	WebhookSecret *string `mapstructure:"webhook_secret"`
+	Repository    *string `mapstructure:"repository"`
+	Branch        *string `mapstructure:"branch"`
+	Path          *string `mapstructure:"path"`
+	APIAddress    *string `mapstructure:"api_address"`
+	APIToken      *string `mapstructure:"api_token"`
+	ACLToken      *string `mapstructure:"acl_token"`
+}
+
+type NodeAttestationRaw struct {
+	Type       *string  `mapstructure:"type"`
+	TPMCommand []string `mapstructure:"tpm_command"`
+	AuthMethod *string  `mapstructure:"auth_method"`
+	Enforce    *bool    `mapstructure:"enforce"`
 }
 
 type AutoConfigRaw struct {
@@ -890,7 +1163,11 @@ type RawUIMetricsProxyAddHeader struct {
 }
 
 type RPC struct {
-	EnableStreaming *bool `mapstructure:"enable_streaming"`
+	EnableStreaming      *bool   `mapstructure:"enable_streaming"`
+	Compression          *string `mapstructure:"compression"`
+	CompressionThreshold *int    `mapstructure:"compression_threshold"`
+	CrossDCCacheSize     *int    `mapstructure:"cross_dc_cache_size"`
+	CrossDCCacheTTL      *string `mapstructure:"cross_dc_cache_ttl"`
 }
 
 type CloudConfigRaw struct {
@@ -902,6 +1179,12 @@ type CloudConfigRaw struct {
 	ScadaAddress *string `mapstructure:"scada_address"`
 }
 
+type EgressProxy struct {
+	HTTPProxy  *string  `mapstructure:"http_proxy" json:"http_proxy,omitempty"`
+	HTTPSProxy *string  `mapstructure:"https_proxy" json:"https_proxy,omitempty"`
+	NoProxy    []string `mapstructure:"no_proxy" json:"no_proxy,omitempty"`
+}
+
 type TLSProtocolConfig struct {
 	CAFile               *string `mapstructure:"ca_file" json:"ca_file,omitempty"`
 	CAPath               *string `mapstructure:"ca_path" json:"ca_path,omitempty"`
@@ -909,6 +1192,7 @@ type TLSProtocolConfig struct {
 	KeyFile              *string `mapstructure:"key_file" json:"key_file,omitempty"`
 	TLSMinVersion        *string `mapstructure:"tls_min_version" json:"tls_min_version,omitempty"`
 	TLSCipherSuites      *string `mapstructure:"tls_cipher_suites" json:"tls_cipher_suites,omitempty"`
+	TLSCurvePreferences  *string `mapstructure:"tls_curve_preferences" json:"tls_curve_preferences,omitempty"`
 	VerifyIncoming       *bool   `mapstructure:"verify_incoming" json:"verify_incoming,omitempty"`
 	VerifyOutgoing       *bool   `mapstructure:"verify_outgoing" json:"verify_outgoing,omitempty"`
 	VerifyServerHostname *bool   `mapstructure:"verify_server_hostname" json:"verify_server_hostname,omitempty"`
@@ -971,6 +1255,42 @@ type RaftWALConfigRaw struct {
 	SegmentSizeMB *int `mapstructure:"segment_size_mb" json:"segment_size_mb,omitempty"`
 }
 
+// RaftArchiveRaw configures the archive the leader ships the committed Raft
+// log entries to.
+type RaftArchiveRaw struct {
+	Types    []string `mapstructure:"types" json:"types,omitempty"`
+	Interval *string  `mapstructure:"interval" json:"interval,omitempty"`
+
+	File RaftArchiveFileRaw `mapstructure:"file" json:"file,omitempty"`
+
+	S3 RaftArchiveS3Raw `mapstructure:"s3" json:"s3,omitempty"`
+}
+
+type RaftArchiveFileRaw struct {
+	Path *string `mapstructure:"path" json:"path,omitempty"`
+}
+
+type RaftArchiveS3Raw struct {
+	Bucket   *string `mapstructure:"bucket" json:"bucket,omitempty"`
+	Prefix   *string `mapstructure:"prefix" json:"prefix,omitempty"`
+	Region   *string `mapstructure:"region" json:"region,omitempty"`
+	Endpoint *string `mapstructure:"endpoint" json:"endpoint,omitempty"`
+}
+
+// RaftSnapshotRaw throttles the Raft snapshots and sets the window of the day
+// during which they are preferably taken.
+type RaftSnapshotRaw struct {
+	IOLimitMB *int `mapstructure:"io_limit_mb" json:"io_limit_mb,omitempty"`
+
+	Window RaftSnapshotWindowRaw `mapstructure:"window" json:"window,omitempty"`
+}
+
+type RaftSnapshotWindowRaw struct {
+	Start         *string `mapstructure:"start" json:"start,omitempty"`
+	End           *string `mapstructure:"end" json:"end,omitempty"`
+	PeakThreshold *int    `mapstructure:"peak_threshold" json:"peak_threshold,omitempty"`
+}
+
 type License struct {
 	Enabled *bool `mapstructure:"enabled"`
 }
@@ -31,6 +31,7 @@ func DefaultSource() Source {
 			policy_ttl = "30s"
 			default_policy = "allow"
 			down_policy = "extend-cache"
+			token_usage_max_tokens = 10000
 		}
 		bind_addr = "0.0.0.0"
 		bootstrap = false
@@ -82,6 +83,7 @@ func DefaultSource() Source {
 			probe_interval = "` + serfLAN.ProbeInterval.String() + `"
 			probe_timeout = "` + serfLAN.ProbeTimeout.String() + `"
 			suspicion_mult = ` + strconv.Itoa(serfLAN.SuspicionMult) + `
+			transport = "udp"
 		}
 		gossip_wan = {
 			gossip_interval = "` + serfWAN.GossipInterval.String() + `"
@@ -90,6 +92,7 @@ func DefaultSource() Source {
 			probe_interval = "` + serfWAN.ProbeInterval.String() + `"
 			probe_timeout = "` + serfWAN.ProbeTimeout.String() + `"
 			suspicion_mult = ` + strconv.Itoa(serfWAN.SuspicionMult) + `
+			transport = "udp"
 		}
 		dns_config = {
 			allow_stale = true
@@ -215,6 +218,39 @@ func DevSource() Source {
 	}
 }
 
+// MaxDevPortOffset is the largest offset DevPortsSource accepts, which keeps
+// the highest default port in range.
+const MaxDevPortOffset = 65535 - 21755
+
+// DevPortsSource shifts the default ports of dev mode by the given offset.
+// This should be merged in the head after DevSource.
+func DevPortsSource(offset int) Source {
+	port := func(p int) *int {
+		p += offset
+		return &p
+	}
+	return LiteralSource{
+		Name: "dev-ports",
+		Config: Config{
+			Ports: Ports{
+				DNS:            port(8600),
+				HTTP:           port(8500),
+				GRPC:           port(8502),
+				GRPCTLS:        port(8503),
+				SerfLAN:        port(consul.DefaultLANSerfPort),
+				SerfWAN:        port(consul.DefaultWANSerfPort),
+				Server:         port(consul.DefaultRPCPort),
+				ProxyMinPort:   port(20000),
+				ProxyMaxPort:   port(20255),
+				SidecarMinPort: port(21000),
+				SidecarMaxPort: port(21255),
+				ExposeMinPort:  port(21500),
+				ExposeMaxPort:  port(21755),
+			},
+		},
+	}
+}
+
 // NonUserSource contains the values the user cannot configure.
 // This needs to be merged in the tail.
 // TODO: return a LiteralSource (no decoding) instead of a FileSource
@@ -47,6 +47,7 @@ func AddFlags(fs *flag.FlagSet, f *LoadOpts) {
 	add(&f.FlagValues.Datacenter, "datacenter", "Datacenter of the agent.")
 	add(&f.FlagValues.DefaultQueryTime, "default-query-time", "the amount of time a blocking query will wait before Consul will force a response. This value can be overridden by the 'wait' query parameter.")
 	add(&f.DevMode, "dev", "Starts the agent in development mode.")
+	add(&f.DevPortOffset, "dev-port-offset", "Shifts the default ports of the development mode by the given offset, so several dev agents can run side by side on ports known in advance.")
 	add(&f.FlagValues.DisableHostNodeID, "disable-host-node-id", "Setting this to true will prevent Consul from using information from the host to generate a node ID, and will cause Consul to generate a random node ID instead.")
 	add(&f.FlagValues.DisableKeyringFile, "disable-keyring-file", "Disables the backing up of the keyring to a file.")
 	add(&f.FlagValues.Ports.DNS, "dns-port", "DNS port to use.")
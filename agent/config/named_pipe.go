@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// namedPipePrefix is the prefix of the paths of the local named pipes.
+const namedPipePrefix = `\\.\pipe\`
+
+// NamedPipeAddr is the address of a Windows named pipe the HTTP or HTTPS API
+// listens on, like \\.\pipe\consul.
+type NamedPipeAddr struct {
+	Name string
+}
+
+func (a *NamedPipeAddr) Network() string { return "npipe" }
+
+func (a *NamedPipeAddr) String() string { return a.Name }
+
+// URL returns the address in the form used by the configuration and the API
+// clients, with forward slashes, like npipe:////./pipe/consul.
+func (a *NamedPipeAddr) URL() string {
+	return "npipe://" + strings.ReplaceAll(a.Name, `\`, "/")
+}
+
+// parseNamedPipeAddr parses the path of a named pipe address, written with
+// either forward slashes or backslashes.
+func parseNamedPipeAddr(path string) (*NamedPipeAddr, error) {
+	name := strings.ReplaceAll(path, "/", `\`)
+	if len(name) <= len(namedPipePrefix) || !strings.EqualFold(name[:len(namedPipePrefix)], namedPipePrefix) {
+		return nil, fmt.Errorf("named pipe address must be of the form npipe:////./pipe/<name>, got %q", "npipe://"+path)
+	}
+	return &NamedPipeAddr{Name: name}, nil
+}
+
+func isNamedPipeAddr(a net.Addr) bool {
+	_, ok := a.(*NamedPipeAddr)
+	return ok
+}
+
+// validSID matches the string form of a Windows security identifier, like
+// S-1-5-32-544, or the two letter SDDL alias of a well-known one, like BA.
+var validSID = regexp.MustCompile(`^(S-1-[0-9]+(-[0-9]+)+|[A-Z]{2})$`)
@@ -14,18 +14,31 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
 	"github.com/hashicorp/consul/agent/consul"
+	"github.com/hashicorp/consul/agent/consul/acme"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
 	consulrate "github.com/hashicorp/consul/agent/consul/rate"
 	hcpconfig "github.com/hashicorp/consul/agent/hcp/config"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/tlsutil"
 	"github.com/hashicorp/consul/types"
 )
 
+const (
+	// GossipTransportUDP sends the gossip packets over UDP.
+	GossipTransportUDP = "udp"
+
+	// GossipTransportTCP sends the gossip packets over TCP connections.
+	GossipTransportTCP = "tcp"
+)
+
 type RuntimeSOAConfig struct {
 	Refresh uint32 // 3600 by default
 	Retry   uint32 // 600
@@ -108,6 +121,23 @@ type RuntimeConfig struct {
 	// hcl: acl.token_replication = boolean
 	ACLTokenReplication bool
 
+	// ACLTokenUsageTracking enables the tracking of the usage of the tokens
+	// over the HTTP API, reported to the leader of the datacenter.
+	//
+	// hcl: acl.enable_token_usage_tracking = boolean
+	ACLTokenUsageTracking bool
+
+	// ACLTokenUsageMaxTokens is the maximum number of tokens whose usage is
+	// tracked. The least recently used tokens are evicted beyond it.
+	//
+	// hcl: acl.token_usage_max_tokens = int
+	ACLTokenUsageMaxTokens int
+
+	// AgentDefaultsLocalKeys are the keys of the settings of the
+	// agent-defaults config entry which are set by the local configuration.
+	// The local values take precedence over the ones of the config entry.
+	AgentDefaultsLocalKeys []string
+
 	// AutopilotCleanupDeadServers enables the automatic cleanup of dead servers when new ones
 	// are added to the peer list. Defaults to true.
 	//
@@ -224,6 +254,20 @@ type RuntimeConfig struct {
 	// hcl: dns_config { enable_truncate = (true|false) }
 	DNSEnableTruncate bool
 
+	// DNSHostResolverEnabled configures the resolver of the host to forward
+	// the queries for the DNS domains of the agent to its DNS server, and
+	// removes this configuration when the agent shuts down.
+	//
+	// hcl: dns_config { host_resolver { enabled = (true|false) } }
+	DNSHostResolverEnabled bool
+
+	// DNSHostResolverMode is the resolver of the host to configure:
+	// "systemd-resolved", "resolvconf", or "auto" to use systemd-resolved when
+	// it is running and resolvconf otherwise.
+	//
+	// hcl: dns_config { host_resolver { mode = ("auto"|"systemd-resolved"|"resolvconf") } }
+	DNSHostResolverMode string
+
 	// DNSMaxStale is used to bound how stale of a result is
 	// accepted for a DNS lookup. This can be used with
 	// AllowStale to limit how old of a value is served up.
@@ -261,6 +305,20 @@ type RuntimeConfig struct {
 	// hcl: dns_config { recursor_timeout = "duration" }
 	DNSRecursorTimeout time.Duration
 
+	// DNSRecursorCacheSize is the maximum number of responses of the
+	// recursors kept in memory to answer identical questions until their TTL
+	// expires. A value of 0 disables the cache.
+	//
+	// hcl: dns_config { recursor_cache_size = int }
+	DNSRecursorCacheSize int
+
+	// DNSRecursorCacheStaleIfError is how long a cached response of the
+	// recursors can still be served after it expired when none of the
+	// recursors answer. A value of 0 disables serving stale responses.
+	//
+	// hcl: dns_config { recursor_cache_stale_if_error = "duration" }
+	DNSRecursorCacheStaleIfError time.Duration
+
 	// DNSServiceTTL provides the TTL value for a service
 	// query for given service. The "*" wildcard can be used
 	// to set a default for all services.
@@ -325,6 +383,14 @@ type RuntimeConfig struct {
 	// hcl: http_config { response_headers = map[string]string }
 	HTTPResponseHeaders map[string]string
 
+	// HTTPClientCertAuthMethod is the name of an auth method of type
+	// tls-cert. HTTPS API requests without a token which present a verified
+	// client certificate are authorized with a token obtained by logging in
+	// to this auth method with the certificate.
+	//
+	// hcl: http_config { client_cert_auth_method = string }
+	HTTPClientCertAuthMethod string
+
 	// Embed Telemetry Config
 	Telemetry lib.TelemetryConfig
 
@@ -356,6 +422,20 @@ type RuntimeConfig struct {
 	// flag: -node string
 	NodeName string
 
+	// ACME configures the client the leader uses to obtain and renew the
+	// certificates of the API gateway listeners. It is only used by servers.
+	//
+	// hcl: acme {
+	//   enabled = (true|false)
+	//   directory_url = string
+	//   email = string
+	//   challenge = ("http-01"|"dns-01")
+	//   renew_before = "duration"
+	//   dns_provider = ("exec"|"rfc2136")
+	//   dns_provider_config = map[string]string
+	// }
+	ACME acme.Config
+
 	// AdvertiseAddrLAN is the address we use for advertising our Serf, and
 	// Consul RPC IP. The address can be specified as an ip address or as a
 	// go-sockaddr template which resolves to a single ip address. If not
@@ -404,6 +484,26 @@ type RuntimeConfig struct {
 	// Cache represent cache configuration of agent
 	Cache cache.Options
 
+	// CatalogProviders are the read-only external sources of service
+	// instances the leader periodically syncs into the catalog, each on a
+	// node dedicated to the provider. They are only used by servers.
+	//
+	// hcl: catalog_providers = [
+	//   {
+	//     name = string
+	//     type = ("aws-cloud-map"|"dns"|"file"|"etcd")
+	//     partition = string
+	//     namespace = string
+	//     sync_interval = "duration"
+	//     aws_cloud_map { namespace_id = string region = string endpoint = string }
+	//     dns { zone = string server = string tsig_key_name = string tsig_secret = string }
+	//     file { path = string }
+	//     etcd { address = string prefix = string username = string password = string }
+	//   },
+	//   ...
+	// ]
+	CatalogProviders []catalogprovider.Config
+
 	// CheckUpdateInterval controls the interval on which the output of a health check
 	// is updated if there is no change to the state. For example, a check in a steady
 	// state may run every 5 second generating a unique output (timestamp, etc), forcing
@@ -415,11 +515,52 @@ type RuntimeConfig struct {
 	// hcl: check_update_interval = "duration"
 	CheckUpdateInterval time.Duration
 
+	// CheckUpdateBatchInterval controls how long the leader batches the check
+	// updates of the agents before applying them in a single Raft transaction.
+	// Check updates are applied one by one if it is zero.
+	//
+	// hcl: performance { check_update_batch_interval = "duration" }
+	CheckUpdateBatchInterval time.Duration
+
+	// CheckFlapConsecutiveResults is the number of consecutive passing or
+	// failing results required before the status of a check changes, for the
+	// checks which set neither success_before_passing nor
+	// failures_before_critical.
+	//
+	// hcl: check_flap_detection { consecutive_results = int }
+	CheckFlapConsecutiveResults int
+
+	// CheckFlapWindowSize is the number of recent results of each check kept
+	// to detect flapping. Flap detection is disabled if it is zero.
+	//
+	// hcl: check_flap_detection { window_size = int }
+	CheckFlapWindowSize int
+
+	// CheckFlapMinRatio is the share of the results of the window which must
+	// agree with a new check status before it is published.
+	//
+	// hcl: check_flap_detection { min_ratio = float64 }
+	CheckFlapMinRatio float64
+
 	// Maximum size for the output of a healtcheck
 	// hcl check_output_max_size int
 	// flag: -check_output_max_size int
 	CheckOutputMaxSize int
 
+	// CheckPlugins are the external programs providing custom check types,
+	// which checks refer to by name with their plugin field. The agent
+	// launches them when their first check is registered.
+	//
+	// hcl: check_plugins = [
+	//   {
+	//     name = string
+	//     path = string
+	//     args = []string
+	//   },
+	//   ...
+	// ]
+	CheckPlugins []checkplugin.Config
+
 	// Checks contains the provided check definitions.
 	//
 	// hcl: checks = [
@@ -515,6 +656,13 @@ type RuntimeConfig struct {
 	// allocated to the agent for exposing checks through a proxy
 	ExposeMaxPort int
 
+	// ConnectAuthorizeLogSampleRate is the fraction, between 0 and 1, of the
+	// authorization decisions made by the agent's authorize endpoint which are
+	// logged. Zero disables the logging.
+	//
+	// hcl: connect { authorize_log_sample_rate = float64 }
+	ConnectAuthorizeLogSampleRate float64
+
 	// ConnectCAProvider is the type of CA provider to use with Connect.
 	ConnectCAProvider string
 
@@ -525,6 +673,42 @@ type RuntimeConfig struct {
 	// datacenters should exclusively traverse mesh gateways.
 	ConnectMeshGatewayWANFederationEnabled bool
 
+	// ConnectMeshGatewayWANAddressProvider is the cloud provider ("aws", "gcp"
+	// or "azure") whose instance metadata is queried for the public address
+	// of the instance. When set, the agent advertises this address as the WAN
+	// address of the mesh gateways registered with it, and updates their
+	// registration when it changes.
+	//
+	// hcl: connect { mesh_gateway_wan_address_provider = string }
+	ConnectMeshGatewayWANAddressProvider string
+
+	// ConnectMeshGatewayWANAddressPort is the port advertised along with the
+	// discovered WAN address, for when a load balancer maps a different port
+	// to the gateway. When zero the port of the existing WAN address, or else
+	// the port of the gateway, is kept.
+	//
+	// hcl: connect { mesh_gateway_wan_address_port = int }
+	ConnectMeshGatewayWANAddressPort int
+
+	// ConnectMeshGatewayWANAddressRefreshInterval is how often the instance
+	// metadata is queried for changes of the public address.
+	//
+	// hcl: connect { mesh_gateway_wan_address_refresh_interval = duration }
+	ConnectMeshGatewayWANAddressRefreshInterval time.Duration
+
+	// ConnectDisableExpiredIntentions makes the leader disable the intentions
+	// whose ExpiresAt time has passed. Otherwise they are only reported.
+	//
+	// hcl: connect { disable_expired_intentions = (true|false) }
+	ConnectDisableExpiredIntentions bool
+
+	// ConnectDisableIntentionsAfterReview makes the leader disable the
+	// intentions whose ReviewBy time has passed. Otherwise they are only
+	// reported.
+	//
+	// hcl: connect { disable_intentions_after_review = (true|false) }
+	ConnectDisableIntentionsAfterReview bool
+
 	// ConnectTestCALeafRootChangeSpread is used to control how long the CA leaf
 	// cache with spread CSRs over when a root change occurs. For now we don't
 	// expose this in public config intentionally but could later with a rename.
@@ -643,6 +827,14 @@ type RuntimeConfig struct {
 	// hcl: discard_check_output = (true|false)
 	DiscardCheckOutput bool
 
+	// EgressProxy configures the HTTP proxies used for requests to systems
+	// outside of the cluster, such as CA providers, OIDC identity providers and
+	// HCP. When nil the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables are used.
+	//
+	// hcl: egress_proxy { http_proxy = string https_proxy = string no_proxy = []string }
+	EgressProxy *egressproxy.Config
+
 	// EnableAgentTLSForChecks is used to apply the agent's TLS settings in
 	// order to configure the HTTP client used for health checks. Enabling
 	// this allows HTTP checks to present a client certificate and verify
@@ -683,6 +875,12 @@ type RuntimeConfig struct {
 	// flag: -encrypt string
 	EncryptKey string
 
+	// GitOps configures the webhook receiver which applies the config
+	// entries and resources stored in a Git repository when it is pushed to.
+	//
+	// hcl: gitops { ... }
+	GitOps GitOpsConfig
+
 	// GRPCPort is the port the gRPC server listens on. It is disabled by default.
 	//
 	// hcl: ports { grpc = int }
@@ -808,6 +1006,21 @@ type RuntimeConfig struct {
 	// hcl: limits { kv_max_value_size = uint64 }
 	KVMaxValueSize uint64
 
+	// KVEncryption configures the envelope encryption of KV values before
+	// they are written to Raft. The data encryption keys are wrapped by the
+	// configured key provider. Encryption is disabled if no provider is set.
+	//
+	// hcl: kv_encryption { provider = ("vault-transit"|"aws-kms"|"static") vault_transit { ... } aws_kms { ... } static { ... } }
+	KVEncryption kvencrypt.Config
+
+	// KVReplicationPrefixes are the KV prefixes mirrored from the primary
+	// datacenter by the leader of a secondary datacenter. The keys missing
+	// from the primary are deleted and the keys modified in the primary
+	// overwrite the local ones.
+	//
+	// hcl: kv_replication { prefixes = []string }
+	KVReplicationPrefixes []string
+
 	// LeaveDrainTime is used to wait after a server has left the LAN Serf
 	// pool for RPCs to drain and new requests to be sent to other servers.
 	//
@@ -833,6 +1046,14 @@ type RuntimeConfig struct {
 	// flags: -max-query-time string
 	MaxQueryTime time.Duration
 
+	// MetaIndexes limits the catalog indexes on node and service metadata to
+	// the declared keys. When a list is not set every key is indexed. Catalog
+	// queries filtering on keys which are not indexed scan the catalog. This
+	// is only used by servers.
+	//
+	// hcl: meta_indexes { node_meta_keys = []string service_meta_keys = []string }
+	MetaIndexes structs.MetaIndexConfig
+
 	// Node ID is a unique ID for this node across space and time. Defaults
 	// to a randomly-generated ID that persists in the data-dir.
 	//
@@ -842,6 +1063,13 @@ type RuntimeConfig struct {
 	// flag: -node-id string
 	NodeID types.NodeID
 
+	// NodeAttestation configures the attestation of the identity of the
+	// machine the agent runs on, and the verification of the attestation of
+	// the other agents by the servers.
+	//
+	// hcl: node_attestation { ... }
+	NodeAttestation NodeAttestationConfig
+
 	// NodeMeta contains metadata key/value pairs. These are excluded from JSON output
 	// because they can be reloaded and might be stale when shown from the
 	// config instead of the local state.
@@ -898,6 +1126,20 @@ type RuntimeConfig struct {
 	// hcl: primary_gateways_interval = "duration"
 	PrimaryGatewaysInterval time.Duration
 
+	// Quotas limits the number of objects that can be stored in each namespace
+	// (or partition). Writes which would exceed a quota are rejected by the
+	// servers. A limit of zero means unlimited, which is the default.
+	//
+	// hcl: quotas { max_services = int max_kv_bytes = int max_intentions = int max_acl_tokens = int overrides = [...] }
+	Quotas structs.QuotaConfig
+
+	// Redaction configures the KV values and config entry fields which are
+	// hidden from the list responses of the callers without secrets:read
+	// access. This is only used by servers.
+	//
+	// hcl: redaction { kv_prefixes = []string config_entry_fields = []string }
+	Redaction structs.RedactionConfig
+
 	// RPCAdvertiseAddr is the TCP address Consul advertises for its RPC endpoint.
 	// By default this is the bind address on the default RPC Server port. If the
 	// advertise address is specified then it is used.
@@ -1006,6 +1248,39 @@ type RuntimeConfig struct {
 
 	RaftLogStoreConfig consul.RaftLogStoreConfig
 
+	// RaftArchive configures the archive the leader ships the committed Raft
+	// log entries to. It is only used by servers.
+	//
+	// hcl: raft_archive {
+	//   types = []string
+	//   interval = "duration"
+	//   file {
+	//     path = string
+	//   }
+	//   s3 {
+	//     bucket = string
+	//     prefix = string
+	//     region = string
+	//     endpoint = string
+	//   }
+	// }
+	RaftArchive consul.RaftArchiveConfig
+
+	// RaftSnapshot throttles the I/O of the Raft snapshots and sets the window
+	// of the day, in UTC, during which they are preferably taken. Outside of
+	// the window the peak threshold is used instead of RaftSnapshotThreshold.
+	// It is only used by servers.
+	//
+	// hcl: raft_snapshot {
+	//   io_limit_mb = int
+	//   window {
+	//     start = "HH:MM"
+	//     end = "HH:MM"
+	//     peak_threshold = int
+	//   }
+	// }
+	RaftSnapshot consul.RaftSnapshotConfig
+
 	// ReconnectTimeoutLAN specifies the amount of time to wait to reconnect with
 	// another agent before deciding it's permanently gone. This can be used to
 	// control the time it takes to reap failed nodes from the cluster.
@@ -1282,6 +1557,17 @@ type RuntimeConfig struct {
 	// hcl: gossip_lan { retransmit_mult = int }
 	GossipLANRetransmitMult int
 
+	// GossipLANTransport is the transport used for LAN gossip packets. It is
+	// either "udp", or "tcp" to send the packets over TCP connections for
+	// networks where UDP is blocked. All the agents in a datacenter must use
+	// the same transport. This configuration only applies to LAN gossip
+	// communications, including network segments.
+	//
+	// The default is: udp
+	//
+	// hcl: gossip_lan { transport = string }
+	GossipLANTransport string
+
 	// GossipWANGossipInterval  is the interval between sending messages that need
 	// to be gossiped that haven't been able to piggyback on probing messages.
 	// If this is set to zero, non-piggyback gossip is disabled. By lowering
@@ -1359,6 +1645,17 @@ type RuntimeConfig struct {
 	// hcl: gossip_wan { retransmit_mult = int }
 	GossipWANRetransmitMult int
 
+	// GossipWANTransport is the transport used for WAN gossip packets. It is
+	// either "udp", or "tcp" to send the packets over TCP connections for
+	// networks where UDP is blocked. All the servers in the WAN pool must use
+	// the same transport. This configuration only applies to WAN gossip
+	// communications.
+	//
+	// The default is: udp
+	//
+	// hcl: gossip_wan { transport = string }
+	GossipWANTransport string
+
 	// ServerMode controls if this agent acts like a Consul server,
 	// or merely as a client. Servers have more state, take part
 	// in leader election, etc.
@@ -1448,6 +1745,13 @@ type RuntimeConfig struct {
 	// hcl: translate_wan_addrs = (true|false)
 	TranslateWANAddrs bool
 
+	// AddressFamilyPreference is the IP family, "ipv4" or "ipv6", of the
+	// address returned for the dual-stack nodes and services when a lookup
+	// accepts both families, like the DNS SRV and ANY queries.
+	//
+	// hcl: address_family_preference = ("ipv4"|"ipv6")
+	AddressFamilyPreference string
+
 	// TxnMaxReqLen configures the upper limit for the size (in bytes) of the
 	// incoming request bodies for transactions to the /txn endpoint.
 	//
@@ -1485,6 +1789,22 @@ type RuntimeConfig struct {
 	// hcl: unix_sockets { user = string }
 	UnixSocketUser string
 
+	// UnixSocketAuthMethod is the name of an auth method of type
+	// socket-cred. HTTP API requests without a token received on a UNIX
+	// socket are authorized with a token obtained by logging in to this auth
+	// method with the user and group of the client process.
+	//
+	// hcl: unix_sockets { auth_method = string }
+	UnixSocketAuthMethod string
+
+	// NamedPipeAllowedSIDs contains the security identifiers of the users
+	// and groups allowed to connect to the named pipes Consul listens on, in
+	// addition to the local system account and the administrators. Named
+	// pipes are only supported on Windows.
+	//
+	// hcl: named_pipes { allowed_sids = []string }
+	NamedPipeAllowedSIDs []string
+
 	StaticRuntimeConfig StaticRuntimeConfig
 
 	// Watches are used to monitor various endpoints and to invoke a
@@ -1501,6 +1821,41 @@ type RuntimeConfig struct {
 	//
 	Watches []map[string]interface{}
 
+	// Webhooks are the HTTP endpoints the leader posts the changes of the
+	// catalog and of the config entries to. They are only used by servers.
+	//
+	// hcl: webhooks = [
+	//   {
+	//     name = string
+	//     url = string
+	//     secret = string
+	//     events = []string
+	//     services = []string
+	//     timeout = "duration"
+	//     max_retries = int
+	//   },
+	//   ...
+	// ]
+	Webhooks []consul.WebhookConfig
+
+	// AdmissionWebhooks are the HTTP endpoints the servers call to validate,
+	// and optionally mutate, the catalog registrations and the config entries
+	// before they are written. They are only used by servers.
+	//
+	// hcl: admission_webhooks = [
+	//   {
+	//     name = string
+	//     url = string
+	//     secret = string
+	//     operations = []string
+	//     mutating = (true|false)
+	//     failure_policy = (fail-closed|fail-open)
+	//     timeout = "duration"
+	//   },
+	//   ...
+	// ]
+	AdmissionWebhooks []consul.AdmissionWebhookConfig
+
 	// XDSUpdateRateLimit controls the maximum rate at which proxy config updates
 	// will be delivered, across all connected xDS streams. This is used to stop
 	// updates to "global" resources (e.g. wildcard intentions) from saturating
@@ -1551,6 +1906,37 @@ type AutoConfigAuthorizer struct {
 	AllowReuse      bool
 }
 
+type GitOpsConfig struct {
+	Enabled       bool
+	Provider      string
+	WebhookSecret string
+	Repository    string
+	Branch        string
+	Path          string
+	APIAddress    string
+	APIToken      string
+	ACLToken      string
+}
+
+type NodeAttestationConfig struct {
+	// Type is the attestation evidence the agent collects and attaches to
+	// the registrations of its node, one of "aws-iid" or "tpm".
+	Type string
+
+	// TPMCommand is the command printing a quote of the TPM over the nonce
+	// passed as its last argument, for the "tpm" type.
+	TPMCommand []string
+
+	// AuthMethod is the node-attestation auth method the agent logs in to
+	// for its agent token when none is configured, and the servers verify
+	// the evidence of the registrations with.
+	AuthMethod string
+
+	// Enforce makes the servers reject the registrations of the agents of
+	// the LAN pool without valid attestation evidence.
+	Enforce bool
+}
+
 type UIConfig struct {
 	Enabled                    bool
 	Dir                        string
@@ -1594,6 +1980,8 @@ func (c *RuntimeConfig) apiAddresses(maxPerType int) (unixAddrs, httpAddrs, http
 					unixAddrs = append(unixAddrs, addr.String())
 					unix_count += 1
 				}
+			case *NamedPipeAddr:
+				// Named pipes are returned by ClientAddress separately.
 			default:
 				if maxPerType < 1 || http_count < maxPerType {
 					httpAddrs = append(httpAddrs, addr.String())
@@ -1611,6 +1999,14 @@ func (c *RuntimeConfig) ClientAddress() (unixAddr, httpAddr, httpsAddr string) {
 
 	if len(unixAddrs) > 0 {
 		unixAddr = "unix://" + unixAddrs[0]
+	} else {
+		// Named pipes are the Windows equivalent of unix sockets.
+		for _, addr := range c.HTTPAddrs {
+			if pipe, ok := addr.(*NamedPipeAddr); ok {
+				unixAddr = pipe.URL()
+				break
+			}
+		}
 	}
 
 	http_any := ""
@@ -1832,6 +2228,8 @@ func sanitize(name string, v reflect.Value) reflect.Value {
 			return reflect.ValueOf("udp://" + x.String())
 		case *net.UnixAddr:
 			return reflect.ValueOf("unix://" + x.String())
+		case *NamedPipeAddr:
+			return reflect.ValueOf(x.URL())
 		case *net.IPAddr:
 			return reflect.ValueOf(x.IP.String())
 		case *net.IPNet:
@@ -28,12 +28,18 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/cache"
 	"github.com/hashicorp/consul/agent/checks"
+	"github.com/hashicorp/consul/agent/checks/checkplugin"
 	"github.com/hashicorp/consul/agent/consul"
+	"github.com/hashicorp/consul/agent/consul/acme"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
 	consulrate "github.com/hashicorp/consul/agent/consul/rate"
 	hcpconfig "github.com/hashicorp/consul/agent/hcp/config"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/proto/private/prototest"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -585,6 +591,7 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		},
 		expected: func(rt *RuntimeConfig) {
 			rt.Logging.LogLevel = "a"
+			rt.AgentDefaultsLocalKeys = []string{"log_level"}
 			rt.DataDir = dataDir
 		},
 	})
@@ -737,6 +744,7 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		},
 		expected: func(rt *RuntimeConfig) {
 			rt.DNSRecursors = []string{"1.2.3.4", "5.6.7.8"}
+			rt.AgentDefaultsLocalKeys = []string{"recursors"}
 			rt.DataDir = dataDir
 		},
 	})
@@ -1411,6 +1419,216 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.DataDir = dataDir
 		},
 	})
+	run(t, testCase{
+		desc: "gossip tcp transport",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "gossip_lan": { "transport": "tcp" }, "gossip_wan": { "transport": "tcp" } }`},
+		hcl:  []string{`gossip_lan { transport = "tcp" } gossip_wan { transport = "tcp" }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.GossipLANTransport = "tcp"
+			rt.GossipWANTransport = "tcp"
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc:        "gossip invalid transport",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "gossip_lan": { "transport": "quic" } }`},
+		hcl:         []string{`gossip_lan { transport = "quic" }`},
+		expectedErr: `gossip_lan.transport must be "udp" or "tcp", got "quic"`,
+	})
+	run(t, testCase{
+		desc: "quotas",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "quotas": { "max_services": 10, "max_acl_tokens": 20, "overrides": [{ "namespace": "default", "max_kv_bytes": 1024 }] } }`},
+		hcl:  []string{`quotas { max_services = 10 max_acl_tokens = 20 overrides = [{ namespace = "default" max_kv_bytes = 1024 }] }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.Quotas = structs.QuotaConfig{
+				QuotaLimits: structs.QuotaLimits{MaxServices: 10, MaxACLTokens: 20},
+				Overrides: []structs.QuotaOverride{
+					{Namespace: "default", QuotaLimits: structs.QuotaLimits{MaxKVBytes: 1024}},
+				},
+			}
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc:        "quotas negative limit",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "quotas": { "overrides": [{ "namespace": "default", "max_intentions": -1 }] } }`},
+		hcl:         []string{`quotas { overrides = [{ namespace = "default" max_intentions = -1 }] }`},
+		expectedErr: `quotas.overrides[0].max_intentions cannot be negative`,
+	})
+	run(t, testCase{
+		desc: "kv_encryption static provider",
+		args: []string{`-data-dir=` + dataDir, `-server`},
+		json: []string{`{ "kv_encryption": { "provider": "static", "static": { "key": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=" } } }`},
+		hcl:  []string{`kv_encryption { provider = "static" static { key = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=" } }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.KVEncryption = kvencrypt.Config{
+				Provider: kvencrypt.ProviderStatic,
+				Static:   kvencrypt.StaticConfig{Key: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+			}
+			rt.DataDir = dataDir
+			rt.ServerMode = true
+			rt.TLS.ServerMode = true
+			rt.LeaveOnTerm = false
+			rt.SkipLeaveOnInt = true
+			rt.RPCConfig.EnableStreaming = true
+			rt.GRPCTLSPort = 8503
+			rt.GRPCTLSAddrs = []net.Addr{defaultGrpcTlsAddr}
+		},
+	})
+	run(t, testCase{
+		desc:        "kv_encryption unknown provider",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "kv_encryption": { "provider": "magic" } }`},
+		hcl:         []string{`kv_encryption { provider = "magic" }`},
+		expectedErr: `kv_encryption: unknown provider "magic"`,
+	})
+	run(t, testCase{
+		desc:        "kv_encryption vault-transit missing key name",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "kv_encryption": { "provider": "vault-transit", "vault_transit": { "address": "https://vault:8200", "token": "abc" } } }`},
+		hcl:         []string{`kv_encryption { provider = "vault-transit" vault_transit { address = "https://vault:8200" token = "abc" } }`},
+		expectedErr: `kv_encryption: invalid vault-transit provider config: key_name must be set`,
+	})
+	run(t, testCase{
+		desc:        "kv_replication empty prefix",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "kv_replication": { "prefixes": ["app/", ""] } }`},
+		hcl:         []string{`kv_replication { prefixes = ["app/", ""] }`},
+		expectedErr: `kv_replication.prefixes[1] cannot be empty`,
+	})
+	run(t, testCase{
+		desc:        "kv_replication overlapping prefixes",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "kv_replication": { "prefixes": ["app/", "app/web/"] } }`},
+		hcl:         []string{`kv_replication { prefixes = ["app/", "app/web/"] }`},
+		expectedErr: `kv_replication.prefixes[1] "app/web/" overlaps with "app/"`,
+	})
+	run(t, testCase{
+		desc: "redaction",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "acl": { "enabled": true }, "redaction": { "kv_prefixes": ["secret/"], "config_entry_fields": ["inline-certificate.PrivateKey"] } }`},
+		hcl:  []string{`acl { enabled = true } redaction { kv_prefixes = ["secret/"] config_entry_fields = ["inline-certificate.PrivateKey"] }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.ACLsEnabled = true
+			rt.Redaction = structs.RedactionConfig{
+				KVPrefixes:        []string{"secret/"},
+				ConfigEntryFields: []string{"inline-certificate.PrivateKey"},
+			}
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc: "redaction without ACLs",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "redaction": { "kv_prefixes": ["secret/"] } }`},
+		hcl:  []string{`redaction { kv_prefixes = ["secret/"] }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.Redaction = structs.RedactionConfig{KVPrefixes: []string{"secret/"}}
+			rt.DataDir = dataDir
+		},
+		expectedWarnings: []string{"redaction has no effect when ACLs are disabled, since every caller is allowed to read the hidden values"},
+	})
+	run(t, testCase{
+		desc:        "redaction unknown config entry field",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "redaction": { "config_entry_fields": ["inline-certificate.Password"] } }`},
+		hcl:         []string{`redaction { config_entry_fields = ["inline-certificate.Password"] }`},
+		expectedErr: `redaction.config_entry_fields[0]: invalid field "inline-certificate.Password" for inline-certificate: unknown field "Password"`,
+	})
+	run(t, testCase{
+		desc: "meta indexes",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "meta_indexes": { "node_meta_keys": ["rack"], "service_meta_keys": ["env", "team"] } }`},
+		hcl:  []string{`meta_indexes { node_meta_keys = ["rack"] service_meta_keys = ["env", "team"] }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.MetaIndexes = structs.MetaIndexConfig{
+				NodeKeys:    []string{"rack"},
+				ServiceKeys: []string{"env", "team"},
+			}
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc:        "meta indexes empty key",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "meta_indexes": { "service_meta_keys": ["env", ""] } }`},
+		hcl:         []string{`meta_indexes { service_meta_keys = ["env", ""] }`},
+		expectedErr: `meta_indexes.service_meta_keys[1] must not be empty`,
+	})
+	run(t, testCase{
+		desc:        "meta indexes duplicate key",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "meta_indexes": { "node_meta_keys": ["rack", "rack"] } }`},
+		hcl:         []string{`meta_indexes { node_meta_keys = ["rack", "rack"] }`},
+		expectedErr: `meta_indexes.node_meta_keys: duplicate key "rack"`,
+	})
+	run(t, testCase{
+		desc: "dns host resolver",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "dns_config": { "host_resolver": { "enabled": true, "mode": "systemd-resolved" } } }`},
+		hcl:  []string{`dns_config { host_resolver { enabled = true mode = "systemd-resolved" } }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DNSHostResolverEnabled = true
+			rt.DNSHostResolverMode = "systemd-resolved"
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc:        "dns host resolver invalid mode",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "dns_config": { "host_resolver": { "mode": "dnsmasq" } } }`},
+		hcl:         []string{`dns_config { host_resolver { mode = "dnsmasq" } }`},
+		expectedErr: `dns_config.host_resolver.mode must be one of ["auto" "systemd-resolved" "resolvconf"], got "dnsmasq"`,
+	})
+	run(t, testCase{
+		desc:        "dns host resolver without dns server",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "ports": { "dns": -1 }, "dns_config": { "host_resolver": { "enabled": true } } }`},
+		hcl:         []string{`ports { dns = -1 } dns_config { host_resolver { enabled = true } }`},
+		expectedErr: `dns_config.host_resolver requires the DNS server to be enabled`,
+	})
+	run(t, testCase{
+		desc:        "dns host resolver resolvconf requires port 53",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "dns_config": { "host_resolver": { "enabled": true, "mode": "resolvconf" } } }`},
+		hcl:         []string{`dns_config { host_resolver { enabled = true mode = "resolvconf" } }`},
+		expectedErr: `dns_config.host_resolver.mode = "resolvconf" requires ports.dns to be 53, got 8600`,
+	})
+	run(t, testCase{
+		desc: "dns host resolver resolvconf without recursors",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "ports": { "dns": 53 }, "dns_config": { "host_resolver": { "enabled": true, "mode": "resolvconf" } } }`},
+		hcl:  []string{`ports { dns = 53 } dns_config { host_resolver { enabled = true mode = "resolvconf" } }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DNSHostResolverEnabled = true
+			rt.DNSHostResolverMode = "resolvconf"
+			rt.DNSPort = 53
+			rt.DNSAddrs = []net.Addr{tcpAddr("127.0.0.1:53"), udpAddr("127.0.0.1:53")}
+			rt.DataDir = dataDir
+		},
+		expectedWarnings: []string{`dns_config.host_resolver.mode = "resolvconf" sends every DNS query of the host to the agent, configure recursors to resolve the other domains`},
+	})
+	run(t, testCase{
+		desc: "address family preference",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "address_family_preference": "ipv4" }`},
+		hcl:  []string{`address_family_preference = "ipv4"`},
+		expected: func(rt *RuntimeConfig) {
+			rt.AddressFamilyPreference = "ipv4"
+			rt.DataDir = dataDir
+		},
+	})
+	run(t, testCase{
+		desc:        "address family preference invalid",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "address_family_preference": "ipx" }`},
+		hcl:         []string{`address_family_preference = "ipx"`},
+		expectedErr: `address_family_preference must be "ipv4" or "ipv6", got "ipx"`,
+	})
 	run(t, testCase{
 		desc: "dns recursor templates with deduplication",
 		args: []string{`-data-dir=` + dataDir},
@@ -1418,6 +1636,7 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:  []string{`recursors = [ "{{ printf \"5.6.7.8:9999\" }}", "{{ printf \"1.2.3.4\" }}", "{{ printf \"5.6.7.8:9999\" }}" ] `},
 		expected: func(rt *RuntimeConfig) {
 			rt.DNSRecursors = []string{"5.6.7.8:9999", "1.2.3.4"}
+			rt.AgentDefaultsLocalKeys = []string{"recursors"}
 			rt.DataDir = dataDir
 		},
 	})
@@ -1603,6 +1822,7 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.Datacenter = "b"
 			rt.PrimaryDatacenter = "b"
 			rt.DNSRecursors = []string{"1.2.3.6", "5.6.7.10", "1.2.3.5", "5.6.7.9"}
+			rt.AgentDefaultsLocalKeys = []string{"recursors"}
 			rt.NodeMeta = map[string]string{"a": "c"}
 			rt.SerfBindAddrLAN = tcpAddr("3.3.3.3:8301")
 			rt.SerfBindAddrWAN = tcpAddr("4.4.4.4:8302")
@@ -1856,6 +2076,256 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:         []string{`autopilot = { max_trailing_logs = -1 }`},
 		expectedErr: "autopilot.max_trailing_logs cannot be -1. Must be greater than or equal to zero",
 	})
+	run(t, testCase{
+		desc: "check_flap_detection.min_ratio invalid",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "check_flap_detection": { "window_size": 5, "min_ratio": 1.5 } }`},
+		hcl:         []string{`check_flap_detection = { window_size = 5 min_ratio = 1.5 }`},
+		expectedErr: "check_flap_detection.min_ratio must be greater than 0 and at most 1 when check_flap_detection.window_size is set",
+	})
+	run(t, testCase{
+		desc: "webhooks invalid url",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "webhooks": [{ "url": "ftp://example.com" }] }`},
+		hcl:         []string{`webhooks = [{ url = "ftp://example.com" }]`},
+		expectedErr: `webhooks[0].url must be an http or https URL, got "ftp://example.com"`,
+	})
+	run(t, testCase{
+		desc: "raft_archive unknown type",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "raft_archive": { "types": ["Kv"], "file": { "path": "/tmp/archive" } } }`},
+		hcl:         []string{`raft_archive { types = ["Kv"] file { path = "/tmp/archive" } }`},
+		expectedErr: `raft_archive.types is invalid: unknown raft message type "Kv"`,
+	})
+	run(t, testCase{
+		desc: "raft_snapshot window default peak threshold",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+			`-server`,
+		},
+		json: []string{`{ "raft_snapshot_threshold": 1000, "raft_snapshot": { "window": { "start": "01:00", "end": "05:30" } } }`},
+		hcl:  []string{`raft_snapshot_threshold = 1000 raft_snapshot { window { start = "01:00" end = "05:30" } }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.Datacenter = "a"
+			rt.PrimaryDatacenter = "a"
+			rt.DataDir = dataDir
+			rt.ServerMode = true
+			rt.TLS.ServerMode = true
+			rt.LeaveOnTerm = false
+			rt.SkipLeaveOnInt = true
+			rt.RPCConfig.EnableStreaming = true
+			rt.GRPCTLSPort = 8503
+			rt.GRPCTLSAddrs = []net.Addr{defaultGrpcTlsAddr}
+			rt.RaftSnapshotThreshold = 1000
+			rt.RaftSnapshot = consul.RaftSnapshotConfig{
+				WindowStart:   1 * time.Hour,
+				WindowEnd:     5*time.Hour + 30*time.Minute,
+				PeakThreshold: 4000,
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "raft_snapshot window invalid time",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "raft_snapshot": { "window": { "start": "1am", "end": "05:00" } } }`},
+		hcl:         []string{`raft_snapshot { window { start = "1am" end = "05:00" } }`},
+		expectedErr: `raft_snapshot.window.start: invalid time of the day "1am", must be formatted as HH:MM`,
+	})
+	run(t, testCase{
+		desc: "raft_snapshot window low peak threshold",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "raft_snapshot_threshold": 1000, "raft_snapshot": { "window": { "start": "01:00", "end": "05:00", "peak_threshold": 500 } } }`},
+		hcl:         []string{`raft_snapshot_threshold = 1000 raft_snapshot { window { start = "01:00" end = "05:00" peak_threshold = 500 } }`},
+		expectedErr: `raft_snapshot.window.peak_threshold (500) cannot be lower than raft_snapshot_threshold (1000)`,
+	})
+	run(t, testCase{
+		desc: "rpc compression invalid algorithm",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "rpc": { "compression": "gzip" } }`},
+		hcl:         []string{`rpc { compression = "gzip" }`},
+		expectedErr: `rpc.compression: unknown compression algorithm "gzip", must be one of "none", "snappy" or "zstd"`,
+	})
+	run(t, testCase{
+		desc: "rpc compression negative threshold",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "rpc": { "compression": "snappy", "compression_threshold": -1 } }`},
+		hcl:         []string{`rpc { compression = "snappy" compression_threshold = -1 }`},
+		expectedErr: `rpc.compression_threshold cannot be negative`,
+	})
+	run(t, testCase{
+		desc: "rpc cross_dc_cache_size negative",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "rpc": { "cross_dc_cache_size": -1 } }`},
+		hcl:         []string{`rpc { cross_dc_cache_size = -1 }`},
+		expectedErr: `rpc.cross_dc_cache_size cannot be negative`,
+	})
+	run(t, testCase{
+		desc: "raft_archive several sinks",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "raft_archive": { "file": { "path": "/tmp/archive" }, "s3": { "bucket": "archive" } } }`},
+		hcl:         []string{`raft_archive { file { path = "/tmp/archive" } s3 { bucket = "archive" } }`},
+		expectedErr: `raft_archive.file and raft_archive.s3 cannot both be set`,
+	})
+	run(t, testCase{
+		desc: "acme dns-01 without provider",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "acme": { "enabled": true, "challenge": "dns-01" } }`},
+		hcl:         []string{`acme { enabled = true challenge = "dns-01" }`},
+		expectedErr: "acme.dns_provider is required when acme.challenge is dns-01",
+	})
+	run(t, testCase{
+		desc: "acme invalid dns provider config",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "acme": { "enabled": true, "challenge": "dns-01", "dns_provider": "exec" } }`},
+		hcl:         []string{`acme { enabled = true challenge = "dns-01" dns_provider = "exec" }`},
+		expectedErr: "acme.dns_provider_config is invalid: the exec provider requires a path",
+	})
+	run(t, testCase{
+		desc: "acme on client",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{ "acme": { "enabled": true } }`},
+		hcl:  []string{`acme { enabled = true }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.Datacenter = "a"
+			rt.PrimaryDatacenter = "a"
+			rt.ACME.Enabled = true
+		},
+		expectedWarnings: []string{"acme is only used by servers and will be ignored"},
+	})
+	run(t, testCase{
+		desc: "webhooks unknown event",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "webhooks": [{ "url": "https://example.com", "events": ["kv-changed"] }] }`},
+		hcl:         []string{`webhooks = [{ url = "https://example.com" events = ["kv-changed"] }]`},
+		expectedErr: `webhooks[0].events contains unknown event "kv-changed"`,
+	})
+	run(t, testCase{
+		desc: "check_plugins duplicate name",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "check_plugins": [{ "name": "jmx", "path": "/bin/a" }, { "name": "jmx", "path": "/bin/b" }] }`},
+		hcl:         []string{`check_plugins = [{ name = "jmx" path = "/bin/a" }, { name = "jmx" path = "/bin/b" }]`},
+		expectedErr: `check_plugins[1].name "jmx" is used by several check plugins`,
+	})
+	run(t, testCase{
+		desc: "check_plugins missing path",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "check_plugins": [{ "name": "jmx" }] }`},
+		hcl:         []string{`check_plugins = [{ name = "jmx" }]`},
+		expectedErr: `check_plugins[0].path is required`,
+	})
+	run(t, testCase{
+		desc: "catalog_providers duplicate name",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{ "catalog_providers": [
+			{ "name": "legacy", "type": "file", "file": { "path": "/a.json" } },
+			{ "name": "legacy", "type": "file", "file": { "path": "/b.json" } }
+		] }`},
+		hcl: []string{`catalog_providers = [
+			{ name = "legacy" type = "file" file { path = "/a.json" } },
+			{ name = "legacy" type = "file" file { path = "/b.json" } }
+		]`},
+		expectedErr: `catalog_providers[1].name "legacy" is used by several catalog providers`,
+	})
+	run(t, testCase{
+		desc: "catalog_providers invalid provider config",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "catalog_providers": [{ "name": "zone", "type": "dns", "dns": { "zone": "example.com" } }] }`},
+		hcl:         []string{`catalog_providers = [{ name = "zone" type = "dns" dns { zone = "example.com" } }]`},
+		expectedErr: `catalog_providers[0]: invalid dns provider config: server must be set`,
+	})
+	run(t, testCase{
+		desc: "admission_webhooks unknown operation",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "admission_webhooks": [{ "url": "https://example.com", "operations": ["kv-put"] }] }`},
+		hcl:         []string{`admission_webhooks = [{ url = "https://example.com" operations = ["kv-put"] }]`},
+		expectedErr: `admission_webhooks[0].operations contains unknown operation "kv-put"`,
+	})
+	run(t, testCase{
+		desc: "admission_webhooks invalid failure_policy",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "admission_webhooks": [{ "url": "https://example.com", "failure_policy": "ignore" }] }`},
+		hcl:         []string{`admission_webhooks = [{ url = "https://example.com" failure_policy = "ignore" }]`},
+		expectedErr: `admission_webhooks[0].failure_policy must be "fail-closed" or "fail-open", got "ignore"`,
+	})
+	run(t, testCase{
+		desc: "telemetry.envoy_metrics_allowlist without prometheus",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "telemetry": { "envoy_metrics_allowlist": ["envoy_cluster_"] } }`},
+		hcl:         []string{`telemetry = { envoy_metrics_allowlist = ["envoy_cluster_"] }`},
+		expectedErr: "telemetry.envoy_metrics_allowlist requires telemetry.prometheus_retention_time to be set",
+	})
+	run(t, testCase{
+		desc: "acl.token_usage_max_tokens not positive",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "acl": { "enable_token_usage_tracking": true, "token_usage_max_tokens": 0 } }`},
+		hcl:         []string{`acl = { enable_token_usage_tracking = true token_usage_max_tokens = 0 }`},
+		expectedErr: "acl.token_usage_max_tokens must be positive",
+	})
 	run(t, testCase{
 		desc:        "bind_addr cannot be empty",
 		args:        []string{`-data-dir=` + dataDir},
@@ -2028,14 +2498,197 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		expectedErr: `datacenter can only contain lowercase alphanumeric, - or _ characters.`,
 	})
 	run(t, testCase{
-		desc: "dns does not allow socket",
-		args: []string{
-			`-datacenter=a`,
-			`-data-dir=` + dataDir,
-		},
-		json:        []string{`{ "addresses": {"dns": "unix:///foo" } }`},
-		hcl:         []string{`addresses = { dns = "unix:///foo" }`},
-		expectedErr: "DNS address cannot be a unix socket",
+		desc: "dns does not allow socket",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "addresses": {"dns": "unix:///foo" } }`},
+		hcl:         []string{`addresses = { dns = "unix:///foo" }`},
+		expectedErr: "DNS address cannot be a unix socket",
+	})
+	run(t, testCase{
+		desc: "dns does not allow named pipe",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "addresses": {"dns": "npipe:////./pipe/consul" } }`},
+		hcl:         []string{`addresses = { dns = "npipe:////./pipe/consul" }`},
+		expectedErr: "DNS address cannot be a named pipe",
+	})
+	run(t, testCase{
+		desc: "grpc does not allow named pipe",
+		args: []string{
+			`-datacenter=a`,
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "addresses": {"grpc": "npipe:////./pipe/consul" }, "ports": { "grpc": 8502 } }`},
+		hcl:         []string{`addresses = { grpc = "npipe:////./pipe/consul" } ports = { grpc = 8502 }`},
+		expectedErr: "gRPC address cannot be a named pipe",
+	})
+	run(t, testCase{
+		desc: "http named pipe",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"addresses": { "http": "npipe:////./pipe/consul" },
+				"named_pipes": { "allowed_sids": ["S-1-5-32-545", "NS"] }
+			}`},
+		hcl: []string{`
+				addresses = { http = "npipe:////./pipe/consul" }
+				named_pipes = { allowed_sids = ["S-1-5-32-545", "NS"] }
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.HTTPAddrs = []net.Addr{&NamedPipeAddr{Name: `\\.\pipe\consul`}}
+			rt.NamedPipeAllowedSIDs = []string{"S-1-5-32-545", "NS"}
+		},
+	})
+	run(t, testCase{
+		desc:        "named pipe outside of the pipe namespace",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "addresses": { "http": "npipe:///tmp/consul" } }`},
+		hcl:         []string{`addresses = { http = "npipe:///tmp/consul" }`},
+		expectedErr: `addresses.http: named pipe address must be of the form npipe:////./pipe/<name>, got "npipe:///tmp/consul"`,
+	})
+	run(t, testCase{
+		desc:        "named_pipes invalid allowed_sids",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "named_pipes": { "allowed_sids": ["Users"] } }`},
+		hcl:         []string{`named_pipes = { allowed_sids = ["Users"] }`},
+		expectedErr: `named_pipes.allowed_sids: invalid SID "Users"`,
+	})
+	run(t, testCase{
+		desc: "named_pipes allowed_sids without named pipe address",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{ "named_pipes": { "allowed_sids": ["BU"] } }`},
+		hcl:  []string{`named_pipes = { allowed_sids = ["BU"] }`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.NamedPipeAllowedSIDs = []string{"BU"}
+		},
+		expectedWarnings: []string{
+			"named_pipes.allowed_sids has no effect unless addresses.http or addresses.https is a named pipe",
+		},
+	})
+	run(t, testCase{
+		desc: "gitops gitlab defaults",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"gitops": {
+					"enabled": true,
+					"provider": "gitlab",
+					"webhook_secret": "s3cr3t",
+					"repository": "platform/consul-config",
+					"path": "/entries/"
+				}
+			}`},
+		hcl: []string{`
+				gitops {
+					enabled = true
+					provider = "gitlab"
+					webhook_secret = "s3cr3t"
+					repository = "platform/consul-config"
+					path = "/entries/"
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.GitOps = GitOpsConfig{
+				Enabled:       true,
+				Provider:      "gitlab",
+				WebhookSecret: "s3cr3t",
+				Repository:    "platform/consul-config",
+				Branch:        "main",
+				Path:          "entries",
+				APIAddress:    "https://gitlab.com/api/v4",
+			}
+		},
+	})
+	run(t, testCase{
+		desc:        "gitops invalid provider",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "gitops": { "enabled": true, "provider": "bitbucket", "webhook_secret": "s", "repository": "a/b" } }`},
+		hcl:         []string{`gitops { enabled = true provider = "bitbucket" webhook_secret = "s" repository = "a/b" }`},
+		expectedErr: `gitops.provider must be one of "github" or "gitlab", got "bitbucket"`,
+	})
+	run(t, testCase{
+		desc:        "gitops without webhook_secret",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "gitops": { "enabled": true, "repository": "a/b" } }`},
+		hcl:         []string{`gitops { enabled = true repository = "a/b" }`},
+		expectedErr: "gitops.webhook_secret must be set when gitops.enabled is true",
+	})
+	run(t, testCase{
+		desc:        "gitops without repository",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "gitops": { "enabled": true, "webhook_secret": "s" } }`},
+		hcl:         []string{`gitops { enabled = true webhook_secret = "s" }`},
+		expectedErr: "gitops.repository must be set when gitops.enabled is true",
+	})
+	run(t, testCase{
+		desc: "node attestation",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"acl": { "enabled": true },
+				"node_attestation": {
+					"type": "tpm",
+					"tpm_command": ["/usr/local/bin/tpm-quote", "-c", "ak.ctx"],
+					"auth_method": "attested-nodes"
+				}
+			}`},
+		hcl: []string{`
+				acl { enabled = true }
+				node_attestation {
+					type = "tpm"
+					tpm_command = ["/usr/local/bin/tpm-quote", "-c", "ak.ctx"]
+					auth_method = "attested-nodes"
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ACLsEnabled = true
+			rt.NodeAttestation = NodeAttestationConfig{
+				Type:       "tpm",
+				TPMCommand: []string{"/usr/local/bin/tpm-quote", "-c", "ak.ctx"},
+				AuthMethod: "attested-nodes",
+			}
+		},
+	})
+	run(t, testCase{
+		desc:        "node attestation invalid type",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "node_attestation": { "type": "gcp" } }`},
+		hcl:         []string{`node_attestation { type = "gcp" }`},
+		expectedErr: `node_attestation.type must be one of ["aws-iid" "tpm"], got "gcp"`,
+	})
+	run(t, testCase{
+		desc:        "node attestation tpm without command",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "node_attestation": { "type": "tpm" } }`},
+		hcl:         []string{`node_attestation { type = "tpm" }`},
+		expectedErr: `node_attestation.tpm_command must be set when node_attestation.type is "tpm"`,
+	})
+	run(t, testCase{
+		desc:        "node attestation enforce on client",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "acl": { "enabled": true }, "node_attestation": { "type": "aws-iid", "auth_method": "nodes", "enforce": true } }`},
+		hcl:         []string{`acl { enabled = true } node_attestation { type = "aws-iid" auth_method = "nodes" enforce = true }`},
+		expectedErr: "node_attestation.enforce can only be set on servers",
+	})
+	run(t, testCase{
+		desc:        "node attestation auth method without acls",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "node_attestation": { "type": "aws-iid", "auth_method": "nodes" } }`},
+		hcl:         []string{`node_attestation { type = "aws-iid" auth_method = "nodes" }`},
+		expectedErr: "node_attestation.auth_method requires acl.enabled",
+	})
+	run(t, testCase{
+		desc:        "node attestation auth method with aws-iid",
+		args:        []string{`-data-dir=` + dataDir},
+		json:        []string{`{ "acl": { "enabled": true }, "node_attestation": { "type": "aws-iid", "auth_method": "nodes" } }`},
+		hcl:         []string{`acl { enabled = true } node_attestation { type = "aws-iid" auth_method = "nodes" }`},
+		expectedErr: `node_attestation.auth_method cannot be set when node_attestation.type is "aws-iid", the evidence cannot be used to log in`,
 	})
 	run(t, testCase{
 		desc: "ui enabled and dir specified",
@@ -2097,6 +2750,24 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 		hcl:         []string{`dns_config = { a_record_limit = -1 }`},
 		expectedErr: "dns_config.a_record_limit cannot be -1. Must be greater than or equal to zero",
 	})
+	run(t, testCase{
+		desc: "dns_config.recursor_cache_size invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "dns_config": { "recursor_cache_size": -1 } }`},
+		hcl:         []string{`dns_config = { recursor_cache_size = -1 }`},
+		expectedErr: "dns_config.recursor_cache_size cannot be -1. Must be greater than or equal to zero",
+	})
+	run(t, testCase{
+		desc: "dns_config.recursor_cache_stale_if_error invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json:        []string{`{ "dns_config": { "recursor_cache_stale_if_error": "-1s" } }`},
+		hcl:         []string{`dns_config = { recursor_cache_stale_if_error = "-1s" }`},
+		expectedErr: "dns_config.recursor_cache_stale_if_error cannot be -1s. Must be greater than or equal to zero",
+	})
 	run(t, testCase{
 		desc: "performance.raft_multiplier < 0",
 		args: []string{
@@ -2314,6 +2985,38 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.HTTPUseCache = false
 		},
 	})
+	run(t, testCase{
+		desc: "http client_cert_auth_method requires https verify_incoming",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"http_config": { "client_cert_auth_method": "certs" }
+			}`},
+		hcl: []string{`
+				http_config = { client_cert_auth_method = "certs" }
+			`},
+		expectedErr: "http_config.client_cert_auth_method requires tls.https.verify_incoming to be enabled",
+	})
+	run(t, testCase{
+		desc: "unix_sockets auth_method without unix socket address",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"unix_sockets": { "auth_method": "local" }
+			}`},
+		hcl: []string{`
+				unix_sockets = { auth_method = "local" }
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.UnixSocketAuthMethod = "local"
+		},
+		expectedWarnings: []string{
+			"unix_sockets.auth_method has no effect unless addresses.http or addresses.https is a Unix domain socket",
+		},
+	})
 	run(t, testCase{
 		desc: "cloud resource id from env",
 		args: []string{
@@ -3774,6 +4477,99 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			`},
 		expectedErr: "'retry_join_wan' is incompatible with 'connect.enable_mesh_gateway_wan_federation = true'",
 	})
+	run(t, testCase{
+		desc: "connect.authorize_log_sample_rate out of range",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+			  "connect": {
+				"authorize_log_sample_rate": 1.5
+			  }
+			}`},
+		hcl: []string{`
+			  connect {
+			    authorize_log_sample_rate = 1.5
+			  }
+			`},
+		expectedErr: "connect.authorize_log_sample_rate must be between 0 and 1, got 1.5",
+	})
+	run(t, testCase{
+		desc: "connect.mesh_gateway_wan_address_provider",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+			  "connect": {
+				"mesh_gateway_wan_address_provider": "gcp",
+				"mesh_gateway_wan_address_refresh_interval": "30s"
+			  }
+			}`},
+		hcl: []string{`
+			  connect {
+			    mesh_gateway_wan_address_provider = "gcp"
+			    mesh_gateway_wan_address_refresh_interval = "30s"
+			  }
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectMeshGatewayWANAddressProvider = "gcp"
+			rt.ConnectMeshGatewayWANAddressRefreshInterval = 30 * time.Second
+		},
+	})
+	run(t, testCase{
+		desc: "connect.mesh_gateway_wan_address_provider invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+			  "connect": {
+				"mesh_gateway_wan_address_provider": "digitalocean"
+			  }
+			}`},
+		hcl: []string{`
+			  connect {
+			    mesh_gateway_wan_address_provider = "digitalocean"
+			  }
+			`},
+		expectedErr: `connect.mesh_gateway_wan_address_provider must be one of aws, gcp, azure, got "digitalocean"`,
+	})
+	run(t, testCase{
+		desc: "connect.mesh_gateway_wan_address_provider requires connect",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+			  "connect": {
+				"enabled": false,
+				"mesh_gateway_wan_address_provider": "aws"
+			  }
+			}`},
+		hcl: []string{`
+			  connect {
+			    enabled = false
+			    mesh_gateway_wan_address_provider = "aws"
+			  }
+			`},
+		expectedErr: "'connect.mesh_gateway_wan_address_provider' requires 'connect.enabled = true'",
+	})
+	run(t, testCase{
+		desc: "connect.mesh_gateway_wan_address_port out of range",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+			  "connect": {
+				"mesh_gateway_wan_address_port": 70000
+			  }
+			}`},
+		hcl: []string{`
+			  connect {
+			    mesh_gateway_wan_address_port = 70000
+			  }
+			`},
+		expectedErr: "connect.mesh_gateway_wan_address_port must be between 0 and 65535, got 70000",
+	})
 	run(t, testCase{
 		desc: "connect.enable_mesh_gateway_wan_federation requires server mode",
 		args: []string{
@@ -5369,6 +6165,86 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.DataDir = dataDir
 		},
 	})
+	run(t, testCase{
+		desc: "egress_proxy",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"egress_proxy": {
+					"https_proxy": "proxy.example.com:3128",
+					"no_proxy": ["vault.internal"]
+				}
+			}`},
+		hcl: []string{`
+			egress_proxy {
+				https_proxy = "proxy.example.com:3128"
+				no_proxy = ["vault.internal"]
+			}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.EgressProxy = &egressproxy.Config{
+				HTTPSProxy: "proxy.example.com:3128",
+				NoProxy:    []string{"vault.internal"},
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "egress_proxy invalid scheme",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"egress_proxy": {
+					"http_proxy": "ftp://proxy.example.com"
+				}
+			}`},
+		hcl: []string{`
+			egress_proxy {
+				http_proxy = "ftp://proxy.example.com"
+			}
+			`},
+		expectedErr: `egress_proxy: invalid http_proxy: unsupported proxy scheme "ftp"`,
+	})
+	run(t, testCase{
+		desc: "logging kafka without topic",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"logging": {
+					"kafka": {
+						"brokers": ["kafka1:9092"]
+					}
+				}
+			}`},
+		hcl: []string{`
+			logging {
+				kafka {
+					brokers = ["kafka1:9092"]
+				}
+			}
+			`},
+		expectedErr: `logging.kafka.topic is required when logging.kafka.brokers is set`,
+	})
+	run(t, testCase{
+		desc: "logging syslog address without enable_syslog",
+		args: []string{`-data-dir=` + dataDir},
+		json: []string{`{
+				"logging": {
+					"syslog": {
+						"address": "syslog.example.com:6514"
+					}
+				}
+			}`},
+		hcl: []string{`
+			logging {
+				syslog {
+					address = "syslog.example.com:6514"
+				}
+			}
+			`},
+		expectedWarnings: []string{"logging.syslog.address has no effect unless enable_syslog is true"},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.Logging.SyslogAddress = "syslog.example.com:6514"
+		},
+	})
 	run(t, testCase{
 		desc: "metrics_provider constraint",
 		args: []string{`-data-dir=` + dataDir},
@@ -5784,6 +6660,70 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			rt.TLS.GRPC.VerifyIncoming = false
 		},
 	})
+	run(t, testCase{
+		desc: "tls_curve_preferences inherited from tls.defaults",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`
+			{
+				"tls": {
+					"defaults": {
+						"tls_curve_preferences": "P256, P384"
+					},
+					"https": {
+						"tls_curve_preferences": "P521"
+					}
+				}
+			}
+		`},
+		hcl: []string{`
+			tls {
+				defaults {
+					tls_curve_preferences = "P256, P384"
+				}
+				https {
+					tls_curve_preferences = "P521"
+				}
+			}
+		`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+
+			rt.TLS.Domain = "consul."
+			rt.TLS.NodeName = "thehostname"
+
+			rt.TLS.InternalRPC.TLSMinVersion = "TLSv1_2"
+			rt.TLS.InternalRPC.CurvePreferences = []types.TLSCurve{types.TLSCurveP256, types.TLSCurveP384}
+			rt.TLS.GRPC.TLSMinVersion = "TLSv1_2"
+			rt.TLS.GRPC.CurvePreferences = []types.TLSCurve{types.TLSCurveP256, types.TLSCurveP384}
+			rt.TLS.HTTPS.TLSMinVersion = "TLSv1_2"
+			rt.TLS.HTTPS.CurvePreferences = []types.TLSCurve{types.TLSCurveP521}
+		},
+	})
+	run(t, testCase{
+		desc: "tls_curve_preferences invalid",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`
+			{
+				"tls": {
+					"grpc": {
+						"tls_curve_preferences": "P256,P224"
+					}
+				}
+			}
+		`},
+		hcl: []string{`
+			tls {
+				grpc {
+					tls_curve_preferences = "P256,P224"
+				}
+			}
+		`},
+		expectedErr: "tls.grpc.tls_curve_preferences: invalid TLS curve preferences: no matching TLS curve found for P224, please specify one of [P256, P384, P521, X25519]",
+	})
 	run(t, testCase{
 		desc: "tls.internal_rpc.verify_server_hostname implies tls.internal_rpc.verify_outgoing",
 		args: []string{
@@ -6292,12 +7232,14 @@ func TestLoad_FullConfig(t *testing.T) {
 		GossipLANProbeTimeout:            102 * time.Millisecond,
 		GossipLANSuspicionMult:           1235,
 		GossipLANRetransmitMult:          1234,
+		GossipLANTransport:               "tcp",
 		GossipWANGossipInterval:          6966 * time.Second,
 		GossipWANGossipNodes:             2,
 		GossipWANProbeInterval:           103 * time.Millisecond,
 		GossipWANProbeTimeout:            104 * time.Millisecond,
 		GossipWANSuspicionMult:           16385,
 		GossipWANRetransmitMult:          16384,
+		GossipWANTransport:               "tcp",
 		ConsulServerHealthInterval:       2 * time.Second,
 
 		// user configurable values
@@ -6324,9 +7266,33 @@ func TestLoad_FullConfig(t *testing.T) {
 			ACLPolicyTTL:     1123 * time.Second,
 			ACLRoleTTL:       9876 * time.Second,
 		},
-		ACLEnableKeyListPolicy:           true,
-		ACLInitialManagementToken:        "3820e09a",
-		ACLTokenReplication:              true,
+		ACLEnableKeyListPolicy:    true,
+		ACLInitialManagementToken: "3820e09a",
+		ACLTokenReplication:       true,
+		ACLTokenUsageTracking:     true,
+		ACLTokenUsageMaxTokens:    4096,
+		AgentDefaultsLocalKeys: []string{
+			"log_level",
+			"recursors",
+			"telemetry.dogstatsd_addr",
+			"telemetry.dogstatsd_tags",
+			"telemetry.statsd_address",
+			"telemetry.statsite_address",
+		},
+		ACME: acme.Config{
+			Enabled:      true,
+			DirectoryURL: "https://Kd7sLq2m.example.com/directory",
+			Email:        "mX4pT9ra@example.com",
+			Challenge:    "dns-01",
+			RenewBefore:  1932 * time.Hour,
+			DNSProvider:  "rfc2136",
+			DNSProviderConfig: map[string]string{
+				"nameserver":  "10.33.8.1:53",
+				"zone":        "Wq3nB7yz.example.com",
+				"tsig_key":    "Pz6cV1ud",
+				"tsig_secret": "Ht2gN5ke",
+			},
+		},
 		AdvertiseAddrLAN:                 ipAddr("17.99.29.16"),
 		AdvertiseAddrWAN:                 ipAddr("78.63.37.19"),
 		AdvertiseReconnectTimeout:        0 * time.Second,
@@ -6344,6 +7310,14 @@ func TestLoad_FullConfig(t *testing.T) {
 			EntryFetchMaxBurst: 42,
 			EntryFetchRate:     0.334,
 		},
+		CatalogProviders: []catalogprovider.Config{
+			{
+				Name:         "jq8-mtz3",
+				Type:         "file",
+				SyncInterval: 3817 * time.Second,
+				File:         catalogprovider.FileConfig{Path: "/etc/Tn4wQz7b.json"},
+			},
+		},
 		CheckOutputMaxSize: checks.DefaultBufSize,
 		Checks: []*structs.CheckDefinition{
 			{
@@ -6428,6 +7402,8 @@ func TestLoad_FullConfig(t *testing.T) {
 				H2PING:                         "rQ8eyCSF",
 				H2PingUseTLS:                   false,
 				OSService:                      "aZaCAXww",
+				Plugin:                         "kD8fRn2w",
+				PluginConfig:                   map[string]string{"Qm4xTz7p": "Lb9vWc3s"},
 				Interval:                       18714 * time.Second,
 				DockerContainerID:              "qF66POS9",
 				Shell:                          "sOnDy228",
@@ -6437,8 +7413,19 @@ func TestLoad_FullConfig(t *testing.T) {
 				DeregisterCriticalServiceAfter: 13209 * time.Second,
 			},
 		},
-		CheckUpdateInterval: 16507 * time.Second,
-		ClientAddrs:         []*net.IPAddr{ipAddr("93.83.18.19")},
+		CheckFlapConsecutiveResults: 3,
+		CheckFlapWindowSize:         7,
+		CheckFlapMinRatio:           0.6,
+		CheckPlugins: []checkplugin.Config{
+			{
+				Name: "kD8fRn2w",
+				Path: "/usr/local/bin/Hy5nGq1e",
+				Args: []string{"Pz3mXc8r", "Wt6kBv2d"},
+			},
+		},
+		CheckUpdateInterval:      16507 * time.Second,
+		CheckUpdateBatchInterval: 44 * time.Millisecond,
+		ClientAddrs:              []*net.IPAddr{ipAddr("93.83.18.19")},
 		ConfigEntryBootstrap: []structs.ConfigEntry{
 			&structs.ProxyConfigEntry{
 				Kind:           structs.ProxyDefaults,
@@ -6492,12 +7479,13 @@ func TestLoad_FullConfig(t *testing.T) {
 				},
 			},
 		},
-		ConnectEnabled:        true,
-		ConnectSidecarMinPort: 8888,
-		ConnectSidecarMaxPort: 9999,
-		ExposeMinPort:         1111,
-		ExposeMaxPort:         2222,
-		ConnectCAProvider:     "consul",
+		ConnectEnabled:                true,
+		ConnectSidecarMinPort:         8888,
+		ConnectSidecarMaxPort:         9999,
+		ExposeMinPort:                 1111,
+		ExposeMaxPort:                 2222,
+		ConnectAuthorizeLogSampleRate: 0.25,
+		ConnectCAProvider:             "consul",
 		ConnectCAConfig: map[string]interface{}{
 			"IntermediateCertTTL": "8760h",
 			"LeafCertTTL":         "1h",
@@ -6505,7 +7493,12 @@ func TestLoad_FullConfig(t *testing.T) {
 			"CSRMaxPerSecond":     float64(100),
 			"CSRMaxConcurrent":    float64(2),
 		},
-		ConnectMeshGatewayWANFederationEnabled: false,
+		ConnectMeshGatewayWANFederationEnabled:      false,
+		ConnectMeshGatewayWANAddressProvider:        "aws",
+		ConnectMeshGatewayWANAddressPort:            8443,
+		ConnectMeshGatewayWANAddressRefreshInterval: 45 * time.Second,
+		ConnectDisableExpiredIntentions:             true,
+		ConnectDisableIntentionsAfterReview:         true,
 		Cloud: hcpconfig.CloudConfig{
 			ResourceID:   "N43DsscE",
 			ClientID:     "6WvsDZCP",
@@ -6523,12 +7516,16 @@ func TestLoad_FullConfig(t *testing.T) {
 		DNSDomain:                        "7W1xXSqd",
 		DNSAltDomain:                     "1789hsd",
 		DNSEnableTruncate:                true,
+		DNSHostResolverEnabled:           true,
+		DNSHostResolverMode:              "systemd-resolved",
 		DNSMaxStale:                      29685 * time.Second,
 		DNSNodeTTL:                       7084 * time.Second,
 		DNSOnlyPassing:                   true,
 		DNSPort:                          7001,
 		DNSRecursorStrategy:              "sequential",
 		DNSRecursorTimeout:               4427 * time.Second,
+		DNSRecursorCacheSize:             17193,
+		DNSRecursorCacheStaleIfError:     2903 * time.Second,
 		DNSRecursors:                     []string{"63.38.39.58", "92.49.18.18"},
 		DNSSOA:                           RuntimeSOAConfig{Refresh: 3600, Retry: 600, Expire: 86400, Minttl: 0},
 		DNSServiceTTL:                    map[string]time.Duration{"*": 32030 * time.Second},
@@ -6548,36 +7545,78 @@ func TestLoad_FullConfig(t *testing.T) {
 		DisableUpdateCheck:               true,
 		DiscardCheckOutput:               true,
 		DiscoveryMaxStale:                5 * time.Second,
-		EnableAgentTLSForChecks:          true,
-		EnableCentralServiceConfig:       false,
-		EnableDebug:                      true,
-		EnableRemoteScriptChecks:         true,
-		EnableLocalScriptChecks:          true,
-		EncryptKey:                       "A4wELWqH",
-		Experiments:                      []string{"foo"},
+		EgressProxy: &egressproxy.Config{
+			HTTPProxy:  "http://10.4.5.6:3128",
+			HTTPSProxy: "http://10.4.5.7:3129",
+			NoProxy:    []string{"10.0.0.0/8", ".consul.internal"},
+		},
+		EnableAgentTLSForChecks:    true,
+		EnableCentralServiceConfig: false,
+		EnableDebug:                true,
+		EnableRemoteScriptChecks:   true,
+		EnableLocalScriptChecks:    true,
+		EncryptKey:                 "A4wELWqH",
+		Experiments:                []string{"foo"},
+		GitOps: GitOpsConfig{
+			Enabled:       true,
+			Provider:      "github",
+			WebhookSecret: "Zq3vTmb8",
+			Repository:    "acme/consul-config",
+			Branch:        "release",
+			Path:          "consul/entries",
+			APIAddress:    "https://github.example.com/api/v3",
+			APIToken:      "vD8wq1Lz",
+			ACLToken:      "5a4fd1d5-c2b6-4f2d-9e2c-3b9f0a1e7c44",
+		},
 		StaticRuntimeConfig: StaticRuntimeConfig{
 			EncryptVerifyIncoming: true,
 			EncryptVerifyOutgoing: true,
 		},
 
-		GRPCPort:              4881,
-		GRPCAddrs:             []net.Addr{tcpAddr("32.31.61.91:4881")},
-		GRPCTLSPort:           5201,
-		GRPCTLSAddrs:          []net.Addr{tcpAddr("23.14.88.19:5201")},
-		GRPCKeepaliveInterval: 33 * time.Second,
-		GRPCKeepaliveTimeout:  22 * time.Second,
-		HTTPAddrs:             []net.Addr{tcpAddr("83.39.91.39:7999")},
-		HTTPBlockEndpoints:    []string{"RBvAFcGD", "fWOWFznh"},
-		AllowWriteHTTPFrom:    []*net.IPNet{cidr("127.0.0.0/8"), cidr("22.33.44.55/32"), cidr("0.0.0.0/0")},
-		HTTPPort:              7999,
-		HTTPResponseHeaders:   map[string]string{"M6TKa9NP": "xjuxjOzQ", "JRCrHZed": "rl0mTx81"},
-		HTTPSAddrs:            []net.Addr{tcpAddr("95.17.17.19:15127")},
-		HTTPMaxConnsPerClient: 100,
-		HTTPMaxHeaderBytes:    10,
-		HTTPSHandshakeTimeout: 2391 * time.Millisecond,
-		HTTPSPort:             15127,
-		HTTPUseCache:          false,
-		KVMaxValueSize:        1234567800,
+		GRPCPort:                 4881,
+		GRPCAddrs:                []net.Addr{tcpAddr("32.31.61.91:4881")},
+		GRPCTLSPort:              5201,
+		GRPCTLSAddrs:             []net.Addr{tcpAddr("23.14.88.19:5201")},
+		GRPCKeepaliveInterval:    33 * time.Second,
+		GRPCKeepaliveTimeout:     22 * time.Second,
+		HTTPAddrs:                []net.Addr{tcpAddr("83.39.91.39:7999")},
+		HTTPBlockEndpoints:       []string{"RBvAFcGD", "fWOWFznh"},
+		HTTPClientCertAuthMethod: "pW2YtBxN",
+		AllowWriteHTTPFrom:       []*net.IPNet{cidr("127.0.0.0/8"), cidr("22.33.44.55/32"), cidr("0.0.0.0/0")},
+		HTTPPort:                 7999,
+		HTTPResponseHeaders:      map[string]string{"M6TKa9NP": "xjuxjOzQ", "JRCrHZed": "rl0mTx81"},
+		HTTPSAddrs:               []net.Addr{tcpAddr("95.17.17.19:15127")},
+		HTTPMaxConnsPerClient:    100,
+		HTTPMaxHeaderBytes:       10,
+		HTTPSHandshakeTimeout:    2391 * time.Millisecond,
+		HTTPSPort:                15127,
+		HTTPUseCache:             false,
+		KVMaxValueSize:           1234567800,
+		KVEncryption: kvencrypt.Config{
+			Provider: kvencrypt.ProviderVaultTransit,
+			VaultTransit: kvencrypt.VaultTransitConfig{
+				Address:       "https://vault.example.com:8200",
+				Token:         "Iegh3gee",
+				Namespace:     "oot0Pheu",
+				MountPath:     "ohw4Ahwa",
+				KeyName:       "eiNg3ahv",
+				CAFile:        "Ua9ohjie",
+				CAPath:        "vie0Ahgh",
+				CertFile:      "Aegh0ooz",
+				KeyFile:       "Chei6aeT",
+				TLSServerName: "Xa7Eeceb",
+				TLSSkipVerify: true,
+			},
+			AWSKMS: kvencrypt.AWSKMSConfig{
+				KeyID:    "alias/Eeb1aiho",
+				Region:   "eu-west-3",
+				Endpoint: "https://kms.example.com",
+			},
+			Static: kvencrypt.StaticConfig{
+				Key: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			},
+		},
+		KVReplicationPrefixes: []string{"Ohf2ooth/", "feiRah4u/"},
 		LeaveDrainTime:        8265 * time.Second,
 		LeaveOnTerm:           true,
 		Locality: &Locality{
@@ -6589,8 +7628,27 @@ func TestLoad_FullConfig(t *testing.T) {
 			LogJSON:        true,
 			EnableSyslog:   true,
 			SyslogFacility: "hHv79Uia",
+			SyslogAddress:  "syslog.example.com:6514",
+			SyslogTLS: logging.TLSConfig{
+				Enabled:    true,
+				CAFile:     "Kee9Ahsh",
+				CertFile:   "ieV4eiwa",
+				KeyFile:    "Ush5eeph",
+				ServerName: "syslog.example.com",
+			},
+			KafkaBrokers: []string{"kafka1:9093", "kafka2:9093"},
+			KafkaTopic:   "ooNg3eth",
+			KafkaTLS: logging.TLSConfig{
+				Enabled:            true,
+				InsecureSkipVerify: true,
+			},
+			LogRotateCompress: true,
+		},
+		MaxQueryTime: 18237 * time.Second,
+		MetaIndexes: structs.MetaIndexConfig{
+			NodeKeys:    []string{"rack"},
+			ServiceKeys: []string{"env", "team"},
 		},
-		MaxQueryTime:            18237 * time.Second,
 		NodeID:                  types.NodeID("AsUIlw99"),
 		NodeMeta:                map[string]string{"5mgGQMBk": "mJLtVMSG", "A7ynFMJB": "0Nx6RGab"},
 		NodeName:                "otlLxGaI",
@@ -6599,6 +7657,27 @@ func TestLoad_FullConfig(t *testing.T) {
 		PidFile:                 "43xN80Km",
 		PrimaryGateways:         []string{"aej8eeZo", "roh2KahS"},
 		PrimaryGatewaysInterval: 18866 * time.Second,
+		Quotas: structs.QuotaConfig{
+			QuotaLimits: structs.QuotaLimits{
+				MaxServices:   3920,
+				MaxKVBytes:    81920,
+				MaxIntentions: 2870,
+				MaxACLTokens:  4410,
+			},
+			Overrides: []structs.QuotaOverride{
+				{
+					Namespace: "default",
+					QuotaLimits: structs.QuotaLimits{
+						MaxServices: 6470,
+						MaxKVBytes:  36330,
+					},
+				},
+			},
+		},
+		Redaction: structs.RedactionConfig{
+			KVPrefixes:        []string{"vault/", "secret/"},
+			ConfigEntryFields: []string{"inline-certificate.PrivateKey", "service-defaults.Meta.password"},
+		},
 		RPCAdvertiseAddr:        tcpAddr("17.99.29.16:3757"),
 		RPCBindAddr:             tcpAddr("16.99.34.17:3757"),
 		RPCHandshakeTimeout:     1932 * time.Millisecond,
@@ -6624,14 +7703,34 @@ func TestLoad_FullConfig(t *testing.T) {
 		RetryJoinMaxAttemptsLAN: 913,
 		RetryJoinMaxAttemptsWAN: 23160,
 		RetryJoinWAN:            []string{"PFsR02Ye", "rJdQIhER", "EbFSc3nA", "kwXTh623"},
-		RPCConfig:               consul.RPCConfig{EnableStreaming: true},
-		SegmentLimit:            123,
-		SerfPortLAN:             8301,
-		SerfPortWAN:             8302,
-		ServerMode:              true,
-		ServerName:              "Oerr9n1G",
-		ServerRejoinAgeMax:      604800 * time.Second,
-		ServerPort:              3757,
+		RPCConfig: consul.RPCConfig{
+			EnableStreaming:      true,
+			Compression:          compression.Zstd,
+			CompressionThreshold: 2048,
+			CrossDCCacheSize:     4096,
+			CrossDCCacheTTL:      7 * time.Second,
+		},
+		SegmentLimit: 123,
+		Segments: []structs.NetworkSegment{
+			{
+				Name:           "jAJQ7WC9",
+				Bind:           tcpAddr("97.32.44.81:8306"),
+				Advertise:      tcpAddr("45.12.83.20:8306"),
+				RPCListener:    true,
+				GossipNodes:    5,
+				GossipInterval: 51 * time.Millisecond,
+				ProbeInterval:  3 * time.Second,
+				ProbeTimeout:   900 * time.Millisecond,
+				SuspicionMult:  7,
+				RetransmitMult: 4,
+			},
+		},
+		SerfPortLAN:        8301,
+		SerfPortWAN:        8302,
+		ServerMode:         true,
+		ServerName:         "Oerr9n1G",
+		ServerRejoinAgeMax: 604800 * time.Second,
+		ServerPort:         3757,
 		Services: []*structs.ServiceDefinition{
 			{
 				ID:      "wI1dzxS4",
@@ -6976,6 +8075,8 @@ func TestLoad_FullConfig(t *testing.T) {
 			},
 			EnableHostMetrics:             true,
 			DisablePerTenancyUsageMetrics: true,
+			EnvoyMetricsAllowlist:         []string{"envoy_cluster_upstream_rq", "envoy_http_downstream_rq"},
+			EnvoyMetricsScrapeInterval:    45 * time.Second,
 		},
 		TLS: tlsutil.Config{
 			InternalRPC: tlsutil.ProtocolConfig{
@@ -6986,28 +8087,31 @@ func TestLoad_FullConfig(t *testing.T) {
 				KeyFile:              "aL1Knkpo",
 				TLSMinVersion:        types.TLSv1_1,
 				CipherSuites:         []types.TLSCipherSuite{types.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256, types.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA},
+				CurvePreferences:     []types.TLSCurve{types.TLSCurveX25519, types.TLSCurveP256},
 				VerifyOutgoing:       true,
 				VerifyServerHostname: true,
 			},
 			GRPC: tlsutil.ProtocolConfig{
-				VerifyIncoming: true,
-				CAFile:         "lOp1nhJk",
-				CAPath:         "fLponKpl",
-				CertFile:       "a674klPn",
-				KeyFile:        "1y4prKjl",
-				TLSMinVersion:  types.TLSv1_0,
-				CipherSuites:   []types.TLSCipherSuite{types.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256, types.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA},
-				VerifyOutgoing: false,
-				UseAutoCert:    true,
+				VerifyIncoming:   true,
+				CAFile:           "lOp1nhJk",
+				CAPath:           "fLponKpl",
+				CertFile:         "a674klPn",
+				KeyFile:          "1y4prKjl",
+				TLSMinVersion:    types.TLSv1_0,
+				CipherSuites:     []types.TLSCipherSuite{types.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256, types.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA},
+				CurvePreferences: []types.TLSCurve{types.TLSCurveX25519, types.TLSCurveP256},
+				VerifyOutgoing:   false,
+				UseAutoCert:      true,
 			},
 			HTTPS: tlsutil.ProtocolConfig{
-				VerifyIncoming: true,
-				CAFile:         "7Yu1PolM",
-				CAPath:         "nu4PlHzn",
-				CertFile:       "1yrhPlMk",
-				KeyFile:        "1bHapOkL",
-				TLSMinVersion:  types.TLSv1_3,
-				VerifyOutgoing: true,
+				VerifyIncoming:   true,
+				CAFile:           "7Yu1PolM",
+				CAPath:           "nu4PlHzn",
+				CertFile:         "1yrhPlMk",
+				KeyFile:          "1bHapOkL",
+				TLSMinVersion:    types.TLSv1_3,
+				CurvePreferences: []types.TLSCurve{types.TLSCurveP384},
+				VerifyOutgoing:   true,
 			},
 			NodeName:                "otlLxGaI",
 			ServerName:              "Oerr9n1G",
@@ -7023,8 +8127,9 @@ func TestLoad_FullConfig(t *testing.T) {
 			"wan":      "78.63.37.19",
 			"wan_ipv4": "78.63.37.19",
 		},
-		TranslateWANAddrs: true,
-		TxnMaxReqLen:      567800000,
+		TranslateWANAddrs:       true,
+		AddressFamilyPreference: "ipv4",
+		TxnMaxReqLen:            567800000,
 		UIConfig: UIConfig{
 			Dir:                        "pVncV4Ey",
 			ContentPath:                "/qp1WRhYH/", // slashes are added in parsing
@@ -7043,9 +8148,11 @@ func TestLoad_FullConfig(t *testing.T) {
 			},
 			DashboardURLTemplates: map[string]string{"u2eziu2n_lower_case": "http://lkjasd.otr"},
 		},
-		UnixSocketUser:  "E0nB1DwA",
-		UnixSocketGroup: "8pFodrV8",
-		UnixSocketMode:  "E8sAwOv4",
+		UnixSocketUser:       "E0nB1DwA",
+		UnixSocketGroup:      "8pFodrV8",
+		UnixSocketMode:       "E8sAwOv4",
+		UnixSocketAuthMethod: "u4bRjVe7",
+		NamedPipeAllowedSIDs: []string{"S-1-5-21-1004336348-1177238915-682003330-512"},
 		Watches: []map[string]interface{}{
 			{
 				"type":       "key",
@@ -7060,6 +8167,28 @@ func TestLoad_FullConfig(t *testing.T) {
 				"args":       []interface{}{"dltjDJ2a", "flEa7C2d"},
 			},
 		},
+		Webhooks: []consul.WebhookConfig{
+			{
+				Name:       "Dc7gYL2u",
+				URL:        "https://bjH2xa9p.example.com/hook",
+				Secret:     "kL3vQ8sd",
+				Events:     []string{"service-registered", "health-changed"},
+				Services:   []string{"Rq5mTz0w"},
+				Timeout:    3651 * time.Second,
+				MaxRetries: 7,
+			},
+		},
+		AdmissionWebhooks: []consul.AdmissionWebhookConfig{
+			{
+				Name:          "Xw8nPq2e",
+				URL:           "https://tR4kLm9s.example.com/admit",
+				Secret:        "Vb6hJy3c",
+				Operations:    []string{"catalog-register", "config-entry-upsert"},
+				Mutating:      true,
+				FailurePolicy: "fail-open",
+				Timeout:       2347 * time.Second,
+			},
+		},
 		XDSUpdateRateLimit: 9526.2,
 		RaftLogStoreConfig: consul.RaftLogStoreConfig{
 			Backend:         consul.LogStoreBackendWAL,
@@ -7071,6 +8200,20 @@ func TestLoad_FullConfig(t *testing.T) {
 			BoltDB: consul.RaftBoltDBConfig{NoFreelistSync: true},
 			WAL:    consul.WALConfig{SegmentSize: 15 * 1024 * 1024},
 		},
+		RaftArchive: consul.RaftArchiveConfig{
+			Types:      []string{"KVS", "ConfigEntry"},
+			Interval:   4321 * time.Second,
+			S3Bucket:   "Xr7gPn2c",
+			S3Prefix:   "archive/Wq4v",
+			S3Region:   "us-west-2",
+			S3Endpoint: "https://Hs8kLm3d.example.com",
+		},
+		RaftSnapshot: consul.RaftSnapshotConfig{
+			IOLimit:       25 * 1024 * 1024,
+			WindowStart:   22*time.Hour + 30*time.Minute,
+			WindowEnd:     4*time.Hour + 15*time.Minute,
+			PeakThreshold: 65536,
+		},
 		AutoReloadConfigCoalesceInterval: 1 * time.Second,
 	}
 	entFullRuntimeConfig(expected)
@@ -7104,6 +8247,8 @@ func TestLoad_FullConfig(t *testing.T) {
 		"The 'tls_prefer_server_cipher_suites' field is deprecated and will be ignored.",
 		deprecationWarning("start_join", "retry_join"),
 		deprecationWarning("start_join_wan", "retry_join_wan"),
+		"unix_sockets.auth_method has no effect unless addresses.http or addresses.https is a Unix domain socket",
+		"named_pipes.allowed_sids has no effect unless addresses.http or addresses.https is a named pipe",
 	}
 	expectedWarns = append(expectedWarns, enterpriseConfigKeyWarnings...)
 
@@ -7562,6 +8707,24 @@ func TestRuntime_ClientAddress(t *testing.T) {
 	require.Equal(t, "198.18.0.1:5689", https)
 }
 
+func TestRuntime_ClientAddressNamedPipe(t *testing.T) {
+	rt := RuntimeConfig{
+		HTTPAddrs: []net.Addr{
+			&NamedPipeAddr{Name: `\\.\pipe\consul`},
+		},
+	}
+
+	pipe, http, https := rt.ClientAddress()
+
+	require.Equal(t, "npipe:////./pipe/consul", pipe)
+	require.Equal(t, "", http)
+	require.Equal(t, "", https)
+
+	cfg, err := rt.APIConfig(false)
+	require.NoError(t, err)
+	require.Equal(t, "npipe:////./pipe/consul", cfg.Address)
+}
+
 func TestRuntime_ClientAddressAnyV4(t *testing.T) {
 	rt := RuntimeConfig{
 		HTTPAddrs: []net.Addr{
@@ -6,15 +6,59 @@
 package config
 
 import (
-	"github.com/hashicorp/consul/agent/structs"
+	"fmt"
+	"regexp"
 )
 
+// reSegmentName matches the valid names of network segments. The names are
+// used in Serf tags and in the names of the snapshot files.
+var reSegmentName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 func (b *builder) validateSegments(rt RuntimeConfig) error {
+	if rt.ServerMode && rt.SegmentName != "" {
+		return fmt.Errorf("Segment option can only be set on clients")
+	}
+	if !rt.ServerMode && len(rt.Segments) > 0 {
+		return fmt.Errorf("Segments can only be configured on servers")
+	}
 	if rt.SegmentName != "" {
-		return structs.ErrSegmentsNotSupported
+		if err := validateSegmentName(rt.SegmentName, rt.SegmentNameLimit); err != nil {
+			return err
+		}
+	}
+
+	if len(rt.Segments) > rt.SegmentLimit {
+		return fmt.Errorf("Cannot exceed network segment limit of %d", rt.SegmentLimit)
+	}
+
+	names := make(map[string]struct{}, len(rt.Segments))
+	ports := map[int]string{rt.SerfPortLAN: "serf_lan"}
+	for _, s := range rt.Segments {
+		if err := validateSegmentName(s.Name, rt.SegmentNameLimit); err != nil {
+			return err
+		}
+		if _, ok := names[s.Name]; ok {
+			return fmt.Errorf("Segment name %q is used more than once", s.Name)
+		}
+		names[s.Name] = struct{}{}
+
+		if other, ok := ports[s.Bind.Port]; ok {
+			return fmt.Errorf("Segment %q port %d is already used by %s", s.Name, s.Bind.Port, other)
+		}
+		ports[s.Bind.Port] = fmt.Sprintf("segment %q", s.Name)
+	}
+	return nil
+}
+
+func validateSegmentName(name string, limit int) error {
+	if name == "" {
+		return fmt.Errorf("Segment name cannot be blank")
+	}
+	if len(name) > limit {
+		return fmt.Errorf("Segment name %q exceeds maximum length of %d", name, limit)
 	}
-	if len(rt.Segments) > 0 {
-		return structs.ErrSegmentsNotSupported
+	if !reSegmentName.MatchString(name) {
+		return fmt.Errorf("Segment name %q must only contain alphanumeric characters, dashes and underscores", name)
 	}
 	return nil
 }
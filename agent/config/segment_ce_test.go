@@ -7,8 +7,12 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/sdk/testutil"
 )
 
@@ -17,39 +21,154 @@ func TestSegments(t *testing.T) {
 
 	tests := []testCase{
 		{
-			desc: "segment name not in CE",
+			desc: "segment name on a server",
 			args: []string{
 				`-data-dir=` + dataDir,
 			},
 			json:        []string{`{ "server": true, "segment": "a" }`},
 			hcl:         []string{` server = true segment = "a" `},
-			expectedErr: `Network segments are not supported in this version of Consul`,
-			expectedWarnings: []string{
-				enterpriseConfigKeyError{key: "segment"}.Error(),
+			expectedErr: `Segment option can only be set on clients`,
+		},
+		{
+			desc: "segments on a client",
+			args: []string{
+				`-data-dir=` + dataDir,
 			},
+			json:        []string{`{ "segments":[{ "name":"x", "port": 123 }] }`},
+			hcl:         []string{`segments = [{ name = "x" port = 123 }]`},
+			expectedErr: `Segments can only be configured on servers`,
 		},
 		{
 			desc: "segment port must be set",
 			args: []string{
 				`-data-dir=` + dataDir,
 			},
-			json:        []string{`{ "segments":[{ "name":"x" }] }`},
-			hcl:         []string{`segments = [{ name = "x" }]`},
+			json:        []string{`{ "server": true, "segments":[{ "name":"x" }] }`},
+			hcl:         []string{`server = true segments = [{ name = "x" }]`},
 			expectedErr: `Port for segment "x" cannot be <= 0`,
-			expectedWarnings: []string{
-				enterpriseConfigKeyError{key: "segments"}.Error(),
+		},
+		{
+			desc: "segment transport",
+			args: []string{
+				`-data-dir=` + dataDir,
 			},
+			json:        []string{`{ "server": true, "segments":[{ "name":"x", "port": 123, "gossip": { "transport": "tcp" } }] }`},
+			hcl:         []string{`server = true segments = [{ name = "x" port = 123 gossip { transport = "tcp" } }]`},
+			expectedErr: `segments[x].gossip.transport cannot be set, segments use gossip_lan.transport`,
 		},
 		{
-			desc: "segments not in CE",
+			desc: "invalid segment name",
 			args: []string{
 				`-data-dir=` + dataDir,
 			},
-			json:        []string{`{ "segments":[{ "name":"x", "port": 123 }] }`},
-			hcl:         []string{`segments = [{ name = "x" port = 123 }]`},
-			expectedErr: `Network segments are not supported in this version of Consul`,
-			expectedWarnings: []string{
-				enterpriseConfigKeyError{key: "segments"}.Error(),
+			json:        []string{`{ "segment": "a.b" }`},
+			hcl:         []string{`segment = "a.b"`},
+			expectedErr: `Segment name "a.b" must only contain alphanumeric characters, dashes and underscores`,
+		},
+		{
+			desc: "segment name too long",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json:        []string{`{ "server": true, "segments":[{ "name":"` + strings.Repeat("a", 65) + `", "port": 123 }] }`},
+			hcl:         []string{`server = true segments = [{ name = "` + strings.Repeat("a", 65) + `" port = 123 }]`},
+			expectedErr: `exceeds maximum length of 64`,
+		},
+		{
+			desc: "duplicate segment names",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json:        []string{`{ "server": true, "segments":[{ "name":"x", "port": 123 }, { "name":"x", "port": 124 }] }`},
+			hcl:         []string{`server = true segments = [{ name = "x" port = 123 }, { name = "x" port = 124 }]`},
+			expectedErr: `Segment name "x" is used more than once`,
+		},
+		{
+			desc: "segment port conflicts with serf_lan",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json:        []string{`{ "server": true, "segments":[{ "name":"x", "port": 8301 }] }`},
+			hcl:         []string{`server = true segments = [{ name = "x" port = 8301 }]`},
+			expectedErr: `Segment "x" port 8301 is already used by serf_lan`,
+		},
+		{
+			desc: "duplicate segment ports",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json:        []string{`{ "server": true, "segments":[{ "name":"x", "port": 123 }, { "name":"y", "port": 123 }] }`},
+			hcl:         []string{`server = true segments = [{ name = "x" port = 123 }, { name = "y" port = 123 }]`},
+			expectedErr: `Segment "y" port 123 is already used by segment "x"`,
+		},
+		{
+			desc: "too many segments",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json:        []string{`{ "server": true, "segment_limit": 1, "segments":[{ "name":"x", "port": 123 }, { "name":"y", "port": 124 }] }`},
+			hcl:         []string{`server = true segment_limit = 1 segments = [{ name = "x" port = 123 }, { name = "y" port = 124 }]`},
+			expectedErr: `Cannot exceed network segment limit of 1`,
+		},
+		{
+			desc: "segment on a client",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json: []string{`{ "segment": "alpha" }`},
+			hcl:  []string{`segment = "alpha"`},
+			expected: func(rt *RuntimeConfig) {
+				rt.DataDir = dataDir
+				rt.SegmentName = "alpha"
+			},
+		},
+		{
+			desc: "segments with gossip tuning",
+			args: []string{
+				`-data-dir=` + dataDir,
+			},
+			json: []string{`{
+				"server": true,
+				"segments": [{
+					"name": "alpha",
+					"port": 8303,
+					"rpc_listener": true,
+					"gossip": { "probe_interval": "5s", "probe_timeout": "2s", "suspicion_mult": 6 }
+				}]
+			}`},
+			hcl: []string{`
+				server = true
+				segments = [{
+					name = "alpha"
+					port = 8303
+					rpc_listener = true
+					gossip {
+						probe_interval = "5s"
+						probe_timeout = "2s"
+						suspicion_mult = 6
+					}
+				}]
+			`},
+			expected: func(rt *RuntimeConfig) {
+				rt.DataDir = dataDir
+				rt.LeaveOnTerm = false
+				rt.ServerMode = true
+				rt.TLS.ServerMode = true
+				rt.SkipLeaveOnInt = true
+				rt.RPCConfig.EnableStreaming = true
+				rt.GRPCTLSPort = 8503
+				rt.GRPCTLSAddrs = []net.Addr{defaultGrpcTlsAddr}
+				rt.Segments = []structs.NetworkSegment{
+					{
+						Name:          "alpha",
+						Bind:          tcpAddr("0.0.0.0:8303"),
+						Advertise:     tcpAddr("10.0.0.1:8303"),
+						RPCListener:   true,
+						ProbeInterval: 5 * time.Second,
+						ProbeTimeout:  2 * time.Second,
+						SuspicionMult: 6,
+					},
+				}
 			},
 		},
 	}
@@ -17,6 +17,8 @@ acl = {
     default_policy = "72c2e7a0"
     enable_key_list_policy = true
     enable_token_persistence = true
+    enable_token_usage_tracking = true
+    token_usage_max_tokens = 4096
     policy_ttl = "1123s"
     role_ttl = "9876s"
     token_ttl = "3321s"
@@ -42,6 +44,20 @@ acl = {
         ]
     }
 }
+acme {
+    enabled = true
+    directory_url = "https://Kd7sLq2m.example.com/directory"
+    email = "mX4pT9ra@example.com"
+    challenge = "dns-01"
+    renew_before = "1932h"
+    dns_provider = "rfc2136"
+    dns_provider_config {
+        nameserver = "10.33.8.1:53"
+        zone = "Wq3nB7yz.example.com"
+        tsig_key = "Pz6cV1ud"
+        tsig_secret = "Ht2gN5ke"
+    }
+}
 addresses = {
     dns = "93.95.95.81"
     http = "83.39.91.39"
@@ -49,6 +65,7 @@ addresses = {
     grpc = "32.31.61.91"
     grpc_tls = "23.14.88.19"
 }
+address_family_preference = "ipv4"
 advertise_addr = "17.99.29.16"
 advertise_addr_wan = "78.63.37.19"
 advertise_reconnect_timeout = "0s"
@@ -91,6 +108,14 @@ autopilot = {
 }
 bind_addr = "16.99.34.17"
 bootstrap_expect = 53
+catalog_providers = [{
+    name = "jq8-mtz3"
+    type = "file"
+    sync_interval = "3817s"
+    file {
+        path = "/etc/Tn4wQz7b.json"
+    }
+}]
 cache = {
     entry_fetch_max_burst = 42
     entry_fetch_rate = 0.334
@@ -123,11 +148,25 @@ check = {
     docker_container_id = "qF66POS9"
     shell = "sOnDy228"
     os_service = "aZaCAXww"
+    plugin = "kD8fRn2w"
+    plugin_config {
+        "Qm4xTz7p" = "Lb9vWc3s"
+    }
     tls_server_name = "7BdnzBYk"
     tls_skip_verify = true
     timeout = "5954s"
     deregister_critical_service_after = "13209s"
 },
+check_flap_detection {
+    consecutive_results = 3
+    window_size = 7
+    min_ratio = 0.6
+}
+check_plugins = [{
+    name = "kD8fRn2w"
+    path = "/usr/local/bin/Hy5nGq1e"
+    args = ["Pz3mXc8r", "Wt6kBv2d"]
+}]
 checks = [
     {
         id = "uAjE6m9Z"
@@ -216,6 +255,12 @@ cloud {
     scada_address = "aoeusth232"
 }
 connect {
+    authorize_log_sample_rate = 0.25
+    mesh_gateway_wan_address_provider = "aws"
+    mesh_gateway_wan_address_port = 8443
+    mesh_gateway_wan_address_refresh_interval = "45s"
+    disable_expired_intentions = true
+    disable_intentions_after_review = true
     ca_provider = "consul"
     ca_config {
         intermediate_cert_ttl = "8760h"
@@ -236,6 +281,7 @@ gossip_lan {
     suspicion_mult  = 1235
     probe_interval  = "101ms"
     probe_timeout   = "102ms"
+    transport       = "tcp"
 }
 gossip_wan {
     gossip_nodes    = 2
@@ -244,6 +290,7 @@ gossip_wan {
     suspicion_mult  = 16385
     probe_interval  = "103ms"
     probe_timeout   = "104ms"
+    transport       = "tcp"
 }
 datacenter = "rzo029wg"
 default_query_time = "16743s"
@@ -256,6 +303,11 @@ disable_remote_exec = true
 disable_update_check = true
 discard_check_output = true
 discovery_max_stale = "5s"
+egress_proxy {
+    http_proxy = "http://10.4.5.6:3128"
+    https_proxy = "http://10.4.5.7:3129"
+    no_proxy = ["10.0.0.0/8", ".consul.internal"]
+}
 domain = "7W1xXSqd"
 alt_domain = "1789hsd"
 dns_config {
@@ -267,12 +319,18 @@ dns_config {
     node_ttl = "7084s"
     only_passing = true
     recursor_timeout = "4427s"
+    recursor_cache_size = 17193
+    recursor_cache_stale_if_error = "2903s"
     service_ttl = {
         "*" = "32030s"
     }
     udp_answer_limit = 29909
     use_cache = true
     cache_max_age = "5m"
+    host_resolver {
+        enabled = true
+        mode = "systemd-resolved"
+    }
     prefer_namespace = true
 }
 enable_acl_replication = true
@@ -288,6 +346,17 @@ encrypt_verify_outgoing = true
 experiments = [
     "foo"
 ]
+gitops {
+    enabled = true
+    provider = "github"
+    webhook_secret = "Zq3vTmb8"
+    repository = "acme/consul-config"
+    branch = "release"
+    path = "consul/entries"
+    api_address = "https://github.example.com/api/v3/"
+    api_token = "vD8wq1Lz"
+    acl_token = "5a4fd1d5-c2b6-4f2d-9e2c-3b9f0a1e7c44"
+}
 http_config {
     block_endpoints = [ "RBvAFcGD", "fWOWFznh" ]
     allow_write_http_from = [ "127.0.0.1/8", "22.33.44.55/32", "0.0.0.0/0" ]
@@ -297,8 +366,36 @@ http_config {
     }
     use_cache = false
     max_header_bytes = 10
+    client_cert_auth_method = "pW2YtBxN"
 }
 key_file = "IEkkwgIA"
+kv_encryption {
+    provider = "vault-transit"
+    vault_transit {
+        address = "https://vault.example.com:8200"
+        token = "Iegh3gee"
+        namespace = "oot0Pheu"
+        mount_path = "ohw4Ahwa"
+        key_name = "eiNg3ahv"
+        ca_file = "Ua9ohjie"
+        ca_path = "vie0Ahgh"
+        cert_file = "Aegh0ooz"
+        key_file = "Chei6aeT"
+        tls_server_name = "Xa7Eeceb"
+        tls_skip_verify = true
+    }
+    aws_kms {
+        key_id = "alias/Eeb1aiho"
+        region = "eu-west-3"
+        endpoint = "https://kms.example.com"
+    }
+    static {
+        key = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+    }
+}
+kv_replication {
+    prefixes = ["Ohf2ooth/", "feiRah4u/"]
+}
 leave_on_terminate = true
 license_path = "/path/to/license.lic"
 limits {
@@ -321,9 +418,39 @@ locality = {
     region = "us-east-2"
     zone = "us-east-2b"
 }
+logging {
+    syslog {
+        address = "syslog.example.com:6514"
+        tls {
+            enabled = true
+            ca_file = "Kee9Ahsh"
+            cert_file = "ieV4eiwa"
+            key_file = "Ush5eeph"
+            server_name = "syslog.example.com"
+        }
+    }
+    kafka {
+        brokers = ["kafka1:9093", "kafka2:9093"]
+        topic = "ooNg3eth"
+        tls {
+            enabled = true
+            insecure_skip_verify = true
+        }
+    }
+    rotation {
+        compress = true
+    }
+}
 log_level = "k1zo9Spt"
 log_json = true
 max_query_time = "18237s"
+meta_indexes {
+    node_meta_keys = ["rack"]
+    service_meta_keys = ["env", "team"]
+}
+named_pipes = {
+    allowed_sids = ["S-1-5-21-1004336348-1177238915-682003330-512"]
+}
 node_id = "AsUIlw99"
 node_meta {
     "5mgGQMBk" = "mJLtVMSG"
@@ -341,6 +468,7 @@ performance {
     rpc_hold_timeout = "15707s"
     grpc_keepalive_interval = "33s"
     grpc_keepalive_timeout = "22s"
+    check_update_batch_interval = "44ms"
 }
 pid_file = "43xN80Km"
 ports {
@@ -361,6 +489,19 @@ protocol = 30793
 primary_datacenter = "ejtmd43d"
 primary_gateways = [ "aej8eeZo", "roh2KahS" ]
 primary_gateways_interval = "18866s"
+quotas {
+    max_services = 3920
+    max_kv_bytes = 81920
+    max_intentions = 2870
+    max_acl_tokens = 4410
+    overrides = [
+        {
+            namespace = "default"
+            max_services = 6470
+            max_kv_bytes = 36330
+        }
+    ]
+}
 raft_protocol = 3
 raft_snapshot_threshold = 16384
 raft_snapshot_interval = "30s"
@@ -379,6 +520,28 @@ raft_logstore {
        segment_size_mb = 15
     }
 }
+raft_archive {
+    types = ["KVS", "ConfigEntry"]
+    interval = "4321s"
+    s3 {
+        bucket = "Xr7gPn2c"
+        prefix = "archive/Wq4v"
+        region = "us-west-2"
+        endpoint = "https://Hs8kLm3d.example.com"
+    }
+}
+raft_snapshot {
+    io_limit_mb = 25
+    window {
+        start = "22:30"
+        end = "04:15"
+        peak_threshold = 65536
+    }
+}
+redaction {
+    kv_prefixes = ["vault/", "secret/"]
+    config_entry_fields = ["inline-certificate.PrivateKey", "service-defaults.Meta.password"]
+}
 read_replica = true
 reconnect_timeout = "23739s"
 reconnect_timeout_wan = "26694s"
@@ -397,8 +560,29 @@ retry_max = 913
 retry_max_wan = 23160
 rpc {
     enable_streaming = true
+    compression = "zstd"
+    compression_threshold = 2048
+    cross_dc_cache_size = 4096
+    cross_dc_cache_ttl = "7s"
 }
 segment_limit = 123
+segments = [
+    {
+        name = "jAJQ7WC9"
+        bind = "97.32.44.81"
+        advertise = "45.12.83.20"
+        port = 8306
+        rpc_listener = true
+        gossip {
+            gossip_nodes = 5
+            gossip_interval = "51ms"
+            probe_interval = "3s"
+            probe_timeout = "900ms"
+            suspicion_mult = 7
+            retransmit_mult = 4
+        }
+    }
+]
 serf_lan = "99.43.63.15"
 serf_wan = "67.88.33.19"
 server = true
@@ -708,6 +892,8 @@ telemetry {
     circonus_submission_interval = "DolzaflP"
     circonus_submission_url = "gTcbS93G"
     enable_host_metrics = true
+    envoy_metrics_allowlist = [ "envoy_cluster_upstream_rq", "envoy_http_downstream_rq" ]
+    envoy_metrics_scrape_interval = "45s"
     disable_hostname = true
     dogstatsd_addr = "0wSndumK"
     dogstatsd_tags = [ "3N81zSUB","Xtj8AnXZ" ]
@@ -727,6 +913,7 @@ tls {
         cert_file = "hB4PoxkL"
         key_file = "Po0hB1tY"
         tls_cipher_suites = "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"
+        tls_curve_preferences = "X25519,P256"
         tls_min_version = "TLSv1_2"
         verify_incoming = true
         verify_outgoing = true
@@ -747,6 +934,7 @@ tls {
         ca_path = "nu4PlHzn"
         cert_file = "1yrhPlMk"
         key_file = "1bHapOkL"
+        tls_curve_preferences = "P384"
         tls_min_version = "TLSv1_3"
         verify_incoming = true
         verify_outgoing = true
@@ -790,6 +978,7 @@ unix_sockets = {
     group = "8pFodrV8"
     mode = "E8sAwOv4"
     user = "E0nB1DwA"
+    auth_method = "u4bRjVe7"
 }
 verify_incoming = true
 verify_incoming_https = true
@@ -807,6 +996,24 @@ watches = [{
     key = "sl3Dffu7"
     args = ["dltjDJ2a", "flEa7C2d"]
 }]
+webhooks = [{
+    name = "Dc7gYL2u"
+    url = "https://bjH2xa9p.example.com/hook"
+    secret = "kL3vQ8sd"
+    events = ["service-registered", "health-changed"]
+    services = ["Rq5mTz0w"]
+    timeout = "3651s"
+    max_retries = 7
+}]
+admission_webhooks = [{
+    name = "Xw8nPq2e"
+    url = "https://tR4kLm9s.example.com/admit"
+    secret = "Vb6hJy3c"
+    operations = ["catalog-register", "config-entry-upsert"]
+    mutating = true
+    failure_policy = "fail-open"
+    timeout = "2347s"
+}]
 xds {
   update_max_per_second = 9526.2
 }
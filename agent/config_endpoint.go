@@ -223,3 +223,28 @@ func (s *HTTPHandlers) ExportedServices(resp http.ResponseWriter, req *http.Requ
 
 	return svcs, nil
 }
+
+// ConnectLint evaluates the service mesh config entries against the rules of
+// the mesh config linter and returns the findings.
+//
+// GET /v1/connect/lint
+func (s *HTTPHandlers) ConnectLint(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+
+	var reply structs.ConfigEntryLintResponse
+	if err := s.agent.RPC(req.Context(), "ConfigEntry.Lint", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	if reply.Findings == nil {
+		reply.Findings = []structs.ConfigEntryLintFinding{}
+	}
+	return reply.Findings, nil
+}
@@ -4,6 +4,7 @@
 package configentry
 
 import (
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/proto/private/pbpeering"
 )
@@ -20,7 +21,9 @@ type DiscoveryChainSet struct {
 	Peers                map[string]*pbpeering.Peering
 	DefaultSamenessGroup *structs.SamenessGroupConfigEntry
 	SamenessGroups       map[string]*structs.SamenessGroupConfigEntry
-	ProxyDefaults        map[string]*structs.ProxyConfigEntry
+
+	// ProxyDefaults is keyed by structs.ProxyDefaultsScopeKey.
+	ProxyDefaults map[string]*structs.ProxyConfigEntry
 }
 
 func NewDiscoveryChainSet() *DiscoveryChainSet {
@@ -74,11 +77,16 @@ func (e *DiscoveryChainSet) GetDefaultSamenessGroup() *structs.SamenessGroupConf
 	return e.DefaultSamenessGroup
 }
 
-func (e *DiscoveryChainSet) GetProxyDefaults(partition string) *structs.ProxyConfigEntry {
-	if e.ProxyDefaults != nil {
-		return e.ProxyDefaults[partition]
-	}
-	return nil
+// GetProxyDefaults returns the effective proxy-defaults for services in
+// entMeta, merged from the proxy-defaults of each scope that applies.
+func (e *DiscoveryChainSet) GetProxyDefaults(entMeta *acl.EnterpriseMeta) *structs.ProxyConfigEntry {
+	return structs.MergeProxyConfigEntries(e.GetProxyDefaultsLayers(entMeta)...)
+}
+
+// GetProxyDefaultsLayers returns the proxy-defaults of each scope that applies
+// to services in entMeta, from the least to the most specific.
+func (e *DiscoveryChainSet) GetProxyDefaultsLayers(entMeta *acl.EnterpriseMeta) []*structs.ProxyConfigEntry {
+	return proxyDefaultsLayers(e.ProxyDefaults, entMeta)
 }
 
 // AddRouters adds router configs. Convenience function for testing.
@@ -151,7 +159,7 @@ func (e *DiscoveryChainSet) AddProxyDefaults(entries ...*structs.ProxyConfigEntr
 		e.ProxyDefaults = make(map[string]*structs.ProxyConfigEntry)
 	}
 	for _, entry := range entries {
-		e.ProxyDefaults[entry.PartitionOrDefault()] = entry
+		e.ProxyDefaults[structs.ProxyDefaultsScopeKey(&entry.EnterpriseMeta)] = entry
 	}
 }
 
@@ -206,3 +214,15 @@ func (e *DiscoveryChainSet) IsEmpty() bool {
 func (e *DiscoveryChainSet) IsChainEmpty() bool {
 	return len(e.Routers) == 0 && len(e.Splitters) == 0 && len(e.Resolvers) == 0 && e.DefaultSamenessGroup == nil
 }
+
+// proxyDefaultsLayers looks up the proxy-defaults of each scope that applies
+// to services in entMeta in a map keyed by structs.ProxyDefaultsScopeKey.
+func proxyDefaultsLayers(m map[string]*structs.ProxyConfigEntry, entMeta *acl.EnterpriseMeta) []*structs.ProxyConfigEntry {
+	var layers []*structs.ProxyConfigEntry
+	for _, scope := range structs.ProxyDefaultsScopes(entMeta) {
+		if entry, ok := m[structs.ProxyDefaultsScopeKey(scope)]; ok && entry != nil {
+			layers = append(layers, entry)
+		}
+	}
+	return layers
+}
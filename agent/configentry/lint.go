@@ -0,0 +1,374 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configentry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// Lint checks the service resolver, splitter, router, intentions and exported
+// services config entries for mistakes which are not caught when each entry
+// is validated on its own. services holds the services registered in the
+// catalog, which are used to detect references to services which do not
+// exist.
+func Lint(entries []structs.ConfigEntry, services []structs.ServiceName) []structs.ConfigEntryLintFinding {
+	l := &linter{
+		resolvers:  make(map[structs.ServiceName]*structs.ServiceResolverConfigEntry),
+		intentions: make(map[structs.ServiceName]*structs.ServiceIntentionsConfigEntry),
+		known:      make(map[structs.ServiceName]struct{}),
+		reached:    make(map[structs.ServiceName]map[string]struct{}),
+	}
+	for _, svc := range services {
+		l.known[svc] = struct{}{}
+	}
+
+	for _, entry := range entries {
+		name := structs.NewServiceName(entry.GetName(), entry.GetEnterpriseMeta())
+		switch e := entry.(type) {
+		case *structs.ServiceConfigEntry:
+			l.known[name] = struct{}{}
+		case *structs.ServiceResolverConfigEntry:
+			l.known[name] = struct{}{}
+			l.resolvers[name] = e
+		case *structs.ServiceIntentionsConfigEntry:
+			l.intentions[name] = e
+		}
+	}
+
+	for _, entry := range entries {
+		switch e := entry.(type) {
+		case *structs.ServiceResolverConfigEntry:
+			l.lintResolver(e)
+		case *structs.ServiceSplitterConfigEntry:
+			l.lintSplitter(e)
+		case *structs.ServiceRouterConfigEntry:
+			l.lintRouter(e)
+		case *structs.ExportedServicesConfigEntry:
+			l.lintExportedServices(e)
+		case *structs.ServiceIntentionsConfigEntry:
+			l.lintIntentions(e)
+		}
+	}
+
+	// Subsets can only be found unreachable once every reference to them
+	// has been recorded.
+	for _, entry := range entries {
+		if e, ok := entry.(*structs.ServiceResolverConfigEntry); ok {
+			l.lintSubsets(e)
+		}
+	}
+
+	sort.Slice(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if severityRank[a.Severity] != severityRank[b.Severity] {
+			return severityRank[a.Severity] < severityRank[b.Severity]
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Message < b.Message
+	})
+	return l.findings
+}
+
+var severityRank = map[string]int{
+	structs.LintSeverityError:   0,
+	structs.LintSeverityWarning: 1,
+	structs.LintSeverityInfo:    2,
+}
+
+type linter struct {
+	resolvers  map[structs.ServiceName]*structs.ServiceResolverConfigEntry
+	intentions map[structs.ServiceName]*structs.ServiceIntentionsConfigEntry
+
+	// known holds the services registered in the catalog or configured with
+	// a service-defaults or service-resolver entry.
+	known map[structs.ServiceName]struct{}
+
+	// reached holds the subsets of each service traffic is sent to.
+	reached map[structs.ServiceName]map[string]struct{}
+
+	findings []structs.ConfigEntryLintFinding
+}
+
+func (l *linter) report(entry structs.ConfigEntry, rule, severity, format string, args ...interface{}) {
+	l.findings = append(l.findings, structs.ConfigEntryLintFinding{
+		Rule:           rule,
+		Severity:       severity,
+		Kind:           entry.GetKind(),
+		Name:           entry.GetName(),
+		Message:        fmt.Sprintf(format, args...),
+		EnterpriseMeta: *entry.GetEnterpriseMeta(),
+	})
+}
+
+// target is a service traffic is sent to by a config entry.
+type target struct {
+	desc      string
+	service   string
+	subset    string
+	namespace string
+	partition string
+
+	// remote is set when the target is in another datacenter, in a peer or
+	// in a sameness group, whose services are not known.
+	remote bool
+}
+
+// reference records the traffic sent by entry to t and reports t when the
+// service or subset it refers to does not exist.
+func (l *linter) reference(entry structs.ConfigEntry, t target, missingRule string) {
+	if t.remote {
+		return
+	}
+	service := t.service
+	if service == "" {
+		service = entry.GetName()
+	}
+	entMeta := acl.NewEnterpriseMetaWithPartition(t.partition, t.namespace)
+	entMeta.MergeNoWildcard(entry.GetEnterpriseMeta())
+	name := structs.NewServiceName(service, &entMeta)
+
+	if _, ok := l.known[name]; !ok {
+		l.report(entry, missingRule, structs.LintSeverityWarning,
+			"%s targets service %q, which is not registered and has no service-defaults or service-resolver entry", t.desc, service)
+	}
+
+	if t.subset == "" {
+		return
+	}
+	if l.reached[name] == nil {
+		l.reached[name] = make(map[string]struct{})
+	}
+	l.reached[name][t.subset] = struct{}{}
+
+	switch resolver := l.resolvers[name]; {
+	case resolver == nil:
+		l.report(entry, structs.LintRuleUndefinedSubset, structs.LintSeverityError,
+			"%s targets subset %q of service %q, which has no service-resolver entry", t.desc, t.subset, service)
+	case !resolver.SubsetExists(t.subset):
+		l.report(entry, structs.LintRuleUndefinedSubset, structs.LintSeverityError,
+			"%s targets subset %q of service %q, which its service-resolver does not define", t.desc, t.subset, service)
+	}
+}
+
+func (l *linter) lintResolver(e *structs.ServiceResolverConfigEntry) {
+	if r := e.Redirect; r != nil {
+		l.reference(e, target{
+			desc:      "The redirect",
+			service:   r.Service,
+			subset:    r.ServiceSubset,
+			namespace: r.Namespace,
+			partition: r.Partition,
+			remote:    r.Datacenter != "" || r.Peer != "" || r.SamenessGroup != "",
+		}, structs.LintRuleMissingService)
+	}
+
+	subsets := make([]string, 0, len(e.Failover))
+	for subset := range e.Failover {
+		subsets = append(subsets, subset)
+	}
+	sort.Strings(subsets)
+	for _, subset := range subsets {
+		f := e.Failover[subset]
+		desc := "The failover"
+		if subset != "*" {
+			desc = fmt.Sprintf("The failover of subset %q", subset)
+		}
+		if f.SamenessGroup != "" {
+			continue
+		}
+		if len(f.Targets) == 0 {
+			l.reference(e, target{
+				desc:      desc,
+				service:   f.Service,
+				subset:    f.ServiceSubset,
+				namespace: f.Namespace,
+				remote:    len(f.Datacenters) > 0,
+			}, structs.LintRuleMissingFailoverService)
+			continue
+		}
+		for i, t := range f.Targets {
+			l.reference(e, target{
+				desc:      fmt.Sprintf("%s target %d", desc, i+1),
+				service:   t.Service,
+				subset:    t.ServiceSubset,
+				namespace: t.Namespace,
+				partition: t.Partition,
+				remote:    t.Datacenter != "" || t.Peer != "",
+			}, structs.LintRuleMissingFailoverService)
+		}
+	}
+}
+
+// lintSubsets reports the subsets of the resolver which are neither its
+// default subset nor targeted by another entry.
+func (l *linter) lintSubsets(e *structs.ServiceResolverConfigEntry) {
+	name := structs.NewServiceName(e.Name, &e.EnterpriseMeta)
+
+	subsets := make([]string, 0, len(e.Subsets))
+	for subset := range e.Subsets {
+		subsets = append(subsets, subset)
+	}
+	sort.Strings(subsets)
+	for _, subset := range subsets {
+		if subset == e.DefaultSubset {
+			continue
+		}
+		if _, ok := l.reached[name][subset]; ok {
+			continue
+		}
+		l.report(e, structs.LintRuleUnreachableSubset, structs.LintSeverityWarning,
+			"Subset %q is not the default subset and no route, split, redirect or failover targets it", subset)
+	}
+}
+
+func (l *linter) lintSplitter(e *structs.ServiceSplitterConfigEntry) {
+	var total float64
+	for _, split := range e.Splits {
+		total += float64(split.Weight)
+
+		desc := fmt.Sprintf("The split to service %q", split.Service)
+		if split.Service == "" {
+			desc = "The split"
+		}
+		l.reference(e, target{
+			desc:      desc,
+			service:   split.Service,
+			subset:    split.ServiceSubset,
+			namespace: split.Namespace,
+			partition: split.Partition,
+		}, structs.LintRuleMissingService)
+	}
+
+	// Allow for the same rounding error as the validation of the entry.
+	if math.Abs(total-100) > 0.01 {
+		l.report(e, structs.LintRuleSplitWeights, structs.LintSeverityError,
+			"The split weights sum to %g instead of 100", total)
+	}
+}
+
+func (l *linter) lintRouter(e *structs.ServiceRouterConfigEntry) {
+	for i, route := range e.Routes {
+		d := route.Destination
+		if d == nil {
+			continue
+		}
+		l.reference(e, target{
+			desc:      fmt.Sprintf("Route %d", i+1),
+			service:   d.Service,
+			subset:    d.ServiceSubset,
+			namespace: d.Namespace,
+			partition: d.Partition,
+		}, structs.LintRuleMissingService)
+	}
+}
+
+func (l *linter) lintExportedServices(e *structs.ExportedServicesConfigEntry) {
+	for _, svc := range e.Services {
+		if svc.Name == structs.WildcardSpecifier {
+			continue
+		}
+		l.reference(e, target{
+			desc:      "The export",
+			service:   svc.Name,
+			namespace: svc.Namespace,
+		}, structs.LintRuleMissingService)
+	}
+}
+
+// lintIntentions reports the sources of a service-intentions entry which have
+// no effect because the intention with the next highest precedence, a
+// wildcard intention, takes the same action. Sources with permissions and
+// sources from peers or sameness groups are not considered.
+func (l *linter) lintIntentions(e *structs.ServiceIntentionsConfigEntry) {
+	wildcardName := structs.NewServiceName(structs.WildcardSpecifier, e.GetEnterpriseMeta())
+	var wildcard *structs.ServiceIntentionsConfigEntry
+	if e.Name != structs.WildcardSpecifier {
+		wildcard = l.intentions[wildcardName]
+	}
+
+	for _, src := range e.Sources {
+		action, ok := l4Action(src)
+		if !ok {
+			continue
+		}
+
+		if e.Name == structs.WildcardSpecifier && src.Name == structs.WildcardSpecifier {
+			if action == structs.IntentionActionAllow {
+				l.report(e, structs.LintRuleIntentionAllowAll, structs.LintSeverityWarning,
+					"The intention from %q allows every service to connect to every other service", src.Name)
+			}
+			continue
+		}
+
+		// Find the intention which would apply to the traffic from the
+		// source if this one was removed.
+		var fallback []*structs.ServiceIntentionsConfigEntry
+		if src.Name != structs.WildcardSpecifier {
+			fallback = append(fallback, e)
+		}
+		if wildcard != nil {
+			fallback = append(fallback, wildcard)
+		}
+		for _, candidate := range fallback {
+			other := findSource(candidate, src)
+			if other == nil {
+				continue
+			}
+			if otherAction, ok := l4Action(other); ok && otherAction == action && !(src.Name == structs.WildcardSpecifier && hasOverride(candidate, action)) {
+				l.report(e, structs.LintRuleWildcardIntentionOverlap, structs.LintSeverityInfo,
+					"The intention from %q has no effect since the intention from %q to %q already takes action %q",
+					src.Name, other.Name, candidate.Name, action)
+			}
+			break
+		}
+	}
+}
+
+// findSource returns the source of entry which would match the traffic from
+// src if src was removed: the exact source when looking in another entry,
+// otherwise the wildcard source.
+func findSource(entry *structs.ServiceIntentionsConfigEntry, src *structs.SourceIntention) *structs.SourceIntention {
+	var wildcard *structs.SourceIntention
+	for _, other := range entry.Sources {
+		if other == src || other.Peer != "" || other.SamenessGroup != "" {
+			continue
+		}
+		switch other.Name {
+		case src.Name:
+			return other
+		case structs.WildcardSpecifier:
+			wildcard = other
+		}
+	}
+	return wildcard
+}
+
+// hasOverride returns whether entry has a source taking another action than
+// action, which a wildcard source in a more precise entry overrides.
+func hasOverride(entry *structs.ServiceIntentionsConfigEntry, action structs.IntentionAction) bool {
+	for _, src := range entry.Sources {
+		if other, ok := l4Action(src); !ok || other != action {
+			return true
+		}
+	}
+	return false
+}
+
+// l4Action returns the action of a local source without permissions.
+func l4Action(src *structs.SourceIntention) (structs.IntentionAction, bool) {
+	if len(src.Permissions) > 0 || src.Peer != "" || src.SamenessGroup != "" {
+		return "", false
+	}
+	return src.Action, true
+}
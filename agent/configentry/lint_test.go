@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configentry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestLint(t *testing.T) {
+	entries := []structs.ConfigEntry{
+		&structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web", Protocol: "http"},
+		&structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "api", Protocol: "http"},
+		&structs.ServiceResolverConfigEntry{
+			Kind:          structs.ServiceResolver,
+			Name:          "web",
+			DefaultSubset: "v1",
+			Subsets: map[string]structs.ServiceResolverSubset{
+				"v1": {Filter: "Service.Meta.version == v1"},
+				"v2": {Filter: "Service.Meta.version == v2"},
+				"v3": {Filter: "Service.Meta.version == v3"},
+			},
+			Failover: map[string]structs.ServiceResolverFailover{
+				"*": {Targets: []structs.ServiceResolverFailoverTarget{
+					{Service: "ghost"},
+					{Service: "web", Datacenter: "dc2"},
+				}},
+			},
+		},
+		&structs.ServiceSplitterConfigEntry{
+			Kind: structs.ServiceSplitter,
+			Name: "web",
+			Splits: []structs.ServiceSplit{
+				{Weight: 50, ServiceSubset: "v1"},
+				{Weight: 40, ServiceSubset: "v2"},
+			},
+		},
+		&structs.ServiceRouterConfigEntry{
+			Kind: structs.ServiceRouter,
+			Name: "api",
+			Routes: []structs.ServiceRoute{
+				{Destination: &structs.ServiceRouteDestination{Service: "web", ServiceSubset: "v9"}},
+				{Destination: &structs.ServiceRouteDestination{Service: "db"}},
+			},
+		},
+		&structs.ExportedServicesConfigEntry{
+			Name: "default",
+			Services: []structs.ExportedService{
+				{Name: "db"},
+				{Name: "missing"},
+			},
+		},
+		&structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "*",
+			Sources: []*structs.SourceIntention{
+				{Name: "*", Action: structs.IntentionActionAllow},
+			},
+		},
+		&structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "web",
+			Sources: []*structs.SourceIntention{
+				{Name: "api", Action: structs.IntentionActionAllow},
+				{Name: "db", Action: structs.IntentionActionDeny},
+			},
+		},
+		&structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "db",
+			Sources: []*structs.SourceIntention{
+				{Name: "*", Action: structs.IntentionActionDeny},
+				{Name: "api", Action: structs.IntentionActionDeny},
+				{Name: "web", Action: structs.IntentionActionAllow},
+			},
+		},
+	}
+	services := []structs.ServiceName{
+		structs.NewServiceName("web", nil),
+		structs.NewServiceName("api", nil),
+		structs.NewServiceName("db", nil),
+	}
+
+	findings := Lint(entries, services)
+	for i := range findings {
+		findings[i].EnterpriseMeta = acl.EnterpriseMeta{}
+	}
+
+	require.Equal(t, []structs.ConfigEntryLintFinding{
+		{
+			Rule:     structs.LintRuleUndefinedSubset,
+			Severity: structs.LintSeverityError,
+			Kind:     structs.ServiceRouter,
+			Name:     "api",
+			Message:  `Route 1 targets subset "v9" of service "web", which its service-resolver does not define`,
+		},
+		{
+			Rule:     structs.LintRuleSplitWeights,
+			Severity: structs.LintSeverityError,
+			Kind:     structs.ServiceSplitter,
+			Name:     "web",
+			Message:  "The split weights sum to 90 instead of 100",
+		},
+		{
+			Rule:     structs.LintRuleMissingService,
+			Severity: structs.LintSeverityWarning,
+			Kind:     structs.ExportedServices,
+			Name:     "default",
+			Message:  `The export targets service "missing", which is not registered and has no service-defaults or service-resolver entry`,
+		},
+		{
+			Rule:     structs.LintRuleIntentionAllowAll,
+			Severity: structs.LintSeverityWarning,
+			Kind:     structs.ServiceIntentions,
+			Name:     "*",
+			Message:  `The intention from "*" allows every service to connect to every other service`,
+		},
+		{
+			Rule:     structs.LintRuleUnreachableSubset,
+			Severity: structs.LintSeverityWarning,
+			Kind:     structs.ServiceResolver,
+			Name:     "web",
+			Message:  `Subset "v3" is not the default subset and no route, split, redirect or failover targets it`,
+		},
+		{
+			Rule:     structs.LintRuleMissingFailoverService,
+			Severity: structs.LintSeverityWarning,
+			Kind:     structs.ServiceResolver,
+			Name:     "web",
+			Message:  `The failover target 1 targets service "ghost", which is not registered and has no service-defaults or service-resolver entry`,
+		},
+		{
+			Rule:     structs.LintRuleWildcardIntentionOverlap,
+			Severity: structs.LintSeverityInfo,
+			Kind:     structs.ServiceIntentions,
+			Name:     "db",
+			Message:  `The intention from "api" has no effect since the intention from "*" to "db" already takes action "deny"`,
+		},
+		{
+			Rule:     structs.LintRuleWildcardIntentionOverlap,
+			Severity: structs.LintSeverityInfo,
+			Kind:     structs.ServiceIntentions,
+			Name:     "web",
+			Message:  `The intention from "api" has no effect since the intention from "*" to "*" already takes action "allow"`,
+		},
+	}, findings)
+}
+
+func TestLint_NoFindings(t *testing.T) {
+	entries := []structs.ConfigEntry{
+		&structs.ServiceResolverConfigEntry{
+			Kind:          structs.ServiceResolver,
+			Name:          "web",
+			DefaultSubset: "v1",
+			Subsets: map[string]structs.ServiceResolverSubset{
+				"v1": {Filter: "Service.Meta.version == v1"},
+				"v2": {Filter: "Service.Meta.version == v2"},
+			},
+		},
+		&structs.ServiceSplitterConfigEntry{
+			Kind: structs.ServiceSplitter,
+			Name: "web",
+			Splits: []structs.ServiceSplit{
+				{Weight: 33.33, ServiceSubset: "v1"},
+				{Weight: 66.67, ServiceSubset: "v2"},
+			},
+		},
+		&structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "*",
+			Sources: []*structs.SourceIntention{
+				{Name: "*", Action: structs.IntentionActionDeny},
+			},
+		},
+		&structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "web",
+			Sources: []*structs.SourceIntention{
+				{Name: "api", Action: structs.IntentionActionAllow},
+			},
+		},
+	}
+	require.Empty(t, Lint(entries, []structs.ServiceName{structs.NewServiceName("web", nil)}))
+}
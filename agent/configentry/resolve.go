@@ -34,10 +34,11 @@ func ComputeResolvedServiceConfig(
 	// TODO(freddy) Refactor this into smaller set of state store functions
 	// Pass the WatchSet to both the service and proxy config lookups. If either is updated during the
 	// blocking query, this function will be rerun and these state store lookups will both be current.
-	// We use the default enterprise meta to look up the global proxy defaults because they are not namespaced.
+	// The proxy-defaults of each scope that applies to the service are merged
+	// with the more specific scopes taking precedence.
 
 	var proxyConfGlobalProtocol string
-	proxyConf := entries.GetProxyDefaults(args.PartitionOrDefault())
+	proxyConf := entries.GetProxyDefaults(&args.EnterpriseMeta)
 	if proxyConf != nil {
 		// Apply the proxy defaults to the sidecar's proxy config
 		mapCopy, err := copystructure.Copy(proxyConf.Config)
@@ -4,6 +4,7 @@
 package configentry
 
 import (
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
@@ -13,7 +14,9 @@ import (
 // None of these are defaulted.
 type ResolvedServiceConfigSet struct {
 	ServiceDefaults map[structs.ServiceID]*structs.ServiceConfigEntry
-	ProxyDefaults   map[string]*structs.ProxyConfigEntry
+
+	// ProxyDefaults is keyed by structs.ProxyDefaultsScopeKey.
+	ProxyDefaults map[string]*structs.ProxyConfigEntry
 }
 
 func (r *ResolvedServiceConfigSet) IsEmpty() bool {
@@ -27,11 +30,10 @@ func (r *ResolvedServiceConfigSet) GetServiceDefaults(sid structs.ServiceID) *st
 	return r.ServiceDefaults[sid]
 }
 
-func (r *ResolvedServiceConfigSet) GetProxyDefaults(partition string) *structs.ProxyConfigEntry {
-	if r.ProxyDefaults == nil {
-		return nil
-	}
-	return r.ProxyDefaults[partition]
+// GetProxyDefaults returns the effective proxy-defaults for services in
+// entMeta, merged from the proxy-defaults of each scope that applies.
+func (r *ResolvedServiceConfigSet) GetProxyDefaults(entMeta *acl.EnterpriseMeta) *structs.ProxyConfigEntry {
+	return structs.MergeProxyConfigEntries(proxyDefaultsLayers(r.ProxyDefaults, entMeta)...)
 }
 
 func (r *ResolvedServiceConfigSet) AddServiceDefaults(entry *structs.ServiceConfigEntry) {
@@ -56,5 +58,5 @@ func (r *ResolvedServiceConfigSet) AddProxyDefaults(entry *structs.ProxyConfigEn
 		r.ProxyDefaults = make(map[string]*structs.ProxyConfigEntry)
 	}
 
-	r.ProxyDefaults[entry.PartitionOrDefault()] = entry
+	r.ProxyDefaults[structs.ProxyDefaultsScopeKey(&entry.EnterpriseMeta)] = entry
 }
@@ -6,6 +6,8 @@ package ca
 import (
 	"crypto/x509"
 	"errors"
+
+	"golang.org/x/crypto/ocsp"
 )
 
 //go:generate mockery --name Provider --inpackage
@@ -212,6 +214,20 @@ type CAChainResult struct {
 	IntermediatePEM string
 }
 
+// RevocationSigner is an optional interface that CA providers may implement
+// to sign the certificate revocation list and the OCSP responses of the
+// datacenter. Both are signed with the key of the active leaf signing cert, so
+// that they are issued by the same CA as the leaf certs they cover.
+type RevocationSigner interface {
+	// SignRevocationList signs the given CRL template and returns the DER
+	// encoded CRL.
+	SignRevocationList(template *x509.RevocationList) ([]byte, error)
+
+	// SignOCSPResponse signs the given OCSP response template and returns the
+	// DER encoded response.
+	SignOCSPResponse(template ocsp.Response) ([]byte, error)
+}
+
 // NeedsStop is an optional interface that allows a CA to define a function
 // to be called when the CA instance is no longer in use. This is different
 // from Cleanup(), as only the local provider instance is being shut down
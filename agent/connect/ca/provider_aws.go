@@ -8,6 +8,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"sync/atomic"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/egressproxy"
 )
 
 const (
@@ -100,6 +102,9 @@ func (a *AWSProvider) Configure(cfg ProviderConfig) error {
 	// store in a new place on disk. One of the existing standard solutions seems
 	// better in all cases.
 	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			HTTPClient: &http.Client{Transport: egressproxy.PooledTransport()},
+		},
 		SharedConfigState: session.SharedConfigEnable,
 	})
 	if err != nil {
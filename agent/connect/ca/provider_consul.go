@@ -5,6 +5,7 @@ package ca
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/x509"
@@ -18,6 +19,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"golang.org/x/crypto/ocsp"
 
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
@@ -563,6 +565,61 @@ func (c *ConsulProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	return buf.String(), nil
 }
 
+// SignRevocationList implements RevocationSigner
+func (c *ConsulProvider) SignRevocationList(template *x509.RevocationList) ([]byte, error) {
+	caCert, signer, err := c.leafSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, template, caCert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("error generating revocation list: %s", err)
+	}
+	return crl, nil
+}
+
+// SignOCSPResponse implements RevocationSigner
+func (c *ConsulProvider) SignOCSPResponse(template ocsp.Response) ([]byte, error) {
+	caCert, signer, err := c.leafSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	// The responses are signed by the CA itself, so there is no need to
+	// include a delegated responder cert.
+	resp, err := ocsp.CreateResponse(caCert, caCert, template, signer)
+	if err != nil {
+		return nil, fmt.Errorf("error generating OCSP response: %s", err)
+	}
+	return resp, nil
+}
+
+// leafSigner returns the active leaf signing cert and its private key.
+func (c *ConsulProvider) leafSigner() (*x509.Certificate, crypto.Signer, error) {
+	providerState, err := c.getState()
+	if err != nil {
+		return nil, nil, err
+	}
+	if providerState.PrivateKey == "" {
+		return nil, nil, ErrNotInitialized
+	}
+	signer, err := connect.ParseSigner(providerState.PrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, err := c.ActiveLeafSigningCert()
+	if err != nil {
+		return nil, nil, err
+	}
+	caCert, err := connect.ParseCert(certPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CA cert: %s", err)
+	}
+	return caCert, signer, nil
+}
+
 // SupportsCrossSigning implements Provider
 func (c *ConsulProvider) SupportsCrossSigning() (bool, error) {
 	return !c.config.DisableCrossSigning, nil
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/lib/decode"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/lib/retry"
 )
 
@@ -111,6 +112,8 @@ func (v *VaultProvider) Configure(cfg ProviderConfig) error {
 	if err != nil {
 		return err
 	}
+	// ConfigureTLS ensures the client has an *http.Transport.
+	clientConf.HttpClient.Transport.(*http.Transport).Proxy = egressproxy.Proxy
 	client, err := vaultapi.NewClient(clientConf)
 	if err != nil {
 		return err
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package connect
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// IntrospectSVID parses the PEM encoded SVID and describes the identity it
+// carries, along with whether it is currently trusted by the given roots. An
+// error is only returned if the SVID can't be parsed; certificates which
+// aren't valid are described with Valid set to false and the reason why.
+func IntrospectSVID(svid string, roots *structs.IndexedCARoots, now time.Time) (*structs.SVIDIntrospection, error) {
+	svid = strings.TrimSpace(svid)
+	if svid == "" {
+		return nil, fmt.Errorf("SVID is required")
+	}
+	if looksLikeJWT(svid) {
+		return nil, fmt.Errorf("JWT-SVIDs are not issued by Consul, only X.509 SVIDs can be introspected")
+	}
+
+	leaf, intermediates, err := ParseLeafCerts(svid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SVID: %w", err)
+	}
+
+	out := &structs.SVIDIntrospection{
+		SerialNumber: EncodeSerialNumber(leaf.SerialNumber),
+		SigningKeyID: EncodeSigningKeyID(leaf.AuthorityKeyId),
+		Subject:      leaf.Subject.String(),
+		Issuer:       leaf.Issuer.String(),
+		DNSNames:     leaf.DNSNames,
+		ValidAfter:   leaf.NotBefore,
+		ValidBefore:  leaf.NotAfter,
+	}
+
+	invalid := func(format string, args ...interface{}) {
+		// Only the first reason is reported.
+		if out.InvalidReason == "" {
+			out.InvalidReason = fmt.Sprintf(format, args...)
+		}
+	}
+
+	switch len(leaf.URIs) {
+	case 0:
+		invalid("certificate has no SPIFFE ID")
+	case 1:
+		out.SpiffeID = leaf.URIs[0].String()
+		out.TrustDomain = leaf.URIs[0].Host
+		out.TrustDomainMatches = trustDomainMatches(out.TrustDomain, roots)
+		certURI, err := ParseCertURI(leaf.URIs[0])
+		if err != nil {
+			invalid("invalid SPIFFE ID: %v", err)
+		} else {
+			setIdentityClaims(out, certURI)
+		}
+	default:
+		invalid("certificate has %d URIs, expected a single SPIFFE ID", len(leaf.URIs))
+	}
+
+	switch {
+	case now.Before(leaf.NotBefore):
+		invalid("certificate is not valid before %s", leaf.NotBefore.Format(time.RFC3339))
+	case now.After(leaf.NotAfter):
+		invalid("certificate expired at %s", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	// Find the root the certificate chains up to. The chain is verified at a
+	// time the certificate is valid, so expired certificates still report
+	// their root.
+	verifyAt := now
+	if verifyAt.Before(leaf.NotBefore) {
+		verifyAt = leaf.NotBefore
+	} else if verifyAt.After(leaf.NotAfter) {
+		verifyAt = leaf.NotAfter
+	}
+	for _, root := range roots.Roots {
+		if verifiesWithRoot(leaf, intermediates, root, verifyAt) {
+			out.SignedByRootID = root.ID
+			out.SignedByActiveRoot = root.ID == roots.ActiveRootID
+			break
+		}
+	}
+	if out.SignedByRootID == "" {
+		invalid("certificate is not signed by a trusted CA root")
+	}
+	if roots.IsRevoked(out.SerialNumber) {
+		out.Revoked = true
+		invalid("certificate was revoked")
+	}
+
+	out.Valid = out.InvalidReason == ""
+	return out, nil
+}
+
+func setIdentityClaims(out *structs.SVIDIntrospection, certURI CertURI) {
+	switch id := certURI.(type) {
+	case *SpiffeIDService:
+		out.Kind = "service"
+		out.Service = id.Service
+		out.Datacenter = id.Datacenter
+		out.Partition = id.PartitionOrDefault()
+		out.Namespace = id.NamespaceOrDefault()
+	case *SpiffeIDWorkloadIdentity:
+		out.Kind = "workload-identity"
+		out.WorkloadIdentity = id.WorkloadIdentity
+		out.Partition = id.Partition
+		out.Namespace = id.Namespace
+	case *SpiffeIDAgent:
+		out.Kind = "agent"
+		out.Agent = id.Agent
+		out.Datacenter = id.Datacenter
+		out.Partition = id.PartitionOrDefault()
+	case *SpiffeIDServer:
+		out.Kind = "server"
+		out.Datacenter = id.Datacenter
+	case *SpiffeIDMeshGateway:
+		out.Kind = "mesh-gateway"
+		out.Datacenter = id.Datacenter
+		out.Partition = id.PartitionOrDefault()
+	}
+}
+
+func trustDomainMatches(trustDomain string, roots *structs.IndexedCARoots) bool {
+	if trustDomain == "" {
+		return false
+	}
+	if strings.EqualFold(trustDomain, roots.TrustDomain) {
+		return true
+	}
+	for _, root := range roots.Roots {
+		if root.ExternalTrustDomain != "" && strings.EqualFold(trustDomain, root.ExternalTrustDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+func verifiesWithRoot(leaf *x509.Certificate, intermediates *x509.CertPool, root *structs.CARoot, at time.Time) bool {
+	rootCert, err := ParseCert(root.RootCert)
+	if err != nil {
+		return false
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(rootCert)
+
+	for _, pem := range root.IntermediateCerts {
+		if cert, err := ParseCert(pem); err == nil {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// looksLikeJWT reports whether the value has the three dot separated
+// segments of a compact serialized JWT.
+func looksLikeJWT(value string) bool {
+	return !strings.HasPrefix(value, "-----") && strings.Count(value, ".") == 2
+}
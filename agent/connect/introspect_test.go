@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package connect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestIntrospectSVID(t *testing.T) {
+	root := TestCA(t, nil)
+	roots := &structs.IndexedCARoots{
+		ActiveRootID: root.ID,
+		TrustDomain:  TestTrustDomain,
+		Roots:        structs.CARoots{root},
+	}
+	host := TestTrustDomain
+
+	t.Run("service", func(t *testing.T) {
+		leaf, _ := TestLeaf(t, "web", root)
+
+		out, err := IntrospectSVID(leaf, roots, time.Now())
+		require.NoError(t, err)
+		require.True(t, out.Valid, out.InvalidReason)
+		require.Equal(t, "service", out.Kind)
+		require.Equal(t, "spiffe://"+host+"/ns/default/dc/dc1/svc/web", out.SpiffeID)
+		require.Equal(t, host, out.TrustDomain)
+		require.True(t, out.TrustDomainMatches)
+		require.Equal(t, "web", out.Service)
+		require.Equal(t, "dc1", out.Datacenter)
+		require.Equal(t, "default", out.Namespace)
+		require.Equal(t, "default", out.Partition)
+		require.Equal(t, root.ID, out.SignedByRootID)
+		require.True(t, out.SignedByActiveRoot)
+		require.Equal(t, root.SigningKeyID, out.SigningKeyID)
+		require.NotEmpty(t, out.SerialNumber)
+	})
+
+	t.Run("workload identity", func(t *testing.T) {
+		id := &SpiffeIDWorkloadIdentity{
+			TrustDomain:      host,
+			Partition:        "default",
+			Namespace:        "default",
+			WorkloadIdentity: "api",
+		}
+		leaf, _, err := testLeafWithID(t, id, "", root, DefaultPrivateKeyType, DefaultPrivateKeyBits, 0)
+		require.NoError(t, err)
+
+		out, err := IntrospectSVID(leaf, roots, time.Now())
+		require.NoError(t, err)
+		require.True(t, out.Valid, out.InvalidReason)
+		require.Equal(t, "workload-identity", out.Kind)
+		require.Equal(t, "api", out.WorkloadIdentity)
+		require.Equal(t, "default", out.Namespace)
+		require.Equal(t, "default", out.Partition)
+		require.Empty(t, out.Service)
+	})
+
+	t.Run("agent", func(t *testing.T) {
+		leaf, _, err := TestAgentLeaf(t, "node-1", "dc2", root, time.Hour)
+		require.NoError(t, err)
+
+		out, err := IntrospectSVID(leaf, roots, time.Now())
+		require.NoError(t, err)
+		require.True(t, out.Valid, out.InvalidReason)
+		require.Equal(t, "agent", out.Kind)
+		require.Equal(t, "node-1", out.Agent)
+		require.Equal(t, "dc2", out.Datacenter)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		leaf, _ := TestLeaf(t, "web", root)
+
+		later := time.Now().Add(20 * 365 * 24 * time.Hour)
+		out, err := IntrospectSVID(leaf, roots, later)
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		require.Contains(t, out.InvalidReason, "certificate expired at")
+		// The root is still reported, to help debugging.
+		require.Equal(t, root.ID, out.SignedByRootID)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		leaf, _ := TestLeaf(t, "web", root)
+		cert, err := ParseCert(leaf)
+		require.NoError(t, err)
+
+		revoked := *roots
+		revoked.RevokedSerialNumbers = []string{EncodeSerialNumber(cert.SerialNumber)}
+
+		out, err := IntrospectSVID(leaf, &revoked, time.Now())
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		require.True(t, out.Revoked)
+		require.Equal(t, "certificate was revoked", out.InvalidReason)
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		other := TestCA(t, nil)
+		leaf, _ := TestLeaf(t, "web", other)
+
+		out, err := IntrospectSVID(leaf, roots, time.Now())
+		require.NoError(t, err)
+		require.False(t, out.Valid)
+		require.Equal(t, "certificate is not signed by a trusted CA root", out.InvalidReason)
+		require.Empty(t, out.SignedByRootID)
+	})
+
+	t.Run("rotated root", func(t *testing.T) {
+		leaf, _ := TestLeaf(t, "web", root)
+		active := TestCA(t, root)
+		rotated := &structs.IndexedCARoots{
+			ActiveRootID: active.ID,
+			TrustDomain:  TestTrustDomain,
+			Roots:        structs.CARoots{active, root},
+		}
+
+		out, err := IntrospectSVID(leaf, rotated, time.Now())
+		require.NoError(t, err)
+		require.True(t, out.Valid, out.InvalidReason)
+		require.Equal(t, root.ID, out.SignedByRootID)
+		require.False(t, out.SignedByActiveRoot)
+	})
+
+	t.Run("other trust domain", func(t *testing.T) {
+		id := &SpiffeIDService{
+			Host:       "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee.consul",
+			Namespace:  "default",
+			Datacenter: "dc1",
+			Service:    "web",
+		}
+		leaf, _, err := testLeafWithID(t, id, "", root, DefaultPrivateKeyType, DefaultPrivateKeyBits, 0)
+		require.NoError(t, err)
+
+		out, err := IntrospectSVID(leaf, roots, time.Now())
+		require.NoError(t, err)
+		require.True(t, out.Valid, out.InvalidReason)
+		require.False(t, out.TrustDomainMatches)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := IntrospectSVID("", roots, time.Now())
+		require.EqualError(t, err, "SVID is required")
+
+		_, err = IntrospectSVID("eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJ3ZWIifQ.c2ln", roots, time.Now())
+		require.EqualError(t, err, "JWT-SVIDs are not issued by Consul, only X.509 SVIDs can be introspected")
+
+		_, err = IntrospectSVID("not a certificate", roots, time.Now())
+		require.ErrorContains(t, err, "failed to parse SVID")
+	})
+}
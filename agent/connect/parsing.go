@@ -191,6 +191,17 @@ func EncodeSerialNumber(serial *big.Int) string {
 	return HexString(serial.Bytes())
 }
 
+// DecodeSerialNumber decodes a serial number encoded by EncodeSerialNumber.
+// The colons are optional.
+func DecodeSerialNumber(s string) (*big.Int, error) {
+	raw := strings.ReplaceAll(strings.TrimSpace(s), ":", "")
+	serial, ok := new(big.Int).SetString(raw, 16)
+	if !ok || serial.Sign() < 0 {
+		return nil, fmt.Errorf("invalid serial number %q", s)
+	}
+	return serial, nil
+}
+
 // EncodeSigningKeyID encodes the given AuthorityKeyId or SubjectKeyId into a
 // colon-hex encoded string suitable for using as a SigningKeyID value.
 func EncodeSigningKeyID(keyID []byte) string { return HexString(keyID) }
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// authorizeDecisionsReportInterval is how often the agent reports the
+// authorization decisions made by its authorize endpoint to the servers.
+const authorizeDecisionsReportInterval = time.Minute
+
+var metricsKeyConnectAuthorize = []string{"agent", "connect", "authorize"}
+
+var ConnectAuthorizeCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyConnectAuthorize,
+		Help: "Increments whenever the agent's authorize endpoint allows or denies a connection, labelled by destination service and result.",
+	},
+}
+
+// authorizeDecisions counts the authorization decisions per destination
+// service until they are reported to the servers.
+type authorizeDecisions struct {
+	lock   sync.Mutex
+	counts map[structs.ServiceName]*structs.IntentionDecisionCount
+}
+
+func newAuthorizeDecisions() *authorizeDecisions {
+	return &authorizeDecisions{counts: make(map[structs.ServiceName]*structs.IntentionDecisionCount)}
+}
+
+func (d *authorizeDecisions) record(destination structs.ServiceName, authorized bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	count, ok := d.counts[destination]
+	if !ok {
+		count = &structs.IntentionDecisionCount{Destination: destination}
+		d.counts[destination] = count
+	}
+	if authorized {
+		count.Allowed++
+	} else {
+		count.Denied++
+	}
+}
+
+// drain returns the decisions recorded since the previous call, sorted by
+// destination.
+func (d *authorizeDecisions) drain() []structs.IntentionDecisionCount {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	result := make([]structs.IntentionDecisionCount, 0, len(d.counts))
+	for _, count := range d.counts {
+		result = append(result, *count)
+	}
+	d.counts = make(map[structs.ServiceName]*structs.IntentionDecisionCount)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Destination.String() < result[j].Destination.String()
+	})
+	return result
+}
+
+// recordAuthorizeDecision emits the metric for a decision of the authorize
+// endpoint, records it for the next report to the servers and logs a sample
+// of the decisions when configured to.
+func (a *Agent) recordAuthorizeDecision(source *connect.SpiffeIDService, destination structs.ServiceName, authorized bool, reason string) {
+	result := "deny"
+	if authorized {
+		result = "allow"
+	}
+	metrics.IncrCounterWithLabels(metricsKeyConnectAuthorize, 1, []metrics.Label{
+		{Name: "destination", Value: destination.String()},
+		{Name: "result", Value: result},
+	})
+
+	a.authorizeDecisions.record(destination, authorized)
+
+	if rate := a.config.ConnectAuthorizeLogSampleRate; rate > 0 && rand.Float64() < rate {
+		a.logger.Info("connect authorize decision",
+			"source", source.URI().String(),
+			"destination", destination.String(),
+			"result", result,
+			"reason", reason,
+		)
+	}
+}
+
+// sendAuthorizeDecisions periodically reports the authorization decisions to
+// the servers, which aggregate the decisions of all the agents.
+func (a *Agent) sendAuthorizeDecisions() {
+	ticker := time.NewTicker(authorizeDecisionsReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			decisions := a.authorizeDecisions.drain()
+			if len(decisions) == 0 {
+				continue
+			}
+
+			agentToken := a.tokens.AgentToken()
+			req := structs.IntentionDecisionsRequest{
+				Datacenter:     a.config.Datacenter,
+				Node:           a.config.NodeName,
+				Decisions:      decisions,
+				EnterpriseMeta: *a.AgentEnterpriseMeta(),
+				WriteRequest:   structs.WriteRequest{Token: agentToken},
+			}
+			var reply struct{}
+			if err := a.RPC(context.Background(), "Intention.ReportDecisions", &req, &reply); err != nil {
+				if acl.IsErrPermissionDenied(err) {
+					accessorID := a.aclAccessorID(agentToken)
+					a.logger.Warn("Authorize decisions report blocked by ACLs", "accessorID", acl.AliasIfAnonymousToken(accessorID))
+				} else {
+					a.logger.Error("Authorize decisions report error", "error", err)
+				}
+			}
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
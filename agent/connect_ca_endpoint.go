@@ -4,14 +4,24 @@
 package agent
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/consul"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
+// ocspMaxRequestSize is the maximum size of an OCSP request body. A request
+// for a single certificate is around a hundred bytes.
+const ocspMaxRequestSize = 64 * 1024
+
 // GET /v1/connect/ca/roots
 func (s *HTTPHandlers) ConnectCARoots(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.DCSpecificRequest
@@ -107,3 +117,168 @@ func (s *HTTPHandlers) ConnectCAConfigurationSet(req *http.Request) (interface{}
 	}
 	return nil, err
 }
+
+// POST /v1/connect/ca/introspect
+func (s *HTTPHandlers) ConnectCAIntrospect(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var body structs.SVIDIntrospectRequest
+	if err := decodeBody(req.Body, &body); err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Request decode failed: %v", err)}
+	}
+
+	var roots structs.IndexedCARoots
+	defer setMeta(resp, &roots.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ConnectCA.Roots", &args, &roots); err != nil {
+		return nil, err
+	}
+
+	out, err := connect.IntrospectSVID(body.SVID, &roots, time.Now())
+	if err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: err.Error()}
+	}
+	return out, nil
+}
+
+// GET /v1/connect/ca/inventory
+func (s *HTTPHandlers) ConnectCAInventory(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.CALeafInventory
+	defer setMeta(resp, &reply.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ConnectCA.Inventory", &args, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Identities == nil {
+		reply.Identities = make([]*structs.CALeafInventoryEntry, 0)
+	}
+	return reply, nil
+}
+
+// /v1/connect/ca/revocations
+func (s *HTTPHandlers) ConnectCARevocations(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.ConnectCARevokedCerts(resp, req)
+
+	case "PUT":
+		return s.ConnectCARevoke(req)
+
+	default:
+		return nil, MethodNotAllowedError{req.Method, []string{"GET", "PUT"}}
+	}
+}
+
+// GET /v1/connect/ca/revocations
+func (s *HTTPHandlers) ConnectCARevokedCerts(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Method is tested in ConnectCARevocations
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.IndexedCARevokedCerts
+	defer setMeta(resp, &reply.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "ConnectCA.RevokedCerts", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.RevokedCerts, nil
+}
+
+// PUT /v1/connect/ca/revocations
+func (s *HTTPHandlers) ConnectCARevoke(req *http.Request) (interface{}, error) {
+	// Method is tested in ConnectCARevocations
+	var args structs.CARevokeRequest
+	if err := decodeBody(req.Body, &args); err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Request decode failed: %v", err)}
+	}
+	s.parseDC(req, &args.Datacenter)
+	s.parseToken(req, &args.Token)
+
+	if args.Certificate == "" && args.SerialNumber == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Certificate or SerialNumber is required"}
+	}
+	if args.SerialNumber != "" {
+		if _, err := connect.DecodeSerialNumber(args.SerialNumber); err != nil {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: err.Error()}
+		}
+	}
+
+	var reply interface{}
+	return nil, s.agent.RPC(req.Context(), "ConnectCA.Revoke", &args, &reply)
+}
+
+// GET /v1/connect/ca/crl
+func (s *HTTPHandlers) ConnectCARevocationList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	pemResponse := false
+	if pemParam := req.URL.Query().Get("pem"); pemParam != "" {
+		val, err := strconv.ParseBool(pemParam)
+		if err != nil {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "The 'pem' query parameter must be a boolean value"}
+		}
+		pemResponse = val
+	}
+
+	var reply structs.CARevocationList
+	if err := s.agent.RPC(req.Context(), "ConnectCA.RevocationList", &args, &reply); err != nil {
+		return nil, err
+	}
+
+	if pemResponse {
+		resp.Header().Set("Content-Type", "application/x-pem-file")
+		return nil, pem.Encode(resp, &pem.Block{Type: "X509 CRL", Bytes: reply.CRL})
+	}
+
+	// defined in RFC 5280 and registered with the IANA
+	resp.Header().Set("Content-Type", "application/pkix-crl")
+	_, err := resp.Write(reply.CRL)
+	return nil, err
+}
+
+// POST /v1/connect/ca/ocsp
+// GET /v1/connect/ca/ocsp/<base64 encoded request>
+func (s *HTTPHandlers) ConnectCAOCSP(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.CAOCSPRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	switch req.Method {
+	case "POST":
+		body, err := io.ReadAll(io.LimitReader(req.Body, ocspMaxRequestSize))
+		if err != nil {
+			return nil, err
+		}
+		args.Request = body
+	default:
+		// RFC 6960 Appendix A.1: the request is base64 encoded, then URL
+		// encoded, which the router already decoded.
+		encoded := strings.TrimPrefix(req.URL.Path, "/v1/connect/ca/ocsp/")
+		body, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "The OCSP request must be base64 encoded"}
+		}
+		args.Request = body
+	}
+
+	var reply structs.CAOCSPResponse
+	if err := s.agent.RPC(req.Context(), "ConnectCA.OCSP", &args, &reply); err != nil {
+		return nil, err
+	}
+
+	// defined in RFC 6960 and registered with the IANA
+	resp.Header().Set("Content-Type", "application/ocsp-response")
+	_, err := resp.Write(reply.Response)
+	return nil, err
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
 
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
@@ -72,6 +73,142 @@ func TestConnectCARoots_list(t *testing.T) {
 	}
 }
 
+func TestConnectCAIntrospect(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	ca := connect.TestCAConfigSet(t, a, nil)
+	leaf, _ := connect.TestLeaf(t, "web", ca)
+
+	req, _ := http.NewRequest("POST", "/v1/connect/ca/introspect", jsonReader(structs.SVIDIntrospectRequest{SVID: leaf}))
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.ConnectCAIntrospect(resp, req)
+	require.NoError(t, err)
+
+	out := obj.(*structs.SVIDIntrospection)
+	require.True(t, out.Valid, out.InvalidReason)
+	require.Equal(t, "service", out.Kind)
+	require.Equal(t, "web", out.Service)
+	require.Equal(t, ca.ID, out.SignedByRootID)
+	require.True(t, out.SignedByActiveRoot)
+
+	// A certificate the agent can't parse is rejected.
+	req, _ = http.NewRequest("POST", "/v1/connect/ca/introspect", jsonReader(structs.SVIDIntrospectRequest{SVID: "not a certificate"}))
+	_, err = a.srv.ConnectCAIntrospect(httptest.NewRecorder(), req)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+}
+
+func TestConnectCAInventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	req, _ := http.NewRequest("GET", "/v1/connect/ca/inventory", nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.ConnectCAInventory(resp, req)
+	require.NoError(t, err)
+
+	inventory := obj.(structs.CALeafInventory)
+	require.False(t, inventory.Since.IsZero())
+	require.NotNil(t, inventory.Identities)
+	require.NotEmpty(t, resp.Header().Get("X-Consul-KnownLeader"))
+}
+
+func TestConnectCARevocations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	ca := connect.TestCAConfigSet(t, a, nil)
+	leaf, _ := connect.TestLeaf(t, "web", ca)
+	cert, err := connect.ParseCert(leaf)
+	require.NoError(t, err)
+	issuer, err := connect.ParseCert(ca.RootCert)
+	require.NoError(t, err)
+
+	// A request without a certificate or serial number is rejected.
+	req, _ := http.NewRequest("PUT", "/v1/connect/ca/revocations", jsonReader(structs.CARevokeRequest{}))
+	_, err = a.srv.ConnectCARevocations(httptest.NewRecorder(), req)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+
+	// So is a malformed serial number.
+	req, _ = http.NewRequest("PUT", "/v1/connect/ca/revocations", jsonReader(structs.CARevokeRequest{SerialNumber: "zz"}))
+	_, err = a.srv.ConnectCARevocations(httptest.NewRecorder(), req)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+
+	req, _ = http.NewRequest("PUT", "/v1/connect/ca/revocations", jsonReader(structs.CARevokeRequest{Certificate: leaf}))
+	_, err = a.srv.ConnectCARevocations(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+
+	req, _ = http.NewRequest("GET", "/v1/connect/ca/revocations", nil)
+	obj, err := a.srv.ConnectCARevocations(httptest.NewRecorder(), req)
+	require.NoError(t, err)
+	revoked := obj.(structs.CARevokedCerts)
+	require.Len(t, revoked, 1)
+	require.Equal(t, connect.EncodeSerialNumber(cert.SerialNumber), revoked[0].SerialNumber)
+
+	t.Run("crl", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/connect/ca/crl?pem=true", nil)
+		resp := httptest.NewRecorder()
+		_, err := a.srv.ConnectCARevocationList(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, "application/x-pem-file", resp.Header().Get("Content-Type"))
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		require.Equal(t, "X509 CRL", block.Type)
+
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		require.NoError(t, err)
+		require.NoError(t, crl.CheckSignatureFrom(issuer))
+		require.Len(t, crl.RevokedCertificateEntries, 1)
+		require.Equal(t, cert.SerialNumber, crl.RevokedCertificateEntries[0].SerialNumber)
+	})
+
+	t.Run("ocsp", func(t *testing.T) {
+		ocspReq, err := ocsp.CreateRequest(cert, issuer, nil)
+		require.NoError(t, err)
+
+		req, _ := http.NewRequest("POST", "/v1/connect/ca/ocsp", bytes.NewReader(ocspReq))
+		resp := httptest.NewRecorder()
+		_, err = a.srv.ConnectCAOCSP(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, "application/ocsp-response", resp.Header().Get("Content-Type"))
+
+		ocspResp, err := ocsp.ParseResponseForCert(resp.Body.Bytes(), cert, issuer)
+		require.NoError(t, err)
+		require.Equal(t, ocsp.Revoked, ocspResp.Status)
+
+		// A GET request with an undecodable path is rejected.
+		req, _ = http.NewRequest("GET", "/v1/connect/ca/ocsp/!!", nil)
+		_, err = a.srv.ConnectCAOCSP(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+	})
+}
+
 func TestConnectCAConfig(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
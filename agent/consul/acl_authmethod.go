@@ -10,8 +10,11 @@ import (
 	"github.com/hashicorp/consul/agent/structs"
 
 	// register these as a builtin auth method
+	_ "github.com/hashicorp/consul/agent/consul/authmethod/attestauth"
 	_ "github.com/hashicorp/consul/agent/consul/authmethod/awsauth"
+	_ "github.com/hashicorp/consul/agent/consul/authmethod/certauth"
 	_ "github.com/hashicorp/consul/agent/consul/authmethod/kubeauth"
+	_ "github.com/hashicorp/consul/agent/consul/authmethod/socketauth"
 	_ "github.com/hashicorp/consul/agent/consul/authmethod/ssoauth"
 )
 
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"golang.org/x/time/rate"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/breakglass"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+)
+
+const (
+	// aclBreakGlassUnsealTimeout is how long the operators have to submit a
+	// threshold of shares once the first one was submitted.
+	aclBreakGlassUnsealTimeout = 15 * time.Minute
+
+	// aclBreakGlassUnsealRateLimit is the number of shares per second which
+	// can be submitted, as the unseal doesn't require a token.
+	aclBreakGlassUnsealRateLimit rate.Limit = 1.0
+
+	// aclBreakGlassUnsealBurst is the number of shares which can be submitted
+	// at once after a period of idleness.
+	aclBreakGlassUnsealBurst = 10
+)
+
+var errBreakGlassNotInitialized = errors.New("the ACL break-glass seal is not initialized")
+
+// aclBreakGlassSeal returns the break-glass seal stored in the state store, or
+// nil if it was never initialized.
+func (s *Server) aclBreakGlassSeal() (*breakglass.Seal, error) {
+	value, err := s.GetSystemMetadata(structs.SystemMetadataACLBreakGlassSeal)
+	if err != nil {
+		return nil, err
+	}
+	return breakglass.DecodeSeal(value)
+}
+
+func (s *Server) aclBreakGlassStatus(seal *breakglass.Seal) structs.ACLBreakGlassStatus {
+	if seal == nil {
+		return structs.ACLBreakGlassStatus{}
+	}
+	return structs.ACLBreakGlassStatus{
+		Initialized: true,
+		Shares:      seal.Shares,
+		Threshold:   seal.Threshold,
+		TTL:         seal.TTL,
+		CreateTime:  seal.CreateTime,
+		Progress:    s.aclBreakGlass.Progress(seal),
+	}
+}
+
+// BreakGlassInit initializes the break-glass seal and returns the key shares
+// to hand to the operators. Initializing it again replaces the seal, the
+// shares of the previous one can no longer activate the token. As the shares
+// activate a global management token, it requires both acl:write and
+// operator:write, and namespace administrators may not initialize the seal.
+func (a *ACL) BreakGlassInit(args *structs.ACLBreakGlassInitRequest, reply *structs.ACLBreakGlassInitResponse) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	// The break-glass token is a global token, so the seal is kept in the
+	// primary datacenter.
+	args.Datacenter = a.srv.config.PrimaryDatacenter
+	if done, err := a.srv.ForwardRPC("ACL.BreakGlassInit", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, nil, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorWriteAllowed(&authzContext); err != nil {
+		return err
+	}
+	if guard, err := a.namespaceAdmin(authz); err != nil {
+		return err
+	} else if err := guard.deny("initialize the break-glass seal"); err != nil {
+		return err
+	}
+
+	if args.TTL < a.srv.config.ACLTokenMinExpirationTTL || args.TTL > a.srv.config.ACLTokenMaxExpirationTTL {
+		return fmt.Errorf("TTL must be between %s and %s",
+			a.srv.config.ACLTokenMinExpirationTTL, a.srv.config.ACLTokenMaxExpirationTTL)
+	}
+
+	seal, shares, err := breakglass.NewSeal(args.Shares, args.Threshold, args.TTL)
+	if err != nil {
+		return err
+	}
+	encoded, err := seal.Encode()
+	if err != nil {
+		return err
+	}
+	if err := a.srv.SetSystemMetadataKey(structs.SystemMetadataACLBreakGlassSeal, encoded); err != nil {
+		return err
+	}
+
+	a.logger.Warn("ACL break-glass seal initialized",
+		"shares", seal.Shares,
+		"threshold", seal.Threshold,
+		"ttl", seal.TTL,
+		"accessor_id", authz.AccessorID(),
+	)
+	reply.Shares = shares
+	return nil
+}
+
+// BreakGlassUnseal submits a key share of the break-glass seal. Once a
+// threshold of shares is submitted, a management token expiring after the TTL
+// of the seal is created and returned. It doesn't require any ACL token, so
+// that it can be used to recover from an ACL lockout, and is rate limited
+// instead.
+func (a *ACL) BreakGlassUnseal(args *structs.ACLBreakGlassUnsealRequest, reply *structs.ACLBreakGlassUnsealResponse) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	args.Datacenter = a.srv.config.PrimaryDatacenter
+	if done, err := a.srv.ForwardRPC("ACL.BreakGlassUnseal", args, reply); done {
+		return err
+	}
+
+	if !a.srv.aclBreakGlassLimiter.Allow() {
+		return structs.ErrRPCRateExceeded
+	}
+
+	seal, err := a.srv.aclBreakGlassSeal()
+	if err != nil {
+		return err
+	}
+	if seal == nil {
+		return errBreakGlassNotInitialized
+	}
+
+	progress, unsealed, err := a.srv.aclBreakGlass.Add(seal, args.Share)
+	if err != nil {
+		a.logger.Warn("ACL break-glass share rejected", "error", err)
+		return err
+	}
+	a.logger.Warn("ACL break-glass share submitted", "progress", progress, "threshold", seal.Threshold)
+
+	reply.Status = a.srv.aclBreakGlassStatus(seal)
+	if !unsealed {
+		return nil
+	}
+
+	token, err := a.srv.createBreakGlassToken(seal)
+	if err != nil {
+		return err
+	}
+
+	// The activation must not go unnoticed.
+	metrics.IncrCounter([]string{"acl", "break_glass", "activated"}, 1)
+	a.logger.Error("ACL BREAK-GLASS TOKEN ACTIVATED: a management token was created from the break-glass key shares",
+		"accessor_id", token.AccessorID,
+		"expiration_time", token.ExpirationTime,
+	)
+	reply.Token = token
+	return nil
+}
+
+// BreakGlassStatus returns the status of the break-glass seal. It doesn't
+// require any ACL token.
+func (a *ACL) BreakGlassStatus(args *structs.ACLBreakGlassStatusRequest, reply *structs.ACLBreakGlassStatusResponse) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	// The progress of the unseal is only known by the leader.
+	args.Datacenter = a.srv.config.PrimaryDatacenter
+	args.AllowStale = false
+	if done, err := a.srv.ForwardRPC("ACL.BreakGlassStatus", args, reply); done {
+		return err
+	}
+
+	seal, err := a.srv.aclBreakGlassSeal()
+	if err != nil {
+		return err
+	}
+	reply.ACLBreakGlassStatus = a.srv.aclBreakGlassStatus(seal)
+	return nil
+}
+
+// createBreakGlassToken creates a global management token expiring after the
+// TTL of the seal.
+func (s *Server) createBreakGlassToken(seal *breakglass.Seal) (*structs.ACLToken, error) {
+	accessor, err := lib.GenerateUUID(s.checkTokenUUID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := lib.GenerateUUID(s.checkTokenUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiration := now.Add(seal.TTL)
+	token := structs.ACLToken{
+		AccessorID:  accessor,
+		SecretID:    secret,
+		Description: "Break-Glass Token (Global Management)",
+		Policies: []structs.ACLTokenPolicyLink{
+			{
+				ID: structs.ACLPolicyGlobalManagementID,
+			},
+		},
+		CreateTime:     now,
+		ExpirationTime: &expiration,
+		Local:          false,
+		EnterpriseMeta: *structs.DefaultEnterpriseMetaInDefaultPartition(),
+	}
+	token.SetHash(true)
+
+	req := structs.ACLTokenBatchSetRequest{
+		Tokens: structs.ACLTokens{&token},
+		CAS:    false,
+	}
+	if _, err := s.raftApply(structs.ACLTokenSetRequestType, &req); err != nil {
+		return nil, err
+	}
+
+	_, created, err := s.fsm.State().ACLTokenGetByAccessor(nil, accessor, structs.DefaultEnterpriseMetaInDefaultPartition())
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, acl.ErrNotFound
+	}
+	return created, nil
+}
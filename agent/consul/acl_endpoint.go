@@ -22,6 +22,8 @@ import (
 	"github.com/hashicorp/consul/acl/resolver"
 	"github.com/hashicorp/consul/agent/consul/auth"
 	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/consul/authmethod/certauth"
+	"github.com/hashicorp/consul/agent/consul/authmethod/socketauth"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/structs/aclfilter"
@@ -282,6 +284,7 @@ func (a *ACL) TokenRead(args *structs.ACLTokenGetRequest, reply *structs.ACLToke
 	}
 
 	var authz resolver.Result
+	var guard *namespaceAdminGuard
 
 	if args.TokenIDType == structs.ACLTokenAccessor {
 		var err error
@@ -293,6 +296,8 @@ func (a *ACL) TokenRead(args *structs.ACLTokenGetRequest, reply *structs.ACLToke
 			return err
 		} else if err := authz.ToAllowAuthorizer().ACLReadAllowed(&authzContext); err != nil {
 			return err
+		} else if guard, err = a.namespaceAdmin(authz); err != nil {
+			return err
 		}
 	}
 
@@ -306,6 +311,7 @@ func (a *ACL) TokenRead(args *structs.ACLTokenGetRequest, reply *structs.ACLToke
 				index, token, err = state.ACLTokenGetByAccessor(ws, args.TokenID, &args.EnterpriseMeta)
 				if token != nil {
 					a.srv.filterACLWithAuthorizer(authz, &token)
+					guard.redactToken(&token)
 
 					// token secret was redacted
 					if token.SecretID == aclfilter.RedactedToken {
@@ -481,6 +487,11 @@ func (a *ACL) TokenClone(args *structs.ACLTokenSetRequest, reply *structs.ACLTok
 		return err
 	}
 
+	guard, err := a.namespaceAdmin(authz)
+	if err != nil {
+		return err
+	}
+
 	_, token, err := a.srv.fsm.State().ACLTokenGetByAccessor(nil, args.ACLToken.AccessorID, &args.ACLToken.EnterpriseMeta)
 	if err != nil {
 		return err
@@ -501,6 +512,10 @@ func (a *ACL) TokenClone(args *structs.ACLTokenSetRequest, reply *structs.ACLTok
 		return fmt.Errorf("Cannot clone a token created from an auth method")
 	}
 
+	if err := guard.vetToken(token); err != nil {
+		return err
+	}
+
 	clone := &structs.ACLToken{
 		Policies:          token.Policies,
 		Roles:             token.Roles,
@@ -548,10 +563,24 @@ func (a *ACL) TokenSet(args *structs.ACLTokenSetRequest, reply *structs.ACLToken
 
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.ACLToken.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
+	}
+
+	// Namespace administrators cannot grant, or take over tokens with,
+	// permissions beyond their own namespace.
+	if err := guard.vetToken(&args.ACLToken); err != nil {
+		return err
+	}
+	if !args.Create {
+		if err := guard.vetExistingToken(args.ACLToken.AccessorID, &args.ACLToken.EnterpriseMeta); err != nil {
+			return err
+		}
 	}
 
 	var (
@@ -592,10 +621,13 @@ func (a *ACL) TokenDelete(args *structs.ACLTokenDeleteRequest, reply *string) er
 
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
 	}
 
 	if _, err := uuid.ParseUUID(args.TokenID); err != nil {
@@ -617,6 +649,10 @@ func (a *ACL) TokenDelete(args *structs.ACLTokenDeleteRequest, reply *string) er
 			return fmt.Errorf("Deletion of the request's authorization token is not permitted")
 		}
 
+		if err := guard.vetToken(token); err != nil {
+			return err
+		}
+
 		// No need to check expiration time because it's being deleted.
 
 		// token found in secondary DC but its not local so it must be deleted in the primary
@@ -690,6 +726,11 @@ func (a *ACL) TokenList(args *structs.ACLTokenListRequest, reply *structs.ACLTok
 		return err
 	}
 
+	guard, err := a.namespaceAdmin(authz)
+	if err != nil {
+		return err
+	}
+
 	var methodMeta *acl.EnterpriseMeta
 	if args.AuthMethod != "" {
 		methodMeta = args.ACLAuthMethodEnterpriseMeta.ToEnterpriseMeta()
@@ -729,6 +770,7 @@ func (a *ACL) TokenList(args *structs.ACLTokenListRequest, reply *structs.ACLTok
 
 			// filter down to just the tokens that the requester has permissions to read
 			a.srv.filterACLWithAuthorizer(authz, &stubs)
+			guard.redactTokenStubs(stubs)
 
 			reply.Index, reply.Tokens = index, stubs
 			return nil
@@ -753,6 +795,11 @@ func (a *ACL) TokenBatchRead(args *structs.ACLTokenBatchGetRequest, reply *struc
 		return err
 	}
 
+	guard, err := a.namespaceAdmin(authz)
+	if err != nil {
+		return err
+	}
+
 	return a.srv.blockingQuery(&args.QueryOptions, &reply.QueryMeta,
 		func(ws memdb.WatchSet, state *state.Store) error {
 			index, tokens, err := state.ACLTokenBatchGet(ws, args.AccessorIDs)
@@ -772,6 +819,7 @@ func (a *ACL) TokenBatchRead(args *structs.ACLTokenBatchGetRequest, reply *struc
 			for _, token := range tokens {
 				final := token
 				a.srv.filterACLWithAuthorizer(authz, &final)
+				guard.redactToken(&final)
 				if final != nil {
 					ret = append(ret, final)
 					if final.SecretID == aclfilter.RedactedToken {
@@ -882,10 +930,13 @@ func (a *ACL) PolicySet(args *structs.ACLPolicySetRequest, reply *structs.ACLPol
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
 
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.Policy.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
 	}
 
 	policy := &args.Policy
@@ -963,6 +1014,14 @@ func (a *ACL) PolicySet(args *structs.ACLPolicySetRequest, reply *structs.ACLPol
 		return err
 	}
 
+	// namespace administrators cannot grant permissions beyond their namespace
+	if err := guard.vetPolicy(idMatch); err != nil {
+		return err
+	}
+	if err := guard.vetPolicy(policy); err != nil {
+		return err
+	}
+
 	// validate the enterprise specific fields
 	if err = a.policyUpsertValidateEnterprise(policy, idMatch); err != nil {
 		return err
@@ -1012,10 +1071,13 @@ func (a *ACL) PolicyDelete(args *structs.ACLPolicyDeleteRequest, reply *string)
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
 
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
 	}
 
 	_, policy, err := a.srv.fsm.State().ACLPolicyGetByID(nil, args.PolicyID, &args.EnterpriseMeta)
@@ -1034,6 +1096,10 @@ func (a *ACL) PolicyDelete(args *structs.ACLPolicyDeleteRequest, reply *string)
 		return fmt.Errorf("Delete operation not permitted on the builtin %s policy", builtinPolicy.Name)
 	}
 
+	if err := guard.vetPolicy(policy); err != nil {
+		return err
+	}
+
 	req := structs.ACLPolicyBatchDeleteRequest{
 		PolicyIDs: []string{args.PolicyID},
 	}
@@ -1271,10 +1337,13 @@ func (a *ACL) RoleSet(args *structs.ACLRoleSetRequest, reply *structs.ACLRole) e
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
 
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.Role.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
 	}
 
 	role := &args.Role
@@ -1331,6 +1400,14 @@ func (a *ACL) RoleSet(args *structs.ACLRoleSetRequest, reply *structs.ACLRole) e
 	}
 
 	// validate the enterprise specific fields
+	// namespace administrators cannot grant permissions beyond their namespace
+	if err := guard.vetRole(existing); err != nil {
+		return err
+	}
+	if err := guard.vetRole(role); err != nil {
+		return err
+	}
+
 	if err := a.roleUpsertValidateEnterprise(role, existing); err != nil {
 		return err
 	}
@@ -1450,10 +1527,13 @@ func (a *ACL) RoleDelete(args *structs.ACLRoleDeleteRequest, reply *string) erro
 	// Verify token is permitted to modify ACLs
 	var authzContext acl.AuthorizerContext
 
+	var guard *namespaceAdminGuard
 	if authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext); err != nil {
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err = a.namespaceAdmin(authz); err != nil {
+		return err
 	}
 
 	_, role, err := a.srv.fsm.State().ACLRoleGetByID(nil, args.RoleID, &args.EnterpriseMeta)
@@ -1468,6 +1548,10 @@ func (a *ACL) RoleDelete(args *structs.ACLRoleDeleteRequest, reply *string) erro
 		return fmt.Errorf("role does not exist: %w", acl.ErrNotFound)
 	}
 
+	if err := guard.vetRole(role); err != nil {
+		return err
+	}
+
 	req := structs.ACLRoleBatchDeleteRequest{
 		RoleIDs: []string{args.RoleID},
 	}
@@ -1646,6 +1730,10 @@ func (a *ACL) BindingRuleSet(args *structs.ACLBindingRuleSetRequest, reply *stru
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err := a.namespaceAdmin(authz); err != nil {
+		return err
+	} else if err := guard.deny("write binding rules"); err != nil {
+		return err
 	}
 
 	var existing *structs.ACLBindingRule
@@ -1769,6 +1857,10 @@ func (a *ACL) BindingRuleDelete(args *structs.ACLBindingRuleDeleteRequest, reply
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err := a.namespaceAdmin(authz); err != nil {
+		return err
+	} else if err := guard.deny("delete binding rules"); err != nil {
+		return err
 	}
 
 	_, rule, err := a.srv.fsm.State().ACLBindingRuleGetByID(nil, args.BindingRuleID, &args.EnterpriseMeta)
@@ -1905,6 +1997,10 @@ func (a *ACL) AuthMethodSet(args *structs.ACLAuthMethodSetRequest, reply *struct
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err := a.namespaceAdmin(authz); err != nil {
+		return err
+	} else if err := guard.deny("write auth methods"); err != nil {
+		return err
 	}
 
 	method := &args.AuthMethod
@@ -2017,6 +2113,10 @@ func (a *ACL) AuthMethodDelete(args *structs.ACLAuthMethodDeleteRequest, reply *
 		return err
 	} else if err := authz.ToAllowAuthorizer().ACLWriteAllowed(&authzContext); err != nil {
 		return err
+	} else if guard, err := a.namespaceAdmin(authz); err != nil {
+		return err
+	} else if err := guard.deny("delete auth methods"); err != nil {
+		return err
 	}
 
 	_, method, err := a.srv.fsm.State().ACLAuthMethodGetByName(nil, args.AuthMethodName, &args.EnterpriseMeta)
@@ -2128,6 +2228,10 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLToken) erro
 		return err
 	}
 
+	if err := auth.ValidateBearerTokenLogin(authMethod); err != nil {
+		return err
+	}
+
 	verifiedIdentity, err := validator.ValidateLogin(context.Background(), args.Auth.BearerToken)
 	if err != nil {
 		return err
@@ -2145,6 +2249,141 @@ func (a *ACL) Login(args *structs.ACLLoginRequest, reply *structs.ACLToken) erro
 	return err
 }
 
+// ClientCertLogin creates a token for a client which presented a certificate to
+// an agent's HTTPS API. It is only used by agents, which must hold node:write
+// on their own node.
+func (a *ACL) ClientCertLogin(args *structs.ACLClientCertLoginRequest, reply *structs.ACLToken) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	if !a.srv.LocalTokensEnabled() {
+		return errAuthMethodsRequireTokenReplication
+	}
+
+	if args.Auth == nil {
+		return fmt.Errorf("Invalid Login request: Missing auth parameters")
+	}
+
+	if err := a.srv.validateEnterpriseRequest(&args.Auth.EnterpriseMeta, true); err != nil {
+		return err
+	}
+
+	if done, err := a.srv.ForwardRPC("ACL.ClientCertLogin", args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"acl", "login"}, time.Now())
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, nil, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().NodeWriteAllowed(args.Node, &authzContext); err != nil {
+		return err
+	}
+
+	authMethod, validator, err := a.srv.loadAuthMethod(args.Auth.AuthMethod, &args.Auth.EnterpriseMeta)
+	if err != nil {
+		return err
+	}
+
+	if authMethod.Type != certauth.AuthMethodType {
+		return fmt.Errorf("auth method %q is not of type %q", authMethod.Name, certauth.AuthMethodType)
+	}
+
+	verifiedIdentity, err := validator.ValidateLogin(context.Background(), args.Auth.BearerToken)
+	if err != nil {
+		return err
+	}
+
+	meta := map[string]string{"node": args.Node}
+	for k, v := range args.Auth.Meta {
+		meta[k] = v
+	}
+	description, err := auth.BuildTokenDescription("token created via client certificate login", meta)
+	if err != nil {
+		return err
+	}
+
+	token, err := a.srv.aclLogin().TokenForVerifiedIdentity(verifiedIdentity, authMethod, description)
+	if err == nil {
+		*reply = *token
+	}
+	return err
+}
+
+// SocketCredLogin creates a token for a client connected to one of an agent's
+// Unix domain sockets, from the peer credentials of its process. It is only
+// used by agents, which must hold node:write on their own node.
+func (a *ACL) SocketCredLogin(args *structs.ACLSocketCredLoginRequest, reply *structs.ACLToken) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	if !a.srv.LocalTokensEnabled() {
+		return errAuthMethodsRequireTokenReplication
+	}
+
+	if args.Auth == nil {
+		return fmt.Errorf("Invalid Login request: Missing auth parameters")
+	}
+
+	if err := a.srv.validateEnterpriseRequest(&args.Auth.EnterpriseMeta, true); err != nil {
+		return err
+	}
+
+	if done, err := a.srv.ForwardRPC("ACL.SocketCredLogin", args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"acl", "login"}, time.Now())
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, nil, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().NodeWriteAllowed(args.Node, &authzContext); err != nil {
+		return err
+	}
+
+	authMethod, validator, err := a.srv.loadAuthMethod(args.Auth.AuthMethod, &args.Auth.EnterpriseMeta)
+	if err != nil {
+		return err
+	}
+
+	if authMethod.Type != socketauth.AuthMethodType {
+		return fmt.Errorf("auth method %q is not of type %q", authMethod.Name, socketauth.AuthMethodType)
+	}
+
+	loginToken, err := socketauth.LoginToken(args.Node, args.Credentials)
+	if err != nil {
+		return err
+	}
+
+	verifiedIdentity, err := validator.ValidateLogin(context.Background(), loginToken)
+	if err != nil {
+		return err
+	}
+
+	meta := map[string]string{"node": args.Node}
+	for k, v := range args.Auth.Meta {
+		meta[k] = v
+	}
+	description, err := auth.BuildTokenDescription("token created via socket credentials login", meta)
+	if err != nil {
+		return err
+	}
+
+	token, err := a.srv.aclLogin().TokenForVerifiedIdentity(verifiedIdentity, authMethod, description)
+	if err == nil {
+		*reply = *token
+	}
+	return err
+}
+
 func (a *ACL) Logout(args *structs.ACLLogoutRequest, reply *bool) error {
 	if err := a.aclPreCheck(); err != nil {
 		return err
@@ -2201,3 +2440,145 @@ func (a *ACL) Authorize(args *structs.RemoteACLAuthorizationRequest, reply *[]st
 	*reply = responses
 	return nil
 }
+
+var errTokenUsageTrackingDisabled = errors.New("ACL token usage tracking is disabled, it can be enabled on the servers with acl.enable_token_usage_tracking")
+
+// ReportTokenUsage merges the usage of the tokens reported by an agent. The
+// usage is aggregated on the leader, so the request is forwarded to it.
+func (a *ACL) ReportTokenUsage(args *structs.ACLTokenUsageReportRequest, reply *struct{}) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	if done, err := a.srv.ForwardRPC("ACL.ReportTokenUsage", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := a.srv.validateEnterpriseRequest(&args.EnterpriseMeta, true); err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().NodeWriteAllowed(args.Node, &authzContext); err != nil {
+		return err
+	}
+
+	if a.srv.aclTokenUsage == nil {
+		return errTokenUsageTrackingDisabled
+	}
+	a.srv.aclTokenUsage.Merge(args.Usage)
+	return nil
+}
+
+// TokenUsage returns the usage of a token in the datacenter. The usage is
+// aggregated on the leader, so the request is always forwarded to it.
+func (a *ACL) TokenUsage(args *structs.ACLTokenUsageRequest, reply *structs.ACLTokenUsageResponse) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	args.AllowStale = false
+	if done, err := a.srv.ForwardRPC("ACL.TokenUsage", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := a.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().ACLReadAllowed(&authzContext); err != nil {
+		return err
+	}
+
+	if a.srv.aclTokenUsage == nil {
+		return errTokenUsageTrackingDisabled
+	}
+
+	_, token, err := a.srv.fsm.State().ACLTokenGetByAccessor(nil, args.AccessorID, &args.EnterpriseMeta)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return acl.ErrNotFound
+	}
+
+	reply.ACLTokenUsage, _ = a.srv.aclTokenUsage.Get(token.AccessorID)
+	reply.TrackingSince = a.srv.aclTokenUsage.Since()
+	return nil
+}
+
+// StaleTokens lists the tokens of the datacenter which were not used for the
+// requested duration, including the tokens never used since the tracking
+// started. Tokens created during the duration are not stale yet.
+func (a *ACL) StaleTokens(args *structs.ACLStaleTokensRequest, reply *structs.ACLStaleTokensResponse) error {
+	if err := a.aclPreCheck(); err != nil {
+		return err
+	}
+
+	args.AllowStale = false
+	if done, err := a.srv.ForwardRPC("ACL.StaleTokens", args, reply); done {
+		return err
+	}
+
+	if args.UnusedFor <= 0 {
+		return fmt.Errorf("UnusedFor must be positive")
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := a.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := a.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().ACLReadAllowed(&authzContext); err != nil {
+		return err
+	}
+
+	if a.srv.aclTokenUsage == nil {
+		return errTokenUsageTrackingDisabled
+	}
+
+	_, tokens, err := a.srv.fsm.State().ACLTokenListWithParameters(nil, state.ACLTokenListParameters{
+		Local:          true,
+		Global:         true,
+		EnterpriseMeta: &args.EnterpriseMeta,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-args.UnusedFor)
+	reply.Tokens = make([]structs.ACLStaleToken, 0)
+	for _, token := range tokens {
+		if token.AccessorID == acl.AnonymousTokenID || token.IsExpired(now) || token.CreateTime.After(cutoff) {
+			continue
+		}
+
+		stale := structs.ACLStaleToken{
+			AccessorID:     token.AccessorID,
+			Description:    token.Description,
+			Local:          token.Local,
+			CreateTime:     token.CreateTime,
+			EnterpriseMeta: token.EnterpriseMeta,
+		}
+		if usage, ok := a.srv.aclTokenUsage.Get(token.AccessorID); ok {
+			if usage.LastUsed.After(cutoff) {
+				continue
+			}
+			stale.LastUsed = &usage.LastUsed
+		}
+		reply.Tokens = append(reply.Tokens, stale)
+	}
+	reply.TrackingSince = a.srv.aclTokenUsage.Since()
+	return nil
+}
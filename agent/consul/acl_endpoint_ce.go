@@ -36,3 +36,7 @@ func enterpriseAuthMethodValidation(method *structs.ACLAuthMethod, validator aut
 func getTokenNamespaceDefaults(ws memdb.WatchSet, state *state.Store, entMeta *acl.EnterpriseMeta) ([]string, []string, error) {
 	return nil, nil, nil
 }
+
+func namespaceAdminEnterpriseRulesViolation(_ *acl.Policy) string {
+	return ""
+}
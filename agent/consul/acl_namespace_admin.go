@@ -0,0 +1,317 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/structs/aclfilter"
+	"github.com/hashicorp/consul/api"
+)
+
+// namespaceAdminGuard enforces the guardrails for delegated namespace
+// administrators, i.e. tokens which link the builtin/namespace-admin templated
+// policy, either directly or through one of their roles.
+//
+// Namespace administrators may manage the tokens, roles and policies of their
+// namespace, but may not grant permissions beyond what they could have been
+// given by the templated policy, nor take over or reveal the secrets of tokens
+// which have such permissions. A nil guard allows everything, so the checks
+// can be called unconditionally.
+type namespaceAdminGuard struct {
+	srv *Server
+}
+
+// namespaceAdmin returns the guard for the given caller, or nil if the caller
+// isn't a delegated namespace administrator.
+func (a *ACL) namespaceAdmin(authz resolver.Result) (*namespaceAdminGuard, error) {
+	identity := authz.ACLIdentity
+	if identity == nil {
+		return nil, nil
+	}
+
+	if hasNamespaceAdminTemplatedPolicy(identity.TemplatedPolicyList()) {
+		return &namespaceAdminGuard{srv: a.srv}, nil
+	}
+	for _, id := range identity.RoleIDs() {
+		_, role, err := a.srv.fsm.State().ACLRoleGetByID(nil, id, acl.WildcardEnterpriseMeta())
+		if err != nil {
+			return nil, err
+		}
+		if role != nil && hasNamespaceAdminTemplatedPolicy(role.TemplatedPolicies) {
+			return &namespaceAdminGuard{srv: a.srv}, nil
+		}
+	}
+	return nil, nil
+}
+
+func hasNamespaceAdminTemplatedPolicy(tps structs.ACLTemplatedPolicies) bool {
+	for _, tp := range tps {
+		if tp.TemplateName == api.ACLTemplatedPolicyNamespaceAdminName {
+			return true
+		}
+	}
+	return false
+}
+
+// deny returns a permission denied error for an operation which namespace
+// administrators are never allowed to perform.
+func (g *namespaceAdminGuard) deny(operation string) error {
+	if g == nil {
+		return nil
+	}
+	return acl.PermissionDenied("namespace administrators cannot %s", operation)
+}
+
+// vetToken returns an error if the token has permissions that a namespace
+// administrator is not allowed to grant.
+func (g *namespaceAdminGuard) vetToken(token *structs.ACLToken) error {
+	if g == nil || token == nil {
+		return nil
+	}
+	return g.vetLinks(
+		&token.EnterpriseMeta,
+		token.Policies,
+		token.Roles,
+		token.NodeIdentities,
+		token.TemplatedPolicies,
+	)
+}
+
+// vetRole returns an error if the role has permissions that a namespace
+// administrator is not allowed to grant.
+func (g *namespaceAdminGuard) vetRole(role *structs.ACLRole) error {
+	if g == nil || role == nil {
+		return nil
+	}
+	policies := make([]structs.ACLTokenPolicyLink, 0, len(role.Policies))
+	for _, link := range role.Policies {
+		policies = append(policies, structs.ACLTokenPolicyLink(link))
+	}
+	return g.vetLinks(
+		&role.EnterpriseMeta,
+		policies,
+		nil,
+		role.NodeIdentities,
+		role.TemplatedPolicies,
+	)
+}
+
+// vetPolicy returns an error if the policy has rules that a namespace
+// administrator is not allowed to grant.
+func (g *namespaceAdminGuard) vetPolicy(policy *structs.ACLPolicy) error {
+	if g == nil || policy == nil {
+		return nil
+	}
+	if policy.ID == structs.ACLPolicyGlobalManagementID {
+		return acl.PermissionDenied("namespace administrators cannot grant the %s policy", policy.Name)
+	}
+
+	rules, err := acl.NewPolicyFromSource(policy.Rules, g.srv.aclConfig, policy.EnterprisePolicyMeta())
+	if err != nil {
+		return err
+	}
+	if violation := namespaceAdminRulesViolation(rules); violation != "" {
+		return acl.PermissionDenied("namespace administrators cannot grant %s (policy %q)", violation, policy.Name)
+	}
+	return nil
+}
+
+func (g *namespaceAdminGuard) vetLinks(
+	entMeta *acl.EnterpriseMeta,
+	policies []structs.ACLTokenPolicyLink,
+	roles []structs.ACLTokenRoleLink,
+	nodeIdentities structs.ACLNodeIdentities,
+	templatedPolicies structs.ACLTemplatedPolicies,
+) error {
+	state := g.srv.fsm.State()
+
+	for _, link := range policies {
+		var policy *structs.ACLPolicy
+		var err error
+		if link.ID != "" {
+			_, policy, err = state.ACLPolicyGetByID(nil, link.ID, entMeta)
+		} else {
+			_, policy, err = state.ACLPolicyGetByName(nil, link.Name, entMeta)
+		}
+		if err != nil {
+			return err
+		}
+		// Links to missing policies are rejected when the token or role is written.
+		if err := g.vetPolicy(policy); err != nil {
+			return err
+		}
+	}
+
+	for _, link := range roles {
+		var role *structs.ACLRole
+		var err error
+		if link.ID != "" {
+			_, role, err = state.ACLRoleGetByID(nil, link.ID, entMeta)
+		} else {
+			_, role, err = state.ACLRoleGetByName(nil, link.Name, entMeta)
+		}
+		if err != nil {
+			return err
+		}
+		if err := g.vetRole(role); err != nil {
+			return err
+		}
+	}
+
+	if len(nodeIdentities) != 0 {
+		return acl.PermissionDenied("namespace administrators cannot grant node identities")
+	}
+
+	for _, tp := range templatedPolicies {
+		if tp.TemplateName == api.ACLTemplatedPolicyNamespaceAdminName {
+			// Namespace administrators may delegate their own permissions.
+			continue
+		}
+		policy, err := tp.SyntheticPolicy(entMeta)
+		if err != nil {
+			return err
+		}
+		rules, err := acl.NewPolicyFromSource(policy.Rules, g.srv.aclConfig, policy.EnterprisePolicyMeta())
+		if err != nil {
+			return err
+		}
+		if violation := namespaceAdminRulesViolation(rules); violation != "" {
+			return acl.PermissionDenied("namespace administrators cannot grant %s (templated policy %q)", violation, tp.TemplateName)
+		}
+	}
+	return nil
+}
+
+// vetExistingToken returns an error if the stored token with the given
+// accessor ID has permissions that a namespace administrator is not allowed
+// to grant, and therefore must not be modified by them.
+func (g *namespaceAdminGuard) vetExistingToken(accessorID string, entMeta *acl.EnterpriseMeta) error {
+	if g == nil || accessorID == "" {
+		return nil
+	}
+	_, token, err := g.srv.fsm.State().ACLTokenGetByAccessor(nil, accessorID, entMeta)
+	if err != nil {
+		return err
+	}
+	return g.vetToken(token)
+}
+
+// redactToken hides the secret of the given token if it has permissions that
+// a namespace administrator is not allowed to grant, since revealing the secret
+// would allow the namespace administrator to use them.
+func (g *namespaceAdminGuard) redactToken(token **structs.ACLToken) {
+	if g == nil || token == nil || *token == nil || (*token).SecretID == aclfilter.RedactedToken {
+		return
+	}
+	if g.vetToken(*token) != nil {
+		clone := *(*token)
+		clone.SecretID = aclfilter.RedactedToken
+		*token = &clone
+	}
+}
+
+// redactTokenStubs hides the secrets of the given tokens, see redactToken.
+func (g *namespaceAdminGuard) redactTokenStubs(stubs []*structs.ACLTokenListStub) {
+	if g == nil {
+		return
+	}
+	for i, stub := range stubs {
+		if stub.SecretID == aclfilter.RedactedToken {
+			continue
+		}
+		err := g.vetLinks(&stub.EnterpriseMeta, stub.Policies, stub.Roles, stub.NodeIdentities, stub.TemplatedPolicies)
+		if err != nil {
+			clone := *stub
+			clone.SecretID = aclfilter.RedactedToken
+			stubs[i] = &clone
+		}
+	}
+}
+
+// namespaceAdminRulesViolation returns a description of the first rule in the
+// given policy that grants write access to resources which are shared with
+// other namespaces, or access to the redacted values, or an empty string if
+// there is none.
+func namespaceAdminRulesViolation(p *acl.Policy) string {
+	for _, rule := range []struct {
+		name   string
+		policy string
+	}{
+		{"acl", p.ACL},
+		{"operator", p.Operator},
+		{"keyring", p.Keyring},
+		{"mesh", p.Mesh},
+		{"peering", p.Peering},
+	} {
+		if rule.policy == acl.PolicyWrite {
+			return fmt.Sprintf("%s write access", rule.name)
+		}
+	}
+
+	// Reading the redacted values isn't scoped to a namespace either.
+	if p.Secrets == acl.PolicyRead || p.Secrets == acl.PolicyWrite {
+		return "secrets read access"
+	}
+
+	for _, rule := range p.Agents {
+		if rule.Policy == acl.PolicyWrite {
+			return "agent write access"
+		}
+	}
+	for _, rule := range p.AgentPrefixes {
+		if rule.Policy == acl.PolicyWrite {
+			return "agent write access"
+		}
+	}
+	for _, rule := range p.Nodes {
+		if rule.Policy == acl.PolicyWrite {
+			return "node write access"
+		}
+	}
+	for _, rule := range p.NodePrefixes {
+		if rule.Policy == acl.PolicyWrite {
+			return "node write access"
+		}
+	}
+	for _, rule := range p.Events {
+		if rule.Policy == acl.PolicyWrite {
+			return "event write access"
+		}
+	}
+	for _, rule := range p.EventPrefixes {
+		if rule.Policy == acl.PolicyWrite {
+			return "event write access"
+		}
+	}
+	for _, rule := range p.PreparedQueries {
+		if rule.Policy == acl.PolicyWrite {
+			return "prepared query write access"
+		}
+	}
+	for _, rule := range p.PreparedQueryPrefixes {
+		if rule.Policy == acl.PolicyWrite {
+			return "prepared query write access"
+		}
+	}
+
+	// The config_entry rules take precedence over the permissions of each
+	// kind, and match the kinds which are shared by the whole cluster, like
+	// proxy-defaults and mesh, as well.
+	for _, rule := range p.ConfigEntries {
+		if rule.Policy == acl.PolicyWrite {
+			return "config entry write access"
+		}
+	}
+	for _, rule := range p.ConfigEntryPrefixes {
+		if rule.Policy == acl.PolicyWrite {
+			return "config entry write access"
+		}
+	}
+
+	return namespaceAdminEnterpriseRulesViolation(p)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/structs/aclfilter"
+	"github.com/hashicorp/consul/api"
+)
+
+func TestACLEndpoint_NamespaceAdmin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, srv, codec := testACLServerWithConfig(t, nil, false)
+	waitForLeaderEstablishment(t, srv)
+
+	namespaceAdmin := []*structs.ACLTemplatedPolicy{{TemplateName: api.ACLTemplatedPolicyNamespaceAdminName}}
+
+	admin, err := upsertTestToken(codec, TestDefaultInitialManagementToken, "dc1", func(token *structs.ACLToken) {
+		token.TemplatedPolicies = namespaceAdmin
+	})
+	require.NoError(t, err)
+
+	adminRole, err := upsertTestCustomizedRole(codec, TestDefaultInitialManagementToken, "dc1", func(role *structs.ACLRole) {
+		role.TemplatedPolicies = namespaceAdmin
+	})
+	require.NoError(t, err)
+	roleAdmin, err := upsertTestToken(codec, TestDefaultInitialManagementToken, "dc1", func(token *structs.ACLToken) {
+		token.Roles = []structs.ACLTokenRoleLink{{ID: adminRole.ID}}
+	})
+	require.NoError(t, err)
+
+	managementAccessor, err := retrieveTestTokenAccessorForSecret(codec, TestDefaultInitialManagementToken, "dc1", TestDefaultInitialManagementToken)
+	require.NoError(t, err)
+
+	operatorPolicy, err := upsertTestPolicyWithRules(codec, TestDefaultInitialManagementToken, "dc1", `operator = "write"`)
+	require.NoError(t, err)
+	operatorToken, err := upsertTestToken(codec, TestDefaultInitialManagementToken, "dc1", func(token *structs.ACLToken) {
+		token.Policies = []structs.ACLTokenPolicyLink{{ID: operatorPolicy.ID}}
+	})
+	require.NoError(t, err)
+
+	for name, adminToken := range map[string]*structs.ACLToken{"token": admin, "role": roleAdmin} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("can create tokens within the namespace", func(t *testing.T) {
+				_, err := upsertTestToken(codec, adminToken.SecretID, "dc1", func(token *structs.ACLToken) {
+					token.ServiceIdentities = []*structs.ACLServiceIdentity{{ServiceName: "web"}}
+				})
+				require.NoError(t, err)
+
+				_, err = upsertTestToken(codec, adminToken.SecretID, "dc1", func(token *structs.ACLToken) {
+					token.TemplatedPolicies = namespaceAdmin
+				})
+				require.NoError(t, err)
+			})
+
+			t.Run("can create policies within the namespace", func(t *testing.T) {
+				_, err := upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `service "web" { policy = "write" }`)
+				require.NoError(t, err)
+
+				_, err = upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `config_entry_prefix "service-defaults/" { policy = "read" }`)
+				require.NoError(t, err)
+			})
+
+			t.Run("cannot grant the global management policy", func(t *testing.T) {
+				_, err := upsertTestToken(codec, adminToken.SecretID, "dc1", func(token *structs.ACLToken) {
+					token.Policies = []structs.ACLTokenPolicyLink{{ID: structs.ACLPolicyGlobalManagementID}}
+				})
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+			})
+
+			t.Run("cannot grant shared permissions", func(t *testing.T) {
+				_, err := upsertTestToken(codec, adminToken.SecretID, "dc1", func(token *structs.ACLToken) {
+					token.Policies = []structs.ACLTokenPolicyLink{{ID: operatorPolicy.ID}}
+				})
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestToken(codec, adminToken.SecretID, "dc1", func(token *structs.ACLToken) {
+					token.NodeIdentities = []*structs.ACLNodeIdentity{{NodeName: "node", Datacenter: "dc1"}}
+				})
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `acl = "write"`)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `secrets = "read"`)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `config_entry "proxy-defaults/global" { policy = "write" }`)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestPolicyWithRules(codec, adminToken.SecretID, "dc1", `config_entry_prefix "" { policy = "write" }`)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				_, err = upsertTestCustomizedRole(codec, adminToken.SecretID, "dc1", func(role *structs.ACLRole) {
+					role.Policies = []structs.ACLRolePolicyLink{{ID: operatorPolicy.ID}}
+				})
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+			})
+
+			t.Run("cannot modify privileged objects", func(t *testing.T) {
+				err := deleteTestToken(codec, adminToken.SecretID, "dc1", managementAccessor)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				err = deleteTestPolicy(codec, adminToken.SecretID, "dc1", operatorPolicy.ID)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				req := structs.ACLTokenSetRequest{
+					Datacenter: "dc1",
+					ACLToken: structs.ACLToken{
+						AccessorID:  operatorToken.AccessorID,
+						Description: "takeover",
+					},
+					WriteRequest: structs.WriteRequest{Token: adminToken.SecretID},
+				}
+				var out structs.ACLToken
+				err = msgpackrpc.CallWithCodec(codec, "ACL.TokenSet", &req, &out)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+				clone := structs.ACLTokenSetRequest{
+					Datacenter:   "dc1",
+					ACLToken:     structs.ACLToken{AccessorID: operatorToken.AccessorID},
+					WriteRequest: structs.WriteRequest{Token: adminToken.SecretID},
+				}
+				err = msgpackrpc.CallWithCodec(codec, "ACL.TokenClone", &clone, &out)
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+			})
+
+			t.Run("cannot manage auth methods", func(t *testing.T) {
+				_, err := upsertTestAuthMethod(codec, adminToken.SecretID, "dc1", "")
+				require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+			})
+
+			t.Run("secrets of privileged tokens are redacted", func(t *testing.T) {
+				resp, err := retrieveTestToken(codec, adminToken.SecretID, "dc1", managementAccessor)
+				require.NoError(t, err)
+				require.Equal(t, aclfilter.RedactedToken, resp.Token.SecretID)
+				require.True(t, resp.Redacted)
+
+				resp, err = retrieveTestToken(codec, adminToken.SecretID, "dc1", adminToken.AccessorID)
+				require.NoError(t, err)
+				require.Equal(t, adminToken.SecretID, resp.Token.SecretID)
+
+				req := structs.ACLTokenListRequest{
+					Datacenter:    "dc1",
+					IncludeLocal:  true,
+					IncludeGlobal: true,
+					QueryOptions:  structs.QueryOptions{Token: adminToken.SecretID},
+				}
+				var list structs.ACLTokenListResponse
+				require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.TokenList", &req, &list))
+				for _, stub := range list.Tokens {
+					switch stub.AccessorID {
+					case managementAccessor, operatorToken.AccessorID:
+						require.Equal(t, aclfilter.RedactedToken, stub.SecretID)
+					case adminToken.AccessorID:
+						require.Equal(t, adminToken.SecretID, stub.SecretID)
+					}
+				}
+			})
+		})
+	}
+}
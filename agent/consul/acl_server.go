@@ -220,6 +220,7 @@ func (s *Server) aclTokenWriter() *auth.TokenWriter {
 		ACLCache:            s.ACLResolver.cache,
 		Store:               s.fsm.State(),
 		CheckUUID:           s.checkTokenUUID,
+		CheckQuota:          s.checkACLTokenQuota,
 		MaxExpirationTTL:    s.config.ACLTokenMaxExpirationTTL,
 		MinExpirationTTL:    s.config.ACLTokenMinExpirationTTL,
 		PrimaryDatacenter:   s.config.PrimaryDatacenter,
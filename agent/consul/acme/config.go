@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	// ChallengeHTTP01 proves the control of a host name by serving a token
+	// on port 80 of the host. The API gateway serves it itself.
+	ChallengeHTTP01 = "http-01"
+
+	// ChallengeDNS01 proves the control of a host name by publishing a TXT
+	// record with a DNS provider. It is the only challenge usable with
+	// wildcard host names.
+	ChallengeDNS01 = "dns-01"
+
+	// DefaultDirectoryURL is the directory of the Let's Encrypt production
+	// environment.
+	DefaultDirectoryURL = acme.LetsEncryptURL
+
+	// DefaultRenewBefore is how long before their expiry the certificates
+	// are renewed by default.
+	DefaultRenewBefore = 30 * 24 * time.Hour
+)
+
+// Config configures the ACME client the leader uses to obtain and renew the
+// certificates of the API gateway listeners.
+type Config struct {
+	// Enabled turns the ACME client on.
+	Enabled bool
+
+	// DirectoryURL is the URL of the ACME directory of the certificate
+	// authority.
+	DirectoryURL string
+
+	// Email is the contact address of the ACME account, which the
+	// certificate authority uses to warn about expiring certificates.
+	Email string
+
+	// Challenge is the type of the challenges used to prove the control of
+	// the host names, either ChallengeHTTP01 or ChallengeDNS01.
+	Challenge string
+
+	// RenewBefore is how long before their expiry the certificates are
+	// renewed.
+	RenewBefore time.Duration
+
+	// DNSProvider is the name of the provider publishing the records of the
+	// DNS-01 challenges, either DNSProviderExec or DNSProviderRFC2136.
+	DNSProvider string
+
+	// DNSProviderConfig is the configuration of the DNS provider.
+	DNSProviderConfig map[string]string
+}
+
+// Validate returns an error if the configuration of an enabled ACME client is
+// not usable.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	u, err := url.Parse(c.DirectoryURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("acme.directory_url must be an https URL, got %q", c.DirectoryURL)
+	}
+	if c.RenewBefore <= 0 {
+		return fmt.Errorf("acme.renew_before cannot be %s. Must be greater than zero", c.RenewBefore)
+	}
+
+	switch c.Challenge {
+	case ChallengeHTTP01:
+		if c.DNSProvider != "" {
+			return errors.New("acme.dns_provider can only be set when acme.challenge is dns-01")
+		}
+	case ChallengeDNS01:
+		if c.DNSProvider == "" {
+			return errors.New("acme.dns_provider is required when acme.challenge is dns-01")
+		}
+		if _, err := NewDNSProvider(c.DNSProvider, c.DNSProviderConfig); err != nil {
+			return fmt.Errorf("acme.dns_provider_config is invalid: %w", err)
+		}
+	default:
+		return fmt.Errorf("acme.challenge must be %q or %q, got %q", ChallengeHTTP01, ChallengeDNS01, c.Challenge)
+	}
+	return nil
+}
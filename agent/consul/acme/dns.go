@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// DNSProviderExec runs an executable to publish and remove the records.
+	DNSProviderExec = "exec"
+
+	// DNSProviderRFC2136 sends dynamic updates to an authoritative name
+	// server, as described in RFC 2136.
+	DNSProviderRFC2136 = "rfc2136"
+)
+
+// DNSProvider publishes the TXT records of the DNS-01 challenges.
+type DNSProvider interface {
+	// Present publishes a TXT record with the given fully qualified name
+	// and value. The record should be visible to the certificate authority
+	// when it returns.
+	Present(ctx context.Context, fqdn, value string) error
+
+	// CleanUp removes a record published by Present.
+	CleanUp(ctx context.Context, fqdn, value string) error
+}
+
+// NewDNSProvider returns the DNS provider with the given name and
+// configuration.
+func NewDNSProvider(name string, config map[string]string) (DNSProvider, error) {
+	switch name {
+	case DNSProviderExec:
+		return newExecDNSProvider(config)
+	case DNSProviderRFC2136:
+		return newRFC2136DNSProvider(config)
+	default:
+		return nil, fmt.Errorf("unknown DNS provider %q, must be %q or %q", name, DNSProviderExec, DNSProviderRFC2136)
+	}
+}
+
+// execDNSProvider runs "<path> present <fqdn> <value>" to publish a record
+// and "<path> cleanup <fqdn> <value>" to remove it.
+type execDNSProvider struct {
+	path string
+}
+
+func newExecDNSProvider(config map[string]string) (*execDNSProvider, error) {
+	for k := range config {
+		if k != "path" {
+			return nil, fmt.Errorf("unknown exec provider option %q", k)
+		}
+	}
+	if config["path"] == "" {
+		return nil, errors.New("the exec provider requires a path")
+	}
+	return &execDNSProvider{path: config["path"]}, nil
+}
+
+func (p *execDNSProvider) Present(ctx context.Context, fqdn, value string) error {
+	return p.run(ctx, "present", fqdn, value)
+}
+
+func (p *execDNSProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	return p.run(ctx, "cleanup", fqdn, value)
+}
+
+func (p *execDNSProvider) run(ctx context.Context, action, fqdn, value string) error {
+	out, err := osexec.CommandContext(ctx, p.path, action, fqdn, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w: %s", p.path, action, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// rfc2136DNSProvider adds and removes the records with dynamic updates of
+// the zone holding them, optionally signed with TSIG.
+type rfc2136DNSProvider struct {
+	nameserver    string
+	zone          string
+	ttl           uint32
+	tsigKey       string
+	tsigSecret    string
+	tsigAlgorithm string
+}
+
+func newRFC2136DNSProvider(config map[string]string) (*rfc2136DNSProvider, error) {
+	p := &rfc2136DNSProvider{
+		ttl:           60,
+		tsigAlgorithm: dns.HmacSHA256,
+	}
+	for k, v := range config {
+		switch k {
+		case "nameserver":
+			p.nameserver = v
+			if _, _, err := net.SplitHostPort(v); err != nil {
+				p.nameserver = net.JoinHostPort(v, "53")
+			}
+		case "zone":
+			p.zone = dns.Fqdn(v)
+		case "ttl":
+			ttl, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ttl %q: %w", v, err)
+			}
+			p.ttl = uint32(ttl)
+		case "tsig_key":
+			p.tsigKey = dns.Fqdn(v)
+		case "tsig_secret":
+			p.tsigSecret = v
+		case "tsig_algorithm":
+			p.tsigAlgorithm = dns.Fqdn(v)
+		default:
+			return nil, fmt.Errorf("unknown rfc2136 provider option %q", k)
+		}
+	}
+	if p.nameserver == "" {
+		return nil, errors.New("the rfc2136 provider requires a nameserver")
+	}
+	if p.zone == "" {
+		return nil, errors.New("the rfc2136 provider requires a zone")
+	}
+	if (p.tsigKey == "") != (p.tsigSecret == "") {
+		return nil, errors.New("the rfc2136 provider requires both tsig_key and tsig_secret, or neither")
+	}
+	return p, nil
+}
+
+func (p *rfc2136DNSProvider) Present(ctx context.Context, fqdn, value string) error {
+	m := new(dns.Msg)
+	m.SetUpdate(p.zone)
+	m.Insert([]dns.RR{p.record(fqdn, value)})
+	return p.exchange(ctx, m)
+}
+
+func (p *rfc2136DNSProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	m := new(dns.Msg)
+	m.SetUpdate(p.zone)
+	m.Remove([]dns.RR{p.record(fqdn, value)})
+	return p.exchange(ctx, m)
+}
+
+func (p *rfc2136DNSProvider) record(fqdn, value string) *dns.TXT {
+	return &dns.TXT{
+		Hdr: dns.RR_Header{
+			Name:   dns.Fqdn(fqdn),
+			Rrtype: dns.TypeTXT,
+			Class:  dns.ClassINET,
+			Ttl:    p.ttl,
+		},
+		Txt: []string{value},
+	}
+}
+
+func (p *rfc2136DNSProvider) exchange(ctx context.Context, m *dns.Msg) error {
+	c := &dns.Client{Net: "tcp"}
+	if p.tsigKey != "" {
+		c.TsigSecret = map[string]string{p.tsigKey: p.tsigSecret}
+		m.SetTsig(p.tsigKey, p.tsigAlgorithm, 300, time.Now().Unix())
+	}
+	rsp, _, err := c.ExchangeContext(ctx, m, p.nameserver)
+	if err != nil {
+		return fmt.Errorf("failed to update zone %s: %w", p.zone, err)
+	}
+	if rsp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("failed to update zone %s: %s", p.zone, dns.RcodeToString[rsp.Rcode])
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDNSProvider(t *testing.T) {
+	for name, tc := range map[string]struct {
+		provider string
+		config   map[string]string
+		err      string
+	}{
+		"unknown provider": {
+			provider: "route53",
+			err:      `unknown DNS provider "route53"`,
+		},
+		"exec without path": {
+			provider: DNSProviderExec,
+			err:      "the exec provider requires a path",
+		},
+		"exec unknown option": {
+			provider: DNSProviderExec,
+			config:   map[string]string{"path": "/bin/true", "args": "-v"},
+			err:      `unknown exec provider option "args"`,
+		},
+		"rfc2136 without zone": {
+			provider: DNSProviderRFC2136,
+			config:   map[string]string{"nameserver": "10.0.0.1"},
+			err:      "the rfc2136 provider requires a zone",
+		},
+		"rfc2136 partial tsig": {
+			provider: DNSProviderRFC2136,
+			config:   map[string]string{"nameserver": "10.0.0.1", "zone": "example.com", "tsig_key": "key"},
+			err:      "requires both tsig_key and tsig_secret",
+		},
+		"rfc2136 invalid ttl": {
+			provider: DNSProviderRFC2136,
+			config:   map[string]string{"nameserver": "10.0.0.1", "zone": "example.com", "ttl": "-1"},
+			err:      `invalid ttl "-1"`,
+		},
+		"rfc2136": {
+			provider: DNSProviderRFC2136,
+			config:   map[string]string{"nameserver": "10.0.0.1", "zone": "example.com"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewDNSProvider(tc.provider, tc.config)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestExecDNSProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test script requires a shell")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "provider.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"$@\" >> "+out+"\n"), 0700))
+
+	provider, err := NewDNSProvider(DNSProviderExec, map[string]string{"path": script})
+	require.NoError(t, err)
+	require.NoError(t, provider.Present(context.Background(), "_acme-challenge.example.com.", "value"))
+	require.NoError(t, provider.CleanUp(context.Background(), "_acme-challenge.example.com.", "value"))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "present _acme-challenge.example.com. value\ncleanup _acme-challenge.example.com. value\n", string(data))
+
+	failing := filepath.Join(dir, "failing.sh")
+	require.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho 'zone not found'\nexit 1\n"), 0700))
+	provider, err = NewDNSProvider(DNSProviderExec, map[string]string{"path": failing})
+	require.NoError(t, err)
+	err = provider.Present(context.Background(), "_acme-challenge.example.com.", "value")
+	require.ErrorContains(t, err, "zone not found")
+}
+
+func TestRFC2136DNSProvider(t *testing.T) {
+	const (
+		tsigKey    = "acme."
+		tsigSecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+	)
+
+	updates := make(chan *dns.Msg, 2)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{
+		Listener:   l,
+		TsigSecret: map[string]string{tsigKey: tsigSecret},
+		// The default accept function rejects updates.
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			rsp := new(dns.Msg)
+			rsp.SetReply(req)
+			if w.TsigStatus() != nil {
+				rsp.Rcode = dns.RcodeNotAuth
+			} else {
+				updates <- req
+				rsp.SetTsig(tsigKey, dns.HmacSHA256, 300, time.Now().Unix())
+			}
+			require.NoError(t, w.WriteMsg(rsp))
+		}),
+	}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+
+	provider, err := NewDNSProvider(DNSProviderRFC2136, map[string]string{
+		"nameserver":  l.Addr().String(),
+		"zone":        "example.com",
+		"tsig_key":    "acme",
+		"tsig_secret": tsigSecret,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Present(context.Background(), "_acme-challenge.www.example.com.", "value"))
+	update := <-updates
+	require.Equal(t, "example.com.", update.Question[0].Name)
+	require.Len(t, update.Ns, 1)
+	txt := update.Ns[0].(*dns.TXT)
+	require.Equal(t, "_acme-challenge.www.example.com.", txt.Hdr.Name)
+	require.Equal(t, uint16(dns.ClassINET), txt.Hdr.Class)
+	require.Equal(t, []string{"value"}, txt.Txt)
+
+	require.NoError(t, provider.CleanUp(context.Background(), "_acme-challenge.www.example.com.", "value"))
+	update = <-updates
+	txt = update.Ns[0].(*dns.TXT)
+	require.Equal(t, uint16(dns.ClassNONE), txt.Hdr.Class)
+
+	provider, err = NewDNSProvider(DNSProviderRFC2136, map[string]string{
+		"nameserver":  l.Addr().String(),
+		"zone":        "example.com",
+		"tsig_key":    "acme",
+		"tsig_secret": "d3Jvbmctc2VjcmV0",
+	})
+	require.NoError(t, err)
+	require.Error(t, provider.Present(context.Background(), "_acme-challenge.www.example.com.", "value"))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{
+		Enabled:      true,
+		DirectoryURL: DefaultDirectoryURL,
+		Challenge:    ChallengeHTTP01,
+		RenewBefore:  DefaultRenewBefore,
+	}
+	require.NoError(t, valid.Validate())
+	require.NoError(t, Config{}.Validate())
+
+	for name, tc := range map[string]struct {
+		modify func(c *Config)
+		err    string
+	}{
+		"http directory": {
+			modify: func(c *Config) { c.DirectoryURL = "http://acme.example.com/directory" },
+			err:    "acme.directory_url must be an https URL",
+		},
+		"renew before": {
+			modify: func(c *Config) { c.RenewBefore = 0 },
+			err:    "acme.renew_before cannot be 0s",
+		},
+		"unknown challenge": {
+			modify: func(c *Config) { c.Challenge = "tls-alpn-01" },
+			err:    `acme.challenge must be "http-01" or "dns-01", got "tls-alpn-01"`,
+		},
+		"http-01 with provider": {
+			modify: func(c *Config) { c.DNSProvider = DNSProviderExec },
+			err:    "acme.dns_provider can only be set when acme.challenge is dns-01",
+		},
+		"dns-01 with invalid provider": {
+			modify: func(c *Config) {
+				c.Challenge = ChallengeDNS01
+				c.DNSProvider = DNSProviderRFC2136
+			},
+			err: "acme.dns_provider_config is invalid: the rfc2136 provider requires a nameserver",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := valid
+			tc.modify(&c)
+			require.ErrorContains(t, c.Validate(), tc.err)
+		})
+	}
+}
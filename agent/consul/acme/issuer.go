@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/crypto/acme"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/lib/file"
+)
+
+// Challenge is a challenge the certificate authority asks to solve to prove
+// the control of a host name.
+type Challenge struct {
+	// Type is either ChallengeHTTP01 or ChallengeDNS01.
+	Type string
+
+	// Host is the host name being validated, without the "*." prefix of
+	// wildcard host names.
+	Host string
+
+	// Token is the token of the challenge. HTTP-01 challenges are fetched
+	// from /.well-known/acme-challenge/<token>.
+	Token string
+
+	// Response is what must be served for the challenge to succeed: the key
+	// authorization for HTTP-01 challenges, the value of the TXT record of
+	// _acme-challenge.<host> for DNS-01 challenges.
+	Response string
+}
+
+// Solver makes the responses to the challenges available to the certificate
+// authority.
+type Solver interface {
+	Present(ctx context.Context, c Challenge) error
+	CleanUp(ctx context.Context, c Challenge) error
+}
+
+// Issuer obtains certificates from a certificate authority.
+type Issuer interface {
+	// Issue obtains a certificate for the given host names, solving the
+	// challenges of the given type with solver. It returns the PEM encoded
+	// certificate chain and private key.
+	Issue(ctx context.Context, hosts []string, challenge string, solver Solver) (certPEM, keyPEM string, err error)
+}
+
+// Client is an Issuer using the ACME protocol (RFC 8555).
+type Client struct {
+	config         Config
+	accountKeyPath string
+	logger         hclog.Logger
+
+	// lock guards client, which is set once the account has been
+	// registered.
+	lock   sync.Mutex
+	client *acme.Client
+}
+
+// NewClient returns an ACME client whose account key is stored in the given
+// directory.
+func NewClient(config Config, dataDir string, logger hclog.Logger) *Client {
+	return &Client{
+		config:         config,
+		accountKeyPath: filepath.Join(dataDir, "acme", "account.key"),
+		logger:         logger,
+	}
+}
+
+var _ Issuer = (*Client)(nil)
+
+func (c *Client) Issue(ctx context.Context, hosts []string, challenge string, solver Solver) (string, string, error) {
+	client, err := c.account(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(hosts...))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create order: %w", err)
+	}
+	for _, u := range order.AuthzURLs {
+		if err := c.authorize(ctx, client, u, challenge, solver); err != nil {
+			return "", "", err
+		}
+	}
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return "", "", fmt.Errorf("order was not authorized: %w", err)
+	}
+
+	key, keyPEM, err := connect.GeneratePrivateKey()
+	if err != nil {
+		return "", "", err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hosts[0]},
+		DNSNames: hosts,
+	}, key)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to finalize order: %w", err)
+	}
+
+	var certPEM strings.Builder
+	for _, der := range chain {
+		if err := pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			return "", "", err
+		}
+	}
+	return certPEM.String(), keyPEM, nil
+}
+
+// authorize solves a challenge of the authorization at the given URL, unless
+// it is valid already.
+func (c *Client) authorize(ctx context.Context, client *acme.Client, authzURL, challenge string, solver Solver) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, ch := range authz.Challenges {
+		if ch.Type == challenge {
+			chal = ch
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("the certificate authority does not offer %s challenges for %s", challenge, authz.Identifier.Value)
+	}
+
+	response := Challenge{
+		Type:  challenge,
+		Host:  authz.Identifier.Value,
+		Token: chal.Token,
+	}
+	switch challenge {
+	case ChallengeHTTP01:
+		response.Response, err = client.HTTP01ChallengeResponse(chal.Token)
+	case ChallengeDNS01:
+		response.Response, err = client.DNS01ChallengeRecord(chal.Token)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := solver.Present(ctx, response); err != nil {
+		return fmt.Errorf("failed to present %s challenge for %s: %w", challenge, response.Host, err)
+	}
+	defer func() {
+		if err := solver.CleanUp(ctx, response); err != nil {
+			c.logger.Warn("failed to clean up challenge", "host", response.Host, "error", err)
+		}
+	}()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("failed to accept %s challenge for %s: %w", challenge, response.Host, err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("failed to validate %s: %w", response.Host, err)
+	}
+	return nil
+}
+
+// account returns a client for the ACME account, registering it the first
+// time it is called.
+func (c *Client) account(ctx context.Context) (*acme.Client, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	key, err := c.accountKey()
+	if err != nil {
+		return nil, err
+	}
+	client := &acme.Client{
+		Key:          key,
+		DirectoryURL: c.config.DirectoryURL,
+		UserAgent:    "consul",
+	}
+
+	account := &acme.Account{}
+	if c.config.Email != "" {
+		account.Contact = []string{"mailto:" + c.config.Email}
+	}
+	_, err = client.Register(ctx, account, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("failed to register ACME account: %w", err)
+	}
+	c.logger.Info("using ACME account", "directory_url", c.config.DirectoryURL)
+
+	c.client = client
+	return client, nil
+}
+
+// accountKey loads the key of the ACME account, generating it if it does not
+// exist yet.
+func (c *Client) accountKey() (crypto.Signer, error) {
+	data, err := os.ReadFile(c.accountKeyPath)
+	if err == nil {
+		key, err := connect.ParseSigner(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ACME account key %s: %w", c.accountKeyPath, err)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key, keyPEM, err := connect.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.accountKeyPath), 0700); err != nil {
+		return nil, err
+	}
+	if err := file.WriteAtomicWithPerms(c.accountKeyPath, []byte(keyPEM), 0700, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save ACME account key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,400 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// renewCheckInterval is the longest time between two checks of the
+	// expiry of the certificates.
+	renewCheckInterval = time.Hour
+
+	// retryWait is how long the manager waits before trying to obtain a
+	// certificate again after a failure. Certificate authorities limit the
+	// number of failed validations, so it is much longer than the usual
+	// error waits.
+	retryWait = 30 * time.Minute
+
+	// minPassInterval is the minimum time between two passes, so bursts of
+	// config entry writes are coalesced.
+	minPassInterval = time.Second
+
+	// defaultChallengeWait is how long the HTTP-01 solver waits for the API
+	// gateways to receive the challenge responses before they are fetched
+	// by the certificate authority.
+	defaultChallengeWait = 5 * time.Second
+)
+
+// Store is the part of the state store the manager reads.
+type Store interface {
+	AbandonCh() <-chan struct{}
+	ConfigEntry(ws memdb.WatchSet, kind, name string, entMeta *acl.EnterpriseMeta) (uint64, structs.ConfigEntry, error)
+	ConfigEntriesByKind(ws memdb.WatchSet, kind string, entMeta *acl.EnterpriseMeta) (uint64, []structs.ConfigEntry, error)
+}
+
+// Deps are the dependencies of the Manager.
+type Deps struct {
+	Config Config
+	Issuer Issuer
+
+	// DNSProvider publishes the records of the DNS-01 challenges. It is
+	// only used with ChallengeDNS01.
+	DNSProvider DNSProvider
+
+	// GetStore returns the current state store.
+	GetStore func() Store
+
+	// Apply writes a config entry using its modify index as a
+	// check-and-set index. It returns false if the entry was modified
+	// since it was read.
+	Apply func(entry structs.ConfigEntry) (bool, error)
+
+	Logger hclog.Logger
+
+	// ChallengeWait overrides how long the HTTP-01 solver waits after
+	// presenting a challenge.
+	ChallengeWait *time.Duration
+}
+
+// Manager obtains and renews the certificates of the API gateway listeners,
+// and stores them as inline-certificate config entries.
+//
+// A certificate is managed when an API gateway listener with a host name
+// references an inline-certificate config entry that does not exist. The
+// entry is then created with the MetaACMEManaged metadata key, and renewed
+// when it is about to expire or when the host names of the listeners
+// referencing it change. Entries written by users are never modified.
+type Manager struct {
+	deps          Deps
+	challengeWait time.Duration
+
+	// failures holds the time of the last failure to obtain a certificate,
+	// by certificate and host names.
+	failures map[string]time.Time
+
+	now func() time.Time
+}
+
+// NewManager returns a Manager. Run must be called for it to do anything.
+func NewManager(deps Deps) *Manager {
+	m := &Manager{
+		deps:          deps,
+		challengeWait: defaultChallengeWait,
+		failures:      make(map[string]time.Time),
+		now:           time.Now,
+	}
+	if deps.ChallengeWait != nil {
+		m.challengeWait = *deps.ChallengeWait
+	}
+	return m
+}
+
+// Run manages the certificates until ctx is done.
+func (m *Manager) Run(ctx context.Context) error {
+	for {
+		ws := memdb.NewWatchSet()
+		store := m.deps.GetStore()
+		ws.Add(store.AbandonCh())
+
+		next, err := m.reconcile(ctx, ws, store)
+		if err != nil {
+			m.deps.Logger.Error("failed to look up the certificates to manage", "error", err)
+		}
+
+		wait := renewCheckInterval
+		if !next.IsZero() && next.Sub(m.now()) < wait {
+			wait = next.Sub(m.now())
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, wait)
+		err = ws.WatchCtx(timeoutCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			// A config entry changed, coalesce the following writes.
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(minPassInterval):
+			}
+		}
+	}
+}
+
+// certificate is an inline-certificate config entry referenced by API
+// gateway listeners with host names.
+type certificate struct {
+	ref   structs.ResourceReference
+	hosts []string
+
+	// gateway is the first API gateway referencing the certificate, which
+	// serves the HTTP-01 challenges.
+	gateway structs.ResourceReference
+}
+
+func (c *certificate) key() string {
+	return fmt.Sprintf("%s/%s/%s", c.ref.PartitionOrDefault(), c.ref.NamespaceOrDefault(), c.ref.Name)
+}
+
+// reconcile obtains the certificates that are missing or need to be renewed.
+// It returns the time at which it must be called again at the latest, if any.
+func (m *Manager) reconcile(ctx context.Context, ws memdb.WatchSet, store Store) (time.Time, error) {
+	certs, err := m.certificates(ws, store)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var next time.Time
+	schedule := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	for _, cert := range certs {
+		if ctx.Err() != nil {
+			return time.Time{}, nil
+		}
+		logger := m.deps.Logger.With("certificate", cert.key(), "hosts", cert.hosts)
+
+		_, entry, err := store.ConfigEntry(ws, structs.InlineCertificate, cert.ref.Name, &cert.ref.EnterpriseMeta)
+		if err != nil {
+			return time.Time{}, err
+		}
+		var existing *structs.InlineCertificateConfigEntry
+		if entry != nil {
+			existing = entry.(*structs.InlineCertificateConfigEntry)
+			if existing.Meta[structs.MetaACMEManaged] != "true" {
+				continue
+			}
+			renewAt, err := m.renewAt(existing, cert.hosts)
+			if err != nil {
+				logger.Warn("failed to parse managed certificate, replacing it", "error", err)
+			} else if m.now().Before(renewAt) {
+				schedule(renewAt)
+				continue
+			}
+		}
+
+		failureKey := cert.key() + "=" + strings.Join(cert.hosts, ",")
+		if failed, ok := m.failures[failureKey]; ok {
+			retryAt := failed.Add(retryWait)
+			if m.now().Before(retryAt) {
+				schedule(retryAt)
+				continue
+			}
+		}
+
+		if err := m.obtain(ctx, cert, existing); err != nil {
+			if ctx.Err() != nil {
+				return time.Time{}, nil
+			}
+			logger.Error("failed to obtain certificate", "error", err)
+			m.failures[failureKey] = m.now()
+			schedule(m.now().Add(retryWait))
+			continue
+		}
+		delete(m.failures, failureKey)
+		logger.Info("obtained certificate")
+	}
+	return next, nil
+}
+
+// certificates returns the inline-certificate config entries referenced by
+// the API gateway listeners with host names, sorted by name.
+func (m *Manager) certificates(ws memdb.WatchSet, store Store) ([]*certificate, error) {
+	_, entries, err := store.ConfigEntriesByKind(ws, structs.APIGateway, acl.WildcardEnterpriseMeta())
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*certificate)
+	for _, entry := range entries {
+		gateway := entry.(*structs.APIGatewayConfigEntry)
+		for _, listener := range gateway.Listeners {
+			if listener.Hostname == "" {
+				continue
+			}
+			if m.deps.Config.Challenge == ChallengeHTTP01 && strings.HasPrefix(listener.Hostname, "*.") {
+				m.deps.Logger.Warn("cannot obtain a certificate for a wildcard host name with the http-01 challenge",
+					"gateway", gateway.Name, "listener", listener.Name, "hostname", listener.Hostname)
+				continue
+			}
+			for _, ref := range listener.TLS.Certificates {
+				if ref.Kind != structs.InlineCertificate {
+					continue
+				}
+				cert := &certificate{
+					ref: ref,
+					gateway: structs.ResourceReference{
+						Kind:           structs.APIGateway,
+						Name:           gateway.Name,
+						EnterpriseMeta: gateway.EnterpriseMeta,
+					},
+				}
+				if existing, ok := byKey[cert.key()]; ok {
+					cert = existing
+				} else {
+					byKey[cert.key()] = cert
+				}
+				cert.hosts = append(cert.hosts, listener.Hostname)
+			}
+		}
+	}
+
+	certs := make([]*certificate, 0, len(byKey))
+	for _, cert := range byKey {
+		slices.Sort(cert.hosts)
+		cert.hosts = slices.Compact(cert.hosts)
+		certs = append(certs, cert)
+	}
+	sort.Slice(certs, func(i, j int) bool {
+		return certs[i].key() < certs[j].key()
+	})
+	return certs, nil
+}
+
+// renewAt returns when a managed certificate must be renewed. It is now if
+// the host names it was obtained for are not the ones of the listeners
+// anymore.
+func (m *Manager) renewAt(entry *structs.InlineCertificateConfigEntry, hosts []string) (time.Time, error) {
+	cert, err := connect.ParseCert(entry.Certificate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	certHosts := slices.Clone(cert.DNSNames)
+	slices.Sort(certHosts)
+	if !slices.Equal(slices.Compact(certHosts), hosts) {
+		return m.now(), nil
+	}
+	return cert.NotAfter.Add(-m.deps.Config.RenewBefore), nil
+}
+
+// obtain obtains a certificate and writes it to its config entry.
+func (m *Manager) obtain(ctx context.Context, cert *certificate, existing *structs.InlineCertificateConfigEntry) error {
+	var solver Solver
+	switch m.deps.Config.Challenge {
+	case ChallengeHTTP01:
+		solver = &httpSolver{manager: m, gateway: cert.gateway}
+	case ChallengeDNS01:
+		solver = &dnsSolver{provider: m.deps.DNSProvider}
+	default:
+		return fmt.Errorf("unknown challenge %q", m.deps.Config.Challenge)
+	}
+
+	certPEM, keyPEM, err := m.deps.Issuer.Issue(ctx, cert.hosts, m.deps.Config.Challenge, solver)
+	if err != nil {
+		return err
+	}
+
+	entry := &structs.InlineCertificateConfigEntry{
+		Kind:           structs.InlineCertificate,
+		Name:           cert.ref.Name,
+		Certificate:    certPEM,
+		PrivateKey:     keyPEM,
+		Meta:           map[string]string{structs.MetaACMEManaged: "true"},
+		EnterpriseMeta: cert.ref.EnterpriseMeta,
+	}
+	if existing != nil {
+		entry.ModifyIndex = existing.ModifyIndex
+	}
+	ok, err := m.deps.Apply(entry)
+	if err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+	if !ok {
+		return errors.New("failed to write certificate: the config entry was modified concurrently")
+	}
+	return nil
+}
+
+// dnsSolver publishes the DNS-01 challenge records with a DNS provider.
+type dnsSolver struct {
+	provider DNSProvider
+}
+
+func (s *dnsSolver) Present(ctx context.Context, c Challenge) error {
+	return s.provider.Present(ctx, dnsChallengeName(c.Host), c.Response)
+}
+
+func (s *dnsSolver) CleanUp(ctx context.Context, c Challenge) error {
+	return s.provider.CleanUp(ctx, dnsChallengeName(c.Host), c.Response)
+}
+
+func dnsChallengeName(host string) string {
+	return "_acme-challenge." + strings.TrimSuffix(host, ".") + "."
+}
+
+// httpSolver has an API gateway serve the HTTP-01 challenge responses, by
+// adding them to the metadata of its bound-api-gateway config entry.
+type httpSolver struct {
+	manager *Manager
+	gateway structs.ResourceReference
+}
+
+func (s *httpSolver) Present(ctx context.Context, c Challenge) error {
+	err := s.update(func(meta map[string]string) {
+		meta[structs.MetaACMEChallengePrefix+c.Token] = c.Response
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(s.manager.challengeWait):
+		return nil
+	}
+}
+
+func (s *httpSolver) CleanUp(_ context.Context, c Challenge) error {
+	return s.update(func(meta map[string]string) {
+		delete(meta, structs.MetaACMEChallengePrefix+c.Token)
+	})
+}
+
+// update modifies the metadata of the bound-api-gateway config entry,
+// retrying when it is modified concurrently by the gateway controller.
+func (s *httpSolver) update(f func(meta map[string]string)) error {
+	for attempt := 0; attempt < 5; attempt++ {
+		_, entry, err := s.manager.deps.GetStore().ConfigEntry(nil, structs.BoundAPIGateway, s.gateway.Name, &s.gateway.EnterpriseMeta)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return fmt.Errorf("API gateway %q has not been bound yet", s.gateway.Name)
+		}
+		bound := entry.(*structs.BoundAPIGatewayConfigEntry).DeepCopy()
+		if bound.Meta == nil {
+			bound.Meta = make(map[string]string)
+		}
+		f(bound.Meta)
+
+		ok, err := s.manager.deps.Apply(bound)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to update bound API gateway %q: too many concurrent modifications", s.gateway.Name)
+}
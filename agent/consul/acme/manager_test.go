@@ -0,0 +1,352 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package acme
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+// fakeIssuer issues self-signed certificates after presenting a challenge
+// for each host name.
+type fakeIssuer struct {
+	validity time.Duration
+	err      error
+
+	// onPresent is called while the challenges are presented.
+	onPresent func(c Challenge)
+
+	issued [][]string
+}
+
+func (f *fakeIssuer) Issue(ctx context.Context, hosts []string, challenge string, solver Solver) (string, string, error) {
+	f.issued = append(f.issued, hosts)
+	if f.err != nil {
+		return "", "", f.err
+	}
+
+	for _, host := range hosts {
+		c := Challenge{Type: challenge, Host: host, Token: "token-" + host, Response: "response-" + host}
+		if err := solver.Present(ctx, c); err != nil {
+			return "", "", err
+		}
+		if f.onPresent != nil {
+			f.onPresent(c)
+		}
+		if err := solver.CleanUp(ctx, c); err != nil {
+			return "", "", err
+		}
+	}
+
+	validity := f.validity
+	if validity == 0 {
+		validity = 90 * 24 * time.Hour
+	}
+	certPEM, keyPEM := testCertificate(hosts, time.Now().Add(validity))
+	return certPEM, keyPEM, nil
+}
+
+func testCertificate(hosts []string, notAfter time.Time) (string, string) {
+	key, keyPEM, err := connect.GeneratePrivateKey()
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), keyPEM
+}
+
+// fakeDNSProvider records the published records.
+type fakeDNSProvider struct {
+	records map[string]string
+	present []string
+}
+
+func (f *fakeDNSProvider) Present(_ context.Context, fqdn, value string) error {
+	f.records[fqdn] = value
+	f.present = append(f.present, fqdn)
+	return nil
+}
+
+func (f *fakeDNSProvider) CleanUp(_ context.Context, fqdn, _ string) error {
+	delete(f.records, fqdn)
+	return nil
+}
+
+type testManager struct {
+	*Manager
+	store  *state.Store
+	issuer *fakeIssuer
+	index  atomic.Uint64
+}
+
+func newTestManager(t *testing.T, challenge string) *testManager {
+	t.Helper()
+
+	tm := &testManager{
+		store:  state.NewStateStore(nil),
+		issuer: &fakeIssuer{},
+	}
+	noWait := time.Duration(0)
+	tm.Manager = NewManager(Deps{
+		Config: Config{
+			Enabled:     true,
+			Challenge:   challenge,
+			RenewBefore: DefaultRenewBefore,
+		},
+		Issuer:      tm.issuer,
+		DNSProvider: &fakeDNSProvider{records: make(map[string]string)},
+		GetStore:    func() Store { return tm.store },
+		Apply: func(entry structs.ConfigEntry) (bool, error) {
+			if err := entry.Normalize(); err != nil {
+				return false, err
+			}
+			if err := entry.Validate(); err != nil {
+				return false, err
+			}
+			return tm.store.EnsureConfigEntryCAS(tm.index.Add(1), entry.GetRaftIndex().ModifyIndex, entry)
+		},
+		Logger:        hclog.NewNullLogger(),
+		ChallengeWait: &noWait,
+	})
+	return tm
+}
+
+func (tm *testManager) write(t *testing.T, entry structs.ConfigEntry) {
+	t.Helper()
+	require.NoError(t, entry.Normalize())
+	require.NoError(t, tm.store.EnsureConfigEntry(tm.index.Add(1), entry))
+}
+
+func (tm *testManager) reconcile(t *testing.T) time.Time {
+	t.Helper()
+	next, err := tm.Manager.reconcile(context.Background(), memdb.NewWatchSet(), tm.store)
+	require.NoError(t, err)
+	return next
+}
+
+func (tm *testManager) certificate(t *testing.T, name string) *structs.InlineCertificateConfigEntry {
+	t.Helper()
+	_, entry, err := tm.store.ConfigEntry(nil, structs.InlineCertificate, name, nil)
+	require.NoError(t, err)
+	if entry == nil {
+		return nil
+	}
+	return entry.(*structs.InlineCertificateConfigEntry)
+}
+
+func testGateway(listeners ...structs.APIGatewayListener) *structs.APIGatewayConfigEntry {
+	return &structs.APIGatewayConfigEntry{
+		Kind:      structs.APIGateway,
+		Name:      "gateway",
+		Listeners: listeners,
+	}
+}
+
+func tlsListener(name, hostname, cert string) structs.APIGatewayListener {
+	return structs.APIGatewayListener{
+		Name:     name,
+		Hostname: hostname,
+		Port:     443,
+		Protocol: structs.ListenerProtocolHTTP,
+		TLS: structs.APIGatewayTLSConfiguration{
+			Certificates: []structs.ResourceReference{{Name: cert}},
+		},
+	}
+}
+
+func TestManager_ObtainsMissingCertificates(t *testing.T) {
+	tm := newTestManager(t, ChallengeDNS01)
+	tm.write(t, testGateway(
+		tlsListener("a", "a.example.com", "shared"),
+		tlsListener("b", "b.example.com", "shared"),
+		tlsListener("c", "c.example.com", "c-cert"),
+		// Listeners without a host name are left alone.
+		tlsListener("d", "", "d-cert"),
+	))
+
+	tm.reconcile(t)
+	require.Equal(t, [][]string{{"c.example.com"}, {"a.example.com", "b.example.com"}}, tm.issuer.issued)
+
+	shared := tm.certificate(t, "shared")
+	require.NotNil(t, shared)
+	require.Equal(t, "true", shared.Meta[structs.MetaACMEManaged])
+	cert, err := connect.ParseCert(shared.Certificate)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.example.com", "b.example.com"}, cert.DNSNames)
+	require.NotNil(t, tm.certificate(t, "c-cert"))
+	require.Nil(t, tm.certificate(t, "d-cert"))
+
+	provider := tm.deps.DNSProvider.(*fakeDNSProvider)
+	require.Equal(t, []string{
+		"_acme-challenge.c.example.com.",
+		"_acme-challenge.a.example.com.",
+		"_acme-challenge.b.example.com.",
+	}, provider.present)
+	require.Empty(t, provider.records)
+
+	// The certificates are valid, so nothing is obtained until they are
+	// about to expire.
+	next := tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 2)
+	require.WithinDuration(t, time.Now().Add(60*24*time.Hour), next, time.Hour)
+}
+
+func TestManager_IgnoresUserCertificates(t *testing.T) {
+	tm := newTestManager(t, ChallengeDNS01)
+	certPEM, keyPEM := testCertificate([]string{"other.example.com"}, time.Now().Add(time.Hour))
+	tm.write(t, &structs.InlineCertificateConfigEntry{
+		Kind:        structs.InlineCertificate,
+		Name:        "user",
+		Certificate: certPEM,
+		PrivateKey:  keyPEM,
+	})
+	tm.write(t, testGateway(tlsListener("a", "a.example.com", "user")))
+
+	tm.reconcile(t)
+	require.Empty(t, tm.issuer.issued)
+	require.Equal(t, certPEM, tm.certificate(t, "user").Certificate)
+}
+
+func TestManager_Renews(t *testing.T) {
+	t.Run("expiring", func(t *testing.T) {
+		tm := newTestManager(t, ChallengeDNS01)
+		tm.issuer.validity = 7 * 24 * time.Hour
+		tm.write(t, testGateway(tlsListener("a", "a.example.com", "cert")))
+
+		tm.reconcile(t)
+		first := tm.certificate(t, "cert")
+
+		tm.issuer.validity = 0
+		tm.reconcile(t)
+		require.Len(t, tm.issuer.issued, 2)
+		second := tm.certificate(t, "cert")
+		require.NotEqual(t, first.Certificate, second.Certificate)
+		require.Equal(t, "true", second.Meta[structs.MetaACMEManaged])
+	})
+
+	t.Run("host names changed", func(t *testing.T) {
+		tm := newTestManager(t, ChallengeDNS01)
+		tm.write(t, testGateway(tlsListener("a", "a.example.com", "cert")))
+		tm.reconcile(t)
+
+		tm.write(t, testGateway(
+			tlsListener("a", "a.example.com", "cert"),
+			tlsListener("b", "b.example.com", "cert"),
+		))
+		tm.reconcile(t)
+		require.Equal(t, [][]string{{"a.example.com"}, {"a.example.com", "b.example.com"}}, tm.issuer.issued)
+	})
+}
+
+func TestManager_RetriesLater(t *testing.T) {
+	tm := newTestManager(t, ChallengeDNS01)
+	tm.issuer.err = errors.New("rate limited")
+	tm.write(t, testGateway(tlsListener("a", "a.example.com", "cert")))
+
+	next := tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 1)
+	require.WithinDuration(t, time.Now().Add(retryWait), next, time.Minute)
+
+	tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 1)
+
+	// Changing the host names is a new request.
+	tm.write(t, testGateway(tlsListener("a", "b.example.com", "cert")))
+	tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 2)
+
+	tm.issuer.err = nil
+	tm.now = func() time.Time { return time.Now().Add(retryWait) }
+	tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 3)
+	require.NotNil(t, tm.certificate(t, "cert"))
+}
+
+func TestManager_HTTP01(t *testing.T) {
+	tm := newTestManager(t, ChallengeHTTP01)
+	tm.write(t, testGateway(
+		tlsListener("a", "a.example.com", "cert"),
+		tlsListener("wildcard", "*.example.com", "wildcard"),
+	))
+
+	// The challenges cannot be served until the gateway is bound.
+	tm.reconcile(t)
+	require.Len(t, tm.issuer.issued, 1)
+	require.Nil(t, tm.certificate(t, "cert"))
+
+	tm.write(t, &structs.BoundAPIGatewayConfigEntry{
+		Kind: structs.BoundAPIGateway,
+		Name: "gateway",
+		Meta: map[string]string{"owner": "team-a"},
+	})
+	bound := func() *structs.BoundAPIGatewayConfigEntry {
+		_, entry, err := tm.store.ConfigEntry(nil, structs.BoundAPIGateway, "gateway", nil)
+		require.NoError(t, err)
+		return entry.(*structs.BoundAPIGatewayConfigEntry)
+	}
+
+	var served map[string]string
+	tm.issuer.onPresent = func(Challenge) {
+		served = bound().ACMEChallenges()
+	}
+	tm.now = func() time.Time { return time.Now().Add(retryWait) }
+	tm.reconcile(t)
+
+	// Wildcard host names require DNS-01 challenges.
+	require.Equal(t, [][]string{{"a.example.com"}, {"a.example.com"}}, tm.issuer.issued)
+	require.Equal(t, map[string]string{"token-a.example.com": "response-a.example.com"}, served)
+	require.NotNil(t, tm.certificate(t, "cert"))
+	require.Nil(t, tm.certificate(t, "wildcard"))
+	require.Equal(t, map[string]string{"owner": "team-a"}, bound().Meta)
+}
+
+func TestManager_Run(t *testing.T) {
+	tm := newTestManager(t, ChallengeDNS01)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	doneCh := make(chan error)
+	go func() { doneCh <- tm.Run(ctx) }()
+
+	// The manager obtains the certificates of the gateways written while
+	// it runs.
+	tm.write(t, testGateway(tlsListener("a", "a.example.com", "cert")))
+	retry.Run(t, func(r *retry.R) {
+		require.NotNil(r, tm.certificate(t, "cert"))
+	})
+
+	cancel()
+	select {
+	case err := <-doneCh:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("manager did not stop")
+	}
+}
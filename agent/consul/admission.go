@@ -0,0 +1,334 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// Operations the admission webhooks are called for.
+const (
+	AdmissionOperationCatalogRegister   = "catalog-register"
+	AdmissionOperationCatalogDeregister = "catalog-deregister"
+	AdmissionOperationConfigEntryUpsert = "config-entry-upsert"
+	AdmissionOperationConfigEntryDelete = "config-entry-delete"
+)
+
+// Failure policies of the admission webhooks, which decide whether a write is
+// accepted when its webhook can't be reached or returns an invalid response.
+const (
+	AdmissionFailClosed = "fail-closed"
+	AdmissionFailOpen   = "fail-open"
+)
+
+// AdmissionOperationHeader holds the operation of the requests posted to the
+// admission webhooks. They are signed like the requests posted to webhooks,
+// see WebhookSignature.
+const AdmissionOperationHeader = "X-Consul-Admission-Operation"
+
+const (
+	// admissionDefaultTimeout is the timeout of the requests to the admission
+	// webhooks which do not set one. It is shorter than the one of webhooks
+	// since the write waits for the response.
+	admissionDefaultTimeout = 5 * time.Second
+
+	// admissionMaxResponseSize is the size of the largest response read from
+	// an admission webhook.
+	admissionMaxResponseSize = 4 * 1024 * 1024
+)
+
+var (
+	metricsKeyAdmissionWebhook = []string{"admission", "webhook"}
+	metricsKeyAdmissionDenied  = []string{"admission", "denied"}
+	metricsKeyAdmissionFailed  = []string{"admission", "failed"}
+)
+
+var AdmissionCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyAdmissionDenied,
+		Help: "Increments when an admission webhook denies a write.",
+	},
+	{
+		Name: metricsKeyAdmissionFailed,
+		Help: "Increments when an admission webhook can't be reached or returns an invalid response.",
+	},
+}
+
+var AdmissionSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: metricsKeyAdmissionWebhook,
+		Help: "Measures the time it takes an admission webhook to review a write.",
+	},
+}
+
+// AdmissionWebhookConfig configures an HTTP endpoint the servers call to
+// validate, and optionally mutate, the catalog registrations and the config
+// entries before they are written.
+type AdmissionWebhookConfig struct {
+	// Name identifies the endpoint in the errors, logs and metrics.
+	Name string
+
+	// URL is the address the reviews are posted to.
+	URL string
+
+	// Secret is the key the requests are signed with. Requests are not
+	// signed if it is empty.
+	Secret string
+
+	// Operations are the operations the endpoint reviews, all of them if
+	// empty.
+	Operations []string
+
+	// Mutating allows the endpoint to replace the object being written.
+	Mutating bool
+
+	// FailurePolicy is AdmissionFailClosed to reject the writes, or
+	// AdmissionFailOpen to accept them, when the endpoint can't be reached or
+	// returns an invalid response.
+	FailurePolicy string
+
+	// Timeout is the timeout of each request.
+	Timeout time.Duration
+}
+
+// AdmissionRequest is the body of the requests posted to admission webhooks.
+type AdmissionRequest struct {
+	ID         string
+	Operation  string
+	Datacenter string
+
+	// Object is the object being written: the registration or deregistration
+	// request of the catalog operations, without its token, or the config
+	// entry of the config entry operations.
+	Object interface{}
+}
+
+// AdmissionResponse is the body of the responses of admission webhooks.
+type AdmissionResponse struct {
+	// Allowed must be true for the write to proceed.
+	Allowed bool
+
+	// Reason is returned to the client when the write is denied.
+	Reason string `json:",omitempty"`
+
+	// Object replaces the object being written when it is set by a mutating
+	// webhook for a catalog registration or a config entry upsert. It is
+	// validated and checked against the ACLs like the original one.
+	Object json.RawMessage `json:",omitempty"`
+}
+
+// admissionController calls the admission webhooks for the writes handled by
+// a server. The webhooks are called in order, each receiving the object
+// returned by the mutating webhooks before it.
+type admissionController struct {
+	datacenter string
+	webhooks   []*admissionWebhook
+	logger     hclog.Logger
+}
+
+type admissionWebhook struct {
+	config AdmissionWebhookConfig
+	client *http.Client
+}
+
+// newAdmissionController returns a controller calling the webhooks, or nil
+// if there are none.
+func newAdmissionController(datacenter string, configs []AdmissionWebhookConfig, logger hclog.Logger) *admissionController {
+	if len(configs) == 0 {
+		return nil
+	}
+	a := &admissionController{
+		datacenter: datacenter,
+		logger:     logger,
+	}
+	for _, config := range configs {
+		if config.Timeout <= 0 {
+			config.Timeout = admissionDefaultTimeout
+		}
+		if config.FailurePolicy == "" {
+			config.FailurePolicy = AdmissionFailClosed
+		}
+		client := cleanhttp.DefaultPooledClient()
+		client.Timeout = config.Timeout
+		a.webhooks = append(a.webhooks, &admissionWebhook{config: config, client: client})
+	}
+	return a
+}
+
+// admitRegister reviews a catalog registration, replacing it with the one
+// returned by the mutating webhooks.
+func (a *admissionController) admitRegister(args *structs.RegisterRequest) error {
+	if a == nil {
+		return nil
+	}
+	view := func() interface{} {
+		req := *args
+		req.WriteRequest = structs.WriteRequest{}
+		req.RaftIndex = structs.RaftIndex{}
+		return &req
+	}
+	patch := func(raw json.RawMessage) error {
+		var req structs.RegisterRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			return err
+		}
+		// The token and the datacenter of the request can't be changed.
+		req.Datacenter = args.Datacenter
+		req.WriteRequest = args.WriteRequest
+		req.RaftIndex = args.RaftIndex
+		*args = req
+		return nil
+	}
+	return a.admit(AdmissionOperationCatalogRegister, view, patch)
+}
+
+// admitDeregister reviews a catalog deregistration.
+func (a *admissionController) admitDeregister(args *structs.DeregisterRequest) error {
+	if a == nil {
+		return nil
+	}
+	view := func() interface{} {
+		req := *args
+		req.WriteRequest = structs.WriteRequest{}
+		return &req
+	}
+	return a.admit(AdmissionOperationCatalogDeregister, view, nil)
+}
+
+// admitConfigEntryUpsert reviews the upsert of a config entry, replacing it
+// with the one returned by the mutating webhooks.
+func (a *admissionController) admitConfigEntryUpsert(args *structs.ConfigEntryRequest) error {
+	if a == nil {
+		return nil
+	}
+	view := func() interface{} {
+		return args.Entry
+	}
+	patch := func(raw json.RawMessage) error {
+		// Decode into an entry of the same type, so the kind can't change.
+		entry := reflect.New(reflect.TypeOf(args.Entry).Elem()).Interface().(structs.ConfigEntry)
+		if err := json.Unmarshal(raw, entry); err != nil {
+			return err
+		}
+		args.Entry = entry
+		return nil
+	}
+	return a.admit(AdmissionOperationConfigEntryUpsert, view, patch)
+}
+
+// admitConfigEntryDelete reviews the deletion of a config entry.
+func (a *admissionController) admitConfigEntryDelete(args *structs.ConfigEntryRequest) error {
+	if a == nil {
+		return nil
+	}
+	view := func() interface{} {
+		return args.Entry
+	}
+	return a.admit(AdmissionOperationConfigEntryDelete, view, nil)
+}
+
+// admit calls the webhooks reviewing the operation with the object returned
+// by view, and calls patch with the objects returned by the mutating ones. It
+// returns a permission denied error if a webhook denies the write.
+func (a *admissionController) admit(op string, view func() interface{}, patch func(json.RawMessage) error) error {
+	for _, w := range a.webhooks {
+		if len(w.config.Operations) > 0 && !slices.Contains(w.config.Operations, op) {
+			continue
+		}
+		labels := []metrics.Label{{Name: "webhook", Value: w.config.Name}, {Name: "operation", Value: op}}
+
+		resp, err := w.review(a.datacenter, op, view())
+		if err == nil && resp.Allowed && len(resp.Object) > 0 && w.config.Mutating && patch != nil {
+			if perr := patch(resp.Object); perr != nil {
+				err = fmt.Errorf("invalid object: %w", perr)
+			}
+		}
+		if err != nil {
+			metrics.IncrCounterWithLabels(metricsKeyAdmissionFailed, 1, labels)
+			if w.config.FailurePolicy == AdmissionFailOpen {
+				a.logger.Warn("admission webhook failed, accepting the write", "webhook", w.config.Name, "operation", op, "error", err)
+				continue
+			}
+			return fmt.Errorf("admission webhook %q failed: %w", w.config.Name, err)
+		}
+
+		if !resp.Allowed {
+			metrics.IncrCounterWithLabels(metricsKeyAdmissionDenied, 1, labels)
+			cause := fmt.Sprintf("admission webhook %q denied the request", w.config.Name)
+			if resp.Reason != "" {
+				cause += ": " + resp.Reason
+			}
+			return acl.PermissionDeniedError{Cause: cause}
+		}
+	}
+	return nil
+}
+
+// review posts the object to the webhook and returns its response.
+func (w *admissionWebhook) review(datacenter, op string, obj interface{}) (*AdmissionResponse, error) {
+	defer metrics.MeasureSinceWithLabels(metricsKeyAdmissionWebhook, time.Now(),
+		[]metrics.Label{{Name: "webhook", Value: w.config.Name}, {Name: "operation", Value: op}})
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(&AdmissionRequest{
+		ID:         id,
+		Operation:  op,
+		Datacenter: datacenter,
+		Object:     obj,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(AdmissionOperationHeader, op)
+	req.Header.Set(WebhookDeliveryHeader, id)
+	if w.config.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	var out AdmissionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, admissionMaxResponseSize)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,529 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+// admissionReview is the request received by a test admission webhook.
+type admissionReview struct {
+	Header http.Header
+	Body   []byte
+	AdmissionRequest
+	Object json.RawMessage
+}
+
+// testAdmissionWebhook runs an admission webhook answering with review, and
+// returns its URL and the requests it received.
+func testAdmissionWebhook(t *testing.T, review func(r *admissionReview) (int, *AdmissionResponse)) (string, func() []*admissionReview) {
+	var (
+		mu       sync.Mutex
+		received []*admissionReview
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r := &admissionReview{Header: req.Header, Body: body}
+		if err := json.Unmarshal(body, &r.AdmissionRequest); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var raw struct{ Object json.RawMessage }
+		if err := json.Unmarshal(body, &raw); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.Object = raw.Object
+
+		mu.Lock()
+		received = append(received, r)
+		mu.Unlock()
+
+		code, resp := review(r)
+		w.WriteHeader(code)
+		if resp != nil {
+			json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL, func() []*admissionReview {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*admissionReview(nil), received...)
+	}
+}
+
+func TestAdmissionController(t *testing.T) {
+	register := func() *structs.RegisterRequest {
+		return &structs.RegisterRequest{
+			Datacenter:   "dc1",
+			Node:         "node1",
+			Address:      "127.0.0.1",
+			Service:      &structs.NodeService{ID: "web1", Service: "web"},
+			WriteRequest: structs.WriteRequest{Token: "secret-token"},
+		}
+	}
+	allow := func(r *admissionReview) (int, *AdmissionResponse) {
+		return http.StatusOK, &AdmissionResponse{Allowed: true}
+	}
+	// addTag returns the registration with a tag added to its service.
+	addTag := func(r *admissionReview) (int, *AdmissionResponse) {
+		var req structs.RegisterRequest
+		if err := json.Unmarshal(r.Object, &req); err != nil {
+			return http.StatusBadRequest, nil
+		}
+		req.Service.Tags = append(req.Service.Tags, "admitted")
+		req.Datacenter = "dc2"
+		obj, _ := json.Marshal(&req)
+		return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+	}
+	logger := testutil.Logger(t)
+
+	t.Run("disabled", func(t *testing.T) {
+		a := newAdmissionController("dc1", nil, logger)
+		require.Nil(t, a)
+		require.NoError(t, a.admitRegister(register()))
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		url, received := testAdmissionWebhook(t, allow)
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url, Secret: "key"}}, logger)
+
+		req := register()
+		require.NoError(t, a.admitRegister(req))
+		require.Equal(t, register(), req)
+
+		reviews := received()
+		require.Len(t, reviews, 1)
+		r := reviews[0]
+		require.Equal(t, AdmissionOperationCatalogRegister, r.Operation)
+		require.Equal(t, AdmissionOperationCatalogRegister, r.Header.Get(AdmissionOperationHeader))
+		require.Equal(t, "dc1", r.Datacenter)
+		require.NotEmpty(t, r.ID)
+		require.Equal(t, r.ID, r.Header.Get(WebhookDeliveryHeader))
+		require.Equal(t, WebhookSignature("key", r.Header.Get(WebhookTimestampHeader), r.Body), r.Header.Get(WebhookSignatureHeader))
+
+		// The token is not sent to the webhook.
+		require.NotContains(t, string(r.Body), "secret-token")
+		var obj structs.RegisterRequest
+		require.NoError(t, json.Unmarshal(r.Object, &obj))
+		require.Equal(t, "web", obj.Service.Service)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		url, _ := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+			return http.StatusOK, &AdmissionResponse{Reason: "web is reserved"}
+		})
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url}}, logger)
+
+		err := a.admitRegister(register())
+		require.True(t, acl.IsErrPermissionDenied(err))
+		require.Contains(t, err.Error(), `admission webhook "test" denied the request: web is reserved`)
+	})
+
+	t.Run("mutating", func(t *testing.T) {
+		url, received := testAdmissionWebhook(t, addTag)
+		url2, received2 := testAdmissionWebhook(t, allow)
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{
+			{Name: "mutating", URL: url, Mutating: true},
+			{Name: "validating", URL: url2},
+		}, logger)
+
+		req := register()
+		require.NoError(t, a.admitRegister(req))
+		require.Equal(t, []string{"admitted"}, req.Service.Tags)
+		// The token and the datacenter are kept.
+		require.Equal(t, "secret-token", req.Token)
+		require.Equal(t, "dc1", req.Datacenter)
+		require.Len(t, received(), 1)
+
+		// The next webhook reviews the mutated registration.
+		reviews := received2()
+		require.Len(t, reviews, 1)
+		var obj structs.RegisterRequest
+		require.NoError(t, json.Unmarshal(reviews[0].Object, &obj))
+		require.Equal(t, []string{"admitted"}, obj.Service.Tags)
+	})
+
+	t.Run("not mutating", func(t *testing.T) {
+		url, _ := testAdmissionWebhook(t, addTag)
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url}}, logger)
+
+		req := register()
+		require.NoError(t, a.admitRegister(req))
+		require.Empty(t, req.Service.Tags)
+	})
+
+	t.Run("operations", func(t *testing.T) {
+		url, received := testAdmissionWebhook(t, allow)
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{
+			Name:       "test",
+			URL:        url,
+			Operations: []string{AdmissionOperationConfigEntryDelete},
+		}}, logger)
+
+		require.NoError(t, a.admitRegister(register()))
+		require.Empty(t, received())
+
+		require.NoError(t, a.admitConfigEntryDelete(&structs.ConfigEntryRequest{
+			Entry: &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web"},
+		}))
+		reviews := received()
+		require.Len(t, reviews, 1)
+		require.Equal(t, AdmissionOperationConfigEntryDelete, reviews[0].Operation)
+	})
+
+	t.Run("failure policy", func(t *testing.T) {
+		url, _ := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+			return http.StatusInternalServerError, nil
+		})
+
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url}}, logger)
+		err := a.admitRegister(register())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `admission webhook "test" failed: unexpected response code 500`)
+		require.False(t, acl.IsErrPermissionDenied(err))
+
+		a = newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url, FailurePolicy: AdmissionFailOpen}}, logger)
+		require.NoError(t, a.admitRegister(register()))
+	})
+
+	t.Run("config entry", func(t *testing.T) {
+		url, _ := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+			var entry map[string]interface{}
+			if err := json.Unmarshal(r.Object, &entry); err != nil {
+				return http.StatusBadRequest, nil
+			}
+			if entry["Name"] == "kind" {
+				entry["Kind"] = structs.ProxyDefaults
+			}
+			entry["Protocol"] = "http"
+			obj, _ := json.Marshal(entry)
+			return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+		})
+		a := newAdmissionController("dc1", []AdmissionWebhookConfig{{Name: "test", URL: url, Mutating: true}}, logger)
+
+		req := &structs.ConfigEntryRequest{
+			Entry: &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web", Protocol: "tcp"},
+		}
+		require.NoError(t, a.admitConfigEntryUpsert(req))
+		require.Equal(t, "http", req.Entry.(*structs.ServiceConfigEntry).Protocol)
+
+		// The kind of the entry can't change.
+		req = &structs.ConfigEntryRequest{
+			Entry: &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "kind"},
+		}
+		require.NoError(t, a.admitConfigEntryUpsert(req))
+		require.IsType(t, &structs.ServiceConfigEntry{}, req.Entry)
+		require.NoError(t, req.Entry.Normalize())
+		require.Equal(t, structs.ServiceDefaults, req.Entry.GetKind())
+	})
+}
+
+func TestServer_AdmissionWebhooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	url, _ := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+		switch r.Operation {
+		case AdmissionOperationCatalogRegister:
+			var req structs.RegisterRequest
+			if err := json.Unmarshal(r.Object, &req); err != nil {
+				return http.StatusBadRequest, nil
+			}
+			if req.Service == nil {
+				return http.StatusOK, &AdmissionResponse{Allowed: true}
+			}
+			if req.Service.Service == "forbidden" {
+				return http.StatusOK, &AdmissionResponse{Reason: "forbidden service"}
+			}
+			req.Service.Meta = map[string]string{"team": "platform"}
+			obj, _ := json.Marshal(&req)
+			return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+
+		case AdmissionOperationConfigEntryUpsert:
+			var entry structs.ServiceConfigEntry
+			if err := json.Unmarshal(r.Object, &entry); err != nil {
+				return http.StatusBadRequest, nil
+			}
+			entry.Protocol = "http"
+			obj, _ := json.Marshal(&entry)
+			return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+
+		case AdmissionOperationConfigEntryDelete:
+			return http.StatusOK, &AdmissionResponse{Reason: "config entries can't be deleted"}
+		}
+		return http.StatusOK, &AdmissionResponse{Allowed: true}
+	})
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.AdmissionWebhooks = []AdmissionWebhookConfig{{
+			Name:     "guardrails",
+			URL:      url,
+			Mutating: true,
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	var out struct{}
+	register := func(service string) error {
+		return s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       "node1",
+			Address:    "127.0.0.1",
+			Service:    &structs.NodeService{ID: service, Service: service},
+		}, &out)
+	}
+
+	err := register("forbidden")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "forbidden service")
+
+	require.NoError(t, register("web"))
+	_, services, err := s1.fsm.State().ServiceNodes(nil, "web", nil, "")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, map[string]string{"team": "platform"}, services[0].ServiceMeta)
+	_, services, err = s1.fsm.State().ServiceNodes(nil, "forbidden", nil, "")
+	require.NoError(t, err)
+	require.Empty(t, services)
+
+	var applied bool
+	require.NoError(t, s1.RPC(context.Background(), "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry:      &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web", Protocol: "tcp"},
+	}, &applied))
+	require.True(t, applied)
+	_, entry, err := s1.fsm.State().ConfigEntry(nil, structs.ServiceDefaults, "web", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http", entry.(*structs.ServiceConfigEntry).Protocol)
+
+	var deleted structs.ConfigEntryDeleteResponse
+	err = s1.RPC(context.Background(), "ConfigEntry.Delete", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry:      &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web"},
+	}, &deleted)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "config entries can't be deleted")
+}
+
+func TestServer_AdmissionWebhooks_ACLs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// The webhook renames the registered services and config entries to db.
+	url, received := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(r.Object, &obj); err != nil {
+			return http.StatusBadRequest, nil
+		}
+		switch r.Operation {
+		case AdmissionOperationCatalogRegister:
+			if service, ok := obj["Service"].(map[string]interface{}); ok {
+				service["ID"] = "db"
+				service["Service"] = "db"
+			}
+		case AdmissionOperationConfigEntryUpsert:
+			obj["Name"] = "db"
+		}
+		raw, _ := json.Marshal(obj)
+		return http.StatusOK, &AdmissionResponse{Allowed: true, Object: raw}
+	})
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+		c.AdmissionWebhooks = []AdmissionWebhookConfig{{
+			Name:     "rename",
+			URL:      url,
+			Mutating: true,
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1", testrpc.WithToken("root"))
+	codec := rpcClient(t, s1)
+
+	token, err := upsertTestTokenWithPolicyRules(codec, "root", "dc1", `
+node "node1" { policy = "write" }
+service "web" { policy = "write" }
+`)
+	require.NoError(t, err)
+
+	register := func(service string) error {
+		var out struct{}
+		return s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+			Datacenter:   "dc1",
+			Node:         "node1",
+			Address:      "127.0.0.1",
+			Service:      &structs.NodeService{ID: service, Service: service},
+			WriteRequest: structs.WriteRequest{Token: token.SecretID},
+		}, &out)
+	}
+	applyEntry := func(name string) error {
+		var applied bool
+		return s1.RPC(context.Background(), "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+			Datacenter:   "dc1",
+			Entry:        &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: name},
+			WriteRequest: structs.WriteRequest{Token: token.SecretID},
+		}, &applied)
+	}
+
+	// Writes the token isn't allowed to make are not sent to the webhook.
+	err = register("db")
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+	err = applyEntry("db")
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+	require.Empty(t, received())
+
+	// The objects returned by the webhook are checked against the ACLs.
+	err = register("web")
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+	err = applyEntry("web")
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+	require.Len(t, received(), 2)
+
+	_, services, err := s1.fsm.State().ServiceNodes(nil, "db", nil, "")
+	require.NoError(t, err)
+	require.Empty(t, services)
+	_, entry, err := s1.fsm.State().ConfigEntry(nil, structs.ServiceDefaults, "db", nil)
+	require.NoError(t, err)
+	require.Nil(t, entry)
+}
+
+func TestServer_AdmissionWebhooks_Txn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	url, received := testAdmissionWebhook(t, func(r *admissionReview) (int, *AdmissionResponse) {
+		switch r.Operation {
+		case AdmissionOperationCatalogRegister:
+			var req structs.RegisterRequest
+			if err := json.Unmarshal(r.Object, &req); err != nil {
+				return http.StatusBadRequest, nil
+			}
+			if req.Service == nil {
+				return http.StatusOK, &AdmissionResponse{Allowed: true}
+			}
+			if req.Service.Service == "forbidden" {
+				return http.StatusOK, &AdmissionResponse{Reason: "forbidden service"}
+			}
+			req.Service.Meta = map[string]string{"team": "platform"}
+			obj, _ := json.Marshal(&req)
+			return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+
+		case AdmissionOperationCatalogDeregister:
+			return http.StatusOK, &AdmissionResponse{Reason: "nodes can't be deregistered"}
+
+		case AdmissionOperationConfigEntryUpsert:
+			var entry structs.ServiceConfigEntry
+			if err := json.Unmarshal(r.Object, &entry); err != nil {
+				return http.StatusBadRequest, nil
+			}
+			entry.Protocol = "http"
+			obj, _ := json.Marshal(&entry)
+			return http.StatusOK, &AdmissionResponse{Allowed: true, Object: obj}
+		}
+		return http.StatusOK, &AdmissionResponse{Allowed: true}
+	})
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.AdmissionWebhooks = []AdmissionWebhookConfig{{
+			Name:     "guardrails",
+			URL:      url,
+			Mutating: true,
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	apply := func(ops ...*structs.TxnOp) structs.TxnResponse {
+		var out structs.TxnResponse
+		require.NoError(t, s1.RPC(context.Background(), "Txn.Apply", &structs.TxnRequest{
+			Datacenter: "dc1",
+			Ops:        ops,
+		}, &out))
+		return out
+	}
+	node := structs.Node{Node: "node1", Address: "127.0.0.1"}
+	serviceOp := func(service string) *structs.TxnOp {
+		return &structs.TxnOp{Service: &structs.TxnServiceOp{
+			Verb:    api.ServiceSet,
+			Node:    "node1",
+			Service: structs.NodeService{ID: service, Service: service},
+		}}
+	}
+
+	out := apply(
+		&structs.TxnOp{Node: &structs.TxnNodeOp{Verb: api.NodeSet, Node: node}},
+		serviceOp("forbidden"),
+	)
+	require.Len(t, out.Errors, 1)
+	require.Equal(t, 1, out.Errors[0].OpIndex)
+	require.Contains(t, out.Errors[0].What, "forbidden service")
+	_, n, err := s1.fsm.State().GetNode("node1", nil, "")
+	require.NoError(t, err)
+	require.Nil(t, n)
+
+	out = apply(
+		&structs.TxnOp{Node: &structs.TxnNodeOp{Verb: api.NodeSet, Node: node}},
+		serviceOp("web"),
+		&structs.TxnOp{ConfigEntry: &structs.TxnConfigEntryOp{
+			Verb:  api.ConfigEntrySet,
+			Entry: &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web", Protocol: "tcp"},
+		}},
+	)
+	require.Empty(t, out.Errors)
+	_, services, err := s1.fsm.State().ServiceNodes(nil, "web", nil, "")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, map[string]string{"team": "platform"}, services[0].ServiceMeta)
+	_, entry, err := s1.fsm.State().ConfigEntry(nil, structs.ServiceDefaults, "web", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http", entry.(*structs.ServiceConfigEntry).Protocol)
+
+	out = apply(&structs.TxnOp{Node: &structs.TxnNodeOp{Verb: api.NodeDelete, Node: node}})
+	require.Len(t, out.Errors, 1)
+	require.Contains(t, out.Errors[0].What, "nodes can't be deregistered")
+	_, n, err = s1.fsm.State().GetNode("node1", nil, "")
+	require.NoError(t, err)
+	require.NotNil(t, n)
+
+	operations := make(map[string]int)
+	for _, r := range received() {
+		operations[r.Operation]++
+	}
+	require.Equal(t, map[string]int{
+		AdmissionOperationCatalogRegister:   4,
+		AdmissionOperationCatalogDeregister: 1,
+		AdmissionOperationConfigEntryUpsert: 1,
+	}, operations)
+}
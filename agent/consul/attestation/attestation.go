@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package attestation proves the identity of the machine an agent runs on to
+// the servers. The agents collect evidence signed by a root of trust outside
+// of Consul, like the instance identity document of a cloud provider or a
+// quote of a TPM, and the servers verify it before accepting the
+// registrations of the node or issuing its token.
+package attestation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// TypeAWSIID attests an EC2 instance with its instance identity document
+	// and the signature of the document by AWS.
+	TypeAWSIID = "aws-iid"
+
+	// TypeTPM attests a machine with a quote of its TPM, signed by an
+	// attestation key registered with the servers.
+	TypeTPM = "tpm"
+)
+
+// Types lists the supported attestation types.
+var Types = []string{TypeAWSIID, TypeTPM}
+
+// MaxEvidenceAge is how long evidence is accepted after it was collected. The
+// agents collect new evidence well before it expires.
+const MaxEvidenceAge = 10 * time.Minute
+
+// CanLogin returns whether the evidence of type typ can be used to log in to
+// an auth method. The signature of an instance identity document does not
+// cover the node name, so only TypeTPM evidence, whose quote is made over
+// the nonce of the node, proves which node the agent is.
+func CanLogin(typ string) bool {
+	return typ == TypeTPM
+}
+
+// IsValidType returns whether typ is supported.
+func IsValidType(typ string) bool {
+	for _, t := range Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Evidence is collected by an agent to prove the identity of its machine. It
+// is sent to the servers encoded with Encode.
+type Evidence struct {
+	Type string
+
+	// Node and NodeID are the node the agent claims to be. The servers only
+	// accept the evidence for the registrations of this node.
+	Node   string
+	NodeID string
+
+	// Timestamp is when the evidence was collected.
+	Timestamp time.Time
+
+	AWS *AWSEvidence `json:",omitempty"`
+	TPM *TPMEvidence `json:",omitempty"`
+}
+
+// Encode returns the encoded evidence, used as the login token of the auth
+// methods and attached to the registrations.
+func (e *Evidence) Encode() (string, error) {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// Decode decodes evidence encoded with Encode.
+func Decode(encoded string) (*Evidence, error) {
+	if encoded == "" {
+		return nil, errors.New("no attestation evidence provided")
+	}
+	var e Evidence
+	if err := json.Unmarshal([]byte(encoded), &e); err != nil {
+		return nil, fmt.Errorf("failed to decode the attestation evidence: %w", err)
+	}
+	return &e, nil
+}
+
+// Nonce returns the nonce a TPM quote must be made over for the evidence. It
+// binds the quote to the node and the time it was collected.
+func (e *Evidence) Nonce() []byte {
+	h := sha256.New()
+	fmt.Fprintf(h, "consul-node-attestation\x00%s\x00%s\x00%d", e.Node, e.NodeID, e.Timestamp.Unix())
+	return h.Sum(nil)
+}
+
+// Identity is the verified identity of the machine an agent runs on.
+type Identity struct {
+	Type   string
+	Node   string
+	NodeID string
+
+	// ID identifies the machine: the instance ID for TypeAWSIID and the
+	// fingerprint of the attestation key for TypeTPM.
+	ID string
+
+	// AccountID, Region and PrivateIP are set for TypeAWSIID.
+	AccountID string
+	Region    string
+	PrivateIP string
+}
+
+// CheckNode returns an error if the identity was not attested for the node
+// with the given name and ID, whose address in the LAN gossip pool is
+// memberAddr. For TypeAWSIID the node name is not signed, so the private
+// address of the instance must be the gossip address of the node.
+func (i *Identity) CheckNode(node, nodeID, memberAddr string) error {
+	if i.Node != node {
+		return fmt.Errorf("attestation evidence was collected for node %q, not %q", i.Node, node)
+	}
+	if i.NodeID != "" && nodeID != "" && i.NodeID != nodeID {
+		return fmt.Errorf("attestation evidence was collected for node ID %q, not %q", i.NodeID, nodeID)
+	}
+	if i.Type != TypeAWSIID {
+		return nil
+	}
+
+	if ip := net.ParseIP(memberAddr); ip != nil && ip.Equal(net.ParseIP(i.PrivateIP)) {
+		return nil
+	}
+	return fmt.Errorf("private address %q of instance %q is not the gossip address of node %q", i.PrivateIP, i.ID, node)
+}
+
+// Config is the configuration of the verification of the evidence. At least
+// one attestation type must be configured.
+type Config struct {
+	// AWSCertificates are the PEM encoded certificates AWS signs the
+	// instance identity documents with, one per region the instances run in.
+	AWSCertificates []string `json:",omitempty"`
+
+	// AWSAccountIDs and AWSRegions restrict the accepted instances when set.
+	AWSAccountIDs []string `json:",omitempty"`
+	AWSRegions    []string `json:",omitempty"`
+
+	// TPMAttestationKeys are the PEM encoded public keys of the attestation
+	// keys of the TPMs of the trusted machines.
+	TPMAttestationKeys []string `json:",omitempty"`
+
+	// TPMPCRDigests restricts the accepted quotes to the ones whose digest of
+	// the selected PCRs is one of these hex encoded values, when set.
+	TPMPCRDigests []string `json:",omitempty"`
+}
+
+// Verifier verifies the evidence collected by the agents.
+type Verifier struct {
+	aws *awsVerifier
+	tpm *tpmVerifier
+}
+
+// NewVerifier returns a verifier for the given configuration.
+func NewVerifier(config Config) (*Verifier, error) {
+	var v Verifier
+	var err error
+	if len(config.AWSCertificates) > 0 {
+		if v.aws, err = newAWSVerifier(config); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.TPMAttestationKeys) > 0 {
+		if v.tpm, err = newTPMVerifier(config); err != nil {
+			return nil, err
+		}
+	}
+	if v.aws == nil && v.tpm == nil {
+		return nil, errors.New("at least one of AWSCertificates or TPMAttestationKeys is required")
+	}
+	return &v, nil
+}
+
+// Verify verifies the encoded evidence and returns the attested identity.
+func (v *Verifier) Verify(encoded string, now time.Time) (*Identity, error) {
+	e, err := Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if e.Node == "" {
+		return nil, errors.New("attestation evidence has no node")
+	}
+	if age := now.Sub(e.Timestamp); age > MaxEvidenceAge || age < -MaxEvidenceAge {
+		return nil, fmt.Errorf("attestation evidence collected at %s has expired", e.Timestamp.UTC().Format(time.RFC3339))
+	}
+
+	var id *Identity
+	switch e.Type {
+	case TypeAWSIID:
+		if v.aws == nil || e.AWS == nil {
+			return nil, fmt.Errorf("attestation type %q is not accepted", e.Type)
+		}
+		id, err = v.aws.verify(e.AWS)
+	case TypeTPM:
+		if v.tpm == nil || e.TPM == nil {
+			return nil, fmt.Errorf("attestation type %q is not accepted", e.Type)
+		}
+		id, err = v.tpm.verify(e.TPM, e.Nonce())
+	default:
+		return nil, fmt.Errorf("unsupported attestation type %q", e.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id.Type = e.Type
+	id.Node = e.Node
+	id.NodeID = e.NodeID
+	return id, nil
+}
+
+// Collector collects the evidence of the machine the agent runs on.
+type Collector struct {
+	// Type is one of TypeAWSIID or TypeTPM.
+	Type string
+
+	Node   string
+	NodeID string
+
+	// TPMCommand is the command producing a quote of the TPM for TypeTPM.
+	// It is run with the hex encoded nonce as its last argument and must
+	// print the quote as JSON, see TPMEvidence.
+	TPMCommand []string
+
+	// AWSEndpoint overrides the address of the EC2 instance metadata service.
+	AWSEndpoint string
+}
+
+// Collect collects new evidence and returns it encoded.
+func (c *Collector) Collect(ctx context.Context, now time.Time) (string, error) {
+	e := &Evidence{
+		Type:      c.Type,
+		Node:      c.Node,
+		NodeID:    c.NodeID,
+		Timestamp: now.UTC().Truncate(time.Second),
+	}
+
+	var err error
+	switch c.Type {
+	case TypeAWSIID:
+		e.AWS, err = collectAWS(ctx, c.AWSEndpoint)
+	case TypeTPM:
+		e.TPM, err = collectTPM(ctx, c.TPMCommand, e.Nonce())
+	default:
+		return "", fmt.Errorf("unsupported attestation type %q", c.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to collect the %s attestation evidence: %w", c.Type, err)
+	}
+	return e.Encode()
+}
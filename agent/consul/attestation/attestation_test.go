@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testIdentityDocument = `{
+  "accountId" : "123456789012",
+  "instanceId" : "i-0123456789abcdef0",
+  "privateIp" : "10.0.0.12",
+  "region" : "us-east-1"
+}`
+
+// testAWSSigner returns the PEM encoded certificate of a key signing
+// instance identity documents like AWS does.
+func testAWSSigner(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Amazon Web Services LLC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func signDocument(t *testing.T, key *rsa.PrivateKey, document string) string {
+	digest := sha256.Sum256([]byte(document))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestAWSIID(t *testing.T) {
+	key, cert := testAWSSigner(t)
+	signature := signDocument(t, key, testIdentityDocument)
+
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			w.Write([]byte("aws-token"))
+		case r.Header.Get("X-aws-ec2-metadata-token") != "aws-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/latest/dynamic/instance-identity/document":
+			w.Write([]byte(testIdentityDocument))
+		case r.URL.Path == "/latest/dynamic/instance-identity/signature":
+			// The signature is wrapped like the instance metadata service
+			// does.
+			w.Write([]byte(signature[:64] + "\n" + signature[64:]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(imds.Close)
+
+	collector := &Collector{Type: TypeAWSIID, Node: "web-1", NodeID: "a5b9e6a0-3a6c-4c1f-9f52-1c3c2c0b6d10", AWSEndpoint: imds.URL}
+	evidence, err := collector.Collect(context.Background(), time.Now())
+	require.NoError(t, err)
+
+	v, err := NewVerifier(Config{AWSCertificates: []string{cert}, AWSAccountIDs: []string{"123456789012"}})
+	require.NoError(t, err)
+	id, err := v.Verify(evidence, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, &Identity{
+		Type:      TypeAWSIID,
+		Node:      "web-1",
+		NodeID:    "a5b9e6a0-3a6c-4c1f-9f52-1c3c2c0b6d10",
+		ID:        "i-0123456789abcdef0",
+		AccountID: "123456789012",
+		Region:    "us-east-1",
+		PrivateIP: "10.0.0.12",
+	}, id)
+
+	// The evidence expires.
+	_, err = v.Verify(evidence, time.Now().Add(MaxEvidenceAge+time.Minute))
+	require.ErrorContains(t, err, "has expired")
+
+	require.NoError(t, id.CheckNode("web-1", "a5b9e6a0-3a6c-4c1f-9f52-1c3c2c0b6d10", "10.0.0.12"))
+	require.ErrorContains(t, id.CheckNode("web-2", "", "10.0.0.12"), `collected for node "web-1", not "web-2"`)
+	require.ErrorContains(t, id.CheckNode("web-1", "", "10.0.0.13"), `private address "10.0.0.12" of instance "i-0123456789abcdef0" is not the gossip address of node "web-1"`)
+	require.False(t, CanLogin(id.Type))
+
+	// The account and the region are restricted.
+	v, err = NewVerifier(Config{AWSCertificates: []string{cert}, AWSRegions: []string{"eu-west-1"}})
+	require.NoError(t, err)
+	_, err = v.Verify(evidence, time.Now())
+	require.ErrorContains(t, err, `AWS region "us-east-1" is not allowed`)
+
+	// A document signed by another key is rejected.
+	_, otherCert := testAWSSigner(t)
+	v, err = NewVerifier(Config{AWSCertificates: []string{otherCert}})
+	require.NoError(t, err)
+	_, err = v.Verify(evidence, time.Now())
+	require.ErrorContains(t, err, "instance identity document is not signed by any of the AWS certificates")
+
+	// A TPM verifier does not accept the evidence.
+	v, err = NewVerifier(Config{TPMAttestationKeys: []string{testAttestationKey(t).pem}})
+	require.NoError(t, err)
+	_, err = v.Verify(evidence, time.Now())
+	require.ErrorContains(t, err, `attestation type "aws-iid" is not accepted`)
+}
+
+type attestationKey struct {
+	key *ecdsa.PrivateKey
+	der []byte
+	pem string
+}
+
+func testAttestationKey(t *testing.T) attestationKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return attestationKey{
+		key: key,
+		der: der,
+		pem: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+}
+
+// testQuote returns a TPMS_ATTEST structure of a quote over extraData.
+func testQuote(extraData, pcrDigest []byte) []byte {
+	var b bytes.Buffer
+	write := func(v interface{}) { binary.Write(&b, binary.BigEndian, v) }
+	write2B := func(v []byte) {
+		write(uint16(len(v)))
+		b.Write(v)
+	}
+	write(tpmGeneratedValue)
+	write(tpmSTAttestQuote)
+	write2B([]byte("signer"))
+	write2B(extraData)
+	b.Write(make([]byte, 17)) // clockInfo
+	write(uint64(1))          // firmwareVersion
+	write(uint32(1))          // pcrSelect count
+	write(uint16(0x000b))     // sha256
+	write(uint8(3))
+	b.Write([]byte{0x01, 0x00, 0x00})
+	write2B(pcrDigest)
+	return b.Bytes()
+}
+
+func (k attestationKey) evidence(t *testing.T, e *Evidence, pcrDigest []byte) string {
+	quote := testQuote(e.Nonce(), pcrDigest)
+	digest := sha256.Sum256(quote)
+	sig, err := ecdsa.SignASN1(rand.Reader, k.key, digest[:])
+	require.NoError(t, err)
+	e.TPM = &TPMEvidence{AttestationKey: k.der, Quote: quote, Signature: sig}
+
+	encoded, err := e.Encode()
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestTPM(t *testing.T) {
+	ak := testAttestationKey(t)
+	pcrDigest := bytes.Repeat([]byte{0xab}, 32)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	v, err := NewVerifier(Config{
+		TPMAttestationKeys: []string{ak.pem},
+		TPMPCRDigests:      []string{"abababababababababababababababababababababababababababababababab"},
+	})
+	require.NoError(t, err)
+
+	evidence := ak.evidence(t, &Evidence{Type: TypeTPM, Node: "web-1", Timestamp: now}, pcrDigest)
+	id, err := v.Verify(evidence, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, TypeTPM, id.Type)
+	require.Equal(t, "web-1", id.Node)
+	require.Equal(t, keyFingerprint(ak.der), id.ID)
+	require.NoError(t, id.CheckNode("web-1", "", ""))
+	require.True(t, CanLogin(id.Type))
+
+	// The evidence expires.
+	_, err = v.Verify(evidence, now.Add(MaxEvidenceAge+time.Minute))
+	require.ErrorContains(t, err, "has expired")
+
+	// The quote must be made over the nonce of the evidence, which binds it
+	// to the node.
+	e, err := Decode(evidence)
+	require.NoError(t, err)
+	e.Node = "web-2"
+	replayed, err := e.Encode()
+	require.NoError(t, err)
+	_, err = v.Verify(replayed, now)
+	require.ErrorContains(t, err, "TPM quote was not made over the nonce of the evidence")
+
+	// The PCRs must match.
+	evidence = ak.evidence(t, &Evidence{Type: TypeTPM, Node: "web-1", Timestamp: now}, bytes.Repeat([]byte{0xcd}, 32))
+	_, err = v.Verify(evidence, now)
+	require.ErrorContains(t, err, "is not allowed")
+
+	// The attestation key must be trusted.
+	evidence = testAttestationKey(t).evidence(t, &Evidence{Type: TypeTPM, Node: "web-1", Timestamp: now}, pcrDigest)
+	_, err = v.Verify(evidence, now)
+	require.ErrorContains(t, err, "is not trusted")
+}
+
+func TestCollector_TPM(t *testing.T) {
+	// The nonce is passed as the last argument of the command.
+	collector := &Collector{
+		Type:       TypeTPM,
+		Node:       "web-1",
+		TPMCommand: []string{"sh", "-c", `test ${#0} -eq 64 && echo '{"Signature": "AQID"}'`},
+	}
+	encoded, err := collector.Collect(context.Background(), time.Now())
+	require.NoError(t, err)
+	e, err := Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, e.TPM.Signature)
+
+	collector.TPMCommand = []string{"sh", "-c", "echo no tpm >&2; exit 1"}
+	_, err = collector.Collect(context.Background(), time.Now())
+	require.ErrorContains(t, err, "TPM quote command failed: exit status 1: no tpm")
+}
+
+func TestNewVerifier(t *testing.T) {
+	_, err := NewVerifier(Config{})
+	require.ErrorContains(t, err, "at least one of AWSCertificates or TPMAttestationKeys is required")
+
+	_, err = NewVerifier(Config{AWSCertificates: []string{"not a certificate"}})
+	require.ErrorContains(t, err, "AWSCertificates[0] does not contain a PEM encoded certificate")
+
+	_, err = NewVerifier(Config{TPMAttestationKeys: []string{testAttestationKey(t).pem}, TPMPCRDigests: []string{"xyz"}})
+	require.ErrorContains(t, err, "TPMPCRDigests[0] is not hex encoded")
+}
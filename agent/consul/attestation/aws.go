@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestation
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/agent/cloudmeta"
+)
+
+// AWSEvidence is the instance identity document of an EC2 instance and its
+// signature, as returned by the instance metadata service.
+type AWSEvidence struct {
+	// Document is the JSON instance identity document.
+	Document string
+
+	// Signature is the base64 encoded RSA-SHA256 signature of the document.
+	Signature string
+}
+
+// awsIdentityDocument holds the fields of the instance identity document
+// used by the verification.
+type awsIdentityDocument struct {
+	AccountID  string `json:"accountId"`
+	InstanceID string `json:"instanceId"`
+	PrivateIP  string `json:"privateIp"`
+	Region     string `json:"region"`
+}
+
+func collectAWS(ctx context.Context, endpoint string) (*AWSEvidence, error) {
+	client := &cloudmeta.Client{Provider: cloudmeta.ProviderAWS, Endpoint: endpoint}
+	document, signature, err := client.AWSInstanceIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &AWSEvidence{Document: document, Signature: signature}, nil
+}
+
+type awsVerifier struct {
+	keys     []*rsa.PublicKey
+	accounts map[string]struct{}
+	regions  map[string]struct{}
+}
+
+func newAWSVerifier(config Config) (*awsVerifier, error) {
+	v := &awsVerifier{
+		accounts: stringSet(config.AWSAccountIDs),
+		regions:  stringSet(config.AWSRegions),
+	}
+	for i, raw := range config.AWSCertificates {
+		block, _ := pem.Decode([]byte(raw))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("AWSCertificates[%d] does not contain a PEM encoded certificate", i)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse AWSCertificates[%d]: %w", i, err)
+		}
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("AWSCertificates[%d] does not contain an RSA public key", i)
+		}
+		v.keys = append(v.keys, key)
+	}
+	return v, nil
+}
+
+func (v *awsVerifier) verify(e *AWSEvidence) (*Identity, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(e.Signature), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the instance identity signature: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(e.Document))
+	verified := false
+	for _, key := range v.keys {
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("instance identity document is not signed by any of the AWS certificates")
+	}
+
+	var doc awsIdentityDocument
+	if err := json.Unmarshal([]byte(e.Document), &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode the instance identity document: %w", err)
+	}
+	if doc.InstanceID == "" {
+		return nil, errors.New("instance identity document has no instance ID")
+	}
+	if !allowed(v.accounts, doc.AccountID) {
+		return nil, fmt.Errorf("AWS account %q is not allowed", doc.AccountID)
+	}
+	if !allowed(v.regions, doc.Region) {
+		return nil, fmt.Errorf("AWS region %q is not allowed", doc.Region)
+	}
+
+	return &Identity{
+		ID:        doc.InstanceID,
+		AccountID: doc.AccountID,
+		Region:    doc.Region,
+		PrivateIP: doc.PrivateIP,
+	}, nil
+}
+
+func stringSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// allowed returns whether value is in set, an empty set allows any value.
+func allowed(set map[string]struct{}, value string) bool {
+	if set == nil {
+		return true
+	}
+	_, ok := set[value]
+	return ok
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestation
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// tpmGeneratedValue is the magic number of the structures signed by a
+	// TPM, which it refuses to sign when they come from outside of the TPM.
+	tpmGeneratedValue uint32 = 0xff544347
+
+	// tpmSTAttestQuote is the type of the attestation structure of a quote.
+	tpmSTAttestQuote uint16 = 0x8018
+)
+
+// TPMEvidence is a quote of the PCRs of a TPM over the nonce of the evidence.
+type TPMEvidence struct {
+	// AttestationKey is the DER encoded public key of the attestation key
+	// which signed the quote.
+	AttestationKey []byte
+
+	// Quote is the TPMS_ATTEST structure returned by TPM2_Quote.
+	Quote []byte
+
+	// Signature is the signature of the quote by the attestation key, either
+	// RSASSA-PKCS1-v1_5 with SHA-256 or ECDSA with SHA-256.
+	Signature []byte
+}
+
+// collectTPM runs the quote command with the hex encoded nonce as its last
+// argument, and decodes the TPMEvidence it prints as JSON.
+func collectTPM(ctx context.Context, command []string, nonce []byte) (*TPMEvidence, error) {
+	if len(command) == 0 {
+		return nil, errors.New("no TPM quote command configured")
+	}
+
+	args := append(append([]string(nil), command[1:]...), hex.EncodeToString(nonce))
+	cmd := exec.CommandContext(ctx, command[0], args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("TPM quote command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var e TPMEvidence
+	if err := json.Unmarshal(out, &e); err != nil {
+		return nil, fmt.Errorf("failed to decode the output of the TPM quote command: %w", err)
+	}
+	return &e, nil
+}
+
+type tpmVerifier struct {
+	keys       map[string]crypto.PublicKey
+	pcrDigests map[string]struct{}
+}
+
+func newTPMVerifier(config Config) (*tpmVerifier, error) {
+	v := &tpmVerifier{
+		keys:       make(map[string]crypto.PublicKey),
+		pcrDigests: stringSet(config.TPMPCRDigests),
+	}
+	for i, raw := range config.TPMAttestationKeys {
+		block, _ := pem.Decode([]byte(raw))
+		if block == nil || block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("TPMAttestationKeys[%d] does not contain a PEM encoded public key", i)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TPMAttestationKeys[%d]: %w", i, err)
+		}
+		switch key.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+		default:
+			return nil, fmt.Errorf("TPMAttestationKeys[%d] is neither an RSA nor an ECDSA key", i)
+		}
+		v.keys[keyFingerprint(block.Bytes)] = key
+	}
+	for i, digest := range config.TPMPCRDigests {
+		if _, err := hex.DecodeString(digest); err != nil {
+			return nil, fmt.Errorf("TPMPCRDigests[%d] is not hex encoded: %w", i, err)
+		}
+	}
+	return v, nil
+}
+
+func (v *tpmVerifier) verify(e *TPMEvidence, nonce []byte) (*Identity, error) {
+	fingerprint := keyFingerprint(e.AttestationKey)
+	key, ok := v.keys[fingerprint]
+	if !ok {
+		return nil, fmt.Errorf("attestation key %s is not trusted", fingerprint)
+	}
+
+	digest := sha256.Sum256(e.Quote)
+	if err := verifySignature(key, digest[:], e.Signature); err != nil {
+		return nil, fmt.Errorf("invalid signature of the TPM quote: %w", err)
+	}
+
+	quote, err := parseTPMQuote(e.Quote)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(quote.extraData, nonce) {
+		return nil, errors.New("TPM quote was not made over the nonce of the evidence")
+	}
+	if !allowed(v.pcrDigests, hex.EncodeToString(quote.pcrDigest)) {
+		return nil, fmt.Errorf("PCR digest %x of the TPM quote is not allowed", quote.pcrDigest)
+	}
+
+	return &Identity{ID: fingerprint}, nil
+}
+
+// keyFingerprint returns the hex encoded SHA-256 of a DER encoded public key.
+func keyFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+func verifySignature(key crypto.PublicKey, digest, sig []byte) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig)
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(key, digest, sig) {
+			return nil
+		}
+		// The TPM tools also output the signature as the concatenation of
+		// its R and S values.
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(sig) == 2*size {
+			r := new(big.Int).SetBytes(sig[:size])
+			s := new(big.Int).SetBytes(sig[size:])
+			if ecdsa.Verify(key, digest, r, s) {
+				return nil
+			}
+		}
+		return errors.New("ECDSA verification failure")
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+type tpmQuote struct {
+	extraData []byte
+	pcrDigest []byte
+}
+
+// parseTPMQuote parses the fields of a TPMS_ATTEST structure of a quote used
+// by the verification.
+func parseTPMQuote(raw []byte) (*tpmQuote, error) {
+	r := bytes.NewReader(raw)
+	var (
+		magic uint32
+		typ   uint16
+		q     tpmQuote
+	)
+	if err := binary.Read(r, binary.BigEndian, &magic); err != nil || magic != tpmGeneratedValue {
+		return nil, errors.New("TPM quote was not generated by a TPM")
+	}
+	if err := binary.Read(r, binary.BigEndian, &typ); err != nil || typ != tpmSTAttestQuote {
+		return nil, errors.New("TPM attestation is not a quote")
+	}
+
+	// qualifiedSigner
+	if _, err := readTPM2B(r); err != nil {
+		return nil, err
+	}
+	extraData, err := readTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	q.extraData = extraData
+
+	// clockInfo (clock, resetCount, restartCount, safe) and firmwareVersion
+	if _, err := r.Seek(8+4+4+1+8, io.SeekCurrent); err != nil {
+		return nil, err
+	}
+
+	// pcrSelect
+	var count uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, errTruncatedQuote
+	}
+	for i := uint32(0); i < count; i++ {
+		var (
+			hash uint16
+			size uint8
+		)
+		if err := binary.Read(r, binary.BigEndian, &hash); err != nil {
+			return nil, errTruncatedQuote
+		}
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, errTruncatedQuote
+		}
+		if _, err := r.Seek(int64(size), io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+
+	pcrDigest, err := readTPM2B(r)
+	if err != nil {
+		return nil, err
+	}
+	q.pcrDigest = pcrDigest
+	return &q, nil
+}
+
+var errTruncatedQuote = errors.New("TPM quote is truncated")
+
+func readTPM2B(r *bytes.Reader) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, errTruncatedQuote
+	}
+	if int(size) > r.Len() {
+		return nil, errTruncatedQuote
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errTruncatedQuote
+	}
+	return b, nil
+}
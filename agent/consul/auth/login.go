@@ -10,6 +10,8 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/consul/authmethod/certauth"
+	"github.com/hashicorp/consul/agent/consul/authmethod/socketauth"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
 )
@@ -80,3 +82,23 @@ func BuildTokenDescription(prefix string, meta map[string]string) (string, error
 	}
 	return fmt.Sprintf("%s: %s", prefix, d), nil
 }
+
+// ValidateBearerTokenLogin returns an error if the auth method can't be used
+// to log in with a bearer token presented by the caller, because the identity
+// it verifies must be established by the agent the caller is connected to.
+func ValidateBearerTokenLogin(authMethod *structs.ACLAuthMethod) error {
+	// Anyone may present a certificate, so certificate logins must come from
+	// an agent which verified the client holds its key.
+	if authMethod.Type == certauth.AuthMethodType {
+		return fmt.Errorf("auth method %q of type %q can only be used by presenting a client certificate to the HTTPS API",
+			authMethod.Name, certauth.AuthMethodType)
+	}
+
+	// Socket credentials are reported by the kernel to the agent, a client
+	// could claim any of them.
+	if authMethod.Type == socketauth.AuthMethodType {
+		return fmt.Errorf("auth method %q of type %q can only be used by connecting to an agent's Unix domain socket",
+			authMethod.Name, socketauth.AuthMethodType)
+	}
+	return nil
+}
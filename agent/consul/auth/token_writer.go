@@ -37,6 +37,10 @@ type TokenWriterConfig struct {
 	Store     TokenWriterStore
 	CheckUUID lib.UUIDCheckFunc
 
+	// CheckQuota is called before a new token is created, to check the token
+	// quota of its namespace. It is optional.
+	CheckQuota func(entMeta *acl.EnterpriseMeta) error
+
 	MaxExpirationTTL time.Duration
 	MinExpirationTTL time.Duration
 
@@ -69,6 +73,12 @@ func (w *TokenWriter) Create(token *structs.ACLToken, fromLogin bool) (*structs.
 		return nil, err
 	}
 
+	if w.CheckQuota != nil {
+		if err := w.CheckQuota(&token.EnterpriseMeta); err != nil {
+			return nil, err
+		}
+	}
+
 	if token.AccessorID == "" {
 		// Caller didn't provide an AccessorID, so generate one.
 		id, err := lib.GenerateUUID(w.CheckUUID)
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestauth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/consul/attestation"
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// AuthMethodType is the type of auth method which authenticates agents by
+// the attestation evidence of the machine they run on.
+const AuthMethodType string = "node-attestation"
+
+func init() {
+	// register this as an available auth method type
+	authmethod.Register(AuthMethodType, func(_ hclog.Logger, method *structs.ACLAuthMethod) (authmethod.Validator, error) {
+		v, err := NewValidator(method)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// Config is the configuration of the auth method, see attestation.Config.
+type Config = attestation.Config
+
+// Validator is the implementation of authmethod.Validator for node
+// attestation.
+//
+// The login token is the encoded evidence collected by the agent. The servers
+// also verify the evidence attached to the registrations of the nodes with
+// the auth method named by node_attestation.auth_method.
+type Validator struct {
+	name     string
+	verifier *attestation.Verifier
+}
+
+func NewValidator(method *structs.ACLAuthMethod) (*Validator, error) {
+	if method.Type != AuthMethodType {
+		return nil, fmt.Errorf("%q is not a node attestation auth method", method.Name)
+	}
+
+	var config Config
+	if err := authmethod.ParseConfig(method.Config, &config); err != nil {
+		return nil, err
+	}
+
+	verifier, err := attestation.NewVerifier(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{
+		name:     method.Name,
+		verifier: verifier,
+	}, nil
+}
+
+// Name implements authmethod.Validator.
+func (v *Validator) Name() string { return v.name }
+
+// Stop implements authmethod.Validator.
+func (v *Validator) Stop() {}
+
+// Verify verifies the encoded evidence and returns the attested identity.
+func (v *Validator) Verify(evidence string) (*attestation.Identity, error) {
+	id, err := v.verifier.Verify(evidence, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("node attestation failed with auth method %q: %w", v.name, err)
+	}
+	return id, nil
+}
+
+// ValidateLogin implements authmethod.Validator.
+func (v *Validator) ValidateLogin(_ context.Context, loginToken string) (*authmethod.Identity, error) {
+	attested, err := v.Verify(loginToken)
+	if err != nil {
+		return nil, err
+	}
+	if !attestation.CanLogin(attested.Type) {
+		return nil, fmt.Errorf("%q attestation evidence is not bound to the node and cannot be used to log in", attested.Type)
+	}
+
+	fields := &attestSelectableFields{
+		Type:      attested.Type,
+		Node:      attested.Node,
+		NodeID:    attested.NodeID,
+		ID:        attested.ID,
+		AccountID: attested.AccountID,
+		Region:    attested.Region,
+		PrivateIP: attested.PrivateIP,
+	}
+
+	id := v.NewIdentity()
+	id.SelectableFields = fields
+	id.ProjectedVars["type"] = fields.Type
+	id.ProjectedVars["node"] = fields.Node
+	id.ProjectedVars["id"] = fields.ID
+	id.ProjectedVars["account_id"] = fields.AccountID
+	id.ProjectedVars["region"] = fields.Region
+	return id, nil
+}
+
+func (v *Validator) NewIdentity() *authmethod.Identity {
+	return &authmethod.Identity{
+		SelectableFields: &attestSelectableFields{},
+		ProjectedVars: map[string]string{
+			"type":       "",
+			"node":       "",
+			"id":         "",
+			"account_id": "",
+			"region":     "",
+		},
+	}
+}
+
+type attestSelectableFields struct {
+	Type      string `bexpr:"type"`
+	Node      string `bexpr:"node"`
+	NodeID    string `bexpr:"node_id"`
+	ID        string `bexpr:"id"`
+	AccountID string `bexpr:"account_id"`
+	Region    string `bexpr:"region"`
+	PrivateIP string `bexpr:"private_ip"`
+}
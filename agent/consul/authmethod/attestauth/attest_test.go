@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package attestauth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/attestation"
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// testEvidence returns the PEM encoded certificate of a key signing instance
+// identity documents like AWS does, and evidence signed by it.
+func testEvidence(t *testing.T, node string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Amazon Web Services LLC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	document := `{"accountId": "123456789012", "instanceId": "i-0123456789abcdef0", "privateIp": "10.0.0.12", "region": "us-east-1"}`
+	digest := sha256.Sum256([]byte(document))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	e := &attestation.Evidence{
+		Type:      attestation.TypeAWSIID,
+		Node:      node,
+		Timestamp: time.Now(),
+		AWS: &attestation.AWSEvidence{
+			Document:  document,
+			Signature: base64.StdEncoding.EncodeToString(sig),
+		},
+	}
+	evidence, err := e.Encode()
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), evidence
+}
+
+// testTPMEvidence returns the PEM encoded public key of an attestation key,
+// its fingerprint and evidence with a quote signed by it.
+func testTPMEvidence(t *testing.T, node string) (string, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	e := &attestation.Evidence{
+		Type:      attestation.TypeTPM,
+		Node:      node,
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+	}
+
+	// The TPMS_ATTEST structure of a quote over the nonce of the evidence.
+	var quote bytes.Buffer
+	write := func(v interface{}) { binary.Write(&quote, binary.BigEndian, v) }
+	write2B := func(v []byte) {
+		write(uint16(len(v)))
+		quote.Write(v)
+	}
+	write(uint32(0xff544347)) // TPM_GENERATED_VALUE
+	write(uint16(0x8018))     // TPM_ST_ATTEST_QUOTE
+	write2B([]byte("signer"))
+	write2B(e.Nonce())
+	quote.Write(make([]byte, 17)) // clockInfo
+	write(uint64(1))              // firmwareVersion
+	write(uint32(1))              // pcrSelect count
+	write(uint16(0x000b))         // sha256
+	write(uint8(3))
+	quote.Write([]byte{0x01, 0x00, 0x00})
+	write2B(bytes.Repeat([]byte{0xab}, 32))
+
+	digest := sha256.Sum256(quote.Bytes())
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	e.TPM = &attestation.TPMEvidence{AttestationKey: der, Quote: quote.Bytes(), Signature: sig}
+	evidence, err := e.Encode()
+	require.NoError(t, err)
+
+	fingerprint := sha256.Sum256(der)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), hex.EncodeToString(fingerprint[:]), evidence
+}
+
+func TestNewValidator(t *testing.T) {
+	cert, _ := testEvidence(t, "web-1")
+	makeMethod := func(config map[string]interface{}) *structs.ACLAuthMethod {
+		return &structs.ACLAuthMethod{
+			Name:   "test-attest",
+			Type:   AuthMethodType,
+			Config: config,
+		}
+	}
+
+	cases := map[string]struct {
+		method *structs.ACLAuthMethod
+		err    string
+	}{
+		"valid": {
+			method: makeMethod(map[string]interface{}{
+				"AWSCertificates": []string{cert},
+				"AWSAccountIDs":   []string{"123456789012"},
+			}),
+		},
+		"wrong type": {
+			method: &structs.ACLAuthMethod{Name: "test-attest", Type: "jwt"},
+			err:    `"test-attest" is not a node attestation auth method`,
+		},
+		"unknown config": {
+			method: makeMethod(map[string]interface{}{"Regions": []string{"us-east-1"}}),
+			err:    "error decoding config: 1 error(s) decoding:\n\n* '' has invalid keys: Regions",
+		},
+		"no root of trust": {
+			method: makeMethod(nil),
+			err:    "at least one of AWSCertificates or TPMAttestationKeys is required",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewValidator(tc.method)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateLogin(t *testing.T) {
+	cert, evidence := testEvidence(t, "web-1")
+	v, err := NewValidator(&structs.ACLAuthMethod{
+		Name:   "test-attest",
+		Type:   AuthMethodType,
+		Config: map[string]interface{}{"AWSCertificates": []string{cert}},
+	})
+	require.NoError(t, err)
+
+	// The instance identity documents are not bound to the node.
+	_, err = v.ValidateLogin(context.Background(), evidence)
+	require.EqualError(t, err, `"aws-iid" attestation evidence is not bound to the node and cannot be used to log in`)
+
+	_, evidence = testEvidence(t, "web-1")
+	_, err = v.ValidateLogin(context.Background(), evidence)
+	require.ErrorContains(t, err, `node attestation failed with auth method "test-attest": instance identity document is not signed`)
+
+	_, err = v.ValidateLogin(context.Background(), "")
+	require.ErrorContains(t, err, "no attestation evidence provided")
+
+	ak, fingerprint, evidence := testTPMEvidence(t, "web-1")
+	v, err = NewValidator(&structs.ACLAuthMethod{
+		Name:   "test-attest",
+		Type:   AuthMethodType,
+		Config: map[string]interface{}{"TPMAttestationKeys": []string{ak}},
+	})
+	require.NoError(t, err)
+
+	id, err := v.ValidateLogin(context.Background(), evidence)
+	require.NoError(t, err)
+	require.Equal(t, &authmethod.Identity{
+		SelectableFields: &attestSelectableFields{
+			Type: attestation.TypeTPM,
+			Node: "web-1",
+			ID:   fingerprint,
+		},
+		ProjectedVars: map[string]string{
+			"type":       attestation.TypeTPM,
+			"node":       "web-1",
+			"id":         fingerprint,
+			"account_id": "",
+			"region":     "",
+		},
+	}, id)
+}
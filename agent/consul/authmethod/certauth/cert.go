@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package certauth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// AuthMethodType is the type of auth method which authenticates clients of
+// the HTTPS API by the certificate they present during the TLS handshake.
+const AuthMethodType string = "tls-cert"
+
+func init() {
+	// register this as an available auth method type
+	authmethod.Register(AuthMethodType, func(_ hclog.Logger, method *structs.ACLAuthMethod) (authmethod.Validator, error) {
+		v, err := NewValidator(method)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+type Config struct {
+	// TrustedCACerts are the PEM encoded certificate authorities which client
+	// certificates must chain to. This is checked in addition to the agent's
+	// own verification of the certificate during the TLS handshake.
+	TrustedCACerts []string `json:",omitempty"`
+}
+
+// Validator is the implementation of authmethod.Validator for client
+// certificates.
+//
+// The login token is the PEM encoded certificate chain, leaf first, that the
+// client presented to the agent. A certificate is public, so presenting one
+// does not prove the caller holds its private key. Logins are therefore only
+// accepted from agents, which have verified possession during the TLS
+// handshake, and never through the regular login endpoints.
+type Validator struct {
+	name  string
+	roots *x509.CertPool
+}
+
+func NewValidator(method *structs.ACLAuthMethod) (*Validator, error) {
+	if method.Type != AuthMethodType {
+		return nil, fmt.Errorf("%q is not a TLS certificate auth method", method.Name)
+	}
+
+	var config Config
+	if err := authmethod.ParseConfig(method.Config, &config); err != nil {
+		return nil, err
+	}
+
+	if len(config.TrustedCACerts) == 0 {
+		return nil, errors.New("TrustedCACerts is required")
+	}
+	roots := x509.NewCertPool()
+	for i, caCert := range config.TrustedCACerts {
+		if !roots.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("TrustedCACerts[%d] does not contain a valid PEM encoded certificate", i)
+		}
+	}
+
+	// Agents cache the token for each certificate they see, so require tokens
+	// to expire rather than accumulating them indefinitely.
+	if method.MaxTokenTTL == 0 {
+		return nil, errors.New("MaxTokenTTL is required for auth methods of type " + AuthMethodType)
+	}
+
+	return &Validator{
+		name:  method.Name,
+		roots: roots,
+	}, nil
+}
+
+// Name implements authmethod.Validator.
+func (v *Validator) Name() string { return v.name }
+
+// Stop implements authmethod.Validator.
+func (v *Validator) Stop() {}
+
+// ValidateLogin implements authmethod.Validator.
+func (v *Validator) ValidateLogin(_ context.Context, loginToken string) (*authmethod.Identity, error) {
+	chain, err := parseChain(loginToken)
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   time.Now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client certificate is not trusted by auth method %q: %w", v.name, err)
+	}
+
+	fields := &certSelectableFields{
+		CommonName:          leaf.Subject.CommonName,
+		OrganizationalUnits: leaf.Subject.OrganizationalUnit,
+		DNSNames:            leaf.DNSNames,
+	}
+	for _, uri := range leaf.URIs {
+		fields.URIs = append(fields.URIs, uri.String())
+	}
+
+	id := v.NewIdentity()
+	id.SelectableFields = fields
+	id.ProjectedVars["common_name"] = fields.CommonName
+	if len(fields.OrganizationalUnits) > 0 {
+		id.ProjectedVars["organizational_unit"] = fields.OrganizationalUnits[0]
+	}
+	if len(fields.DNSNames) > 0 {
+		id.ProjectedVars["dns_name"] = fields.DNSNames[0]
+	}
+	if len(fields.URIs) > 0 {
+		id.ProjectedVars["uri"] = fields.URIs[0]
+	}
+	return id, nil
+}
+
+func (v *Validator) NewIdentity() *authmethod.Identity {
+	return &authmethod.Identity{
+		SelectableFields: &certSelectableFields{},
+		ProjectedVars: map[string]string{
+			"common_name":         "",
+			"organizational_unit": "",
+			"dns_name":            "",
+			"uri":                 "",
+		},
+	}
+}
+
+func parseChain(pemChain string) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := []byte(pemChain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no client certificate provided")
+	}
+	return chain, nil
+}
+
+type certSelectableFields struct {
+	CommonName          string   `bexpr:"common_name"`
+	OrganizationalUnits []string `bexpr:"organizational_units"`
+	DNSNames            []string `bexpr:"dns_sans"`
+	URIs                []string `bexpr:"uri_sans"`
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package certauth
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestNewValidator(t *testing.T) {
+	ca := NewTestCA(t)
+
+	makeMethod := func(config map[string]interface{}, ttl time.Duration) *structs.ACLAuthMethod {
+		return &structs.ACLAuthMethod{
+			Name:        "test-cert",
+			Type:        AuthMethodType,
+			MaxTokenTTL: ttl,
+			Config:      config,
+		}
+	}
+
+	cases := map[string]struct {
+		method *structs.ACLAuthMethod
+		err    string
+	}{
+		"valid": {
+			method: makeMethod(map[string]interface{}{
+				"TrustedCACerts": []string{ca.CertPEM},
+			}, time.Hour),
+		},
+		"wrong type": {
+			method: &structs.ACLAuthMethod{Name: "test-cert", Type: "jwt"},
+			err:    `"test-cert" is not a TLS certificate auth method`,
+		},
+		"missing CA": {
+			method: makeMethod(map[string]interface{}{}, time.Hour),
+			err:    "TrustedCACerts is required",
+		},
+		"invalid CA": {
+			method: makeMethod(map[string]interface{}{
+				"TrustedCACerts": []string{"not a certificate"},
+			}, time.Hour),
+			err: "TrustedCACerts[0] does not contain a valid PEM encoded certificate",
+		},
+		"missing TTL": {
+			method: makeMethod(map[string]interface{}{
+				"TrustedCACerts": []string{ca.CertPEM},
+			}, 0),
+			err: "MaxTokenTTL is required for auth methods of type tls-cert",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewValidator(tc.method)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateLogin(t *testing.T) {
+	ca := NewTestCA(t)
+
+	validator, err := NewValidator(&structs.ACLAuthMethod{
+		Name:        "test-cert",
+		Type:        AuthMethodType,
+		MaxTokenTTL: time.Hour,
+		Config: map[string]interface{}{
+			"TrustedCACerts": []string{ca.CertPEM},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("new identity", func(t *testing.T) {
+		authmethod.RequireIdentityMatch(t, validator.NewIdentity(), map[string]string{
+			"common_name":         "",
+			"organizational_unit": "",
+			"dns_name":            "",
+			"uri":                 "",
+		},
+			`common_name == ""`,
+		)
+	})
+
+	t.Run("trusted certificate", func(t *testing.T) {
+		cert := ca.IssueClientCert(t, pkix.Name{
+			CommonName:         "deployer",
+			OrganizationalUnit: []string{"platform"},
+		}, "spiffe://infra.example.com/deployer")
+
+		id, err := validator.ValidateLogin(context.Background(), cert.CertPEM)
+		require.NoError(t, err)
+
+		authmethod.RequireIdentityMatch(t, id, map[string]string{
+			"common_name":         "deployer",
+			"organizational_unit": "platform",
+			"dns_name":            "",
+			"uri":                 "spiffe://infra.example.com/deployer",
+		},
+			`common_name == "deployer"`,
+			`"platform" in organizational_units`,
+			`"spiffe://infra.example.com/deployer" in uri_sans`,
+		)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		other := NewTestCA(t)
+		cert := other.IssueClientCert(t, pkix.Name{CommonName: "deployer"})
+
+		_, err := validator.ValidateLogin(context.Background(), cert.CertPEM)
+		require.ErrorContains(t, err, `client certificate is not trusted by auth method "test-cert"`)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := validator.ValidateLogin(context.Background(), "")
+		require.EqualError(t, err, "no client certificate provided")
+	})
+}
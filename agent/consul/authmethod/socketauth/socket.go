@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package socketauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// AuthMethodType is the type of auth method which authenticates the local
+// clients of an agent's HTTP API by the user and group of the process at the
+// other end of its Unix domain socket.
+const AuthMethodType string = "socket-cred"
+
+func init() {
+	// register this as an available auth method type
+	authmethod.Register(AuthMethodType, func(_ hclog.Logger, method *structs.ACLAuthMethod) (authmethod.Validator, error) {
+		v, err := NewValidator(method)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+}
+
+// Config is the configuration of the auth method. The identities are only
+// selected with binding rules, so it has no settings.
+type Config struct{}
+
+// loginToken is the login token of the auth method. It is built by the
+// servers from the credentials reported by an agent, never by the client.
+type loginToken struct {
+	Node  string
+	UID   uint32
+	GID   uint32
+	User  string `json:",omitempty"`
+	Group string `json:",omitempty"`
+}
+
+// LoginToken returns the login token for the peer credentials of a client
+// connected to the Unix domain socket of the agent running on node.
+func LoginToken(node string, creds structs.ACLSocketCredentials) (string, error) {
+	raw, err := json.Marshal(loginToken{
+		Node:  node,
+		UID:   creds.UID,
+		GID:   creds.GID,
+		User:  creds.User,
+		Group: creds.Group,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// Validator is the implementation of authmethod.Validator for Unix domain
+// socket peer credentials.
+//
+// The credentials are reported by the kernel to the agent which accepted the
+// connection, so they can't be presented by the client itself. Logins are
+// therefore only accepted from agents, and never through the regular login
+// endpoints.
+type Validator struct {
+	name string
+}
+
+func NewValidator(method *structs.ACLAuthMethod) (*Validator, error) {
+	if method.Type != AuthMethodType {
+		return nil, fmt.Errorf("%q is not a socket credentials auth method", method.Name)
+	}
+
+	var config Config
+	if err := authmethod.ParseConfig(method.Config, &config); err != nil {
+		return nil, err
+	}
+
+	// Agents cache the token for each user and group they see, so require
+	// tokens to expire rather than accumulating them indefinitely.
+	if method.MaxTokenTTL == 0 {
+		return nil, errors.New("MaxTokenTTL is required for auth methods of type " + AuthMethodType)
+	}
+
+	return &Validator{name: method.Name}, nil
+}
+
+// Name implements authmethod.Validator.
+func (v *Validator) Name() string { return v.name }
+
+// Stop implements authmethod.Validator.
+func (v *Validator) Stop() {}
+
+// ValidateLogin implements authmethod.Validator.
+func (v *Validator) ValidateLogin(_ context.Context, raw string) (*authmethod.Identity, error) {
+	var token loginToken
+	if err := json.Unmarshal([]byte(raw), &token); err != nil {
+		return nil, fmt.Errorf("invalid socket credentials: %w", err)
+	}
+	if token.Node == "" {
+		return nil, errors.New("invalid socket credentials: missing node")
+	}
+
+	fields := &socketSelectableFields{
+		Node:  token.Node,
+		UID:   token.UID,
+		GID:   token.GID,
+		User:  token.User,
+		Group: token.Group,
+	}
+
+	id := v.NewIdentity()
+	id.SelectableFields = fields
+	id.ProjectedVars["node"] = fields.Node
+	id.ProjectedVars["uid"] = strconv.FormatUint(uint64(fields.UID), 10)
+	id.ProjectedVars["gid"] = strconv.FormatUint(uint64(fields.GID), 10)
+	id.ProjectedVars["user"] = fields.User
+	id.ProjectedVars["group"] = fields.Group
+	return id, nil
+}
+
+func (v *Validator) NewIdentity() *authmethod.Identity {
+	return &authmethod.Identity{
+		SelectableFields: &socketSelectableFields{},
+		ProjectedVars: map[string]string{
+			"node":  "",
+			"uid":   "",
+			"gid":   "",
+			"user":  "",
+			"group": "",
+		},
+	}
+}
+
+type socketSelectableFields struct {
+	Node  string `bexpr:"node"`
+	UID   uint32 `bexpr:"uid"`
+	GID   uint32 `bexpr:"gid"`
+	User  string `bexpr:"user"`
+	Group string `bexpr:"group"`
+}
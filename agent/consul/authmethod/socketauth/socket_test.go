@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package socketauth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestNewValidator(t *testing.T) {
+	makeMethod := func(config map[string]interface{}, ttl time.Duration) *structs.ACLAuthMethod {
+		return &structs.ACLAuthMethod{
+			Name:        "test-socket",
+			Type:        AuthMethodType,
+			MaxTokenTTL: ttl,
+			Config:      config,
+		}
+	}
+
+	cases := map[string]struct {
+		method *structs.ACLAuthMethod
+		err    string
+	}{
+		"valid": {
+			method: makeMethod(nil, time.Hour),
+		},
+		"wrong type": {
+			method: &structs.ACLAuthMethod{Name: "test-socket", Type: "jwt"},
+			err:    `"test-socket" is not a socket credentials auth method`,
+		},
+		"unknown config": {
+			method: makeMethod(map[string]interface{}{"Users": []string{"root"}}, time.Hour),
+			err:    "error decoding config: 1 error(s) decoding:\n\n* '' has invalid keys: Users",
+		},
+		"missing TTL": {
+			method: makeMethod(nil, 0),
+			err:    "MaxTokenTTL is required for auth methods of type socket-cred",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewValidator(tc.method)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateLogin(t *testing.T) {
+	validator, err := NewValidator(&structs.ACLAuthMethod{
+		Name:        "test-socket",
+		Type:        AuthMethodType,
+		MaxTokenTTL: time.Hour,
+	})
+	require.NoError(t, err)
+
+	t.Run("new identity", func(t *testing.T) {
+		authmethod.RequireIdentityMatch(t, validator.NewIdentity(), map[string]string{
+			"node":  "",
+			"uid":   "",
+			"gid":   "",
+			"user":  "",
+			"group": "",
+		},
+			`node == ""`,
+		)
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		token, err := LoginToken("node1", structs.ACLSocketCredentials{
+			UID:   1000,
+			GID:   100,
+			User:  "deployer",
+			Group: "users",
+		})
+		require.NoError(t, err)
+
+		id, err := validator.ValidateLogin(context.Background(), token)
+		require.NoError(t, err)
+
+		authmethod.RequireIdentityMatch(t, id, map[string]string{
+			"node":  "node1",
+			"uid":   "1000",
+			"gid":   "100",
+			"user":  "deployer",
+			"group": "users",
+		},
+			`node == "node1"`,
+			`uid == 1000`,
+			`gid == 100`,
+			`user == "deployer"`,
+			`group == "users"`,
+		)
+	})
+
+	t.Run("unresolved names", func(t *testing.T) {
+		token, err := LoginToken("node1", structs.ACLSocketCredentials{UID: 0, GID: 0})
+		require.NoError(t, err)
+
+		id, err := validator.ValidateLogin(context.Background(), token)
+		require.NoError(t, err)
+
+		authmethod.RequireIdentityMatch(t, id, map[string]string{
+			"node":  "node1",
+			"uid":   "0",
+			"gid":   "0",
+			"user":  "",
+			"group": "",
+		},
+			`uid == 0`,
+			`user == ""`,
+		)
+	})
+
+	t.Run("missing node", func(t *testing.T) {
+		token, err := LoginToken("", structs.ACLSocketCredentials{UID: 1000})
+		require.NoError(t, err)
+
+		_, err = validator.ValidateLogin(context.Background(), token)
+		require.EqualError(t, err, "invalid socket credentials: missing node")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := validator.ValidateLogin(context.Background(), "not json")
+		require.ErrorContains(t, err, "invalid socket credentials")
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package breakglass implements the sealed emergency management token: a key
+// is split between operators with Shamir's secret sharing when the seal is
+// initialized, and a threshold of the shares must be submitted again to
+// activate a time-limited management token.
+package breakglass
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/consul/lib/shamir"
+)
+
+// keySize is the size in bytes of the key split between the operators.
+const keySize = 32
+
+// ErrInvalidShares is returned when a threshold of shares was submitted but
+// they don't recover the key of the seal. The unseal progress is reset.
+var ErrInvalidShares = errors.New("the submitted shares don't match the break-glass seal, the unseal progress was reset")
+
+// ErrUnknownShare is returned when a share isn't one of the shares of the
+// seal. It isn't counted, so that it can't disrupt the unseal progress.
+var ErrUnknownShare = errors.New("the share doesn't belong to the break-glass seal")
+
+// Seal is the break-glass seal stored in the state store. Only the hash of the
+// key split between the operators is stored, the key itself never is.
+type Seal struct {
+	// ID identifies the seal, it changes every time the seal is initialized
+	// so that the shares of a previous seal are rejected.
+	ID string
+
+	// Shares is the number of shares the key was split into.
+	Shares int
+
+	// Threshold is the number of shares required to activate the token.
+	Threshold int
+
+	// TTL is how long the activated token is valid.
+	TTL time.Duration
+
+	// KeyHash is the SHA-256 hash of the key.
+	KeyHash []byte
+
+	// ShareHashes are the hashes of the shares of the key, see shareHash,
+	// to reject the shares which don't belong to the seal as they are
+	// submitted.
+	ShareHashes [][]byte
+
+	CreateTime time.Time
+}
+
+// NewSeal returns a new seal and the base64 encoded shares of its key, which
+// must be handed to the operators as they are never stored.
+func NewSeal(shares, threshold int, ttl time.Duration) (*Seal, []string, error) {
+	if ttl <= 0 {
+		return nil, nil, errors.New("TTL must be positive")
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	parts, err := shamir.Split(key, shares, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash := sha256.Sum256(key)
+	seal := &Seal{
+		ID:         id,
+		Shares:     shares,
+		Threshold:  threshold,
+		TTL:        ttl,
+		KeyHash:    hash[:],
+		CreateTime: time.Now().UTC(),
+	}
+	encoded := make([]string, len(parts))
+	for i, part := range parts {
+		encoded[i] = base64.StdEncoding.EncodeToString(part)
+		seal.ShareHashes = append(seal.ShareHashes, seal.shareHash(part))
+	}
+	return seal, encoded, nil
+}
+
+// shareHash returns the SHA-256 hash of a share salted with the ID of the
+// seal.
+func (s *Seal) shareHash(share []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(s.ID))
+	h.Write(share)
+	return h.Sum(nil)
+}
+
+// hasShare returns whether the share is one of the shares of the seal.
+func (s *Seal) hasShare(share []byte) bool {
+	hash := s.shareHash(share)
+	var found int
+	for _, h := range s.ShareHashes {
+		found |= subtle.ConstantTimeCompare(hash, h)
+	}
+	return found == 1
+}
+
+// Verify returns whether the key matches the seal.
+func (s *Seal) Verify(key []byte) bool {
+	hash := sha256.Sum256(key)
+	return subtle.ConstantTimeCompare(hash[:], s.KeyHash) == 1
+}
+
+// Encode returns the seal encoded for the state store.
+func (s *Seal) Encode() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeSeal decodes a seal encoded by Encode, it returns nil if the value is
+// empty because the seal was never initialized.
+func DecodeSeal(value string) (*Seal, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var seal Seal
+	if err := json.Unmarshal([]byte(value), &seal); err != nil {
+		return nil, fmt.Errorf("failed to decode the break-glass seal: %w", err)
+	}
+	return &seal, nil
+}
+
+// Unsealer collects the shares submitted by the operators until a threshold
+// of them is reached. The progress is kept in memory and is reset after the
+// timeout, when the seal changes and once the token is activated.
+type Unsealer struct {
+	timeout time.Duration
+
+	lock    sync.Mutex
+	sealID  string
+	shares  [][]byte
+	started time.Time
+}
+
+// NewUnsealer returns an Unsealer resetting the progress when the threshold
+// isn't reached within the timeout after the first share.
+func NewUnsealer(timeout time.Duration) *Unsealer {
+	return &Unsealer{timeout: timeout}
+}
+
+// Add submits a share of the key of the seal. It returns the number of shares
+// submitted so far, and true once they recover the key, the progress then
+// being reset. The shares which don't belong to the seal are rejected without
+// changing the progress.
+func (u *Unsealer) Add(seal *Seal, encoded string) (int, bool, error) {
+	share, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid share: %w", err)
+	}
+	if len(share) != keySize+1 || share[keySize] == 0 {
+		return 0, false, errors.New("invalid share")
+	}
+	if !seal.hasShare(share) {
+		return 0, false, ErrUnknownShare
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.resetIfStaleLocked(seal)
+	for _, existing := range u.shares {
+		if bytes.Equal(existing, share) {
+			return len(u.shares), false, errors.New("this share was already submitted")
+		}
+	}
+	if len(u.shares) == 0 {
+		u.sealID = seal.ID
+		u.started = time.Now()
+	}
+	u.shares = append(u.shares, share)
+
+	if len(u.shares) < seal.Threshold {
+		return len(u.shares), false, nil
+	}
+
+	key, err := shamir.Combine(u.shares)
+	u.resetLocked()
+	if err != nil || !seal.Verify(key) {
+		return 0, false, ErrInvalidShares
+	}
+	return seal.Threshold, true, nil
+}
+
+// Progress returns the number of shares of the seal submitted so far.
+func (u *Unsealer) Progress(seal *Seal) int {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.resetIfStaleLocked(seal)
+	return len(u.shares)
+}
+
+func (u *Unsealer) resetIfStaleLocked(seal *Seal) {
+	if len(u.shares) == 0 {
+		return
+	}
+	if seal == nil || u.sealID != seal.ID || time.Since(u.started) > u.timeout {
+		u.resetLocked()
+	}
+}
+
+func (u *Unsealer) resetLocked() {
+	u.sealID = ""
+	u.shares = nil
+	u.started = time.Time{}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglass
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsealer(t *testing.T) {
+	seal, shares, err := NewSeal(5, 3, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	encoded, err := seal.Encode()
+	require.NoError(t, err)
+	decoded, err := DecodeSeal(encoded)
+	require.NoError(t, err)
+	require.Equal(t, seal, decoded)
+
+	u := NewUnsealer(time.Minute)
+
+	progress, unsealed, err := u.Add(seal, shares[3])
+	require.NoError(t, err)
+	require.Equal(t, 1, progress)
+	require.False(t, unsealed)
+
+	_, _, err = u.Add(seal, shares[3])
+	require.Error(t, err)
+
+	progress, unsealed, err = u.Add(seal, shares[1])
+	require.NoError(t, err)
+	require.Equal(t, 2, progress)
+	require.False(t, unsealed)
+	require.Equal(t, 2, u.Progress(seal))
+
+	progress, unsealed, err = u.Add(seal, shares[0])
+	require.NoError(t, err)
+	require.Equal(t, 3, progress)
+	require.True(t, unsealed)
+	require.Equal(t, 0, u.Progress(seal))
+
+	// The shares of another seal are rejected without resetting the
+	// progress.
+	other, otherShares, err := NewSeal(5, 3, time.Hour)
+	require.NoError(t, err)
+	_, _, err = u.Add(seal, shares[0])
+	require.NoError(t, err)
+	_, _, err = u.Add(seal, shares[1])
+	require.NoError(t, err)
+	_, unsealed, err = u.Add(seal, otherShares[2])
+	require.ErrorIs(t, err, ErrUnknownShare)
+	require.False(t, unsealed)
+	require.Equal(t, 2, u.Progress(seal))
+	progress, unsealed, err = u.Add(seal, shares[4])
+	require.NoError(t, err)
+	require.Equal(t, 3, progress)
+	require.True(t, unsealed)
+
+	// A seal without share hashes accepts no share.
+	invalid := *seal
+	invalid.ShareHashes = nil
+	_, _, err = u.Add(&invalid, shares[0])
+	require.ErrorIs(t, err, ErrUnknownShare)
+	require.Equal(t, 0, u.Progress(&invalid))
+
+	// The progress is reset when the seal changes.
+	_, _, err = u.Add(seal, shares[0])
+	require.NoError(t, err)
+	require.Equal(t, 0, u.Progress(other))
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
-	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-uuid"
@@ -114,6 +113,11 @@ func (c *Catalog) Register(args *structs.RegisterRequest, reply *struct{}) error
 	}
 	defer metrics.MeasureSince([]string{"catalog", "register"}, time.Now())
 
+	// The attestation evidence is only verified, never stored nor passed to
+	// the admission webhooks.
+	attestation := args.NodeAttestation
+	args.NodeAttestation = ""
+
 	// Fetch the ACL token, if any.
 	authz, err := c.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, nil)
 	if err != nil {
@@ -124,26 +128,67 @@ func (c *Catalog) Register(args *structs.RegisterRequest, reply *struct{}) error
 		return err
 	}
 
+	// The registration is only sent to the admission webhooks once it is
+	// known to be allowed, and the one they return is checked again.
+	ns, err := c.preApplyRegister(authz, args, attestation)
+	if err != nil {
+		return err
+	}
+	if c.srv.admission != nil {
+		if err := c.srv.admission.admitRegister(args); err != nil {
+			return err
+		}
+		if ns, err = c.preApplyRegister(authz, args, attestation); err != nil {
+			return err
+		}
+	}
+
+	if args.Service != nil {
+		if err := c.srv.checkServiceQuota(args.Service); err != nil {
+			return err
+		}
+	}
+
+	// The status updates of checks are coalesced by the leader to cut down on
+	// the number of Raft writes.
+	if c.srv.checkUpdateBatcher != nil && isCheckUpdate(args, ns) {
+		return c.srv.checkUpdateBatcher.register(args)
+	}
+
+	_, err = c.srv.raftApply(structs.RegisterRequestType, args)
+	return err
+}
+
+// preApplyRegister validates the registration and checks it against the
+// given ACL policy, returning the services currently registered on the node.
+func (c *Catalog) preApplyRegister(authz resolver.Result, args *structs.RegisterRequest, attestation string) (*structs.NodeServices, error) {
 	// This needs to happen before the other preapply checks as it will fixup some of the
 	// internal enterprise metas on the services and checks
 	state := c.srv.fsm.State()
 	entMeta, err := state.ValidateRegisterRequest(args)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := c.srv.checkNodeAttestation(args.Node, args.ID, args.PeerName, attestation, entMeta); err != nil {
+		return nil, err
 	}
 
 	// Verify the args.
 	if err := nodePreApply(args.Node, string(args.ID)); err != nil {
-		return err
+		return nil, err
 	}
 	if args.Address == "" && !args.SkipNodeUpdate {
-		return fmt.Errorf("Must provide address if SkipNodeUpdate is not set")
+		return nil, fmt.Errorf("Must provide address if SkipNodeUpdate is not set")
+	}
+	if _, ok := args.NodeMeta[structs.MetaCatalogProvider]; ok {
+		return nil, fmt.Errorf("Node metadata key %q is reserved for catalog providers", structs.MetaCatalogProvider)
 	}
 
 	// Handle a service registration.
 	if args.Service != nil {
 		if err := servicePreApply(args.Service, authz, args.Service.FillAuthzContext); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -169,14 +214,23 @@ func (c *Catalog) Register(args *structs.RegisterRequest, reply *struct{}) error
 	// Check the complete register request against the given ACL policy.
 	_, ns, err := state.NodeServices(nil, args.Node, entMeta, args.PeerName)
 	if err != nil {
-		return fmt.Errorf("Node lookup failed: %v", err)
+		return nil, fmt.Errorf("Node lookup failed: %v", err)
 	}
 	if err := vetRegisterWithACL(authz, args, ns); err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = c.srv.raftApply(structs.RegisterRequestType, args)
-	return err
+	// Only report that the node is managed by a catalog provider once the
+	// token is known to be allowed to write to it.
+	_, node, err := state.GetNode(args.Node, entMeta, args.PeerName)
+	if err != nil {
+		return nil, fmt.Errorf("Node lookup failed: %v", err)
+	}
+	if err := checkCatalogProviderNode(node); err != nil {
+		return nil, err
+	}
+
+	return ns, nil
 }
 
 // nodePreApply does the verification of a node before it is applied to Raft.
@@ -419,6 +473,22 @@ func (c *Catalog) Deregister(args *structs.DeregisterRequest, reply *struct{}) e
 		return err
 	}
 
+	// Only report that the node is managed by a catalog provider once the
+	// token is known to be allowed to write to it.
+	_, node, err := state.GetNode(args.Node, &args.EnterpriseMeta, args.PeerName)
+	if err != nil {
+		return fmt.Errorf("Node lookup failed: %v", err)
+	}
+	if err := checkCatalogProviderNode(node); err != nil {
+		return err
+	}
+
+	// The deregistration is only sent to the admission webhooks once it is
+	// known to be allowed.
+	if err := c.srv.admission.admitDeregister(args); err != nil {
+		return err
+	}
+
 	_, err = c.srv.raftApply(structs.DeregisterRequestType, args)
 	return err
 }
@@ -509,7 +579,7 @@ func (c *Catalog) ListNodes(args *structs.DCSpecificRequest, reply *structs.Inde
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.Nodes)
+	filter, err := c.srv.filterCache.Get(args.Filter, reply.Nodes)
 	if err != nil {
 		return err
 	}
@@ -518,9 +588,16 @@ func (c *Catalog) ListNodes(args *structs.DCSpecificRequest, reply *structs.Inde
 		&args.QueryOptions,
 		&reply.QueryMeta,
 		func(ws memdb.WatchSet, state *state.Store) error {
+			// Equality matches on node metadata in the filter can be served
+			// from the meta index. The filter is still executed below.
+			nodeMetaFilters := args.NodeMetaFilters
+			if len(nodeMetaFilters) == 0 {
+				nodeMetaFilters = filter.MetaEqualities("Meta")
+			}
+
 			var err error
-			if len(args.NodeMetaFilters) > 0 {
-				reply.Index, reply.Nodes, err = state.NodesByMeta(ws, args.NodeMetaFilters, &args.EnterpriseMeta, args.PeerName)
+			if len(nodeMetaFilters) > 0 {
+				reply.Index, reply.Nodes, err = state.NodesByMeta(ws, nodeMetaFilters, &args.EnterpriseMeta, args.PeerName)
 			} else {
 				reply.Index, reply.Nodes, err = state.Nodes(ws, &args.EnterpriseMeta, args.PeerName)
 			}
@@ -546,7 +623,10 @@ func (c *Catalog) ListNodes(args *structs.DCSpecificRequest, reply *structs.Inde
 				return err
 			}
 
-			return c.srv.sortNodesByDistanceFrom(args.Source, reply.Nodes)
+			if err := c.srv.sortNodesByDistanceFrom(args.Source, reply.Nodes); err != nil {
+				return err
+			}
+			return structs.SortResults(args.Sort, reply.Nodes)
 		})
 }
 
@@ -578,7 +658,7 @@ func (c *Catalog) ListServices(args *structs.DCSpecificRequest, reply *structs.I
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, []*structs.ServiceNode{})
+	filter, err := c.srv.filterCache.Get(args.Filter, []*structs.ServiceNode{})
 	if err != nil {
 		return err
 	}
@@ -595,6 +675,10 @@ func (c *Catalog) ListServices(args *structs.DCSpecificRequest, reply *structs.I
 			var serviceNodes structs.ServiceNodes
 			if len(args.NodeMetaFilters) > 0 {
 				reply.Index, serviceNodes, err = state.ServicesByNodeMeta(ws, args.NodeMetaFilters, &args.EnterpriseMeta, args.PeerName)
+			} else if serviceMetaFilters := filter.MetaEqualities("ServiceMeta"); len(serviceMetaFilters) > 0 {
+				// Equality matches on service metadata in the filter can be
+				// served from the meta index. The filter is still executed below.
+				reply.Index, serviceNodes, err = state.ServicesByServiceMeta(ws, serviceMetaFilters, &args.EnterpriseMeta, args.PeerName, true)
 			} else {
 				reply.Index, serviceNodes, err = state.Services(ws, &args.EnterpriseMeta, args.PeerName, args.Filter != "")
 			}
@@ -740,7 +824,7 @@ func (c *Catalog) ServiceNodes(args *structs.ServiceSpecificRequest, reply *stru
 		}
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.ServiceNodes)
+	filter, err := c.srv.filterCache.Get(args.Filter, reply.ServiceNodes)
 	if err != nil {
 		return err
 	}
@@ -827,7 +911,10 @@ func (c *Catalog) ServiceNodes(args *structs.ServiceSpecificRequest, reply *stru
 				return err
 			}
 
-			return c.srv.sortNodesByDistanceFrom(args.Source, reply.ServiceNodes)
+			if err := c.srv.sortNodesByDistanceFrom(args.Source, reply.ServiceNodes); err != nil {
+				return err
+			}
+			return structs.SortResults(args.Sort, reply.ServiceNodes)
 		})
 
 	// Provide some metrics
@@ -880,7 +967,7 @@ func (c *Catalog) NodeServices(args *structs.NodeSpecificRequest, reply *structs
 	}
 
 	var filterType map[string]*structs.NodeService
-	filter, err := bexpr.CreateFilter(args.Filter, nil, filterType)
+	filter, err := c.srv.filterCache.Get(args.Filter, filterType)
 	if err != nil {
 		return err
 	}
@@ -936,7 +1023,7 @@ func (c *Catalog) NodeServiceList(args *structs.NodeSpecificRequest, reply *stru
 	}
 
 	var filterType []*structs.NodeService
-	filter, err := bexpr.CreateFilter(args.Filter, nil, filterType)
+	filter, err := c.srv.filterCache.Get(args.Filter, filterType)
 	if err != nil {
 		return err
 	}
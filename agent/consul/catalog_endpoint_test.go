@@ -140,6 +140,82 @@ func TestCatalog_RegisterService_SkipNodeUpdate(t *testing.T) {
 	}
 }
 
+func TestCatalog_Register_BatchedCheckUpdates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.CheckUpdateBatchInterval = 200 * time.Millisecond
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	register := func(codec rpc.ClientCodec, node, status string, skipNodeUpdate bool) error {
+		arg := structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      "web",
+				Service: "web",
+				Port:    8080,
+				Weights: &structs.Weights{Passing: 1, Warning: 1},
+			},
+			Check: &structs.HealthCheck{
+				CheckID:   "web-check",
+				Name:      "web-check",
+				ServiceID: "web",
+				Status:    status,
+			},
+			SkipNodeUpdate: skipNodeUpdate,
+		}
+		var out struct{}
+		return msgpackrpc.CallWithCodec(codec, "Catalog.Register", &arg, &out)
+	}
+
+	codecs := make([]rpc.ClientCodec, 2)
+	for i := range codecs {
+		codecs[i] = rpcClient(t, s1)
+	}
+	nodes := []string{"node1", "node2"}
+	for _, node := range nodes {
+		require.NoError(t, register(codecs[0], node, api.HealthPassing, false))
+	}
+
+	// The status updates sent by the agents are applied together.
+	errCh := make(chan error, len(nodes))
+	for i, node := range nodes {
+		go func(codec rpc.ClientCodec, node string) {
+			errCh <- register(codec, node, api.HealthCritical, true)
+		}(codecs[i], node)
+	}
+	for range nodes {
+		require.NoError(t, <-errCh)
+	}
+
+	state := s1.fsm.State()
+	var indexes []uint64
+	for _, node := range nodes {
+		_, hc, err := state.NodeCheck(node, "web-check", nil, "")
+		require.NoError(t, err)
+		require.Equal(t, api.HealthCritical, hc.Status)
+		indexes = append(indexes, hc.ModifyIndex)
+	}
+	require.Equal(t, indexes[0], indexes[1])
+
+	// The errors of the batched requests are returned to their callers.
+	arg := structs.RegisterRequest{
+		Datacenter:     "dc1",
+		Node:           "node1",
+		Check:          &structs.HealthCheck{Node: "node2", CheckID: "web-check"},
+		SkipNodeUpdate: true,
+	}
+	var out struct{}
+	err := msgpackrpc.CallWithCodec(codecs[0], "Catalog.Register", &arg, &out)
+	require.ErrorContains(t, err, "doesn't match register request node")
+}
+
 func TestCatalog_Register_NodeID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
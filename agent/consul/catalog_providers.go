@@ -0,0 +1,323 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+var CatalogProviderSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: []string{"leader", "catalog_provider", "sync"},
+		Help: "Measures the time it takes the leader to sync the instances of a catalog provider into the catalog.",
+	},
+}
+
+// catalogProviderNodeAddress is the address of the nodes of the catalog
+// providers. Their instances always have their own address.
+const catalogProviderNodeAddress = "127.0.0.1"
+
+// catalogProviderNode returns the name of the node the instances of a
+// catalog provider are registered on.
+func catalogProviderNode(name string) string {
+	return "catalog-provider-" + name
+}
+
+// catalogProviderSyncer syncs the instances of a catalog provider into the
+// catalog. The instances are registered as the services of a node dedicated
+// to the provider, and the services of the node which are not returned by the
+// provider anymore are deregistered.
+type catalogProviderSyncer struct {
+	srv    *Server
+	config catalogprovider.Config
+	logger hclog.Logger
+
+	node    string
+	entMeta acl.EnterpriseMeta
+}
+
+func (p *catalogProviderSyncer) run(ctx context.Context) error {
+	provider, err := catalogprovider.NewProvider(p.config)
+	if err != nil {
+		p.srv.updateCatalogProviderStatusError(p.config.Name, err)
+		return fmt.Errorf("failed to create catalog provider %q: %w", p.config.Name, err)
+	}
+
+	interval := p.config.SyncInterval
+	if interval <= 0 {
+		interval = catalogprovider.DefaultSyncInterval
+	}
+	for {
+		if err := p.sync(ctx, provider, interval); err != nil && ctx.Err() == nil {
+			p.logger.Warn("failed to sync catalog provider", "error", err)
+			p.srv.updateCatalogProviderStatusError(p.config.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (p *catalogProviderSyncer) sync(ctx context.Context, provider catalogprovider.Provider, timeout time.Duration) error {
+	defer metrics.MeasureSinceWithLabels([]string{"leader", "catalog_provider", "sync"}, time.Now(),
+		[]metrics.Label{{Name: "provider", Value: p.config.Name}})
+
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	instances, err := catalogprovider.Fetch(fetchCtx, provider)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	_, nodeServices, err := p.srv.fsm.State().NodeServices(nil, p.node, &p.entMeta, structs.DefaultPeerKeyword)
+	if err != nil {
+		return err
+	}
+	stale := make(map[string]*structs.NodeService)
+	if nodeServices != nil {
+		for _, svc := range nodeServices.Services {
+			stale[svc.ID] = svc
+		}
+	}
+
+	services := make(map[string]struct{})
+	var registered, deregistered int
+	for _, inst := range instances {
+		services[inst.Service] = struct{}{}
+		svc := p.nodeService(inst)
+		current, ok := stale[svc.ID]
+		delete(stale, svc.ID)
+		if ok && sameCatalogProviderService(current, svc) {
+			continue
+		}
+
+		req := structs.RegisterRequest{
+			Datacenter: p.srv.config.Datacenter,
+			Node:       p.node,
+			Address:    catalogProviderNodeAddress,
+			NodeMeta: map[string]string{
+				structs.MetaCatalogProvider: p.config.Name,
+				structs.MetaExternalSource:  p.config.Type,
+			},
+			Service:        svc,
+			EnterpriseMeta: p.entMeta,
+		}
+		if _, err := p.srv.leaderRaftApply("Catalog.Register", structs.RegisterRequestType, &req); err != nil {
+			return fmt.Errorf("failed to register instance %q: %w", svc.ID, err)
+		}
+		registered++
+	}
+
+	for id := range stale {
+		req := structs.DeregisterRequest{
+			Datacenter:     p.srv.config.Datacenter,
+			Node:           p.node,
+			ServiceID:      id,
+			EnterpriseMeta: p.entMeta,
+		}
+		if _, err := p.srv.leaderRaftApply("Catalog.Deregister", structs.DeregisterRequestType, &req); err != nil {
+			return fmt.Errorf("failed to deregister instance %q: %w", id, err)
+		}
+		deregistered++
+	}
+
+	if registered > 0 || deregistered > 0 {
+		p.logger.Debug("synced catalog provider",
+			"instances", len(instances),
+			"registered", registered,
+			"deregistered", deregistered,
+		)
+	}
+	p.srv.updateCatalogProviderStatusSuccess(p.config.Name, len(services), len(instances))
+	return nil
+}
+
+func (p *catalogProviderSyncer) nodeService(inst catalogprovider.Instance) *structs.NodeService {
+	meta := make(map[string]string, len(inst.Meta)+1)
+	for k, v := range inst.Meta {
+		meta[k] = v
+	}
+	meta[structs.MetaExternalSource] = p.config.Type
+
+	return &structs.NodeService{
+		ID:             inst.ID,
+		Service:        inst.Service,
+		Address:        inst.Address,
+		Port:           inst.Port,
+		Tags:           inst.Tags,
+		Meta:           meta,
+		EnterpriseMeta: p.entMeta,
+	}
+}
+
+// sameCatalogProviderService returns whether a registered service is up to
+// date with an instance of the provider.
+func sameCatalogProviderService(current, desired *structs.NodeService) bool {
+	if current.Service != desired.Service || current.Address != desired.Address || current.Port != desired.Port {
+		return false
+	}
+	if len(current.Tags) != len(desired.Tags) || len(current.Meta) != len(desired.Meta) {
+		return false
+	}
+	for i := range current.Tags {
+		if current.Tags[i] != desired.Tags[i] {
+			return false
+		}
+	}
+	for k, v := range desired.Meta {
+		if cv, ok := current.Meta[k]; !ok || cv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// cleanupCatalogProviderNodes deregisters the nodes of the catalog providers
+// which have been removed from the configuration.
+func (s *Server) cleanupCatalogProviderNodes(ctx context.Context) error {
+	configured := make(map[string]struct{}, len(s.config.CatalogProviders))
+	for _, c := range s.config.CatalogProviders {
+		configured[c.Name] = struct{}{}
+	}
+
+	_, nodes, err := s.fsm.State().Nodes(nil, acl.WildcardEnterpriseMeta(), structs.DefaultPeerKeyword)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		name, ok := node.Meta[structs.MetaCatalogProvider]
+		if !ok {
+			continue
+		}
+		if _, ok := configured[name]; ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		req := structs.DeregisterRequest{
+			Datacenter:     s.config.Datacenter,
+			Node:           node.Node,
+			EnterpriseMeta: *node.GetEnterpriseMeta(),
+		}
+		if _, err := s.leaderRaftApply("Catalog.Deregister", structs.DeregisterRequestType, &req); err != nil {
+			return fmt.Errorf("failed to deregister node %q of removed catalog provider %q: %w", node.Node, name, err)
+		}
+		s.logger.Info("deregistered node of removed catalog provider", "node", node.Node, "provider", name)
+	}
+	return nil
+}
+
+func (s *Server) startCatalogProviders(ctx context.Context) {
+	s.catalogProviderStatusLock.Lock()
+	s.catalogProviderStatus = make(map[string]*structs.CatalogProviderSyncStatus)
+	s.catalogProviderStatusLock.Unlock()
+
+	// The cleanup runs once, so it isn't a leader routine: the routine manager
+	// doesn't cancel the context of the routines which already returned.
+	if err := s.cleanupCatalogProviderNodes(ctx); err != nil {
+		s.logger.Error("failed to clean up the nodes of removed catalog providers", "error", err)
+	}
+
+	for _, c := range s.config.CatalogProviders {
+		syncer := &catalogProviderSyncer{
+			srv:     s,
+			config:  c,
+			logger:  s.loggers.Named(logging.CatalogProviders).With("provider", c.Name),
+			node:    catalogProviderNode(c.Name),
+			entMeta: acl.NewEnterpriseMetaWithPartition(c.Partition, c.Namespace),
+		}
+
+		s.catalogProviderStatusLock.Lock()
+		s.catalogProviderStatus[c.Name] = &structs.CatalogProviderSyncStatus{
+			Name:      c.Name,
+			Type:      c.Type,
+			Node:      syncer.node,
+			Partition: c.Partition,
+			Namespace: c.Namespace,
+		}
+		s.catalogProviderStatusLock.Unlock()
+
+		s.leaderRoutineManager.Start(ctx, catalogProviderRoutine(c.Name), syncer.run)
+	}
+}
+
+func (s *Server) stopCatalogProviders() {
+	// will be a no-op when not started
+	for _, c := range s.config.CatalogProviders {
+		s.leaderRoutineManager.Stop(catalogProviderRoutine(c.Name))
+	}
+
+	s.catalogProviderStatusLock.Lock()
+	s.catalogProviderStatus = nil
+	s.catalogProviderStatusLock.Unlock()
+}
+
+func catalogProviderRoutine(name string) string {
+	return fmt.Sprintf("%s %q", catalogProviderRoutineName, name)
+}
+
+func (s *Server) updateCatalogProviderStatusError(name string, err error) {
+	s.catalogProviderStatusLock.Lock()
+	defer s.catalogProviderStatusLock.Unlock()
+
+	if status, ok := s.catalogProviderStatus[name]; ok {
+		status.LastError = time.Now().Round(time.Second).UTC()
+		status.LastErrorMessage = err.Error()
+	}
+}
+
+func (s *Server) updateCatalogProviderStatusSuccess(name string, services, instances int) {
+	s.catalogProviderStatusLock.Lock()
+	defer s.catalogProviderStatusLock.Unlock()
+
+	if status, ok := s.catalogProviderStatus[name]; ok {
+		status.LastSuccess = time.Now().Round(time.Second).UTC()
+		status.Services = services
+		status.Instances = instances
+	}
+}
+
+// getCatalogProviderStatus returns the status of the catalog providers on
+// this server, in the order of the configuration. It is empty if the server
+// is not the leader.
+func (s *Server) getCatalogProviderStatus() structs.CatalogProviderStatus {
+	s.catalogProviderStatusLock.RLock()
+	defer s.catalogProviderStatusLock.RUnlock()
+
+	var status structs.CatalogProviderStatus
+	for _, c := range s.config.CatalogProviders {
+		if p, ok := s.catalogProviderStatus[c.Name]; ok {
+			status.Providers = append(status.Providers, *p)
+		}
+	}
+	return status
+}
+
+// checkCatalogProviderNode returns an error if the node is managed by a
+// catalog provider, since the changes would be reverted by the next sync.
+func checkCatalogProviderNode(node *structs.Node) error {
+	if node == nil {
+		return nil
+	}
+	if name, ok := node.Meta[structs.MetaCatalogProvider]; ok {
+		return fmt.Errorf("node %q is managed by catalog provider %q and is read-only", node.Node, name)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestCatalogProviders(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "instances.json")
+	writeInstances := func(instances string) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"instances": `+instances+`}`), 0600))
+	}
+	writeInstances(`[
+		{"service": "db", "address": "10.0.0.1", "port": 5432},
+		{"service": "db", "address": "10.0.0.2", "port": 5432},
+		{"service": "ldap", "id": "ldap", "address": "10.0.0.3", "port": 389, "tags": ["primary"]}
+	]`)
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.CatalogProviders = []catalogprovider.Config{{
+			Name:         "legacy",
+			Type:         catalogprovider.ProviderFile,
+			SyncInterval: 50 * time.Millisecond,
+			File:         catalogprovider.FileConfig{Path: path},
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	nodeServices := func(r require.TestingT) map[string]*structs.NodeService {
+		_, ns, err := s1.fsm.State().NodeServices(nil, "catalog-provider-legacy", nil, "")
+		require.NoError(r, err)
+		require.NotNil(r, ns)
+		require.Equal(r, "legacy", ns.Node.Meta[structs.MetaCatalogProvider])
+		require.Equal(r, "file", ns.Node.Meta[structs.MetaExternalSource])
+		return ns.Services
+	}
+
+	retry.Run(t, func(r *retry.R) {
+		services := nodeServices(r)
+		require.Len(r, services, 3)
+		require.Contains(r, services, "db-10.0.0.1-5432")
+		require.Contains(r, services, "db-10.0.0.2-5432")
+		require.Equal(r, []string{"primary"}, services["ldap"].Tags)
+		require.Equal(r, "file", services["ldap"].Meta[structs.MetaExternalSource])
+	})
+
+	var status structs.CatalogProviderStatus
+	require.NoError(t, s1.RPC(context.Background(), "Operator.CatalogProviderStatus", &structs.DCSpecificRequest{Datacenter: "dc1"}, &status))
+	require.Len(t, status.Providers, 1)
+	require.Equal(t, "legacy", status.Providers[0].Name)
+	require.Equal(t, "catalog-provider-legacy", status.Providers[0].Node)
+	require.Equal(t, 2, status.Providers[0].Services)
+	require.Equal(t, 3, status.Providers[0].Instances)
+	require.False(t, status.Providers[0].LastSuccess.IsZero())
+
+	// The node of the provider is read-only.
+	var out struct{}
+	err := s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "catalog-provider-legacy",
+		Address:    "127.0.0.1",
+		Service:    &structs.NodeService{Service: "web"},
+	}, &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `node "catalog-provider-legacy" is managed by catalog provider "legacy" and is read-only`)
+	err = s1.RPC(context.Background(), "Catalog.Deregister", &structs.DeregisterRequest{
+		Datacenter: "dc1",
+		Node:       "catalog-provider-legacy",
+		ServiceID:  "ldap",
+	}, &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is read-only")
+
+	// Nodes can't be made to look like they are managed by a provider.
+	err = s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "foo",
+		Address:    "127.0.0.1",
+		NodeMeta:   map[string]string{structs.MetaCatalogProvider: "legacy"},
+	}, &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Node metadata key "consul-catalog-provider" is reserved for catalog providers`)
+
+	// Removed instances are deregistered and modified ones are updated.
+	writeInstances(`[
+		{"service": "db", "address": "10.0.0.1", "port": 5432},
+		{"service": "ldap", "id": "ldap", "address": "10.0.0.4", "port": 389}
+	]`)
+	retry.Run(t, func(r *retry.R) {
+		services := nodeServices(r)
+		require.Len(r, services, 2)
+		require.Contains(r, services, "db-10.0.0.1-5432")
+		require.Equal(r, "10.0.0.4", services["ldap"].Address)
+		require.Empty(r, services["ldap"].Tags)
+	})
+
+	// A failed sync leaves the instances in place and is reported.
+	writeInstances(`{}`)
+	retry.Run(t, func(r *retry.R) {
+		var status structs.CatalogProviderStatus
+		require.NoError(r, s1.RPC(context.Background(), "Operator.CatalogProviderStatus", &structs.DCSpecificRequest{Datacenter: "dc1"}, &status))
+		require.Contains(r, status.Providers[0].LastErrorMessage, "failed to decode")
+	})
+	require.Len(t, nodeServices(t), 2)
+
+	// The nodes of the providers removed from the configuration are
+	// deregistered.
+	_, err = s1.leaderRaftApply("Catalog.Register", structs.RegisterRequestType, &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "catalog-provider-removed",
+		Address:    "127.0.0.1",
+		NodeMeta:   map[string]string{structs.MetaCatalogProvider: "removed"},
+		Service:    &structs.NodeService{ID: "web", Service: "web"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s1.cleanupCatalogProviderNodes(context.Background()))
+	_, node, err := s1.fsm.State().GetNode("catalog-provider-removed", nil, "")
+	require.NoError(t, err)
+	require.Nil(t, node)
+	require.Len(t, nodeServices(t), 2)
+}
+
+func TestCatalogProviders_ACLs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "instances.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"instances": [{"service": "db", "id": "db", "address": "10.0.0.1", "port": 5432}]}`), 0600))
+
+	_, s1, codec := testACLServerWithConfig(t, func(c *Config) {
+		c.CatalogProviders = []catalogprovider.Config{{
+			Name:         "legacy",
+			Type:         catalogprovider.ProviderFile,
+			SyncInterval: 50 * time.Millisecond,
+			File:         catalogprovider.FileConfig{Path: path},
+		}}
+	}, false)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1", testrpc.WithToken(TestDefaultInitialManagementToken))
+
+	retry.Run(t, func(r *retry.R) {
+		_, node, err := s1.fsm.State().GetNode("catalog-provider-legacy", nil, "")
+		require.NoError(r, err)
+		require.NotNil(r, node)
+	})
+
+	// A token that can't write to the node isn't told that it is managed by
+	// a provider.
+	var out struct{}
+	err := msgpackrpc.CallWithCodec(codec, "Catalog.Register", &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "catalog-provider-legacy",
+		Address:    "127.0.0.1",
+	}, &out)
+	require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+	err = msgpackrpc.CallWithCodec(codec, "Catalog.Deregister", &structs.DeregisterRequest{
+		Datacenter: "dc1",
+		Node:       "catalog-provider-legacy",
+		ServiceID:  "db",
+	}, &out)
+	require.True(t, acl.IsErrPermissionDenied(err), "unexpected error: %v", err)
+
+	err = msgpackrpc.CallWithCodec(codec, "Catalog.Deregister", &structs.DeregisterRequest{
+		Datacenter:   "dc1",
+		Node:         "catalog-provider-legacy",
+		ServiceID:    "db",
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}, &out)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is read-only")
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package catalogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	cases := map[string]struct {
+		config Config
+		err    string
+	}{
+		"no name": {
+			config: Config{Type: ProviderFile, File: FileConfig{Path: "/tmp/instances.json"}},
+			err:    "name must be set",
+		},
+		"unknown type": {
+			config: Config{Name: "legacy", Type: "zookeeper"},
+			err:    `unknown type "zookeeper", must be one of [aws-cloud-map dns etcd file]`,
+		},
+		"negative sync interval": {
+			config: Config{Name: "legacy", Type: ProviderFile, File: FileConfig{Path: "/tmp/instances.json"}, SyncInterval: -1},
+			err:    "sync_interval cannot be -1ns. Must be greater than or equal to zero",
+		},
+		"file": {
+			config: Config{Name: "legacy", Type: ProviderFile, File: FileConfig{Path: "/tmp/instances.json"}},
+		},
+		"file without path": {
+			config: Config{Name: "legacy", Type: ProviderFile},
+			err:    "invalid file provider config: path must be set",
+		},
+		"aws-cloud-map without namespace": {
+			config: Config{Name: "ecs", Type: ProviderAWSCloudMap},
+			err:    "invalid aws-cloud-map provider config: namespace_id must be set",
+		},
+		"dns tsig key without secret": {
+			config: Config{Name: "zone", Type: ProviderDNS, DNS: DNSConfig{Zone: "example.com", Server: "10.0.0.53", TSIGKeyName: "axfr"}},
+			err:    "invalid dns provider config: tsig_key_name and tsig_secret must be set together",
+		},
+		"etcd invalid address": {
+			config: Config{Name: "etcd", Type: ProviderEtcd, Etcd: EtcdConfig{Address: "10.0.0.1:2379"}},
+			err:    `invalid etcd provider config: address must be an http or https URL, got "10.0.0.1:2379"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+type staticInstances []Instance
+
+func (s staticInstances) Instances(context.Context) ([]Instance, error) {
+	return s, nil
+}
+
+func TestFetch(t *testing.T) {
+	instances, err := Fetch(context.Background(), staticInstances{
+		{Service: "web", Address: "10.0.0.2", Port: 8080},
+		{Service: "db", ID: "db-primary", Address: "10.0.0.1", Port: 5432},
+		{Service: "ntp", Address: "10.0.0.3"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Instance{
+		{Service: "db", ID: "db-primary", Address: "10.0.0.1", Port: 5432},
+		{Service: "ntp", ID: "ntp-10.0.0.3", Address: "10.0.0.3"},
+		{Service: "web", ID: "web-10.0.0.2-8080", Address: "10.0.0.2", Port: 8080},
+	}, instances)
+
+	_, err = Fetch(context.Background(), staticInstances{{Service: "web"}})
+	require.EqualError(t, err, `instance 0 of service "web" has no address`)
+
+	_, err = Fetch(context.Background(), staticInstances{
+		{Service: "web", Address: "10.0.0.2", Port: 8080},
+		{Service: "web", Address: "10.0.0.2", Port: 8080},
+	})
+	require.EqualError(t, err, `instance ID "web-10.0.0.2-8080" is used by several instances`)
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instances.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"instances": [
+		{"service": "db", "address": "10.0.0.1", "port": 5432, "tags": ["primary"], "meta": {"version": "14"}}
+	]}`), 0600))
+
+	p, err := NewProvider(Config{Name: "legacy", Type: ProviderFile, File: FileConfig{Path: path}})
+	require.NoError(t, err)
+	instances, err := p.Instances(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Instance{
+		{Service: "db", Address: "10.0.0.1", Port: 5432, Tags: []string{"primary"}, Meta: map[string]string{"version": "14"}},
+	}, instances)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0600))
+	_, err = p.Instances(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode")
+}
+
+func TestEtcdProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			var req etcdAuthenticateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Name != "consul" || req.Password != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(etcdAuthenticateResponse{Token: "token-1"})
+		case "/v3/kv/range":
+			if r.Header.Get("Authorization") != "token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req etcdRangeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "services/", string(req.Key))
+			require.Equal(t, "services0", string(req.RangeEnd))
+			var resp etcdRangeResponse
+			resp.KVs = append(resp.KVs, struct {
+				Key   []byte `json:"key"`
+				Value []byte `json:"value"`
+			}{
+				Key:   []byte("services/db"),
+				Value: []byte(`{"service": "db", "address": "10.0.0.1", "port": 5432}`),
+			})
+			json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := Config{Name: "etcd", Type: ProviderEtcd, Etcd: EtcdConfig{
+		Address:  srv.URL,
+		Prefix:   "services/",
+		Username: "consul",
+		Password: "hunter2",
+	}}
+	p, err := NewProvider(config)
+	require.NoError(t, err)
+	instances, err := p.Instances(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Instance{{Service: "db", Address: "10.0.0.1", Port: 5432}}, instances)
+
+	config.Etcd.Password = "wrong"
+	p, err = NewProvider(config)
+	require.NoError(t, err)
+	_, err = p.Instances(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to authenticate to etcd: unexpected response code 401")
+}
+
+func TestEtcdPrefixRange(t *testing.T) {
+	require.Equal(t, etcdRangeRequest{Key: []byte("a/"), RangeEnd: []byte("a0")}, etcdPrefixRange("a/"))
+	require.Equal(t, etcdRangeRequest{Key: []byte("a\xff"), RangeEnd: []byte("b")}, etcdPrefixRange("a\xff"))
+	require.Equal(t, etcdRangeRequest{Key: []byte{0}, RangeEnd: []byte{0}}, etcdPrefixRange(""))
+}
+
+func TestDNSProvider(t *testing.T) {
+	zone := []string{
+		"example.com. 3600 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 60",
+		"_db._tcp.example.com. 60 IN SRV 10 10 5432 db1.example.com.",
+		"_db._tcp.example.com. 60 IN SRV 10 10 5432 db2.example.net.",
+		"_www.example.com. 60 IN SRV 10 10 80 www.example.com.",
+		"db1.example.com. 60 IN A 10.0.0.1",
+		"db1.example.com. 60 IN AAAA 2001:db8::1",
+		"example.com. 3600 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 60",
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{
+		Listener: l,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			if req.Question[0].Qtype != dns.TypeAXFR || req.Question[0].Name != "example.com." {
+				rsp := new(dns.Msg)
+				rsp.SetRcode(req, dns.RcodeRefused)
+				w.WriteMsg(rsp)
+				return
+			}
+			var rrs []dns.RR
+			for _, record := range zone {
+				rr, err := dns.NewRR(record)
+				require.NoError(t, err)
+				rrs = append(rrs, rr)
+			}
+			ch := make(chan *dns.Envelope, 1)
+			ch <- &dns.Envelope{RR: rrs}
+			close(ch)
+			require.NoError(t, new(dns.Transfer).Out(w, req, ch))
+			w.Hijack()
+		}),
+	}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+
+	p, err := NewProvider(Config{Name: "zone", Type: ProviderDNS, DNS: DNSConfig{Zone: "example.com", Server: l.Addr().String()}})
+	require.NoError(t, err)
+	instances, err := p.Instances(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Instance{
+		{Service: "db", Address: "10.0.0.1", Port: 5432, Tags: []string{"tcp"}, Meta: map[string]string{"dns-target": "db1.example.com"}},
+		{Service: "db", Address: "2001:db8::1", Port: 5432, Tags: []string{"tcp"}, Meta: map[string]string{"dns-target": "db1.example.com"}},
+		{Service: "db", Address: "db2.example.net", Port: 5432, Tags: []string{"tcp"}, Meta: map[string]string{"dns-target": "db2.example.net"}},
+	}, instances)
+
+	p, err = NewProvider(Config{Name: "zone", Type: ProviderDNS, DNS: DNSConfig{Zone: "example.org", Server: l.Addr().String()}})
+	require.NoError(t, err)
+	_, err = p.Instances(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `failed to transfer zone "example.org."`)
+}
+
+func TestAWSCloudMapProvider(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.Header.Get("X-Amz-Target") {
+		case "Route53AutoNaming_v20170314.ListServices":
+			require.Equal(t, "ns-1", req["Filters"].([]interface{})[0].(map[string]interface{})["Values"].([]interface{})[0])
+			w.Write([]byte(`{"Services": [{"Id": "srv-1", "Name": "web"}]}`))
+		case "Route53AutoNaming_v20170314.ListInstances":
+			require.Equal(t, "srv-1", req["ServiceId"])
+			w.Write([]byte(`{"Instances": [
+				{"Id": "i-1", "Attributes": {"AWS_INSTANCE_IPV4": "10.0.0.1", "AWS_INSTANCE_PORT": "8080", "version": "2"}},
+				{"Id": "i-2", "Attributes": {"AWS_EC2_INSTANCE_ID": "i-0123"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(Config{Name: "ecs", Type: ProviderAWSCloudMap, AWSCloudMap: AWSCloudMapConfig{
+		NamespaceID: "ns-1",
+		Region:      "us-east-1",
+		Endpoint:    srv.URL,
+	}})
+	require.NoError(t, err)
+	instances, err := p.Instances(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Instance{
+		{Service: "web", ID: "web-i-1", Address: "10.0.0.1", Port: 8080, Meta: map[string]string{"version": "2"}},
+	}, instances)
+}
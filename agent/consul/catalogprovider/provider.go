@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package catalogprovider implements the catalog providers, the read-only
+// external sources of service instances such as AWS Cloud Map, DNS zones,
+// static files or etcd. The leader periodically fetches the instances of
+// each provider and syncs them into the catalog, where they are registered on
+// a node dedicated to the provider.
+package catalogprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	ProviderAWSCloudMap = "aws-cloud-map"
+	ProviderDNS         = "dns"
+	ProviderFile        = "file"
+	ProviderEtcd        = "etcd"
+)
+
+// DefaultSyncInterval is how often the instances are synced when the
+// provider does not set a sync interval.
+const DefaultSyncInterval = 30 * time.Second
+
+// Instance is a service instance of an external source.
+type Instance struct {
+	// Service is the name of the service.
+	Service string `json:"service"`
+
+	// ID is the ID of the instance, unique for the provider. It defaults to
+	// the service name, the address and the port of the instance.
+	ID string `json:"id,omitempty"`
+
+	Address string            `json:"address"`
+	Port    int               `json:"port,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+// Provider returns the service instances of an external source.
+type Provider interface {
+	// Instances returns all the instances of the source. An error aborts the
+	// sync, leaving the instances registered by the previous sync untouched.
+	Instances(ctx context.Context) ([]Instance, error)
+}
+
+// Config configures a catalog provider.
+type Config struct {
+	// Name identifies the provider. It is used to name the node the
+	// instances are registered on.
+	Name string
+
+	// Type is the type of the provider.
+	Type string
+
+	// Partition and Namespace are where the instances are registered. They
+	// are only supported by Consul Enterprise.
+	Partition string
+	Namespace string
+
+	// SyncInterval is how often the instances are synced.
+	SyncInterval time.Duration
+
+	AWSCloudMap AWSCloudMapConfig
+	DNS         DNSConfig
+	File        FileConfig
+	Etcd        EtcdConfig
+}
+
+type providerFactory struct {
+	validate func(Config) error
+	new      func(Config) (Provider, error)
+}
+
+var providers = map[string]providerFactory{
+	ProviderAWSCloudMap: {
+		validate: func(c Config) error { return c.AWSCloudMap.validate() },
+		new:      func(c Config) (Provider, error) { return newAWSCloudMapProvider(c.AWSCloudMap) },
+	},
+	ProviderDNS: {
+		validate: func(c Config) error { return c.DNS.validate() },
+		new:      func(c Config) (Provider, error) { return newDNSProvider(c.DNS), nil },
+	},
+	ProviderFile: {
+		validate: func(c Config) error { return c.File.validate() },
+		new:      func(c Config) (Provider, error) { return newFileProvider(c.File), nil },
+	},
+	ProviderEtcd: {
+		validate: func(c Config) error { return c.Etcd.validate() },
+		new:      func(c Config) (Provider, error) { return newEtcdProvider(c.Etcd), nil },
+	},
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("name must be set")
+	}
+	p, ok := providers[c.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %q, must be one of %v", c.Type, providerNames())
+	}
+	if c.SyncInterval < 0 {
+		return fmt.Errorf("sync_interval cannot be %s. Must be greater than or equal to zero", c.SyncInterval)
+	}
+	if err := p.validate(c); err != nil {
+		return fmt.Errorf("invalid %s provider config: %w", c.Type, err)
+	}
+	return nil
+}
+
+// NewProvider returns the provider for the given configuration.
+func NewProvider(c Config) (Provider, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return providers[c.Type].new(c)
+}
+
+func providerNames() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fetch returns the instances of the provider, sorted by ID, after checking
+// that they are valid and filling in their default ID.
+func Fetch(ctx context.Context, p Provider) ([]Instance, error) {
+	instances, err := p.Instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]struct{}, len(instances))
+	for i := range instances {
+		inst := &instances[i]
+		if inst.Service == "" {
+			return nil, fmt.Errorf("instance %d has no service name", i)
+		}
+		if inst.Address == "" {
+			return nil, fmt.Errorf("instance %d of service %q has no address", i, inst.Service)
+		}
+		if inst.Port < 0 || inst.Port > 65535 {
+			return nil, fmt.Errorf("instance %d of service %q has an invalid port %d", i, inst.Service, inst.Port)
+		}
+		if inst.ID == "" {
+			inst.ID = inst.Service + "-" + inst.Address
+			if inst.Port != 0 {
+				inst.ID += "-" + strconv.Itoa(inst.Port)
+			}
+		}
+		if _, ok := ids[inst.ID]; ok {
+			return nil, fmt.Errorf("instance ID %q is used by several instances", inst.ID)
+		}
+		ids[inst.ID] = struct{}{}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].ID < instances[j].ID
+	})
+	return instances, nil
+}
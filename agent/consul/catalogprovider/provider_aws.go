@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package catalogprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/servicediscovery"
+
+	"github.com/hashicorp/consul/lib/egressproxy"
+)
+
+// The attributes of the Cloud Map instances holding their address and port.
+const (
+	awsInstanceIPv4Attribute  = "AWS_INSTANCE_IPV4"
+	awsInstanceIPv6Attribute  = "AWS_INSTANCE_IPV6"
+	awsInstanceCNAMEAttribute = "AWS_INSTANCE_CNAME"
+	awsInstancePortAttribute  = "AWS_INSTANCE_PORT"
+)
+
+// AWSCloudMapConfig configures the aws-cloud-map provider, which lists the
+// instances of the services of an AWS Cloud Map namespace. The custom
+// attributes of the instances become their metadata.
+//
+// Credentials are only read from the standard locations of the AWS SDK, i.e.
+// the environment, the shared credentials file or the instance's IAM role.
+type AWSCloudMapConfig struct {
+	// NamespaceID is the ID of the Cloud Map namespace.
+	NamespaceID string
+
+	// Region overrides the region of the AWS SDK configuration.
+	Region string
+
+	// Endpoint overrides the Cloud Map endpoint, e.g. for VPC endpoints.
+	Endpoint string
+}
+
+func (c AWSCloudMapConfig) validate() error {
+	if c.NamespaceID == "" {
+		return errors.New("namespace_id must be set")
+	}
+	return nil
+}
+
+type awsCloudMapProvider struct {
+	client      *servicediscovery.ServiceDiscovery
+	namespaceID string
+}
+
+func newAWSCloudMapProvider(c AWSCloudMapConfig) (Provider, error) {
+	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			HTTPClient: &http.Client{Transport: egressproxy.PooledTransport()},
+		},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := aws.NewConfig()
+	if c.Region != "" {
+		cfg = cfg.WithRegion(c.Region)
+	}
+	if c.Endpoint != "" {
+		cfg = cfg.WithEndpoint(c.Endpoint)
+	}
+	return &awsCloudMapProvider{
+		client:      servicediscovery.New(awsSession, cfg),
+		namespaceID: c.NamespaceID,
+	}, nil
+}
+
+func (p *awsCloudMapProvider) Instances(ctx context.Context) ([]Instance, error) {
+	var services []*servicediscovery.ServiceSummary
+	err := p.client.ListServicesPagesWithContext(ctx, &servicediscovery.ListServicesInput{
+		Filters: []*servicediscovery.ServiceFilter{{
+			Name:      aws.String(servicediscovery.ServiceFilterNameNamespaceId),
+			Condition: aws.String(servicediscovery.FilterConditionEq),
+			Values:    []*string{aws.String(p.namespaceID)},
+		}},
+	}, func(out *servicediscovery.ListServicesOutput, _ bool) bool {
+		services = append(services, out.Services...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the services of Cloud Map namespace %q: %w", p.namespaceID, err)
+	}
+
+	var instances []Instance
+	for _, service := range services {
+		name := aws.StringValue(service.Name)
+		err := p.client.ListInstancesPagesWithContext(ctx, &servicediscovery.ListInstancesInput{
+			ServiceId: service.Id,
+		}, func(out *servicediscovery.ListInstancesOutput, _ bool) bool {
+			for _, summary := range out.Instances {
+				if inst, ok := awsCloudMapInstance(name, summary); ok {
+					instances = append(instances, inst)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the instances of Cloud Map service %q: %w", name, err)
+		}
+	}
+	return instances, nil
+}
+
+// awsCloudMapInstance converts a Cloud Map instance, skipping the instances
+// without an address such as the ones registered for Cloud Map API calls
+// only.
+func awsCloudMapInstance(service string, summary *servicediscovery.InstanceSummary) (Instance, bool) {
+	attrs := aws.StringValueMap(summary.Attributes)
+	inst := Instance{
+		Service: service,
+		ID:      service + "-" + aws.StringValue(summary.Id),
+	}
+	for _, attr := range []string{awsInstanceIPv4Attribute, awsInstanceIPv6Attribute, awsInstanceCNAMEAttribute} {
+		if addr := attrs[attr]; addr != "" {
+			inst.Address = addr
+			break
+		}
+	}
+	if inst.Address == "" {
+		return Instance{}, false
+	}
+	if port, err := strconv.Atoi(attrs[awsInstancePortAttribute]); err == nil {
+		inst.Port = port
+	}
+	for k, v := range attrs {
+		if strings.HasPrefix(k, "AWS_") {
+			continue
+		}
+		if inst.Meta == nil {
+			inst.Meta = make(map[string]string)
+		}
+		inst.Meta[k] = v
+	}
+	return inst, true
+}
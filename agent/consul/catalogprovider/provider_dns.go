@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package catalogprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DNSConfig configures the dns provider, which transfers a DNS zone (AXFR)
+// and turns its SRV records into instances. An SRV record named
+// _<service>._<proto>.<zone> is an instance of the service, tagged with the
+// protocol, whose address is the A or AAAA record of its target if the zone
+// has one, or the target itself otherwise.
+type DNSConfig struct {
+	// Zone is the name of the zone.
+	Zone string
+
+	// Server is the address of a DNS server allowing zone transfers, with
+	// an optional port.
+	Server string
+
+	// TSIGKeyName and TSIGSecret sign the zone transfer requests with the
+	// hmac-sha256 algorithm when set. The secret is base64 encoded.
+	TSIGKeyName string
+	TSIGSecret  string
+}
+
+func (c DNSConfig) validate() error {
+	if c.Zone == "" {
+		return errors.New("zone must be set")
+	}
+	if c.Server == "" {
+		return errors.New("server must be set")
+	}
+	if (c.TSIGKeyName == "") != (c.TSIGSecret == "") {
+		return errors.New("tsig_key_name and tsig_secret must be set together")
+	}
+	return nil
+}
+
+type dnsProvider struct {
+	config DNSConfig
+	zone   string
+	server string
+}
+
+func newDNSProvider(c DNSConfig) Provider {
+	server := c.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &dnsProvider{
+		config: c,
+		zone:   dns.Fqdn(c.Zone),
+		server: server,
+	}
+}
+
+func (p *dnsProvider) Instances(ctx context.Context) ([]Instance, error) {
+	msg := new(dns.Msg)
+	msg.SetAxfr(p.zone)
+	transfer := new(dns.Transfer)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		transfer.DialTimeout, transfer.ReadTimeout = timeout, timeout
+	}
+	if p.config.TSIGKeyName != "" {
+		keyName := dns.Fqdn(p.config.TSIGKeyName)
+		msg.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+		transfer.TsigSecret = map[string]string{keyName: p.config.TSIGSecret}
+	}
+
+	envelopes, err := transfer.In(msg, p.server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer zone %q from %s: %w", p.zone, p.server, err)
+	}
+
+	var srvs []*dns.SRV
+	addrs := make(map[string][]string)
+	for env := range envelopes {
+		if env.Error != nil {
+			return nil, fmt.Errorf("failed to transfer zone %q from %s: %w", p.zone, p.server, env.Error)
+		}
+		for _, rr := range env.RR {
+			switch rr := rr.(type) {
+			case *dns.SRV:
+				srvs = append(srvs, rr)
+			case *dns.A:
+				name := strings.ToLower(rr.Hdr.Name)
+				addrs[name] = append(addrs[name], rr.A.String())
+			case *dns.AAAA:
+				name := strings.ToLower(rr.Hdr.Name)
+				addrs[name] = append(addrs[name], rr.AAAA.String())
+			}
+		}
+	}
+
+	var instances []Instance
+	for _, srv := range srvs {
+		service, proto, ok := p.parseSRVName(srv.Hdr.Name)
+		if !ok {
+			continue
+		}
+		target := strings.ToLower(srv.Target)
+		targetAddrs := addrs[target]
+		if len(targetAddrs) == 0 {
+			targetAddrs = []string{strings.TrimSuffix(target, ".")}
+		}
+		for _, addr := range targetAddrs {
+			instances = append(instances, Instance{
+				Service: service,
+				Address: addr,
+				Port:    int(srv.Port),
+				Tags:    []string{proto},
+				Meta:    map[string]string{"dns-target": strings.TrimSuffix(target, ".")},
+			})
+		}
+	}
+	return instances, nil
+}
+
+// parseSRVName returns the service and the protocol of an SRV record named
+// _<service>._<proto>.<zone>.
+func (p *dnsProvider) parseSRVName(name string) (string, string, bool) {
+	name = strings.ToLower(name)
+	zone := strings.ToLower(p.zone)
+	if !strings.HasSuffix(name, "."+zone) {
+		return "", "", false
+	}
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, "."+zone))
+	if len(labels) != 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return "", "", false
+	}
+	return strings.TrimPrefix(labels[0], "_"), strings.TrimPrefix(labels[1], "_"), true
+}
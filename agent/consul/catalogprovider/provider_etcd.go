@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package catalogprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// EtcdConfig configures the etcd provider, which reads the instances from
+// the keys under a prefix of an etcd v3 cluster, through its JSON gateway.
+// The value of each key is an instance encoded in JSON, for example
+// {"service": "db", "address": "10.0.0.1", "port": 5432}.
+type EtcdConfig struct {
+	// Address is the http or https URL of an etcd member.
+	Address string
+
+	// Prefix is the prefix of the keys holding the instances.
+	Prefix string
+
+	// Username and Password authenticate the requests when the etcd cluster
+	// has authentication enabled.
+	Username string
+	Password string
+}
+
+func (c EtcdConfig) validate() error {
+	u, err := url.Parse(c.Address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("address must be an http or https URL, got %q", c.Address)
+	}
+	if c.Password != "" && c.Username == "" {
+		return errors.New("username must be set with password")
+	}
+	return nil
+}
+
+type etcdProvider struct {
+	config EtcdConfig
+	client *http.Client
+}
+
+func newEtcdProvider(c EtcdConfig) Provider {
+	return &etcdProvider{
+		config: c,
+		client: cleanhttp.DefaultPooledClient(),
+	}
+}
+
+type etcdRangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end"`
+}
+
+type etcdRangeResponse struct {
+	KVs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	} `json:"kvs"`
+}
+
+type etcdAuthenticateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type etcdAuthenticateResponse struct {
+	Token string `json:"token"`
+}
+
+func (p *etcdProvider) Instances(ctx context.Context) ([]Instance, error) {
+	var token string
+	if p.config.Username != "" {
+		var auth etcdAuthenticateResponse
+		req := etcdAuthenticateRequest{Name: p.config.Username, Password: p.config.Password}
+		if err := p.post(ctx, "/v3/auth/authenticate", "", req, &auth); err != nil {
+			return nil, fmt.Errorf("failed to authenticate to etcd: %w", err)
+		}
+		token = auth.Token
+	}
+
+	var resp etcdRangeResponse
+	if err := p.post(ctx, "/v3/kv/range", token, etcdPrefixRange(p.config.Prefix), &resp); err != nil {
+		return nil, fmt.Errorf("failed to list etcd keys under %q: %w", p.config.Prefix, err)
+	}
+
+	instances := make([]Instance, 0, len(resp.KVs))
+	for _, kv := range resp.KVs {
+		var inst Instance
+		if err := json.Unmarshal(kv.Value, &inst); err != nil {
+			return nil, fmt.Errorf("failed to decode etcd key %q: %w", kv.Key, err)
+		}
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+func (p *etcdProvider) post(ctx context.Context, path, token string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(p.config.Address, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response code %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// etcdPrefixRange returns the range of the keys under the prefix, all the
+// keys for an empty prefix.
+func etcdPrefixRange(prefix string) etcdRangeRequest {
+	if prefix == "" {
+		return etcdRangeRequest{Key: []byte{0}, RangeEnd: []byte{0}}
+	}
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return etcdRangeRequest{Key: []byte(prefix), RangeEnd: end[:i+1]}
+		}
+	}
+	// The prefix is made of 0xff bytes, so the range ends with the keyspace.
+	return etcdRangeRequest{Key: []byte(prefix), RangeEnd: []byte{0}}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package catalogprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// FileConfig configures the file provider, which reads the instances from a
+// JSON file of the form {"instances": [{"service": "db", "address": "10.0.0.1",
+// "port": 5432}]}. The file is read again on each sync.
+type FileConfig struct {
+	// Path is the path of the file.
+	Path string
+}
+
+func (c FileConfig) validate() error {
+	if c.Path == "" {
+		return errors.New("path must be set")
+	}
+	return nil
+}
+
+type fileProvider struct {
+	path string
+}
+
+func newFileProvider(c FileConfig) Provider {
+	return &fileProvider{path: c.Path}
+}
+
+// instanceList is the format of the file provider and of the values of the
+// etcd provider.
+type instanceList struct {
+	Instances []Instance `json:"instances"`
+}
+
+func (p *fileProvider) Instances(_ context.Context) ([]Instance, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	var list instanceList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", p.path, err)
+	}
+	return list.Instances, nil
+}
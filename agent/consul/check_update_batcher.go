@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+var CheckUpdateBatcherSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: []string{"catalog", "register", "batch_size"},
+		Help: "Measures the number of check updates applied together in a single Raft transaction.",
+	},
+}
+
+// checkUpdateBatcher coalesces the check updates the leader receives from the
+// agents into batches which are applied with a single Raft log entry, so that
+// clusters with many checks do not need one Raft write per status change.
+type checkUpdateBatcher struct {
+	interval time.Duration
+	maxSize  int
+
+	// apply is used to apply a batch to the Raft log. It is the server's
+	// raftApply outside of tests.
+	apply func(t structs.MessageType, msg interface{}) (interface{}, error)
+
+	// lock protects pending.
+	lock    sync.Mutex
+	pending []*pendingCheckUpdate
+}
+
+type pendingCheckUpdate struct {
+	req   *structs.RegisterRequest
+	errCh chan error
+}
+
+func newCheckUpdateBatcher(interval time.Duration, maxSize int, apply func(structs.MessageType, interface{}) (interface{}, error)) *checkUpdateBatcher {
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+	return &checkUpdateBatcher{
+		interval: interval,
+		maxSize:  maxSize,
+		apply:    apply,
+	}
+}
+
+// register queues the register request to be applied with the next batch,
+// and waits until it has been applied. The batch is applied once the flush
+// interval has elapsed since its first request was queued, or as soon as it
+// is full.
+func (b *checkUpdateBatcher) register(req *structs.RegisterRequest) error {
+	update := &pendingCheckUpdate{req: req, errCh: make(chan error, 1)}
+
+	b.lock.Lock()
+	b.pending = append(b.pending, update)
+	switch {
+	case len(b.pending) >= b.maxSize:
+		batch := b.pending
+		b.pending = nil
+		go b.flush(batch)
+	case len(b.pending) == 1:
+		time.AfterFunc(b.interval, b.flushPending)
+	}
+	b.lock.Unlock()
+
+	return <-update.errCh
+}
+
+// flushPending applies the pending batch, unless it was already applied
+// because it was full.
+func (b *checkUpdateBatcher) flushPending() {
+	b.lock.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.lock.Unlock()
+
+	if len(batch) > 0 {
+		b.flush(batch)
+	}
+}
+
+// flush applies the batch with a single Raft log entry, and hands each of the
+// queued requests its own result.
+func (b *checkUpdateBatcher) flush(batch []*pendingCheckUpdate) {
+	metrics.AddSample([]string{"catalog", "register", "batch_size"}, float32(len(batch)))
+
+	req := &structs.RegisterBatchRequest{Requests: make([]*structs.RegisterRequest, len(batch))}
+	for i, update := range batch {
+		req.Requests[i] = update.req
+	}
+
+	resp, err := b.apply(structs.RegisterBatchRequestType, req)
+	errs, ok := resp.([]error)
+	if err == nil && (!ok || len(errs) != len(batch)) {
+		err = fmt.Errorf("unexpected response to register batch: %T", resp)
+	}
+	for i, update := range batch {
+		if err != nil {
+			update.errCh <- err
+		} else {
+			update.errCh <- errs[i]
+		}
+	}
+}
+
+// isCheckUpdate returns whether the register request only updates checks of
+// the node, given the services already registered on it. These are the
+// requests the agents send whenever the status of one of their checks
+// changes, and which can be batched.
+func isCheckUpdate(req *structs.RegisterRequest, ns *structs.NodeServices) bool {
+	if len(req.Checks) == 0 || !req.SkipNodeUpdate || ns == nil {
+		return false
+	}
+	if req.Service == nil {
+		return true
+	}
+	existing, ok := ns.Services[req.Service.ID]
+	return ok && existing.IsSame(req.Service)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// fakeBatchApplier records the batches it is asked to apply, and fails the
+// requests of the nodes in failNodes.
+type fakeBatchApplier struct {
+	mu        sync.Mutex
+	batches   [][]*structs.RegisterRequest
+	failNodes map[string]bool
+	err       error
+}
+
+func (a *fakeBatchApplier) apply(t structs.MessageType, msg interface{}) (interface{}, error) {
+	if t != structs.RegisterBatchRequestType {
+		return nil, fmt.Errorf("unexpected message type %d", t)
+	}
+	req := msg.(*structs.RegisterBatchRequest)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.batches = append(a.batches, req.Requests)
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	errs := make([]error, len(req.Requests))
+	for i, r := range req.Requests {
+		if a.failNodes[r.Node] {
+			errs[i] = fmt.Errorf("failed to register %s", r.Node)
+		}
+	}
+	return errs, nil
+}
+
+func registerConcurrently(b *checkUpdateBatcher, nodes ...string) map[string]error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make(map[string]error)
+	)
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node string) {
+			defer wg.Done()
+			err := b.register(&structs.RegisterRequest{Node: node})
+			mu.Lock()
+			errs[node] = err
+			mu.Unlock()
+		}(node)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestCheckUpdateBatcher(t *testing.T) {
+	t.Run("coalesces updates", func(t *testing.T) {
+		applier := &fakeBatchApplier{failNodes: map[string]bool{"node2": true}}
+		b := newCheckUpdateBatcher(50*time.Millisecond, 100, applier.apply)
+
+		errs := registerConcurrently(b, "node1", "node2", "node3")
+		require.NoError(t, errs["node1"])
+		require.EqualError(t, errs["node2"], "failed to register node2")
+		require.NoError(t, errs["node3"])
+
+		require.Len(t, applier.batches, 1)
+		require.Len(t, applier.batches[0], 3)
+	})
+
+	t.Run("flushes full batches", func(t *testing.T) {
+		applier := &fakeBatchApplier{}
+		b := newCheckUpdateBatcher(time.Hour, 2, applier.apply)
+
+		errs := registerConcurrently(b, "node1", "node2", "node3", "node4")
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		require.Len(t, applier.batches, 2)
+	})
+
+	t.Run("apply error", func(t *testing.T) {
+		applier := &fakeBatchApplier{err: errors.New("no leader")}
+		b := newCheckUpdateBatcher(10*time.Millisecond, 100, applier.apply)
+
+		errs := registerConcurrently(b, "node1", "node2")
+		require.EqualError(t, errs["node1"], "no leader")
+		require.EqualError(t, errs["node2"], "no leader")
+	})
+}
+
+func TestIsCheckUpdate(t *testing.T) {
+	web := &structs.NodeService{ID: "web", Service: "web", Port: 80}
+	ns := &structs.NodeServices{
+		Node:     &structs.Node{Node: "node1"},
+		Services: map[string]*structs.NodeService{"web": web},
+	}
+	checks := structs.HealthChecks{{Node: "node1", CheckID: "web"}}
+
+	cases := map[string]struct {
+		req    *structs.RegisterRequest
+		ns     *structs.NodeServices
+		expect bool
+	}{
+		"node check": {
+			req:    &structs.RegisterRequest{Node: "node1", SkipNodeUpdate: true, Checks: checks},
+			ns:     ns,
+			expect: true,
+		},
+		"service check": {
+			req:    &structs.RegisterRequest{Node: "node1", SkipNodeUpdate: true, Service: web, Checks: checks},
+			ns:     ns,
+			expect: true,
+		},
+		"service change": {
+			req:    &structs.RegisterRequest{Node: "node1", SkipNodeUpdate: true, Service: &structs.NodeService{ID: "web", Service: "web", Port: 81}, Checks: checks},
+			ns:     ns,
+			expect: false,
+		},
+		"node update": {
+			req:    &structs.RegisterRequest{Node: "node1", Checks: checks},
+			ns:     ns,
+			expect: false,
+		},
+		"new node": {
+			req:    &structs.RegisterRequest{Node: "node1", SkipNodeUpdate: true, Checks: checks},
+			expect: false,
+		},
+		"no checks": {
+			req:    &structs.RegisterRequest{Node: "node1", SkipNodeUpdate: true, Service: web},
+			ns:     ns,
+			expect: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expect, isCheckUpdate(tc.req, tc.ns))
+		})
+	}
+}
@@ -81,6 +81,9 @@ type Client struct {
 	// eventCh is used to receive events from the serf cluster in the datacenter
 	eventCh chan serf.Event
 
+	// memberEvents records the changes of the membership of the serf cluster.
+	memberEvents *memberEventLog
+
 	// Logger uses the provided LogOutput
 	logger hclog.InterceptLogger
 
@@ -117,6 +120,7 @@ func NewClient(config *Config, deps Deps) (*Client, error) {
 		config:          config,
 		connPool:        deps.ConnPool,
 		eventCh:         make(chan serf.Event, serfEventBacklog),
+		memberEvents:    newMemberEventLog(config.SerfLANConfig.TombstoneTimeout),
 		logger:          deps.Logger.NamedIntercept(logging.ConsulClient),
 		shutdownCh:      make(chan struct{}),
 		tlsConfigurator: deps.TLSConfigurator,
@@ -234,6 +238,13 @@ func (c *Client) LANMembersInAgentPartition() []serf.Member {
 	return c.serf.Members()
 }
 
+// MemberEvents returns the changes of the membership of the agent's canonical
+// serf pool observed after index, the index of the last change and a channel
+// closed on the next change.
+func (c *Client) MemberEvents(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{}) {
+	return c.memberEvents.since(index)
+}
+
 // LANMembers returns the LAN members for one of:
 //
 // - the requested partition
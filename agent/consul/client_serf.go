@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/serf/serf"
 
 	"github.com/hashicorp/consul/agent/metadata"
 	"github.com/hashicorp/consul/internal/gossip/libserf"
+	"github.com/hashicorp/consul/internal/gossip/tcptransport"
 	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/types"
@@ -67,6 +69,14 @@ func (c *Client) setupSerf(conf *serf.Config, ch chan serf.Event, path string) (
 
 	addSerfMetricsLabels(conf, false, c.config.Segment, c.config.AgentEnterpriseMeta().PartitionOrDefault(), "")
 
+	if c.config.SerfLANTCPOnly {
+		transport, err := tcptransport.NewNetTransport(conf.MemberlistConfig, conf.MetricLabels)
+		if err != nil {
+			return nil, err
+		}
+		conf.MemberlistConfig.Transport = transport
+	}
+
 	addEnterpriseSerfTags(conf.Tags, c.config.AgentEnterpriseMeta())
 
 	conf.ReconnectTimeoutOverride = libserf.NewReconnectOverride(c.logger)
@@ -93,8 +103,10 @@ func (c *Client) lanEventHandler() {
 			switch e.EventType() {
 			case serf.EventMemberJoin:
 				c.nodeJoin(e.(serf.MemberEvent))
+				c.memberEvents.record(c.serf, e.(serf.MemberEvent), time.Now())
 			case serf.EventMemberLeave, serf.EventMemberFailed, serf.EventMemberReap:
 				c.nodeFail(e.(serf.MemberEvent))
+				c.memberEvents.record(c.serf, e.(serf.MemberEvent), time.Now())
 			case serf.EventUser:
 				c.localEvent(e.(serf.UserEvent))
 			case serf.EventMemberUpdate: // Ignore
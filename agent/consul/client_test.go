@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/consul/agent/rpc/middleware"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/internal/gossip/tcptransport"
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
@@ -138,6 +139,38 @@ func TestClient_JoinLAN(t *testing.T) {
 	})
 }
 
+func TestClient_JoinLAN_TCPOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.SerfLANTCPOnly = true
+	})
+	_, c1 := testClientWithConfig(t, func(c *Config) {
+		c.SerfLANTCPOnly = true
+	})
+
+	require.IsType(t, &tcptransport.Transport{}, s1.config.SerfLANConfig.MemberlistConfig.Transport)
+	require.IsType(t, &tcptransport.Transport{}, c1.config.SerfLANConfig.MemberlistConfig.Transport)
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	joinLAN(t, c1, s1)
+	testrpc.WaitForTestAgent(t, c1.RPC, "dc1")
+
+	// The members stay healthy while probing each other over TCP.
+	time.Sleep(2 * tcptransport.MinProbeInterval)
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, 1, c1.router.GetLANManager().NumServers())
+		for _, m := range s1.LANMembersInAgentPartition() {
+			require.Equal(r, serf.StatusAlive, m.Status, m.Name)
+		}
+		require.Zero(r, s1.serfLAN.Memberlist().GetHealthScore())
+		require.Zero(r, c1.serf.Memberlist().GetHealthScore())
+	})
+}
+
 func TestClient_LANReap(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -545,16 +578,18 @@ func newDefaultDeps(t testutil.TestingTB, c *Config) Deps {
 	)
 
 	connPool := &pool.ConnPool{
-		Server:           false,
-		SrcAddr:          c.RPCSrcAddr,
-		Logger:           logger.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true}),
-		MaxTime:          2 * time.Minute,
-		MaxStreams:       4,
-		TLSConfigurator:  tls,
-		Datacenter:       c.Datacenter,
-		DefaultQueryTime: c.DefaultQueryTime,
-		MaxQueryTime:     c.MaxQueryTime,
-		RPCHoldTimeout:   c.RPCHoldTimeout,
+		Server:               false,
+		SrcAddr:              c.RPCSrcAddr,
+		Logger:               logger.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true}),
+		MaxTime:              2 * time.Minute,
+		MaxStreams:           4,
+		TLSConfigurator:      tls,
+		Datacenter:           c.Datacenter,
+		DefaultQueryTime:     c.DefaultQueryTime,
+		MaxQueryTime:         c.MaxQueryTime,
+		RPCHoldTimeout:       c.RPCHoldTimeout,
+		Compression:          c.RPCConfig.Compression,
+		CompressionThreshold: c.RPCConfig.CompressionThreshold,
 	}
 	connPool.SetRPCClientTimeout(c.RPCClientTimeout)
 	return Deps{
@@ -16,10 +16,14 @@ import (
 	"github.com/hashicorp/serf/serf"
 
 	"github.com/hashicorp/consul/agent/checks"
+	"github.com/hashicorp/consul/agent/consul/acme"
+	"github.com/hashicorp/consul/agent/consul/catalogprovider"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
 	consulrate "github.com/hashicorp/consul/agent/consul/rate"
 	hcpconfig "github.com/hashicorp/consul/agent/hcp/config"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/internal/gossip/libserf"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/tlsutil"
 	"github.com/hashicorp/consul/types"
 	"github.com/hashicorp/consul/version"
@@ -64,7 +68,7 @@ func init() {
 	}
 }
 
-// (Enterprise-only) NetworkSegment is the address and port configuration
+// NetworkSegment is the address and port configuration
 // for a network segment.
 type NetworkSegment struct {
 	Name       string
@@ -148,10 +152,10 @@ type Config struct {
 	// GRPCTLSPort is the port the public gRPC TLS server listens on.
 	GRPCTLSPort int
 
-	// (Enterprise-only) The network segment this agent is part of.
+	// The network segment this agent is part of.
 	Segment string
 
-	// (Enterprise-only) Segments is a list of network segments for a server to
+	// Segments is a list of network segments for a server to
 	// bind on.
 	Segments []NetworkSegment
 
@@ -161,6 +165,12 @@ type Config struct {
 	// SerfWANConfig is the configuration for the cross-dc serf
 	SerfWANConfig *serf.Config
 
+	// SerfLANTCPOnly and SerfWANTCPOnly send the gossip packets of the LAN
+	// and WAN pools over TCP connections instead of UDP. The LAN setting
+	// also applies to the network segments.
+	SerfLANTCPOnly bool
+	SerfWANTCPOnly bool
+
 	// SerfFloodInterval controls how often we attempt to flood local Serf
 	// Consul servers into the global areas (WAN and user-defined areas in
 	// Consul Enterprise).
@@ -229,6 +239,14 @@ type Config struct {
 	// by default in Consul 1.0 and later.
 	ACLEnableKeyListPolicy bool
 
+	// ACLTokenUsageTracking enables the aggregation of the usage of the tokens
+	// reported by the agents on the leader.
+	ACLTokenUsageTracking bool
+
+	// ACLTokenUsageMaxTokens is the maximum number of tokens whose usage is
+	// aggregated on the leader.
+	ACLTokenUsageMaxTokens int
+
 	AutoConfigEnabled              bool
 	AutoConfigIntroToken           string
 	AutoConfigIntroTokenFile       string
@@ -312,6 +330,23 @@ type Config struct {
 	// used to limit the amount of Raft bandwidth used for replication.
 	FederationStateReplicationApplyLimit int
 
+	// KVReplicationPrefixes are the KV prefixes mirrored from the primary
+	// datacenter by the leader of a secondary datacenter.
+	KVReplicationPrefixes []string
+
+	// KVReplicationRate is the max number of replication rounds that can
+	// be run per second for each prefix.
+	KVReplicationRate int
+
+	// KVReplicationBurst is how many replication rounds can be bursted after a
+	// period of idleness
+	KVReplicationBurst int
+
+	// KVReplicationApplyLimit is the max number of replication-related
+	// apply operations that we allow during a one second period for each
+	// prefix.
+	KVReplicationApplyLimit int
+
 	// CoordinateUpdatePeriod controls how long a server batches coordinate
 	// updates before applying them in a Raft transaction. A larger period
 	// leads to fewer Raft transactions, but also the stored coordinates
@@ -327,6 +362,48 @@ type Config struct {
 	// warning and discard the remaining updates.
 	CoordinateUpdateMaxBatches int
 
+	// CheckUpdateBatchInterval controls how long the leader batches the check
+	// updates of the agents before applying them in a single Raft transaction.
+	// Check updates are applied one by one if it is zero.
+	CheckUpdateBatchInterval time.Duration
+
+	// CheckUpdateBatchSize controls the maximum number of check updates the
+	// leader batches before applying them in a Raft transaction.
+	CheckUpdateBatchSize int
+
+	// Webhooks are the HTTP endpoints the leader posts the changes of the
+	// catalog and of the config entries to.
+	Webhooks []WebhookConfig
+
+	// CatalogProviders are the external sources of service instances the
+	// leader syncs into the catalog.
+	CatalogProviders []catalogprovider.Config
+
+	// NodeAttestationAuthMethod is the node-attestation auth method the
+	// attestation evidence of the registrations is verified with.
+	NodeAttestationAuthMethod string
+
+	// NodeAttestationEnforce rejects the registrations of the agents of the
+	// LAN pool without valid attestation evidence.
+	NodeAttestationEnforce bool
+
+	// AdmissionWebhooks are the HTTP endpoints the servers call to validate,
+	// and optionally mutate, the catalog registrations and the config entries
+	// before they are written.
+	AdmissionWebhooks []AdmissionWebhookConfig
+
+	// ACME configures the client obtaining and renewing the certificates of
+	// the API gateway listeners.
+	ACME acme.Config
+
+	// RaftArchive configures the archive the leader ships the committed Raft
+	// log entries to.
+	RaftArchive RaftArchiveConfig
+
+	// RaftSnapshot throttles the Raft snapshots and sets the window during
+	// which they are preferably taken.
+	RaftSnapshot RaftSnapshotConfig
+
 	// CheckOutputMaxSize control the max size of output of checks
 	CheckOutputMaxSize int
 
@@ -384,6 +461,30 @@ type Config struct {
 	// allowed from a single source IP.
 	RPCMaxConnsPerClient int
 
+	// KVMaxValueSize is the largest KV value, in bytes, that will be accepted
+	// by the public gRPC KV service. It mirrors the limit the HTTP API applies.
+	KVMaxValueSize uint64
+
+	// TxnMaxReqLen is the largest cumulative size, in bytes, of the values in
+	// a transaction accepted by the public gRPC KV service.
+	TxnMaxReqLen uint64
+
+	// Quotas limits the number of objects which can be stored in each namespace.
+	// The quotas are enforced by the leader when objects are written.
+	Quotas structs.QuotaConfig
+
+	// MetaIndexes limits the catalog indexes on node and service metadata to
+	// the declared keys.
+	MetaIndexes structs.MetaIndexConfig
+
+	// Redaction configures the values hidden from the list responses of the
+	// callers without secrets:read access.
+	Redaction structs.RedactionConfig
+
+	// KVEncryption configures the encryption of KV values before they are
+	// written to Raft.
+	KVEncryption kvencrypt.Config
+
 	// LeaveDrainTime is used to wait after a server has left the LAN Serf
 	// pool for RPCs to drain and new requests to be sent to other servers.
 	LeaveDrainTime time.Duration
@@ -414,6 +515,14 @@ type Config struct {
 	// datacenters should exclusively traverse mesh gateways.
 	ConnectMeshGatewayWANFederationEnabled bool
 
+	// DisableExpiredIntentions makes the leader disable the intentions whose
+	// ExpiresAt time has passed. Otherwise they are only reported.
+	DisableExpiredIntentions bool
+
+	// DisableIntentionsAfterReview makes the leader disable the intentions
+	// whose ReviewBy time has passed. Otherwise they are only reported.
+	DisableIntentionsAfterReview bool
+
 	// DefaultIntentionPolicy is used to define a default intention action for all
 	// sources and destinations. Possible values are "allow", "deny", or "" (blank).
 	// For compatibility, falls back to ACLResolverSettings.ACLDefaultPolicy (which
@@ -532,6 +641,9 @@ func DefaultConfig() *Config {
 		FederationStateReplicationRate:       1,
 		FederationStateReplicationBurst:      5,
 		FederationStateReplicationApplyLimit: 100, // ops / sec
+		KVReplicationRate:                    1,
+		KVReplicationBurst:                   5,
+		KVReplicationApplyLimit:              100, // ops / sec
 		TombstoneTTL:                         15 * time.Minute,
 		TombstoneTTLGranularity:              30 * time.Second,
 		SessionTTLMin:                        10 * time.Second,
@@ -547,6 +659,8 @@ func DefaultConfig() *Config {
 		CoordinateUpdateBatchSize:  128,
 		CoordinateUpdateMaxBatches: 5,
 
+		CheckUpdateBatchSize: 256,
+
 		CheckOutputMaxSize: checks.DefaultBufSize,
 
 		RequestLimitsMode:      "disabled",
@@ -556,6 +670,9 @@ func DefaultConfig() *Config {
 		RPCRateLimit: rate.Inf,
 		RPCMaxBurst:  1000,
 
+		KVMaxValueSize: raft.SuggestedMaxDataSize,
+		TxnMaxReqLen:   raft.SuggestedMaxDataSize,
+
 		// TODO (slackpad) - Until #3744 is done, we need to keep these
 		// in sync with agent/config/default.go.
 		AutopilotConfig: &structs.AutopilotConfig{
@@ -672,6 +789,23 @@ func CloneSerfLANConfig(base *serf.Config) *serf.Config {
 // TODO: move many settings to this struct.
 type RPCConfig struct {
 	EnableStreaming bool
+
+	// Compression is the algorithm the RPCs forwarded to the servers of other
+	// datacenters, and the peering streams, are compressed with.
+	Compression compression.Algorithm
+
+	// CompressionThreshold is the minimum size, in bytes, of the compressed
+	// writes. Zero uses compression.DefaultThreshold.
+	CompressionThreshold int
+
+	// CrossDCCacheSize is the number of responses to the stale catalog and
+	// health reads forwarded to other datacenters that are cached. Zero
+	// disables the caching.
+	CrossDCCacheSize int
+
+	// CrossDCCacheTTL is how long a cached response is served. Zero uses
+	// defaultForwardCacheTTL.
+	CrossDCCacheTTL time.Duration
 }
 
 // RequestLimits is configuration for serverrate limiting that is a part of
@@ -694,6 +828,7 @@ type ReloadableConfig struct {
 	RaftSnapshotThreshold int
 	RaftSnapshotInterval  time.Duration
 	RaftTrailingLogs      int
+	RaftSnapshot          RaftSnapshotConfig
 	HeartbeatTimeout      time.Duration
 	ElectionTimeout       time.Duration
 	Reporting             Reporting
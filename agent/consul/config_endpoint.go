@@ -10,7 +10,6 @@ import (
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
-	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	hashstructure_v2 "github.com/mitchellh/hashstructure/v2"
@@ -84,12 +83,21 @@ func (c *ConfigEntry) Apply(args *structs.ConfigEntryRequest, reply *bool) error
 		return err
 	}
 
-	// Normalize and validate the incoming config entry as if it came from a user.
-	if err := args.Entry.Normalize(); err != nil {
+	// The entry is only sent to the admission webhooks once it is known to be
+	// allowed, and the one they return is checked again.
+	if err := preApplyConfigEntryUpsert(args.Entry, authz); err != nil {
 		return err
 	}
-	if err := args.Entry.Validate(); err != nil {
-		return err
+	if c.srv.admission != nil {
+		if err := c.srv.admission.admitConfigEntryUpsert(args); err != nil {
+			return err
+		}
+		if err := c.srv.validateEnterpriseRequest(args.Entry.GetEnterpriseMeta(), true); err != nil {
+			return err
+		}
+		if err := preApplyConfigEntryUpsert(args.Entry, authz); err != nil {
+			return err
+		}
 	}
 
 	// Log any applicable warnings about the contents of the config entry.
@@ -100,10 +108,6 @@ func (c *ConfigEntry) Apply(args *structs.ConfigEntryRequest, reply *bool) error
 		}
 	}
 
-	if err := args.Entry.CanWrite(authz); err != nil {
-		return err
-	}
-
 	if args.Op != structs.ConfigEntryUpsert && args.Op != structs.ConfigEntryUpsertCAS {
 		args.Op = structs.ConfigEntryUpsert
 	}
@@ -115,6 +119,18 @@ func (c *ConfigEntry) Apply(args *structs.ConfigEntryRequest, reply *bool) error
 		return nil
 	}
 
+	if entry, ok := args.Entry.(*structs.ServiceIntentionsConfigEntry); ok {
+		if err := c.srv.checkIntentionsQuota(entry); err != nil {
+			return err
+		}
+	}
+
+	// The secret fields of the entry are encrypted at rest with the keys of
+	// the KV encryption.
+	if args.Entry, err = c.srv.sealConfigEntry(args.Entry); err != nil {
+		return err
+	}
+
 	resp, err := c.srv.raftApply(structs.ConfigEntryRequestType, args)
 	if err != nil {
 		return err
@@ -126,6 +142,18 @@ func (c *ConfigEntry) Apply(args *structs.ConfigEntryRequest, reply *bool) error
 	return nil
 }
 
+// preApplyConfigEntryUpsert normalizes and validates the incoming config
+// entry as if it came from a user, and checks it against the given ACL policy.
+func preApplyConfigEntryUpsert(entry structs.ConfigEntry, authz acl.Authorizer) error {
+	if err := entry.Normalize(); err != nil {
+		return err
+	}
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	return structs.CanWriteConfigEntry(entry, authz)
+}
+
 // shouldSkipOperation returns true if the result of the operation has
 // already happened and is safe to skip.
 //
@@ -205,7 +233,7 @@ func (c *ConfigEntry) Get(args *structs.ConfigEntryQuery, reply *structs.ConfigE
 	}
 	lookupEntry.GetEnterpriseMeta().Merge(&args.EnterpriseMeta)
 
-	if err := lookupEntry.CanRead(authz); err != nil {
+	if err := structs.CanReadConfigEntry(lookupEntry, authz); err != nil {
 		return err
 	}
 
@@ -218,10 +246,15 @@ func (c *ConfigEntry) Get(args *structs.ConfigEntryQuery, reply *structs.ConfigE
 				return err
 			}
 
-			reply.Index, reply.Entry = index, entry
 			if entry == nil {
+				reply.Index, reply.Entry = index, nil
 				return errNotFound
 			}
+			entry, err = c.srv.openConfigEntry(entry)
+			if err != nil {
+				return err
+			}
+			reply.Index, reply.Entry = index, entry
 			return nil
 		})
 }
@@ -251,11 +284,11 @@ func (c *ConfigEntry) List(args *structs.ConfigEntryQuery, reply *structs.Indexe
 
 	// Filtering.
 	// This is only supported for certain config entries.
-	var filter *bexpr.Filter
+	var filter *compiledFilter
 	if args.Filter != "" {
 		switch args.Kind {
 		case structs.ServiceDefaults:
-			f, err := bexpr.CreateFilter(args.Filter, nil, []*structs.ServiceConfigEntry{})
+			f, err := c.srv.filterCache.Get(args.Filter, []*structs.ServiceConfigEntry{})
 			if err != nil {
 				return err
 			}
@@ -281,7 +314,7 @@ func (c *ConfigEntry) List(args *structs.ConfigEntryQuery, reply *structs.Indexe
 			// Filter the entries returned by ACL permissions.
 			filteredEntries := make([]structs.ConfigEntry, 0, len(entries))
 			for _, entry := range entries {
-				if err := entry.CanRead(authz); err != nil {
+				if err := structs.CanReadConfigEntry(entry, authz); err != nil {
 					// TODO we may wish to extract more details from this error to aid user comprehension
 					reply.QueryMeta.ResultsFilteredByACLs = true
 					continue
@@ -289,6 +322,11 @@ func (c *ConfigEntry) List(args *structs.ConfigEntryQuery, reply *structs.Indexe
 				filteredEntries = append(filteredEntries, entry)
 			}
 
+			// Hide the redacted fields before filtering, so that the filter
+			// can't be used to guess their values.
+			filteredEntries = c.srv.redactConfigEntries(authz, filteredEntries)
+			filteredEntries = c.srv.openConfigEntries(filteredEntries)
+
 			reply.Kind = args.Kind
 			reply.Index = index
 			reply.Entries = filteredEntries
@@ -371,7 +409,7 @@ func (c *ConfigEntry) ListAll(args *structs.ConfigEntryListAllRequest, reply *st
 			// Filter the entries returned by ACL permissions or by the provided kinds.
 			filteredEntries := make([]structs.ConfigEntry, 0, len(entries))
 			for _, entry := range entries {
-				if err := entry.CanRead(authz); err != nil {
+				if err := structs.CanReadConfigEntry(entry, authz); err != nil {
 					// TODO we may wish to extract more details from this error to aid user comprehension
 					reply.QueryMeta.ResultsFilteredByACLs = true
 					continue
@@ -388,7 +426,7 @@ func (c *ConfigEntry) ListAll(args *structs.ConfigEntryListAllRequest, reply *st
 				filteredEntries = append(filteredEntries, entry)
 			}
 
-			reply.Entries = filteredEntries
+			reply.Entries = c.srv.openConfigEntries(filteredEntries)
 			reply.Index = index
 			return nil
 		})
@@ -423,7 +461,13 @@ func (c *ConfigEntry) Delete(args *structs.ConfigEntryRequest, reply *structs.Co
 		return err
 	}
 
-	if err := args.Entry.CanWrite(authz); err != nil {
+	if err := structs.CanWriteConfigEntry(args.Entry, authz); err != nil {
+		return err
+	}
+
+	// The deletion is only sent to the admission webhooks once it is known to
+	// be allowed.
+	if err := c.srv.admission.admitConfigEntryDelete(args); err != nil {
 		return err
 	}
 
@@ -274,6 +274,89 @@ operator = "write"
 	require.NoError(t, err)
 }
 
+func TestConfigEntry_Apply_ConfigEntryACLs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1", testrpc.WithToken("root"))
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	rules := `
+config_entry_prefix "service-defaults/team-a-" {
+	policy = "write"
+}
+config_entry "service-defaults/team-a-db" {
+	policy = "deny"
+}
+service_prefix "" {
+	policy = "write"
+}
+`
+	id := createTokenWithPolicyNameFull(t, codec, "services", rules, "root").SecretID
+
+	// The config_entry_prefix rule delegates the team-a service-defaults
+	// without any service permission.
+	delegated := createTokenWithPolicyNameFull(t, codec, "team-a", `
+config_entry_prefix "service-defaults/team-a-" {
+	policy = "write"
+}
+`, "root").SecretID
+	args := structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceConfigEntry{
+			Name: "team-a-web",
+		},
+		WriteRequest: structs.WriteRequest{Token: delegated},
+	}
+	out := false
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &args, &out))
+
+	// Other names are still subject to the service permissions.
+	args.Entry = &structs.ServiceConfigEntry{
+		Name: "team-b-web",
+	}
+	err := msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &args, &out)
+	require.True(t, acl.IsErrPermissionDenied(err), "err: %v", err)
+
+	// The config_entry deny rule takes precedence over the service:write
+	// permission.
+	args.Entry = &structs.ServiceConfigEntry{
+		Name: "team-a-db",
+	}
+	args.WriteRequest.Token = id
+	err = msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &args, &out)
+	require.True(t, acl.IsErrPermissionDenied(err), "err: %v", err)
+
+	args.Entry = &structs.ServiceConfigEntry{
+		Name: "team-b-web",
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &args, &out))
+
+	// Listing only returns the entries the token can read.
+	listArgs := structs.ConfigEntryQuery{
+		Kind:         structs.ServiceDefaults,
+		Datacenter:   "dc1",
+		QueryOptions: structs.QueryOptions{Token: delegated},
+	}
+	var listOut structs.IndexedConfigEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.List", &listArgs, &listOut))
+	require.Len(t, listOut.Entries, 1)
+	require.Equal(t, "team-a-web", listOut.Entries[0].GetName())
+	require.True(t, listOut.QueryMeta.ResultsFilteredByACLs)
+}
+
 func TestConfigEntry_Get(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
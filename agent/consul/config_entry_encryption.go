@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// configEntrySecretData returns the additional data of a secret field of the
+// config entry, so that its sealed value can't be moved to another entry or
+// field.
+func configEntrySecretData(entry structs.ConfigEntry, field string) []byte {
+	meta := entry.GetEnterpriseMeta()
+	return []byte(fmt.Sprintf("%s/%s/%s/%s/%s",
+		entry.GetKind(), meta.PartitionOrDefault(), meta.NamespaceOrDefault(), entry.GetName(), field))
+}
+
+// sealConfigEntry returns a copy of the entry with its secret fields encrypted
+// with the active data encryption key of the KV encryption. The entry itself is
+// returned if it has no secret fields or KV encryption is disabled.
+func (s *Server) sealConfigEntry(entry structs.ConfigEntry) (structs.ConfigEntry, error) {
+	secret, ok := entry.(structs.SecretConfigEntry)
+	if s.kvEncryption == nil || !ok {
+		return entry, nil
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return nil, err
+	}
+	if keyring == nil || keyring.ActiveKeyID == "" {
+		return nil, fmt.Errorf("KV encryption keyring has not been initialized by the leader yet")
+	}
+	key, err := s.kvEncryptionKey(keyring.ActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, fields := secret.CopySecrets()
+	for name, field := range fields {
+		if *field == "" {
+			continue
+		}
+		*field, err = kvencrypt.SealText(keyring.ActiveKeyID, key, *field, configEntrySecretData(entry, name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sealed, nil
+}
+
+// openConfigEntry returns the entry with its secret fields decrypted. The entry
+// is copied if any of them was encrypted, since it belongs to the state store.
+func (s *Server) openConfigEntry(entry structs.ConfigEntry) (structs.ConfigEntry, error) {
+	secret, ok := entry.(structs.SecretConfigEntry)
+	if !ok {
+		return entry, nil
+	}
+
+	opened, fields := secret.CopySecrets()
+	changed := false
+	for name, field := range fields {
+		keyID, ok := kvencrypt.TextKeyID(*field)
+		if !ok {
+			continue
+		}
+		key, err := s.kvEncryptionKey(keyID)
+		if err != nil {
+			return nil, err
+		}
+		*field, err = kvencrypt.OpenText(key, *field, configEntrySecretData(entry, name))
+		if err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if !changed {
+		return entry, nil
+	}
+	return opened, nil
+}
+
+// openConfigEntries decrypts the secret fields of the given entries, see
+// openConfigEntry. The entries which can't be decrypted are returned as stored,
+// so that a single entry doesn't fail the whole list.
+func (s *Server) openConfigEntries(entries []structs.ConfigEntry) []structs.ConfigEntry {
+	var opened []structs.ConfigEntry
+	for i, entry := range entries {
+		o := s.openStoredConfigEntry(entry)
+		if o != entry && opened == nil {
+			opened = make([]structs.ConfigEntry, len(entries))
+			copy(opened, entries[:i])
+		}
+		if opened != nil {
+			opened[i] = o
+		}
+	}
+	if opened == nil {
+		return entries
+	}
+	return opened
+}
+
+// openStoredConfigEntry is like openConfigEntry, but returns the entry as
+// stored if it can't be decrypted. It is used to open the entries sent to the
+// streaming subscribers.
+func (s *Server) openStoredConfigEntry(entry structs.ConfigEntry) structs.ConfigEntry {
+	opened, err := s.openConfigEntry(entry)
+	if err != nil {
+		s.logger.Warn("failed to decrypt config entry, returning it as stored",
+			"kind", entry.GetKind(),
+			"name", entry.GetName(),
+			"error", err,
+		)
+		return entry
+	}
+	return opened
+}
+
+// rewrapConfigEntryEncryption re-encrypts the secret fields of every config
+// entry which aren't sealed with the active data encryption key. It returns the
+// number of re-encrypted entries.
+func (s *Server) rewrapConfigEntryEncryption(ctx context.Context, activeKeyID string) (int, error) {
+	_, entries, err := s.fsm.State().ConfigEntries(nil, structs.WildcardEnterpriseMetaInPartition(structs.WildcardSpecifier))
+	if err != nil {
+		return 0, err
+	}
+
+	rewrapped := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return rewrapped, err
+		}
+		secret, ok := entry.(structs.SecretConfigEntry)
+		if !ok || !configEntryNeedsRewrap(secret, activeKeyID) {
+			continue
+		}
+
+		opened, err := s.openConfigEntry(entry)
+		if err != nil {
+			return rewrapped, err
+		}
+		sealed, err := s.sealConfigEntry(opened)
+		if err != nil {
+			return rewrapped, err
+		}
+		// The check-and-set fails if the entry was modified concurrently, in
+		// which case it was sealed with the active key by the concurrent write.
+		resp, err := s.raftApply(structs.ConfigEntryRequestType, &structs.ConfigEntryRequest{
+			Op:    structs.ConfigEntryUpsertCAS,
+			Entry: sealed,
+		})
+		if err != nil {
+			return rewrapped, err
+		}
+		if updated, ok := resp.(bool); ok && updated {
+			rewrapped++
+		}
+	}
+	return rewrapped, nil
+}
+
+// configEntryNeedsRewrap returns whether any of the secret fields of the entry
+// isn't sealed with the given key.
+func configEntryNeedsRewrap(entry structs.SecretConfigEntry, activeKeyID string) bool {
+	_, fields := entry.CopySecrets()
+	for _, field := range fields {
+		if *field == "" {
+			continue
+		}
+		if keyID, ok := kvencrypt.TextKeyID(*field); !ok || keyID != activeKeyID {
+			return true
+		}
+	}
+	return false
+}
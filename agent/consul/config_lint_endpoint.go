@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/agent/configentry"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+)
+
+// Lint evaluates the service mesh config entries against the rules of the
+// mesh config linter. Only the entries the token can read are evaluated.
+func (c *ConfigEntry) Lint(args *structs.DCSpecificRequest, reply *structs.ConfigEntryLintResponse) error {
+	if err := c.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	if done, err := c.srv.ForwardRPC("ConfigEntry.Lint", args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"config_entry", "lint"}, time.Now())
+
+	authz, err := c.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			entriesIndex, entries, err := state.ConfigEntries(ws, &args.EnterpriseMeta)
+			if err != nil {
+				return err
+			}
+			servicesIndex, services, err := state.ServiceList(ws, args.EnterpriseMeta.WithWildcardNamespace(), "")
+			if err != nil {
+				return err
+			}
+
+			filteredEntries := make([]structs.ConfigEntry, 0, len(entries))
+			for _, entry := range entries {
+				if err := structs.CanReadConfigEntry(entry, authz); err != nil {
+					reply.QueryMeta.ResultsFilteredByACLs = true
+					continue
+				}
+				filteredEntries = append(filteredEntries, entry)
+			}
+
+			reply.Findings = configentry.Lint(filteredEntries, services)
+			reply.Index = lib.MaxUint64(entriesIndex, servicesIndex)
+			return nil
+		})
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"os"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestConfigEntry_Lint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1", testrpc.WithToken("root"))
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	state := s1.fsm.State()
+	require.NoError(t, state.EnsureRegistration(1, &structs.RegisterRequest{
+		Node:    "node1",
+		Address: "10.0.0.1",
+		Service: &structs.NodeService{Service: "db"},
+	}))
+	require.NoError(t, state.EnsureConfigEntry(2, &structs.ServiceResolverConfigEntry{
+		Kind: structs.ServiceResolver,
+		Name: "web",
+		Failover: map[string]structs.ServiceResolverFailover{
+			"*": {Service: "db"},
+		},
+	}))
+	require.NoError(t, state.EnsureConfigEntry(3, &structs.ServiceResolverConfigEntry{
+		Kind: structs.ServiceResolver,
+		Name: "api",
+		Failover: map[string]structs.ServiceResolverFailover{
+			"*": {Service: "ghost"},
+		},
+	}))
+
+	lint := func(t *testing.T, token string) []structs.ConfigEntryLintFinding {
+		args := structs.DCSpecificRequest{
+			Datacenter:   "dc1",
+			QueryOptions: structs.QueryOptions{Token: token},
+		}
+		var out structs.ConfigEntryLintResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Lint", &args, &out))
+		return out.Findings
+	}
+
+	t.Run("management token", func(t *testing.T) {
+		findings := lint(t, "root")
+		require.Len(t, findings, 1)
+		require.Equal(t, structs.LintRuleMissingFailoverService, findings[0].Rule)
+		require.Equal(t, "api", findings[0].Name)
+	})
+
+	t.Run("entries filtered by ACLs", func(t *testing.T) {
+		id := createToken(t, codec, `service "web" { policy = "read" }`)
+		require.Empty(t, lint(t, id))
+	})
+}
@@ -78,6 +78,16 @@ func (s *Server) reconcileLocalConfig(ctx context.Context, configs []structs.Con
 		if entry.GetKind() == structs.ExportedServices {
 			continue
 		}
+		if op == structs.ConfigEntryUpsert || op == structs.ConfigEntryUpsertCAS {
+			// The primary datacenter returns the secret fields decrypted,
+			// they are encrypted again with the keys of this datacenter.
+			sealed, err := s.sealConfigEntry(entry)
+			if err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("Failed to encrypt config entry: %w", err))
+				continue
+			}
+			entry = sealed
+		}
 		req := structs.ConfigEntryRequest{
 			Op:         op,
 			Datacenter: s.config.Datacenter,
@@ -211,3 +211,145 @@ func (s *ConnectCA) SignIntermediate(
 
 	return nil
 }
+
+// Revoke revokes a certificate issued by the Connect CA in this datacenter.
+func (s *ConnectCA) Revoke(
+	args *structs.CARevokeRequest,
+	reply *interface{}) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.Revoke", args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorWriteAllowed(nil); err != nil {
+		return err
+	}
+
+	return s.srv.caManager.RevokeCertificate(args)
+}
+
+// RevokedCerts returns the certificates revoked in this datacenter.
+func (s *ConnectCA) RevokedCerts(
+	args *structs.DCSpecificRequest,
+	reply *structs.IndexedCARevokedCerts) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.RevokedCerts", args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, certs, err := state.CARevokedCerts(ws)
+			if err != nil {
+				return err
+			}
+
+			reply.Index, reply.RevokedCerts = index, certs
+			if reply.RevokedCerts == nil {
+				reply.RevokedCerts = make(structs.CARevokedCerts, 0)
+			}
+			return nil
+		},
+	)
+}
+
+// RevocationList returns the certificate revocation list of this datacenter.
+// Like the roots, it is public.
+func (s *ConnectCA) RevocationList(
+	args *structs.DCSpecificRequest,
+	reply *structs.CARevocationList) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader holds the CA provider that signs the list.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.RevocationList", args, reply); done {
+		return err
+	}
+
+	crl, err := s.srv.caManager.RevocationList()
+	if err != nil {
+		return err
+	}
+	*reply = *crl
+	return nil
+}
+
+// OCSP answers an OCSP request for a certificate issued in this datacenter.
+// Like the roots, it is public.
+func (s *ConnectCA) OCSP(
+	args *structs.CAOCSPRequest,
+	reply *structs.CAOCSPResponse) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader holds the CA provider that signs the responses.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.OCSP", args, reply); done {
+		return err
+	}
+
+	resp, err := s.srv.caManager.OCSPResponse(args.Request)
+	if err != nil {
+		return err
+	}
+	reply.Response = resp
+	return nil
+}
+
+// Inventory summarizes the leaf certificates signed in this datacenter since
+// its leader was elected, for each service.
+func (s *ConnectCA) Inventory(
+	args *structs.DCSpecificRequest,
+	reply *structs.CALeafInventory) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader signs the certificates.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.Inventory", args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	reply.Since, reply.Identities = s.srv.caManager.leafInventory.summary(s.srv.caManager.timeNow())
+	s.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
+	return nil
+}
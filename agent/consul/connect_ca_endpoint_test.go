@@ -15,6 +15,7 @@ import (
 	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/connect"
@@ -821,6 +822,98 @@ func TestConnectCAConfig_UpdateSecondary(t *testing.T) {
 }
 
 // Test CA signing
+func TestConnectCAInventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	sign := func(spiffeID connect.CertURI) error {
+		csr, _ := connect.TestCSR(t, spiffeID)
+		args := &structs.CASignRequest{
+			Datacenter: "dc1",
+			CSR:        csr,
+		}
+		var reply structs.IssuedCert
+		return msgpackrpc.CallWithCodec(codec, "ConnectCA.Sign", args, &reply)
+	}
+	require.NoError(t, sign(connect.TestSpiffeIDService(t, "web")))
+	require.NoError(t, sign(connect.TestSpiffeIDService(t, "web")))
+	require.NoError(t, sign(connect.TestSpiffeIDService(t, "api")))
+	require.Error(t, sign(connect.TestSpiffeIDServiceWithHost(t, "db", "other.consul")))
+
+	args := &structs.DCSpecificRequest{Datacenter: "dc1"}
+	var reply structs.CALeafInventory
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Inventory", args, &reply))
+	require.False(t, reply.Since.IsZero())
+	require.Len(t, reply.Identities, 3)
+
+	// The service which failed to get a certificate comes first.
+	db := reply.Identities[0]
+	require.Equal(t, "db", db.Name)
+	require.Equal(t, 0, db.ActiveCerts)
+	require.Equal(t, uint64(1), db.SignErrors)
+	require.Contains(t, db.LastSignError, "different trust domain")
+
+	web, api := reply.Identities[1], reply.Identities[2]
+	if web.Name != "web" {
+		web, api = api, web
+	}
+	require.Equal(t, "service", web.Kind)
+	require.Equal(t, "web", web.Name)
+	require.Equal(t, 2, web.ActiveCerts)
+	require.Equal(t, uint64(2), web.Signed)
+	require.Equal(t, 2, web.SignedLastHour)
+	require.Zero(t, web.SignErrors)
+	require.False(t, web.LatestExpiry.Before(web.EarliestExpiry))
+
+	require.Equal(t, "api", api.Name)
+	require.Equal(t, 1, api.ActiveCerts)
+	require.Equal(t, api.EarliestExpiry, api.LatestExpiry)
+}
+
+func TestConnectCAInventory_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = TestDefaultInitialManagementToken
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	args := &structs.DCSpecificRequest{Datacenter: "dc1"}
+	var reply structs.CALeafInventory
+	err := msgpackrpc.CallWithCodec(codec, "ConnectCA.Inventory", args, &reply)
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+
+	opReadToken, err := upsertTestTokenWithPolicyRules(
+		codec, TestDefaultInitialManagementToken, "dc1", `operator = "read"`)
+	require.NoError(t, err)
+
+	args.Token = opReadToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Inventory", args, &reply))
+}
+
 func TestConnectCASign(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -896,6 +989,161 @@ func TestConnectCASign(t *testing.T) {
 	}
 }
 
+func TestConnectCARevoke(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	sign := func(service string) *x509.Certificate {
+		csr, _ := connect.TestCSR(t, connect.TestSpiffeIDService(t, service))
+		args := &structs.CASignRequest{Datacenter: "dc1", CSR: csr}
+		var reply structs.IssuedCert
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Sign", args, &reply))
+		return testParseCert(t, reply.CertPEM)
+	}
+	web := sign("web")
+	api := sign("api")
+	db := sign("db")
+
+	// Revoke web with its certificate and api with its serial number.
+	var out interface{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Revoke", &structs.CARevokeRequest{
+		Datacenter:  "dc1",
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: web.Raw})),
+		Reason:      ocsp.KeyCompromise,
+	}, &out))
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Revoke", &structs.CARevokeRequest{
+		Datacenter:   "dc1",
+		SerialNumber: connect.EncodeSerialNumber(api.SerialNumber),
+	}, &out))
+
+	err := msgpackrpc.CallWithCodec(codec, "ConnectCA.Revoke", &structs.CARevokeRequest{
+		Datacenter:   "dc1",
+		SerialNumber: connect.EncodeSerialNumber(db.SerialNumber),
+		Reason:       8,
+	}, &out)
+	testutil.RequireErrorContains(t, err, "invalid revocation reason 8")
+
+	var revoked structs.IndexedCARevokedCerts
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.RevokedCerts", &structs.DCSpecificRequest{Datacenter: "dc1"}, &revoked))
+	require.Len(t, revoked.RevokedCerts, 2)
+	revokedSerials := []string{
+		connect.EncodeSerialNumber(web.SerialNumber),
+		connect.EncodeSerialNumber(api.SerialNumber),
+	}
+	for _, r := range revoked.RevokedCerts {
+		require.Contains(t, revokedSerials, r.SerialNumber)
+		if r.SerialNumber == revokedSerials[0] {
+			require.Equal(t, ocsp.KeyCompromise, r.Reason)
+			require.True(t, web.NotAfter.Equal(r.NotAfter))
+			require.NotEmpty(t, r.SigningKeyID)
+		}
+	}
+
+	// The revocations are distributed along with the roots.
+	var roots structs.IndexedCARoots
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Roots", &structs.DCSpecificRequest{Datacenter: "dc1"}, &roots))
+	require.ElementsMatch(t, revokedSerials, roots.RevokedSerialNumbers)
+	require.GreaterOrEqual(t, roots.Index, revoked.Index)
+	issuer := testParseCert(t, roots.Active().RootCert)
+
+	// The leader distributes the CRL along with the roots too, so that the
+	// proxies check the leaf certs of their peers against it.
+	retry.Run(t, func(r *retry.R) {
+		var roots structs.IndexedCARoots
+		require.NoError(r, msgpackrpc.CallWithCodec(codec, "ConnectCA.Roots", &structs.DCSpecificRequest{Datacenter: "dc1"}, &roots))
+		block, rest := pem.Decode([]byte(roots.RevocationList))
+		require.NotNil(r, block)
+		require.Empty(r, rest)
+		list, err := x509.ParseRevocationList(block.Bytes)
+		require.NoError(r, err)
+		require.NoError(r, list.CheckSignatureFrom(issuer))
+		require.Len(r, list.RevokedCertificateEntries, 2)
+	})
+
+	// The CRL lists both certificates and is signed by the CA.
+	var crl structs.CARevocationList
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.RevocationList", &structs.DCSpecificRequest{Datacenter: "dc1"}, &crl))
+	list, err := x509.ParseRevocationList(crl.CRL)
+	require.NoError(t, err)
+	require.NoError(t, list.CheckSignatureFrom(issuer))
+	require.Equal(t, revoked.Index, list.Number.Uint64())
+	require.Len(t, list.RevokedCertificateEntries, 2)
+	for _, entry := range list.RevokedCertificateEntries {
+		require.Contains(t, revokedSerials, connect.EncodeSerialNumber(entry.SerialNumber))
+	}
+
+	// The OCSP responder reports the status of each certificate.
+	ocspStatus := func(cert *x509.Certificate) *ocsp.Response {
+		req, err := ocsp.CreateRequest(cert, issuer, nil)
+		require.NoError(t, err)
+		var reply structs.CAOCSPResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.OCSP", &structs.CAOCSPRequest{Datacenter: "dc1", Request: req}, &reply))
+		resp, err := ocsp.ParseResponseForCert(reply.Response, cert, issuer)
+		require.NoError(t, err)
+		return resp
+	}
+	resp := ocspStatus(web)
+	require.Equal(t, ocsp.Revoked, resp.Status)
+	require.Equal(t, ocsp.KeyCompromise, resp.RevocationReason)
+	require.Equal(t, ocsp.Revoked, ocspStatus(api).Status)
+	require.Equal(t, ocsp.Good, ocspStatus(db).Status)
+
+	// Certificates from another CA are not answered for.
+	other := testParseCert(t, connect.TestCA(t, nil).RootCert)
+	req, err := ocsp.CreateRequest(web, other, nil)
+	require.NoError(t, err)
+	var reply structs.CAOCSPResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.OCSP", &structs.CAOCSPRequest{Datacenter: "dc1", Request: req}, &reply))
+	require.Equal(t, ocsp.UnauthorizedErrorResponse, reply.Response)
+}
+
+func TestConnectCARevoke_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = TestDefaultInitialManagementToken
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	args := &structs.CARevokeRequest{
+		Datacenter:   "dc1",
+		SerialNumber: "01:02",
+	}
+	var out interface{}
+	err := msgpackrpc.CallWithCodec(codec, "ConnectCA.Revoke", args, &out)
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+
+	var revoked structs.IndexedCARevokedCerts
+	err = msgpackrpc.CallWithCodec(codec, "ConnectCA.RevokedCerts", &structs.DCSpecificRequest{Datacenter: "dc1"}, &revoked)
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+
+	args.Token = TestDefaultInitialManagementToken
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Revoke", args, &out))
+}
+
 // Bench how long Signing RPC takes. This was used to ballpark reasonable
 // default rate limit to protect servers from thundering herds of signing
 // requests on root rotation.
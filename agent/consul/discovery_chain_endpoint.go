@@ -52,6 +52,30 @@ func (c *DiscoveryChain) Get(args *structs.DiscoveryChainRequest, reply *structs
 		evalDC = c.srv.config.Datacenter
 	}
 
+	req := discoverychain.CompileRequest{
+		ServiceName:            args.Name,
+		EvaluateInNamespace:    entMeta.NamespaceOrDefault(),
+		EvaluateInPartition:    entMeta.PartitionOrDefault(),
+		EvaluateInDatacenter:   evalDC,
+		OverrideMeshGateway:    args.OverrideMeshGateway,
+		OverrideProtocol:       args.OverrideProtocol,
+		OverrideConnectTimeout: args.OverrideConnectTimeout,
+		Explain:                args.Explain,
+	}
+
+	// Failover targets in other datacenters that are ordered by health need
+	// an RPC to that datacenter, which must not be made while blocking. Fetch
+	// their health up front and bound the wait so that the caller comes back
+	// for fresh data.
+	var remoteHealth map[string]remoteFailoverHealth
+	if _, chain, _, err := c.srv.fsm.State().ServiceDiscoveryChain(nil, args.Name, entMeta, req); err != nil {
+		return err
+	} else if remoteHealth = c.fetchRemoteFailoverHealth(chain, authz, args.Token); len(remoteHealth) > 0 {
+		if args.MaxQueryTime <= 0 || args.MaxQueryTime > failoverHealthRecomputeInterval {
+			args.MaxQueryTime = failoverHealthRecomputeInterval
+		}
+	}
+
 	var (
 		priorHash uint64
 		ranOnce   bool
@@ -60,20 +84,18 @@ func (c *DiscoveryChain) Get(args *structs.DiscoveryChainRequest, reply *structs
 		&args.QueryOptions,
 		&reply.QueryMeta,
 		func(ws memdb.WatchSet, state *state.Store) error {
-			req := discoverychain.CompileRequest{
-				ServiceName:            args.Name,
-				EvaluateInNamespace:    entMeta.NamespaceOrDefault(),
-				EvaluateInPartition:    entMeta.PartitionOrDefault(),
-				EvaluateInDatacenter:   evalDC,
-				OverrideMeshGateway:    args.OverrideMeshGateway,
-				OverrideProtocol:       args.OverrideProtocol,
-				OverrideConnectTimeout: args.OverrideConnectTimeout,
-			}
 			index, chain, entries, err := state.ServiceDiscoveryChain(ws, args.Name, entMeta, req)
 			if err != nil {
 				return err
 			}
 
+			// Failover targets ordered by health can change order without
+			// any config entry changing, so account for the health data
+			// read in the index.
+			if healthIndex := c.orderFailoverByHealth(ws, state, chain, remoteHealth, authz); healthIndex > index {
+				index = healthIndex
+			}
+
 			// Generate a hash of the config entry content driving this
 			// response. Use it to determine if the response is identical to a
 			// prior wakeup.
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/testrpc"
 )
@@ -348,3 +349,188 @@ func TestDiscoveryChainEndpoint_Get_BlockOnNoChange(t *testing.T) {
 		run(t, "completely-different-other")
 	})
 }
+
+func TestDiscoveryChainEndpoint_Get_FailoverOrderByHealth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.DevMode = true // keep it in ram to make it 10x faster on macos
+		c.PrimaryDatacenter = "dc1"
+	})
+
+	codec := rpcClient(t, s1)
+
+	waitForLeaderEstablishment(t, s1)
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	register := func(t *testing.T, node, service, status string) {
+		t.Helper()
+		var out struct{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Catalog.Register", &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      service,
+				Service: service,
+				Port:    8080,
+			},
+			Check: &structs.HealthCheck{
+				Name:      service + "-check",
+				Status:    status,
+				ServiceID: service,
+			},
+		}, &out))
+	}
+	register(t, "node1", "db-b", api.HealthPassing)
+	register(t, "node2", "db-b", api.HealthCritical)
+	register(t, "node1", "db-c", api.HealthPassing)
+
+	var out bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceResolverConfigEntry{
+			Kind: structs.ServiceResolver,
+			Name: "db",
+			Failover: map[string]structs.ServiceResolverFailover{
+				"*": {
+					Targets: []structs.ServiceResolverFailoverTarget{
+						{Service: "db-b"},
+						{Service: "db-c"},
+					},
+					Policy: &structs.ServiceResolverFailoverPolicy{Mode: "order-by-health"},
+				},
+			},
+		},
+	}, &out))
+	require.True(t, out)
+
+	args := structs.DiscoveryChainRequest{
+		Name:                 "db",
+		EvaluateInDatacenter: "dc1",
+		Datacenter:           "dc1",
+	}
+	var resp structs.DiscoveryChainResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "DiscoveryChain.Get", &args, &resp))
+
+	failover := resp.Chain.Nodes["resolver:db.default.default.dc1"].Resolver.Failover
+	require.Equal(t, []string{"db-c.default.default.dc1", "db-b.default.default.dc1"}, failover.Targets)
+	require.Equal(t, []structs.DiscoveryFailoverTargetHealth{
+		{Target: "db-c.default.default.dc1", HealthPercent: 100},
+		{Target: "db-b.default.default.dc1", HealthPercent: 50},
+	}, failover.TargetHealth)
+
+	// Once both targets are healthy, they fall back to the configured order
+	// and blocked watchers are woken up.
+	args.MinQueryIndex = resp.Index
+	var blockingResp structs.DiscoveryChainResponse
+	errCh := channelCallRPC(s1, "DiscoveryChain.Get", &args, &blockingResp, nil)
+
+	register(t, "node2", "db-b", api.HealthPassing)
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("blocking query did not return")
+	}
+	require.Greater(t, blockingResp.Index, resp.Index)
+	failover = blockingResp.Chain.Nodes["resolver:db.default.default.dc1"].Resolver.Failover
+	require.Equal(t, []string{"db-b.default.default.dc1", "db-c.default.default.dc1"}, failover.Targets)
+	require.Equal(t, []structs.DiscoveryFailoverTargetHealth{
+		{Target: "db-b.default.default.dc1", HealthPercent: 100},
+		{Target: "db-c.default.default.dc1", HealthPercent: 100},
+	}, failover.TargetHealth)
+}
+
+func TestDiscoveryChainEndpoint_Get_FailoverOrderByHealth_RemoteDatacenter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.DevMode = true // keep it in ram to make it 10x faster on macos
+		c.PrimaryDatacenter = "dc1"
+	})
+	_, s2 := testServerWithConfig(t, func(c *Config) {
+		c.DevMode = true // keep it in ram to make it 10x faster on macos
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+	})
+	joinWAN(t, s2, s1)
+
+	codec := rpcClient(t, s1)
+
+	waitForLeaderEstablishment(t, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc2")
+
+	register := func(t *testing.T, dc, node, status string) {
+		t.Helper()
+		var out struct{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Catalog.Register", &structs.RegisterRequest{
+			Datacenter: dc,
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      "db",
+				Service: "db",
+				Port:    8080,
+			},
+			Check: &structs.HealthCheck{
+				Name:      "db-check",
+				Status:    status,
+				ServiceID: "db",
+			},
+		}, &out))
+	}
+	register(t, "dc2", "node1", api.HealthPassing)
+	register(t, "dc2", "node2", api.HealthCritical)
+	register(t, "dc1", "node1", api.HealthPassing)
+
+	var out bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceResolverConfigEntry{
+			Kind: structs.ServiceResolver,
+			Name: "web",
+			Failover: map[string]structs.ServiceResolverFailover{
+				"*": {
+					Targets: []structs.ServiceResolverFailoverTarget{
+						{Service: "db", Datacenter: "dc2"},
+						{Service: "db"},
+					},
+					Policy: &structs.ServiceResolverFailoverPolicy{Mode: "order-by-health"},
+				},
+			},
+		},
+	}, &out))
+	require.True(t, out)
+
+	args := structs.DiscoveryChainRequest{
+		Name:                 "web",
+		EvaluateInDatacenter: "dc1",
+		Datacenter:           "dc1",
+	}
+	var resp structs.DiscoveryChainResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "DiscoveryChain.Get", &args, &resp))
+
+	failover := resp.Chain.Nodes["resolver:web.default.default.dc1"].Resolver.Failover
+	require.Equal(t, []string{"db.default.default.dc1", "db.default.default.dc2"}, failover.Targets)
+	require.Equal(t, 100, failover.TargetHealth[0].HealthPercent)
+	require.Equal(t, 50, failover.TargetHealth[1].HealthPercent)
+
+	// Nothing changed, so a blocking query times out with the same index
+	// rather than one moved past the index it waited on.
+	args.MinQueryIndex = resp.Index
+	args.MaxQueryTime = 100 * time.Millisecond
+	var blockingResp structs.DiscoveryChainResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "DiscoveryChain.Get", &args, &blockingResp))
+	require.Equal(t, resp.Index, blockingResp.Index)
+	require.Equal(t, failover.Targets, blockingResp.Chain.Nodes["resolver:web.default.default.dc1"].Resolver.Failover.Targets)
+}
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// failoverHealthRecomputeInterval is the longest a blocking query for a
+// discovery chain waits when one of its "order-by-health" failover targets is
+// in another datacenter. Health changes in the local datacenter and of peered
+// services wake it up immediately, but the health of other datacenters is
+// fetched once per query, so the caller must query again to pick up changes.
+var failoverHealthRecomputeInterval = 30 * time.Second
+
+// failoverTargetScore is the health of a failover target along with the RTT
+// used for sorting, which is positive infinity when it is not known.
+type failoverTargetScore struct {
+	health structs.DiscoveryFailoverTargetHealth
+	rtt    float64
+}
+
+// remoteFailoverHealth is the health of a failover target in another
+// datacenter, fetched before the blocking query starts along with the index of
+// the remote reply.
+type remoteFailoverHealth struct {
+	index uint64
+	nodes structs.CheckServiceNodes
+	err   error
+}
+
+// orderByHealthFailovers returns the failovers of the resolvers in the chain
+// whose failover policy mode is "order-by-health".
+func orderByHealthFailovers(chain *structs.CompiledDiscoveryChain) []*structs.DiscoveryFailover {
+	var failovers []*structs.DiscoveryFailover
+	for _, node := range chain.Nodes {
+		if !node.IsResolver() || node.Resolver.Failover == nil {
+			continue
+		}
+		failover := node.Resolver.Failover
+		if failover.Policy == nil || failover.Policy.Mode != "order-by-health" {
+			continue
+		}
+		failovers = append(failovers, failover)
+	}
+	return failovers
+}
+
+// fetchRemoteFailoverHealth looks up the health of the "order-by-health"
+// failover targets of the chain that are in other datacenters, keyed by target
+// ID. It makes RPCs to those datacenters, so it must not be called from within
+// a blocking query. Targets the token cannot read are skipped.
+func (c *DiscoveryChain) fetchRemoteFailoverHealth(
+	chain *structs.CompiledDiscoveryChain,
+	authz acl.Authorizer,
+	token string,
+) map[string]remoteFailoverHealth {
+	var remote map[string]remoteFailoverHealth
+	for _, failover := range orderByHealthFailovers(chain) {
+		for _, id := range failover.Targets {
+			target := chain.Targets[id]
+			if target.Peer != "" || target.Datacenter == c.srv.config.Datacenter {
+				continue
+			}
+			if _, ok := remote[id]; ok || !canReadFailoverTarget(authz, target) {
+				continue
+			}
+
+			args := structs.ServiceSpecificRequest{
+				Datacenter:     target.Datacenter,
+				ServiceName:    target.Service,
+				EnterpriseMeta: *target.GetEnterpriseMetadata(),
+				QueryOptions: structs.QueryOptions{
+					Token:      token,
+					AllowStale: true,
+					Filter:     target.Subset.Filter,
+				},
+			}
+			var reply structs.IndexedCheckServiceNodes
+			err := c.srv.forwardDC("Health.ServiceNodes", target.Datacenter, &args, &reply)
+			if remote == nil {
+				remote = make(map[string]remoteFailoverHealth)
+			}
+			remote[id] = remoteFailoverHealth{index: reply.Index, nodes: reply.Nodes, err: err}
+		}
+	}
+	return remote
+}
+
+// orderFailoverByHealth sorts the failover targets of each resolver in the
+// chain whose failover policy mode is "order-by-health". Targets with a higher
+// percentage of passing instances are tried first, followed by those with a
+// lower RTT. Targets that score the same keep their configured order.
+//
+// The health of targets in other datacenters is taken from remote, as returned
+// by fetchRemoteFailoverHealth. It returns the highest index of the local
+// health data that was read and of the remote replies that were used.
+func (c *DiscoveryChain) orderFailoverByHealth(
+	ws memdb.WatchSet,
+	state *state.Store,
+	chain *structs.CompiledDiscoveryChain,
+	remote map[string]remoteFailoverHealth,
+	authz acl.Authorizer,
+) uint64 {
+	var (
+		index     uint64
+		distances map[string]float64
+	)
+	for _, failover := range orderByHealthFailovers(chain) {
+		if distances == nil {
+			var err error
+			distances, err = c.srv.router.GetDatacenterDistances()
+			if err != nil {
+				c.srv.logger.Warn("failed to compute datacenter distances for failover", "error", err)
+				distances = make(map[string]float64)
+			}
+		}
+
+		scores := make(map[string]failoverTargetScore, len(failover.Targets))
+		for _, id := range failover.Targets {
+			score, idx := c.scoreFailoverTarget(ws, state, chain.Targets[id], remote, distances, authz)
+			scores[id] = score
+			if idx > index {
+				index = idx
+			}
+		}
+
+		sort.SliceStable(failover.Targets, func(i, j int) bool {
+			a, b := scores[failover.Targets[i]], scores[failover.Targets[j]]
+			if a.health.HealthPercent != b.health.HealthPercent {
+				return a.health.HealthPercent > b.health.HealthPercent
+			}
+			return a.rtt < b.rtt
+		})
+
+		failover.TargetHealth = make([]structs.DiscoveryFailoverTargetHealth, 0, len(failover.Targets))
+		for _, id := range failover.Targets {
+			failover.TargetHealth = append(failover.TargetHealth, scores[id].health)
+		}
+	}
+	return index
+}
+
+// scoreFailoverTarget looks up the health of a failover target and the RTT to
+// its datacenter, along with the index of the health data that was read.
+// Targets the token cannot read are left with no score so that the chain does
+// not disclose their health.
+func (c *DiscoveryChain) scoreFailoverTarget(
+	ws memdb.WatchSet,
+	state *state.Store,
+	target *structs.DiscoveryTarget,
+	remote map[string]remoteFailoverHealth,
+	distances map[string]float64,
+	authz acl.Authorizer,
+) (failoverTargetScore, uint64) {
+	score := failoverTargetScore{
+		health: structs.DiscoveryFailoverTargetHealth{Target: target.ID},
+		rtt:    math.Inf(1),
+	}
+
+	if !canReadFailoverTarget(authz, target) {
+		return score, 0
+	}
+
+	var (
+		index uint64
+		nodes structs.CheckServiceNodes
+		err   error
+	)
+	if target.Peer != "" || target.Datacenter == c.srv.config.Datacenter {
+		index, nodes, err = failoverTargetNodes(ws, state, target)
+	} else if health, ok := remote[target.ID]; ok {
+		index, nodes, err = health.index, health.nodes, health.err
+	} else {
+		// The target was added to the chain after the remote health was
+		// fetched. The change to the chain wakes up the caller, which picks
+		// up its health on the next query.
+		err = fmt.Errorf("health of datacenter %q was not fetched", target.Datacenter)
+	}
+	if err != nil {
+		c.srv.logger.Warn("failed to look up the health of failover target",
+			"target", target.ID,
+			"error", err,
+		)
+	} else if len(nodes) > 0 {
+		passing := nodes.ShallowClone().Filter(structs.CheckServiceNodeFilterOptions{
+			FilterType: structs.HealthFilterIncludeOnlyPassing,
+		})
+		score.health.HealthPercent = len(passing) * 100 / len(nodes)
+	}
+
+	if target.Peer == "" {
+		if rtt, ok := distances[target.Datacenter]; ok && !math.IsInf(rtt, 1) {
+			score.rtt = rtt
+			score.health.RTT = time.Duration(rtt * float64(time.Second))
+		}
+	}
+	return score, index
+}
+
+// canReadFailoverTarget reports whether the token can read the service of a
+// failover target.
+func canReadFailoverTarget(authz acl.Authorizer, target *structs.DiscoveryTarget) bool {
+	authzContext := acl.AuthorizerContext{Peer: target.Peer}
+	target.GetEnterpriseMetadata().FillAuthzContext(&authzContext)
+	return authz.ServiceRead(target.Service, &authzContext) == acl.Allow
+}
+
+// failoverTargetNodes returns the instances of a failover target in the local
+// datacenter or imported from its peer, narrowed by the target's subset filter.
+func failoverTargetNodes(ws memdb.WatchSet, state *state.Store, target *structs.DiscoveryTarget) (uint64, structs.CheckServiceNodes, error) {
+	index, nodes, err := state.CheckServiceNodes(ws, target.Service, target.GetEnterpriseMetadata(), target.Peer)
+	if err != nil || target.Subset.Filter == "" {
+		return index, nodes, err
+	}
+
+	filter, err := bexpr.CreateFilter(target.Subset.Filter, nil, nodes)
+	if err != nil {
+		return 0, nil, err
+	}
+	raw, err := filter.Execute(nodes)
+	if err != nil {
+		return 0, nil, err
+	}
+	return index, raw.(structs.CheckServiceNodes), nil
+}
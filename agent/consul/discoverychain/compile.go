@@ -41,6 +41,10 @@ type CompileRequest struct {
 	// overridden for any resolver in the compiled chain.
 	OverrideConnectTimeout time.Duration
 
+	// Explain annotates the compiled chain with the config entries and
+	// defaults that produced it.
+	Explain bool
+
 	Entries *configentry.DiscoveryChainSet
 
 	// AutoVirtualIPs and ManualVirtualIPs are lists of IPs associated with
@@ -117,6 +121,10 @@ func Compile(req CompileRequest) (*structs.CompiledDiscoveryChain, error) {
 		c.disableAdvancedRoutingFeatures = !enableAdvancedRoutingForProtocol(req.OverrideProtocol)
 	}
 
+	if req.Explain {
+		c.explanation = &structs.DiscoveryChainExplanation{}
+	}
+
 	// Clone this resolver map to avoid mutating the input map during compilation.
 	if len(entries.Resolvers) > 0 {
 		for k, v := range entries.Resolvers {
@@ -201,6 +209,11 @@ type compiler struct {
 	// This is an OUTPUT field.
 	loadedTargets   map[string]*structs.DiscoveryTarget
 	retainedTargets map[string]struct{}
+
+	// explanation is only set when an explanation was requested.
+	//
+	// This is an OUTPUT field.
+	explanation *structs.DiscoveryChainExplanation
 }
 
 type customizationMarkers struct {
@@ -239,13 +252,21 @@ func (c *compiler) recordNode(node *structs.DiscoveryGraphNode) {
 
 func (c *compiler) recordServiceProtocol(sid structs.ServiceID) error {
 	if serviceDefault := c.entries.GetService(sid); serviceDefault != nil {
+		if serviceDefault.Protocol == "" {
+			c.explainChain("service %q uses protocol \"tcp\" because %s does not set a protocol", sid.ID, describeEntry(serviceDefault))
+		} else {
+			c.explainChain("service %q uses protocol %q from %s", sid.ID, serviceDefault.Protocol, describeEntry(serviceDefault))
+		}
 		return c.recordProtocol(sid, serviceDefault.Protocol)
 	}
-	if proxyDefault := c.entries.GetProxyDefaults(sid.PartitionOrDefault()); proxyDefault != nil {
+	if proxyDefault := c.entries.GetProxyDefaults(&sid.EnterpriseMeta); proxyDefault != nil {
 		if proxyDefault.Protocol != "" {
+			source := c.proxyDefaultsSource(&sid.EnterpriseMeta, func(e *structs.ProxyConfigEntry) bool { return e.Protocol != "" })
+			c.explainChain("service %q uses protocol %q from %s", sid.ID, proxyDefault.Protocol, describeEntry(source))
 			return c.recordProtocol(sid, proxyDefault.Protocol)
 		}
 	}
+	c.explainChain("service %q uses protocol \"tcp\" because no service-defaults or proxy-defaults sets a protocol", sid.ID)
 	return c.recordProtocol(sid, "")
 }
 
@@ -312,11 +333,14 @@ func (c *compiler) compile() (*structs.CompiledDiscoveryChain, error) {
 
 	if c.overrideProtocol != "" {
 		if c.overrideProtocol != c.protocol {
+			c.explainChain("protocol %q is overridden by the request to %q", c.protocol, c.overrideProtocol)
 			c.protocol = c.overrideProtocol
 			c.customizedBy.Protocol = true
 		}
 	}
 
+	c.pruneExplanation()
+
 	var customizationHash string
 	if !c.customizedBy.IsZero() {
 		var customization struct {
@@ -356,6 +380,7 @@ func (c *compiler) compile() (*structs.CompiledDiscoveryChain, error) {
 		Targets:           c.loadedTargets,
 		AutoVirtualIPs:    c.autoVirtualIPs,
 		ManualVirtualIPs:  c.manualVirtualIPs,
+		Explanation:       c.explanation,
 	}, nil
 }
 
@@ -462,6 +487,7 @@ func (c *compiler) flattenAdjacentSplitterNodes() error {
 				}
 
 				changed = true
+				c.explainNode(node.MapKey(), nil, fmt.Sprintf("splits to %s are merged into this splitter with their weights scaled by %.2f%%", nextNode.Name, split.Weight))
 
 				for _, innerSplit := range nextNode.Splits {
 					effectiveWeight := split.Weight * innerSplit.Weight / 100
@@ -570,7 +596,8 @@ func (c *compiler) assembleChain() error {
 	sid := structs.NewServiceID(c.serviceName, c.GetEnterpriseMeta())
 
 	// Extract extensions from proxy defaults.
-	proxyDefaults := c.entries.GetProxyDefaults(c.GetEnterpriseMeta().PartitionOrDefault())
+	c.explainProxyDefaultsLayers(c.GetEnterpriseMeta())
+	proxyDefaults := c.entries.GetProxyDefaults(c.GetEnterpriseMeta())
 	if proxyDefaults != nil {
 		c.envoyExtensions = proxyDefaults.EnvoyExtensions
 	}
@@ -586,12 +613,14 @@ func (c *compiler) assembleChain() error {
 	if len(c.resolvers) == 0 && c.entries.IsChainEmpty() {
 		// Materialize defaults and cache.
 		c.resolvers[sid] = c.newDefaultServiceResolver(sid, "")
+		c.explainChain("no service-router, service-splitter or service-resolver applies to %q, so it is resolved with defaults", c.serviceName)
 	}
 
 	// The only router we consult is the one for the service name at the top of
 	// the chain.
 	router := c.entries.GetRouter(sid)
 	if router != nil && c.disableAdvancedRoutingFeatures {
+		c.explainChain("%s is ignored because the requested protocol %q does not support routing", describeEntry(router), c.overrideProtocol)
 		router = nil
 		c.customizedBy.Protocol = true
 	}
@@ -635,6 +664,7 @@ func (c *compiler) assembleChain() error {
 
 		dest := route.Destination
 		if dest == nil {
+			c.explainNode(routeNode.MapKey(), router, fmt.Sprintf("route %d has no destination, so it sends to %q", i, c.serviceName))
 			dest = &structs.ServiceRouteDestination{
 				Service:   c.serviceName,
 				Namespace: router.NamespaceOrDefault(),
@@ -693,6 +723,11 @@ func (c *compiler) assembleChain() error {
 	}
 	routeNode.Routes = append(routeNode.Routes, defaultRoute)
 
+	c.explainNode(routeNode.MapKey(), router,
+		fmt.Sprintf("%d route(s) from %s", len(router.Routes), describeEntry(router)),
+		fmt.Sprintf("a catch-all route for path prefix \"/\" to %q is added last for requests no route matches", router.Name),
+	)
+
 	c.startNode = routeNode.MapKey()
 	c.recordNode(routeNode)
 
@@ -815,6 +850,7 @@ func (c *compiler) getSplitterNode(sid structs.ServiceID) (*structs.DiscoveryGra
 	// Fetch the config entry.
 	splitter := c.entries.GetSplitter(sid)
 	if splitter != nil && c.disableAdvancedRoutingFeatures {
+		c.explainChain("%s is ignored because the requested protocol %q does not support splitting", describeEntry(splitter), c.overrideProtocol)
 		splitter = nil
 		c.customizedBy.Protocol = true
 	}
@@ -832,6 +868,7 @@ func (c *compiler) getSplitterNode(sid structs.ServiceID) (*structs.DiscoveryGra
 	// If we record this exists before recursing down it will short-circuit
 	// reasonably if there is some sort of graph loop below.
 	c.recordNode(splitNode)
+	c.explainNode(splitNode.MapKey(), splitter, fmt.Sprintf("%d split(s) from %s", len(splitter.Splits), describeEntry(splitter)))
 
 	var hasLB bool
 	for i := range splitter.Splits {
@@ -902,6 +939,10 @@ func (c *compiler) getResolverNode(target *structs.DiscoveryTarget, recursedForF
 		// messages.
 		redirectHistory = make(map[string]struct{})
 		redirectOrder   []string
+
+		// reasons explains the redirects and defaults applied on the way to
+		// the final target.
+		reasons []string
 	)
 
 RESOLVE_AGAIN:
@@ -956,6 +997,7 @@ RESOLVE_AGAIN:
 			redirect.ToDiscoveryTargetOpts(),
 		)
 		if redirectedTarget.ID != target.ID {
+			reasons = append(reasons, fmt.Sprintf("redirected from %q to %q by %s", target.ID, redirectedTarget.ID, describeEntry(resolver)))
 			target = redirectedTarget
 			goto RESOLVE_AGAIN
 		}
@@ -963,6 +1005,7 @@ RESOLVE_AGAIN:
 
 	// Handle default subset.
 	if target.ServiceSubset == "" && resolver.DefaultSubset != "" {
+		reasons = append(reasons, fmt.Sprintf("default subset %q from %s", resolver.DefaultSubset, describeEntry(resolver)))
 		target = c.rewriteTarget(target, structs.DiscoveryTargetOpts{
 			ServiceSubset: resolver.DefaultSubset,
 		})
@@ -979,13 +1022,27 @@ RESOLVE_AGAIN:
 		}
 	}
 
+	// The resolver is only the source of the node when it was configured,
+	// rather than materialized from defaults.
+	var resolverEntry structs.ConfigEntry
+	if _, ok := c.entries.Resolvers[targetID]; ok {
+		resolverEntry = resolver
+		reasons = append(reasons, fmt.Sprintf("resolved by %s", describeEntry(resolver)))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("no service-resolver for %q, so the default resolver is used", target.Service))
+	}
+
 	connectTimeout := resolver.ConnectTimeout
 	if connectTimeout < 1 {
 		connectTimeout = 5 * time.Second
+		reasons = append(reasons, "connect timeout defaults to 5s")
+	} else {
+		reasons = append(reasons, fmt.Sprintf("connect timeout %s from %s", connectTimeout, describeEntry(resolver)))
 	}
 
 	if c.overrideConnectTimeout > 0 {
 		if connectTimeout != c.overrideConnectTimeout {
+			reasons = append(reasons, fmt.Sprintf("connect timeout is overridden by the request to %s", c.overrideConnectTimeout))
 			connectTimeout = c.overrideConnectTimeout
 			c.customizedBy.ConnectTimeout = true
 		}
@@ -1007,25 +1064,34 @@ RESOLVE_AGAIN:
 		LoadBalancer: resolver.LoadBalancer,
 	}
 
-	proxyDefault := c.entries.GetProxyDefaults(targetID.PartitionOrDefault())
+	proxyDefault := c.entries.GetProxyDefaults(&targetID.EnterpriseMeta)
 
 	// Only set PrioritizeByLocality for targets in the same partition.
 	if target.Partition == c.evaluateInPartition && target.Peer == "" {
 		if resolver.PrioritizeByLocality != nil {
 			target.PrioritizeByLocality = resolver.PrioritizeByLocality.ToDiscovery()
+			c.explainTarget(target.ID, resolverEntry, fmt.Sprintf("prioritize by locality mode %q from %s", resolver.PrioritizeByLocality.Mode, describeEntry(resolver)))
 		}
 
 		if target.PrioritizeByLocality == nil && proxyDefault != nil {
 			target.PrioritizeByLocality = proxyDefault.PrioritizeByLocality.ToDiscovery()
+			if target.PrioritizeByLocality != nil {
+				source := c.proxyDefaultsSource(&targetID.EnterpriseMeta, func(e *structs.ProxyConfigEntry) bool { return e.PrioritizeByLocality != nil })
+				c.explainTarget(target.ID, resolverEntry, fmt.Sprintf("prioritize by locality mode %q from %s", target.PrioritizeByLocality.Mode, describeEntry(source)))
+			}
 		}
 	}
 
 	target.Subset = resolver.Subsets[target.ServiceSubset]
+	if target.ServiceSubset != "" {
+		c.explainTarget(target.ID, resolverEntry, fmt.Sprintf("subset %q from %s selects instances with filter %q", target.ServiceSubset, describeEntry(resolver), target.Subset.Filter))
+	}
 
 	if serviceDefault := c.entries.GetService(targetID); serviceDefault != nil && serviceDefault.ExternalSNI != "" {
 		// Override the default SNI value.
 		target.SNI = serviceDefault.ExternalSNI
 		target.External = true
+		c.explainTarget(target.ID, resolverEntry, fmt.Sprintf("external SNI %q from %s makes the service external, so it bypasses mesh gateways", serviceDefault.ExternalSNI, describeEntry(serviceDefault)))
 	}
 
 	// If using external SNI the service is fundamentally external.
@@ -1064,14 +1130,25 @@ RESOLVE_AGAIN:
 		target.MeshGateway.Mode = structs.MeshGatewayModeDefault
 	} else {
 		// Default mesh gateway settings
-		if serviceDefault := c.entries.GetService(targetID); serviceDefault != nil {
+		meshGatewayReason := "mesh gateway mode is unset because no service-defaults or proxy-defaults sets one"
+		serviceDefault := c.entries.GetService(targetID)
+		if serviceDefault != nil {
 			target.MeshGateway = serviceDefault.MeshGateway
 			target.TransparentProxy.DialedDirectly = serviceDefault.TransparentProxy.DialedDirectly
+			if target.MeshGateway.Mode != structs.MeshGatewayModeDefault {
+				meshGatewayReason = fmt.Sprintf("mesh gateway mode %q from %s", target.MeshGateway.Mode, describeEntry(serviceDefault))
+			}
 		}
-		proxyDefault := c.entries.GetProxyDefaults(targetID.PartitionOrDefault())
+		proxyDefault := c.entries.GetProxyDefaults(&targetID.EnterpriseMeta)
 		if proxyDefault != nil {
 			if target.MeshGateway.Mode == structs.MeshGatewayModeDefault {
 				target.MeshGateway.Mode = proxyDefault.MeshGateway.Mode
+				if target.MeshGateway.Mode != structs.MeshGatewayModeDefault {
+					source := c.proxyDefaultsSource(&targetID.EnterpriseMeta, func(e *structs.ProxyConfigEntry) bool {
+						return e.MeshGateway.Mode != structs.MeshGatewayModeDefault
+					})
+					meshGatewayReason = fmt.Sprintf("mesh gateway mode %q from %s", target.MeshGateway.Mode, describeEntry(source))
+				}
 			}
 			if !target.TransparentProxy.DialedDirectly {
 				target.TransparentProxy.DialedDirectly = proxyDefault.TransparentProxy.DialedDirectly
@@ -1082,12 +1159,15 @@ RESOLVE_AGAIN:
 			if target.MeshGateway.Mode != c.overrideMeshGateway.Mode {
 				target.MeshGateway.Mode = c.overrideMeshGateway.Mode
 				c.customizedBy.MeshGateway = true
+				meshGatewayReason = fmt.Sprintf("mesh gateway mode is overridden by the request to %q", c.overrideMeshGateway.Mode)
 			}
 		}
+		c.explainTarget(target.ID, resolverEntry, meshGatewayReason)
 	}
 
 	// Retain this target in the final results.
 	c.retainedTargets[target.ID] = struct{}{}
+	c.explainTarget(target.ID, resolverEntry)
 
 	if recursedForFailover {
 		// If we recursed here from ourselves in a failover context, just emit
@@ -1100,6 +1180,7 @@ RESOLVE_AGAIN:
 	// If we record this exists before recursing down it will short-circuit
 	// reasonably if there is some sort of graph loop below.
 	c.recordNode(node)
+	c.explainNode(node.MapKey(), resolverEntry, reasons...)
 
 	var err error
 	// Determine which failover definitions apply.
@@ -1109,8 +1190,14 @@ RESOLVE_AGAIN:
 	if proxyDefault != nil {
 		failoverPolicy = proxyDefault.FailoverPolicy
 	}
+	failoverPolicyReason := ""
+	if failoverPolicy != nil {
+		source := c.proxyDefaultsSource(&targetID.EnterpriseMeta, func(e *structs.ProxyConfigEntry) bool { return e.FailoverPolicy != nil })
+		failoverPolicyReason = fmt.Sprintf("failover policy mode %q from %s", failoverPolicy.Mode, describeEntry(source))
+	}
 
 	if resolver.Redirect != nil && resolver.Redirect.SamenessGroup != "" {
+		c.explainNode(node.MapKey(), resolverEntry, fmt.Sprintf("fails over to the members of sameness group %q", resolver.Redirect.SamenessGroup))
 		opts := structs.MergeDiscoveryTargetOpts(resolver.ToSamenessDiscoveryTargetOpts(),
 			resolver.Redirect.ToDiscoveryTargetOpts())
 		failoverTargets, err = c.makeSamenessGroupFailover(target, opts, resolver.Redirect.SamenessGroup)
@@ -1129,11 +1216,19 @@ RESOLVE_AGAIN:
 		}
 
 		if !ok {
+			c.explainNode(node.MapKey(), resolverEntry, fmt.Sprintf("%s has no failover for subset %q or \"*\"", describeEntry(resolver), target.ServiceSubset))
 			return node, nil
 		}
 
+		failoverKey := target.ServiceSubset
+		if _, ok := f[failoverKey]; !ok {
+			failoverKey = "*"
+		}
+		c.explainNode(node.MapKey(), resolverEntry, fmt.Sprintf("failover %q from %s", failoverKey, describeEntry(resolver)))
+
 		if failover.Policy != nil {
 			failoverPolicy = failover.Policy
+			failoverPolicyReason = fmt.Sprintf("failover policy mode %q from %s", failover.Policy.Mode, describeEntry(resolver))
 		}
 
 		if len(failover.Datacenters) > 0 {
@@ -1177,6 +1272,9 @@ RESOLVE_AGAIN:
 		node.Resolver.Failover = df
 
 		df.Policy = failoverPolicy
+		if failoverPolicyReason != "" {
+			c.explainNode(node.MapKey(), resolverEntry, failoverPolicyReason)
+		}
 
 		// Take care of doing any redirects or configuration loading
 		// related to targets by cheating a bit and recursing into
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package discoverychain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// explainChain records a reason that applies to the whole chain. It is a no-op
+// unless an explanation was requested.
+func (c *compiler) explainChain(format string, args ...interface{}) {
+	if c.explanation == nil {
+		return
+	}
+	c.explanation.Chain = appendReason(c.explanation.Chain, fmt.Sprintf(format, args...))
+}
+
+// explainNode records the config entry a node was compiled from, if any, and
+// the reasons for how it was compiled.
+func (c *compiler) explainNode(key string, entry structs.ConfigEntry, reasons ...string) {
+	if c.explanation == nil {
+		return
+	}
+	if c.explanation.Nodes == nil {
+		c.explanation.Nodes = make(map[string]*structs.DiscoveryExplanation)
+	}
+	c.explanation.Nodes[key] = explain(c.explanation.Nodes[key], entry, reasons)
+}
+
+// explainTarget records the config entry a target was resolved with, if any,
+// and the reasons for how it was configured.
+func (c *compiler) explainTarget(id string, entry structs.ConfigEntry, reasons ...string) {
+	if c.explanation == nil {
+		return
+	}
+	if c.explanation.Targets == nil {
+		c.explanation.Targets = make(map[string]*structs.DiscoveryExplanation)
+	}
+	c.explanation.Targets[id] = explain(c.explanation.Targets[id], entry, reasons)
+}
+
+// pruneExplanation drops the explanations of nodes and targets that were
+// removed from the compiled chain.
+func (c *compiler) pruneExplanation() {
+	if c.explanation == nil {
+		return
+	}
+	for key := range c.explanation.Nodes {
+		if _, ok := c.nodes[key]; !ok {
+			delete(c.explanation.Nodes, key)
+		}
+	}
+	for id := range c.explanation.Targets {
+		if _, ok := c.loadedTargets[id]; !ok {
+			delete(c.explanation.Targets, id)
+		}
+	}
+}
+
+func explain(e *structs.DiscoveryExplanation, entry structs.ConfigEntry, reasons []string) *structs.DiscoveryExplanation {
+	if e == nil {
+		e = &structs.DiscoveryExplanation{}
+	}
+	if entry != nil && e.ConfigEntry == nil {
+		entMeta := entry.GetEnterpriseMeta()
+		e.ConfigEntry = &structs.DiscoveryExplanationConfigEntry{
+			Kind:      entry.GetKind(),
+			Name:      entry.GetName(),
+			Namespace: entMeta.NamespaceOrEmpty(),
+			Partition: entMeta.PartitionOrEmpty(),
+		}
+	}
+	for _, reason := range reasons {
+		e.Reasons = appendReason(e.Reasons, reason)
+	}
+	return e
+}
+
+// appendReason appends a reason unless it was already recorded, since the
+// compiler can visit the same service several times.
+func appendReason(reasons []string, reason string) []string {
+	if slices.Contains(reasons, reason) {
+		return reasons
+	}
+	return append(reasons, reason)
+}
+
+// explainProxyDefaultsLayers records the scopes of the proxy-defaults that are
+// merged for services in entMeta, when there is more than one.
+func (c *compiler) explainProxyDefaultsLayers(entMeta *acl.EnterpriseMeta) {
+	layers := c.entries.GetProxyDefaultsLayers(entMeta)
+	if c.explanation == nil || len(layers) < 2 {
+		return
+	}
+	scopes := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		scopes = append(scopes, describeEntry(layer))
+	}
+	c.explainChain("proxy-defaults are merged from %s, with later entries taking precedence", strings.Join(scopes, ", "))
+}
+
+// proxyDefaultsSource returns the most specific proxy-defaults for services in
+// entMeta that sets the field tested by isSet, which is the one the merged
+// proxy-defaults took that field from.
+func (c *compiler) proxyDefaultsSource(entMeta *acl.EnterpriseMeta, isSet func(*structs.ProxyConfigEntry) bool) *structs.ProxyConfigEntry {
+	layers := c.entries.GetProxyDefaultsLayers(entMeta)
+	for i := len(layers) - 1; i >= 0; i-- {
+		if isSet(layers[i]) {
+			return layers[i]
+		}
+	}
+	return nil
+}
+
+// describeEntry formats a config entry for use in a reason. Entries outside
+// the default namespace or partition are qualified with their scope.
+func describeEntry(entry structs.ConfigEntry) string {
+	desc := fmt.Sprintf("%s %q", entry.GetKind(), entry.GetName())
+	entMeta := entry.GetEnterpriseMeta()
+	if ns := entMeta.NamespaceOrEmpty(); ns != "" && ns != acl.DefaultNamespaceName {
+		desc += fmt.Sprintf(" in namespace %q", ns)
+	}
+	if ap := entMeta.PartitionOrEmpty(); ap != "" && ap != acl.DefaultPartitionName {
+		desc += fmt.Sprintf(" in partition %q", ap)
+	}
+	return desc
+}
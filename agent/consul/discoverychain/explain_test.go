@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package discoverychain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestCompile_Explain(t *testing.T) {
+	t.Parallel()
+
+	entries := newEntries()
+	entries.AddProxyDefaults(&structs.ProxyConfigEntry{
+		Kind:        structs.ProxyDefaults,
+		Name:        structs.ProxyConfigGlobal,
+		Protocol:    "http",
+		MeshGateway: structs.MeshGatewayConfig{Mode: structs.MeshGatewayModeLocal},
+	})
+	setServiceProtocol(entries, "main", "http")
+	entries.AddRouters(&structs.ServiceRouterConfigEntry{
+		Kind:   structs.ServiceRouter,
+		Name:   "main",
+		Routes: []structs.ServiceRoute{newSimpleRoute("api")},
+	})
+	entries.AddResolvers(&structs.ServiceResolverConfigEntry{
+		Kind:           structs.ServiceResolver,
+		Name:           "main",
+		ConnectTimeout: 10 * time.Second,
+		Failover: map[string]structs.ServiceResolverFailover{
+			"*": {Datacenters: []string{"dc2"}},
+		},
+	})
+
+	req := CompileRequest{
+		ServiceName:           "main",
+		EvaluateInNamespace:   "default",
+		EvaluateInPartition:   "default",
+		EvaluateInDatacenter:  "dc1",
+		EvaluateInTrustDomain: "trustdomain.consul",
+		Entries:               entries,
+	}
+
+	chain, err := Compile(req)
+	require.NoError(t, err)
+	require.Nil(t, chain.Explanation)
+
+	req.Explain = true
+	chain, err = Compile(req)
+	require.NoError(t, err)
+
+	router := &structs.DiscoveryExplanationConfigEntry{Kind: structs.ServiceRouter, Name: "main"}
+	resolver := &structs.DiscoveryExplanationConfigEntry{Kind: structs.ServiceResolver, Name: "main"}
+	expect := &structs.DiscoveryChainExplanation{
+		Chain: []string{
+			`service "main" uses protocol "http" from service-defaults "main"`,
+			`service "api" uses protocol "http" from proxy-defaults "global"`,
+		},
+		Nodes: map[string]*structs.DiscoveryExplanation{
+			"router:main.default.default": {
+				ConfigEntry: router,
+				Reasons: []string{
+					`1 route(s) from service-router "main"`,
+					`a catch-all route for path prefix "/" to "main" is added last for requests no route matches`,
+				},
+			},
+			"resolver:api.default.default.dc1": {
+				Reasons: []string{
+					`no service-resolver for "api", so the default resolver is used`,
+					"connect timeout defaults to 5s",
+				},
+			},
+			"resolver:main.default.default.dc1": {
+				ConfigEntry: resolver,
+				Reasons: []string{
+					`resolved by service-resolver "main"`,
+					`connect timeout 10s from service-resolver "main"`,
+					`failover "*" from service-resolver "main"`,
+				},
+			},
+		},
+		Targets: map[string]*structs.DiscoveryExplanation{
+			"api.default.default.dc1": {
+				Reasons: []string{`mesh gateway mode "local" from proxy-defaults "global"`},
+			},
+			"main.default.default.dc1": {
+				ConfigEntry: resolver,
+				Reasons:     []string{`mesh gateway mode "local" from proxy-defaults "global"`},
+			},
+			"main.default.default.dc2": {
+				ConfigEntry: resolver,
+				Reasons:     []string{`mesh gateway mode "local" from proxy-defaults "global"`},
+			},
+		},
+	}
+	require.Equal(t, expect, chain.Explanation)
+}
+
+func TestCompile_ExplainOverrides(t *testing.T) {
+	t.Parallel()
+
+	entries := newEntries()
+	setServiceProtocol(entries, "main", "http")
+	entries.AddRouters(&structs.ServiceRouterConfigEntry{
+		Kind:   structs.ServiceRouter,
+		Name:   "main",
+		Routes: []structs.ServiceRoute{newSimpleRoute("api")},
+	})
+
+	chain, err := Compile(CompileRequest{
+		ServiceName:            "main",
+		EvaluateInNamespace:    "default",
+		EvaluateInPartition:    "default",
+		EvaluateInDatacenter:   "dc1",
+		EvaluateInTrustDomain:  "trustdomain.consul",
+		OverrideProtocol:       "tcp",
+		OverrideConnectTimeout: 2 * time.Second,
+		Explain:                true,
+		Entries:                entries,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		`service-router "main" is ignored because the requested protocol "tcp" does not support routing`,
+		`service "main" uses protocol "http" from service-defaults "main"`,
+		`protocol "http" is overridden by the request to "tcp"`,
+	}, chain.Explanation.Chain)
+	require.Equal(t, []string{
+		`no service-resolver for "main", so the default resolver is used`,
+		"connect timeout defaults to 5s",
+		"connect timeout is overridden by the request to 2s",
+	}, chain.Explanation.Nodes["resolver:main.default.default.dc1"].Reasons)
+}
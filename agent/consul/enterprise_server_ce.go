@@ -48,7 +48,7 @@ func (s *Server) handleEnterpriseNativeTLSConn(alpnProto string, conn net.Conn)
 }
 
 func (s *Server) handleEnterpriseLeave() {
-	return
+	s.leaveSegments()
 }
 
 func (s *Server) establishEnterpriseLeadership(_ context.Context) error {
@@ -116,6 +116,7 @@ func (s *Server) shutdownSerfLAN() {
 	if s.serfLAN != nil {
 		s.serfLAN.Shutdown()
 	}
+	s.shutdownSegments()
 }
 
 func addEnterpriseSerfTags(_ map[string]string, _ *acl.EnterpriseMeta) {
@@ -33,6 +33,34 @@ func FilterDirEnt(authorizer acl.Authorizer, ent structs.DirEntries) structs.Dir
 	return ent[:FilterEntries(&df)]
 }
 
+type keyNameFilter struct {
+	authorizer acl.Authorizer
+	ent        structs.DirEntries
+}
+
+func (d *keyNameFilter) Len() int {
+	return len(d.ent)
+}
+func (d *keyNameFilter) Filter(i int) bool {
+	var entCtx acl.AuthorizerContext
+	d.ent[i].FillAuthzContext(&entCtx)
+
+	// The list permission exposes the names of the keys, even if reading
+	// their values is denied.
+	return d.authorizer.KeyRead(d.ent[i].Key, &entCtx) != acl.Allow &&
+		d.authorizer.KeyList(d.ent[i].Key, &entCtx) != acl.Allow
+}
+func (d *keyNameFilter) Move(dst, src, span int) {
+	copy(d.ent[dst:dst+span], d.ent[src:src+span])
+}
+
+// FilterKeyNames is used to filter a list of directory entries whose names,
+// but not values, are returned by applying an ACL policy
+func FilterKeyNames(authorizer acl.Authorizer, ent structs.DirEntries) structs.DirEntries {
+	df := keyNameFilter{authorizer: authorizer, ent: ent}
+	return ent[:FilterEntries(&df)]
+}
+
 type txnResultsFilter struct {
 	authorizer acl.Authorizer
 	results    structs.TxnResults
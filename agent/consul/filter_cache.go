@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/go-bexpr"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// filterCacheSize is the number of compiled filters kept by a filterCache.
+const filterCacheSize = 512
+
+// filterCache holds compiled bexpr filters keyed by their expression and the
+// type they were compiled for, so that clients repeatedly querying with the
+// same filter don't pay for parsing and validating it on every request.
+//
+// A compiled filter is safe for concurrent use since validation coerces all
+// of its values up front and evaluation only reads them.
+type filterCache struct {
+	filters *lru.Cache
+}
+
+type filterCacheKey struct {
+	expression string
+	dataType   reflect.Type
+}
+
+func newFilterCache() *filterCache {
+	filters, err := lru.New(filterCacheSize)
+	if err != nil {
+		// lru.New only fails for a non-positive size.
+		panic(err)
+	}
+	return &filterCache{filters: filters}
+}
+
+// Get returns the filter for expression compiled for dataType, which is
+// either a container type or its element type as for bexpr.CreateFilter.
+// An empty expression returns a nil filter that matches everything.
+func (c *filterCache) Get(expression string, dataType interface{}) (*compiledFilter, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	key := filterCacheKey{expression: expression, dataType: reflect.TypeOf(dataType)}
+	if raw, ok := c.filters.Get(key); ok {
+		return raw.(*compiledFilter), nil
+	}
+
+	filter, err := bexpr.CreateFilter(expression, nil, dataType)
+	if err != nil {
+		return nil, err
+	}
+	// CreateFilter already validated the expression so it is known to parse.
+	ast, err := bexpr.Parse("", []byte(expression))
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &compiledFilter{
+		filter:         filter,
+		metaEqualities: make(map[string]map[string]string),
+	}
+	compiled.collectMetaEqualities(ast.(bexpr.Expression))
+	c.filters.Add(key, compiled)
+	return compiled, nil
+}
+
+// compiledFilter is a bexpr filter along with the metadata equality matches
+// that every item it selects must satisfy. A nil compiledFilter matches
+// everything.
+type compiledFilter struct {
+	filter *bexpr.Filter
+
+	// metaEqualities maps the selector of a metadata map, such as NodeMeta,
+	// to the key/value pairs that map must contain for an item to match.
+	metaEqualities map[string]map[string]string
+}
+
+// Execute runs the filter against data as bexpr.Filter.Execute does.
+func (f *compiledFilter) Execute(data interface{}) (interface{}, error) {
+	if f == nil {
+		return data, nil
+	}
+	return f.filter.Execute(data)
+}
+
+// MetaEqualities returns the key/value pairs that the metadata map named by
+// selector must contain for an item to match the filter. Callers can use
+// them to narrow the items read from the state store with an index before
+// executing the filter. The returned map must not be modified.
+func (f *compiledFilter) MetaEqualities(selector string) map[string]string {
+	if f == nil {
+		return nil
+	}
+	return f.metaEqualities[selector]
+}
+
+// collectMetaEqualities records the `<selector>.<key> == <value>` matches of
+// expr that an item must satisfy, which are the ones that are not nested
+// below an `or` or a `not`.
+func (f *compiledFilter) collectMetaEqualities(expr bexpr.Expression) {
+	switch e := expr.(type) {
+	case *bexpr.BinaryExpression:
+		if e.Operator == bexpr.BinaryOpAnd {
+			f.collectMetaEqualities(e.Left)
+			f.collectMetaEqualities(e.Right)
+		}
+	case *bexpr.MatchExpression:
+		if e.Operator != bexpr.MatchEqual || len(e.Selector) != 2 || e.Value == nil {
+			return
+		}
+		selector, key := e.Selector[0], e.Selector[1]
+		pairs, ok := f.metaEqualities[selector]
+		if !ok {
+			pairs = make(map[string]string)
+			f.metaEqualities[selector] = pairs
+		}
+		if _, ok := pairs[key]; !ok {
+			pairs[key] = e.Value.Raw
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestFilterCache_Get(t *testing.T) {
+	c := newFilterCache()
+
+	filter, err := c.Get("", structs.Nodes{})
+	require.NoError(t, err)
+	require.Nil(t, filter)
+	require.Nil(t, filter.MetaEqualities("Meta"))
+
+	nodes := structs.Nodes{
+		{Node: "a", Meta: map[string]string{"env": "prod"}},
+		{Node: "b", Meta: map[string]string{"env": "dev"}},
+	}
+	raw, err := filter.Execute(nodes)
+	require.NoError(t, err)
+	require.Equal(t, nodes, raw)
+
+	filter, err = c.Get(`Meta.env == prod`, structs.Nodes{})
+	require.NoError(t, err)
+	raw, err = filter.Execute(nodes)
+	require.NoError(t, err)
+	require.Equal(t, structs.Nodes{nodes[0]}, raw)
+
+	// The same expression for the same type is compiled once.
+	again, err := c.Get(`Meta.env == prod`, structs.Nodes{})
+	require.NoError(t, err)
+	require.Same(t, filter, again)
+
+	// The same expression for another type is compiled separately.
+	other, err := c.Get(`Meta.env == prod`, structs.HealthChecks{})
+	require.Error(t, err)
+	require.Nil(t, other)
+	require.Equal(t, 1, c.filters.Len())
+
+	_, err = c.Get(`Meta.env ==`, structs.Nodes{})
+	require.Error(t, err)
+}
+
+func TestCompiledFilter_MetaEqualities(t *testing.T) {
+	c := newFilterCache()
+
+	cases := map[string]struct {
+		expression string
+		selector   string
+		expect     map[string]string
+	}{
+		"single": {
+			expression: `ServiceMeta.env == prod`,
+			selector:   "ServiceMeta",
+			expect:     map[string]string{"env": "prod"},
+		},
+		"conjunction": {
+			expression: `ServiceMeta.env == "prod" and ServiceName == api and ServiceMeta["team"] == a`,
+			selector:   "ServiceMeta",
+			expect:     map[string]string{"env": "prod", "team": "a"},
+		},
+		"other selector": {
+			expression: `NodeMeta.env == prod`,
+			selector:   "ServiceMeta",
+		},
+		"disjunction": {
+			expression: `ServiceMeta.env == prod or ServiceMeta.env == dev`,
+			selector:   "ServiceMeta",
+		},
+		"negation": {
+			expression: `not ServiceMeta.env == prod`,
+			selector:   "ServiceMeta",
+		},
+		"inequality": {
+			expression: `ServiceMeta.env != prod`,
+			selector:   "ServiceMeta",
+		},
+		"nested conjunction": {
+			expression: `(ServiceMeta.env == prod and ServiceMeta.team == a) or ServiceName == api`,
+			selector:   "ServiceMeta",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			filter, err := c.Get(tc.expression, structs.ServiceNodes{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, filter.MetaEqualities(tc.selector))
+		})
+	}
+}
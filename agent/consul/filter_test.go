@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 )
@@ -53,6 +55,49 @@ func TestFilter_DirEnt(t *testing.T) {
 	}
 }
 
+func TestFilter_KeyNames(t *testing.T) {
+	t.Parallel()
+	policy, err := acl.NewPolicyFromSource(`
+		key_prefix "foo/" {
+			policy = "read"
+		}
+		key_prefix "metadata/" {
+			policy = "list"
+			deny_value_read = true
+		}
+		key_prefix "dropbox/" {
+			policy = "write"
+			deny_value_read = true
+		}
+	`, nil, nil)
+	require.NoError(t, err)
+	aclR, err := acl.NewPolicyAuthorizerWithDefaults(acl.DenyAll(), []*acl.Policy{policy}, nil)
+	require.NoError(t, err)
+
+	in := []string{"foo/test", "metadata/a", "dropbox/b", "zoo"}
+	ents := structs.DirEntries{}
+	for _, key := range in {
+		ents = append(ents, &structs.DirEntry{Key: key})
+	}
+
+	var names []string
+	for _, e := range FilterKeyNames(aclR, ents) {
+		names = append(names, e.Key)
+	}
+	require.Equal(t, []string{"foo/test", "metadata/a", "dropbox/b"}, names)
+
+	// The values of the keys can only be read with the read policy.
+	ents = structs.DirEntries{}
+	for _, key := range in {
+		ents = append(ents, &structs.DirEntry{Key: key})
+	}
+	var values []string
+	for _, e := range FilterDirEnt(aclR, ents) {
+		values = append(values, e.Key)
+	}
+	require.Equal(t, []string{"foo/test"}, values)
+}
+
 func TestFilter_TxnResults(t *testing.T) {
 	t.Parallel()
 	policy, _ := acl.NewPolicyFromSource(testFilterRules, nil, nil)
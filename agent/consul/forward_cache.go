@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"time"
+
+	"github.com/armon/go-metrics"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/hashicorp/consul-net-rpc/go-msgpack/codec"
+
+	"github.com/hashicorp/consul/agent/cache"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// defaultForwardCacheTTL is how long a cached response is served when the
+// cache is enabled without a TTL.
+const defaultForwardCacheTTL = 5 * time.Second
+
+// forwardCacheMethods are the read RPCs whose responses from other datacenters
+// are cached. They are the catalog and health lookups that clients repeat the
+// most, such as the DNS lookups of the services of a remote datacenter.
+var forwardCacheMethods = map[string]bool{
+	"Catalog.ListNodes":    true,
+	"Catalog.ListServices": true,
+	"Catalog.ServiceNodes": true,
+	"Health.ServiceNodes":  true,
+}
+
+// forwardCacheRequest is implemented by the requests of forwardCacheMethods.
+type forwardCacheRequest interface {
+	CacheInfo() cache.RequestInfo
+	ConsistencyLevel() string
+}
+
+// forwardCacheReply is implemented by the replies of forwardCacheMethods
+// through their embedded structs.QueryMeta.
+type forwardCacheReply interface {
+	GetIndex() uint64
+	GetLastContact() (time.Duration, error)
+	SetLastContact(time.Duration)
+}
+
+// forwardCache holds the responses of the stale reads recently forwarded to
+// other datacenters, so that a popular lookup of a remote service is answered
+// locally instead of crossing the WAN for every client.
+//
+// The raft index of the remote datacenter is used to invalidate the responses:
+// a blocking query waiting for a change past the index of the cached response
+// is always forwarded, and the newer response it returns replaces the cached
+// one for all the other clients. A response older than the cached one, such
+// as from a lagging follower, never replaces it.
+type forwardCache struct {
+	ttl     time.Duration
+	entries *lru.Cache
+}
+
+type forwardCacheKey struct {
+	method     string
+	datacenter string
+	token      string
+	peer       string
+	request    string
+}
+
+type forwardCacheEntry struct {
+	// reply is the msgpack encoded response, which is decoded into the reply
+	// of each request it answers so they don't share any memory.
+	reply       []byte
+	index       uint64
+	lastContact time.Duration
+	fetched     time.Time
+}
+
+// newForwardCache returns a cache of up to size responses served for ttl, or
+// nil to disable the caching if size is not positive. A ttl of 0 uses
+// defaultForwardCacheTTL.
+func newForwardCache(size int, ttl time.Duration) *forwardCache {
+	if size <= 0 {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = defaultForwardCacheTTL
+	}
+	entries, err := lru.New(size)
+	if err != nil {
+		// lru.New only fails for a non-positive size.
+		panic(err)
+	}
+	return &forwardCache{ttl: ttl, entries: entries}
+}
+
+// key returns the key of the response to the request, and false if the
+// response must not be cached. Only the stale reads are cached, since the
+// other consistency modes require the remote leader to serve them.
+func (c *forwardCache) key(method, dc string, args interface{}) (forwardCacheKey, cache.RequestInfo, bool) {
+	if c == nil || !forwardCacheMethods[method] {
+		return forwardCacheKey{}, cache.RequestInfo{}, false
+	}
+	req, ok := args.(forwardCacheRequest)
+	if !ok || req.ConsistencyLevel() != "stale" {
+		return forwardCacheKey{}, cache.RequestInfo{}, false
+	}
+	info := req.CacheInfo()
+	if info.Key == "" {
+		return forwardCacheKey{}, cache.RequestInfo{}, false
+	}
+	key := forwardCacheKey{
+		method:     method,
+		datacenter: dc,
+		token:      info.Token,
+		peer:       info.PeerName,
+		request:    info.Key,
+	}
+	return key, info, true
+}
+
+// get decodes the cached response into reply and returns true if there is one
+// fresh enough for the request, and newer than the index it blocks on.
+func (c *forwardCache) get(key forwardCacheKey, info cache.RequestInfo, reply interface{}, now time.Time) bool {
+	raw, ok := c.entries.Get(key)
+	if !ok {
+		return false
+	}
+	entry := raw.(*forwardCacheEntry)
+
+	age := now.Sub(entry.fetched)
+	if age >= c.ttl || info.MustRevalidate || (info.MaxAge > 0 && age > info.MaxAge) {
+		return false
+	}
+	if info.MinIndex >= entry.index {
+		return false
+	}
+
+	if err := codec.NewDecoderBytes(entry.reply, structs.MsgpackHandle).Decode(reply); err != nil {
+		return false
+	}
+	// Report the age of the response as part of its staleness.
+	if meta, ok := reply.(forwardCacheReply); ok {
+		meta.SetLastContact(entry.lastContact + age)
+	}
+	return true
+}
+
+// set caches the response to the request.
+func (c *forwardCache) set(key forwardCacheKey, info cache.RequestInfo, reply interface{}, now time.Time) {
+	meta, ok := reply.(forwardCacheReply)
+	if !ok || meta.GetIndex() == 0 {
+		return
+	}
+	index := meta.GetIndex()
+
+	var existing *forwardCacheEntry
+	if raw, ok := c.entries.Peek(key); ok {
+		existing = raw.(*forwardCacheEntry)
+	}
+	if existing != nil && existing.index > index {
+		return
+	}
+
+	// A response at the index the request blocked on, from a blocking query
+	// that timed out or a not modified response, holds no new data but
+	// confirms the cached response is still current.
+	if index <= info.MinIndex {
+		if existing != nil && existing.index == index {
+			refreshed := *existing
+			refreshed.fetched = now
+			c.entries.Add(key, &refreshed)
+		}
+		return
+	}
+
+	var buf []byte
+	if err := codec.NewEncoderBytes(&buf, structs.MsgpackHandle).Encode(reply); err != nil {
+		return
+	}
+	lastContact, _ := meta.GetLastContact()
+	c.entries.Add(key, &forwardCacheEntry{
+		reply:       buf,
+		index:       index,
+		lastContact: lastContact,
+		fetched:     now,
+	})
+}
+
+// forwardDCCached forwards the RPC to a remote datacenter like forwardDC, or
+// answers it from the forward cache when it holds a fresh enough response.
+func (s *Server) forwardDCCached(method, dc string, args interface{}, reply interface{}) error {
+	key, info, ok := s.forwardCache.key(method, dc, args)
+	if !ok {
+		return s.forwardDC(method, dc, args, reply)
+	}
+
+	if s.forwardCache.get(key, info, reply, time.Now()) {
+		metrics.IncrCounterWithLabels([]string{"rpc", "cross-dc", "cache_hit"}, 1,
+			[]metrics.Label{{Name: "datacenter", Value: dc}})
+		return nil
+	}
+
+	if err := s.forwardDC(method, dc, args, reply); err != nil {
+		return err
+	}
+	s.forwardCache.set(key, info, reply, time.Now())
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestForwardCache(t *testing.T) {
+	now := time.Now()
+	request := func(modify func(req *structs.ServiceSpecificRequest)) *structs.ServiceSpecificRequest {
+		req := &structs.ServiceSpecificRequest{
+			Datacenter:  "dc2",
+			ServiceName: "web",
+			QueryOptions: structs.QueryOptions{
+				AllowStale: true,
+				Token:      "token",
+			},
+		}
+		if modify != nil {
+			modify(req)
+		}
+		return req
+	}
+	reply := func(index uint64, nodes ...string) *structs.IndexedServiceNodes {
+		out := &structs.IndexedServiceNodes{QueryMeta: structs.QueryMeta{Index: index, LastContact: time.Second}}
+		for _, node := range nodes {
+			out.ServiceNodes = append(out.ServiceNodes, &structs.ServiceNode{Node: node, ServiceName: "web"})
+		}
+		return out
+	}
+	// lookup returns the nodes of the cached response to req, or nil.
+	lookup := func(c *forwardCache, req *structs.ServiceSpecificRequest, at time.Time) []string {
+		key, info, ok := c.key("Catalog.ServiceNodes", "dc2", req)
+		require.True(t, ok)
+		var out structs.IndexedServiceNodes
+		if !c.get(key, info, &out, at) {
+			return nil
+		}
+		var nodes []string
+		for _, sn := range out.ServiceNodes {
+			nodes = append(nodes, sn.Node)
+		}
+		return nodes
+	}
+	store := func(c *forwardCache, req *structs.ServiceSpecificRequest, out *structs.IndexedServiceNodes, at time.Time) {
+		key, info, ok := c.key("Catalog.ServiceNodes", "dc2", req)
+		require.True(t, ok)
+		c.set(key, info, out, at)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		c := newForwardCache(0, time.Minute)
+		require.Nil(t, c)
+		_, _, ok := c.key("Catalog.ServiceNodes", "dc2", request(nil))
+		require.False(t, ok)
+	})
+
+	t.Run("not cacheable", func(t *testing.T) {
+		c := newForwardCache(10, time.Minute)
+		_, _, ok := c.key("KVS.Get", "dc2", &structs.KeyRequest{Datacenter: "dc2", QueryOptions: structs.QueryOptions{AllowStale: true}})
+		require.False(t, ok)
+		for name, modify := range map[string]func(req *structs.ServiceSpecificRequest){
+			"default":       func(req *structs.ServiceSpecificRequest) { req.AllowStale = false },
+			"consistent":    func(req *structs.ServiceSpecificRequest) { req.RequireConsistent = true },
+			"bounded stale": func(req *structs.ServiceSpecificRequest) { req.BoundedStaleness = time.Second },
+		} {
+			_, _, ok := c.key("Catalog.ServiceNodes", "dc2", request(modify))
+			require.False(t, ok, name)
+		}
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		c := newForwardCache(10, time.Minute)
+		require.Nil(t, lookup(c, request(nil), now))
+		store(c, request(nil), reply(10, "a", "b"), now)
+		require.Equal(t, []string{"a", "b"}, lookup(c, request(nil), now.Add(time.Second)))
+
+		key, info, _ := c.key("Catalog.ServiceNodes", "dc2", request(nil))
+		var out structs.IndexedServiceNodes
+		require.True(t, c.get(key, info, &out, now.Add(2*time.Second)))
+		require.Equal(t, uint64(10), out.Index)
+		// The age of the response adds up to its last contact.
+		require.Equal(t, 3*time.Second, out.LastContact)
+
+		// Other tokens, datacenters and requests are cached separately.
+		require.Nil(t, lookup(c, request(func(req *structs.ServiceSpecificRequest) { req.Token = "other" }), now))
+		require.Nil(t, lookup(c, request(func(req *structs.ServiceSpecificRequest) { req.ServiceName = "db" }), now))
+		key, info, _ = c.key("Catalog.ServiceNodes", "dc3", request(nil))
+		require.False(t, c.get(key, info, &out, now))
+		key, info, _ = c.key("Health.ServiceNodes", "dc2", request(nil))
+		require.False(t, c.get(key, info, &structs.IndexedCheckServiceNodes{}, now))
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		c := newForwardCache(10, time.Minute)
+		store(c, request(nil), reply(10, "a"), now)
+		require.Nil(t, lookup(c, request(nil), now.Add(time.Minute)))
+
+		maxAge := request(func(req *structs.ServiceSpecificRequest) { req.MaxAge = time.Second })
+		require.Equal(t, []string{"a"}, lookup(c, maxAge, now.Add(time.Second)))
+		require.Nil(t, lookup(c, maxAge, now.Add(2*time.Second)))
+
+		revalidate := request(func(req *structs.ServiceSpecificRequest) { req.MustRevalidate = true })
+		require.Nil(t, lookup(c, revalidate, now))
+	})
+
+	t.Run("index", func(t *testing.T) {
+		c := newForwardCache(10, time.Minute)
+		store(c, request(nil), reply(10, "a"), now)
+
+		// A blocking query on an older index is answered by the cache, one
+		// waiting for a change past the cached index is not.
+		blocking := func(index uint64) *structs.ServiceSpecificRequest {
+			return request(func(req *structs.ServiceSpecificRequest) { req.MinQueryIndex = index })
+		}
+		require.Equal(t, []string{"a"}, lookup(c, blocking(9), now))
+		require.Nil(t, lookup(c, blocking(10), now))
+
+		// The newer response it returns replaces the cached one.
+		store(c, blocking(10), reply(12, "a", "b"), now)
+		require.Equal(t, []string{"a", "b"}, lookup(c, request(nil), now))
+
+		// An older response, such as from a lagging follower, is ignored.
+		store(c, request(nil), reply(11, "a"), now)
+		require.Equal(t, []string{"a", "b"}, lookup(c, request(nil), now))
+
+		// A blocking query timing out at the cached index refreshes it.
+		store(c, blocking(12), reply(12, "a", "b"), now.Add(50*time.Second))
+		require.Equal(t, []string{"a", "b"}, lookup(c, request(nil), now.Add(90*time.Second)))
+	})
+}
+
+func TestServer_ForwardCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.RPCConfig.CrossDCCacheSize = 100
+		c.RPCConfig.CrossDCCacheTTL = time.Hour
+	})
+	_, s2 := testServerDC(t, "dc2")
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	testrpc.WaitForLeader(t, s1.RPC, "dc2")
+
+	register := func(node string) {
+		req := structs.RegisterRequest{
+			Datacenter: "dc2",
+			Node:       node,
+			Address:    "10.0.0.1",
+			Service:    &structs.NodeService{Service: "web"},
+		}
+		var out struct{}
+		require.NoError(t, s2.RPC(context.Background(), "Catalog.Register", &req, &out))
+	}
+	query := func(minIndex uint64) structs.IndexedServiceNodes {
+		req := structs.ServiceSpecificRequest{
+			Datacenter:  "dc2",
+			ServiceName: "web",
+			QueryOptions: structs.QueryOptions{
+				AllowStale:    true,
+				MinQueryIndex: minIndex,
+				MaxQueryTime:  10 * time.Second,
+			},
+		}
+		var out structs.IndexedServiceNodes
+		require.NoError(t, s1.RPC(context.Background(), "Catalog.ServiceNodes", &req, &out))
+		return out
+	}
+
+	register("a")
+	first := query(0)
+	require.Len(t, first.ServiceNodes, 1)
+
+	// The new instance isn't seen until the cached response is invalidated.
+	register("b")
+	require.Len(t, query(0).ServiceNodes, 1)
+
+	// A blocking query on the cached index reaches dc2 and refreshes the
+	// response for the following queries.
+	require.Len(t, query(first.Index).ServiceNodes, 2)
+	require.Len(t, query(0).ServiceNodes, 2)
+}
@@ -22,6 +22,10 @@ var CommandsSummaries = []prometheus.SummaryDefinition{
 		Name: []string{"fsm", "register"},
 		Help: "Measures the time it takes to apply a catalog register operation to the FSM.",
 	},
+	{
+		Name: []string{"fsm", "register_batch"},
+		Help: "Measures the time it takes to apply a batch of catalog register operations to the FSM.",
+	},
 	{
 		Name: []string{"fsm", "deregister"},
 		Help: "Measures the time it takes to apply a catalog deregister operation to the FSM.",
@@ -148,6 +152,7 @@ func init() {
 	registerCommand(structs.PeeringSecretsWriteType, (*FSM).applyPeeringSecretsWrite)
 	registerCommand(structs.ResourceOperationType, (*FSM).applyResourceOperation)
 	registerCommand(structs.UpdateVirtualIPRequestType, (*FSM).applyManualVirtualIPs)
+	registerCommand(structs.RegisterBatchRequestType, (*FSM).applyRegisterBatch)
 }
 
 func (c *FSM) applyRegister(buf []byte, index uint64) interface{} {
@@ -169,6 +174,25 @@ func (c *FSM) applyRegister(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyRegisterBatch applies each of the register requests of the batch on
+// its own, and returns the error of each of them.
+func (c *FSM) applyRegisterBatch(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"fsm", "register_batch"}, time.Now())
+	var req structs.RegisterBatchRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	errs := make([]error, len(req.Requests))
+	for i, r := range req.Requests {
+		if err := c.state.EnsureRegistration(index, r); err != nil {
+			c.logger.Warn("EnsureRegistration failed", "error", err)
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
 func (c *FSM) applyDeregister(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"fsm", "deregister"}, time.Now())
 	var req structs.DeregisterRequest
@@ -477,6 +501,18 @@ func ApplyConnectCAOperationFromRequest(state *state.Store, req *structs.CAReque
 		}
 
 		return sn
+	case structs.CAOpRevokeCertificates:
+		if err := state.CARevokeCerts(index, req.RevokedCerts); err != nil {
+			return err
+		}
+
+		return true
+	case structs.CAOpSetRevocationLists:
+		if err := state.CASetRevocationLists(index, req.RevocationLists); err != nil {
+			return err
+		}
+
+		return true
 	default:
 		return fmt.Errorf("Invalid CA operation '%s'", req.Op)
 	}
@@ -166,6 +166,56 @@ func TestFSM_RegisterNode_Service(t *testing.T) {
 	}
 }
 
+func TestFSM_RegisterBatch(t *testing.T) {
+	t.Parallel()
+	logger := testutil.Logger(t)
+	fsm, err := New(nil, logger)
+	require.NoError(t, err)
+
+	check := func(node, status string) *structs.RegisterRequest {
+		return &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Check: &structs.HealthCheck{
+				Node:    node,
+				CheckID: "web",
+				Name:    "web",
+				Status:  status,
+			},
+		}
+	}
+	req := structs.RegisterBatchRequest{
+		Requests: []*structs.RegisterRequest{
+			check("foo", api.HealthPassing),
+			// The check does not belong to the registered node.
+			{
+				Datacenter: "dc1",
+				Node:       "bar",
+				Address:    "127.0.0.1",
+				Check:      &structs.HealthCheck{Node: "baz", CheckID: "web"},
+			},
+			check("foo", api.HealthCritical),
+		},
+	}
+	buf, err := structs.Encode(structs.RegisterBatchRequestType, req)
+	require.NoError(t, err)
+
+	resp := fsm.Apply(makeLog(buf))
+	errs, ok := resp.([]error)
+	require.True(t, ok, "unexpected response %#v", resp)
+	require.Len(t, errs, 3)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.NoError(t, errs[2])
+
+	// The requests are applied in order.
+	_, hc, err := fsm.state.NodeCheck("foo", "web", nil, "")
+	require.NoError(t, err)
+	require.Equal(t, api.HealthCritical, hc.Status)
+	require.Equal(t, uint64(1), hc.ModifyIndex)
+}
+
 func TestFSM_DeregisterService(t *testing.T) {
 	t.Parallel()
 	logger := testutil.Logger(t)
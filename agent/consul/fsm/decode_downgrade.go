@@ -629,6 +629,8 @@ func MakeShadowConfigEntry(kind, name string) (structs.ConfigEntry, error) {
 		return &ShadowTCPRouteConfigEntry{TCPRouteConfigEntry: &structs.TCPRouteConfigEntry{Name: name}}, nil
 	case structs.JWTProvider:
 		return &ShadowJWTProviderConfigEntry{JWTProviderConfigEntry: &structs.JWTProviderConfigEntry{Name: name}}, nil
+	case structs.AgentDefaults:
+		return &ShadowAgentDefaultsConfigEntry{AgentDefaultsConfigEntry: &structs.AgentDefaultsConfigEntry{Name: name}}, nil
 	default:
 		return nil, fmt.Errorf("invalid config entry kind: %s", kind)
 	}
@@ -1020,3 +1022,12 @@ type ShadowJWTProviderConfigEntry struct {
 func (s ShadowJWTProviderConfigEntry) GetRealConfigEntry() structs.ConfigEntry {
 	return s.JWTProviderConfigEntry
 }
+
+type ShadowAgentDefaultsConfigEntry struct {
+	ShadowBase
+	*structs.AgentDefaultsConfigEntry
+}
+
+func (s ShadowAgentDefaultsConfigEntry) GetRealConfigEntry() structs.ConfigEntry {
+	return s.AgentDefaultsConfigEntry
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-raftchunking"
 	"github.com/hashicorp/raft"
+	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/consul-net-rpc/go-msgpack/codec"
 
@@ -97,6 +98,10 @@ type Deps struct {
 	// manages its own state and has methods for handling Raft logs, snapshotting,
 	// and restoring snapshots.
 	StorageBackend StorageBackend
+
+	// SnapshotLimiter, if set, limits the rate at which the snapshots are
+	// written and restored, in bytes per second.
+	SnapshotLimiter *rate.Limiter
 }
 
 // StorageBackend contains the methods on the Raft resource storage backend that
@@ -221,6 +226,7 @@ func (c *FSM) Snapshot() (raft.FSMSnapshot, error) {
 		state:           c.state.Snapshot(),
 		chunkState:      chunkState,
 		storageSnapshot: storageSnapshot,
+		limiter:         c.deps.SnapshotLimiter,
 	}, nil
 }
 
@@ -279,7 +285,11 @@ func (c *FSM) Restore(old io.ReadCloser) error {
 		}
 		return nil
 	}
-	if err := ReadSnapshot(old, handler); err != nil {
+	var r io.Reader = old
+	if c.deps.SnapshotLimiter != nil {
+		r = &throttledReader{r: old, limiter: c.deps.SnapshotLimiter}
+	}
+	if err := ReadSnapshot(r, handler); err != nil {
 		return err
 	}
 
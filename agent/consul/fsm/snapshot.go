@@ -11,6 +11,7 @@ import (
 	"github.com/armon/go-metrics/prometheus"
 	"github.com/hashicorp/go-raftchunking"
 	"github.com/hashicorp/raft"
+	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/consul-net-rpc/go-msgpack/codec"
 	"github.com/hashicorp/consul/agent/consul/state"
@@ -34,6 +35,7 @@ type snapshot struct {
 	state           *state.Snapshot
 	chunkState      *raftchunking.State
 	storageSnapshot *raftstorage.Snapshot
+	limiter         *rate.Limiter
 }
 
 // SnapshotHeader is the first entry in our snapshot
@@ -68,6 +70,10 @@ func registerRestorer(msg structs.MessageType, fn restorer) {
 func (s *snapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"fsm", "persist"}, time.Now())
 
+	if s.limiter != nil {
+		sink = &throttledSink{SnapshotSink: sink, limiter: s.limiter}
+	}
+
 	// Write the header
 	header := SnapshotHeader{
 		LastIndex: s.state.LastIndex(),
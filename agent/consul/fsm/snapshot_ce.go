@@ -30,6 +30,8 @@ func init() {
 	registerRestorer(structs.ConnectCARequestType, restoreConnectCA)
 	registerRestorer(structs.ConnectCAProviderStateType, restoreConnectCAProviderState)
 	registerRestorer(structs.ConnectCAConfigType, restoreConnectCAConfig)
+	registerRestorer(structs.ConnectCARevocationType, restoreConnectCARevocation)
+	registerRestorer(structs.ConnectCARevocationListsType, restoreConnectCARevocationLists)
 	registerRestorer(structs.IndexRequestType, restoreIndex)
 	registerRestorer(structs.ACLTokenSetRequestType, restoreToken)
 	registerRestorer(structs.ACLPolicySetRequestType, restorePolicy)
@@ -83,6 +85,12 @@ func persistCE(s *snapshot, sink raft.SnapshotSink, encoder *codec.Encoder) erro
 	if err := s.persistConnectCAConfig(sink, encoder); err != nil {
 		return err
 	}
+	if err := s.persistConnectCARevocations(sink, encoder); err != nil {
+		return err
+	}
+	if err := s.persistConnectCARevocationLists(sink, encoder); err != nil {
+		return err
+	}
 	if err := s.persistConfigEntries(sink, encoder); err != nil {
 		return err
 	}
@@ -422,6 +430,43 @@ func (s *snapshot) persistConnectCAProviderState(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *snapshot) persistConnectCARevocations(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	certs, err := s.state.CARevokedCerts()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range certs {
+		if _, err := sink.Write([]byte{byte(structs.ConnectCARevocationType)}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *snapshot) persistConnectCARevocationLists(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	lists, err := s.state.CARevocationLists()
+	if err != nil {
+		return err
+	}
+	if lists == nil {
+		return nil
+	}
+
+	if _, err := sink.Write([]byte{byte(structs.ConnectCARevocationListsType)}); err != nil {
+		return err
+	}
+	if err := encoder.Encode(lists); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (s *snapshot) persistLegacyIntentions(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	//nolint:staticcheck
@@ -750,6 +795,28 @@ func restoreConnectCAProviderState(header *SnapshotHeader, restore *state.Restor
 	return nil
 }
 
+func restoreConnectCARevocation(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
+	var req structs.CARevokedCert
+	if err := decoder.Decode(&req); err != nil {
+		return err
+	}
+	if err := restore.CARevokedCert(&req); err != nil {
+		return err
+	}
+	return nil
+}
+
+func restoreConnectCARevocationLists(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
+	var req structs.CARevocationLists
+	if err := decoder.Decode(&req); err != nil {
+		return err
+	}
+	if err := restore.CARevocationLists(&req); err != nil {
+		return err
+	}
+	return nil
+}
+
 func restoreConnectCAConfig(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
 	var req structs.CAConfiguration
 	if err := decoder.Decode(&req); err != nil {
@@ -249,6 +249,27 @@ func TestFSM_SnapshotRestore_CE(t *testing.T) {
 	err = fsm.state.CASetConfig(17, caConfig)
 	require.NoError(t, err)
 
+	// CA revocations
+	revokedCert := &structs.CARevokedCert{
+		SerialNumber: "01:02",
+		Reason:       1,
+		RevokedAt:    time.Now().UTC().Round(time.Second),
+		NotAfter:     time.Now().UTC().Round(time.Second).Add(time.Hour),
+	}
+	require.NoError(t, fsm.state.CARevokeCerts(17, []*structs.CARevokedCert{revokedCert}))
+	revocationLists := &structs.CARevocationLists{
+		Lists: []*structs.CADatacenterRevocationList{
+			{
+				Datacenter:   "dc1",
+				SigningKeyID: "aa:bb",
+				CRL:          []byte("crl"),
+				Revoked:      true,
+				NextUpdate:   time.Now().UTC().Round(time.Second).Add(time.Hour),
+			},
+		},
+	}
+	require.NoError(t, fsm.state.CASetRevocationLists(17, revocationLists))
+
 	// Config entries
 	serviceConfig := &structs.ServiceConfigEntry{
 		Kind:     structs.ServiceDefaults,
@@ -811,6 +832,19 @@ func TestFSM_SnapshotRestore_CE(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, caConfig, caConf)
 
+	// Verify CA revocations are restored.
+	_, revokedCerts, err := fsm2.state.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Len(t, revokedCerts, 1)
+	require.Equal(t, revokedCert.SerialNumber, revokedCerts[0].SerialNumber)
+	require.Equal(t, revokedCert.Reason, revokedCerts[0].Reason)
+	require.True(t, revokedCert.RevokedAt.Equal(revokedCerts[0].RevokedAt))
+	_, lists, err := fsm2.state.CARevocationLists(nil)
+	require.NoError(t, err)
+	require.Len(t, lists.Lists, 1)
+	require.Equal(t, revocationLists.Lists[0].CRL, lists.Lists[0].CRL)
+	require.True(t, revocationLists.Lists[0].NextUpdate.Equal(lists.Lists[0].NextUpdate))
+
 	// Verify config entries are restored
 	_, serviceConfEntry, err := fsm2.state.ConfigEntry(nil, structs.ServiceDefaults, "foo", structs.DefaultEnterpriseMetaInDefaultPartition())
 	require.NoError(t, err)
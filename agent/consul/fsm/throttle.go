@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fsm
+
+import (
+	"context"
+	"io"
+
+	"github.com/hashicorp/raft"
+	"golang.org/x/time/rate"
+)
+
+// throttleChunkSize is the largest number of bytes waited for at once, it
+// must not exceed the burst of the snapshot limiter, see SetSnapshotIOLimit.
+const throttleChunkSize = 64 * 1024
+
+// throttledSink limits the rate at which a snapshot is written to its sink.
+type throttledSink struct {
+	raft.SnapshotSink
+	limiter *rate.Limiter
+}
+
+func (s *throttledSink) Write(p []byte) (int, error) {
+	if s.limiter.Limit() == rate.Inf {
+		return s.SnapshotSink.Write(p)
+	}
+
+	var written int
+	for len(p) > 0 {
+		n := len(p)
+		if n > throttleChunkSize {
+			n = throttleChunkSize
+		}
+		if err := s.limiter.WaitN(context.Background(), n); err != nil {
+			return written, err
+		}
+		m, err := s.SnapshotSink.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// throttledReader limits the rate at which a snapshot is read while it is
+// restored.
+type throttledReader struct {
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if r.limiter.Limit() == rate.Inf {
+		return r.r.Read(p)
+	}
+
+	if len(p) > throttleChunkSize {
+		p = p[:throttleChunkSize]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(context.Background(), n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+// NewSnapshotLimiter returns a limiter of the rate at which the snapshots are
+// written and restored, which does not limit anything until SetSnapshotIOLimit
+// is called.
+func NewSnapshotLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Inf, 0)
+}
+
+// SetSnapshotIOLimit sets the bytes per second read and written by the
+// snapshots using the limiter, 0 removes the limit.
+func SetSnapshotIOLimit(limiter *rate.Limiter, bytesPerSecond int) {
+	if bytesPerSecond <= 0 {
+		limiter.SetLimit(rate.Inf)
+		return
+	}
+	// Allow bursts of a second worth of I/O, but never less than a chunk.
+	burst := bytesPerSecond
+	if burst < throttleChunkSize {
+		burst = throttleChunkSize
+	}
+	limiter.SetBurst(burst)
+	limiter.SetLimit(rate.Limit(bytesPerSecond))
+}
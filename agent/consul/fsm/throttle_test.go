@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fsm
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func TestThrottledSink(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewSnapshotLimiter()
+	SetSnapshotIOLimit(limiter, 4*throttleChunkSize)
+
+	data := bytes.Repeat([]byte("a"), 8*throttleChunkSize)
+	sink := &throttledSink{SnapshotSink: &MockSink{new(bytes.Buffer), false}, limiter: limiter}
+
+	// The first second worth of data is written at once, the rest at the
+	// limited rate.
+	start := time.Now()
+	n, err := sink.Write(data)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	require.Equal(t, data, sink.SnapshotSink.(*MockSink).Bytes())
+
+	// Removing the limit writes without waiting.
+	SetSnapshotIOLimit(limiter, 0)
+	start = time.Now()
+	_, err = sink.Write(data)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestThrottledReader(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewSnapshotLimiter()
+	SetSnapshotIOLimit(limiter, 4*throttleChunkSize)
+
+	data := bytes.Repeat([]byte("a"), 8*throttleChunkSize)
+	r := &throttledReader{r: bytes.NewReader(data), limiter: limiter}
+
+	start := time.Now()
+	read, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestFSM_SnapshotRestore_Throttled(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.Logger(t)
+	limiter := NewSnapshotLimiter()
+	SetSnapshotIOLimit(limiter, 1024*1024)
+	newFSM := func() *FSM {
+		return NewFromDeps(Deps{
+			Logger: logger,
+			NewStateStore: func() *state.Store {
+				return state.NewStateStore(nil)
+			},
+			StorageBackend:  newStorageBackend(t, nil),
+			SnapshotLimiter: limiter,
+		})
+	}
+
+	fsm := newFSM()
+	require.NoError(t, fsm.state.EnsureNode(1, &structs.Node{Node: "foo", Address: "127.0.0.1"}))
+
+	snap, err := fsm.Snapshot()
+	require.NoError(t, err)
+	defer snap.Release()
+
+	sink := &MockSink{new(bytes.Buffer), false}
+	require.NoError(t, snap.Persist(sink))
+
+	fsm2 := newFSM()
+	require.NoError(t, fsm2.Restore(sink))
+
+	_, nodes, err := fsm2.state.Nodes(nil, nil, "")
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	require.Equal(t, "foo", nodes[0].Node)
+}
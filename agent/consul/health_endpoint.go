@@ -14,7 +14,7 @@ import (
 	"github.com/hashicorp/consul/agent/configentry"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
-	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 )
@@ -32,7 +32,7 @@ func (h *Health) ChecksInState(args *structs.ChecksInStateRequest,
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.HealthChecks)
+	filter, err := h.srv.filterCache.Get(args.Filter, reply.HealthChecks)
 	if err != nil {
 		return err
 	}
@@ -76,7 +76,10 @@ func (h *Health) ChecksInState(args *structs.ChecksInStateRequest,
 				return err
 			}
 
-			return h.srv.sortNodesByDistanceFrom(args.Source, reply.HealthChecks)
+			if err := h.srv.sortNodesByDistanceFrom(args.Source, reply.HealthChecks); err != nil {
+				return err
+			}
+			return structs.SortResults(args.Sort, reply.HealthChecks)
 		})
 }
 
@@ -87,7 +90,7 @@ func (h *Health) NodeChecks(args *structs.NodeSpecificRequest,
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.HealthChecks)
+	filter, err := h.srv.filterCache.Get(args.Filter, reply.HealthChecks)
 	if err != nil {
 		return err
 	}
@@ -142,7 +145,7 @@ func (h *Health) ServiceChecks(args *structs.ServiceSpecificRequest,
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.HealthChecks)
+	filter, err := h.srv.filterCache.Get(args.Filter, reply.HealthChecks)
 	if err != nil {
 		return err
 	}
@@ -186,7 +189,10 @@ func (h *Health) ServiceChecks(args *structs.ServiceSpecificRequest,
 				return err
 			}
 
-			return h.srv.sortNodesByDistanceFrom(args.Source, reply.HealthChecks)
+			if err := h.srv.sortNodesByDistanceFrom(args.Source, reply.HealthChecks); err != nil {
+				return err
+			}
+			return structs.SortResults(args.Sort, reply.HealthChecks)
 		})
 }
 
@@ -234,7 +240,7 @@ func (h *Health) ServiceNodes(args *structs.ServiceSpecificRequest, reply *struc
 		}
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.Nodes)
+	filter, err := h.srv.filterCache.Get(args.Filter, reply.Nodes)
 	if err != nil {
 		return err
 	}
@@ -320,6 +326,9 @@ func (h *Health) ServiceNodes(args *structs.ServiceSpecificRequest, reply *struc
 				if err := h.srv.sortNodesByDistanceFrom(arg.Source, thisReply.Nodes); err != nil {
 					return err
 				}
+				if err := structs.SortResults(arg.Sort, thisReply.Nodes); err != nil {
+					return err
+				}
 				if len(thisReply.Nodes) > 0 {
 					break
 				}
@@ -374,6 +383,206 @@ func (h *Health) ServiceNodes(args *structs.ServiceSpecificRequest, reply *struc
 	return err
 }
 
+// ServicesNodes returns all the nodes registered as part of several services
+// including health info, so that they can be watched with a single blocking
+// query. When no service names are given, all the services are queried.
+func (h *Health) ServicesNodes(args *structs.ServicesSpecificRequest, reply *structs.IndexedServicesCheckServiceNodes) error {
+	if done, err := h.srv.ForwardRPC("Health.ServicesNodes", args, reply); done {
+		return err
+	}
+
+	// Verify the arguments
+	if len(args.ServiceNames) == 0 && args.Filter == "" {
+		return fmt.Errorf("Must provide service names or a filter")
+	}
+
+	authzContext := acl.AuthorizerContext{
+		Peer: args.PeerName,
+	}
+	if _, err := h.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext); err != nil {
+		return err
+	}
+
+	if err := h.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	filter, err := h.srv.filterCache.Get(args.Filter, structs.CheckServiceNodes(nil))
+	if err != nil {
+		return err
+	}
+
+	// Deduplicate the service names so their instances are only listed once.
+	names := make([]string, 0, len(args.ServiceNames))
+	seen := make(map[string]struct{}, len(args.ServiceNames))
+	for _, name := range args.ServiceNames {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	err = h.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			var (
+				index uint64
+				nodes structs.CheckServiceNodes
+			)
+			if len(names) == 0 {
+				idx, all, err := state.ServiceDump(ws, "", false, &args.EnterpriseMeta, args.PeerName)
+				if err != nil {
+					return err
+				}
+				index, nodes = idx, all
+			}
+			for _, name := range names {
+				idx, serviceNodes, err := state.CheckServiceNodes(ws, name, &args.EnterpriseMeta, args.PeerName)
+				if err != nil {
+					return err
+				}
+				if idx > index {
+					index = idx
+				}
+				nodes = append(nodes, serviceNodes...)
+			}
+
+			if len(args.NodeMetaFilters) > 0 {
+				nodes = nodeMetaFilter(args.NodeMetaFilters, nodes)
+			}
+
+			raw, err := filter.Execute(nodes)
+			if err != nil {
+				return err
+			}
+			filteredNodes := raw.(structs.CheckServiceNodes)
+
+			thisReply := structs.IndexedCheckServiceNodes{
+				Nodes: filteredNodes.Filter(structs.CheckServiceNodeFilterOptions{FilterType: args.HealthFilterType}),
+			}
+
+			// As for ServiceNodes, filter the results with ACLs *after*
+			// applying the user-supplied bexpr filter.
+			if err := h.srv.filterACL(args.Token, &thisReply); err != nil {
+				return err
+			}
+
+			if err := h.srv.sortNodesByDistanceFrom(args.Source, thisReply.Nodes); err != nil {
+				return err
+			}
+
+			// Requested services without instances are still part of the
+			// response.
+			services := make(map[string]structs.CheckServiceNodes, len(names))
+			for _, name := range names {
+				services[name] = nil
+			}
+			for _, node := range thisReply.Nodes {
+				services[node.Service.Service] = append(services[node.Service.Service], node)
+			}
+
+			reply.Index = index
+			reply.ResultsFilteredByACLs = thisReply.ResultsFilteredByACLs
+			reply.Services = services
+			return nil
+		})
+
+	if err == nil {
+		metrics.IncrCounter([]string{"health", "services", "query"}, 1)
+	}
+	return err
+}
+
+// Summary returns the number of passing, warning and critical instances of
+// each service in the namespace or partition of the request.
+func (h *Health) Summary(args *structs.DCSpecificRequest, reply *structs.IndexedServiceHealthSummaries) error {
+	if done, err := h.srv.ForwardRPC("Health.Summary", args, reply); done {
+		return err
+	}
+
+	authzContext := acl.AuthorizerContext{
+		Peer: args.PeerName,
+	}
+	if _, err := h.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext); err != nil {
+		return err
+	}
+
+	if err := h.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	filter, err := h.srv.filterCache.Get(args.Filter, structs.CheckServiceNodes(nil))
+	if err != nil {
+		return err
+	}
+
+	return h.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, nodes, err := state.ServiceDump(ws, "", false, &args.EnterpriseMeta, args.PeerName)
+			if err != nil {
+				return err
+			}
+
+			if len(args.NodeMetaFilters) > 0 {
+				nodes = nodeMetaFilter(args.NodeMetaFilters, nodes)
+			}
+
+			raw, err := filter.Execute(nodes)
+			if err != nil {
+				return err
+			}
+
+			// Instances are counted after filtering them with ACLs, so that
+			// the summaries do not disclose services the token cannot read.
+			thisReply := structs.IndexedCheckServiceNodes{
+				Nodes: raw.(structs.CheckServiceNodes),
+			}
+			if err := h.srv.filterACL(args.Token, &thisReply); err != nil {
+				return err
+			}
+
+			summaries := make(map[structs.ServiceName]*structs.ServiceHealthSummary)
+			for _, node := range thisReply.Nodes {
+				name := node.Service.CompoundServiceName()
+				summary, ok := summaries[name]
+				if !ok {
+					summary = &structs.ServiceHealthSummary{
+						Name:           name.Name,
+						Kind:           node.Service.Kind,
+						EnterpriseMeta: name.EnterpriseMeta,
+					}
+					summaries[name] = summary
+				}
+				switch node.AggregatedStatus() {
+				case api.HealthCritical:
+					summary.Critical++
+				case api.HealthWarning:
+					summary.Warning++
+				default:
+					summary.Passing++
+				}
+			}
+
+			reply.Summaries = make([]*structs.ServiceHealthSummary, 0, len(summaries))
+			for _, summary := range summaries {
+				reply.Summaries = append(reply.Summaries, summary)
+			}
+			sort.Slice(reply.Summaries, func(i, j int) bool {
+				a, b := reply.Summaries[i], reply.Summaries[j]
+				if a.Name != b.Name {
+					return a.Name < b.Name
+				}
+				return a.EnterpriseMeta.LessThan(&b.EnterpriseMeta)
+			})
+			reply.Index = index
+			reply.ResultsFilteredByACLs = thisReply.ResultsFilteredByACLs
+			return nil
+		})
+}
+
 // The serviceNodes* functions below are the various lookup methods that
 // can be used by the ServiceNodes endpoint.
 
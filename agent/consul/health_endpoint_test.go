@@ -4,6 +4,7 @@
 package consul
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/hashicorp/consul-net-rpc/net/rpc"
 
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
@@ -669,6 +671,185 @@ func TestHealth_ServiceNodes(t *testing.T) {
 	}
 }
 
+func TestHealth_ServicesNodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServer(t)
+	codec := rpcClient(t, s1)
+
+	waitForLeaderEstablishment(t, s1)
+
+	register := func(codec rpc.ClientCodec, node, address, service, status string) error {
+		arg := structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    address,
+			Service: &structs.NodeService{
+				ID:      service,
+				Service: service,
+			},
+			Check: &structs.HealthCheck{
+				Name:      service + " check",
+				Status:    status,
+				ServiceID: service,
+			},
+		}
+		var out struct{}
+		return msgpackrpc.CallWithCodec(codec, "Catalog.Register", &arg, &out)
+	}
+	require.NoError(t, register(codec, "foo", "127.0.0.1", "db", api.HealthPassing))
+	require.NoError(t, register(codec, "bar", "127.0.0.2", "web", api.HealthCritical))
+
+	nodeNames := func(out structs.IndexedServicesCheckServiceNodes) map[string][]string {
+		names := make(map[string][]string)
+		for service, nodes := range out.Services {
+			names[service] = []string{}
+			for _, n := range nodes {
+				names[service] = append(names[service], n.Node.Node)
+			}
+		}
+		return names
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		var out structs.IndexedServicesCheckServiceNodes
+		req := structs.ServicesSpecificRequest{
+			Datacenter:   "dc1",
+			ServiceNames: []string{"db", "web", "missing", "db"},
+		}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out))
+		require.NotZero(t, out.Index)
+		require.Equal(t, map[string][]string{
+			"db":      {"foo"},
+			"web":     {"bar"},
+			"missing": {},
+		}, nodeNames(out))
+
+		req.HealthFilterType = structs.HealthFilterIncludeOnlyPassing
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out))
+		require.Equal(t, map[string][]string{
+			"db":      {"foo"},
+			"web":     {},
+			"missing": {},
+		}, nodeNames(out))
+	})
+
+	t.Run("by filter", func(t *testing.T) {
+		var out structs.IndexedServicesCheckServiceNodes
+		req := structs.ServicesSpecificRequest{
+			Datacenter:   "dc1",
+			QueryOptions: structs.QueryOptions{Filter: "Service.Service == web"},
+		}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out))
+		require.Equal(t, map[string][]string{"web": {"bar"}}, nodeNames(out))
+	})
+
+	t.Run("missing names and filter", func(t *testing.T) {
+		var out structs.IndexedServicesCheckServiceNodes
+		req := structs.ServicesSpecificRequest{Datacenter: "dc1"}
+		err := msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out)
+		require.ErrorContains(t, err, "Must provide service names or a filter")
+	})
+
+	t.Run("blocking", func(t *testing.T) {
+		var out structs.IndexedServicesCheckServiceNodes
+		req := structs.ServicesSpecificRequest{
+			Datacenter:   "dc1",
+			ServiceNames: []string{"db", "web"},
+		}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out))
+
+		// A change to any of the services unblocks the query.
+		codec2 := rpcClient(t, s1)
+		errCh := make(chan error, 1)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			errCh <- register(codec2, "baz", "127.0.0.3", "web", api.HealthPassing)
+		}()
+
+		req.MinQueryIndex = out.Index
+		req.MaxQueryTime = 5 * time.Second
+		start := time.Now()
+		var out2 structs.IndexedServicesCheckServiceNodes
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.ServicesNodes", &req, &out2))
+		require.Less(t, time.Since(start), req.MaxQueryTime)
+		require.Greater(t, out2.Index, out.Index)
+		require.ElementsMatch(t, []string{"bar", "baz"}, nodeNames(out2)["web"])
+		require.NoError(t, <-errCh)
+	})
+}
+
+func TestHealth_Summary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServer(t)
+	codec := rpcClient(t, s1)
+
+	waitForLeaderEstablishment(t, s1)
+
+	register := func(node, service string, statuses ...string) {
+		arg := structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      service,
+				Service: service,
+			},
+		}
+		for i, status := range statuses {
+			arg.Checks = append(arg.Checks, &structs.HealthCheck{
+				CheckID:   types.CheckID(fmt.Sprintf("%s-%d", service, i)),
+				Name:      "check",
+				Status:    status,
+				ServiceID: service,
+			})
+		}
+		var out struct{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Catalog.Register", &arg, &out))
+	}
+	register("foo", "db", api.HealthPassing)
+	register("bar", "db", api.HealthPassing, api.HealthWarning)
+	register("baz", "db", api.HealthWarning, api.HealthCritical)
+	register("foo", "web")
+
+	var out structs.IndexedServiceHealthSummaries
+	req := structs.DCSpecificRequest{Datacenter: "dc1"}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.Summary", &req, &out))
+	require.NotZero(t, out.Index)
+
+	// The server registers the consul service itself.
+	require.Len(t, out.Summaries, 3)
+	require.Equal(t, "consul", out.Summaries[0].Name)
+	entMeta := *structs.DefaultEnterpriseMetaInDefaultPartition()
+	require.Equal(t, &structs.ServiceHealthSummary{
+		Name:           "db",
+		Passing:        1,
+		Warning:        1,
+		Critical:       1,
+		EnterpriseMeta: entMeta,
+	}, out.Summaries[1])
+	require.Equal(t, &structs.ServiceHealthSummary{
+		Name:           "web",
+		Passing:        1,
+		EnterpriseMeta: entMeta,
+	}, out.Summaries[2])
+
+	// Filters apply to the instances before they are counted.
+	req.Filter = "Node.Node != baz"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Health.Summary", &req, &out))
+	require.Len(t, out.Summaries, 3)
+	require.Equal(t, 1, out.Summaries[1].Passing)
+	require.Equal(t, 1, out.Summaries[1].Warning)
+	require.Zero(t, out.Summaries[1].Critical)
+}
+
 func TestHealth_ServiceNodes_BlockingQuery_withFilter(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
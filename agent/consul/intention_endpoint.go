@@ -10,7 +10,6 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
-	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	hashstructure_v2 "github.com/mitchellh/hashstructure/v2"
@@ -32,6 +31,15 @@ var IntentionSummaries = []prometheus.SummaryDefinition{
 	},
 }
 
+var IntentionCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyIntentionDecisions,
+		Help: "Increments by the number of connections allowed or denied to a destination service, as reported by the agents. Emitted by the leader.",
+	},
+}
+
+var metricsKeyIntentionDecisions = []string{"intentions", "decisions"}
+
 var (
 	// ErrIntentionNotFound is returned if the intention lookup failed.
 	ErrIntentionNotFound = errors.New("Intention not found")
@@ -152,6 +160,10 @@ func (s *Intention) Apply(args *structs.IntentionRequest, reply *string) error {
 		return nil // short circuit
 	}
 
+	if err := s.srv.checkIntentionMutationQuota(args.Op, mut); err != nil {
+		return err
+	}
+
 	if legacyWrite {
 		*reply = args.Intention.ID
 	} else {
@@ -185,6 +197,9 @@ func (s *Intention) computeApplyChangesLegacyCreate(
 			"accessorID", acl.AliasIfAnonymousToken(accessorID))
 		return nil, acl.ErrPermissionDenied
 	}
+	if err := s.checkConfigEntryWrite(accessorID, authz, args.Intention.DestinationServiceName()); err != nil {
+		return nil, err
+	}
 
 	// If no ID is provided, generate a new ID. This must be done prior to
 	// appending to the Raft log, because the ID is not deterministic. Once
@@ -257,6 +272,9 @@ func (s *Intention) computeApplyChangesLegacyUpdate(
 			"accessorID", acl.AliasIfAnonymousToken(accessorID))
 		return nil, acl.ErrPermissionDenied
 	}
+	if err := s.checkConfigEntryWrite(accessorID, authz, ixn.DestinationServiceName()); err != nil {
+		return nil, err
+	}
 
 	args.Intention.FillPartitionAndNamespace(entMeta, true)
 
@@ -320,6 +338,9 @@ func (s *Intention) computeApplyChangesUpsert(
 			"accessorID", acl.AliasIfAnonymousToken(accessorID))
 		return nil, acl.ErrPermissionDenied
 	}
+	if err := s.checkConfigEntryWrite(accessorID, authz, args.Intention.DestinationServiceName()); err != nil {
+		return nil, err
+	}
 
 	_, prevEntry, err := s.srv.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, args.Intention.DestinationName, args.Intention.DestinationEnterpriseMeta())
 	if err != nil {
@@ -378,6 +399,9 @@ func (s *Intention) computeApplyChangesLegacyDelete(
 			"accessorID", acl.AliasIfAnonymousToken(accessorID))
 		return nil, acl.ErrPermissionDenied
 	}
+	if err := s.checkConfigEntryWrite(accessorID, authz, ixn.DestinationServiceName()); err != nil {
+		return nil, err
+	}
 
 	return &structs.IntentionMutation{
 		ID: args.Intention.ID,
@@ -401,6 +425,9 @@ func (s *Intention) computeApplyChangesDelete(
 			"accessorID", acl.AliasIfAnonymousToken(accessorID))
 		return nil, acl.ErrPermissionDenied
 	}
+	if err := s.checkConfigEntryWrite(accessorID, authz, args.Intention.DestinationServiceName()); err != nil {
+		return nil, err
+	}
 
 	// Pre-flight to avoid pointless raft operations.
 	exactIxn := args.Intention.ToExact()
@@ -418,6 +445,25 @@ func (s *Intention) computeApplyChangesDelete(
 	}, nil
 }
 
+// checkConfigEntryWrite returns an error if the authorizer isn't allowed to
+// write the service-intentions config entry of the destination, which is what
+// every intention write ends up modifying. This enforces the config_entry
+// rules on top of the intention permissions checked by the callers.
+func (s *Intention) checkConfigEntryWrite(accessorID string, authz acl.Authorizer, dest structs.ServiceName) error {
+	entry := &structs.ServiceIntentionsConfigEntry{
+		Kind:           structs.ServiceIntentions,
+		Name:           dest.Name,
+		EnterpriseMeta: dest.EnterpriseMeta,
+	}
+	if err := structs.CanWriteConfigEntry(entry, authz); err != nil {
+		s.logger.Debug("Intention write denied due to config entry ACLs",
+			"destination", dest.String(),
+			"accessorID", acl.AliasIfAnonymousToken(accessorID))
+		return err
+	}
+	return nil
+}
+
 // Get returns a single intention by ID.
 func (s *Intention) Get(args *structs.IntentionQueryRequest, reply *structs.IndexedIntentions) error {
 	// Exit early if Connect hasn't been enabled.
@@ -508,7 +554,7 @@ func (s *Intention) List(args *structs.IntentionListRequest, reply *structs.Inde
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.Intentions)
+	filter, err := s.srv.filterCache.Get(args.Filter, reply.Intentions)
 	if err != nil {
 		return err
 	}
@@ -556,6 +602,17 @@ func (s *Intention) List(args *structs.IntentionListRequest, reply *structs.Inde
 			}
 			reply.Intentions = raw.(structs.Intentions)
 
+			if args.ExpiringWithin > 0 {
+				now := time.Now()
+				due := make(structs.Intentions, 0, len(reply.Intentions))
+				for _, ixn := range reply.Intentions {
+					if ixn.DueWithin(now, args.ExpiringWithin) {
+						due = append(due, ixn)
+					}
+				}
+				reply.Intentions = due
+			}
+
 			// Note: we filter the results with ACLs *after* applying the user-supplied
 			// bexpr filter, to ensure QueryMeta.ResultsFilteredByACLs does not include
 			// results that would be filtered out even if the user did have permission.
@@ -636,7 +693,7 @@ func (s *Intention) Match(args *structs.IntentionQueryRequest, reply *structs.In
 		&args.QueryOptions,
 		&reply.QueryMeta,
 		func(ws memdb.WatchSet, state *state.Store) error {
-			index, matches, err := state.IntentionMatch(ws, args.Match)
+			index, matches, err := s.srv.intentionGraph.Match(ws, state, args.Match)
 			if err != nil {
 				return err
 			}
@@ -754,28 +811,17 @@ func (s *Intention) Check(args *structs.IntentionQueryRequest, reply *structs.In
 
 	defaultAllow := DefaultIntentionAllow(authz, s.srv.config.DefaultIntentionPolicy)
 
-	store := s.srv.fsm.State()
-
-	entry := structs.IntentionMatchEntry{
+	source := structs.IntentionMatchEntry{
 		Namespace: query.SourceNS,
 		Partition: query.SourcePartition,
 		Name:      query.SourceName,
 	}
-	_, intentions, err := store.IntentionMatchOne(nil, entry, structs.IntentionMatchSource, structs.IntentionTargetService)
-	if err != nil {
-		return fmt.Errorf("failed to query intentions for %s/%s", query.SourceNS, query.SourceName)
+	destination := structs.IntentionMatchEntry{
+		Namespace: query.DestinationNS,
+		Partition: query.DestinationPartition,
+		Name:      query.DestinationName,
 	}
-
-	opts := state.IntentionDecisionOpts{
-		Target:           query.DestinationName,
-		Namespace:        query.DestinationNS,
-		Partition:        query.DestinationPartition,
-		Intentions:       intentions,
-		MatchType:        structs.IntentionMatchDestination,
-		DefaultAllow:     defaultAllow,
-		AllowPermissions: false,
-	}
-	decision, err := store.IntentionDecision(opts)
+	decision, err := s.srv.intentionGraph.Decision(s.srv.fsm.State(), source, destination, defaultAllow)
 	if err != nil {
 		return fmt.Errorf("failed to get intention decision from (%s/%s) to (%s/%s): %v",
 			query.SourceNS, query.SourceName, query.DestinationNS, query.DestinationName, err)
@@ -785,6 +831,41 @@ func (s *Intention) Check(args *structs.IntentionQueryRequest, reply *structs.In
 	return nil
 }
 
+// ReportDecisions aggregates the authorization decisions reported by an agent
+// into the decisions metric, labelled by destination service and result.
+func (s *Intention) ReportDecisions(args *structs.IntentionDecisionsRequest, reply *struct{}) error {
+	if done, err := s.srv.ForwardRPC("Intention.ReportDecisions", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := s.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+
+	if err := s.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	if err := authz.ToAllowAuthorizer().NodeWriteAllowed(args.Node, &authzContext); err != nil {
+		return err
+	}
+
+	for _, d := range args.Decisions {
+		labels := []metrics.Label{{Name: "destination", Value: d.Destination.String()}}
+		if d.Allowed > 0 {
+			metrics.IncrCounterWithLabels(metricsKeyIntentionDecisions, float32(d.Allowed),
+				append(labels, metrics.Label{Name: "result", Value: "allow"}))
+		}
+		if d.Denied > 0 {
+			metrics.IncrCounterWithLabels(metricsKeyIntentionDecisions, float32(d.Denied),
+				append(labels, metrics.Label{Name: "result", Value: "deny"}))
+		}
+	}
+	return nil
+}
+
 func (s *Intention) validateEnterpriseIntention(ixn *structs.Intention) error {
 	if err := s.srv.validateEnterpriseIntentionPartition(ixn.SourcePartition); err != nil {
 		return fmt.Errorf("Invalid source partition %q: %v", ixn.SourcePartition, err)
@@ -959,6 +959,73 @@ service "foobar" {
 	}
 }
 
+func TestIntentionApply_aclConfigEntryDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	waitForLeaderEstablishment(t, s1)
+
+	rules := `
+service_prefix "" {
+	policy = "deny"
+	intentions = "write"
+}
+config_entry "service-intentions/foobar" {
+	policy = "deny"
+}`
+	token := createToken(t, codec, rules)
+
+	// Create an intention to update and delete with the management token.
+	ixn := structs.IntentionRequest{
+		Datacenter:   "dc1",
+		Op:           structs.IntentionOpCreate,
+		Intention:    structs.TestIntention(t),
+		WriteRequest: structs.WriteRequest{Token: "root"},
+	}
+	ixn.Intention.DestinationName = "foobar"
+	var id string
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Intention.Apply", &ixn, &id))
+
+	// The config_entry deny rule takes precedence over intentions:write, for
+	// both the legacy and the by-name operations.
+	ixn.WriteRequest.Token = token
+	ixn.Intention = structs.TestIntention(t)
+	ixn.Intention.SourceName = "web"
+	ixn.Intention.DestinationName = "foobar"
+	var reply string
+	err := msgpackrpc.CallWithCodec(codec, "Intention.Apply", &ixn, &reply)
+	require.True(t, acl.IsErrPermissionDenied(err), "err: %v", err)
+
+	ixn.Op = structs.IntentionOpUpsert
+	err = msgpackrpc.CallWithCodec(codec, "Intention.Apply", &ixn, &reply)
+	require.True(t, acl.IsErrPermissionDenied(err), "err: %v", err)
+
+	ixn.Op = structs.IntentionOpDelete
+	ixn.Intention = &structs.Intention{ID: id}
+	err = msgpackrpc.CallWithCodec(codec, "Intention.Apply", &ixn, &reply)
+	require.True(t, acl.IsErrPermissionDenied(err), "err: %v", err)
+
+	// Other destinations are still subject to the intention permissions only.
+	ixn.Op = structs.IntentionOpUpsert
+	ixn.Intention = structs.TestIntention(t)
+	ixn.Intention.DestinationName = "baz"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Intention.Apply", &ixn, &reply))
+}
+
 func TestIntention_WildcardACLEnforcement(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1620,6 +1687,57 @@ func TestIntentionList(t *testing.T) {
 	}
 }
 
+func TestIntentionList_ExpiringWithin(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, s1 := testServer(t)
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+	waitForLeaderEstablishment(t, s1)
+
+	now := time.Now()
+	overdue := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(72 * time.Hour)
+
+	var ok bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: "web",
+			Sources: []*structs.SourceIntention{
+				{Name: "overdue", Action: structs.IntentionActionAllow, ReviewBy: &overdue},
+				{Name: "soon", Action: structs.IntentionActionAllow, ExpiresAt: &soon},
+				{Name: "later", Action: structs.IntentionActionAllow, ReviewBy: &later},
+				{Name: "never", Action: structs.IntentionActionAllow},
+			},
+		},
+	}, &ok))
+	require.True(t, ok)
+
+	list := func(within time.Duration) []string {
+		var resp structs.IndexedIntentions
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Intention.List", &structs.IntentionListRequest{
+			Datacenter:     "dc1",
+			ExpiringWithin: within,
+		}, &resp))
+		var names []string
+		for _, ixn := range resp.Intentions {
+			names = append(names, ixn.SourceName)
+		}
+		return names
+	}
+
+	require.ElementsMatch(t, []string{"overdue", "soon", "later", "never"}, list(0))
+	require.ElementsMatch(t, []string{"overdue", "soon"}, list(24*time.Hour))
+	require.ElementsMatch(t, []string{"overdue", "soon", "later"}, list(96*time.Hour))
+}
+
 // Test listing with ACLs
 func TestIntentionList_acl(t *testing.T) {
 	if testing.Short() {
@@ -2087,6 +2205,44 @@ service "bar" {
 	require.True(t, acl.IsErrPermissionDenied(err))
 }
 
+func TestIntentionReportDecisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	waitForLeaderEstablishment(t, s1)
+
+	token := createToken(t, codec, `node "node1" { policy = "write" }`)
+	report := func(node string) error {
+		req := &structs.IntentionDecisionsRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Decisions: []structs.IntentionDecisionCount{
+				{Destination: structs.NewServiceName("db", nil), Allowed: 3, Denied: 1},
+			},
+			WriteRequest: structs.WriteRequest{Token: token},
+		}
+		var reply struct{}
+		return msgpackrpc.CallWithCodec(codec, "Intention.ReportDecisions", req, &reply)
+	}
+
+	require.NoError(t, report("node1"))
+	require.True(t, acl.IsErrPermissionDenied(report("node2")))
+}
+
 // Test the Check method returns allow/deny properly.
 func TestIntentionCheck_match(t *testing.T) {
 	if testing.Short() {
@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+var (
+	metricsKeyIntentionGraphRebuild = []string{"intention", "graph", "rebuild"}
+	metricsKeyIntentionGraphEntries = []string{"intention", "graph", "entries"}
+)
+
+var IntentionGraphSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: metricsKeyIntentionGraphRebuild,
+		Help: "Measures the time it takes to bring the intention graph up to date after intentions change, labelled by whether the whole graph or only the changed destinations were evicted.",
+	},
+}
+
+var IntentionGraphGauges = []prometheus.GaugeDefinition{
+	{
+		Name: metricsKeyIntentionGraphEntries,
+		Help: "The number of intention match results and authorization decisions held in the intention graph.",
+	},
+}
+
+// intentionGraph is a server-side table of intention match results and
+// source to destination authorization decisions. Entries are computed from
+// the state store on first use and kept until a write invalidates them, so
+// repeated Intention.Match and Intention.Check requests for the same services
+// are answered with a map lookup instead of walking the service-intentions
+// config entries.
+//
+// The table is brought up to date with the state store before every lookup.
+// Only the entries for destinations whose service-intentions config entry
+// changed are evicted. Changes that can affect every result, such as a
+// sameness group update or a snapshot restore, evict the whole table. While
+// intentions are still stored in the legacy table nothing is cached.
+type intentionGraph struct {
+	lock sync.Mutex
+
+	// store and deps identify the state the table is currently valid for.
+	store       *state.Store
+	deps        *state.IntentionDependencies
+	syncedIndex uint64
+
+	// generation is incremented whenever the dependencies change. Results
+	// computed while it moved are not inserted as they may be stale.
+	generation uint64
+
+	decisions map[intentionGraphDecisionKey]structs.IntentionDecisionSummary
+	matches   map[intentionGraphMatchKey]structs.Intentions
+}
+
+type intentionGraphDecisionKey struct {
+	source       structs.ServiceName
+	destination  structs.ServiceName
+	defaultAllow bool
+}
+
+type intentionGraphMatchKey struct {
+	matchType          structs.IntentionMatchType
+	service            structs.ServiceName
+	withSamenessGroups bool
+}
+
+func newIntentionGraph() *intentionGraph {
+	return &intentionGraph{
+		decisions: make(map[intentionGraphDecisionKey]structs.IntentionDecisionSummary),
+		matches:   make(map[intentionGraphMatchKey]structs.Intentions),
+	}
+}
+
+// Decision returns whether source is allowed to connect to destination. L7
+// intentions are treated as a deny, as they are for Intention.Check.
+func (g *intentionGraph) Decision(
+	store *state.Store,
+	source, destination structs.IntentionMatchEntry,
+	defaultAllow bool,
+) (structs.IntentionDecisionSummary, error) {
+	deps, generation, err := g.sync(nil, store)
+	if err != nil {
+		return structs.IntentionDecisionSummary{}, err
+	}
+
+	key := intentionGraphDecisionKey{
+		source:       structs.NewServiceName(source.Name, source.GetEnterpriseMeta()),
+		destination:  structs.NewServiceName(destination.Name, destination.GetEnterpriseMeta()),
+		defaultAllow: defaultAllow,
+	}
+	if !deps.Legacy {
+		g.lock.Lock()
+		decision, ok := g.decisions[key]
+		g.lock.Unlock()
+		if ok {
+			return decision, nil
+		}
+	}
+
+	_, intentions, err := store.IntentionMatchOne(nil, source, structs.IntentionMatchSource, structs.IntentionTargetService)
+	if err != nil {
+		return structs.IntentionDecisionSummary{}, err
+	}
+	decision, err := store.IntentionDecision(state.IntentionDecisionOpts{
+		Target:           destination.Name,
+		Namespace:        destination.Namespace,
+		Partition:        destination.Partition,
+		Intentions:       intentions,
+		MatchType:        structs.IntentionMatchDestination,
+		DefaultAllow:     defaultAllow,
+		AllowPermissions: false,
+	})
+	if err != nil {
+		return structs.IntentionDecisionSummary{}, err
+	}
+
+	if !deps.Legacy {
+		g.lock.Lock()
+		if g.generation == generation {
+			g.decisions[key] = decision
+			g.setEntriesGauge()
+		}
+		g.lock.Unlock()
+	}
+	return decision, nil
+}
+
+// Match returns the intentions matching each entry of args, in the same form
+// as state.Store.IntentionMatch. Watches that fire when the result may have
+// changed are added to ws.
+func (g *intentionGraph) Match(
+	ws memdb.WatchSet,
+	store *state.Store,
+	args *structs.IntentionQueryMatch,
+) (uint64, []structs.Intentions, error) {
+	deps, generation, err := g.sync(ws, store)
+	if err != nil {
+		return 0, nil, err
+	}
+	if deps.Legacy {
+		return store.IntentionMatch(ws, args)
+	}
+
+	index := deps.Index
+	if index < 1 {
+		index = 1
+	}
+
+	results := make([]structs.Intentions, len(args.Entries))
+	var misses []int
+	g.lock.Lock()
+	for i, entry := range args.Entries {
+		ixns, ok := g.matches[g.matchKey(args, entry)]
+		if !ok {
+			misses = append(misses, i)
+			continue
+		}
+		results[i] = ixns
+	}
+	g.lock.Unlock()
+
+	if len(misses) == 0 {
+		return index, results, nil
+	}
+
+	query := &structs.IntentionQueryMatch{
+		Type:               args.Type,
+		WithSamenessGroups: args.WithSamenessGroups,
+	}
+	for _, i := range misses {
+		query.Entries = append(query.Entries, args.Entries[i])
+	}
+	_, matches, err := store.IntentionMatch(nil, query)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for j, i := range misses {
+		results[i] = matches[j]
+		if g.generation == generation {
+			g.matches[g.matchKey(args, args.Entries[i])] = matches[j]
+		}
+	}
+	g.setEntriesGauge()
+
+	return index, results, nil
+}
+
+func (g *intentionGraph) matchKey(args *structs.IntentionQueryMatch, entry structs.IntentionMatchEntry) intentionGraphMatchKey {
+	return intentionGraphMatchKey{
+		matchType:          args.Type,
+		service:            structs.NewServiceName(entry.Name, entry.GetEnterpriseMeta()),
+		withSamenessGroups: args.WithSamenessGroups,
+	}
+}
+
+// sync brings the table up to date with store, evicting the entries that
+// writes since the previous sync may have invalidated. It returns the
+// dependencies the table is now valid for and the current generation.
+func (g *intentionGraph) sync(ws memdb.WatchSet, store *state.Store) (*state.IntentionDependencies, uint64, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.store == store && g.deps != nil {
+		if store.IntentionDependenciesIndex(ws, g.deps.Catalog) == g.syncedIndex {
+			return g.deps, g.generation, nil
+		}
+	}
+
+	start := time.Now()
+
+	// Watch the catalog too, since the new dependencies may rely on it for
+	// the first time. The watches are added before the dependencies are
+	// read so that no write in between can be missed.
+	store.IntentionDependenciesIndex(ws, true)
+	deps, err := store.IntentionDependencies()
+	if err != nil {
+		return nil, 0, err
+	}
+	syncedIndex := deps.Index
+	if deps.Catalog && deps.CatalogIndex > syncedIndex {
+		syncedIndex = deps.CatalogIndex
+	}
+
+	full := g.store != store || g.requiresFullEviction(deps)
+	if full {
+		clear(g.decisions)
+		clear(g.matches)
+	} else {
+		g.evictChangedDestinations(deps)
+	}
+
+	g.store = store
+	g.deps = deps
+	g.syncedIndex = syncedIndex
+	g.generation++
+
+	rebuild := "incremental"
+	if full {
+		rebuild = "full"
+	}
+	metrics.MeasureSinceWithLabels(metricsKeyIntentionGraphRebuild, start,
+		[]metrics.Label{{Name: "type", Value: rebuild}})
+	g.setEntriesGauge()
+
+	return deps, g.generation, nil
+}
+
+// requiresFullEviction reports whether the change from the current
+// dependencies to deps can affect results for any service.
+func (g *intentionGraph) requiresFullEviction(deps *state.IntentionDependencies) bool {
+	prev := g.deps
+	switch {
+	case prev == nil, prev.Legacy || deps.Legacy:
+		return true
+	case (prev.Catalog || deps.Catalog) && prev.CatalogIndex != deps.CatalogIndex:
+		return true
+	case len(prev.Shared) != len(deps.Shared):
+		return true
+	}
+	for kn, idx := range deps.Shared {
+		if prevIdx, ok := prev.Shared[kn]; !ok || prevIdx != idx {
+			return true
+		}
+	}
+	return false
+}
+
+// evictChangedDestinations removes the entries that depend on a
+// service-intentions config entry that was created, modified or deleted
+// since the previous sync.
+func (g *intentionGraph) evictChangedDestinations(deps *state.IntentionDependencies) {
+	var changed []structs.ServiceName
+	for sn, idx := range deps.Destinations {
+		if prevIdx, ok := g.deps.Destinations[sn]; !ok || prevIdx != idx {
+			changed = append(changed, sn)
+		}
+	}
+	for sn := range g.deps.Destinations {
+		if _, ok := deps.Destinations[sn]; !ok {
+			changed = append(changed, sn)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	for key := range g.decisions {
+		if intentionDestinationCoversAny(changed, key.destination) {
+			delete(g.decisions, key)
+		}
+	}
+	for key := range g.matches {
+		// Any service could be a source of the changed intentions.
+		if key.matchType == structs.IntentionMatchSource || intentionDestinationCoversAny(changed, key.service) {
+			delete(g.matches, key)
+		}
+	}
+}
+
+func (g *intentionGraph) setEntriesGauge() {
+	metrics.SetGauge(metricsKeyIntentionGraphEntries, float32(len(g.decisions)+len(g.matches)))
+}
+
+// intentionDestinationCoversAny reports whether intentions for any of the
+// destinations can apply to the service sn.
+func intentionDestinationCoversAny(destinations []structs.ServiceName, sn structs.ServiceName) bool {
+	for _, dst := range destinations {
+		if intentionDestinationCovers(dst, sn) {
+			return true
+		}
+	}
+	return false
+}
+
+// intentionDestinationCovers reports whether intentions for the destination
+// dst can apply to the service sn. Wildcards on either side are treated as a
+// match.
+func intentionDestinationCovers(dst, sn structs.ServiceName) bool {
+	covers := func(a, b string) bool {
+		return a == b || a == acl.WildcardName || b == acl.WildcardName
+	}
+	return covers(dst.PartitionOrDefault(), sn.PartitionOrDefault()) &&
+		covers(dst.NamespaceOrDefault(), sn.NamespaceOrDefault()) &&
+		covers(dst.Name, sn.Name)
+}
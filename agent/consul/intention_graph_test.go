@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestIntentionGraph(t *testing.T) {
+	store := state.NewStateStore(nil)
+	require.NoError(t, store.SystemMetadataSet(1, &structs.SystemMetadataEntry{
+		Key:   structs.SystemMetadataIntentionFormatKey,
+		Value: structs.SystemMetadataIntentionFormatConfigValue,
+	}))
+
+	setIntention := func(idx uint64, destination, source string, action structs.IntentionAction) {
+		t.Helper()
+		conf := &structs.ServiceIntentionsConfigEntry{
+			Kind: structs.ServiceIntentions,
+			Name: destination,
+			Sources: []*structs.SourceIntention{
+				{Name: source, Action: action},
+			},
+		}
+		require.NoError(t, conf.Normalize())
+		require.NoError(t, store.EnsureConfigEntry(idx, conf))
+	}
+	entry := func(name string) structs.IntentionMatchEntry {
+		return structs.IntentionMatchEntry{
+			Namespace: acl.DefaultNamespaceName,
+			Partition: acl.DefaultPartitionName,
+			Name:      name,
+		}
+	}
+
+	setIntention(2, "api", "web", structs.IntentionActionAllow)
+	setIntention(3, "db", "web", structs.IntentionActionDeny)
+
+	g := newIntentionGraph()
+
+	decision, err := g.Decision(store, entry("web"), entry("api"), false)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = g.Decision(store, entry("web"), entry("db"), true)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+
+	index, matches, err := g.Match(nil, store, &structs.IntentionQueryMatch{
+		Type:    structs.IntentionMatchDestination,
+		Entries: []structs.IntentionMatchEntry{entry("api"), entry("db")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), index)
+	require.Len(t, matches, 2)
+	require.Len(t, matches[0], 1)
+	require.Equal(t, "web", matches[0][0].SourceName)
+	require.Len(t, g.decisions, 2)
+	require.Len(t, g.matches, 2)
+
+	// Writes that don't affect intentions keep the table.
+	require.NoError(t, store.EnsureConfigEntry(4, &structs.ProxyConfigEntry{
+		Kind: structs.ProxyDefaults,
+		Name: structs.ProxyConfigGlobal,
+	}))
+	_, _, err = g.sync(nil, store)
+	require.NoError(t, err)
+	require.Len(t, g.decisions, 2)
+	require.Len(t, g.matches, 2)
+
+	// Changing the intentions for db only evicts the entries for db.
+	setIntention(5, "db", "web", structs.IntentionActionAllow)
+	decision, err = g.Decision(store, entry("web"), entry("db"), true)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Contains(t, g.decisions, intentionGraphDecisionKey{
+		source:       structs.NewServiceName("web", nil),
+		destination:  structs.NewServiceName("api", nil),
+		defaultAllow: false,
+	})
+	require.Contains(t, g.matches, intentionGraphMatchKey{
+		matchType: structs.IntentionMatchDestination,
+		service:   structs.NewServiceName("api", nil),
+	})
+	require.NotContains(t, g.matches, intentionGraphMatchKey{
+		matchType: structs.IntentionMatchDestination,
+		service:   structs.NewServiceName("db", nil),
+	})
+
+	// Wildcard destinations evict every entry they could apply to.
+	setIntention(6, "*", "web", structs.IntentionActionDeny)
+	_, _, err = g.sync(nil, store)
+	require.NoError(t, err)
+	require.Empty(t, g.decisions)
+	require.Empty(t, g.matches)
+
+	// A restored snapshot evicts the whole table.
+	decision, err = g.Decision(store, entry("web"), entry("api"), false)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Len(t, g.decisions, 1)
+
+	restored := state.NewStateStore(nil)
+	_, _, err = g.sync(nil, restored)
+	require.NoError(t, err)
+	require.Empty(t, g.decisions)
+
+	// Nothing is cached while intentions are stored in the legacy table.
+	decision, err = g.Decision(restored, entry("web"), entry("api"), true)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Empty(t, g.decisions)
+}
+
+func TestIntentionDestinationCovers(t *testing.T) {
+	api := structs.NewServiceName("api", nil)
+	require.True(t, intentionDestinationCovers(api, api))
+	require.True(t, intentionDestinationCovers(structs.NewServiceName("*", nil), api))
+	require.True(t, intentionDestinationCovers(api, structs.NewServiceName("*", nil)))
+	require.False(t, intentionDestinationCovers(structs.NewServiceName("db", nil), api))
+}
@@ -6,8 +6,8 @@ package consul
 import (
 	"fmt"
 	"net"
+	"time"
 
-	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/serf/serf"
@@ -66,7 +66,7 @@ func (m *Internal) NodeDump(args *structs.DCSpecificRequest,
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.Dump)
+	filter, err := m.srv.filterCache.Get(args.Filter, reply.Dump)
 	if err != nil {
 		return err
 	}
@@ -150,7 +150,7 @@ func (m *Internal) ServiceDump(args *structs.ServiceDumpRequest, reply *structs.
 		return err
 	}
 
-	filter, err := bexpr.CreateFilter(args.Filter, nil, reply.Nodes)
+	filter, err := m.srv.filterCache.Get(args.Filter, reply.Nodes)
 	if err != nil {
 		return err
 	}
@@ -323,6 +323,58 @@ func (m *Internal) ServiceTopology(args *structs.ServiceSpecificRequest, reply *
 		})
 }
 
+// MeshTrafficMetrics returns the rollups of the requests made by a service to
+// its upstreams and by its downstreams to the service, as reported by the
+// dataplanes. The rollups are held by the leader, so the request is always
+// forwarded to it.
+func (m *Internal) MeshTrafficMetrics(args *structs.ServiceSpecificRequest, reply *structs.IndexedMeshTrafficMetrics) error {
+	args.AllowStale = false
+	if done, err := m.srv.ForwardRPC("Internal.MeshTrafficMetrics", args, reply); done {
+		return err
+	}
+	if args.ServiceName == "" {
+		return fmt.Errorf("Must provide a service name")
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := m.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := m.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().ServiceReadAllowed(args.ServiceName, &authzContext); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	service := structs.NewServiceName(args.ServiceName, &args.EnterpriseMeta)
+	reply.Upstreams = filterMeshTrafficRollups(authz, m.srv.meshMetrics.Upstreams(now, service), func(r structs.MeshTrafficRollup) structs.ServiceName {
+		return r.Upstream
+	})
+	reply.Downstreams = filterMeshTrafficRollups(authz, m.srv.meshMetrics.Downstreams(now, service), func(r structs.MeshTrafficRollup) structs.ServiceName {
+		return r.Source
+	})
+	return nil
+}
+
+// filterMeshTrafficRollups drops the rollups of the services the token cannot
+// read.
+func filterMeshTrafficRollups(authz acl.Authorizer, rollups []structs.MeshTrafficRollup, other func(structs.MeshTrafficRollup) structs.ServiceName) []structs.MeshTrafficRollup {
+	result := make([]structs.MeshTrafficRollup, 0, len(rollups))
+	for _, r := range rollups {
+		sn := other(r)
+		var authzContext acl.AuthorizerContext
+		sn.FillAuthzContext(&authzContext)
+		if authz.ServiceRead(sn.Name, &authzContext) != acl.Allow {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
 // IntentionUpstreams returns a service's upstreams which are inferred from intentions.
 // If intentions allow a connection from the target to some candidate service, the candidate service is considered
 // an upstream of the target.
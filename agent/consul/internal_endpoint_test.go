@@ -19,6 +19,7 @@ import (
 	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/meshmetrics"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/lib/stringslice"
@@ -2508,6 +2509,67 @@ func TestInternal_ServiceTopology_DefaultIntentionPolicy(t *testing.T) {
 	require.False(t, out.ServiceTopology.DownstreamDecisions[webSN.String()].DefaultAllow)
 }
 
+func TestInternal_MeshTrafficMetrics_ACL(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = TestDefaultInitialManagementToken
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	var (
+		web = structs.NewServiceName("web", nil)
+		api = structs.NewServiceName("api", nil)
+		db  = structs.NewServiceName("db", nil)
+		now = time.Now()
+	)
+	sample := meshmetrics.Sample{Interval: 10 * time.Second, Requests: 100}
+	s1.meshMetrics.Record(now, web, structs.PeeredServiceName{ServiceName: api}, sample)
+	s1.meshMetrics.Record(now, api, structs.PeeredServiceName{ServiceName: db}, sample)
+
+	// Token grants read to api and web, but not db.
+	userToken, err := upsertTestTokenWithPolicyRules(codec, TestDefaultInitialManagementToken, "dc1", `
+service "api" { policy = "read" }
+service "web" { policy = "read" }
+`)
+	require.NoError(t, err)
+
+	t.Run("rollups filtered", func(t *testing.T) {
+		args := structs.ServiceSpecificRequest{
+			Datacenter:   "dc1",
+			ServiceName:  "api",
+			QueryOptions: structs.QueryOptions{Token: userToken.SecretID},
+		}
+		var out structs.IndexedMeshTrafficMetrics
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Internal.MeshTrafficMetrics", &args, &out))
+		require.Empty(t, out.Upstreams)
+		require.Len(t, out.Downstreams, 1)
+		require.Equal(t, web, out.Downstreams[0].Source)
+		require.InDelta(t, 10, out.Downstreams[0].RequestsPerSecond, 1)
+	})
+
+	t.Run("service not readable", func(t *testing.T) {
+		args := structs.ServiceSpecificRequest{
+			Datacenter:   "dc1",
+			ServiceName:  "db",
+			QueryOptions: structs.QueryOptions{Token: userToken.SecretID},
+		}
+		var out structs.IndexedMeshTrafficMetrics
+		err := msgpackrpc.CallWithCodec(codec, "Internal.MeshTrafficMetrics", &args, &out)
+		require.True(t, acl.IsErrPermissionDenied(err))
+	})
+}
+
 func TestInternal_IntentionUpstreams(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -0,0 +1,452 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+const (
+	// kvEncryptionProviderTimeout bounds the calls to the key provider.
+	kvEncryptionProviderTimeout = 10 * time.Second
+
+	// kvEncryptionRewrapBatchSize is the number of KV entries that are
+	// re-encrypted in a single Raft transaction.
+	kvEncryptionRewrapBatchSize = 64
+)
+
+var errKVEncryptionNotConfigured = errors.New("KV value is encrypted but KV encryption is not configured on this server")
+
+var errKVReservedPrefix = errors.New("KV values cannot start with the reserved prefix of the encrypted values")
+
+// kvEncryption seals KV values before they are written to Raft, and opens them
+// when they are read. It caches the data encryption keys once they have been
+// unwrapped by the provider.
+type kvEncryption struct {
+	config   kvencrypt.Config
+	provider kvencrypt.Provider
+
+	lock sync.RWMutex
+	keys map[string][]byte
+}
+
+// newKVEncryption returns the KV encryption for the given configuration, or nil
+// if it is disabled.
+func newKVEncryption(config kvencrypt.Config) (*kvEncryption, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+	provider, err := kvencrypt.NewProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	return &kvEncryption{
+		config:   config,
+		provider: provider,
+		keys:     make(map[string][]byte),
+	}, nil
+}
+
+// kvEncryptionKeyring returns the keyring stored in the state store, or nil if
+// it hasn't been initialized yet.
+func (s *Server) kvEncryptionKeyring() (*kvencrypt.Keyring, error) {
+	value, err := s.GetSystemMetadata(structs.SystemMetadataKVEncryptionKeyring)
+	if err != nil {
+		return nil, err
+	}
+	return kvencrypt.DecodeKeyring(value)
+}
+
+// kvEncryptionKey returns the unwrapped data encryption key with the given ID.
+func (s *Server) kvEncryptionKey(id string) ([]byte, error) {
+	e := s.kvEncryption
+	if e == nil {
+		return nil, errKVEncryptionNotConfigured
+	}
+
+	e.lock.RLock()
+	key, ok := e.keys[id]
+	e.lock.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return nil, err
+	}
+	wrapped := keyring.Key(id)
+	if wrapped == nil {
+		return nil, fmt.Errorf("unknown KV encryption key %q", id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kvEncryptionProviderTimeout)
+	defer cancel()
+	key, err = e.provider.UnwrapKey(ctx, wrapped.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	e.keys[id] = key
+	e.lock.Unlock()
+	return key, nil
+}
+
+// sealKVEntry encrypts the value of a KV operation which writes a value. The
+// entry is modified in place.
+func (s *Server) sealKVEntry(op api.KVOp, dirEnt *structs.DirEntry) error {
+	if s.kvEncryption == nil || len(dirEnt.Value) == 0 {
+		return nil
+	}
+	switch op {
+	case api.KVSet, api.KVCAS, api.KVLock, api.KVUnlock:
+	default:
+		return nil
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return err
+	}
+	if keyring == nil || keyring.ActiveKeyID == "" {
+		return fmt.Errorf("KV encryption keyring has not been initialized by the leader yet")
+	}
+	key, err := s.kvEncryptionKey(keyring.ActiveKeyID)
+	if err != nil {
+		return err
+	}
+	sealed, err := kvencrypt.Seal(keyring.ActiveKeyID, key, dirEnt.Value, []byte(dirEnt.Key))
+	if err != nil {
+		return err
+	}
+	dirEnt.Value = sealed
+	return nil
+}
+
+// kvEncryptionKeyExists returns whether the keyring holds the data encryption
+// key with the given ID.
+func (s *Server) kvEncryptionKeyExists(id string) (bool, error) {
+	if e := s.kvEncryption; e != nil {
+		e.lock.RLock()
+		_, ok := e.keys[id]
+		e.lock.RUnlock()
+		if ok {
+			return true, nil
+		}
+	}
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return false, err
+	}
+	return keyring != nil && keyring.Key(id) != nil, nil
+}
+
+// openKVEntry returns the entry with its value decrypted. The entry is copied
+// if it was encrypted, since it belongs to the state store.
+func (s *Server) openKVEntry(dirEnt *structs.DirEntry) (*structs.DirEntry, error) {
+	if dirEnt == nil {
+		return nil, nil
+	}
+	keyID, ok := kvencrypt.KeyID(dirEnt.Value)
+	if !ok {
+		return dirEnt, nil
+	}
+
+	// Values written before the prefix was reserved may start with it, only
+	// the ones naming a key of the keyring are sealed.
+	exists, err := s.kvEncryptionKeyExists(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return dirEnt, nil
+	}
+	key, err := s.kvEncryptionKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := kvencrypt.Open(key, dirEnt.Value, []byte(dirEnt.Key))
+	if err != nil {
+		return nil, err
+	}
+	opened := dirEnt.Clone()
+	opened.Value = plaintext
+	return opened, nil
+}
+
+// openKVEntries decrypts the values of the given entries, see openKVEntry.
+// The entries which can't be decrypted are returned as stored, so that a
+// single entry doesn't fail the whole list.
+func (s *Server) openKVEntries(ents structs.DirEntries) structs.DirEntries {
+	var opened structs.DirEntries
+	for i, ent := range ents {
+		o, err := s.openKVEntry(ent)
+		if err != nil {
+			s.logger.Warn("failed to decrypt KV value, returning it as stored", "key", ent.Key, "error", err)
+			o = ent
+		}
+		if o != ent && opened == nil {
+			opened = make(structs.DirEntries, len(ents))
+			copy(opened, ents[:i])
+		}
+		if opened != nil {
+			opened[i] = o
+		}
+	}
+	if opened == nil {
+		return ents
+	}
+	return opened
+}
+
+// openTxnResults decrypts the values of the KV results of a transaction.
+func (s *Server) openTxnResults(results structs.TxnResults) error {
+	for _, r := range results {
+		if r.KV == nil {
+			continue
+		}
+		opened, err := s.openKVEntry(r.KV)
+		if err != nil {
+			return err
+		}
+		r.KV = opened
+	}
+	return nil
+}
+
+// initializeKVEncryption creates the first data encryption key, if KV
+// encryption is enabled and the keyring is empty.
+func (s *Server) initializeKVEncryption(ctx context.Context) error {
+	if s.kvEncryption == nil {
+		return nil
+	}
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return err
+	}
+	if keyring != nil && keyring.ActiveKeyID != "" {
+		return nil
+	}
+	if _, err := s.rotateKVEncryptionKey(ctx); err != nil {
+		return fmt.Errorf("failed to initialize KV encryption keyring: %w", err)
+	}
+	s.logger.Info("initialized KV encryption keyring", "provider", s.kvEncryption.config.Provider)
+	return nil
+}
+
+// rotateKVEncryptionKey generates a new data encryption key and makes it the
+// active key, so that new values are sealed with it. Values sealed with the
+// previous keys remain readable, use rewrapKVEncryption to re-encrypt them.
+func (s *Server) rotateKVEncryptionKey(ctx context.Context) (*kvencrypt.Keyring, error) {
+	e := s.kvEncryption
+	if e == nil {
+		return nil, fmt.Errorf("KV encryption is not enabled")
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return nil, err
+	}
+	if keyring == nil {
+		keyring = &kvencrypt.Keyring{}
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	key, err := kvencrypt.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, kvEncryptionProviderTimeout)
+	defer cancel()
+	wrapped, err := e.provider.WrapKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring.Keys = append(keyring.Keys, &kvencrypt.WrappedKey{
+		ID:         id,
+		Provider:   e.config.Provider,
+		Ciphertext: wrapped,
+		CreateTime: time.Now().UTC(),
+	})
+	keyring.ActiveKeyID = id
+	if err := s.setKVEncryptionKeyring(keyring); err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	e.keys[id] = key
+	e.lock.Unlock()
+	return keyring, nil
+}
+
+func (s *Server) setKVEncryptionKeyring(keyring *kvencrypt.Keyring) error {
+	encoded, err := keyring.Encode()
+	if err != nil {
+		return err
+	}
+	return s.SetSystemMetadataKey(structs.SystemMetadataKVEncryptionKeyring, encoded)
+}
+
+// rewrapKVEncryption re-wraps all of the data encryption keys with the
+// provider's current key encryption key, e.g. after it was rotated in Vault or
+// KMS, and then re-encrypts every KV value which isn't sealed with the active
+// data encryption key, including values written before encryption was enabled.
+// The secret fields of the config entries are re-encrypted too. It returns the
+// number of re-encrypted values and config entries.
+func (s *Server) rewrapKVEncryption(ctx context.Context) (int, error) {
+	e := s.kvEncryption
+	if e == nil {
+		return 0, fmt.Errorf("KV encryption is not enabled")
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return 0, err
+	}
+	if keyring == nil || keyring.ActiveKeyID == "" {
+		return 0, fmt.Errorf("KV encryption keyring has not been initialized yet")
+	}
+
+	for _, wrapped := range keyring.Keys {
+		key, err := s.kvEncryptionKey(wrapped.ID)
+		if err != nil {
+			return 0, err
+		}
+		wrapCtx, cancel := context.WithTimeout(ctx, kvEncryptionProviderTimeout)
+		wrapped.Ciphertext, err = e.provider.WrapKey(wrapCtx, key)
+		cancel()
+		if err != nil {
+			return 0, err
+		}
+		wrapped.Provider = e.config.Provider
+	}
+	if err := s.setKVEncryptionKeyring(keyring); err != nil {
+		return 0, err
+	}
+
+	_, entries, err := s.fsm.State().KVSList(nil, "", structs.WildcardEnterpriseMetaInPartition(structs.WildcardSpecifier))
+	if err != nil {
+		return 0, err
+	}
+
+	var ops structs.TxnOps
+	rewrapped := 0
+	apply := func() error {
+		if len(ops) == 0 {
+			return nil
+		}
+		resp, err := s.raftApply(structs.TxnRequestType, &structs.TxnRequest{Ops: ops})
+		if err != nil {
+			return err
+		}
+		// Entries which were modified concurrently fail the CAS, which aborts
+		// the whole transaction. They were sealed with the active key by the
+		// concurrent write, so retry the batch one entry at a time.
+		if txnResp, ok := resp.(structs.TxnResponse); ok && len(txnResp.Errors) > 0 {
+			for _, op := range ops {
+				resp, err := s.raftApply(structs.TxnRequestType, &structs.TxnRequest{Ops: structs.TxnOps{op}})
+				if err != nil {
+					return err
+				}
+				if txnResp, ok := resp.(structs.TxnResponse); ok && len(txnResp.Errors) == 0 {
+					rewrapped++
+				}
+			}
+		} else {
+			rewrapped += len(ops)
+		}
+		ops = ops[:0]
+		return nil
+	}
+
+	for _, ent := range entries {
+		if err := ctx.Err(); err != nil {
+			return rewrapped, err
+		}
+		if keyID, ok := kvencrypt.KeyID(ent.Value); (ok && keyID == keyring.ActiveKeyID) || len(ent.Value) == 0 {
+			continue
+		}
+
+		opened, err := s.openKVEntry(ent)
+		if err != nil {
+			return rewrapped, err
+		}
+		dirEnt := opened.Clone()
+		if err := s.sealKVEntry(api.KVCAS, dirEnt); err != nil {
+			return rewrapped, err
+		}
+		ops = append(ops, &structs.TxnOp{
+			KV: &structs.TxnKVOp{Verb: api.KVCAS, DirEnt: *dirEnt},
+		})
+		if len(ops) == kvEncryptionRewrapBatchSize {
+			if err := apply(); err != nil {
+				return rewrapped, err
+			}
+		}
+	}
+	if err := apply(); err != nil {
+		return rewrapped, err
+	}
+
+	n, err := s.rewrapConfigEntryEncryption(ctx, keyring.ActiveKeyID)
+	return rewrapped + n, err
+}
+
+// kvEncryptionStatus returns the status of the KV encryption, including the
+// number of values sealed with each key.
+func (s *Server) kvEncryptionStatus() (*structs.KVEncryptionStatus, error) {
+	status := &structs.KVEncryptionStatus{}
+	if s.kvEncryption != nil {
+		status.Enabled = true
+		status.Provider = s.kvEncryption.config.Provider
+	}
+
+	keyring, err := s.kvEncryptionKeyring()
+	if err != nil {
+		return nil, err
+	}
+	index, entries, err := s.fsm.State().KVSList(nil, "", structs.WildcardEnterpriseMetaInPartition(structs.WildcardSpecifier))
+	if err != nil {
+		return nil, err
+	}
+	status.Index = index
+
+	counts := make(map[string]int)
+	for _, ent := range entries {
+		if keyID, ok := kvencrypt.KeyID(ent.Value); ok && keyring != nil && keyring.Key(keyID) != nil {
+			counts[keyID]++
+		} else if len(ent.Value) > 0 {
+			status.PlaintextEntries++
+		}
+	}
+
+	if keyring != nil {
+		status.ActiveKeyID = keyring.ActiveKeyID
+		for _, key := range keyring.Keys {
+			status.Keys = append(status.Keys, structs.KVEncryptionKey{
+				ID:         key.ID,
+				Provider:   key.Provider,
+				CreateTime: key.CreateTime,
+				Active:     key.ID == keyring.ActiveKeyID,
+				Entries:    counts[key.ID],
+			})
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,394 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestKVEncryption(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	kek, err := kvencrypt.GenerateKey()
+	require.NoError(t, err)
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.KVEncryption = kvencrypt.Config{
+			Provider: kvencrypt.ProviderStatic,
+			Static:   kvencrypt.StaticConfig{Key: base64.StdEncoding.EncodeToString(kek)},
+		}
+	})
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	status := func(t require.TestingT) structs.KVEncryptionStatus {
+		var out structs.KVEncryptionStatus
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionStatus", &structs.DCSpecificRequest{Datacenter: "dc1"}, &out))
+		return out
+	}
+	var firstKeyID string
+	retry.Run(t, func(r *retry.R) {
+		out := status(r)
+		require.True(r, out.Enabled)
+		require.NotEmpty(r, out.ActiveKeyID)
+		firstKeyID = out.ActiveKeyID
+	})
+
+	// A value written before encryption was enabled is stored as is.
+	_, err = s1.raftApply(structs.KVSRequestType, &structs.KVSRequest{
+		Op:     api.KVSet,
+		DirEnt: structs.DirEntry{Key: "legacy", Value: []byte("plaintext")},
+	})
+	require.NoError(t, err)
+
+	for _, key := range []string{"secret/a", "secret/b"} {
+		var ok bool
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &structs.KVSRequest{
+			Datacenter: "dc1",
+			Op:         api.KVSet,
+			DirEnt:     structs.DirEntry{Key: key, Value: []byte("hunter2")},
+		}, &ok))
+	}
+
+	requirePlaintext := func(t *testing.T) {
+		t.Helper()
+
+		// The state store, and therefore raft and the snapshots, only hold
+		// the sealed values.
+		_, ent, err := s1.fsm.State().KVSGet(nil, "secret/a", nil)
+		require.NoError(t, err)
+		require.NotContains(t, string(ent.Value), "hunter2")
+		_, ok := kvencrypt.KeyID(ent.Value)
+		require.True(t, ok)
+
+		var get structs.IndexedDirEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &structs.KeyRequest{
+			Datacenter: "dc1",
+			Key:        "secret/a",
+		}, &get))
+		require.Len(t, get.Entries, 1)
+		require.Equal(t, "hunter2", string(get.Entries[0].Value))
+
+		var list structs.IndexedDirEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.List", &structs.KeyRequest{
+			Datacenter: "dc1",
+			Key:        "",
+		}, &list))
+		require.Len(t, list.Entries, 3)
+		values := map[string]string{}
+		for _, ent := range list.Entries {
+			values[ent.Key] = string(ent.Value)
+		}
+		require.Equal(t, map[string]string{
+			"legacy":   "plaintext",
+			"secret/a": "hunter2",
+			"secret/b": "hunter2",
+		}, values)
+
+		var txn structs.TxnResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &structs.TxnRequest{
+			Datacenter: "dc1",
+			Ops: structs.TxnOps{
+				&structs.TxnOp{KV: &structs.TxnKVOp{Verb: api.KVGet, DirEnt: structs.DirEntry{Key: "secret/b"}}},
+			},
+		}, &txn))
+		require.Empty(t, txn.Errors)
+		require.Len(t, txn.Results, 1)
+		require.Equal(t, "hunter2", string(txn.Results[0].KV.Value))
+	}
+	requirePlaintext(t)
+
+	out := status(t)
+	require.Equal(t, 1, out.PlaintextEntries)
+	require.Len(t, out.Keys, 1)
+	require.Equal(t, 2, out.Keys[0].Entries)
+
+	// Rotating the key only changes the key used by new writes.
+	var rotated structs.KVEncryptionStatus
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionRotate", &structs.KVEncryptionRequest{Datacenter: "dc1"}, &rotated))
+	require.NotEqual(t, firstKeyID, rotated.ActiveKeyID)
+	require.Len(t, rotated.Keys, 2)
+	require.Equal(t, firstKeyID, rotated.Keys[0].ID)
+	require.Equal(t, 2, rotated.Keys[0].Entries)
+	require.False(t, rotated.Keys[0].Active)
+	require.Equal(t, 0, rotated.Keys[1].Entries)
+	require.True(t, rotated.Keys[1].Active)
+	requirePlaintext(t)
+
+	// A value which can't be decrypted doesn't fail the whole list.
+	_, ent, err := s1.fsm.State().KVSGet(nil, "secret/b", nil)
+	require.NoError(t, err)
+	corrupted := ent.Clone()
+	corrupted.Key = "corrupted"
+	_, err = s1.raftApply(structs.KVSRequestType, &structs.KVSRequest{Op: api.KVSet, DirEnt: *corrupted})
+	require.NoError(t, err)
+
+	var list structs.IndexedDirEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.List", &structs.KeyRequest{
+		Datacenter: "dc1",
+		Key:        "",
+	}, &list))
+	require.Len(t, list.Entries, 4)
+
+	var get structs.IndexedDirEntries
+	err = msgpackrpc.CallWithCodec(codec, "KVS.Get", &structs.KeyRequest{
+		Datacenter: "dc1",
+		Key:        "corrupted",
+	}, &get)
+	require.ErrorContains(t, err, "failed to decrypt value")
+
+	_, err = s1.raftApply(structs.KVSRequestType, &structs.KVSRequest{Op: api.KVDelete, DirEnt: structs.DirEntry{Key: "corrupted"}})
+	require.NoError(t, err)
+
+	// Rewrapping re-encrypts every value with the active key, including the
+	// plaintext ones.
+	var rewrapped structs.KVEncryptionStatus
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionRewrap", &structs.KVEncryptionRequest{Datacenter: "dc1"}, &rewrapped))
+	require.Equal(t, 3, rewrapped.Rewrapped)
+	require.Equal(t, 0, rewrapped.PlaintextEntries)
+	require.Equal(t, 0, rewrapped.Keys[0].Entries)
+	require.Equal(t, 3, rewrapped.Keys[1].Entries)
+	requirePlaintext(t)
+}
+
+func TestKVEncryption_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, s1 := testServer(t)
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	var ok bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &structs.KVSRequest{
+		Datacenter: "dc1",
+		Op:         api.KVSet,
+		DirEnt:     structs.DirEntry{Key: "secret", Value: []byte("hunter2")},
+	}, &ok))
+
+	_, ent, err := s1.fsm.State().KVSGet(nil, "secret", nil)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", string(ent.Value))
+
+	var out structs.KVEncryptionStatus
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionStatus", &structs.DCSpecificRequest{Datacenter: "dc1"}, &out))
+	require.False(t, out.Enabled)
+	require.Equal(t, 1, out.PlaintextEntries)
+
+	err = msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionRewrap", &structs.KVEncryptionRequest{Datacenter: "dc1"}, &out)
+	require.ErrorContains(t, err, "KV encryption is not enabled")
+
+	// The values starting with the prefix of the encrypted values are
+	// rejected.
+	reserved := []byte("\x00ckv1\x03abcdefghijklmnop")
+	err = msgpackrpc.CallWithCodec(codec, "KVS.Apply", &structs.KVSRequest{
+		Datacenter: "dc1",
+		Op:         api.KVSet,
+		DirEnt:     structs.DirEntry{Key: "reserved", Value: reserved},
+	}, &ok)
+	require.ErrorContains(t, err, "reserved prefix")
+
+	// The ones written before the prefix was reserved are returned as they
+	// are, since they don't name a key of the keyring.
+	_, err = s1.raftApply(structs.KVSRequestType, &structs.KVSRequest{
+		Op:     api.KVSet,
+		DirEnt: structs.DirEntry{Key: "reserved", Value: reserved},
+	})
+	require.NoError(t, err)
+
+	var get structs.IndexedDirEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &structs.KeyRequest{
+		Datacenter: "dc1",
+		Key:        "reserved",
+	}, &get))
+	require.Len(t, get.Entries, 1)
+	require.Equal(t, reserved, get.Entries[0].Value)
+
+	var list structs.IndexedDirEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.List", &structs.KeyRequest{
+		Datacenter: "dc1",
+		Key:        "",
+	}, &list))
+	require.Len(t, list.Entries, 2)
+}
+
+func TestKVEncryption_ConfigEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	kek, err := kvencrypt.GenerateKey()
+	require.NoError(t, err)
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.KVEncryption = kvencrypt.Config{
+			Provider: kvencrypt.ProviderStatic,
+			Static:   kvencrypt.StaticConfig{Key: base64.StdEncoding.EncodeToString(kek)},
+		}
+	})
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	var firstKeyID string
+	retry.Run(t, func(r *retry.R) {
+		var out structs.KVEncryptionStatus
+		require.NoError(r, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionStatus", &structs.DCSpecificRequest{Datacenter: "dc1"}, &out))
+		require.NotEmpty(r, out.ActiveKeyID)
+		firstKeyID = out.ActiveKeyID
+	})
+
+	ca := connect.TestCA(t, nil)
+	var ok bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.InlineCertificateConfigEntry{
+			Kind:        structs.InlineCertificate,
+			Name:        "apply",
+			Certificate: ca.RootCert,
+			PrivateKey:  ca.SigningKey,
+		},
+	}, &ok))
+	require.True(t, ok)
+
+	var txn structs.TxnResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &structs.TxnRequest{
+		Datacenter: "dc1",
+		Ops: structs.TxnOps{
+			&structs.TxnOp{ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb: api.ConfigEntrySet,
+				Entry: &structs.InlineCertificateConfigEntry{
+					Kind:        structs.InlineCertificate,
+					Name:        "txn",
+					Certificate: ca.RootCert,
+					PrivateKey:  ca.SigningKey,
+				},
+			}},
+		},
+	}, &txn))
+	require.Empty(t, txn.Errors)
+
+	// requireSealed checks that the state store, and therefore raft and the
+	// snapshots, only hold the sealed private keys.
+	requireSealed := func(t *testing.T, keyID string) {
+		t.Helper()
+		for _, name := range []string{"apply", "txn"} {
+			_, entry, err := s1.fsm.State().ConfigEntry(nil, structs.InlineCertificate, name, nil)
+			require.NoError(t, err)
+			stored := entry.(*structs.InlineCertificateConfigEntry)
+			require.Equal(t, ca.RootCert, stored.Certificate)
+			require.NotContains(t, stored.PrivateKey, "PRIVATE KEY")
+			id, ok := kvencrypt.TextKeyID(stored.PrivateKey)
+			require.True(t, ok)
+			require.Equal(t, keyID, id)
+		}
+	}
+	requireSealed(t, firstKeyID)
+
+	var get structs.ConfigEntryResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Get", &structs.ConfigEntryQuery{
+		Datacenter: "dc1",
+		Kind:       structs.InlineCertificate,
+		Name:       "apply",
+	}, &get))
+	require.Equal(t, ca.SigningKey, get.Entry.(*structs.InlineCertificateConfigEntry).PrivateKey)
+
+	var list structs.IndexedConfigEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.List", &structs.ConfigEntryQuery{
+		Datacenter: "dc1",
+		Kind:       structs.InlineCertificate,
+	}, &list))
+	require.Len(t, list.Entries, 2)
+	for _, entry := range list.Entries {
+		require.Equal(t, ca.SigningKey, entry.(*structs.InlineCertificateConfigEntry).PrivateKey)
+	}
+
+	var listAll structs.IndexedGenericConfigEntries
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.ListAll", &structs.ConfigEntryListAllRequest{
+		Datacenter: "dc1",
+		Kinds:      []string{structs.InlineCertificate},
+	}, &listAll))
+	require.Len(t, listAll.Entries, 2)
+	for _, entry := range listAll.Entries {
+		require.Equal(t, ca.SigningKey, entry.(*structs.InlineCertificateConfigEntry).PrivateKey)
+	}
+
+	// The streaming subscribers, e.g. the API gateways served by this
+	// server, receive the decrypted private keys.
+	sub, err := s1.publisher.Subscribe(&stream.SubscribeRequest{
+		Topic:   state.EventTopicInlineCertificate,
+		Subject: state.EventSubjectConfigEntry{Name: "apply", EnterpriseMeta: structs.DefaultEnterpriseMetaInDefaultPartition()},
+	})
+	require.NoError(t, err)
+	t.Cleanup(sub.Unsubscribe)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	event, err := sub.Next(ctx)
+	require.NoError(t, err)
+	update := event.Payload.ToSubscriptionEvent(event.Index).GetConfigEntry()
+	require.NotNil(t, update)
+	require.Equal(t, ca.SigningKey, update.ConfigEntry.GetInlineCertificate().PrivateKey)
+
+	event, err = sub.Next(ctx)
+	require.NoError(t, err)
+	require.True(t, event.IsFramingEvent())
+
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.InlineCertificateConfigEntry{
+			Kind:        structs.InlineCertificate,
+			Name:        "apply",
+			Certificate: ca.RootCert,
+			PrivateKey:  ca.SigningKey,
+			Meta:        map[string]string{"updated": "true"},
+		},
+	}, &ok))
+	event, err = sub.Next(ctx)
+	require.NoError(t, err)
+	update = event.Payload.ToSubscriptionEvent(event.Index).GetConfigEntry()
+	require.NotNil(t, update)
+	require.Equal(t, "true", update.ConfigEntry.GetInlineCertificate().GetMeta()["updated"])
+	require.Equal(t, ca.SigningKey, update.ConfigEntry.GetInlineCertificate().PrivateKey)
+
+	// Rewrapping re-encrypts the private keys with the active key.
+	var rotated structs.KVEncryptionStatus
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionRotate", &structs.KVEncryptionRequest{Datacenter: "dc1"}, &rotated))
+	requireSealed(t, firstKeyID)
+
+	var rewrapped structs.KVEncryptionStatus
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.KVEncryptionRewrap", &structs.KVEncryptionRequest{Datacenter: "dc1"}, &rewrapped))
+	require.Equal(t, 2, rewrapped.Rewrapped)
+	requireSealed(t, rotated.ActiveKeyID)
+}
@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/logging"
+)
+
+var errKVReplicationFiltered = errors.New("the replication token is not allowed to read all the keys under the prefix")
+
+// KVReplicator mirrors a KV prefix from the primary datacenter. The keys
+// missing from the primary are deleted and the keys modified in the primary
+// since the last round overwrite the local ones, so the last write in the
+// primary wins over any local write.
+type KVReplicator struct {
+	srv    *Server
+	prefix string
+}
+
+var _ IndexReplicatorDelegate = (*KVReplicator)(nil)
+
+// SingularNoun implements IndexReplicatorDelegate.
+func (r *KVReplicator) SingularNoun() string { return "KV entry" }
+
+// PluralNoun implements IndexReplicatorDelegate.
+func (r *KVReplicator) PluralNoun() string { return "KV entries" }
+
+// MetricName implements IndexReplicatorDelegate.
+func (r *KVReplicator) MetricName() string { return "kv" }
+
+// FetchRemote implements IndexReplicatorDelegate.
+func (r *KVReplicator) FetchRemote(lastRemoteIndex uint64) (int, interface{}, uint64, error) {
+	req := structs.KeyRequest{
+		Datacenter: r.srv.config.PrimaryDatacenter,
+		Key:        r.prefix,
+		QueryOptions: structs.QueryOptions{
+			AllowStale:    true,
+			MinQueryIndex: lastRemoteIndex,
+			Token:         r.srv.tokens.ReplicationToken(),
+		},
+		EnterpriseMeta: *r.srv.replicationEnterpriseMeta(),
+	}
+
+	var response structs.IndexedDirEntries
+	if err := r.srv.RPC(context.Background(), "KVS.List", &req, &response); err != nil {
+		return 0, nil, 0, err
+	}
+	// Replicating a partial view would delete the keys the token can't read.
+	if response.QueryMeta.ResultsFilteredByACLs {
+		return 0, nil, 0, errKVReplicationFiltered
+	}
+
+	entries := []*structs.DirEntry(response.Entries)
+	return len(entries), entries, response.QueryMeta.Index, nil
+}
+
+// FetchLocal implements IndexReplicatorDelegate.
+func (r *KVReplicator) FetchLocal() (int, interface{}, error) {
+	_, local, err := r.srv.fsm.State().KVSList(nil, r.prefix, r.srv.replicationEnterpriseMeta())
+	if err != nil {
+		return 0, nil, err
+	}
+	entries := []*structs.DirEntry(r.srv.openKVEntries(local))
+	return len(entries), entries, nil
+}
+
+// DiffRemoteAndLocalState implements IndexReplicatorDelegate.
+func (r *KVReplicator) DiffRemoteAndLocalState(localRaw interface{}, remoteRaw interface{}, lastRemoteIndex uint64) (*IndexReplicatorDiff, error) {
+	local, ok := localRaw.([]*structs.DirEntry)
+	if !ok {
+		return nil, fmt.Errorf("invalid type for local KV entries: %T", localRaw)
+	}
+	remote, ok := remoteRaw.([]*structs.DirEntry)
+	if !ok {
+		return nil, fmt.Errorf("invalid type for remote KV entries: %T", remoteRaw)
+	}
+	deletions, updates := diffKVEntries(local, remote, lastRemoteIndex)
+
+	return &IndexReplicatorDiff{
+		NumDeletions: len(deletions),
+		Deletions:    deletions,
+		NumUpdates:   len(updates),
+		Updates:      updates,
+	}, nil
+}
+
+func diffKVEntries(local, remote []*structs.DirEntry, lastRemoteIndex uint64) ([]*structs.DirEntry, []*structs.DirEntry) {
+	kvEntrySort(local)
+	kvEntrySort(remote)
+
+	var (
+		deletions []*structs.DirEntry
+		updates   []*structs.DirEntry
+		localIdx  int
+		remoteIdx int
+	)
+	for localIdx, remoteIdx = 0, 0; localIdx < len(local) && remoteIdx < len(remote); {
+		if local[localIdx].Key == remote[remoteIdx].Key {
+			// key is in both the local and remote state - need to check raft indices
+			if remote[remoteIdx].ModifyIndex > lastRemoteIndex && !sameKVEntry(local[localIdx], remote[remoteIdx]) {
+				updates = append(updates, remote[remoteIdx])
+			}
+			// increment both indices when equal
+			localIdx += 1
+			remoteIdx += 1
+		} else if local[localIdx].Key < remote[remoteIdx].Key {
+			// key no longer in remote state - needs deleting
+			deletions = append(deletions, local[localIdx])
+
+			// increment just the local index
+			localIdx += 1
+		} else {
+			// local state doesn't have this key - needs updating
+			updates = append(updates, remote[remoteIdx])
+
+			// increment just the remote index
+			remoteIdx += 1
+		}
+	}
+
+	for ; localIdx < len(local); localIdx += 1 {
+		deletions = append(deletions, local[localIdx])
+	}
+
+	for ; remoteIdx < len(remote); remoteIdx += 1 {
+		updates = append(updates, remote[remoteIdx])
+	}
+
+	return deletions, updates
+}
+
+func sameKVEntry(a, b *structs.DirEntry) bool {
+	return a.Flags == b.Flags && bytes.Equal(a.Value, b.Value)
+}
+
+func kvEntrySort(entries []*structs.DirEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+}
+
+// PerformDeletions implements IndexReplicatorDelegate.
+func (r *KVReplicator) PerformDeletions(ctx context.Context, deletionsRaw interface{}) (exit bool, err error) {
+	deletions, ok := deletionsRaw.([]*structs.DirEntry)
+	if !ok {
+		return false, fmt.Errorf("invalid type for KV entries deletions list: %T", deletionsRaw)
+	}
+
+	return r.apply(ctx, api.KVDelete, deletions)
+}
+
+// PerformUpdates implements IndexReplicatorDelegate.
+func (r *KVReplicator) PerformUpdates(ctx context.Context, updatesRaw interface{}) (exit bool, err error) {
+	updates, ok := updatesRaw.([]*structs.DirEntry)
+	if !ok {
+		return false, fmt.Errorf("invalid type for KV entries update list: %T", updatesRaw)
+	}
+
+	return r.apply(ctx, api.KVSet, updates)
+}
+
+func (r *KVReplicator) apply(ctx context.Context, op api.KVOp, entries []*structs.DirEntry) (bool, error) {
+	ticker := time.NewTicker(time.Second / time.Duration(r.srv.config.KVReplicationApplyLimit))
+	defer ticker.Stop()
+
+	for i, entry := range entries {
+		// The sessions and raft indexes of the primary are meaningless here.
+		dirEnt := structs.DirEntry{
+			Key:            entry.Key,
+			Flags:          entry.Flags,
+			Value:          entry.Value,
+			EnterpriseMeta: entry.EnterpriseMeta,
+		}
+		if err := r.srv.sealKVEntry(op, &dirEnt); err != nil {
+			return false, err
+		}
+
+		req := structs.KVSRequest{
+			Datacenter: r.srv.config.Datacenter,
+			Op:         op,
+			DirEnt:     dirEnt,
+		}
+		if _, err := r.srv.leaderRaftApply("KVS.Apply", structs.KVSRequestType, &req); err != nil {
+			return false, err
+		}
+
+		if i < len(entries)-1 {
+			select {
+			case <-ctx.Done():
+				return true, nil
+			case <-ticker.C:
+				// do nothing - ready for the next batch
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// kvReplicationStatusDelegate records the outcome of each replication round of
+// a prefix in the KV replication status.
+type kvReplicationStatusDelegate struct {
+	*IndexReplicator
+	srv    *Server
+	prefix string
+}
+
+func (d *kvReplicationStatusDelegate) Replicate(ctx context.Context, lastRemoteIndex uint64, logger hclog.Logger) (uint64, bool, error) {
+	index, exit, err := d.IndexReplicator.Replicate(ctx, lastRemoteIndex, logger)
+	switch {
+	case exit:
+	case err != nil:
+		d.srv.updateKVReplicationStatusError(d.prefix, err.Error())
+	default:
+		d.srv.updateKVReplicationStatusIndex(d.prefix, index)
+	}
+	return index, exit, err
+}
+
+func (s *Server) startKVReplication(ctx context.Context) {
+	if s.config.PrimaryDatacenter == "" || s.config.PrimaryDatacenter == s.config.Datacenter {
+		// replication shouldn't run in the primary DC
+		return
+	}
+	if len(s.config.KVReplicationPrefixes) == 0 {
+		return
+	}
+
+	s.kvReplicationStatusLock.Lock()
+	s.kvReplicationStatus = make(map[string]*structs.KVReplicationPrefixStatus)
+	s.kvReplicationStatusLock.Unlock()
+
+	for _, prefix := range s.config.KVReplicationPrefixes {
+		logger := s.loggers.Named(logging.Replication).Named(logging.KV)
+		replicator, err := NewReplicator(&ReplicatorConfig{
+			Name: logging.KV,
+			Delegate: &kvReplicationStatusDelegate{
+				IndexReplicator: &IndexReplicator{
+					Delegate: &KVReplicator{srv: s, prefix: prefix},
+					Logger:   logger.With("prefix", prefix),
+				},
+				srv:    s,
+				prefix: prefix,
+			},
+			Rate:   s.config.KVReplicationRate,
+			Burst:  s.config.KVReplicationBurst,
+			Logger: s.logger.With("prefix", prefix),
+		})
+		if err != nil {
+			s.logger.Error("failed to start KV replication", "prefix", prefix, "error", err)
+			continue
+		}
+
+		s.kvReplicationStatusLock.Lock()
+		s.kvReplicationStatus[prefix] = &structs.KVReplicationPrefixStatus{Prefix: prefix}
+		s.kvReplicationStatusLock.Unlock()
+
+		s.leaderRoutineManager.Start(ctx, kvReplicationRoutine(prefix), replicator.Run)
+	}
+}
+
+func (s *Server) stopKVReplication() {
+	// will be a no-op when not started
+	for _, prefix := range s.config.KVReplicationPrefixes {
+		s.leaderRoutineManager.Stop(kvReplicationRoutine(prefix))
+	}
+
+	s.kvReplicationStatusLock.Lock()
+	s.kvReplicationStatus = nil
+	s.kvReplicationStatusLock.Unlock()
+}
+
+func kvReplicationRoutine(prefix string) string {
+	return fmt.Sprintf("%s %q", kvReplicationRoutineName, prefix)
+}
+
+func (s *Server) updateKVReplicationStatusError(prefix, errorMsg string) {
+	s.kvReplicationStatusLock.Lock()
+	defer s.kvReplicationStatusLock.Unlock()
+
+	if status, ok := s.kvReplicationStatus[prefix]; ok {
+		status.LastError = time.Now().Round(time.Second).UTC()
+		status.LastErrorMessage = errorMsg
+	}
+}
+
+func (s *Server) updateKVReplicationStatusIndex(prefix string, index uint64) {
+	s.kvReplicationStatusLock.Lock()
+	defer s.kvReplicationStatusLock.Unlock()
+
+	if status, ok := s.kvReplicationStatus[prefix]; ok {
+		status.LastSuccess = time.Now().Round(time.Second).UTC()
+		status.ReplicatedIndex = index
+	}
+}
+
+// getKVReplicationStatus returns the status of the KV replication on this
+// server, the prefixes are in the order of the configuration.
+func (s *Server) getKVReplicationStatus() structs.KVReplicationStatus {
+	s.kvReplicationStatusLock.RLock()
+	defer s.kvReplicationStatusLock.RUnlock()
+
+	status := structs.KVReplicationStatus{
+		Enabled: len(s.config.KVReplicationPrefixes) > 0,
+		Running: s.kvReplicationStatus != nil,
+	}
+	if !status.Running {
+		return status
+	}
+
+	status.SourceDatacenter = s.config.PrimaryDatacenter
+	for _, prefix := range s.config.KVReplicationPrefixes {
+		if p, ok := s.kvReplicationStatus[prefix]; ok {
+			status.Prefixes = append(status.Prefixes, *p)
+		}
+	}
+	return status
+}
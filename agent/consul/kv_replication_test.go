@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestReplication_KV(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.KVReplicationPrefixes = []string{"app/"}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	_, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.KVReplicationPrefixes = []string{"app/"}
+		c.KVReplicationRate = 100
+		c.KVReplicationBurst = 100
+		c.KVReplicationApplyLimit = 1000000
+	})
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+
+	// Try to join.
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	testrpc.WaitForLeader(t, s1.RPC, "dc2")
+
+	apply := func(dc string, op api.KVOp, key, value string) {
+		var out bool
+		require.NoError(t, s1.RPC(context.Background(), "KVS.Apply", &structs.KVSRequest{
+			Datacenter: dc,
+			Op:         op,
+			DirEnt:     structs.DirEntry{Key: key, Value: []byte(value)},
+		}, &out))
+	}
+	for i := 0; i < 10; i++ {
+		apply("dc1", api.KVSet, fmt.Sprintf("app/key-%d", i), fmt.Sprintf("value-%d", i))
+	}
+	apply("dc1", api.KVSet, "other/key", "not replicated")
+	apply("dc2", api.KVSet, "app/local", "deleted")
+
+	checkSame := func(r *retry.R) {
+		_, remote, err := s1.fsm.State().KVSList(nil, "app/", structs.ReplicationEnterpriseMeta())
+		require.NoError(r, err)
+		_, local, err := s2.fsm.State().KVSList(nil, "app/", structs.ReplicationEnterpriseMeta())
+		require.NoError(r, err)
+
+		require.Len(r, local, len(remote))
+		for i, entry := range remote {
+			require.Equal(r, entry.Key, local[i].Key)
+			require.Equal(r, entry.Value, local[i].Value)
+		}
+	}
+
+	// Wait for the replica to converge.
+	retry.Run(t, checkSame)
+
+	_, ent, err := s2.fsm.State().KVSGet(nil, "other/key", nil)
+	require.NoError(t, err)
+	require.Nil(t, ent)
+
+	// A write in the primary wins over a local write.
+	apply("dc2", api.KVSet, "app/key-1", "local")
+	apply("dc1", api.KVSet, "app/key-1", "primary")
+	apply("dc1", api.KVDelete, "app/key-2", "")
+	retry.Run(t, func(r *retry.R) {
+		checkSame(r)
+		_, ent, err := s2.fsm.State().KVSGet(nil, "app/key-1", nil)
+		require.NoError(r, err)
+		require.Equal(r, []byte("primary"), ent.Value)
+	})
+
+	var status structs.KVReplicationStatus
+	require.NoError(t, s2.RPC(context.Background(), "Operator.KVReplicationStatus", &structs.DCSpecificRequest{
+		Datacenter: "dc2",
+	}, &status))
+	require.True(t, status.Enabled)
+	require.True(t, status.Running)
+	require.Equal(t, "dc1", status.SourceDatacenter)
+	require.Len(t, status.Prefixes, 1)
+	require.Equal(t, "app/", status.Prefixes[0].Prefix)
+	require.NotZero(t, status.Prefixes[0].ReplicatedIndex)
+
+	// The primary doesn't replicate.
+	require.NoError(t, s1.RPC(context.Background(), "Operator.KVReplicationStatus", &structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}, &status))
+	require.True(t, status.Enabled)
+	require.False(t, status.Running)
+	require.Empty(t, status.Prefixes)
+}
+
+func Test_diffKVEntries(t *testing.T) {
+	ent := func(key, value string, index uint64) *structs.DirEntry {
+		return &structs.DirEntry{
+			Key:       key,
+			Value:     []byte(value),
+			RaftIndex: structs.RaftIndex{ModifyIndex: index},
+		}
+	}
+
+	local := []*structs.DirEntry{
+		ent("app/a", "1", 1),
+		ent("app/b", "local", 2),
+		ent("app/c", "3", 3),
+		ent("app/d", "local", 4),
+	}
+	remote := []*structs.DirEntry{
+		ent("app/e", "5", 15),
+		ent("app/b", "2", 12),
+		ent("app/c", "3", 13),
+		ent("app/d", "4", 9),
+	}
+
+	deletions, updates := diffKVEntries(local, remote, 10)
+	require.Equal(t, []*structs.DirEntry{ent("app/a", "1", 1)}, deletions)
+	require.Equal(t, []*structs.DirEntry{ent("app/b", "2", 12), ent("app/e", "5", 15)}, updates)
+
+	// A full sync overwrites all the keys that differ.
+	_, updates = diffKVEntries(local, remote, 0)
+	require.Equal(t, []*structs.DirEntry{ent("app/b", "2", 12), ent("app/d", "4", 9), ent("app/e", "5", 15)}, updates)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencrypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KeySize is the size of the data encryption keys, which are used for
+// AES-256-GCM.
+const KeySize = 32
+
+// sealedPrefix marks values that were sealed by Seal. It starts with a NUL
+// byte so that it can't be mistaken for text values.
+var sealedPrefix = []byte("\x00ckv1")
+
+// sealedTextPrefix marks the text values sealed by SealText.
+const sealedTextPrefix = "consul-sealed:v1:"
+
+var errMalformed = errors.New("malformed encrypted value")
+
+// GenerateKey returns a new random data encryption key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal encrypts the plaintext with the data encryption key. The ID of the key
+// is stored in the sealed value so that it can be looked up by Open. The
+// additional data, usually the KV key, must be passed to Open unchanged.
+//
+// The sealed value has the format:
+//
+//	prefix | len(keyID) | keyID | nonce | ciphertext
+func Seal(keyID string, key, plaintext, additionalData []byte) ([]byte, error) {
+	if len(keyID) == 0 || len(keyID) > 255 {
+		return nil, fmt.Errorf("invalid key ID %q", keyID)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(sealedPrefix)+1+len(keyID))
+	header = append(header, sealedPrefix...)
+	header = append(header, byte(len(keyID)))
+	header = append(header, keyID...)
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, aad(header, additionalData)), nil
+}
+
+// Open decrypts a value sealed by Seal using the data encryption key with the
+// ID returned by KeyID.
+func Open(key, sealed, additionalData []byte) ([]byte, error) {
+	keyID, ok := KeyID(sealed)
+	if !ok {
+		return nil, errMalformed
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	headerLen := len(sealedPrefix) + 1 + len(keyID)
+	if len(sealed) < headerLen+aead.NonceSize() {
+		return nil, errMalformed
+	}
+	header := sealed[:headerLen]
+	nonce := sealed[headerLen : headerLen+aead.NonceSize()]
+	ciphertext := sealed[headerLen+aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad(header, additionalData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value with key %q: %w", keyID, err)
+	}
+	return plaintext, nil
+}
+
+// HasSealedPrefix returns whether the value starts with the prefix of the
+// sealed values. The plaintext values must not, so that they can't be mistaken
+// for sealed ones.
+func HasSealedPrefix(value []byte) bool {
+	return bytes.HasPrefix(value, sealedPrefix)
+}
+
+// KeyID returns the ID of the data encryption key the value was sealed with,
+// or false if the value isn't sealed.
+func KeyID(value []byte) (string, bool) {
+	if !bytes.HasPrefix(value, sealedPrefix) || len(value) <= len(sealedPrefix) {
+		return "", false
+	}
+	n := int(value[len(sealedPrefix)])
+	rest := value[len(sealedPrefix)+1:]
+	if n == 0 || len(rest) < n {
+		return "", false
+	}
+	return string(rest[:n]), true
+}
+
+// SealText seals the plaintext as Seal does, and encodes the sealed value as
+// text so that it can be stored in string fields such as the secrets of config
+// entries.
+func SealText(keyID string, key []byte, plaintext string, additionalData []byte) (string, error) {
+	sealed, err := Seal(keyID, key, []byte(plaintext), additionalData)
+	if err != nil {
+		return "", err
+	}
+	return sealedTextPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenText decrypts a value sealed by SealText using the data encryption key
+// with the ID returned by TextKeyID.
+func OpenText(key []byte, sealed string, additionalData []byte) (string, error) {
+	raw, err := decodeText(sealed)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := Open(key, raw, additionalData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// TextKeyID returns the ID of the data encryption key the text value was
+// sealed with, or false if the value isn't sealed.
+func TextKeyID(value string) (string, bool) {
+	raw, err := decodeText(value)
+	if err != nil {
+		return "", false
+	}
+	return KeyID(raw)
+}
+
+func decodeText(value string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(value, sealedTextPrefix)
+	if !ok {
+		return nil, errMalformed
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errMalformed
+	}
+	return raw, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid key size %d, must be %d bytes", len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func aad(header, additionalData []byte) []byte {
+	out := make([]byte, 0, len(header)+len(additionalData))
+	out = append(out, header...)
+	return append(out, additionalData...)
+}
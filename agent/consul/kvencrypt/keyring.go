@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencrypt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Keyring holds the wrapped data encryption keys. It is stored in the state
+// store so that every server can unwrap the keys with the provider.
+type Keyring struct {
+	// ActiveKeyID is the ID of the key that new values are sealed with.
+	ActiveKeyID string
+
+	// Keys are all of the keys that values may be sealed with. Keys are never
+	// removed, since values sealed with them could otherwise no longer be read.
+	Keys []*WrappedKey
+}
+
+// WrappedKey is a data encryption key that is wrapped by the key provider.
+type WrappedKey struct {
+	ID         string
+	Provider   string
+	Ciphertext []byte
+	CreateTime time.Time
+}
+
+// Key returns the key with the given ID, or nil if there is none.
+func (k *Keyring) Key(id string) *WrappedKey {
+	if k == nil {
+		return nil
+	}
+	for _, key := range k.Keys {
+		if key.ID == id {
+			return key
+		}
+	}
+	return nil
+}
+
+// Encode returns the serialized keyring.
+func (k *Keyring) Encode() (string, error) {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeKeyring returns the keyring serialized by Encode, or nil if the
+// keyring is empty.
+func DecodeKeyring(s string) (*Keyring, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var k Keyring
+	if err := json.Unmarshal([]byte(s), &k); err != nil {
+		return nil, fmt.Errorf("failed to decode KV encryption keyring: %w", err)
+	}
+	return &k, nil
+}
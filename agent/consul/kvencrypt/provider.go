@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package kvencrypt implements envelope encryption of KV values. Each value is
+// encrypted with a data encryption key (DEK) which is stored in the state
+// store, wrapped by a key encryption key that is managed by an external key
+// provider such as Vault's transit secrets engine or AWS KMS.
+package kvencrypt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+const (
+	ProviderVaultTransit = "vault-transit"
+	ProviderAWSKMS       = "aws-kms"
+	ProviderStatic       = "static"
+)
+
+// Provider wraps and unwraps data encryption keys using a key encryption key
+// which never leaves the provider.
+type Provider interface {
+	// WrapKey encrypts the given data encryption key.
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data encryption key previously wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// Config configures the encryption of KV values. Encryption is disabled when
+// no provider is set.
+type Config struct {
+	// Provider is the name of the key provider used to wrap the data
+	// encryption keys.
+	Provider string
+
+	VaultTransit VaultTransitConfig
+	AWSKMS       AWSKMSConfig
+	Static       StaticConfig
+}
+
+// Enabled returns whether the encryption of KV values is enabled.
+func (c Config) Enabled() bool {
+	return c.Provider != ""
+}
+
+type providerFactory struct {
+	validate func(Config) error
+	new      func(Config) (Provider, error)
+}
+
+var providers = map[string]providerFactory{
+	ProviderVaultTransit: {
+		validate: func(c Config) error { return c.VaultTransit.validate() },
+		new:      func(c Config) (Provider, error) { return newVaultTransitProvider(c.VaultTransit) },
+	},
+	ProviderAWSKMS: {
+		validate: func(c Config) error { return c.AWSKMS.validate() },
+		new:      func(c Config) (Provider, error) { return newAWSKMSProvider(c.AWSKMS) },
+	},
+	ProviderStatic: {
+		validate: func(c Config) error { return c.Static.validate() },
+		new:      func(c Config) (Provider, error) { return newStaticProvider(c.Static) },
+	},
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	p, ok := providers[c.Provider]
+	if !ok {
+		return fmt.Errorf("unknown provider %q, must be one of %v", c.Provider, providerNames())
+	}
+	if err := p.validate(c); err != nil {
+		return fmt.Errorf("invalid %s provider config: %w", c.Provider, err)
+	}
+	return nil
+}
+
+// NewProvider returns the key provider for the given configuration.
+func NewProvider(c Config) (Provider, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if !c.Enabled() {
+		return nil, fmt.Errorf("no provider configured")
+	}
+	return providers[c.Provider].new(c)
+}
+
+func providerNames() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
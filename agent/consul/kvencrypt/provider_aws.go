@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencrypt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+
+	"github.com/hashicorp/consul/lib/egressproxy"
+)
+
+// awsKMSEncryptionContext binds the wrapped keys to their use, so that they
+// can't be decrypted by KMS on behalf of another application.
+var awsKMSEncryptionContext = map[string]*string{
+	"consul": aws.String("kv-encryption"),
+}
+
+// AWSKMSConfig configures the aws-kms provider, which wraps the data encryption
+// keys with an AWS KMS key.
+//
+// Credentials are only read from the standard locations of the AWS SDK, i.e.
+// the environment, the shared credentials file or the instance's IAM role.
+type AWSKMSConfig struct {
+	// KeyID is the ID, ARN or alias of the KMS key.
+	KeyID string
+
+	// Region overrides the region of the AWS SDK configuration.
+	Region string
+
+	// Endpoint overrides the KMS endpoint, e.g. for VPC endpoints.
+	Endpoint string
+}
+
+func (c AWSKMSConfig) validate() error {
+	if c.KeyID == "" {
+		return errors.New("key_id must be set")
+	}
+	return nil
+}
+
+type awsKMSProvider struct {
+	client *kms.KMS
+	keyID  string
+}
+
+func newAWSKMSProvider(c AWSKMSConfig) (Provider, error) {
+	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			HTTPClient: &http.Client{Transport: egressproxy.PooledTransport()},
+		},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := aws.NewConfig()
+	if c.Region != "" {
+		cfg = cfg.WithRegion(c.Region)
+	}
+	if c.Endpoint != "" {
+		cfg = cfg.WithEndpoint(c.Endpoint)
+	}
+	return &awsKMSProvider{
+		client: kms.New(awsSession, cfg),
+		keyID:  c.KeyID,
+	}, nil
+}
+
+func (p *awsKMSProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	out, err := p.client.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:             aws.String(p.keyID),
+		Plaintext:         key,
+		EncryptionContext: awsKMSEncryptionContext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key with KMS key %q: %w", p.keyID, err)
+	}
+	return out.CiphertextBlob, nil
+}
+
+func (p *awsKMSProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	out, err := p.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(p.keyID),
+		CiphertextBlob:    wrapped,
+		EncryptionContext: awsKMSEncryptionContext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with KMS key %q: %w", p.keyID, err)
+	}
+	return out.Plaintext, nil
+}
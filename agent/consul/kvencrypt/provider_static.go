@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencrypt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StaticConfig configures the static provider, which wraps the data encryption
+// keys with a key from the agent configuration. It is meant for testing and
+// development, since the key encryption key is stored alongside Consul.
+type StaticConfig struct {
+	// Key is the base64 encoded 32 byte AES key encryption key.
+	Key string
+}
+
+func (c StaticConfig) validate() error {
+	_, err := c.decodeKey()
+	return err
+}
+
+func (c StaticConfig) decodeKey() ([]byte, error) {
+	if c.Key == "" {
+		return nil, errors.New("key must be set")
+	}
+	key, err := base64.StdEncoding.DecodeString(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+type staticProvider struct {
+	key []byte
+}
+
+func newStaticProvider(c StaticConfig) (Provider, error) {
+	key, err := c.decodeKey()
+	if err != nil {
+		return nil, err
+	}
+	return &staticProvider{key: key}, nil
+}
+
+func (p *staticProvider) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	aead, err := newAEAD(p.key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+func (p *staticProvider) UnwrapKey(_ context.Context, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(p.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, errMalformed
+	}
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], nil)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencrypt
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/consul/lib/egressproxy"
+)
+
+const defaultVaultTransitMountPath = "transit"
+
+// VaultTransitConfig configures the vault-transit provider, which wraps the
+// data encryption keys with a key of Vault's transit secrets engine.
+type VaultTransitConfig struct {
+	Address   string
+	Token     string
+	Namespace string
+
+	// MountPath is the path the transit secrets engine is mounted at,
+	// defaults to "transit".
+	MountPath string
+
+	// KeyName is the name of the transit key.
+	KeyName string
+
+	CAFile        string
+	CAPath        string
+	CertFile      string
+	KeyFile       string
+	TLSServerName string
+	TLSSkipVerify bool
+}
+
+func (c VaultTransitConfig) validate() error {
+	if c.Address == "" {
+		return errors.New("address must be set")
+	}
+	if c.Token == "" {
+		return errors.New("token must be set")
+	}
+	if c.KeyName == "" {
+		return errors.New("key_name must be set")
+	}
+	return nil
+}
+
+type vaultTransitProvider struct {
+	client    *vaultapi.Client
+	mountPath string
+	keyName   string
+}
+
+func newVaultTransitProvider(c VaultTransitConfig) (Provider, error) {
+	clientConf := &vaultapi.Config{
+		Address: c.Address,
+	}
+	err := clientConf.ConfigureTLS(&vaultapi.TLSConfig{
+		CACert:        c.CAFile,
+		CAPath:        c.CAPath,
+		ClientCert:    c.CertFile,
+		ClientKey:     c.KeyFile,
+		Insecure:      c.TLSSkipVerify,
+		TLSServerName: c.TLSServerName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// ConfigureTLS ensures the client has an *http.Transport.
+	clientConf.HttpClient.Transport.(*http.Transport).Proxy = egressproxy.Proxy
+	client, err := vaultapi.NewClient(clientConf)
+	if err != nil {
+		return nil, err
+	}
+	if c.Namespace != "" {
+		client.SetNamespace(c.Namespace)
+	}
+	client.SetToken(c.Token)
+
+	mountPath := strings.Trim(c.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultVaultTransitMountPath
+	}
+	return &vaultTransitProvider{
+		client:    client,
+		mountPath: mountPath,
+		keyName:   c.KeyName,
+	}, nil
+}
+
+func (p *vaultTransitProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/encrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key with Vault transit key %q: %w", p.keyName, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("failed to wrap key with Vault transit key %q: empty response", p.keyName)
+	}
+	ciphertext, ok := secret.Data["ciphertext"].(string)
+	if !ok || ciphertext == "" {
+		return nil, fmt.Errorf("failed to wrap key with Vault transit key %q: missing ciphertext", p.keyName)
+	}
+	return []byte(ciphertext), nil
+}
+
+func (p *vaultTransitProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	path := fmt.Sprintf("%s/decrypt/%s", p.mountPath, p.keyName)
+	secret, err := p.client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+		"ciphertext": string(wrapped),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key with Vault transit key %q: %w", p.keyName, err)
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("failed to unwrap key with Vault transit key %q: empty response", p.keyName)
+	}
+	plaintext, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to unwrap key with Vault transit key %q: missing plaintext", p.keyName)
+	}
+	return base64.StdEncoding.DecodeString(plaintext)
+}
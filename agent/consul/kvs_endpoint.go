@@ -15,6 +15,7 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/agent/consul/kvencrypt"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
@@ -96,6 +97,21 @@ func kvsPreApply(logger hclog.Logger, srv *Server, authz resolver.Result, op api
 		}
 	}
 
+	// The values starting like the encrypted values would be mistaken for
+	// them when they are read.
+	switch op {
+	case api.KVSet, api.KVCAS, api.KVLock, api.KVUnlock:
+		if kvencrypt.HasSealedPrefix(dirEnt.Value) {
+			return false, errKVReservedPrefix
+		}
+	}
+
+	// Encrypt the value before it is written to Raft, so that it is never
+	// persisted in plaintext.
+	if err := srv.sealKVEntry(op, dirEnt); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -125,6 +141,10 @@ func (k *KVS) Apply(args *structs.KVSRequest, reply *bool) error {
 		return nil
 	}
 
+	if _, err := k.srv.checkKVSQuota(args.Op, &args.DirEnt, 0); err != nil {
+		return err
+	}
+
 	// Apply the update.
 	resp, err := k.srv.raftApply(structs.KVSRequestType, args)
 	if err != nil {
@@ -172,8 +192,13 @@ func (k *KVS) Get(args *structs.KeyRequest, reply *structs.IndexedDirEntries) er
 				return errNotFound
 			}
 
+			ent, err = k.srv.openKVEntry(ent)
+			if err != nil {
+				return err
+			}
+
 			reply.Index = ent.ModifyIndex
-			reply.Entries = structs.DirEntries{ent}
+			reply.Entries = k.srv.redactDirEntries(authz, structs.DirEntries{ent})
 			return nil
 		})
 }
@@ -213,6 +238,9 @@ func (k *KVS) List(args *structs.KeyRequest, reply *structs.IndexedDirEntries) e
 			ent = FilterDirEnt(authz, ent)
 			reply.QueryMeta.ResultsFilteredByACLs = total != len(ent)
 
+			ent = k.srv.openKVEntries(ent)
+			ent = k.srv.redactDirEntries(authz, ent)
+
 			if len(ent) == 0 {
 				// Must provide non-zero index to prevent blocking
 				// Index 1 is impossible anyways (due to Raft internals)
@@ -273,7 +301,7 @@ func (k *KVS) ListKeys(args *structs.KeyListRequest, reply *structs.IndexedKeyLi
 			}
 
 			total := len(entries)
-			entries = FilterDirEnt(authz, entries)
+			entries = FilterKeyNames(authz, entries)
 			reply.QueryMeta.ResultsFilteredByACLs = total != len(entries)
 
 			// Collect the keys from the filtered entries
@@ -313,6 +313,10 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.initializeKVEncryption(ctx); err != nil {
+		return err
+	}
+
 	if err := s.establishEnterpriseLeadership(ctx); err != nil {
 		return err
 	}
@@ -324,6 +328,10 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 
 	s.startFederationStateReplication(ctx)
 
+	s.startKVReplication(ctx)
+
+	s.startCatalogProviders(ctx)
+
 	s.startFederationStateAntiEntropy(ctx)
 
 	if s.config.PeeringEnabled {
@@ -332,6 +340,14 @@ func (s *Server) establishLeadership(ctx context.Context) error {
 
 	s.startDeferredDeletion(ctx)
 
+	s.startRegistrationLeaseReaping(ctx)
+
+	s.startWebhooks(ctx)
+
+	s.startACME(ctx)
+
+	s.startRaftArchive(ctx)
+
 	if err := s.startConnectLeader(ctx); err != nil {
 		return err
 	}
@@ -386,8 +402,26 @@ func (s *Server) revokeLeadership() {
 
 	s.stopDeferredDeletion()
 
+	s.stopRegistrationLeaseReaping()
+
+	s.stopWebhooks()
+
+	s.stopACME()
+
+	s.stopRaftArchive()
+
+	s.meshMetrics.Reset()
+
+	if s.aclTokenUsage != nil {
+		s.aclTokenUsage.Reset()
+	}
+
 	s.stopFederationStateAntiEntropy()
 
+	s.stopKVReplication()
+
+	s.stopCatalogProviders()
+
 	s.stopFederationStateReplication()
 
 	s.stopConfigReplication()
@@ -759,6 +793,9 @@ func (s *Server) runACLReplicator(
 	var failedAttempts uint
 	limiter := rate.NewLimiter(rate.Limit(s.config.ACLReplicationRate), s.config.ACLReplicationBurst)
 
+	tracker := s.aclReplicationTrackers[replicationType]
+	tracker.reset()
+
 	var lastRemoteIndex uint64
 	for {
 		if err := limiter.Wait(ctx); err != nil {
@@ -778,6 +815,9 @@ func (s *Server) runACLReplicator(
 			metrics.SetGauge([]string{"leader", "replication", metricName, "status"},
 				0,
 			)
+			metrics.SetGauge([]string{"leader", "replication", metricName, "lag"},
+				float32(tracker.failure(err).Seconds()),
+			)
 			lastRemoteIndex = 0
 			s.updateACLReplicationStatusError(err.Error())
 			logger.Warn("ACL replication error (will retry if still leader)",
@@ -800,6 +840,9 @@ func (s *Server) runACLReplicator(
 			metrics.SetGauge([]string{"leader", "replication", metricName, "index"},
 				float32(index),
 			)
+			metrics.SetGauge([]string{"leader", "replication", metricName, "lag"},
+				float32(tracker.success(index).Seconds()),
+			)
 			lastRemoteIndex = index
 			s.updateACLReplicationStatusIndex(replicationType, index)
 			logger.Debug("ACL replication completed through remote index",
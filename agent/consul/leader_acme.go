@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/consul/agent/consul/acme"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+// startACME starts obtaining and renewing the certificates of the API gateway
+// listeners. It only runs in the primary datacenter, since the config entries
+// of the other datacenters are replicated from it.
+func (s *Server) startACME(ctx context.Context) {
+	if !s.config.ACME.Enabled || !s.InPrimaryDatacenter() {
+		return
+	}
+	s.leaderRoutineManager.Start(ctx, acmeRoutineName, s.runACME)
+}
+
+func (s *Server) stopACME() {
+	s.leaderRoutineManager.Stop(acmeRoutineName)
+}
+
+func (s *Server) runACME(ctx context.Context) error {
+	logger := s.loggers.Named(logging.ACME)
+
+	var provider acme.DNSProvider
+	if s.config.ACME.Challenge == acme.ChallengeDNS01 {
+		var err error
+		provider, err = acme.NewDNSProvider(s.config.ACME.DNSProvider, s.config.ACME.DNSProviderConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return acme.NewManager(acme.Deps{
+		Config:      s.config.ACME,
+		Issuer:      acme.NewClient(s.config.ACME, s.config.DataDir, logger),
+		DNSProvider: provider,
+		GetStore:    func() acme.Store { return s.fsm.State() },
+		Apply:       s.applyACMEConfigEntry,
+		Logger:      logger,
+	}).Run(ctx)
+}
+
+// applyACMEConfigEntry writes a config entry with a check-and-set on its
+// modify index.
+func (s *Server) applyACMEConfigEntry(entry structs.ConfigEntry) (bool, error) {
+	if err := entry.Normalize(); err != nil {
+		return false, err
+	}
+	if err := entry.Validate(); err != nil {
+		return false, err
+	}
+	entry, err := s.sealConfigEntry(entry)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.leaderRaftApply("ConfigEntry.Apply", structs.ConfigEntryRequestType, &structs.ConfigEntryRequest{
+		Op:    structs.ConfigEntryUpsertCAS,
+		Entry: entry,
+	})
+	if err != nil {
+		return false, err
+	}
+	updated, ok := resp.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected response type %T", resp)
+	}
+	return updated, nil
+}
@@ -55,8 +55,10 @@ func (s *Server) startConnectLeader(ctx context.Context) error {
 	s.leaderRoutineManager.Start(ctx, caRootPruningRoutineName, s.runCARootPruning)
 	s.leaderRoutineManager.Start(ctx, caRootMetricRoutineName, rootCAExpiryMonitor(s).Monitor)
 	s.leaderRoutineManager.Start(ctx, caSigningMetricRoutineName, signingCAExpiryMonitor(s).Monitor)
+	s.leaderRoutineManager.Start(ctx, caLeafMetricRoutineName, leafCertExpiryMonitor(s).Monitor)
 	s.leaderRoutineManager.Start(ctx, virtualIPCheckRoutineName, s.runVirtualIPVersionCheck)
 	s.leaderRoutineManager.Start(ctx, configEntryControllersRoutineName, s.runConfigEntryControllers)
+	s.startIntentionExpiry(ctx)
 
 	return s.startIntentionConfigEntryMigration(ctx)
 }
@@ -68,8 +70,10 @@ func (s *Server) stopConnectLeader() {
 	s.leaderRoutineManager.Stop(caRootPruningRoutineName)
 	s.leaderRoutineManager.Stop(caRootMetricRoutineName)
 	s.leaderRoutineManager.Stop(caSigningMetricRoutineName)
+	s.leaderRoutineManager.Stop(caLeafMetricRoutineName)
 	s.leaderRoutineManager.Stop(virtualIPCheckRoutineName)
 	s.leaderRoutineManager.Stop(configEntryControllersRoutineName)
+	s.leaderRoutineManager.Stop(intentionExpiryRoutineName)
 }
 
 func (s *Server) runConfigEntryControllers(ctx context.Context) error {
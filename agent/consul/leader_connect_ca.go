@@ -4,18 +4,26 @@
 package consul
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-uuid"
+	"golang.org/x/crypto/ocsp"
 	"golang.org/x/time/rate"
 
 	"github.com/hashicorp/consul/acl"
@@ -48,6 +56,7 @@ type caServerDelegate interface {
 	ApplyCALeafRequest() (uint64, error)
 
 	forwardDC(method, dc string, args interface{}, reply interface{}) error
+	datacenters() []string
 	generateCASignRequest(csr string) *structs.CASignRequest
 
 	ServersSupportMultiDCConnectCA() error
@@ -79,6 +88,11 @@ type CAManager struct {
 	primaryRoots structs.IndexedCARoots // The most recently seen state of the root CAs from the primary datacenter.
 
 	leaderRoutineManager *routine.Manager
+
+	// leafInventory summarizes the leaf certificates signed since the
+	// server became the leader.
+	leafInventory *leafCertInventory
+
 	// providerShim is used to test CAManager with a fake provider.
 	providerShim ca.Provider
 
@@ -132,6 +146,10 @@ func (c *caDelegateWithState) generateCASignRequest(csr string) *structs.CASignR
 	}
 }
 
+func (c *caDelegateWithState) datacenters() []string {
+	return c.Server.router.GetDatacenters()
+}
+
 func (c *caDelegateWithState) ServersSupportMultiDCConnectCA() error {
 	versionOk, primaryFound := ServersInDCMeetMinimumVersion(c.Server, c.Server.config.PrimaryDatacenter, minMultiDCConnectVersion)
 	if !primaryFound {
@@ -155,6 +173,7 @@ func NewCAManager(delegate caServerDelegate, leaderRoutineManager *routine.Manag
 		serverConf:           config,
 		state:                caStateUninitialized,
 		leaderRoutineManager: leaderRoutineManager,
+		leafInventory:        newLeafCertInventory(),
 		timeNow:              time.Now,
 	}
 }
@@ -317,6 +336,8 @@ func (c *CAManager) setCAProvider(newProvider ca.Provider, root *structs.CARoot)
 }
 
 func (c *CAManager) Start(ctx context.Context) {
+	c.leafInventory.reset(c.timeNow())
+
 	// Attempt to initialize the Connect CA now. This will
 	// happen during leader establishment and it would be great
 	// if the CA was ready to go once that process was finished.
@@ -338,6 +359,7 @@ func (c *CAManager) Stop() {
 	c.leaderRoutineManager.Stop(secondaryCARootWatchRoutineName)
 	c.leaderRoutineManager.Stop(intermediateCertRenewWatchRoutineName)
 	c.leaderRoutineManager.Stop(backgroundCAInitializationRoutineName)
+	c.leaderRoutineManager.Stop(caRevocationListsRefreshRoutineName)
 
 	if provider, _ := c.getCAProvider(); provider != nil {
 		if needsStop, ok := provider.(ca.NeedsStop); ok {
@@ -357,6 +379,7 @@ func (c *CAManager) startPostInitializeRoutines(ctx context.Context) {
 	}
 
 	c.leaderRoutineManager.Start(ctx, intermediateCertRenewWatchRoutineName, c.runRenewIntermediate)
+	c.leaderRoutineManager.Start(ctx, caRevocationListsRefreshRoutineName, c.runRevocationListsRefresh)
 }
 
 func (c *CAManager) backgroundCAInitialization(ctx context.Context) error {
@@ -1500,7 +1523,21 @@ func (c *CAManager) AuthorizeAndSignCertificate(csr *x509.CertificateRequest, au
 	return c.SignCertificate(csr, spiffeID)
 }
 
+// SignCertificate signs a leaf certificate for the given SPIFFE ID, and records
+// it, or the error which prevented signing it, in the leaf inventory.
 func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID connect.CertURI) (*structs.IssuedCert, error) {
+	cert, err := c.signCertificate(csr, spiffeID)
+	if err != nil {
+		metrics.IncrCounter(metricsKeyMeshLeafCertsSignErrors, 1)
+		c.leafInventory.recordSignError(spiffeID, err, c.timeNow())
+		return nil, err
+	}
+	metrics.IncrCounter(metricsKeyMeshLeafCertsSigned, 1)
+	c.leafInventory.recordSigned(spiffeID, cert, c.timeNow())
+	return cert, nil
+}
+
+func (c *CAManager) signCertificate(csr *x509.CertificateRequest, spiffeID connect.CertURI) (*structs.IssuedCert, error) {
 	provider, caRoot := c.getCAProvider()
 	if provider == nil {
 		return nil, fmt.Errorf("CA is uninitialized and unable to sign certificates yet: provider is nil")
@@ -1714,3 +1751,403 @@ func providerPrettyName(provider string) string {
 		return provider
 	}
 }
+
+// caRevocationListValidity is how long the certificate revocation lists and
+// OCSP responses are valid for. Both are generated on request, so relying
+// parties can fetch fresh ones at any time.
+const caRevocationListValidity = time.Hour
+
+// RevokeCertificate records the certificate described by args as revoked, so
+// that it is listed in the revocation list and the OCSP responses of the
+// datacenter until it expires.
+func (c *CAManager) RevokeCertificate(args *structs.CARevokeRequest) error {
+	if err := validateRevocationReason(args.Reason); err != nil {
+		return err
+	}
+
+	now := c.timeNow()
+	revoked := &structs.CARevokedCert{
+		Reason:    args.Reason,
+		RevokedAt: now,
+	}
+
+	switch {
+	case args.Certificate != "":
+		cert, err := connect.ParseCert(args.Certificate)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		revoked.SerialNumber = connect.EncodeSerialNumber(cert.SerialNumber)
+		revoked.SigningKeyID = connect.EncodeSigningKeyID(cert.AuthorityKeyId)
+		revoked.NotAfter = cert.NotAfter
+
+		if args.SerialNumber != "" {
+			sn, err := connect.DecodeSerialNumber(args.SerialNumber)
+			if err != nil {
+				return err
+			}
+			if sn.Cmp(cert.SerialNumber) != 0 {
+				return fmt.Errorf("serial number %s does not match the certificate's serial number %s",
+					args.SerialNumber, revoked.SerialNumber)
+			}
+		}
+	case args.SerialNumber != "":
+		sn, err := connect.DecodeSerialNumber(args.SerialNumber)
+		if err != nil {
+			return err
+		}
+		revoked.SerialNumber = connect.EncodeSerialNumber(sn)
+
+		// Without the certificate its expiry is unknown, so keep the
+		// revocation for as long as any certificate issued by the CA can be
+		// valid.
+		_, config, err := c.delegate.State().CAConfig(nil)
+		if err != nil {
+			return err
+		}
+		commonCfg, err := config.GetCommonConfig()
+		if err != nil {
+			return err
+		}
+		ttl := commonCfg.LeafCertTTL
+		if commonCfg.IntermediateCertTTL > ttl {
+			ttl = commonCfg.IntermediateCertTTL
+		}
+		revoked.NotAfter = now.Add(ttl)
+	default:
+		return fmt.Errorf("a certificate or a serial number is required")
+	}
+
+	if !revoked.NotAfter.After(now) {
+		return fmt.Errorf("certificate %s already expired", revoked.SerialNumber)
+	}
+
+	req := &structs.CARequest{
+		Op:           structs.CAOpRevokeCertificates,
+		Datacenter:   c.serverConf.Datacenter,
+		RevokedCerts: []*structs.CARevokedCert{revoked},
+	}
+	if _, err := c.delegate.ApplyCARequest(req); err != nil {
+		return err
+	}
+
+	c.logger.Info("revoked certificate",
+		"serial_number", revoked.SerialNumber,
+		"reason", revoked.Reason,
+	)
+	return nil
+}
+
+// RevocationList returns the certificate revocation list of the datacenter,
+// signed by the active leaf signing cert.
+func (c *CAManager) RevocationList() (*structs.CARevocationList, error) {
+	signer, _, err := c.revocationSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	index, revoked, err := c.delegate.State().CARevokedCerts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.timeNow()
+	template := &x509.RevocationList{
+		Number:     new(big.Int).SetUint64(index),
+		ThisUpdate: now,
+		NextUpdate: now.Add(caRevocationListValidity),
+	}
+	for _, r := range revoked {
+		// Expired certificates are no longer trusted, so there is no need to
+		// list them.
+		if r.NotAfter.Before(now) {
+			continue
+		}
+		sn, err := connect.DecodeSerialNumber(r.SerialNumber)
+		if err != nil {
+			return nil, err
+		}
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   sn,
+			RevocationTime: r.RevokedAt,
+			ReasonCode:     r.Reason,
+		})
+	}
+
+	crl, err := signer.SignRevocationList(template)
+	if err != nil {
+		return nil, err
+	}
+	return &structs.CARevocationList{
+		CRL:        crl,
+		Number:     index,
+		ThisUpdate: template.ThisUpdate,
+		NextUpdate: template.NextUpdate,
+	}, nil
+}
+
+// OCSPResponse returns the DER encoded response to the DER encoded OCSP
+// request. Only certificates issued by the active leaf signing cert are
+// answered for, other requests get an unauthorized response.
+func (c *CAManager) OCSPResponse(der []byte) ([]byte, error) {
+	req, err := ocsp.ParseRequest(der)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+
+	signer, issuer, err := c.revocationSigner()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := ocspIssuerMatches(req, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	_, revoked, err := c.delegate.State().CARevokedCerts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.timeNow()
+	template := ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: req.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(caRevocationListValidity),
+	}
+	serialNumber := connect.EncodeSerialNumber(req.SerialNumber)
+	for _, r := range revoked {
+		if r.SerialNumber == serialNumber {
+			template.Status = ocsp.Revoked
+			template.RevokedAt = r.RevokedAt
+			template.RevocationReason = r.Reason
+			break
+		}
+	}
+
+	return signer.SignOCSPResponse(template)
+}
+
+// caRevocationListsRefreshInterval is how often the leader gathers the
+// certificate revocation lists of the other datacenters. It leaves time to
+// replace a list before it expires.
+const caRevocationListsRefreshInterval = caRevocationListValidity / 4
+
+// runRevocationListsRefresh gathers the certificate revocation lists of all
+// the datacenters whenever the revocations or the roots of this datacenter
+// change, and periodically to pick up the changes of the other datacenters.
+func (c *CAManager) runRevocationListsRefresh(ctx context.Context) error {
+	for {
+		state := c.delegate.State()
+		ws := memdb.NewWatchSet()
+		ws.Add(state.AbandonCh())
+		if _, _, err := state.CARevokedCerts(ws); err != nil {
+			return err
+		}
+		if _, _, err := state.CARoots(ws); err != nil {
+			return err
+		}
+
+		if err := c.refreshRevocationLists(); err != nil {
+			c.logger.Error("error refreshing the certificate revocation lists",
+				"routine", caRevocationListsRefreshRoutineName,
+				"error", err,
+			)
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, caRevocationListsRefreshInterval)
+		ws.WatchCtx(timeoutCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// refreshRevocationLists gathers the certificate revocation lists of all the
+// datacenters so that they are distributed along with the roots. Once a proxy
+// checks the leaf certs of its peers against a list, it needs the list of the
+// signer of every leaf cert, so the lists are only distributed while
+// certificates are revoked in any of the datacenters and the lists of all of
+// them are known.
+func (c *CAManager) refreshRevocationLists() error {
+	_, current, err := c.delegate.State().CARevocationLists(nil)
+	if err != nil {
+		return err
+	}
+
+	var (
+		lists    []*structs.CADatacenterRevocationList
+		revoked  bool
+		failures = make(map[string]error)
+	)
+	for _, dc := range c.delegate.datacenters() {
+		list, err := c.datacenterRevocationList(dc)
+		if err != nil {
+			failures[dc] = err
+			continue
+		}
+		lists = append(lists, list)
+		revoked = revoked || list.Revoked
+	}
+
+	// Keep the lists signed by keys that were rotated out, or of datacenters
+	// that could not be reached, for as long as they are valid, since the leaf
+	// certs those keys signed may still be in use.
+	if current != nil {
+		cutoff := c.timeNow().Add(caRevocationListsRefreshInterval)
+		for _, l := range current.Lists {
+			if l.NextUpdate.Before(cutoff) || hasRevocationList(lists, l.Datacenter, l.SigningKeyID) {
+				continue
+			}
+			lists = append(lists, l)
+			revoked = revoked || l.Revoked
+		}
+	}
+
+	var next *structs.CARevocationLists
+	if revoked {
+		next = &structs.CARevocationLists{Lists: lists}
+		for dc, err := range failures {
+			if !hasRevocationList(lists, dc, "") {
+				c.logger.Warn("not distributing the certificate revocation lists, since the list of a datacenter is unknown",
+					"datacenter", dc,
+					"error", err,
+				)
+				next = nil
+			}
+		}
+	}
+	if next == nil && current == nil {
+		return nil
+	}
+	if next != nil {
+		sort.Slice(next.Lists, func(i, j int) bool {
+			if next.Lists[i].Datacenter != next.Lists[j].Datacenter {
+				return next.Lists[i].Datacenter < next.Lists[j].Datacenter
+			}
+			return next.Lists[i].SigningKeyID < next.Lists[j].SigningKeyID
+		})
+	}
+
+	req := &structs.CARequest{
+		Op:              structs.CAOpSetRevocationLists,
+		Datacenter:      c.serverConf.Datacenter,
+		RevocationLists: next,
+	}
+	_, err = c.delegate.ApplyCARequest(req)
+	return err
+}
+
+// datacenterRevocationList returns the current certificate revocation list of
+// the given datacenter.
+func (c *CAManager) datacenterRevocationList(dc string) (*structs.CADatacenterRevocationList, error) {
+	var crl *structs.CARevocationList
+	if dc == c.serverConf.Datacenter {
+		var err error
+		if crl, err = c.RevocationList(); err != nil {
+			return nil, err
+		}
+	} else {
+		crl = &structs.CARevocationList{}
+		args := &structs.DCSpecificRequest{Datacenter: dc}
+		if err := c.delegate.forwardDC("ConnectCA.RevocationList", dc, args, crl); err != nil {
+			return nil, err
+		}
+	}
+
+	parsed, err := x509.ParseRevocationList(crl.CRL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing revocation list: %w", err)
+	}
+	return &structs.CADatacenterRevocationList{
+		Datacenter:   dc,
+		SigningKeyID: connect.EncodeSigningKeyID(parsed.AuthorityKeyId),
+		CRL:          crl.CRL,
+		Revoked:      len(parsed.RevokedCertificateEntries) > 0,
+		NextUpdate:   crl.NextUpdate,
+	}, nil
+}
+
+// hasRevocationList reports whether lists hold a list of the given datacenter
+// signed by the given key, or by any key when signingKeyID is empty.
+func hasRevocationList(lists []*structs.CADatacenterRevocationList, dc, signingKeyID string) bool {
+	for _, l := range lists {
+		if l.Datacenter == dc && (signingKeyID == "" || l.SigningKeyID == signingKeyID) {
+			return true
+		}
+	}
+	return false
+}
+
+// revocationSigner returns the current provider if it can sign revocation
+// lists, along with its active leaf signing cert.
+func (c *CAManager) revocationSigner() (ca.RevocationSigner, *x509.Certificate, error) {
+	provider, _ := c.getCAProvider()
+	if provider == nil {
+		return nil, nil, fmt.Errorf("CA is uninitialized and unable to sign revocation lists yet: provider is nil")
+	}
+
+	signer, ok := provider.(ca.RevocationSigner)
+	if !ok {
+		_, config, err := c.delegate.State().CAConfig(nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("the %s CA provider does not support certificate revocation lists",
+			providerPrettyName(config.Provider))
+	}
+
+	pem, err := provider.ActiveLeafSigningCert()
+	if err != nil {
+		return nil, nil, err
+	}
+	issuer, err := connect.ParseCert(pem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CA cert: %w", err)
+	}
+	return signer, issuer, nil
+}
+
+// validateRevocationReason checks that reason is an RFC 5280 CRLReason code
+// that can be used in a complete CRL.
+func validateRevocationReason(reason int) error {
+	switch reason {
+	case ocsp.Unspecified, ocsp.KeyCompromise, ocsp.CACompromise,
+		ocsp.AffiliationChanged, ocsp.Superseded, ocsp.CessationOfOperation,
+		ocsp.CertificateHold, ocsp.PrivilegeWithdrawn, ocsp.AACompromise:
+		return nil
+	default:
+		return fmt.Errorf("invalid revocation reason %d", reason)
+	}
+}
+
+// ocspIssuerMatches reports whether req asks about a certificate issued by
+// issuer.
+func ocspIssuerMatches(req *ocsp.Request, issuer *x509.Certificate) (bool, error) {
+	if !req.HashAlgorithm.Available() {
+		return false, nil
+	}
+
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false, fmt.Errorf("error parsing CA public key: %w", err)
+	}
+
+	h := req.HashAlgorithm.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+
+	return bytes.Equal(nameHash, req.IssuerNameHash) && bytes.Equal(keyHash, req.IssuerKeyHash), nil
+}
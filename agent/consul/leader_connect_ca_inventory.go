@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics/prometheus"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+var (
+	metricsKeyMeshLeafCertsSigned     = []string{"mesh", "leaf-certs", "signed"}
+	metricsKeyMeshLeafCertsSignErrors = []string{"mesh", "leaf-certs", "sign-errors"}
+	metricsKeyMeshLeafCertsExpiry     = []string{"mesh", "leaf-certs", "expiry"}
+)
+
+var LeafCertCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyMeshLeafCertsSigned,
+		Help: "Increments when the leader signs a leaf certificate.",
+	},
+	{
+		Name: metricsKeyMeshLeafCertsSignErrors,
+		Help: "Increments when the leader fails to sign a leaf certificate.",
+	},
+}
+
+// leafInventoryRetention is how long an identity is kept in the leaf inventory
+// after its latest certificate expired, or after the last error signing one.
+const leafInventoryRetention = 24 * time.Hour
+
+// errNoLeafCertsSigned is returned by the leaf certificate expiry query until
+// the leader signs a certificate.
+var errNoLeafCertsSigned = errors.New("no leaf certificate signed")
+
+// leafCertInventory keeps track of the leaf certificates signed for services,
+// workload identities and mesh gateways. Only the leader signs certificates, so
+// the inventory is reset when a server becomes the leader.
+type leafCertInventory struct {
+	lock       sync.Mutex
+	since      time.Time
+	identities map[string]*leafIdentityInventory
+}
+
+type leafIdentityInventory struct {
+	kind    string
+	name    string
+	entMeta acl.EnterpriseMeta
+
+	// certs are the signed certificates which didn't expire yet.
+	certs []leafCertRecord
+
+	signed       uint64
+	lastSigned   time.Time
+	latestExpiry time.Time
+
+	signErrors      uint64
+	lastSignError   string
+	lastSignErrorAt time.Time
+}
+
+type leafCertRecord struct {
+	signedAt time.Time
+	notAfter time.Time
+}
+
+func newLeafCertInventory() *leafCertInventory {
+	return &leafCertInventory{
+		identities: make(map[string]*leafIdentityInventory),
+	}
+}
+
+// reset clears the inventory.
+func (i *leafCertInventory) reset(now time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.since = now
+	i.identities = make(map[string]*leafIdentityInventory)
+}
+
+// recordSigned adds a certificate signed for the given SPIFFE ID.
+func (i *leafCertInventory) recordSigned(spiffeID connect.CertURI, cert *structs.IssuedCert, now time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	id := i.identityLocked(spiffeID)
+	if id == nil {
+		return
+	}
+	id.certs = append(id.certs, leafCertRecord{signedAt: now, notAfter: cert.ValidBefore})
+	id.signed++
+	id.lastSigned = now
+	id.latestExpiry = cert.ValidBefore
+	id.prune(now)
+}
+
+// recordSignError records an error signing a certificate for the given
+// SPIFFE ID.
+func (i *leafCertInventory) recordSignError(spiffeID connect.CertURI, err error, now time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	id := i.identityLocked(spiffeID)
+	if id == nil {
+		return
+	}
+	id.signErrors++
+	id.lastSignError = err.Error()
+	id.lastSignErrorAt = now
+	id.prune(now)
+}
+
+// identityLocked returns the inventory of the identity encoded in the SPIFFE
+// ID, creating it if needed. It returns nil for the agents and servers, which
+// aren't tracked.
+func (i *leafCertInventory) identityLocked(spiffeID connect.CertURI) *leafIdentityInventory {
+	var id leafIdentityInventory
+	switch v := spiffeID.(type) {
+	case *connect.SpiffeIDService:
+		id.kind, id.name, id.entMeta = "service", v.Service, *v.GetEnterpriseMeta()
+	case *connect.SpiffeIDWorkloadIdentity:
+		id.kind, id.name, id.entMeta = "workload-identity", v.WorkloadIdentity, *v.GetEnterpriseMeta()
+	case *connect.SpiffeIDMeshGateway:
+		id.kind, id.entMeta = string(structs.ServiceKindMeshGateway), *v.GetEnterpriseMeta()
+	default:
+		return nil
+	}
+	id.entMeta.Normalize()
+
+	key := id.kind + "/" + id.entMeta.PartitionOrDefault() + "/" + id.entMeta.NamespaceOrDefault() + "/" + id.name
+	if existing, ok := i.identities[key]; ok {
+		return existing
+	}
+	i.identities[key] = &id
+	return &id
+}
+
+// pruneLocked forgets the expired certificates, and the identities which had
+// no activity during the retention period.
+func (i *leafCertInventory) pruneLocked(now time.Time) {
+	for key, id := range i.identities {
+		if id.prune(now) {
+			delete(i.identities, key)
+		}
+	}
+}
+
+// prune forgets the expired certificates of the identity, and reports whether
+// it had no activity during the retention period.
+func (id *leafIdentityInventory) prune(now time.Time) bool {
+	active := id.certs[:0]
+	for _, cert := range id.certs {
+		if cert.notAfter.After(now) {
+			active = append(active, cert)
+		}
+	}
+	id.certs = active
+
+	return len(id.certs) == 0 &&
+		now.Sub(id.latestExpiry) > leafInventoryRetention &&
+		now.Sub(id.lastSignErrorAt) > leafInventoryRetention
+}
+
+// summary returns the inventory of each identity, sorted by the expiration
+// time of their latest certificate so the ones closest to running out of valid
+// certificates come first.
+func (i *leafCertInventory) summary(now time.Time) (time.Time, []*structs.CALeafInventoryEntry) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.pruneLocked(now)
+
+	entries := make([]*structs.CALeafInventoryEntry, 0, len(i.identities))
+	for _, id := range i.identities {
+		entry := &structs.CALeafInventoryEntry{
+			Kind:            id.kind,
+			Name:            id.name,
+			ActiveCerts:     len(id.certs),
+			Signed:          id.signed,
+			LastSigned:      id.lastSigned,
+			LatestExpiry:    id.latestExpiry,
+			SignErrors:      id.signErrors,
+			LastSignError:   id.lastSignError,
+			LastSignErrorAt: id.lastSignErrorAt,
+			EnterpriseMeta:  id.entMeta,
+		}
+		for _, cert := range id.certs {
+			if entry.EarliestExpiry.IsZero() || cert.notAfter.Before(entry.EarliestExpiry) {
+				entry.EarliestExpiry = cert.notAfter
+			}
+			// The minimum leaf certificate TTL is an hour, so the certificates
+			// signed during the last hour didn't expire yet.
+			if now.Sub(cert.signedAt) <= time.Hour {
+				entry.SignedLastHour++
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		if !entries[a].LatestExpiry.Equal(entries[b].LatestExpiry) {
+			return entries[a].LatestExpiry.Before(entries[b].LatestExpiry)
+		}
+		if entries[a].Kind != entries[b].Kind {
+			return entries[a].Kind < entries[b].Kind
+		}
+		return entries[a].Name < entries[b].Name
+	})
+	return i.since, entries
+}
+
+// nextExpiry returns the lifetime and the expiration time of the latest
+// certificate which expires first among the identities which still have a
+// valid certificate.
+func (i *leafCertInventory) nextExpiry(now time.Time) (time.Duration, time.Time, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.pruneLocked(now)
+
+	var next *leafIdentityInventory
+	for _, id := range i.identities {
+		if len(id.certs) == 0 {
+			continue
+		}
+		if next == nil || id.latestExpiry.Before(next.latestExpiry) {
+			next = id
+		}
+	}
+	if next == nil {
+		return 0, time.Time{}, errNoLeafCertsSigned
+	}
+	return next.latestExpiry.Sub(next.lastSigned), next.latestExpiry, nil
+}
+
+func leafCertExpiryMonitor(s *Server) CertExpirationMonitor {
+	return CertExpirationMonitor{
+		Key:    metricsKeyMeshLeafCertsExpiry,
+		Logger: s.logger.Named(logging.Connect),
+		Query: func() (time.Duration, time.Duration, error) {
+			now := time.Now()
+			lifetime, expiry, err := s.caManager.leafInventory.nextExpiry(now)
+			if err != nil {
+				return 0, 0, err
+			}
+			return lifetime, expiry.Sub(now), nil
+		},
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestLeafCertInventory(t *testing.T) {
+	now := time.Now()
+	inv := newLeafCertInventory()
+	inv.reset(now)
+
+	web := connect.TestSpiffeIDService(t, "web")
+	api := connect.TestSpiffeIDService(t, "api")
+	agent := &connect.SpiffeIDAgent{Host: "foo.consul", Datacenter: "dc1", Agent: "node1"}
+
+	_, _, err := inv.nextExpiry(now)
+	require.ErrorIs(t, err, errNoLeafCertsSigned)
+
+	inv.recordSigned(web, &structs.IssuedCert{ValidBefore: now.Add(2 * time.Hour)}, now.Add(-2*time.Hour))
+	inv.recordSigned(web, &structs.IssuedCert{ValidBefore: now.Add(3 * time.Hour)}, now)
+	inv.recordSigned(api, &structs.IssuedCert{ValidBefore: now.Add(time.Hour)}, now)
+	inv.recordSignError(api, errors.New("rate limited"), now)
+
+	// Agent certificates aren't tracked.
+	inv.recordSigned(agent, &structs.IssuedCert{ValidBefore: now.Add(time.Hour)}, now)
+
+	since, entries := inv.summary(now)
+	require.Equal(t, now, since)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "api", entries[0].Name)
+	require.Equal(t, 1, entries[0].ActiveCerts)
+	require.Equal(t, uint64(1), entries[0].SignErrors)
+	require.Equal(t, "rate limited", entries[0].LastSignError)
+
+	require.Equal(t, "web", entries[1].Name)
+	require.Equal(t, 2, entries[1].ActiveCerts)
+	require.Equal(t, 1, entries[1].SignedLastHour)
+	require.Equal(t, now.Add(2*time.Hour), entries[1].EarliestExpiry)
+	require.Equal(t, now.Add(3*time.Hour), entries[1].LatestExpiry)
+
+	lifetime, expiry, err := inv.nextExpiry(now)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, lifetime)
+	require.Equal(t, now.Add(time.Hour), expiry)
+
+	// Once the certificates of api expired, the next to expire is web's.
+	later := now.Add(90 * time.Minute)
+	_, expiry, err = inv.nextExpiry(later)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(3*time.Hour), expiry)
+
+	_, entries = inv.summary(later)
+	require.Len(t, entries, 2)
+	require.Equal(t, "api", entries[0].Name)
+	require.Equal(t, 0, entries[0].ActiveCerts)
+
+	// The identities are forgotten after the retention period.
+	_, entries = inv.summary(now.Add(3*time.Hour + leafInventoryRetention + time.Second))
+	require.Empty(t, entries)
+
+	inv.recordSigned(web, &structs.IssuedCert{ValidBefore: now.Add(time.Hour)}, now)
+	inv.reset(later)
+	since, entries = inv.summary(later)
+	require.Equal(t, later, since)
+	require.Empty(t, entries)
+}
@@ -242,6 +242,10 @@ func (m *mockCAServerDelegate) forwardDC(method, dc string, args interface{}, re
 	return nil
 }
 
+func (m *mockCAServerDelegate) datacenters() []string {
+	return []string{m.config.Datacenter}
+}
+
 func (m *mockCAServerDelegate) generateCASignRequest(csr string) *structs.CASignRequest {
 	return &structs.CASignRequest{
 		Datacenter: m.config.PrimaryDatacenter,
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+const (
+	// intentionExpiryInterval is how often the leader looks for the
+	// intentions which expired or are overdue for review.
+	intentionExpiryInterval = time.Minute
+)
+
+var metricsKeyIntentionsReviewOverdue = []string{"leader", "intentions", "review_overdue"}
+
+var LeaderIntentionExpiryGauges = []prometheus.GaugeDefinition{
+	{
+		Name: metricsKeyIntentionsReviewOverdue,
+		Help: "The number of enabled intentions whose ReviewBy time has passed. Updated every minute",
+	},
+}
+
+func (s *Server) startIntentionExpiry(ctx context.Context) {
+	// Config entries are only written in the primary datacenter, the other
+	// datacenters get the updated entries through replication.
+	if s.config.PrimaryDatacenter != s.config.Datacenter {
+		return
+	}
+	s.leaderRoutineManager.Start(ctx, intentionExpiryRoutineName, s.runIntentionExpiry)
+}
+
+// runIntentionExpiry reports the intentions which expired or are overdue for
+// review, and disables them if configured to.
+func (s *Server) runIntentionExpiry(ctx context.Context) error {
+	ticker := time.NewTicker(intentionExpiryInterval)
+	defer ticker.Stop()
+
+	var reported map[string]struct{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		var err error
+		reported, err = s.expireIntentions(time.Now(), reported)
+		if err != nil {
+			s.loggers.Named(logging.Connect).Error("error expiring intentions", "error", err)
+		}
+	}
+}
+
+// expireIntentions looks for the enabled intentions which expired or are
+// overdue for review. It disables them if DisableExpiredIntentions or
+// DisableIntentionsAfterReview is set, and otherwise logs a warning for each
+// intention that expired or became overdue since the previous pass. It returns
+// the intentions which were reported in this pass.
+func (s *Server) expireIntentions(now time.Time, prevReported map[string]struct{}) (map[string]struct{}, error) {
+	logger := s.loggers.Named(logging.Connect)
+
+	_, entries, err := s.fsm.State().ConfigEntriesByKind(nil, structs.ServiceIntentions, acl.WildcardEnterpriseMeta())
+	if err != nil {
+		return prevReported, err
+	}
+
+	var (
+		reported = make(map[string]struct{})
+		overdue  int
+	)
+	report := func(key, msg string, args ...interface{}) {
+		reported[key] = struct{}{}
+		if _, ok := prevReported[key]; !ok {
+			logger.Warn(msg, append([]interface{}{"intention", key}, args...)...)
+		}
+	}
+	for _, raw := range entries {
+		entry, ok := raw.(*structs.ServiceIntentionsConfigEntry)
+		if !ok {
+			continue
+		}
+
+		var updated *structs.ServiceIntentionsConfigEntry
+		for i, src := range entry.Sources {
+			if src.Disabled {
+				continue
+			}
+			ixn := entry.ToIntention(src).String()
+
+			var disable bool
+			switch {
+			case src.IsExpired(now) && s.config.DisableExpiredIntentions:
+				logger.Info("disabling expired intention", "intention", ixn, "expires_at", src.ExpiresAt)
+				disable = true
+			case src.IsExpired(now):
+				report("expired:"+ixn, "intention has expired but is still enforced", "expires_at", src.ExpiresAt)
+			case src.IsReviewOverdue(now) && s.config.DisableIntentionsAfterReview:
+				logger.Info("disabling intention overdue for review", "intention", ixn, "review_by", src.ReviewBy)
+				disable = true
+			case src.IsReviewOverdue(now):
+				overdue++
+				report("overdue:"+ixn, "intention is overdue for review", "review_by", src.ReviewBy)
+			}
+			if !disable {
+				continue
+			}
+
+			if updated == nil {
+				copied := *entry
+				copied.Sources = make([]*structs.SourceIntention, len(entry.Sources))
+				copy(copied.Sources, entry.Sources)
+				updated = &copied
+			}
+			disabled := src.Clone()
+			disabled.Disabled = true
+			updated.Sources[i] = disabled
+		}
+		if updated == nil {
+			continue
+		}
+
+		// A failed check-and-set means the entry was modified concurrently,
+		// the next pass will handle it.
+		req := &structs.ConfigEntryRequest{Op: structs.ConfigEntryUpsertCAS, Entry: updated}
+		if _, err := s.leaderRaftApply("ConfigEntry.Apply", structs.ConfigEntryRequestType, req); err != nil {
+			return reported, err
+		}
+	}
+
+	metrics.SetGauge(metricsKeyIntentionsReviewOverdue, float32(overdue))
+	return reported, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestLeader_IntentionExpiry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	writeEntries := func(t *testing.T, s *Server) {
+		codec := rpcClient(t, s)
+		defer codec.Close()
+
+		for _, entry := range []*structs.ServiceIntentionsConfigEntry{
+			{
+				Kind: structs.ServiceIntentions,
+				Name: "web",
+				Sources: []*structs.SourceIntention{
+					{Name: "expired", Action: structs.IntentionActionAllow, ExpiresAt: &past},
+					{Name: "overdue", Action: structs.IntentionActionAllow, ReviewBy: &past, ExpiresAt: &future},
+					{Name: "current", Action: structs.IntentionActionAllow, ReviewBy: &future},
+				},
+			},
+			{
+				Kind: structs.ServiceIntentions,
+				Name: "api",
+				Sources: []*structs.SourceIntention{
+					{Name: "expired", Action: structs.IntentionActionDeny, ExpiresAt: &past},
+				},
+			},
+		} {
+			var ok bool
+			require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+				Datacenter: "dc1",
+				Entry:      entry,
+			}, &ok))
+			require.True(t, ok)
+		}
+	}
+
+	// disabled returns the names of the disabled sources of the entry.
+	disabled := func(t *testing.T, s *Server, name string) []string {
+		_, raw, err := s.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, name, nil)
+		require.NoError(t, err)
+		require.NotNil(t, raw)
+		var names []string
+		for _, src := range raw.(*structs.ServiceIntentionsConfigEntry).Sources {
+			if src.Disabled {
+				names = append(names, src.Name)
+			}
+		}
+		return names
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		_, s1 := testServer(t)
+		waitForLeaderEstablishment(t, s1)
+		writeEntries(t, s1)
+
+		reported, err := s1.expireIntentions(now, nil)
+		require.NoError(t, err)
+		require.Len(t, reported, 3)
+		require.Empty(t, disabled(t, s1, "web"))
+		require.Empty(t, disabled(t, s1, "api"))
+
+		// A second pass still reports the same intentions.
+		again, err := s1.expireIntentions(now, reported)
+		require.NoError(t, err)
+		require.Equal(t, reported, again)
+	})
+
+	t.Run("disable expired", func(t *testing.T) {
+		_, s1 := testServerWithConfig(t, func(c *Config) {
+			c.DisableExpiredIntentions = true
+		})
+		waitForLeaderEstablishment(t, s1)
+		writeEntries(t, s1)
+
+		reported, err := s1.expireIntentions(now, nil)
+		require.NoError(t, err)
+		require.Len(t, reported, 1)
+		for key := range reported {
+			require.Contains(t, key, "overdue")
+		}
+		require.Equal(t, []string{"expired"}, disabled(t, s1, "web"))
+		// The entry left without any enabled source is kept.
+		require.Equal(t, []string{"expired"}, disabled(t, s1, "api"))
+
+		// The disabled intentions are listed but no longer matched.
+		_, ixns, err := s1.fsm.State().IntentionMatchOne(nil, structs.IntentionMatchEntry{
+			Namespace: structs.IntentionDefaultNamespace,
+			Partition: acl.DefaultPartitionName,
+			Name:      "web",
+		}, structs.IntentionMatchDestination, structs.IntentionTargetService)
+		require.NoError(t, err)
+		var names []string
+		for _, ixn := range ixns {
+			names = append(names, ixn.SourceName)
+		}
+		require.ElementsMatch(t, []string{"overdue", "current"}, names)
+	})
+
+	t.Run("disable after review", func(t *testing.T) {
+		_, s1 := testServerWithConfig(t, func(c *Config) {
+			c.DisableExpiredIntentions = true
+			c.DisableIntentionsAfterReview = true
+		})
+		waitForLeaderEstablishment(t, s1)
+		writeEntries(t, s1)
+
+		reported, err := s1.expireIntentions(now, nil)
+		require.NoError(t, err)
+		require.Empty(t, reported)
+		require.ElementsMatch(t, []string{"expired", "overdue"}, disabled(t, s1, "web"))
+
+		// A second pass has nothing left to disable.
+		index, _, err := s1.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, "web", nil)
+		require.NoError(t, err)
+		_, err = s1.expireIntentions(now, reported)
+		require.NoError(t, err)
+		after, _, err := s1.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, "web", nil)
+		require.NoError(t, err)
+		require.Equal(t, index, after)
+	})
+}
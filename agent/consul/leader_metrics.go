@@ -33,6 +33,10 @@ var LeaderCertExpirationGauges = []prometheus.GaugeDefinition{
 		Name: metricsKeyMeshActiveSigningCAExpiry,
 		Help: "Seconds until the service mesh signing certificate expires. Updated every hour",
 	},
+	{
+		Name: metricsKeyMeshLeafCertsExpiry,
+		Help: "Seconds until the first service runs out of valid leaf certificates if they aren't rotated. Updated every hour",
+	},
 }
 
 func rootCAExpiryMonitor(s *Server) CertExpirationMonitor {
@@ -121,6 +125,10 @@ func (m CertExpirationMonitor) Monitor(ctx context.Context) error {
 
 	emitMetric := func() {
 		lifetime, untilAfter, err := m.Query()
+		if errors.Is(err, errNoLeafCertsSigned) {
+			metrics.SetGaugeWithLabels(m.Key, float32(math.NaN()), m.Labels)
+			return
+		}
 		if err != nil {
 			logger.Warn("failed to emit certificate expiry metric", "error", err)
 			return
@@ -141,6 +149,12 @@ func (m CertExpirationMonitor) Monitor(ctx context.Context) error {
 					"expiration", time.Now().Add(untilAfter),
 					"suggested_action", "check consul logs for rotation issues",
 				)
+			case "mesh:leaf-certs:expiry":
+				logger.Warn("leaf certificates will expire soon without being rotated",
+					"time_to_expiry", untilAfter,
+					"expiration", time.Now().Add(untilAfter),
+					"suggested_action", "check the certificate inventory for rotation issues",
+				)
 			case "agent:tls:cert:expiry":
 				logger.Warn("agent TLS certificate will expire soon",
 					"time_to_expiry", untilAfter,
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/consul/agent/grpc-external/services/peerstream"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/proto/private/pbcommon"
 	"github.com/hashicorp/consul/proto/private/pbpeering"
@@ -39,6 +40,7 @@ var leaderExportedServicesCountKeyDeprecated = []string{"consul", "peering", "ex
 var leaderExportedServicesCountKey = []string{"peering", "exported_services"}
 var leaderHealthyPeeringKeyDeprecated = []string{"consul", "peering", "healthy"}
 var leaderHealthyPeeringKey = []string{"peering", "healthy"}
+var leaderPeeringReplicationLagKey = []string{"peering", "replication_lag"}
 var LeaderPeeringMetrics = []prometheus.GaugeDefinition{
 	{
 		Name: leaderExportedServicesCountKeyDeprecated,
@@ -60,6 +62,12 @@ var LeaderPeeringMetrics = []prometheus.GaugeDefinition{
 			"The labels are \"peer_name\", \"peer_id\" and, for enterprise, \"partition\". " +
 			"We emit this metric every 9 seconds",
 	},
+	{
+		Name: leaderPeeringReplicationLagKey,
+		Help: "A gauge that tracks how many seconds the data replicated from the peer may be behind the peer. " +
+			"The labels are \"peer_name\", \"peer_id\" and, for enterprise, \"partition\". " +
+			"We emit this metric every 9 seconds",
+	},
 }
 var (
 	// fastConnRetryTimeout is how long we wait between retrying connections following the "fast" path
@@ -136,6 +144,9 @@ func (s *Server) emitPeeringMetricsOnce(metricsImpl *metrics.Metrics) error {
 		}
 		metricsImpl.SetGaugeWithLabels(leaderHealthyPeeringKeyDeprecated, float32(healthy), labels)
 		metricsImpl.SetGaugeWithLabels(leaderHealthyPeeringKey, float32(healthy), labels)
+
+		lag := peeringReplicationLag(status, time.Now())
+		metricsImpl.SetGaugeWithLabels(leaderPeeringReplicationLagKey, float32(lag.Seconds()), labels)
 	}
 
 	return nil
@@ -344,6 +355,10 @@ func (s *Server) establishStream(ctx context.Context,
 	nextServerAddr := make(chan string)
 	go s.watchAddresses(streamCtx, peer.ID, nextServerAddr)
 
+	// The stream is compressed with the configured algorithm until the peer
+	// rejects it for not supporting it.
+	compressor := compression.GRPCName(s.config.RPCConfig.Compression)
+
 	// Establish a stream-specific retry so that retrying stream/conn errors isn't dependent on state store changes.
 	go retryLoopBackoffPeering(streamCtx, logger, func() error {
 		// Try a new address on each iteration by advancing the ring buffer on errors.
@@ -366,6 +381,9 @@ func (s *Server) establishStream(ctx context.Context,
 			}),
 			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(8*1024*1024), grpc.MaxCallRecvMsgSize(8*1024*1024)),
 		}
+		if compressor != "" {
+			opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(compressor)))
+		}
 
 		logger.Trace("dialing peer", "addr", addr)
 		conn, err := grpc.DialContext(streamCtx, addr, opts...)
@@ -428,6 +446,12 @@ func (s *Server) establishStream(ctx context.Context,
 			logger.Debug("stream disconnected due to 'resource exhausted' error; reconnecting",
 				"error", err)
 
+		case compressor != "" && isErrCode(err, codes.Unimplemented) && strings.Contains(err.Error(), "grpc-encoding"):
+			// Peers that predate the compression don't have its decompressor.
+			logger.Info("peer does not support stream compression; reconnecting uncompressed",
+				"compression", compressor)
+			compressor = ""
+
 		case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
 			logger.Debug("stream context was canceled", "error", err)
 
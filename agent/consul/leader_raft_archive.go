@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+func (s *Server) startRaftArchive(ctx context.Context) {
+	if !s.config.RaftArchive.Enabled() {
+		return
+	}
+	s.leaderRoutineManager.Start(ctx, raftArchiveRoutineName, s.runRaftArchive)
+}
+
+func (s *Server) stopRaftArchive() {
+	s.leaderRoutineManager.Stop(raftArchiveRoutineName)
+}
+
+// runRaftArchive periodically writes the committed Raft log entries to the
+// archive, starting after the checkpoint recorded by the previous leader.
+func (s *Server) runRaftArchive(ctx context.Context) error {
+	logger := s.loggers.Named(logging.RaftArchive)
+
+	sink, err := newRaftArchiveSink(s.config.RaftArchive)
+	if err != nil {
+		logger.Error("failed to set up the raft archive sink", "error", err)
+		return err
+	}
+	types, err := raftArchiveMessageTypes(s.config.RaftArchive.Types)
+	if err != nil {
+		logger.Error("invalid raft archive configuration", "error", err)
+		return err
+	}
+
+	interval := s.config.RaftArchive.Interval
+	if interval <= 0 {
+		interval = raftArchiveDefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	archiver := &raftArchiver{
+		server: s,
+		sink:   sink,
+		types:  types,
+		logger: logger,
+	}
+	for {
+		if err := archiver.archive(ctx); err != nil {
+			metrics.IncrCounter(metricsKeyRaftArchiveFailed, 1)
+			logger.Error("failed to archive raft log entries", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// raftArchiver tracks the progress of the archive while this server is the
+// leader.
+type raftArchiver struct {
+	server *Server
+	sink   raftArchiveSink
+	types  map[structs.MessageType]struct{}
+	logger hclog.Logger
+
+	// next is the index of the next entry to read, zero until the
+	// checkpoint is loaded.
+	next uint64
+}
+
+// archive writes the entries committed since the last pass to the sink. The
+// checkpoint is only recorded once entries were written, so the passes not
+// archiving anything don't append to the log themselves.
+func (a *raftArchiver) archive(ctx context.Context) error {
+	if a.next == 0 {
+		checkpoint, err := a.server.raftArchiveCheckpoint()
+		if err != nil {
+			return err
+		}
+		a.next = checkpoint + 1
+	}
+
+	first, err := a.server.raftLog.FirstIndex()
+	if err != nil {
+		return fmt.Errorf("failed to read the first index of the raft log: %w", err)
+	}
+	if first > 0 && a.next < first {
+		lost := first - a.next
+		metrics.IncrCounter(metricsKeyRaftArchiveLost, float32(lost))
+		a.logger.Warn("raft log entries were compacted before they could be archived",
+			"from", a.next,
+			"to", first-1,
+		)
+		a.next = first
+	}
+
+	last := a.server.raft.AppliedIndex()
+	for a.next <= last {
+		end := a.next + raftArchiveBatchSize - 1
+		if end > last {
+			end = last
+		}
+
+		records, err := a.read(a.next, end)
+		if err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			if err := a.sink.Archive(ctx, records); err != nil {
+				return err
+			}
+			metrics.IncrCounter(metricsKeyRaftArchiveArchived, float32(len(records)))
+			if err := a.server.setRaftArchiveCheckpoint(end); err != nil {
+				return err
+			}
+		}
+		a.next = end + 1
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// read returns the records of the entries between the indexes, inclusive,
+// which must be archived.
+func (a *raftArchiver) read(from, to uint64) ([]RaftArchiveRecord, error) {
+	var records []RaftArchiveRecord
+	for index := from; index <= to; index++ {
+		var entry raft.Log
+		if err := a.server.raftLog.GetLog(index, &entry); err != nil {
+			return nil, fmt.Errorf("failed to read raft log entry %d: %w", index, err)
+		}
+		if entry.Type != raft.LogCommand || len(entry.Data) == 0 {
+			continue
+		}
+
+		msgType := structs.MessageType(entry.Data[0]) &^ structs.IgnoreUnknownTypeFlag
+		if a.types != nil {
+			if _, ok := a.types[msgType]; !ok {
+				continue
+			}
+		}
+		if isRaftArchiveCheckpoint(msgType, entry.Data[1:]) {
+			continue
+		}
+		if len(entry.Extensions) > 0 {
+			// Large requests are split in chunks which are only reassembled
+			// by the FSM.
+			metrics.IncrCounter(metricsKeyRaftArchiveLost, 1)
+			a.logger.Warn("skipping chunked raft log entry", "index", entry.Index, "type", msgType.String())
+			continue
+		}
+
+		records = append(records, RaftArchiveRecord{
+			Index:      entry.Index,
+			Term:       entry.Term,
+			Type:       msgType.String(),
+			AppendedAt: entry.AppendedAt,
+			Data:       entry.Data[1:],
+		})
+	}
+	return records, nil
+}
+
+// isRaftArchiveCheckpoint returns whether the entry records the checkpoint of
+// the archive, such entries are never archived.
+func isRaftArchiveCheckpoint(msgType structs.MessageType, data []byte) bool {
+	if msgType != structs.SystemMetadataRequestType {
+		return false
+	}
+	var req structs.SystemMetadataRequest
+	if err := structs.Decode(data, &req); err != nil {
+		return false
+	}
+	return req.Entry != nil && req.Entry.Key == structs.SystemMetadataRaftArchiveCheckpoint
+}
+
+// raftArchiveCheckpoint returns the index of the last entry archived, zero if
+// none was.
+func (s *Server) raftArchiveCheckpoint() (uint64, error) {
+	value, err := s.GetSystemMetadata(structs.SystemMetadataRaftArchiveCheckpoint)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+	index, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid raft archive checkpoint %q: %w", value, err)
+	}
+	return index, nil
+}
+
+func (s *Server) setRaftArchiveCheckpoint(index uint64) error {
+	return s.SetSystemMetadataKey(structs.SystemMetadataRaftArchiveCheckpoint, strconv.FormatUint(index, 10))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestRaftArchiveMessageTypes(t *testing.T) {
+	types, err := raftArchiveMessageTypes(nil)
+	require.NoError(t, err)
+	require.Nil(t, types)
+
+	types, err = raftArchiveMessageTypes([]string{"KVS", "ConfigEntry"})
+	require.NoError(t, err)
+	require.Equal(t, map[structs.MessageType]struct{}{
+		structs.KVSRequestType:         {},
+		structs.ConfigEntryRequestType: {},
+	}, types)
+
+	_, err = raftArchiveMessageTypes([]string{"KV"})
+	require.EqualError(t, err, `unknown raft message type "KV"`)
+}
+
+func TestRaftArchiveObjectKey(t *testing.T) {
+	records := []RaftArchiveRecord{{Index: 12}, {Index: 345}}
+	require.Equal(t, "00000000000000000012-00000000000000000345.jsonl", raftArchiveObjectKey("", records))
+	require.Equal(t, "dc1/00000000000000000012-00000000000000000345.jsonl", raftArchiveObjectKey("dc1", records))
+	require.Equal(t, "dc1/00000000000000000012-00000000000000000345.jsonl", raftArchiveObjectKey("dc1/", records))
+}
+
+func TestLeader_RaftArchive(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	path := filepath.Join(t.TempDir(), "archive", "raft.jsonl")
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.RaftArchive = RaftArchiveConfig{
+			Types:    []string{"KVS"},
+			Interval: 50 * time.Millisecond,
+			FilePath: path,
+		}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	var out bool
+	for _, key := range []string{"foo", "bar"} {
+		require.NoError(t, s1.RPC(context.Background(), "KVS.Apply", &structs.KVSRequest{
+			Datacenter: "dc1",
+			Op:         api.KVSet,
+			DirEnt:     structs.DirEntry{Key: key, Value: []byte("value")},
+		}, &out))
+	}
+	var reg struct{}
+	require.NoError(t, s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "node1",
+		Address:    "127.0.0.1",
+	}, &reg))
+
+	readArchive := func(r require.TestingT) []RaftArchiveRecord {
+		f, err := os.Open(path)
+		require.NoError(r, err)
+		defer f.Close()
+
+		var records []RaftArchiveRecord
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record RaftArchiveRecord
+			require.NoError(r, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		require.NoError(r, scanner.Err())
+		return records
+	}
+
+	var records []RaftArchiveRecord
+	retry.Run(t, func(r *retry.R) {
+		records = readArchive(r)
+		require.Len(r, records, 2)
+	})
+
+	var keys []string
+	for i, record := range records {
+		require.Equal(t, "KVS", record.Type)
+		if i > 0 {
+			require.Greater(t, record.Index, records[i-1].Index)
+		}
+
+		var req structs.KVSRequest
+		require.NoError(t, structs.Decode(record.Data, &req))
+		keys = append(keys, req.DirEnt.Key)
+	}
+	require.Equal(t, []string{"foo", "bar"}, keys)
+
+	checkpoint, err := s1.raftArchiveCheckpoint()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, checkpoint, records[1].Index)
+
+	// Recording the checkpoint doesn't cause anything else to be archived.
+	time.Sleep(200 * time.Millisecond)
+	require.Len(t, readArchive(t), 2)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+
+	"github.com/hashicorp/consul/acl"
+	registrationgrpc "github.com/hashicorp/consul/agent/grpc-external/services/registration"
+	"github.com/hashicorp/consul/agent/metadata"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// leaseOwnerTag is the Serf tag advertising the identifier a server
+	// records in the node meta of the registrations whose lease it holds.
+	leaseOwnerTag = "lease_owner"
+
+	// registrationReapInterval is how often the leader looks for the
+	// registrations whose lease was held by a server which is gone.
+	registrationReapInterval = time.Minute
+)
+
+func (s *Server) startRegistrationLeaseReaping(ctx context.Context) {
+	s.leaderRoutineManager.Start(ctx, registrationLeaseReapingRoutineName, s.runRegistrationLeaseReaping)
+}
+
+func (s *Server) stopRegistrationLeaseReaping() {
+	s.leaderRoutineManager.Stop(registrationLeaseReapingRoutineName)
+}
+
+// runRegistrationLeaseReaping deregisters the nodes registered through the
+// registration gRPC API whose lease was held by a server which has crashed or
+// left, since no server will ever expire their lease. An owner has to be
+// missing for two consecutive passes, so a server briefly flapping in Serf
+// doesn't lose its registrations.
+func (s *Server) runRegistrationLeaseReaping(ctx context.Context) error {
+	ticker := time.NewTicker(registrationReapInterval)
+	defer ticker.Stop()
+
+	var missing map[string]struct{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		var err error
+		missing, err = s.reapOrphanedRegistrations(missing)
+		if err != nil {
+			s.logger.Error("error reaping orphaned registrations", "error", err)
+		}
+	}
+}
+
+// reapOrphanedRegistrations deregisters the nodes whose lease owner is not an
+// alive server and was already missing from the previous pass. It returns the
+// owners which are missing in this pass.
+func (s *Server) reapOrphanedRegistrations(prevMissing map[string]struct{}) (map[string]struct{}, error) {
+	alive := s.aliveLeaseOwners()
+
+	_, nodes, err := s.fsm.State().Nodes(nil, structs.NodeEnterpriseMetaInPartition(acl.WildcardPartitionName), structs.DefaultPeerKeyword)
+	if err != nil {
+		return prevMissing, err
+	}
+
+	missing := make(map[string]struct{})
+	for _, node := range nodes {
+		owner, ok := node.Meta[registrationgrpc.LeaseOwnerMetaKey]
+		if !ok {
+			continue
+		}
+		if _, ok := alive[owner]; ok {
+			continue
+		}
+		missing[owner] = struct{}{}
+		if _, ok := prevMissing[owner]; !ok {
+			continue
+		}
+
+		req := structs.DeregisterRequest{
+			Node:           node.Node,
+			EnterpriseMeta: *node.GetEnterpriseMeta(),
+		}
+		if _, err := s.raftApply(structs.DeregisterRequestType, &req); err != nil {
+			return missing, err
+		}
+		metrics.IncrCounter([]string{"leader", "registration", "reaped"}, 1)
+		s.logger.Info("deregistered node whose lease owner is gone", "node", node.Node, "owner", owner)
+	}
+	return missing, nil
+}
+
+// aliveLeaseOwners returns the lease owners advertised by the alive servers.
+func (s *Server) aliveLeaseOwners() map[string]struct{} {
+	owners := make(map[string]struct{})
+	for _, m := range s.serfLAN.Members() {
+		if m.Status != serf.StatusAlive {
+			continue
+		}
+		if ok, _ := metadata.IsConsulServer(m); !ok {
+			continue
+		}
+		if owner := m.Tags[leaseOwnerTag]; owner != "" {
+			owners[owner] = struct{}{}
+		}
+	}
+	return owners
+}
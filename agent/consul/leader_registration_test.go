@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	registrationgrpc "github.com/hashicorp/consul/agent/grpc-external/services/registration"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestLeader_ReapOrphanedRegistrations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServer(t)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// The server advertises its lease owner ID.
+	require.Contains(t, s1.aliveLeaseOwners(), s1.leaseOwner)
+
+	state := s1.fsm.State()
+	for i, node := range []*structs.Node{
+		{Node: "held", Address: "10.0.0.1", Meta: map[string]string{registrationgrpc.LeaseOwnerMetaKey: s1.leaseOwner}},
+		{Node: "orphaned", Address: "10.0.0.2", Meta: map[string]string{registrationgrpc.LeaseOwnerMetaKey: "gone"}},
+		{Node: "agent", Address: "10.0.0.3"},
+	} {
+		require.NoError(t, state.EnsureNode(uint64(100+i), node))
+	}
+
+	nodeExists := func(name string) bool {
+		_, node, err := state.GetNode(name, nil, "")
+		require.NoError(t, err)
+		return node != nil
+	}
+
+	// Owners have to be missing from two consecutive passes.
+	missing, err := s1.reapOrphanedRegistrations(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"gone": {}}, missing)
+	require.True(t, nodeExists("orphaned"))
+
+	missing, err = s1.reapOrphanedRegistrations(missing)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"gone": {}}, missing)
+	require.False(t, nodeExists("orphaned"))
+	require.True(t, nodeExists("held"))
+	require.True(t, nodeExists("agent"))
+}
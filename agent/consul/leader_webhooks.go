@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/logging"
+)
+
+const (
+	// webhookWatchInterval is the minimum time between two passes looking
+	// for changes, so bursts of writes are coalesced.
+	webhookWatchInterval = time.Second
+
+	// webhookErrorWait is how long the leader waits before looking for
+	// changes again after a failure.
+	webhookErrorWait = 5 * time.Second
+)
+
+func (s *Server) startWebhooks(ctx context.Context) {
+	if len(s.config.Webhooks) == 0 {
+		return
+	}
+	s.leaderRoutineManager.Start(ctx, webhooksRoutineName, s.runWebhooks)
+}
+
+func (s *Server) stopWebhooks() {
+	s.leaderRoutineManager.Stop(webhooksRoutineName)
+}
+
+// runWebhooks watches the catalog and the config entries, and posts their
+// changes to the configured webhooks. Only the changes made while this server
+// is the leader are posted: the first pass records the current state without
+// posting anything.
+func (s *Server) runWebhooks(ctx context.Context) error {
+	logger := s.loggers.Named(logging.Webhooks)
+
+	senders := make([]*webhookSender, 0, len(s.config.Webhooks))
+	for _, config := range s.config.Webhooks {
+		sender := newWebhookSender(config, logger)
+		senders = append(senders, sender)
+		go sender.run(ctx)
+	}
+
+	watcher := newWebhookWatcher(s.config.Datacenter)
+	for {
+		ws := memdb.NewWatchSet()
+		store := s.fsm.State()
+		ws.Add(store.AbandonCh())
+
+		events, err := watcher.update(ws, store, time.Now())
+		if err != nil {
+			logger.Error("error looking for changes", "error", err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(webhookErrorWait):
+			}
+			continue
+		}
+		for _, e := range events {
+			for _, sender := range senders {
+				sender.enqueue(e)
+			}
+		}
+
+		if err := ws.WatchCtx(ctx); err != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(webhookWatchInterval):
+		}
+	}
+}
+
+// webhookWatcher computes the events posted to webhooks by comparing the
+// state of the catalog and of the config entries with the one of its previous
+// pass.
+type webhookWatcher struct {
+	datacenter string
+	seeded     bool
+
+	// instances holds the service instances by node, partition, namespace
+	// and ID.
+	instances map[string]WebhookServiceInstance
+
+	// entries holds the modify index of the config entries by kind,
+	// partition, namespace and name.
+	entries map[string]webhookConfigEntryState
+}
+
+type webhookConfigEntryState struct {
+	entry       WebhookConfigEntry
+	modifyIndex uint64
+}
+
+func newWebhookWatcher(datacenter string) *webhookWatcher {
+	return &webhookWatcher{
+		datacenter: datacenter,
+		instances:  make(map[string]WebhookServiceInstance),
+		entries:    make(map[string]webhookConfigEntryState),
+	}
+}
+
+// update returns the events for the changes made since the previous call,
+// adding to ws the channels which fire on the next change.
+func (w *webhookWatcher) update(ws memdb.WatchSet, store *state.Store, now time.Time) ([]*WebhookEvent, error) {
+	catalogIndex, nodes, err := store.ServiceDump(ws, "", false, acl.WildcardEnterpriseMeta(), structs.DefaultPeerKeyword)
+	if err != nil {
+		return nil, err
+	}
+	entriesIndex, entries, err := store.ConfigEntries(ws, acl.WildcardEnterpriseMeta())
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*WebhookEvent
+	emit := func(typ string, index uint64, instance *WebhookServiceInstance, entry *WebhookConfigEntry) {
+		if !w.seeded {
+			return
+		}
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return
+		}
+		events = append(events, &WebhookEvent{
+			ID:          id,
+			Type:        typ,
+			Datacenter:  w.datacenter,
+			Index:       index,
+			Timestamp:   now.UTC(),
+			Service:     instance,
+			ConfigEntry: entry,
+		})
+	}
+
+	instances := make(map[string]WebhookServiceInstance, len(nodes))
+	for _, csn := range nodes {
+		if csn.Node == nil || csn.Service == nil {
+			continue
+		}
+		key := csn.Node.Node + "/" + csn.Service.EnterpriseMeta.PartitionOrDefault() + "/" +
+			csn.Service.EnterpriseMeta.NamespaceOrDefault() + "/" + csn.Service.ID
+		instance := WebhookServiceInstance{
+			Node:      csn.Node.Node,
+			ID:        csn.Service.ID,
+			Name:      csn.Service.Service,
+			Namespace: csn.Service.EnterpriseMeta.NamespaceOrEmpty(),
+			Partition: csn.Service.EnterpriseMeta.PartitionOrEmpty(),
+			Status:    csn.AggregatedStatus(),
+		}
+		instances[key] = instance
+
+		prev, ok := w.instances[key]
+		switch {
+		case !ok:
+			emit(WebhookEventServiceRegistered, catalogIndex, &instance, nil)
+		case prev.Status != instance.Status:
+			changed := instance
+			changed.PreviousStatus = prev.Status
+			emit(WebhookEventHealthChanged, catalogIndex, &changed, nil)
+		}
+	}
+	for _, key := range sortedKeys(w.instances) {
+		if _, ok := instances[key]; !ok {
+			instance := w.instances[key]
+			emit(WebhookEventServiceDeregistered, catalogIndex, &instance, nil)
+		}
+	}
+
+	current := make(map[string]webhookConfigEntryState, len(entries))
+	for _, entry := range entries {
+		entMeta := entry.GetEnterpriseMeta()
+		key := entry.GetKind() + "/" + entMeta.PartitionOrDefault() + "/" +
+			entMeta.NamespaceOrDefault() + "/" + entry.GetName()
+		es := webhookConfigEntryState{
+			entry: WebhookConfigEntry{
+				Kind:      entry.GetKind(),
+				Name:      entry.GetName(),
+				Namespace: entMeta.NamespaceOrEmpty(),
+				Partition: entMeta.PartitionOrEmpty(),
+			},
+			modifyIndex: entry.GetRaftIndex().ModifyIndex,
+		}
+		current[key] = es
+
+		if prev, ok := w.entries[key]; !ok || prev.modifyIndex != es.modifyIndex {
+			changed := es.entry
+			emit(WebhookEventConfigEntryChanged, es.modifyIndex, nil, &changed)
+		}
+	}
+	for _, key := range sortedKeys(w.entries) {
+		if _, ok := current[key]; !ok {
+			deleted := w.entries[key].entry
+			deleted.Deleted = true
+			emit(WebhookEventConfigEntryChanged, entriesIndex, nil, &deleted)
+		}
+	}
+
+	w.instances = instances
+	w.entries = current
+	w.seeded = true
+	return events, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
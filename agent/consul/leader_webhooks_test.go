@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestWebhookWatcher(t *testing.T) {
+	store := state.NewStateStore(nil)
+	watcher := newWebhookWatcher("dc1")
+	now := time.Now()
+
+	register := func(idx uint64, status string) {
+		t.Helper()
+		require.NoError(t, store.EnsureRegistration(idx, &structs.RegisterRequest{
+			Node:    "node1",
+			Address: "127.0.0.1",
+			Service: &structs.NodeService{ID: "web1", Service: "web"},
+			Check: &structs.HealthCheck{
+				Node:      "node1",
+				CheckID:   "web1-check",
+				ServiceID: "web1",
+				Status:    status,
+			},
+		}))
+	}
+
+	// The current state is recorded without posting anything.
+	register(1, api.HealthPassing)
+	require.NoError(t, store.EnsureConfigEntry(2, &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web"}))
+	events, err := watcher.update(nil, store, now)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	register(3, api.HealthCritical)
+	require.NoError(t, store.EnsureRegistration(4, &structs.RegisterRequest{
+		Node:    "node1",
+		Address: "127.0.0.1",
+		Service: &structs.NodeService{ID: "api1", Service: "api"},
+	}))
+	require.NoError(t, store.EnsureConfigEntry(5, &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web", Protocol: "http"}))
+
+	events, err = watcher.update(nil, store, now)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for _, e := range events {
+		require.NotEmpty(t, e.ID)
+		require.Equal(t, "dc1", e.Datacenter)
+	}
+	require.Equal(t, WebhookEventServiceRegistered, events[0].Type)
+	require.Equal(t, "api1", events[0].Service.ID)
+	require.Equal(t, api.HealthPassing, events[0].Service.Status)
+	require.Equal(t, WebhookEventHealthChanged, events[1].Type)
+	require.Equal(t, "web1", events[1].Service.ID)
+	require.Equal(t, api.HealthCritical, events[1].Service.Status)
+	require.Equal(t, api.HealthPassing, events[1].Service.PreviousStatus)
+	require.Equal(t, WebhookEventConfigEntryChanged, events[2].Type)
+	require.Equal(t, &WebhookConfigEntry{Kind: structs.ServiceDefaults, Name: "web"}, events[2].ConfigEntry)
+	require.Equal(t, uint64(5), events[2].Index)
+
+	require.NoError(t, store.DeleteService(6, "node1", "api1", nil, ""))
+	require.NoError(t, store.DeleteConfigEntry(7, structs.ServiceDefaults, "web", nil))
+
+	events, err = watcher.update(nil, store, now)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, WebhookEventServiceDeregistered, events[0].Type)
+	require.Equal(t, "api1", events[0].Service.ID)
+	require.Equal(t, WebhookEventConfigEntryChanged, events[1].Type)
+	require.True(t, events[1].ConfigEntry.Deleted)
+
+	events, err = watcher.update(nil, store, now)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestLeader_Webhooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		events []WebhookEvent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e WebhookEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.Webhooks = []WebhookConfig{{
+			Name:     "test",
+			URL:      srv.URL,
+			Events:   []string{WebhookEventServiceRegistered},
+			Services: []string{"web"},
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// Registrations made before the first pass of the leader are not posted,
+	// so keep registering instances until one is.
+	var out struct{}
+	require.NoError(t, s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "node1",
+		Address:    "127.0.0.1",
+		Service:    &structs.NodeService{ID: "api", Service: "api"},
+	}, &out))
+	attempt := 0
+	retry.Run(t, func(r *retry.R) {
+		attempt++
+		require.NoError(r, s1.RPC(context.Background(), "Catalog.Register", &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       "node1",
+			Address:    "127.0.0.1",
+			Service:    &structs.NodeService{ID: fmt.Sprintf("web%d", attempt), Service: "web"},
+		}, &out))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(r, events)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range events {
+		require.Equal(t, WebhookEventServiceRegistered, e.Type)
+		require.Equal(t, "web", e.Service.Name)
+	}
+}
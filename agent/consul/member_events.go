@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/serf/coordinate"
+	"github.com/hashicorp/serf/serf"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// memberEventLogSize is the number of membership events kept by an agent.
+const memberEventLogSize = 1024
+
+// memberEventLog records the changes of the membership of the LAN gossip
+// pool observed by the agent, so that operators can reconstruct the timeline
+// of a network partition from the events seen by each side.
+type memberEventLog struct {
+	// tombstoneTimeout tells the members pruned after leaving from the ones
+	// reaped once their tombstone expired.
+	tombstoneTimeout time.Duration
+
+	lock   sync.Mutex
+	index  uint64
+	events []structs.MemberEvent
+
+	// members holds the last status of the known members and when it
+	// changed, to give the reason of the events.
+	members map[string]memberEventState
+
+	// notifyCh is closed and replaced when an event is recorded.
+	notifyCh chan struct{}
+}
+
+// memberEventSource is the serf cluster the events are observed in.
+type memberEventSource interface {
+	LocalMember() serf.Member
+	GetCoordinate() (*coordinate.Coordinate, error)
+	GetCachedCoordinate(name string) (*coordinate.Coordinate, bool)
+}
+
+type memberEventState struct {
+	status serf.MemberStatus
+	since  time.Time
+}
+
+func newMemberEventLog(tombstoneTimeout time.Duration) *memberEventLog {
+	return &memberEventLog{
+		tombstoneTimeout: tombstoneTimeout,
+		members:          make(map[string]memberEventState),
+		notifyCh:         make(chan struct{}),
+	}
+}
+
+// record records the membership event e of the pool cluster.
+func (l *memberEventLog) record(cluster memberEventSource, e serf.MemberEvent, now time.Time) {
+	var typ string
+	switch e.EventType() {
+	case serf.EventMemberJoin:
+		typ = structs.MemberEventJoin
+	case serf.EventMemberLeave:
+		typ = structs.MemberEventLeave
+	case serf.EventMemberFailed:
+		typ = structs.MemberEventFailed
+	case serf.EventMemberReap:
+		typ = structs.MemberEventReap
+	default:
+		return
+	}
+
+	// The coordinates are disabled when an error is returned.
+	local := cluster.LocalMember()
+	localCoord, _ := cluster.GetCoordinate()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, m := range e.Members {
+		prev, known := l.members[m.Name]
+		l.index++
+		event := structs.MemberEvent{
+			Index:                l.index,
+			Time:                 now,
+			Type:                 typ,
+			Reason:               l.reason(typ, m, prev, known, now),
+			Name:                 m.Name,
+			Addr:                 m.Addr.String(),
+			Port:                 m.Port,
+			Tags:                 m.Tags,
+			Status:               m.Status.String(),
+			DetectedBy:           local.Name,
+			DetectedByCoordinate: localCoord,
+		}
+		if coord, ok := cluster.GetCachedCoordinate(m.Name); ok {
+			event.Coordinate = coord
+		}
+		l.events = append(l.events, event)
+
+		if typ == structs.MemberEventReap {
+			delete(l.members, m.Name)
+		} else {
+			l.members[m.Name] = memberEventState{status: m.Status, since: now}
+		}
+	}
+
+	// The oldest events are dropped in batches to not copy the log on each
+	// event.
+	if len(l.events) >= 2*memberEventLogSize {
+		l.events = append([]structs.MemberEvent(nil), l.events[len(l.events)-memberEventLogSize:]...)
+	}
+
+	close(l.notifyCh)
+	l.notifyCh = make(chan struct{})
+}
+
+func (l *memberEventLog) reason(typ string, m serf.Member, prev memberEventState, known bool, now time.Time) string {
+	switch typ {
+	case structs.MemberEventJoin:
+		switch {
+		case known && prev.status == serf.StatusFailed:
+			return structs.MemberEventReasonRecovered
+		case known && prev.status == serf.StatusLeft:
+			return structs.MemberEventReasonRejoined
+		}
+		return structs.MemberEventReasonJoined
+	case structs.MemberEventLeave:
+		if known && prev.status == serf.StatusFailed {
+			return structs.MemberEventReasonForceLeft
+		}
+		return structs.MemberEventReasonLeft
+	case structs.MemberEventFailed:
+		return structs.MemberEventReasonFailureDetected
+	default:
+		if m.Status != serf.StatusLeft {
+			return structs.MemberEventReasonReconnectTimeout
+		}
+		if known && now.Sub(prev.since) < l.tombstoneTimeout {
+			return structs.MemberEventReasonPruned
+		}
+		return structs.MemberEventReasonTombstoneTimeout
+	}
+}
+
+// since returns the events recorded after index, the index of the last event
+// and a channel closed when the next event is recorded.
+func (l *memberEventLog) since(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{}) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	events := l.events
+	if len(events) > memberEventLogSize {
+		events = events[len(events)-memberEventLogSize:]
+	}
+	// The indexes are contiguous so the first event after index is found
+	// without a search.
+	if len(events) > 0 && index >= events[0].Index {
+		skip := index - events[0].Index + 1
+		if skip > uint64(len(events)) {
+			skip = uint64(len(events))
+		}
+		events = events[skip:]
+	}
+
+	out := make([]structs.MemberEvent, len(events))
+	copy(out, events)
+	return out, l.index, l.notifyCh
+}
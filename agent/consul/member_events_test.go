@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/serf/coordinate"
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+type testMemberEventSource struct {
+	coords map[string]*coordinate.Coordinate
+}
+
+func (s *testMemberEventSource) LocalMember() serf.Member {
+	return serf.Member{Name: "server-1"}
+}
+
+func (s *testMemberEventSource) GetCoordinate() (*coordinate.Coordinate, error) {
+	return s.coords["server-1"], nil
+}
+
+func (s *testMemberEventSource) GetCachedCoordinate(name string) (*coordinate.Coordinate, bool) {
+	coord, ok := s.coords[name]
+	return coord, ok
+}
+
+func TestMemberEventLog(t *testing.T) {
+	local := coordinate.NewCoordinate(coordinate.DefaultConfig())
+	remote := coordinate.NewCoordinate(coordinate.DefaultConfig())
+	remote.Vec[0] = 0.01
+	source := &testMemberEventSource{coords: map[string]*coordinate.Coordinate{
+		"server-1": local,
+		"web-1":    remote,
+	}}
+
+	l := newMemberEventLog(time.Hour)
+	now := time.Now()
+	record := func(typ serf.EventType, status serf.MemberStatus, after time.Duration) {
+		l.record(source, serf.MemberEvent{
+			Type: typ,
+			Members: []serf.Member{{
+				Name:   "web-1",
+				Addr:   net.ParseIP("10.0.0.1"),
+				Port:   8301,
+				Status: status,
+			}},
+		}, now.Add(after))
+	}
+
+	events, index, watchCh := l.since(0)
+	require.Empty(t, events)
+	require.Equal(t, uint64(0), index)
+
+	record(serf.EventMemberJoin, serf.StatusAlive, 0)
+	select {
+	case <-watchCh:
+	default:
+		t.Fatal("the watchers were not notified")
+	}
+
+	record(serf.EventMemberFailed, serf.StatusFailed, time.Minute)
+	record(serf.EventMemberJoin, serf.StatusAlive, 2*time.Minute)
+	record(serf.EventMemberLeave, serf.StatusLeft, 3*time.Minute)
+	record(serf.EventMemberReap, serf.StatusLeft, 4*time.Minute)
+	record(serf.EventMemberJoin, serf.StatusAlive, 5*time.Minute)
+	record(serf.EventMemberFailed, serf.StatusFailed, 6*time.Minute)
+	record(serf.EventMemberLeave, serf.StatusLeft, 7*time.Minute)
+	record(serf.EventMemberReap, serf.StatusLeft, 2*time.Hour)
+	record(serf.EventMemberFailed, serf.StatusFailed, 3*time.Hour)
+	record(serf.EventMemberReap, serf.StatusFailed, 4*time.Hour)
+
+	events, index, _ = l.since(0)
+	require.Equal(t, uint64(11), index)
+	var reasons []string
+	for _, e := range events {
+		reasons = append(reasons, e.Type+"/"+e.Reason)
+	}
+	require.Equal(t, []string{
+		"join/joined",
+		"failed/failure-detected",
+		"join/recovered",
+		"leave/left",
+		"reap/pruned",
+		"join/joined",
+		"failed/failure-detected",
+		"leave/force-left",
+		"reap/tombstone-timeout",
+		"failed/failure-detected",
+		"reap/reconnect-timeout",
+	}, reasons)
+
+	require.Equal(t, structs.MemberEvent{
+		Index:                1,
+		Time:                 now,
+		Type:                 structs.MemberEventJoin,
+		Reason:               structs.MemberEventReasonJoined,
+		Name:                 "web-1",
+		Addr:                 "10.0.0.1",
+		Port:                 8301,
+		Status:               "alive",
+		Coordinate:           remote,
+		DetectedBy:           "server-1",
+		DetectedByCoordinate: local,
+	}, events[0])
+
+	events, _, _ = l.since(9)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(10), events[0].Index)
+
+	events, _, _ = l.since(11)
+	require.Empty(t, events)
+
+	// Only the last events are kept.
+	for i := 0; i < 2*memberEventLogSize; i++ {
+		record(serf.EventMemberJoin, serf.StatusAlive, 5*time.Hour)
+	}
+	events, index, _ = l.since(0)
+	require.Len(t, events, memberEventLogSize)
+	require.Equal(t, index, events[len(events)-1].Index)
+	require.Equal(t, index-memberEventLogSize+1, events[0].Index)
+}
+
+func TestServer_MemberEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	dir2, c1 := testClient(t)
+	defer os.RemoveAll(dir2)
+	defer c1.Shutdown()
+	joinLAN(t, c1, s1)
+
+	retry.Run(t, func(r *retry.R) {
+		events, _, _ := s1.MemberEvents(0)
+		var found bool
+		for _, e := range events {
+			if e.Name == c1.config.NodeName && e.Type == structs.MemberEventJoin {
+				require.Equal(r, structs.MemberEventReasonJoined, e.Reason)
+				require.Equal(r, s1.config.NodeName, e.DetectedBy)
+				found = true
+			}
+		}
+		require.True(r, found)
+	})
+
+	// The client observes the server joining.
+	retry.Run(t, func(r *retry.R) {
+		events, _, _ := c1.MemberEvents(0)
+		var found bool
+		for _, e := range events {
+			if e.Name == s1.config.NodeName && e.Type == structs.MemberEventJoin {
+				require.Equal(r, c1.config.NodeName, e.DetectedBy)
+				found = true
+			}
+		}
+		require.True(r, found)
+	})
+
+	require.NoError(t, c1.Leave())
+	retry.Run(t, func(r *retry.R) {
+		events, _, _ := s1.MemberEvents(0)
+		last := events[len(events)-1]
+		require.Equal(r, c1.config.NodeName, last.Name)
+		require.Equal(r, structs.MemberEventLeave, last.Type)
+		require.Equal(r, structs.MemberEventReasonLeft, last.Reason)
+	})
+}
@@ -20,9 +20,9 @@ func (md *lanMergeDelegate) enterpriseNotifyMergeMember(m *serf.Member) error {
 		return fmt.Errorf("Member '%s' part of partition '%s'; Partitions are a Consul Enterprise feature",
 			m.Name, memberPartition)
 	}
-	if segment := m.Tags["segment"]; segment != "" {
-		return fmt.Errorf("Member '%s' part of segment '%s'; Network Segments are a Consul Enterprise feature",
-			m.Name, segment)
+	if segment := m.Tags["segment"]; segment != md.segment {
+		return fmt.Errorf("Member '%s' part of wrong segment '%s' (expected '%s')",
+			m.Name, segment, md.segment)
 	}
 	return nil
 }
@@ -55,7 +55,30 @@ func TestMerge_CE_LAN(t *testing.T) {
 					segment: "alpha",
 				}),
 			},
-			expect: `Member 'node1' part of segment 'alpha'; Network Segments are a Consul Enterprise feature`,
+			expect: `Member 'node1' part of wrong segment 'alpha' (expected '')`,
+		},
+		"node in the same segment": {
+			segment: "alpha",
+			members: []*serf.Member{
+				makeTestNode(t, testMember{
+					dc:      "dc1",
+					name:    "node1",
+					build:   "0.7.5",
+					segment: "alpha",
+				}),
+			},
+		},
+		"node in another segment": {
+			segment: "alpha",
+			members: []*serf.Member{
+				makeTestNode(t, testMember{
+					dc:      "dc1",
+					name:    "node1",
+					build:   "0.7.5",
+					segment: "beta",
+				}),
+			},
+			expect: `Member 'node1' part of wrong segment 'beta' (expected 'alpha')`,
 		},
 		"node in a partition": {
 			members: []*serf.Member{
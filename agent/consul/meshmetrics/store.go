@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package meshmetrics maintains rollups of the upstream request metrics
+// reported by the dataplanes, so the servers can serve basic golden signals
+// for each pair of services without a metrics stack.
+package meshmetrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// DefaultWindow is the period the rollups are computed over.
+const DefaultWindow = 5 * time.Minute
+
+// MaxLatencySamples is the maximum number of latency samples kept from each
+// report, to bound the memory used by the rollups.
+const MaxLatencySamples = 100
+
+// Sample holds the requests made by a proxy to one of its upstreams during an
+// interval.
+type Sample struct {
+	Interval time.Duration
+	Requests uint64
+	Errors   uint64
+
+	// Latencies holds the latencies of a sample of the requests.
+	Latencies []time.Duration
+}
+
+type pair struct {
+	source   structs.ServiceName
+	upstream structs.PeeredServiceName
+}
+
+type report struct {
+	at time.Time
+	Sample
+}
+
+// Store holds the samples reported over the window for each pair of services
+// and computes their rollups.
+type Store struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	reports   map[pair][]report
+	lastPrune time.Time
+}
+
+func NewStore(window time.Duration) *Store {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Store{
+		window:  window,
+		reports: make(map[pair][]report),
+	}
+}
+
+// Record adds a sample of the requests made by source to upstream, reported
+// at the given time.
+func (s *Store) Record(at time.Time, source structs.ServiceName, upstream structs.PeeredServiceName, sample Sample) {
+	if len(sample.Latencies) > MaxLatencySamples {
+		sample.Latencies = sample.Latencies[:MaxLatencySamples]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := pair{source: source, upstream: upstream}
+	s.reports[key] = append(s.reports[key], report{at: at, Sample: sample})
+
+	// Pairs which are not reported anymore are only dropped here, so prune
+	// them once per window.
+	if at.Sub(s.lastPrune) >= s.window {
+		for key := range s.reports {
+			s.prune(at, key)
+		}
+		s.lastPrune = at
+	}
+}
+
+// Reset drops all the samples, e.g. when the server loses the leadership.
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports = make(map[pair][]report)
+}
+
+// Upstreams returns the rollups of the requests made by the service to its
+// upstreams, sorted by upstream.
+func (s *Store) Upstreams(now time.Time, service structs.ServiceName) []structs.MeshTrafficRollup {
+	return s.rollups(now, func(p pair) bool { return p.source == service })
+}
+
+// Downstreams returns the rollups of the requests made to the service by its
+// downstreams in the local datacenter, sorted by downstream.
+func (s *Store) Downstreams(now time.Time, service structs.ServiceName) []structs.MeshTrafficRollup {
+	return s.rollups(now, func(p pair) bool {
+		return p.upstream.Peer == "" && p.upstream.ServiceName == service
+	})
+}
+
+func (s *Store) rollups(now time.Time, match func(pair) bool) []structs.MeshTrafficRollup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []structs.MeshTrafficRollup
+	for key := range s.reports {
+		if !match(key) {
+			continue
+		}
+		if reports := s.prune(now, key); len(reports) > 0 {
+			result = append(result, rollup(now, key, reports))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Source.String() != b.Source.String() {
+			return a.Source.String() < b.Source.String()
+		}
+		if a.Upstream.String() != b.Upstream.String() {
+			return a.Upstream.String() < b.Upstream.String()
+		}
+		return a.UpstreamPeer < b.UpstreamPeer
+	})
+	return result
+}
+
+// prune drops the reports of the pair older than the window and returns the
+// remaining ones. The caller must hold s.mu.
+func (s *Store) prune(now time.Time, key pair) []report {
+	reports := s.reports[key]
+	i := 0
+	for i < len(reports) && now.Sub(reports[i].at) > s.window {
+		i++
+	}
+	reports = reports[i:]
+	if len(reports) == 0 {
+		delete(s.reports, key)
+		return nil
+	}
+	s.reports[key] = reports
+	return reports
+}
+
+func rollup(now time.Time, key pair, reports []report) structs.MeshTrafficRollup {
+	var (
+		requests, errors uint64
+		latencies        []time.Duration
+		start            = now
+	)
+	for _, r := range reports {
+		requests += r.Requests
+		errors += r.Errors
+		latencies = append(latencies, r.Latencies...)
+		if begin := r.at.Add(-r.Interval); begin.Before(start) {
+			start = begin
+		}
+	}
+
+	result := structs.MeshTrafficRollup{
+		Source:       key.source,
+		Upstream:     key.upstream.ServiceName,
+		UpstreamPeer: key.upstream.Peer,
+	}
+	// The rate is computed over the period covered by the reports, so it is
+	// not underestimated when the pair started being reported recently.
+	if span := now.Sub(start); span > 0 {
+		result.RequestsPerSecond = float64(requests) / span.Seconds()
+	}
+	if requests > 0 {
+		result.ErrorRate = float64(errors) / float64(requests)
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result.P50Latency = percentile(latencies, 0.50)
+		result.P99Latency = percentile(latencies, 0.99)
+	}
+	return result
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package meshmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestStore(t *testing.T) {
+	var (
+		web  = structs.NewServiceName("web", nil)
+		api  = structs.NewServiceName("api", nil)
+		db   = structs.NewServiceName("db", nil)
+		now  = time.Now()
+		then = now.Add(-30 * time.Second)
+	)
+	s := NewStore(time.Minute)
+
+	// Two instances of web report their requests to db.
+	s.Record(then, web, structs.PeeredServiceName{ServiceName: db}, Sample{
+		Interval:  30 * time.Second,
+		Requests:  300,
+		Errors:    3,
+		Latencies: []time.Duration{1 * time.Millisecond, 2 * time.Millisecond},
+	})
+	s.Record(now, web, structs.PeeredServiceName{ServiceName: db}, Sample{
+		Interval:  30 * time.Second,
+		Requests:  300,
+		Errors:    9,
+		Latencies: []time.Duration{3 * time.Millisecond, 100 * time.Millisecond},
+	})
+	s.Record(now, api, structs.PeeredServiceName{ServiceName: db}, Sample{
+		Interval: 10 * time.Second,
+		Requests: 50,
+	})
+	s.Record(now, web, structs.PeeredServiceName{ServiceName: db, Peer: "cluster-02"}, Sample{
+		Interval: 10 * time.Second,
+		Requests: 10,
+	})
+
+	upstreams := s.Upstreams(now, web)
+	require.Len(t, upstreams, 2)
+	require.Equal(t, structs.MeshTrafficRollup{
+		Source:            web,
+		Upstream:          db,
+		RequestsPerSecond: 10,
+		ErrorRate:         0.02,
+		P50Latency:        2 * time.Millisecond,
+		P99Latency:        100 * time.Millisecond,
+	}, upstreams[0])
+	require.Equal(t, "cluster-02", upstreams[1].UpstreamPeer)
+	require.Equal(t, float64(1), upstreams[1].RequestsPerSecond)
+
+	downstreams := s.Downstreams(now, db)
+	require.Len(t, downstreams, 2)
+	require.Equal(t, api, downstreams[0].Source)
+	require.Equal(t, float64(5), downstreams[0].RequestsPerSecond)
+	require.Zero(t, downstreams[0].ErrorRate)
+	require.Equal(t, web, downstreams[1].Source)
+
+	// The reports older than the window are dropped.
+	later := now.Add(45 * time.Second)
+	upstreams = s.Upstreams(later, web)
+	require.Len(t, upstreams, 2)
+	require.Equal(t, float64(300)/75, upstreams[0].RequestsPerSecond)
+	require.Equal(t, 3*time.Millisecond, upstreams[0].P50Latency)
+
+	require.Empty(t, s.Upstreams(now.Add(2*time.Minute), web))
+
+	s.Reset()
+	require.Empty(t, s.Downstreams(now, db))
+}
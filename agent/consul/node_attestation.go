@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"fmt"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/authmethod/attestauth"
+	"github.com/hashicorp/consul/agent/metadata"
+	"github.com/hashicorp/consul/types"
+)
+
+// checkNodeAttestation verifies the attestation evidence of a write to the
+// given node, its services or its checks when node_attestation.enforce is set.
+// Only the nodes of the clients of the LAN pool are attested, not the servers
+// nor the nodes registered without an agent, like the external nodes.
+func (s *Server) checkNodeAttestation(node string, nodeID types.NodeID, peerName, evidence string, entMeta *acl.EnterpriseMeta) error {
+	if !s.config.NodeAttestationEnforce || peerName != "" {
+		return nil
+	}
+	member, ok := s.lanMember(node, entMeta)
+	if !ok {
+		return nil
+	}
+	if isServer, _ := metadata.IsConsulServer(member); isServer {
+		return nil
+	}
+
+	if err := s.verifyNodeAttestation(node, nodeID, member, evidence, entMeta); err != nil {
+		metrics.IncrCounter([]string{"catalog", "register", "attestation_failed"}, 1)
+		return err
+	}
+	return nil
+}
+
+func (s *Server) verifyNodeAttestation(node string, nodeID types.NodeID, member serf.Member, evidence string, entMeta *acl.EnterpriseMeta) error {
+	if evidence == "" {
+		return fmt.Errorf("node %q must present attestation evidence to register", node)
+	}
+
+	methodMeta := acl.NewEnterpriseMetaWithPartition(entMeta.PartitionOrDefault(), "")
+	method, validator, err := s.loadAuthMethod(s.config.NodeAttestationAuthMethod, &methodMeta)
+	if err != nil {
+		return err
+	}
+	v, ok := validator.(*attestauth.Validator)
+	if !ok {
+		return fmt.Errorf("auth method %q is not of type %q", method.Name, attestauth.AuthMethodType)
+	}
+
+	id, err := v.Verify(evidence)
+	if err != nil {
+		return err
+	}
+
+	// The addresses of the registration are chosen by the caller, only the
+	// gossip address of the node ties an instance to it.
+	return id.CheckNode(node, string(nodeID), member.Addr.String())
+}
+
+// lanMember returns the member of the LAN pool of the partition with the
+// given name.
+func (s *Server) lanMember(name string, entMeta *acl.EnterpriseMeta) (serf.Member, bool) {
+	partition := entMeta.PartitionOrDefault()
+	members, err := s.LANMembers(LANMemberFilter{
+		Partition:   partition,
+		AllSegments: acl.IsDefaultPartition(partition),
+	})
+	if err != nil {
+		return serf.Member{}, false
+	}
+	for _, m := range members {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return serf.Member{}, false
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/attestation"
+	"github.com/hashicorp/consul/agent/consul/authmethod/attestauth"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestCatalog_Register_NodeAttestation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Amazon Web Services LLC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	evidence := func(node, privateIP string) string {
+		document := `{"accountId": "123456789012", "instanceId": "i-0123456789abcdef0", "privateIp": "` + privateIP + `", "region": "us-east-1"}`
+		digest := sha256.Sum256([]byte(document))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+		e := &attestation.Evidence{
+			Type:      attestation.TypeAWSIID,
+			Node:      node,
+			Timestamp: time.Now(),
+			AWS: &attestation.AWSEvidence{
+				Document:  document,
+				Signature: base64.StdEncoding.EncodeToString(sig),
+			},
+		}
+		encoded, err := e.Encode()
+		require.NoError(t, err)
+		return encoded
+	}
+
+	_, s1, codec := testACLServerWithConfig(t, func(c *Config) {
+		c.NodeAttestationAuthMethod = "attested-nodes"
+		c.NodeAttestationEnforce = true
+	}, false)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1", testrpc.WithToken(TestDefaultInitialManagementToken))
+
+	var method structs.ACLAuthMethod
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.AuthMethodSet", &structs.ACLAuthMethodSetRequest{
+		Datacenter: "dc1",
+		AuthMethod: structs.ACLAuthMethod{
+			Name:   "attested-nodes",
+			Type:   attestauth.AuthMethodType,
+			Config: map[string]interface{}{"AWSCertificates": []string{cert}},
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}, &method))
+
+	_, c1 := testClientWithConfig(t, func(c *Config) {
+		c.NodeName = "attested-client"
+	})
+	joinLAN(t, c1, s1)
+
+	_, c2 := testClientWithConfig(t, func(c *Config) {
+		c.NodeName = "other-client"
+		c.SerfLANConfig.MemberlistConfig.BindAddr = "127.0.0.2"
+	})
+	joinLAN(t, c2, s1)
+
+	register := func(node, address, evidence string) error {
+		var out struct{}
+		return msgpackrpc.CallWithCodec(codec, "Catalog.Register", &structs.RegisterRequest{
+			Datacenter:      "dc1",
+			Node:            node,
+			Address:         address,
+			NodeAttestation: evidence,
+			WriteRequest:    structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+		}, &out)
+	}
+
+	// The clients must present evidence.
+	err = register("attested-client", "127.0.0.1", "")
+	require.ErrorContains(t, err, `node "attested-client" must present attestation evidence to register`)
+
+	// The evidence must be collected for the node and its machine.
+	err = register("attested-client", "127.0.0.1", evidence("other-client", "127.0.0.1"))
+	require.ErrorContains(t, err, `collected for node "other-client", not "attested-client"`)
+	err = register("attested-client", "127.0.0.1", evidence("attested-client", "10.0.0.12"))
+	require.ErrorContains(t, err, `private address "10.0.0.12"`)
+
+	// The address of the registration does not attest the instance, only the
+	// gossip address of the node does.
+	err = register("attested-client", "10.0.0.12", evidence("attested-client", "10.0.0.12"))
+	require.ErrorContains(t, err, `private address "10.0.0.12" of instance "i-0123456789abcdef0" is not the gossip address of node "attested-client"`)
+
+	valid := evidence("attested-client", "127.0.0.1")
+	require.NoError(t, register("attested-client", "127.0.0.1", valid))
+
+	// The node name is not signed, a document replayed under the name of
+	// another node is rejected because the instance is not that node.
+	replayed, err := attestation.Decode(valid)
+	require.NoError(t, err)
+	replayed.Node = "other-client"
+	encoded, err := replayed.Encode()
+	require.NoError(t, err)
+	err = register("other-client", "127.0.0.1", encoded)
+	require.ErrorContains(t, err, `private address "127.0.0.1" of instance "i-0123456789abcdef0" is not the gossip address of node "other-client"`)
+
+	// Expired evidence is rejected.
+	stale, err := attestation.Decode(valid)
+	require.NoError(t, err)
+	stale.Timestamp = time.Now().Add(-attestation.MaxEvidenceAge - time.Minute)
+	encoded, err = stale.Encode()
+	require.NoError(t, err)
+	err = register("attested-client", "127.0.0.1", encoded)
+	require.ErrorContains(t, err, "has expired")
+
+	// The node is registered.
+	_, node, err := s1.fsm.State().GetNode("attested-client", nil, "")
+	require.NoError(t, err)
+	require.NotNil(t, node)
+
+	// The nodes which are not members of the LAN pool are not attested.
+	require.NoError(t, register("external", "127.0.0.1", ""))
+
+	// Transactions can't write the nodes of the clients without evidence.
+	txn := func(evidence string, ops ...*structs.TxnOp) structs.TxnErrors {
+		var out structs.TxnResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &structs.TxnRequest{
+			Datacenter:      "dc1",
+			Ops:             ops,
+			NodeAttestation: evidence,
+			WriteRequest:    structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+		}, &out))
+		return out.Errors
+	}
+	nodeOp := &structs.TxnOp{Node: &structs.TxnNodeOp{
+		Verb: api.NodeSet,
+		Node: structs.Node{Node: "attested-client", Address: "127.0.0.1"},
+	}}
+	serviceOp := &structs.TxnOp{Service: &structs.TxnServiceOp{
+		Verb:    api.ServiceSet,
+		Node:    "attested-client",
+		Service: structs.NodeService{ID: "web", Service: "web"},
+	}}
+	checkOp := &structs.TxnOp{Check: &structs.TxnCheckOp{
+		Verb:  api.CheckSet,
+		Check: structs.HealthCheck{Node: "attested-client", CheckID: "web-check", Name: "web-check"},
+	}}
+	for _, op := range []*structs.TxnOp{nodeOp, serviceOp, checkOp} {
+		errs := txn("", op)
+		require.Len(t, errs, 1)
+		require.Contains(t, errs[0].What, `node "attested-client" must present attestation evidence to register`)
+	}
+	require.Empty(t, txn(valid, nodeOp, serviceOp, checkOp))
+
+	// The nodes which are not members of the LAN pool are not attested.
+	require.Empty(t, txn("", &structs.TxnOp{Node: &structs.TxnNodeOp{
+		Verb: api.NodeSet,
+		Node: structs.Node{Node: "external", Address: "127.0.0.1"},
+	}}))
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// CatalogProviderStatus returns the status of the sync of the catalog
+// providers.
+func (op *Operator) CatalogProviderStatus(args *structs.DCSpecificRequest, reply *structs.CatalogProviderStatus) error {
+	// The status is only tracked by the leader, so we fix the args since we
+	// are re-using a structure where we don't support all the options.
+	args.RequireConsistent = true
+	args.AllowStale = false
+	if done, err := op.srv.ForwardRPC("Operator.CatalogProviderStatus", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	*reply = op.srv.getCatalogProviderStatus()
+	op.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"sort"
+
+	"github.com/hashicorp/serf/serf"
+
+	"github.com/hashicorp/consul/agent/metadata"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// clusterFeatureFlags maps the protocol features reported by
+// Operator.ClusterFeatures to the feature flags servers advertise in their
+// Serf tags.
+var clusterFeatureFlags = map[string]string{
+	structs.ClusterFeatureFederationStates:  "fs",
+	structs.ClusterFeatureServiceIntentions: "si",
+	structs.ClusterFeatureStreaming:         "st",
+	structs.ClusterFeaturePeering:           "pe",
+	structs.ClusterFeatureResourceAPIs:      "ra",
+}
+
+// enabledFeatureFlags returns the flags of the optional features enabled on
+// this server, which are advertised alongside the features every server
+// supports.
+func (s *Server) enabledFeatureFlags() []string {
+	var flags []string
+	if s.config.RPCConfig.EnableStreaming {
+		flags = append(flags, clusterFeatureFlags[structs.ClusterFeatureStreaming])
+	}
+	if s.config.PeeringEnabled {
+		flags = append(flags, clusterFeatureFlags[structs.ClusterFeaturePeering])
+	}
+	if s.useV2Resources {
+		flags = append(flags, clusterFeatureFlags[structs.ClusterFeatureResourceAPIs])
+	}
+	return flags
+}
+
+// ClusterFeatures is used to report which protocol features are active in
+// the datacenter given the versions and configuration of its servers.
+func (op *Operator) ClusterFeatures(args *structs.DCSpecificRequest, reply *structs.ClusterFeaturesResponse) error {
+	if done, err := op.srv.ForwardRPC("Operator.ClusterFeatures", args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	// Use the Serf members rather than the server lookup so that failed
+	// servers, which are expected to come back, are taken into account.
+	var servers []*metadata.Server
+	for _, member := range op.srv.serfLAN.Members() {
+		valid, parts := metadata.IsConsulServer(member)
+		if !valid || parts.Segment != "" {
+			continue
+		}
+		servers = append(servers, parts)
+	}
+
+	reply.Datacenter = op.srv.config.Datacenter
+	reply.Features = clusterFeatures(servers)
+	return nil
+}
+
+// clusterFeatures computes the features which every alive or failed server
+// advertises. Servers which have left are ignored.
+func clusterFeatures(servers []*metadata.Server) []structs.ClusterFeature {
+	features := make([]structs.ClusterFeature, 0, len(clusterFeatureFlags))
+	for name, flag := range clusterFeatureFlags {
+		feature := structs.ClusterFeature{Name: name}
+		found := false
+		for _, srv := range servers {
+			if srv.Status != serf.StatusAlive && srv.Status != serf.StatusFailed {
+				continue
+			}
+			found = true
+			if srv.FeatureFlags[flag] != 1 {
+				feature.MissingServers = append(feature.MissingServers, srv.ShortName)
+			}
+		}
+		sort.Strings(feature.MissingServers)
+		feature.Active = found && len(feature.MissingServers) == 0
+		features = append(features, feature)
+	}
+	sort.Slice(features, func(i, j int) bool {
+		return features[i].Name < features[j].Name
+	})
+	return features
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/require"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/metadata"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestOperator_clusterFeatures(t *testing.T) {
+	server := func(name string, status serf.MemberStatus, flags ...string) *metadata.Server {
+		srv := &metadata.Server{ShortName: name, Status: status, FeatureFlags: make(map[string]int)}
+		for _, flag := range flags {
+			srv.FeatureFlags[flag] = 1
+		}
+		return srv
+	}
+	active := func(features []structs.ClusterFeature) map[string]bool {
+		out := make(map[string]bool)
+		for _, f := range features {
+			out[f.Name] = f.Active
+		}
+		return out
+	}
+
+	features := clusterFeatures([]*metadata.Server{
+		server("s1", serf.StatusAlive, "fs", "si", "st", "pe"),
+		server("s2", serf.StatusFailed, "fs", "si", "pe"),
+		server("s3", serf.StatusLeft),
+	})
+	require.Equal(t, map[string]bool{
+		structs.ClusterFeatureFederationStates:  true,
+		structs.ClusterFeaturePeering:           true,
+		structs.ClusterFeatureResourceAPIs:      false,
+		structs.ClusterFeatureServiceIntentions: true,
+		structs.ClusterFeatureStreaming:         false,
+	}, active(features))
+
+	for _, f := range features {
+		switch f.Name {
+		case structs.ClusterFeatureStreaming:
+			require.Equal(t, []string{"s2"}, f.MissingServers)
+		case structs.ClusterFeatureResourceAPIs:
+			require.Equal(t, []string{"s1", "s2"}, f.MissingServers)
+		default:
+			require.Empty(t, f.MissingServers)
+		}
+	}
+
+	// Without servers no feature is active.
+	for _, f := range clusterFeatures(nil) {
+		require.False(t, f.Active, f.Name)
+	}
+}
+
+func TestOperator_ClusterFeatures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.RPCConfig.EnableStreaming = true
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Bootstrap = false
+		c.RPCConfig.EnableStreaming = false
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	joinLAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	codec := rpcClient(t, s2)
+	defer codec.Close()
+
+	arg := structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}
+	var reply structs.ClusterFeaturesResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.ClusterFeatures", &arg, &reply))
+	require.Equal(t, "dc1", reply.Datacenter)
+
+	features := make(map[string]structs.ClusterFeature)
+	for _, f := range reply.Features {
+		features[f.Name] = f
+	}
+	require.True(t, features[structs.ClusterFeatureFederationStates].Active)
+	require.True(t, features[structs.ClusterFeaturePeering].Active)
+	require.False(t, features[structs.ClusterFeatureStreaming].Active)
+	require.Equal(t, []string{s2.config.NodeName}, features[structs.ClusterFeatureStreaming].MissingServers)
+}
+
+func TestOperator_ClusterFeatures_ACLDeny(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	arg := structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}
+	var reply structs.ClusterFeaturesResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.ClusterFeatures", &arg, &reply)
+	require.True(t, acl.IsErrPermissionDenied(err), err)
+
+	rules := `operator = "read"`
+	tokenID := createToken(t, codec, rules)
+
+	arg.Token = tokenID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.ClusterFeatures", &arg, &reply))
+	require.Equal(t, "dc1", reply.Datacenter)
+}
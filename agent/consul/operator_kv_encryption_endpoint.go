@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// KVEncryptionStatus returns the KV encryption keys, along with the number of
+// KV entries encrypted with each of them.
+func (op *Operator) KVEncryptionStatus(args *structs.DCSpecificRequest, reply *structs.KVEncryptionStatus) error {
+	if done, err := op.srv.ForwardRPC("Operator.KVEncryptionStatus", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	status, err := op.srv.kvEncryptionStatus()
+	if err != nil {
+		return err
+	}
+	*reply = *status
+	op.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
+	return nil
+}
+
+// KVEncryptionRotate generates a new KV encryption key, which is used to
+// encrypt the values written from now on.
+func (op *Operator) KVEncryptionRotate(args *structs.KVEncryptionRequest, reply *structs.KVEncryptionStatus) error {
+	if done, err := op.srv.ForwardRPC("Operator.KVEncryptionRotate", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorWriteAllowed(nil); err != nil {
+		return err
+	}
+
+	if _, err := op.srv.rotateKVEncryptionKey(context.Background()); err != nil {
+		return err
+	}
+	op.logger.Info("rotated KV encryption key")
+
+	status, err := op.srv.kvEncryptionStatus()
+	if err != nil {
+		return err
+	}
+	*reply = *status
+	return nil
+}
+
+// KVEncryptionRewrap re-wraps the KV encryption keys with the provider's
+// current key, and re-encrypts the KV values which are not encrypted with the
+// active KV encryption key.
+func (op *Operator) KVEncryptionRewrap(args *structs.KVEncryptionRequest, reply *structs.KVEncryptionStatus) error {
+	if done, err := op.srv.ForwardRPC("Operator.KVEncryptionRewrap", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorWriteAllowed(nil); err != nil {
+		return err
+	}
+
+	rewrapped, err := op.srv.rewrapKVEncryption(context.Background())
+	if err != nil {
+		return err
+	}
+	op.logger.Info("rewrapped KV encryption keys", "entries", rewrapped)
+
+	status, err := op.srv.kvEncryptionStatus()
+	if err != nil {
+		return err
+	}
+	*reply = *status
+	reply.Rewrapped = rewrapped
+	return nil
+}
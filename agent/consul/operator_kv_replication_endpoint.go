@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// KVReplicationStatus returns the status of the replication of the KV
+// prefixes from the primary datacenter.
+func (op *Operator) KVReplicationStatus(args *structs.DCSpecificRequest, reply *structs.KVReplicationStatus) error {
+	// The status is only tracked by the leader, so we fix the args since we
+	// are re-using a structure where we don't support all the options.
+	args.RequireConsistent = true
+	args.AllowStale = false
+	if done, err := op.srv.ForwardRPC("Operator.KVReplicationStatus", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	*reply = op.srv.getKVReplicationStatus()
+	op.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
+	return nil
+}
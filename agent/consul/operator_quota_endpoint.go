@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// Quota returns the quota limits of a namespace, along with the usage of the
+// objects they limit.
+func (op *Operator) Quota(args *structs.QuotaRequest, reply *structs.QuotaResponse) error {
+	if done, err := op.srv.ForwardRPC("Operator.Quota", args, reply); done {
+		return err
+	}
+
+	var authzContext acl.AuthorizerContext
+	authz, err := op.srv.ResolveTokenAndDefaultMeta(args.Token, &args.EnterpriseMeta, &authzContext)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(&authzContext); err != nil {
+		return err
+	}
+
+	if err := op.srv.validateEnterpriseRequest(&args.EnterpriseMeta, false); err != nil {
+		return err
+	}
+
+	return op.srv.blockingQuery(
+		&args.QueryOptions,
+		&reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, usage, err := state.QuotaUsage(ws, &args.EnterpriseMeta)
+			if err != nil {
+				return err
+			}
+
+			reply.Index = index
+			reply.Partition = args.PartitionOrEmpty()
+			reply.Namespace = args.NamespaceOrEmpty()
+			reply.Limits = op.srv.config.Quotas.LimitsFor(&args.EnterpriseMeta)
+			reply.Usage = usage
+			return nil
+		})
+}
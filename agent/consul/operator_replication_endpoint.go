@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// ReplicationStatus returns the status of the replication of the config
+// entries, federation states and ACLs from the primary datacenter, and of the
+// replication from the peers, along with how far behind each of them may be.
+func (op *Operator) ReplicationStatus(args *structs.DCSpecificRequest, reply *structs.IndexedReplicationStatuses) error {
+	// The status is only tracked by the leader, so we fix the args since we
+	// are re-using a structure where we don't support all the options.
+	args.RequireConsistent = true
+	args.AllowStale = false
+	if done, err := op.srv.ForwardRPC("Operator.ReplicationStatus", args, reply); done {
+		return err
+	}
+
+	authz, err := op.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return err
+	}
+
+	statuses, err := op.srv.replicationStatuses()
+	if err != nil {
+		return err
+	}
+	reply.Datacenter = op.srv.config.Datacenter
+	reply.Replications = statuses
+	op.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
+	return nil
+}
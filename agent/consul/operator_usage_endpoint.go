@@ -10,7 +10,8 @@ import (
 	"github.com/hashicorp/go-memdb"
 )
 
-// Usage returns counts for service usage within catalog.
+// Usage returns counts for service usage within catalog, along with counts
+// of KV entries, config entries, peerings and ACL tokens.
 func (op *Operator) Usage(args *structs.OperatorUsageRequest, reply *structs.Usage) error {
 	reply.Usage = make(map[string]structs.ServiceUsage)
 
@@ -59,6 +60,18 @@ func (op *Operator) Usage(args *structs.OperatorUsageRequest, reply *structs.Usa
 				return err
 			}
 
+			dataIndex, dataUsage, err := state.DataUsage(ws)
+			if err != nil {
+				return err
+			}
+			if dataIndex > index {
+				index = dataIndex
+			}
+			serviceUsage.KVEntries = dataUsage.KVEntries
+			serviceUsage.ConfigEntries = dataUsage.ConfigEntries
+			serviceUsage.Peerings = dataUsage.Peerings
+			serviceUsage.ACLTokens = dataUsage.ACLTokens
+
 			reply.QueryMeta.Index, reply.Usage[op.srv.config.Datacenter] = index, serviceUsage
 			return nil
 		})
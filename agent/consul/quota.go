@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+// checkQuota returns an error if a write, which changes the usage of the given
+// namespace as described by the change function, would exceed its quotas.
+//
+// Quotas are checked against the state before the write is applied to Raft, so
+// concurrent writes may slightly overshoot a quota.
+func (s *Server) checkQuota(entMeta *acl.EnterpriseMeta, change func(usage *structs.QuotaUsage) error) error {
+	limits := s.config.Quotas.LimitsFor(entMeta)
+	if limits == (structs.QuotaLimits{}) {
+		return nil
+	}
+
+	_, current, err := s.fsm.State().QuotaUsage(nil, entMeta)
+	if err != nil {
+		return err
+	}
+	proposed := current
+	if err := change(&proposed); err != nil {
+		return err
+	}
+	return limits.Check(current, proposed, entMeta)
+}
+
+// checkServiceQuota checks the service quota before the given service is
+// registered.
+func (s *Server) checkServiceQuota(svc *structs.NodeService) error {
+	if svc.PeerName != "" {
+		// Peered services are not counted, see updateUsage.
+		return nil
+	}
+	return s.checkQuota(&svc.EnterpriseMeta, func(usage *structs.QuotaUsage) error {
+		_, nodes, err := s.fsm.State().ServiceNodes(nil, svc.Service, &svc.EnterpriseMeta, svc.PeerName)
+		if err != nil {
+			return err
+		}
+		if len(nodes) == 0 {
+			usage.Services++
+		}
+		return nil
+	})
+}
+
+// checkKVSQuota checks the KV bytes quota before the given KV operation is
+// applied. Pending is the number of bytes already added to the namespace by
+// earlier operations of the same transaction, and the returned delta is the
+// number of bytes added by this operation.
+func (s *Server) checkKVSQuota(op api.KVOp, dirEnt *structs.DirEntry, pending int) (int, error) {
+	switch op {
+	case api.KVSet, api.KVCAS, api.KVLock, api.KVUnlock:
+	default:
+		return 0, nil
+	}
+
+	var delta int
+	err := s.checkQuota(&dirEnt.EnterpriseMeta, func(usage *structs.QuotaUsage) error {
+		_, existing, err := s.fsm.State().KVSGet(nil, dirEnt.Key, &dirEnt.EnterpriseMeta)
+		if err != nil {
+			return err
+		}
+		delta = len(dirEnt.Value)
+		if existing != nil {
+			delta -= len(existing.Value)
+		}
+		usage.KVBytes += pending + delta
+		return nil
+	})
+	return delta, err
+}
+
+// checkIntentionsQuota checks the intentions quota before the given
+// service-intentions config entry is written.
+func (s *Server) checkIntentionsQuota(entry *structs.ServiceIntentionsConfigEntry) error {
+	return s.checkQuota(&entry.EnterpriseMeta, func(usage *structs.QuotaUsage) error {
+		_, existing, err := s.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, entry.Name, &entry.EnterpriseMeta)
+		if err != nil {
+			return err
+		}
+		usage.Intentions += len(entry.Sources)
+		if existing != nil {
+			usage.Intentions -= len(existing.(*structs.ServiceIntentionsConfigEntry).Sources)
+		}
+		return nil
+	})
+}
+
+// checkIntentionMutationQuota checks the intentions quota before the given
+// intention mutation is applied.
+func (s *Server) checkIntentionMutationQuota(op structs.IntentionOp, mut *structs.IntentionMutation) error {
+	if op != structs.IntentionOpCreate && op != structs.IntentionOpUpsert {
+		return nil
+	}
+	return s.checkQuota(&mut.Destination.EnterpriseMeta, func(usage *structs.QuotaUsage) error {
+		if op == structs.IntentionOpUpsert {
+			_, existing, err := s.fsm.State().ConfigEntry(nil, structs.ServiceIntentions, mut.Destination.Name, &mut.Destination.EnterpriseMeta)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				for _, src := range existing.(*structs.ServiceIntentionsConfigEntry).Sources {
+					if src.SourceServiceName() == mut.Source {
+						// Updating an existing intention.
+						return nil
+					}
+				}
+			}
+		}
+		usage.Intentions++
+		return nil
+	})
+}
+
+// checkACLTokenQuota checks the ACL token quota before a token is created.
+func (s *Server) checkACLTokenQuota(entMeta *acl.EnterpriseMeta) error {
+	return s.checkQuota(entMeta, func(usage *structs.QuotaUsage) error {
+		usage.ACLTokens++
+		return nil
+	})
+}
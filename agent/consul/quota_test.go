@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestQuota_Enforcement(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1, codec := testACLServerWithConfig(t, func(c *Config) {
+		c.Quotas = structs.QuotaConfig{
+			QuotaLimits: structs.QuotaLimits{
+				MaxServices:   2,
+				MaxKVBytes:    8,
+				MaxIntentions: 1,
+				MaxACLTokens:  3,
+			},
+		}
+	}, false)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1", testrpc.WithToken(TestDefaultInitialManagementToken))
+
+	writeReq := structs.WriteRequest{Token: TestDefaultInitialManagementToken}
+
+	getQuota := func(t *testing.T) structs.QuotaResponse {
+		t.Helper()
+		var out structs.QuotaResponse
+		req := structs.QuotaRequest{
+			Datacenter:   "dc1",
+			QueryOptions: structs.QueryOptions{Token: TestDefaultInitialManagementToken},
+		}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.Quota", &req, &out))
+		return out
+	}
+
+	t.Run("services", func(t *testing.T) {
+		register := func(name string) error {
+			req := structs.RegisterRequest{
+				Datacenter:   "dc1",
+				Node:         "foo",
+				Address:      "127.0.0.1",
+				Service:      &structs.NodeService{ID: name + "-1", Service: name},
+				WriteRequest: writeReq,
+			}
+			return msgpackrpc.CallWithCodec(codec, "Catalog.Register", &req, nil)
+		}
+
+		// The consul service is already registered by the leader.
+		require.Equal(t, 1, getQuota(t).Usage.Services)
+		require.NoError(t, register("web"))
+
+		err := register("api")
+		require.Error(t, err)
+		require.True(t, structs.IsErrQuotaExceeded(err))
+		require.Contains(t, err.Error(), "services quota of 2")
+
+		// Instances of existing services can still be registered.
+		req := structs.RegisterRequest{
+			Datacenter:   "dc1",
+			Node:         "bar",
+			Address:      "127.0.0.2",
+			Service:      &structs.NodeService{ID: "web-2", Service: "web"},
+			WriteRequest: writeReq,
+		}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Catalog.Register", &req, nil))
+	})
+
+	t.Run("kv bytes", func(t *testing.T) {
+		set := func(key, value string) error {
+			req := structs.KVSRequest{
+				Datacenter:   "dc1",
+				Op:           api.KVSet,
+				DirEnt:       structs.DirEntry{Key: key, Value: []byte(value)},
+				WriteRequest: writeReq,
+			}
+			var out bool
+			return msgpackrpc.CallWithCodec(codec, "KVS.Apply", &req, &out)
+		}
+
+		require.NoError(t, set("a", "123456"))
+		err := set("b", "123")
+		require.Error(t, err)
+		require.True(t, structs.IsErrQuotaExceeded(err))
+
+		// Replacing a value only counts the change in size.
+		require.NoError(t, set("a", "12345678"))
+
+		// The quota covers the transaction as a whole.
+		txn := structs.TxnRequest{
+			Datacenter: "dc1",
+			Ops: structs.TxnOps{
+				{KV: &structs.TxnKVOp{Verb: api.KVSet, DirEnt: structs.DirEntry{Key: "a", Value: []byte("1")}}},
+				{KV: &structs.TxnKVOp{Verb: api.KVSet, DirEnt: structs.DirEntry{Key: "b", Value: []byte("12345678")}}},
+			},
+			WriteRequest: writeReq,
+		}
+		var txnResp structs.TxnResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &txn, &txnResp))
+		require.Len(t, txnResp.Errors, 1)
+		require.Equal(t, 1, txnResp.Errors[0].OpIndex)
+		require.Contains(t, txnResp.Errors[0].What, "KV bytes quota of 8")
+
+		require.Equal(t, 8, getQuota(t).Usage.KVBytes)
+	})
+
+	t.Run("intentions", func(t *testing.T) {
+		apply := func(sources ...string) error {
+			entry := &structs.ServiceIntentionsConfigEntry{
+				Kind: structs.ServiceIntentions,
+				Name: "web",
+			}
+			for _, src := range sources {
+				entry.Sources = append(entry.Sources, &structs.SourceIntention{
+					Name:   src,
+					Action: structs.IntentionActionAllow,
+				})
+			}
+			req := structs.ConfigEntryRequest{
+				Datacenter:   "dc1",
+				Entry:        entry,
+				WriteRequest: writeReq,
+			}
+			var out bool
+			return msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &req, &out)
+		}
+
+		require.NoError(t, apply("api"))
+		err := apply("api", "db")
+		require.Error(t, err)
+		require.True(t, structs.IsErrQuotaExceeded(err))
+
+		req := structs.IntentionRequest{
+			Datacenter: "dc1",
+			Op:         structs.IntentionOpUpsert,
+			Intention: &structs.Intention{
+				SourceName:      "db",
+				DestinationName: "web",
+				Action:          structs.IntentionActionAllow,
+			},
+			WriteRequest: writeReq,
+		}
+		var reply string
+		err = msgpackrpc.CallWithCodec(codec, "Intention.Apply", &req, &reply)
+		require.Error(t, err)
+		require.True(t, structs.IsErrQuotaExceeded(err))
+
+		// Updating an existing intention doesn't count towards the quota.
+		req.Intention.SourceName = "api"
+		req.Intention.Action = structs.IntentionActionDeny
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Intention.Apply", &req, &reply))
+	})
+
+	t.Run("acl tokens", func(t *testing.T) {
+		create := func() error {
+			req := structs.ACLTokenSetRequest{
+				Datacenter:   "dc1",
+				ACLToken:     structs.ACLToken{Description: "quota"},
+				WriteRequest: writeReq,
+			}
+			var out structs.ACLToken
+			return msgpackrpc.CallWithCodec(codec, "ACL.TokenSet", &req, &out)
+		}
+
+		// The anonymous and initial management tokens already exist.
+		require.Equal(t, 2, getQuota(t).Usage.ACLTokens)
+		require.NoError(t, create())
+		err := create()
+		require.Error(t, err)
+		require.True(t, structs.IsErrQuotaExceeded(err))
+	})
+
+	require.Equal(t, structs.QuotaLimits{
+		MaxServices:   2,
+		MaxKVBytes:    8,
+		MaxIntentions: 1,
+		MaxACLTokens:  3,
+	}, getQuota(t).Limits)
+}
@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib/egressproxy"
+)
+
+const (
+	// raftArchiveDefaultInterval is the time between two passes archiving
+	// the new entries when the configuration doesn't set one.
+	raftArchiveDefaultInterval = 10 * time.Second
+
+	// raftArchiveBatchSize is the maximum number of entries read from the
+	// log before they are written to the sink.
+	raftArchiveBatchSize = 1024
+)
+
+var (
+	metricsKeyRaftArchiveArchived = []string{"leader", "raft_archive", "archived"}
+	metricsKeyRaftArchiveFailed   = []string{"leader", "raft_archive", "failed"}
+	metricsKeyRaftArchiveLost     = []string{"leader", "raft_archive", "lost"}
+)
+
+var RaftArchiveCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyRaftArchiveArchived,
+		Help: "Increments by the number of Raft log entries written to the archive.",
+	},
+	{
+		Name: metricsKeyRaftArchiveFailed,
+		Help: "Increments when a batch of Raft log entries could not be written to the archive.",
+	},
+	{
+		Name: metricsKeyRaftArchiveLost,
+		Help: "Increments by the number of Raft log entries that could not be archived because they were compacted or chunked.",
+	},
+}
+
+// RaftArchiveConfig configures the archive the leader ships the committed
+// Raft log entries to. The archive is disabled unless a sink is configured.
+type RaftArchiveConfig struct {
+	// Types are the names of the message types archived, as reported by
+	// `consul snapshot inspect` (for example "KVS" or "ConfigEntry"). All the
+	// types are archived if it is empty.
+	Types []string
+
+	// Interval is the time between two passes archiving the new entries.
+	Interval time.Duration
+
+	// FilePath is the file the entries are appended to.
+	FilePath string
+
+	// S3Bucket is the bucket the entries are uploaded to, one object per
+	// batch.
+	S3Bucket string
+
+	// S3Prefix is prepended to the keys of the objects.
+	S3Prefix string
+
+	// S3Region is the region of the bucket, the default region of the AWS
+	// configuration is used if it is empty.
+	S3Region string
+
+	// S3Endpoint overrides the S3 endpoint, for S3 compatible storage.
+	S3Endpoint string
+}
+
+// Enabled returns whether a sink is configured.
+func (c *RaftArchiveConfig) Enabled() bool {
+	return c.FilePath != "" || c.S3Bucket != ""
+}
+
+// RaftArchiveRecord is a committed Raft log entry written to the archive.
+type RaftArchiveRecord struct {
+	Index      uint64
+	Term       uint64
+	Type       string
+	AppendedAt time.Time `json:",omitempty"`
+
+	// Data is the msgpack encoded request applied by the FSM, without the
+	// leading message type byte.
+	Data []byte
+}
+
+// raftArchiveSink writes the records to the archive. The records of a batch
+// are consecutive and in order, the same batch may be written again if the
+// leader fails before recording its checkpoint.
+type raftArchiveSink interface {
+	Archive(ctx context.Context, records []RaftArchiveRecord) error
+}
+
+func newRaftArchiveSink(config RaftArchiveConfig) (raftArchiveSink, error) {
+	switch {
+	case config.FilePath != "":
+		return &raftArchiveFileSink{path: config.FilePath}, nil
+	case config.S3Bucket != "":
+		return newRaftArchiveS3Sink(config)
+	default:
+		return nil, errors.New("no raft archive sink is configured")
+	}
+}
+
+// raftArchiveMessageTypes returns the message types named in types, or nil
+// if all of them are archived.
+func raftArchiveMessageTypes(types []string) (map[structs.MessageType]struct{}, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	byName := make(map[string]structs.MessageType)
+	for t := structs.MessageType(0); t < structs.IgnoreUnknownTypeFlag; t++ {
+		byName[t.String()] = t
+	}
+	out := make(map[structs.MessageType]struct{}, len(types))
+	for _, name := range types {
+		t, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown raft message type %q", name)
+		}
+		out[t] = struct{}{}
+	}
+	return out, nil
+}
+
+// ValidateRaftArchiveTypes returns an error if one of the types isn't the
+// name of a message type.
+func ValidateRaftArchiveTypes(types []string) error {
+	_, err := raftArchiveMessageTypes(types)
+	return err
+}
+
+func encodeRaftArchiveRecords(records []RaftArchiveRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// raftArchiveFileSink appends the records to a file, one JSON object per
+// line.
+type raftArchiveFileSink struct {
+	path string
+}
+
+func (s *raftArchiveFileSink) Archive(_ context.Context, records []RaftArchiveRecord) error {
+	b, err := encodeRaftArchiveRecords(records)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// raftArchiveS3Sink uploads each batch of records to an object named after
+// the first and last indexes of the batch, so uploading a batch again
+// overwrites the previous object.
+type raftArchiveS3Sink struct {
+	bucket string
+	prefix string
+	client *s3.S3
+}
+
+func newRaftArchiveS3Sink(config RaftArchiveConfig) (*raftArchiveS3Sink, error) {
+	// The credentials are set through the standard methods of the AWS SDK:
+	// environment, shared credentials file or IAM role.
+	awsConfig := aws.Config{
+		HTTPClient: &http.Client{Transport: egressproxy.PooledTransport()},
+	}
+	if config.S3Region != "" {
+		awsConfig.Region = aws.String(config.S3Region)
+	}
+	if config.S3Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.S3Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &raftArchiveS3Sink{
+		bucket: config.S3Bucket,
+		prefix: config.S3Prefix,
+		client: s3.New(awsSession),
+	}, nil
+}
+
+func (s *raftArchiveS3Sink) Archive(ctx context.Context, records []RaftArchiveRecord) error {
+	b, err := encodeRaftArchiveRecords(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(raftArchiveObjectKey(s.prefix, records)),
+		Body:        bytes.NewReader(b),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	return err
+}
+
+// raftArchiveObjectKey returns the key of the object holding the records. The
+// indexes are zero padded so that the keys sort in the order of the log.
+func raftArchiveObjectKey(prefix string, records []RaftArchiveRecord) string {
+	first, last := records[0].Index, records[len(records)-1].Index
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return fmt.Sprintf("%s%020d-%020d.jsonl", prefix, first, last)
+}
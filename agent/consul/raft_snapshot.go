@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/consul/logging"
+)
+
+// raftSnapshotWindowInterval is the time between two checks of whether the
+// server entered or left the snapshot window.
+var raftSnapshotWindowInterval = time.Minute
+
+// RaftSnapshotConfig throttles the Raft snapshots and sets a window of the day
+// during which they are preferably taken.
+type RaftSnapshotConfig struct {
+	// IOLimit is the number of bytes per second written when creating a
+	// snapshot and read when installing one, 0 for no limit.
+	IOLimit int
+
+	// WindowStart and WindowEnd are the times of the day, in UTC and as
+	// offsets from midnight, at which the snapshot window starts and ends.
+	// The window wraps around midnight when it ends before it starts, and
+	// there is no window when they are equal.
+	WindowStart time.Duration
+	WindowEnd   time.Duration
+
+	// PeakThreshold is the snapshot threshold used outside of the window. It
+	// bounds the growth of the Raft log during the peak hours.
+	PeakThreshold int
+}
+
+// WindowEnabled returns whether a snapshot window is configured.
+func (c RaftSnapshotConfig) WindowEnabled() bool {
+	return c.WindowStart != c.WindowEnd
+}
+
+// InWindow returns whether the given time is within the snapshot window, it
+// always is when there is no window.
+func (c RaftSnapshotConfig) InWindow(now time.Time) bool {
+	if !c.WindowEnabled() {
+		return true
+	}
+	now = now.UTC()
+	offset := now.Sub(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+	if c.WindowStart < c.WindowEnd {
+		return offset >= c.WindowStart && offset < c.WindowEnd
+	}
+	return offset >= c.WindowStart || offset < c.WindowEnd
+}
+
+// snapshotThreshold returns the snapshot threshold to use at the given time,
+// threshold is the one configured for the window.
+func (c RaftSnapshotConfig) snapshotThreshold(threshold uint64, now time.Time) uint64 {
+	if c.InWindow(now) || c.PeakThreshold <= 0 {
+		return threshold
+	}
+	return uint64(c.PeakThreshold)
+}
+
+// runRaftSnapshotWindow switches the Raft snapshot threshold between the
+// configured one within the snapshot window and the peak threshold outside
+// of it.
+func (s *Server) runRaftSnapshotWindow(ctx context.Context) {
+	ticker := time.NewTicker(raftSnapshotWindowInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.applyRaftSnapshotWindow(time.Now()); err != nil {
+			s.loggers.Named(logging.Raft).Error("failed to update the raft snapshot threshold", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyRaftSnapshotWindow reloads the Raft config with the snapshot threshold
+// to use at the given time, if it changed.
+func (s *Server) applyRaftSnapshotWindow(now time.Time) error {
+	s.raftSnapshotLock.Lock()
+	defer s.raftSnapshotLock.Unlock()
+
+	raftCfg := s.raft.ReloadableConfig()
+	threshold := s.raftSnapshotConfig.snapshotThreshold(s.raftSnapshotThreshold, now)
+	if raftCfg.SnapshotThreshold == threshold {
+		return nil
+	}
+
+	s.loggers.Named(logging.Raft).Info("updating the raft snapshot threshold",
+		"in_window", s.raftSnapshotConfig.InWindow(now),
+		"threshold", threshold,
+	)
+	raftCfg.SnapshotThreshold = threshold
+	return s.raft.ReloadConfig(raftCfg)
+}
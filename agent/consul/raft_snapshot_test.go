@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestRaftSnapshotConfig_InWindow(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2024, 3, 4, hour, min, 0, 0, time.UTC)
+	}
+
+	cases := map[string]struct {
+		start, end time.Duration
+		in         []time.Time
+		out        []time.Time
+	}{
+		"no window": {
+			in: []time.Time{at(0, 0), at(12, 0), at(23, 59)},
+		},
+		"same day": {
+			start: 1 * time.Hour,
+			end:   5 * time.Hour,
+			in:    []time.Time{at(1, 0), at(3, 30), at(4, 59)},
+			out:   []time.Time{at(0, 59), at(5, 0), at(23, 0)},
+		},
+		"around midnight": {
+			start: 22 * time.Hour,
+			end:   2 * time.Hour,
+			in:    []time.Time{at(22, 0), at(23, 59), at(0, 0), at(1, 59)},
+			out:   []time.Time{at(2, 0), at(12, 0), at(21, 59)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := RaftSnapshotConfig{WindowStart: tc.start, WindowEnd: tc.end}
+			for _, now := range tc.in {
+				require.True(t, cfg.InWindow(now), now)
+			}
+			for _, now := range tc.out {
+				require.False(t, cfg.InWindow(now), now)
+			}
+		})
+	}
+
+	// The window is in UTC.
+	cfg := RaftSnapshotConfig{WindowStart: 1 * time.Hour, WindowEnd: 5 * time.Hour}
+	require.True(t, cfg.InWindow(at(2, 0).In(time.FixedZone("PST", -8*3600))))
+}
+
+func TestServer_RaftSnapshotWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	_, s := testServerWithConfig(t, func(c *Config) {
+		c.RaftConfig.SnapshotThreshold = 1000
+	})
+	testrpc.WaitForLeader(t, s.RPC, "dc1")
+
+	now := time.Now().UTC()
+	hour := time.Duration(now.Hour()) * time.Hour
+
+	// Outside of the window, the peak threshold is used.
+	rc := ReloadableConfig{
+		RaftSnapshotThreshold: 2000,
+		RaftSnapshot: RaftSnapshotConfig{
+			IOLimit:       10 * 1024 * 1024,
+			WindowStart:   (hour + 2*time.Hour) % (24 * time.Hour),
+			WindowEnd:     (hour + 3*time.Hour) % (24 * time.Hour),
+			PeakThreshold: 50000,
+		},
+	}
+	require.NoError(t, s.ReloadConfig(rc))
+	require.Equal(t, uint64(50000), s.raft.ReloadableConfig().SnapshotThreshold)
+	require.Equal(t, rate.Limit(10*1024*1024), s.raftSnapshotLimiter.Limit())
+
+	// Entering the window switches back to the configured threshold.
+	require.NoError(t, s.applyRaftSnapshotWindow(now.Add(2*time.Hour)))
+	require.Equal(t, uint64(2000), s.raft.ReloadableConfig().SnapshotThreshold)
+
+	require.NoError(t, s.applyRaftSnapshotWindow(now.Add(4*time.Hour)))
+	require.Equal(t, uint64(50000), s.raft.ReloadableConfig().SnapshotThreshold)
+
+	// Removing the window and the limit restores the defaults.
+	require.NoError(t, s.ReloadConfig(ReloadableConfig{RaftSnapshotThreshold: 2000}))
+	require.Equal(t, uint64(2000), s.raft.ReloadableConfig().SnapshotThreshold)
+	require.Equal(t, rate.Inf, s.raftSnapshotLimiter.Limit())
+}
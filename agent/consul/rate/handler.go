@@ -132,6 +132,7 @@ const (
 	OperationCategoryDNS             OperationCategory = "DNS"
 	OperationCategorySubscribe       OperationCategory = "Subscribe"
 	OperationCategoryResource        OperationCategory = "Resource"
+	OperationCategoryExec            OperationCategory = "Exec"
 )
 
 // Operation the client is attempting to perform.
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// redactionApplies returns whether the values hidden by the redaction
+// settings must be hidden from the caller, i.e. when redaction is configured
+// and the caller isn't allowed to read secrets.
+func (s *Server) redactionApplies(authz acl.Authorizer) bool {
+	if !s.config.Redaction.Enabled() {
+		return false
+	}
+	return authz.SecretsRead(nil) != acl.Allow
+}
+
+// redactDirEntries hides the values under the redacted KV prefixes, unless
+// the caller is allowed to read them.
+func (s *Server) redactDirEntries(authz acl.Authorizer, ents structs.DirEntries) structs.DirEntries {
+	if !s.redactionApplies(authz) {
+		return ents
+	}
+	ents, _ = s.config.Redaction.RedactDirEntries(ents)
+	return ents
+}
+
+// redactTxnResults hides the values of the KV results under the redacted KV
+// prefixes, unless the caller is allowed to read them.
+func (s *Server) redactTxnResults(authz acl.Authorizer, results structs.TxnResults) {
+	if !s.redactionApplies(authz) {
+		return
+	}
+	for _, r := range results {
+		if r.KV == nil {
+			continue
+		}
+		if redacted, ok := s.config.Redaction.RedactDirEntries(structs.DirEntries{r.KV}); ok {
+			r.KV = redacted[0]
+		}
+	}
+}
+
+// redactConfigEntries hides the redacted config entry fields, unless the
+// caller is allowed to read them.
+func (s *Server) redactConfigEntries(authz acl.Authorizer, entries []structs.ConfigEntry) []structs.ConfigEntry {
+	if !s.redactionApplies(authz) {
+		return entries
+	}
+	entries, _ = s.config.Redaction.RedactConfigEntries(entries)
+	return entries
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+func TestRedaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	_, srv, codec := testACLServerWithConfig(t, func(c *Config) {
+		c.Redaction = structs.RedactionConfig{
+			KVPrefixes:        []string{"secret/"},
+			ConfigEntryFields: []string{"service-defaults.Meta.password"},
+		}
+	}, false)
+	waitForLeaderEstablishment(t, srv)
+
+	for key, value := range map[string]string{"public/a": "a", "secret/b": "hunter2"} {
+		var ok bool
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Apply", &structs.KVSRequest{
+			Datacenter:   "dc1",
+			Op:           api.KVSet,
+			DirEnt:       structs.DirEntry{Key: key, Value: []byte(value)},
+			WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+		}, &ok))
+	}
+	var ok bool
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceConfigEntry{
+			Kind: structs.ServiceDefaults,
+			Name: "web",
+			Meta: map[string]string{"password": "hunter2", "owner": "team"},
+		},
+		WriteRequest: structs.WriteRequest{Token: TestDefaultInitialManagementToken},
+	}, &ok))
+
+	reader, err := upsertTestTokenWithPolicyRules(codec, TestDefaultInitialManagementToken, "dc1", `
+		key_prefix "" { policy = "read" }
+		service_prefix "" { policy = "read" }
+	`)
+	require.NoError(t, err)
+	secretsReader, err := upsertTestTokenWithPolicyRules(codec, TestDefaultInitialManagementToken, "dc1", `
+		key_prefix "" { policy = "read" }
+		service_prefix "" { policy = "read" }
+		secrets = "read"
+	`)
+	require.NoError(t, err)
+
+	requireValues := func(t *testing.T, token, secret string) {
+		t.Helper()
+
+		var list structs.IndexedDirEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.List", &structs.KeyRequest{
+			Datacenter:   "dc1",
+			QueryOptions: structs.QueryOptions{Token: token},
+		}, &list))
+		values := map[string]string{}
+		for _, ent := range list.Entries {
+			values[ent.Key] = string(ent.Value)
+		}
+		require.Equal(t, map[string]string{"public/a": "a", "secret/b": secret}, values)
+
+		var get structs.IndexedDirEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "KVS.Get", &structs.KeyRequest{
+			Datacenter:   "dc1",
+			Key:          "secret/b",
+			QueryOptions: structs.QueryOptions{Token: token},
+		}, &get))
+		require.Len(t, get.Entries, 1)
+		require.Equal(t, secret, string(get.Entries[0].Value))
+
+		var txn structs.TxnReadResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Read", &structs.TxnReadRequest{
+			Datacenter: "dc1",
+			Ops: structs.TxnOps{
+				&structs.TxnOp{KV: &structs.TxnKVOp{Verb: api.KVGet, DirEnt: structs.DirEntry{Key: "secret/b"}}},
+			},
+			QueryOptions: structs.QueryOptions{Token: token},
+		}, &txn))
+		require.Empty(t, txn.Errors)
+		require.Len(t, txn.Results, 1)
+		require.Equal(t, secret, string(txn.Results[0].KV.Value))
+
+		var entries structs.IndexedConfigEntries
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConfigEntry.List", &structs.ConfigEntryQuery{
+			Datacenter:   "dc1",
+			Kind:         structs.ServiceDefaults,
+			QueryOptions: structs.QueryOptions{Token: token},
+		}, &entries))
+		require.Len(t, entries.Entries, 1)
+		require.Equal(t, map[string]string{"password": secret, "owner": "team"}, entries.Entries[0].GetMeta())
+	}
+
+	t.Run("hidden without secrets read", func(t *testing.T) {
+		requireValues(t, reader.SecretID, structs.RedactedValue)
+	})
+	t.Run("visible with secrets read", func(t *testing.T) {
+		requireValues(t, secretsReader.SecretID, "hunter2")
+	})
+	t.Run("visible with global management", func(t *testing.T) {
+		requireValues(t, TestDefaultInitialManagementToken, "hunter2")
+	})
+
+	// The stored values are left untouched.
+	_, ent, err := srv.fsm.State().KVSGet(nil, "secret/b", nil)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", string(ent.Value))
+}
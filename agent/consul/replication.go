@@ -27,6 +27,10 @@ var ReplicationGauges = []prometheus.GaugeDefinition{
 		Name: []string{"leader", "replication", "acl-policies", "index"},
 		Help: "Tracks the index of ACL policies in the primary that the secondary has successfully replicated",
 	},
+	{
+		Name: []string{"leader", "replication", "acl-policies", "lag"},
+		Help: "Tracks how many seconds the ACL policies replicated by the secondary may be behind the primary",
+	},
 	{
 		Name: []string{"leader", "replication", "acl-tokens", "status"},
 		Help: "Tracks the current health of ACL token replication on the leader",
@@ -35,6 +39,10 @@ var ReplicationGauges = []prometheus.GaugeDefinition{
 		Name: []string{"leader", "replication", "acl-tokens", "index"},
 		Help: "Tracks the index of ACL tokens in the primary that the secondary has successfully replicated",
 	},
+	{
+		Name: []string{"leader", "replication", "acl-tokens", "lag"},
+		Help: "Tracks how many seconds the ACL tokens replicated by the secondary may be behind the primary",
+	},
 	{
 		Name: []string{"leader", "replication", "acl-roles", "status"},
 		Help: "Tracks the current health of ACL role replication on the leader",
@@ -43,6 +51,10 @@ var ReplicationGauges = []prometheus.GaugeDefinition{
 		Name: []string{"leader", "replication", "acl-roles", "index"},
 		Help: "Tracks the index of ACL roles in the primary that the secondary has successfully replicated",
 	},
+	{
+		Name: []string{"leader", "replication", "acl-roles", "lag"},
+		Help: "Tracks how many seconds the ACL roles replicated by the secondary may be behind the primary",
+	},
 	{
 		Name: []string{"leader", "replication", "config-entries", "status"},
 		Help: "Tracks the current health of config entry replication on the leader",
@@ -51,6 +63,10 @@ var ReplicationGauges = []prometheus.GaugeDefinition{
 		Name: []string{"leader", "replication", "config-entries", "index"},
 		Help: "Tracks the index of config entries in the primary that the secondary has successfully replicated",
 	},
+	{
+		Name: []string{"leader", "replication", "config-entries", "lag"},
+		Help: "Tracks how many seconds the config entries replicated by the secondary may be behind the primary",
+	},
 	{
 		Name: []string{"leader", "replication", "federation-state", "status"},
 		Help: "Tracks the current health of federation state replication on the leader",
@@ -59,6 +75,22 @@ var ReplicationGauges = []prometheus.GaugeDefinition{
 		Name: []string{"leader", "replication", "federation-state", "index"},
 		Help: "Tracks the index of federation states in the primary that the secondary has successfully replicated",
 	},
+	{
+		Name: []string{"leader", "replication", "federation-state", "lag"},
+		Help: "Tracks how many seconds the federation states replicated by the secondary may be behind the primary",
+	},
+	{
+		Name: []string{"leader", "replication", "kv", "status"},
+		Help: "Tracks the current health of KV replication on the leader",
+	},
+	{
+		Name: []string{"leader", "replication", "kv", "index"},
+		Help: "Tracks the index of the KV prefixes in the primary that the secondary has successfully replicated",
+	},
+	{
+		Name: []string{"leader", "replication", "kv", "lag"},
+		Help: "Tracks how many seconds the the KV prefixes replicated by the secondary may be behind the primary",
+	},
 	{
 		Name: []string{"leader", "replication", "namespaces", "status"},
 		Help: "Tracks the current health of federation state replication on the leader",
@@ -106,6 +138,7 @@ type Replicator struct {
 	logger           hclog.Logger
 	lastRemoteIndex  uint64
 	suppressErrorLog func(err error) bool
+	tracker          replicationTracker
 }
 
 func NewReplicator(config *ReplicatorConfig) (*Replicator, error) {
@@ -142,6 +175,7 @@ func NewReplicator(config *ReplicatorConfig) (*Replicator, error) {
 func (r *Replicator) Run(ctx context.Context) error {
 	defer r.logger.Info("stopped replication")
 
+	r.tracker.reset()
 	for {
 		// This ensures we aren't doing too many successful replication rounds - mostly useful when
 		// the data within the primary datacenter is changing rapidly but we try to limit the amount
@@ -159,6 +193,9 @@ func (r *Replicator) Run(ctx context.Context) error {
 			metrics.SetGauge([]string{"leader", "replication", r.delegate.MetricName(), "status"},
 				0,
 			)
+			metrics.SetGauge([]string{"leader", "replication", r.delegate.MetricName(), "lag"},
+				float32(r.tracker.failure(err).Seconds()),
+			)
 			// reset the lastRemoteIndex when there is an RPC failure. This should cause a full sync to be done during
 			// the next round of replication
 			atomic.StoreUint64(&r.lastRemoteIndex, 0)
@@ -179,6 +216,9 @@ func (r *Replicator) Run(ctx context.Context) error {
 		metrics.SetGauge([]string{"leader", "replication", r.delegate.MetricName(), "index"},
 			float32(index),
 		)
+		metrics.SetGauge([]string{"leader", "replication", r.delegate.MetricName(), "lag"},
+			float32(r.tracker.success(index).Seconds()),
+		)
 
 		atomic.StoreUint64(&r.lastRemoteIndex, index)
 		r.logger.Debug("replication completed through remote index", "index", index)
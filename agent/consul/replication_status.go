@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/grpc-external/services/peerstream"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// replicationTracker records the outcome of the rounds of a replication
+// routine, to report its status and how far behind the source it may be.
+type replicationTracker struct {
+	lock             sync.RWMutex
+	started          time.Time
+	index            uint64
+	lastSuccess      time.Time
+	lastError        time.Time
+	lastErrorMessage string
+}
+
+// reset is called when the replication routine starts.
+func (t *replicationTracker) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.started = time.Now()
+	t.index = 0
+	t.lastSuccess = time.Time{}
+	t.lastError = time.Time{}
+	t.lastErrorMessage = ""
+}
+
+// success records a successful round and returns the lag of the replication,
+// which is always zero.
+func (t *replicationTracker) success(index uint64) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.index = index
+	t.lastSuccess = time.Now()
+	return t.lagLocked(t.lastSuccess)
+}
+
+// failure records a failed round and returns the lag of the replication.
+func (t *replicationTracker) failure(err error) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.lastError = time.Now()
+	t.lastErrorMessage = err.Error()
+	return t.lagLocked(t.lastError)
+}
+
+// lagLocked returns how long the replicated data may have been behind the
+// source. Since the replication rounds block until the source changes, the
+// data is up to date as long as the last round succeeded.
+func (t *replicationTracker) lagLocked(now time.Time) time.Duration {
+	if !t.lastSuccess.IsZero() && !t.lastError.After(t.lastSuccess) {
+		return 0
+	}
+	since := t.lastSuccess
+	if since.IsZero() {
+		since = t.started
+	}
+	if since.IsZero() {
+		return 0
+	}
+	return now.Sub(since)
+}
+
+func (t *replicationTracker) status(kind string) structs.ReplicationStatus {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	status := structs.ReplicationStatus{
+		Kind:             kind,
+		ReplicatedIndex:  t.index,
+		LastErrorMessage: t.lastErrorMessage,
+		LagSeconds:       t.lagLocked(time.Now()).Round(time.Millisecond).Seconds(),
+	}
+	if !t.lastSuccess.IsZero() {
+		status.LastSuccess = t.lastSuccess.Round(time.Second).UTC()
+	}
+	if !t.lastError.IsZero() {
+		status.LastError = t.lastError.Round(time.Second).UTC()
+	}
+	return status
+}
+
+// replicationStatuses returns the status of the replication of the config
+// entries, federation states and ACLs from the primary datacenter, and of
+// the replication from the peers.
+func (s *Server) replicationStatuses() ([]structs.ReplicationStatus, error) {
+	var statuses []structs.ReplicationStatus
+
+	if s.config.PrimaryDatacenter != "" && s.config.PrimaryDatacenter != s.config.Datacenter {
+		fromPrimary := func(kind, routineName string, tracker *replicationTracker) {
+			status := tracker.status(kind)
+			status.SourceDatacenter = s.config.PrimaryDatacenter
+			status.Running = s.leaderRoutineManager.IsRunning(routineName)
+			statuses = append(statuses, status)
+		}
+
+		fromPrimary("config-entries", configReplicationRoutineName, &s.configReplicator.tracker)
+		fromPrimary("federation-states", federationStateReplicationRoutineName, &s.federationStateReplicator.tracker)
+		if s.config.ACLsEnabled {
+			fromPrimary("acl-policies", aclPolicyReplicationRoutineName, s.aclReplicationTrackers[structs.ACLReplicatePolicies])
+			fromPrimary("acl-roles", aclRoleReplicationRoutineName, s.aclReplicationTrackers[structs.ACLReplicateRoles])
+			if s.config.ACLTokenReplication {
+				fromPrimary("acl-tokens", aclTokenReplicationRoutineName, s.aclReplicationTrackers[structs.ACLReplicateTokens])
+			}
+		}
+	}
+
+	if s.config.PeeringEnabled {
+		_, peers, err := s.fsm.State().PeeringList(nil, *structs.NodeEnterpriseMetaInPartition(structs.WildcardSpecifier))
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		for _, peer := range peers {
+			if !peer.IsActive() {
+				continue
+			}
+			stream, _ := s.peerStreamServer.StreamStatus(peer.ID)
+			statuses = append(statuses, peeringReplicationStatus(peer.Name, peer.Partition, stream, now))
+		}
+	}
+
+	return statuses, nil
+}
+
+// peeringReplicationStatus returns the status of the replication from a peer.
+// The peer pushes its changes over the stream, so the data is up to date as
+// long as the stream is connected and the last resource received was stored.
+func peeringReplicationStatus(name, partition string, stream peerstream.Status, now time.Time) structs.ReplicationStatus {
+	status := structs.ReplicationStatus{
+		Kind:            "peering",
+		SourcePeer:      name,
+		SourcePartition: partition,
+		Running:         stream.Connected,
+	}
+	if stream.LastRecvResourceSuccess != nil {
+		status.LastSuccess = stream.LastRecvResourceSuccess.Round(time.Second).UTC()
+	}
+	if stream.LastRecvError != nil {
+		status.LastError = stream.LastRecvError.Round(time.Second).UTC()
+		status.LastErrorMessage = stream.LastRecvErrorMessage
+	}
+	if !stream.Connected && stream.DisconnectTime != nil && stream.DisconnectErrorMessage != "" &&
+		stream.DisconnectTime.After(status.LastError) {
+		status.LastError = stream.DisconnectTime.Round(time.Second).UTC()
+		status.LastErrorMessage = stream.DisconnectErrorMessage
+	}
+	status.LagSeconds = peeringReplicationLag(stream, now).Round(time.Millisecond).Seconds()
+	return status
+}
+
+func peeringReplicationLag(stream peerstream.Status, now time.Time) time.Duration {
+	var lastSuccess time.Time
+	if stream.LastRecvResourceSuccess != nil {
+		lastSuccess = *stream.LastRecvResourceSuccess
+	}
+	failing := stream.LastRecvError != nil && stream.LastRecvError.After(lastSuccess)
+	if stream.Connected && !failing {
+		return 0
+	}
+
+	// The heartbeats show the peer was reachable, even if nothing changed.
+	since := lastSuccess
+	if stream.LastRecvHeartbeat != nil && stream.LastRecvHeartbeat.After(since) && !failing {
+		since = *stream.LastRecvHeartbeat
+	}
+	if since.IsZero() {
+		return 0
+	}
+	return now.Sub(since)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/grpc-external/services/peerstream"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestReplicationTracker(t *testing.T) {
+	var tracker replicationTracker
+	tracker.reset()
+
+	// The lag grows until the first success.
+	tracker.started = time.Now().Add(-time.Minute)
+	lag := tracker.failure(errors.New("boom"))
+	require.GreaterOrEqual(t, lag, time.Minute)
+
+	status := tracker.status("config-entries")
+	require.Equal(t, "config-entries", status.Kind)
+	require.Equal(t, "boom", status.LastErrorMessage)
+	require.GreaterOrEqual(t, status.LagSeconds, 60.0)
+	require.True(t, status.LastSuccess.IsZero())
+
+	require.Zero(t, tracker.success(42))
+	status = tracker.status("config-entries")
+	require.Equal(t, uint64(42), status.ReplicatedIndex)
+	require.Zero(t, status.LagSeconds)
+	require.False(t, status.LastSuccess.IsZero())
+
+	// After a success, the lag is the time since the success.
+	tracker.lastSuccess = time.Now().Add(-30 * time.Second)
+	lag = tracker.failure(errors.New("boom"))
+	require.GreaterOrEqual(t, lag, 30*time.Second)
+	require.Less(t, lag, time.Minute)
+}
+
+func TestPeeringReplicationLag(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	cases := map[string]struct {
+		stream peerstream.Status
+		lag    time.Duration
+	}{
+		"never connected": {
+			stream: peerstream.Status{NeverConnected: true},
+		},
+		"connected": {
+			stream: peerstream.Status{
+				Connected:               true,
+				LastRecvResourceSuccess: ago(time.Hour),
+				LastRecvHeartbeat:       ago(time.Second),
+			},
+		},
+		"connected failing": {
+			stream: peerstream.Status{
+				Connected:               true,
+				LastRecvResourceSuccess: ago(time.Minute),
+				LastRecvHeartbeat:       ago(time.Second),
+				LastRecvError:           ago(10 * time.Second),
+			},
+			lag: time.Minute,
+		},
+		"disconnected": {
+			stream: peerstream.Status{
+				LastRecvResourceSuccess: ago(time.Hour),
+				LastRecvHeartbeat:       ago(2 * time.Minute),
+				DisconnectTime:          ago(time.Minute),
+			},
+			lag: 2 * time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.lag, peeringReplicationLag(tc.stream, now))
+		})
+	}
+}
+
+func TestOperator_ReplicationStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	_, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+	})
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	testrpc.WaitForLeader(t, s1.RPC, "dc2")
+
+	var out bool
+	require.NoError(t, s1.RPC(context.Background(), "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry: &structs.ServiceConfigEntry{
+			Kind:     structs.ServiceDefaults,
+			Name:     "web",
+			Protocol: "http",
+		},
+	}, &out))
+
+	retry.Run(t, func(r *retry.R) {
+		var reply structs.IndexedReplicationStatuses
+		require.NoError(r, s2.RPC(context.Background(), "Operator.ReplicationStatus", &structs.DCSpecificRequest{
+			Datacenter: "dc2",
+		}, &reply))
+		require.Equal(r, "dc2", reply.Datacenter)
+
+		byKind := make(map[string]structs.ReplicationStatus)
+		for _, status := range reply.Replications {
+			byKind[status.Kind] = status
+		}
+		status, ok := byKind["config-entries"]
+		require.True(r, ok)
+		require.True(r, status.Running)
+		require.Equal(r, "dc1", status.SourceDatacenter)
+		require.NotZero(r, status.ReplicatedIndex)
+		require.False(r, status.LastSuccess.IsZero())
+		require.Zero(r, status.LagSeconds)
+	})
+
+	// The primary doesn't replicate from another datacenter.
+	var reply structs.IndexedReplicationStatuses
+	require.NoError(t, s1.RPC(context.Background(), "Operator.ReplicationStatus", &structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}, &reply))
+	for _, status := range reply.Replications {
+		require.Equal(t, "peering", status.Kind)
+	}
+}
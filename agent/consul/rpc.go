@@ -38,6 +38,7 @@ import (
 	"github.com/hashicorp/consul/agent/rpc/middleware"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/logging"
 )
 
@@ -62,10 +63,18 @@ var RPCCounters = []prometheus.CounterDefinition{
 		Name: []string{"rpc", "cross-dc"},
 		Help: "Increments when a server sends a (potentially blocking) cross datacenter RPC query.",
 	},
+	{
+		Name: []string{"rpc", "cross-dc", "cache_hit"},
+		Help: "Increments when a server answers a cross datacenter RPC query from its cache instead of forwarding it.",
+	},
 	{
 		Name: []string{"rpc", "query"},
 		Help: "Increments when a server receives a read request, indicating the rate of new read queries.",
 	},
+	{
+		Name: []string{"rpc", "bounded-stale", "forwarded"},
+		Help: "Increments when a follower forwards a bounded-stale read to the leader because its data is older than the bound.",
+	},
 }
 
 var RPCGauges = []prometheus.GaugeDefinition{
@@ -230,6 +239,9 @@ func (s *Server) handleConn(conn net.Conn, isTLS bool) {
 	case pool.RPCMultiplexV2:
 		s.handleMultiplexV2(conn)
 
+	case pool.RPCMultiplexV2Compressed:
+		s.handleCompressedMultiplexV2(conn)
+
 	case pool.RPCSnapshot:
 		s.handleSnapshotConn(conn)
 
@@ -311,6 +323,9 @@ func (s *Server) handleNativeTLS(conn net.Conn) {
 	case pool.ALPN_RPCMultiplexV2:
 		s.handleMultiplexV2(tlsConn)
 
+	case pool.ALPN_RPCMultiplexV2Compressed:
+		s.handleCompressedMultiplexV2(tlsConn)
+
 	case pool.ALPN_RPCSnapshot:
 		s.handleSnapshotConn(tlsConn)
 
@@ -356,6 +371,46 @@ func (s *Server) handleNativeTLS(conn net.Conn) {
 	}
 }
 
+// handleCompressedMultiplexV2 negotiates the compression algorithm of the
+// connection before multiplexing it like handleMultiplexV2. An algorithm this
+// server doesn't implement is declined by answering compression.None, and the
+// connection is multiplexed uncompressed.
+func (s *Server) handleCompressedMultiplexV2(conn net.Conn) {
+	if s.config.RPCHandshakeTimeout > 0 {
+		conn.SetReadDeadline(time.Now().Add(s.config.RPCHandshakeTimeout))
+	}
+	buf := make([]byte, 1)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		s.rpcLogger().Error("failed to read compression algorithm",
+			"conn", logConn(conn),
+			"error", err,
+		)
+		conn.Close()
+		return
+	}
+	if s.config.RPCHandshakeTimeout > 0 {
+		conn.SetReadDeadline(time.Time{})
+	}
+
+	alg := compression.Algorithm(buf[0])
+	if !alg.Supported() {
+		alg = compression.None
+	}
+	if _, err := conn.Write([]byte{byte(alg)}); err != nil {
+		s.rpcLogger().Error("failed to accept compression algorithm",
+			"conn", logConn(conn),
+			"error", err,
+		)
+		conn.Close()
+		return
+	}
+
+	if alg != compression.None {
+		conn = compression.NewConn(conn, alg, s.config.RPCConfig.CompressionThreshold)
+	}
+	s.handleMultiplexV2(conn)
+}
+
 // handleMultiplexV2 is used to multiplex a single incoming connection
 // using the Yamux multiplexer
 func (s *Server) handleMultiplexV2(conn net.Conn) {
@@ -636,7 +691,7 @@ func canRetry(info structs.RPCInfo, err error, start time.Time, config *Config,
 // should handle the request.
 func (s *Server) ForwardRPC(method string, info structs.RPCInfo, reply interface{}) (bool, error) {
 	forwardToDC := func(dc string) error {
-		return s.forwardDC(method, dc, info, reply)
+		return s.forwardDCCached(method, dc, info, reply)
 	}
 	forwardToLeader := func(leader *metadata.Server) error {
 		return s.connPool.RPC(s.config.Datacenter, leader.ShortName, leader.Addr,
@@ -741,11 +796,60 @@ func (s *Server) forwardRequestToOtherDatacenter(info structs.RPCInfo, forwardTo
 	return false, nil
 }
 
+// boundedStaleReadApplyPoll is how often a follower checks whether its FSM
+// caught up before serving a bounded-stale read.
+const boundedStaleReadApplyPoll = 5 * time.Millisecond
+
+// boundedStaleRead is implemented by the requests which may bound the
+// staleness of the data a follower serves them from.
+type boundedStaleRead interface {
+	MaxStaleness() time.Duration
+}
+
 // canServeReadRequest determines if the request is a stale read request and
 // the current node can safely process that request.
 func (s *Server) canServeReadRequest(info structs.RPCInfo) bool {
 	// Check if we can allow a stale read, ensure our local DB is initialized
-	return info.IsRead() && info.AllowStaleRead() && !s.raft.LastContact().IsZero()
+	if !info.IsRead() || !info.AllowStaleRead() || s.raft.LastContact().IsZero() {
+		return false
+	}
+
+	if bounded, ok := info.(boundedStaleRead); ok {
+		if maxStaleness := bounded.MaxStaleness(); maxStaleness > 0 && !s.isFresherThan(maxStaleness) {
+			metrics.IncrCounter([]string{"rpc", "bounded-stale", "forwarded"}, 1)
+			return false
+		}
+	}
+	return true
+}
+
+// isFresherThan reports whether the local state is at most maxStaleness old.
+// That is the case when the server was in contact with the leader within
+// maxStaleness, and has applied the log entries it received as of that
+// contact. It waits for those entries to be applied while the bound allows.
+func (s *Server) isFresherThan(maxStaleness time.Duration) bool {
+	if s.IsLeader() {
+		return true
+	}
+
+	age := time.Since(s.raft.LastContact())
+	if age > maxStaleness {
+		return false
+	}
+
+	lastIndex := s.raft.LastIndex()
+	deadline := time.Now().Add(maxStaleness - age)
+	for s.raft.AppliedIndex() < lastIndex {
+		if time.Now().After(deadline) {
+			return false
+		}
+		select {
+		case <-time.After(boundedStaleReadApplyPoll):
+		case <-s.shutdownCh:
+			return false
+		}
+	}
+	return true
 }
 
 // forwardRequestToLeader is an implementation detail of forwardRPC.
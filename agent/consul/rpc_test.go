@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/raft"
+	"github.com/hashicorp/yamux"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
@@ -41,6 +42,7 @@ import (
 	tokenStore "github.com/hashicorp/consul/agent/token"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/proto/private/pbsubscribe"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
@@ -165,6 +167,48 @@ func TestRPC_NoLeader_Retry(t *testing.T) {
 	}
 }
 
+func TestRPC_canServeReadRequest_BoundedStale(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServer(t)
+	_, s2 := testServerDCBootstrap(t, "dc1", false)
+
+	joinLAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	bounded := func(maxStaleness time.Duration) *structs.DCSpecificRequest {
+		return &structs.DCSpecificRequest{
+			Datacenter: "dc1",
+			QueryOptions: structs.QueryOptions{
+				AllowStale:       true,
+				BoundedStaleness: maxStaleness,
+			},
+		}
+	}
+
+	// The follower serves the reads within the bound once it caught up with
+	// the leader.
+	retry.Run(t, func(r *retry.R) {
+		require.True(r, s2.canServeReadRequest(bounded(time.Minute)))
+	})
+
+	// The follower cannot have been in contact with the leader that recently,
+	// so it forwards the read to the leader.
+	require.False(t, s2.canServeReadRequest(bounded(time.Nanosecond)))
+
+	codec := rpcClient(t, s2)
+	var out structs.IndexedNodes
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Catalog.ListNodes", bounded(time.Nanosecond), &out))
+	require.True(t, out.KnownLeader)
+	require.Zero(t, out.LastContact)
+
+	// Unbounded stale reads are still served by the follower.
+	require.True(t, s2.canServeReadRequest(bounded(0)))
+}
+
 func TestRPC_getLeader_ErrLeaderNotTracked(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1656,3 +1700,84 @@ func rpcBlockingQueryTestHarness(
 
 	require.Equal(t, 1, count, "if this fails, then the timer likely needs to be increased above")
 }
+
+func TestRPC_CompressedMultiplexV2(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServer(t)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	cases := map[string]struct {
+		requested compression.Algorithm
+		accepted  compression.Algorithm
+	}{
+		"snappy":      {requested: compression.Snappy, accepted: compression.Snappy},
+		"zstd":        {requested: compression.Zstd, accepted: compression.Zstd},
+		"unsupported": {requested: compression.Algorithm(42), accepted: compression.None},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn, err := net.DialTimeout("tcp", s1.config.RPCAdvertise.String(), time.Second)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte{byte(pool.RPCMultiplexV2Compressed), byte(tc.requested)})
+			require.NoError(t, err)
+			accepted := make([]byte, 1)
+			_, err = io.ReadFull(conn, accepted)
+			require.NoError(t, err)
+			require.Equal(t, tc.accepted, compression.Algorithm(accepted[0]))
+
+			var muxConn net.Conn = conn
+			if tc.accepted != compression.None {
+				muxConn = compression.NewConn(conn, tc.accepted, 0)
+			}
+			session, err := yamux.Client(muxConn, nil)
+			require.NoError(t, err)
+			defer session.Close()
+			stream, err := session.Open()
+			require.NoError(t, err)
+
+			codec := msgpackrpc.NewCodecFromHandle(true, true, stream, structs.MsgpackHandle)
+			var out struct{}
+			require.NoError(t, msgpackrpc.CallWithCodec(codec, "Status.Ping", struct{}{}, &out))
+		})
+	}
+}
+
+func TestRPC_CompressedForwardToDatacenter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		c.RPCConfig.Compression = compression.Zstd
+		// Compress every write, however small.
+		c.RPCConfig.CompressionThreshold = 1
+	})
+	_, s2 := testServerDC(t, "dc2")
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	testrpc.WaitForLeader(t, s1.RPC, "dc2")
+
+	// Register enough nodes in dc2 for the response to span several writes.
+	for i := 0; i < 50; i++ {
+		req := structs.RegisterRequest{
+			Datacenter: "dc2",
+			Node:       fmt.Sprintf("node-%d", i),
+			Address:    fmt.Sprintf("10.0.0.%d", i),
+		}
+		var out struct{}
+		require.NoError(t, s1.RPC(context.Background(), "Catalog.Register", &req, &out))
+	}
+
+	args := structs.DCSpecificRequest{Datacenter: "dc2"}
+	var out structs.IndexedNodes
+	require.NoError(t, s1.RPC(context.Background(), "Catalog.ListNodes", &args, &out))
+	// The 50 registered nodes and the dc2 server itself.
+	require.Len(t, out.Nodes, 51)
+}
@@ -6,11 +6,14 @@
 package consul
 
 import (
+	"fmt"
 	"net"
+	"path/filepath"
 
 	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/serf/serf"
 
-	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/metadata"
 )
 
 var SegmentCESummaries = []prometheus.SummaryDefinition{
@@ -20,31 +23,143 @@ var SegmentCESummaries = []prometheus.SummaryDefinition{
 	},
 }
 
+// segmentSnapshotPath returns the path of the snapshot of the Serf cluster
+// of a network segment.
+func segmentSnapshotPath(name string) string {
+	return filepath.Join("serf", fmt.Sprintf("segment-%s.snapshot", name))
+}
+
+// segmentTagPrefix prefixes the tags servers use to advertise the address of
+// their Serf cluster in each network segment. See metadata.IsConsulServer.
+const segmentTagPrefix = "sl_"
+
 // LANSegmentAddr is used to return the address used for the given LAN segment.
 func (s *Server) LANSegmentAddr(name string) string {
-	return ""
+	segment, ok := s.segmentLAN[name]
+	if !ok {
+		return ""
+	}
+	m := segment.LocalMember()
+	return net.JoinHostPort(m.Addr.String(), fmt.Sprintf("%d", m.Port))
 }
 
-// setupSegmentRPC returns an error if any segments are defined since the CE
-// version of Consul doesn't support them.
+// setupSegmentRPC starts the extra RPC listeners of the segments that have
+// one.
 func (s *Server) setupSegmentRPC() (map[string]net.Listener, error) {
-	if len(s.config.Segments) > 0 {
-		return nil, structs.ErrSegmentsNotSupported
-	}
+	listeners := make(map[string]net.Listener)
+	for _, segment := range s.config.Segments {
+		if segment.RPCAddr == nil {
+			continue
+		}
 
-	return nil, nil
+		ln, err := net.ListenTCP("tcp", segment.RPCAddr)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, fmt.Errorf("failed to listen on the RPC address of segment %q: %w", segment.Name, err)
+		}
+		listeners[segment.Name] = ln
+		go func() {
+			<-s.shutdownCh
+			ln.Close()
+		}()
+	}
+	return listeners, nil
 }
 
-// setupSegments returns an error if any segments are defined since the CE
-// version of Consul doesn't support them.
+// setupSegments creates the Serf clusters of the network segments. Servers
+// are members of all segments, so that the clients of each segment can reach
+// them, while clients are members of a single segment.
 func (s *Server) setupSegments(config *Config, rpcListeners map[string]net.Listener) error {
-	if len(config.Segments) > 0 {
-		return structs.ErrSegmentsNotSupported
-	}
+	s.segmentLAN = make(map[string]*serf.Serf, len(config.Segments))
+	for _, segment := range config.Segments {
+		listener := s.Listener
+		if l, ok := rpcListeners[segment.Name]; ok {
+			listener = l
+		}
+
+		eventCh := make(chan serf.Event, serfEventChSize)
+		cluster, _, err := s.setupSerf(setupSerfOptions{
+			Config:       segment.SerfConfig,
+			EventCh:      eventCh,
+			SnapshotPath: segmentSnapshotPath(segment.Name),
+			Listener:     listener,
+			Segment:      segment.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start Serf for segment %q: %w", segment.Name, err)
+		}
+		s.segmentLAN[segment.Name] = cluster
+		go s.segmentEventHandler(eventCh)
 
+		// Advertise the address of the segment in the default segment so
+		// that the other servers can join it.
+		if config.SerfLANConfig.Tags == nil {
+			config.SerfLANConfig.Tags = make(map[string]string)
+		}
+		config.SerfLANConfig.Tags[segmentTagPrefix+segment.Name] = s.LANSegmentAddr(segment.Name)
+	}
 	return nil
 }
 
-// floodSegments is a NOP in the CE version of Consul.
+// segmentEventHandler reconciles the members of a segment. The servers are
+// members of the default segment, which already reconciles them, and user
+// events are delivered to the servers through the default segment.
+func (s *Server) segmentEventHandler(eventCh chan serf.Event) {
+	for {
+		select {
+		case e := <-eventCh:
+			me, ok := e.(serf.MemberEvent)
+			if !ok {
+				continue
+			}
+			var clients []serf.Member
+			for _, m := range me.Members {
+				if ok, _ := metadata.IsConsulServer(m); !ok {
+					clients = append(clients, m)
+				}
+			}
+			if len(clients) > 0 {
+				me.Members = clients
+				s.localMemberEvent(me)
+			}
+
+		case <-s.shutdownCh:
+			return
+		}
+	}
+}
+
+// floodSegments joins the servers of the default segment to all segments,
+// using the addresses they advertise for each segment.
 func (s *Server) floodSegments(config *Config) {
+	for name, segment := range s.segmentLAN {
+		name := name
+		addrFn := func(srv *metadata.Server) (string, error) {
+			addr, ok := srv.SegmentAddrs[name]
+			if !ok {
+				return "", fmt.Errorf("server not part of segment %q", name)
+			}
+			port := srv.SegmentPorts[name]
+			return net.JoinHostPort(addr, fmt.Sprintf("%d", port)), nil
+		}
+		go s.Flood(addrFn, segment)
+	}
+}
+
+// shutdownSegments shuts down the Serf clusters of the network segments.
+func (s *Server) shutdownSegments() {
+	for _, segment := range s.segmentLAN {
+		segment.Shutdown()
+	}
+}
+
+// leaveSegments leaves the Serf clusters of the network segments.
+func (s *Server) leaveSegments() {
+	for name, segment := range s.segmentLAN {
+		if err := segment.Leave(); err != nil {
+			s.logger.Error("failed to leave LAN Serf cluster of segment", "segment", name, "error", err)
+		}
+	}
 }
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !consulent
+
+package consul
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/freeport"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestServer_NetworkSegments(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	port := freeport.GetOne(t)
+	_, s1 := testServerWithConfig(t, func(c *Config) {
+		serfConf := DefaultConfig().SerfLANConfig
+		serfConf.MemberlistConfig.BindAddr = "127.0.0.1"
+		serfConf.MemberlistConfig.BindPort = port
+		serfConf.MemberlistConfig.AdvertiseAddr = "127.0.0.1"
+		serfConf.MemberlistConfig.AdvertisePort = port
+		serfConf.MemberlistConfig.SuspicionMult = 3
+		serfConf.MemberlistConfig.ProbeTimeout = 50 * time.Millisecond
+		serfConf.MemberlistConfig.ProbeInterval = 200 * time.Millisecond
+		serfConf.MemberlistConfig.GossipInterval = 100 * time.Millisecond
+
+		c.Segments = []NetworkSegment{{
+			Name:       "alpha",
+			Bind:       "127.0.0.1",
+			Advertise:  "127.0.0.1",
+			Port:       port,
+			SerfConfig: serfConf,
+		}}
+	})
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// The segment uses its own gossip settings.
+	segment := s1.segmentLAN["alpha"]
+	require.NotNil(t, segment)
+	require.Equal(t, 200*time.Millisecond, s1.config.Segments[0].SerfConfig.MemberlistConfig.ProbeInterval)
+	require.Equal(t, "alpha", segment.LocalMember().Tags["segment"])
+
+	// The server advertises the address of the segment in the default one.
+	require.Equal(t, s1.LANSegmentAddr("alpha"), s1.serfLAN.LocalMember().Tags[segmentTagPrefix+"alpha"])
+
+	_, c1 := testClientWithConfig(t, func(c *Config) {
+		c.Segment = "alpha"
+	})
+	joinLAN(t, c1, s1)
+
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, 1, c1.router.GetLANManager().NumServers())
+
+		// The client is only a member of its segment.
+		members, err := s1.LANMembers(LANMemberFilter{Segment: "alpha"})
+		require.NoError(r, err)
+		require.Len(r, members, 2)
+		require.Len(r, s1.LANMembersInAgentPartition(), 1)
+
+		members, err = s1.LANMembers(LANMemberFilter{AllSegments: true})
+		require.NoError(r, err)
+		require.Len(r, members, 2)
+	})
+
+	_, err := s1.LANMembers(LANMemberFilter{Segment: "beta"})
+	require.EqualError(t, err, `segment "beta" not found`)
+
+	// The client is registered in the catalog by the leader.
+	retry.Run(t, func(r *retry.R) {
+		_, node, err := s1.fsm.State().GetNode(c1.config.NodeName, nil, "")
+		require.NoError(r, err)
+		require.NotNil(r, node)
+	})
+}
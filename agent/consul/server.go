@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/go-connlimit"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/raft"
 	autopilot "github.com/hashicorp/raft-autopilot"
 	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
@@ -42,16 +43,20 @@ import (
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/consul/authmethod"
 	"github.com/hashicorp/consul/agent/consul/authmethod/ssoauth"
+	"github.com/hashicorp/consul/agent/consul/breakglass"
 	"github.com/hashicorp/consul/agent/consul/fsm"
+	"github.com/hashicorp/consul/agent/consul/meshmetrics"
 	"github.com/hashicorp/consul/agent/consul/multilimiter"
 	rpcRate "github.com/hashicorp/consul/agent/consul/rate"
 	"github.com/hashicorp/consul/agent/consul/reporting"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/consul/tokenusage"
 	"github.com/hashicorp/consul/agent/consul/usagemetrics"
 	"github.com/hashicorp/consul/agent/consul/wanfed"
 	"github.com/hashicorp/consul/agent/consul/xdscapacity"
 	"github.com/hashicorp/consul/agent/grpc-external/services/peerstream"
+	registrationgrpc "github.com/hashicorp/consul/agent/grpc-external/services/registration"
 	"github.com/hashicorp/consul/agent/hcp"
 	"github.com/hashicorp/consul/agent/hcp/bootstrap"
 	hcpclient "github.com/hashicorp/consul/agent/hcp/client"
@@ -78,6 +83,7 @@ import (
 	raftstorage "github.com/hashicorp/consul/internal/storage/raft"
 	"github.com/hashicorp/consul/internal/tenancy"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/lib/routine"
 	"github.com/hashicorp/consul/lib/stringslice"
 	"github.com/hashicorp/consul/logging"
@@ -159,20 +165,29 @@ const (
 	caRootPruningRoutineName              = "CA root pruning"
 	caRootMetricRoutineName               = "CA root expiration metric"
 	caSigningMetricRoutineName            = "CA signing expiration metric"
+	caLeafMetricRoutineName               = "CA leaf expiration metric"
 	configEntryControllersRoutineName     = "config entry controllers"
 	configReplicationRoutineName          = "config entry replication"
 	federationStateReplicationRoutineName = "federation state replication"
 	federationStateAntiEntropyRoutineName = "federation state anti-entropy"
 	federationStatePruningRoutineName     = "federation state pruning"
 	intentionMigrationRoutineName         = "intention config entry migration"
+	intentionExpiryRoutineName            = "intention expiry"
 	secondaryCARootWatchRoutineName       = "secondary CA roots watch"
 	intermediateCertRenewWatchRoutineName = "intermediate cert renew watch"
 	backgroundCAInitializationRoutineName = "CA initialization"
+	caRevocationListsRefreshRoutineName   = "CA revocation lists refresh"
 	virtualIPCheckRoutineName             = "virtual IP version check"
 	peeringStreamsRoutineName             = "streaming peering resources"
 	peeringDeletionRoutineName            = "peering deferred deletion"
 	peeringStreamsMetricsRoutineName      = "metrics for streaming peering resources"
 	raftLogVerifierRoutineName            = "raft log verifier"
+	registrationLeaseReapingRoutineName   = "registration lease reaping"
+	webhooksRoutineName                   = "webhooks"
+	acmeRoutineName                       = "acme"
+	raftArchiveRoutineName                = "raft archive"
+	kvReplicationRoutineName              = "kv replication"
+	catalogProviderRoutineName            = "catalog provider"
 )
 
 var (
@@ -207,6 +222,10 @@ type Server struct {
 	// aclConfig is the configuration for the ACL system
 	aclConfig *acl.Config
 
+	// kvEncryption encrypts KV values before they are written to Raft. It is
+	// nil if KV encryption is disabled.
+	kvEncryption *kvEncryption
+
 	// acls is used to resolve tokens to effective policies
 	*ACLResolver
 
@@ -257,6 +276,10 @@ type Server struct {
 	// serf cluster that spans datacenters
 	eventChWAN chan serf.Event
 
+	// memberEvents records the changes of the membership of the LAN gossip
+	// pool of the default segment.
+	memberEvents *memberEventLog
+
 	// wanMembershipNotifyCh is used to receive notifications that the
 	// serfWAN wan pool may have changed.
 	//
@@ -280,6 +303,10 @@ type Server struct {
 	raftTransport *raft.NetworkTransport
 	raftInmem     *raft.InmemStore
 
+	// raftLog is the log store given to Raft, it is read by the leader to
+	// archive the committed entries.
+	raftLog raft.LogStore
+
 	// raftNotifyCh is set up by setupRaft() and ensures that we get reliable leader
 	// transition notifications from the Raft layer.
 	raftNotifyCh <-chan bool
@@ -378,6 +405,10 @@ type Server struct {
 	//
 	serfLAN *serf.Serf
 
+	// segmentLAN maps the names of the network segments to their Serf
+	// clusters. Servers are members of all segments.
+	segmentLAN map[string]*serf.Serf
+
 	// serfWAN is the Serf cluster maintained between DC's
 	// which SHOULD only consist of Consul servers
 	serfWAN                *serf.Serf
@@ -402,6 +433,21 @@ type Server struct {
 	// destroy the session via standard session destroy processing
 	sessionTimers *SessionTimers
 
+	// filterCache holds the compiled bexpr filters of recent requests.
+	filterCache *filterCache
+
+	// forwardCache holds the responses of the stale reads recently forwarded
+	// to other datacenters. It is nil when the caching is disabled.
+	forwardCache *forwardCache
+
+	// admission calls the admission webhooks for the catalog and config
+	// entry writes. It is nil when there are none.
+	admission *admissionController
+
+	// intentionGraph caches intention match results and authorization
+	// decisions for the Intention endpoints.
+	intentionGraph *intentionGraph
+
 	// statsFetcher is used by autopilot to check the status of the other
 	// Consul router.
 	statsFetcher *StatsFetcher
@@ -423,6 +469,43 @@ type Server struct {
 	aclReplicationStatus     structs.ACLReplicationStatus
 	aclReplicationStatusLock sync.RWMutex
 
+	// aclReplicationTrackers record the outcome of the rounds of each ACL
+	// replication routine, to report their lag.
+	aclReplicationTrackers map[structs.ACLReplicationType]*replicationTracker
+
+	// catalogProviderStatus (and its associated lock) provide information
+	// about the last sync of each catalog provider.
+	catalogProviderStatus     map[string]*structs.CatalogProviderSyncStatus
+	catalogProviderStatusLock sync.RWMutex
+
+	// kvReplicationStatus (and its associated lock) provide information
+	// about the health of the KV replication of each prefix.
+	kvReplicationStatus     map[string]*structs.KVReplicationPrefixStatus
+	kvReplicationStatusLock sync.RWMutex
+
+	// checkUpdateBatcher coalesces the check updates of the agents into
+	// batched Raft writes. It is nil if check updates are not batched.
+	checkUpdateBatcher *checkUpdateBatcher
+
+	// meshMetrics holds the rollups of the upstream request metrics reported
+	// by the dataplanes. It is only populated on the leader, which the
+	// reports are forwarded to.
+	meshMetrics *meshmetrics.Store
+
+	// aclTokenUsage aggregates the usage of the tokens reported by the agents.
+	// It is only populated on the leader, which the reports are forwarded
+	// to, and is nil if the token usage tracking is disabled.
+	aclTokenUsage *tokenusage.Tracker
+
+	// aclBreakGlass collects the key shares submitted to activate the
+	// break-glass token. It is only populated on the leader, which the
+	// shares are forwarded to.
+	aclBreakGlass *breakglass.Unsealer
+
+	// aclBreakGlassLimiter limits the rate of the shares submitted to
+	// aclBreakGlass.
+	aclBreakGlassLimiter *rate.Limiter
+
 	// shutdown and the associated members here are used in orchestrating
 	// a clean shutdown. The shutdownCh is never written to, only closed to
 	// indicate a shutdown has been initiated.
@@ -454,6 +537,15 @@ type Server struct {
 	// peerStreamServer is a server used to handle peering streams from external clusters.
 	peerStreamServer *peerstream.Server
 
+	// leaseOwner identifies this server in the registrations whose lease it
+	// holds. It is generated on startup, so the registrations held by a
+	// previous run of the server are reaped by the leader.
+	leaseOwner string
+
+	// registrationServer handles the registrations made through the
+	// registration gRPC API, and holds their leases.
+	registrationServer *registrationgrpc.Server
+
 	// xdsCapacityController controls the number of concurrent xDS streams the
 	// server is able to handle.
 	xdsCapacityController *xdscapacity.Controller
@@ -474,6 +566,17 @@ type Server struct {
 	// handles metrics reporting to HashiCorp
 	reportingManager *reporting.ReportingManager
 
+	// raftSnapshotLimiter limits the rate at which the FSM snapshots are
+	// written and restored.
+	raftSnapshotLimiter *rate.Limiter
+
+	// raftSnapshotLock serializes the reloads of the Raft config, and
+	// protects raftSnapshotConfig and raftSnapshotThreshold, the snapshot
+	// threshold used within the snapshot window.
+	raftSnapshotLock      sync.Mutex
+	raftSnapshotConfig    RaftSnapshotConfig
+	raftSnapshotThreshold uint64
+
 	registry resource.Registry
 
 	useV2Resources bool
@@ -547,6 +650,11 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		incomingRPCLimiter = rpcRate.NullRequestLimitsHandler()
 	}
 
+	leaseOwner, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lease owner ID: %w", err)
+	}
+
 	// Create server.
 	s := &Server{
 		config:                  config,
@@ -555,6 +663,7 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		grpcConnPool:            flat.GRPCConnPool,
 		eventChLAN:              make(chan serf.Event, serfEventChSize),
 		eventChWAN:              make(chan serf.Event, serfEventChSize),
+		memberEvents:            newMemberEventLog(config.SerfLANConfig.TombstoneTimeout),
 		logger:                  serverLogger,
 		loggers:                 loggers,
 		leaveCh:                 make(chan struct{}),
@@ -564,6 +673,10 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		externalGRPCServer:      externalGRPCServer,
 		reassertLeaderCh:        make(chan chan error),
 		sessionTimers:           NewSessionTimers(),
+		intentionGraph:          newIntentionGraph(),
+		filterCache:             newFilterCache(),
+		forwardCache:            newForwardCache(config.RPCConfig.CrossDCCacheSize, config.RPCConfig.CrossDCCacheTTL),
+		admission:               newAdmissionController(config.Datacenter, config.AdmissionWebhooks, loggers.Named(logging.Admission)),
 		tombstoneGC:             gc,
 		serverLookup:            NewServerLookup(),
 		shutdownCh:              shutdownCh,
@@ -576,9 +689,22 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		useV2Resources:          flat.UseV2Resources(),
 		useV2Tenancy:            flat.UseV2Tenancy(),
 		hcpAllowV2Resources:     flat.HCPAllowV2Resources(),
+		leaseOwner:              leaseOwner,
+		meshMetrics:             meshmetrics.NewStore(meshmetrics.DefaultWindow),
+		aclBreakGlass:           breakglass.NewUnsealer(aclBreakGlassUnsealTimeout),
+		aclBreakGlassLimiter:    rate.NewLimiter(aclBreakGlassUnsealRateLimit, aclBreakGlassUnsealBurst),
+		aclReplicationTrackers: map[structs.ACLReplicationType]*replicationTracker{
+			structs.ACLReplicatePolicies: {},
+			structs.ACLReplicateRoles:    {},
+			structs.ACLReplicateTokens:   {},
+		},
 	}
 	incomingRPCLimiter.Register(s)
 
+	if config.ACLTokenUsageTracking {
+		s.aclTokenUsage = tokenusage.NewTracker(config.ACLTokenUsageMaxTokens)
+	}
+
 	s.raftStorageBackend, err = raftstorage.NewBackend(&raftHandle{s}, logger.Named("raft-storage-backend"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage backend: %w", err)
@@ -587,13 +713,25 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 
 	s.storageBackend = s.raftStorageBackend
 
+	// The gRPC compressors are registered globally, so the threshold of the
+	// compressed peering messages is too.
+	compression.SetGRPCThreshold(config.RPCConfig.CompressionThreshold)
+
+	s.raftSnapshotLimiter = fsm.NewSnapshotLimiter()
+	fsm.SetSnapshotIOLimit(s.raftSnapshotLimiter, config.RaftSnapshot.IOLimit)
+	s.raftSnapshotConfig = config.RaftSnapshot
+	s.raftSnapshotThreshold = config.RaftConfig.SnapshotThreshold
+
 	s.fsm = fsm.NewFromDeps(fsm.Deps{
 		Logger: flat.Logger,
 		NewStateStore: func() *state.Store {
-			return state.NewStateStoreWithEventPublisher(gc, flat.EventPublisher)
+			store := state.NewStateStoreWithMetaIndexes(gc, flat.EventPublisher, config.MetaIndexes)
+			store.SetConfigEntryOpener(s.openStoredConfigEntry)
+			return store
 		},
-		Publisher:      flat.EventPublisher,
-		StorageBackend: s.raftStorageBackend,
+		Publisher:       flat.EventPublisher,
+		StorageBackend:  s.raftStorageBackend,
+		SnapshotLimiter: s.raftSnapshotLimiter,
 	})
 
 	if s.config.Cloud.IsConfigured() {
@@ -653,6 +791,10 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		rpcServerOpts = append(rpcServerOpts, rpc.WithServerServiceCallInterceptor(flat.GetNetRPCInterceptorFunc(recorder)))
 	}
 
+	if config.CheckUpdateBatchInterval > 0 {
+		s.checkUpdateBatcher = newCheckUpdateBatcher(config.CheckUpdateBatchInterval, config.CheckUpdateBatchSize, s.raftApply)
+	}
+
 	s.rpcServer = rpc.NewServerWithOpts(rpcServerOpts...)
 	s.insecureRPCServer = rpc.NewServerWithOpts(rpcServerOpts...)
 
@@ -734,6 +876,12 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		return nil, fmt.Errorf("Failed to create ACL resolver: %v", err)
 	}
 
+	// Initialize the encryption of KV values.
+	if s.kvEncryption, err = newKVEncryption(config.KVEncryption); err != nil {
+		s.Shutdown()
+		return nil, fmt.Errorf("Failed to set up KV encryption: %v", err)
+	}
+
 	// Initialize the RPC layer.
 	if err := s.setupRPC(); err != nil {
 		s.Shutdown()
@@ -752,6 +900,7 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		s.Shutdown()
 		return nil, fmt.Errorf("Failed to start Raft: %v", err)
 	}
+	go s.runRaftSnapshotWindow(&lib.StopChannelContext{StopCh: s.shutdownCh})
 
 	s.caManager = NewCAManager(&caDelegateWithState{Server: s}, s.leaderRoutineManager, s.logger.ResetNamed("connect.ca"), s.config)
 	if s.config.ConnectEnabled && (s.config.AutoEncryptAllowTLS || s.config.AutoConfigAuthzEnabled) {
@@ -937,6 +1086,8 @@ func NewServer(config *Config, flat Deps, externalGRPCServer *grpc.Server,
 		Logger:         s.logger.Named(logging.XDSCapacityController),
 		GetStore:       func() xdscapacity.Store { return s.fsm.State() },
 		SessionLimiter: flat.XDSStreamLimiter,
+
+		FetchServerSessions: s.fetchServerXDSSessions,
 	})
 	go s.xdsCapacityController.Run(&lib.StopChannelContext{StopCh: s.shutdownCh})
 
@@ -1346,6 +1497,7 @@ func (s *Server) setupRaft(isCatalogResourceExperiment bool) error {
 
 	// Setup the Raft store.
 	var err error
+	s.raftLog = log
 	s.raft, err = raft.NewRaft(s.config.RaftConfig, s.fsm.ChunkingFSM(), log, stable, snap, trans)
 	return err
 }
@@ -1451,6 +1603,10 @@ func (s *Server) Shutdown() error {
 		s.leaderRoutineManager.StopAll()
 	}
 
+	if s.registrationServer != nil {
+		s.registrationServer.Shutdown()
+	}
+
 	s.shutdownSerfLAN()
 
 	if s.serfWAN != nil {
@@ -1688,6 +1844,13 @@ func (s *Server) LANMembersInAgentPartition() []serf.Member {
 	return s.serfLAN.Members()
 }
 
+// MemberEvents returns the changes of the membership of the agent's canonical
+// serf pool observed after index, the index of the last change and a channel
+// closed on the next change.
+func (s *Server) MemberEvents(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{}) {
+	return s.memberEvents.since(index)
+}
+
 // WANMembers is used to return the members of the WAN cluster
 func (s *Server) WANMembers() []serf.Member {
 	if s.serfWAN == nil {
@@ -1982,10 +2145,10 @@ func (s *Server) agentSegmentName() string {
 func (s *Server) ReloadConfig(config ReloadableConfig) error {
 	// Reload raft config first before updating any other state since it could
 	// error if the new config is invalid.
-	raftCfg := computeRaftReloadableConfig(config)
-	if err := s.raft.ReloadConfig(raftCfg); err != nil {
+	if err := s.reloadRaftConfig(config); err != nil {
 		return err
 	}
+	fsm.SetSnapshotIOLimit(s.raftSnapshotLimiter, config.RaftSnapshot.IOLimit)
 
 	s.updateReportingConfig(config)
 
@@ -2009,6 +2172,23 @@ func (s *Server) ReloadConfig(config ReloadableConfig) error {
 	return nil
 }
 
+// reloadRaftConfig reloads the Raft config, using the peak snapshot threshold
+// when outside of the snapshot window.
+func (s *Server) reloadRaftConfig(config ReloadableConfig) error {
+	s.raftSnapshotLock.Lock()
+	defer s.raftSnapshotLock.Unlock()
+
+	raftCfg := computeRaftReloadableConfig(config)
+	threshold := raftCfg.SnapshotThreshold
+	raftCfg.SnapshotThreshold = config.RaftSnapshot.snapshotThreshold(threshold, time.Now())
+	if err := s.raft.ReloadConfig(raftCfg); err != nil {
+		return err
+	}
+	s.raftSnapshotConfig = config.RaftSnapshot
+	s.raftSnapshotThreshold = threshold
+	return nil
+}
+
 // computeRaftReloadableConfig works out the correct reloadable config for raft.
 // We reload raft even if nothing has changed since it's cheap and simpler than
 // trying to work out if it's different from the current raft config. This
@@ -7,6 +7,7 @@ package consul
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -69,6 +70,14 @@ func (s *Server) removeFailedNode(
 		} else if found {
 			foundAny = true
 		}
+
+		for name, segment := range s.segmentLAN {
+			if found, err := maybeRemove(segment, node); err != nil {
+				merr = multierror.Append(merr, fmt.Errorf("could not remove failed node from LAN segment %q: %w", name, err))
+			} else if found {
+				foundAny = true
+			}
+		}
 	}
 
 	if s.serfWAN != nil {
@@ -90,10 +99,45 @@ func (s *Server) removeFailedNode(
 	return nil
 }
 
-// lanPoolAllMembers only returns our own segment or partition's members, because
-// CE servers can't be in multiple segments or partitions.
+// lanPoolAllMembers returns the members of the default segment and of all
+// network segments, because CE servers can't be in multiple partitions.
 func (s *Server) lanPoolAllMembers() ([]serf.Member, error) {
-	return s.LANMembersInAgentPartition(), nil
+	return s.lanMembersAllSegments(), nil
+}
+
+// lanMembersAllSegments returns the members of the default segment and of all
+// network segments. Servers are members of all segments, so only their member
+// in the default segment is returned.
+func (s *Server) lanMembersAllSegments() []serf.Member {
+	members := s.serfLAN.Members()
+	if len(s.segmentLAN) == 0 {
+		return members
+	}
+
+	seen := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		seen[strings.ToLower(m.Name)] = struct{}{}
+	}
+	for _, name := range s.segmentNames() {
+		for _, m := range s.segmentLAN[name].Members() {
+			if _, ok := seen[strings.ToLower(m.Name)]; ok {
+				continue
+			}
+			seen[strings.ToLower(m.Name)] = struct{}{}
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+// segmentNames returns the names of the network segments in a stable order.
+func (s *Server) segmentNames() []string {
+	names := make([]string, 0, len(s.segmentLAN))
+	for name := range s.segmentLAN {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // LANMembers returns the LAN members for one of:
@@ -107,17 +151,38 @@ func (s *Server) LANMembers(filter LANMemberFilter) ([]serf.Member, error) {
 	if err := filter.Validate(); err != nil {
 		return nil, err
 	}
+	if filter.AllSegments {
+		return s.lanMembersAllSegments(), nil
+	}
 	if filter.Segment != "" {
-		return nil, structs.ErrSegmentsNotSupported
+		segment, ok := s.segmentLAN[filter.Segment]
+		if !ok {
+			return nil, fmt.Errorf("segment %q not found", filter.Segment)
+		}
+		return segment.Members(), nil
 	}
 	return s.LANMembersInAgentPartition(), nil
 }
 
-func (s *Server) GetMatchingLANCoordinate(_, _ string) (*coordinate.Coordinate, error) {
-	return s.serfLAN.GetCoordinate()
+func (s *Server) GetMatchingLANCoordinate(_, segment string) (*coordinate.Coordinate, error) {
+	if segment == "" {
+		return s.serfLAN.GetCoordinate()
+	}
+	pool, ok := s.segmentLAN[segment]
+	if !ok {
+		return nil, fmt.Errorf("segment %q not found", segment)
+	}
+	return pool.GetCoordinate()
 }
 
 func (s *Server) addEnterpriseLANCoordinates(cs librtt.CoordinateSet) error {
+	for name, segment := range s.segmentLAN {
+		c, err := segment.GetCoordinate()
+		if err != nil {
+			return err
+		}
+		cs[name] = c
+	}
 	return nil
 }
 
@@ -126,6 +191,14 @@ func (s *Server) LANSendUserEvent(name string, payload []byte, coalesce bool) er
 	if err != nil {
 		return fmt.Errorf("error broadcasting event: %w", err)
 	}
+
+	// The servers receive the event in the default segment, so the segments
+	// only need it for their clients.
+	for segmentName, segment := range s.segmentLAN {
+		if err := segment.UserEvent(name, payload, coalesce); err != nil {
+			return fmt.Errorf("error broadcasting event to segment %q: %w", segmentName, err)
+		}
+	}
 	return nil
 }
 
@@ -140,6 +213,13 @@ func (s *Server) DoWithLANSerfs(
 	if err != nil {
 		return errorFn("", "", err)
 	}
+	for _, name := range s.segmentNames() {
+		if err := fn(name, PoolKindSegment, s.segmentLAN[name]); err != nil {
+			if err := errorFn(name, PoolKindSegment, err); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -150,7 +230,7 @@ func (s *Server) DoWithLANSerfs(
 func (s *Server) reconcile() (err error) {
 	defer metrics.MeasureSince([]string{"leader", "reconcile"}, time.Now())
 
-	members := s.serfLAN.Members()
+	members := s.lanMembersAllSegments()
 	knownMembers := make(map[string]struct{})
 	for _, member := range members {
 		memberName := strings.ToLower(member.Name)
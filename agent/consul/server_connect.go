@@ -4,6 +4,7 @@
 package consul
 
 import (
+	"encoding/pem"
 	"fmt"
 
 	"github.com/hashicorp/go-memdb"
@@ -25,6 +26,22 @@ func (s *Server) getCARoots(ws memdb.WatchSet, state *state.Store) (*structs.Ind
 		return nil, err
 	}
 
+	revokedIndex, revoked, err := state.CARevokedCerts(ws)
+	if err != nil {
+		return nil, err
+	}
+	if revokedIndex > index {
+		index = revokedIndex
+	}
+
+	listsIndex, lists, err := state.CARevocationLists(ws)
+	if err != nil {
+		return nil, err
+	}
+	if listsIndex > index {
+		index = listsIndex
+	}
+
 	indexedRoots := &structs.IndexedCARoots{}
 
 	indexedRoots.TrustDomain = trustDomain
@@ -33,6 +50,14 @@ func (s *Server) getCARoots(ws memdb.WatchSet, state *state.Store) (*structs.Ind
 	if indexedRoots.Roots == nil {
 		indexedRoots.Roots = make(structs.CARoots, 0)
 	}
+	for _, r := range revoked {
+		indexedRoots.RevokedSerialNumbers = append(indexedRoots.RevokedSerialNumbers, r.SerialNumber)
+	}
+	if lists != nil {
+		for _, l := range lists.Lists {
+			indexedRoots.RevocationList += string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: l.CRL}))
+		}
+	}
 
 	// The response should not contain all fields as there are sensitive
 	// data such as key material stored within the struct. So here we
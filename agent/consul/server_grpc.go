@@ -19,7 +19,10 @@ import (
 	"github.com/hashicorp/consul/agent/grpc-external/services/configentry"
 	"github.com/hashicorp/consul/agent/grpc-external/services/connectca"
 	"github.com/hashicorp/consul/agent/grpc-external/services/dataplane"
+	healthgrpc "github.com/hashicorp/consul/agent/grpc-external/services/health"
+	kvgrpc "github.com/hashicorp/consul/agent/grpc-external/services/kv"
 	"github.com/hashicorp/consul/agent/grpc-external/services/peerstream"
+	registrationgrpc "github.com/hashicorp/consul/agent/grpc-external/services/registration"
 	resourcegrpc "github.com/hashicorp/consul/agent/grpc-external/services/resource"
 	"github.com/hashicorp/consul/agent/grpc-external/services/serverdiscovery"
 	agentgrpc "github.com/hashicorp/consul/agent/grpc-internal"
@@ -333,6 +336,38 @@ func (s *Server) setupGRPCServices(config *Config, deps Deps) error {
 		return err
 	}
 
+	// register the KV service on the "secure" in-process channel and the
+	// external gRPC server. Requests are translated into KVS/Txn RPCs which
+	// handle any leader or datacenter forwarding themselves, so there is no
+	// need to register it on the internal/multiplexed interface.
+	err = s.registerKVServer(
+		s.secureSafeGRPCChan,
+		s.externalGRPCServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// register the health service on the same interfaces as the KV service,
+	// for the same reasons.
+	err = s.registerHealthServer(
+		s.secureSafeGRPCChan,
+		s.externalGRPCServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// register the registration service on the external gRPC server only,
+	// since the leases it hands out are held by the stream's server and
+	// in-process callers have no use for them.
+	err = s.registerRegistrationServer(
+		s.externalGRPCServer,
+	)
+	if err != nil {
+		return err
+	}
+
 	// enable grpc server reflection for the external gRPC interface only
 	reflection.Register(s.externalGRPCServer)
 
@@ -518,6 +553,10 @@ func (s *Server) registerDataplaneServer(deps Deps, registrars ...grpc.ServiceRe
 		Datacenter:        s.config.Datacenter,
 		EnableV2:          stringslice.Contains(deps.Experiments, CatalogResourceExperimentName),
 		ResourceAPIClient: pbresource.NewResourceServiceClient(s.insecureSafeGRPCChan),
+		ForwardRPC: func(info structs.RPCInfo, fn func(*grpc.ClientConn) error) (bool, error) {
+			return s.ForwardGRPC(s.grpcConnPool, info, fn)
+		},
+		MeshMetrics: s.meshMetrics,
 	})
 
 	for _, reg := range registrars {
@@ -541,6 +580,48 @@ func (s *Server) registerServerDiscoveryServer(resolver serverdiscovery.ACLResol
 	return nil
 }
 
+func (s *Server) registerKVServer(registrars ...grpc.ServiceRegistrar) error {
+	srv := kvgrpc.NewServer(kvgrpc.Config{
+		Backend:      s,
+		Logger:       s.loggers.Named(logging.GRPCAPI).Named(logging.KV),
+		MaxValueSize: s.config.KVMaxValueSize,
+		MaxTxnLen:    s.config.TxnMaxReqLen,
+	})
+
+	for _, reg := range registrars {
+		srv.Register(reg)
+	}
+
+	return nil
+}
+
+func (s *Server) registerHealthServer(registrars ...grpc.ServiceRegistrar) error {
+	srv := healthgrpc.NewServer(healthgrpc.Config{
+		Backend: s,
+		Logger:  s.loggers.Named(logging.GRPCAPI).Named(logging.Health),
+	})
+
+	for _, reg := range registrars {
+		srv.Register(reg)
+	}
+
+	return nil
+}
+
+func (s *Server) registerRegistrationServer(registrars ...grpc.ServiceRegistrar) error {
+	s.registrationServer = registrationgrpc.NewServer(registrationgrpc.Config{
+		Backend:    s,
+		Logger:     s.loggers.Named(logging.GRPCAPI).Named(logging.Registration),
+		LeaseOwner: s.leaseOwner,
+	})
+
+	for _, reg := range registrars {
+		s.registrationServer.Register(reg)
+	}
+
+	return nil
+}
+
 func (s *Server) registerConfigEntryServer(registrars ...grpc.ServiceRegistrar) error {
 
 	srv := configentry.NewServer(configentry.Config{
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/consul/agent/metadata"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/internal/gossip/libserf"
+	"github.com/hashicorp/consul/internal/gossip/tcptransport"
 	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/types"
@@ -107,6 +108,7 @@ func (s *Server) setupSerfConfig(opts setupSerfOptions) (*serf.Config, error) {
 	conf.Tags["vsn_max"] = fmt.Sprintf("%d", ProtocolVersionMax)
 	conf.Tags["raft_vsn"] = fmt.Sprintf("%d", s.config.RaftConfig.ProtocolVersion)
 	conf.Tags["build"] = s.config.Build
+	conf.Tags[leaseOwnerTag] = s.leaseOwner
 	addr := opts.Listener.Addr().(*net.TCPAddr)
 	conf.Tags["port"] = fmt.Sprintf("%d", addr.Port)
 	if s.config.GRPCPort > 0 {
@@ -145,6 +147,9 @@ func (s *Server) setupSerfConfig(opts setupSerfOptions) (*serf.Config, error) {
 	// feature flag: advertise support for service-intentions
 	conf.Tags["ft_si"] = "1"
 
+	// feature flags: advertise the optional features enabled on this server
+	metadata.AddFeatureFlags(conf.Tags, s.enabledFeatureFlags()...)
+
 	var subLoggerName string
 	if opts.WAN {
 		subLoggerName = logging.WAN
@@ -195,10 +200,16 @@ func (s *Server) setupSerfConfig(opts setupSerfOptions) (*serf.Config, error) {
 			return nil, err
 		}
 
+		var transport wanfed.IngestionAwareTransport = nt
+		if s.config.SerfWANTCPOnly {
+			tcptransport.ConfigureTiming(conf.MemberlistConfig)
+			transport = tcptransport.New(nt, conf.MemberlistConfig.Logger)
+		}
+
 		if s.config.ConnectMeshGatewayWANFederationEnabled {
 			mgwTransport, err := wanfed.NewTransport(
 				s.tlsConfigurator,
-				nt,
+				transport,
 				s.config.Datacenter,
 				s.gatewayLocator.PickGateway,
 			)
@@ -208,7 +219,7 @@ func (s *Server) setupSerfConfig(opts setupSerfOptions) (*serf.Config, error) {
 
 			conf.MemberlistConfig.Transport = mgwTransport
 		} else {
-			conf.MemberlistConfig.Transport = nt
+			conf.MemberlistConfig.Transport = transport
 		}
 	}
 
@@ -242,6 +253,14 @@ func (s *Server) setupSerfConfig(opts setupSerfOptions) (*serf.Config, error) {
 
 	addSerfMetricsLabels(conf, opts.WAN, opts.Segment, s.config.AgentEnterpriseMeta().PartitionOrDefault(), "")
 
+	if !opts.WAN && s.config.SerfLANTCPOnly {
+		transport, err := tcptransport.NewNetTransport(conf.MemberlistConfig, conf.MetricLabels)
+		if err != nil {
+			return nil, err
+		}
+		conf.MemberlistConfig.Transport = transport
+	}
+
 	addEnterpriseSerfTags(conf.Tags, s.config.AgentEnterpriseMeta())
 
 	if s.config.OverrideInitialSerfTags != nil {
@@ -275,10 +294,12 @@ func (s *Server) lanEventHandler() {
 			case serf.EventMemberJoin:
 				s.lanNodeJoin(e.(serf.MemberEvent))
 				s.localMemberEvent(e.(serf.MemberEvent))
+				s.memberEvents.record(s.serfLAN, e.(serf.MemberEvent), time.Now())
 
 			case serf.EventMemberLeave, serf.EventMemberFailed, serf.EventMemberReap:
 				s.lanNodeFailed(e.(serf.MemberEvent))
 				s.localMemberEvent(e.(serf.MemberEvent))
+				s.memberEvents.record(s.serfLAN, e.(serf.MemberEvent), time.Now())
 
 			case serf.EventUser:
 				s.localEvent(e.(serf.UserEvent))
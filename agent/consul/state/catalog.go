@@ -603,32 +603,9 @@ func (s *Store) NodesByMeta(ws memdb.WatchSet, filters map[string]string, entMet
 		return idx, nil, nil // NodesByMeta is never called with an empty map, but just in case make it return no results.
 	}
 
-	// Retrieve all of the nodes. We'll do a lookup of just ONE KV pair, which
-	// over-matches if multiple pairs are requested, but then in the loop below
-	// we'll finish filtering.
-	var firstKey, firstValue string
-	for firstKey, firstValue = range filters {
-		break
-	}
-
-	nodes, err := tx.Get(tableNodes, indexMeta, KeyValueQuery{
-		Key:            firstKey,
-		Value:          firstValue,
-		EnterpriseMeta: *entMeta,
-		PeerName:       peerName,
-	})
+	results, err := s.nodesByMetaTxn(tx, ws, filters, entMeta, peerName)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed nodes lookup: %s", err)
-	}
-	ws.Add(nodes.WatchCh())
-
-	// Create and return the nodes list.
-	var results structs.Nodes
-	for node := nodes.Next(); node != nil; node = nodes.Next() {
-		n := node.(*structs.Node)
-		if len(filters) <= 1 || structs.SatisfiesMetaFilters(n.Meta, filters) {
-			results = append(results, n)
-		}
+		return 0, nil, err
 	}
 	return idx, results, nil
 }
@@ -1304,24 +1281,10 @@ func (s *Store) ServicesByNodeMeta(ws memdb.WatchSet, filters map[string]string,
 		return idx, nil, nil // ServicesByNodeMeta is never called with an empty map, but just in case make it return no results.
 	}
 
-	// Retrieve all of the nodes. We'll do a lookup of just ONE KV pair, which
-	// over-matches if multiple pairs are requested, but then in the loop below
-	// we'll finish filtering.
-	var firstKey, firstValue string
-	for firstKey, firstValue = range filters {
-		break
-	}
-
-	nodes, err := tx.Get(tableNodes, indexMeta, KeyValueQuery{
-		Key:            firstKey,
-		Value:          firstValue,
-		EnterpriseMeta: *entMeta,
-		PeerName:       peerName,
-	})
+	nodes, err := s.nodesByMetaTxn(tx, ws, filters, entMeta, peerName)
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed nodes lookup: %s", err)
+		return 0, nil, err
 	}
-	ws.Add(nodes.WatchCh())
 
 	// We don't want to track an unlimited number of services, so we pull a
 	// top-level watch to use as a fallback.
@@ -1332,12 +1295,7 @@ func (s *Store) ServicesByNodeMeta(ws memdb.WatchSet, filters map[string]string,
 	allServicesCh := allServices.WatchCh()
 
 	var result structs.ServiceNodes
-	for node := nodes.Next(); node != nil; node = nodes.Next() {
-		n := node.(*structs.Node)
-		if len(filters) > 1 && !structs.SatisfiesMetaFilters(n.Meta, filters) {
-			continue
-		}
-
+	for _, n := range nodes {
 		// List all the services on the node
 		services, err := catalogServiceListByNode(tx, n.Node, entMeta, n.PeerName, false)
 		if err != nil {
@@ -1352,6 +1310,39 @@ func (s *Store) ServicesByNodeMeta(ws memdb.WatchSet, filters map[string]string,
 	return idx, result, nil
 }
 
+// ServicesByServiceMeta returns all services with service metadata matching
+// every key/value pair in filters. When joinServiceNodes is true the node
+// information is filled in as it is for Services.
+func (s *Store) ServicesByServiceMeta(ws memdb.WatchSet, filters map[string]string, entMeta *acl.EnterpriseMeta, peerName string, joinServiceNodes bool) (uint64, structs.ServiceNodes, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	// TODO: accept non-pointer value
+	if entMeta == nil {
+		entMeta = structs.NodeEnterpriseMetaInDefaultPartition()
+	}
+
+	// Get the table index.
+	idx := catalogServicesMaxIndex(tx, entMeta, peerName)
+
+	if len(filters) == 0 {
+		return idx, nil, nil
+	}
+
+	result, err := s.servicesByServiceMetaTxn(tx, ws, filters, entMeta, peerName)
+	if err != nil {
+		return 0, nil, err
+	}
+	if joinServiceNodes {
+		parsedResult, err := parseServiceNodes(tx, ws, result, entMeta, peerName)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed querying and parsing services :%s", err)
+		}
+		return idx, parsedResult, nil
+	}
+	return idx, result, nil
+}
+
 // maxIndexForService return the maximum Raft Index for a service
 // If the index is not set for the service, it will return the missing
 // service index.
@@ -1466,9 +1457,16 @@ func serviceNodesTxn(tx ReadTxn, ws memdb.WatchSet, index string, q Query) (uint
 	}
 	ws.Add(services.WatchCh())
 
-	var results structs.ServiceNodes
+	// The instances are collected in a pooled buffer, the results are built
+	// from them by parseServiceNodes.
+	buf := getServiceNodesBuffer()
+	matches := *buf
+	defer func() {
+		*buf = matches
+		putServiceNodesBuffer(buf)
+	}()
 	for service := services.Next(); service != nil; service = services.Next() {
-		results = append(results, service.(*structs.ServiceNode))
+		matches = append(matches, service.(*structs.ServiceNode))
 	}
 
 	// If we are querying for Connect nodes, the associated proxy might be a gateway.
@@ -1496,12 +1494,12 @@ func serviceNodesTxn(tx ReadTxn, ws memdb.WatchSet, index string, q Query) (uint
 		}
 
 		for i := 0; i < len(nodes); i++ {
-			results = append(results, nodes[i])
+			matches = append(matches, nodes[i])
 		}
 	}
 
 	// Fill in the node details.
-	results, err = parseServiceNodes(tx, ws, results, &q.EnterpriseMeta, q.PeerName)
+	results, err := parseServiceNodes(tx, ws, matches, &q.EnterpriseMeta, q.PeerName)
 	if err != nil {
 		return 0, nil, fmt.Errorf("failed parsing service nodes: %s", err)
 	}
@@ -1646,8 +1644,18 @@ func parseServiceNodes(tx ReadTxn, ws memdb.WatchSet, services structs.ServiceNo
 	}
 	allNodesCh := allNodes.WatchCh()
 
+	// Large services usually run several instances per node, so each node is
+	// only looked up once.
+	var nodeCache map[nodeCacheKey]nodeCacheEntry
+	if len(services) > 1 {
+		nodeCache = make(map[nodeCacheKey]nodeCacheEntry)
+	}
+
 	// Fill in the node data for each service instance.
 	var results structs.ServiceNodes
+	if len(services) > 0 {
+		results = make(structs.ServiceNodes, 0, len(services))
+	}
 	for _, sn := range services {
 		// Note that we have to clone here because we don't want to
 		// modify the node-related fields on the object in the database,
@@ -1655,20 +1663,28 @@ func parseServiceNodes(tx ReadTxn, ws memdb.WatchSet, services structs.ServiceNo
 		s := sn.PartialClone()
 
 		// Grab the corresponding node record.
-		watchCh, n, err := tx.FirstWatch(tableNodes, indexID, Query{
-			Value:          sn.Node,
-			EnterpriseMeta: sn.EnterpriseMeta,
-			PeerName:       sn.PeerName,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed node lookup: %s", err)
+		key := newNodeCacheKey(sn)
+		cached, ok := nodeCache[key]
+		if !ok {
+			watchCh, n, err := tx.FirstWatch(tableNodes, indexID, Query{
+				Value:          sn.Node,
+				EnterpriseMeta: sn.EnterpriseMeta,
+				PeerName:       sn.PeerName,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed node lookup: %s", err)
+			}
+			ws.AddWithLimit(watchLimit, watchCh, allNodesCh)
+			cached.node = n.(*structs.Node)
+			if nodeCache != nil {
+				nodeCache[key] = cached
+			}
 		}
-		ws.AddWithLimit(watchLimit, watchCh, allNodesCh)
 
 		// Populate the node-related fields. The tagged addresses may be
 		// used by agents to perform address translation if they are
 		// configured to do that.
-		node := n.(*structs.Node)
+		node := cached.node
 		s.ID = node.ID
 		s.Address = node.Address
 		s.Datacenter = node.Datacenter
@@ -2867,8 +2883,14 @@ func checkServiceNodesTxn(tx ReadTxn, ws memdb.WatchSet, serviceName string, con
 	// Note we decide if we want to watch this iterator or not down below. We need
 	// to see if it returned anything first.
 
-	// Return the results.
-	var results structs.ServiceNodes
+	// The instances are collected in a pooled buffer, the results are built
+	// from them by parseCheckServiceNodes.
+	buf := getServiceNodesBuffer()
+	results := *buf
+	defer func() {
+		*buf = results
+		putServiceNodesBuffer(buf)
+	}()
 
 	// For connect queries we need a list of any proxy service names in the result
 	// set. Rather than have different code path for connect and non-connect, we
@@ -3229,57 +3251,86 @@ func parseCheckServiceNodes(
 	}
 	allChecksCh := allChecks.WatchCh()
 
+	// Large services usually run several instances per node, so the node and
+	// its node-level checks are only looked up once per node.
+	var nodeCache map[nodeCacheKey]nodeCacheEntry
+	if len(services) > 1 {
+		nodeCache = make(map[nodeCacheKey]nodeCacheEntry)
+	}
+
+	// serviceChecks is reused to collect the service-specific checks of each
+	// instance before they are copied to an exactly sized slice.
+	var serviceChecks structs.HealthChecks
+
 	results := make(structs.CheckServiceNodes, 0, len(services))
 	for _, sn := range services {
-		// Retrieve the node.
-		watchCh, n, err := tx.FirstWatch(tableNodes, indexID, Query{
-			Value:          sn.Node,
-			EnterpriseMeta: sn.EnterpriseMeta,
-			PeerName:       sn.PeerName,
-		})
-		if err != nil {
-			return 0, nil, fmt.Errorf("failed node lookup: %s", err)
-		}
-		ws.AddWithLimit(watchLimit, watchCh, allNodesCh)
+		key := newNodeCacheKey(sn)
+		cached, ok := nodeCache[key]
+		if !ok {
+			// Retrieve the node.
+			watchCh, n, err := tx.FirstWatch(tableNodes, indexID, Query{
+				Value:          sn.Node,
+				EnterpriseMeta: sn.EnterpriseMeta,
+				PeerName:       sn.PeerName,
+			})
+			if err != nil {
+				return 0, nil, fmt.Errorf("failed node lookup: %s", err)
+			}
+			ws.AddWithLimit(watchLimit, watchCh, allNodesCh)
 
-		if n == nil {
-			return 0, nil, ErrMissingNode
-		}
-		node := n.(*structs.Node)
+			if n == nil {
+				return 0, nil, ErrMissingNode
+			}
+			cached.node = n.(*structs.Node)
+
+			// First add the node-level checks. These always apply to any
+			// service on the node.
+			q := NodeServiceQuery{
+				Node:           sn.Node,
+				Service:        "", // node checks have no service
+				EnterpriseMeta: *sn.EnterpriseMeta.WithWildcardNamespace(),
+				PeerName:       sn.PeerName,
+			}
+			iter, err := tx.Get(tableChecks, indexNodeService, q)
+			if err != nil {
+				return 0, nil, err
+			}
+			ws.AddWithLimit(watchLimit, iter.WatchCh(), allChecksCh)
+			for check := iter.Next(); check != nil; check = iter.Next() {
+				cached.checks = append(cached.checks, check.(*structs.HealthCheck))
+			}
 
-		// First add the node-level checks. These always apply to any
-		// service on the node.
-		var checks structs.HealthChecks
-		q := NodeServiceQuery{
-			Node:           sn.Node,
-			Service:        "", // node checks have no service
-			EnterpriseMeta: *sn.EnterpriseMeta.WithWildcardNamespace(),
-			PeerName:       sn.PeerName,
-		}
-		iter, err := tx.Get(tableChecks, indexNodeService, q)
-		if err != nil {
-			return 0, nil, err
-		}
-		ws.AddWithLimit(watchLimit, iter.WatchCh(), allChecksCh)
-		for check := iter.Next(); check != nil; check = iter.Next() {
-			checks = append(checks, check.(*structs.HealthCheck))
+			if nodeCache != nil {
+				nodeCache[key] = cached
+			}
 		}
 
 		// Now add the service-specific checks.
-		q = NodeServiceQuery{
+		q := NodeServiceQuery{
 			Node:           sn.Node,
 			Service:        sn.ServiceID,
 			EnterpriseMeta: sn.EnterpriseMeta,
 			PeerName:       sn.PeerName,
 		}
-		iter, err = tx.Get(tableChecks, indexNodeService, q)
+		iter, err := tx.Get(tableChecks, indexNodeService, q)
 		if err != nil {
 			return 0, nil, err
 		}
 		ws.AddWithLimit(watchLimit, iter.WatchCh(), allChecksCh)
+		serviceChecks = serviceChecks[:0]
 		for check := iter.Next(); check != nil; check = iter.Next() {
-			checks = append(checks, check.(*structs.HealthCheck))
+			serviceChecks = append(serviceChecks, check.(*structs.HealthCheck))
+		}
+
+		// The node checks are shared between the instances of the node, so
+		// they are copied along with the service checks in a new slice.
+		var checks structs.HealthChecks
+		if n := len(cached.checks) + len(serviceChecks); n > 0 {
+			checks = make(structs.HealthChecks, 0, n)
+			checks = append(checks, cached.checks...)
+			checks = append(checks, serviceChecks...)
 		}
+		node := cached.node
 
 		// Append to the results.
 		results = append(results, structs.CheckServiceNode{
@@ -3762,7 +3813,13 @@ func terminatingConfigGatewayServices(
 			KeyFile:         svc.KeyFile,
 			CertFile:        svc.CertFile,
 			CAFile:          svc.CAFile,
+			CAPEM:           svc.CAPEM,
+			VaultPKI:        svc.VaultPKI.Clone(),
+			TLSMinVersion:   svc.TLSMinVersion,
+			TLSMaxVersion:   svc.TLSMaxVersion,
+			CipherSuites:    svc.CipherSuites,
 			SNI:             svc.SNI,
+			HostRewrite:     svc.HostRewrite,
 			ServiceKind:     kind,
 			AutoHostRewrite: !svc.DisableAutoHostRewrite,
 		}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"sync"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// maxPooledServiceNodes is the capacity above which a buffer is not returned
+// to the pool, so that a single query of a huge service doesn't pin its
+// memory for the lifetime of the process.
+const maxPooledServiceNodes = 128 * 1024
+
+// serviceNodesPool holds the buffers the service instances matching a query
+// are collected in before the results are built from them. Reusing them
+// avoids growing a new slice for every query of a large service, which adds
+// up when many blocking queries fire at once.
+var serviceNodesPool = sync.Pool{
+	New: func() interface{} {
+		return new(structs.ServiceNodes)
+	},
+}
+
+// getServiceNodesBuffer returns an empty buffer from the pool. It must be
+// released with putServiceNodesBuffer once nothing references it anymore.
+func getServiceNodesBuffer() *structs.ServiceNodes {
+	return serviceNodesPool.Get().(*structs.ServiceNodes)
+}
+
+// putServiceNodesBuffer clears the buffer and returns it to the pool.
+func putServiceNodesBuffer(buf *structs.ServiceNodes) {
+	if cap(*buf) > maxPooledServiceNodes {
+		return
+	}
+	// Drop the references to the state store objects so the buffer doesn't
+	// keep them alive after they are deleted.
+	for i := range *buf {
+		(*buf)[i] = nil
+	}
+	*buf = (*buf)[:0]
+	serviceNodesPool.Put(buf)
+}
+
+// nodeCacheKey identifies a node within a query of the service instances.
+type nodeCacheKey struct {
+	node      string
+	partition string
+	peer      string
+}
+
+// nodeCacheEntry is a node, and its node-level checks, shared by all the
+// service instances registered on it within a query.
+type nodeCacheEntry struct {
+	node   *structs.Node
+	checks structs.HealthChecks
+}
+
+func newNodeCacheKey(sn *structs.ServiceNode) nodeCacheKey {
+	return nodeCacheKey{
+		node:      sn.Node,
+		partition: sn.EnterpriseMeta.PartitionOrDefault(),
+		peer:      sn.PeerName,
+	}
+}
@@ -671,6 +671,54 @@ func testIndexerTableServices() map[string]indexerTestCase {
 				},
 			},
 		},
+		indexMeta: {
+			read: indexValue{
+				source: KeyValueQuery{
+					Key:   "KeY",
+					Value: "VaLuE",
+				},
+				expected: []byte("~\x00KeY\x00VaLuE\x00"),
+			},
+			writeMulti: indexValueMulti{
+				source: &structs.ServiceNode{
+					Node:        "NoDeId",
+					ServiceName: "ServiceName",
+					ServiceMeta: map[string]string{
+						"MaP-kEy-1": "mAp-VaL-1",
+						"mAp-KeY-2": "MaP-vAl-2",
+					},
+				},
+				expected: [][]byte{
+					[]byte("~\x00MaP-kEy-1\x00mAp-VaL-1\x00"),
+					[]byte("~\x00mAp-KeY-2\x00MaP-vAl-2\x00"),
+				},
+			},
+			extra: []indexerTestCase{
+				{
+					read: indexValue{
+						source: KeyValueQuery{
+							Key:      "KeY",
+							Value:    "VaLuE",
+							PeerName: "Peer1",
+						},
+						expected: []byte("peer1\x00KeY\x00VaLuE\x00"),
+					},
+					writeMulti: indexValueMulti{
+						source: &structs.ServiceNode{
+							Node:        "NoDeId",
+							ServiceName: "ServiceName",
+							ServiceMeta: map[string]string{
+								"MaP-kEy-1": "mAp-VaL-1",
+							},
+							PeerName: "Peer1",
+						},
+						expected: [][]byte{
+							[]byte("peer1\x00MaP-kEy-1\x00mAp-VaL-1\x00"),
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// metaIndexKeys is the set of metadata keys included in a meta index. A nil
+// set includes every key.
+type metaIndexKeys map[string]struct{}
+
+func newMetaIndexKeys(keys []string) metaIndexKeys {
+	if keys == nil {
+		return nil
+	}
+	set := make(metaIndexKeys, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+	return set
+}
+
+func (k metaIndexKeys) includes(key string) bool {
+	if k == nil {
+		return true
+	}
+	_, ok := k[key]
+	return ok
+}
+
+// lookup returns a key/value pair of filters that can be looked up in the
+// meta index, or false if none of the filtered keys are indexed.
+func (k metaIndexKeys) lookup(filters map[string]string) (string, string, bool) {
+	for key, value := range filters {
+		if k.includes(key) {
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// indexFromMeta returns the meta index values for the pairs of meta whose key
+// is included in keys.
+func indexFromMeta(meta map[string]string, keys metaIndexKeys) ([][]byte, error) {
+	// NOTE: this is case-sensitive!
+
+	vals := make([][]byte, 0, len(meta))
+	for key, val := range meta {
+		if key == "" || !keys.includes(key) {
+			continue
+		}
+
+		var b indexBuilder
+		b.String(key)
+		b.String(val)
+		vals = append(vals, b.Bytes())
+	}
+	if len(vals) == 0 {
+		return nil, errMissingValueForIndex
+	}
+
+	return vals, nil
+}
+
+// applyMetaIndexConfig limits the meta indexes of the nodes and services
+// tables in schema to the keys declared in nodeKeys and serviceKeys.
+func applyMetaIndexConfig(schema *memdb.DBSchema, nodeKeys, serviceKeys metaIndexKeys) {
+	if nodeKeys != nil {
+		schema.Tables[tableNodes].Indexes[indexMeta].Indexer = indexerMulti[KeyValueQuery, *structs.Node]{
+			readIndex: indexWithPeerName(indexFromKeyValueQuery),
+			writeIndexMulti: multiIndexWithPeerName(func(n *structs.Node) ([][]byte, error) {
+				return indexFromMeta(n.Meta, nodeKeys)
+			}),
+		}
+	}
+	if serviceKeys != nil {
+		schema.Tables[tableServices].Indexes[indexMeta].Indexer = indexerMulti[KeyValueQuery, *structs.ServiceNode]{
+			readIndex: indexWithPeerName(indexFromKeyValueQuery),
+			writeIndexMulti: multiIndexWithPeerName(func(n *structs.ServiceNode) ([][]byte, error) {
+				return indexFromMeta(n.ServiceMeta, serviceKeys)
+			}),
+		}
+	}
+}
+
+// nodesByMetaTxn returns the nodes with node metadata matching every
+// key/value pair in filters. The meta index is used when one of the filtered
+// keys is indexed, otherwise all the nodes are scanned.
+func (s *Store) nodesByMetaTxn(
+	tx ReadTxn,
+	ws memdb.WatchSet,
+	filters map[string]string,
+	entMeta *acl.EnterpriseMeta,
+	peerName string,
+) (structs.Nodes, error) {
+	var (
+		nodes memdb.ResultIterator
+		err   error
+	)
+	if key, value, ok := s.nodeMetaIndexKeys.lookup(filters); ok {
+		nodes, err = tx.Get(tableNodes, indexMeta, KeyValueQuery{
+			Key:            key,
+			Value:          value,
+			EnterpriseMeta: *entMeta,
+			PeerName:       peerName,
+		})
+	} else {
+		nodes, err = tx.Get(tableNodes, indexID+"_prefix", Query{
+			EnterpriseMeta: *entMeta,
+			PeerName:       peerName,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed nodes lookup: %s", err)
+	}
+	ws.Add(nodes.WatchCh())
+
+	var results structs.Nodes
+	for node := nodes.Next(); node != nil; node = nodes.Next() {
+		n := node.(*structs.Node)
+		if structs.SatisfiesMetaFilters(n.Meta, filters) {
+			results = append(results, n)
+		}
+	}
+	return results, nil
+}
+
+// servicesByServiceMetaTxn returns the services with service metadata
+// matching every key/value pair in filters. The meta index is used when one
+// of the filtered keys is indexed, otherwise all the services are scanned.
+func (s *Store) servicesByServiceMetaTxn(
+	tx ReadTxn,
+	ws memdb.WatchSet,
+	filters map[string]string,
+	entMeta *acl.EnterpriseMeta,
+	peerName string,
+) (structs.ServiceNodes, error) {
+	var (
+		services memdb.ResultIterator
+		err      error
+	)
+	if key, value, ok := s.serviceMetaIndexKeys.lookup(filters); ok {
+		services, err = tx.Get(tableServices, indexMeta, KeyValueQuery{
+			Key:            key,
+			Value:          value,
+			EnterpriseMeta: *entMeta,
+			PeerName:       peerName,
+		})
+	} else {
+		services, err = catalogServiceListNoWildcard(tx, entMeta, peerName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed services lookup: %s", err)
+	}
+	ws.Add(services.WatchCh())
+
+	var results structs.ServiceNodes
+	for service := services.Next(); service != nil; service = services.Next() {
+		sn := service.(*structs.ServiceNode)
+		if !entMeta.Matches(&sn.EnterpriseMeta) {
+			continue
+		}
+		if structs.SatisfiesMetaFilters(sn.ServiceMeta, filters) {
+			results = append(results, sn)
+		}
+	}
+	return results, nil
+}
@@ -104,24 +104,7 @@ func indexIDFromNode(n *structs.Node) ([]byte, error) {
 }
 
 func indexMetaFromNode(n *structs.Node) ([][]byte, error) {
-	// NOTE: this is case-sensitive!
-
-	vals := make([][]byte, 0, len(n.Meta))
-	for key, val := range n.Meta {
-		if key == "" {
-			continue
-		}
-
-		var b indexBuilder
-		b.String(key)
-		b.String(val)
-		vals = append(vals, b.Bytes())
-	}
-	if len(vals) == 0 {
-		return nil, errMissingValueForIndex
-	}
-
-	return vals, nil
+	return indexFromMeta(n.Meta, nil)
 }
 
 // servicesTableSchema returns a new table schema used to store information
@@ -176,6 +159,15 @@ func servicesTableSchema() *memdb.TableSchema {
 					writeIndex: indexWithPeerName(indexKindFromServiceNode),
 				},
 			},
+			indexMeta: {
+				Name:         indexMeta,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: indexerMulti[KeyValueQuery, *structs.ServiceNode]{
+					readIndex:       indexWithPeerName(indexFromKeyValueQuery),
+					writeIndexMulti: multiIndexWithPeerName(indexMetaFromServiceNode),
+				},
+			},
 		},
 	}
 }
@@ -256,6 +248,10 @@ func connectNameFromServiceNode(sn *structs.ServiceNode) (string, bool) {
 	}
 }
 
+func indexMetaFromServiceNode(n *structs.ServiceNode) ([][]byte, error) {
+	return indexFromMeta(n.ServiceMeta, nil)
+}
+
 func indexKindFromServiceNode(n *structs.ServiceNode) ([]byte, error) {
 	var b indexBuilder
 	b.String(strings.ToLower(string(n.ServiceKind)))
@@ -2374,6 +2374,111 @@ func TestStateStore_ServicesByNodeMeta(t *testing.T) {
 	})
 }
 
+func TestStateStore_ServicesByServiceMeta(t *testing.T) {
+	s := testStateStore(t)
+
+	ws := memdb.NewWatchSet()
+	idx, res, err := s.ServicesByServiceMeta(ws, map[string]string{"env": "prod"}, nil, "", false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Empty(t, res)
+
+	testRegisterNode(t, s, 0, "node0")
+	testRegisterNodeWithMeta(t, s, 1, "node1", map[string]string{"rack": "r1"})
+	ns1 := &structs.NodeService{
+		ID:      "api1",
+		Service: "api",
+		Meta:    map[string]string{"env": "prod", "team": "a"},
+	}
+	require.NoError(t, s.EnsureService(2, "node0", ns1))
+	ns2 := &structs.NodeService{
+		ID:      "api2",
+		Service: "api",
+		Meta:    map[string]string{"env": "prod", "team": "b"},
+	}
+	require.NoError(t, s.EnsureService(3, "node1", ns2))
+	ns3 := &structs.NodeService{
+		ID:      "web",
+		Service: "web",
+		Meta:    map[string]string{"env": "dev"},
+	}
+	require.NoError(t, s.EnsureService(4, "node1", ns3))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	idx, res, err = s.ServicesByServiceMeta(ws, map[string]string{"env": "prod"}, nil, "", false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), idx)
+	require.Len(t, res, 2)
+	require.Equal(t, "api1", res[0].ServiceID)
+	require.Equal(t, "api2", res[1].ServiceID)
+
+	_, res, err = s.ServicesByServiceMeta(ws, map[string]string{"env": "prod", "team": "b"}, nil, "", true)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "api2", res[0].ServiceID)
+	require.Equal(t, map[string]string{"rack": "r1"}, res[0].NodeMeta)
+
+	_, res, err = s.ServicesByServiceMeta(ws, map[string]string{"env": "staging"}, nil, "", false)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	// Changing the metadata of a matching service fires the watch.
+	ns2.Meta = map[string]string{"env": "dev"}
+	require.NoError(t, s.EnsureService(5, "node1", ns2))
+	require.True(t, watchFired(ws))
+}
+
+func TestStateStore_MetaIndexes(t *testing.T) {
+	s := newStateStore(nil, structs.MetaIndexConfig{
+		NodeKeys:    []string{"rack"},
+		ServiceKeys: []string{"env"},
+	})
+
+	testRegisterNodeWithMeta(t, s, 1, "node1", map[string]string{"rack": "r1", "zone": "a"})
+	testRegisterNodeWithMeta(t, s, 2, "node2", map[string]string{"rack": "r2", "zone": "a"})
+	testRegisterServiceWithMeta(t, s, 3, "node1", "api", map[string]string{"env": "prod", "team": "a"})
+	testRegisterServiceWithMeta(t, s, 4, "node2", "web", map[string]string{"env": "dev", "team": "a"})
+
+	// Only the declared keys are written to the indexes.
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+	nodes, err := tx.Get(tableNodes, indexMeta, KeyValueQuery{Key: "zone", Value: "a"})
+	require.NoError(t, err)
+	require.Nil(t, nodes.Next())
+	services, err := tx.Get(tableServices, indexMeta, KeyValueQuery{Key: "team", Value: "a"})
+	require.NoError(t, err)
+	require.Nil(t, services.Next())
+	services, err = tx.Get(tableServices, indexMeta, KeyValueQuery{Key: "env", Value: "prod"})
+	require.NoError(t, err)
+	require.NotNil(t, services.Next())
+
+	// Queries on indexed keys use the index.
+	_, nodeRes, err := s.NodesByMeta(nil, map[string]string{"rack": "r1"}, nil, "")
+	require.NoError(t, err)
+	require.Len(t, nodeRes, 1)
+	require.Equal(t, "node1", nodeRes[0].Node)
+
+	_, svcRes, err := s.ServicesByServiceMeta(nil, map[string]string{"env": "dev"}, nil, "", false)
+	require.NoError(t, err)
+	require.Len(t, svcRes, 1)
+	require.Equal(t, "web", svcRes[0].ServiceID)
+
+	// Queries on keys that aren't indexed scan the catalog.
+	_, nodeRes, err = s.NodesByMeta(nil, map[string]string{"zone": "a"}, nil, "")
+	require.NoError(t, err)
+	require.Len(t, nodeRes, 2)
+
+	_, nodeRes, err = s.NodesByMeta(nil, map[string]string{"zone": "a", "rack": "r2"}, nil, "")
+	require.NoError(t, err)
+	require.Len(t, nodeRes, 1)
+	require.Equal(t, "node2", nodeRes[0].Node)
+
+	_, svcRes, err = s.ServicesByServiceMeta(nil, map[string]string{"team": "a"}, nil, "", false)
+	require.NoError(t, err)
+	require.Len(t, svcRes, 2)
+}
+
 // patchWatchLimit package variable. Not safe for concurrent use. Do not use
 // with t.Parallel.
 func patchWatchLimit(t *testing.T, limit int) {
@@ -4360,6 +4465,53 @@ func TestStateStore_CheckConnectServiceNodes_Gateways(t *testing.T) {
 	assert.Equal(t, 8000, nodes[0].Service.Port)
 }
 
+func TestStateStore_CheckServiceNodes_SharedNode(t *testing.T) {
+	s := testStateStore(t)
+
+	// Register two instances of a service on the same node, and one on
+	// another node.
+	testRegisterNodeWithMeta(t, s, 1, "node1", map[string]string{"name": "node1"})
+	testRegisterNodeWithMeta(t, s, 2, "node2", map[string]string{"name": "node2"})
+	testRegisterCheck(t, s, 3, "node1", "", "node-check", api.HealthPassing)
+	for i, id := range []string{"db1", "db2"} {
+		idx := uint64(10 + 2*i)
+		require.NoError(t, s.EnsureService(idx, "node1", &structs.NodeService{ID: id, Service: "db"}))
+		testRegisterCheck(t, s, idx+1, "node1", id, types.CheckID("check-"+id), api.HealthCritical)
+	}
+	require.NoError(t, s.EnsureService(20, "node2", &structs.NodeService{ID: "db3", Service: "db"}))
+
+	// Each instance has the node checks and its own checks only.
+	_, results, err := s.CheckServiceNodes(nil, "db", nil, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	checkIDs := make(map[string][]types.CheckID)
+	for _, csn := range results {
+		require.Equal(t, csn.Service.ID == "db3", csn.Node.Node == "node2")
+		for _, check := range csn.Checks {
+			checkIDs[csn.Service.ID] = append(checkIDs[csn.Service.ID], check.CheckID)
+		}
+	}
+	require.Equal(t, map[string][]types.CheckID{
+		"db1": {"node-check", "check-db1"},
+		"db2": {"node-check", "check-db2"},
+	}, checkIDs)
+
+	// The instances without checks keep a nil slice.
+	for _, csn := range results {
+		if csn.Service.ID == "db3" {
+			require.Nil(t, csn.Checks)
+		}
+	}
+
+	// The node data is filled in the service instances of the same node.
+	_, nodes, err := s.ServiceNodes(nil, "db", nil, "")
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+	for _, sn := range nodes {
+		require.Equal(t, sn.Node, sn.NodeMeta["name"])
+	}
+}
+
 func BenchmarkCheckServiceNodes(b *testing.B) {
 	s := NewStateStore(nil)
 
@@ -9124,3 +9276,62 @@ func assertDeepEqual(t *testing.T, x, y interface{}, opts ...cmp.Option) {
 		t.Fatalf("assertion failed: values are not equal\n--- expected\n+++ actual\n%v", diff)
 	}
 }
+
+// benchmarkLargeCatalog registers nodes running instances of a service, with
+// a node check and a check per instance.
+func benchmarkLargeCatalog(b *testing.B, nodes, instancesPerNode int) *Store {
+	s := NewStateStore(nil)
+	idx := uint64(1)
+	for n := 0; n < nodes; n++ {
+		node := fmt.Sprintf("node-%d", n)
+		require.NoError(b, s.EnsureNode(idx, &structs.Node{Node: node, Address: "127.0.0.1"}))
+		idx++
+		require.NoError(b, s.EnsureCheck(idx, &structs.HealthCheck{
+			Node:    node,
+			CheckID: "serfHealth",
+			Name:    "serf",
+			Status:  api.HealthPassing,
+		}))
+		idx++
+		for i := 0; i < instancesPerNode; i++ {
+			id := fmt.Sprintf("web-%d", i)
+			require.NoError(b, s.EnsureService(idx, node, &structs.NodeService{ID: id, Service: "web", Port: 8000 + i}))
+			idx++
+			require.NoError(b, s.EnsureCheck(idx, &structs.HealthCheck{
+				Node:      node,
+				CheckID:   types.CheckID("service:" + id),
+				Name:      "alive",
+				Status:    api.HealthPassing,
+				ServiceID: id,
+			}))
+			idx++
+		}
+	}
+	return s
+}
+
+func BenchmarkCheckServiceNodes_Large(b *testing.B) {
+	s := benchmarkLargeCatalog(b, 1000, 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, nodes, err := s.CheckServiceNodes(memdb.NewWatchSet(), "web", nil, "")
+		if err != nil || len(nodes) != 10000 {
+			b.Fatalf("bad: %d %v", len(nodes), err)
+		}
+	}
+}
+
+func BenchmarkServiceNodes_Large(b *testing.B) {
+	s := benchmarkLargeCatalog(b, 1000, 10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, nodes, err := s.ServiceNodes(memdb.NewWatchSet(), "web", nil, "")
+		if err != nil || len(nodes) != 10000 {
+			b.Fatalf("bad: %d %v", len(nodes), err)
+		}
+	}
+}
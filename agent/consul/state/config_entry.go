@@ -651,6 +651,7 @@ func validateProposedConfigEntryInGraph(
 	case structs.HTTPRoute:
 	case structs.TCPRoute:
 	case structs.RateLimitIPConfig:
+	case structs.AgentDefaults:
 	case structs.JWTProvider:
 		if newEntry == nil && existingEntry != nil {
 			err := validateJWTProviderIsReferenced(tx, kindName, existingEntry)
@@ -1025,6 +1026,10 @@ func validateProposedConfigEntryInServiceGraph(
 		// Exported services and mesh config do not influence discovery chains.
 		return nil
 
+	case structs.AgentDefaults:
+		// The agent defaults only configure the agents.
+		return nil
+
 	case structs.SamenessGroup:
 		// Any service resolver could reference a sameness group.
 		_, resolverEntries, err := configEntriesByKindTxn(tx, nil, structs.ServiceResolver, wildcardEntMeta)
@@ -1538,20 +1543,11 @@ func (s *Store) ReadResolvedServiceConfigEntries(
 	// definitions.
 	var inferredProxyMode structs.ProxyMode
 
-	index, proxyEntry, err := configEntryTxn(tx, ws, structs.ProxyDefaults, structs.ProxyConfigGlobal, entMeta)
+	maxIndex, err := readProxyDefaultsScopesTxn(tx, ws, nil, entMeta, res.AddProxyDefaults)
 	if err != nil {
 		return 0, nil, err
 	}
-	maxIndex := index
-
-	if proxyEntry != nil {
-		var ok bool
-		proxyConf, ok := proxyEntry.(*structs.ProxyConfigEntry)
-		if !ok {
-			return 0, nil, fmt.Errorf("invalid proxy config type %T", proxyEntry)
-		}
-		res.AddProxyDefaults(proxyConf)
-
+	if proxyConf := res.GetProxyDefaults(entMeta); proxyConf != nil {
 		inferredProxyMode = proxyConf.Mode
 	}
 
@@ -1861,6 +1857,7 @@ func readDiscoveryChainConfigEntriesTxn(
 		}
 	}
 
+	fetchedProxyDefaults := make(map[string]bool)
 	for {
 		svcID, ok := anyKey(todoDefaults)
 		if !ok {
@@ -1872,17 +1869,17 @@ func readDiscoveryChainConfigEntriesTxn(
 			continue // already fetched
 		}
 
-		if _, ok := res.ProxyDefaults[svcID.PartitionOrDefault()]; !ok {
-			idx, proxy, err := getProxyConfigEntryTxn(tx, ws, structs.ProxyConfigGlobal, overrides, &svcID.EnterpriseMeta)
+		if scope := structs.ProxyDefaultsScopeKey(&svcID.EnterpriseMeta); !fetchedProxyDefaults[scope] {
+			fetchedProxyDefaults[scope] = true
+			idx, err := readProxyDefaultsScopesTxn(tx, ws, overrides, &svcID.EnterpriseMeta, func(proxy *structs.ProxyConfigEntry) {
+				res.AddProxyDefaults(proxy)
+			})
 			if err != nil {
 				return 0, nil, err
 			}
 			if idx > maxIdx {
 				maxIdx = idx
 			}
-			if proxy != nil {
-				res.ProxyDefaults[proxy.PartitionOrDefault()] = proxy
-			}
 		}
 
 		idx, entry, err := getServiceConfigEntryTxn(tx, ws, svcID.ID, overrides, &svcID.EnterpriseMeta)
@@ -1980,6 +1977,32 @@ func getProxyConfigEntryTxn(
 	return idx, proxy, nil
 }
 
+// readProxyDefaultsScopesTxn fetches the proxy-defaults of each scope that
+// applies to services in entMeta and passes those that exist to add, from the
+// least to the most specific scope.
+func readProxyDefaultsScopesTxn(
+	tx ReadTxn,
+	ws memdb.WatchSet,
+	overrides map[configentry.KindName]structs.ConfigEntry,
+	entMeta *acl.EnterpriseMeta,
+	add func(*structs.ProxyConfigEntry),
+) (uint64, error) {
+	var maxIdx uint64
+	for _, scope := range structs.ProxyDefaultsScopes(entMeta) {
+		idx, proxy, err := getProxyConfigEntryTxn(tx, ws, structs.ProxyConfigGlobal, overrides, scope)
+		if err != nil {
+			return 0, err
+		}
+		if idx > maxIdx {
+			maxIdx = idx
+		}
+		if proxy != nil {
+			add(proxy)
+		}
+	}
+	return maxIdx, nil
+}
+
 // getServiceConfigEntryTxn is a convenience method for fetching a
 // service-defaults kind of config entry.
 //
@@ -2142,8 +2165,12 @@ func protocolForService(
 	ws memdb.WatchSet,
 	svc structs.ServiceName,
 ) (uint64, string, error) {
-	// Get the global proxy defaults (for default protocol)
-	maxIdx, proxyConfig, err := getProxyConfigEntryTxn(tx, ws, structs.ProxyConfigGlobal, nil, &svc.EnterpriseMeta)
+	entries := configentry.NewDiscoveryChainSet()
+
+	// Get the proxy defaults of each scope (for default protocol)
+	maxIdx, err := readProxyDefaultsScopesTxn(tx, ws, nil, &svc.EnterpriseMeta, func(proxy *structs.ProxyConfigEntry) {
+		entries.AddEntries(proxy)
+	})
 	if err != nil {
 		return 0, "", err
 	}
@@ -2154,10 +2181,6 @@ func protocolForService(
 	}
 	maxIdx = lib.MaxUint64(maxIdx, idx)
 
-	entries := configentry.NewDiscoveryChainSet()
-	if proxyConfig != nil {
-		entries.AddEntries(proxyConfig)
-	}
 	if serviceDefaults != nil {
 		entries.AddEntries(serviceDefaults)
 	}
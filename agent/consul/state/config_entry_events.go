@@ -51,6 +51,10 @@ func (s EventSubjectConfigEntry) String() string {
 type EventPayloadConfigEntry struct {
 	Op    pbsubscribe.ConfigEntryUpdate_UpdateOp
 	Value structs.ConfigEntry
+
+	// open returns the entry with its encrypted fields decrypted, see
+	// Store.SetConfigEntryOpener.
+	open func(structs.ConfigEntry) structs.ConfigEntry
 }
 
 func (e EventPayloadConfigEntry) Subject() stream.Subject {
@@ -61,16 +65,20 @@ func (e EventPayloadConfigEntry) Subject() stream.Subject {
 }
 
 func (e EventPayloadConfigEntry) HasReadPermission(authz acl.Authorizer) bool {
-	return e.Value.CanRead(authz) == nil
+	return structs.CanReadConfigEntry(e.Value, authz) == nil
 }
 
 func (e EventPayloadConfigEntry) ToSubscriptionEvent(idx uint64) *pbsubscribe.Event {
+	value := e.Value
+	if e.open != nil {
+		value = e.open(value)
+	}
 	return &pbsubscribe.Event{
 		Index: idx,
 		Payload: &pbsubscribe.Event_ConfigEntry{
 			ConfigEntry: &pbsubscribe.ConfigEntryUpdate{
 				Op:          e.Op,
-				ConfigEntry: pbconfigentry.ConfigEntryFromStructs(e.Value),
+				ConfigEntry: pbconfigentry.ConfigEntryFromStructs(value),
 			},
 		},
 	}
@@ -219,6 +227,7 @@ func (s *Store) configEntrySnapshot(kind string, req stream.SubscribeRequest, bu
 		for i, e := range entries {
 			events[i] = configEntryEvent(topic, idx, pbsubscribe.ConfigEntryUpdate_Upsert, e)
 		}
+		setConfigEntryOpener(events, s.db.openConfigEntry)
 		buf.Append(events)
 	}
 
@@ -235,3 +244,24 @@ func configEntryEvent(topic stream.Topic, idx uint64, op pbsubscribe.ConfigEntry
 		},
 	}
 }
+
+// SetConfigEntryOpener sets the function which decrypts the encrypted fields of
+// the config entries sent to the streaming subscribers. The entries are stored
+// encrypted, the function is only applied when the events are converted for the
+// subscribers. It must be called before the store is used.
+func (s *Store) SetConfigEntryOpener(open func(structs.ConfigEntry) structs.ConfigEntry) {
+	s.db.openConfigEntry = open
+}
+
+// setConfigEntryOpener sets the opener of the config entry events.
+func setConfigEntryOpener(events []stream.Event, open func(structs.ConfigEntry) structs.ConfigEntry) {
+	if open == nil {
+		return
+	}
+	for i, event := range events {
+		if payload, ok := event.Payload.(EventPayloadConfigEntry); ok {
+			payload.open = open
+			events[i].Payload = payload
+		}
+	}
+}
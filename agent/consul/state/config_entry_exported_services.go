@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/consul/acl"
@@ -131,10 +132,18 @@ func resolvedExportedServicesTxn(tx ReadTxn, ws memdb.WatchSet, entMeta *acl.Ent
 	return maxIdx, resp, nil
 }
 
+// exportConsumer identifies a consumer of an exported service once selectors
+// have been resolved.
+type exportConsumer struct {
+	Partition     string
+	Peer          string
+	SamenessGroup string
+}
+
 // getUniqueExportedServices removes duplicate services and consumers. Services are also sorted in ascending order
 func getUniqueExportedServices(exportedServices []structs.ExportedService, entMeta *acl.EnterpriseMeta) []structs.ExportedService {
 	// Services -> ServiceConsumers
-	var exportedServicesMapper = make(map[structs.ServiceName]map[structs.ServiceConsumer]struct{})
+	var exportedServicesMapper = make(map[structs.ServiceName]map[exportConsumer]struct{})
 	for _, svc := range exportedServices {
 		svcEntMeta := acl.NewEnterpriseMetaWithPartition(entMeta.PartitionOrDefault(), svc.Namespace)
 		svcName := structs.NewServiceName(svc.Name, &svcEntMeta)
@@ -142,10 +151,10 @@ func getUniqueExportedServices(exportedServices []structs.ExportedService, entMe
 		for _, c := range svc.Consumers {
 			cons, ok := exportedServicesMapper[svcName]
 			if !ok {
-				cons = make(map[structs.ServiceConsumer]struct{})
+				cons = make(map[exportConsumer]struct{})
 				exportedServicesMapper[svcName] = cons
 			}
-			cons[c] = struct{}{}
+			cons[exportConsumer{Partition: c.Partition, Peer: c.Peer, SamenessGroup: c.SamenessGroup}] = struct{}{}
 		}
 	}
 
@@ -154,7 +163,11 @@ func getUniqueExportedServices(exportedServices []structs.ExportedService, entMe
 	for svc, cons := range exportedServicesMapper {
 		consumers := make([]structs.ServiceConsumer, 0, len(cons))
 		for con := range cons {
-			consumers = append(consumers, con)
+			consumers = append(consumers, structs.ServiceConsumer{
+				Partition:     con.Partition,
+				Peer:          con.Peer,
+				SamenessGroup: con.SamenessGroup,
+			})
 		}
 
 		uniqueExportedServices = append(uniqueExportedServices, structs.ExportedService{
@@ -172,3 +185,110 @@ func getUniqueExportedServices(exportedServices []structs.ExportedService, entMe
 
 	return uniqueExportedServices
 }
+
+// resolveExportSelectorsTxn replaces the wildcard exports that use service
+// metadata selectors with an export of each matching service, so that callers
+// only ever see unrestricted wildcards and concrete service names. Consumers
+// of a wildcard export without a selector keep the unrestricted wildcard.
+//
+// The watch set fires when services are registered or deregistered or their
+// instances change, so that exports are recomputed as services start or stop
+// matching a selector.
+func resolveExportSelectorsTxn(tx ReadTxn, ws memdb.WatchSet, entMeta acl.EnterpriseMeta, exports *SimplifiedExportedServices) (uint64, error) {
+	var maxIdx uint64
+
+	// Lookups prefer an exact match to the wildcard, so the export of each
+	// matching service must also include every consumer of the unrestricted
+	// wildcards in its namespace.
+	unrestricted := make(map[string][]structs.ServiceConsumer)
+	for _, svc := range exports.Services {
+		if svc.Name != structs.WildcardSpecifier {
+			continue
+		}
+		for _, c := range svc.Consumers {
+			if len(svc.SelectorFor(c)) == 0 {
+				unrestricted[svc.Namespace] = append(unrestricted[svc.Namespace], c)
+			}
+		}
+	}
+
+	services := make([]structs.ExportedService, 0, len(exports.Services))
+	for _, svc := range exports.Services {
+		if svc.Name != structs.WildcardSpecifier || !svc.HasSelector() {
+			services = append(services, svc)
+			continue
+		}
+
+		var selective, rest []structs.ServiceConsumer
+		for _, c := range svc.Consumers {
+			if len(svc.SelectorFor(c)) == 0 {
+				rest = append(rest, c)
+			} else {
+				selective = append(selective, c)
+			}
+		}
+		if len(rest) > 0 {
+			services = append(services, structs.ExportedService{
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+				Consumers: rest,
+			})
+		}
+
+		svcEntMeta := acl.NewEnterpriseMetaWithPartition(entMeta.PartitionOrDefault(), svc.Namespace)
+		idx, typicalServices, err := serviceNamesOfKindTxn(tx, ws, structs.ServiceKindTypical, svcEntMeta)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get typical service names: %w", err)
+		}
+		maxIdx = lib.MaxUint64(maxIdx, idx)
+
+		for _, sn := range typicalServices {
+			// Prevent exporting the "consul" service.
+			if sn.Service.Name == structs.ConsulServiceName {
+				continue
+			}
+
+			iter, err := tx.Get(tableServices, indexService, Query{
+				Value:          sn.Service.Name,
+				EnterpriseMeta: sn.Service.EnterpriseMeta,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("failed service lookup: %w", err)
+			}
+			ws.Add(iter.WatchCh())
+			maxIdx = lib.MaxUint64(maxIdx, maxIndexForService(tx, sn.Service.Name, true, false, &sn.Service.EnterpriseMeta, structs.DefaultPeerKeyword))
+
+			var consumers []structs.ServiceConsumer
+			matched := make([]bool, len(selective))
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				instance := raw.(*structs.ServiceNode)
+				for i, c := range selective {
+					if !matched[i] && structs.SelectorMatches(svc.SelectorFor(c), instance.ServiceMeta) {
+						matched[i] = true
+						c.Selector = nil
+						consumers = append(consumers, c)
+					}
+				}
+			}
+			if len(consumers) == 0 {
+				continue
+			}
+			for _, c := range unrestricted[svc.Namespace] {
+				if !slices.ContainsFunc(consumers, func(o structs.ServiceConsumer) bool {
+					return o.Partition == c.Partition && o.Peer == c.Peer && o.SamenessGroup == c.SamenessGroup
+				}) {
+					consumers = append(consumers, c)
+				}
+			}
+
+			services = append(services, structs.ExportedService{
+				Name:      sn.Service.Name,
+				Namespace: sn.Service.NamespaceOrDefault(),
+				Consumers: consumers,
+			})
+		}
+	}
+
+	exports.Services = services
+	return maxIdx, nil
+}
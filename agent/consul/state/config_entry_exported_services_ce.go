@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/configentry"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/proto/private/pbconfigentry"
 	"github.com/hashicorp/go-memdb"
 )
@@ -25,8 +26,16 @@ func getSimplifiedExportedServices(
 	if exports == nil {
 		return idx, nil, err
 	}
+	if err != nil {
+		return 0, nil, err
+	}
 	simple := SimplifiedExportedServices(*exports)
-	return idx, &simple, err
+
+	selectorIdx, err := resolveExportSelectorsTxn(tx, ws, entMeta, &simple)
+	if err != nil {
+		return 0, nil, err
+	}
+	return lib.MaxUint64(idx, selectorIdx), &simple, nil
 }
 
 func (s *Store) GetSimplifiedExportedServices(ws memdb.WatchSet, entMeta acl.EnterpriseMeta) (uint64, *SimplifiedExportedServices, error) {
@@ -223,6 +223,96 @@ func TestStore_ResolvedExportingServices(t *testing.T) {
 	})
 }
 
+func TestStore_ResolvedExportedServices_Selectors(t *testing.T) {
+	s := NewStateStore(nil)
+	var c indexCounter
+
+	require.NoError(t, s.EnsureNode(c.Next(), &structs.Node{
+		Node: "foo", Address: "127.0.0.1",
+	}))
+	require.NoError(t, s.EnsureService(c.Next(), "foo", &structs.NodeService{
+		ID: "billing", Service: "billing", Port: 5000,
+		Meta: map[string]string{"team": "payments", "tier": "public"},
+	}))
+	require.NoError(t, s.EnsureService(c.Next(), "foo", &structs.NodeService{
+		ID: "ledger", Service: "ledger", Port: 5000,
+		Meta: map[string]string{"team": "payments"},
+	}))
+	require.NoError(t, s.EnsureService(c.Next(), "foo", &structs.NodeService{
+		ID: "web", Service: "web", Port: 5000,
+	}))
+
+	require.NoError(t, s.EnsureConfigEntry(c.Next(), &structs.ExportedServicesConfigEntry{
+		Name: "default",
+		Services: []structs.ExportedService{
+			{
+				Name:     "*",
+				Selector: map[string]string{"team": "payments"},
+				Consumers: []structs.ServiceConsumer{
+					{Peer: "east"},
+					{Peer: "west", Selector: map[string]string{"tier": "public"}},
+				},
+			},
+			{
+				Name: "*",
+				Consumers: []structs.ServiceConsumer{
+					{Peer: "north"},
+				},
+			},
+		},
+	}))
+
+	resolve := func(t *testing.T, ws memdb.WatchSet) []*pbconfigentry.ResolvedExportedService {
+		_, services, err := s.ResolvedExportedServices(ws, structs.DefaultEnterpriseMetaInDefaultPartition())
+		require.NoError(t, err)
+		return services
+	}
+	exported := func(name string, peers ...string) *pbconfigentry.ResolvedExportedService {
+		return &pbconfigentry.ResolvedExportedService{
+			Service:   name,
+			Consumers: &pbconfigentry.Consumers{Peers: peers},
+		}
+	}
+
+	ws := memdb.NewWatchSet()
+	require.Equal(t, []*pbconfigentry.ResolvedExportedService{
+		exported("billing", "east", "north", "west"),
+		exported("ledger", "east", "north"),
+		exported("web", "north"),
+	}, resolve(t, ws))
+
+	t.Run("exports follow services that start matching", func(t *testing.T) {
+		require.NoError(t, s.EnsureService(c.Next(), "foo", &structs.NodeService{
+			ID: "web", Service: "web", Port: 5000,
+			Meta: map[string]string{"team": "payments"},
+		}))
+		require.True(t, watchFired(ws))
+
+		ws = memdb.NewWatchSet()
+		require.Equal(t, []*pbconfigentry.ResolvedExportedService{
+			exported("billing", "east", "north", "west"),
+			exported("ledger", "east", "north"),
+			exported("web", "east", "north"),
+		}, resolve(t, ws))
+	})
+
+	t.Run("exports follow services that disappear", func(t *testing.T) {
+		require.NoError(t, s.DeleteService(c.Next(), "foo", "billing", nil, ""))
+		require.True(t, watchFired(ws))
+
+		require.Equal(t, []*pbconfigentry.ResolvedExportedService{
+			exported("ledger", "east", "north"),
+			exported("web", "east", "north"),
+		}, resolve(t, nil))
+	})
+
+	t.Run("peers for service", func(t *testing.T) {
+		_, peers, err := peersForServiceTxn(s.db.ReadTxn(), nil, "ledger", structs.DefaultEnterpriseMetaInDefaultPartition())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"east", "north"}, peers)
+	})
+}
+
 func TestStore_getUniqueExportedServices(t *testing.T) {
 
 	exportedServices := []structs.ExportedService{
@@ -440,7 +440,8 @@ func readSourceIntentionsFromConfigEntriesForServiceTxn(
 		}
 
 		for _, src := range entry.Sources {
-			if src.SourceServiceName() == sn {
+			// Disabled intentions are only returned when listing them.
+			if src.SourceServiceName() == sn && !src.Disabled {
 				canAdd, err := intentionMatches(targetType, kind, entry.HasWildcardDestination())
 				if err != nil {
 					return nil, err
@@ -498,7 +499,11 @@ func readDestinationIntentionsFromConfigEntriesTxn(tx ReadTxn, ws memdb.WatchSet
 		if err != nil {
 			return 0, nil, err
 		} else if entry != nil {
-			results = append(results, entry.ToIntentions()...)
+			for _, src := range entry.Sources {
+				if !src.Disabled {
+					results = append(results, entry.ToIntention(src))
+				}
+			}
 		}
 	}
 	// Sort the results by precedence
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/pkg/errors"
@@ -13,11 +14,13 @@ import (
 )
 
 const (
-	tableConnectCABuiltin       = "connect-ca-builtin"
-	tableConnectCABuiltinSerial = "connect-ca-builtin-serial"
-	tableConnectCAConfig        = "connect-ca-config"
-	tableConnectCARoots         = "connect-ca-roots"
-	tableConnectCALeafCerts     = "connect-ca-leaf-certs"
+	tableConnectCABuiltin         = "connect-ca-builtin"
+	tableConnectCABuiltinSerial   = "connect-ca-builtin-serial"
+	tableConnectCAConfig          = "connect-ca-config"
+	tableConnectCARoots           = "connect-ca-roots"
+	tableConnectCALeafCerts       = "connect-ca-leaf-certs"
+	tableConnectCARevocations     = "connect-ca-revocations"
+	tableConnectCARevocationLists = "connect-ca-revocation-lists"
 )
 
 // caBuiltinProviderTableSchema returns a new table schema used for storing
@@ -77,6 +80,44 @@ func caRootTableSchema() *memdb.TableSchema {
 	}
 }
 
+// caRevocationTableSchema returns a new table schema used for storing the
+// certificates revoked by the Connect CA.
+func caRevocationTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tableConnectCARevocations,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "SerialNumber",
+				},
+			},
+		},
+	}
+}
+
+// caRevocationListsTableSchema returns a new table schema used for storing
+// the certificate revocation lists of all the datacenters.
+func caRevocationListsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tableConnectCARevocationLists,
+		Indexes: map[string]*memdb.IndexSchema{
+			// This table only stores one row, so this just ignores the ID field
+			// and always overwrites the same lists.
+			"id": {
+				Name:         "id",
+				AllowMissing: true,
+				Unique:       true,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: func(obj interface{}) (bool, error) { return true, nil },
+				},
+			},
+		},
+	}
+}
+
 // CAConfig is used to pull the CA config from the snapshot.
 func (s *Snapshot) CAConfig() (*structs.CAConfiguration, error) {
 	c, err := s.tx.First(tableConnectCAConfig, "id")
@@ -498,3 +539,198 @@ func (s *Store) CAIncrementProviderSerialNumber(idx uint64) (uint64, error) {
 	err = tx.Commit()
 	return next, err
 }
+
+// CARevokedCerts is used to pull the revoked certificates from the snapshot.
+func (s *Snapshot) CARevokedCerts() (structs.CARevokedCerts, error) {
+	iter, err := s.tx.Get(tableConnectCARevocations, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret structs.CARevokedCerts
+	for wrapped := iter.Next(); wrapped != nil; wrapped = iter.Next() {
+		ret = append(ret, wrapped.(*structs.CARevokedCert))
+	}
+
+	return ret, nil
+}
+
+// CARevokedCert is used when restoring from a snapshot.
+func (s *Restore) CARevokedCert(cert *structs.CARevokedCert) error {
+	if err := s.tx.Insert(tableConnectCARevocations, cert); err != nil {
+		return fmt.Errorf("failed restoring CA revocation: %s", err)
+	}
+	if err := indexUpdateMaxTxn(s.tx, cert.ModifyIndex, tableConnectCARevocations); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return nil
+}
+
+// CARevokedCerts returns the certificates revoked by the Connect CA, sorted
+// by serial number.
+func (s *Store) CARevokedCerts(ws memdb.WatchSet) (uint64, structs.CARevokedCerts, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	return caRevokedCertsTxn(tx, ws)
+}
+
+func caRevokedCertsTxn(tx ReadTxn, ws memdb.WatchSet) (uint64, structs.CARevokedCerts, error) {
+	idx := maxIndexTxn(tx, tableConnectCARevocations)
+
+	iter, err := tx.Get(tableConnectCARevocations, "id")
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed CA revocation lookup: %s", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var results structs.CARevokedCerts
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		results = append(results, v.(*structs.CARevokedCert))
+	}
+	return idx, results, nil
+}
+
+// CARevokeCerts records the given certificates as revoked. Certificates which
+// were already revoked keep their original revocation. Revocations of
+// certificates that expired before the new ones were revoked are removed,
+// since expired certificates are no longer trusted anyway.
+func (s *Store) CARevokeCerts(idx uint64, certs []*structs.CARevokedCert) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	var now time.Time
+	for _, cert := range certs {
+		if cert.RevokedAt.After(now) {
+			now = cert.RevokedAt
+		}
+	}
+
+	iter, err := tx.Get(tableConnectCARevocations, "id")
+	if err != nil {
+		return fmt.Errorf("failed CA revocation lookup: %s", err)
+	}
+	var expired []*structs.CARevokedCert
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		if existing := v.(*structs.CARevokedCert); existing.NotAfter.Before(now) {
+			expired = append(expired, existing)
+		}
+	}
+	for _, cert := range expired {
+		if err := tx.Delete(tableConnectCARevocations, cert); err != nil {
+			return fmt.Errorf("failed deleting expired CA revocation: %s", err)
+		}
+	}
+
+	for _, cert := range certs {
+		existing, err := tx.First(tableConnectCARevocations, "id", cert.SerialNumber)
+		if err != nil {
+			return fmt.Errorf("failed CA revocation lookup: %s", err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		// Copy the revocation so the caller's value isn't modified.
+		revoked := *cert
+		revoked.CreateIndex = idx
+		revoked.ModifyIndex = idx
+		if err := tx.Insert(tableConnectCARevocations, &revoked); err != nil {
+			return fmt.Errorf("failed inserting CA revocation: %s", err)
+		}
+	}
+
+	if err := tx.Insert(tableIndex, &IndexEntry{tableConnectCARevocations, idx}); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return tx.Commit()
+}
+
+// CARevocationLists is used to pull the revocation lists from the snapshot.
+func (s *Snapshot) CARevocationLists() (*structs.CARevocationLists, error) {
+	l, err := s.tx.First(tableConnectCARevocationLists, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	lists, ok := l.(*structs.CARevocationLists)
+	if !ok {
+		return nil, nil
+	}
+
+	return lists, nil
+}
+
+// CARevocationLists is used when restoring from a snapshot.
+func (s *Restore) CARevocationLists(lists *structs.CARevocationLists) error {
+	if err := s.tx.Insert(tableConnectCARevocationLists, lists); err != nil {
+		return fmt.Errorf("failed restoring CA revocation lists: %s", err)
+	}
+	if err := indexUpdateMaxTxn(s.tx, lists.ModifyIndex, tableConnectCARevocationLists); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return nil
+}
+
+// CARevocationLists returns the certificate revocation lists of all the
+// datacenters, or nil when there are none.
+func (s *Store) CARevocationLists(ws memdb.WatchSet) (uint64, *structs.CARevocationLists, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	return caRevocationListsTxn(tx, ws)
+}
+
+func caRevocationListsTxn(tx ReadTxn, ws memdb.WatchSet) (uint64, *structs.CARevocationLists, error) {
+	idx := maxIndexTxn(tx, tableConnectCARevocationLists)
+
+	ch, l, err := tx.FirstWatch(tableConnectCARevocationLists, "id")
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed CA revocation lists lookup: %s", err)
+	}
+	ws.Add(ch)
+
+	lists, ok := l.(*structs.CARevocationLists)
+	if !ok {
+		return idx, nil, nil
+	}
+	return idx, lists, nil
+}
+
+// CASetRevocationLists replaces the certificate revocation lists of all the
+// datacenters. Nil lists remove them.
+func (s *Store) CASetRevocationLists(idx uint64, lists *structs.CARevocationLists) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	if lists == nil {
+		if _, err := tx.DeleteAll(tableConnectCARevocationLists, "id"); err != nil {
+			return fmt.Errorf("failed deleting CA revocation lists: %s", err)
+		}
+	} else {
+		existing, err := tx.First(tableConnectCARevocationLists, "id")
+		if err != nil {
+			return fmt.Errorf("failed CA revocation lists lookup: %s", err)
+		}
+
+		// Copy the lists so the caller's value isn't modified.
+		updated := *lists
+		updated.CreateIndex = idx
+		if prev, ok := existing.(*structs.CARevocationLists); ok {
+			updated.CreateIndex = prev.CreateIndex
+		}
+		updated.ModifyIndex = idx
+		if err := tx.Insert(tableConnectCARevocationLists, &updated); err != nil {
+			return fmt.Errorf("failed updating CA revocation lists: %s", err)
+		}
+	}
+
+	if err := tx.Insert(tableIndex, &IndexEntry{tableConnectCARevocationLists, idx}); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return tx.Commit()
+}
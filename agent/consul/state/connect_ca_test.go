@@ -6,6 +6,7 @@ package state
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/proto/private/prototest"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -495,3 +496,156 @@ func TestStore_CABuiltinProvider_Snapshot_Restore(t *testing.T) {
 		assert.Equal(t, state, res)
 	}
 }
+
+func TestStore_CARevokeCerts(t *testing.T) {
+	s := testStateStore(t)
+
+	now := time.Now()
+	ws := memdb.NewWatchSet()
+	idx, res, err := s.CARevokedCerts(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Empty(t, res)
+
+	expiring := &structs.CARevokedCert{
+		SerialNumber: "01",
+		RevokedAt:    now.Add(-2 * time.Hour),
+		NotAfter:     now.Add(-time.Minute),
+	}
+	require.NoError(t, s.CARevokeCerts(1, []*structs.CARevokedCert{expiring}))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	idx, res, err = s.CARevokedCerts(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), idx)
+	require.Len(t, res, 1)
+	require.Equal(t, uint64(1), res[0].CreateIndex)
+
+	// Revoking a certificate removes the revocations of the certificates
+	// that expired since.
+	revoked := &structs.CARevokedCert{
+		SerialNumber: "02",
+		Reason:       1,
+		RevokedAt:    now,
+		NotAfter:     now.Add(time.Hour),
+	}
+	require.NoError(t, s.CARevokeCerts(2, []*structs.CARevokedCert{revoked}))
+	require.True(t, watchFired(ws))
+
+	idx, res, err = s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), idx)
+	require.Len(t, res, 1)
+	require.Equal(t, "02", res[0].SerialNumber)
+	require.Equal(t, 1, res[0].Reason)
+
+	// Revoking a certificate again keeps the original revocation.
+	again := *revoked
+	again.Reason = 4
+	again.RevokedAt = now.Add(time.Minute)
+	require.NoError(t, s.CARevokeCerts(3, []*structs.CARevokedCert{&again}))
+
+	idx, res, err = s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), idx)
+	require.Len(t, res, 1)
+	require.Equal(t, 1, res[0].Reason)
+	require.Equal(t, uint64(2), res[0].ModifyIndex)
+}
+
+func TestStore_CASetRevocationLists(t *testing.T) {
+	s := testStateStore(t)
+
+	ws := memdb.NewWatchSet()
+	idx, res, err := s.CARevocationLists(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Nil(t, res)
+
+	lists := &structs.CARevocationLists{
+		Lists: []*structs.CADatacenterRevocationList{
+			{
+				Datacenter:   "dc1",
+				SigningKeyID: "aa:bb",
+				CRL:          []byte("crl"),
+				Revoked:      true,
+				NextUpdate:   time.Now().Add(time.Hour),
+			},
+		},
+	}
+	require.NoError(t, s.CASetRevocationLists(1, lists))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	idx, res, err = s.CARevocationLists(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), idx)
+	require.Len(t, res.Lists, 1)
+	require.Equal(t, "dc1", res.Lists[0].Datacenter)
+	require.Equal(t, uint64(1), res.CreateIndex)
+	require.Equal(t, uint64(1), res.ModifyIndex)
+
+	// Replacing the lists keeps the original create index.
+	lists.Lists = append(lists.Lists, &structs.CADatacenterRevocationList{
+		Datacenter:   "dc2",
+		SigningKeyID: "cc:dd",
+		CRL:          []byte("other"),
+	})
+	require.NoError(t, s.CASetRevocationLists(2, lists))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	idx, res, err = s.CARevocationLists(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), idx)
+	require.Len(t, res.Lists, 2)
+	require.Equal(t, uint64(1), res.CreateIndex)
+	require.Equal(t, uint64(2), res.ModifyIndex)
+
+	// Setting no lists removes them.
+	require.NoError(t, s.CASetRevocationLists(3, nil))
+	require.True(t, watchFired(ws))
+
+	idx, res, err = s.CARevocationLists(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), idx)
+	require.Nil(t, res)
+}
+
+func TestStore_CARevokeCerts_Snapshot_Restore(t *testing.T) {
+	s := testStateStore(t)
+
+	now := time.Now()
+	before := structs.CARevokedCerts{
+		{SerialNumber: "01", RevokedAt: now, NotAfter: now.Add(time.Hour)},
+		{SerialNumber: "02", Reason: 1, RevokedAt: now, NotAfter: now.Add(time.Hour)},
+	}
+	require.NoError(t, s.CARevokeCerts(98, before))
+
+	// Take a snapshot.
+	snap := s.Snapshot()
+	defer snap.Close()
+
+	// Modify the state store.
+	require.NoError(t, s.CARevokeCerts(99, []*structs.CARevokedCert{
+		{SerialNumber: "03", RevokedAt: now, NotAfter: now.Add(time.Hour)},
+	}))
+
+	snapped, err := snap.CARevokedCerts()
+	require.NoError(t, err)
+	require.Len(t, snapped, 2)
+
+	// Restore onto a new state store.
+	s2 := testStateStore(t)
+	restore := s2.Restore()
+	for _, cert := range snapped {
+		require.NoError(t, restore.CARevokedCert(cert))
+	}
+	require.NoError(t, restore.Commit())
+
+	idx, res, err := s2.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(98), idx)
+	require.Equal(t, snapped, res)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/configentry"
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
 )
@@ -1186,3 +1187,103 @@ func (s *Store) intentionTopologyTxn(
 	}
 	return maxIdx, result, err
 }
+
+// IntentionDependencies describes the state that intention match results and
+// decisions are derived from. It lets callers that cache those results work
+// out which of them a later write may have invalidated.
+type IntentionDependencies struct {
+	// Index is the highest index of the config entry, legacy intention and
+	// system metadata tables.
+	Index uint64
+
+	// CatalogIndex is the index of the services table. Intention results
+	// only depend on the catalog when Catalog is true.
+	CatalogIndex uint64
+
+	// Catalog is true when a service-defaults config entry declares a
+	// terminating gateway destination. Whether such a destination is also
+	// registered in the catalog changes which intentions match it.
+	Catalog bool
+
+	// Legacy is true while intentions are still stored in the legacy
+	// intentions table rather than in config entries.
+	Legacy bool
+
+	// Destinations maps the destination of every service-intentions config
+	// entry to the entry's ModifyIndex.
+	Destinations map[structs.ServiceName]uint64
+
+	// Shared maps every other config entry that can change the intentions
+	// matched for any service to its ModifyIndex. These are sameness groups
+	// and service-defaults entries that declare a destination.
+	Shared map[configentry.KindName]uint64
+}
+
+// IntentionDependenciesIndex returns the highest index of the tables that
+// intention match results are read from, and adds watches on them to ws. The
+// services table is only included when withCatalog is true.
+func (s *Store) IntentionDependenciesIndex(ws memdb.WatchSet, withCatalog bool) uint64 {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	tables := []string{tableConfigEntries, tableConnectIntentions, tableSystemMetadata}
+	if withCatalog {
+		tables = append(tables, tableServices)
+	}
+	return maxIndexWatchTxn(tx, ws, tables...)
+}
+
+// IntentionDependencies returns a summary of the state that intention match
+// results and decisions are derived from.
+func (s *Store) IntentionDependencies() (*IntentionDependencies, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	deps := &IntentionDependencies{
+		Index:        maxIndexTxn(tx, tableConfigEntries, tableConnectIntentions, tableSystemMetadata),
+		CatalogIndex: maxIndexTxn(tx, tableServices),
+		Destinations: make(map[structs.ServiceName]uint64),
+		Shared:       make(map[configentry.KindName]uint64),
+	}
+
+	usingConfigEntries, err := areIntentionsInConfigEntries(tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	deps.Legacy = !usingConfigEntries
+
+	iter, err := getAllConfigEntriesByKindWithTxn(tx, structs.ServiceIntentions)
+	if err != nil {
+		return nil, fmt.Errorf("failed config entry lookup: %s", err)
+	}
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		entry := v.(*structs.ServiceIntentionsConfigEntry)
+		deps.Destinations[entry.DestinationServiceName()] = entry.GetRaftIndex().ModifyIndex
+	}
+
+	iter, err = getAllConfigEntriesByKindWithTxn(tx, structs.SamenessGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed config entry lookup: %s", err)
+	}
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		entry := v.(structs.ConfigEntry)
+		kn := configentry.NewKindName(entry.GetKind(), entry.GetName(), entry.GetEnterpriseMeta())
+		deps.Shared[kn] = entry.GetRaftIndex().ModifyIndex
+	}
+
+	iter, err = getAllConfigEntriesByKindWithTxn(tx, structs.ServiceDefaults)
+	if err != nil {
+		return nil, fmt.Errorf("failed config entry lookup: %s", err)
+	}
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		entry, ok := v.(*structs.ServiceConfigEntry)
+		if !ok || entry.Destination == nil {
+			continue
+		}
+		kn := configentry.NewKindName(entry.GetKind(), entry.GetName(), entry.GetEnterpriseMeta())
+		deps.Shared[kn] = entry.GetRaftIndex().ModifyIndex
+		deps.Catalog = true
+	}
+
+	return deps, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 
 	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/proto/private/pbsubscribe"
 )
 
@@ -56,6 +57,10 @@ type changeTrackerDB struct {
 	db             *memdb.MemDB
 	publisher      EventPublisher
 	processChanges func(ReadTxn, Changes) ([]stream.Event, error)
+
+	// openConfigEntry is applied to the config entries of the events when
+	// they are sent to subscribers, see Store.SetConfigEntryOpener.
+	openConfigEntry func(structs.ConfigEntry) structs.ConfigEntry
 }
 
 type EventPublisher interface {
@@ -98,6 +103,13 @@ func (c *changeTrackerDB) WriteTxn(idx uint64) *txn {
 		publish:    c.publisher.Publish,
 		prePublish: c.processChanges,
 	}
+	if open := c.openConfigEntry; open != nil {
+		t.prePublish = func(tx ReadTxn, changes Changes) ([]stream.Event, error) {
+			events, err := c.processChanges(tx, changes)
+			setConfigEntryOpener(events, open)
+			return events, err
+		}
+	}
 	t.Txn.TrackChanges()
 	return t
 }
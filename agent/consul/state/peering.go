@@ -874,6 +874,28 @@ func exportedServicesForPeerTxn(
 		}
 	}
 
+	// The ACL policies attached to the peering constrain what is exported on top
+	// of the exported-services config entries.
+	if len(peering.ACLPolicies) > 0 {
+		idx, authz, err := peeringAuthorizerTxn(tx, ws, peering)
+		if err != nil {
+			return 0, nil, err
+		}
+		if idx > maxIdx {
+			maxIdx = idx
+		}
+		for sn := range exportedServices {
+			if !peeringServiceAllowed(authz, sn) {
+				delete(exportedServices, sn)
+			}
+		}
+		for sn := range exportedConnectServices {
+			if !peeringServiceAllowed(authz, sn) {
+				delete(exportedConnectServices, sn)
+			}
+		}
+	}
+
 	// At least one of the following should be true for a name to replicate it as a *connect* service:
 	// - are a discovery chain by definition (service-router, service-splitter, service-resolver)
 	// - have an explicit sidecar kind=connect-proxy
@@ -1016,6 +1038,46 @@ func exportedServicesForPeerTxn(
 	return maxIdx, list, nil
 }
 
+// peeringAuthorizerTxn returns an authorizer denying by default and granting
+// what the ACL policies attached to the peering grant. The policies that don't
+// exist are ignored.
+func peeringAuthorizerTxn(tx ReadTxn, ws memdb.WatchSet, peering *pbpeering.Peering) (uint64, acl.Authorizer, error) {
+	var (
+		maxIdx   uint64
+		policies []*acl.Policy
+	)
+	entMeta := structs.DefaultEnterpriseMetaInPartition(peering.Partition)
+	for _, name := range peering.ACLPolicies {
+		policy, err := getPolicyWithTxn(tx, ws, name, aclPolicyGetByName, entMeta)
+		if err != nil {
+			return 0, nil, err
+		}
+		if policy == nil {
+			continue
+		}
+		if policy.ModifyIndex > maxIdx {
+			maxIdx = policy.ModifyIndex
+		}
+		parsed, err := acl.NewPolicyFromSource(policy.Rules, nil, policy.EnterprisePolicyMeta())
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to parse ACL policy %q of peering %q: %w", name, peering.Name, err)
+		}
+		policies = append(policies, parsed)
+	}
+
+	authz, err := acl.NewPolicyAuthorizerWithDefaults(acl.DenyAll(), policies, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to build the ACL authorizer of peering %q: %w", peering.Name, err)
+	}
+	return maxIdx, authz, nil
+}
+
+func peeringServiceAllowed(authz acl.Authorizer, sn structs.ServiceName) bool {
+	var authzContext acl.AuthorizerContext
+	sn.FillAuthzContext(&authzContext)
+	return authz.ServiceRead(sn.Name, &authzContext) == acl.Allow
+}
+
 func listAllExportedServices(
 	ws memdb.WatchSet,
 	tx ReadTxn,
@@ -2303,6 +2303,89 @@ func TestStateStore_ExportedServicesForPeer(t *testing.T) {
 	})
 }
 
+func TestStateStore_ExportedServicesForPeer_ACLPolicies(t *testing.T) {
+	s := NewStateStore(nil)
+
+	var lastIdx uint64
+
+	lastIdx++
+	require.NoError(t, s.PeeringWrite(lastIdx, &pbpeering.PeeringWriteRequest{
+		Peering: &pbpeering.Peering{
+			ID:          testUUID(),
+			Name:        "my-peering",
+			ACLPolicies: []string{"peer-policy"},
+		},
+	}))
+	_, p, err := s.PeeringRead(nil, Query{Value: "my-peering"})
+	require.NoError(t, err)
+	require.NotNil(t, p)
+
+	lastIdx++
+	testRegisterNode(t, s, lastIdx, "foo")
+	for _, svc := range []string{"web", "db", "api"} {
+		lastIdx++
+		testRegisterService(t, s, lastIdx, "foo", svc)
+	}
+
+	entry := &structs.ExportedServicesConfigEntry{
+		Name: "default",
+		Services: []structs.ExportedService{
+			{
+				Name:      structs.WildcardSpecifier,
+				Consumers: []structs.ServiceConsumer{{Peer: "my-peering"}},
+			},
+		},
+	}
+	require.NoError(t, entry.Normalize())
+	lastIdx++
+	require.NoError(t, s.EnsureConfigEntry(lastIdx, entry))
+
+	exported := func(t *testing.T, ws memdb.WatchSet) []string {
+		t.Helper()
+		_, list, err := s.ExportedServicesForPeer(ws, p.ID, "dc1")
+		require.NoError(t, err)
+		var names []string
+		for _, sn := range list.Services {
+			names = append(names, sn.Name)
+		}
+		return names
+	}
+
+	ws := memdb.NewWatchSet()
+	testutil.RunStep(t, "missing policy exports nothing", func(t *testing.T) {
+		require.Empty(t, exported(t, ws))
+	})
+
+	testutil.RunStep(t, "policy restricts the exported services", func(t *testing.T) {
+		lastIdx++
+		require.NoError(t, s.ACLPolicySet(lastIdx, &structs.ACLPolicy{
+			ID:    testUUID(),
+			Name:  "peer-policy",
+			Rules: `service_prefix "we" { policy = "read" } service "db" { policy = "deny" } service "api" { policy = "read" }`,
+		}))
+		require.True(t, watchFired(ws))
+
+		ws = memdb.NewWatchSet()
+		require.Equal(t, []string{"api", "web"}, exported(t, ws))
+	})
+
+	testutil.RunStep(t, "exact names are restricted too", func(t *testing.T) {
+		entry := &structs.ExportedServicesConfigEntry{
+			Name: "default",
+			Services: []structs.ExportedService{
+				{Name: "db", Consumers: []structs.ServiceConsumer{{Peer: "my-peering"}}},
+				{Name: "web", Consumers: []structs.ServiceConsumer{{Peer: "my-peering"}}},
+			},
+		}
+		require.NoError(t, entry.Normalize())
+		lastIdx++
+		require.NoError(t, s.EnsureConfigEntry(lastIdx, entry))
+		require.True(t, watchFired(ws))
+
+		require.Equal(t, []string{"web"}, exported(t, nil))
+	})
+}
+
 func TestStateStore_PeeringsForService(t *testing.T) {
 	type testPeering struct {
 		peering *pbpeering.Peering
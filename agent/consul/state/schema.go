@@ -20,6 +20,8 @@ func newDBSchema() *memdb.DBSchema {
 		bindingRulesTableSchema,
 		caBuiltinProviderTableSchema,
 		caConfigTableSchema,
+		caRevocationListsTableSchema,
+		caRevocationTableSchema,
 		caRootTableSchema,
 		checksTableSchema,
 		configTableSchema,
@@ -115,6 +115,11 @@ type Store struct {
 
 	// lockDelay holds expiration times for locks associated with keys.
 	lockDelay *Delay
+
+	// nodeMetaIndexKeys and serviceMetaIndexKeys are the metadata keys
+	// included in the meta indexes of the nodes and services tables.
+	nodeMetaIndexKeys    metaIndexKeys
+	serviceMetaIndexKeys metaIndexKeys
 }
 
 // Snapshot is used to provide a point-in-time snapshot. It
@@ -146,8 +151,16 @@ type sessionCheck struct {
 
 // NewStateStore creates a new in-memory state storage layer.
 func NewStateStore(gc *TombstoneGC) *Store {
+	return newStateStore(gc, structs.MetaIndexConfig{})
+}
+
+func newStateStore(gc *TombstoneGC, metaIndexes structs.MetaIndexConfig) *Store {
+	nodeMetaIndexKeys := newMetaIndexKeys(metaIndexes.NodeKeys)
+	serviceMetaIndexKeys := newMetaIndexKeys(metaIndexes.ServiceKeys)
+
 	// Create the in-memory DB.
 	schema := newDBSchema()
+	applyMetaIndexConfig(schema, nodeMetaIndexKeys, serviceMetaIndexKeys)
 	db, err := memdb.NewMemDB(schema)
 	if err != nil {
 		// the only way for NewMemDB to error is if the schema is invalid. The
@@ -165,6 +178,8 @@ func NewStateStore(gc *TombstoneGC) *Store {
 			publisher:      stream.NoOpEventPublisher{},
 			processChanges: processDBChanges,
 		},
+		nodeMetaIndexKeys:    nodeMetaIndexKeys,
+		serviceMetaIndexKeys: serviceMetaIndexKeys,
 	}
 	return s
 }
@@ -176,6 +191,16 @@ func NewStateStoreWithEventPublisher(gc *TombstoneGC, publisher EventPublisher)
 	return store
 }
 
+// NewStateStoreWithMetaIndexes creates a state store like
+// NewStateStoreWithEventPublisher whose node and service metadata indexes
+// only include the keys declared in metaIndexes.
+func NewStateStoreWithMetaIndexes(gc *TombstoneGC, publisher EventPublisher, metaIndexes structs.MetaIndexConfig) *Store {
+	store := newStateStore(gc, metaIndexes)
+	store.db.publisher = publisher
+
+	return store
+}
+
 // Snapshot is used to create a point-in-time snapshot of the entire db.
 func (s *Store) Snapshot() *Snapshot {
 	tx := s.db.Txn(false)
@@ -151,6 +151,45 @@ func txnSession(tx WriteTxn, idx uint64, op *structs.TxnSessionOp) error {
 	return nil
 }
 
+// txnConfigEntry handles all ConfigEntry-related operations.
+func txnConfigEntry(tx WriteTxn, idx uint64, op *structs.TxnConfigEntryOp) error {
+	entry := op.Entry
+
+	var cas bool
+	switch op.Verb {
+	case api.ConfigEntrySet, api.ConfigEntryDelete:
+	case api.ConfigEntryCAS, api.ConfigEntryDeleteCAS:
+		cas = true
+	default:
+		return &UnsupportedFSMApplyPanicError{fmt.Errorf("unknown ConfigEntry verb %q", op.Verb)}
+	}
+
+	if cas {
+		existing, err := tx.First(tableConfigEntries, indexID, newConfigEntryQuery(entry))
+		if err != nil {
+			return fmt.Errorf("failed config entry lookup: %s", err)
+		}
+		// An index of 0 means that we are doing a set-if-not-exists.
+		var stale bool
+		switch {
+		case existing == nil:
+			stale = op.Index != 0 || op.Verb == api.ConfigEntryDeleteCAS
+		default:
+			stale = existing.(structs.ConfigEntry).GetRaftIndex().ModifyIndex != op.Index
+		}
+		if stale {
+			return fmt.Errorf("failed to %s config entry %s/%s, index is stale", op.Verb, entry.GetKind(), entry.GetName())
+		}
+	}
+
+	switch op.Verb {
+	case api.ConfigEntrySet, api.ConfigEntryCAS:
+		return ensureConfigEntryTxn(tx, idx, false, entry)
+	default:
+		return deleteConfigEntryTxn(tx, idx, entry.GetKind(), entry.GetName(), entry.GetEnterpriseMeta())
+	}
+}
+
 // txnLegacyIntention handles all Intention-related operations.
 //
 // Deprecated: see TxnOp.Intention description
@@ -390,6 +429,8 @@ func (s *Store) txnDispatch(tx WriteTxn, idx uint64, ops structs.TxnOps) (struct
 			ret, err = s.txnCheck(tx, idx, op.Check)
 		case op.Session != nil:
 			err = txnSession(tx, idx, op.Session)
+		case op.ConfigEntry != nil:
+			err = txnConfigEntry(tx, idx, op.ConfigEntry)
 		case op.Intention != nil:
 			// NOTE: this branch is deprecated and exists for backwards
 			// compatibility with pre-1.9.0 raft logs and during upgrades.
@@ -368,6 +368,114 @@ func TestStateStore_Txn_Service(t *testing.T) {
 	require.Equal(t, expectedServices, actual)
 }
 
+func TestStateStore_Txn_ConfigEntry(t *testing.T) {
+	s := testStateStore(t)
+
+	// Create some config entries.
+	entry := func(name, protocol string) *structs.ServiceConfigEntry {
+		return &structs.ServiceConfigEntry{
+			Kind:     structs.ServiceDefaults,
+			Name:     name,
+			Protocol: protocol,
+		}
+	}
+	require.NoError(t, s.EnsureConfigEntry(1, entry("web", "http")))
+	require.NoError(t, s.EnsureConfigEntry(2, entry("db", "tcp")))
+	require.NoError(t, s.EnsureConfigEntry(3, entry("cache", "tcp")))
+	require.NoError(t, s.EnsureConfigEntry(4, entry("api", "http")))
+
+	// Set up a transaction that hits every operation.
+	ops := structs.TxnOps{
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntrySet,
+				Entry: entry("web", "grpc"),
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryCAS,
+				Entry: entry("db", "http"),
+				Index: 2,
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryCAS,
+				Entry: entry("billing", "http2"),
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryDelete,
+				Entry: entry("cache", ""),
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryDeleteCAS,
+				Entry: entry("api", ""),
+				Index: 4,
+			},
+		},
+	}
+	results, errors := s.TxnRW(5, ops)
+	require.Empty(t, errors)
+	require.Empty(t, results)
+
+	// Make sure the state store looks as expected.
+	idx, entries, err := s.ConfigEntriesByKind(nil, structs.ServiceDefaults, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	protocols := make(map[string]string)
+	for _, e := range entries {
+		protocols[e.GetName()] = e.(*structs.ServiceConfigEntry).Protocol
+	}
+	require.Equal(t, map[string]string{"web": "grpc", "db": "http", "billing": "http2"}, protocols)
+
+	// The CAS operations fail on a stale index, rolling back the transaction.
+	ops = structs.TxnOps{
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntrySet,
+				Entry: entry("cache", "tcp"),
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryCAS,
+				Entry: entry("web", "http"),
+				Index: 1,
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryCAS,
+				Entry: entry("db", "tcp"),
+			},
+		},
+		&structs.TxnOp{
+			ConfigEntry: &structs.TxnConfigEntryOp{
+				Verb:  api.ConfigEntryDeleteCAS,
+				Entry: entry("api", ""),
+			},
+		},
+	}
+	results, errors = s.TxnRW(6, ops)
+	require.Nil(t, results)
+	require.Len(t, errors, 3)
+	require.Equal(t, 1, errors[0].OpIndex)
+	require.Contains(t, errors[0].What, "failed to cas config entry service-defaults/web, index is stale")
+	require.Equal(t, 2, errors[1].OpIndex)
+	require.Contains(t, errors[1].What, "failed to cas config entry service-defaults/db, index is stale")
+	require.Equal(t, 3, errors[2].OpIndex)
+	require.Contains(t, errors[2].What, "failed to delete-cas config entry service-defaults/api, index is stale")
+
+	_, cache, err := s.ConfigEntry(nil, structs.ServiceDefaults, "cache", nil)
+	require.NoError(t, err)
+	require.Nil(t, cache)
+}
+
 func TestStateStore_Txn_Checks(t *testing.T) {
 	s := testStateStore(t)
 
@@ -1461,6 +1569,13 @@ func TestStateStore_UnknownTxnOperationsPanic(t *testing.T) {
 				},
 			},
 		},
+		{
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: "crane-the-neck",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8,12 +8,15 @@ import (
 
 	"github.com/hashicorp/go-memdb"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
 const (
 	serviceNamesUsageTable      = "service-names"
 	kvUsageTable                = "kv-entries"
+	kvBytesUsageTable           = "kv-bytes"
+	intentionsUsageTable        = "intentions"
 	connectNativeInstancesTable = "connect-native"
 	connectPrefix               = "connect-mesh"
 
@@ -79,6 +82,15 @@ type ConfigEntryUsage struct {
 	EnterpriseConfigEntryUsage
 }
 
+// DataUsage contains the usage data which is reported alongside service
+// usage by the operator usage endpoint.
+type DataUsage struct {
+	KVEntries     int
+	ConfigEntries map[string]int
+	Peerings      int
+	ACLTokens     int
+}
+
 type uniqueServiceState int
 
 const (
@@ -139,11 +151,17 @@ func updateUsage(tx WriteTxn, changes Changes) error {
 
 		case "kvs":
 			usageDeltas[change.Table] += delta
+			usageDeltas[kvBytesUsageTable] += kvBytesDelta(change)
 			addEnterpriseKVUsage(usageDeltas, change)
 		case tableConfigEntries:
 			entry := changeObject(change).(structs.ConfigEntry)
 			usageDeltas[configEntryUsageTableName(entry.GetKind())] += delta
+			if entry.GetKind() == structs.ServiceIntentions {
+				usageDeltas[intentionsUsageTable] += intentionsDelta(change)
+			}
 			addEnterpriseConfigEntryUsage(usageDeltas, change)
+		case tableACLTokens:
+			usageDeltas[change.Table] += delta
 		}
 	}
 
@@ -316,6 +334,32 @@ func billableServiceInstancesDeltas(change memdb.Change, usageDeltas map[string]
 	}
 }
 
+// kvBytesDelta returns the change in the total size of the KV entry values
+// made by the given change.
+func kvBytesDelta(change memdb.Change) int {
+	var delta int
+	if change.Before != nil {
+		delta -= len(change.Before.(*structs.DirEntry).Value)
+	}
+	if change.After != nil {
+		delta += len(change.After.(*structs.DirEntry).Value)
+	}
+	return delta
+}
+
+// intentionsDelta returns the change in the number of intentions made by the
+// given change to a service-intentions config entry.
+func intentionsDelta(change memdb.Change) int {
+	var delta int
+	if change.Before != nil {
+		delta -= len(change.Before.(*structs.ServiceIntentionsConfigEntry).Sources)
+	}
+	if change.After != nil {
+		delta += len(change.After.(*structs.ServiceIntentionsConfigEntry).Sources)
+	}
+	return delta
+}
+
 // writeUsageDeltas will take in a map of IDs to deltas and update each
 // entry accordingly, checking for integer underflow. The index that is
 // passed in will be recorded on the entry as well.
@@ -511,6 +555,89 @@ func (s *Store) ConfigEntryUsage() (uint64, ConfigEntryUsage, error) {
 	return maxIdx, results, nil
 }
 
+// DataUsage returns the latest seen Raft index across the KV, config entry,
+// peering and ACL token usage data, the compiled usage data, and any errors.
+func (s *Store) DataUsage(ws memdb.WatchSet) (uint64, DataUsage, error) {
+	tx := s.db.ReadTxn()
+	defer tx.Abort()
+
+	var maxIdx uint64
+	lookup := func(id string) (int, error) {
+		entry, err := firstUsageEntry(ws, tx, id)
+		if err != nil {
+			return 0, err
+		}
+		if entry.Index > maxIdx {
+			maxIdx = entry.Index
+		}
+		return entry.Count, nil
+	}
+
+	var usage DataUsage
+	var err error
+	if usage.KVEntries, err = lookup("kvs"); err != nil {
+		return 0, DataUsage{}, fmt.Errorf("failed kvs lookup: %s", err)
+	}
+	if usage.Peerings, err = lookup(tablePeering); err != nil {
+		return 0, DataUsage{}, fmt.Errorf("failed peerings lookup: %s", err)
+	}
+	if usage.ACLTokens, err = lookup(tableACLTokens); err != nil {
+		return 0, DataUsage{}, fmt.Errorf("failed acl tokens lookup: %s", err)
+	}
+
+	usage.ConfigEntries = make(map[string]int)
+	for _, kind := range structs.AllConfigEntryKinds {
+		count, err := lookup(configEntryUsageTableName(kind))
+		if err != nil {
+			return 0, DataUsage{}, fmt.Errorf("failed config entry usage lookup: %s", err)
+		}
+		usage.ConfigEntries[kind] = count
+	}
+
+	return maxIdx, usage, nil
+}
+
+// QuotaUsage returns the latest seen Raft index and the usage of the objects
+// limited by quotas in the given namespace.
+func (s *Store) QuotaUsage(ws memdb.WatchSet, entMeta *acl.EnterpriseMeta) (uint64, structs.QuotaUsage, error) {
+	tx := s.db.ReadTxn()
+	defer tx.Abort()
+
+	var maxIdx uint64
+	lookup := func(id string) (int, error) {
+		entry, err := firstUsageEntry(ws, tx, id)
+		if err != nil {
+			return 0, err
+		}
+		if entry.Index > maxIdx {
+			maxIdx = entry.Index
+		}
+		return entry.Count, nil
+	}
+
+	var usage structs.QuotaUsage
+	var err error
+	if usage.Services, err = lookup(serviceNamesUsageTable); err != nil {
+		return 0, structs.QuotaUsage{}, fmt.Errorf("failed services lookup: %s", err)
+	}
+	if usage.KVBytes, err = lookup(kvBytesUsageTable); err != nil {
+		return 0, structs.QuotaUsage{}, fmt.Errorf("failed kvs lookup: %s", err)
+	}
+	if usage.Intentions, err = lookup(intentionsUsageTable); err != nil {
+		return 0, structs.QuotaUsage{}, fmt.Errorf("failed intentions lookup: %s", err)
+	}
+	if usage.ACLTokens, err = lookup(tableACLTokens); err != nil {
+		return 0, structs.QuotaUsage{}, fmt.Errorf("failed acl tokens lookup: %s", err)
+	}
+
+	results, err := compileEnterpriseQuotaUsage(ws, tx, entMeta, usage)
+	if err != nil {
+		return 0, structs.QuotaUsage{}, fmt.Errorf("failed quota usage lookup: %s", err)
+	}
+
+	return maxIdx, results, nil
+}
+
 func firstUsageEntry(ws memdb.WatchSet, tx ReadTxn, id string) (*UsageEntry, error) {
 	watch, usage, err := tx.FirstWatch(tableUsage, indexID, id)
 	if err != nil {
@@ -8,6 +8,7 @@ package state
 import (
 	"github.com/hashicorp/go-memdb"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 )
 
@@ -52,3 +53,7 @@ func compileEnterpriseKVUsage(tx ReadTxn, usage KVUsage) (KVUsage, error) {
 func compileEnterpriseConfigEntryUsage(tx ReadTxn, usage ConfigEntryUsage) (ConfigEntryUsage, error) {
 	return usage, nil
 }
+
+func compileEnterpriseQuotaUsage(ws memdb.WatchSet, tx ReadTxn, _ *acl.EnterpriseMeta, usage structs.QuotaUsage) (structs.QuotaUsage, error) {
+	return usage, nil
+}
@@ -642,3 +642,103 @@ func TestStateStore_Usage_ConfigEntries(t *testing.T) {
 		require.Equal(t, 1, usage.ConfigByKind[structs.ServiceIntentions])
 	})
 }
+
+func TestStateStore_Usage_DataUsage(t *testing.T) {
+	s := testACLStateStore(t)
+
+	// Only the anonymous token exists in the fresh ACL state store.
+	idx, usage, err := s.DataUsage(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), idx)
+	require.Equal(t, 0, usage.KVEntries)
+	require.Equal(t, 0, usage.Peerings)
+	require.Equal(t, 1, usage.ACLTokens)
+	for _, kind := range structs.AllConfigEntryKinds {
+		require.Equal(t, 0, usage.ConfigEntries[kind])
+	}
+
+	testSetKey(t, s, 3, "key-1", "0", nil)
+	testSetKey(t, s, 4, "key-2", "0", nil)
+	testRegisterPeering(t, s, 5, "test-peering1")
+	require.NoError(t, s.EnsureConfigEntry(6, &structs.ServiceConfigEntry{
+		Kind: structs.ServiceDefaults,
+		Name: "web",
+	}))
+	require.NoError(t, s.ACLTokenSet(7, &structs.ACLToken{
+		AccessorID: "8c50af2a-5d1a-4e2d-a4b7-3e9c5a3d6f7e",
+		SecretID:   "8c50af2a-5d1a-4e2d-a4b7-3e9c5a3d6f7f",
+	}))
+
+	ws := memdb.NewWatchSet()
+	idx, usage, err = s.DataUsage(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), idx)
+	require.Equal(t, 2, usage.KVEntries)
+	require.Equal(t, 1, usage.Peerings)
+	require.Equal(t, 2, usage.ACLTokens)
+	require.Equal(t, 1, usage.ConfigEntries[structs.ServiceDefaults])
+
+	require.NoError(t, s.KVSDelete(8, "key-1", nil))
+	require.True(t, watchFired(ws))
+
+	idx, usage, err = s.DataUsage(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), idx)
+	require.Equal(t, 1, usage.KVEntries)
+}
+
+func TestStateStore_Usage_QuotaUsage(t *testing.T) {
+	s := testACLStateStore(t)
+
+	// Only the anonymous token exists in the fresh ACL state store.
+	_, usage, err := s.QuotaUsage(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, structs.QuotaUsage{ACLTokens: 1}, usage)
+
+	testRegisterNode(t, s, 2, "node1")
+	testRegisterService(t, s, 3, "node1", "web")
+	testRegisterService(t, s, 4, "node1", "api")
+	testSetKey(t, s, 5, "key-1", "abcd", nil)
+	testSetKey(t, s, 6, "key-2", "ab", nil)
+	require.NoError(t, s.EnsureConfigEntry(7, &structs.ServiceIntentionsConfigEntry{
+		Kind: structs.ServiceIntentions,
+		Name: "web",
+		Sources: []*structs.SourceIntention{
+			{Name: "api", Action: structs.IntentionActionAllow},
+			{Name: "db", Action: structs.IntentionActionDeny},
+		},
+	}))
+
+	ws := memdb.NewWatchSet()
+	idx, usage, err := s.QuotaUsage(ws, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), idx)
+	require.Equal(t, structs.QuotaUsage{
+		Services:   2,
+		KVBytes:    6,
+		Intentions: 2,
+		ACLTokens:  1,
+	}, usage)
+
+	// Updating a key only accounts for the change in its size.
+	testSetKey(t, s, 8, "key-1", "a", nil)
+	require.True(t, watchFired(ws))
+	require.NoError(t, s.EnsureConfigEntry(9, &structs.ServiceIntentionsConfigEntry{
+		Kind: structs.ServiceIntentions,
+		Name: "web",
+		Sources: []*structs.SourceIntention{
+			{Name: "api", Action: structs.IntentionActionAllow},
+		},
+	}))
+	require.NoError(t, s.KVSDelete(10, "key-2", nil))
+
+	idx, usage, err = s.QuotaUsage(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), idx)
+	require.Equal(t, structs.QuotaUsage{
+		Services:   2,
+		KVBytes:    1,
+		Intentions: 1,
+		ACLTokens:  1,
+	}, usage)
+}
@@ -66,6 +66,13 @@ func (e EmptyReadRequest) IsRead() bool {
 	return true
 }
 
+// XDSSessions is used by the other servers to query the number of xDS streams
+// served by the local server, to rebalance the streams across servers.
+func (s *Status) XDSSessions(args EmptyReadRequest, reply *uint32) error {
+	*reply = s.server.xdsCapacityController.Sessions()
+	return nil
+}
+
 // RaftStats is used by Autopilot to query the raft stats of the local server.
 func (s *Status) RaftStats(args EmptyReadRequest, reply *structs.RaftStats) error {
 	stats := s.server.raft.Stats()
@@ -4,6 +4,7 @@
 package consul
 
 import (
+	"context"
 	"net"
 	"os"
 	"testing"
@@ -14,8 +15,10 @@ import (
 	msgpackrpc "github.com/hashicorp/consul-net-rpc/net-rpc-msgpackrpc"
 	"github.com/hashicorp/consul-net-rpc/net/rpc"
 
+	"github.com/hashicorp/consul/agent/grpc-external/limiter"
 	"github.com/hashicorp/consul/agent/pool"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 	"github.com/hashicorp/consul/testrpc"
 	"github.com/hashicorp/consul/tlsutil"
 )
@@ -182,3 +185,41 @@ func TestStatusPeers_ForwardDC(t *testing.T) {
 	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Status.Peers", &args, &out))
 	require.Equal(t, []string{s2.config.RPCAdvertise.String()}, out)
 }
+
+func TestStatusXDSSessions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	lim := limiter.NewSessionLimiter()
+	dir2, s2 := testServerWithDepsAndConfig(t, func(deps *Deps) {
+		deps.XDSStreamLimiter = lim
+	}, func(c *Config) {
+		c.Bootstrap = false
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	joinLAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	for i := 0; i < 2; i++ {
+		sess, err := lim.BeginSession()
+		require.NoError(t, err)
+		defer sess.End()
+	}
+
+	codec := rpcClient(t, s2)
+	var sessions uint32
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Status.XDSSessions", EmptyReadRequest{}, &sessions))
+	require.Equal(t, uint32(2), sessions)
+
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, []uint32{2}, s1.fetchServerXDSSessions(context.Background()))
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tokenusage tracks the usage of the ACL tokens. The agents track the
+// requests made with each token and periodically report them to the leader,
+// which aggregates them for the whole datacenter.
+package tokenusage
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// DefaultMaxTokens is the default number of tokens tracked.
+const DefaultMaxTokens = 10000
+
+// MaxSourceAddrs is the number of source addresses kept for each token.
+const MaxSourceAddrs = 10
+
+// Tracker holds the usage of a bounded number of tokens. When it is full, the
+// least recently used token is evicted to make room for a new one.
+type Tracker struct {
+	maxTokens int
+
+	mu    sync.Mutex
+	usage map[string]*structs.ACLTokenUsage
+	since time.Time
+}
+
+func NewTracker(maxTokens int) *Tracker {
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	return &Tracker{
+		maxTokens: maxTokens,
+		usage:     make(map[string]*structs.ACLTokenUsage),
+		since:     time.Now(),
+	}
+}
+
+// Record adds a request made with the token from the source address.
+func (t *Tracker) Record(accessorID, sourceAddr string, at time.Time) {
+	t.Merge([]structs.ACLTokenUsage{{
+		AccessorID:  accessorID,
+		Count:       1,
+		LastUsed:    at,
+		SourceAddrs: []string{sourceAddr},
+	}})
+}
+
+// Merge adds the usage reported by an agent.
+func (t *Tracker) Merge(usages []structs.ACLTokenUsage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, u := range usages {
+		if u.AccessorID == "" {
+			continue
+		}
+		existing, ok := t.usage[u.AccessorID]
+		if !ok {
+			if len(t.usage) >= t.maxTokens {
+				t.evictLocked()
+			}
+			existing = &structs.ACLTokenUsage{AccessorID: u.AccessorID}
+			t.usage[u.AccessorID] = existing
+		}
+
+		existing.Count += u.Count
+		if u.LastUsed.After(existing.LastUsed) {
+			existing.LastUsed = u.LastUsed
+		}
+		for _, addr := range u.SourceAddrs {
+			if len(existing.SourceAddrs) >= MaxSourceAddrs {
+				break
+			}
+			if addr != "" && !slices.Contains(existing.SourceAddrs, addr) {
+				existing.SourceAddrs = append(existing.SourceAddrs, addr)
+			}
+		}
+	}
+}
+
+// evictLocked drops the least recently used token. The caller must hold t.mu.
+func (t *Tracker) evictLocked() {
+	var oldest *structs.ACLTokenUsage
+	for _, u := range t.usage {
+		if oldest == nil || u.LastUsed.Before(oldest.LastUsed) {
+			oldest = u
+		}
+	}
+	if oldest != nil {
+		delete(t.usage, oldest.AccessorID)
+	}
+}
+
+// Drain returns the usage tracked since the last call and resets it.
+func (t *Tracker) Drain() []structs.ACLTokenUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]structs.ACLTokenUsage, 0, len(t.usage))
+	for _, u := range t.usage {
+		result = append(result, *u)
+	}
+	t.usage = make(map[string]*structs.ACLTokenUsage)
+	sort.Slice(result, func(i, j int) bool { return result[i].AccessorID < result[j].AccessorID })
+	return result
+}
+
+// Get returns the usage of the token, and whether it was used.
+func (t *Tracker) Get(accessorID string) (structs.ACLTokenUsage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.usage[accessorID]
+	if !ok {
+		return structs.ACLTokenUsage{AccessorID: accessorID}, false
+	}
+	result := *u
+	result.SourceAddrs = append([]string(nil), u.SourceAddrs...)
+	return result, true
+}
+
+// Since returns when the tracking started.
+func (t *Tracker) Since() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.since
+}
+
+// Reset drops the usage tracked so far, e.g. when the server loses the
+// leadership.
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage = make(map[string]*structs.ACLTokenUsage)
+	t.since = time.Now()
+}
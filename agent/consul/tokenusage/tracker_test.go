@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tokenusage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestTracker(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(2)
+
+	tracker.Record("a", "10.0.0.1", now.Add(-time.Minute))
+	tracker.Record("a", "10.0.0.1", now)
+	tracker.Record("a", "10.0.0.2", now.Add(-2*time.Minute))
+
+	usage, ok := tracker.Get("a")
+	require.True(t, ok)
+	require.Equal(t, structs.ACLTokenUsage{
+		AccessorID:  "a",
+		Count:       3,
+		LastUsed:    now,
+		SourceAddrs: []string{"10.0.0.1", "10.0.0.2"},
+	}, usage)
+
+	_, ok = tracker.Get("b")
+	require.False(t, ok)
+
+	// The least recently used token is evicted when the tracker is full.
+	tracker.Record("b", "10.0.0.1", now.Add(-time.Hour))
+	tracker.Record("c", "10.0.0.1", now)
+	_, ok = tracker.Get("b")
+	require.False(t, ok)
+	_, ok = tracker.Get("a")
+	require.True(t, ok)
+
+	drained := tracker.Drain()
+	require.Len(t, drained, 2)
+	require.Equal(t, "a", drained[0].AccessorID)
+	require.Equal(t, "c", drained[1].AccessorID)
+	require.Empty(t, tracker.Drain())
+
+	// Merging adds the reports of several agents.
+	tracker.Merge(drained)
+	tracker.Merge([]structs.ACLTokenUsage{{AccessorID: "a", Count: 2, LastUsed: now.Add(-time.Hour)}})
+	usage, _ = tracker.Get("a")
+	require.Equal(t, uint64(5), usage.Count)
+	require.Equal(t, now, usage.LastUsed)
+}
+
+func TestTracker_MaxSourceAddrs(t *testing.T) {
+	tracker := NewTracker(0)
+	for i := 0; i < 2*MaxSourceAddrs; i++ {
+		tracker.Record("a", fmt.Sprintf("10.0.0.%d", i), time.Now())
+	}
+	usage, _ := tracker.Get("a")
+	require.Equal(t, uint64(2*MaxSourceAddrs), usage.Count)
+	require.Len(t, usage.SourceAddrs, MaxSourceAddrs)
+}
+
+func TestTracker_Reset(t *testing.T) {
+	tracker := NewTracker(0)
+	since := tracker.Since()
+	tracker.Record("a", "10.0.0.1", time.Now())
+
+	tracker.Reset()
+	_, ok := tracker.Get("a")
+	require.False(t, ok)
+	require.False(t, tracker.Since().Before(since))
+}
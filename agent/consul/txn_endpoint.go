@@ -35,10 +35,15 @@ type Txn struct {
 }
 
 // preCheck is used to verify the incoming operations before any further
-// processing takes place. This checks things like ACLs.
-func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.TxnErrors {
+// processing takes place. This checks things like ACLs and the attestation
+// evidence of the nodes written by the catalog operations.
+func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps, attestation string) structs.TxnErrors {
 	var errors structs.TxnErrors
 
+	// Track the KV bytes added to each namespace by the transaction, so the
+	// quota is checked against the transaction as a whole.
+	kvBytes := make(map[acl.EnterpriseMeta]int)
+
 	// Perform the pre-apply checks for any KV operations.
 	for i, op := range ops {
 		switch {
@@ -55,6 +60,16 @@ func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.T
 					OpIndex: i,
 					What:    err.Error(),
 				})
+			} else {
+				entMeta := op.KV.DirEnt.EnterpriseMeta
+				delta, err := t.srv.checkKVSQuota(op.KV.Verb, &op.KV.DirEnt, kvBytes[entMeta])
+				if err != nil {
+					errors = append(errors, &structs.TxnError{
+						OpIndex: i,
+						What:    err.Error(),
+					})
+				}
+				kvBytes[entMeta] += delta
 			}
 		case op.Node != nil:
 			requiresPreApply, err := nodeVerbValidate(op.Node.Verb)
@@ -69,17 +84,7 @@ func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.T
 				break
 			}
 
-			node := op.Node.Node
-			if err := nodePreApply(node.Node, string(node.ID)); err != nil {
-				errors = append(errors, &structs.TxnError{
-					OpIndex: i,
-					What:    err.Error(),
-				})
-				break
-			}
-
-			// Check that the token has permissions for the given operation.
-			if err := vetNodeTxnOp(op.Node, authorizer); err != nil {
+			if err := t.nodePreApply(authorizer, op.Node, attestation); err != nil {
 				errors = append(errors, &structs.TxnError{
 					OpIndex: i,
 					What:    err.Error(),
@@ -98,8 +103,7 @@ func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.T
 				break
 			}
 
-			service := &op.Service.Service
-			if err := servicePreApply(service, authorizer, op.Service.FillAuthzContext); err != nil {
+			if err := t.servicePreApply(authorizer, op.Service, attestation); err != nil {
 				errors = append(errors, &structs.TxnError{
 					OpIndex: i,
 					What:    err.Error(),
@@ -118,10 +122,14 @@ func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.T
 				break
 			}
 
-			checkPreApply(&op.Check.Check)
-
-			// Check that the token has permissions for the given operation.
-			if err := vetCheckTxnOp(op.Check, authorizer); err != nil {
+			if err := t.checkPreApply(authorizer, op.Check, attestation); err != nil {
+				errors = append(errors, &structs.TxnError{
+					OpIndex: i,
+					What:    err.Error(),
+				})
+			}
+		case op.ConfigEntry != nil:
+			if err := t.configEntryPreApply(authorizer, op.ConfigEntry); err != nil {
 				errors = append(errors, &structs.TxnError{
 					OpIndex: i,
 					What:    err.Error(),
@@ -152,6 +160,260 @@ func (t *Txn) preCheck(authorizer resolver.Result, ops structs.TxnOps) structs.T
 	return errors
 }
 
+// configEntryPreApply runs the checks of ConfigEntry.Apply and
+// ConfigEntry.Delete on a config entry transaction operation.
+func (t *Txn) configEntryPreApply(authz resolver.Result, op *structs.TxnConfigEntryOp) error {
+	write, err := configEntryVerbValidate(op.Verb)
+	if err != nil {
+		return err
+	}
+	if op.Entry == nil {
+		return fmt.Errorf("missing config entry")
+	}
+
+	// Config entries are replicated from the primary datacenter, writing them
+	// anywhere else would be overwritten by the replication.
+	if t.srv.config.PrimaryDatacenter != "" && t.srv.config.Datacenter != t.srv.config.PrimaryDatacenter {
+		return fmt.Errorf("config entry operations must target the primary datacenter %q", t.srv.config.PrimaryDatacenter)
+	}
+
+	vet := func(entry structs.ConfigEntry) error {
+		if err := t.srv.validateEnterpriseRequest(entry.GetEnterpriseMeta(), true); err != nil {
+			return err
+		}
+		if !write {
+			if err := entry.Normalize(); err != nil {
+				return err
+			}
+			return structs.CanWriteConfigEntry(entry, authz)
+		}
+		ce := &ConfigEntry{srv: t.srv, logger: t.logger}
+		if err := ce.preflightCheck(entry.GetKind()); err != nil {
+			return err
+		}
+		return preApplyConfigEntryUpsert(entry, authz)
+	}
+
+	// The entry is only sent to the admission webhooks once it is known to be
+	// allowed, and the one they return is checked again.
+	if err := vet(op.Entry); err != nil {
+		return err
+	}
+	if t.srv.admission != nil {
+		req := structs.ConfigEntryRequest{Datacenter: t.srv.config.Datacenter, Entry: op.Entry}
+		if write {
+			if err := t.srv.admission.admitConfigEntryUpsert(&req); err != nil {
+				return err
+			}
+			if err := vet(req.Entry); err != nil {
+				return err
+			}
+			op.Entry = req.Entry
+		} else if err := t.srv.admission.admitConfigEntryDelete(&req); err != nil {
+			return err
+		}
+	}
+
+	entry := op.Entry
+	if intentions, ok := entry.(*structs.ServiceIntentionsConfigEntry); ok && write {
+		if err := t.srv.checkIntentionsQuota(intentions); err != nil {
+			return err
+		}
+	}
+	if write {
+		op.Entry, err = t.srv.sealConfigEntry(entry)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodePreApply validates a node transaction operation and checks it against
+// the ACLs, before and after passing it to the admission webhooks. Writes are
+// then checked against the attestation evidence of the node.
+func (t *Txn) nodePreApply(authz resolver.Result, op *structs.TxnNodeOp, attestation string) error {
+	vet := func() error {
+		if err := nodePreApply(op.Node.Node, string(op.Node.ID)); err != nil {
+			return err
+		}
+		// Check that the token has permissions for the given operation.
+		return vetNodeTxnOp(op, authz)
+	}
+	if err := vet(); err != nil {
+		return err
+	}
+	if t.srv.admission != nil {
+		if err := t.admitNodeTxnOp(op); err != nil {
+			return err
+		}
+		if err := vet(); err != nil {
+			return err
+		}
+	}
+	if op.Verb != api.NodeSet && op.Verb != api.NodeCAS {
+		return nil
+	}
+	return t.srv.checkNodeAttestation(op.Node.Node, op.Node.ID, op.Node.PeerName, attestation, op.Node.GetEnterpriseMeta())
+}
+
+// servicePreApply validates a service transaction operation and checks it
+// against the ACLs, before and after passing it to the admission webhooks.
+// Writes are then checked against the attestation evidence of the node.
+func (t *Txn) servicePreApply(authz resolver.Result, op *structs.TxnServiceOp, attestation string) error {
+	vet := func() error {
+		return servicePreApply(&op.Service, authz, op.FillAuthzContext)
+	}
+	if err := vet(); err != nil {
+		return err
+	}
+	if t.srv.admission != nil {
+		if err := t.admitServiceTxnOp(op); err != nil {
+			return err
+		}
+		if err := vet(); err != nil {
+			return err
+		}
+	}
+	if op.Verb != api.ServiceSet && op.Verb != api.ServiceCAS {
+		return nil
+	}
+	return t.srv.checkNodeAttestation(op.Node, "", op.Service.PeerName, attestation, &op.Service.EnterpriseMeta)
+}
+
+// checkPreApply validates a check transaction operation and checks it
+// against the ACLs, before and after passing it to the admission webhooks.
+// Writes are then checked against the attestation evidence of the node.
+func (t *Txn) checkPreApply(authz resolver.Result, op *structs.TxnCheckOp, attestation string) error {
+	vet := func() error {
+		checkPreApply(&op.Check)
+		// Check that the token has permissions for the given operation.
+		return vetCheckTxnOp(op, authz)
+	}
+	if err := vet(); err != nil {
+		return err
+	}
+	if t.srv.admission != nil {
+		if err := t.admitCheckTxnOp(op); err != nil {
+			return err
+		}
+		if err := vet(); err != nil {
+			return err
+		}
+	}
+	if op.Verb != api.CheckSet && op.Verb != api.CheckCAS {
+		return nil
+	}
+	return t.srv.checkNodeAttestation(op.Check.Node, "", op.Check.PeerName, attestation, &op.Check.EnterpriseMeta)
+}
+
+// admitNodeTxnOp runs the admission webhooks of Catalog.Register and
+// Catalog.Deregister on a node transaction operation, replacing the node with
+// the one returned by the mutating webhooks.
+func (t *Txn) admitNodeTxnOp(op *structs.TxnNodeOp) error {
+	node := &op.Node
+	switch op.Verb {
+	case api.NodeDelete, api.NodeDeleteCAS:
+		return t.srv.admission.admitDeregister(&structs.DeregisterRequest{
+			Datacenter:     t.srv.config.Datacenter,
+			Node:           node.Node,
+			PeerName:       node.PeerName,
+			EnterpriseMeta: *node.GetEnterpriseMeta(),
+		})
+	}
+
+	req := structs.RegisterRequest{
+		Datacenter:      t.srv.config.Datacenter,
+		ID:              node.ID,
+		Node:            node.Node,
+		Address:         node.Address,
+		TaggedAddresses: node.TaggedAddresses,
+		NodeMeta:        node.Meta,
+		Locality:        node.Locality,
+		PeerName:        node.PeerName,
+		EnterpriseMeta:  *node.GetEnterpriseMeta(),
+	}
+	if err := t.srv.admission.admitRegister(&req); err != nil {
+		return err
+	}
+	node.ID = req.ID
+	node.Node = req.Node
+	node.Address = req.Address
+	node.TaggedAddresses = req.TaggedAddresses
+	node.Meta = req.NodeMeta
+	node.Locality = req.Locality
+	return nil
+}
+
+// admitServiceTxnOp runs the admission webhooks of Catalog.Register and
+// Catalog.Deregister on a service transaction operation, replacing the service
+// with the one returned by the mutating webhooks.
+func (t *Txn) admitServiceTxnOp(op *structs.TxnServiceOp) error {
+	switch op.Verb {
+	case api.ServiceDelete, api.ServiceDeleteCAS:
+		return t.srv.admission.admitDeregister(&structs.DeregisterRequest{
+			Datacenter:     t.srv.config.Datacenter,
+			Node:           op.Node,
+			ServiceID:      op.Service.ID,
+			PeerName:       op.Service.PeerName,
+			EnterpriseMeta: op.Service.EnterpriseMeta,
+		})
+	}
+
+	service := op.Service
+	req := structs.RegisterRequest{
+		Datacenter:     t.srv.config.Datacenter,
+		Node:           op.Node,
+		Service:        &service,
+		SkipNodeUpdate: true,
+		PeerName:       op.Service.PeerName,
+		EnterpriseMeta: op.Service.EnterpriseMeta,
+	}
+	if err := t.srv.admission.admitRegister(&req); err != nil {
+		return err
+	}
+	if req.Service == nil {
+		return fmt.Errorf("admission webhooks removed the service of the operation")
+	}
+	op.Node = req.Node
+	op.Service = *req.Service
+	return nil
+}
+
+// admitCheckTxnOp runs the admission webhooks of Catalog.Register and
+// Catalog.Deregister on a check transaction operation, replacing the check
+// with the one returned by the mutating webhooks.
+func (t *Txn) admitCheckTxnOp(op *structs.TxnCheckOp) error {
+	switch op.Verb {
+	case api.CheckDelete, api.CheckDeleteCAS:
+		return t.srv.admission.admitDeregister(&structs.DeregisterRequest{
+			Datacenter:     t.srv.config.Datacenter,
+			Node:           op.Check.Node,
+			CheckID:        op.Check.CheckID,
+			PeerName:       op.Check.PeerName,
+			EnterpriseMeta: op.Check.EnterpriseMeta,
+		})
+	}
+
+	check := op.Check
+	req := structs.RegisterRequest{
+		Datacenter:     t.srv.config.Datacenter,
+		Node:           op.Check.Node,
+		Check:          &check,
+		SkipNodeUpdate: true,
+		PeerName:       op.Check.PeerName,
+		EnterpriseMeta: op.Check.EnterpriseMeta,
+	}
+	if err := t.srv.admission.admitRegister(&req); err != nil {
+		return err
+	}
+	if req.Check == nil {
+		return fmt.Errorf("admission webhooks removed the check of the operation")
+	}
+	op.Check = *req.Check
+	return nil
+}
+
 // vetNodeTxnOp applies the given ACL policy to a node transaction operation.
 func vetNodeTxnOp(op *structs.TxnNodeOp, authz resolver.Result) error {
 	var authzContext acl.AuthorizerContext
@@ -194,7 +456,10 @@ func (t *Txn) Apply(args *structs.TxnRequest, reply *structs.TxnResponse) error
 	if err != nil {
 		return err
 	}
-	reply.Errors = t.preCheck(authz, args.Ops)
+	// The attestation evidence is only verified, never stored.
+	attestation := args.NodeAttestation
+	args.NodeAttestation = ""
+	reply.Errors = t.preCheck(authz, args.Ops, attestation)
 	if len(reply.Errors) > 0 {
 		return nil
 	}
@@ -209,6 +474,10 @@ func (t *Txn) Apply(args *structs.TxnRequest, reply *structs.TxnResponse) error
 	// just taking the two slices.
 	if txnResp, ok := resp.(structs.TxnResponse); ok {
 		txnResp.Results = FilterTxnResults(authz, txnResp.Results)
+		if err := t.srv.openTxnResults(txnResp.Results); err != nil {
+			return err
+		}
+		t.srv.redactTxnResults(authz, txnResp.Results)
 		*reply = txnResp
 	} else {
 		return fmt.Errorf("unexpected return type %T", resp)
@@ -246,7 +515,7 @@ func (t *Txn) Read(args *structs.TxnReadRequest, reply *structs.TxnReadResponse)
 	// KVCheckIndex, the txn fails and permission denied errors are returned.
 	//
 	// TODO: Maybe we should unify these, or at least cover it in the docs?
-	reply.Errors = t.preCheck(authz, args.Ops)
+	reply.Errors = t.preCheck(authz, args.Ops, "")
 	if len(reply.Errors) > 0 {
 		return nil
 	}
@@ -258,6 +527,10 @@ func (t *Txn) Read(args *structs.TxnReadRequest, reply *structs.TxnReadResponse)
 	total := len(reply.Results)
 	reply.Results = FilterTxnResults(authz, reply.Results)
 	reply.QueryMeta.ResultsFilteredByACLs = total != len(reply.Results)
+	if err := t.srv.openTxnResults(reply.Results); err != nil {
+		return err
+	}
+	t.srv.redactTxnResults(authz, reply.Results)
 
 	// We have to do this ourselves since we are not doing a blocking RPC.
 	t.srv.SetQueryMeta(&reply.QueryMeta, args.Token)
@@ -310,6 +583,20 @@ func checkVerbValidate(op api.CheckOp) (bool, error) {
 	}
 }
 
+// configEntryVerbValidate checks for a known operation type. It also
+// indicates if the operation writes the entry, rather than deleting it.
+func configEntryVerbValidate(op api.ConfigEntryOp) (bool, error) {
+	// enumcover: api.ConfigEntryOp
+	switch op {
+	case api.ConfigEntrySet, api.ConfigEntryCAS:
+		return true, nil
+	case api.ConfigEntryDelete, api.ConfigEntryDeleteCAS:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown config entry operation: %s", op)
+	}
+}
+
 // intentionVerbValidate checks for a known operation type.
 func intentionVerbValidate(op structs.IntentionOp) error {
 	// enumcover: structs.IntentionOp
@@ -6,6 +6,7 @@ package consul
 import (
 	"bytes"
 	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -620,6 +621,140 @@ func TestTxn_Apply_ACLDeny(t *testing.T) {
 	}
 }
 
+func TestTxn_Apply_ConfigEntry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.ACLsEnabled = true
+		c.ACLInitialManagementToken = "root"
+		c.ACLResolverSettings.ACLDefaultPolicy = "deny"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+	waitForLeaderEstablishment(t, s1)
+
+	state := s1.fsm.State()
+	require.NoError(t, state.EnsureConfigEntry(1, &structs.ServiceConfigEntry{
+		Kind: structs.ServiceDefaults,
+		Name: "test-svc",
+	}))
+	_, existing, err := state.ConfigEntry(nil, structs.ServiceDefaults, "test-svc", nil)
+	require.NoError(t, err)
+
+	// Apply config entries atomically, with a CAS on the existing entry.
+	arg := structs.TxnRequest{
+		Datacenter: "dc1",
+		Ops: structs.TxnOps{
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntryCAS,
+					Entry: &structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "test-svc",
+						Protocol: "http",
+					},
+					Index: existing.GetRaftIndex().ModifyIndex,
+				},
+			},
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntryCAS,
+					Entry: &structs.ServiceIntentionsConfigEntry{
+						Kind: structs.ServiceIntentions,
+						Name: "test-svc",
+						Sources: []*structs.SourceIntention{
+							{Name: "web", Action: structs.IntentionActionAllow},
+						},
+					},
+				},
+			},
+		},
+		WriteRequest: structs.WriteRequest{Token: "root"},
+	}
+	var out structs.TxnResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &arg, &out))
+	require.Empty(t, out.Errors)
+
+	_, entry, err := state.ConfigEntry(nil, structs.ServiceDefaults, "test-svc", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http", entry.(*structs.ServiceConfigEntry).Protocol)
+
+	_, entry, err = state.ConfigEntry(nil, structs.ServiceIntentions, "test-svc", nil)
+	require.NoError(t, err)
+	intentions := entry.(*structs.ServiceIntentionsConfigEntry)
+	require.Len(t, intentions.Sources, 1)
+	require.Equal(t, "web", intentions.Sources[0].Name)
+
+	// The operations are validated and the token needs write permissions on
+	// every entry.
+	token := createTokenFull(t, codec, testTxnRules)
+	arg = structs.TxnRequest{
+		Datacenter: "dc1",
+		Ops: structs.TxnOps{
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntrySet,
+					Entry: &structs.ServiceConfigEntry{
+						Kind:     structs.ServiceDefaults,
+						Name:     "test-svc",
+						Protocol: "tcp",
+					},
+				},
+			},
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntrySet,
+					Entry: &structs.ServiceConfigEntry{
+						Kind: structs.ServiceDefaults,
+						Name: "nope",
+					},
+				},
+			},
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntrySet,
+					Entry: &structs.ServiceIntentionsConfigEntry{
+						Kind: structs.ServiceIntentions,
+						Name: "test-svc",
+						Sources: []*structs.SourceIntention{
+							{Name: "web"},
+						},
+					},
+				},
+			},
+			&structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb: api.ConfigEntrySet,
+				},
+			},
+		},
+		WriteRequest: structs.WriteRequest{Token: token.SecretID},
+	}
+	out = structs.TxnResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Txn.Apply", &arg, &out))
+	require.Len(t, out.Errors, 3)
+	require.Equal(t, 1, out.Errors[0].OpIndex)
+	require.True(t, acl.IsErrPermissionDenied(errors.New(out.Errors[0].What)), out.Errors[0].What)
+	require.Equal(t, 2, out.Errors[1].OpIndex)
+	require.Contains(t, out.Errors[1].What, "Action must be set")
+	require.Equal(t, 3, out.Errors[2].OpIndex)
+	require.Contains(t, out.Errors[2].What, "missing config entry")
+
+	// Nothing was applied.
+	_, entry, err = state.ConfigEntry(nil, structs.ServiceDefaults, "test-svc", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http", entry.(*structs.ServiceConfigEntry).Protocol)
+}
+
 func TestTxn_Apply_LockDelay(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1050,6 +1185,19 @@ func TestTxn_Validation(t *testing.T) {
 			},
 			expectedError: "unknown intention operation",
 		},
+		{
+			request: structs.TxnReadRequest{
+				Datacenter: "dc1",
+				Ops: structs.TxnOps{
+					&structs.TxnOp{
+						ConfigEntry: &structs.TxnConfigEntryOp{
+							Verb: "tick",
+						},
+					},
+				},
+			},
+			expectedError: "unknown config entry operation",
+		},
 		{
 			request: structs.TxnReadRequest{
 				Datacenter: "dc1",
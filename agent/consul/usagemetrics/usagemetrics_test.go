@@ -382,6 +382,14 @@ var baseCases = map[string]testCase{
 					{Name: "kind", Value: "control-plane-request-limit"},
 				},
 			},
+			"consul.usage.test.state.config_entries;datacenter=dc1;kind=agent-defaults": {
+				Name:  "consul.usage.test.state.config_entries",
+				Value: 0,
+				Labels: []metrics.Label{
+					{Name: "datacenter", Value: "dc1"},
+					{Name: "kind", Value: "agent-defaults"},
+				},
+			},
 			// --- version ---
 			fmt.Sprintf("consul.usage.test.version;version=%s;pre_release=%s", versionWithMetadata(), version.VersionPrerelease): {
 				Name:  "consul.usage.test.version",
@@ -663,6 +671,14 @@ var baseCases = map[string]testCase{
 					{Name: "kind", Value: "control-plane-request-limit"},
 				},
 			},
+			"consul.usage.test.state.config_entries;datacenter=dc1;kind=agent-defaults": {
+				Name:  "consul.usage.test.state.config_entries",
+				Value: 0,
+				Labels: []metrics.Label{
+					{Name: "datacenter", Value: "dc1"},
+					{Name: "kind", Value: "agent-defaults"},
+				},
+			},
 			// --- version ---
 			fmt.Sprintf("consul.usage.test.version;version=%s;pre_release=%s", versionWithMetadata(), version.VersionPrerelease): {
 				Name:  "consul.usage.test.version",
@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/lib/retry"
+)
+
+// Types of the events posted to webhooks.
+const (
+	WebhookEventServiceRegistered   = "service-registered"
+	WebhookEventServiceDeregistered = "service-deregistered"
+	WebhookEventHealthChanged       = "health-changed"
+	WebhookEventConfigEntryChanged  = "config-entry-changed"
+)
+
+// Headers of the requests posted to webhooks.
+const (
+	// WebhookEventHeader holds the type of the event.
+	WebhookEventHeader = "X-Consul-Event"
+
+	// WebhookDeliveryHeader holds the ID of the event, which is the same
+	// for all the attempts to deliver it.
+	WebhookDeliveryHeader = "X-Consul-Delivery"
+
+	// WebhookTimestampHeader holds the Unix time the request was signed at.
+	WebhookTimestampHeader = "X-Consul-Timestamp"
+
+	// WebhookSignatureHeader holds the signature of the request, see
+	// WebhookSignature.
+	WebhookSignatureHeader = "X-Consul-Signature"
+)
+
+const (
+	// webhookQueueSize is how many events are queued for an endpoint before
+	// new events are dropped.
+	webhookQueueSize = 1024
+
+	// webhookDefaultTimeout is the timeout of the requests to the endpoints
+	// which do not set one.
+	webhookDefaultTimeout = 10 * time.Second
+)
+
+var (
+	metricsKeyWebhookDelivered = []string{"leader", "webhooks", "delivered"}
+	metricsKeyWebhookFailed    = []string{"leader", "webhooks", "failed"}
+	metricsKeyWebhookDropped   = []string{"leader", "webhooks", "dropped"}
+)
+
+var WebhookCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyWebhookDelivered,
+		Help: "Increments when an event is delivered to a webhook endpoint.",
+	},
+	{
+		Name: metricsKeyWebhookFailed,
+		Help: "Increments when an event could not be delivered to a webhook endpoint after all the retries.",
+	},
+	{
+		Name: metricsKeyWebhookDropped,
+		Help: "Increments when an event is dropped because the queue of a webhook endpoint is full.",
+	},
+}
+
+// WebhookConfig configures an HTTP endpoint the leader posts the changes of
+// the catalog and of the config entries to.
+type WebhookConfig struct {
+	// Name identifies the endpoint in the logs and metrics.
+	Name string
+
+	// URL is the address the events are posted to.
+	URL string
+
+	// Secret is the key the requests are signed with. Requests are not
+	// signed if it is empty.
+	Secret string
+
+	// Events are the types of the events posted to the endpoint, all of them
+	// if empty.
+	Events []string
+
+	// Services restricts the service events to the given services, and the
+	// config entry events to the entries of the given names.
+	Services []string
+
+	// Timeout is the timeout of each request.
+	Timeout time.Duration
+
+	// MaxRetries is how many times the delivery of an event is retried
+	// before it is given up.
+	MaxRetries int
+}
+
+// matches returns whether the event must be posted to the endpoint.
+func (c *WebhookConfig) matches(e *WebhookEvent) bool {
+	if len(c.Events) > 0 && !slices.Contains(c.Events, e.Type) {
+		return false
+	}
+	if len(c.Services) == 0 {
+		return true
+	}
+	switch {
+	case e.Service != nil:
+		return slices.Contains(c.Services, e.Service.Name)
+	case e.ConfigEntry != nil:
+		return slices.Contains(c.Services, e.ConfigEntry.Name)
+	}
+	return true
+}
+
+// WebhookEvent is the body of the requests posted to webhooks.
+type WebhookEvent struct {
+	ID         string
+	Type       string
+	Datacenter string
+	Index      uint64
+	Timestamp  time.Time
+
+	// Service is set for the service registered, service deregistered and
+	// health changed events.
+	Service *WebhookServiceInstance `json:",omitempty"`
+
+	// ConfigEntry is set for the config entry changed events.
+	ConfigEntry *WebhookConfigEntry `json:",omitempty"`
+}
+
+// WebhookServiceInstance identifies the service instance an event is about.
+type WebhookServiceInstance struct {
+	Node      string
+	ID        string
+	Name      string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
+
+	// Status is the aggregated status of the checks of the instance, and
+	// PreviousStatus its status before a health change.
+	Status         string
+	PreviousStatus string `json:",omitempty"`
+}
+
+// WebhookConfigEntry identifies the config entry an event is about.
+type WebhookConfigEntry struct {
+	Kind      string
+	Name      string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
+	Deleted   bool
+}
+
+// WebhookSignature returns the signature of a request posted to a webhook:
+// the hex encoded HMAC-SHA256, keyed by the secret of the endpoint, of the
+// timestamp header, a dot and the body, prefixed by "sha256=". Receivers
+// should compare it in constant time and reject old timestamps.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookSender posts the events to an endpoint, in order and retrying the
+// failed deliveries.
+type webhookSender struct {
+	config WebhookConfig
+	client *http.Client
+	logger hclog.Logger
+	waiter *retry.Waiter
+	queue  chan *WebhookEvent
+}
+
+func newWebhookSender(config WebhookConfig, logger hclog.Logger) *webhookSender {
+	if config.Timeout <= 0 {
+		config.Timeout = webhookDefaultTimeout
+	}
+	return &webhookSender{
+		config: config,
+		client: cleanhttp.DefaultPooledClient(),
+		logger: logger.With("webhook", config.Name),
+		waiter: &retry.Waiter{
+			MinFailures: 1,
+			MinWait:     time.Second,
+			MaxWait:     30 * time.Second,
+			Jitter:      retry.NewJitter(20),
+		},
+		queue: make(chan *WebhookEvent, webhookQueueSize),
+	}
+}
+
+// enqueue queues the event for delivery if it matches the filters of the
+// endpoint. The event is dropped if the queue is full, so a slow endpoint
+// can't block the others.
+func (w *webhookSender) enqueue(e *WebhookEvent) {
+	if !w.config.matches(e) {
+		return
+	}
+	select {
+	case w.queue <- e:
+	default:
+		w.logger.Warn("dropping event, the queue of the webhook is full", "event", e.ID, "type", e.Type)
+		metrics.IncrCounterWithLabels(metricsKeyWebhookDropped, 1, w.labels())
+	}
+}
+
+// run delivers the queued events until ctx is cancelled.
+func (w *webhookSender) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-w.queue:
+			w.deliver(ctx, e)
+		}
+	}
+}
+
+func (w *webhookSender) deliver(ctx context.Context, e *WebhookEvent) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		w.logger.Error("failed to encode event", "event", e.ID, "error", err)
+		return
+	}
+
+	w.waiter.Reset()
+	for {
+		retryable, err := w.post(ctx, e, body)
+		if err == nil {
+			metrics.IncrCounterWithLabels(metricsKeyWebhookDelivered, 1, w.labels())
+			return
+		}
+		if !retryable || w.waiter.Failures() >= w.config.MaxRetries {
+			w.logger.Error("failed to deliver event", "event", e.ID, "type", e.Type, "error", err)
+			metrics.IncrCounterWithLabels(metricsKeyWebhookFailed, 1, w.labels())
+			return
+		}
+		w.logger.Warn("failed to deliver event, retrying", "event", e.ID, "type", e.Type, "error", err)
+		if err := w.waiter.Wait(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// post posts the event once. It returns whether the delivery should be
+// retried when it fails: requests rejected by the endpoint with a client
+// error other than 408 or 429 are not retried.
+func (w *webhookSender) post(ctx context.Context, e *WebhookEvent, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, e.Type)
+	req.Header.Set(WebhookDeliveryHeader, e.ID)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if w.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, WebhookSignature(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected response code: %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected response code: %d", resp.StatusCode)
+	}
+}
+
+func (w *webhookSender) labels() []metrics.Label {
+	return []metrics.Label{{Name: "webhook", Value: w.config.Name}}
+}
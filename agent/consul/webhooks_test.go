@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func TestWebhookConfig_matches(t *testing.T) {
+	registered := &WebhookEvent{
+		Type:    WebhookEventServiceRegistered,
+		Service: &WebhookServiceInstance{Name: "web"},
+	}
+	entryChanged := &WebhookEvent{
+		Type:        WebhookEventConfigEntryChanged,
+		ConfigEntry: &WebhookConfigEntry{Kind: "service-defaults", Name: "api"},
+	}
+
+	cases := map[string]struct {
+		config WebhookConfig
+		event  *WebhookEvent
+		want   bool
+	}{
+		"no filter": {
+			config: WebhookConfig{},
+			event:  registered,
+			want:   true,
+		},
+		"matching event": {
+			config: WebhookConfig{Events: []string{WebhookEventServiceRegistered}},
+			event:  registered,
+			want:   true,
+		},
+		"other event": {
+			config: WebhookConfig{Events: []string{WebhookEventHealthChanged}},
+			event:  registered,
+			want:   false,
+		},
+		"matching service": {
+			config: WebhookConfig{Services: []string{"web"}},
+			event:  registered,
+			want:   true,
+		},
+		"other service": {
+			config: WebhookConfig{Services: []string{"api"}},
+			event:  registered,
+			want:   false,
+		},
+		"matching config entry": {
+			config: WebhookConfig{Services: []string{"api"}},
+			event:  entryChanged,
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.config.matches(tc.event))
+		})
+	}
+}
+
+func TestWebhookSender(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		received []*http.Request
+		bodies   [][]byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		// The first attempt fails, and is retried.
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	sender := newWebhookSender(WebhookConfig{
+		Name:       "test",
+		URL:        srv.URL,
+		Secret:     "s3cr3t",
+		Services:   []string{"web"},
+		MaxRetries: 2,
+	}, testutil.Logger(t))
+	sender.waiter.MinWait = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go sender.run(ctx)
+
+	sender.enqueue(&WebhookEvent{
+		ID:      "filtered",
+		Type:    WebhookEventServiceRegistered,
+		Service: &WebhookServiceInstance{Name: "api"},
+	})
+	sender.enqueue(&WebhookEvent{
+		ID:      "delivered",
+		Type:    WebhookEventServiceRegistered,
+		Service: &WebhookServiceInstance{Name: "web", Status: "passing"},
+	})
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2, attempts)
+
+	req, body := received[0], bodies[0]
+	require.Equal(t, WebhookEventServiceRegistered, req.Header.Get(WebhookEventHeader))
+	require.Equal(t, "delivered", req.Header.Get(WebhookDeliveryHeader))
+	require.Equal(t,
+		WebhookSignature("s3cr3t", req.Header.Get(WebhookTimestampHeader), body),
+		req.Header.Get(WebhookSignatureHeader))
+
+	var event WebhookEvent
+	require.NoError(t, json.Unmarshal(body, &event))
+	require.Equal(t, "web", event.Service.Name)
+}
+
+func TestWebhookSender_ClientErrorsAreNotRetried(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+
+	sender := newWebhookSender(WebhookConfig{Name: "test", URL: srv.URL, MaxRetries: 5}, testutil.Logger(t))
+	sender.waiter.MinWait = 10 * time.Millisecond
+	sender.deliver(context.Background(), &WebhookEvent{ID: "rejected", Type: WebhookEventHealthChanged})
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, attempts)
+}
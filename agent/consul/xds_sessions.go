@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package consul
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/consul/agent/metadata"
+	"github.com/hashicorp/consul/logging"
+)
+
+// fetchServerXDSSessions queries the number of xDS streams served by each of
+// the other servers of the datacenter. Servers which cannot be reached, or
+// which do not report their streams yet, are left out.
+func (s *Server) fetchServerXDSSessions(ctx context.Context) []uint32 {
+	logger := s.loggers.Named(logging.XDSCapacityController)
+
+	var (
+		lock   sync.Mutex
+		counts []uint32
+		wg     sync.WaitGroup
+	)
+	for _, server := range s.serverLookup.Servers() {
+		if server.ID == string(s.config.NodeID) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(server *metadata.Server) {
+			defer wg.Done()
+
+			var args EmptyReadRequest
+			var reply uint32
+			if err := s.connPool.RPC(s.config.Datacenter, server.ShortName, server.Addr, "Status.XDSSessions", &args, &reply); err != nil {
+				logger.Debug("failed to get xDS streams from server", "server", server.Name, "error", err)
+				return
+			}
+
+			lock.Lock()
+			counts = append(counts, reply)
+			lock.Unlock()
+		}(server)
+	}
+
+	doneCh := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(doneCh)
+	}()
+	select {
+	case <-doneCh:
+	case <-ctx.Done():
+	}
+
+	// Copy the counts since the RPCs still in-flight once the context is
+	// done keep appending to them.
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]uint32(nil), counts...)
+}
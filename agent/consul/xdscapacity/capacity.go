@@ -26,12 +26,35 @@ var StatsGauges = []prometheus.GaugeDefinition{
 	},
 }
 
+var StatsCounters = []prometheus.CounterDefinition{
+	{
+		Name: []string{"xds", "server", "streamsRebalanced"},
+		Help: "Increments by the number of xDS streams the server drains to rebalance the load across servers.",
+	},
+}
+
 // errorMargin is amount to which we allow a server to be over-occupied,
 // expressed as a percentage (between 0 and 1).
 //
 // We allow 10% more than the ideal number of streams per server.
 const errorMargin = 0.1
 
+const (
+	// rebalanceInterval is how often the server compares its number of xDS
+	// streams with the other servers.
+	rebalanceInterval = 30 * time.Second
+
+	// rebalanceThreshold is the difference between the most and the least
+	// loaded servers from which the streams are rebalanced, expressed as a
+	// percentage (between 0 and 1) of the mean number of streams per server.
+	rebalanceThreshold = 0.2
+
+	// rebalanceDrainFraction is the percentage (between 0 and 1) of its
+	// streams that an overloaded server drains on each rebalance, so the
+	// streams move to the other servers gradually.
+	rebalanceDrainFraction = 0.1
+)
+
 // Controller determines the ideal number of xDS streams for the server to
 // handle and enforces it using the given SessionLimiter.
 //
@@ -43,6 +66,12 @@ const errorMargin = 0.1
 // Controller receives changes to the number of healthy servers from the
 // autopilot delegate. It queries the state store's catalog tables to discover
 // the number of registered proxy (sidecar and gateway) services.
+//
+// The limit alone does not relieve the existing servers when a server is added,
+// since they stay within the error margin while the new server is almost
+// idle. So Controller also periodically compares its number of streams with
+// the other servers and, when the spread exceeds rebalanceThreshold, drains a
+// fraction of its streams if it is above the mean.
 type Controller struct {
 	cfg Config
 
@@ -58,6 +87,10 @@ type Config struct {
 	Logger         hclog.Logger
 	GetStore       func() Store
 	SessionLimiter SessionLimiter
+
+	// FetchServerSessions returns the number of xDS streams of each of the
+	// other servers. If nil, the streams are not rebalanced.
+	FetchServerSessions func(ctx context.Context) []uint32
 }
 
 // SessionLimiter is used to enforce the session limit to achieve the ideal
@@ -65,6 +98,8 @@ type Config struct {
 type SessionLimiter interface {
 	SetMaxSessions(maxSessions uint32)
 	SetDrainRateLimit(rateLimit rate.Limit)
+	DrainSessions(n uint32)
+	InFlight() uint32
 }
 
 // NewController creates a new capacity controller with the given config.
@@ -88,6 +123,13 @@ func (c *Controller) Run(ctx context.Context) {
 		return
 	}
 
+	var rebalanceCh <-chan time.Time
+	if c.cfg.FetchServerSessions != nil {
+		ticker := time.NewTicker(rebalanceInterval)
+		defer ticker.Stop()
+		rebalanceCh = ticker.C
+	}
+
 	var numServers uint32
 	for {
 		select {
@@ -101,6 +143,8 @@ func (c *Controller) Run(ctx context.Context) {
 			}
 			c.updateDrainRateLimit(numProxies)
 			c.updateMaxSessions(numServers, numProxies)
+		case <-rebalanceCh:
+			c.rebalance(ctx)
 		case <-ctx.Done():
 			return
 		}
@@ -116,6 +160,65 @@ func (c *Controller) SetServerCount(count uint32) {
 	}
 }
 
+// Sessions returns the number of xDS streams served by this server.
+func (c *Controller) Sessions() uint32 {
+	return c.cfg.SessionLimiter.InFlight()
+}
+
+func (c *Controller) rebalance(ctx context.Context) {
+	local := c.cfg.SessionLimiter.InFlight()
+	others := c.cfg.FetchServerSessions(ctx)
+
+	drain := calcRebalanceDrain(local, others)
+	if drain == 0 {
+		return
+	}
+
+	c.cfg.Logger.Info(
+		"draining xDS streams to rebalance load across servers",
+		"streams", local,
+		"drain", drain,
+		"num_servers", len(others)+1,
+	)
+	metrics.IncrCounter([]string{"xds", "server", "streamsRebalanced"}, float32(drain))
+	c.cfg.SessionLimiter.DrainSessions(drain)
+}
+
+// calcRebalanceDrain returns the number of streams to drain from a server
+// serving local streams while the other servers serve the given numbers.
+//
+// Streams are only drained when the spread between the most and the least
+// loaded servers exceeds rebalanceThreshold of the mean, and only from servers
+// above the mean. At most rebalanceDrainFraction of the streams are drained at
+// once, and never so many that the server would fall below the mean.
+func calcRebalanceDrain(local uint32, others []uint32) uint32 {
+	if len(others) == 0 || local == 0 {
+		return 0
+	}
+
+	total, lowest, highest := local, local, local
+	for _, count := range others {
+		total += count
+		if count < lowest {
+			lowest = count
+		}
+		if count > highest {
+			highest = count
+		}
+	}
+	mean := float64(total) / float64(len(others)+1)
+
+	if float64(highest-lowest) <= mean*rebalanceThreshold {
+		return 0
+	}
+
+	excess := float64(local) - math.Ceil(mean)
+	if excess <= 0 {
+		return 0
+	}
+	return uint32(math.Min(excess, math.Ceil(float64(local)*rebalanceDrainFraction)))
+}
+
 func (c *Controller) updateDrainRateLimit(numProxies uint32) {
 	rateLimit := calcRateLimit(numProxies)
 	if rateLimit == c.prevRateLimit {
@@ -124,6 +124,31 @@ func (tl *testLimiter) receive(t *testing.T) int {
 
 func (tl *testLimiter) SetDrainRateLimit(rateLimit rate.Limit) {}
 
+func (tl *testLimiter) DrainSessions(n uint32) {}
+
+func (tl *testLimiter) InFlight() uint32 { return 0 }
+
+func TestCalcRebalanceDrain(t *testing.T) {
+	for name, tc := range map[string]struct {
+		local  uint32
+		others []uint32
+		drain  uint32
+	}{
+		"single server":               {local: 100, others: nil, drain: 0},
+		"no streams":                  {local: 0, others: []uint32{100, 0}, drain: 0},
+		"balanced":                    {local: 100, others: []uint32{100, 100}, drain: 0},
+		"within threshold":            {local: 110, others: []uint32{95, 95}, drain: 0},
+		"new server, this one loaded": {local: 1000, others: []uint32{1000, 1000, 0}, drain: 100},
+		"new server, this one idle":   {local: 10, others: []uint32{1000, 1000, 1000}, drain: 0},
+		"never below the mean":        {local: 825, others: []uint32{825, 825, 525}, drain: 75},
+		"below the mean":              {local: 700, others: []uint32{900, 900, 500}, drain: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.drain, calcRebalanceDrain(tc.local, tc.others))
+		})
+	}
+}
+
 func TestCalcRateLimit(t *testing.T) {
 	for in, out := range map[uint32]rate.Limit{
 		0:          rate.Limit(1),
@@ -40,6 +40,11 @@ func (m *delegateMock) LANMembers(f consul.LANMemberFilter) ([]serf.Member, erro
 	return ret.Get(0).([]serf.Member), ret.Error(1)
 }
 
+func (m *delegateMock) MemberEvents(index uint64) ([]structs.MemberEvent, uint64, <-chan struct{}) {
+	ret := m.Called(index)
+	return ret.Get(0).([]structs.MemberEvent), ret.Get(1).(uint64), ret.Get(2).(<-chan struct{})
+}
+
 func (m *delegateMock) AgentLocalMember() serf.Member {
 	return m.Called().Get(0).(serf.Member)
 }
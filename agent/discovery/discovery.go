@@ -55,6 +55,7 @@ const (
 	QueryTypeIngress       QueryType = "INGRESS" // deprecated: use for V1 only
 	QueryTypeInvalid       QueryType = "INVALID"
 	QueryTypeNode          QueryType = "NODE"
+	QueryTypeNodeMeta      QueryType = "NODE_META"      // V1-only
 	QueryTypePreparedQuery QueryType = "PREPARED_QUERY" // deprecated: use for V1 only
 	QueryTypeService       QueryType = "SERVICE"
 	QueryTypeVirtual       QueryType = "VIRTUAL"
@@ -85,6 +86,9 @@ type QueryPayload struct {
 	Tenancy  QueryTenancy // tenancy includes any additional labels specified before the domain
 	Limit    int          // The maximum number of records to return
 
+	// NodeMeta selects the nodes of node metadata lookups.
+	NodeMeta map[string]string
+
 	// v2 fields only
 	EnableFailover bool
 }
@@ -170,6 +174,10 @@ type CatalogDataFetcher interface {
 	// FetchNodes fetches A/AAAA/CNAME
 	FetchNodes(ctx Context, req *QueryPayload) ([]*Result, error)
 
+	// FetchNodesByMeta fetches A/AAAA/CNAME records for the nodes matching
+	// the node metadata of the request. V1-only.
+	FetchNodesByMeta(ctx Context, req *QueryPayload) ([]*Result, error)
+
 	// FetchEndpoints fetches records for A/AAAA/CNAME or SRV requests for services
 	FetchEndpoints(ctx Context, req *QueryPayload, lookupType LookupType) ([]*Result, error)
 
@@ -219,6 +227,8 @@ func (p *QueryProcessor) QueryByName(query *Query, ctx Context) ([]*Result, erro
 	switch query.QueryType {
 	case QueryTypeNode:
 		return p.dataFetcher.FetchNodes(ctx, &query.QueryPayload)
+	case QueryTypeNodeMeta:
+		return p.dataFetcher.FetchNodesByMeta(ctx, &query.QueryPayload)
 	case QueryTypeService:
 		return p.dataFetcher.FetchEndpoints(ctx, &query.QueryPayload, LookupTypeService)
 	case QueryTypeConnect:
@@ -66,6 +66,32 @@ func (_m *MockCatalogDataFetcher) FetchNodes(ctx Context, req *QueryPayload) ([]
 	return r0, r1
 }
 
+// FetchNodesByMeta provides a mock function with given fields: ctx, req
+func (_m *MockCatalogDataFetcher) FetchNodesByMeta(ctx Context, req *QueryPayload) ([]*Result, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []*Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(Context, *QueryPayload) ([]*Result, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(Context, *QueryPayload) []*Result); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(Context, *QueryPayload) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchPreparedQuery provides a mock function with given fields: ctx, req
 func (_m *MockCatalogDataFetcher) FetchPreparedQuery(ctx Context, req *QueryPayload) ([]*Result, error) {
 	ret := _m.Called(ctx, req)
@@ -6,7 +6,9 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -27,15 +29,24 @@ import (
 const (
 	// Increment a counter when requests staler than this are served
 	staleCounterThreshold = 5 * time.Second
+
+	// servicePortMetaPrefix prefixes the service meta keys which register the
+	// named ports of a service, e.g. "port-admin" = "9090", so that they can
+	// be looked up with <port>.port.<service>.service.consul.
+	servicePortMetaPrefix = "port-"
 )
 
-// DNSCounters pre-registers the staleness metric.
+// DNSCounters pre-registers the staleness and truncation metrics.
 // This value is used by both the V1 and V2 DNS (V1 Catalog-only) servers.
 var DNSCounters = []prometheus.CounterDefinition{
 	{
 		Name: []string{"dns", "stale_queries"},
 		Help: "Increments when an agent serves a query within the allowed stale threshold.",
 	},
+	{
+		Name: []string{"dns", "truncated_responses"},
+		Help: "Increments when records are dropped from a DNS response, labeled by protocol and by the cause of the truncation.",
+	},
 }
 
 // V1DataFetcherDynamicConfig is used to store the dynamic configuration of the V1 data fetcher.
@@ -111,6 +122,10 @@ func (f *V1DataFetcher) GetConfig() *V1DataFetcherDynamicConfig {
 
 // FetchNodes fetches A/AAAA/CNAME
 func (f *V1DataFetcher) FetchNodes(ctx Context, req *QueryPayload) ([]*Result, error) {
+	if req.PortName != "" {
+		// Named ports are only supported for service lookups.
+		return nil, ErrNotSupported
+	}
 	if req.Tenancy.Namespace != "" && req.Tenancy.Namespace != acl.DefaultNamespaceName {
 		// Nodes are not namespaced, so this is a name error
 		return nil, ErrNotFound
@@ -166,6 +181,74 @@ func (f *V1DataFetcher) FetchNodes(ctx Context, req *QueryPayload) ([]*Result, e
 	return results, nil
 }
 
+// FetchNodesByMeta fetches A/AAAA/CNAME records for the nodes matching the
+// node metadata of the request.
+func (f *V1DataFetcher) FetchNodesByMeta(ctx Context, req *QueryPayload) ([]*Result, error) {
+	if req.Tenancy.Namespace != "" && req.Tenancy.Namespace != acl.DefaultNamespaceName {
+		// Nodes are not namespaced, so this is a name error
+		return nil, ErrNotFound
+	}
+	if req.PortName != "" || len(req.NodeMeta) == 0 {
+		return nil, ErrNotSupported
+	}
+	cfg := f.dynamicConfig.Load().(*V1DataFetcherDynamicConfig)
+
+	// If no datacenter is passed, default to our own
+	datacenter := cfg.Datacenter
+	if req.Tenancy.Datacenter != "" {
+		datacenter = req.Tenancy.Datacenter
+	}
+
+	args := &structs.DCSpecificRequest{
+		Datacenter:      datacenter,
+		NodeMetaFilters: req.NodeMeta,
+		QueryOptions: structs.QueryOptions{
+			Token:      ctx.Token,
+			AllowStale: cfg.AllowStale,
+		},
+		EnterpriseMeta: queryTenancyToEntMeta(req.Tenancy),
+	}
+	out, err := f.fetchNodesByMeta(cfg, args)
+	if err != nil {
+		if strings.Contains(err.Error(), structs.ErrNoDCPath.Error()) {
+			return nil, ErrNoPathToDatacenter
+		}
+		return nil, fmt.Errorf("failed rpc request: %w", err)
+	}
+
+	if len(out.Nodes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	// Perform a random shuffle and apply the limit, as for services.
+	rand.Shuffle(len(out.Nodes), func(i, j int) {
+		out.Nodes[i], out.Nodes[j] = out.Nodes[j], out.Nodes[i]
+	})
+	limit := req.Limit
+	if len(out.Nodes) < limit || limit == 0 {
+		limit = len(out.Nodes)
+	}
+
+	results := make([]*Result, 0, limit)
+	for _, n := range out.Nodes[:limit] {
+		results = append(results, &Result{
+			Node: &Location{
+				Name:            n.Node,
+				Address:         n.Address,
+				TaggedAddresses: makeTaggedAddressesFromStrings(n.TaggedAddresses),
+			},
+			Type:     ResultTypeNode,
+			Metadata: n.Meta,
+
+			Tenancy: ResultTenancy{
+				Partition:  n.GetEnterpriseMeta().PartitionOrDefault(),
+				Datacenter: n.Datacenter,
+			},
+		})
+	}
+	return results, nil
+}
+
 // FetchEndpoints fetches records for A/AAAA/CNAME or SRV requests for services
 func (f *V1DataFetcher) FetchEndpoints(ctx Context, req *QueryPayload, lookupType LookupType) ([]*Result, error) {
 	f.logger.Trace(fmt.Sprintf("FetchEndpoints - req: %+v / lookupType: %+v", req, lookupType))
@@ -175,6 +258,9 @@ func (f *V1DataFetcher) FetchEndpoints(ctx Context, req *QueryPayload, lookupTyp
 
 // FetchVirtualIP fetches A/AAAA records for virtual IPs
 func (f *V1DataFetcher) FetchVirtualIP(ctx Context, req *QueryPayload) (*Result, error) {
+	if req.PortName != "" {
+		return nil, ErrNotSupported
+	}
 	args := structs.ServiceSpecificRequest{
 		// The Datacenter of the request is not specified because cross-Datacenter virtual IP
 		// queries are not supported. This guard rail is in place because virtual IPs are allocated
@@ -302,6 +388,9 @@ func (f *V1DataFetcher) FetchWorkload(ctx Context, req *QueryPayload) (*Result,
 // FetchPreparedQuery evaluates the results of a prepared query.
 // deprecated in V2
 func (f *V1DataFetcher) FetchPreparedQuery(ctx Context, req *QueryPayload) ([]*Result, error) {
+	if req.PortName != "" {
+		return nil, ErrNotSupported
+	}
 	cfg := f.dynamicConfig.Load().(*V1DataFetcherDynamicConfig)
 
 	// If no datacenter is passed, default to our own
@@ -428,9 +517,6 @@ func (f *V1DataFetcher) ValidateRequest(_ Context, req *QueryPayload) error {
 	if req.EnableFailover {
 		return ErrNotSupported
 	}
-	if req.PortName != "" {
-		return ErrNotSupported
-	}
 	return validateEnterpriseTenancy(req.Tenancy)
 }
 
@@ -445,6 +531,12 @@ func (f *V1DataFetcher) buildResultsFromServiceNodes(nodes []structs.CheckServic
 	results := make([]*Result, 0, limit)
 	for idx := 0; idx < limit; idx++ {
 		n := nodes[idx]
+		port := Port{Number: uint32(f.translateServicePortFunc(n.Node.Datacenter, n.Service.Port, n.Service.TaggedAddresses))}
+		if req.PortName != "" {
+			// The nodes were filtered on the named port already.
+			number, _ := namedServicePort(n.Service, req.PortName)
+			port = Port{Name: req.PortName, Number: uint32(number)}
+		}
 		results = append(results, &Result{
 			Service: &Location{
 				Name:            n.Service.Service,
@@ -461,9 +553,7 @@ func (f *V1DataFetcher) buildResultsFromServiceNodes(nodes []structs.CheckServic
 				TTL:    ttlOverride,
 				Weight: uint32(findWeight(n)),
 			},
-			Ports: []Port{
-				{Number: uint32(f.translateServicePortFunc(n.Node.Datacenter, n.Service.Port, n.Service.TaggedAddresses))},
-			},
+			Ports:    []Port{port},
 			Metadata: n.Node.Meta,
 			Tenancy: ResultTenancy{
 				Namespace:  n.Service.NamespaceOrEmpty(),
@@ -542,11 +632,40 @@ RPC:
 	return &out, nil
 }
 
+// fetchNodesByMeta is used to list the nodes matching node metadata in the
+// Consul catalog.
+func (f *V1DataFetcher) fetchNodesByMeta(cfg *V1DataFetcherDynamicConfig, args *structs.DCSpecificRequest) (*structs.IndexedNodes, error) {
+	var out structs.IndexedNodes
+RPC:
+	if err := f.rpcFunc(context.Background(), "Catalog.ListNodes", args, &out); err != nil {
+		return nil, err
+	}
+
+	// Verify that request is not too stale, redo the request
+	if args.AllowStale {
+		if out.LastContact > cfg.MaxStale {
+			args.AllowStale = false
+			f.logger.Warn("Query results too stale, re-requesting")
+			goto RPC
+		} else if out.LastContact > staleCounterThreshold {
+			metrics.IncrCounter([]string{"dns", "stale_queries"}, 1)
+		}
+	}
+
+	return &out, nil
+}
+
 // fetchService is used to look up a service in the Consul catalog.
 func (f *V1DataFetcher) fetchService(ctx Context, req *QueryPayload,
 	cfg *V1DataFetcherDynamicConfig, lookupType LookupType) ([]*Result, error) {
 	f.logger.Trace(fmt.Sprintf("fetchService - req: %+v", req))
 
+	if req.PortName != "" && lookupType != LookupTypeService {
+		// Named ports are registered by the services themselves, not by
+		// their sidecars or gateways.
+		return nil, ErrNotSupported
+	}
+
 	// If no datacenter is passed, default to our own
 	datacenter := cfg.Datacenter
 	if req.Tenancy.Datacenter != "" {
@@ -592,6 +711,10 @@ func (f *V1DataFetcher) fetchService(ctx Context, req *QueryPayload,
 		return nil, fmt.Errorf("rpc request failed: %w", err)
 	}
 
+	if req.PortName != "" {
+		out.Nodes = filterNodesByPortName(out.Nodes, req.PortName)
+	}
+
 	// If we have no nodes, return not found!
 	if len(out.Nodes) == 0 {
 		return nil, ErrNotFound
@@ -602,6 +725,35 @@ func (f *V1DataFetcher) fetchService(ctx Context, req *QueryPayload,
 	return f.buildResultsFromServiceNodes(out.Nodes, req, nil), nil
 }
 
+// filterNodesByPortName returns the nodes whose service registers the named
+// port in its meta.
+func filterNodesByPortName(nodes structs.CheckServiceNodes, name string) structs.CheckServiceNodes {
+	filtered := make(structs.CheckServiceNodes, 0, len(nodes))
+	for _, n := range nodes {
+		if _, ok := namedServicePort(n.Service, name); ok {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// namedServicePort returns the port registered under the given name in the
+// meta of the service. Since DNS names are case-insensitive, so are port
+// names. Values which are not valid port numbers are ignored.
+func namedServicePort(svc *structs.NodeService, name string) (int, bool) {
+	for k, v := range svc.Meta {
+		if !strings.HasPrefix(k, servicePortMetaPrefix) || !strings.EqualFold(k[len(servicePortMetaPrefix):], name) {
+			continue
+		}
+		port, err := strconv.Atoi(v)
+		if err != nil || port <= 0 || port > 65535 {
+			continue
+		}
+		return port, true
+	}
+	return 0, false
+}
+
 // findWeight returns the weight of a service node.
 func findWeight(node structs.CheckServiceNode) int {
 	// By default, when only_passing is false, warning and passing nodes are returned
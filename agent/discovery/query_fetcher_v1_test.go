@@ -205,3 +205,141 @@ func Test_FetchEndpoints(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedResults, results)
 }
+
+// Test_FetchEndpoints_PortName tests that service lookups by port name only
+// return the instances registering the port in their meta, with that port.
+func Test_FetchEndpoints_PortName(t *testing.T) {
+	rc := &config.RuntimeConfig{
+		Datacenter: "dc1",
+	}
+	ctx := Context{
+		Token: "test-token",
+	}
+
+	logger := testutil.Logger(t)
+	mockRPC := cachetype.NewMockRPC(t)
+	translateServicePortFunc := func(dc string, port int, taggedAddresses map[string]structs.ServiceAddress) int { return port }
+	rpcFuncForSamenessGroup := func(ctx context.Context, req *structs.ConfigEntryQuery) (structs.SamenessGroupConfigEntry, cache.ResultMeta, error) {
+		return structs.SamenessGroupConfigEntry{}, cache.ResultMeta{}, nil
+	}
+	getFromCacheFunc := func(ctx context.Context, t string, r cache.Request) (interface{}, cache.ResultMeta, error) {
+		return nil, cache.ResultMeta{}, nil
+	}
+	rpcFuncForServiceNodes := func(ctx context.Context, req structs.ServiceSpecificRequest) (structs.IndexedCheckServiceNodes, cache.ResultMeta, error) {
+		return structs.IndexedCheckServiceNodes{
+			Nodes: []structs.CheckServiceNode{
+				{
+					Node: &structs.Node{Node: "node-1", Address: "10.0.0.1"},
+					Service: &structs.NodeService{
+						Service: "web",
+						Port:    8080,
+						Meta:    map[string]string{"port-admin": "9090"},
+					},
+				},
+				{
+					Node: &structs.Node{Node: "node-2", Address: "10.0.0.2"},
+					Service: &structs.NodeService{
+						Service: "web",
+						Port:    8080,
+						Meta:    map[string]string{"port-Admin": "9091"},
+					},
+				},
+				{
+					Node: &structs.Node{Node: "node-3", Address: "10.0.0.3"},
+					Service: &structs.NodeService{
+						Service: "web",
+						Port:    8080,
+						Meta:    map[string]string{"port-admin": "not-a-port"},
+					},
+				},
+				{
+					Node:    &structs.Node{Node: "node-4", Address: "10.0.0.4"},
+					Service: &structs.NodeService{Service: "web", Port: 8080},
+				},
+			},
+		}, cache.ResultMeta{}, nil
+	}
+
+	df := NewV1DataFetcher(rc, acl.DefaultEnterpriseMeta(), getFromCacheFunc, mockRPC.RPC, rpcFuncForServiceNodes, rpcFuncForSamenessGroup, translateServicePortFunc, logger)
+
+	results, err := df.FetchEndpoints(ctx, &QueryPayload{Name: "web", PortName: "admin"}, LookupTypeService)
+	require.NoError(t, err)
+	ports := make(map[string]Port)
+	for _, r := range results {
+		require.Len(t, r.Ports, 1)
+		ports[r.Node.Name] = r.Ports[0]
+	}
+	require.Equal(t, map[string]Port{
+		"node-1": {Name: "admin", Number: 9090},
+		"node-2": {Name: "admin", Number: 9091},
+	}, ports)
+
+	_, err = df.FetchEndpoints(ctx, &QueryPayload{Name: "web", PortName: "metrics"}, LookupTypeService)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = df.FetchEndpoints(ctx, &QueryPayload{Name: "web", PortName: "admin"}, LookupTypeConnect)
+	require.ErrorIs(t, err, ErrNotSupported)
+
+	_, err = df.FetchNodes(ctx, &QueryPayload{Name: "node-1", PortName: "admin"})
+	require.ErrorIs(t, err, ErrNotSupported)
+}
+
+// Test_FetchNodesByMeta tests that node metadata lookups list the nodes
+// matching the metadata of the request.
+func Test_FetchNodesByMeta(t *testing.T) {
+	rc := &config.RuntimeConfig{
+		Datacenter: "dc1",
+	}
+	ctx := Context{
+		Token: "test-token",
+	}
+
+	logger := testutil.Logger(t)
+	mockRPC := cachetype.NewMockRPC(t)
+	mockRPC.On("RPC", mock.Anything, "Catalog.ListNodes", mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			req := args.Get(2).(*structs.DCSpecificRequest)
+			require.Equal(t, "dc1", req.Datacenter)
+			require.Equal(t, map[string]string{"rack": "r1"}, req.NodeMetaFilters)
+			require.Equal(t, ctx.Token, req.Token)
+
+			reply := args.Get(3).(*structs.IndexedNodes)
+			reply.Nodes = structs.Nodes{
+				{Node: "node-1", Address: "10.0.0.1", Datacenter: "dc1", Meta: map[string]string{"rack": "r1"}},
+				{Node: "node-2", Address: "10.0.0.2", Datacenter: "dc1", Meta: map[string]string{"rack": "r1"}},
+			}
+		})
+	translateServicePortFunc := func(dc string, port int, taggedAddresses map[string]structs.ServiceAddress) int { return 0 }
+	rpcFuncForServiceNodes := func(ctx context.Context, req structs.ServiceSpecificRequest) (structs.IndexedCheckServiceNodes, cache.ResultMeta, error) {
+		return structs.IndexedCheckServiceNodes{}, cache.ResultMeta{}, nil
+	}
+	rpcFuncForSamenessGroup := func(ctx context.Context, req *structs.ConfigEntryQuery) (structs.SamenessGroupConfigEntry, cache.ResultMeta, error) {
+		return structs.SamenessGroupConfigEntry{}, cache.ResultMeta{}, nil
+	}
+	getFromCacheFunc := func(ctx context.Context, t string, r cache.Request) (interface{}, cache.ResultMeta, error) {
+		return nil, cache.ResultMeta{}, nil
+	}
+
+	df := NewV1DataFetcher(rc, acl.DefaultEnterpriseMeta(), getFromCacheFunc, mockRPC.RPC, rpcFuncForServiceNodes, rpcFuncForSamenessGroup, translateServicePortFunc, logger)
+
+	results, err := df.FetchNodesByMeta(ctx, &QueryPayload{NodeMeta: map[string]string{"rack": "r1"}})
+	require.NoError(t, err)
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		require.Equal(t, ResultTypeNode, r.Type)
+		require.Equal(t, map[string]string{"rack": "r1"}, r.Metadata)
+		names = append(names, r.Node.Name)
+	}
+	require.ElementsMatch(t, []string{"node-1", "node-2"}, names)
+
+	results, err = df.FetchNodesByMeta(ctx, &QueryPayload{NodeMeta: map[string]string{"rack": "r1"}, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	_, err = df.FetchNodesByMeta(ctx, &QueryPayload{
+		NodeMeta: map[string]string{"rack": "r1"},
+		Tenancy:  QueryTenancy{Namespace: "other"},
+	})
+	require.ErrorIs(t, err, ErrNotFound)
+}
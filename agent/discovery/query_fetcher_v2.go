@@ -72,6 +72,11 @@ func (f *V2DataFetcher) FetchNodes(ctx Context, req *QueryPayload) ([]*Result, e
 	return nil, fmt.Errorf("not implemented")
 }
 
+// FetchNodesByMeta fetches the nodes matching node metadata. V1-only.
+func (f *V2DataFetcher) FetchNodesByMeta(ctx Context, req *QueryPayload) ([]*Result, error) {
+	return nil, ErrNotSupported
+}
+
 // FetchEndpoints fetches records for A/AAAA/CNAME or SRV requests for services
 func (f *V2DataFetcher) FetchEndpoints(reqContext Context, req *QueryPayload, lookupType LookupType) ([]*Result, error) {
 	if lookupType != LookupTypeService {
@@ -378,4 +378,26 @@ func TestDiscoveryChainRead(t *testing.T) {
 
 		require.Equal(t, expectModifiedWithOverrides, value.Chain)
 	}))
+
+	require.True(t, t.Run("GET: read modified chain with explanation", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/v1/discovery-chain/web?explain", nil)
+		require.NoError(t, err)
+
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.DiscoveryChainRead(resp, req)
+		require.NoError(t, err)
+
+		value := obj.(discoveryChainReadResponse)
+
+		explanation := value.Chain.Explanation
+		require.NotNil(t, explanation)
+		node := explanation.Nodes["resolver:web.default.default.dc1"]
+		require.NotNil(t, node)
+		require.Equal(t, &structs.DiscoveryExplanationConfigEntry{
+			Kind: structs.ServiceResolver,
+			Name: "web",
+		}, node.ConfigEntry)
+		require.Contains(t, node.Reasons, `connect timeout 33s from service-resolver "web"`)
+		require.Contains(t, node.Reasons, `failover "*" from service-resolver "web"`)
+	}))
 }
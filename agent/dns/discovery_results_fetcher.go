@@ -42,16 +42,22 @@ func (d discoveryResultsFetcher) getQueryResults(opts *getQueryOptions) ([]*disc
 	case requestTypeConsul:
 		// This is a special case of discovery.QueryByName where we know that we need to query the consul service
 		// regardless of the question name.
+		//
+		// We specify the partition here so that in the case we are a client agent in a non-default partition.
+		// We don't want the query processors default partition to be used.
+		// This is a small hack because for V1 CE, this is not the correct default partition name, but we
+		// need to add something to disambiguate the empty field.
+		// When the request is scoped to a partition, the servers of that partition are the nameservers.
+		partition := acl.DefaultPartitionName //NOTE: note this won't work if we ever have V2 client agents
+		if opts.reqCtx.DefaultPartition != "" {
+			partition = opts.reqCtx.DefaultPartition
+		}
 		query := &discovery.Query{
 			QueryType: discovery.QueryTypeService,
 			QueryPayload: discovery.QueryPayload{
 				Name: structs.ConsulServiceName,
 				Tenancy: discovery.QueryTenancy{
-					// We specify the partition here so that in the case we are a client agent in a non-default partition.
-					// We don't want the query processors default partition to be used.
-					// This is a small hack because for V1 CE, this is not the correct default partition name, but we
-					// need to add something to disambiguate the empty field.
-					Partition: acl.DefaultPartitionName, //NOTE: note this won't work if we ever have V2 client agents
+					Partition: partition,
 				},
 				Limit: 3,
 			},
@@ -113,6 +119,11 @@ func buildQueryFromDNSMessage(req *dns.Msg, reqCtx Context, domain, altDomain st
 
 	portName := parsePort(queryParts)
 
+	var nodeMeta map[string]string
+	if queryType == discovery.QueryTypeNodeMeta {
+		nodeMeta = parseNodeMeta(queryParts)
+	}
+
 	switch {
 	case queryType == discovery.QueryTypeWorkload && req.Question[0].Qtype == dns.TypeSRV:
 		// Currently we do not support SRV records for workloads
@@ -129,6 +140,7 @@ func buildQueryFromDNSMessage(req *dns.Msg, reqCtx Context, domain, altDomain st
 			Tag:      tag,
 			PortName: portName,
 			SourceIP: getSourceIP(req, queryType, remoteAddress),
+			NodeMeta: nodeMeta,
 		},
 	}, nil
 }
@@ -224,6 +236,12 @@ func getQueryNameAndTagFromParts(queryType discovery.QueryType, queryParts []str
 			return "", "", errInvalidQuestion
 		}
 		return name, "", nil
+	case discovery.QueryTypeNodeMeta:
+		// <key>.<value>.node-meta, the selector is parsed by parseNodeMeta.
+		if n != 2 {
+			return "", "", errInvalidQuestion
+		}
+		return queryParts[0] + "=" + queryParts[1], "", nil
 	}
 	name := queryParts[n-1]
 	if name == "" {
@@ -302,7 +320,7 @@ func getQueryTypePartsAndSuffixesFromDNSMessage(req *dns.Msg, domain, altDomain
 		switch queryType {
 		case discovery.QueryTypeService, discovery.QueryTypeWorkload,
 			discovery.QueryTypeConnect, discovery.QueryTypeVirtual, discovery.QueryTypeIngress,
-			discovery.QueryTypeNode, discovery.QueryTypePreparedQuery:
+			discovery.QueryTypeNode, discovery.QueryTypeNodeMeta, discovery.QueryTypePreparedQuery:
 			parts = labels[:i]
 			suffixes = labels[i+1:]
 			done = true
@@ -352,6 +370,8 @@ func getQueryTypeFromLabels(label string) discovery.QueryType {
 		return discovery.QueryTypeIngress
 	case "node":
 		return discovery.QueryTypeNode
+	case "node-meta":
+		return discovery.QueryTypeNodeMeta
 	case "query":
 		return discovery.QueryTypePreparedQuery
 	case "workload":
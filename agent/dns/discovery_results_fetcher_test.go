@@ -231,6 +231,47 @@ func Test_buildQueryFromDNSMessage(t *testing.T) {
 			},
 			expectedError: "invalid question",
 		},
+		{
+			name: "test A 'node-meta.'",
+			request: &dns.Msg{
+				MsgHdr: dns.MsgHdr{
+					Opcode: dns.OpcodeQuery,
+				},
+				Question: []dns.Question{
+					{
+						Name:   "rack.r-12.node-meta.dc1.consul.",
+						Qtype:  dns.TypeA,
+						Qclass: dns.ClassINET,
+					},
+				},
+			},
+			expectedQuery: &discovery.Query{
+				QueryType: discovery.QueryTypeNodeMeta,
+				QueryPayload: discovery.QueryPayload{
+					Name:     "rack=r-12",
+					NodeMeta: map[string]string{"rack": "r-12"},
+					Tenancy: discovery.QueryTenancy{
+						Datacenter: "dc1",
+					},
+				},
+			},
+		},
+		{
+			name: "test A 'node-meta.' without a value",
+			request: &dns.Msg{
+				MsgHdr: dns.MsgHdr{
+					Opcode: dns.OpcodeQuery,
+				},
+				Question: []dns.Question{
+					{
+						Name:   "rack.node-meta.consul.",
+						Qtype:  dns.TypeA,
+						Qclass: dns.ClassINET,
+					},
+				},
+			},
+			expectedError: "invalid question",
+		},
 		// V2 Catalog Queries
 		{
 			name: "test A 'workload.'",
@@ -12,6 +12,7 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/discovery"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/internal/dnsutil"
@@ -222,14 +223,14 @@ func (d messageSerializer) getAnswerExtraAndNs(opts *getAnswerExtraAndNsOptions)
 		if parseRequestType(opts.req) == requestTypeConsul && resultType == discovery.ResultTypeService {
 			resultType = discovery.ResultTypeNode
 		}
-		fqdn := canonicalNameForResult(resultType, target, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
+		fqdn := nameserverNameForResult(resultType, target, opts)
 		extraRecord := opts.dnsRecordMaker.makeIPBasedRecord(fqdn, nodeAddress, opts.ttl)
 
 		answer = append(answer, opts.dnsRecordMaker.makeNS(opts.responseDomain, fqdn, opts.ttl))
 		extra = append(extra, extraRecord)
 	case qType == dns.TypeSOA:
 		// to be returned in the result.
-		fqdn := canonicalNameForResult(opts.result.Type, opts.result.Node.Name, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
+		fqdn := nameserverNameForResult(opts.result.Type, opts.result.Node.Name, opts)
 		extraRecord := opts.dnsRecordMaker.makeIPBasedRecord(fqdn, nodeAddress, opts.ttl)
 
 		ns = append(ns, opts.dnsRecordMaker.makeNS(opts.responseDomain, fqdn, opts.ttl))
@@ -295,6 +296,7 @@ func (d messageSerializer) getAnswerExtrasForAddressAndTarget(nodeAddress *dnsAd
 		canonicalNodeName := canonicalNameForResult(opts.result.Type,
 			opts.result.Node.Name, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
 		a, e := getAnswerExtrasForIP(canonicalNodeName, nodeAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, false)
+		a, e = d.appendDualStackRecords(a, e, nodeAddress, false, opts)
 		answer = append(answer, a...)
 		extra = append(extra, e...)
 
@@ -314,7 +316,8 @@ func (d messageSerializer) getAnswerExtrasForAddressAndTarget(nodeAddress *dnsAd
 		}
 		canonicalNodeName := canonicalNameForResult(resultType, opts.result.Node.Name,
 			opts.responseDomain, opts.result.Tenancy, opts.port.Name)
-		a, e := getAnswerExtrasForIP(canonicalNodeName, nodeAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, nodeAddress.String() == opts.result.Node.Address) // We compare the node address to the result to detect changes from the WAN translation
+		a, e := getAnswerExtrasForIP(canonicalNodeName, nodeAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, isNodeLANAddress(nodeAddress, opts.result.Node)) // We compare the node address to the result to detect changes from the WAN translation
+		a, e = d.appendDualStackRecords(a, e, nodeAddress, false, opts)
 		answer = append(answer, a...)
 		extra = append(extra, e...)
 
@@ -334,6 +337,7 @@ func (d messageSerializer) getAnswerExtrasForAddressAndTarget(nodeAddress *dnsAd
 		canonicalServiceName := canonicalNameForResult(discovery.ResultTypeService,
 			opts.result.Service.Name, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
 		a, e := getAnswerExtrasForIP(canonicalServiceName, serviceAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, false)
+		a, e = d.appendDualStackRecords(a, e, serviceAddress, true, opts)
 		answer = append(answer, a...)
 		extra = append(extra, e...)
 
@@ -342,7 +346,8 @@ func (d messageSerializer) getAnswerExtrasForAddressAndTarget(nodeAddress *dnsAd
 	case serviceAddress.FQDN() == opts.req.Question[0].Name && nodeAddress.IsIP():
 		canonicalNodeName := canonicalNameForResult(discovery.ResultTypeNode,
 			opts.result.Node.Name, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
-		a, e := getAnswerExtrasForIP(canonicalNodeName, nodeAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, nodeAddress.String() == opts.result.Node.Address) // We compare the node address to the result to detect changes from the WAN translation
+		a, e := getAnswerExtrasForIP(canonicalNodeName, nodeAddress, opts.req.Question[0], reqType, opts.result, opts.ttl, opts.responseDomain, &opts.port, opts.dnsRecordMaker, isNodeLANAddress(nodeAddress, opts.result.Node)) // We compare the node address to the result to detect changes from the WAN translation
+		a, e = d.appendDualStackRecords(a, e, nodeAddress, false, opts)
 		answer = append(answer, a...)
 		extra = append(extra, e...)
 
@@ -356,6 +361,59 @@ func (d messageSerializer) getAnswerExtrasForAddressAndTarget(nodeAddress *dnsAd
 	return
 }
 
+// appendDualStackRecords adds the record of the other IP family of a dual-stack
+// service or node next to the A or AAAA record of addr, for the queries
+// accepting both families. The SRV targets which encode the IP address can't
+// have records of the other family, so the SRV queries only get them when the
+// target is the node name.
+func (d messageSerializer) appendDualStackRecords(answer, extra []dns.RR, addr *dnsAddress, isService bool, opts *getAnswerExtraAndNsOptions) ([]dns.RR, []dns.RR) {
+	qType := opts.req.Question[0].Qtype
+	if (qType != dns.TypeANY && qType != dns.TypeSRV) || !addr.IsIP() {
+		return answer, extra
+	}
+
+	accept := dnsutil.TranslateAddressAcceptIPv6
+	if !addr.IsIPV4() {
+		accept = dnsutil.TranslateAddressAcceptIPv4
+	}
+	var other *dnsAddress
+	if isService {
+		other = newDNSAddress(opts.translateServiceAddressFunc(opts.result.Tenancy.Datacenter,
+			opts.result.Service.Address, getServiceAddressMapFromLocationMap(opts.result.Service.TaggedAddresses), accept))
+	} else {
+		other = newDNSAddress(opts.translateAddressFunc(opts.result.Tenancy.Datacenter,
+			opts.result.Node.Address, getStringAddressMapFromTaggedAddressMap(opts.result.Node.TaggedAddresses), accept))
+	}
+	if !other.IsIP() || other.IsIPV4() == addr.IsIPV4() {
+		return answer, extra
+	}
+
+	appendOther := func(rrs []dns.RR) []dns.RR {
+		for _, rr := range rrs {
+			name := rr.Header().Name
+			switch rec := rr.(type) {
+			case *dns.A:
+				if !rec.A.Equal(addr.IP()) {
+					continue
+				}
+			case *dns.AAAA:
+				if !rec.AAAA.Equal(addr.IP()) {
+					continue
+				}
+			default:
+				continue
+			}
+			if qType == dns.TypeSRV && name != canonicalNameForResult(discovery.ResultTypeNode,
+				opts.result.Node.Name, opts.responseDomain, opts.result.Tenancy, opts.port.Name) {
+				return rrs
+			}
+			return append(rrs, opts.dnsRecordMaker.makeIPBasedRecord(name, other, opts.ttl))
+		}
+		return rrs
+	}
+	return appendOther(answer), appendOther(extra)
+}
+
 // makeRecordFromFQDN creates a DNS record from a FQDN.
 func (d messageSerializer) makeRecordFromFQDN(fqdn string, opts *getAnswerExtraAndNsOptions) ([]dns.RR, []dns.RR) {
 	edns := opts.req.IsEdns0() != nil
@@ -446,7 +504,7 @@ func shouldAppendTXTRecord(query *discovery.Query, cfg *RouterDynamicConfig, req
 	qType := req.Question[0].Qtype
 	switch {
 	// Node records
-	case query != nil && query.QueryType == discovery.QueryTypeNode && (cfg.NodeMetaTXT || qType == dns.TypeANY || qType == dns.TypeTXT):
+	case query != nil && (query.QueryType == discovery.QueryTypeNode || query.QueryType == discovery.QueryTypeNodeMeta) && (cfg.NodeMetaTXT || qType == dns.TypeANY || qType == dns.TypeTXT):
 		return true
 	// Service records
 	case query != nil && query.QueryType == discovery.QueryTypeService && cfg.NodeMetaTXT && qType == dns.TypeSRV:
@@ -541,6 +599,24 @@ func encodeIPAsFqdn(result *discovery.Result, ip net.IP, responseDomain string)
 	return fmt.Sprintf("%s.addr.%s.%s", ipStr, result.Tenancy.Datacenter, responseDomain)
 }
 
+// nameserverNameForResult returns the name of the nameserver for a discovery result
+// in the NS records. For lookups scoped to a non-default partition, the
+// nameservers are the servers of that partition, so a partition-qualified
+// workload name is returned to keep the delegation within the partition.
+func nameserverNameForResult(resultType discovery.ResultType, target string, opts *getAnswerExtraAndNsOptions) string {
+	partition := opts.reqCtx.DefaultPartition
+	if partition == "" || partition == acl.NonEmptyDefaultPartitionName {
+		return canonicalNameForResult(resultType, target, opts.responseDomain, opts.result.Tenancy, opts.port.Name)
+	}
+
+	tenancy := opts.result.Tenancy
+	tenancy.Partition = partition
+	if tenancy.Namespace == "" {
+		tenancy.Namespace = acl.DefaultNamespaceName
+	}
+	return canonicalNameForResult(discovery.ResultTypeWorkload, target, opts.responseDomain, tenancy, "")
+}
+
 // canonicalNameForResult returns the canonical name for a discovery result.
 func canonicalNameForResult(resultType discovery.ResultType, target, domain string,
 	tenancy discovery.ResultTenancy, portName string) string {
@@ -603,6 +679,20 @@ func getStringAddressMapFromTaggedAddressMap(taggedAddresses map[string]*discove
 	return taggedServiceAddresses
 }
 
+// isNodeLANAddress reports whether addr is an address of the node in its own
+// datacenter, of either IP family, rather than its translated WAN address.
+func isNodeLANAddress(addr *dnsAddress, node *discovery.Location) bool {
+	if addr.String() == node.Address {
+		return true
+	}
+	for _, key := range []string{structs.TaggedAddressLANIPv4, structs.TaggedAddressLANIPv6} {
+		if ta, ok := node.TaggedAddresses[key]; ok && ta != nil && ta.Address == addr.String() {
+			return true
+		}
+	}
+	return false
+}
+
 // getTTLForResult returns the TTL for a given result.
 func getTTLForResult(name string, overrideTTL *uint32, query *discovery.Query, cfg *RouterDynamicConfig) uint32 {
 	// In the case we are not making a discovery query, such as addr. or arpa. lookups,
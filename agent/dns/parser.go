@@ -76,6 +76,17 @@ func parseLabels(labels []string) (*parsedLabels, bool) {
 	return &result, false
 }
 
+// parseNodeMeta returns the node metadata selector of a node metadata lookup.
+// It assumes the only valid input format is ["<key>", "<value>"].
+// Keys and values may both contain dashes, which is why they are separate
+// labels rather than a single "<key>-<value>" label.
+func parseNodeMeta(parts []string) map[string]string {
+	if len(parts) != 2 {
+		return nil
+	}
+	return map[string]string{parts[0]: parts[1]}
+}
+
 // parsePort looks through the query parts for a named port label.
 // It assumes the only valid input format is["<portName>", "port", "<targetName>"].
 // The other expected formats are ["<targetName>"] and ["<tag>", "<targetName>"].
@@ -17,11 +17,13 @@ import (
 
 type recursor struct {
 	logger hclog.Logger
+	cache  *recursorCache
 }
 
 func newRecursor(logger hclog.Logger) *recursor {
 	return &recursor{
 		logger: logger.Named(logging.DNS),
+		cache:  newRecursorCache(),
 	}
 }
 
@@ -41,8 +43,27 @@ func (r *recursor) handle(req *dns.Msg, cfgCtx *RouterDynamicConfig, remoteAddr
 	}(time.Now())
 
 	// Switch to TCP if the client is
+	maxSize := dns.MaxMsgSize
 	if _, ok := remoteAddr.(*net.TCPAddr); ok {
 		network = "tcp"
+	} else {
+		maxSize = dns.MinMsgSize
+		if edns := req.IsEdns0(); edns != nil && edns.UDPSize() > dns.MinMsgSize {
+			maxSize = int(edns.UDPSize())
+		}
+	}
+
+	// Answer from the cache when the response has not expired yet, and keep
+	// any stale response in case the recursors fail.
+	var cached *dns.Msg
+	if cfgCtx.RecursorCacheSize > 0 {
+		var stale bool
+		cached, stale = r.cache.get(req, maxSize, cfgCtx.RecursorCacheStaleIfError, time.Now())
+		if cached != nil && !stale {
+			cached.Compress = !cfgCtx.DisableCompression
+			r.logger.Trace("recurse answered from cache for question", "question", q)
+			return cached, nil
+		}
 	}
 
 	// Recursively resolve
@@ -76,6 +97,7 @@ func (r *recursor) handle(req *dns.Msg, cfgCtx *RouterDynamicConfig, remoteAddr
 				"rtt", rtt,
 				"recursor", recurseAddr,
 			)
+			r.cache.set(req, resp, cfgCtx.RecursorCacheSize, time.Now())
 			return resp, nil
 		}
 		r.logger.Error("recurse failed", "error", err)
@@ -88,6 +110,14 @@ func (r *recursor) handle(req *dns.Msg, cfgCtx *RouterDynamicConfig, remoteAddr
 		"client_network", remoteAddr.Network(),
 	)
 
+	if cached != nil {
+		cached.Compress = !cfgCtx.DisableCompression
+		r.logger.Warn("serving stale cached response for question",
+			"question", q,
+			"client", remoteAddr.String(),
+		)
+		return cached, nil
+	}
 	return nil, errRecursionFailed
 }
 
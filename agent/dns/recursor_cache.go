@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dns
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// maxRecursorCacheTTL caps how long a response of the recursors is cached,
+	// whatever the TTL of its records.
+	maxRecursorCacheTTL = time.Hour
+
+	// staleRecursorTTL is the TTL of the records of the stale responses served
+	// when the recursors fail, as recommended by RFC 8767.
+	staleRecursorTTL = 30
+)
+
+// recursorCacheKey identifies the questions answered by the same response.
+// Names are compared case-insensitively, and the DNSSEC flags are part of
+// the key since they change the records returned by the recursors.
+type recursorCacheKey struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+	do     bool
+	cd     bool
+}
+
+type recursorCacheEntry struct {
+	key    recursorCacheKey
+	msg    *dns.Msg
+	stored time.Time
+	expiry time.Time
+}
+
+// recursorCache is a size-bounded cache of the responses of the recursors.
+// Responses are kept until the smallest TTL of their records expires, and are
+// served with their TTLs decremented by the time spent in the cache. Once
+// expired, they can still be served for a while when the recursors fail.
+// When the cache is full, the least recently used response is evicted.
+type recursorCache struct {
+	mu      sync.Mutex
+	entries map[recursorCacheKey]*list.Element
+	lru     *list.List
+}
+
+func newRecursorCache() *recursorCache {
+	return &recursorCache{
+		entries: make(map[recursorCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func recursorCacheKeyFor(req *dns.Msg) recursorCacheKey {
+	q := req.Question[0]
+	key := recursorCacheKey{
+		name:   strings.ToLower(q.Name),
+		qtype:  q.Qtype,
+		qclass: q.Qclass,
+		cd:     req.CheckingDisabled,
+	}
+	if edns := req.IsEdns0(); edns != nil {
+		key.do = edns.Do()
+	}
+	return key
+}
+
+// recursorCacheTTL returns how long resp can be cached for, or zero if it
+// must not be cached. Positive responses are cached for the smallest TTL of
+// their records. Negative responses are cached for the TTL of the SOA record
+// of their authority section, capped by its minimum field, as per RFC 2308.
+func recursorCacheTTL(resp *dns.Msg) time.Duration {
+	if resp.Truncated || (resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError) {
+		return 0
+	}
+
+	var ttl uint32
+	if resp.Rcode == dns.RcodeSuccess && len(resp.Answer) > 0 {
+		ttl = minTTL(resp.Answer, resp.Ns, resp.Extra)
+	} else {
+		// NXDOMAIN or NODATA responses are only cached when the authority
+		// section tells for how long.
+		for _, rr := range resp.Ns {
+			if soa, ok := rr.(*dns.SOA); ok {
+				ttl = soa.Hdr.Ttl
+				if soa.Minttl < ttl {
+					ttl = soa.Minttl
+				}
+				break
+			}
+		}
+	}
+
+	d := time.Duration(ttl) * time.Second
+	if d > maxRecursorCacheTTL {
+		d = maxRecursorCacheTTL
+	}
+	return d
+}
+
+// minTTL returns the smallest TTL of the given records, ignoring the OPT
+// pseudo-record whose TTL field holds flags.
+func minTTL(sections ...[]dns.RR) uint32 {
+	first := true
+	var ttl uint32
+	for _, rrs := range sections {
+		for _, rr := range rrs {
+			hdr := rr.Header()
+			if hdr.Rrtype == dns.TypeOPT {
+				continue
+			}
+			if first || hdr.Ttl < ttl {
+				ttl = hdr.Ttl
+				first = false
+			}
+		}
+	}
+	return ttl
+}
+
+// set caches resp as the response to req, evicting the least recently used
+// responses to keep at most size of them.
+func (c *recursorCache) set(req, resp *dns.Msg, size int, now time.Time) {
+	ttl := recursorCacheTTL(resp)
+	if ttl <= 0 || size <= 0 {
+		return
+	}
+
+	entry := &recursorCacheEntry{
+		key:    recursorCacheKeyFor(req),
+		msg:    resp.Copy(),
+		stored: now,
+		expiry: now.Add(ttl),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[entry.key]; ok {
+		c.lru.Remove(elem)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// get returns the cached response to req, with its TTLs decremented by the
+// time spent in the cache. Once the response expired, it is still returned
+// for staleIfError, flagged as stale and with the TTLs of its records set to
+// staleRecursorTTL, so that it can be served if the recursors fail. It
+// returns nil if there is no such response, or if it is longer than maxSize
+// bytes.
+func (c *recursorCache) get(req *dns.Msg, maxSize int, staleIfError time.Duration, now time.Time) (resp *dns.Msg, stale bool) {
+	key := recursorCacheKeyFor(req)
+
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+	entry := elem.Value.(*recursorCacheEntry)
+	if !now.Before(entry.expiry.Add(staleIfError)) {
+		c.remove(elem)
+		c.mu.Unlock()
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	c.mu.Unlock()
+
+	resp = entry.msg.Copy()
+	resp.Id = req.Id
+	resp.Question = append([]dns.Question(nil), req.Question...)
+	if resp.Len() > maxSize {
+		return nil, false
+	}
+
+	stale = !now.Before(entry.expiry)
+	elapsed := uint32(now.Sub(entry.stored) / time.Second)
+	for _, rrs := range [][]dns.RR{resp.Answer, resp.Ns, resp.Extra} {
+		for _, rr := range rrs {
+			hdr := rr.Header()
+			switch {
+			case hdr.Rrtype == dns.TypeOPT:
+			case stale:
+				hdr.Ttl = staleRecursorTTL
+			case hdr.Ttl > elapsed:
+				hdr.Ttl -= elapsed
+			default:
+				hdr.Ttl = 0
+			}
+		}
+	}
+	return resp, stale
+}
+
+// remove must be called with the lock held.
+func (c *recursorCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*recursorCacheEntry)
+	delete(c.entries, entry.key)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dns
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func recursorCacheTestResponse(req *dns.Msg, ttl uint32) *dns.Msg {
+	resp := &dns.Msg{}
+	resp.SetReply(req)
+	resp.Answer = []dns.RR{
+		&dns.CNAME{
+			Hdr:    dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: ttl * 2},
+			Target: "www.example.com.",
+		},
+		&dns.A{
+			Hdr: dns.RR_Header{Name: "www.example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: ttl},
+			A:   net.ParseIP("93.184.216.34"),
+		},
+	}
+	return resp
+}
+
+func TestRecursorCache(t *testing.T) {
+	now := time.Now()
+	req := &dns.Msg{}
+	req.SetQuestion("example.com.", dns.TypeA)
+
+	t.Run("decrements TTLs", func(t *testing.T) {
+		c := newRecursorCache()
+		c.set(req, recursorCacheTestResponse(req, 60), 10, now)
+
+		// Questions are matched case-insensitively and answered with the ID
+		// and question of the request.
+		other := &dns.Msg{}
+		other.SetQuestion("EXAMPLE.com.", dns.TypeA)
+		resp, stale := c.get(other, dns.MaxMsgSize, 0, now.Add(25*time.Second))
+		require.NotNil(t, resp)
+		require.False(t, stale)
+		require.Equal(t, other.Id, resp.Id)
+		require.Equal(t, other.Question, resp.Question)
+		require.Equal(t, uint32(95), resp.Answer[0].Header().Ttl)
+		require.Equal(t, uint32(35), resp.Answer[1].Header().Ttl)
+
+		// The response expires with its smallest TTL.
+		resp, _ = c.get(req, dns.MaxMsgSize, 0, now.Add(60*time.Second))
+		require.Nil(t, resp)
+	})
+
+	t.Run("other questions", func(t *testing.T) {
+		c := newRecursorCache()
+		c.set(req, recursorCacheTestResponse(req, 60), 10, now)
+
+		aaaa := &dns.Msg{}
+		aaaa.SetQuestion("example.com.", dns.TypeAAAA)
+		resp, _ := c.get(aaaa, dns.MaxMsgSize, 0, now)
+		require.Nil(t, resp)
+
+		dnssec := &dns.Msg{}
+		dnssec.SetQuestion("example.com.", dns.TypeA)
+		dnssec.SetEdns0(4096, true)
+		resp, _ = c.get(dnssec, dns.MaxMsgSize, 0, now)
+		require.Nil(t, resp)
+
+		// Responses which do not fit are not served.
+		resp, _ = c.get(req, 40, 0, now)
+		require.Nil(t, resp)
+	})
+
+	t.Run("stale if error", func(t *testing.T) {
+		c := newRecursorCache()
+		c.set(req, recursorCacheTestResponse(req, 60), 10, now)
+
+		resp, stale := c.get(req, dns.MaxMsgSize, time.Minute, now.Add(90*time.Second))
+		require.NotNil(t, resp)
+		require.True(t, stale)
+		for _, rr := range resp.Answer {
+			require.Equal(t, uint32(staleRecursorTTL), rr.Header().Ttl)
+		}
+
+		// Responses are dropped once they are too old to be served.
+		resp, _ = c.get(req, dns.MaxMsgSize, time.Minute, now.Add(2*time.Minute))
+		require.Nil(t, resp)
+		require.Zero(t, c.lru.Len())
+	})
+
+	t.Run("negative responses", func(t *testing.T) {
+		c := newRecursorCache()
+		nx := &dns.Msg{}
+		nx.SetRcode(req, dns.RcodeNameError)
+		c.set(req, nx, 10, now)
+		resp, _ := c.get(req, dns.MaxMsgSize, 0, now)
+		require.Nil(t, resp, "negative responses without SOA are not cached")
+
+		nx.Ns = []dns.RR{
+			&dns.SOA{
+				Hdr:    dns.RR_Header{Name: "com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 900},
+				Minttl: 300,
+			},
+		}
+		c.set(req, nx, 10, now)
+		resp, _ = c.get(req, dns.MaxMsgSize, 0, now.Add(299*time.Second))
+		require.NotNil(t, resp)
+		require.Equal(t, dns.RcodeNameError, resp.Rcode)
+		resp, _ = c.get(req, dns.MaxMsgSize, 0, now.Add(300*time.Second))
+		require.Nil(t, resp)
+	})
+
+	t.Run("uncacheable responses", func(t *testing.T) {
+		c := newRecursorCache()
+		servfail := &dns.Msg{}
+		servfail.SetRcode(req, dns.RcodeServerFailure)
+		c.set(req, servfail, 10, now)
+
+		truncated := recursorCacheTestResponse(req, 60)
+		truncated.Truncated = true
+		c.set(req, truncated, 10, now)
+
+		c.set(req, recursorCacheTestResponse(req, 0), 10, now)
+		require.Zero(t, c.lru.Len())
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		c := newRecursorCache()
+		names := []string{"a.example.com.", "b.example.com.", "c.example.com."}
+		reqs := make([]*dns.Msg, len(names))
+		for i, name := range names {
+			reqs[i] = &dns.Msg{}
+			reqs[i].SetQuestion(name, dns.TypeA)
+		}
+
+		c.set(reqs[0], recursorCacheTestResponse(reqs[0], 60), 2, now)
+		c.set(reqs[1], recursorCacheTestResponse(reqs[1], 60), 2, now)
+		resp, _ := c.get(reqs[0], dns.MaxMsgSize, 0, now)
+		require.NotNil(t, resp)
+		c.set(reqs[2], recursorCacheTestResponse(reqs[2], 60), 2, now)
+
+		resp, _ = c.get(reqs[1], dns.MaxMsgSize, 0, now)
+		require.Nil(t, resp)
+		for _, r := range []*dns.Msg{reqs[0], reqs[2]} {
+			resp, _ = c.get(r, dns.MaxMsgSize, 0, now)
+			require.NotNil(t, resp)
+		}
+	})
+}
+
+func TestRecursor_handle_Cache(t *testing.T) {
+	var queries int32
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, req *dns.Msg) {
+		atomic.AddInt32(&queries, 1)
+		require.NoError(t, w.WriteMsg(recursorCacheTestResponse(req, 60)))
+	})
+	up := make(chan struct{})
+	server := &dns.Server{
+		Addr:              "127.0.0.1:0",
+		Net:               "udp",
+		Handler:           mux,
+		NotifyStartedFunc: func() { close(up) },
+	}
+	go server.ListenAndServe()
+	<-up
+	t.Cleanup(func() { server.Shutdown() })
+
+	r := newRecursor(testutil.Logger(t))
+	cfg := &RouterDynamicConfig{
+		Recursors:         []string{server.PacketConn.LocalAddr().String()},
+		RecursorTimeout:   time.Second,
+		RecursorCacheSize: 10,
+	}
+	remoteAddr := &net.UDPAddr{IP: net.ParseIP("127.0.0.1")}
+
+	for i := 0; i < 3; i++ {
+		req := &dns.Msg{}
+		req.SetQuestion("example.com.", dns.TypeA)
+		resp, err := r.handle(req, cfg, remoteAddr)
+		require.NoError(t, err)
+		require.Equal(t, req.Id, resp.Id)
+		require.Len(t, resp.Answer, 2)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&queries))
+
+	// Expired responses are served when the recursors fail.
+	req := &dns.Msg{}
+	req.SetQuestion("stale.example.com.", dns.TypeA)
+	r.cache.set(req, recursorCacheTestResponse(req, 60), 10, time.Now().Add(-2*time.Minute))
+	require.NoError(t, server.Shutdown())
+
+	cfg.RecursorTimeout = 100 * time.Millisecond
+	_, err := r.handle(req, cfg, remoteAddr)
+	require.ErrorIs(t, err, errRecursionFailed)
+
+	r.cache.set(req, recursorCacheTestResponse(req, 60), 10, time.Now().Add(-2*time.Minute))
+	cfg.RecursorCacheStaleIfError = time.Hour
+	resp, err := r.handle(req, cfg, remoteAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint32(staleRecursorTTL), resp.Answer[0].Header().Ttl)
+}
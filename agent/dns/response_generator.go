@@ -10,6 +10,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/armon/go-metrics"
 	"github.com/miekg/dns"
 
 	"github.com/hashicorp/consul/agent/discovery"
@@ -28,9 +29,8 @@ const (
 
 	// If a consumer sets a buffer size greater than this amount we will default it down
 	// to this amount to ensure that consul does respond. Previously if consumer had a larger buffer
-	// size than 65535 - 60 bytes (maximim 60 bytes for IP header. UDP header will be offset in the
-	// trimUDP call) consul would fail to respond and the consumer timesout
-	// the request.
+	// size than 65535 - 68 bytes (maximum 60 bytes for IP header and 8 bytes for the UDP header)
+	// consul would fail to respond and the consumer timesout the request.
 	maxUDPDatagramSize = math.MaxUint16 - 68
 )
 
@@ -145,25 +145,34 @@ func (d dnsResponseGenerator) trimDNSResponse(cfg *RouterDynamicConfig, remoteAd
 	}
 
 	var trimmed bool
+	var cause string
 	originalSize := resp.Len()
 	originalNumRecords := len(resp.Answer)
 	if network != "tcp" {
-		trimmed = trimUDPResponse(req, resp, cfg.UDPAnswerLimit)
+		trimmed, cause = trimUDPResponse(req, resp, cfg.UDPAnswerLimit)
 	} else {
-		trimmed = trimTCPResponse(req, resp)
+		trimmed, cause = trimTCPResponse(req, resp)
 	}
+	if cause == "" {
+		return
+	}
+	metrics.IncrCounterWithLabels([]string{"dns", "truncated_responses"}, 1,
+		[]metrics.Label{
+			{Name: "protocol", Value: network},
+			{Name: "cause", Value: cause},
+		})
+
 	// Flag that there are more records to return in the UDP response
-	if trimmed {
-		if cfg.EnableTruncate {
-			resp.Truncated = true
-		}
-		logger.Debug("DNS response too large, truncated",
-			"protocol", network,
-			"question", req.Question,
-			"records", fmt.Sprintf("%d/%d", len(resp.Answer), originalNumRecords),
-			"size", fmt.Sprintf("%d/%d", resp.Len(), originalSize),
-		)
+	if trimmed && cfg.EnableTruncate {
+		resp.Truncated = true
 	}
+	logger.Debug("DNS response too large, truncated",
+		"protocol", network,
+		"question", req.Question,
+		"cause", cause,
+		"records", fmt.Sprintf("%d/%d", len(resp.Answer), originalNumRecords),
+		"size", fmt.Sprintf("%d/%d", resp.Len(), originalSize),
+	)
 }
 
 // setEDNS is used to set the responses EDNS size headers and
@@ -218,17 +227,122 @@ func ednsSubnetForRequest(req *dns.Msg) *dns.EDNS0_SUBNET {
 	return nil
 }
 
+// Causes of the truncation of DNS responses, reported by the cause label of
+// the dns.truncated_responses metric. When several sections were trimmed,
+// the cause is the most significant one.
+const (
+	// truncateCauseAuthority is reported when only the authority section was
+	// dropped.
+	truncateCauseAuthority = "authority"
+
+	// truncateCauseAdditional is reported when additional records were
+	// dropped but every answer was kept.
+	truncateCauseAdditional = "additional"
+
+	// truncateCauseAnswerLimit is reported when answers over the UDP answer
+	// limit were dropped.
+	truncateCauseAnswerLimit = "answer_limit"
+
+	// truncateCauseAnswer is reported when answers were dropped because they
+	// did not fit in the response.
+	truncateCauseAnswer = "answer"
+)
+
+// splitOPT separates the OPT pseudo-records from the other records of an
+// additional section.
+func splitOPT(extra []dns.RR) (records, opt []dns.RR) {
+	for _, rr := range extra {
+		if rr.Header().Rrtype == dns.TypeOPT {
+			opt = append(opt, rr)
+		} else {
+			records = append(records, rr)
+		}
+	}
+	return records, opt
+}
+
+// dnsBinaryTruncate finds the largest n between 0 and limit for which resp
+// is no longer than maxSize bytes once set(n) has been called, using a binary
+// search. The size of the response must grow with n. It leaves the response
+// in an unspecified state, so the caller must call set with the result.
+func dnsBinaryTruncate(resp *dns.Msg, maxSize, limit int, set func(n int)) int {
+	low, high := 0, limit
+	for low < high {
+		median := low + (high-low+1)/2
+		set(median)
+		if resp.Len() <= maxSize {
+			low = median
+		} else {
+			high = median - 1
+		}
+	}
+	return low
+}
+
+// trimToSize makes resp fit in maxSize bytes while dropping as few records as
+// possible. The authority section is dropped first, then the additional
+// records, and answers are only dropped when they do not fit on their own.
+// The additional records covering the answers that were kept are then added
+// back in the order of the answers for as long as they fit. The first answer
+// and the OPT record are always kept. It returns the cause of the truncation,
+// or an empty string if resp already fit.
+func trimToSize(resp *dns.Msg, maxSize int) string {
+	if resp.Len() <= maxSize {
+		return ""
+	}
+	numAnswers, numExtra, numNs := len(resp.Answer), len(resp.Extra), len(resp.Ns)
+
+	if numNs != 0 {
+		resp.Ns = []dns.RR{}
+	}
+
+	records, opt := splitOPT(resp.Extra)
+	if len(records) > 0 && resp.Len() > maxSize {
+		resp.Extra = opt
+	}
+
+	if answers := resp.Answer; len(answers) > 1 && resp.Len() > maxSize {
+		n := dnsBinaryTruncate(resp, maxSize, len(answers), func(n int) {
+			resp.Answer = answers[:n]
+		})
+		if n == 0 {
+			// Even when a single record is too big, send it anyway.
+			n = 1
+		}
+		resp.Answer = answers[:n]
+	}
+
+	if len(resp.Extra) < numExtra {
+		index := make(map[string]dns.RR, len(records))
+		indexRRs(records, index)
+		syncExtra(index, resp)
+
+		covering, _ := splitOPT(resp.Extra)
+		set := func(n int) {
+			resp.Extra = append(covering[:n:n], opt...)
+		}
+		set(dnsBinaryTruncate(resp, maxSize, len(covering), set))
+	}
+
+	switch {
+	case len(resp.Answer) < numAnswers:
+		return truncateCauseAnswer
+	case len(resp.Extra) < numExtra:
+		return truncateCauseAdditional
+	case numNs != 0:
+		return truncateCauseAuthority
+	default:
+		return ""
+	}
+}
+
 // trimTCPResponse limit the MaximumSize of messages to 64k as it is the limit
 // of DNS responses
-func trimTCPResponse(req, resp *dns.Msg) (trimmed bool) {
-	hasExtra := len(resp.Extra) > 0
+func trimTCPResponse(req, resp *dns.Msg) (trimmed bool, cause string) {
+	numAnswers := len(resp.Answer)
 	// There is some overhead, 65535 does not work
 	maxSize := 65523 // 64k - 12 bytes DNS raw overhead
 
-	// We avoid some function calls and allocations by only handling the
-	// extra data when necessary.
-	var index map[string]dns.RR
-
 	// It is not possible to return more than 4k records even with compression
 	// Since we are performing binary search it is not a big deal, but it
 	// improves a bit performance, even with binary search
@@ -239,45 +353,32 @@ func trimTCPResponse(req, resp *dns.Msg) (trimmed bool) {
 	}
 	if len(resp.Answer) > truncateAt {
 		resp.Answer = resp.Answer[:truncateAt]
-	}
-	if hasExtra {
-		index = make(map[string]dns.RR, len(resp.Extra))
-		indexRRs(resp.Extra, index)
-	}
-	truncated := false
-
-	// This enforces the given limit on 64k, the max limit for DNS messages
-	for len(resp.Answer) > 1 && resp.Len() > maxSize {
-		truncated = true
-		// first try to remove the NS section may be it will truncate enough
-		if len(resp.Ns) != 0 {
-			resp.Ns = []dns.RR{}
-		}
-		// More than 100 bytes, find with a binary search
-		if resp.Len()-maxSize > 100 {
-			bestIndex := dnsBinaryTruncate(resp, maxSize, index, hasExtra)
-			resp.Answer = resp.Answer[:bestIndex]
-		} else {
-			resp.Answer = resp.Answer[:len(resp.Answer)-1]
-		}
-		if hasExtra {
+		if len(resp.Extra) > 0 {
+			index := make(map[string]dns.RR, len(resp.Extra))
+			indexRRs(resp.Extra, index)
 			syncExtra(index, resp)
 		}
+		cause = truncateCauseAnswer
 	}
 
-	return truncated
+	// This enforces the given limit on 64k, the max limit for DNS messages
+	if c := trimToSize(resp, maxSize); cause == "" {
+		cause = c
+	}
+	return len(resp.Answer) < numAnswers, cause
 }
 
 // trimUDPResponse makes sure a UDP response is not longer than allowed by RFC
-// 1035. Enforce an arbitrary limit that can be further ratcheted down by
-// config, and then make sure the response doesn't exceed 512 bytes. Any extra
-// records will be trimmed along with answers.
-func trimUDPResponse(req, resp *dns.Msg, udpAnswerLimit int) (trimmed bool) {
+// 1035 or by the EDNS0 UDP payload size advertised by the client. Enforce an
+// arbitrary limit on the number of answers that can be further ratcheted down
+// by config for clients not using EDNS0, and then make sure the response fits
+// in the payload size. Additional records are trimmed before answers.
+func trimUDPResponse(req, resp *dns.Msg, udpAnswerLimit int) (trimmed bool, cause string) {
 	numAnswers := len(resp.Answer)
-	hasExtra := len(resp.Extra) > 0
 	maxSize := defaultMaxUDPSize
 
-	// Update to the maximum edns size
+	// Update to the maximum edns size. Sizes below 512 bytes must be treated
+	// as 512 bytes as per RFC 6891.
 	if edns := req.IsEdns0(); edns != nil {
 		if size := edns.UDPSize(); size > uint16(maxSize) {
 			maxSize = int(size)
@@ -289,14 +390,6 @@ func trimUDPResponse(req, resp *dns.Msg, udpAnswerLimit int) (trimmed bool) {
 		maxSize = maxUDPDatagramSize
 	}
 
-	// We avoid some function calls and allocations by only handling the
-	// extra data when necessary.
-	var index map[string]dns.RR
-	if hasExtra {
-		index = make(map[string]dns.RR, len(resp.Extra))
-		indexRRs(resp.Extra, index)
-	}
-
 	// This cuts UDP responses to a useful but limited number of responses.
 	maxAnswers := lib.MinInt(maxUDPAnswerLimit, udpAnswerLimit)
 	compress := resp.Compress
@@ -304,17 +397,12 @@ func trimUDPResponse(req, resp *dns.Msg, udpAnswerLimit int) (trimmed bool) {
 		// We disable computation of Len ONLY for non-eDNS request (512 bytes)
 		resp.Compress = false
 		resp.Answer = resp.Answer[:maxAnswers]
-		if hasExtra {
-			syncExtra(index, resp)
-		}
-	}
-	if maxSize == defaultMaxUDPSize && numAnswers > maxAnswers {
-		// We disable computation of Len ONLY for non-eDNS request (512 bytes)
-		resp.Compress = false
-		resp.Answer = resp.Answer[:maxAnswers]
-		if hasExtra {
+		if len(resp.Extra) > 0 {
+			index := make(map[string]dns.RR, len(resp.Extra))
+			indexRRs(resp.Extra, index)
 			syncExtra(index, resp)
 		}
+		cause = truncateCauseAnswerLimit
 	}
 
 	// This enforces the given limit on the number bytes. The default is 512 as
@@ -323,36 +411,20 @@ func trimUDPResponse(req, resp *dns.Msg, udpAnswerLimit int) (trimmed bool) {
 	// that will not exceed 512 bytes uncompressed, which is more conservative and
 	// will allow our responses to be compliant even if some downstream server
 	// uncompresses them.
-	// Even when size is too big for one single record, try to send it anyway
-	// (useful for 512 bytes messages). 8 is removed from maxSize to ensure that we account
-	// for the udp header (8 bytes).
-	for len(resp.Answer) > 1 && resp.Len() > maxSize-8 {
-		// first try to remove the NS section may be it will truncate enough
-		if len(resp.Ns) != 0 {
-			resp.Ns = []dns.RR{}
-		}
-		// More than 100 bytes, find with a binary search
-		if resp.Len()-maxSize > 100 {
-			bestIndex := dnsBinaryTruncate(resp, maxSize, index, hasExtra)
-			resp.Answer = resp.Answer[:bestIndex]
-		} else {
-			resp.Answer = resp.Answer[:len(resp.Answer)-1]
-		}
-		if hasExtra {
-			syncExtra(index, resp)
-		}
+	if c := trimToSize(resp, maxSize); cause == "" || c == truncateCauseAnswer {
+		cause = c
 	}
 	// For 512 non-eDNS responses, while we compute size non-compressed,
 	// we send result compressed
 	resp.Compress = compress
-	return len(resp.Answer) < numAnswers
+	return len(resp.Answer) < numAnswers, cause
 }
 
 // syncExtra takes a DNS response message and sets the extra data to the most
 // minimal set needed to cover the answer data. A pre-made index of RRs is given
 // so that can be re-used between calls. This assumes that the extra data is
 // only used to provide info for SRV records. If that's not the case, then this
-// will wipe out any additional data.
+// will wipe out any additional data other than the OPT record.
 func syncExtra(index map[string]dns.RR, resp *dns.Msg) {
 	extra := make([]dns.RR, 0, len(resp.Answer))
 	resolved := make(map[string]struct{}, len(resp.Answer))
@@ -382,34 +454,8 @@ func syncExtra(index map[string]dns.RR, resp *dns.Msg) {
 			}
 		}
 	}
-	resp.Extra = extra
-}
-
-// dnsBinaryTruncate find the optimal number of records using a fast binary search and return
-// it in order to return a DNS answer lower than maxSize parameter.
-func dnsBinaryTruncate(resp *dns.Msg, maxSize int, index map[string]dns.RR, hasExtra bool) int {
-	originalAnswser := resp.Answer
-	startIndex := 0
-	endIndex := len(resp.Answer) + 1
-	for endIndex-startIndex > 1 {
-		median := startIndex + (endIndex-startIndex)/2
-
-		resp.Answer = originalAnswser[:median]
-		if hasExtra {
-			syncExtra(index, resp)
-		}
-		aLen := resp.Len()
-		if aLen <= maxSize {
-			if maxSize-aLen < 10 {
-				// We are good, increasing will go out of bounds
-				return median
-			}
-			startIndex = median
-		} else {
-			endIndex = median
-		}
-	}
-	return startIndex
+	_, opt := splitOPT(resp.Extra)
+	resp.Extra = append(extra, opt...)
 }
 
 // indexRRs populates a map which indexes a given list of RRs by name. NOTE that
@@ -5,6 +5,7 @@ package dns
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"testing"
 	"time"
@@ -737,3 +738,52 @@ func TestDNSResponseGenerator_trimDNSResponse(t *testing.T) {
 
 	}
 }
+
+func TestDNSResponseGenerator_trimDNSResponse_DropsExtraBeforeAnswers(t *testing.T) {
+	req := &dns.Msg{}
+	req.SetQuestion("web.service.consul.", dns.TypeSRV)
+	req.SetEdns0(2048, false)
+
+	resp := &dns.Msg{Compress: true}
+	for i := 0; i < 40; i++ {
+		target := fmt.Sprintf("web-%d.node.dc1.consul.", i)
+		resp.Answer = append(resp.Answer, &dns.SRV{
+			Hdr: dns.RR_Header{
+				Name:   "web.service.consul.",
+				Rrtype: dns.TypeSRV,
+				Class:  dns.ClassINET,
+			},
+			Target: target,
+		})
+		resp.Extra = append(resp.Extra, &dns.A{
+			Hdr: dns.RR_Header{
+				Name:   target,
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+			},
+			A: net.ParseIP(fmt.Sprintf("10.0.1.%d", i)),
+		})
+	}
+	generator := dnsResponseGenerator{}
+	generator.setEDNS(req, resp, true)
+
+	cfg := &RouterDynamicConfig{EnableTruncate: true, UDPAnswerLimit: 3}
+	generator.trimDNSResponse(cfg, &net.UDPAddr{IP: net.ParseIP("127.0.0.1")}, req, resp, testutil.Logger(t))
+
+	// Every answer fits once additional records are dropped, so the response
+	// is not flagged as truncated.
+	require.False(t, resp.Truncated)
+	require.Len(t, resp.Answer, 40)
+	require.LessOrEqual(t, resp.Len(), 2048)
+
+	// The additional records kept cover the first answers, and the OPT
+	// record is kept last.
+	records, opt := splitOPT(resp.Extra)
+	require.NotEmpty(t, records)
+	require.Less(t, len(records), 40)
+	require.Len(t, opt, 1)
+	require.Equal(t, opt[0], resp.Extra[len(resp.Extra)-1])
+	for i, rr := range records {
+		require.Equal(t, resp.Answer[i].(*dns.SRV).Target, rr.Header().Name)
+	}
+}
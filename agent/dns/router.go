@@ -61,6 +61,12 @@ type RouterDynamicConfig struct {
 	// TTLStrict sets TTLs to service by full name match. It Has higher priority than TTLRadix
 	TTLStrict      map[string]time.Duration
 	UDPAnswerLimit int
+	// RecursorCacheSize is the maximum number of responses of the recursors
+	// which are cached. The cache is disabled when it is 0.
+	RecursorCacheSize int
+	// RecursorCacheStaleIfError is how long expired responses of the
+	// recursors can be served when the recursors fail.
+	RecursorCacheStaleIfError time.Duration
 }
 
 // GetTTLForService Find the TTL for a given service.
@@ -289,8 +295,10 @@ func (r *Router) handleRequestRecursively(req *dns.Msg, reqCtx Context, configCt
 		})
 	}
 
-	respGenerator.trimDNSResponse(configCtx, remoteAddress, req, resp, r.logger)
+	// The OPT record is set first so that its size is accounted for when
+	// trimming the response.
 	respGenerator.setEDNS(req, resp, isECSGlobal)
+	respGenerator.trimDNSResponse(configCtx, remoteAddress, req, resp, r.logger)
 	return resp
 }
 
@@ -521,6 +529,8 @@ func getDynamicRouterConfig(conf *config.RuntimeConfig) (*RouterDynamicConfig, e
 			Refresh: conf.DNSSOA.Refresh,
 			Retry:   conf.DNSSOA.Retry,
 		},
+		RecursorCacheSize:         conf.DNSRecursorCacheSize,
+		RecursorCacheStaleIfError: conf.DNSRecursorCacheStaleIfError,
 	}
 
 	if conf.DNSServiceTTL != nil {
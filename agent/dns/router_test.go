@@ -381,9 +381,12 @@ func TestDNS_BinaryTruncate(t *testing.T) {
 				msg.Ns = msgSrc.Ns
 				index := make(map[string]dns.RR, len(msg.Extra))
 				indexRRs(msg.Extra, index)
-				blen := dnsBinaryTruncate(msg, maxSize, index, true)
-				msg.Answer = msg.Answer[:blen]
-				syncExtra(index, msg)
+				answers := msg.Answer
+				set := func(n int) {
+					msg.Answer = answers[:n]
+					syncExtra(index, msg)
+				}
+				set(dnsBinaryTruncate(msg, maxSize, len(answers), set))
 				predicted := msg.Len()
 				buf, err := msg.Pack()
 				if err != nil {
@@ -661,6 +664,8 @@ func TestRouter_ReloadConfig(t *testing.T) {
 			"8.8.8.8",
 			"2001:4860:4860::8888",
 		},
+		DNSRecursorCacheSize:         567,
+		DNSRecursorCacheStaleIfError: 678,
 	}
 
 	expectTTLRadix := radix.New()
@@ -689,6 +694,8 @@ func TestRouter_ReloadConfig(t *testing.T) {
 			"8.8.8.8:53",
 			"[2001:4860:4860::8888]:53",
 		},
+		RecursorCacheSize:         567,
+		RecursorCacheStaleIfError: 678,
 	}
 	err = router.ReloadConfig(newAgentConfig)
 	require.NoError(t, err)
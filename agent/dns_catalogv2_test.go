@@ -114,17 +114,17 @@ func TestDNS_CatalogV2_Basic(t *testing.T) {
 
 	// Register workloads.
 	dbWorkloadId1 := &pbresource.ID{
-		Name:    "db-1",
+		Name:    "db-0001",
 		Type:    pbcatalog.WorkloadType,
 		Tenancy: resource.DefaultNamespacedTenancy(),
 	}
 	dbWorkloadId2 := &pbresource.ID{
-		Name:    "db-2",
+		Name:    "db-0002",
 		Type:    pbcatalog.WorkloadType,
 		Tenancy: resource.DefaultNamespacedTenancy(),
 	}
 	dbWorkloadId3 := &pbresource.ID{
-		Name:    "db-3",
+		Name:    "db-0003",
 		Type:    pbcatalog.WorkloadType,
 		Tenancy: resource.DefaultNamespacedTenancy(),
 	}
@@ -180,9 +180,9 @@ func TestDNS_CatalogV2_Basic(t *testing.T) {
 
 	// Validate workloads written.
 	dbWorkloads := make(map[string]*pbcatalog.Workload)
-	dbWorkloads["db-1"] = readResource(t, client, dbWorkloadId1, new(pbcatalog.Workload)).(*pbcatalog.Workload)
-	dbWorkloads["db-2"] = readResource(t, client, dbWorkloadId2, new(pbcatalog.Workload)).(*pbcatalog.Workload)
-	dbWorkloads["db-3"] = readResource(t, client, dbWorkloadId3, new(pbcatalog.Workload)).(*pbcatalog.Workload)
+	dbWorkloads["db-0001"] = readResource(t, client, dbWorkloadId1, new(pbcatalog.Workload)).(*pbcatalog.Workload)
+	dbWorkloads["db-0002"] = readResource(t, client, dbWorkloadId2, new(pbcatalog.Workload)).(*pbcatalog.Workload)
+	dbWorkloads["db-0003"] = readResource(t, client, dbWorkloadId3, new(pbcatalog.Workload)).(*pbcatalog.Workload)
 
 	// Ensure endpoints exist and have health status, which is required for inclusion in DNS results.
 	retry.Run(t, func(r *retry.R) {
@@ -261,10 +261,12 @@ func TestDNS_CatalogV2_Basic(t *testing.T) {
 				require.Equal(t, 9, len(in.Answer), "answer count did not match expected\n\n%s", in.String())
 				require.Equal(t, 9, len(in.Extra), "extra answer count did not match expected\n\n%s", in.String())
 			} else {
-				// Expect 1 result per port, per workload, up to the default limit of 3. In practice the results are truncated
-				// at 2 because of the record byte size.
-				require.Equal(t, 2, len(in.Answer), "answer count did not match expected\n\n%s", in.String())
+				// Expect 1 result per port, per workload, up to the default limit of 3. The additional records are
+				// truncated at 2 to fit in 512 bytes. The workload names are long enough for the third record never to
+				// fit, whichever answers are picked by the shuffle.
+				require.Equal(t, 3, len(in.Answer), "answer count did not match expected\n\n%s", in.String())
 				require.Equal(t, 2, len(in.Extra), "extra answer count did not match expected\n\n%s", in.String())
+				require.LessOrEqual(t, in.Len(), 512, "response is larger than the UDP payload size\n\n%s", in.String())
 			}
 		}
 
@@ -304,9 +306,9 @@ func TestDNS_CatalogV2_Basic(t *testing.T) {
 		}
 		for _, question := range questions {
 			for workloadName, dnsType := range map[string]uint16{
-				"db-1": dns.TypeA,
-				"db-2": dns.TypeA,
-				"db-3": dns.TypeAAAA,
+				"db-0001": dns.TypeA,
+				"db-0002": dns.TypeA,
+				"db-0003": dns.TypeAAAA,
 			} {
 				workload := dbWorkloads[workloadName]
 
@@ -357,9 +359,9 @@ func TestDNS_CatalogV2_Basic(t *testing.T) {
 
 		// Lookup workloads directly with a port.
 		for workloadName, dnsType := range map[string]uint16{
-			"db-1": dns.TypeA,
-			"db-2": dns.TypeA,
-			"db-3": dns.TypeAAAA,
+			"db-0001": dns.TypeA,
+			"db-0002": dns.TypeA,
+			"db-0003": dns.TypeAAAA,
 		} {
 			for _, question := range []string{
 				fmt.Sprintf("%s.workload.default.ns.default.ap.consul.", workloadName),
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/miekg/dns"
@@ -116,6 +117,56 @@ func TestDNS_NodeLookup(t *testing.T) {
 	}
 }
 
+func TestDNS_NodeMetaLookup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	// Node metadata lookups are not supported by the v1 DNS server.
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	for i, rack := range []string{"r1", "r1", "r2"} {
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       fmt.Sprintf("node-%d", i),
+			Address:    fmt.Sprintf("127.0.0.%d", i+10),
+			NodeMeta: map[string]string{
+				"rack": rack,
+			},
+		}
+
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion("rack.r1.node-meta.consul.", dns.TypeA)
+
+	c := new(dns.Client)
+	in, _, err := c.Exchange(m, a.DNSAddr())
+	require.NoError(t, err)
+	var addrs []string
+	for _, rr := range in.Answer {
+		aRec, ok := rr.(*dns.A)
+		require.True(t, ok, "Answer is not an A record")
+		addrs = append(addrs, aRec.A.String())
+	}
+	require.ElementsMatch(t, []string{"127.0.0.10", "127.0.0.11"}, addrs)
+
+	// Lookup a value no node has, we should receive a SOA
+	m = new(dns.Msg)
+	m.SetQuestion("rack.r3.node-meta.dc1.consul.", dns.TypeA)
+
+	in, _, err = c.Exchange(m, a.DNSAddr())
+	require.NoError(t, err)
+	require.Equal(t, dns.RcodeNameError, in.Rcode)
+	require.Len(t, in.Ns, 1)
+	_, ok := in.Ns[0].(*dns.SOA)
+	require.True(t, ok, "NS RR is not a SOA record")
+}
+
 func TestDNS_NodeLookup_CaseInsensitive(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
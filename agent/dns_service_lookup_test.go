@@ -3001,16 +3001,18 @@ func TestDNS_ServiceLookup_LargeResponses(t *testing.T) {
 					t.Fatalf("Bad: %d", in.Len())
 				}
 
-				// We should only have two answers now
-				if len(in.Answer) != 2 {
+				// We should have three answers now, as the additional records
+				// are dropped before the answers
+				if len(in.Answer) != 3 {
 					t.Fatalf("Bad: %d", len(in.Answer))
 				}
 
-				// Make sure the ADDITIONAL section matches the ANSWER section.
-				if len(in.Answer) != len(in.Extra) {
+				// Make sure the ADDITIONAL section matches the first answers
+				// of the ANSWER section.
+				if len(in.Extra) >= len(in.Answer) {
 					t.Fatalf("Bad: %d vs. %d", len(in.Answer), len(in.Extra))
 				}
-				for i := 0; i < len(in.Answer); i++ {
+				for i := 0; i < len(in.Extra); i++ {
 					srv, ok := in.Answer[i].(*dns.SRV)
 					if !ok {
 						t.Fatalf("Bad: %#v", in.Answer[i])
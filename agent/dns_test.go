@@ -1064,6 +1064,106 @@ func TestDNS_Lookup_TaggedIPAddresses(t *testing.T) {
 	}
 }
 
+func TestDNS_Lookup_DualStackAddresses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	// ipRecords returns the addresses of the A and AAAA records, in order.
+	ipRecords := func(rrs []dns.RR) []string {
+		var out []string
+		for _, rr := range rrs {
+			switch rec := rr.(type) {
+			case *dns.A:
+				out = append(out, rec.Hdr.Name+" A "+rec.A.String())
+			case *dns.AAAA:
+				out = append(out, rec.Hdr.Name+" AAAA "+rec.AAAA.String())
+			}
+		}
+		return out
+	}
+
+	for name, experimentsHCL := range getVersionHCL(true) {
+		for _, preference := range []string{structs.AddressFamilyIPv4, structs.AddressFamilyIPv6} {
+			t.Run(name+"/"+preference, func(t *testing.T) {
+				a := NewTestAgent(t, experimentsHCL+"\naddress_family_preference = \""+preference+"\"")
+				defer a.Shutdown()
+				testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+				for _, svc := range []*structs.NodeService{
+					{
+						Service: "db",
+						Address: "127.0.0.2",
+						Port:    8080,
+						TaggedAddresses: map[string]structs.ServiceAddress{
+							structs.TaggedAddressLANIPv4: {Address: "127.0.0.2", Port: 8080},
+							structs.TaggedAddressLANIPv6: {Address: "::2", Port: 8080},
+						},
+					},
+					{
+						Service: "web",
+						Port:    8081,
+					},
+				} {
+					args := &structs.RegisterRequest{
+						Datacenter: "dc1",
+						Node:       "foo",
+						Address:    "127.0.0.1",
+						TaggedAddresses: map[string]string{
+							structs.TaggedAddressLANIPv4: "127.0.0.1",
+							structs.TaggedAddressLANIPv6: "::1",
+						},
+						Service: svc,
+					}
+					var out struct{}
+					require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+				}
+
+				exchange := func(question string, qType uint16) *dns.Msg {
+					m := new(dns.Msg)
+					m.SetQuestion(question, qType)
+					in, _, err := new(dns.Client).Exchange(m, a.config.DNSAddrs[0].String())
+					require.NoError(t, err)
+					return in
+				}
+				ordered := func(name, v4, v6 string) []string {
+					if preference == structs.AddressFamilyIPv4 {
+						return []string{name + " A " + v4, name + " AAAA " + v6}
+					}
+					return []string{name + " AAAA " + v6, name + " A " + v4}
+				}
+
+				// Both addresses of the service are returned for ANY queries.
+				in := exchange("db.service.consul.", dns.TypeANY)
+				require.Equal(t, ordered("db.service.consul.", "127.0.0.2", "::2"), ipRecords(in.Answer))
+
+				// A and AAAA queries only return their family.
+				in = exchange("db.service.consul.", dns.TypeA)
+				require.Equal(t, []string{"db.service.consul. A 127.0.0.2"}, ipRecords(in.Answer))
+				in = exchange("db.service.consul.", dns.TypeAAAA)
+				require.Equal(t, []string{"db.service.consul. AAAA ::2"}, ipRecords(in.Answer))
+
+				// The SRV target of a service without an address is the node,
+				// which gets both addresses.
+				in = exchange("web.service.consul.", dns.TypeSRV)
+				require.Len(t, in.Answer, 1)
+				srv, ok := in.Answer[0].(*dns.SRV)
+				require.True(t, ok, "Bad: %#v", in.Answer[0])
+				require.Equal(t, "foo.node.dc1.consul.", srv.Target)
+				require.Equal(t, ordered("foo.node.dc1.consul.", "127.0.0.1", "::1"), ipRecords(in.Extra))
+
+				// The SRV target of the service encodes its preferred address.
+				in = exchange("db.service.consul.", dns.TypeSRV)
+				require.Len(t, in.Answer, 1)
+				require.Len(t, ipRecords(in.Extra), 1)
+
+				in = exchange("foo.node.consul.", dns.TypeANY)
+				require.Equal(t, ordered("foo.node.consul.", "127.0.0.1", "::1"), ipRecords(in.Answer))
+			})
+		}
+	}
+}
+
 func TestDNS_PreparedQueryNearIPEDNS(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1480,9 +1580,12 @@ func TestDNS_BinarySearch(t *testing.T) {
 				msg.Ns = msgSrc.Ns
 				index := make(map[string]dns.RR, len(msg.Extra))
 				indexRRs(msg.Extra, index)
-				blen := dnsBinaryTruncate(msg, maxSize, index, true)
-				msg.Answer = msg.Answer[:blen]
-				syncExtra(index, msg)
+				answers := msg.Answer
+				set := func(n int) {
+					msg.Answer = answers[:n]
+					syncExtra(index, msg)
+				}
+				set(dnsBinaryTruncate(msg, maxSize, len(answers), set))
 				predicted := msg.Len()
 				buf, err := msg.Pack()
 				if err != nil {
@@ -2532,7 +2635,7 @@ func TestDNS_trimUDPResponse_NoTrim(t *testing.T) {
 			}
 
 			cfg := loadRuntimeConfig(t, `node_name = "test" data_dir = "a" bind_addr = "127.0.0.1" node_name = "dummy" `+experimentsHCL)
-			if trimmed := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); trimmed {
+			if trimmed, _ := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); trimmed {
 				t.Fatalf("Bad %#v", *resp)
 			}
 
@@ -2598,7 +2701,7 @@ func TestDNS_trimUDPResponse_TrimLimit(t *testing.T) {
 				}
 			}
 
-			if trimmed := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
+			if trimmed, _ := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
 				t.Fatalf("Bad %#v", *resp)
 			}
 			if !reflect.DeepEqual(resp, expected) {
@@ -2650,7 +2753,7 @@ func TestDNS_trimUDPResponse_TrimLimitWithNS(t *testing.T) {
 				}
 			}
 
-			if trimmed := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
+			if trimmed, _ := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
 				t.Fatalf("Bad %#v", *resp)
 			}
 			require.LessOrEqual(t, resp.Len(), defaultMaxUDPSize)
@@ -2702,7 +2805,7 @@ func TestDNS_trimTCPResponse_TrimLimitWithNS(t *testing.T) {
 			}
 			req.Question = append(req.Question, dns.Question{Qtype: dns.TypeSRV})
 
-			if trimmed := trimTCPResponse(req, resp); !trimmed {
+			if trimmed, _ := trimTCPResponse(req, resp); !trimmed {
 				t.Fatalf("Bad %#v", *resp)
 			}
 			require.LessOrEqual(t, resp.Len(), 65523)
@@ -2750,7 +2853,7 @@ func TestDNS_trimUDPResponse_TrimSize(t *testing.T) {
 
 			// We don't know the exact trim, but we know the resulting answer
 			// data should match its extra data.
-			if trimmed := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
+			if trimmed, _ := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
 				t.Fatalf("Bad %#v", *resp)
 			}
 			if len(resp.Answer) == 0 || len(resp.Answer) != len(resp.Extra) {
@@ -2813,20 +2916,20 @@ func TestDNS_trimUDPResponse_TrimSizeEDNS(t *testing.T) {
 			respEDNS.Extra = append(respEDNS.Extra, resp.Extra...)
 
 			// Trim each response
-			if trimmed := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
+			if trimmed, _ := trimUDPResponse(req, resp, cfg.DNSUDPAnswerLimit); !trimmed {
 				t.Errorf("expected response to be trimmed: %#v", resp)
 			}
-			if trimmed := trimUDPResponse(reqEDNS, respEDNS, cfg.DNSUDPAnswerLimit); !trimmed {
-				t.Errorf("expected edns to be trimmed: %#v", resp)
+			trimmed, cause := trimUDPResponse(reqEDNS, respEDNS, cfg.DNSUDPAnswerLimit)
+			if !trimmed {
+				t.Errorf("expected edns to be trimmed: %#v", respEDNS)
 			}
+			require.Equal(t, truncateCauseAnswer, cause)
+			require.LessOrEqual(t, respEDNS.Len(), 2048)
 
 			// Check answer lengths
 			if len(resp.Answer) == 0 || len(resp.Answer) != len(resp.Extra) {
 				t.Errorf("bad response answer length: %#v", resp)
 			}
-			if len(respEDNS.Answer) == 0 || len(respEDNS.Answer) != len(respEDNS.Extra) {
-				t.Errorf("bad edns answer length: %#v", resp)
-			}
 
 			// Due to the compression, we can't check exact equality of sizes, but we can
 			// make two requests and ensure that the edns one returns a larger payload
@@ -2834,24 +2937,25 @@ func TestDNS_trimUDPResponse_TrimSizeEDNS(t *testing.T) {
 			if len(resp.Answer) >= len(respEDNS.Answer) {
 				t.Errorf("expected edns have larger answer: %#v\n%#v", resp, respEDNS)
 			}
-			if len(resp.Extra) >= len(respEDNS.Extra) {
-				t.Errorf("expected edns have larger extra: %#v\n%#v", resp, respEDNS)
-			}
 
-			// Verify that the things point where they should
-			for i := range resp.Answer {
-				srv, ok := resp.Answer[i].(*dns.SRV)
-				if !ok {
-					t.Errorf("%d should be an SRV", i)
-				}
+			// Additional records are dropped before answers, and only kept
+			// in the order of the answers they cover.
+			require.LessOrEqual(t, len(respEDNS.Extra), len(respEDNS.Answer))
+			for _, msg := range []*dns.Msg{resp, respEDNS} {
+				for i := range msg.Extra {
+					srv, ok := msg.Answer[i].(*dns.SRV)
+					if !ok {
+						t.Errorf("%d should be an SRV", i)
+					}
 
-				a, ok := resp.Extra[i].(*dns.A)
-				if !ok {
-					t.Errorf("%d should be an A", i)
-				}
+					a, ok := msg.Extra[i].(*dns.A)
+					if !ok {
+						t.Errorf("%d should be an A", i)
+					}
 
-				if srv.Target != a.Header().Name {
-					t.Errorf("%d: bad %#v vs. %#v", i, srv, a)
+					if srv.Target != a.Header().Name {
+						t.Errorf("%d: bad %#v vs. %#v", i, srv, a)
+					}
 				}
 			}
 		})
@@ -2896,20 +3000,84 @@ func TestDNS_trimUDPResponse_TrimSizeMaxSize(t *testing.T) {
 			require.Greater(t, respEDNS.Len(), math.MaxUint16)
 			t.Logf("length is: %v", respEDNS.Len())
 
-			if trimmed := trimUDPResponse(reqEDNS, respEDNS, cfg.DNSUDPAnswerLimit); !trimmed {
-				t.Errorf("expected edns to be trimmed: %#v", resp)
-			}
+			// All the answers fit once some of the additional records are
+			// dropped.
+			trimmed, cause := trimUDPResponse(reqEDNS, respEDNS, cfg.DNSUDPAnswerLimit)
+			require.False(t, trimmed)
+			require.Equal(t, truncateCauseAdditional, cause)
 			require.Greater(t, math.MaxUint16, respEDNS.Len())
 
 			t.Logf("length is: %v", respEDNS.Len())
 
-			if len(respEDNS.Answer) == 0 || len(respEDNS.Answer) != len(respEDNS.Extra) {
-				t.Errorf("bad edns answer length: %#v", resp)
+			require.Len(t, respEDNS.Answer, 600)
+			require.NotEmpty(t, respEDNS.Extra)
+			require.Less(t, len(respEDNS.Extra), len(respEDNS.Answer))
+			for i := range respEDNS.Extra {
+				require.Equal(t, respEDNS.Answer[i].(*dns.SRV).Target, respEDNS.Extra[i].Header().Name)
 			}
 		})
 	}
 }
 
+func TestDNS_trimUDPResponse_EDNSSize(t *testing.T) {
+	req, resp := &dns.Msg{}, &dns.Msg{}
+	req.SetEdns0(1232, false)
+	for i := 0; i < 100; i++ {
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{
+				Name:   "web.service.consul.",
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+			},
+			A: net.ParseIP(fmt.Sprintf("10.0.1.%d", i)),
+		})
+	}
+	setEDNS(req, resp, true)
+	answers := resp.Answer
+
+	trimmed, cause := trimUDPResponse(req, resp, 3)
+	require.True(t, trimmed)
+	require.Equal(t, truncateCauseAnswer, cause)
+
+	// The response uses the whole payload size advertised by the client and
+	// keeps its OPT record.
+	require.LessOrEqual(t, resp.Len(), 1232)
+	require.Len(t, resp.Extra, 1)
+	require.NotNil(t, resp.IsEdns0())
+	resp.Answer = answers[:len(resp.Answer)+1]
+	require.Greater(t, resp.Len(), 1232)
+}
+
+func TestDNS_trimUDPResponse_TrimAuthority(t *testing.T) {
+	req, resp := &dns.Msg{}, &dns.Msg{}
+	resp.Answer = []dns.RR{
+		&dns.A{
+			Hdr: dns.RR_Header{
+				Name:   "web.service.consul.",
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+			},
+			A: net.ParseIP("10.0.1.1"),
+		},
+	}
+	for i := 0; i < 20; i++ {
+		resp.Ns = append(resp.Ns, &dns.NS{
+			Hdr: dns.RR_Header{
+				Name:   "consul.",
+				Rrtype: dns.TypeNS,
+				Class:  dns.ClassINET,
+			},
+			Ns: fmt.Sprintf("server-%d.node.dc1.consul.", i),
+		})
+	}
+
+	trimmed, cause := trimUDPResponse(req, resp, 3)
+	require.False(t, trimmed)
+	require.Equal(t, truncateCauseAuthority, cause)
+	require.Len(t, resp.Answer, 1)
+	require.Empty(t, resp.Ns)
+}
+
 func TestDNS_syncExtra(t *testing.T) {
 	resp := &dns.Msg{
 		Answer: []dns.RR{
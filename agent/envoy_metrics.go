@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net"
+	"net/url"
+
+	"github.com/hashicorp/consul/agent/envoymetrics"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/logging"
+)
+
+const (
+	// envoyPrometheusBindAddrConfig is the key of the proxy config setting
+	// the address of the Prometheus endpoint of Envoy.
+	envoyPrometheusBindAddrConfig = "envoy_prometheus_bind_addr"
+
+	// envoyPrometheusScrapePath is the path of the Prometheus endpoint of
+	// Envoy, as set by default by the consul connect envoy command.
+	envoyPrometheusScrapePath = "/metrics"
+)
+
+// startEnvoyMetrics starts scraping the Envoy metrics of the proxies
+// registered with the agent, if the telemetry configuration allowlists some.
+func (a *Agent) startEnvoyMetrics() {
+	telemetry := a.config.Telemetry
+	if len(telemetry.EnvoyMetricsAllowlist) == 0 {
+		return
+	}
+	a.envoyMetrics = envoymetrics.NewAggregator(envoymetrics.Config{
+		Allowlist:      telemetry.EnvoyMetricsAllowlist,
+		ScrapeInterval: telemetry.EnvoyMetricsScrapeInterval,
+		Targets:        a.envoyMetricsTargets,
+		Logger:         a.logger.Named(logging.Proxy),
+	})
+	go a.envoyMetrics.Run(&lib.StopChannelContext{StopCh: a.shutdownCh})
+}
+
+// envoyMetricsTargets returns the proxies registered with the agent which
+// expose the Prometheus endpoint of Envoy.
+func (a *Agent) envoyMetricsTargets() []envoymetrics.Target {
+	var targets []envoymetrics.Target
+	for _, svc := range a.State.AllServices() {
+		if svc.Kind == structs.ServiceKindTypical || svc.Proxy.Config == nil {
+			continue
+		}
+		bindAddr, ok := svc.Proxy.Config[envoyPrometheusBindAddrConfig].(string)
+		if !ok || bindAddr == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(bindAddr)
+		if err != nil {
+			continue
+		}
+		// The proxies run next to the agent, so the endpoints listening on
+		// all the interfaces are scraped through the loopback one.
+		if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+			host = "127.0.0.1"
+		}
+
+		service := svc.Service
+		if svc.Kind == structs.ServiceKindConnectProxy && svc.Proxy.DestinationServiceName != "" {
+			service = svc.Proxy.DestinationServiceName
+		}
+		u := url.URL{Scheme: "http", Host: net.JoinHostPort(host, port), Path: envoyPrometheusScrapePath}
+		targets = append(targets, envoymetrics.Target{
+			ProxyID:   svc.ID,
+			Service:   service,
+			Namespace: svc.EnterpriseMeta.NamespaceOrEmpty(),
+			Partition: svc.EnterpriseMeta.PartitionOrEmpty(),
+			URL:       u.String(),
+		})
+	}
+	return targets
+}
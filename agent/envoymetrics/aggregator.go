@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package envoymetrics scrapes the Envoy stats of the sidecar proxies
+// registered with the agent, so an allowlisted subset of them can be exposed
+// on the Prometheus endpoint of the agent instead of scraping each sidecar.
+package envoymetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// Labels added to the scraped metrics to identify the proxy they come from.
+const (
+	ServiceLabel   = "consul_service"
+	ProxyIDLabel   = "consul_proxy_id"
+	NamespaceLabel = "consul_namespace"
+	PartitionLabel = "consul_partition"
+)
+
+// DefaultScrapeInterval is how often the proxies are scraped when the
+// configuration does not set it.
+const DefaultScrapeInterval = 30 * time.Second
+
+// Target is a proxy whose Envoy stats are scraped.
+type Target struct {
+	// ProxyID is the ID of the proxy service.
+	ProxyID string
+
+	// Service is the name of the service the proxy is for.
+	Service string
+
+	Namespace string
+	Partition string
+
+	// URL is the address of the Prometheus endpoint of the proxy.
+	URL string
+}
+
+// Config configures an Aggregator.
+type Config struct {
+	// Allowlist holds the prefixes of the names of the metrics exposed.
+	Allowlist []string
+
+	// ScrapeInterval is how often the proxies are scraped.
+	ScrapeInterval time.Duration
+
+	// Targets returns the proxies to scrape.
+	Targets func() []Target
+
+	Logger hclog.Logger
+}
+
+// Aggregator periodically scrapes the Envoy stats of the proxies, and
+// implements prometheus.Gatherer to expose the allowlisted metrics labeled
+// with the service and the ID of their proxy.
+type Aggregator struct {
+	config Config
+	client *http.Client
+
+	mu sync.Mutex
+	// families holds the allowlisted metric families scraped from each
+	// proxy, by proxy ID.
+	families map[string][]*dto.MetricFamily
+}
+
+func NewAggregator(config Config) *Aggregator {
+	if config.ScrapeInterval <= 0 {
+		config.ScrapeInterval = DefaultScrapeInterval
+	}
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = config.ScrapeInterval
+	return &Aggregator{
+		config:   config,
+		client:   client,
+		families: make(map[string][]*dto.MetricFamily),
+	}
+}
+
+// Run scrapes the proxies until ctx is cancelled.
+func (a *Aggregator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.config.ScrapeInterval)
+	defer ticker.Stop()
+
+	for {
+		a.scrape(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrape scrapes all the proxies once. The metrics of the proxies which
+// failed to be scraped or are gone are dropped, rather than exposed stale.
+func (a *Aggregator) scrape(ctx context.Context) {
+	families := make(map[string][]*dto.MetricFamily)
+	for _, target := range a.config.Targets() {
+		scraped, err := a.scrapeTarget(ctx, target)
+		if err != nil {
+			a.config.Logger.Debug("failed to scrape proxy metrics", "proxy", target.ProxyID, "error", err)
+			continue
+		}
+		families[target.ProxyID] = scraped
+	}
+
+	a.mu.Lock()
+	a.families = families
+	a.mu.Unlock()
+}
+
+func (a *Aggregator) scrapeTarget(ctx context.Context, target Target) ([]*dto.MetricFamily, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code: %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	parsed, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []*dto.LabelPair{
+		{Name: proto.String(ServiceLabel), Value: proto.String(target.Service)},
+		{Name: proto.String(ProxyIDLabel), Value: proto.String(target.ProxyID)},
+	}
+	if target.Namespace != "" {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(NamespaceLabel), Value: proto.String(target.Namespace)})
+	}
+	if target.Partition != "" {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(PartitionLabel), Value: proto.String(target.Partition)})
+	}
+
+	var families []*dto.MetricFamily
+	for name, family := range parsed {
+		if !a.allowed(name) {
+			continue
+		}
+		for _, m := range family.Metric {
+			m.Label = append(m.Label, labels...)
+			sort.Slice(m.Label, func(i, j int) bool {
+				return m.Label[i].GetName() < m.Label[j].GetName()
+			})
+		}
+		families = append(families, family)
+	}
+	return families, nil
+}
+
+func (a *Aggregator) allowed(name string) bool {
+	for _, prefix := range a.config.Allowlist {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Gather returns the metric families scraped from all the proxies, merging
+// the metrics of the families of the same name.
+func (a *Aggregator) Gather() ([]*dto.MetricFamily, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	byName := make(map[string]*dto.MetricFamily)
+	for _, families := range a.families {
+		for _, family := range families {
+			merged, ok := byName[family.GetName()]
+			if !ok {
+				merged = &dto.MetricFamily{
+					Name: family.Name,
+					Help: family.Help,
+					Type: family.Type,
+				}
+				byName[family.GetName()] = merged
+			}
+			if merged.GetType() != family.GetType() {
+				continue
+			}
+			merged.Metric = append(merged.Metric, family.Metric...)
+		}
+	}
+
+	result := make([]*dto.MetricFamily, 0, len(byName))
+	for _, family := range byName {
+		result = append(result, family)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+	return result, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package envoymetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func envoyStatsServer(t *testing.T, requests int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `# TYPE envoy_cluster_upstream_rq_total counter
+envoy_cluster_upstream_rq_total{envoy_cluster_name="db"} %d
+# TYPE envoy_server_live gauge
+envoy_server_live 1
+`, requests)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAggregator(t *testing.T) {
+	web := envoyStatsServer(t, 3)
+	api := envoyStatsServer(t, 5)
+
+	targets := []Target{
+		{ProxyID: "web-sidecar-proxy", Service: "web", URL: web.URL + "/metrics"},
+		{ProxyID: "api-sidecar-proxy", Service: "api", Namespace: "ns1", URL: api.URL + "/metrics"},
+		{ProxyID: "gone", Service: "gone", URL: "http://127.0.0.1:0/metrics"},
+	}
+	a := NewAggregator(Config{
+		Allowlist: []string{"envoy_cluster_upstream_rq"},
+		Targets:   func() []Target { return targets },
+		Logger:    hclog.NewNullLogger(),
+	})
+
+	families, err := a.Gather()
+	require.NoError(t, err)
+	require.Empty(t, families)
+
+	a.scrape(context.Background())
+	families, err = a.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, "envoy_cluster_upstream_rq_total", families[0].GetName())
+	require.Len(t, families[0].Metric, 2)
+
+	values := make(map[string]float64)
+	for _, m := range families[0].Metric {
+		labels := make(map[string]string)
+		for _, l := range m.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		require.Equal(t, "db", labels["envoy_cluster_name"])
+		require.Equal(t, labels[ServiceLabel]+"-sidecar-proxy", labels[ProxyIDLabel])
+		if labels[ServiceLabel] == "api" {
+			require.Equal(t, "ns1", labels[NamespaceLabel])
+		} else {
+			require.NotContains(t, labels, NamespaceLabel)
+		}
+		values[labels[ServiceLabel]] = m.GetCounter().GetValue()
+	}
+	require.Equal(t, map[string]float64{"web": 3, "api": 5}, values)
+
+	// The metrics of the proxies which are gone are dropped.
+	targets = targets[:1]
+	a.scrape(context.Background())
+	families, err = a.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Len(t, families[0].Metric, 1)
+}
@@ -0,0 +1,516 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/config"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/internal/resourcehcl"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+const (
+	// gitOpsMaxPayloadSize limits the size of the webhook payloads.
+	gitOpsMaxPayloadSize = 5 * 1024 * 1024
+
+	// gitOpsSyncTimeout bounds the time taken to fetch and apply a commit.
+	gitOpsSyncTimeout = 5 * time.Minute
+
+	// gitOpsStateKeyPrefix is the prefix of the KV keys holding the state of
+	// the receiver for each repository and branch.
+	gitOpsStateKeyPrefix = "_gitops/"
+
+	// gitOpsMaxDeliveryIDs is the number of applied deliveries remembered to
+	// reject replays.
+	gitOpsMaxDeliveryIDs = 100
+)
+
+const (
+	GitOpsStatusApplied  = "applied"
+	GitOpsStatusFailed   = "failed"
+	GitOpsStatusIgnored  = "ignored"
+	GitOpsStatusRejected = "rejected"
+)
+
+// GitOpsSync is the outcome of handling a webhook delivery.
+type GitOpsSync struct {
+	Provider   string
+	Repository string
+	Ref        string `json:",omitempty"`
+	Commit     string `json:",omitempty"`
+	DeliveryID string `json:",omitempty"`
+	Status     string
+	Error      string   `json:",omitempty"`
+	Applied    []string `json:",omitempty"`
+	RolledBack bool     `json:",omitempty"`
+	Time       time.Time
+}
+
+// gitOpsReceiver applies the config entries and resources stored in a Git
+// repository when notified of a push by a webhook.
+type gitOpsReceiver struct {
+	agent  *Agent
+	logger hclog.Logger
+	config config.GitOpsConfig
+	source gitOpsSource
+
+	// syncLock serializes the syncs so that concurrent deliveries are
+	// applied one at a time.
+	syncLock sync.Mutex
+
+	statusLock sync.RWMutex
+	lastSync   *GitOpsSync
+}
+
+// gitOpsState is the state of the receiver stored in the KV store. It is
+// written in the same transaction as the config entries of the commit, so
+// that it always matches what was applied.
+type gitOpsState struct {
+	// Commit is the last applied commit.
+	Commit string
+
+	// DeliveryIDs are the IDs of the last applied deliveries, oldest first.
+	DeliveryIDs []string
+}
+
+func newGitOpsReceiver(a *Agent) (*gitOpsReceiver, error) {
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = time.Minute
+	source, err := newGitOpsSource(a.config.GitOps, client)
+	if err != nil {
+		return nil, err
+	}
+	return &gitOpsReceiver{
+		agent:  a,
+		logger: a.logger.Named("gitops"),
+		config: a.config.GitOps,
+		source: source,
+	}, nil
+}
+
+// gitOpsChange is a config entry or resource to apply. Resources can't be
+// written in a transaction, so the state they replace is recorded to roll them
+// back.
+type gitOpsChange struct {
+	file     string
+	entry    structs.ConfigEntry
+	resource *pbresource.Resource
+
+	applied       bool
+	priorResource *pbresource.Resource
+}
+
+func (c *gitOpsChange) String() string {
+	if c.entry != nil {
+		return fmt.Sprintf("%s/%s (%s)", c.entry.GetKind(), c.entry.GetName(), c.file)
+	}
+	return fmt.Sprintf("%s.%s.%s/%s (%s)", c.resource.Id.Type.Group, c.resource.Id.Type.GroupVersion,
+		c.resource.Id.Type.Kind, c.resource.Id.Name, c.file)
+}
+
+// order ranks the change so that the config entries other entries depend
+// on, such as the protocol set by service-defaults which routers require,
+// come first in the transaction. Resources are ranked last.
+func (c *gitOpsChange) order() int {
+	if c.entry == nil {
+		return 4
+	}
+	switch c.entry.GetKind() {
+	case structs.ProxyDefaults, structs.MeshConfig, structs.ServiceDefaults:
+		return 0
+	case structs.ServiceResolver:
+		return 1
+	case structs.ServiceSplitter, structs.ServiceRouter:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// handle verifies and processes a webhook delivery.
+func (r *gitOpsReceiver) handle(req *http.Request, body []byte) (*GitOpsSync, error) {
+	if err := r.source.verify(req, body); err != nil {
+		r.logger.Warn("rejected webhook delivery", "error", err)
+		return nil, HTTPError{StatusCode: http.StatusUnauthorized, Reason: err.Error()}
+	}
+
+	push, err := r.source.parsePush(req, body)
+	if err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: err.Error()}
+	}
+
+	result := &GitOpsSync{
+		Provider:   r.config.Provider,
+		Repository: r.config.Repository,
+		Status:     GitOpsStatusIgnored,
+		Time:       time.Now().UTC(),
+	}
+	if push == nil {
+		return result, nil
+	}
+	result.Ref = push.Ref
+	result.Commit = push.Commit
+	result.DeliveryID = push.DeliveryID
+	if !strings.EqualFold(push.Repository, r.config.Repository) || push.Ref != "refs/heads/"+r.config.Branch {
+		return result, nil
+	}
+
+	r.syncLock.Lock()
+	defer r.syncLock.Unlock()
+
+	// The sync is not tied to the request so that a provider giving up on
+	// the delivery does not interrupt it halfway through.
+	ctx, cancel := context.WithTimeout(context.Background(), gitOpsSyncTimeout)
+	defer cancel()
+
+	state, stateIndex, err := r.loadState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the GitOps state: %w", err)
+	}
+	if err := r.checkDelivery(ctx, push, state); err != nil {
+		r.logger.Warn("rejected webhook delivery", "commit", push.Commit, "delivery_id", push.DeliveryID, "error", err)
+		result.Status = GitOpsStatusRejected
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	applied, rolledBack, err := r.sync(ctx, push, state, stateIndex)
+	result.Status = GitOpsStatusApplied
+	result.Applied = applied
+	result.RolledBack = rolledBack
+	if err != nil {
+		result.Status = GitOpsStatusFailed
+		result.Error = err.Error()
+		r.logger.Error("failed to apply commit", "commit", push.Commit, "error", err)
+	} else {
+		r.logger.Info("applied commit", "commit", push.Commit, "changes", len(applied))
+	}
+	result.Time = time.Now().UTC()
+
+	r.statusLock.Lock()
+	r.lastSync = result
+	r.statusLock.Unlock()
+
+	return result, nil
+}
+
+// status returns the outcome of the last sync, or nil if none happened yet.
+func (r *gitOpsReceiver) status() *GitOpsSync {
+	r.statusLock.RLock()
+	defer r.statusLock.RUnlock()
+	return r.lastSync
+}
+
+func (r *gitOpsReceiver) token() string {
+	if r.config.ACLToken != "" {
+		return r.config.ACLToken
+	}
+	return r.agent.tokens.UserToken()
+}
+
+// stateKey returns the KV key of the state of the receiver.
+func (r *gitOpsReceiver) stateKey() string {
+	return gitOpsStateKeyPrefix + r.config.Provider + "/" + r.config.Repository + "/" + r.config.Branch
+}
+
+// datacenter returns the datacenter the changes are applied to. Config
+// entries can only be written in a transaction in the primary datacenter.
+func (r *gitOpsReceiver) datacenter() string {
+	if r.agent.config.PrimaryDatacenter != "" {
+		return r.agent.config.PrimaryDatacenter
+	}
+	return r.agent.config.Datacenter
+}
+
+// loadState returns the state of the receiver along with the index to
+// update it at.
+func (r *gitOpsReceiver) loadState(ctx context.Context) (*gitOpsState, uint64, error) {
+	args := structs.KeyRequest{
+		Datacenter:   r.datacenter(),
+		Key:          r.stateKey(),
+		QueryOptions: structs.QueryOptions{Token: r.token(), RequireConsistent: true},
+	}
+	var reply structs.IndexedDirEntries
+	if err := r.agent.RPC(ctx, "KVS.Get", &args, &reply); err != nil {
+		return nil, 0, err
+	}
+	if len(reply.Entries) == 0 {
+		return &gitOpsState{}, 0, nil
+	}
+
+	var state gitOpsState
+	if err := json.Unmarshal(reply.Entries[0].Value, &state); err != nil {
+		return nil, 0, err
+	}
+	return &state, reply.Entries[0].ModifyIndex, nil
+}
+
+// checkDelivery rejects the deliveries that were already applied, and the
+// pushes of commits older than the last applied one, which providers may
+// deliver out of order.
+func (r *gitOpsReceiver) checkDelivery(ctx context.Context, push *gitOpsPush, state *gitOpsState) error {
+	for _, id := range state.DeliveryIDs {
+		if push.DeliveryID != "" && id == push.DeliveryID {
+			return fmt.Errorf("delivery %s was already applied", push.DeliveryID)
+		}
+	}
+	if state.Commit == "" {
+		return nil
+	}
+	if push.Commit == state.Commit {
+		return fmt.Errorf("commit %s was already applied", push.Commit)
+	}
+	stale, err := r.source.isAncestor(ctx, push.Commit, state.Commit)
+	if err != nil {
+		return fmt.Errorf("failed to compare commit %s with the last applied commit %s: %w", push.Commit, state.Commit, err)
+	}
+	if stale {
+		return fmt.Errorf("commit %s is older than the last applied commit %s", push.Commit, state.Commit)
+	}
+	return nil
+}
+
+// sync fetches the files of the pushed commit and applies them. Every file is
+// decoded and validated before anything is applied. The config entries are
+// applied in a single transaction along with the new state, and the resources
+// written beforehand are rolled back if it fails.
+func (r *gitOpsReceiver) sync(ctx context.Context, push *gitOpsPush, state *gitOpsState, stateIndex uint64) ([]string, bool, error) {
+	files, err := r.source.fetch(ctx, push.Commit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changes := make([]*gitOpsChange, 0, len(files))
+	for _, file := range files {
+		change, err := r.decode(file)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", file.Path, err)
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].order() < changes[j].order()
+	})
+
+	fail := func(err error) ([]string, bool, error) {
+		if rbErr := r.rollback(ctx, changes); rbErr != nil {
+			return nil, false, errors.Join(err, rbErr)
+		}
+		return nil, true, err
+	}
+
+	var ops structs.TxnOps
+	var entries []*gitOpsChange
+	for _, change := range changes {
+		if change.entry != nil {
+			ops = append(ops, &structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{Verb: api.ConfigEntrySet, Entry: change.entry},
+			})
+			entries = append(entries, change)
+			continue
+		}
+		if err := r.apply(ctx, change); err != nil {
+			return fail(fmt.Errorf("failed to apply %s: %w", change, err))
+		}
+	}
+
+	next := gitOpsState{Commit: push.Commit, DeliveryIDs: state.DeliveryIDs}
+	if push.DeliveryID != "" {
+		next.DeliveryIDs = append(next.DeliveryIDs, push.DeliveryID)
+		if n := len(next.DeliveryIDs); n > gitOpsMaxDeliveryIDs {
+			next.DeliveryIDs = next.DeliveryIDs[n-gitOpsMaxDeliveryIDs:]
+		}
+	}
+	value, err := json.Marshal(next)
+	if err != nil {
+		return fail(err)
+	}
+	// The state is updated with a check-and-set, so that a concurrent sync by
+	// another agent makes the transaction fail rather than applying a stale
+	// commit over a newer one.
+	ops = append(ops, &structs.TxnOp{
+		KV: &structs.TxnKVOp{
+			Verb:   api.KVCAS,
+			DirEnt: structs.DirEntry{Key: r.stateKey(), Value: value, RaftIndex: structs.RaftIndex{ModifyIndex: stateIndex}},
+		},
+	})
+
+	args := structs.TxnRequest{
+		Datacenter:   r.datacenter(),
+		Ops:          ops,
+		WriteRequest: structs.WriteRequest{Token: r.token()},
+	}
+	var reply structs.TxnResponse
+	if err := r.agent.RPC(ctx, "Txn.Apply", &args, &reply); err != nil {
+		return fail(fmt.Errorf("failed to apply config entries: %w", err))
+	}
+	if len(reply.Errors) > 0 {
+		var errs []error
+		for _, txnErr := range reply.Errors {
+			if txnErr.OpIndex < len(entries) {
+				errs = append(errs, fmt.Errorf("failed to apply %s: %s", entries[txnErr.OpIndex], txnErr.What))
+			} else {
+				errs = append(errs, fmt.Errorf("the GitOps state was updated concurrently: %s", txnErr.What))
+			}
+		}
+		return fail(errors.Join(errs...))
+	}
+
+	applied := make([]string, 0, len(changes))
+	for _, change := range changes {
+		applied = append(applied, change.String())
+	}
+	return applied, false, nil
+}
+
+// decode parses a file as a config entry, or as a resource when the file is
+// written in HCL and has no Kind.
+func (r *gitOpsReceiver) decode(file gitOpsFile) (*gitOpsChange, error) {
+	var raw map[string]interface{}
+	if err := hcl.Decode(&raw, string(file.Data)); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	_, hasKind := raw["Kind"]
+	if _, ok := raw["kind"]; ok {
+		hasKind = true
+	}
+	if hasKind || !strings.HasSuffix(file.Path, ".hcl") {
+		entry, err := structs.DecodeConfigEntry(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := entry.Normalize(); err != nil {
+			return nil, err
+		}
+		if err := entry.Validate(); err != nil {
+			return nil, err
+		}
+		return &gitOpsChange{file: file.Path, entry: entry}, nil
+	}
+
+	if !r.agent.baseDeps.UseV2Resources() {
+		return nil, fmt.Errorf("resources can only be applied when the %q experiment is enabled", "resource-apis")
+	}
+	res, err := resourcehcl.Unmarshal(file.Data, r.agent.baseDeps.Registry)
+	if err != nil {
+		return nil, err
+	}
+	return &gitOpsChange{file: file.Path, resource: res}, nil
+}
+
+func (r *gitOpsReceiver) resourceContext(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-consul-token", r.token())
+}
+
+// apply records the current state of the resource and then writes the new
+// one.
+func (r *gitOpsReceiver) apply(ctx context.Context, change *gitOpsChange) error {
+	client := r.agent.delegate.ResourceServiceClient()
+	ctx = r.resourceContext(ctx)
+	read, err := client.Read(ctx, &pbresource.ReadRequest{Id: change.resource.Id})
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return fmt.Errorf("failed to read current resource: %w", err)
+	default:
+		change.priorResource = read.Resource
+	}
+
+	written, err := client.Write(ctx, &pbresource.WriteRequest{Resource: change.resource})
+	if err != nil {
+		return err
+	}
+	change.resource = written.Resource
+	change.applied = true
+	return nil
+}
+
+// rollback restores the resources written before the changes failed to
+// apply, in reverse order.
+func (r *gitOpsReceiver) rollback(ctx context.Context, changes []*gitOpsChange) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if !change.applied {
+			continue
+		}
+
+		var err error
+		switch {
+		case change.priorResource != nil:
+			prior := change.priorResource
+			prior.Version = ""
+			_, err = r.agent.delegate.ResourceServiceClient().Write(r.resourceContext(ctx), &pbresource.WriteRequest{Resource: prior})
+		default:
+			_, err = r.agent.delegate.ResourceServiceClient().Delete(r.resourceContext(ctx), &pbresource.DeleteRequest{Id: change.resource.Id})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back %s: %w", change, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GitOpsWebhook receives the push notifications of the Git hosting provider.
+// Requests are authenticated with the webhook secret rather than an ACL
+// token.
+//
+// POST /v1/gitops/webhook
+func (s *HTTPHandlers) GitOpsWebhook(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if s.agent.gitops == nil {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: "GitOps webhook receiver is disabled"}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(resp, req.Body, gitOpsMaxPayloadSize))
+	if err != nil {
+		return nil, HTTPError{StatusCode: http.StatusRequestEntityTooLarge, Reason: fmt.Sprintf("Failed to read webhook payload: %v", err)}
+	}
+
+	return s.agent.gitops.handle(req, body)
+}
+
+// GitOpsStatus returns the outcome of the last sync of the GitOps webhook
+// receiver.
+//
+// GET /v1/gitops/status
+func (s *HTTPHandlers) GitOpsStatus(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.delegate.ResolveTokenAndDefaultMeta(token, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := authz.ToAllowAuthorizer().OperatorReadAllowed(nil); err != nil {
+		return nil, err
+	}
+
+	if s.agent.gitops == nil {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: "GitOps webhook receiver is disabled"}
+	}
+	last := s.agent.gitops.status()
+	if last == nil {
+		return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: "No commit has been applied yet"}
+	}
+	return last, nil
+}
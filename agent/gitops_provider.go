@@ -0,0 +1,375 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul/agent/config"
+)
+
+// gitOpsMaxFileSize limits the size of a single file fetched from the
+// repository.
+const gitOpsMaxFileSize = 1024 * 1024
+
+// gitOpsPush is a push to a Git repository, as reported by a webhook.
+type gitOpsPush struct {
+	Repository string
+	Ref        string
+	Commit     string
+	DeliveryID string
+}
+
+// gitOpsFile is a file fetched from the repository.
+type gitOpsFile struct {
+	Path string
+	Data []byte
+}
+
+// gitOpsSource abstracts the Git hosting providers supported by the GitOps
+// webhook receiver.
+type gitOpsSource interface {
+	// verify checks that the webhook request was sent by the provider using
+	// the shared webhook secret.
+	verify(req *http.Request, body []byte) error
+
+	// parsePush decodes the push described by the webhook request. It returns
+	// nil when the request is for another kind of event.
+	parsePush(req *http.Request, body []byte) (*gitOpsPush, error)
+
+	// fetch returns the configuration files under the configured path at
+	// the given commit.
+	fetch(ctx context.Context, commit string) ([]gitOpsFile, error)
+
+	// isAncestor returns whether commit is an ancestor of, or the same
+	// commit as, other.
+	isAncestor(ctx context.Context, commit, other string) (bool, error)
+}
+
+func newGitOpsSource(cfg config.GitOpsConfig, client *http.Client) (gitOpsSource, error) {
+	switch cfg.Provider {
+	case "github":
+		return &gitHubSource{config: cfg, client: client}, nil
+	case "gitlab":
+		return &gitLabSource{config: cfg, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported GitOps provider %q", cfg.Provider)
+	}
+}
+
+// isGitOpsConfigFile returns whether the file at p holds a config entry or a
+// resource.
+func isGitOpsConfigFile(p string) bool {
+	switch path.Ext(p) {
+	case ".hcl", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// gitOpsGet performs a GET request against a provider API and decodes the
+// JSON response into out, or returns the raw body when out is nil.
+func gitOpsGet(ctx context.Context, client *http.Client, u string, header http.Header, out interface{}) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, gitOpsMaxFileSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected response code %d from %s: %s", resp.StatusCode, req.URL.Redacted(), strings.TrimSpace(string(body)))
+	}
+	if len(body) > gitOpsMaxFileSize {
+		return nil, nil, fmt.Errorf("response from %s exceeds %d bytes", req.URL.Redacted(), gitOpsMaxFileSize)
+	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode response from %s: %w", req.URL.Redacted(), err)
+		}
+	}
+	return body, resp.Header, nil
+}
+
+// escapePath escapes each segment of a slash separated path.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// gitHubSource receives webhooks from and fetches files with the GitHub API.
+type gitHubSource struct {
+	config config.GitOpsConfig
+	client *http.Client
+}
+
+func (s *gitHubSource) verify(req *http.Request, body []byte) error {
+	sig, ok := strings.CutPrefix(req.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !ok {
+		return fmt.Errorf("missing X-Hub-Signature-256 header")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("malformed X-Hub-Signature-256 header")
+	}
+	mac := hmac.New(sha256.New, []byte(s.config.WebhookSecret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("invalid webhook signature")
+	}
+	return nil
+}
+
+func (s *gitHubSource) parsePush(req *http.Request, body []byte) (*gitOpsPush, error) {
+	if req.Header.Get("X-GitHub-Event") != "push" {
+		return nil, nil
+	}
+
+	var payload struct {
+		Ref        string `json:"ref"`
+		After      string `json:"after"`
+		Deleted    bool   `json:"deleted"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode push event: %w", err)
+	}
+	if payload.Deleted {
+		return nil, nil
+	}
+	return &gitOpsPush{
+		Repository: payload.Repository.FullName,
+		Ref:        payload.Ref,
+		Commit:     payload.After,
+		DeliveryID: req.Header.Get("X-GitHub-Delivery"),
+	}, nil
+}
+
+func (s *gitHubSource) header(accept string) http.Header {
+	h := http.Header{}
+	h.Set("Accept", accept)
+	h.Set("X-GitHub-Api-Version", "2022-11-28")
+	if s.config.APIToken != "" {
+		h.Set("Authorization", "Bearer "+s.config.APIToken)
+	}
+	return h
+}
+
+func (s *gitHubSource) contentsURL(p, commit string) string {
+	return fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s",
+		s.config.APIAddress, escapePath(s.config.Repository), escapePath(p), url.QueryEscape(commit))
+}
+
+func (s *gitHubSource) isAncestor(ctx context.Context, commit, other string) (bool, error) {
+	u := fmt.Sprintf("%s/repos/%s/compare/%s...%s",
+		s.config.APIAddress, escapePath(s.config.Repository), url.PathEscape(commit), url.PathEscape(other))
+	var comparison struct {
+		Status string `json:"status"`
+	}
+	if _, _, err := gitOpsGet(ctx, s.client, u, s.header("application/vnd.github+json"), &comparison); err != nil {
+		return false, err
+	}
+	// The status tells how other relates to commit.
+	return comparison.Status == "ahead" || comparison.Status == "identical", nil
+}
+
+func (s *gitHubSource) fetch(ctx context.Context, commit string) ([]gitOpsFile, error) {
+	var paths []string
+	if err := s.listFiles(ctx, s.config.Path, commit, &paths); err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	files := make([]gitOpsFile, 0, len(paths))
+	for _, p := range paths {
+		data, _, err := gitOpsGet(ctx, s.client, s.contentsURL(p, commit), s.header("application/vnd.github.raw"), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", p, err)
+		}
+		files = append(files, gitOpsFile{Path: p, Data: data})
+	}
+	return files, nil
+}
+
+// listFiles appends the configuration files under dir to paths, descending
+// into subdirectories.
+func (s *gitHubSource) listFiles(ctx context.Context, dir, commit string, paths *[]string) error {
+	type content struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}
+
+	body, _, err := gitOpsGet(ctx, s.client, s.contentsURL(dir, commit), s.header("application/vnd.github+json"), nil)
+	if err != nil {
+		return fmt.Errorf("failed to list %q: %w", dir, err)
+	}
+
+	// The contents API returns an object rather than a list when the path
+	// is a file.
+	var contents []content
+	if err := json.Unmarshal(body, &contents); err != nil {
+		var single content
+		if err := json.Unmarshal(body, &single); err != nil {
+			return fmt.Errorf("failed to decode contents of %q: %w", dir, err)
+		}
+		contents = []content{single}
+	}
+
+	for _, c := range contents {
+		switch {
+		case c.Type == "dir":
+			if err := s.listFiles(ctx, c.Path, commit, paths); err != nil {
+				return err
+			}
+		case c.Type == "file" && isGitOpsConfigFile(c.Path):
+			*paths = append(*paths, c.Path)
+		}
+	}
+	return nil
+}
+
+// gitLabSource receives webhooks from and fetches files with the GitLab API.
+type gitLabSource struct {
+	config config.GitOpsConfig
+	client *http.Client
+}
+
+func (s *gitLabSource) verify(req *http.Request, _ []byte) error {
+	token := req.Header.Get("X-Gitlab-Token")
+	if token == "" {
+		return fmt.Errorf("missing X-Gitlab-Token header")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.config.WebhookSecret)) != 1 {
+		return fmt.Errorf("invalid webhook token")
+	}
+	return nil
+}
+
+func (s *gitLabSource) parsePush(req *http.Request, body []byte) (*gitOpsPush, error) {
+	if req.Header.Get("X-Gitlab-Event") != "Push Hook" {
+		return nil, nil
+	}
+
+	var payload struct {
+		Ref         string `json:"ref"`
+		CheckoutSHA string `json:"checkout_sha"`
+		Project     struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode push event: %w", err)
+	}
+	// The checkout SHA is null when the branch was deleted.
+	if payload.CheckoutSHA == "" {
+		return nil, nil
+	}
+	return &gitOpsPush{
+		Repository: payload.Project.PathWithNamespace,
+		Ref:        payload.Ref,
+		Commit:     payload.CheckoutSHA,
+		DeliveryID: req.Header.Get("X-Gitlab-Event-UUID"),
+	}, nil
+}
+
+func (s *gitLabSource) isAncestor(ctx context.Context, commit, other string) (bool, error) {
+	query := url.Values{}
+	query.Add("refs[]", commit)
+	query.Add("refs[]", other)
+
+	var base struct {
+		ID string `json:"id"`
+	}
+	if _, _, err := gitOpsGet(ctx, s.client, s.projectURL()+"/repository/merge_base?"+query.Encode(), s.header(), &base); err != nil {
+		return false, err
+	}
+	return base.ID == commit, nil
+}
+
+func (s *gitLabSource) header() http.Header {
+	h := http.Header{}
+	if s.config.APIToken != "" {
+		h.Set("PRIVATE-TOKEN", s.config.APIToken)
+	}
+	return h
+}
+
+func (s *gitLabSource) projectURL() string {
+	return fmt.Sprintf("%s/projects/%s", s.config.APIAddress, url.PathEscape(s.config.Repository))
+}
+
+func (s *gitLabSource) fetch(ctx context.Context, commit string) ([]gitOpsFile, error) {
+	var paths []string
+	for page := "1"; page != ""; {
+		query := url.Values{}
+		query.Set("recursive", "true")
+		query.Set("ref", commit)
+		query.Set("per_page", "100")
+		query.Set("page", page)
+		if s.config.Path != "" {
+			query.Set("path", s.config.Path)
+		}
+
+		var tree []struct {
+			Type string `json:"type"`
+			Path string `json:"path"`
+		}
+		_, header, err := gitOpsGet(ctx, s.client, s.projectURL()+"/repository/tree?"+query.Encode(), s.header(), &tree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %q: %w", s.config.Path, err)
+		}
+		for _, entry := range tree {
+			if entry.Type == "blob" && isGitOpsConfigFile(entry.Path) {
+				paths = append(paths, entry.Path)
+			}
+		}
+
+		page = header.Get("X-Next-Page")
+		if _, err := strconv.Atoi(page); page != "" && err != nil {
+			return nil, fmt.Errorf("invalid X-Next-Page header %q", page)
+		}
+	}
+	sort.Strings(paths)
+
+	files := make([]gitOpsFile, 0, len(paths))
+	for _, p := range paths {
+		u := fmt.Sprintf("%s/repository/files/%s/raw?ref=%s", s.projectURL(), url.PathEscape(p), url.QueryEscape(commit))
+		data, _, err := gitOpsGet(ctx, s.client, u, s.header(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", p, err)
+		}
+		files = append(files, gitOpsFile{Path: p, Data: data})
+	}
+	return files, nil
+}
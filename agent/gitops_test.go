@@ -0,0 +1,359 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/config"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+// gitOpsTestHistory are the commits of the acme/consul-config repository,
+// oldest first.
+var gitOpsTestHistory = []string{"0ld", "c0ffee", "dec0de"}
+
+// newFakeGitHub serves the files of the acme/consul-config repository through
+// a minimal implementation of the GitHub contents and compare APIs. The files
+// are the same at every commit but the oldest.
+func newFakeGitHub(t *testing.T, files map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer api-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if refs, ok := strings.CutPrefix(r.URL.Path, "/repos/acme/consul-config/compare/"); ok {
+			base, head, _ := strings.Cut(refs, "...")
+			baseIdx, headIdx := slices.Index(gitOpsTestHistory, base), slices.Index(gitOpsTestHistory, head)
+			if baseIdx < 0 || headIdx < 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			status := "identical"
+			switch {
+			case headIdx > baseIdx:
+				status = "ahead"
+			case headIdx < baseIdx:
+				status = "behind"
+			}
+			json.NewEncoder(w).Encode(map[string]string{"status": status})
+			return
+		}
+
+		p, ok := strings.CutPrefix(r.URL.Path, "/repos/acme/consul-config/contents/")
+		if ref := r.URL.Query().Get("ref"); !ok || ref == gitOpsTestHistory[0] || !slices.Contains(gitOpsTestHistory, ref) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		p = strings.Trim(p, "/")
+
+		if data, ok := files[p]; ok {
+			if r.Header.Get("Accept") == "application/vnd.github.raw" {
+				fmt.Fprint(w, data)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"type": "file", "path": p})
+			return
+		}
+
+		var contents []map[string]string
+		seen := make(map[string]bool)
+		for name := range files {
+			rel, ok := strings.CutPrefix(name, p+"/")
+			if !ok {
+				continue
+			}
+			child, _, isDir := strings.Cut(rel, "/")
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			kind := "file"
+			if isDir {
+				kind = "dir"
+			}
+			contents = append(contents, map[string]string{"type": kind, "path": path.Join(p, child)})
+		}
+		if contents == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(contents)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func gitHubPushRequest(t *testing.T, secret, event, ref string) *http.Request {
+	return gitHubCommitPushRequest(t, secret, event, ref, "c0ffee", "delivery-1")
+}
+
+func gitHubCommitPushRequest(t *testing.T, secret, event, ref, commit, deliveryID string) *http.Request {
+	body := fmt.Sprintf(`{"ref": %q, "after": %q, "repository": {"full_name": "acme/consul-config"}}`, ref, commit)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req, err := http.NewRequest("POST", "/v1/gitops/webhook", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newGitOpsTestAgent(t *testing.T, apiAddress string) *TestAgent {
+	a := NewTestAgent(t, fmt.Sprintf(`
+		gitops {
+			enabled = true
+			webhook_secret = "s3cr3t"
+			repository = "acme/consul-config"
+			path = "consul"
+			api_address = %q
+			api_token = "api-token"
+		}
+	`, apiAddress))
+	t.Cleanup(func() { a.Shutdown() })
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+	return a
+}
+
+func TestGitOpsWebhook_Disabled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	resp := httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, gitHubPushRequest(t, "s3cr3t", "push", "refs/heads/main"))
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestGitOpsWebhook_GitHub(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	gh := newFakeGitHub(t, map[string]string{
+		"consul/README.md": "ignored",
+		"consul/web.hcl": `
+			Kind     = "service-defaults"
+			Name     = "web"
+			Protocol = "http"
+		`,
+		"consul/resolvers/web.json": `{"Kind": "service-resolver", "Name": "web", "ConnectTimeout": "15s"}`,
+	})
+	a := newGitOpsTestAgent(t, gh.URL)
+
+	serve := func(req *http.Request) (*httptest.ResponseRecorder, GitOpsSync) {
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		var out GitOpsSync
+		if resp.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		}
+		return resp, out
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		resp, _ := serve(gitHubPushRequest(t, "wrong", "push", "refs/heads/main"))
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		resp, out := serve(gitHubPushRequest(t, "s3cr3t", "ping", ""))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusIgnored, out.Status)
+	})
+
+	t.Run("other branch", func(t *testing.T) {
+		resp, out := serve(gitHubPushRequest(t, "s3cr3t", "push", "refs/heads/feature"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusIgnored, out.Status)
+	})
+
+	t.Run("status before sync", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/gitops/status", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Code)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		resp, out := serve(gitHubPushRequest(t, "s3cr3t", "push", "refs/heads/main"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusApplied, out.Status, out.Error)
+		require.Equal(t, "c0ffee", out.Commit)
+		require.Equal(t, "delivery-1", out.DeliveryID)
+		require.Equal(t, []string{
+			"service-defaults/web (consul/web.hcl)",
+			"service-resolver/web (consul/resolvers/web.json)",
+		}, out.Applied)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/gitops/status", nil)
+		resp := httptest.NewRecorder()
+		a.srv.h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var out GitOpsSync
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		require.Equal(t, GitOpsStatusApplied, out.Status)
+		require.Equal(t, "c0ffee", out.Commit)
+	})
+
+	t.Run("replayed delivery", func(t *testing.T) {
+		resp, out := serve(gitHubPushRequest(t, "s3cr3t", "push", "refs/heads/main"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusRejected, out.Status)
+		require.Contains(t, out.Error, "delivery delivery-1 was already applied")
+	})
+
+	t.Run("applied commit", func(t *testing.T) {
+		resp, out := serve(gitHubCommitPushRequest(t, "s3cr3t", "push", "refs/heads/main", "c0ffee", "delivery-2"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusRejected, out.Status)
+		require.Contains(t, out.Error, "commit c0ffee was already applied")
+	})
+
+	t.Run("stale commit", func(t *testing.T) {
+		resp, out := serve(gitHubCommitPushRequest(t, "s3cr3t", "push", "refs/heads/main", "0ld", "delivery-3"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusRejected, out.Status)
+		require.Contains(t, out.Error, "commit 0ld is older than the last applied commit c0ffee")
+	})
+
+	t.Run("new commit", func(t *testing.T) {
+		resp, out := serve(gitHubCommitPushRequest(t, "s3cr3t", "push", "refs/heads/main", "dec0de", "delivery-4"))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, GitOpsStatusApplied, out.Status, out.Error)
+		require.Equal(t, "dec0de", out.Commit)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		args := structs.KeyRequest{Datacenter: "dc1", Key: "_gitops/github/acme/consul-config/main"}
+		var reply structs.IndexedDirEntries
+		require.NoError(t, a.RPC(context.Background(), "KVS.Get", &args, &reply))
+		require.Len(t, reply.Entries, 1)
+
+		var state gitOpsState
+		require.NoError(t, json.Unmarshal(reply.Entries[0].Value, &state))
+		require.Equal(t, gitOpsState{Commit: "dec0de", DeliveryIDs: []string{"delivery-1", "delivery-4"}}, state)
+	})
+}
+
+func TestGitOpsWebhook_Rollback(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	gh := newFakeGitHub(t, map[string]string{
+		"consul/a-web.hcl": `
+			Kind     = "service-defaults"
+			Name     = "web"
+			Protocol = "http"
+		`,
+		// The api service uses the default tcp protocol, which does not
+		// support routing, so applying this entry fails.
+		"consul/b-api.hcl": `
+			Kind = "service-router"
+			Name = "api"
+			Routes = [{ Match { HTTP { PathPrefix = "/v2" } } Destination { Service = "api-v2" } }]
+		`,
+	})
+	a := newGitOpsTestAgent(t, gh.URL)
+
+	resp := httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, gitHubPushRequest(t, "s3cr3t", "push", "refs/heads/main"))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var out GitOpsSync
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, GitOpsStatusFailed, out.Status)
+	require.Contains(t, out.Error, "service-router/api (consul/b-api.hcl)")
+	require.True(t, out.RolledBack)
+
+	args := structs.ConfigEntryQuery{Kind: structs.ServiceDefaults, Name: "web", Datacenter: "dc1"}
+	var reply structs.ConfigEntryResponse
+	require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Get", &args, &reply))
+	require.Nil(t, reply.Entry)
+
+	// The commit isn't recorded as applied, so its redelivery is retried.
+	keyArgs := structs.KeyRequest{Datacenter: "dc1", Key: "_gitops/github/acme/consul-config/main"}
+	var keyReply structs.IndexedDirEntries
+	require.NoError(t, a.RPC(context.Background(), "KVS.Get", &keyArgs, &keyReply))
+	require.Empty(t, keyReply.Entries)
+}
+
+func TestGitLabSource(t *testing.T) {
+	src := &gitLabSource{config: config.GitOpsConfig{WebhookSecret: "s3cr3t"}}
+	body := []byte(`{"object_kind": "push", "ref": "refs/heads/main", "checkout_sha": "c0ffee", "project": {"path_with_namespace": "platform/consul-config"}}`)
+
+	req := httptest.NewRequest("POST", "/v1/gitops/webhook", nil)
+	require.Error(t, src.verify(req, body))
+	req.Header.Set("X-Gitlab-Token", "wrong")
+	require.Error(t, src.verify(req, body))
+	req.Header.Set("X-Gitlab-Token", "s3cr3t")
+	require.NoError(t, src.verify(req, body))
+
+	push, err := src.parsePush(req, body)
+	require.NoError(t, err)
+	require.Nil(t, push)
+
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", "delivery-1")
+	push, err = src.parsePush(req, body)
+	require.NoError(t, err)
+	require.Equal(t, &gitOpsPush{
+		Repository: "platform/consul-config",
+		Ref:        "refs/heads/main",
+		Commit:     "c0ffee",
+		DeliveryID: "delivery-1",
+	}, push)
+
+	gl := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refs := r.URL.Query()["refs[]"]
+		if r.URL.EscapedPath() != "/projects/platform%2Fconsul-config/repository/merge_base" || len(refs) != 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// 0ld is the parent of c0ffee.
+		base := refs[0]
+		if refs[1] == "0ld" {
+			base = "0ld"
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": base})
+	}))
+	defer gl.Close()
+	src = &gitLabSource{
+		config: config.GitOpsConfig{Repository: "platform/consul-config", APIAddress: gl.URL},
+		client: gl.Client(),
+	}
+
+	ancestor, err := src.isAncestor(context.Background(), "0ld", "c0ffee")
+	require.NoError(t, err)
+	require.True(t, ancestor)
+	ancestor, err = src.isAncestor(context.Background(), "c0ffee", "0ld")
+	require.NoError(t, err)
+	require.False(t, ancestor)
+}
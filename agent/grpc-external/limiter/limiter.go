@@ -39,11 +39,14 @@ var ErrCapacityReached = errors.New("active session limit reached")
 // If there are more than the given maximum sessions already in-flight,
 // SessionLimiter will drain randomly-selected sessions at a rate controlled
 // by SetDrainRateLimit.
+//
+// Sessions can also be drained on demand with DrainSessions, to move them to
+// other servers when the load is unevenly spread.
 type SessionLimiter struct {
 	drainLimiter *rate.Limiter
 
-	// max and inFlight are read/written using atomic operations.
-	max, inFlight uint32
+	// max, inFlight and pendingDrain are read/written using atomic operations.
+	max, inFlight, pendingDrain uint32
 
 	// wakeCh is used to trigger the Run loop to start draining excess sessions.
 	wakeCh chan struct{}
@@ -74,7 +77,7 @@ func (l *SessionLimiter) Run(ctx context.Context) {
 		select {
 		case <-l.wakeCh:
 			for {
-				if !l.overCapacity() {
+				if !l.overCapacity() && atomic.LoadUint32(&l.pendingDrain) == 0 {
 					break
 				}
 
@@ -82,7 +85,7 @@ func (l *SessionLimiter) Run(ctx context.Context) {
 					break
 				}
 
-				if !l.overCapacity() {
+				if !l.overCapacity() && !l.consumePendingDrain() {
 					break
 				}
 
@@ -107,6 +110,23 @@ func (l *SessionLimiter) SetMaxSessions(max uint32) {
 	}
 }
 
+// DrainSessions terminates the given number of randomly-selected sessions, at
+// the rate controlled by SetDrainRateLimit, so that their holders reconnect to
+// other servers. It replaces the sessions still pending from a previous call.
+func (l *SessionLimiter) DrainSessions(n uint32) {
+	atomic.StoreUint32(&l.pendingDrain, n)
+
+	select {
+	case l.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// InFlight returns the number of concurrent sessions.
+func (l *SessionLimiter) InFlight() uint32 {
+	return atomic.LoadUint32(&l.inFlight)
+}
+
 // SetDrainRateLimit controls the rate at which excess sessions will be drained.
 func (l *SessionLimiter) SetDrainRateLimit(limit rate.Limit) {
 	l.drainLimiter.SetLimit(limit)
@@ -161,10 +181,29 @@ func (l *SessionLimiter) overCapacity() bool {
 	return cur > max
 }
 
+// consumePendingDrain decrements the number of sessions pending to be drained
+// by DrainSessions, and reports whether there was any.
+func (l *SessionLimiter) consumePendingDrain() bool {
+	for {
+		n := atomic.LoadUint32(&l.pendingDrain)
+		if n == 0 {
+			return false
+		}
+		if atomic.CompareAndSwapUint32(&l.pendingDrain, n, n-1) {
+			return true
+		}
+	}
+}
+
 func (l *SessionLimiter) terminateSession() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	// Sessions pending to be drained may have ended on their own.
+	if len(l.sessionIDs) == 0 {
+		return
+	}
+
 	idx := rand.Intn(len(l.sessionIDs))
 	id := l.sessionIDs[idx]
 	l.sessions[id].terminate()
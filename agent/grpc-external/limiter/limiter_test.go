@@ -78,3 +78,51 @@ func TestSessionLimiter(t *testing.T) {
 	_, err = lim.BeginSession()
 	require.Equal(t, ErrCapacityReached, err)
 }
+
+func TestSessionLimiter_DrainSessions(t *testing.T) {
+	lim := NewSessionLimiter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go lim.Run(ctx)
+
+	doneCh := make(chan struct{})
+	t.Cleanup(func() { close(doneCh) })
+
+	var (
+		terminations uint32
+		wg           sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			sess, err := lim.BeginSession()
+			require.NoError(t, err)
+			defer sess.End()
+
+			wg.Done()
+
+			select {
+			case <-sess.Terminated():
+				atomic.AddUint32(&terminations, 1)
+			case <-doneCh:
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, uint32(10), lim.InFlight())
+
+	// Draining 3 sessions should terminate exactly 3 sessions, and leave room
+	// for new ones since the limit is unchanged.
+	lim.DrainSessions(3)
+	require.Eventually(t, func() bool {
+		return atomic.LoadUint32(&terminations) == 3
+	}, 2*time.Second, 50*time.Millisecond)
+	require.Never(t, func() bool {
+		return atomic.LoadUint32(&terminations) > 3
+	}, 200*time.Millisecond, 50*time.Millisecond)
+	require.Equal(t, uint32(7), lim.InFlight())
+
+	_, err := lim.BeginSession()
+	require.NoError(t, err)
+}
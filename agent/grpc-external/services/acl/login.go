@@ -59,6 +59,9 @@ func (s *Server) Login(ctx context.Context, req *pbacl.LoginRequest) (*pbacl.Log
 		logger.Error("failed to load auth method", "error", err.Error())
 		return nil, status.Error(codes.Internal, "failed to load auth method")
 	}
+	if err := auth.ValidateBearerTokenLogin(authMethod); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	verifiedIdentity, err := validator.ValidateLogin(ctx, req.BearerToken)
 	if err != nil {
@@ -19,6 +19,8 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/consul/authmethod"
+	"github.com/hashicorp/consul/agent/consul/authmethod/certauth"
+	"github.com/hashicorp/consul/agent/consul/authmethod/socketauth"
 	"github.com/hashicorp/consul/agent/grpc-external/testutils"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/proto-public/pbacl"
@@ -169,6 +171,48 @@ func TestServer_Login_ValidateLoginError(t *testing.T) {
 	require.Equal(t, codes.Unauthenticated.String(), status.Code(err).String())
 }
 
+func TestServer_Login_AgentOnlyAuthMethods(t *testing.T) {
+	testCases := map[string]struct {
+		methodType string
+		error      string
+	}{
+		"tls-cert": {
+			methodType: certauth.AuthMethodType,
+			error:      `auth method "agent-only" of type "tls-cert" can only be used by presenting a client certificate to the HTTPS API`,
+		},
+		"socket-cred": {
+			methodType: socketauth.AuthMethodType,
+			error:      `auth method "agent-only" of type "socket-cred" can only be used by connecting to an agent's Unix domain socket`,
+		},
+	}
+	for desc, tc := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			// The validator must not be called, the bearer token doesn't
+			// prove the identity for these methods.
+			validator := NewMockValidator(t)
+
+			server := NewServer(Config{
+				ACLsEnabled: true,
+				Logger:      hclog.NewNullLogger(),
+				LoadAuthMethod: func(methodName string, entMeta *acl.EnterpriseMeta) (*structs.ACLAuthMethod, Validator, error) {
+					return &structs.ACLAuthMethod{Name: "agent-only", Type: tc.methodType}, validator, nil
+				},
+				ValidateEnterpriseRequest: noopValidateEnterpriseRequest,
+				LocalTokensEnabled:        noopLocalTokensEnabled,
+				ForwardRPC:                noopForwardRPC,
+			})
+
+			_, err := server.Login(context.Background(), &pbacl.LoginRequest{
+				AuthMethod:  "agent-only",
+				BearerToken: bearerToken,
+			})
+			require.Error(t, err)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+			require.Equal(t, tc.error, status.Convert(err).Message())
+		})
+	}
+}
+
 func TestServer_Login_TokenForVerifiedIdentityErrors(t *testing.T) {
 	testCases := map[string]struct {
 		error error
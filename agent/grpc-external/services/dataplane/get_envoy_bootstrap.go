@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dataplane
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/xds"
+	"github.com/hashicorp/consul/agent/xds/bootstrap"
+	"github.com/hashicorp/consul/proto-public/pbdataplane"
+)
+
+const defaultAdminBindAddress = "127.0.0.1:19000"
+
+// GetEnvoyBootstrap generates the Envoy bootstrap configuration for a proxy
+// the same way `consul connect envoy -bootstrap` does, so that callers do not
+// need to run the CLI.
+func (s *Server) GetEnvoyBootstrap(ctx context.Context, req *pbdataplane.GetEnvoyBootstrapRequest) (*pbdataplane.GetEnvoyBootstrapResponse, error) {
+	logger := s.Logger.Named("get-envoy-bootstrap").With("proxy_id", req.ProxyId, "request_id", external.TraceID())
+
+	logger.Trace("Started processing request")
+	defer logger.Trace("Finished processing request")
+
+	if s.EnableV2 {
+		return nil, status.Error(codes.Unimplemented, "GetEnvoyBootstrap is not supported with the v2 catalog, use GetEnvoyBootstrapParams instead")
+	}
+
+	if req.XdsAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "xds_address is required")
+	}
+	// The addresses are only rendered into the bootstrap for the caller, so
+	// they must be IP addresses. Resolving host names here would make DNS
+	// lookups on the caller's behalf from the server's network.
+	grpcArgs, err := bootstrap.ParseAgentIPAddress(req.XdsAddress)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid xds_address: %v", err)
+	}
+	// Configure unix sockets to encrypt traffic whenever a certificate is explicitly defined.
+	if grpcArgs.AgentSocket != "" && req.XdsCaPem != "" {
+		grpcArgs.AgentTLS = true
+	}
+
+	adminBind := req.AdminBindAddress
+	if adminBind == "" {
+		adminBind = defaultAdminBindAddress
+	}
+	adminAddr, adminPort, err := net.SplitHostPort(adminBind)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid admin_bind_address: %v", err)
+	}
+	adminBindIP := net.ParseIP(adminAddr)
+	if adminBindIP == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid admin_bind_address: %q is not an IP address", adminAddr)
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var authzContext acl.AuthorizerContext
+	entMeta := acl.NewEnterpriseMetaWithPartition(req.GetPartition(), req.GetNamespace())
+	authz, err := s.ACLResolver.ResolveTokenAndDefaultMeta(options.Token, &entMeta, &authzContext)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	svc, ns, err := s.lookupProxyService(logger, req.GetNodeId(), req.GetNodeName(), req.ProxyId, &entMeta, authz, &authzContext)
+	if err != nil {
+		return nil, err
+	}
+	if !ns.Kind.IsProxy() {
+		return nil, status.Errorf(codes.InvalidArgument, "service %q is not a Connect proxy or gateway", svc.ServiceID)
+	}
+
+	args := &bootstrap.BootstrapTplArgs{
+		GRPC:                  grpcArgs,
+		ProxyCluster:          svc.ServiceName,
+		ProxyID:               svc.ServiceID,
+		NodeName:              svc.Node,
+		ProxySourceService:    svc.ServiceName,
+		AgentCAPEM:            bootstrap.EscapeCAPEM(req.XdsCaPem),
+		AdminAccessLogPath:    bootstrap.DefaultAdminAccessLogPath,
+		AdminBindAddress:      adminBindIP.String(),
+		AdminBindPort:         adminPort,
+		Token:                 options.Token,
+		LocalAgentClusterName: xds.LocalAgentClusterName,
+		Namespace:             svc.EnterpriseMeta.NamespaceOrDefault(),
+		Partition:             svc.EnterpriseMeta.PartitionOrDefault(),
+		Datacenter:            s.Datacenter,
+	}
+	if ns.Proxy.DestinationServiceName != "" {
+		args.ProxyCluster = ns.Proxy.DestinationServiceName
+		args.ProxySourceService = ns.Proxy.DestinationServiceName
+	}
+
+	if ns.Proxy.AccessLogs.Enabled {
+		args.AdminAccessLogConfig, err = bootstrap.AccessLogConfigs(&ns.Proxy.AccessLogs)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	var bsCfg bootstrap.BootstrapConfig
+	if err := mapstructure.WeakDecode(ns.Proxy.Config, &bsCfg); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed parsing Proxy.Config: %v", err)
+	}
+
+	// Setup ready listener for ingress gateway to pass healthcheck
+	if ns.Kind == structs.ServiceKindIngressGateway {
+		addr := ns.Address
+		if addr == "" {
+			addr = "127.0.0.1"
+		}
+		bsCfg.ReadyBindAddr = net.JoinHostPort(addr, strconv.Itoa(ns.Port))
+	}
+
+	bootstrapJSON, err := bsCfg.GenerateJSON(args, req.OmitDeprecatedTags)
+	if err != nil {
+		logger.Error("Error generating the envoy bootstrap config", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed generating the envoy bootstrap config: %v", err)
+	}
+
+	return &pbdataplane.GetEnvoyBootstrapResponse{
+		BootstrapJson: string(bootstrapJSON),
+	}, nil
+}
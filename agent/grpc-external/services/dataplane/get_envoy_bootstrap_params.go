@@ -116,36 +116,9 @@ func (s *Server) GetEnvoyBootstrapParams(ctx context.Context, req *pbdataplane.G
 
 	// The remainder of this file focuses on v1 implementation of this endpoint.
 
-	store := s.GetStore()
-
-	_, svc, err := store.ServiceNode(req.GetNodeId(), req.GetNodeName(), proxyID, &entMeta, structs.DefaultPeerKeyword)
-	if err != nil {
-		logger.Error("Error looking up service", "error", err)
-		if errors.Is(err, state.ErrNodeNotFound) {
-			return nil, status.Error(codes.NotFound, err.Error())
-		} else if strings.Contains(err.Error(), "Node ID or name required") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		} else {
-			return nil, status.Error(codes.Internal, "Failure looking up service")
-		}
-	}
-	if svc == nil {
-		return nil, status.Error(codes.NotFound, "Service not found")
-	}
-
-	if err := authz.ToAllowAuthorizer().ServiceReadAllowed(svc.ServiceName, &authzContext); err != nil {
-		return nil, status.Error(codes.PermissionDenied, err.Error())
-	}
-
-	_, ns, err := configentry.MergeNodeServiceWithCentralConfig(
-		nil,
-		store,
-		svc.ToNodeService(),
-		logger,
-	)
+	svc, ns, err := s.lookupProxyService(logger, req.GetNodeId(), req.GetNodeName(), proxyID, &entMeta, authz, &authzContext)
 	if err != nil {
-		logger.Error("Error merging with central config", "error", err)
-		return nil, status.Errorf(codes.Unknown, "Error merging central config: %v", err)
+		return nil, err
 	}
 
 	bootstrapConfig, err := structpb.NewStruct(ns.Proxy.Config)
@@ -181,6 +154,43 @@ func (s *Server) GetEnvoyBootstrapParams(ctx context.Context, req *pbdataplane.G
 	}, nil
 }
 
+// lookupProxyService returns the v1 proxy service registered on the given
+// node, and a copy of it merged with its central configuration.
+func (s *Server) lookupProxyService(logger hclog.Logger, nodeID, nodeName, proxyID string, entMeta *acl.EnterpriseMeta, authz acl.Authorizer, authzContext *acl.AuthorizerContext) (*structs.ServiceNode, *structs.NodeService, error) {
+	store := s.GetStore()
+
+	_, svc, err := store.ServiceNode(nodeID, nodeName, proxyID, entMeta, structs.DefaultPeerKeyword)
+	if err != nil {
+		logger.Error("Error looking up service", "error", err)
+		if errors.Is(err, state.ErrNodeNotFound) {
+			return nil, nil, status.Error(codes.NotFound, err.Error())
+		} else if strings.Contains(err.Error(), "Node ID or name required") {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		} else {
+			return nil, nil, status.Error(codes.Internal, "Failure looking up service")
+		}
+	}
+	if svc == nil {
+		return nil, nil, status.Error(codes.NotFound, "Service not found")
+	}
+
+	if err := authz.ToAllowAuthorizer().ServiceReadAllowed(svc.ServiceName, authzContext); err != nil {
+		return nil, nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	_, ns, err := configentry.MergeNodeServiceWithCentralConfig(
+		nil,
+		store,
+		svc.ToNodeService(),
+		logger,
+	)
+	if err != nil {
+		logger.Error("Error merging with central config", "error", err)
+		return nil, nil, status.Errorf(codes.Unknown, "Error merging central config: %v", err)
+	}
+	return svc, ns, nil
+}
+
 func makeAccessLogs(logs structs.AccessLogs, logger hclog.Logger) []string {
 	var accessLogs []string
 	if logs.GetEnabled() {
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dataplane
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/go-hclog"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbdataplane"
+)
+
+func TestGetEnvoyBootstrap_Success(t *testing.T) {
+	type testCase struct {
+		name          string
+		registerReq   *structs.RegisterRequest
+		proxyDefaults structs.ConfigEntry
+		req           *pbdataplane.GetEnvoyBootstrapRequest
+		check         func(t *testing.T, bootstrap map[string]interface{}, bootstrapJSON string)
+	}
+
+	run := func(t *testing.T, tc testCase) {
+		store := testutils.TestStateStore(t, nil)
+		require.NoError(t, store.EnsureRegistration(1, tc.registerReq))
+		if tc.proxyDefaults != nil {
+			require.NoError(t, store.EnsureConfigEntry(2, tc.proxyDefaults))
+		}
+
+		aclResolver := &MockACLResolver{}
+		aclResolver.On("ResolveTokenAndDefaultMeta", testToken, mock.Anything, mock.Anything).
+			Return(testutils.ACLServiceRead(t, tc.registerReq.Service.ID), nil)
+
+		options := structs.QueryOptions{Token: testToken}
+		ctx, err := external.ContextWithQueryOptions(context.Background(), options)
+		require.NoError(t, err)
+
+		server := NewServer(Config{
+			GetStore:    func() StateStore { return store },
+			Logger:      hclog.NewNullLogger(),
+			ACLResolver: aclResolver,
+			Datacenter:  serverDC,
+		})
+		client := testClient(t, server)
+
+		tc.req.ProxyId = tc.registerReq.Service.ID
+		tc.req.NodeSpec = &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: tc.registerReq.Node}
+		resp, err := client.GetEnvoyBootstrap(ctx, tc.req)
+		require.NoError(t, err)
+
+		var bootstrap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(resp.BootstrapJson), &bootstrap))
+
+		node := bootstrap["node"].(map[string]interface{})
+		require.Equal(t, tc.registerReq.Service.ID, node["id"])
+		metadata := node["metadata"].(map[string]interface{})
+		require.Equal(t, tc.registerReq.Node, metadata["node_name"])
+
+		tc.check(t, bootstrap, resp.BootstrapJson)
+	}
+
+	testCases := []testCase{
+		{
+			name:        "sidecar proxy",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				XdsAddress: "127.0.0.1:8502",
+			},
+			check: func(t *testing.T, bootstrap map[string]interface{}, bootstrapJSON string) {
+				node := bootstrap["node"].(map[string]interface{})
+				require.Equal(t, testServiceName, node["cluster"])
+
+				require.Contains(t, bootstrapJSON, `"port_value": 19000`)
+				require.Contains(t, bootstrapJSON, `"path": "/dev/null"`)
+
+				// The caller's token authenticates Envoy to the xDS server.
+				require.Contains(t, bootstrapJSON, testToken)
+				require.Contains(t, bootstrapJSON, `"port_value": 8502`)
+				// envoy_dogstatsd_url from the proxy config configures a stats sink.
+				require.Contains(t, bootstrapJSON, "envoy.stat_sinks.dog_statsd")
+			},
+		},
+		{
+			name:          "ingress gateway over a TLS unix socket with access logs",
+			registerReq:   testRegisterIngressGateway(t),
+			proxyDefaults: testProxyDefaults(t, true),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				XdsAddress:       "unix:///var/run/consul/xds.sock",
+				XdsCaPem:         "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n",
+				AdminBindAddress: "127.0.0.1:19005",
+			},
+			check: func(t *testing.T, bootstrap map[string]interface{}, bootstrapJSON string) {
+				require.Contains(t, bootstrapJSON, `"path": "/var/run/consul/xds.sock"`)
+				require.Contains(t, bootstrapJSON, `-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----\n`)
+				require.Contains(t, bootstrapJSON, `"port_value": 19005`)
+				require.Contains(t, bootstrapJSON, "envoy.extensions.access_loggers.stream.v3.StdoutAccessLog")
+				require.Contains(t, bootstrapJSON, "envoy_ready_listener")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			run(t, tc)
+		})
+	}
+}
+
+func TestGetEnvoyBootstrap_Error(t *testing.T) {
+	type testCase struct {
+		name            string
+		registerReq     *structs.RegisterRequest
+		req             *pbdataplane.GetEnvoyBootstrapRequest
+		expectedErrCode codes.Code
+		expecteErrMsg   string
+	}
+
+	run := func(t *testing.T, tc testCase) {
+		aclResolver := &MockACLResolver{}
+		aclResolver.On("ResolveTokenAndDefaultMeta", testToken, mock.Anything, mock.Anything).
+			Return(testutils.ACLServiceRead(t, tc.registerReq.Service.ID), nil)
+
+		options := structs.QueryOptions{Token: testToken}
+		ctx, err := external.ContextWithQueryOptions(context.Background(), options)
+		require.NoError(t, err)
+
+		store := testutils.TestStateStore(t, nil)
+		require.NoError(t, store.EnsureRegistration(1, tc.registerReq))
+
+		server := NewServer(Config{
+			GetStore:    func() StateStore { return store },
+			Logger:      hclog.NewNullLogger(),
+			ACLResolver: aclResolver,
+		})
+		client := testClient(t, server)
+
+		resp, err := client.GetEnvoyBootstrap(ctx, tc.req)
+		require.Nil(t, resp)
+		require.Error(t, err)
+		errStatus, ok := status.FromError(err)
+		require.True(t, ok)
+		require.Equal(t, tc.expectedErrCode.String(), errStatus.Code().String())
+		require.Equal(t, tc.expecteErrMsg, errStatus.Message())
+	}
+
+	typicalService := testRegisterRequestProxy(t)
+	typicalService.Service.Kind = structs.ServiceKindTypical
+	typicalService.Service.Proxy = structs.ConnectProxyConfig{}
+
+	testCases := []testCase{
+		{
+			name:        "missing xds address",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:  proxyServiceID,
+				NodeSpec: &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+			},
+			expectedErrCode: codes.InvalidArgument,
+			expecteErrMsg:   "xds_address is required",
+		},
+		{
+			name:        "invalid admin bind address",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:          proxyServiceID,
+				NodeSpec:         &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+				XdsAddress:       "127.0.0.1:8502",
+				AdminBindAddress: "localhost",
+			},
+			expectedErrCode: codes.InvalidArgument,
+			expecteErrMsg:   "invalid admin_bind_address: address localhost: missing port in address",
+		},
+		{
+			name:        "xds address host name is not resolved",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:    proxyServiceID,
+				NodeSpec:   &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+				XdsAddress: "localhost:8502",
+			},
+			expectedErrCode: codes.InvalidArgument,
+			expecteErrMsg:   `invalid xds_address: agent address "localhost" is not an IP address`,
+		},
+		{
+			name:        "admin bind address host name is not resolved",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:          proxyServiceID,
+				NodeSpec:         &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+				XdsAddress:       "127.0.0.1:8502",
+				AdminBindAddress: "localhost:19000",
+			},
+			expectedErrCode: codes.InvalidArgument,
+			expecteErrMsg:   `invalid admin_bind_address: "localhost" is not an IP address`,
+		},
+		{
+			name:        "unregistered service",
+			registerReq: testRegisterRequestProxy(t),
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:    "blah-service",
+				NodeSpec:   &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+				XdsAddress: "127.0.0.1:8502",
+			},
+			expectedErrCode: codes.NotFound,
+			expecteErrMsg:   "Service not found",
+		},
+		{
+			name:        "not a proxy",
+			registerReq: typicalService,
+			req: &pbdataplane.GetEnvoyBootstrapRequest{
+				ProxyId:    proxyServiceID,
+				NodeSpec:   &pbdataplane.GetEnvoyBootstrapRequest_NodeName{NodeName: nodeName},
+				XdsAddress: "127.0.0.1:8502",
+			},
+			expectedErrCode: codes.InvalidArgument,
+			expecteErrMsg:   `service "web-proxy" is not a Connect proxy or gateway`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			run(t, tc)
+		})
+	}
+}
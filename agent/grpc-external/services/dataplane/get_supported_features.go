@@ -45,6 +45,10 @@ func (s *Server) GetSupportedDataplaneFeatures(ctx context.Context, _ *pbdatapla
 			FeatureName: pbdataplane.DataplaneFeatures_DATAPLANE_FEATURES_FIPS,
 			Supported:   version.IsFIPS(),
 		},
+		{
+			FeatureName: pbdataplane.DataplaneFeatures_DATAPLANE_FEATURES_UPSTREAM_METRICS,
+			Supported:   !s.EnableV2,
+		},
 	}
 
 	return &pbdataplane.GetSupportedDataplaneFeaturesResponse{SupportedDataplaneFeatures: supportedFeatures}, nil
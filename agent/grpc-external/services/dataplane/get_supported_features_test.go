@@ -41,7 +41,7 @@ func TestSupportedDataplaneFeatures_Success(t *testing.T) {
 	client := testClient(t, server)
 	resp, err := client.GetSupportedDataplaneFeatures(ctx, &pbdataplane.GetSupportedDataplaneFeaturesRequest{})
 	require.NoError(t, err)
-	require.Equal(t, 4, len(resp.SupportedDataplaneFeatures))
+	require.Equal(t, 5, len(resp.SupportedDataplaneFeatures))
 
 	for _, feature := range resp.SupportedDataplaneFeatures {
 		switch feature.GetFeatureName() {
@@ -53,6 +53,8 @@ func TestSupportedDataplaneFeatures_Success(t *testing.T) {
 			require.True(t, feature.GetSupported())
 		case pbdataplane.DataplaneFeatures_DATAPLANE_FEATURES_FIPS:
 			require.Equal(t, version.IsFIPS(), feature.GetSupported())
+		case pbdataplane.DataplaneFeatures_DATAPLANE_FEATURES_UPSTREAM_METRICS:
+			require.True(t, feature.GetSupported())
 		default:
 			require.False(t, feature.GetSupported())
 		}
@@ -75,7 +77,7 @@ func TestSupportedDataplaneFeatures_ACLsDisabled(t *testing.T) {
 	client := testClient(t, server)
 	resp, err := client.GetSupportedDataplaneFeatures(ctx, &pbdataplane.GetSupportedDataplaneFeaturesRequest{})
 	require.NoError(t, err)
-	require.Equal(t, 4, len(resp.SupportedDataplaneFeatures))
+	require.Equal(t, 5, len(resp.SupportedDataplaneFeatures))
 }
 
 func TestSupportedDataplaneFeatures_InvalidACLToken(t *testing.T) {
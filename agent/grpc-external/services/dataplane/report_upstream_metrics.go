@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dataplane
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/meshmetrics"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbdataplane"
+)
+
+// ReportUpstreamMetrics records the requests made by a proxy to its upstreams
+// in the rollups of the leader, which serves them for the topology view.
+func (s *Server) ReportUpstreamMetrics(ctx context.Context, req *pbdataplane.ReportUpstreamMetricsRequest) (*pbdataplane.ReportUpstreamMetricsResponse, error) {
+	logger := s.Logger.Named("report-upstream-metrics").With("proxy_id", req.ProxyId, "request_id", external.TraceID())
+
+	logger.Trace("Started processing request")
+	defer logger.Trace("Finished processing request")
+
+	if s.EnableV2 {
+		return nil, status.Error(codes.Unimplemented, "ReportUpstreamMetrics is not supported with the v2 catalog")
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Embedding WriteRequest ensures the reports are forwarded to the leader,
+	// which holds the rollups.
+	var rpcInfo struct {
+		structs.WriteRequest
+		structs.DCSpecificRequest
+	}
+	rpcInfo.Token = options.Token
+
+	var rsp *pbdataplane.ReportUpstreamMetricsResponse
+	handled, err := s.ForwardRPC(&rpcInfo, func(conn *grpc.ClientConn) error {
+		logger.Trace("forwarding RPC")
+		ctx := external.ForwardMetadataContext(ctx)
+		var err error
+		rsp, err = pbdataplane.NewDataplaneServiceClient(conn).ReportUpstreamMetrics(ctx, req)
+		return err
+	})
+	if handled || err != nil {
+		return rsp, err
+	}
+
+	if req.ProxyId == "" {
+		return nil, status.Error(codes.InvalidArgument, "proxy_id is required")
+	}
+	interval := req.GetInterval().AsDuration()
+	if interval <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "interval must be positive")
+	}
+
+	var authzContext acl.AuthorizerContext
+	entMeta := acl.NewEnterpriseMetaWithPartition(req.GetPartition(), req.GetNamespace())
+	authz, err := s.ACLResolver.ResolveTokenAndDefaultMeta(options.Token, &entMeta, &authzContext)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	svc, _, err := s.lookupProxyService(logger, req.GetNodeId(), req.GetNodeName(), req.ProxyId, &entMeta, authz, &authzContext)
+	if err != nil {
+		return nil, err
+	}
+	// Only the proxy itself may report the requests it made.
+	if err := authz.ToAllowAuthorizer().ServiceWriteAllowed(svc.ServiceName, &authzContext); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	sourceName := svc.ServiceName
+	if svc.ServiceKind == structs.ServiceKindConnectProxy {
+		sourceName = svc.ServiceProxy.DestinationServiceName
+	}
+	source := structs.NewServiceName(sourceName, &svc.EnterpriseMeta)
+
+	now := time.Now()
+	for _, u := range req.Upstreams {
+		if u.Service == "" {
+			continue
+		}
+		upstreamMeta := acl.NewEnterpriseMetaWithPartition(u.Partition, u.Namespace)
+		upstreamMeta.MergeNoWildcard(&svc.EnterpriseMeta)
+
+		latencies := u.LatencySamplesMs
+		if len(latencies) > meshmetrics.MaxLatencySamples {
+			latencies = latencies[:meshmetrics.MaxLatencySamples]
+		}
+		sample := meshmetrics.Sample{
+			Interval:  interval,
+			Requests:  u.RequestCount,
+			Errors:    u.ErrorCount,
+			Latencies: make([]time.Duration, 0, len(latencies)),
+		}
+		for _, ms := range latencies {
+			sample.Latencies = append(sample.Latencies, time.Duration(ms*float64(time.Millisecond)))
+		}
+
+		s.MeshMetrics.Record(now, source, structs.PeeredServiceName{
+			ServiceName: structs.NewServiceName(u.Service, &upstreamMeta),
+			Peer:        u.Peer,
+		}, sample)
+	}
+
+	return &pbdataplane.ReportUpstreamMetricsResponse{}, nil
+}
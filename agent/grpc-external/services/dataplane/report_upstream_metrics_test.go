@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dataplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/consul/meshmetrics"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbdataplane"
+)
+
+func noopForwardRPC(structs.RPCInfo, func(*grpc.ClientConn) error) (bool, error) {
+	return false, nil
+}
+
+func TestReportUpstreamMetrics(t *testing.T) {
+	store := testutils.TestStateStore(t, nil)
+	require.NoError(t, store.EnsureRegistration(1, testRegisterRequestProxy(t)))
+
+	setup := func(t *testing.T, policy acl.AccessLevel) (pbdataplane.DataplaneServiceClient, *meshmetrics.Store, context.Context) {
+		aclResolver := &MockACLResolver{}
+		aclResolver.On("ResolveTokenAndDefaultMeta", testToken, mock.Anything, mock.Anything).
+			Return(testutils.ACLUseProvidedPolicy(t, &acl.Policy{
+				PolicyRules: acl.PolicyRules{
+					Services: []*acl.ServiceRule{{Name: proxyServiceID, Policy: policy.String()}},
+				},
+			}), nil)
+
+		ctx, err := external.ContextWithQueryOptions(context.Background(), structs.QueryOptions{Token: testToken})
+		require.NoError(t, err)
+
+		metrics := meshmetrics.NewStore(time.Minute)
+		server := NewServer(Config{
+			GetStore:    func() StateStore { return store },
+			Logger:      hclog.NewNullLogger(),
+			ACLResolver: aclResolver,
+			Datacenter:  serverDC,
+			ForwardRPC:  noopForwardRPC,
+			MeshMetrics: metrics,
+		})
+		return testClient(t, server), metrics, ctx
+	}
+
+	req := &pbdataplane.ReportUpstreamMetricsRequest{
+		NodeSpec: &pbdataplane.ReportUpstreamMetricsRequest_NodeName{NodeName: nodeName},
+		ProxyId:  proxyServiceID,
+		Interval: durationpb.New(10 * time.Second),
+		Upstreams: []*pbdataplane.UpstreamMetrics{
+			{
+				Service:          "db",
+				RequestCount:     100,
+				ErrorCount:       5,
+				LatencySamplesMs: []float64{10, 20, 30},
+			},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		client, metrics, ctx := setup(t, acl.AccessWrite)
+		_, err := client.ReportUpstreamMetrics(ctx, req)
+		require.NoError(t, err)
+
+		source := structs.NewServiceName(testServiceName, nil)
+		rollups := metrics.Upstreams(time.Now(), source)
+		require.Len(t, rollups, 1)
+		require.Equal(t, source, rollups[0].Source)
+		require.Equal(t, structs.NewServiceName("db", nil), rollups[0].Upstream)
+		require.InDelta(t, 0.05, rollups[0].ErrorRate, 0.0001)
+		require.Equal(t, 20*time.Millisecond, rollups[0].P50Latency)
+		require.Equal(t, 30*time.Millisecond, rollups[0].P99Latency)
+	})
+
+	t.Run("missing interval", func(t *testing.T) {
+		client, _, ctx := setup(t, acl.AccessWrite)
+		_, err := client.ReportUpstreamMetrics(ctx, &pbdataplane.ReportUpstreamMetricsRequest{
+			NodeSpec: req.NodeSpec,
+			ProxyId:  proxyServiceID,
+		})
+		require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	})
+
+	t.Run("unknown proxy", func(t *testing.T) {
+		client, _, ctx := setup(t, acl.AccessWrite)
+		_, err := client.ReportUpstreamMetrics(ctx, &pbdataplane.ReportUpstreamMetricsRequest{
+			NodeSpec: req.NodeSpec,
+			ProxyId:  "unknown",
+			Interval: req.Interval,
+		})
+		require.Equal(t, codes.NotFound.String(), status.Code(err).String())
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		client, metrics, ctx := setup(t, acl.AccessRead)
+		_, err := client.ReportUpstreamMetrics(ctx, req)
+		require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+		require.Empty(t, metrics.Upstreams(time.Now(), structs.NewServiceName(testServiceName, nil)))
+	})
+}
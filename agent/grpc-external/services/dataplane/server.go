@@ -4,6 +4,8 @@
 package dataplane
 
 import (
+	"time"
+
 	"github.com/hashicorp/consul/proto-public/pbresource"
 	"google.golang.org/grpc"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
 	"github.com/hashicorp/consul/agent/configentry"
+	"github.com/hashicorp/consul/agent/consul/meshmetrics"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/proto-public/pbdataplane"
 )
@@ -31,6 +34,12 @@ type Config struct {
 	// EnableV2 indicates whether a feature flag for v2 APIs is provided.
 	EnableV2          bool
 	ResourceAPIClient pbresource.ResourceServiceClient
+
+	// ForwardRPC forwards the upstream metrics reports to the leader.
+	ForwardRPC func(structs.RPCInfo, func(*grpc.ClientConn) error) (bool, error)
+
+	// MeshMetrics records the upstream metrics reported by the dataplanes.
+	MeshMetrics MeshMetrics
 }
 
 type StateStore interface {
@@ -38,6 +47,10 @@ type StateStore interface {
 	ReadResolvedServiceConfigEntries(memdb.WatchSet, string, *acl.EnterpriseMeta, []structs.ServiceID, structs.ProxyMode) (uint64, *configentry.ResolvedServiceConfigSet, error)
 }
 
+type MeshMetrics interface {
+	Record(time.Time, structs.ServiceName, structs.PeeredServiceName, meshmetrics.Sample)
+}
+
 //go:generate mockery --name ACLResolver --inpackage
 type ACLResolver interface {
 	ResolveTokenAndDefaultMeta(string, *acl.EnterpriseMeta, *acl.AuthorizerContext) (resolver.Result, error)
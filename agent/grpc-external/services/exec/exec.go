@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/exec"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+const (
+	// defaultTimeout is how long a command may run when the request does not
+	// set a timeout.
+	defaultTimeout = 5 * time.Minute
+
+	// outputChunkSize is the maximum size of the output sent in a single
+	// message.
+	outputChunkSize = 4 * 1024
+
+	// failedExitCode is the exit code reported when the command could not be
+	// run or was killed, as with the KV based remote exec.
+	failedExitCode = 255
+)
+
+// Exec runs a command on the agent's node and streams its output as it is
+// produced. The last message on the stream holds the command's exit code.
+func (s *Server) Exec(req *pbexec.ExecRequest, serverStream pbexec.ExecService_ExecServer) error {
+	if (req.Command == "") == (len(req.Args) == 0) {
+		return status.Error(codes.InvalidArgument, "exactly one of command and args is required")
+	}
+	timeout := defaultTimeout
+	if req.Timeout != nil {
+		if err := req.Timeout.CheckValid(); err != nil || req.Timeout.AsDuration() <= 0 {
+			return status.Error(codes.InvalidArgument, "timeout must be positive")
+		}
+		timeout = req.Timeout.AsDuration()
+	}
+
+	// The external gRPC server also serves the plaintext port, where the
+	// token would be sent in cleartext.
+	if s.ACLsEnabled && !isTLS(serverStream.Context()) {
+		return status.Error(codes.Unauthenticated, "commands can only be run over TLS when ACLs are enabled")
+	}
+
+	options, err := external.QueryOptionsFromContext(serverStream.Context())
+	if err != nil {
+		return err
+	}
+	if options.Token == "" && s.ACLsEnabled {
+		return status.Error(codes.Unauthenticated, "an ACL token is required to run commands")
+	}
+
+	authz, err := s.ACLResolver.ResolveTokenAndDefaultMeta(options.Token, nil, nil)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	var authzContext acl.AuthorizerContext
+	s.EnterpriseMeta.FillAuthzContext(&authzContext)
+	if err := authz.ToAllowAuthorizer().AgentWriteAllowed(s.NodeName, &authzContext); err != nil {
+		return external.RPCErrorToStatus(err)
+	}
+
+	command := req.Command
+	if command == "" {
+		command = strings.Join(req.Args, " ")
+	}
+	event := AuditEvent{
+		Stage:      "OperationStart",
+		RequestID:  external.TraceID(),
+		AccessorID: authz.AccessorID(),
+		Node:       s.NodeName,
+		Command:    command,
+	}
+	if p, ok := peer.FromContext(serverStream.Context()); ok {
+		event.Source = p.Addr.String()
+	}
+	logger := s.Logger.Named("exec").With(
+		"request_id", event.RequestID,
+		"accessor_id", event.AccessorID,
+		"command", command,
+		"source", event.Source,
+	)
+
+	start := time.Now()
+	logger.Info("running command")
+	s.Audit(event)
+
+	ctx, cancel := context.WithTimeout(serverStream.Context(), timeout)
+	defer cancel()
+
+	code, runErr := s.run(ctx, req, serverStream)
+	event.Stage = "OperationComplete"
+	event.ExitCode = code
+	event.Duration = time.Since(start)
+	if runErr != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			runErr = fmt.Errorf("timed out after %s", timeout)
+		}
+		event.Error = runErr.Error()
+		logger.Warn("command failed",
+			"exit_code", code,
+			"duration", event.Duration,
+			"error", runErr,
+		)
+	} else {
+		logger.Info("command exited",
+			"exit_code", code,
+			"duration", event.Duration,
+		)
+	}
+	s.Audit(event)
+
+	// The caller is gone if the stream was canceled, so there is no one to
+	// send the exit code to.
+	if err := serverStream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	exit := &pbexec.Exit{Code: int32(code)}
+	if runErr != nil {
+		exit.Error = runErr.Error()
+	}
+	return serverStream.Send(&pbexec.ExecResponse{
+		Node:   s.NodeName,
+		Result: &pbexec.ExecResponse_Exit{Exit: exit},
+	})
+}
+
+// run runs the command until it exits or ctx is done, sending its output to
+// the stream. It returns the exit code of the command, and an error if it
+// could not be run or was killed.
+func (s *Server) run(ctx context.Context, req *pbexec.ExecRequest, serverStream pbexec.ExecService_ExecServer) (int, error) {
+	var cmd *osexec.Cmd
+	var err error
+	if len(req.Args) > 0 {
+		cmd, err = exec.Subprocess(req.Args)
+	} else {
+		cmd, err = exec.Script(req.Command)
+	}
+	if err != nil {
+		return failedExitCode, err
+	}
+	exec.SetSysProcAttr(cmd)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return failedExitCode, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return failedExitCode, err
+	}
+	if err := cmd.Start(); err != nil {
+		return failedExitCode, err
+	}
+
+	// The stream must only be sent to by one goroutine at a time, so the
+	// output of both pipes is funneled through a single channel.
+	outputCh := make(chan *pbexec.Output)
+	doneCh := make(chan struct{}, 2)
+	go readOutput(ctx, stdout, pbexec.Stream_STREAM_STDOUT, outputCh, doneCh)
+	go readOutput(ctx, stderr, pbexec.Stream_STREAM_STDERR, outputCh, doneCh)
+
+	var sendErr error
+	killed := false
+	ctxDone := ctx.Done()
+	kill := func() {
+		if killed {
+			return
+		}
+		killed = true
+		ctxDone = nil
+		if err := exec.KillCommandSubtree(cmd); err != nil {
+			s.Logger.Warn("failed to kill command", "error", err)
+		}
+	}
+	for open := 2; open > 0; {
+		select {
+		case out := <-outputCh:
+			if sendErr != nil {
+				continue
+			}
+			sendErr = serverStream.Send(&pbexec.ExecResponse{
+				Node:   s.NodeName,
+				Result: &pbexec.ExecResponse_Output{Output: out},
+			})
+			if sendErr != nil {
+				kill()
+			}
+		case <-doneCh:
+			open--
+		case <-ctxDone:
+			kill()
+		}
+	}
+
+	waitErr := cmd.Wait()
+	switch {
+	case sendErr != nil:
+		return failedExitCode, sendErr
+	case killed:
+		return failedExitCode, ctx.Err()
+	case waitErr == nil:
+		return 0, nil
+	}
+
+	var exitErr *osexec.ExitError
+	if errors.As(waitErr, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode(), nil
+	}
+	return failedExitCode, waitErr
+}
+
+// readOutput reads the output of a pipe in chunks until it is closed, and
+// signals doneCh when it is.
+func readOutput(ctx context.Context, r io.Reader, stream pbexec.Stream, outputCh chan<- *pbexec.Output, doneCh chan<- struct{}) {
+	defer func() { doneCh <- struct{}{} }()
+
+	for {
+		buf := make([]byte, outputChunkSize)
+		n, err := r.Read(buf)
+		if n > 0 {
+			select {
+			case outputCh <- &pbexec.Output{Stream: stream, Data: buf[:n]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// isTLS returns whether the call was received over a TLS connection.
+func isTLS(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	_, ok = p.AuthInfo.(credentials.TLSInfo)
+	return ok
+}
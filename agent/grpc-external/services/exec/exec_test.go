@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package exec
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbexec"
+	"github.com/hashicorp/consul/tlsutil"
+)
+
+type fakeACLResolver func(token string) (resolver.Result, error)
+
+func (f fakeACLResolver) ResolveTokenAndDefaultMeta(token string, _ *acl.EnterpriseMeta, _ *acl.AuthorizerContext) (resolver.Result, error) {
+	return f(token)
+}
+
+func testClient(t *testing.T, aclResolver fakeACLResolver) pbexec.ExecServiceClient {
+	t.Helper()
+
+	return testClientWithAudit(t, aclResolver, func(AuditEvent) {})
+}
+
+func testClientWithAudit(t *testing.T, aclResolver fakeACLResolver, audit func(AuditEvent)) pbexec.ExecServiceClient {
+	t.Helper()
+
+	server := NewServer(Config{
+		ACLResolver:    aclResolver,
+		Logger:         hclog.NewNullLogger(),
+		NodeName:       "node1",
+		EnterpriseMeta: structs.NodeEnterpriseMetaInDefaultPartition(),
+		ACLsEnabled:    true,
+		Audit:          audit,
+	})
+
+	ca, caKey, err := tlsutil.GenerateCA(tlsutil.CAOpts{Name: "test-ca", Days: 1})
+	require.NoError(t, err)
+	signer, err := connect.ParseSigner(caKey)
+	require.NoError(t, err)
+	cert, key, err := tlsutil.GenerateCert(tlsutil.CertOpts{
+		Signer:      signer,
+		CA:          ca,
+		Name:        "node1",
+		Days:        1,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	require.NoError(t, err)
+	keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM([]byte(ca)))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+	})))
+	server.Register(grpcServer)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), lis.Addr().String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots})))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return pbexec.NewExecServiceClient(conn)
+}
+
+func TestServer_Exec_Plaintext(t *testing.T) {
+	var resolved bool
+	server := NewServer(Config{
+		ACLResolver: fakeACLResolver(func(tok string) (resolver.Result, error) {
+			resolved = true
+			return agentWrite(t, "node1")(tok)
+		}),
+		Logger:         hclog.NewNullLogger(),
+		NodeName:       "node1",
+		EnterpriseMeta: structs.NodeEnterpriseMetaInDefaultPartition(),
+		ACLsEnabled:    true,
+		Audit:          func(AuditEvent) {},
+	})
+	addr := testutils.RunTestServer(t, server)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+	client := pbexec.NewExecServiceClient(conn)
+
+	// The token is rejected without being resolved, and nothing is run.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "user-token")
+	res, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{Command: "echo hello"})
+	require.Equal(t, codes.Unauthenticated.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "over TLS")
+	require.Empty(t, res.stdout)
+	require.False(t, resolved)
+}
+
+// agentWrite returns a resolver allowing agent:write on the given node.
+func agentWrite(t *testing.T, node string) fakeACLResolver {
+	return func(string) (resolver.Result, error) {
+		return testutils.ACLUseProvidedPolicy(t, &acl.Policy{
+			PolicyRules: acl.PolicyRules{
+				Agents: []*acl.AgentRule{{Node: node, Policy: acl.PolicyWrite}},
+			},
+		}), nil
+	}
+}
+
+type execResult struct {
+	stdout, stderr string
+	exit           *pbexec.Exit
+}
+
+func execAndWait(t *testing.T, ctx context.Context, client pbexec.ExecServiceClient, req *pbexec.ExecRequest) (execResult, error) {
+	t.Helper()
+
+	stream, err := client.Exec(ctx, req)
+	require.NoError(t, err)
+
+	var res execResult
+	for {
+		rsp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		require.Equal(t, "node1", rsp.Node)
+		require.Nil(t, res.exit, "received a message after the exit code")
+
+		switch r := rsp.Result.(type) {
+		case *pbexec.ExecResponse_Output:
+			switch r.Output.Stream {
+			case pbexec.Stream_STREAM_STDOUT:
+				res.stdout += string(r.Output.Data)
+			case pbexec.Stream_STREAM_STDERR:
+				res.stderr += string(r.Output.Data)
+			}
+		case *pbexec.ExecResponse_Exit:
+			res.exit = r.Exit
+		}
+	}
+}
+
+func TestServer_Exec(t *testing.T) {
+	var token string
+	allowed := agentWrite(t, "node1")
+	client := testClient(t, func(tok string) (resolver.Result, error) {
+		token = tok
+		return allowed(tok)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "user-token")
+		res, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{
+			Command: "echo hello; echo oops 1>&2; exit 3",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "user-token", token)
+		require.Equal(t, "hello\n", res.stdout)
+		require.Equal(t, "oops\n", res.stderr)
+		require.Equal(t, int32(3), res.exit.Code)
+		require.Empty(t, res.exit.Error)
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "other-token")
+
+	t.Run("args", func(t *testing.T) {
+		res, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{
+			Args: []string{"echo", "$HOME"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "other-token", token)
+		require.Equal(t, "$HOME\n", res.stdout)
+		require.Equal(t, int32(0), res.exit.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		res, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{
+			Args: []string{"/does/not/exist"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(255), res.exit.Code)
+		require.NotEmpty(t, res.exit.Error)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		res, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{
+			Command: "echo started; sleep 30",
+			Timeout: durationpb.New(200 * time.Millisecond),
+		})
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), 10*time.Second)
+		require.Equal(t, "started\n", res.stdout)
+		require.Equal(t, int32(255), res.exit.Code)
+		require.Equal(t, "timed out after 200ms", res.exit.Error)
+	})
+}
+
+func TestServer_Exec_InvalidRequest(t *testing.T) {
+	client := testClient(t, agentWrite(t, "node1"))
+
+	for name, req := range map[string]*pbexec.ExecRequest{
+		"no command":       {},
+		"command and args": {Command: "true", Args: []string{"true"}},
+		"negative timeout": {Command: "true", Timeout: durationpb.New(-time.Second)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := execAndWait(t, context.Background(), client, req)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		})
+	}
+}
+
+func TestServer_Exec_Audit(t *testing.T) {
+	eventCh := make(chan AuditEvent, 10)
+	client := testClientWithAudit(t, agentWrite(t, "node1"), func(event AuditEvent) {
+		eventCh <- event
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "user-token")
+	_, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{Command: "exit 3"})
+	require.NoError(t, err)
+
+	require.Len(t, eventCh, 2)
+	start, complete := <-eventCh, <-eventCh
+	require.Equal(t, "OperationStart", start.Stage)
+	require.Equal(t, "node1", start.Node)
+	require.Equal(t, "exit 3", start.Command)
+	require.NotEmpty(t, start.Source)
+	require.Equal(t, "OperationComplete", complete.Stage)
+	require.Equal(t, start.RequestID, complete.RequestID)
+	require.Equal(t, start.AccessorID, complete.AccessorID)
+	require.Equal(t, 3, complete.ExitCode)
+	require.Empty(t, complete.Error)
+
+	// Requests that aren't authorized run nothing, so they aren't audited.
+	_, err = execAndWait(t, context.Background(), client, &pbexec.ExecRequest{Command: "true"})
+	require.Equal(t, codes.Unauthenticated.String(), status.Code(err).String())
+	require.Empty(t, eventCh)
+}
+
+func TestServer_Exec_ACLs(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "user-token")
+
+	t.Run("no token", func(t *testing.T) {
+		var resolved bool
+		client := testClient(t, func(tok string) (resolver.Result, error) {
+			resolved = true
+			return agentWrite(t, "node1")(tok)
+		})
+
+		_, err := execAndWait(t, context.Background(), client, &pbexec.ExecRequest{Command: "true"})
+		require.Equal(t, codes.Unauthenticated.String(), status.Code(err).String())
+		require.False(t, resolved)
+	})
+
+	t.Run("other node", func(t *testing.T) {
+		client := testClient(t, agentWrite(t, "node2"))
+
+		_, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{Command: "true"})
+		require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		client := testClient(t, func(string) (resolver.Result, error) {
+			return testutils.ACLUseProvidedPolicy(t, &acl.Policy{
+				PolicyRules: acl.PolicyRules{
+					Agents: []*acl.AgentRule{{Node: "node1", Policy: acl.PolicyRead}},
+				},
+			}), nil
+		})
+
+		_, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{Command: "true"})
+		require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		client := testClient(t, func(string) (resolver.Result, error) {
+			return resolver.Result{}, acl.ErrNotFound
+		})
+
+		_, err := execAndWait(t, ctx, client, &pbexec.ExecRequest{Command: "true"})
+		require.Equal(t, codes.Unauthenticated.String(), status.Code(err).String())
+	})
+}
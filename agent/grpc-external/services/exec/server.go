@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/acl"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+// Server implements pbexec.ExecServiceServer by running the requested
+// commands on the agent's node. It replaces the remote exec protocol built on
+// top of the KV store and user events, which could not enforce ACLs per node
+// and left the output of every command in the KV store.
+type Server struct {
+	Config
+}
+
+type Config struct {
+	ACLResolver external.ACLResolver
+	Logger      hclog.Logger
+
+	// NodeName is the name of the agent's node, which callers must have
+	// agent:write on.
+	NodeName string
+
+	// EnterpriseMeta is the agent's enterprise meta, used to authorize calls.
+	EnterpriseMeta *acl.EnterpriseMeta
+
+	// ACLsEnabled is whether ACLs are enabled, in which case requests must
+	// carry a token and be sent over TLS, so that the token isn't sent in
+	// cleartext. The agent's own tokens are never used to run commands.
+	ACLsEnabled bool
+
+	// Audit is called when a command starts and when it completes, so that it
+	// can be recorded in the audit log.
+	Audit func(AuditEvent)
+}
+
+// AuditEvent records a command run through the service.
+type AuditEvent struct {
+	// Stage is either "OperationStart" or "OperationComplete", as with the
+	// audit events of the RPC calls.
+	Stage      string
+	RequestID  string
+	AccessorID string
+	Node       string
+	Source     string
+	Command    string
+
+	// ExitCode, Duration and Error are only set when the command completed.
+	ExitCode int
+	Duration time.Duration
+	Error    string
+}
+
+func NewServer(cfg Config) *Server {
+	external.RequireNotNil(cfg.ACLResolver, "ACLResolver")
+	external.RequireNotNil(cfg.Logger, "Logger")
+	external.RequireNotNil(cfg.Audit, "Audit")
+
+	return &Server{cfg}
+}
+
+var _ pbexec.ExecServiceServer = (*Server)(nil)
+
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pbexec.RegisterExecServiceServer(registrar, s)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/armon/go-metrics"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// ListServices returns the names and tags of the services in the catalog.
+func (s *Server) ListServices(ctx context.Context, req *pbhealth.ListServicesRequest) (*pbhealth.ListServicesResponse, error) {
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.Filter = req.Filter
+
+	defer metrics.MeasureSince([]string{"grpc", "health", "list_services"}, time.Now())
+
+	args := structs.DCSpecificRequest{
+		Datacenter:      req.Datacenter,
+		NodeMetaFilters: req.NodeMeta,
+		PeerName:        req.PeerName,
+		EnterpriseMeta:  entMetaFrom(req.Partition, req.Namespace),
+		QueryOptions:    options,
+	}
+	var out structs.IndexedServices
+	if err := s.Backend.RPC(ctx, "Catalog.ListServices", &args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	rsp := &pbhealth.ListServicesResponse{
+		Services: make([]*pbhealth.ServiceSummary, 0, len(out.Services)),
+		Index:    out.Index,
+	}
+	for name, tags := range out.Services {
+		rsp.Services = append(rsp.Services, &pbhealth.ServiceSummary{Name: name, Tags: tags})
+	}
+	sort.Slice(rsp.Services, func(i, j int) bool {
+		return rsp.Services[i].Name < rsp.Services[j].Name
+	})
+	return rsp, nil
+}
+
+// ListNodes returns the nodes in the catalog.
+func (s *Server) ListNodes(ctx context.Context, req *pbhealth.ListNodesRequest) (*pbhealth.ListNodesResponse, error) {
+	if err := validateReadMask(req.ReadMask, &pbhealth.Node{}); err != nil {
+		return nil, err
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.Filter = req.Filter
+
+	defer metrics.MeasureSince([]string{"grpc", "health", "list_nodes"}, time.Now())
+
+	args := structs.DCSpecificRequest{
+		Datacenter:      req.Datacenter,
+		NodeMetaFilters: req.NodeMeta,
+		PeerName:        req.PeerName,
+		EnterpriseMeta:  entMetaFrom(req.Partition, ""),
+		QueryOptions:    options,
+	}
+	var out structs.IndexedNodes
+	if err := s.Backend.RPC(ctx, "Catalog.ListNodes", &args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	nodes := make([]*pbhealth.Node, 0, len(out.Nodes))
+	for _, n := range out.Nodes {
+		nodes = append(nodes, nodeFromStructs(n))
+	}
+	applyReadMask(req.ReadMask, nodes)
+
+	return &pbhealth.ListNodesResponse{Nodes: nodes, Index: out.Index}, nil
+}
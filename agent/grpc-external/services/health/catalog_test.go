@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+func TestServer_ListServices(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Catalog.ListServices", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.IndexedServices)
+			out.Index = 5
+			out.Services = structs.Services{
+				"web": {"v1", "v2"},
+				"api": nil,
+			}
+			return nil
+		})
+	client := testClient(t, backend)
+
+	rsp, err := client.ListServices(context.Background(), &pbhealth.ListServicesRequest{
+		Filter:   `ServiceMeta.env == "prod"`,
+		NodeMeta: map[string]string{"rack": "a"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), rsp.Index)
+	require.Len(t, rsp.Services, 2)
+	require.Equal(t, "api", rsp.Services[0].Name)
+	require.Equal(t, "web", rsp.Services[1].Name)
+	require.Equal(t, []string{"v1", "v2"}, rsp.Services[1].Tags)
+
+	backend.AssertCalled(t, "RPC", mock.Anything, "Catalog.ListServices", mock.MatchedBy(func(req *structs.DCSpecificRequest) bool {
+		return req.Filter == `ServiceMeta.env == "prod"` && req.NodeMetaFilters["rack"] == "a"
+	}), mock.Anything)
+}
+
+func TestServer_ListNodes(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Catalog.ListNodes", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.IndexedNodes)
+			out.Index = 3
+			out.Nodes = structs.Nodes{
+				{ID: "abc", Node: "node1", Address: "10.0.0.1", Meta: map[string]string{"rack": "a"}},
+			}
+			return nil
+		})
+	client := testClient(t, backend)
+
+	rsp, err := client.ListNodes(context.Background(), &pbhealth.ListNodesRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "address"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, rsp.Nodes, 1)
+	require.Equal(t, "node1", rsp.Nodes[0].Name)
+	require.Equal(t, "10.0.0.1", rsp.Nodes[0].Address)
+	require.Empty(t, rsp.Nodes[0].Id)
+	require.Empty(t, rsp.Nodes[0].Meta)
+
+	_, err = client.ListNodes(context.Background(), &pbhealth.ListNodesRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"bogus"}},
+	})
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+}
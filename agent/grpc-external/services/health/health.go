@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// watchQueryTime bounds each blocking query issued on behalf of a watch
+// stream, so cancelled streams are noticed in a timely manner.
+const watchQueryTime = time.Minute
+
+// NodeChecks returns the health checks registered on a node.
+func (s *Server) NodeChecks(ctx context.Context, req *pbhealth.NodeChecksRequest) (*pbhealth.NodeChecksResponse, error) {
+	if req.Node == "" {
+		return nil, status.Error(codes.InvalidArgument, "node is required")
+	}
+	if err := validateReadMask(req.ReadMask, &pbhealth.Check{}); err != nil {
+		return nil, err
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.Filter = req.Filter
+
+	defer metrics.MeasureSince([]string{"grpc", "health", "node_checks"}, time.Now())
+
+	args := structs.NodeSpecificRequest{
+		Datacenter:     req.Datacenter,
+		Node:           req.Node,
+		PeerName:       req.PeerName,
+		EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+		QueryOptions:   options,
+	}
+	var out structs.IndexedHealthChecks
+	if err := s.Backend.RPC(ctx, "Health.NodeChecks", &args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	checks := checksFromStructs(out.HealthChecks)
+	applyReadMask(req.ReadMask, checks)
+
+	return &pbhealth.NodeChecksResponse{Checks: checks, Index: out.Index}, nil
+}
+
+// ServiceHealth returns the instances of a service along with their node and
+// health checks.
+func (s *Server) ServiceHealth(ctx context.Context, req *pbhealth.ServiceHealthRequest) (*pbhealth.ServiceHealthResponse, error) {
+	args, err := serviceHealthArgs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "health", "service_health"}, time.Now())
+
+	var out structs.IndexedCheckServiceNodes
+	if err := s.Backend.RPC(ctx, "Health.ServiceNodes", args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+	return serviceHealthResponse(req, out), nil
+}
+
+// WatchServiceHealth provides a stream on which you can receive the instances
+// of a service. The current instances are sent immediately at the start of the
+// stream, and the full set is sent again whenever it changes.
+func (s *Server) WatchServiceHealth(req *pbhealth.ServiceHealthRequest, serverStream pbhealth.HealthService_WatchServiceHealthServer) error {
+	ctx := serverStream.Context()
+
+	args, err := serviceHealthArgs(ctx, req)
+	if err != nil {
+		return err
+	}
+	if args.MaxQueryTime == 0 || args.MaxQueryTime > watchQueryTime {
+		args.MaxQueryTime = watchQueryTime
+	}
+
+	logger := s.Logger.Named("watch-service-health").With("request_id", external.TraceID())
+	logger.Trace("starting stream")
+	defer logger.Trace("stream closed")
+
+	var idx uint64
+	for {
+		args.MinQueryIndex = idx
+
+		var out structs.IndexedCheckServiceNodes
+		err := s.Backend.RPC(ctx, "Health.ServiceNodes", args, &out)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			logger.Error("failed to query service health", "error", err)
+			return external.RPCErrorToStatus(err)
+		}
+
+		// The blocking query timed out without any changes.
+		if out.Index == idx {
+			continue
+		}
+		idx = out.Index
+
+		if err := serverStream.Send(serviceHealthResponse(req, out)); err != nil {
+			logger.Error("failed to send response", "error", err)
+			return err
+		}
+	}
+}
+
+func serviceHealthArgs(ctx context.Context, req *pbhealth.ServiceHealthRequest) (*structs.ServiceSpecificRequest, error) {
+	if req.Service == "" {
+		return nil, status.Error(codes.InvalidArgument, "service is required")
+	}
+	if err := validateReadMask(req.ReadMask, &pbhealth.ServiceEntry{}); err != nil {
+		return nil, err
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.Filter = req.Filter
+
+	args := &structs.ServiceSpecificRequest{
+		Datacenter:      req.Datacenter,
+		PeerName:        req.PeerName,
+		NodeMetaFilters: req.NodeMeta,
+		ServiceName:     req.Service,
+		ServiceTags:     req.Tags,
+		TagFilter:       len(req.Tags) > 0,
+		Connect:         req.Connect,
+		EnterpriseMeta:  entMetaFrom(req.Partition, req.Namespace),
+		QueryOptions:    options,
+	}
+	if req.PassingOnly {
+		args.HealthFilterType = structs.HealthFilterIncludeOnlyPassing
+	}
+	return args, nil
+}
+
+func serviceHealthResponse(req *pbhealth.ServiceHealthRequest, out structs.IndexedCheckServiceNodes) *pbhealth.ServiceHealthResponse {
+	return &pbhealth.ServiceHealthResponse{
+		Entries: serviceEntriesFromStructs(req.ReadMask, out.Nodes),
+		Index:   out.Index,
+	}
+}
+
+// serviceEntriesFromStructs converts the given service instances, trimmed
+// down to the fields selected by the mask. The mask must have already been
+// validated.
+func serviceEntriesFromStructs(mask *fieldmaskpb.FieldMask, nodes structs.CheckServiceNodes) []*pbhealth.ServiceEntry {
+	entries := make([]*pbhealth.ServiceEntry, 0, len(nodes))
+	for _, csn := range nodes {
+		entry := &pbhealth.ServiceEntry{Checks: checksFromStructs(csn.Checks)}
+		if csn.Node != nil {
+			entry.Node = nodeFromStructs(csn.Node)
+		}
+		if csn.Service != nil {
+			entry.Service = serviceFromStructs(csn.Service)
+		}
+		entries = append(entries, entry)
+	}
+	applyReadMask(mask, entries)
+
+	return entries
+}
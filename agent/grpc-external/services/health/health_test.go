@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+func testCheckServiceNodes(index uint64) structs.IndexedCheckServiceNodes {
+	return structs.IndexedCheckServiceNodes{
+		Nodes: structs.CheckServiceNodes{
+			{
+				Node:    &structs.Node{Node: "node1", Address: "10.0.0.1"},
+				Service: &structs.NodeService{ID: "web-1", Service: "web", Port: 8080, Tags: []string{"v1"}},
+				Checks: structs.HealthChecks{
+					{Node: "node1", CheckID: "serfHealth", Status: api.HealthPassing},
+				},
+			},
+		},
+		QueryMeta: structs.QueryMeta{Index: index},
+	}
+}
+
+func TestServer_ServiceHealth(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Health.ServiceNodes", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			*reply.(*structs.IndexedCheckServiceNodes) = testCheckServiceNodes(9)
+			return nil
+		})
+	client := testClient(t, backend)
+
+	rsp, err := client.ServiceHealth(context.Background(), &pbhealth.ServiceHealthRequest{
+		Service:     "web",
+		Tags:        []string{"v1"},
+		PassingOnly: true,
+		Filter:      `Service.Port == 8080`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), rsp.Index)
+	require.Len(t, rsp.Entries, 1)
+	require.Equal(t, "node1", rsp.Entries[0].Node.Name)
+	require.Equal(t, int32(8080), rsp.Entries[0].Service.Port)
+	require.Equal(t, pbhealth.Health_HEALTH_PASSING, rsp.Entries[0].Checks[0].Status)
+
+	backend.AssertCalled(t, "RPC", mock.Anything, "Health.ServiceNodes", mock.MatchedBy(func(req *structs.ServiceSpecificRequest) bool {
+		return req.ServiceName == "web" &&
+			req.TagFilter &&
+			req.HealthFilterType == structs.HealthFilterIncludeOnlyPassing &&
+			req.Filter == `Service.Port == 8080`
+	}), mock.Anything)
+
+	t.Run("read mask", func(t *testing.T) {
+		rsp, err := client.ServiceHealth(context.Background(), &pbhealth.ServiceHealthRequest{
+			Service:  "web",
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"node.address", "service.port"}},
+		})
+		require.NoError(t, err)
+		entry := rsp.Entries[0]
+		require.Equal(t, "10.0.0.1", entry.Node.Address)
+		require.Empty(t, entry.Node.Name)
+		require.Equal(t, int32(8080), entry.Service.Port)
+		require.Empty(t, entry.Service.Tags)
+		require.Empty(t, entry.Checks)
+	})
+
+	t.Run("service required", func(t *testing.T) {
+		_, err := client.ServiceHealth(context.Background(), &pbhealth.ServiceHealthRequest{})
+		require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	})
+}
+
+func TestServer_ServiceHealth_PermissionDenied(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Health.ServiceNodes", mock.Anything, mock.Anything).Return(acl.ErrPermissionDenied)
+	client := testClient(t, backend)
+
+	_, err := client.ServiceHealth(context.Background(), &pbhealth.ServiceHealthRequest{Service: "web"})
+	require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+}
+
+func TestServer_NodeChecks(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Health.NodeChecks", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.IndexedHealthChecks)
+			out.HealthChecks = structs.HealthChecks{
+				{Node: "node1", CheckID: "serfHealth", Status: api.HealthCritical, Output: "boom"},
+			}
+			return nil
+		})
+	client := testClient(t, backend)
+
+	rsp, err := client.NodeChecks(context.Background(), &pbhealth.NodeChecksRequest{
+		Node:     "node1",
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "status"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, rsp.Checks, 1)
+	require.Equal(t, "serfHealth", rsp.Checks[0].Id)
+	require.Equal(t, pbhealth.Health_HEALTH_CRITICAL, rsp.Checks[0].Status)
+	require.Empty(t, rsp.Checks[0].Output)
+
+	_, err = client.NodeChecks(context.Background(), &pbhealth.NodeChecksRequest{})
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+}
+
+func TestServer_WatchServiceHealth(t *testing.T) {
+	updates := make(chan structs.IndexedCheckServiceNodes, 3)
+	updates <- testCheckServiceNodes(10)
+	updates <- testCheckServiceNodes(10)
+	updates <- testCheckServiceNodes(11)
+
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Health.ServiceNodes", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			select {
+			case update := <-updates:
+				*reply.(*structs.IndexedCheckServiceNodes) = update
+				return nil
+			default:
+				return context.Canceled
+			}
+		})
+	client := testClient(t, backend)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := client.WatchServiceHealth(ctx, &pbhealth.ServiceHealthRequest{Service: "web"})
+	require.NoError(t, err)
+
+	rsp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), rsp.Index)
+
+	rsp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), rsp.Index)
+	require.Len(t, rsp.Entries, 1)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hashicorp/consul/acl"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/internal/protoutil"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// Server implements pbhealth.HealthServiceServer by translating requests into
+// the same Catalog and Health RPCs used by the HTTP API. Filter expressions are
+// evaluated by those RPCs, so only matching results leave the server.
+type Server struct {
+	Config
+}
+
+type Config struct {
+	Backend Backend
+	Logger  hclog.Logger
+}
+
+// Backend makes the Catalog and Health RPCs the queries are translated into,
+// including the blocking queries behind the watch endpoints, through the
+// server's in-memory RPC handler.
+//
+//go:generate mockery --name Backend --inpackage
+type Backend interface {
+	RPC(ctx context.Context, method string, args interface{}, reply interface{}) error
+}
+
+func NewServer(cfg Config) *Server {
+	external.RequireNotNil(cfg.Backend, "Backend")
+	external.RequireNotNil(cfg.Logger, "Logger")
+
+	return &Server{cfg}
+}
+
+var _ pbhealth.HealthServiceServer = (*Server)(nil)
+
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pbhealth.RegisterHealthServiceServer(registrar, s)
+}
+
+// validateReadMask returns an InvalidArgument error if the mask does not
+// apply to the given message type.
+func validateReadMask(mask *fieldmaskpb.FieldMask, m proto.Message) error {
+	if err := protoutil.ValidateFieldMask(mask, m); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// applyReadMask trims each of the given messages down to the fields selected
+// by the mask. The mask must have already been validated.
+func applyReadMask[T proto.Message](mask *fieldmaskpb.FieldMask, msgs []T) {
+	for _, m := range msgs {
+		// The mask was validated up front so this cannot fail.
+		_ = protoutil.ApplyFieldMask(mask, m)
+	}
+}
+
+func entMetaFrom(partition, namespace string) acl.EnterpriseMeta {
+	return acl.NewEnterpriseMetaWithPartition(partition, namespace)
+}
+
+func nodeFromStructs(n *structs.Node) *pbhealth.Node {
+	return &pbhealth.Node{
+		Id:              string(n.ID),
+		Name:            n.Node,
+		Address:         n.Address,
+		Datacenter:      n.Datacenter,
+		TaggedAddresses: n.TaggedAddresses,
+		Meta:            n.Meta,
+		Partition:       n.Partition,
+		PeerName:        n.PeerName,
+	}
+}
+
+func serviceFromStructs(svc *structs.NodeService) *pbhealth.Service {
+	return &pbhealth.Service{
+		Id:        svc.ID,
+		Name:      svc.Service,
+		Kind:      string(svc.Kind),
+		Tags:      svc.Tags,
+		Address:   svc.Address,
+		Port:      int32(svc.Port),
+		Meta:      svc.Meta,
+		Namespace: svc.NamespaceOrEmpty(),
+		Partition: svc.PartitionOrEmpty(),
+		PeerName:  svc.PeerName,
+	}
+}
+
+func healthFromStatus(s string) pbhealth.Health {
+	switch s {
+	case api.HealthPassing:
+		return pbhealth.Health_HEALTH_PASSING
+	case api.HealthWarning:
+		return pbhealth.Health_HEALTH_WARNING
+	case api.HealthCritical:
+		return pbhealth.Health_HEALTH_CRITICAL
+	case api.HealthMaint:
+		return pbhealth.Health_HEALTH_MAINTENANCE
+	}
+	return pbhealth.Health_HEALTH_UNSPECIFIED
+}
+
+func checkFromStructs(c *structs.HealthCheck) *pbhealth.Check {
+	return &pbhealth.Check{
+		Node:        c.Node,
+		Id:          string(c.CheckID),
+		Name:        c.Name,
+		Status:      healthFromStatus(c.Status),
+		Notes:       c.Notes,
+		Output:      c.Output,
+		ServiceId:   c.ServiceID,
+		ServiceName: c.ServiceName,
+		Type:        c.Type,
+		Namespace:   c.NamespaceOrEmpty(),
+		Partition:   c.PartitionOrEmpty(),
+	}
+}
+
+func checksFromStructs(checks structs.HealthChecks) []*pbhealth.Check {
+	out := make([]*pbhealth.Check, 0, len(checks))
+	for _, c := range checks {
+		out = append(out, checkFromStructs(c))
+	}
+	return out
+}
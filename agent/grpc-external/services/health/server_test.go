@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+func testClient(t *testing.T, backend *MockBackend) pbhealth.HealthServiceClient {
+	t.Helper()
+
+	server := NewServer(Config{
+		Backend: backend,
+		Logger:  hclog.NewNullLogger(),
+	})
+	addr := testutils.RunTestServer(t, server)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return pbhealth.NewHealthServiceClient(conn)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/cacheshim"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// SubscriptionServer implements pbhealth.HealthSubscriptionServiceServer on
+// top of the agent's streaming cache. Subscriptions to the same service share
+// a single materialized view, so client libraries can follow their upstreams
+// without each of them holding blocking queries against the servers.
+type SubscriptionServer struct {
+	SubscriptionConfig
+}
+
+type SubscriptionConfig struct {
+	Notifier Notifier
+	Logger   hclog.Logger
+
+	// Datacenter is the agent's datacenter, which is used when the request
+	// does not name one.
+	Datacenter string
+
+	// TokenFunc returns the token used when the request does not carry one.
+	TokenFunc func() string
+}
+
+// Notifier is used to watch the instances of a service. It is satisfied by
+// the agent's health client, which serves the updates from its streaming
+// cache.
+type Notifier interface {
+	Notify(ctx context.Context, req structs.ServiceSpecificRequest, correlationID string, cb cacheshim.Callback) error
+}
+
+func NewSubscriptionServer(cfg SubscriptionConfig) *SubscriptionServer {
+	external.RequireNotNil(cfg.Notifier, "Notifier")
+	external.RequireNotNil(cfg.Logger, "Logger")
+
+	return &SubscriptionServer{cfg}
+}
+
+var _ pbhealth.HealthSubscriptionServiceServer = (*SubscriptionServer)(nil)
+
+func (s *SubscriptionServer) Register(registrar grpc.ServiceRegistrar) {
+	pbhealth.RegisterHealthSubscriptionServiceServer(registrar, s)
+}
+
+// SubscribeServices provides a stream on which you can receive the instances
+// of several services. The current instances of each service are sent at the
+// start of the stream, and the full set of instances of a service is sent
+// again whenever it changes.
+func (s *SubscriptionServer) SubscribeServices(req *pbhealth.SubscribeServicesRequest, serverStream pbhealth.HealthSubscriptionService_SubscribeServicesServer) error {
+	if len(req.Services) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one service is required")
+	}
+	for _, name := range req.Services {
+		if name == "" {
+			return status.Error(codes.InvalidArgument, "service names must not be empty")
+		}
+	}
+	if err := validateReadMask(req.ReadMask, &pbhealth.ServiceEntry{}); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(serverStream.Context())
+	defer cancel()
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if options.Token == "" && s.TokenFunc != nil {
+		options.Token = s.TokenFunc()
+	}
+	options.Filter = req.Filter
+
+	datacenter := req.Datacenter
+	if datacenter == "" {
+		datacenter = s.Datacenter
+	}
+
+	logger := s.Logger.Named("subscribe-services").With("request_id", external.TraceID())
+	logger.Trace("starting stream")
+	defer logger.Trace("stream closed")
+
+	updates := make(chan cacheshim.UpdateEvent)
+	notify := func(ctx context.Context, event cacheshim.UpdateEvent) {
+		select {
+		case updates <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	seen := make(map[string]struct{}, len(req.Services))
+	for _, name := range req.Services {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		args := structs.ServiceSpecificRequest{
+			Datacenter:     datacenter,
+			PeerName:       req.PeerName,
+			ServiceName:    name,
+			Connect:        req.Connect,
+			EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+			QueryOptions:   options,
+		}
+		if req.PassingOnly {
+			args.HealthFilterType = structs.HealthFilterIncludeOnlyPassing
+		}
+
+		// The service name is used as the correlation ID to tell which
+		// service an update is about.
+		if err := s.Notifier.Notify(ctx, args, name, notify); err != nil {
+			logger.Error("failed to subscribe to service", "service", name, "error", err)
+			return external.RPCErrorToStatus(err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-updates:
+			if event.Err != nil {
+				logger.Error("failed to watch service health", "service", event.CorrelationID, "error", event.Err)
+				return external.RPCErrorToStatus(event.Err)
+			}
+			out, ok := event.Result.(*structs.IndexedCheckServiceNodes)
+			if !ok {
+				logger.Error("unexpected result type", "type", hclog.Fmt("%T", event.Result))
+				return status.Error(codes.Internal, "unexpected result type")
+			}
+
+			err := serverStream.Send(&pbhealth.SubscribeServicesResponse{
+				Service: event.CorrelationID,
+				Entries: serviceEntriesFromStructs(req.ReadMask, out.Nodes),
+				Index:   out.Index,
+			})
+			if err != nil {
+				logger.Error("failed to send response", "error", err)
+				return err
+			}
+		}
+	}
+}
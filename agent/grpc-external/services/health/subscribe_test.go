@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/cacheshim"
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// fakeNotifier records the subscriptions it receives and lets the test send
+// updates to them.
+type fakeNotifier struct {
+	mu    sync.Mutex
+	reqs  map[string]structs.ServiceSpecificRequest
+	cbs   map[string]cacheshim.Callback
+	ctxs  map[string]context.Context
+	ready chan struct{}
+	want  int
+}
+
+func newFakeNotifier(want int) *fakeNotifier {
+	return &fakeNotifier{
+		reqs:  make(map[string]structs.ServiceSpecificRequest),
+		cbs:   make(map[string]cacheshim.Callback),
+		ctxs:  make(map[string]context.Context),
+		ready: make(chan struct{}),
+		want:  want,
+	}
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, req structs.ServiceSpecificRequest, correlationID string, cb cacheshim.Callback) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.reqs[correlationID] = req
+	n.cbs[correlationID] = cb
+	n.ctxs[correlationID] = ctx
+	if len(n.cbs) == n.want {
+		close(n.ready)
+	}
+	return nil
+}
+
+func (n *fakeNotifier) send(t *testing.T, correlationID string, result interface{}, err error) {
+	t.Helper()
+
+	<-n.ready
+	n.mu.Lock()
+	cb, ctx := n.cbs[correlationID], n.ctxs[correlationID]
+	n.mu.Unlock()
+
+	go cb(ctx, cacheshim.UpdateEvent{CorrelationID: correlationID, Result: result, Err: err})
+}
+
+func testSubscriptionClient(t *testing.T, notifier Notifier) pbhealth.HealthSubscriptionServiceClient {
+	t.Helper()
+
+	server := NewSubscriptionServer(SubscriptionConfig{
+		Notifier:   notifier,
+		Logger:     hclog.NewNullLogger(),
+		Datacenter: "dc1",
+		TokenFunc:  func() string { return "agent-token" },
+	})
+	addr := testutils.RunTestServer(t, server)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return pbhealth.NewHealthSubscriptionServiceClient(conn)
+}
+
+func TestSubscriptionServer_SubscribeServices(t *testing.T) {
+	notifier := newFakeNotifier(2)
+	client := testSubscriptionClient(t, notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := client.SubscribeServices(ctx, &pbhealth.SubscribeServicesRequest{
+		Services:    []string{"web", "api", "web"},
+		PassingOnly: true,
+		Filter:      `Service.Port == 8080`,
+		ReadMask:    &fieldmaskpb.FieldMask{Paths: []string{"service.address", "service.port"}},
+	})
+	require.NoError(t, err)
+
+	web := testCheckServiceNodes(10)
+	notifier.send(t, "web", &web, nil)
+
+	rsp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "web", rsp.Service)
+	require.Equal(t, uint64(10), rsp.Index)
+	require.Len(t, rsp.Entries, 1)
+	require.Equal(t, int32(8080), rsp.Entries[0].Service.Port)
+	require.Empty(t, rsp.Entries[0].Service.Name)
+	require.Nil(t, rsp.Entries[0].Node)
+
+	api := structs.IndexedCheckServiceNodes{QueryMeta: structs.QueryMeta{Index: 11}}
+	notifier.send(t, "api", &api, nil)
+
+	rsp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "api", rsp.Service)
+	require.Equal(t, uint64(11), rsp.Index)
+	require.Empty(t, rsp.Entries)
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	require.Len(t, notifier.reqs, 2)
+	for name, req := range notifier.reqs {
+		require.Equal(t, name, req.ServiceName)
+		require.Equal(t, "dc1", req.Datacenter)
+		require.Equal(t, "agent-token", req.Token)
+		require.Equal(t, `Service.Port == 8080`, req.Filter)
+		require.Equal(t, structs.HealthFilterIncludeOnlyPassing, req.HealthFilterType)
+	}
+}
+
+func TestSubscriptionServer_SubscribeServices_Token(t *testing.T) {
+	notifier := newFakeNotifier(1)
+	client := testSubscriptionClient(t, notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-consul-token", "user-token")
+
+	stream, err := client.SubscribeServices(ctx, &pbhealth.SubscribeServicesRequest{
+		Services:   []string{"web"},
+		Datacenter: "dc2",
+	})
+	require.NoError(t, err)
+
+	web := testCheckServiceNodes(10)
+	notifier.send(t, "web", &web, nil)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	require.Equal(t, "user-token", notifier.reqs["web"].Token)
+	require.Equal(t, "dc2", notifier.reqs["web"].Datacenter)
+}
+
+func TestSubscriptionServer_SubscribeServices_Errors(t *testing.T) {
+	t.Run("services required", func(t *testing.T) {
+		client := testSubscriptionClient(t, newFakeNotifier(1))
+
+		for _, services := range [][]string{nil, {"web", ""}} {
+			stream, err := client.SubscribeServices(context.Background(), &pbhealth.SubscribeServicesRequest{Services: services})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		}
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		notifier := newFakeNotifier(1)
+		client := testSubscriptionClient(t, notifier)
+
+		stream, err := client.SubscribeServices(context.Background(), &pbhealth.SubscribeServicesRequest{Services: []string{"web"}})
+		require.NoError(t, err)
+
+		notifier.send(t, "web", nil, acl.ErrPermissionDenied)
+		_, err = stream.Recv()
+		require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		notifier := newFakeNotifier(1)
+		client := testSubscriptionClient(t, notifier)
+
+		stream, err := client.SubscribeServices(context.Background(), &pbhealth.SubscribeServicesRequest{Services: []string{"web"}})
+		require.NoError(t, err)
+
+		notifier.send(t, "web", nil, errors.New("boom"))
+		_, err = stream.Recv()
+		require.Error(t, err)
+		require.NotEqual(t, codes.OK, status.Code(err))
+	})
+}
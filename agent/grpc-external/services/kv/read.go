@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// Get reads a single entry from the KV store.
+func (s *Server) Get(ctx context.Context, req *pbkv.GetRequest) (*pbkv.GetResponse, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "kv", "get"}, time.Now())
+
+	args := structs.KeyRequest{
+		Datacenter:     req.Datacenter,
+		Key:            req.Key,
+		EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+		QueryOptions:   options,
+	}
+	var out structs.IndexedDirEntries
+	if err := s.Backend.RPC(ctx, "KVS.Get", &args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	// Like the HTTP API, entries the caller is not permitted to read are
+	// indistinguishable from entries that do not exist.
+	if len(out.Entries) == 0 {
+		return nil, status.Errorf(codes.NotFound, "key %q not found", req.Key)
+	}
+
+	return &pbkv.GetResponse{
+		Entry: entryFromStructs(out.Entries[0]),
+		Index: out.Index,
+	}, nil
+}
+
+// List reads the entries, or only the keys, under a prefix.
+func (s *Server) List(ctx context.Context, req *pbkv.ListRequest) (*pbkv.ListResponse, error) {
+	if req.Separator != "" && !req.KeysOnly {
+		return nil, status.Error(codes.InvalidArgument, "separator can only be used with keys_only")
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "kv", "list"}, time.Now())
+
+	entMeta := entMetaFrom(req.Partition, req.Namespace)
+
+	if req.KeysOnly {
+		args := structs.KeyListRequest{
+			Datacenter:     req.Datacenter,
+			Prefix:         req.Prefix,
+			Seperator:      req.Separator,
+			EnterpriseMeta: entMeta,
+			QueryOptions:   options,
+		}
+		var out structs.IndexedKeyList
+		if err := s.Backend.RPC(ctx, "KVS.ListKeys", &args, &out); err != nil {
+			return nil, external.RPCErrorToStatus(err)
+		}
+		keys := out.Keys
+		if keys == nil {
+			keys = []string{}
+		}
+		return &pbkv.ListResponse{Keys: keys, Index: out.Index}, nil
+	}
+
+	args := structs.KeyRequest{
+		Datacenter:     req.Datacenter,
+		Key:            req.Prefix,
+		EnterpriseMeta: entMeta,
+		QueryOptions:   options,
+	}
+	var out structs.IndexedDirEntries
+	if err := s.Backend.RPC(ctx, "KVS.List", &args, &out); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	return &pbkv.ListResponse{
+		Entries: entriesFromStructs(out.Entries),
+		Index:   out.Index,
+	}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+func TestServer_Get(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "KVS.Get", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, args, reply interface{}) error {
+			req := args.(*structs.KeyRequest)
+			out := reply.(*structs.IndexedDirEntries)
+			out.Index = 42
+			if req.Key == "foo" {
+				out.Entries = structs.DirEntries{{Key: "foo", Value: []byte("bar"), Flags: 3}}
+			}
+			return nil
+		})
+	client := testClient(t, testServer(t, backend))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-consul-token", "my-token")
+	rsp, err := client.Get(ctx, &pbkv.GetRequest{Key: "foo", Datacenter: "dc2"})
+	require.NoError(t, err)
+	require.Equal(t, "foo", rsp.Entry.Key)
+	require.Equal(t, []byte("bar"), rsp.Entry.Value)
+	require.Equal(t, uint64(3), rsp.Entry.Flags)
+	require.Equal(t, uint64(42), rsp.Index)
+
+	backend.AssertCalled(t, "RPC", mock.Anything, "KVS.Get", mock.MatchedBy(func(req *structs.KeyRequest) bool {
+		return req.Token == "my-token" && req.Datacenter == "dc2"
+	}), mock.Anything)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Get(context.Background(), &pbkv.GetRequest{Key: "missing"})
+		require.Equal(t, codes.NotFound.String(), status.Code(err).String())
+	})
+
+	t.Run("key required", func(t *testing.T) {
+		_, err := client.Get(context.Background(), &pbkv.GetRequest{})
+		require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	})
+}
+
+func TestServer_Get_ACLErrors(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"permission denied": {acl.ErrPermissionDenied, codes.PermissionDenied},
+		"token not found":   {acl.ErrNotFound, codes.Unauthenticated},
+		"rate limited":      {structs.ErrRPCRateExceeded, codes.ResourceExhausted},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			backend := NewMockBackend(t)
+			backend.On("RPC", mock.Anything, "KVS.Get", mock.Anything, mock.Anything).Return(tc.err)
+			client := testClient(t, testServer(t, backend))
+
+			_, err := client.Get(context.Background(), &pbkv.GetRequest{Key: "foo"})
+			require.Error(t, err)
+			require.Equal(t, tc.code.String(), status.Code(err).String())
+		})
+	}
+}
+
+func TestServer_List(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "KVS.List", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.IndexedDirEntries)
+			out.Index = 7
+			out.Entries = structs.DirEntries{{Key: "foo/a"}, {Key: "foo/b"}}
+			return nil
+		})
+	backend.On("RPC", mock.Anything, "KVS.ListKeys", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.IndexedKeyList)
+			out.Index = 8
+			out.Keys = []string{"foo/a", "foo/b/"}
+			return nil
+		})
+	client := testClient(t, testServer(t, backend))
+
+	rsp, err := client.List(context.Background(), &pbkv.ListRequest{Prefix: "foo/"})
+	require.NoError(t, err)
+	require.Len(t, rsp.Entries, 2)
+	require.Empty(t, rsp.Keys)
+	require.Equal(t, uint64(7), rsp.Index)
+
+	rsp, err = client.List(context.Background(), &pbkv.ListRequest{Prefix: "foo/", KeysOnly: true, Separator: "/"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo/a", "foo/b/"}, rsp.Keys)
+	require.Equal(t, uint64(8), rsp.Index)
+
+	backend.AssertCalled(t, "RPC", mock.Anything, "KVS.ListKeys", mock.MatchedBy(func(req *structs.KeyListRequest) bool {
+		return req.Seperator == "/"
+	}), mock.Anything)
+
+	_, err = client.List(context.Background(), &pbkv.ListRequest{Prefix: "foo/", Separator: "/"})
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+}
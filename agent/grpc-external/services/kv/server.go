@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/acl"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// Server implements pbkv.KVServiceServer by translating requests into the
+// same KVS and Txn RPCs used by the HTTP API. This gives both interfaces
+// identical ACL enforcement, datacenter forwarding and consistency semantics.
+type Server struct {
+	Config
+}
+
+type Config struct {
+	Backend Backend
+	Logger  hclog.Logger
+
+	// MaxValueSize is the largest value, in bytes, accepted for a single key.
+	MaxValueSize uint64
+
+	// MaxTxnLen is the largest cumulative size, in bytes, of the values in a
+	// transaction. MaxValueSize is used instead if it is larger.
+	MaxTxnLen uint64
+}
+
+// Backend makes the KVS and Txn RPCs the requests are translated into, through
+// the server's in-memory RPC handler so that they are forwarded to the leader
+// or another datacenter when needed.
+//
+//go:generate mockery --name Backend --inpackage
+type Backend interface {
+	RPC(ctx context.Context, method string, args interface{}, reply interface{}) error
+}
+
+func NewServer(cfg Config) *Server {
+	external.RequireNotNil(cfg.Backend, "Backend")
+	external.RequireNotNil(cfg.Logger, "Logger")
+
+	return &Server{cfg}
+}
+
+var _ pbkv.KVServiceServer = (*Server)(nil)
+
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pbkv.RegisterKVServiceServer(registrar, s)
+}
+
+func entMetaFrom(partition, namespace string) acl.EnterpriseMeta {
+	return acl.NewEnterpriseMetaWithPartition(partition, namespace)
+}
+
+func entryFromStructs(e *structs.DirEntry) *pbkv.Entry {
+	return &pbkv.Entry{
+		Key:         e.Key,
+		Value:       e.Value,
+		Flags:       e.Flags,
+		Session:     e.Session,
+		LockIndex:   e.LockIndex,
+		CreateIndex: e.CreateIndex,
+		ModifyIndex: e.ModifyIndex,
+		Namespace:   e.NamespaceOrEmpty(),
+		Partition:   e.PartitionOrEmpty(),
+	}
+}
+
+func entriesFromStructs(entries structs.DirEntries) []*pbkv.Entry {
+	out := make([]*pbkv.Entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, entryFromStructs(e))
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+func testServer(t *testing.T, backend *MockBackend) *Server {
+	t.Helper()
+
+	return NewServer(Config{
+		Backend:      backend,
+		Logger:       hclog.NewNullLogger(),
+		MaxValueSize: 16,
+		MaxTxnLen:    32,
+	})
+}
+
+func testClient(t *testing.T, server *Server) pbkv.KVServiceClient {
+	t.Helper()
+
+	addr := testutils.RunTestServer(t, server)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return pbkv.NewKVServiceClient(conn)
+}
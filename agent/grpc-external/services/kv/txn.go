@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// maxTxnOps is the upper limit on the number of operations in a transaction,
+// matching the limit enforced by the HTTP API.
+const maxTxnOps = 128
+
+var txnVerbs = map[pbkv.TxnVerb]api.KVOp{
+	pbkv.TxnVerb_TXN_VERB_SET:              api.KVSet,
+	pbkv.TxnVerb_TXN_VERB_DELETE:           api.KVDelete,
+	pbkv.TxnVerb_TXN_VERB_DELETE_CAS:       api.KVDeleteCAS,
+	pbkv.TxnVerb_TXN_VERB_DELETE_TREE:      api.KVDeleteTree,
+	pbkv.TxnVerb_TXN_VERB_CAS:              api.KVCAS,
+	pbkv.TxnVerb_TXN_VERB_LOCK:             api.KVLock,
+	pbkv.TxnVerb_TXN_VERB_UNLOCK:           api.KVUnlock,
+	pbkv.TxnVerb_TXN_VERB_GET:              api.KVGet,
+	pbkv.TxnVerb_TXN_VERB_GET_OR_EMPTY:     api.KVGetOrEmpty,
+	pbkv.TxnVerb_TXN_VERB_GET_TREE:         api.KVGetTree,
+	pbkv.TxnVerb_TXN_VERB_CHECK_SESSION:    api.KVCheckSession,
+	pbkv.TxnVerb_TXN_VERB_CHECK_INDEX:      api.KVCheckIndex,
+	pbkv.TxnVerb_TXN_VERB_CHECK_NOT_EXISTS: api.KVCheckNotExists,
+}
+
+func isWrite(op api.KVOp) bool {
+	switch op {
+	case api.KVSet, api.KVDelete, api.KVDeleteCAS, api.KVDeleteTree, api.KVCAS, api.KVLock, api.KVUnlock:
+		return true
+	}
+	return false
+}
+
+// Txn atomically applies a list of KV operations. Like the HTTP API,
+// transactions that only contain reads are served by the read-only fast path
+// and honor the consistency mode requested in the call's metadata.
+func (s *Server) Txn(ctx context.Context, req *pbkv.TxnRequest) (*pbkv.TxnResponse, error) {
+	ops, writes, err := s.convertTxnOps(req.Ops)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "kv", "txn"}, time.Now())
+
+	var out structs.TxnResponse
+	if writes == 0 {
+		args := structs.TxnReadRequest{
+			Datacenter:   req.Datacenter,
+			Ops:          ops,
+			QueryOptions: options,
+		}
+		var readOut structs.TxnReadResponse
+		if err := s.Backend.RPC(ctx, "Txn.Read", &args, &readOut); err != nil {
+			return nil, external.RPCErrorToStatus(err)
+		}
+		out = readOut.TxnResponse
+	} else {
+		args := structs.TxnRequest{
+			Datacenter:   req.Datacenter,
+			Ops:          ops,
+			WriteRequest: structs.WriteRequest{Token: options.Token},
+		}
+		if err := s.Backend.RPC(ctx, "Txn.Apply", &args, &out); err != nil {
+			return nil, external.RPCErrorToStatus(err)
+		}
+	}
+
+	rsp := &pbkv.TxnResponse{
+		Results: make([]*pbkv.Entry, 0, len(out.Results)),
+		Errors:  make([]*pbkv.TxnError, 0, len(out.Errors)),
+	}
+	for _, result := range out.Results {
+		if result.KV != nil {
+			rsp.Results = append(rsp.Results, entryFromStructs(result.KV))
+		}
+	}
+	for _, txnErr := range out.Errors {
+		rsp.Errors = append(rsp.Errors, &pbkv.TxnError{
+			OpIndex: int32(txnErr.OpIndex),
+			What:    txnErr.What,
+		})
+	}
+	return rsp, nil
+}
+
+// convertTxnOps converts the operations into the RPC format, enforcing the same
+// size limits as the HTTP API. It also returns the number of write operations.
+func (s *Server) convertTxnOps(in []*pbkv.TxnOp) (structs.TxnOps, int, error) {
+	if len(in) == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "at least one operation is required")
+	}
+	if len(in) > maxTxnOps {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "transaction contains too many operations (%d > %d)", len(in), maxTxnOps)
+	}
+
+	maxTxnLen := s.MaxTxnLen
+	if maxTxnLen < s.MaxValueSize {
+		maxTxnLen = s.MaxValueSize
+	}
+
+	var (
+		ops    structs.TxnOps
+		writes int
+		total  uint64
+	)
+	for idx, op := range in {
+		verb, ok := txnVerbs[op.Verb]
+		if !ok {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "op %d: unknown verb %q", idx, op.Verb)
+		}
+		if err := s.checkValueSize(op.Key, op.Value); err != nil {
+			return nil, 0, err
+		}
+		total += uint64(len(op.Value))
+		if maxTxnLen > 0 && total > maxTxnLen {
+			return nil, 0, status.Errorf(codes.InvalidArgument, "transaction too large, max size: %d bytes", maxTxnLen)
+		}
+		if isWrite(verb) {
+			writes++
+		}
+
+		ops = append(ops, &structs.TxnOp{
+			KV: &structs.TxnKVOp{
+				Verb: verb,
+				DirEnt: structs.DirEntry{
+					Key:            op.Key,
+					Value:          op.Value,
+					Flags:          op.Flags,
+					Session:        op.Session,
+					EnterpriseMeta: entMetaFrom(op.Partition, op.Namespace),
+					RaftIndex:      structs.RaftIndex{ModifyIndex: op.Index},
+				},
+			},
+		})
+	}
+	return ops, writes, nil
+}
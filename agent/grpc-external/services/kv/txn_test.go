@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+func TestServer_Txn(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Txn.Read", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.TxnReadResponse)
+			out.Results = structs.TxnResults{{KV: &structs.DirEntry{Key: "foo", Value: []byte("bar")}}}
+			return nil
+		})
+	backend.On("RPC", mock.Anything, "Txn.Apply", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, _, reply interface{}) error {
+			out := reply.(*structs.TxnResponse)
+			out.Errors = structs.TxnErrors{{OpIndex: 1, What: "failed"}}
+			return nil
+		})
+	client := testClient(t, testServer(t, backend))
+
+	t.Run("read-only", func(t *testing.T) {
+		rsp, err := client.Txn(context.Background(), &pbkv.TxnRequest{
+			Ops: []*pbkv.TxnOp{{Verb: pbkv.TxnVerb_TXN_VERB_GET, Key: "foo"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, rsp.Results, 1)
+		require.Equal(t, []byte("bar"), rsp.Results[0].Value)
+
+		backend.AssertCalled(t, "RPC", mock.Anything, "Txn.Read", mock.Anything, mock.Anything)
+		backend.AssertNotCalled(t, "RPC", mock.Anything, "Txn.Apply", mock.Anything, mock.Anything)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		rsp, err := client.Txn(context.Background(), &pbkv.TxnRequest{
+			Ops: []*pbkv.TxnOp{
+				{Verb: pbkv.TxnVerb_TXN_VERB_SET, Key: "foo", Value: []byte("bar")},
+				{Verb: pbkv.TxnVerb_TXN_VERB_CHECK_INDEX, Key: "baz", Index: 4},
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, rsp.Results)
+		require.Len(t, rsp.Errors, 1)
+		require.Equal(t, int32(1), rsp.Errors[0].OpIndex)
+
+		backend.AssertCalled(t, "RPC", mock.Anything, "Txn.Apply", mock.MatchedBy(func(req *structs.TxnRequest) bool {
+			ops := req.Ops
+			return len(ops) == 2 &&
+				ops[0].KV.Verb == api.KVSet &&
+				ops[1].KV.Verb == api.KVCheckIndex &&
+				ops[1].KV.DirEnt.ModifyIndex == 4
+		}), mock.Anything)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, req := range map[string]*pbkv.TxnRequest{
+			"no ops":       {},
+			"unknown verb": {Ops: []*pbkv.TxnOp{{Key: "foo"}}},
+			"too large": {Ops: []*pbkv.TxnOp{
+				{Verb: pbkv.TxnVerb_TXN_VERB_SET, Key: "a", Value: make([]byte, 16)},
+				{Verb: pbkv.TxnVerb_TXN_VERB_SET, Key: "b", Value: make([]byte, 16)},
+				{Verb: pbkv.TxnVerb_TXN_VERB_SET, Key: "c", Value: make([]byte, 16)},
+			}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := client.Txn(context.Background(), req)
+				require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+			})
+		}
+	})
+}
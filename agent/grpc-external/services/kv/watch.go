@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// watchQueryTime bounds each blocking query issued on behalf of a Watch
+// stream, so cancelled streams are noticed in a timely manner.
+const watchQueryTime = time.Minute
+
+// Watch provides a stream on which you can receive the entries matching a key
+// or prefix. The current entries are sent immediately at the start of the
+// stream, and the full set is sent again whenever it changes.
+//
+// Under the hood this performs the same blocking queries as the HTTP API, so
+// entries the caller is not permitted to read are filtered from the results
+// and ACL changes take effect on the next update.
+func (s *Server) Watch(req *pbkv.WatchRequest, serverStream pbkv.KVService_WatchServer) error {
+	if req.Key == "" && !req.Recurse {
+		return status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	ctx := serverStream.Context()
+
+	logger := s.Logger.Named("watch").With("request_id", external.TraceID())
+	logger.Trace("starting stream")
+	defer logger.Trace("stream closed")
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if options.MaxQueryTime == 0 || options.MaxQueryTime > watchQueryTime {
+		options.MaxQueryTime = watchQueryTime
+	}
+
+	method := "KVS.Get"
+	if req.Recurse {
+		method = "KVS.List"
+	}
+	args := structs.KeyRequest{
+		Datacenter:     req.Datacenter,
+		Key:            req.Key,
+		EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+		QueryOptions:   options,
+	}
+
+	var idx uint64
+	for {
+		args.MinQueryIndex = idx
+
+		var out structs.IndexedDirEntries
+		err := s.Backend.RPC(ctx, method, &args, &out)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			logger.Error("failed to query KV store", "error", err)
+			return external.RPCErrorToStatus(err)
+		}
+
+		// The blocking query timed out without any changes.
+		if out.Index == idx {
+			continue
+		}
+
+		// If the index went backwards (e.g. following a snapshot restore) we
+		// still send the new results, and continue blocking from the new index.
+		idx = out.Index
+
+		rsp := &pbkv.WatchResponse{
+			Entries: entriesFromStructs(out.Entries),
+			Index:   out.Index,
+		}
+		if err := serverStream.Send(rsp); err != nil {
+			logger.Error("failed to send response", "error", err)
+			return err
+		}
+	}
+}
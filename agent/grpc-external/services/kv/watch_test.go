@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+func TestServer_Watch(t *testing.T) {
+	updates := make(chan structs.IndexedDirEntries, 3)
+	updates <- structs.IndexedDirEntries{
+		Entries:   structs.DirEntries{{Key: "foo/a"}},
+		QueryMeta: structs.QueryMeta{Index: 10},
+	}
+	// A blocking query which times out without changes should not be sent.
+	updates <- structs.IndexedDirEntries{
+		Entries:   structs.DirEntries{{Key: "foo/a"}},
+		QueryMeta: structs.QueryMeta{Index: 10},
+	}
+	updates <- structs.IndexedDirEntries{
+		Entries:   structs.DirEntries{{Key: "foo/a"}, {Key: "foo/b"}},
+		QueryMeta: structs.QueryMeta{Index: 12},
+	}
+
+	var (
+		mu         sync.Mutex
+		minIndexes []uint64
+	)
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "KVS.List", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ string, args, reply interface{}) error {
+			mu.Lock()
+			minIndexes = append(minIndexes, args.(*structs.KeyRequest).MinQueryIndex)
+			mu.Unlock()
+
+			select {
+			case update := <-updates:
+				*reply.(*structs.IndexedDirEntries) = update
+				return nil
+			default:
+				return context.Canceled
+			}
+		})
+	client := testClient(t, testServer(t, backend))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stream, err := client.Watch(ctx, &pbkv.WatchRequest{Key: "foo/", Recurse: true})
+	require.NoError(t, err)
+
+	rsp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), rsp.Index)
+	require.Len(t, rsp.Entries, 1)
+
+	rsp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), rsp.Index)
+	require.Len(t, rsp.Entries, 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{0, 10, 10}, minIndexes[:3])
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// Put writes an entry to the KV store.
+func (s *Server) Put(ctx context.Context, req *pbkv.PutRequest) (*pbkv.PutResponse, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	if err := s.checkValueSize(req.Key, req.Value); err != nil {
+		return nil, err
+	}
+
+	op := api.KVSet
+	switch {
+	case req.Acquire != "" && req.Release != "":
+		return nil, status.Error(codes.InvalidArgument, "acquire and release cannot be combined")
+	case (req.Acquire != "" || req.Release != "") && req.Cas:
+		return nil, status.Error(codes.InvalidArgument, "cas cannot be combined with acquire or release")
+	case req.Acquire != "":
+		op = api.KVLock
+	case req.Release != "":
+		op = api.KVUnlock
+	case req.Cas:
+		op = api.KVCAS
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "kv", "put"}, time.Now())
+
+	args := structs.KVSRequest{
+		Datacenter: req.Datacenter,
+		Op:         op,
+		DirEnt: structs.DirEntry{
+			Key:            req.Key,
+			Value:          req.Value,
+			Flags:          req.Flags,
+			Session:        req.Acquire + req.Release,
+			EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+			RaftIndex:      structs.RaftIndex{ModifyIndex: req.CasIndex},
+		},
+		WriteRequest: structs.WriteRequest{Token: options.Token},
+	}
+	var ok bool
+	if err := s.Backend.RPC(ctx, "KVS.Apply", &args, &ok); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	// Only check-and-set and lock operations can fail without an error.
+	if op == api.KVSet {
+		ok = true
+	}
+	return &pbkv.PutResponse{Success: ok}, nil
+}
+
+// Delete removes an entry, or every entry under a prefix, from the KV store.
+func (s *Server) Delete(ctx context.Context, req *pbkv.DeleteRequest) (*pbkv.DeleteResponse, error) {
+	op := api.KVDelete
+	switch {
+	case req.Recurse && req.Cas:
+		return nil, status.Error(codes.InvalidArgument, "cas cannot be combined with recurse")
+	case req.Recurse:
+		op = api.KVDeleteTree
+	case req.Cas:
+		op = api.KVDeleteCAS
+	}
+	if req.Key == "" && !req.Recurse {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "kv", "delete"}, time.Now())
+
+	args := structs.KVSRequest{
+		Datacenter: req.Datacenter,
+		Op:         op,
+		DirEnt: structs.DirEntry{
+			Key:            req.Key,
+			EnterpriseMeta: entMetaFrom(req.Partition, req.Namespace),
+			RaftIndex:      structs.RaftIndex{ModifyIndex: req.CasIndex},
+		},
+		WriteRequest: structs.WriteRequest{Token: options.Token},
+	}
+	var ok bool
+	if err := s.Backend.RPC(ctx, "KVS.Apply", &args, &ok); err != nil {
+		return nil, external.RPCErrorToStatus(err)
+	}
+
+	// Only check-and-set deletes can fail without an error.
+	if op != api.KVDeleteCAS {
+		ok = true
+	}
+	return &pbkv.DeleteResponse{Success: ok}, nil
+}
+
+func (s *Server) checkValueSize(key string, value []byte) error {
+	if s.MaxValueSize > 0 && uint64(len(value)) > s.MaxValueSize {
+		return status.Errorf(codes.InvalidArgument, "value for key %q is too large (%d > %d bytes)", key, len(value), s.MaxValueSize)
+	}
+	return nil
+}
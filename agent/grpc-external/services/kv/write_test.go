@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+func TestServer_Put(t *testing.T) {
+	testCases := map[string]struct {
+		req     *pbkv.PutRequest
+		reply   bool
+		op      api.KVOp
+		success bool
+	}{
+		"set": {
+			req:     &pbkv.PutRequest{Key: "foo", Value: []byte("bar")},
+			op:      api.KVSet,
+			success: true,
+		},
+		"cas failed": {
+			req:     &pbkv.PutRequest{Key: "foo", Cas: true, CasIndex: 5},
+			op:      api.KVCAS,
+			success: false,
+		},
+		"acquire": {
+			req:     &pbkv.PutRequest{Key: "foo", Acquire: "session-id"},
+			reply:   true,
+			op:      api.KVLock,
+			success: true,
+		},
+		"release": {
+			req:     &pbkv.PutRequest{Key: "foo", Release: "session-id"},
+			reply:   true,
+			op:      api.KVUnlock,
+			success: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			backend := NewMockBackend(t)
+			backend.On("RPC", mock.Anything, "KVS.Apply", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, _ string, _, reply interface{}) error {
+					*reply.(*bool) = tc.reply
+					return nil
+				})
+			client := testClient(t, testServer(t, backend))
+
+			rsp, err := client.Put(context.Background(), tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.success, rsp.Success)
+
+			backend.AssertCalled(t, "RPC", mock.Anything, "KVS.Apply", mock.MatchedBy(func(apply *structs.KVSRequest) bool {
+				return apply.Op == tc.op &&
+					apply.DirEnt.ModifyIndex == tc.req.CasIndex &&
+					apply.DirEnt.Session == tc.req.Acquire+tc.req.Release
+			}), mock.Anything)
+		})
+	}
+}
+
+func TestServer_Put_InvalidArgument(t *testing.T) {
+	client := testClient(t, testServer(t, NewMockBackend(t)))
+
+	for name, req := range map[string]*pbkv.PutRequest{
+		"no key":              {Value: []byte("bar")},
+		"value too large":     {Key: "foo", Value: make([]byte, 17)},
+		"acquire and release": {Key: "foo", Acquire: "a", Release: "b"},
+		"acquire and cas":     {Key: "foo", Acquire: "a", Cas: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Put(context.Background(), req)
+			require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		})
+	}
+}
+
+func TestServer_Delete(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "KVS.Apply", mock.Anything, mock.Anything).Return(nil)
+	client := testClient(t, testServer(t, backend))
+
+	rsp, err := client.Delete(context.Background(), &pbkv.DeleteRequest{Key: "foo/", Recurse: true})
+	require.NoError(t, err)
+	require.True(t, rsp.Success)
+	backend.AssertCalled(t, "RPC", mock.Anything, "KVS.Apply", mock.MatchedBy(func(apply *structs.KVSRequest) bool {
+		return apply.Op == api.KVDeleteTree
+	}), mock.Anything)
+
+	rsp, err = client.Delete(context.Background(), &pbkv.DeleteRequest{Key: "foo", Cas: true, CasIndex: 3})
+	require.NoError(t, err)
+	require.False(t, rsp.Success)
+	backend.AssertCalled(t, "RPC", mock.Anything, "KVS.Apply", mock.MatchedBy(func(apply *structs.KVSRequest) bool {
+		return apply.Op == api.KVDeleteCAS
+	}), mock.Anything)
+
+	_, err = client.Delete(context.Background(), &pbkv.DeleteRequest{Key: "foo", Cas: true, Recurse: true})
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+}
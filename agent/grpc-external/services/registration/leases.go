@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// lease tracks a registration made on a stream. It is held by the stream
+// which made it until the registration is taken over by another stream.
+type lease struct {
+	node     string
+	entMeta  acl.EnterpriseMeta
+	token    string
+	ttl      time.Duration
+	services []structs.ServiceID
+
+	timer *time.Timer
+}
+
+func (l *lease) key() string {
+	return leaseKey(l.node, &l.entMeta)
+}
+
+// leaseKey returns the key of a node's lease. Node names are case
+// insensitive in the catalog.
+func leaseKey(node string, entMeta *acl.EnterpriseMeta) string {
+	return strings.ToLower(entMeta.PartitionOrDefault() + "/" + node)
+}
+
+// leases holds the leases of the registrations made on this server, and
+// calls onExpire for the leases which aren't renewed in time.
+type leases struct {
+	mu       sync.Mutex
+	byKey    map[string]*lease
+	onExpire func(*lease)
+}
+
+func newLeases(onExpire func(*lease)) *leases {
+	return &leases{
+		byKey:    make(map[string]*lease),
+		onExpire: onExpire,
+	}
+}
+
+// acquire starts the given lease, replacing and returning the current lease
+// of the same node if there is one.
+func (ls *leases) acquire(l *lease) *lease {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	prev := ls.byKey[l.key()]
+	if prev != nil {
+		prev.timer.Stop()
+	}
+	ls.byKey[l.key()] = l
+	l.timer = time.AfterFunc(l.ttl, func() { ls.expired(l) })
+	return prev
+}
+
+// renew resets the TTL of the given lease. It returns false if the lease has
+// expired or has been taken over.
+func (ls *leases) renew(l *lease) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.byKey[l.key()] != l {
+		return false
+	}
+	l.timer.Reset(l.ttl)
+	return true
+}
+
+// retry restarts an expired lease, unless the node has been registered
+// again in the meantime.
+func (ls *leases) retry(l *lease) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if _, ok := ls.byKey[l.key()]; ok {
+		return
+	}
+	ls.byKey[l.key()] = l
+	l.timer = time.AfterFunc(l.ttl, func() { ls.expired(l) })
+}
+
+func (ls *leases) expired(l *lease) {
+	ls.mu.Lock()
+	if ls.byKey[l.key()] != l {
+		// The lease was renewed by a new registration in the meantime.
+		ls.mu.Unlock()
+		return
+	}
+	delete(ls.byKey, l.key())
+	ls.mu.Unlock()
+
+	ls.onExpire(l)
+}
+
+// stopAll stops the timers of all the leases, without expiring them.
+func (ls *leases) stopAll() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for key, l := range ls.byKey {
+		l.timer.Stop()
+		delete(ls.byKey, key)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/acl"
+)
+
+func TestLeases(t *testing.T) {
+	expired := make(chan *lease, 10)
+	ls := newLeases(func(l *lease) { expired <- l })
+	t.Cleanup(ls.stopAll)
+
+	newLease := func(node string, ttl time.Duration) *lease {
+		return &lease{node: node, entMeta: *acl.DefaultEnterpriseMeta(), ttl: ttl}
+	}
+
+	// Leases which aren't renewed expire.
+	l1 := newLease("node-1", 20*time.Millisecond)
+	require.Nil(t, ls.acquire(l1))
+	select {
+	case l := <-expired:
+		require.Same(t, l1, l)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the lease to expire")
+	}
+	require.False(t, ls.renew(l1))
+
+	// Renewals push the expiry back.
+	l2 := newLease("node-2", 200*time.Millisecond)
+	require.Nil(t, ls.acquire(l2))
+	for i := 0; i < 5; i++ {
+		time.Sleep(100 * time.Millisecond)
+		require.True(t, ls.renew(l2))
+	}
+	require.Empty(t, expired)
+
+	// Acquiring the lease of the same node replaces the previous lease,
+	// which is never expired. Node names are case insensitive.
+	l3 := newLease("NODE-2", 20*time.Millisecond)
+	require.Same(t, l2, ls.acquire(l3))
+	require.False(t, ls.renew(l2))
+	select {
+	case l := <-expired:
+		require.Same(t, l3, l)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the lease to expire")
+	}
+	require.Empty(t, expired)
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package registration
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockBackend is an autogenerated mock type for the Backend type
+type MockBackend struct {
+	mock.Mock
+}
+
+// RPC provides a mock function with given fields: ctx, method, args, reply
+func (_m *MockBackend) RPC(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	ret := _m.Called(ctx, method, args, reply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RPC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}) error); ok {
+		r0 = rf(ctx, method, args, reply)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockBackend creates a new instance of MockBackend. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBackend(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBackend {
+	mock := &MockBackend{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
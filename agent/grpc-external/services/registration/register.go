@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/consul/acl"
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbregistration"
+	"github.com/hashicorp/consul/types"
+)
+
+// expireTimeout bounds the RPCs made to deregister an expired registration.
+const expireTimeout = 30 * time.Second
+
+// RegisterWorkload registers a node and its services, and keeps them
+// registered for as long as the stream renews their lease.
+func (s *Server) RegisterWorkload(stream pbregistration.RegistrationService_RegisterWorkloadServer) error {
+	ctx := stream.Context()
+
+	options, err := external.QueryOptionsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// current is the lease held by this stream.
+	var current *lease
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch r := req.Request.(type) {
+		case *pbregistration.RegisterWorkloadRequest_Registration:
+			l, err := s.register(ctx, options.Token, r.Registration, current)
+			if err != nil {
+				return err
+			}
+			current = l
+		case *pbregistration.RegisterWorkloadRequest_Renew:
+			if current == nil {
+				return status.Error(codes.FailedPrecondition, "the first message must be a registration")
+			}
+			if !s.leases.renew(current) {
+				return status.Error(codes.FailedPrecondition, "lease has expired or was taken over by another stream")
+			}
+		default:
+			return status.Error(codes.InvalidArgument, "request is required")
+		}
+
+		err = stream.Send(&pbregistration.RegisterWorkloadResponse{
+			LeaseTtl: durationpb.New(current.ttl),
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// register registers the node and its services, and starts their lease. The
+// services which were registered on the node by the previous lease of the
+// node and are no longer part of the registration are deregistered.
+func (s *Server) register(ctx context.Context, token string, reg *pbregistration.Registration, current *lease) (*lease, error) {
+	args, l, err := s.registerArgs(token, reg)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.key() != l.key() {
+		return nil, status.Error(codes.InvalidArgument, "the node of a registration cannot be changed")
+	}
+
+	defer metrics.MeasureSince([]string{"grpc", "registration", "register"}, time.Now())
+
+	for _, arg := range args {
+		var out struct{}
+		if err := s.Backend.RPC(ctx, "Catalog.Register", arg, &out); err != nil {
+			return nil, external.RPCErrorToStatus(err)
+		}
+	}
+
+	prev := s.leases.acquire(l)
+	if prev == nil {
+		return l, nil
+	}
+
+	registered := make(map[structs.ServiceID]struct{}, len(l.services))
+	for _, sid := range l.services {
+		registered[sid] = struct{}{}
+	}
+	for _, sid := range prev.services {
+		if _, ok := registered[sid]; ok {
+			continue
+		}
+		args := structs.DeregisterRequest{
+			Node:           l.node,
+			ServiceID:      sid.ID,
+			EnterpriseMeta: sid.EnterpriseMeta,
+			WriteRequest:   structs.WriteRequest{Token: token},
+		}
+		var out struct{}
+		if err := s.Backend.RPC(ctx, "Catalog.Deregister", &args, &out); err != nil {
+			return nil, external.RPCErrorToStatus(err)
+		}
+	}
+	return l, nil
+}
+
+// registerArgs validates the registration and returns the Catalog.Register
+// requests registering it along with its lease. The node is registered with
+// each of its services, or alone if it doesn't have any.
+func (s *Server) registerArgs(token string, reg *pbregistration.Registration) ([]*structs.RegisterRequest, *lease, error) {
+	if reg.Node == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "node is required")
+	}
+	if _, ok := reg.NodeMeta[LeaseOwnerMetaKey]; ok {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "node meta key %q is reserved", LeaseOwnerMetaKey)
+	}
+
+	ttl := DefaultLeaseTTL
+	if reg.LeaseTtl != nil {
+		ttl = reg.LeaseTtl.AsDuration()
+		if ttl < MinLeaseTTL || ttl > MaxLeaseTTL {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "lease_ttl must be between %s and %s", MinLeaseTTL, MaxLeaseTTL)
+		}
+	}
+
+	meta := make(map[string]string, len(reg.NodeMeta)+1)
+	for k, v := range reg.NodeMeta {
+		meta[k] = v
+	}
+	meta[LeaseOwnerMetaKey] = s.LeaseOwner
+
+	l := &lease{
+		node:    reg.Node,
+		entMeta: acl.NewEnterpriseMetaWithPartition(reg.Partition, ""),
+		token:   token,
+		ttl:     ttl,
+	}
+	newArgs := func() *structs.RegisterRequest {
+		return &structs.RegisterRequest{
+			ID:              types.NodeID(reg.NodeId),
+			Node:            reg.Node,
+			Address:         reg.Address,
+			TaggedAddresses: reg.TaggedAddresses,
+			NodeMeta:        meta,
+			EnterpriseMeta:  l.entMeta,
+			WriteRequest:    structs.WriteRequest{Token: token},
+		}
+	}
+
+	if len(reg.Services) == 0 {
+		return []*structs.RegisterRequest{newArgs()}, l, nil
+	}
+
+	args := make([]*structs.RegisterRequest, 0, len(reg.Services))
+	seen := make(map[structs.ServiceID]struct{}, len(reg.Services))
+	for i, svc := range reg.Services {
+		if svc.Name == "" {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "services[%d]: name is required", i)
+		}
+		id := svc.Id
+		if id == "" {
+			id = svc.Name
+		}
+		entMeta := acl.NewEnterpriseMetaWithPartition(reg.Partition, svc.Namespace)
+		sid := structs.NewServiceID(id, &entMeta)
+		if _, ok := seen[sid]; ok {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "services[%d]: duplicate service id %q", i, id)
+		}
+		seen[sid] = struct{}{}
+		l.services = append(l.services, sid)
+
+		arg := newArgs()
+		arg.Service = &structs.NodeService{
+			ID:             id,
+			Service:        svc.Name,
+			Tags:           svc.Tags,
+			Address:        svc.Address,
+			Port:           int(svc.Port),
+			Meta:           svc.Meta,
+			EnterpriseMeta: sid.EnterpriseMeta,
+		}
+		args = append(args, arg)
+	}
+	return args, l, nil
+}
+
+// expire deregisters the node of an expired lease, unless its registration
+// has been taken over by another server in the meantime. The deregistration
+// is retried after another TTL if it fails.
+func (s *Server) expire(l *lease) {
+	ctx, cancel := context.WithTimeout(context.Background(), expireTimeout)
+	defer cancel()
+
+	logger := s.Logger.With("node", l.node)
+
+	args := structs.NodeSpecificRequest{
+		Node:           l.node,
+		EnterpriseMeta: l.entMeta,
+		QueryOptions:   structs.QueryOptions{Token: l.token},
+	}
+	var out structs.IndexedNodeServiceList
+	if err := s.Backend.RPC(ctx, "Catalog.NodeServiceList", &args, &out); err != nil {
+		logger.Warn("failed to read registration with expired lease, retrying", "error", err)
+		s.leases.retry(l)
+		return
+	}
+	if out.NodeServices.Node == nil {
+		return
+	}
+	if owner := out.NodeServices.Node.Meta[LeaseOwnerMetaKey]; owner != s.LeaseOwner {
+		logger.Debug("registration with expired lease was taken over by another server", "owner", owner)
+		return
+	}
+
+	dereg := structs.DeregisterRequest{
+		Node:           l.node,
+		EnterpriseMeta: l.entMeta,
+		WriteRequest:   structs.WriteRequest{Token: l.token},
+	}
+	var dout struct{}
+	if err := s.Backend.RPC(ctx, "Catalog.Deregister", &dereg, &dout); err != nil {
+		logger.Warn("failed to deregister registration with expired lease, retrying", "error", err)
+		s.leases.retry(l)
+		return
+	}
+
+	metrics.IncrCounter([]string{"grpc", "registration", "expired"}, 1)
+	logger.Info("deregistered node after its lease expired")
+}
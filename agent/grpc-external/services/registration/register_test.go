@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+// acceptAll returns a backend accepting every RPC.
+func acceptAll(t *testing.T) *MockBackend {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return backend
+}
+
+func registration(node string, services ...string) *pbregistration.RegisterWorkloadRequest {
+	reg := &pbregistration.Registration{
+		Node:     node,
+		Address:  "10.0.0.1",
+		NodeMeta: map[string]string{"env": "test"},
+		LeaseTtl: durationpb.New(10 * time.Second),
+	}
+	for _, name := range services {
+		reg.Services = append(reg.Services, &pbregistration.Service{Name: name, Port: 8080})
+	}
+	return &pbregistration.RegisterWorkloadRequest{
+		Request: &pbregistration.RegisterWorkloadRequest_Registration{Registration: reg},
+	}
+}
+
+func renewal() *pbregistration.RegisterWorkloadRequest {
+	return &pbregistration.RegisterWorkloadRequest{
+		Request: &pbregistration.RegisterWorkloadRequest_Renew{Renew: &pbregistration.Renew{}},
+	}
+}
+
+func openStream(t *testing.T, client pbregistration.RegistrationServiceClient) pbregistration.RegistrationService_RegisterWorkloadClient {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-consul-token", "my-token")
+
+	stream, err := client.RegisterWorkload(ctx)
+	require.NoError(t, err)
+	return stream
+}
+
+func TestServer_RegisterWorkload(t *testing.T) {
+	// The node is registered along with each of its services.
+	registers := func(service string) interface{} {
+		return mock.MatchedBy(func(req *structs.RegisterRequest) bool {
+			return req.Node == "web-1" &&
+				req.Address == "10.0.0.1" &&
+				req.Token == "my-token" &&
+				len(req.NodeMeta) == 2 &&
+				req.NodeMeta["env"] == "test" &&
+				req.NodeMeta[LeaseOwnerMetaKey] == testLeaseOwner &&
+				req.Service.ID == service &&
+				req.Service.Service == service &&
+				req.Service.Port == 8080
+		})
+	}
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Catalog.Register", registers("web"), mock.Anything).Return(nil).Twice()
+	backend.On("RPC", mock.Anything, "Catalog.Register", registers("metrics"), mock.Anything).Return(nil).Once()
+	backend.On("RPC", mock.Anything, "Catalog.Deregister", mock.MatchedBy(func(req *structs.DeregisterRequest) bool {
+		return req.Node == "web-1" && req.ServiceID == "metrics" && req.Token == "my-token"
+	}), mock.Anything).Return(nil).Once()
+
+	server := testServer(t, backend)
+	stream := openStream(t, testClient(t, server))
+
+	require.NoError(t, stream.Send(registration("web-1", "web", "metrics")))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, resp.LeaseTtl.AsDuration())
+	backend.AssertNumberOfCalls(t, "RPC", 2)
+
+	// Renewals are acknowledged without any RPC.
+	require.NoError(t, stream.Send(renewal()))
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, resp.LeaseTtl.AsDuration())
+	backend.AssertNumberOfCalls(t, "RPC", 2)
+
+	// A new registration replaces the previous one, deregistering the
+	// services which are no longer part of it.
+	require.NoError(t, stream.Send(registration("web-1", "web")))
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	backend.AssertNumberOfCalls(t, "RPC", 4)
+}
+
+func TestServer_RegisterWorkload_InvalidRequests(t *testing.T) {
+	withRegistration := func(fn func(*pbregistration.Registration)) *pbregistration.RegisterWorkloadRequest {
+		req := registration("web-1", "web")
+		fn(req.GetRegistration())
+		return req
+	}
+
+	cases := map[string]struct {
+		requests []*pbregistration.RegisterWorkloadRequest
+		code     codes.Code
+		err      string
+	}{
+		"renewal before registration": {
+			requests: []*pbregistration.RegisterWorkloadRequest{renewal()},
+			code:     codes.FailedPrecondition,
+			err:      "the first message must be a registration",
+		},
+		"empty request": {
+			requests: []*pbregistration.RegisterWorkloadRequest{{}},
+			code:     codes.InvalidArgument,
+			err:      "request is required",
+		},
+		"missing node": {
+			requests: []*pbregistration.RegisterWorkloadRequest{registration("")},
+			code:     codes.InvalidArgument,
+			err:      "node is required",
+		},
+		"reserved node meta": {
+			requests: []*pbregistration.RegisterWorkloadRequest{withRegistration(func(reg *pbregistration.Registration) {
+				reg.NodeMeta[LeaseOwnerMetaKey] = "mine"
+			})},
+			code: codes.InvalidArgument,
+			err:  `node meta key "consul-lease-owner" is reserved`,
+		},
+		"lease ttl too short": {
+			requests: []*pbregistration.RegisterWorkloadRequest{withRegistration(func(reg *pbregistration.Registration) {
+				reg.LeaseTtl = durationpb.New(time.Second)
+			})},
+			code: codes.InvalidArgument,
+			err:  "lease_ttl must be between 5s and 10m0s",
+		},
+		"lease ttl too long": {
+			requests: []*pbregistration.RegisterWorkloadRequest{withRegistration(func(reg *pbregistration.Registration) {
+				reg.LeaseTtl = durationpb.New(time.Hour)
+			})},
+			code: codes.InvalidArgument,
+			err:  "lease_ttl must be between 5s and 10m0s",
+		},
+		"missing service name": {
+			requests: []*pbregistration.RegisterWorkloadRequest{withRegistration(func(reg *pbregistration.Registration) {
+				reg.Services = append(reg.Services, &pbregistration.Service{Id: "db"})
+			})},
+			code: codes.InvalidArgument,
+			err:  "services[1]: name is required",
+		},
+		"duplicate service id": {
+			requests: []*pbregistration.RegisterWorkloadRequest{registration("web-1", "web", "web")},
+			code:     codes.InvalidArgument,
+			err:      `services[1]: duplicate service id "web"`,
+		},
+		"changed node": {
+			requests: []*pbregistration.RegisterWorkloadRequest{registration("web-1"), registration("web-2")},
+			code:     codes.InvalidArgument,
+			err:      "the node of a registration cannot be changed",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stream := openStream(t, testClient(t, testServer(t, acceptAll(t))))
+
+			var err error
+			for _, req := range tc.requests {
+				require.NoError(t, stream.Send(req))
+				if _, err = stream.Recv(); err != nil {
+					break
+				}
+			}
+			require.Equal(t, tc.code.String(), status.Code(err).String())
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestServer_RegisterWorkload_PermissionDenied(t *testing.T) {
+	backend := NewMockBackend(t)
+	backend.On("RPC", mock.Anything, "Catalog.Register", mock.Anything, mock.Anything).Return(acl.ErrPermissionDenied)
+	server := testServer(t, backend)
+	stream := openStream(t, testClient(t, server))
+
+	require.NoError(t, stream.Send(registration("web-1", "web")))
+	_, err := stream.Recv()
+	require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+
+	// The failed registration doesn't hold a lease.
+	require.Empty(t, server.leases.byKey)
+}
+
+func TestServer_RegisterWorkload_TakenOver(t *testing.T) {
+	server := testServer(t, acceptAll(t))
+	client := testClient(t, server)
+
+	first := openStream(t, client)
+	require.NoError(t, first.Send(registration("web-1", "web")))
+	_, err := first.Recv()
+	require.NoError(t, err)
+
+	// A broken stream keeps its lease until it is taken over.
+	require.NoError(t, first.CloseSend())
+	second := openStream(t, client)
+	require.NoError(t, second.Send(registration("web-1", "web")))
+	_, err = second.Recv()
+	require.NoError(t, err)
+
+	require.NoError(t, second.Send(renewal()))
+	_, err = second.Recv()
+	require.NoError(t, err)
+
+	// A stream whose lease was taken over can no longer renew it.
+	third := openStream(t, client)
+	require.NoError(t, third.Send(registration("web-1", "web")))
+	_, err = third.Recv()
+	require.NoError(t, err)
+
+	require.NoError(t, second.Send(renewal()))
+	_, err = second.Recv()
+	require.Equal(t, codes.FailedPrecondition.String(), status.Code(err).String())
+	require.Contains(t, err.Error(), "lease has expired or was taken over by another stream")
+}
+
+func TestServer_expire(t *testing.T) {
+	newLease := func() *lease {
+		return &lease{
+			node:    "web-1",
+			entMeta: *acl.DefaultEnterpriseMeta(),
+			token:   "my-token",
+			ttl:     time.Hour,
+		}
+	}
+	// backendWithOwner returns a backend answering the lookups of the node
+	// with the given lease owner.
+	backendWithOwner := func(t *testing.T, owner string) *MockBackend {
+		backend := NewMockBackend(t)
+		backend.On("RPC", mock.Anything, "Catalog.NodeServiceList", mock.Anything, mock.Anything).
+			Return(func(_ context.Context, _ string, _, reply interface{}) error {
+				reply.(*structs.IndexedNodeServiceList).NodeServices.Node = &structs.Node{
+					Node: "web-1",
+					Meta: map[string]string{LeaseOwnerMetaKey: owner},
+				}
+				return nil
+			}).Once()
+		return backend
+	}
+
+	t.Run("deregisters the node", func(t *testing.T) {
+		backend := backendWithOwner(t, testLeaseOwner)
+		backend.On("RPC", mock.Anything, "Catalog.Deregister", mock.MatchedBy(func(req *structs.DeregisterRequest) bool {
+			return req.Node == "web-1" && req.ServiceID == "" && req.Token == "my-token"
+		}), mock.Anything).Return(nil).Once()
+		server := testServer(t, backend)
+
+		server.expire(newLease())
+	})
+
+	t.Run("skips nodes taken over by another server", func(t *testing.T) {
+		backend := backendWithOwner(t, "other-server")
+		server := testServer(t, backend)
+
+		server.expire(newLease())
+		backend.AssertNotCalled(t, "RPC", mock.Anything, "Catalog.Deregister", mock.Anything, mock.Anything)
+	})
+
+	t.Run("retries failed deregistrations", func(t *testing.T) {
+		backend := backendWithOwner(t, testLeaseOwner)
+		backend.On("RPC", mock.Anything, "Catalog.Deregister", mock.Anything, mock.Anything).Return(errors.New("no leader")).Once()
+		server := testServer(t, backend)
+
+		l := newLease()
+		server.expire(l)
+		require.True(t, server.leases.renew(l))
+	})
+}
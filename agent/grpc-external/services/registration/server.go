@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+
+	external "github.com/hashicorp/consul/agent/grpc-external"
+	"github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+const (
+	// DefaultLeaseTTL is used when a registration doesn't set its lease TTL.
+	DefaultLeaseTTL = 30 * time.Second
+
+	// MinLeaseTTL and MaxLeaseTTL bound the lease TTL of a registration.
+	MinLeaseTTL = 5 * time.Second
+	MaxLeaseTTL = 10 * time.Minute
+
+	// LeaseOwnerMetaKey is the node meta key recording the server which holds
+	// the node's lease. The leader uses it to deregister the nodes whose lease
+	// was held by a server which is no longer running.
+	LeaseOwnerMetaKey = "consul-lease-owner"
+)
+
+// Server implements pbregistration.RegistrationServiceServer by translating
+// registrations into the same Catalog RPCs used by the HTTP API, and by
+// keeping track of their leases.
+type Server struct {
+	Config
+
+	leases *leases
+}
+
+type Config struct {
+	Backend Backend
+	Logger  hclog.Logger
+
+	// LeaseOwner identifies this server in the LeaseOwnerMetaKey node meta of
+	// the nodes whose lease it holds.
+	LeaseOwner string
+}
+
+// Backend makes the Catalog RPCs registering and deregistering the workloads,
+// with the token of the stream which holds their lease, through the server's
+// in-memory RPC handler.
+//
+//go:generate mockery --name Backend --inpackage
+type Backend interface {
+	RPC(ctx context.Context, method string, args interface{}, reply interface{}) error
+}
+
+func NewServer(cfg Config) *Server {
+	external.RequireNotNil(cfg.Backend, "Backend")
+	external.RequireNotNil(cfg.Logger, "Logger")
+	if cfg.LeaseOwner == "" {
+		panic("LeaseOwner is required")
+	}
+
+	s := &Server{Config: cfg}
+	s.leases = newLeases(s.expire)
+	return s
+}
+
+var _ pbregistration.RegistrationServiceServer = (*Server)(nil)
+
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	pbregistration.RegisterRegistrationServiceServer(registrar, s)
+}
+
+// Shutdown stops all the lease timers, leaving the registrations in the
+// catalog. They are deregistered by the leader once this server has left the
+// cluster, unless they are taken over by another server first.
+func (s *Server) Shutdown() {
+	s.leases.stopAll()
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package registration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/consul/agent/grpc-external/testutils"
+	"github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+const testLeaseOwner = "4e2cd1e3-7b0a-4a1b-9c3e-1d2f3a4b5c6d"
+
+func testServer(t *testing.T, backend *MockBackend) *Server {
+	t.Helper()
+
+	server := NewServer(Config{
+		Backend:    backend,
+		Logger:     hclog.NewNullLogger(),
+		LeaseOwner: testLeaseOwner,
+	})
+	t.Cleanup(server.Shutdown)
+	return server
+}
+
+func testClient(t *testing.T, server *Server) pbregistration.RegistrationServiceClient {
+	t.Helper()
+
+	addr := testutils.RunTestServer(t, server)
+
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(context.Background(), addr.String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return pbregistration.NewRegistrationServiceClient(conn)
+}
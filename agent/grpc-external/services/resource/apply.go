@@ -0,0 +1,412 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/storage"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// apply implements the Write endpoint when a field manager is given. The fields
+// set in the given resource's data are merged into the stored resource, and the
+// field manager is recorded as their owner, so that multiple actors (e.g. a GitOps
+// pipeline and a controller) can safely manage disjoint fields of the same resource.
+func (s *Server) apply(ctx context.Context, req *pbresource.WriteRequest) (*pbresource.WriteResponse, error) {
+	reg, err := s.ensureResourceValid(req.Resource, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the user sent the correct type of data before attempting to merge it.
+	if req.Resource.Data != nil && !req.Resource.Data.MessageIs(reg.Proto) {
+		got := strings.TrimPrefix(req.Resource.Data.TypeUrl, "type.googleapis.com/")
+
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"resource.data is of wrong type (expected=%q, got=%q)",
+			reg.Proto.ProtoReflect().Descriptor().FullName(),
+			got,
+		)
+	}
+
+	// The caller must be allowed to write the resource and to read the stored
+	// one before it is read and merged, otherwise the result of the merge would
+	// leak the existence of the resource and the owners of its fields. The
+	// merged resource is authorized again when it is written.
+	entMeta := v2TenancyToV1EntMeta(req.Resource.Id.Tenancy)
+	authz, authzContext, err := s.getAuthorizer(tokenFromContext(ctx), entMeta)
+	if err != nil {
+		return nil, err
+	}
+	v1EntMetaToV2Tenancy(reg, entMeta, req.Resource.Id.Tenancy)
+
+	err = reg.ACLs.Write(authz, authzContext, req.Resource)
+	switch {
+	case acl.IsErrPermissionDenied(err):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed write acl: %v", err)
+	}
+
+	readNeedsData := false
+	err = reg.ACLs.Read(authz, authzContext, req.Resource.Id, nil)
+	switch {
+	case errors.Is(err, resource.ErrNeedResource):
+		readNeedsData = true
+	case acl.IsErrPermissionDenied(err):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
+	}
+
+	var rsp *pbresource.WriteResponse
+	err = s.retryCAS(ctx, req.Resource.Version, func() error {
+		// See Write for why we read with EventualConsistency. The merged resource
+		// is written with the version we read, so the write will fail and be
+		// retried if the resource has been modified in the meantime.
+		var mismatchError storage.GroupVersionMismatchError
+		existing, err := s.Backend.Read(ctx, storage.EventualConsistency, req.Resource.Id)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			existing = nil
+		case errors.As(err, &mismatchError):
+			existing = mismatchError.Stored
+		case err != nil:
+			return err
+		}
+
+		if readNeedsData && existing != nil {
+			err = reg.ACLs.Read(authz, authzContext, existing.Id, existing)
+			switch {
+			case acl.IsErrPermissionDenied(err):
+				return status.Error(codes.PermissionDenied, err.Error())
+			case err != nil:
+				return status.Errorf(codes.Internal, "failed read acl: %v", err)
+			}
+		}
+
+		merged, err := applyFields(reg.Proto, req.Resource, existing, req.FieldManager, req.Force)
+		if err != nil {
+			return err
+		}
+
+		rsp, err = s.write(ctx, merged, true)
+		if status.Code(err) == codes.Aborted && req.Resource.Version == "" {
+			return storage.ErrCASFailure
+		}
+		return err
+	})
+
+	switch {
+	case errors.Is(err, storage.ErrCASFailure):
+		return nil, status.Error(codes.Aborted, err.Error())
+	case isGRPCStatusError(err):
+		return nil, err
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to apply resource: %v", err.Error())
+	}
+	return rsp, nil
+}
+
+// applyFields merges the fields set in the input resource's data into the existing
+// resource (which may be nil if the resource doesn't exist yet) on behalf of the
+// given field manager, and returns the resource to write.
+func applyFields(typ proto.Message, input, existing *pbresource.Resource, manager string, force bool) (*pbresource.Resource, error) {
+	applied, err := decodeData(typ, input)
+	if err != nil {
+		return nil, err
+	}
+	appliedPaths := fieldPaths(applied, "")
+
+	result := clone(input)
+	delete(result.Metadata, resource.ManagedFieldsKey)
+
+	if existing == nil {
+		if err := resource.SetManagedFields(result, resource.ManagedFields{manager: appliedPaths}); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return result, nil
+	}
+
+	stored, err := decodeData(typ, existing)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := resource.GetManagedFields(existing)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Check whether the apply changes the value of any field owned by another
+	// field manager. Setting a field to the value it already has is not a
+	// conflict; the field manager just becomes one of the field's owners.
+	var conflicts []string
+	managers := maps.Keys(managed)
+	sort.Strings(managers)
+	for _, other := range managers {
+		if other == manager {
+			continue
+		}
+		var kept []string
+		for _, owned := range managed[other] {
+			conflict := false
+			for _, path := range appliedPaths {
+				common, overlap := overlappingPath(applied.Descriptor(), path, owned)
+				if overlap && !equalAt(stored, applied, common) {
+					conflict = true
+					break
+				}
+			}
+			switch {
+			case !conflict:
+				kept = append(kept, owned)
+			case !force:
+				conflicts = append(conflicts, fmt.Sprintf("field %q is owned by field manager %q", owned, other))
+			}
+		}
+		managed[other] = kept
+	}
+	if len(conflicts) != 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "apply conflicts with other field managers: %s", strings.Join(conflicts, "; "))
+	}
+
+	merged := proto.Clone(stored.Interface()).ProtoReflect()
+
+	// Clear the fields the field manager no longer wants to set, unless another
+	// field manager still owns them.
+	for _, path := range managed[manager] {
+		if slices.Contains(appliedPaths, path) || ownedByOther(managed, manager, path) {
+			continue
+		}
+		copyField(merged, stored.Type().New(), strings.Split(path, "."))
+	}
+	for _, path := range appliedPaths {
+		copyField(merged, applied, strings.Split(path, "."))
+	}
+	managed[manager] = appliedPaths
+
+	result.Data, err = anypb.New(merged.Interface())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode merged data: %v", err)
+	}
+
+	// Metadata and owner given in the apply are merged into the existing ones.
+	metadata := maps.Clone(existing.Metadata)
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	for k, v := range result.Metadata {
+		metadata[k] = v
+	}
+	result.Metadata = metadata
+	if result.Owner == nil {
+		result.Owner = existing.Owner
+	}
+	if result.Version == "" {
+		result.Version = existing.Version
+	}
+
+	if err := resource.SetManagedFields(result, managed); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return result, nil
+}
+
+// releaseChangedFields removes the ownership of any fields whose values are changed
+// by writing the input resource over the existing resource.
+func releaseChangedFields(input, existing *pbresource.Resource) error {
+	managed, err := resource.GetManagedFields(existing)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if len(managed) == 0 {
+		delete(input.Metadata, resource.ManagedFieldsKey)
+		return nil
+	}
+
+	if existing.Data == nil {
+		// Nothing to compare against, so all of the fields have changed.
+		delete(input.Metadata, resource.ManagedFieldsKey)
+		return nil
+	}
+
+	// Data is validated against the registered type before this is called, so
+	// the stored type can be used to decode both.
+	typ, err := existing.Data.UnmarshalNew()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to decode resource.data: %v", err)
+	}
+	stored := typ.ProtoReflect()
+	written, err := decodeData(typ, input)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for manager, paths := range managed {
+		var kept []string
+		for _, path := range paths {
+			if equalAt(stored, written, strings.Split(path, ".")) {
+				kept = append(kept, path)
+			} else {
+				changed = true
+			}
+		}
+		managed[manager] = kept
+	}
+
+	if !changed {
+		// Preserve the existing encoding to avoid spurious metadata changes.
+		if input.Metadata == nil {
+			input.Metadata = make(map[string]string)
+		}
+		input.Metadata[resource.ManagedFieldsKey] = existing.Metadata[resource.ManagedFieldsKey]
+		return nil
+	}
+	if err := resource.SetManagedFields(input, managed); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func decodeData(typ proto.Message, res *pbresource.Resource) (protoreflect.Message, error) {
+	msg := typ.ProtoReflect().New()
+	if res.Data == nil {
+		return msg, nil
+	}
+	if err := res.Data.UnmarshalTo(msg.Interface()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode resource.data: %v", err)
+	}
+	return msg, nil
+}
+
+func ownedByOther(managed resource.ManagedFields, manager, path string) bool {
+	for other, paths := range managed {
+		if other != manager && slices.Contains(paths, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPaths returns the paths of the fields set in the given message. Singular
+// message fields are recursed into so that field managers can own disjoint fields
+// of the same nested message, all other fields (e.g. lists and maps) are owned
+// as a whole.
+func fieldPaths(msg protoreflect.Message, prefix string) []string {
+	var paths []string
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := prefix + string(fd.Name())
+		if isNestedMessage(fd) {
+			if nested := fieldPaths(v.Message(), path+"."); len(nested) != 0 {
+				paths = append(paths, nested...)
+				return true
+			}
+		}
+		paths = append(paths, path)
+		return true
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// isNestedMessage returns whether fields of the given field may be owned
+// individually. Well-known types such as Duration are treated as values.
+func isNestedMessage(fd protoreflect.FieldDescriptor) bool {
+	return fd.Message() != nil &&
+		!fd.IsList() &&
+		!fd.IsMap() &&
+		fd.Message().ParentFile().Package() != "google.protobuf"
+}
+
+// overlappingPath returns whether the given field paths refer to overlapping
+// fields, along with the path of the common field which contains both of them.
+// Paths overlap if one contains the other, or if they diverge at fields that
+// are members of the same oneof.
+func overlappingPath(desc protoreflect.MessageDescriptor, a, b string) ([]string, bool) {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if desc == nil {
+			return nil, false
+		}
+		af := desc.Fields().ByName(protoreflect.Name(as[i]))
+		if as[i] == bs[i] {
+			if af == nil {
+				return nil, false
+			}
+			desc = af.Message()
+			continue
+		}
+
+		bf := desc.Fields().ByName(protoreflect.Name(bs[i]))
+		if af == nil || bf == nil {
+			return nil, false
+		}
+		oneof := af.ContainingOneof()
+		if oneof == nil || oneof.IsSynthetic() || oneof != bf.ContainingOneof() {
+			return nil, false
+		}
+		return as[:i], true
+	}
+
+	if len(as) < len(bs) {
+		return as, true
+	}
+	return bs, true
+}
+
+// equalAt returns whether the value at the given path is the same in both messages.
+func equalAt(a, b protoreflect.Message, path []string) bool {
+	return proto.Equal(extractField(a, path), extractField(b, path))
+}
+
+// extractField returns a message containing only the field at the given path.
+func extractField(msg protoreflect.Message, path []string) proto.Message {
+	if len(path) == 0 {
+		return msg.Interface()
+	}
+	out := msg.Type().New()
+	copyField(out, msg, path)
+	return out.Interface()
+}
+
+// copyField sets the field at the given path in dst to its value in src, or
+// clears it if it isn't set in src.
+func copyField(dst, src protoreflect.Message, path []string) {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return
+	}
+
+	if len(path) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return
+	}
+
+	if !isNestedMessage(fd) || (!src.Has(fd) && !dst.Has(fd)) {
+		return
+	}
+	copyField(dst.Mutable(fd).Message(), src.Get(fd).Message(), path[1:])
+}
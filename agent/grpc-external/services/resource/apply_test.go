@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/acl/resolver"
+	svc "github.com/hashicorp/consul/agent/grpc-external/services/resource"
+	svctest "github.com/hashicorp/consul/agent/grpc-external/services/resource/testing"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	rtest "github.com/hashicorp/consul/internal/resource/resourcetest"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
+	"github.com/hashicorp/consul/proto/private/prototest"
+)
+
+func TestWrite_Apply(t *testing.T) {
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	apply := func(t *testing.T, manager string, force bool, artist *pbdemov2.Artist) (*pbresource.Resource, error) {
+		t.Helper()
+
+		res := rtest.Resource(pbdemov2.ArtistType, "the-cure").
+			WithTenancy(resource.DefaultNamespacedTenancy()).
+			WithData(t, artist).
+			Build()
+
+		rsp, err := client.Write(testContext(t), &pbresource.WriteRequest{
+			Resource:     res,
+			FieldManager: manager,
+			Force:        force,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return rsp.Resource, nil
+	}
+
+	requireState := func(t *testing.T, res *pbresource.Resource, expected *pbdemov2.Artist, managed resource.ManagedFields) {
+		t.Helper()
+
+		prototest.AssertDeepEqual(t, expected, rtest.MustDecode[*pbdemov2.Artist](t, res).Data)
+
+		actual, err := resource.GetManagedFields(res)
+		require.NoError(t, err)
+		require.Equal(t, managed, actual)
+	}
+
+	members := map[string]string{"singer": "Robert Smith"}
+
+	// GitOps creates the resource.
+	res, err := apply(t, "gitops", false, &pbdemov2.Artist{
+		Name:  "The Cure",
+		Genre: pbdemov2.Genre_GENRE_POP,
+	})
+	require.NoError(t, err)
+	requireState(t, res,
+		&pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_POP},
+		resource.ManagedFields{"gitops": {"genre", "name"}},
+	)
+
+	// A controller manages a disjoint field.
+	res, err = apply(t, "controller", false, &pbdemov2.Artist{GroupMembers: members})
+	require.NoError(t, err)
+	requireState(t, res,
+		&pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_POP, GroupMembers: members},
+		resource.ManagedFields{"gitops": {"genre", "name"}, "controller": {"group_members"}},
+	)
+
+	// Changing a field owned by another field manager is rejected.
+	_, err = apply(t, "controller", false, &pbdemov2.Artist{
+		Genre:        pbdemov2.Genre_GENRE_METAL,
+		GroupMembers: members,
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.FailedPrecondition.String(), status.Code(err).String())
+	require.ErrorContains(t, err, `field "genre" is owned by field manager "gitops"`)
+
+	// Applying the same value is not a conflict, the field becomes co-owned.
+	res, err = apply(t, "controller", false, &pbdemov2.Artist{
+		Genre:        pbdemov2.Genre_GENRE_POP,
+		GroupMembers: members,
+	})
+	require.NoError(t, err)
+	requireState(t, res,
+		&pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_POP, GroupMembers: members},
+		resource.ManagedFields{"gitops": {"genre", "name"}, "controller": {"genre", "group_members"}},
+	)
+
+	// Forcing the apply takes ownership away from the other field managers.
+	res, err = apply(t, "controller", true, &pbdemov2.Artist{
+		Genre:        pbdemov2.Genre_GENRE_METAL,
+		GroupMembers: members,
+	})
+	require.NoError(t, err)
+	requireState(t, res,
+		&pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_METAL, GroupMembers: members},
+		resource.ManagedFields{"gitops": {"name"}, "controller": {"genre", "group_members"}},
+	)
+
+	// Fields omitted from an apply are cleared if no one else owns them.
+	res, err = apply(t, "controller", false, &pbdemov2.Artist{Genre: pbdemov2.Genre_GENRE_METAL})
+	require.NoError(t, err)
+	requireState(t, res,
+		&pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_METAL},
+		resource.ManagedFields{"gitops": {"name"}, "controller": {"genre"}},
+	)
+
+	// Writes without a field manager release ownership of the fields they change.
+	res.Data = rtest.Resource(pbdemov2.ArtistType, "the-cure").
+		WithData(t, &pbdemov2.Artist{Name: "The Cure (Remastered)", Genre: pbdemov2.Genre_GENRE_METAL}).
+		Build().Data
+	rsp, err := client.Write(testContext(t), &pbresource.WriteRequest{Resource: res})
+	require.NoError(t, err)
+	requireState(t, rsp.Resource,
+		&pbdemov2.Artist{Name: "The Cure (Remastered)", Genre: pbdemov2.Genre_GENRE_METAL},
+		resource.ManagedFields{"controller": {"genre"}},
+	)
+}
+
+func TestWrite_Apply_ACLs(t *testing.T) {
+	authz := AuthorizerFrom(t, demo.ArtistV2WritePolicy)
+	mockACLResolver := &svc.MockACLResolver{}
+	mockACLResolver.On("ResolveTokenAndDefaultMeta", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(string, *acl.EnterpriseMeta, *acl.AuthorizerContext) resolver.Result { return authz }, nil)
+
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		WithACLResolver(mockACLResolver).
+		Run(t)
+
+	apply := func(t *testing.T, name, manager string, artist *pbdemov2.Artist) error {
+		t.Helper()
+
+		res := rtest.Resource(pbdemov2.ArtistType, name).
+			WithTenancy(resource.DefaultNamespacedTenancy()).
+			WithData(t, artist).
+			Build()
+
+		_, err := client.Write(testContext(t), &pbresource.WriteRequest{
+			Resource:     res,
+			FieldManager: manager,
+		})
+		return err
+	}
+
+	require.NoError(t, apply(t, "the-cure", "gitops", &pbdemov2.Artist{Name: "The Cure", Genre: pbdemov2.Genre_GENRE_POP}))
+
+	// Without write access, the apply is denied before the stored resource is
+	// merged, so that neither its existence nor its field owners are leaked.
+	authz = AuthorizerFrom(t, demo.ArtistV1WritePolicy)
+	for _, name := range []string{"the-cure", "the-smiths"} {
+		err := apply(t, name, "controller", &pbdemov2.Artist{Genre: pbdemov2.Genre_GENRE_METAL})
+		require.Error(t, err)
+		require.Equal(t, codes.PermissionDenied.String(), status.Code(err).String())
+		require.NotContains(t, err.Error(), "gitops")
+	}
+}
+
+func TestWrite_Apply_ForceRequiresFieldManager(t *testing.T) {
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	res, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+
+	_, err = client.Write(testContext(t), &pbresource.WriteRequest{Resource: res, Force: true})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+	require.ErrorContains(t, err, "force can only be used with field_manager")
+}
+
+func TestWrite_ManagedFieldsIgnoredWithoutFieldManager(t *testing.T) {
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	res, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	require.NoError(t, resource.SetManagedFields(res, resource.ManagedFields{"gitops": {"name"}}))
+
+	rsp, err := client.Write(testContext(t), &pbresource.WriteRequest{Resource: res})
+	require.NoError(t, err)
+	require.NotContains(t, rsp.Resource.Metadata, resource.ManagedFieldsKey)
+}
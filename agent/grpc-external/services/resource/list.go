@@ -21,6 +21,11 @@ func (s *Server) List(ctx context.Context, req *pbresource.ListRequest) (*pbreso
 		return nil, err
 	}
 
+	filter, err := metadataFilterFrom(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
 	// v1 ACL subsystem is "wildcard" aware so just pass on through.
 	entMeta := v2TenancyToV1EntMeta(req.Tenancy)
 	token := tokenFromContext(ctx)
@@ -59,6 +64,15 @@ func (s *Server) List(ctx context.Context, req *pbresource.ListRequest) (*pbreso
 			continue
 		}
 
+		// Filter out items that don't match the metadata filter.
+		match, err := filter.Matches(resource)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to evaluate filter: %v", err)
+		}
+		if !match {
+			continue
+		}
+
 		// Need to rebuild authorizer per resource since wildcard inputs may
 		// result in different tenancies. Consider caching per tenancy if this
 		// is deemed expensive.
@@ -76,7 +90,7 @@ func (s *Server) List(ctx context.Context, req *pbresource.ListRequest) (*pbreso
 		case err != nil:
 			return nil, status.Errorf(codes.Internal, "failed read acl: %v", err)
 		}
-		result = append(result, resource)
+		result = append(result, applyReadMask(req.ReadMask, resource))
 	}
 	return &pbresource.ListResponse{Resources: result}, nil
 }
@@ -94,6 +108,10 @@ func (s *Server) ensureListRequestValid(req *pbresource.ListRequest) (*resource.
 		return nil, status.Errorf(codes.InvalidArgument, "%s is required", field)
 	}
 
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
+
 	// Check type exists.
 	reg, err := s.resolveType(req.Type)
 	if err != nil {
@@ -124,3 +142,13 @@ func (s *Server) ensureListRequestValid(req *pbresource.ListRequest) (*resource.
 
 	return reg, nil
 }
+
+// metadataFilterFrom parses the metadata filter given in a List or WatchList
+// request.
+func metadataFilterFrom(filter string) (*resource.MetadataFilter, error) {
+	f, err := resource.NewMetadataFilter(filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return f, nil
+}
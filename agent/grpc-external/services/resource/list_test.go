@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/consul/acl"
 	svc "github.com/hashicorp/consul/agent/grpc-external/services/resource"
@@ -79,6 +80,16 @@ func TestList_InputValidation(t *testing.T) {
 			},
 			errContains: "cannot have a namespace",
 		},
+		"read_mask unknown field": {
+			modReqFn: func(req *pbresource.ListRequest) {
+				req.ReadMask = &fieldmaskpb.FieldMask{Paths: []string{"bogus"}}
+			},
+			errContains: "read_mask invalid",
+		},
+		"filter invalid": {
+			modReqFn:    func(req *pbresource.ListRequest) { req.Filter = "metadata.team ==" },
+			errContains: `filter "metadata.team ==" is invalid`,
+		},
 	}
 	for desc, tc := range testCases {
 		t.Run(desc, func(t *testing.T) {
@@ -159,6 +170,30 @@ func TestList_Many(t *testing.T) {
 	}
 }
 
+func TestList_ReadMask(t *testing.T) {
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	rsp1, err := client.Write(testContext(t), &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp1.Resource
+
+	rsp, err := client.List(testContext(t), &pbresource.ListRequest{
+		Type:     demo.TypeV2Artist,
+		Tenancy:  resource.DefaultNamespacedTenancy(),
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "version"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, rsp.Resources, 1)
+	prototest.AssertDeepEqual(t, &pbresource.Resource{
+		Id:      artist.Id,
+		Version: artist.Version,
+	}, rsp.Resources[0])
+}
+
 func TestList_NamePrefix(t *testing.T) {
 	for desc, tc := range listTestCases() {
 		t.Run(desc, func(t *testing.T) {
@@ -199,6 +234,49 @@ func TestList_NamePrefix(t *testing.T) {
 	}
 }
 
+func TestList_Filter(t *testing.T) {
+	for desc, tc := range listTestCases() {
+		t.Run(desc, func(t *testing.T) {
+			client := svctest.NewResourceServiceBuilder().
+				WithRegisterFns(demo.RegisterTypes).
+				Run(t)
+
+			expectedResources := []*pbresource.Resource{}
+
+			for i := 0; i < 10; i++ {
+				artist, err := demo.GenerateV2Artist()
+				require.NoError(t, err)
+
+				// Prevent test flakes if the generated names collide.
+				artist.Id.Name = fmt.Sprintf("%s-%d", artist.Id.Name, i)
+
+				team := "payments"
+				if i%2 == 0 {
+					team = "billing"
+				}
+				artist.Metadata = map[string]string{"team": team}
+
+				rsp, err := client.Write(tc.ctx, &pbresource.WriteRequest{Resource: artist})
+				require.NoError(t, err)
+
+				// only resources matching the filter are expected
+				if team == "payments" {
+					expectedResources = append(expectedResources, rsp.Resource)
+				}
+			}
+
+			rsp, err := client.List(tc.ctx, &pbresource.ListRequest{
+				Type:    demo.TypeV2Artist,
+				Tenancy: resource.DefaultNamespacedTenancy(),
+				Filter:  `metadata.team == "payments"`,
+			})
+
+			require.NoError(t, err)
+			prototest.AssertElementsMatch(t, expectedResources, rsp.Resources)
+		})
+	}
+}
+
 func TestList_Tenancy_Defaults_And_Normalization(t *testing.T) {
 	// Test units of tenancy get defaulted correctly when empty.
 	ctx := context.Background()
@@ -84,7 +84,7 @@ func (s *Server) Read(ctx context.Context, req *pbresource.ReadRequest) (*pbreso
 		}
 	}
 
-	return &pbresource.ReadResponse{Resource: resource}, nil
+	return &pbresource.ReadResponse{Resource: applyReadMask(req.ReadMask, resource)}, nil
 }
 
 func (s *Server) ensureReadRequestValid(req *pbresource.ReadRequest) (*resource.Registration, error) {
@@ -96,6 +96,10 @@ func (s *Server) ensureReadRequestValid(req *pbresource.ReadRequest) (*resource.
 		return nil, err
 	}
 
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
+
 	// Check type exists.
 	reg, err := s.resolveType(req.Id.Type)
 	if err != nil {
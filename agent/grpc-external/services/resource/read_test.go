@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
@@ -294,6 +295,53 @@ func TestRead_Success(t *testing.T) {
 	}
 }
 
+func TestRead_ReadMask(t *testing.T) {
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	rsp1, err := client.Write(testContext(t), &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	artist = rsp1.Resource
+
+	t.Run("status only", func(t *testing.T) {
+		rsp, err := client.Read(testContext(t), &pbresource.ReadRequest{
+			Id:       artist.Id,
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+		})
+		require.NoError(t, err)
+		require.Nil(t, rsp.Resource.Id)
+		require.Nil(t, rsp.Resource.Data)
+		require.Empty(t, rsp.Resource.Version)
+	})
+
+	t.Run("data only", func(t *testing.T) {
+		rsp, err := client.Read(testContext(t), &pbresource.ReadRequest{
+			Id:       artist.Id,
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"data"}},
+		})
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, &pbresource.Resource{Data: artist.Data}, rsp.Resource)
+	})
+
+	t.Run("unmasked read is unaffected", func(t *testing.T) {
+		rsp, err := client.Read(testContext(t), &pbresource.ReadRequest{Id: artist.Id})
+		require.NoError(t, err)
+		prototest.AssertDeepEqual(t, artist, rsp.Resource)
+	})
+
+	t.Run("invalid mask", func(t *testing.T) {
+		_, err := client.Read(testContext(t), &pbresource.ReadRequest{
+			Id:       artist.Id,
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"data.bogus"}},
+		})
+		require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		require.ErrorContains(t, err, "read_mask invalid")
+	})
+}
+
 func TestRead_VerifyReadConsistencyArg(t *testing.T) {
 	// Uses a mockBackend instead of the inmem Backend to verify the ReadConsistency argument is set correctly.
 	for desc, tc := range readTestCases() {
@@ -14,9 +14,11 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/internal/protoutil"
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/storage"
 	"github.com/hashicorp/consul/lib/retry"
@@ -305,4 +307,23 @@ func (s *Server) retryCAS(ctx context.Context, vsn string, cas func() error) err
 	return err
 }
 
+func validateReadMask(mask *fieldmaskpb.FieldMask) error {
+	if err := protoutil.ValidateFieldMask(mask, &pbresource.Resource{}); err != nil {
+		return status.Errorf(codes.InvalidArgument, "read_mask invalid: %v", err)
+	}
+	return nil
+}
+
+// applyReadMask returns a copy of the resource containing only the fields
+// selected by the (previously validated) mask. The resource itself is never
+// modified as it may be shared with the storage backend.
+func applyReadMask(mask *fieldmaskpb.FieldMask, res *pbresource.Resource) *pbresource.Resource {
+	if len(mask.GetPaths()) == 0 {
+		return res
+	}
+	res = clone(res)
+	_ = protoutil.ApplyFieldMask(mask, res)
+	return res
+}
+
 func clone[T proto.Message](v T) T { return proto.Clone(v).(T) }
@@ -8,6 +8,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/internal/resource"
@@ -21,6 +22,11 @@ func (s *Server) WatchList(req *pbresource.WatchListRequest, stream pbresource.R
 		return err
 	}
 
+	filter, err := metadataFilterFrom(req.Filter)
+	if err != nil {
+		return err
+	}
+
 	// v1 ACL subsystem is "wildcard" aware so just pass on through.
 	entMeta := v2TenancyToV1EntMeta(req.Tenancy)
 	token := tokenFromContext(stream.Context())
@@ -53,6 +59,11 @@ func (s *Server) WatchList(req *pbresource.WatchListRequest, stream pbresource.R
 	}
 	defer watch.Close()
 
+	// When filtering, keep track of which resources the watcher has been told
+	// about, so that a delete event can be emitted when a resource is updated
+	// such that it no longer matches the filter.
+	matched := make(map[resource.ReferenceKey]struct{})
+
 	for {
 		event, err := watch.Next(stream.Context())
 		switch {
@@ -104,12 +115,85 @@ func (s *Server) WatchList(req *pbresource.WatchListRequest, stream pbresource.R
 			return status.Errorf(codes.Internal, "failed read acl: %v", err)
 		}
 
-		if err = stream.Send(event); err != nil {
+		if filter != nil {
+			event, err = filterWatchEvent(filter, matched, event, resource)
+			if err != nil {
+				return err
+			}
+			if event == nil {
+				continue
+			}
+		}
+
+		if err = stream.Send(maskWatchEvent(req.ReadMask, event)); err != nil {
 			return err
 		}
 	}
 }
 
+// maskWatchEvent returns the given upsert or delete event with the read mask
+// applied to its resource. The event is copied as it may be shared with the
+// storage backend.
+func maskWatchEvent(mask *fieldmaskpb.FieldMask, event *pbresource.WatchEvent) *pbresource.WatchEvent {
+	if len(mask.GetPaths()) == 0 {
+		return event
+	}
+	switch {
+	case event.GetUpsert() != nil:
+		return &pbresource.WatchEvent{
+			Event: &pbresource.WatchEvent_Upsert_{
+				Upsert: &pbresource.WatchEvent_Upsert{Resource: applyReadMask(mask, event.GetUpsert().GetResource())},
+			},
+		}
+	case event.GetDelete() != nil:
+		return &pbresource.WatchEvent{
+			Event: &pbresource.WatchEvent_Delete_{
+				Delete: &pbresource.WatchEvent_Delete{Resource: applyReadMask(mask, event.GetDelete().GetResource())},
+			},
+		}
+	default:
+		return event
+	}
+}
+
+// filterWatchEvent applies the metadata filter to the given upsert or delete event,
+// returning the event that should be sent to the watcher (or nil if the event should
+// be dropped).
+func filterWatchEvent(filter *resource.MetadataFilter, matched map[resource.ReferenceKey]struct{}, event *pbresource.WatchEvent, res *pbresource.Resource) (*pbresource.WatchEvent, error) {
+	key := resource.NewReferenceKey(res.Id)
+	_, wasMatched := matched[key]
+
+	if event.GetDelete() != nil {
+		if !wasMatched {
+			return nil, nil
+		}
+		delete(matched, key)
+		return event, nil
+	}
+
+	match, err := filter.Matches(res)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to evaluate filter: %v", err)
+	}
+
+	switch {
+	case match:
+		matched[key] = struct{}{}
+		return event, nil
+	case wasMatched:
+		// The resource no longer matches the filter, so from the watcher's
+		// point of view it has been deleted.
+		delete(matched, key)
+		return &pbresource.WatchEvent{
+			Event: &pbresource.WatchEvent_Delete_{
+				Delete: &pbresource.WatchEvent_Delete{Resource: res},
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
 func (s *Server) ensureWatchListRequestValid(req *pbresource.WatchListRequest) (*resource.Registration, error) {
 	if req.Type == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "type is required")
@@ -143,6 +227,10 @@ func (s *Server) ensureWatchListRequestValid(req *pbresource.WatchListRequest) (
 		return nil, err
 	}
 
+	if err := validateReadMask(req.ReadMask); err != nil {
+		return nil, err
+	}
+
 	return reg, nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
@@ -58,6 +59,16 @@ func TestWatchList_InputValidation(t *testing.T) {
 			modFn:       func(req *pbresource.WatchListRequest) { req.Tenancy.Namespace = "Default" },
 			errContains: "tenancy.namespace invalid",
 		},
+		"filter invalid": {
+			modFn:       func(req *pbresource.WatchListRequest) { req.Filter = "metadata.team ==" },
+			errContains: `filter "metadata.team ==" is invalid`,
+		},
+		"read_mask unknown field": {
+			modFn: func(req *pbresource.WatchListRequest) {
+				req.ReadMask = &fieldmaskpb.FieldMask{Paths: []string{"bogus"}}
+			},
+			errContains: "read_mask invalid",
+		},
 		"namespace too long": {
 			modFn: func(req *pbresource.WatchListRequest) {
 				req.Tenancy.Namespace = strings.Repeat("n", resource.MaxNameLength+1)
@@ -175,6 +186,104 @@ func TestWatchList_GroupVersionMatches(t *testing.T) {
 	prototest.AssertDeepEqual(t, r2.Id, rsp.GetDelete().Resource.Id)
 }
 
+func TestWatchList_Filter(t *testing.T) {
+	t.Parallel()
+
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	ctx := context.Background()
+
+	// create a watch
+	stream, err := client.WatchList(ctx, &pbresource.WatchListRequest{
+		Type:    demo.TypeV2Artist,
+		Tenancy: resource.DefaultNamespacedTenancy(),
+		Filter:  `metadata.team == "payments"`,
+	})
+	require.NoError(t, err)
+	rspCh := handleResourceStream(t, stream)
+
+	mustGetEndOfSnapshot(t, rspCh)
+
+	// insert a non-matching resource and verify no event received
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	artist.Metadata = map[string]string{"team": "billing"}
+
+	r1Resp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+	r1 := r1Resp.Resource
+	mustGetNoResource(t, rspCh)
+
+	// update to match and verify upsert event received
+	r2 := clone(r1)
+	r2.Metadata = map[string]string{"team": "payments"}
+	r2Resp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: r2})
+	require.NoError(t, err)
+	r2 = r2Resp.Resource
+	rsp := mustGetResource(t, rspCh)
+	require.NotNil(t, rsp.GetUpsert())
+	prototest.AssertDeepEqual(t, r2, rsp.GetUpsert().Resource)
+
+	// update to no longer match and verify delete event received
+	r3 := clone(r2)
+	r3.Metadata = map[string]string{"team": "billing"}
+	r3Resp, err := client.Write(ctx, &pbresource.WriteRequest{Resource: r3})
+	require.NoError(t, err)
+	r3 = r3Resp.Resource
+	rsp = mustGetResource(t, rspCh)
+	require.NotNil(t, rsp.GetDelete())
+	prototest.AssertDeepEqual(t, r3, rsp.GetDelete().Resource)
+
+	// delete the non-matching resource and verify no event received
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: r3.Id, Version: r3.Version})
+	require.NoError(t, err)
+	mustGetNoResource(t, rspCh)
+}
+
+func TestWatchList_ReadMask(t *testing.T) {
+	t.Parallel()
+
+	client := svctest.NewResourceServiceBuilder().
+		WithRegisterFns(demo.RegisterTypes).
+		Run(t)
+
+	ctx := context.Background()
+
+	stream, err := client.WatchList(ctx, &pbresource.WatchListRequest{
+		Type:     demo.TypeV2Artist,
+		Tenancy:  resource.DefaultNamespacedTenancy(),
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "version"}},
+	})
+	require.NoError(t, err)
+	rspCh := handleResourceStream(t, stream)
+
+	mustGetEndOfSnapshot(t, rspCh)
+
+	artist, err := demo.GenerateV2Artist()
+	require.NoError(t, err)
+	rsp1, err := client.Write(ctx, &pbresource.WriteRequest{Resource: artist})
+	require.NoError(t, err)
+
+	// Only the fields of the mask are sent.
+	rsp := mustGetResource(t, rspCh)
+	require.NotNil(t, rsp.GetUpsert())
+	prototest.AssertDeepEqual(t, &pbresource.Resource{
+		Id:      rsp1.Resource.Id,
+		Version: rsp1.Resource.Version,
+	}, rsp.GetUpsert().Resource)
+
+	_, err = client.Delete(ctx, &pbresource.DeleteRequest{Id: rsp1.Resource.Id})
+	require.NoError(t, err)
+	rsp = mustGetResource(t, rspCh)
+	require.NotNil(t, rsp.GetDelete())
+	prototest.AssertDeepEqual(t, &pbresource.Resource{
+		Id:      rsp1.Resource.Id,
+		Version: rsp1.Resource.Version,
+	}, rsp.GetDelete().Resource)
+}
+
 func TestWatchList_Tenancy_Defaults_And_Normalization(t *testing.T) {
 	// Test units of tenancy get lowercased and defaulted correctly when empty.
 	for desc, tc := range wildcardTenancyCases() {
@@ -35,7 +35,20 @@ import (
 var errUseWriteStatus = status.Error(codes.InvalidArgument, "resource.status can only be set using the WriteStatus endpoint")
 
 func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbresource.WriteResponse, error) {
-	tenancyMarkedForDeletion, err := s.mutateAndValidate(ctx, req.Resource, true)
+	if req.FieldManager != "" {
+		return s.apply(ctx, req)
+	}
+	if req.Force {
+		return nil, status.Error(codes.InvalidArgument, "force can only be used with field_manager")
+	}
+	return s.write(ctx, req.Resource, false)
+}
+
+// write performs the write of the given resource. If applied is true, the
+// resource is the result of merging an apply write into the stored resource
+// and its managed fields have already been computed.
+func (s *Server) write(ctx context.Context, res *pbresource.Resource, applied bool) (*pbresource.WriteResponse, error) {
+	tenancyMarkedForDeletion, err := s.mutateAndValidate(ctx, res, true)
 	if err != nil {
 		return nil, err
 	}
@@ -51,8 +64,8 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 	// users generally don't need them. If the user is performing a non-CAS write,
 	// we read the current version, and automatically retry if the CAS write fails.
 	var result *pbresource.Resource
-	err = s.retryCAS(ctx, req.Resource.Version, func() error {
-		input := clone(req.Resource)
+	err = s.retryCAS(ctx, res.Version, func() error {
+		input := clone(res)
 
 		// We read with EventualConsistency here because:
 		//
@@ -114,6 +127,11 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 				input.Owner = owner.Id
 			}
 
+			// Only apply writes may claim ownership of fields.
+			if !applied {
+				delete(input.Metadata, resource.ManagedFieldsKey)
+			}
+
 			// TODO(spatel): Revisit owner<->resource tenancy rules post-1.16
 
 		// Update path.
@@ -172,6 +190,14 @@ func (s *Server) Write(ctx context.Context, req *pbresource.WriteRequest) (*pbre
 				return errUseWriteStatus
 			}
 
+			// Writes without a field manager replace the resource's data wholesale,
+			// so field managers lose ownership of any fields changed by them.
+			if !applied {
+				if err := releaseChangedFields(input, existing); err != nil {
+					return err
+				}
+			}
+
 			// If the write is related to a deferred deletion (marking for deletion or removal
 			// of finalizers), make sure nothing else is changed.
 			if err := vetIfDeleteRelated(input, existing, tenancyMarkedForDeletion); err != nil {
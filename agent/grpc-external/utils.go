@@ -4,12 +4,16 @@
 package external
 
 import (
+	"context"
+	"errors"
+
 	"github.com/hashicorp/go-uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/acl/resolver"
+	"github.com/hashicorp/consul/agent/structs"
 )
 
 // We tag logs with a unique identifier to ease debugging. In the future this
@@ -45,6 +49,28 @@ func RequireAnyValidACLToken(resolver ACLResolver, token string) error {
 	return nil
 }
 
+// RPCErrorToStatus converts an error returned by one of the server's net/rpc
+// endpoints into a gRPC status error with an appropriate code. It is intended
+// for services which are implemented in terms of the existing RPC endpoints.
+func RPCErrorToStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case acl.IsErrNotFound(err):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case acl.IsErrPermissionDenied(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, structs.ErrRPCRateExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 func RequireNotNil(v interface{}, name string) {
 	if v == nil {
 		panic(name + " is required")
@@ -8,9 +8,18 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"/hashicorp.consul.acl.ACLService/Logout":                                               {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"/hashicorp.consul.connectca.ConnectCAService/Sign":                                     {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConnectCA},
 	"/hashicorp.consul.connectca.ConnectCAService/WatchRoots":                               {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
+	"/hashicorp.consul.dataplane.DataplaneService/GetEnvoyBootstrap":                        {Type: rate.OperationTypeRead, Category: rate.OperationCategoryDataPlane},
 	"/hashicorp.consul.dataplane.DataplaneService/GetEnvoyBootstrapParams":                  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryDataPlane},
 	"/hashicorp.consul.dataplane.DataplaneService/GetSupportedDataplaneFeatures":            {Type: rate.OperationTypeRead, Category: rate.OperationCategoryDataPlane},
+	"/hashicorp.consul.dataplane.DataplaneService/ReportUpstreamMetrics":                    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryDataPlane},
 	"/hashicorp.consul.dns.DNSService/Query":                                                {Type: rate.OperationTypeRead, Category: rate.OperationCategoryDNS},
+	"/hashicorp.consul.exec.ExecService/Exec":                                               {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryExec},
+	"/hashicorp.consul.health.HealthService/ListNodes":                                      {Type: rate.OperationTypeRead, Category: rate.OperationCategoryCatalog},
+	"/hashicorp.consul.health.HealthService/ListServices":                                   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryCatalog},
+	"/hashicorp.consul.health.HealthService/NodeChecks":                                     {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
+	"/hashicorp.consul.health.HealthService/ServiceHealth":                                  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
+	"/hashicorp.consul.health.HealthService/WatchServiceHealth":                             {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
+	"/hashicorp.consul.health.HealthSubscriptionService/SubscribeServices":                  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
 	"/hashicorp.consul.internal.configentry.ConfigEntryService/GetResolvedExportedServices": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
 	"/hashicorp.consul.internal.operator.OperatorService/TransferLeader":                    {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"/hashicorp.consul.internal.peering.PeeringService/Establish":                           {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryPeering},
@@ -27,6 +36,13 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"/hashicorp.consul.internal.storage.raft.ForwardingService/List":                        {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryResource},
 	"/hashicorp.consul.internal.storage.raft.ForwardingService/Read":                        {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryResource},
 	"/hashicorp.consul.internal.storage.raft.ForwardingService/Write":                       {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryResource},
+	"/hashicorp.consul.kv.KVService/Delete":                                                 {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryKV},
+	"/hashicorp.consul.kv.KVService/Get":                                                    {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
+	"/hashicorp.consul.kv.KVService/List":                                                   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
+	"/hashicorp.consul.kv.KVService/Put":                                                    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryKV},
+	"/hashicorp.consul.kv.KVService/Txn":                                                    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryTxn},
+	"/hashicorp.consul.kv.KVService/Watch":                                                  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryKV},
+	"/hashicorp.consul.registration.RegistrationService/RegisterWorkload":                   {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryCatalog},
 	"/hashicorp.consul.resource.ResourceService/Delete":                                     {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryResource},
 	"/hashicorp.consul.resource.ResourceService/List":                                       {Type: rate.OperationTypeRead, Category: rate.OperationCategoryResource},
 	"/hashicorp.consul.resource.ResourceService/ListByOwner":                                {Type: rate.OperationTypeRead, Category: rate.OperationCategoryResource},
@@ -21,8 +21,10 @@ import (
 	gnmmod "github.com/hashicorp/hcp-sdk-go/clients/cloud-global-network-manager-service/preview/2022-02-15/models"
 	"github.com/hashicorp/hcp-sdk-go/httpclient"
 	"github.com/hashicorp/hcp-sdk-go/resource"
+	hcpversion "github.com/hashicorp/hcp-sdk-go/version"
 
 	"github.com/hashicorp/consul/agent/hcp/config"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/version"
 )
 
@@ -94,10 +96,41 @@ func httpClient(c config.CloudConfig) (*httptransport.Runtime, error) {
 		return nil, err
 	}
 
-	return httpclient.New(httpclient.Config{
+	sourceChannel := "consul " + version.GetHumanVersion()
+	runtime, err := httpclient.New(httpclient.Config{
 		HCPConfig:     cfg,
-		SourceChannel: "consul " + version.GetHumanVersion(),
+		SourceChannel: sourceChannel,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The SDK's transport always takes its proxy from the environment, so
+	// replace it with one that uses the agent's egress proxy configuration.
+	tlsTransport := egressproxy.PooledTransport()
+	tlsTransport.TLSClientConfig = cfg.APITLSConfig()
+	runtime.Transport = &sourceChannelTransport{
+		base: &oauth2.Transport{
+			Base:   tlsTransport,
+			Source: cfg,
+		},
+		sourceChannel: fmt.Sprintf("%s hcp-go-sdk/%s", sourceChannel, hcpversion.Version),
+	}
+
+	return runtime, nil
+}
+
+// sourceChannelTransport stamps requests with the source channel header the
+// HCP SDK's own transport would set.
+type sourceChannelTransport struct {
+	base          http.RoundTripper
+	sourceChannel string
+}
+
+func (t *sourceChannelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-HCP-Source-Channel", t.sourceChannel)
+	return t.base.RoundTrip(req)
 }
 
 // FetchTelemetryConfig obtains telemetry configuration from the Telemetry Gateway.
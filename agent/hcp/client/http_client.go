@@ -8,10 +8,11 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-retryablehttp"
 	"golang.org/x/oauth2"
+
+	"github.com/hashicorp/consul/lib/egressproxy"
 )
 
 const (
@@ -29,7 +30,7 @@ const (
 
 // NewHTTPClient configures the retryable HTTP client.
 func NewHTTPClient(tlsCfg *tls.Config, source oauth2.TokenSource) *retryablehttp.Client {
-	tlsTransport := cleanhttp.DefaultPooledTransport()
+	tlsTransport := egressproxy.PooledTransport()
 	tlsTransport.TLSClientConfig = tlsCfg
 
 	var transport http.RoundTripper = &oauth2.Transport{
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/internal/dnsutil"
@@ -27,6 +28,9 @@ func (s *HTTPHandlers) HealthChecksInState(resp http.ResponseWriter, req *http.R
 		return nil, err
 	}
 	s.parseSource(req, &args.Source)
+	if err := parseSort(req, &args.Sort, true); err != nil {
+		return nil, err
+	}
 	args.NodeMetaFilters = s.parseMetaFilter(req)
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
 		return nil, nil
@@ -117,6 +121,9 @@ func (s *HTTPHandlers) HealthServiceChecks(resp http.ResponseWriter, req *http.R
 		return nil, err
 	}
 	s.parseSource(req, &args.Source)
+	if err := parseSort(req, &args.Sort, true); err != nil {
+		return nil, err
+	}
 	args.NodeMetaFilters = s.parseMetaFilter(req)
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
 		return nil, nil
@@ -182,6 +189,9 @@ func (s *HTTPHandlers) healthServiceNodes(resp http.ResponseWriter, req *http.Re
 		return nil, err
 	}
 	s.parseSource(req, &args.Source)
+	if err := parseSort(req, &args.Sort, true); err != nil {
+		return nil, err
+	}
 	args.NodeMetaFilters = s.parseMetaFilter(req)
 	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
 		return nil, nil
@@ -204,6 +214,11 @@ func (s *HTTPHandlers) healthServiceNodes(resp http.ResponseWriter, req *http.Re
 		args.MergeCentralConfig = true
 	}
 
+	_, includeSidecarProxy := params["sidecar-proxy"]
+	if includeSidecarProxy && healthType != serviceHealth {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "sidecar-proxy is only supported by /v1/health/service/"}
+	}
+
 	// Determine the prefix
 	var prefix string
 	switch healthType {
@@ -271,9 +286,232 @@ func (s *HTTPHandlers) healthServiceNodes(resp http.ResponseWriter, req *http.Re
 			out.Nodes[i].Service = &clone
 		}
 	}
+
+	if includeSidecarProxy {
+		return s.joinSidecarProxies(req, args, out.Nodes, passing)
+	}
 	return out.Nodes, nil
 }
 
+// checkServiceNodeWithSidecarProxy is a service instance joined with the
+// health of its sidecar proxy, returned by /v1/health/service/ with
+// ?sidecar-proxy.
+type checkServiceNodeWithSidecarProxy struct {
+	Node    *structs.Node
+	Service *structs.NodeService
+	Checks  structs.HealthChecks
+
+	// SidecarProxy is omitted for the instances without a sidecar proxy.
+	SidecarProxy *sidecarProxyHealth `json:",omitempty"`
+}
+
+// sidecarProxyHealth is the health of the sidecar proxy of a service
+// instance.
+type sidecarProxyHealth struct {
+	Service *structs.NodeService
+	Checks  structs.HealthChecks
+
+	// XDSStatus is api.XDSStatusConnected or api.XDSStatusDisconnected for
+	// the proxies registered with the agent answering the query, which
+	// serves their xDS stream. It is api.XDSStatusUnknown for the others.
+	XDSStatus string
+}
+
+// joinSidecarProxies looks up the sidecar proxies of the given instances of
+// args.ServiceName and joins each instance with its sidecar. If passing is
+// set, the instances whose sidecar proxy is not passing are left out.
+//
+// The proxies are looked up without blocking: a blocking query only waits
+// for changes to the instances themselves.
+func (s *HTTPHandlers) joinSidecarProxies(req *http.Request, args structs.ServiceSpecificRequest, nodes structs.CheckServiceNodes, passing bool) (interface{}, error) {
+	proxyArgs := args
+	proxyArgs.Connect = true
+	proxyArgs.ServiceTags = nil
+	proxyArgs.TagFilter = false
+	proxyArgs.MergeCentralConfig = false
+	proxyArgs.HealthFilterType = structs.HealthFilterIncludeAll
+	proxyArgs.QueryOptions.Filter = ""
+	proxyArgs.QueryOptions.MinQueryIndex = 0
+	proxyArgs.QueryOptions.MaxQueryTime = 0
+
+	proxies, _, err := s.agent.rpcClientHealth.ServiceNodes(req.Context(), proxyArgs)
+	if err != nil {
+		return nil, err
+	}
+	s.agent.TranslateAddresses(args.Datacenter, proxies.Nodes, dnsutil.TranslateAddressAcceptAny)
+
+	// Index the sidecar proxies by node and destination service instance.
+	type instanceKey struct {
+		node string
+		id   structs.ServiceID
+	}
+	sidecars := make(map[instanceKey]structs.CheckServiceNode)
+	for _, proxy := range proxies.Nodes {
+		if proxy.Node == nil || proxy.Service == nil || proxy.Service.Kind != structs.ServiceKindConnectProxy {
+			continue
+		}
+		key := instanceKey{
+			node: proxy.Node.Node,
+			id:   structs.NewServiceID(proxy.Service.Proxy.DestinationServiceID, &proxy.Service.EnterpriseMeta),
+		}
+		sidecars[key] = proxy
+	}
+
+	connected := make(map[structs.ServiceID]struct{})
+	if s.agent.xdsServer != nil {
+		for _, p := range s.agent.xdsServer.ConnectedProxies() {
+			entMeta := acl.NewEnterpriseMetaWithPartition(p.Partition, p.Namespace)
+			connected[structs.NewServiceID(p.ProxyID, &entMeta)] = struct{}{}
+		}
+	}
+
+	out := make([]checkServiceNodeWithSidecarProxy, 0, len(nodes))
+	for _, node := range nodes {
+		result := checkServiceNodeWithSidecarProxy{
+			Node:    node.Node,
+			Service: node.Service,
+			Checks:  node.Checks,
+		}
+		if node.Node != nil && node.Service != nil {
+			proxy, ok := sidecars[instanceKey{node: node.Node.Node, id: node.Service.CompoundServiceID()}]
+			if ok {
+				if passing && proxy.AggregatedStatus() != api.HealthPassing {
+					continue
+				}
+
+				checks := proxy.Checks
+				if checks == nil {
+					checks = make(structs.HealthChecks, 0)
+				}
+				status := api.XDSStatusUnknown
+				if proxy.Node.PeerName == "" && proxy.Node.Node == s.agent.config.NodeName && s.agent.State.ServiceExists(proxy.Service.CompoundServiceID()) {
+					status = api.XDSStatusDisconnected
+					if _, ok := connected[proxy.Service.CompoundServiceID()]; ok {
+						status = api.XDSStatusConnected
+					}
+				}
+				result.SidecarProxy = &sidecarProxyHealth{
+					Service:   proxy.Service,
+					Checks:    checks,
+					XDSStatus: status,
+				}
+			}
+		}
+		out = append(out, result)
+	}
+	return out, nil
+}
+
+// HealthServicesNodes returns the instances of several services, keyed by
+// service name, so that they can be watched with a single blocking query.
+func (s *HTTPHandlers) HealthServicesNodes(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Set default DC
+	args := structs.ServicesSpecificRequest{}
+	if err := s.parseEntMetaNoWildcard(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+	s.parseSource(req, &args.Source)
+	args.NodeMetaFilters = s.parseMetaFilter(req)
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	params := req.URL.Query()
+	args.PeerName = params.Get("peer")
+	args.ServiceNames = params["service"]
+	if len(args.ServiceNames) == 0 && args.Filter == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing service names or filter"}
+	}
+
+	passing, err := getBoolQueryParam(params, api.HealthPassing)
+	if err != nil {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Invalid value for ?passing"}
+	}
+	args.HealthFilterType = structs.HealthFilterIncludeAll
+	if passing {
+		args.HealthFilterType = structs.HealthFilterIncludeOnlyPassing
+	}
+
+	// Make the RPC request
+	var out structs.IndexedServicesCheckServiceNodes
+	defer setMeta(resp, &out.QueryMeta)
+RETRY_ONCE:
+	if err := s.agent.RPC(req.Context(), "Health.ServicesNodes", &args, &out); err != nil {
+		return nil, err
+	}
+	if args.QueryOptions.AllowStale && args.MaxStaleDuration > 0 && args.MaxStaleDuration < out.LastContact {
+		args.AllowStale = false
+		args.MaxStaleDuration = 0
+		goto RETRY_ONCE
+	}
+	out.ConsistencyLevel = args.QueryOptions.ConsistencyLevel()
+
+	// Use empty lists instead of nil
+	if out.Services == nil {
+		out.Services = make(map[string]structs.CheckServiceNodes)
+	}
+	for name, nodes := range out.Services {
+		s.agent.TranslateAddresses(args.Datacenter, nodes, dnsutil.TranslateAddressAcceptAny)
+
+		if nodes == nil {
+			out.Services[name] = make(structs.CheckServiceNodes, 0)
+		}
+		for i := range nodes {
+			if nodes[i].Checks == nil {
+				nodes[i].Checks = make(structs.HealthChecks, 0)
+			}
+			for j, c := range nodes[i].Checks {
+				if c.ServiceTags == nil {
+					clone := *c
+					clone.ServiceTags = make([]string, 0)
+					nodes[i].Checks[j] = &clone
+				}
+			}
+			if nodes[i].Service != nil && nodes[i].Service.Tags == nil {
+				clone := *nodes[i].Service
+				clone.Tags = make([]string, 0)
+				nodes[i].Service = &clone
+			}
+		}
+	}
+	return out.Services, nil
+}
+
+// HealthSummary returns the number of passing, warning and critical instances
+// of each service in a namespace or partition.
+func (s *HTTPHandlers) HealthSummary(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Set default DC
+	args := structs.DCSpecificRequest{}
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+	args.NodeMetaFilters = s.parseMetaFilter(req)
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+	args.PeerName = req.URL.Query().Get("peer")
+
+	// Make the RPC request
+	var out structs.IndexedServiceHealthSummaries
+	defer setMeta(resp, &out.QueryMeta)
+RETRY_ONCE:
+	if err := s.agent.RPC(req.Context(), "Health.Summary", &args, &out); err != nil {
+		return nil, err
+	}
+	if args.QueryOptions.AllowStale && args.MaxStaleDuration > 0 && args.MaxStaleDuration < out.LastContact {
+		args.AllowStale = false
+		args.MaxStaleDuration = 0
+		goto RETRY_ONCE
+	}
+	out.ConsistencyLevel = args.QueryOptions.ConsistencyLevel()
+
+	// Use empty list instead of nil
+	if out.Summaries == nil {
+		out.Summaries = make([]*structs.ServiceHealthSummary, 0)
+	}
+	return out.Summaries, nil
+}
+
 func getBoolQueryParam(params url.Values, key string) (bool, error) {
 	var param bool
 	if _, ok := params[key]; ok {
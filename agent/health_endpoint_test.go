@@ -42,6 +42,8 @@ func TestHealthEndpointsFailInV2(t *testing.T) {
 	checkRequest("GET", "/v1/health/checks/web")
 	checkRequest("GET", "/v1/health/state/web")
 	checkRequest("GET", "/v1/health/service/web")
+	checkRequest("GET", "/v1/health/services?service=web")
+	checkRequest("GET", "/v1/health/summary")
 	checkRequest("GET", "/v1/health/connect/web")
 	checkRequest("GET", "/v1/health/ingress/web")
 }
@@ -1375,6 +1377,110 @@ func testHealthServiceNodes_Filter(t *testing.T, backendCfg *queryBackendConfigu
 	require.Equal(t, backendCfg.queryBackend, resp.Header().Get("X-Consul-Query-Backend"))
 }
 
+func TestHealthServicesNodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Service names or a filter are required
+	req, _ := http.NewRequest("GET", "/v1/health/services", nil)
+	resp := httptest.NewRecorder()
+	_, err := a.srv.HealthServicesNodes(resp, req)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+
+	args := &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "bar",
+		Address:    "127.0.0.1",
+		Service: &structs.NodeService{
+			ID:      "test",
+			Service: "test",
+		},
+		Check: &structs.HealthCheck{
+			Node:      "bar",
+			Name:      "test check",
+			ServiceID: "test",
+			Status:    api.HealthCritical,
+		},
+	}
+	var out struct{}
+	require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+
+	req, _ = http.NewRequest("GET", "/v1/health/services?dc=dc1&service=test&service=missing", nil)
+	resp = httptest.NewRecorder()
+	obj, err := a.srv.HealthServicesNodes(resp, req)
+	require.NoError(t, err)
+	assertIndex(t, resp)
+
+	services := obj.(map[string]structs.CheckServiceNodes)
+	require.Len(t, services, 2)
+	require.Len(t, services["test"], 1)
+	require.NotNil(t, services["test"][0].Service.Tags)
+	// Should be a non-nil empty list
+	require.NotNil(t, services["missing"])
+	require.Empty(t, services["missing"])
+
+	req, _ = http.NewRequest("GET", "/v1/health/services?dc=dc1&passing&filter="+url.QueryEscape("Service.Service == `test`"), nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.HealthServicesNodes(resp, req)
+	require.NoError(t, err)
+	require.Empty(t, obj.(map[string]structs.CheckServiceNodes))
+}
+
+func TestHealthSummary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	args := &structs.RegisterRequest{
+		Datacenter: "dc1",
+		Node:       "bar",
+		Address:    "127.0.0.1",
+		Service: &structs.NodeService{
+			ID:      "test",
+			Service: "test",
+		},
+		Check: &structs.HealthCheck{
+			Node:      "bar",
+			Name:      "test check",
+			ServiceID: "test",
+			Status:    api.HealthCritical,
+		},
+	}
+	var out struct{}
+	require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+
+	req, _ := http.NewRequest("GET", "/v1/health/summary?dc=dc1&filter="+url.QueryEscape("Service.Service == `test`"), nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.HealthSummary(resp, req)
+	require.NoError(t, err)
+	assertIndex(t, resp)
+
+	summaries := obj.([]*structs.ServiceHealthSummary)
+	require.Len(t, summaries, 1)
+	require.Equal(t, "test", summaries[0].Name)
+	require.Equal(t, 1, summaries[0].Critical)
+
+	// Should be a non-nil empty list
+	req, _ = http.NewRequest("GET", "/v1/health/summary?dc=dc1&filter="+url.QueryEscape("Service.Service == `missing`"), nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.HealthSummary(resp, req)
+	require.NoError(t, err)
+	require.NotNil(t, obj)
+	require.Empty(t, obj.([]*structs.ServiceHealthSummary))
+}
+
 func TestHealthServiceNodes_DistanceSort(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1458,6 +1564,73 @@ func TestHealthServiceNodes_DistanceSort(t *testing.T) {
 	})
 }
 
+func TestHealthServiceNodes_Sort(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	var out struct{}
+	for node, status := range map[string]string{
+		"foo": api.HealthPassing,
+		"bar": api.HealthCritical,
+		"baz": api.HealthWarning,
+	} {
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       node,
+			Address:    "127.0.0.1",
+			Service: &structs.NodeService{
+				ID:      "test",
+				Service: "test",
+			},
+			Check: &structs.HealthCheck{
+				Node:      node,
+				Name:      "test check",
+				ServiceID: "test",
+				Status:    status,
+			},
+		}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	names := func(nodes structs.CheckServiceNodes) []string {
+		var out []string
+		for _, node := range nodes {
+			out = append(out, node.Node.Node)
+		}
+		return out
+	}
+
+	// The least healthy instances come first.
+	req, _ := http.NewRequest("GET", "/v1/health/service/test?dc=dc1&sort=-status", nil)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.HealthServiceNodes(resp, req)
+	require.NoError(t, err)
+	assertIndex(t, resp)
+	require.Equal(t, []string{"bar", "baz", "foo"}, names(obj.(structs.CheckServiceNodes)))
+
+	req, _ = http.NewRequest("GET", "/v1/health/service/test?dc=dc1&sort=node", nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.HealthServiceNodes(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", "baz", "foo"}, names(obj.(structs.CheckServiceNodes)))
+
+	req, _ = http.NewRequest("GET", "/v1/health/service/test?dc=dc1&sort=-node", nil)
+	resp = httptest.NewRecorder()
+	obj, err = a.srv.HealthServiceNodes(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo", "baz", "bar"}, names(obj.(structs.CheckServiceNodes)))
+
+	req, _ = http.NewRequest("GET", "/v1/health/service/test?dc=dc1&sort=address", nil)
+	_, err = a.srv.HealthServiceNodes(httptest.NewRecorder(), req)
+	require.True(t, isHTTPBadRequest(err), "expected a bad request error, got %v", err)
+}
+
 func TestHealthServiceNodes_PassingFilter(t *testing.T) {
 	for _, cfg := range queryBackendConfigs {
 		t.Run(cfg.name, func(t *testing.T) {
@@ -2197,3 +2370,103 @@ func waitForStreamingToBeReady(t *testing.T, a *TestAgent) {
 		require.True(r, a.rpcClientHealth.IsReadyForStreaming())
 	})
 }
+
+func TestHealthServiceNodes_SidecarProxy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Register an instance with a sidecar proxy on the agent, which serves the
+	// xDS stream of the proxy.
+	web1 := &structs.NodeService{ID: "web1", Service: "web", Port: 8080}
+	require.NoError(t, a.addServiceFromSource(web1, nil, false, "", ConfigSourceLocal))
+	proxy1 := &structs.NodeService{
+		Kind:    structs.ServiceKindConnectProxy,
+		ID:      "web1-sidecar-proxy",
+		Service: "web-sidecar-proxy",
+		Port:    21000,
+		Proxy: structs.ConnectProxyConfig{
+			DestinationServiceName: "web",
+			DestinationServiceID:   "web1",
+		},
+	}
+	require.NoError(t, a.addServiceFromSource(proxy1, nil, false, "", ConfigSourceLocal))
+
+	// Register an instance with a failing sidecar proxy, and one without a
+	// sidecar proxy, on another node.
+	register := func(svc *structs.NodeService, check *structs.HealthCheck) {
+		args := &structs.RegisterRequest{
+			Datacenter: "dc1",
+			Node:       "other",
+			Address:    "127.0.0.2",
+			Service:    svc,
+			Check:      check,
+		}
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+	register(&structs.NodeService{ID: "web2", Service: "web", Port: 8080}, nil)
+	register(&structs.NodeService{
+		Kind:    structs.ServiceKindConnectProxy,
+		ID:      "web2-sidecar-proxy",
+		Service: "web-sidecar-proxy",
+		Port:    21000,
+		Proxy: structs.ConnectProxyConfig{
+			DestinationServiceName: "web",
+			DestinationServiceID:   "web2",
+		},
+	}, &structs.HealthCheck{
+		Node:      "other",
+		CheckID:   "proxy-alive",
+		Name:      "proxy alive",
+		ServiceID: "web2-sidecar-proxy",
+		Status:    api.HealthCritical,
+	})
+	register(&structs.NodeService{ID: "web3", Service: "web", Port: 8080}, nil)
+
+	query := func(t require.TestingT, url string) map[string]checkServiceNodeWithSidecarProxy {
+		req, _ := http.NewRequest("GET", url, nil)
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.HealthServiceNodes(resp, req)
+		require.NoError(t, err)
+		out := make(map[string]checkServiceNodeWithSidecarProxy)
+		for _, node := range obj.([]checkServiceNodeWithSidecarProxy) {
+			out[node.Service.ID] = node
+		}
+		return out
+	}
+
+	// The proxy registered with the agent is disconnected, the status of the
+	// others is unknown to the agent.
+	retry.Run(t, func(r *retry.R) {
+		nodes := query(r, "/v1/health/service/web?sidecar-proxy")
+		require.Len(r, nodes, 3)
+
+		require.NotNil(r, nodes["web1"].SidecarProxy)
+		require.Equal(r, "web1-sidecar-proxy", nodes["web1"].SidecarProxy.Service.ID)
+		require.Equal(r, api.XDSStatusDisconnected, nodes["web1"].SidecarProxy.XDSStatus)
+
+		require.NotNil(r, nodes["web2"].SidecarProxy)
+		require.Equal(r, "web2-sidecar-proxy", nodes["web2"].SidecarProxy.Service.ID)
+		require.Len(r, nodes["web2"].SidecarProxy.Checks, 1)
+		require.Equal(r, api.XDSStatusUnknown, nodes["web2"].SidecarProxy.XDSStatus)
+
+		require.Nil(r, nodes["web3"].SidecarProxy)
+	})
+
+	// The instances whose sidecar proxy is failing are not passing.
+	nodes := query(t, "/v1/health/service/web?sidecar-proxy&passing")
+	require.Len(t, nodes, 2)
+	require.Contains(t, nodes, "web1")
+	require.Contains(t, nodes, "web3")
+
+	// Sidecar proxies are only joined to service instances.
+	req, _ := http.NewRequest("GET", "/v1/health/connect/web?sidecar-proxy", nil)
+	_, err := a.srv.HealthConnectServiceNodes(httptest.NewRecorder(), req)
+	require.True(t, isHTTPBadRequest(err), fmt.Sprintf("Expected bad request HTTP error but got %v", err))
+}
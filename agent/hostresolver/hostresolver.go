@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package hostresolver configures the resolver of the host to forward the
+// queries for the Consul domains to the DNS server of the local agent, so that
+// applications can resolve them without any further setup.
+package hostresolver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// ModeAuto configures systemd-resolved when it is running, and
+	// resolvconf otherwise.
+	ModeAuto = "auto"
+
+	// ModeSystemdResolved adds a drop-in to the systemd-resolved
+	// configuration, routing the Consul domains to the agent.
+	ModeSystemdResolved = "systemd-resolved"
+
+	// ModeResolvconf registers the agent as a nameserver with resolvconf.
+	// resolv.conf has no notion of split DNS, so every query is sent to the
+	// agent first.
+	ModeResolvconf = "resolvconf"
+)
+
+// Modes are the supported values of Config.Mode.
+var Modes = []string{ModeAuto, ModeSystemdResolved, ModeResolvconf}
+
+// Config is the configuration of the host resolver integration.
+type Config struct {
+	// Mode is the host resolver to configure. It defaults to ModeAuto.
+	Mode string
+
+	// Domains are the DNS domains answered by the agent, like "consul.".
+	Domains []string
+
+	// Addrs are the addresses the agent's DNS server listens on. Unspecified
+	// addresses are replaced by the loopback address.
+	Addrs []net.Addr
+
+	Logger hclog.Logger
+}
+
+// resolver is a host resolver which can be configured to forward queries to
+// the agent.
+type resolver interface {
+	// configure forwards the queries for the domains to the nameservers,
+	// replacing any previous configuration.
+	configure(nameservers []netip.AddrPort, domains []string) error
+
+	// cleanup removes the configuration.
+	cleanup() error
+}
+
+// commandRunner runs a command, feeding it stdin when it isn't nil.
+type commandRunner func(stdin []byte, name string, args ...string) error
+
+// Manager configures the host resolver when the agent starts, and restores it
+// when the agent shuts down.
+type Manager struct {
+	logger      hclog.Logger
+	mode        string
+	resolver    resolver
+	nameservers []netip.AddrPort
+	domains     []string
+
+	lock       sync.Mutex
+	configured bool
+}
+
+// New returns a Manager for the resolver of the host selected by the
+// configuration. It fails when the resolver isn't available.
+func New(cfg Config) (*Manager, error) {
+	if err := checkPlatform(); err != nil {
+		return nil, err
+	}
+
+	nameservers, err := nameserversFromAddrs(cfg.Addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := cfg.Mode
+	if mode == "" || mode == ModeAuto {
+		mode, err = detect(resolvedRuntimeDir, exec.LookPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var r resolver
+	switch mode {
+	case ModeSystemdResolved:
+		r = newSystemdResolved(resolvedDropInDir, runCommand)
+	case ModeResolvconf:
+		r = newResolvconf(runCommand)
+	default:
+		return nil, fmt.Errorf("unsupported host resolver mode %q", mode)
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &Manager{
+		logger:      logger,
+		mode:        mode,
+		resolver:    r,
+		nameservers: nameservers,
+		domains:     trimDomains(cfg.Domains),
+	}, nil
+}
+
+// Mode returns the host resolver the Manager configures.
+func (m *Manager) Mode() string {
+	return m.mode
+}
+
+// Start configures the host resolver to forward the queries for the Consul
+// domains to the agent.
+func (m *Manager) Start() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.resolver.configure(m.nameservers, m.domains); err != nil {
+		return fmt.Errorf("failed to configure %s: %w", m.mode, err)
+	}
+	m.configured = true
+
+	m.logger.Info("Configured the host resolver to forward queries to the agent",
+		"resolver", m.mode,
+		"nameservers", m.nameservers,
+		"domains", m.domains,
+	)
+	return nil
+}
+
+// Stop removes the configuration added by Start. It is a no-op when the
+// host resolver wasn't configured.
+func (m *Manager) Stop() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.configured {
+		return nil
+	}
+	if err := m.resolver.cleanup(); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", m.mode, err)
+	}
+	m.configured = false
+
+	m.logger.Info("Removed the agent from the host resolver configuration", "resolver", m.mode)
+	return nil
+}
+
+// detect returns the host resolver to configure in ModeAuto.
+func detect(resolvedDir string, lookPath func(string) (string, error)) (string, error) {
+	// systemd-resolved creates its runtime directory when it starts.
+	if info, err := os.Stat(resolvedDir); err == nil && info.IsDir() {
+		return ModeSystemdResolved, nil
+	}
+	if _, err := lookPath("resolvconf"); err == nil {
+		return ModeResolvconf, nil
+	}
+	return "", errors.New("no supported host resolver found: systemd-resolved isn't running and resolvconf isn't installed")
+}
+
+// nameserversFromAddrs returns the UDP addresses of the agent's DNS server,
+// replacing the unspecified addresses by the loopback address.
+func nameserversFromAddrs(addrs []net.Addr) ([]netip.AddrPort, error) {
+	var out []netip.AddrPort
+	seen := make(map[netip.AddrPort]struct{})
+	for _, addr := range addrs {
+		udp, ok := addr.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+
+		ip, ok := netip.AddrFromSlice(udp.IP)
+		if !ok {
+			continue
+		}
+		ip = ip.Unmap()
+		if ip.IsUnspecified() {
+			if ip.Is4() {
+				ip = netip.AddrFrom4([4]byte{127, 0, 0, 1})
+			} else {
+				ip = netip.IPv6Loopback()
+			}
+		}
+
+		ns := netip.AddrPortFrom(ip, uint16(udp.Port))
+		if _, ok := seen[ns]; ok {
+			continue
+		}
+		seen[ns] = struct{}{}
+		out = append(out, ns)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("the DNS server doesn't listen on any UDP address")
+	}
+	return out, nil
+}
+
+// trimDomains removes the trailing dot of the domains, and the duplicates.
+func trimDomains(domains []string) []string {
+	var out []string
+	seen := make(map[string]struct{})
+	for _, d := range domains {
+		d = strings.TrimSuffix(d, ".")
+		if d == "" {
+			continue
+		}
+		if _, ok := seen[d]; ok {
+			continue
+		}
+		seen[d] = struct{}{}
+		out = append(out, d)
+	}
+	return out
+}
+
+func runCommand(stdin []byte, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var output bytes.Buffer
+	cmd.Stdout = io.Discard
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
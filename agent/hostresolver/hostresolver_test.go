@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostresolver
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+type command struct {
+	stdin string
+	name  string
+	args  []string
+}
+
+type fakeRunner struct {
+	commands []command
+	err      error
+}
+
+func (f *fakeRunner) run(stdin []byte, name string, args ...string) error {
+	f.commands = append(f.commands, command{stdin: string(stdin), name: name, args: args})
+	return f.err
+}
+
+func TestNameserversFromAddrs(t *testing.T) {
+	addrs := []net.Addr{
+		&net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 8600},
+		&net.UDPAddr{IP: net.ParseIP("0.0.0.0"), Port: 8600},
+		&net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8600},
+		&net.UDPAddr{IP: net.ParseIP("::"), Port: 8600},
+		&net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 53},
+	}
+	nameservers, err := nameserversFromAddrs(addrs)
+	require.NoError(t, err)
+	require.Equal(t, []netip.AddrPort{
+		netip.MustParseAddrPort("127.0.0.1:8600"),
+		netip.MustParseAddrPort("[::1]:8600"),
+		netip.MustParseAddrPort("10.0.0.1:53"),
+	}, nameservers)
+
+	_, err = nameserversFromAddrs([]net.Addr{&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8600}})
+	require.Error(t, err)
+}
+
+func TestTrimDomains(t *testing.T) {
+	require.Equal(t, []string{"consul", "example.com"}, trimDomains([]string{"consul.", "example.com.", "consul", ""}))
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	noResolvconf := func(string) (string, error) { return "", errors.New("not found") }
+	withResolvconf := func(string) (string, error) { return "/sbin/resolvconf", nil }
+
+	mode, err := detect(dir, noResolvconf)
+	require.NoError(t, err)
+	require.Equal(t, ModeSystemdResolved, mode)
+
+	missing := filepath.Join(dir, "missing")
+	mode, err = detect(missing, withResolvconf)
+	require.NoError(t, err)
+	require.Equal(t, ModeResolvconf, mode)
+
+	_, err = detect(missing, noResolvconf)
+	require.Error(t, err)
+}
+
+func TestSystemdResolved(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "resolved.conf.d")
+	runner := &fakeRunner{}
+	r := newSystemdResolved(dir, runner.run)
+
+	nameservers := []netip.AddrPort{
+		netip.MustParseAddrPort("127.0.0.1:8600"),
+		netip.MustParseAddrPort("[::1]:8600"),
+	}
+	require.NoError(t, r.configure(nameservers, []string{"consul", "example.com"}))
+
+	content, err := os.ReadFile(filepath.Join(dir, "consul.conf"))
+	require.NoError(t, err)
+	require.Equal(t, `# Routes the Consul domains to the local Consul agent. This file is managed
+# by the agent, which removes it when it shuts down.
+[Resolve]
+DNS=127.0.0.1:8600
+DNS=[::1]:8600
+Domains=~consul ~example.com
+`, string(content))
+
+	reload := command{name: "systemctl", args: []string{"reload-or-restart", "systemd-resolved"}}
+	require.Equal(t, []command{reload}, runner.commands)
+
+	require.NoError(t, r.cleanup())
+	require.NoFileExists(t, filepath.Join(dir, "consul.conf"))
+	require.Equal(t, []command{reload, reload}, runner.commands)
+
+	// Cleaning up again doesn't reload systemd-resolved.
+	require.NoError(t, r.cleanup())
+	require.Len(t, runner.commands, 2)
+
+	require.Error(t, r.configure(nameservers, nil))
+}
+
+func TestResolvconf(t *testing.T) {
+	runner := &fakeRunner{}
+	r := newResolvconf(runner.run)
+
+	nameservers := []netip.AddrPort{
+		netip.MustParseAddrPort("127.0.0.1:53"),
+		netip.MustParseAddrPort("[::1]:53"),
+	}
+	require.NoError(t, r.configure(nameservers, []string{"consul"}))
+	require.NoError(t, r.cleanup())
+	require.Equal(t, []command{
+		{stdin: "nameserver 127.0.0.1\nnameserver ::1\n", name: "resolvconf", args: []string{"-a", "lo.consul"}},
+		{name: "resolvconf", args: []string{"-d", "lo.consul"}},
+	}, runner.commands)
+
+	// resolv.conf can't set the port of a nameserver.
+	err := r.configure([]netip.AddrPort{netip.MustParseAddrPort("127.0.0.1:8600")}, []string{"consul"})
+	require.ErrorContains(t, err, "port 53")
+}
+
+func TestManager(t *testing.T) {
+	runner := &fakeRunner{}
+	m := &Manager{
+		logger:      hclog.NewNullLogger(),
+		mode:        ModeResolvconf,
+		resolver:    newResolvconf(runner.run),
+		nameservers: []netip.AddrPort{netip.MustParseAddrPort("127.0.0.1:53")},
+		domains:     []string{"consul"},
+	}
+
+	// Stopping a manager which wasn't started is a no-op.
+	require.NoError(t, m.Stop())
+	require.Empty(t, runner.commands)
+
+	require.NoError(t, m.Start())
+	require.NoError(t, m.Stop())
+	require.NoError(t, m.Stop())
+	require.Len(t, runner.commands, 2)
+
+	// A failed configuration isn't cleaned up.
+	runner.err = errors.New("permission denied")
+	require.ErrorContains(t, m.Start(), "failed to configure resolvconf")
+	require.NoError(t, m.Stop())
+	require.Len(t, runner.commands, 3)
+}
@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package hostresolver
+
+import "errors"
+
+func checkPlatform() error {
+	return errors.New("the host resolver integration is only supported on Linux")
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostresolver
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// resolvconfInterface is the name the agent's nameservers are registered
+// under. resolvconf orders the "lo.*" interfaces first, so the agent is the
+// first nameserver in resolv.conf.
+const resolvconfInterface = "lo.consul"
+
+// resolvconf registers the agent as a nameserver with resolvconf(8). The
+// nameservers of resolv.conf listen on the standard DNS port, so the agent's
+// DNS server must listen on port 53.
+type resolvconf struct {
+	run commandRunner
+}
+
+func newResolvconf(run commandRunner) *resolvconf {
+	return &resolvconf{run: run}
+}
+
+func (r *resolvconf) configure(nameservers []netip.AddrPort, _ []string) error {
+	var b strings.Builder
+	for _, ns := range nameservers {
+		if ns.Port() != 53 {
+			return fmt.Errorf("resolv.conf nameservers must listen on port 53, the DNS server listens on %s", ns)
+		}
+		fmt.Fprintf(&b, "nameserver %s\n", ns.Addr())
+	}
+	return r.run([]byte(b.String()), "resolvconf", "-a", resolvconfInterface)
+}
+
+func (r *resolvconf) cleanup() error {
+	return r.run(nil, "resolvconf", "-d", resolvconfInterface)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostresolver
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/consul/lib/file"
+)
+
+const (
+	// resolvedRuntimeDir exists while systemd-resolved is running.
+	resolvedRuntimeDir = "/run/systemd/resolve"
+
+	// resolvedDropInDir is the directory of the systemd-resolved drop-ins.
+	resolvedDropInDir = "/etc/systemd/resolved.conf.d"
+
+	// resolvedDropInName is the name of the drop-in managed by the agent.
+	resolvedDropInName = "consul.conf"
+)
+
+// systemdResolved routes the Consul domains to the agent with a
+// systemd-resolved drop-in. The "~" prefix of the domains makes them routing
+// only domains: they select the agent as the nameserver of the queries for
+// these domains without being added to the search list.
+type systemdResolved struct {
+	dropInPath string
+	run        commandRunner
+}
+
+func newSystemdResolved(dropInDir string, run commandRunner) *systemdResolved {
+	return &systemdResolved{
+		dropInPath: filepath.Join(dropInDir, resolvedDropInName),
+		run:        run,
+	}
+}
+
+func (r *systemdResolved) configure(nameservers []netip.AddrPort, domains []string) error {
+	if len(domains) == 0 {
+		return fmt.Errorf("no domain to route to the agent")
+	}
+
+	var b strings.Builder
+	b.WriteString("# Routes the Consul domains to the local Consul agent. This file is managed\n")
+	b.WriteString("# by the agent, which removes it when it shuts down.\n")
+	b.WriteString("[Resolve]\n")
+	for _, ns := range nameservers {
+		fmt.Fprintf(&b, "DNS=%s\n", ns)
+	}
+	b.WriteString("Domains=")
+	for i, d := range domains {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString("~" + d)
+	}
+	b.WriteString("\n")
+
+	if err := file.WriteAtomicWithPerms(r.dropInPath, []byte(b.String()), 0755, 0644); err != nil {
+		return err
+	}
+	return r.reload()
+}
+
+func (r *systemdResolved) cleanup() error {
+	if err := os.Remove(r.dropInPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return r.reload()
+}
+
+func (r *systemdResolved) reload() error {
+	return r.run(nil, "systemctl", "reload-or-restart", "systemd-resolved")
+}
@@ -422,6 +422,9 @@ func (s *HTTPHandlers) wrap(handler endpoint, methods []string) http.HandlerFunc
 			if e, ok := status.FromError(err); ok && e.Code() == codes.PermissionDenied {
 				return true
 			}
+			if structs.IsErrQuotaExceeded(err) {
+				return true
+			}
 			return false
 		}
 
@@ -584,6 +587,12 @@ func (s *HTTPHandlers) wrap(handler endpoint, methods []string) http.HandlerFunc
 				}
 			}
 
+			if err == nil && s.agent.tokenUsage != nil {
+				var token string
+				s.parseToken(req, &token)
+				s.agent.recordTokenUsage(req, token)
+			}
+
 			if err == nil {
 				// Invoke the handler
 				if rejectCatalogV1Endpoint {
@@ -1036,6 +1045,17 @@ func (s *HTTPHandlers) parseConsistency(resp http.ResponseWriter, req *http.Requ
 			defaults = false
 		}
 	}
+	if boundedStale := query.Get("bounded-stale"); boundedStale != "" {
+		dur, err := time.ParseDuration(boundedStale)
+		if err != nil || dur <= 0 {
+			resp.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(resp, "Invalid bounded-stale value %q", boundedStale)
+			return true
+		}
+		b.SetBoundedStaleness(dur)
+		b.SetAllowStale(true)
+		defaults = false
+	}
 	// No specific Consistency has been specified by caller
 	if defaults {
 		path := req.URL.Path
@@ -1132,11 +1152,34 @@ func (s *HTTPHandlers) clearTokenFromHeaders(req *http.Request) {
 }
 
 // parseTokenWithDefault passes through to parseTokenInternal and optionally resolves proxy tokens to real ACL tokens.
-// If the token is invalid or not specified it will populate the token with the agents UserToken (acl_token in the
-// consul configuration)
+// If the token is not specified it will populate the token with one obtained for the request's verified client
+// certificate (http_config.client_cert_auth_method) or Unix domain socket peer credentials
+// (unix_sockets.auth_method), or else with the agents UserToken (acl_token in the consul configuration)
 func (s *HTTPHandlers) parseTokenWithDefault(req *http.Request, token *string) {
 	s.parseTokenInternal(req, token) // parseTokenInternal modifies *token
 	if token != nil && *token == "" {
+		certToken, err := s.agent.clientCertLoginToken(req)
+		if err != nil {
+			s.agent.logger.Warn("failed to log in with client certificate, falling back to the default token",
+				"from", req.RemoteAddr,
+				"error", err,
+			)
+		}
+		if certToken != "" {
+			*token = certToken
+			return
+		}
+		socketToken, err := s.agent.socketCredLoginToken(req)
+		if err != nil {
+			s.agent.logger.Warn("failed to log in with Unix domain socket peer credentials, falling back to the default token",
+				"from", req.RemoteAddr,
+				"error", err,
+			)
+		}
+		if socketToken != "" {
+			*token = socketToken
+			return
+		}
 		*token = s.agent.tokens.UserToken()
 		return
 	}
@@ -1202,6 +1245,25 @@ func (s *HTTPHandlers) parseSource(req *http.Request, source *structs.QuerySourc
 	}
 }
 
+// parseSort is used to parse the ?sort query param, used to sort the results
+// of the catalog and health list endpoints. Sorting by status is only allowed
+// for the health endpoints.
+func parseSort(req *http.Request, sort *string, health bool) error {
+	spec := req.URL.Query().Get("sort")
+	if spec == "" {
+		return nil
+	}
+	key, _, err := structs.ParseSort(spec)
+	if err != nil {
+		return HTTPError{StatusCode: http.StatusBadRequest, Reason: err.Error()}
+	}
+	if key == structs.SortByStatus && !health {
+		return HTTPError{StatusCode: http.StatusBadRequest, Reason: "Sorting by status is only supported by the health endpoints"}
+	}
+	*sort = spec
+	return nil
+}
+
 func (s *HTTPHandlers) parsePeerName(req *http.Request, args *structs.ServiceSpecificRequest) {
 	if peer := req.URL.Query().Get("peer"); peer != "" {
 		args.PeerName = peer
@@ -1313,6 +1375,7 @@ type QueryOptionsCompat interface {
 	SetMaxAge(time.Duration)
 	SetMaxStaleDuration(time.Duration)
 	SetStaleIfError(time.Duration)
+	SetBoundedStaleness(time.Duration)
 
 	SetMaxQueryTime(time.Duration)
 	SetMinQueryIndex(uint64)
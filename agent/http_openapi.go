@@ -0,0 +1,392 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+//go:generate go run ../internal/tools/http-openapi-gen -agent .
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/version"
+)
+
+// openAPIEndpoint describes an endpoint registered with registerEndpoint. The
+// descriptions are generated from the source of the handlers by
+// http-openapi-gen in http_openapi_gen.go.
+type openAPIEndpoint struct {
+	// PathParam is the name of the parameter a prefix endpoint takes from the
+	// rest of the path.
+	PathParam string
+
+	// Operations are keyed by HTTP method.
+	Operations map[string]openAPIOperation
+}
+
+type openAPIOperation struct {
+	ID          string
+	QueryParams []string
+
+	// Request and Response return the types of the request body and of the
+	// response, they are nil when the generator could not determine them.
+	Request  func() reflect.Type
+	Response func() reflect.Type
+}
+
+// openAPITypeOf returns the static type of its argument, so that the compiler
+// resolves the types of the request and response values of the generated
+// operations.
+func openAPITypeOf[T any](T) reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// openAPIParam describes a query parameter common to many endpoints.
+type openAPIParam struct {
+	Type        string
+	Description string
+	// Flag is set for the parameters that are set by their presence, as in
+	// ?stale.
+	Flag bool
+}
+
+var openAPIParams = map[string]openAPIParam{
+	"bounded-stale": {Description: "Serve the read from a follower whose data is at most this old, as in 5s."},
+	"cached":        {Flag: true, Description: "Serve the read from the agent cache."},
+	"consistent":    {Flag: true, Description: "Require the leader to verify its leadership before serving the read."},
+	"datacenter":    {Description: "The datacenter to query, takes precedence over dc."},
+	"dc":            {Description: "The datacenter to query, defaults to the datacenter of the agent."},
+	"filter":        {Description: "A filter expression applied to the results."},
+	"index":         {Type: "integer", Description: "Block until the result changes past this index."},
+	"leader":        {Flag: true, Description: "Use the default consistency mode."},
+	"max_stale":     {Description: "Serve the read from a follower unless its data is older than this duration."},
+	"near":          {Description: "Sort the results by round trip time from this node."},
+	"node-meta":     {Description: "Only return the nodes with this metadata, as key:value. Can be repeated."},
+	"ns":            {Description: "The namespace of the resources (Enterprise)."},
+	"partition":     {Description: "The admin partition of the resources (Enterprise)."},
+	"peer":          {Description: "The name of the cluster peer whose imported resources are queried."},
+	"pretty":        {Flag: true, Description: "Indent the JSON response."},
+	"stale":         {Flag: true, Description: "Allow any server to serve the read, the results may be stale."},
+	"token":         {Description: "The ACL token of the request, prefer the X-Consul-Token header."},
+	"wait":          {Description: "The maximum duration of a blocking query, as in 5m."},
+}
+
+// OpenAPI serves the OpenAPI document describing the HTTP API of the agent.
+// GET /v1/openapi.json
+func (s *HTTPHandlers) OpenAPI(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	return openAPIDocument(), nil
+}
+
+var (
+	openAPIDocumentOnce sync.Once
+	openAPIDocumentData map[string]interface{}
+)
+
+// openAPIDocument returns the OpenAPI document of the registered endpoints,
+// built once since they don't change at runtime.
+func openAPIDocument() map[string]interface{} {
+	openAPIDocumentOnce.Do(func() {
+		openAPIDocumentData = buildOpenAPIDocument(allowedMethods, openAPIEndpoints)
+	})
+	return openAPIDocumentData
+}
+
+// buildOpenAPIDocument returns the OpenAPI document of the endpoints with the
+// given patterns and methods, described by the generated descriptions.
+func buildOpenAPIDocument(methods map[string][]string, descriptions map[string]openAPIEndpoint) map[string]interface{} {
+	schemas := newOpenAPISchemas()
+
+	patterns := make([]string, 0, len(methods))
+	for pattern := range methods {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	paths := make(map[string]interface{})
+	for _, pattern := range patterns {
+		desc := descriptions[pattern]
+
+		path := pattern
+		var pathParams []interface{}
+		if strings.HasSuffix(pattern, "/") {
+			name := desc.PathParam
+			if name == "" {
+				name = "path"
+			}
+			path += "{" + name + "}"
+			pathParams = append(pathParams, map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+
+		// The endpoints registered without methods check them in their
+		// handler, the generator found them there.
+		endpointMethods := methods[pattern]
+		if len(endpointMethods) == 0 {
+			for method := range desc.Operations {
+				endpointMethods = append(endpointMethods, method)
+			}
+			sort.Strings(endpointMethods)
+		}
+
+		item := make(map[string]interface{})
+		for _, method := range endpointMethods {
+			op := desc.Operations[method]
+			item[strings.ToLower(method)] = openAPIOperationObject(pattern, method, op, pathParams, schemas)
+		}
+		paths[path] = item
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Consul Agent HTTP API",
+			"version": version.GetHumanVersion(),
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"ConsulToken": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-Consul-Token",
+				},
+			},
+		},
+		// The token is optional, the anonymous token is used without one.
+		"security": []interface{}{
+			map[string]interface{}{"ConsulToken": []string{}},
+			map[string]interface{}{},
+		},
+	}
+}
+
+func openAPIOperationObject(pattern, method string, op openAPIOperation, pathParams []interface{}, schemas *openAPISchemas) map[string]interface{} {
+	id := op.ID
+	if id == "" {
+		id = strings.ToLower(method) + strings.ReplaceAll(pattern, "/", "_")
+	}
+
+	params := append([]interface{}{}, pathParams...)
+	queryParams := append([]string{"pretty"}, op.QueryParams...)
+	sort.Strings(queryParams)
+	for i, name := range queryParams {
+		if i > 0 && queryParams[i-1] == name {
+			continue
+		}
+		param := openAPIParams[name]
+		typ := param.Type
+		if typ == "" {
+			typ = "string"
+		}
+		if param.Flag {
+			typ = "boolean"
+		}
+		obj := map[string]interface{}{
+			"name":   name,
+			"in":     "query",
+			"schema": map[string]interface{}{"type": typ},
+		}
+		if param.Description != "" {
+			obj["description"] = param.Description
+		}
+		if param.Flag {
+			obj["allowEmptyValue"] = true
+		}
+		params = append(params, obj)
+	}
+
+	obj := map[string]interface{}{
+		"operationId": id,
+		"tags":        []string{openAPITag(pattern)},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "The request succeeded.",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": schemas.schema(openAPIType(op.Response)),
+					},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "The request failed.",
+				"content": map[string]interface{}{
+					"text/plain": map[string]interface{}{
+						"schema": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+	if len(params) > 0 {
+		obj["parameters"] = params
+	}
+	if typ := openAPIType(op.Request); typ != nil {
+		obj["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemas.schema(typ),
+				},
+			},
+		}
+	}
+	return obj
+}
+
+// openAPIType returns the type returned by fn, or nil if fn is nil or fails,
+// as when the generated expression selects a field promoted through a nil
+// embedded pointer.
+func openAPIType(fn func() reflect.Type) (typ reflect.Type) {
+	if fn == nil {
+		return nil
+	}
+	defer func() {
+		if recover() != nil {
+			typ = nil
+		}
+	}()
+	return fn()
+}
+
+// openAPITag returns the tag grouping the operations of an endpoint, which is
+// the first segment of its path after the version, as in "catalog".
+func openAPITag(pattern string) string {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// openAPISchemas builds the schemas of Go types from their JSON encoding. The
+// named struct types are described once in the components and referenced.
+type openAPISchemas struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+func newOpenAPISchemas() *openAPISchemas {
+	return &openAPISchemas{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+var (
+	openAPITimeType     = reflect.TypeOf(time.Time{})
+	openAPIComponentsRE = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+// schema returns the schema of the type, or an empty schema matching any
+// value if the type is nil.
+func (s *openAPISchemas) schema(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == openAPITimeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + s.component(t)}
+	default:
+		// Interfaces, and the kinds without a JSON encoding.
+		return map[string]interface{}{}
+	}
+}
+
+// component returns the name of the component describing the named struct
+// type, adding it to the components on first use.
+func (s *openAPISchemas) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	base := t.Name()
+	if pkg := t.PkgPath(); pkg != "" {
+		base = pkg[strings.LastIndex(pkg, "/")+1:] + "." + base
+	}
+	base = openAPIComponentsRE.ReplaceAllString(base, "_")
+	name := base
+	for i := 2; s.components[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+
+	// Register the name before describing the fields, for the recursive types.
+	s.names[t] = name
+	s.components[name] = map[string]interface{}{}
+	s.components[name] = s.structSchema(t)
+	return name
+}
+
+// structSchema returns the schema of the fields of a struct as encoded by
+// encoding/json, with the fields of the embedded structs inlined.
+func (s *openAPISchemas) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	s.addFields(t, properties)
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (s *openAPISchemas) addFields(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			s.addFields(ft, properties)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		// The fields of the outer struct win over the embedded ones.
+		if _, ok := properties[name]; ok {
+			continue
+		}
+		properties[name] = s.schema(field.Type)
+	}
+}
@@ -0,0 +1,1623 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Code generated by http-openapi-gen. DO NOT EDIT.
+
+package agent
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/serf/serf"
+)
+
+// openAPIEndpoints describes the operations of the endpoints registered in
+// http_register.go, keyed by their pattern.
+var openAPIEndpoints = map[string]openAPIEndpoint{
+	"/v1/acl/auth-method": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLAuthMethodCreate",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLAuthMethodSetRequest)).AuthMethod) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLAuthMethod)) },
+			},
+		},
+	},
+	"/v1/acl/auth-method/": {
+		PathParam: "methodName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLAuthMethodCRUDGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLAuthMethodResponse)).AuthMethod) },
+			},
+			"PUT": {
+				ID:          "ACLAuthMethodCRUDPut",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLAuthMethodSetRequest)).AuthMethod) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLAuthMethod)) },
+			},
+			"DELETE": {
+				ID:          "ACLAuthMethodCRUDDelete",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/acl/auth-methods": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLAuthMethodList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLAuthMethodListResponse)).AuthMethods) },
+			},
+		},
+	},
+	"/v1/acl/binding-rule": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLBindingRuleCreate",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLBindingRuleSetRequest)).BindingRule) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLBindingRule)) },
+			},
+		},
+	},
+	"/v1/acl/binding-rule/": {
+		PathParam: "bindingRuleID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLBindingRuleCRUDGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLBindingRuleResponse)).BindingRule) },
+			},
+			"PUT": {
+				ID:          "ACLBindingRuleCRUDPut",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLBindingRuleSetRequest)).BindingRule) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLBindingRule)) },
+			},
+			"DELETE": {
+				ID:          "ACLBindingRuleCRUDDelete",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/acl/binding-rules": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLBindingRuleList",
+				QueryParams: []string{"authmethod", "bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLBindingRuleListResponse)).BindingRules) },
+			},
+		},
+	},
+	"/v1/acl/bootstrap": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:       "ACLBootstrap",
+				Request:  func() reflect.Type { return openAPITypeOf(*new(api.BootstrapRequest)) },
+				Response: func() reflect.Type { return openAPITypeOf(*new(aclBootstrapResponse)) },
+			},
+		},
+	},
+	"/v1/acl/break-glass": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLBreakGlassStatus",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response: func() reflect.Type {
+					return openAPITypeOf((*new(structs.ACLBreakGlassStatusResponse)).ACLBreakGlassStatus)
+				},
+			},
+		},
+	},
+	"/v1/acl/break-glass/init": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLBreakGlassInit",
+				QueryParams: []string{"token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.ACLBreakGlassInitRequest)) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLBreakGlassInitResponse)) },
+			},
+		},
+	},
+	"/v1/acl/break-glass/unseal": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:       "ACLBreakGlassUnseal",
+				Request:  func() reflect.Type { return openAPITypeOf(*new(api.ACLBreakGlassUnsealRequest)) },
+				Response: func() reflect.Type { return openAPITypeOf(*new(structs.ACLBreakGlassUnsealResponse)) },
+			},
+		},
+	},
+	"/v1/acl/login": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ACLLogin",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLLoginRequest)).Auth) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLToken)) },
+			},
+		},
+	},
+	"/v1/acl/logout": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ACLLogout",
+				QueryParams: []string{"datacenter", "dc", "token"},
+			},
+		},
+	},
+	"/v1/acl/policies": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLPolicyList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLPolicyListResponse)).Policies) },
+			},
+		},
+	},
+	"/v1/acl/policy": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLPolicyCreate",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLPolicySetRequest)).Policy) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLPolicy)) },
+			},
+		},
+	},
+	"/v1/acl/policy/": {
+		PathParam: "policyID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLPolicyCRUDGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLPolicyResponse)).Policy) },
+			},
+			"PUT": {
+				ID:          "ACLPolicyCRUDPut",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLPolicySetRequest)).Policy) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLPolicy)) },
+			},
+			"DELETE": {
+				ID:          "ACLPolicyCRUDDelete",
+				QueryParams: []string{"ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/acl/policy/name/": {
+		PathParam: "policyName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLPolicyReadByName",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLPolicyResponse)).Policy) },
+			},
+		},
+	},
+	"/v1/acl/replication": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLReplicationStatus",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "near", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLReplicationStatus)) },
+			},
+		},
+	},
+	"/v1/acl/role": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLRoleCreate",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLRoleSetRequest)).Role) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLRole)) },
+			},
+		},
+	},
+	"/v1/acl/role/": {
+		PathParam: "roleID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLRoleCRUDGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLRoleResponse)).Role) },
+			},
+			"PUT": {
+				ID:          "ACLRoleCRUDPut",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLRoleSetRequest)).Role) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLRole)) },
+			},
+			"DELETE": {
+				ID:          "ACLRoleCRUDDelete",
+				QueryParams: []string{"ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/acl/role/name/": {
+		PathParam: "roleName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLRoleReadByName",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLRoleResponse)).Role) },
+			},
+		},
+	},
+	"/v1/acl/roles": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLRoleList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "policy", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLRoleListResponse)).Roles) },
+			},
+		},
+	},
+	"/v1/acl/templated-policies": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLTemplatedPoliciesList",
+				QueryParams: []string{"ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/acl/templated-policy/name/": {
+		PathParam: "templateName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLTemplatedPolicyRead",
+				QueryParams: []string{"ns", "partition", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.ACLTemplatedPolicyResponse)) },
+			},
+		},
+	},
+	"/v1/acl/templated-policy/preview/": {
+		PathParam: "templateName",
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ACLTemplatedPolicyPreview",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.ACLTemplatedPolicyVariables)) },
+			},
+		},
+	},
+	"/v1/acl/token": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ACLTokenCreate",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenSetRequest)).ACLToken) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ACLToken)) },
+			},
+		},
+	},
+	"/v1/acl/token/": {
+		PathParam: "tokenAccessorID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLTokenCRUDGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "expanded", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenUsageResponse)).ACLTokenUsageReport) },
+			},
+			"PUT": {
+				ID:          "ACLTokenCRUDPut",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenSetRequest)).ACLToken) },
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenUsageResponse)).ACLTokenUsageReport) },
+			},
+			"DELETE": {
+				ID:          "ACLTokenCRUDDelete",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenSetRequest)).ACLToken) },
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenUsageResponse)).ACLTokenUsageReport) },
+			},
+		},
+	},
+	"/v1/acl/token/self": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLTokenSelf",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenResponse)).Token) },
+			},
+		},
+	},
+	"/v1/acl/tokens": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLTokenList",
+				QueryParams: []string{"authmethod", "authmethod-ns", "bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "policy", "role", "servicename", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLTokenListResponse)).Tokens) },
+			},
+		},
+	},
+	"/v1/acl/tokens/stale": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ACLStaleTokens",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "unused-for", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ACLStaleTokensResponse)).ACLStaleTokensReport) },
+			},
+		},
+	},
+	"/v1/agent/cache": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentCache",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/agent/cache/": {
+		PathParam: "cacheType",
+		Operations: map[string]openAPIOperation{
+			"DELETE": {
+				ID:          "AgentCacheInvalidate",
+				QueryParams: []string{"dc", "peer", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.AgentCacheInvalidateResponse)) },
+			},
+		},
+	},
+	"/v1/agent/check/deregister/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentDeregisterCheck",
+				QueryParams: []string{"ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/check/fail/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentCheckFail",
+				QueryParams: []string{"note", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/check/pass/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentCheckPass",
+				QueryParams: []string{"note", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/check/register": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentRegisterCheck",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.CheckDefinition)) },
+			},
+		},
+	},
+	"/v1/agent/check/update/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentCheckUpdate",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(checkUpdate)) },
+			},
+		},
+	},
+	"/v1/agent/check/warn/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentCheckWarn",
+				QueryParams: []string{"note", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/checks": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentChecks",
+				QueryParams: []string{"filter", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/connect/authorize": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "AgentConnectAuthorize",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.ConnectAuthorizeRequest)) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(connectAuthorizeResp)) },
+			},
+		},
+	},
+	"/v1/agent/connect/ca/leaf/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentConnectCALeafCert",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/agent/connect/ca/roots": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentConnectCARoots",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/agent/connect/proxies": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentConnectProxies",
+				QueryParams: []string{"token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(connectProxiesResp)) },
+			},
+		},
+	},
+	"/v1/agent/force-leave/": {
+		PathParam: "addr",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentForceLeave",
+				QueryParams: []string{"partition", "prune", "token", "wan"},
+			},
+		},
+	},
+	"/v1/agent/health/service/id/": {
+		PathParam: "serviceID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentHealthServiceByID",
+				QueryParams: []string{"format", "ns", "partition", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.AgentServiceChecksInfo)) },
+			},
+		},
+	},
+	"/v1/agent/health/service/name/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentHealthServiceByName",
+				QueryParams: []string{"format", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/host": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentHost",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/agent/join/": {
+		PathParam: "addr",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentJoin",
+				QueryParams: []string{"partition", "token", "wan"},
+			},
+		},
+	},
+	"/v1/agent/leave": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentLeave",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/agent/log-level": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentLogLevelGet",
+				QueryParams: []string{"token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.AgentLogLevel)) },
+			},
+			"PUT": {
+				ID:          "AgentLogLevelPut",
+				QueryParams: []string{"token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.AgentLogLevelRequest)) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.AgentLogLevel)) },
+			},
+		},
+	},
+	"/v1/agent/maintenance": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentNodeMaintenance",
+				QueryParams: []string{"enable", "reason", "token"},
+			},
+		},
+	},
+	"/v1/agent/members": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentMembers",
+				QueryParams: []string{"filter", "partition", "segment", "token", "wan"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new([]serf.Member)) },
+			},
+		},
+	},
+	"/v1/agent/members/events": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentMemberEvents",
+				QueryParams: []string{"filter", "index", "token", "wait"},
+			},
+		},
+	},
+	"/v1/agent/metrics": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentMetrics",
+				QueryParams: []string{"format", "token"},
+			},
+		},
+	},
+	"/v1/agent/metrics/stream": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentMetricsStream",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/agent/monitor": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentMonitor",
+				QueryParams: []string{"logjson", "loglevel", "recent", "subsystem", "token"},
+			},
+		},
+	},
+	"/v1/agent/reload": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentReload",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/agent/self": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentSelf",
+				QueryParams: []string{"token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(Self)) },
+			},
+		},
+	},
+	"/v1/agent/service/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentService",
+				QueryParams: []string{"hash", "index", "ns", "partition", "token", "wait"},
+			},
+		},
+	},
+	"/v1/agent/service/deregister/": {
+		PathParam: "serviceID",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentDeregisterService",
+				QueryParams: []string{"ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/service/maintenance/": {
+		PathParam: "serviceID",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentServiceMaintenance",
+				QueryParams: []string{"enable", "ns", "partition", "reason", "token"},
+			},
+		},
+	},
+	"/v1/agent/service/register": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentRegisterService",
+				QueryParams: []string{"ns", "partition", "replace-existing-checks", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.ServiceDefinition)) },
+			},
+		},
+	},
+	"/v1/agent/services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "AgentServices",
+				QueryParams: []string{"filter", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/agent/token/": {
+		PathParam: "target",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AgentToken",
+				QueryParams: []string{"token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.AgentToken)) },
+			},
+		},
+	},
+	"/v1/agent/version": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID: "AgentVersion",
+			},
+		},
+	},
+	"/v1/catalog/connect/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogConnectServiceNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "near", "node-meta", "ns", "partition", "sort", "stale", "tag", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedServiceNodes)).ServiceNodes) },
+			},
+		},
+	},
+	"/v1/catalog/datacenters": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogDatacenters",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "leader", "max_stale", "stale"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new([]string)) },
+			},
+		},
+	},
+	"/v1/catalog/deregister": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "CatalogDeregister",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.DeregisterRequest)) },
+			},
+		},
+	},
+	"/v1/catalog/gateway-services/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogGatewayServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedGatewayServices)).Services) },
+			},
+		},
+	},
+	"/v1/catalog/node-services/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogNodeServiceList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedNodeServiceList)).NodeServices) },
+			},
+		},
+	},
+	"/v1/catalog/node/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogNodeServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedNodeServices)).NodeServices) },
+			},
+		},
+	},
+	"/v1/catalog/nodes": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "near", "node-meta", "partition", "sort", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedNodes)).Nodes) },
+			},
+		},
+	},
+	"/v1/catalog/register": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "CatalogRegister",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.RegisterRequest)) },
+			},
+		},
+	},
+	"/v1/catalog/service/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogServiceNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "near", "node-meta", "ns", "partition", "sort", "stale", "tag", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedServiceNodes)).ServiceNodes) },
+			},
+		},
+	},
+	"/v1/catalog/services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CatalogServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "node-meta", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedServices)).Services) },
+			},
+		},
+	},
+	"/v1/config": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "ConfigApply",
+				QueryParams: []string{"cas", "datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(map[string]interface{})) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(bool)) },
+			},
+		},
+	},
+	"/v1/config/": {
+		PathParam: "kindAndName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConfigGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedConfigEntries)).Entries) },
+			},
+			"DELETE": {
+				ID:          "ConfigDelete",
+				QueryParams: []string{"cas", "datacenter", "dc", "ns", "partition", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(struct{})) },
+			},
+		},
+	},
+	"/v1/connect/ca/configuration": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCAConfigurationGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.CAConfiguration)) },
+			},
+			"PUT": {
+				ID:          "ConnectCAConfigurationPut",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.CARequest)).Config) },
+			},
+		},
+	},
+	"/v1/connect/ca/crl": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCARevocationList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "pem", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/connect/ca/introspect": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ConnectCAIntrospect",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.SVIDIntrospectRequest)) },
+			},
+		},
+	},
+	"/v1/connect/ca/inventory": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCAInventory",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.CALeafInventory)) },
+			},
+		},
+	},
+	"/v1/connect/ca/ocsp": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ConnectCAOCSP",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/connect/ca/ocsp/": {
+		PathParam: "encoded",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCAOCSP2",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/connect/ca/revocations": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCARevocationsGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedCARevokedCerts)).RevokedCerts) },
+			},
+			"PUT": {
+				ID:          "ConnectCARevocationsPut",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.CARevokeRequest)) },
+			},
+		},
+	},
+	"/v1/connect/ca/roots": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectCARoots",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "pem", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.IndexedCARoots)) },
+			},
+		},
+	},
+	"/v1/connect/intentions": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "IntentionEndpointGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "expiring-within", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedIntentions)).Intentions) },
+			},
+			"POST": {
+				ID:          "IntentionEndpointPost",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.IntentionRequest)).Intention) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(intentionCreateResponse)) },
+			},
+		},
+	},
+	"/v1/connect/intentions/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "IntentionSpecificGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+			"PUT": {
+				ID:          "IntentionSpecificPut",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.IntentionRequest)).Intention) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(intentionCreateResponse)) },
+			},
+			"DELETE": {
+				ID:          "IntentionSpecificDelete",
+				QueryParams: []string{"datacenter", "dc", "token"},
+			},
+		},
+	},
+	"/v1/connect/intentions/check": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "IntentionCheck",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "destination", "filter", "index", "leader", "max_stale", "ns", "partition", "source", "source-type", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.IntentionQueryCheckResponse)) },
+			},
+		},
+	},
+	"/v1/connect/intentions/exact": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "IntentionExactGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "destination", "filter", "index", "leader", "max_stale", "ns", "partition", "source", "stale", "token", "wait"},
+			},
+			"PUT": {
+				ID:          "IntentionExactPut",
+				QueryParams: []string{"datacenter", "dc", "destination", "ns", "partition", "source", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.IntentionRequest)).Intention) },
+			},
+			"DELETE": {
+				ID:          "IntentionExactDelete",
+				QueryParams: []string{"datacenter", "dc", "destination", "ns", "partition", "source", "token"},
+			},
+		},
+	},
+	"/v1/connect/intentions/match": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "IntentionMatch",
+				QueryParams: []string{"bounded-stale", "by", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "name", "ns", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/connect/lint": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ConnectLint",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.ConfigEntryLintResponse)).Findings) },
+			},
+		},
+	},
+	"/v1/coordinate/datacenters": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:       "CoordinateDatacenters",
+				Response: func() reflect.Type { return openAPITypeOf(*new([]structs.DatacenterMap)) },
+			},
+		},
+	},
+	"/v1/coordinate/node/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CoordinateNode",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "partition", "segment", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/coordinate/nodes": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "CoordinateNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "partition", "segment", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.Coordinates)) },
+			},
+		},
+	},
+	"/v1/coordinate/update": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "CoordinateUpdate",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.CoordinateUpdateRequest)) },
+			},
+		},
+	},
+	"/v1/discovery-chain/": {
+		PathParam: "name",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "DiscoveryChainReadGet",
+				QueryParams: []string{"bounded-stale", "cached", "compile-dc", "consistent", "datacenter", "dc", "explain", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(discoveryChainReadResponse)) },
+			},
+			"POST": {
+				ID:          "DiscoveryChainReadPost",
+				QueryParams: []string{"bounded-stale", "cached", "compile-dc", "consistent", "datacenter", "dc", "explain", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(map[string]interface{})) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(discoveryChainReadResponse)) },
+			},
+		},
+	},
+	"/v1/event/fire/": {
+		PathParam: "name",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "EventFire",
+				QueryParams: []string{"datacenter", "dc", "node", "service", "tag", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(UserEvent)) },
+			},
+		},
+	},
+	"/v1/event/list": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "EventList",
+				QueryParams: []string{"index", "name", "token", "wait"},
+			},
+		},
+	},
+	"/v1/exported-services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "ExportedServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/gitops/status": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "GitOpsStatus",
+				QueryParams: []string{"token"},
+			},
+		},
+	},
+	"/v1/gitops/webhook": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID: "GitOpsWebhook",
+			},
+		},
+	},
+	"/v1/health/checks/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthServiceChecks",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "near", "node-meta", "ns", "partition", "sort", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedHealthChecks)).HealthChecks) },
+			},
+		},
+	},
+	"/v1/health/connect/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthConnectServiceNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "near", "node-meta", "ns", "partition", "peer", "sameness-group", "sg", "sidecar-proxy", "sort", "stale", "tag", "token", "wait"},
+			},
+		},
+	},
+	"/v1/health/ingress/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthIngressServiceNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "near", "node-meta", "ns", "partition", "peer", "sameness-group", "sg", "sidecar-proxy", "sort", "stale", "tag", "token", "wait"},
+			},
+		},
+	},
+	"/v1/health/node/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthNodeChecks",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedHealthChecks)).HealthChecks) },
+			},
+		},
+	},
+	"/v1/health/service/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthServiceNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "merge-central-config", "near", "node-meta", "ns", "partition", "peer", "sameness-group", "sg", "sidecar-proxy", "sort", "stale", "tag", "token", "wait"},
+			},
+		},
+	},
+	"/v1/health/services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthServicesNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "near", "node-meta", "ns", "partition", "peer", "service", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedServicesCheckServiceNodes)).Services) },
+			},
+		},
+	},
+	"/v1/health/state/": {
+		PathParam: "state",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthChecksInState",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "near", "node-meta", "ns", "partition", "sort", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedHealthChecks)).HealthChecks) },
+			},
+		},
+	},
+	"/v1/health/summary": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "HealthSummary",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "node-meta", "ns", "partition", "peer", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedServiceHealthSummaries)).Summaries) },
+			},
+		},
+	},
+	"/v1/internal/acl/authorize": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "ACLAuthorize",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.RemoteACLAuthorizationRequest)).Requests) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new([]structs.ACLAuthorizationResponse)) },
+			},
+		},
+	},
+	"/v1/internal/federation-state/": {
+		PathParam: "datacenterName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "FederationStateGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.FederationStateResponse)) },
+			},
+		},
+	},
+	"/v1/internal/federation-states": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "FederationStateList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedFederationStates)).States) },
+			},
+		},
+	},
+	"/v1/internal/federation-states/mesh-gateways": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "FederationStateListMeshGateways",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response: func() reflect.Type {
+					return openAPITypeOf((*new(structs.DatacenterIndexedCheckServiceNodes)).DatacenterNodes)
+				},
+			},
+		},
+	},
+	"/v1/internal/prometheus/targets": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "PrometheusTargets",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "passing", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/internal/service-virtual-ip": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "AssignManualServiceVIPs",
+				QueryParams: []string{"ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(structs.AssignServiceManualVIPsRequest)) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.AssignServiceManualVIPsResponse)) },
+			},
+		},
+	},
+	"/v1/internal/ui/catalog-overview": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UICatalogOverview",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.CatalogSummary)) },
+			},
+		},
+	},
+	"/v1/internal/ui/exported-services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIExportedServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "peer", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/internal/ui/gateway-intentions/": {
+		PathParam: "name",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIGatewayIntentions",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedIntentions)).Intentions) },
+			},
+		},
+	},
+	"/v1/internal/ui/gateway-services-nodes/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIGatewayServicesNodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/internal/ui/metrics-proxy/": {
+		PathParam: "path",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIMetricsProxy",
+				QueryParams: []string{"partition"},
+			},
+		},
+	},
+	"/v1/internal/ui/node/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UINodeInfo",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "peer", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/internal/ui/nodes": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UINodes",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/internal/ui/service-topology-metrics/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIServiceTopologyMetrics",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response: func() reflect.Type {
+					return openAPITypeOf((*new(structs.IndexedMeshTrafficMetrics)).MeshTrafficMetrics)
+				},
+			},
+		},
+	},
+	"/v1/internal/ui/service-topology/": {
+		PathParam: "serviceName",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIServiceTopology",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "kind", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(ServiceTopology)) },
+			},
+		},
+	},
+	"/v1/internal/ui/services": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "UIServices",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "peer", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/kv/": {
+		PathParam: "key",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "KVSEndpointGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "keys", "leader", "max_stale", "ns", "partition", "raw", "recurse", "separator", "seperator", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedDirEntries)).Entries) },
+			},
+			"PUT": {
+				ID:          "KVSEndpointPut",
+				QueryParams: []string{"acquire", "bounded-stale", "cached", "cas", "consistent", "datacenter", "dc", "filter", "flags", "index", "keys", "leader", "max_stale", "ns", "partition", "release", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(bool)) },
+			},
+			"DELETE": {
+				ID:          "KVSEndpointDelete",
+				QueryParams: []string{"bounded-stale", "cached", "cas", "consistent", "datacenter", "dc", "filter", "index", "keys", "leader", "max_stale", "ns", "partition", "recurse", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(bool)) },
+			},
+		},
+	},
+	"/v1/openapi.json": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID: "OpenAPI",
+			},
+		},
+	},
+	"/v1/operator/autopilot/configuration": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorAutopilotConfigurationGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.AutopilotConfiguration)) },
+			},
+			"PUT": {
+				ID:          "OperatorAutopilotConfigurationPut",
+				QueryParams: []string{"cas", "datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(interface{})) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(bool)) },
+			},
+		},
+	},
+	"/v1/operator/autopilot/health": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorServerHealth",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.OperatorHealthReply)) },
+			},
+		},
+	},
+	"/v1/operator/autopilot/state": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorAutopilotState",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/operator/catalog-provider/": {
+		PathParam: "name",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorCatalogProvider",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/operator/catalog-providers": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorCatalogProviders",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.CatalogProviderStatus)) },
+			},
+		},
+	},
+	"/v1/operator/features": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorClusterFeatures",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.ClusterFeaturesResponse)) },
+			},
+		},
+	},
+	"/v1/operator/keyring": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorKeyringEndpointGet",
+				QueryParams: []string{"local-only", "relay-factor", "token"},
+			},
+			"POST": {
+				ID:          "OperatorKeyringEndpointPost",
+				QueryParams: []string{"local-only", "relay-factor", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(keyringArgs)) },
+			},
+			"PUT": {
+				ID:          "OperatorKeyringEndpointPut",
+				QueryParams: []string{"local-only", "relay-factor", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(keyringArgs)) },
+			},
+			"DELETE": {
+				ID:          "OperatorKeyringEndpointDelete",
+				QueryParams: []string{"local-only", "relay-factor", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(keyringArgs)) },
+			},
+		},
+	},
+	"/v1/operator/kv-encryption": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorKVEncryption",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.KVEncryptionStatus)) },
+			},
+		},
+	},
+	"/v1/operator/kv-encryption/rewrap": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "OperatorKVEncryptionRewrap",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.KVEncryptionStatus)) },
+			},
+		},
+	},
+	"/v1/operator/kv-encryption/rotate": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "OperatorKVEncryptionRotate",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.KVEncryptionStatus)) },
+			},
+		},
+	},
+	"/v1/operator/kv-replication": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorKVReplication",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.KVReplicationStatus)) },
+			},
+		},
+	},
+	"/v1/operator/quota": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorQuota",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.QuotaResponse)) },
+			},
+		},
+	},
+	"/v1/operator/raft/configuration": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorRaftConfiguration",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.RaftConfigurationResponse)) },
+			},
+		},
+	},
+	"/v1/operator/raft/peer": {
+		Operations: map[string]openAPIOperation{
+			"DELETE": {
+				ID:          "OperatorRaftPeer",
+				QueryParams: []string{"address", "datacenter", "dc", "id", "token"},
+			},
+		},
+	},
+	"/v1/operator/raft/transfer-leader": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "OperatorRaftTransferLeader",
+				QueryParams: []string{"id", "partition", "token"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(api.TransferLeaderResponse)) },
+			},
+		},
+	},
+	"/v1/operator/replication": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorReplication",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.IndexedReplicationStatuses)) },
+			},
+		},
+	},
+	"/v1/operator/usage": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "OperatorUsage",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "global", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(structs.Usage)) },
+			},
+		},
+	},
+	"/v1/peering/": {
+		PathParam: "name",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "PeeringEndpointGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "partition", "stale", "token", "wait"},
+			},
+			"DELETE": {
+				ID:          "PeeringEndpointDelete",
+				QueryParams: []string{"partition", "token"},
+			},
+		},
+	},
+	"/v1/peering/establish": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "PeeringEstablish",
+				QueryParams: []string{"partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.PeeringEstablishRequest)) },
+			},
+		},
+	},
+	"/v1/peering/token": {
+		Operations: map[string]openAPIOperation{
+			"POST": {
+				ID:          "PeeringGenerateToken",
+				QueryParams: []string{"partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.PeeringGenerateTokenRequest)) },
+			},
+		},
+	},
+	"/v1/peerings": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "PeeringList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "partition", "stale", "token", "wait"},
+			},
+		},
+	},
+	"/v1/query": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "PreparedQueryGeneralGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedPreparedQueries)).Queries) },
+			},
+			"POST": {
+				ID:          "PreparedQueryGeneralPost",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.PreparedQueryRequest)).Query) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(preparedQueryCreateResponse)) },
+			},
+		},
+	},
+	"/v1/query/": {
+		PathParam: "id",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "PreparedQuerySpecificGet",
+				QueryParams: []string{"bounded-stale", "cached", "connect", "consistent", "datacenter", "dc", "filter", "index", "leader", "limit", "max_stale", "near", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedPreparedQueries)).Queries) },
+			},
+			"PUT": {
+				ID:          "PreparedQuerySpecificPut",
+				QueryParams: []string{"datacenter", "dc", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.PreparedQueryRequest)).Query) },
+			},
+			"DELETE": {
+				ID:          "PreparedQuerySpecificDelete",
+				QueryParams: []string{"datacenter", "dc", "token"},
+			},
+		},
+	},
+	"/v1/session/create": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "SessionCreate",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+				Request:     func() reflect.Type { return openAPITypeOf((*new(structs.SessionRequest)).Session) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(sessionCreateResponse)) },
+			},
+		},
+	},
+	"/v1/session/destroy/": {
+		PathParam: "iD",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "SessionDestroy",
+				QueryParams: []string{"datacenter", "dc", "ns", "partition", "token"},
+			},
+		},
+	},
+	"/v1/session/info/": {
+		PathParam: "sessionID",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "SessionGet",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedSessions)).Sessions) },
+			},
+		},
+	},
+	"/v1/session/list": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "SessionList",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedSessions)).Sessions) },
+			},
+		},
+	},
+	"/v1/session/node/": {
+		PathParam: "node",
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "SessionsForNode",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedSessions)).Sessions) },
+			},
+		},
+	},
+	"/v1/session/renew/": {
+		PathParam: "sessionID",
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "SessionRenew",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "ns", "partition", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf((*new(structs.IndexedSessions)).Sessions) },
+			},
+		},
+	},
+	"/v1/snapshot": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "SnapshotGet",
+				QueryParams: []string{"datacenter", "dc", "stale", "token"},
+			},
+			"PUT": {
+				ID:          "SnapshotPut",
+				QueryParams: []string{"datacenter", "dc", "stale", "token"},
+			},
+		},
+	},
+	"/v1/status/leader": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "StatusLeader",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new(string)) },
+			},
+		},
+	},
+	"/v1/status/peers": {
+		Operations: map[string]openAPIOperation{
+			"GET": {
+				ID:          "StatusPeers",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "stale", "token", "wait"},
+				Response:    func() reflect.Type { return openAPITypeOf(*new([]string)) },
+			},
+		},
+	},
+	"/v1/txn": {
+		Operations: map[string]openAPIOperation{
+			"PUT": {
+				ID:          "Txn",
+				QueryParams: []string{"bounded-stale", "cached", "consistent", "datacenter", "dc", "filter", "index", "leader", "max_stale", "pretty", "stale", "token", "wait"},
+				Request:     func() reflect.Type { return openAPITypeOf(*new(api.TxnOps)) },
+				Response:    func() reflect.Type { return openAPITypeOf(*new(interface{})) },
+			},
+		},
+	},
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestOpenAPIEndpoints(t *testing.T) {
+	// Every registered endpoint is described, with an operation for each of
+	// its methods.
+	for pattern, methods := range allowedMethods {
+		desc, ok := openAPIEndpoints[pattern]
+		require.True(t, ok, "%s is not described, run go generate ./agent", pattern)
+		for _, method := range methods {
+			require.Contains(t, desc.Operations, method, "%s %s is not described, run go generate ./agent", method, pattern)
+		}
+	}
+	for pattern := range openAPIEndpoints {
+		require.Contains(t, allowedMethods, pattern, "%s is not registered, run go generate ./agent", pattern)
+	}
+
+	// The generated types resolve.
+	for pattern, desc := range openAPIEndpoints {
+		for method, op := range desc.Operations {
+			if op.Response != nil {
+				require.NotNil(t, openAPIType(op.Response), "%s %s", method, pattern)
+			}
+			if op.Request != nil {
+				require.NotNil(t, openAPIType(op.Request), "%s %s", method, pattern)
+			}
+		}
+	}
+}
+
+func TestBuildOpenAPIDocument(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Meta map[string]string
+		Next *node `json:",omitempty"`
+	}
+	type response struct {
+		structs.QueryMeta
+		Nodes   []*node
+		Ignored string `json:"-"`
+	}
+
+	doc := buildOpenAPIDocument(
+		map[string][]string{
+			"/v1/test/nodes": {"GET", "PUT"},
+			"/v1/test/node/": {},
+		},
+		map[string]openAPIEndpoint{
+			"/v1/test/nodes": {
+				Operations: map[string]openAPIOperation{
+					"GET": {
+						ID:          "TestNodesGet",
+						QueryParams: []string{"index", "stale"},
+						Response:    func() reflect.Type { return openAPITypeOf(*new(response)) },
+					},
+					"PUT": {
+						ID:      "TestNodesPut",
+						Request: func() reflect.Type { return openAPITypeOf((*new(response)).Nodes) },
+					},
+				},
+			},
+			"/v1/test/node/": {
+				PathParam: "name",
+				Operations: map[string]openAPIOperation{
+					"DELETE": {ID: "TestNodeDelete"},
+				},
+			},
+		},
+	)
+	require.Equal(t, "3.0.3", doc["openapi"])
+
+	paths := doc["paths"].(map[string]interface{})
+	require.Len(t, paths, 2)
+
+	get := paths["/v1/test/nodes"].(map[string]interface{})["get"].(map[string]interface{})
+	require.Equal(t, "TestNodesGet", get["operationId"])
+	require.Equal(t, []string{"test"}, get["tags"])
+	var params []string
+	for _, p := range get["parameters"].([]interface{}) {
+		params = append(params, p.(map[string]interface{})["name"].(string))
+	}
+	require.Equal(t, []string{"index", "pretty", "stale"}, params)
+
+	schema := get["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"]
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/agent.response"}, schema)
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	props := schemas["agent.response"].(map[string]interface{})["properties"].(map[string]interface{})
+	// The fields of the embedded QueryMeta are inlined, and the ignored field
+	// is not described.
+	require.Contains(t, props, "Index")
+	require.Contains(t, props, "Nodes")
+	require.NotContains(t, props, "Ignored")
+	require.Equal(t, map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"$ref": "#/components/schemas/agent.node"},
+	}, props["Nodes"])
+
+	props = schemas["agent.node"].(map[string]interface{})["properties"].(map[string]interface{})
+	require.Equal(t, map[string]interface{}{"type": "string"}, props["name"])
+	require.Equal(t, map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}, props["Meta"])
+	require.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/agent.node"}, props["Next"])
+
+	put := paths["/v1/test/nodes"].(map[string]interface{})["put"].(map[string]interface{})
+	require.Contains(t, put, "requestBody")
+
+	// The endpoints registered without methods use the described operations.
+	del := paths["/v1/test/node/{name}"].(map[string]interface{})
+	require.Len(t, del, 1)
+	require.Equal(t, "TestNodeDelete", del["delete"].(map[string]interface{})["operationId"])
+}
+
+func TestHTTPHandlers_OpenAPI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	req, _ := http.NewRequest("GET", "/v1/openapi.json", nil)
+	resp := httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var doc struct {
+		OpenAPI string
+		Paths   map[string]map[string]json.RawMessage
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+
+	for pattern, methods := range allowedMethods {
+		path := pattern
+		if strings.HasSuffix(pattern, "/") {
+			path += "{" + openAPIEndpoints[pattern].PathParam + "}"
+		}
+		require.Contains(t, doc.Paths, path)
+		for _, method := range methods {
+			require.Contains(t, doc.Paths[path], strings.ToLower(method), "%s %s", method, pattern)
+		}
+	}
+	require.Contains(t, doc.Paths["/v1/query/{id}"], "delete")
+}
@@ -22,7 +22,11 @@ func init() {
 	registerEndpoint("/v1/acl/auth-methods", []string{"GET"}, (*HTTPHandlers).ACLAuthMethodList)
 	registerEndpoint("/v1/acl/auth-method", []string{"PUT"}, (*HTTPHandlers).ACLAuthMethodCreate)
 	registerEndpoint("/v1/acl/auth-method/", []string{"GET", "PUT", "DELETE"}, (*HTTPHandlers).ACLAuthMethodCRUD)
+	registerEndpoint("/v1/acl/break-glass", []string{"GET"}, (*HTTPHandlers).ACLBreakGlassStatus)
+	registerEndpoint("/v1/acl/break-glass/init", []string{"PUT"}, (*HTTPHandlers).ACLBreakGlassInit)
+	registerEndpoint("/v1/acl/break-glass/unseal", []string{"PUT"}, (*HTTPHandlers).ACLBreakGlassUnseal)
 	registerEndpoint("/v1/acl/tokens", []string{"GET"}, (*HTTPHandlers).ACLTokenList)
+	registerEndpoint("/v1/acl/tokens/stale", []string{"GET"}, (*HTTPHandlers).ACLStaleTokens)
 	registerEndpoint("/v1/acl/token", []string{"PUT"}, (*HTTPHandlers).ACLTokenCreate)
 	registerEndpoint("/v1/acl/token/self", []string{"GET"}, (*HTTPHandlers).ACLTokenSelf)
 	registerEndpoint("/v1/acl/token/", []string{"GET", "PUT", "DELETE"}, (*HTTPHandlers).ACLTokenCRUD)
@@ -35,13 +39,17 @@ func init() {
 	registerEndpoint("/v1/agent/version", []string{"GET"}, (*HTTPHandlers).AgentVersion)
 	registerEndpoint("/v1/agent/maintenance", []string{"PUT"}, (*HTTPHandlers).AgentNodeMaintenance)
 	registerEndpoint("/v1/agent/reload", []string{"PUT"}, (*HTTPHandlers).AgentReload)
+	registerEndpoint("/v1/agent/cache", []string{"GET"}, (*HTTPHandlers).AgentCache)
+	registerEndpoint("/v1/agent/cache/", []string{"DELETE"}, (*HTTPHandlers).AgentCacheInvalidate)
 	registerEndpoint("/v1/agent/monitor", []string{"GET"}, (*HTTPHandlers).AgentMonitor)
+	registerEndpoint("/v1/agent/log-level", []string{"GET", "PUT"}, (*HTTPHandlers).AgentLogLevel)
 	registerEndpoint("/v1/agent/metrics", []string{"GET"}, (*HTTPHandlers).AgentMetrics)
 	registerEndpoint("/v1/agent/metrics/stream", []string{"GET"}, (*HTTPHandlers).AgentMetricsStream)
 	registerEndpoint("/v1/agent/services", []string{"GET"}, (*HTTPHandlers).AgentServices)
 	registerEndpoint("/v1/agent/service/", []string{"GET"}, (*HTTPHandlers).AgentService)
 	registerEndpoint("/v1/agent/checks", []string{"GET"}, (*HTTPHandlers).AgentChecks)
 	registerEndpoint("/v1/agent/members", []string{"GET"}, (*HTTPHandlers).AgentMembers)
+	registerEndpoint("/v1/agent/members/events", []string{"GET"}, (*HTTPHandlers).AgentMemberEvents)
 	registerEndpoint("/v1/agent/join/", []string{"PUT"}, (*HTTPHandlers).AgentJoin)
 	registerEndpoint("/v1/agent/leave", []string{"PUT"}, (*HTTPHandlers).AgentLeave)
 	registerEndpoint("/v1/agent/force-leave/", []string{"PUT"}, (*HTTPHandlers).AgentForceLeave)
@@ -56,6 +64,7 @@ func init() {
 	registerEndpoint("/v1/agent/connect/authorize", []string{"POST"}, (*HTTPHandlers).AgentConnectAuthorize)
 	registerEndpoint("/v1/agent/connect/ca/roots", []string{"GET"}, (*HTTPHandlers).AgentConnectCARoots)
 	registerEndpoint("/v1/agent/connect/ca/leaf/", []string{"GET"}, (*HTTPHandlers).AgentConnectCALeafCert)
+	registerEndpoint("/v1/agent/connect/proxies", []string{"GET"}, (*HTTPHandlers).AgentConnectProxies)
 	registerEndpoint("/v1/agent/service/register", []string{"PUT"}, (*HTTPHandlers).AgentRegisterService)
 	registerEndpoint("/v1/agent/service/deregister/", []string{"PUT"}, (*HTTPHandlers).AgentDeregisterService)
 	registerEndpoint("/v1/agent/service/maintenance/", []string{"PUT"}, (*HTTPHandlers).AgentServiceMaintenance)
@@ -72,12 +81,19 @@ func init() {
 	registerEndpoint("/v1/config/", []string{"GET", "DELETE"}, (*HTTPHandlers).Config)
 	registerEndpoint("/v1/config", []string{"PUT"}, (*HTTPHandlers).ConfigApply)
 	registerEndpoint("/v1/connect/ca/configuration", []string{"GET", "PUT"}, (*HTTPHandlers).ConnectCAConfiguration)
+	registerEndpoint("/v1/connect/ca/crl", []string{"GET"}, (*HTTPHandlers).ConnectCARevocationList)
+	registerEndpoint("/v1/connect/ca/introspect", []string{"POST"}, (*HTTPHandlers).ConnectCAIntrospect)
+	registerEndpoint("/v1/connect/ca/inventory", []string{"GET"}, (*HTTPHandlers).ConnectCAInventory)
+	registerEndpoint("/v1/connect/ca/ocsp", []string{"POST"}, (*HTTPHandlers).ConnectCAOCSP)
+	registerEndpoint("/v1/connect/ca/ocsp/", []string{"GET"}, (*HTTPHandlers).ConnectCAOCSP)
+	registerEndpoint("/v1/connect/ca/revocations", []string{"GET", "PUT"}, (*HTTPHandlers).ConnectCARevocations)
 	registerEndpoint("/v1/connect/ca/roots", []string{"GET"}, (*HTTPHandlers).ConnectCARoots)
 	registerEndpoint("/v1/connect/intentions", []string{"GET", "POST"}, (*HTTPHandlers).IntentionEndpoint) // POST is deprecated
 	registerEndpoint("/v1/connect/intentions/match", []string{"GET"}, (*HTTPHandlers).IntentionMatch)
 	registerEndpoint("/v1/connect/intentions/check", []string{"GET"}, (*HTTPHandlers).IntentionCheck)
 	registerEndpoint("/v1/connect/intentions/exact", []string{"GET", "PUT", "DELETE"}, (*HTTPHandlers).IntentionExact)
 	registerEndpoint("/v1/connect/intentions/", []string{"GET", "PUT", "DELETE"}, (*HTTPHandlers).IntentionSpecific) // deprecated
+	registerEndpoint("/v1/connect/lint", []string{"GET"}, (*HTTPHandlers).ConnectLint)
 	registerEndpoint("/v1/coordinate/datacenters", []string{"GET"}, (*HTTPHandlers).CoordinateDatacenters)
 	registerEndpoint("/v1/coordinate/nodes", []string{"GET"}, (*HTTPHandlers).CoordinateNodes)
 	registerEndpoint("/v1/coordinate/node/", []string{"GET"}, (*HTTPHandlers).CoordinateNode)
@@ -89,10 +105,14 @@ func init() {
 	registerEndpoint("/v1/exported-services", []string{"GET"}, (*HTTPHandlers).ExportedServices)
 	registerEndpoint("/v1/event/fire/", []string{"PUT"}, (*HTTPHandlers).EventFire)
 	registerEndpoint("/v1/event/list", []string{"GET"}, (*HTTPHandlers).EventList)
+	registerEndpoint("/v1/gitops/status", []string{"GET"}, (*HTTPHandlers).GitOpsStatus)
+	registerEndpoint("/v1/gitops/webhook", []string{"POST"}, (*HTTPHandlers).GitOpsWebhook)
 	registerEndpoint("/v1/health/node/", []string{"GET"}, (*HTTPHandlers).HealthNodeChecks)
 	registerEndpoint("/v1/health/checks/", []string{"GET"}, (*HTTPHandlers).HealthServiceChecks)
 	registerEndpoint("/v1/health/state/", []string{"GET"}, (*HTTPHandlers).HealthChecksInState)
 	registerEndpoint("/v1/health/service/", []string{"GET"}, (*HTTPHandlers).HealthServiceNodes)
+	registerEndpoint("/v1/health/services", []string{"GET"}, (*HTTPHandlers).HealthServicesNodes)
+	registerEndpoint("/v1/health/summary", []string{"GET"}, (*HTTPHandlers).HealthSummary)
 	registerEndpoint("/v1/health/connect/", []string{"GET"}, (*HTTPHandlers).HealthConnectServiceNodes)
 	registerEndpoint("/v1/health/ingress/", []string{"GET"}, (*HTTPHandlers).HealthIngressServiceNodes)
 	registerEndpoint("/v1/internal/ui/metrics-proxy/", []string{"GET"}, (*HTTPHandlers).UIMetricsProxy)
@@ -104,14 +124,26 @@ func init() {
 	registerEndpoint("/v1/internal/ui/gateway-services-nodes/", []string{"GET"}, (*HTTPHandlers).UIGatewayServicesNodes)
 	registerEndpoint("/v1/internal/ui/gateway-intentions/", []string{"GET"}, (*HTTPHandlers).UIGatewayIntentions)
 	registerEndpoint("/v1/internal/ui/service-topology/", []string{"GET"}, (*HTTPHandlers).UIServiceTopology)
+	registerEndpoint("/v1/internal/ui/service-topology-metrics/", []string{"GET"}, (*HTTPHandlers).UIServiceTopologyMetrics)
 	registerEndpoint("/v1/internal/acl/authorize", []string{"POST"}, (*HTTPHandlers).ACLAuthorize)
+	registerEndpoint("/v1/internal/prometheus/targets", []string{"GET"}, (*HTTPHandlers).PrometheusTargets)
 	registerEndpoint("/v1/internal/service-virtual-ip", []string{"PUT"}, (*HTTPHandlers).AssignManualServiceVIPs)
 	registerEndpoint("/v1/kv/", []string{"GET", "PUT", "DELETE"}, (*HTTPHandlers).KVSEndpoint)
+	registerEndpoint("/v1/openapi.json", []string{"GET"}, (*HTTPHandlers).OpenAPI)
 	registerEndpoint("/v1/operator/raft/configuration", []string{"GET"}, (*HTTPHandlers).OperatorRaftConfiguration)
 	registerEndpoint("/v1/operator/raft/transfer-leader", []string{"POST"}, (*HTTPHandlers).OperatorRaftTransferLeader)
 	registerEndpoint("/v1/operator/raft/peer", []string{"DELETE"}, (*HTTPHandlers).OperatorRaftPeer)
+	registerEndpoint("/v1/operator/features", []string{"GET"}, (*HTTPHandlers).OperatorClusterFeatures)
 	registerEndpoint("/v1/operator/keyring", []string{"GET", "POST", "PUT", "DELETE"}, (*HTTPHandlers).OperatorKeyringEndpoint)
 	registerEndpoint("/v1/operator/usage", []string{"GET"}, (*HTTPHandlers).OperatorUsage)
+	registerEndpoint("/v1/operator/quota", []string{"GET"}, (*HTTPHandlers).OperatorQuota)
+	registerEndpoint("/v1/operator/kv-encryption", []string{"GET"}, (*HTTPHandlers).OperatorKVEncryption)
+	registerEndpoint("/v1/operator/kv-encryption/rotate", []string{"PUT"}, (*HTTPHandlers).OperatorKVEncryptionRotate)
+	registerEndpoint("/v1/operator/kv-encryption/rewrap", []string{"PUT"}, (*HTTPHandlers).OperatorKVEncryptionRewrap)
+	registerEndpoint("/v1/operator/kv-replication", []string{"GET"}, (*HTTPHandlers).OperatorKVReplication)
+	registerEndpoint("/v1/operator/catalog-providers", []string{"GET"}, (*HTTPHandlers).OperatorCatalogProviders)
+	registerEndpoint("/v1/operator/catalog-provider/", []string{"GET"}, (*HTTPHandlers).OperatorCatalogProvider)
+	registerEndpoint("/v1/operator/replication", []string{"GET"}, (*HTTPHandlers).OperatorReplication)
 	registerEndpoint("/v1/operator/autopilot/configuration", []string{"GET", "PUT"}, (*HTTPHandlers).OperatorAutopilotConfiguration)
 	registerEndpoint("/v1/operator/autopilot/health", []string{"GET"}, (*HTTPHandlers).OperatorServerHealth)
 	registerEndpoint("/v1/operator/autopilot/state", []string{"GET"}, (*HTTPHandlers).OperatorAutopilotState)
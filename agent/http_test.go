@@ -1336,6 +1336,32 @@ func TestParseConsistency_Invalid(t *testing.T) {
 	}
 }
 
+func TestParseConsistency_BoundedStale(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	resp := httptest.NewRecorder()
+	var b structs.QueryOptions
+	req, _ := http.NewRequest("GET", "/v1/health/service/web?bounded-stale=2s", nil)
+	require.False(t, a.srv.parseConsistency(resp, req, &b))
+	require.True(t, b.AllowStale)
+	require.Equal(t, 2*time.Second, b.BoundedStaleness)
+	require.Equal(t, "bounded-stale", b.ConsistencyLevel())
+
+	for _, query := range []string{"bounded-stale=bad", "bounded-stale=-1s", "bounded-stale=2s&consistent"} {
+		resp := httptest.NewRecorder()
+		var b structs.QueryOptions
+		req, _ := http.NewRequest("GET", "/v1/health/service/web?"+query, nil)
+		require.True(t, a.srv.parseConsistency(resp, req, &b), query)
+		require.Equal(t, http.StatusBadRequest, resp.Code, query)
+	}
+}
+
 // Test ACL token is resolved in correct order
 func TestACLResolution(t *testing.T) {
 	if testing.Short() {
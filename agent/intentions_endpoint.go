@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul/acl"
 	cachetype "github.com/hashicorp/consul/agent/cache-types"
@@ -41,6 +42,14 @@ func (s *HTTPHandlers) IntentionList(resp http.ResponseWriter, req *http.Request
 		return nil, err
 	}
 
+	if within := req.URL.Query().Get("expiring-within"); within != "" {
+		d, err := time.ParseDuration(within)
+		if err != nil || d <= 0 {
+			return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Invalid expiring-within duration %q", within)}
+		}
+		args.ExpiringWithin = d
+	}
+
 	var reply structs.IndexedIntentions
 	defer setMeta(resp, &reply.QueryMeta)
 	if err := s.agent.RPC(req.Context(), "Intention.List", &args, &reply); err != nil {
@@ -42,6 +42,14 @@ func TestIntentionList(t *testing.T) {
 		require.Len(t, value, 0)
 	})
 
+	t.Run("invalid expiring-within", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/connect/intentions?expiring-within=soon", nil)
+		resp := httptest.NewRecorder()
+		_, err := a.srv.IntentionList(resp, req)
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, err.(HTTPError).StatusCode)
+	})
+
 	t.Run("values", func(t *testing.T) {
 		// Create some intentions, note we create the lowest precedence first to test
 		// sorting.
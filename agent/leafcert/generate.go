@@ -85,11 +85,12 @@ func (m *Manager) attemptLeafRefresh(
 		} else if roots == nil {
 			return nil, state, errors.New("no CA roots")
 		}
-		if activeRootHasKey(roots, state.authorityKeyID) {
+		if activeRootHasKey(roots, state.authorityKeyID) && !roots.IsRevoked(existing.SerialNumber) {
 			return nil, state, nil
 		}
 
-		// if we reach here then the current leaf was not signed by the same CAs, just regen
+		// if we reach here then the current leaf was not signed by the same CAs
+		// or was revoked, just regen
 		return m.generateNewLeaf(req, state, false)
 	}
 
@@ -147,6 +148,12 @@ func (m *Manager) attemptLeafRefresh(
 				return nil, state, errors.New("no CA roots")
 			}
 
+			// A revoked cert is no longer trusted, so replace it right away
+			// rather than after the jitter of a root rotation.
+			if roots.IsRevoked(existing.SerialNumber) {
+				return m.generateNewLeaf(req, state, false)
+			}
+
 			// Handle _possibly_ changed roots. We still need to verify the new active
 			// root is not the same as the one our current cert was signed by since we
 			// can be notified spuriously if we are the first request since the
@@ -88,6 +88,69 @@ func TestManager_changingRoots(t *testing.T) {
 	}
 }
 
+// Test that revoking the current leaf cert replaces it right away.
+func TestManager_revokedLeaf(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	m, signer := NewTestManager(t, nil)
+
+	caRoot := signer.UpdateCA(t, nil)
+
+	req := &ConnectCALeafRequest{
+		Datacenter: "dc1", Service: "web",
+		MinQueryIndex: 0, MaxQueryTime: 10 * time.Second,
+	}
+
+	var issued *structs.IssuedCert
+	getCh := testAsyncGet(t, m, req)
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("shouldn't block waiting for fetch")
+	case result := <-getCh:
+		require.NoError(t, result.Err)
+		requireLeafValidUnderCA(t, result.Value, caRoot)
+		issued = result.Value
+		req.MinQueryIndex = result.Index
+	}
+
+	getCh = testAsyncGet(t, m, req)
+	select {
+	case result := <-getCh:
+		t.Fatalf("should not return: %#v", result)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Revoke the current leaf, without rotating the CA.
+	roots, err := signer.RootsReader.Get()
+	require.NoError(t, err)
+	revoked := *roots
+	revoked.RevokedSerialNumbers = []string{issued.SerialNumber}
+	revoked.QueryMeta = structs.QueryMeta{Index: signer.nextIndex()}
+	signer.RootsReader.Set(&revoked)
+
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("shouldn't block waiting for fetch")
+	case result := <-getCh:
+		require.NoError(t, result.Err)
+		requireLeafValidUnderCA(t, result.Value, caRoot)
+		require.NotEqual(t, issued.SerialNumber, result.Value.SerialNumber)
+		req.MinQueryIndex = result.Index
+	}
+
+	// The new leaf isn't revoked, so the next fetch blocks.
+	getCh = testAsyncGet(t, m, req)
+	select {
+	case result := <-getCh:
+		t.Fatalf("should not return: %#v", result)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // Tests that if the root change jitter is longer than the time left on the
 // timeout, we return normally but then still renew the cert on a subsequent
 // call.
@@ -5,6 +5,7 @@ package leafcert
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -141,8 +142,10 @@ func (r *rootWatcher) rootWatcher(ctx context.Context) {
 			// The Fetch calls will also validate this since the first call here we
 			// don't know if it changed or not, but there is no point waking up all
 			// Fetch calls to check this if we know none of them will need to act on
-			// this update.
-			if oldRoots != nil && oldRoots.ActiveRootID == roots.ActiveRootID {
+			// this update. Revocations are distributed along with the roots, so
+			// a change of the revoked certs is an update too.
+			if oldRoots != nil && oldRoots.ActiveRootID == roots.ActiveRootID &&
+				slices.Equal(oldRoots.RevokedSerialNumbers, roots.RevokedSerialNumbers) {
 				continue
 			}
 
@@ -185,6 +185,13 @@ type State struct {
 	// created.
 	TriggerSyncChanges func()
 
+	// NodeAttestation returns the attestation evidence of the node attached
+	// to the registrations, or an empty string.
+	//
+	// It is set after both the state and the agent have been created when
+	// node_attestation is configured.
+	NodeAttestation func() string
+
 	logger hclog.Logger
 
 	// Config is the agent config
@@ -1465,6 +1472,7 @@ func (l *State) syncService(key structs.ServiceID) error {
 		EnterpriseMeta:  key.EnterpriseMeta,
 		WriteRequest:    structs.WriteRequest{Token: st},
 		SkipNodeUpdate:  l.nodeInfoInSync,
+		NodeAttestation: l.nodeAttestation(),
 	}
 
 	// Backwards-compatibility for Consul < 0.5
@@ -1528,6 +1536,7 @@ func (l *State) syncCheck(key structs.CheckID) error {
 		EnterpriseMeta:  c.Check.EnterpriseMeta,
 		WriteRequest:    structs.WriteRequest{Token: ct},
 		SkipNodeUpdate:  l.nodeInfoInSync,
+		NodeAttestation: l.nodeAttestation(),
 	}
 
 	serviceKey := structs.NewServiceID(c.Check.ServiceID, &key.EnterpriseMeta)
@@ -1569,6 +1578,14 @@ func (l *State) syncCheck(key structs.CheckID) error {
 	}
 }
 
+// nodeAttestation returns the attestation evidence of the node, if any.
+func (l *State) nodeAttestation() string {
+	if l.NodeAttestation == nil {
+		return ""
+	}
+	return l.NodeAttestation()
+}
+
 func (l *State) syncNodeInfo() error {
 	at := l.tokens.AgentToken()
 	req := structs.RegisterRequest{
@@ -1581,6 +1598,7 @@ func (l *State) syncNodeInfo() error {
 		NodeMeta:        l.metadata,
 		EnterpriseMeta:  l.agentEnterpriseMeta,
 		WriteRequest:    structs.WriteRequest{Token: at},
+		NodeAttestation: l.nodeAttestation(),
 	}
 	var out struct{}
 	err := l.Delegate.RPC(context.Background(), "Catalog.Register", &req, &out)
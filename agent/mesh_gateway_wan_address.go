@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/cloudmeta"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+)
+
+const (
+	// defaultMeshGatewayWANAddressRefreshInterval is how often the instance
+	// metadata is queried when connect.mesh_gateway_wan_address_refresh_interval
+	// is not set.
+	defaultMeshGatewayWANAddressRefreshInterval = time.Minute
+
+	// meshGatewayWANAddressLookupTimeout bounds a single query of the
+	// instance metadata.
+	meshGatewayWANAddressLookupTimeout = 10 * time.Second
+)
+
+// meshGatewayWANAddress holds the public address of the instance discovered
+// from the cloud metadata, advertised as the WAN address of the local mesh
+// gateways.
+type meshGatewayWANAddress struct {
+	lock    sync.RWMutex
+	address string
+}
+
+func (m *meshGatewayWANAddress) get() string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.address
+}
+
+// set stores the address and returns whether it changed.
+func (m *meshGatewayWANAddress) set(address string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	changed := m.address != address
+	m.address = address
+	return changed
+}
+
+// startMeshGatewayWANAddressDiscovery starts querying the cloud metadata for
+// the WAN address of the local mesh gateways, when
+// connect.mesh_gateway_wan_address_provider is set.
+func (a *Agent) startMeshGatewayWANAddressDiscovery() {
+	provider := a.config.ConnectMeshGatewayWANAddressProvider
+	if provider == "" {
+		return
+	}
+
+	interval := a.config.ConnectMeshGatewayWANAddressRefreshInterval
+	if interval <= 0 {
+		interval = defaultMeshGatewayWANAddressRefreshInterval
+	}
+	go a.discoverMeshGatewayWANAddress(&cloudmeta.Client{Provider: provider}, interval)
+}
+
+func (a *Agent) discoverMeshGatewayWANAddress(client *cloudmeta.Client, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := a.logger.Named("mesh-gateway-wan-address")
+	for {
+		ctx, cancel := context.WithTimeout(&lib.StopChannelContext{StopCh: a.shutdownCh}, meshGatewayWANAddressLookupTimeout)
+		address, err := client.PublicAddress(ctx)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to discover the WAN address of the mesh gateways", "error", err)
+		} else if a.meshGatewayWANAddress.set(address) {
+			logger.Info("Discovered the WAN address of the mesh gateways", "address", address)
+			if err := a.updateMeshGatewayWANAddresses(); err != nil {
+				logger.Error("Failed to update the WAN address of the mesh gateways", "error", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// updateMeshGatewayWANAddresses re-registers the local mesh gateways whose
+// WAN address differs from the discovered one.
+func (a *Agent) updateMeshGatewayWANAddresses() error {
+	a.stateLock.Lock()
+	defer a.stateLock.Unlock()
+
+	for _, state := range a.State.ServiceStates(structs.WildcardEnterpriseMetaInDefaultPartition()) {
+		if state.Service.Kind != structs.ServiceKindMeshGateway {
+			continue
+		}
+
+		service := state.Service.DeepCopy()
+		if !a.applyMeshGatewayWANAddress(service) {
+			continue
+		}
+		if err := a.State.AddServiceWithChecks(service, nil, state.Token, state.IsLocallyDefined); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMeshGatewayWANAddress sets the discovered WAN address on a mesh
+// gateway registration, and returns whether it changed. The port defaults to
+// the one of the registered WAN address, or else the port of the gateway,
+// unless connect.mesh_gateway_wan_address_port is set.
+func (a *Agent) applyMeshGatewayWANAddress(service *structs.NodeService) bool {
+	if service.Kind != structs.ServiceKindMeshGateway {
+		return false
+	}
+	address := a.meshGatewayWANAddress.get()
+	if address == "" {
+		return false
+	}
+
+	existing, ok := service.TaggedAddresses[structs.TaggedAddressWAN]
+	port := a.config.ConnectMeshGatewayWANAddressPort
+	if port == 0 {
+		port = service.Port
+		if ok && existing.Port != 0 {
+			port = existing.Port
+		}
+	}
+
+	wan := structs.ServiceAddress{Address: address, Port: port}
+	if ok && existing == wan {
+		return false
+	}
+	if service.TaggedAddresses == nil {
+		service.TaggedAddresses = make(map[string]structs.ServiceAddress)
+	}
+	service.TaggedAddresses[structs.TaggedAddressWAN] = wan
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/cloudmeta"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestAgent_MeshGatewayWANAddress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	gateway := &structs.NodeService{
+		Kind:    structs.ServiceKindMeshGateway,
+		ID:      "mesh-gateway",
+		Service: "mesh-gateway",
+		Port:    8443,
+		TaggedAddresses: map[string]structs.ServiceAddress{
+			structs.TaggedAddressWAN: {Address: "10.0.0.1", Port: 443},
+		},
+	}
+	require.NoError(t, a.addServiceFromSource(gateway, nil, false, "gateway-token", ConfigSourceLocal))
+	web := &structs.NodeService{ID: "web", Service: "web", Port: 8080}
+	require.NoError(t, a.addServiceFromSource(web, nil, false, "", ConfigSourceLocal))
+
+	// The address is applied to the registered gateways, keeping the port of
+	// their WAN address.
+	require.True(t, a.meshGatewayWANAddress.set("198.51.100.1"))
+	require.NoError(t, a.updateMeshGatewayWANAddresses())
+
+	id := structs.NewServiceID("mesh-gateway", nil)
+	state := a.State.ServiceState(id)
+	require.Equal(t, structs.ServiceAddress{Address: "198.51.100.1", Port: 443}, state.Service.TaggedAddresses[structs.TaggedAddressWAN])
+	require.Equal(t, "gateway-token", state.Token)
+	require.True(t, state.IsLocallyDefined)
+	require.NotContains(t, a.State.Service(structs.NewServiceID("web", nil)).TaggedAddresses, structs.TaggedAddressWAN)
+
+	// Gateways registered after the discovery get the address too, with the
+	// configured port.
+	a.config.ConnectMeshGatewayWANAddressPort = 9443
+	other := &structs.NodeService{
+		Kind:    structs.ServiceKindMeshGateway,
+		ID:      "other-gateway",
+		Service: "mesh-gateway",
+		Port:    8443,
+	}
+	require.NoError(t, a.addServiceFromSource(other, nil, false, "", ConfigSourceLocal))
+	require.Equal(t, structs.ServiceAddress{Address: "198.51.100.1", Port: 9443},
+		a.State.Service(structs.NewServiceID("other-gateway", nil)).TaggedAddresses[structs.TaggedAddressWAN])
+}
+
+func TestAgent_DiscoverMeshGatewayWANAddress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	var address atomic.Value
+	address.Store("198.51.100.1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(address.Load().(string)))
+	}))
+	t.Cleanup(server.Close)
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	gateway := &structs.NodeService{
+		Kind:    structs.ServiceKindMeshGateway,
+		ID:      "mesh-gateway",
+		Service: "mesh-gateway",
+		Port:    8443,
+	}
+	require.NoError(t, a.addServiceFromSource(gateway, nil, false, "", ConfigSourceLocal))
+
+	client := &cloudmeta.Client{Provider: cloudmeta.ProviderGCP, Endpoint: server.URL}
+	go a.discoverMeshGatewayWANAddress(client, 10*time.Millisecond)
+
+	id := structs.NewServiceID("mesh-gateway", nil)
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, structs.ServiceAddress{Address: "198.51.100.1", Port: 8443},
+			a.State.Service(id).TaggedAddresses[structs.TaggedAddressWAN])
+	})
+
+	// The registration follows the changes of the public address.
+	address.Store("198.51.100.2")
+	retry.Run(t, func(r *retry.R) {
+		require.Equal(r, structs.ServiceAddress{Address: "198.51.100.2", Port: 8443},
+			a.State.Service(id).TaggedAddresses[structs.TaggedAddressWAN])
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// namedPipeSecurityDescriptor returns the SDDL security descriptor of the
+// named pipes the HTTP API listens on. It grants full access to the account
+// running the agent, the local system account and the administrators, and
+// read and write access to the allowed SIDs. Nobody else can connect.
+func namedPipeSecurityDescriptor(allowedSIDs []string) string {
+	var b strings.Builder
+	b.WriteString("D:P(A;;GA;;;OW)(A;;GA;;;SY)(A;;GA;;;BA)")
+	for _, sid := range allowedSIDs {
+		fmt.Fprintf(&b, "(A;;GRGW;;;%s)", sid)
+	}
+	return b.String()
+}
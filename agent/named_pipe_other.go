@@ -0,0 +1,15 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !windows
+
+package agent
+
+import (
+	"fmt"
+	"net"
+)
+
+func (a *Agent) listenNamedPipe(path string) (net.Listener, error) {
+	return nil, fmt.Errorf("cannot listen on %s: named pipes are only supported on Windows", path)
+}
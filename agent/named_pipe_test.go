@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedPipeSecurityDescriptor(t *testing.T) {
+	require.Equal(t, "D:P(A;;GA;;;OW)(A;;GA;;;SY)(A;;GA;;;BA)", namedPipeSecurityDescriptor(nil))
+	require.Equal(t,
+		"D:P(A;;GA;;;OW)(A;;GA;;;SY)(A;;GA;;;BA)(A;;GRGW;;;S-1-5-32-545)(A;;GRGW;;;NS)",
+		namedPipeSecurityDescriptor([]string{"S-1-5-32-545", "NS"}),
+	)
+}
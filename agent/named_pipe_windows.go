@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build windows
+
+package agent
+
+import (
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func (a *Agent) listenNamedPipe(path string) (net.Listener, error) {
+	return winio.ListenPipe(path, &winio.PipeConfig{
+		SecurityDescriptor: namedPipeSecurityDescriptor(a.config.NamedPipeAllowedSIDs),
+	})
+}
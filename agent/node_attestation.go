@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/agent/consul/attestation"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/logging"
+)
+
+const (
+	// nodeAttestationInterval is how often the agent checks whether the
+	// attestation evidence of the node must be collected again and whether
+	// it must log in again.
+	nodeAttestationInterval = time.Minute
+
+	// nodeAttestationRefresh is the age of the evidence after which the
+	// agent collects it again, well before the servers consider it expired.
+	nodeAttestationRefresh = attestation.MaxEvidenceAge / 2
+
+	// nodeAttestationTimeout bounds the collection of the evidence.
+	nodeAttestationTimeout = 30 * time.Second
+)
+
+// nodeAttestation holds the last attestation evidence collected for the node
+// and the agent token obtained by logging in with it.
+type nodeAttestation struct {
+	lock        sync.Mutex
+	evidence    string
+	collectedAt time.Time
+	token       loginToken
+}
+
+func (n *nodeAttestation) get() string {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.evidence
+}
+
+// startNodeAttestation starts collecting the attestation evidence of the node
+// when node_attestation.type is set. The evidence is attached to the
+// registrations of the node, and used to log in to obtain the agent token
+// when node_attestation.auth_method is set.
+func (a *Agent) startNodeAttestation() {
+	cfg := a.config.NodeAttestation
+	if cfg.Type == "" {
+		return
+	}
+
+	a.nodeAttestation = &nodeAttestation{}
+	a.State.NodeAttestation = a.nodeAttestation.get
+
+	collector := &attestation.Collector{
+		Type:       cfg.Type,
+		Node:       a.config.NodeName,
+		NodeID:     string(a.config.NodeID),
+		TPMCommand: cfg.TPMCommand,
+	}
+	go a.runNodeAttestation(collector, a.logger.Named(logging.NodeAttestation))
+}
+
+func (a *Agent) runNodeAttestation(collector *attestation.Collector, logger hclog.Logger) {
+	ticker := time.NewTicker(nodeAttestationInterval)
+	defer ticker.Stop()
+
+	for {
+		a.refreshNodeAttestation(collector, logger, time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+func (a *Agent) refreshNodeAttestation(collector *attestation.Collector, logger hclog.Logger, now time.Time) {
+	n := a.nodeAttestation
+	n.lock.Lock()
+	stale := n.evidence == "" || now.Sub(n.collectedAt) >= nodeAttestationRefresh
+	n.lock.Unlock()
+
+	if stale {
+		ctx, cancel := context.WithTimeout(context.Background(), nodeAttestationTimeout)
+		evidence, err := collector.Collect(ctx, now)
+		cancel()
+		if err != nil {
+			logger.Error("failed to collect the attestation evidence of the node", "type", collector.Type, "error", err)
+			return
+		}
+
+		n.lock.Lock()
+		first := n.evidence == ""
+		n.evidence = evidence
+		n.collectedAt = now
+		n.lock.Unlock()
+
+		// The registrations rejected for the lack of evidence are retried
+		// right away.
+		if first {
+			logger.Info("collected the attestation evidence of the node", "type", collector.Type)
+			a.State.TriggerSyncChanges()
+		}
+	}
+
+	if err := a.nodeAttestationLogin(now); err != nil {
+		logger.Error("failed to log in with the attestation evidence of the node",
+			"auth_method", a.config.NodeAttestation.AuthMethod,
+			"error", err,
+		)
+	}
+}
+
+// nodeAttestationLogin logs in to node_attestation.auth_method with the
+// evidence of the node and uses the token as the agent token, unless another
+// agent token is configured. It logs in again before the token expires.
+func (a *Agent) nodeAttestationLogin(now time.Time) error {
+	method := a.config.NodeAttestation.AuthMethod
+	if method == "" || !a.config.ACLsEnabled || !attestation.CanLogin(a.config.NodeAttestation.Type) {
+		return nil
+	}
+
+	// Only the goroutine running the attestation changes the token, the lock
+	// is not held during the RPCs so that the registrations are not blocked.
+	n := a.nodeAttestation
+	n.lock.Lock()
+	evidence, previous := n.evidence, n.token
+	n.lock.Unlock()
+
+	current, _ := a.tokens.AgentTokenAndSource()
+	if current != "" && current != previous.secretID {
+		return nil
+	}
+	if previous.secretID != "" && (previous.expiresAt.IsZero() || now.Before(previous.expiresAt)) {
+		// A configuration reload clears the agent token.
+		if current == "" {
+			a.tokens.UpdateAgentToken(previous.secretID, token.TokenSourceConfig)
+		}
+		return nil
+	}
+
+	args := structs.ACLLoginRequest{
+		Auth: &structs.ACLLoginParams{
+			AuthMethod:     method,
+			BearerToken:    evidence,
+			Meta:           map[string]string{"node": a.config.NodeName},
+			EnterpriseMeta: *a.AgentEnterpriseMeta(),
+		},
+		Datacenter: a.config.Datacenter,
+	}
+	var out structs.ACLToken
+	if err := a.RPC(context.Background(), "ACL.Login", &args, &out); err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if out.ExpirationTime != nil {
+		expiresAt = out.ExpirationTime.Add(-loginTokenRefreshMargin)
+	}
+	n.lock.Lock()
+	n.token = loginToken{secretID: out.SecretID, expiresAt: expiresAt}
+	n.lock.Unlock()
+	a.tokens.UpdateAgentToken(out.SecretID, token.TokenSourceConfig)
+
+	if previous.secretID != "" {
+		logout := structs.ACLLogoutRequest{
+			Datacenter:   a.config.Datacenter,
+			WriteRequest: structs.WriteRequest{Token: previous.secretID},
+		}
+		var ignored bool
+		if err := a.RPC(context.Background(), "ACL.Logout", &logout, &ignored); err != nil {
+			a.logger.Named(logging.NodeAttestation).Warn("failed to log out the previous agent token", "error", err)
+		}
+	}
+	return nil
+}
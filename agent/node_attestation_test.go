@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/consul/attestation"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestAgent_NodeAttestation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, `
+		node_attestation {
+			type        = "tpm"
+			tpm_command = ["sh", "-c", "echo '{\"Signature\": \"AQID\"}'"]
+		}
+	`)
+	defer a.Shutdown()
+
+	retry.Run(t, func(r *retry.R) {
+		encoded := a.State.NodeAttestation()
+		require.NotEmpty(r, encoded)
+
+		e, err := attestation.Decode(encoded)
+		require.NoError(r, err)
+		require.Equal(r, attestation.TypeTPM, e.Type)
+		require.Equal(r, a.config.NodeName, e.Node)
+		require.Equal(r, string(a.config.NodeID), e.NodeID)
+		require.Equal(r, []byte{1, 2, 3}, e.TPM.Signature)
+	})
+}
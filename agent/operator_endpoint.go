@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -38,6 +39,23 @@ func (s *HTTPHandlers) OperatorRaftConfiguration(resp http.ResponseWriter, req *
 	return reply, nil
 }
 
+// OperatorClusterFeatures is used to report which protocol features are
+// active in the datacenter given the versions and configuration of its
+// servers.
+func (s *HTTPHandlers) OperatorClusterFeatures(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.ClusterFeaturesResponse
+	if err := s.agent.RPC(req.Context(), "Operator.ClusterFeatures", &args, &reply); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
 // OperatorRaftTransferLeader is used to transfer raft cluster leadership to another node
 func (s *HTTPHandlers) OperatorRaftTransferLeader(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 
@@ -407,6 +425,139 @@ RETRY_ONCE:
 	return out, nil
 }
 
+// OperatorQuota is used to get the quota limits and usage of a namespace.
+func (s *HTTPHandlers) OperatorQuota(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.QuotaRequest
+	if err := s.parseEntMetaNoWildcard(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.QuotaResponse
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.Quota", &args, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorKVEncryption is used to get the status of the KV encryption.
+func (s *HTTPHandlers) OperatorKVEncryption(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.KVEncryptionStatus
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.KVEncryptionStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorKVReplication is used to get the status of the replication of the
+// KV prefixes from the primary datacenter.
+func (s *HTTPHandlers) OperatorKVReplication(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.KVReplicationStatus
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.KVReplicationStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorReplication is used to get the status and the lag of the
+// replication from the primary datacenter and from the peers.
+func (s *HTTPHandlers) OperatorReplication(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.IndexedReplicationStatuses
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.ReplicationStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperatorCatalogProviders is used to get the status of the sync of the
+// catalog providers.
+func (s *HTTPHandlers) OperatorCatalogProviders(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.CatalogProviderStatus
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.CatalogProviderStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	if out.Providers == nil {
+		out.Providers = make([]structs.CatalogProviderSyncStatus, 0)
+	}
+	return out, nil
+}
+
+// OperatorCatalogProvider is used to get the status of the sync of a single
+// catalog provider.
+func (s *HTTPHandlers) OperatorCatalogProvider(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	name := strings.TrimPrefix(req.URL.Path, "/v1/operator/catalog-provider/")
+	if name == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing catalog provider name"}
+	}
+
+	var args structs.DCSpecificRequest
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var out structs.CatalogProviderStatus
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Operator.CatalogProviderStatus", &args, &out); err != nil {
+		return nil, err
+	}
+	for _, p := range out.Providers {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, HTTPError{StatusCode: http.StatusNotFound, Reason: fmt.Sprintf("Catalog provider %q not found", name)}
+}
+
+// OperatorKVEncryptionRotate is used to generate a new KV encryption key.
+func (s *HTTPHandlers) OperatorKVEncryptionRotate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	return s.operatorKVEncryptionWrite(req, "Operator.KVEncryptionRotate")
+}
+
+// OperatorKVEncryptionRewrap is used to re-encrypt the KV values with the
+// active KV encryption key.
+func (s *HTTPHandlers) OperatorKVEncryptionRewrap(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	return s.operatorKVEncryptionWrite(req, "Operator.KVEncryptionRewrap")
+}
+
+func (s *HTTPHandlers) operatorKVEncryptionWrite(req *http.Request, method string) (interface{}, error) {
+	var args structs.KVEncryptionRequest
+	s.parseDC(req, &args.Datacenter)
+	s.parseToken(req, &args.Token)
+
+	var out structs.KVEncryptionStatus
+	if err := s.agent.RPC(req.Context(), method, &args, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func stringIDs(ids []raft.ServerID) []string {
 	out := make([]string, len(ids))
 	for i, id := range ids {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,9 +48,27 @@ func TestOperator_Usage(t *testing.T) {
 		svc.Connect.Native = true
 	}))
 
+	// Write a KV entry and a config entry
+	var applied bool
+	require.NoError(t, a.RPC(context.Background(), "KVS.Apply", &structs.KVSRequest{
+		Datacenter: "dc1",
+		Op:         api.KVSet,
+		DirEnt:     structs.DirEntry{Key: "foo", Value: []byte("bar")},
+	}, &applied))
+	require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Apply", &structs.ConfigEntryRequest{
+		Datacenter: "dc1",
+		Entry:      &structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web"},
+	}, &applied))
+
 	raw, err := a.srv.OperatorUsage(httptest.NewRecorder(), req)
 	require.NoError(t, err)
 
+	configEntries := make(map[string]int)
+	for _, kind := range structs.AllConfigEntryKinds {
+		configEntries[kind] = 0
+	}
+	configEntries[structs.ServiceDefaults] = 1
+
 	expected := map[string]structs.ServiceUsage{
 		"dc1": {
 			Services:         5,
@@ -65,6 +84,8 @@ func TestOperator_Usage(t *testing.T) {
 			// 4 = 6 total service instances - 1 connect proxy - 1 consul service
 			BillableServiceInstances: 4,
 			Nodes:                    2,
+			KVEntries:                1,
+			ConfigEntries:            configEntries,
 		},
 	}
 	require.Equal(t, expected, raw.(structs.Usage).Usage)
@@ -6,6 +6,7 @@ package agent
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -53,6 +54,33 @@ func TestOperator_RaftConfiguration(t *testing.T) {
 	}
 }
 
+func TestOperator_ClusterFeatures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, `
+		peering { enabled = true }
+		rpc { enable_streaming = true }
+	`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	req, _ := http.NewRequest("GET", "/v1/operator/features", nil)
+	resp := httptest.NewRecorder()
+	a.srv.h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var out api.ClusterFeatures
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, "dc1", out.Datacenter)
+	require.True(t, out.Active(api.ClusterFeatureFederationStates))
+	require.True(t, out.Active(api.ClusterFeaturePeering))
+	require.True(t, out.Active(api.ClusterFeatureStreaming))
+	require.False(t, out.Active(api.ClusterFeatureResourceAPIs))
+}
+
 func TestOperator_RaftPeer(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
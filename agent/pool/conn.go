@@ -25,6 +25,8 @@ func (t RPCType) ALPNString() string {
 		return "" // unsupported
 	case RPCGRPC:
 		return ALPN_RPCGRPC
+	case RPCMultiplexV2Compressed:
+		return ALPN_RPCMultiplexV2Compressed
 	default:
 		return "" // unsupported
 	}
@@ -47,11 +49,15 @@ const (
 	RPCTLSInsecure    RPCType = 7
 	RPCGRPC           RPCType = 8
 	RPCRaftForwarding RPCType = 9
+	// RPCMultiplexV2Compressed is RPCMultiplexV2 over a compressed connection.
+	// It is followed by a byte with the compression algorithm the client
+	// requests, which the server echoes when it accepts it.
+	RPCMultiplexV2Compressed RPCType = 10
 
 	// RPCMaxTypeValue is the maximum rpc type byte value currently used for the
 	// various protocols riding over our "rpc" port.
 	//
-	// Currently our 0-9 values are mutually exclusive with any valid first byte
+	// Currently our 0-10 values are mutually exclusive with any valid first byte
 	// of a TLS header.  The first TLS header byte will begin with a TLS content
 	// type and the values 0-19 are all explicitly unassigned and marked as
 	// requiring coordination. RFC 7983 does the marking and goes into some
@@ -63,7 +69,7 @@ const (
 	//
 	// NOTE: if you add new RPCTypes beyond this value, you must similarly bump
 	// this value.
-	RPCMaxTypeValue = 9
+	RPCMaxTypeValue = 10
 )
 
 const (
@@ -75,6 +81,8 @@ const (
 	ALPN_RPCGossip         = "consul/rpc-gossip"      // RPCGossip
 	ALPN_RPCGRPC           = "consul/rpc-grpc"        // RPCGRPC
 	ALPN_RPCRaftForwarding = "consul/raft-forwarding" // RPCRaftForwarding
+	// compressed rpc between datacenters
+	ALPN_RPCMultiplexV2Compressed = "consul/rpc-multi-compressed" // RPCMultiplexV2Compressed
 	// wan federation additions
 	ALPN_WANGossipPacket = "consul/wan-gossip/packet"
 	ALPN_WANGossipStream = "consul/wan-gossip/stream"
@@ -88,6 +96,7 @@ var RPCNextProtos = []string{
 	ALPN_RPCGossip,
 	ALPN_RPCGRPC,
 	ALPN_RPCRaftForwarding,
+	ALPN_RPCMultiplexV2Compressed,
 	ALPN_WANGossipPacket,
 	ALPN_WANGossipStream,
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"sync"
@@ -21,12 +22,18 @@ import (
 
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/compression"
 	"github.com/hashicorp/consul/proto/private/pbcommon"
 	"github.com/hashicorp/consul/tlsutil"
 )
 
 const DefaultDialTimeout = 10 * time.Second
 
+// compressionRetryInterval is how long the connections to a server that failed
+// to negotiate the compression are left uncompressed, before negotiating it
+// again in case the server was upgraded.
+const compressionRetryInterval = 10 * time.Minute
+
 // muxSession is used to provide an interface for a stream multiplexer.
 type muxSession interface {
 	Open() (net.Conn, error)
@@ -173,8 +180,20 @@ type ConnPool struct {
 	// server instead of a client.
 	Server bool
 
+	// Compression is the algorithm the RPC connections to the servers of
+	// other datacenters are compressed with, if they support it.
+	Compression compression.Algorithm
+
+	// CompressionThreshold is the minimum size, in bytes, of the compressed
+	// writes. Zero uses compression.DefaultThreshold.
+	CompressionThreshold int
+
 	sync.Mutex
 
+	// noCompression maps a nodeName+address to the time until which its
+	// connections are not compressed, after it failed to negotiate it.
+	noCompression map[string]time.Time
+
 	// pool maps a nodeName+address to a open connection
 	pool map[string]*Conn
 
@@ -198,6 +217,7 @@ type ConnPool struct {
 func (p *ConnPool) init() {
 	p.pool = make(map[string]*Conn)
 	p.limiter = make(map[string]chan struct{})
+	p.noCompression = make(map[string]time.Time)
 	p.shutdownCh = make(chan struct{})
 	if p.MaxTime > 0 {
 		go p.reap()
@@ -474,7 +494,7 @@ func (p *ConnPool) getNewConn(dc string, nodeName string, addr net.Addr) (*Conn,
 	}
 
 	// Get a new, raw connection and write the Consul multiplex byte to set the mode
-	conn, _, err := p.DialTimeout(dc, nodeName, addr, RPCMultiplexV2)
+	conn, err := p.dialMultiplex(dc, nodeName, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -505,6 +525,77 @@ func (p *ConnPool) getNewConn(dc string, nodeName string, addr net.Addr) (*Conn,
 	return c, nil
 }
 
+// dialMultiplex dials a connection to multiplex the RPCs on. The connections to
+// the servers of other datacenters are compressed when the pool is configured
+// to and the server supports it.
+func (p *ConnPool) dialMultiplex(dc string, nodeName string, addr net.Addr) (net.Conn, error) {
+	if p.Compression == compression.None || dc == p.Datacenter {
+		conn, _, err := p.DialTimeout(dc, nodeName, addr, RPCMultiplexV2)
+		return conn, err
+	}
+
+	poolKey := makePoolKey(dc, nodeName, addr.String())
+	p.Lock()
+	retryAt, skip := p.noCompression[poolKey]
+	if skip && time.Now().After(retryAt) {
+		delete(p.noCompression, poolKey)
+		skip = false
+	}
+	p.Unlock()
+
+	if !skip {
+		conn, err := p.dialCompressed(dc, nodeName, addr)
+		if err == nil {
+			return conn, nil
+		}
+
+		// Servers that predate the compression close the connection on the
+		// unknown RPC byte, or reject the unknown ALPN protocol, so fall back
+		// to an uncompressed connection for a while.
+		if p.Logger != nil {
+			p.Logger.Printf("[DEBUG] pool: failed to negotiate compression with %s in %s, falling back to uncompressed RPC: %v", nodeName, dc, err)
+		}
+		p.Lock()
+		p.noCompression[poolKey] = time.Now().Add(compressionRetryInterval)
+		p.Unlock()
+	}
+
+	conn, _, err := p.DialTimeout(dc, nodeName, addr, RPCMultiplexV2)
+	return conn, err
+}
+
+// dialCompressed dials a connection to multiplex the RPCs on and negotiates
+// its compression. The connection is returned uncompressed if the server
+// declines the requested algorithm.
+func (p *ConnPool) dialCompressed(dc string, nodeName string, addr net.Addr) (net.Conn, error) {
+	conn, _, err := p.DialTimeout(dc, nodeName, addr, RPCMultiplexV2Compressed)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(DefaultDialTimeout))
+	if _, err := conn.Write([]byte{byte(p.Compression)}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var accepted [1]byte
+	if _, err := io.ReadFull(conn, accepted[:]); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	switch alg := compression.Algorithm(accepted[0]); alg {
+	case compression.None:
+		return conn, nil
+	case p.Compression:
+		return compression.NewConn(conn, alg, p.CompressionThreshold), nil
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("server accepted compression %s instead of %s", alg, p.Compression)
+	}
+}
+
 // clearConn is used to clear any cached connection, potentially in response to an error
 func (p *ConnPool) clearConn(conn *Conn) {
 	if conn.nodeName == "" {
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+)
+
+// Service meta keys hinting how Prometheus should scrape the instances of a
+// service.
+const (
+	// prometheusScrapeMeta excludes the instances from the targets when set
+	// to "false".
+	prometheusScrapeMeta = "prometheus_scrape"
+
+	// prometheusPortMeta overrides the port of the target, for services
+	// exposing their metrics on a dedicated port.
+	prometheusPortMeta = "prometheus_port"
+
+	// prometheusPathMeta sets the path the metrics are scraped from.
+	prometheusPathMeta = "prometheus_path"
+
+	// prometheusSchemeMeta sets the scheme the metrics are scraped with.
+	prometheusSchemeMeta = "prometheus_scheme"
+)
+
+// prometheusLabelPrefix is the prefix of the labels of the targets, the same
+// as the one of the Consul service discovery of Prometheus so relabeling
+// rules can be shared.
+const prometheusLabelPrefix = "__meta_consul_"
+
+// PrometheusTargetGroup is a group of targets sharing the same labels, as
+// expected by the HTTP service discovery of Prometheus.
+type PrometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// PrometheusTargets returns the service instances of the catalog in the
+// format of the HTTP service discovery of Prometheus, with one target group
+// per instance. The "passing" query parameter excludes the instances with
+// failing checks.
+func (s *HTTPHandlers) PrometheusTargets(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.ServiceDumpRequest{NodesOnly: true}
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+	s.parseFilter(req, &args.Filter)
+	_, passingOnly := req.URL.Query()["passing"]
+
+	var out structs.IndexedNodesWithGateways
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Internal.ServiceDump", &args, &out); err != nil {
+		return nil, err
+	}
+
+	groups := make([]PrometheusTargetGroup, 0, len(out.Nodes))
+	for _, csn := range out.Nodes {
+		if passingOnly && csn.AggregatedStatus() != api.HealthPassing {
+			continue
+		}
+		if group, ok := prometheusTargetGroup(args.Datacenter, csn); ok {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// prometheusTargetGroup returns the target group of the instance, or false
+// if it must not be scraped.
+func prometheusTargetGroup(datacenter string, csn structs.CheckServiceNode) (PrometheusTargetGroup, bool) {
+	node, svc := csn.Node, csn.Service
+	if node == nil || svc == nil || svc.Meta[prometheusScrapeMeta] == "false" {
+		return PrometheusTargetGroup{}, false
+	}
+
+	port := svc.Port
+	if hint := svc.Meta[prometheusPortMeta]; hint != "" {
+		p, err := strconv.Atoi(hint)
+		if err != nil {
+			return PrometheusTargetGroup{}, false
+		}
+		port = p
+	}
+	if port <= 0 {
+		return PrometheusTargetGroup{}, false
+	}
+
+	address := svc.Address
+	if address == "" {
+		address = node.Address
+	}
+
+	labels := map[string]string{
+		prometheusLabelPrefix + "address":         node.Address,
+		prometheusLabelPrefix + "dc":              datacenter,
+		prometheusLabelPrefix + "health":          csn.AggregatedStatus(),
+		prometheusLabelPrefix + "node":            node.Node,
+		prometheusLabelPrefix + "service":         svc.Service,
+		prometheusLabelPrefix + "service_address": address,
+		prometheusLabelPrefix + "service_id":      svc.ID,
+		prometheusLabelPrefix + "service_port":    strconv.Itoa(svc.Port),
+		prometheusLabelPrefix + "tags":            prometheusTagsLabel(svc.Tags),
+	}
+	if ns := svc.EnterpriseMeta.NamespaceOrEmpty(); ns != "" {
+		labels[prometheusLabelPrefix+"namespace"] = ns
+	}
+	if ap := svc.EnterpriseMeta.PartitionOrEmpty(); ap != "" {
+		labels[prometheusLabelPrefix+"partition"] = ap
+	}
+	for k, v := range node.Meta {
+		labels[prometheusLabelPrefix+"metadata_"+prometheusLabelName(k)] = v
+	}
+	for k, v := range node.TaggedAddresses {
+		labels[prometheusLabelPrefix+"tagged_address_"+prometheusLabelName(k)] = v
+	}
+	for k, v := range svc.Meta {
+		labels[prometheusLabelPrefix+"service_metadata_"+prometheusLabelName(k)] = v
+	}
+	if path := svc.Meta[prometheusPathMeta]; path != "" {
+		labels["__metrics_path__"] = path
+	}
+	if scheme := svc.Meta[prometheusSchemeMeta]; scheme != "" {
+		labels["__scheme__"] = scheme
+	}
+
+	return PrometheusTargetGroup{
+		Targets: []string{net.JoinHostPort(address, strconv.Itoa(port))},
+		Labels:  labels,
+	}, true
+}
+
+// prometheusTagsLabel joins the tags with commas, with a leading and a
+// trailing comma so relabeling rules can match ",tag,".
+func prometheusTagsLabel(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+// prometheusLabelName replaces the characters which are not allowed in the
+// names of Prometheus labels with underscores.
+func prometheusLabelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
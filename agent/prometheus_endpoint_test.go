@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestPrometheusTargets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	requests := []*structs.RegisterRequest{
+		{
+			Datacenter: "dc1",
+			Node:       "foo",
+			Address:    "10.0.0.1",
+			NodeMeta:   map[string]string{"rack": "r1"},
+			Service: &structs.NodeService{
+				ID:      "web-1",
+				Service: "web",
+				Port:    8080,
+				Tags:    []string{"primary", "v1"},
+				Meta: map[string]string{
+					"prometheus_port": "9102",
+					"prometheus_path": "/stats",
+					"team-name":       "edge",
+				},
+			},
+		},
+		{
+			Datacenter: "dc1",
+			Node:       "bar",
+			Address:    "10.0.0.2",
+			Service: &structs.NodeService{
+				ID:      "web-2",
+				Service: "web",
+				Address: "10.0.1.2",
+				Port:    8080,
+			},
+			Check: &structs.HealthCheck{
+				Node:      "bar",
+				CheckID:   "web-2-check",
+				ServiceID: "web-2",
+				Status:    api.HealthCritical,
+			},
+		},
+		{
+			Datacenter: "dc1",
+			Node:       "bar",
+			Address:    "10.0.0.2",
+			Service: &structs.NodeService{
+				ID:      "db-1",
+				Service: "db",
+				Port:    5432,
+				Meta:    map[string]string{"prometheus_scrape": "false"},
+			},
+		},
+	}
+	for _, args := range requests {
+		var out struct{}
+		require.NoError(t, a.RPC(context.Background(), "Catalog.Register", args, &out))
+	}
+
+	get := func(t *testing.T, query url.Values) []PrometheusTargetGroup {
+		t.Helper()
+		req, _ := http.NewRequest("GET", "/v1/internal/prometheus/targets?"+query.Encode(), nil)
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.PrometheusTargets(resp, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Header().Get("X-Consul-Index"))
+		return obj.([]PrometheusTargetGroup)
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		groups := get(t, url.Values{"filter": []string{`Service.Service == "web"`}})
+		require.Len(t, groups, 2)
+
+		byID := make(map[string]PrometheusTargetGroup)
+		for _, g := range groups {
+			byID[g.Labels["__meta_consul_service_id"]] = g
+		}
+
+		web1 := byID["web-1"]
+		require.Equal(t, []string{"10.0.0.1:9102"}, web1.Targets)
+		require.Equal(t, map[string]string{
+			"__meta_consul_address":                          "10.0.0.1",
+			"__meta_consul_dc":                               "dc1",
+			"__meta_consul_health":                           api.HealthPassing,
+			"__meta_consul_node":                             "foo",
+			"__meta_consul_service":                          "web",
+			"__meta_consul_service_address":                  "10.0.0.1",
+			"__meta_consul_service_id":                       "web-1",
+			"__meta_consul_service_port":                     "8080",
+			"__meta_consul_tags":                             ",primary,v1,",
+			"__meta_consul_metadata_rack":                    "r1",
+			"__meta_consul_service_metadata_prometheus_port": "9102",
+			"__meta_consul_service_metadata_prometheus_path": "/stats",
+			"__meta_consul_service_metadata_team_name":       "edge",
+			"__metrics_path__":                               "/stats",
+		}, web1.Labels)
+
+		web2 := byID["web-2"]
+		require.Equal(t, []string{"10.0.1.2:8080"}, web2.Targets)
+		require.Equal(t, api.HealthCritical, web2.Labels["__meta_consul_health"])
+	})
+
+	t.Run("passing", func(t *testing.T) {
+		groups := get(t, url.Values{
+			"filter":  []string{`Service.Service == "web"`},
+			"passing": []string{""},
+		})
+		require.Len(t, groups, 1)
+		require.Equal(t, "web-1", groups[0].Labels["__meta_consul_service_id"])
+	})
+
+	t.Run("scrape disabled", func(t *testing.T) {
+		groups := get(t, url.Values{"filter": []string{`Service.Service == "db"`}})
+		require.Empty(t, groups)
+	})
+}
@@ -92,6 +92,15 @@ func CachePrepraredQuery(c *cache.Cache) proxycfg.PreparedQuery {
 	return &cacheProxyDataSource[*structs.PreparedQueryExecuteRequest]{c, cachetype.PreparedQueryName}
 }
 
+// CacheVaultClientCertificate satisfies the proxycfg.VaultClientCertificate
+// interface by sourcing data from the agent cache.
+//
+// Note: there isn't a server-local equivalent of this data source because the
+// certificates are issued by Vault rather than the Consul servers.
+func CacheVaultClientCertificate(c *cache.Cache) proxycfg.VaultClientCertificate {
+	return &cacheProxyDataSource[*cachetype.VaultClientCertificateRequest]{c, cachetype.VaultClientCertificateName}
+}
+
 // cacheProxyDataSource implements a generic wrapper around the agent cache to
 // provide data to the proxycfg.Manager.
 type cacheProxyDataSource[ReqType cache.Request] struct {
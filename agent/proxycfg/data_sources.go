@@ -131,6 +131,10 @@ type DataSources struct {
 	// services in a datacenter on a notification channel.
 	ExportedPeeredServices ExportedPeeredServices
 
+	// VaultClientCertificate provides updates about client certificates issued
+	// by a Vault PKI secrets engine on a notification channel.
+	VaultClientCertificate VaultClientCertificate
+
 	DataSourcesEnterprise
 }
 
@@ -262,3 +266,9 @@ type TrustBundleList interface {
 type ExportedPeeredServices interface {
 	Notify(ctx context.Context, req *structs.DCSpecificRequest, correlationID string, ch chan<- UpdateEvent) error
 }
+
+// VaultClientCertificate is the interface used to consume updates about a
+// client certificate issued by a Vault PKI secrets engine.
+type VaultClientCertificate interface {
+	Notify(ctx context.Context, req *cachetype.VaultClientCertificateRequest, correlationID string, ch chan<- UpdateEvent) error
+}
@@ -896,5 +896,22 @@ func (o *configSnapshotTerminatingGateway) DeepCopy() *configSnapshotTerminating
 			cp.InboundPeerTrustBundles[k2] = cp_InboundPeerTrustBundles_v2
 		}
 	}
+	if o.WatchedVaultClientCerts != nil {
+		cp.WatchedVaultClientCerts = make(map[structs.ServiceName]context.CancelFunc, len(o.WatchedVaultClientCerts))
+		for k2, v2 := range o.WatchedVaultClientCerts {
+			cp.WatchedVaultClientCerts[k2] = v2
+		}
+	}
+	if o.VaultClientCerts != nil {
+		cp.VaultClientCerts = make(map[structs.ServiceName]*structs.IssuedCert, len(o.VaultClientCerts))
+		for k2, v2 := range o.VaultClientCerts {
+			var cp_VaultClientCerts_v2 *structs.IssuedCert
+			if v2 != nil {
+				cp_VaultClientCerts_v2 = new(structs.IssuedCert)
+				*cp_VaultClientCerts_v2 = *v2
+			}
+			cp.VaultClientCerts[k2] = cp_VaultClientCerts_v2
+		}
+	}
 	return &cp
 }
@@ -294,6 +294,15 @@ type configSnapshotTerminatingGateway struct {
 	// These bundles are used to configure RBAC policies for inbound filter chains on the gateway
 	// from services that are in a cluster-peered datacenter.
 	InboundPeerTrustBundles map[structs.ServiceName][]*pbpeering.PeeringTrustBundle
+
+	// WatchedVaultClientCerts is a map of service name to a cancel function.
+	// This cancel function is tied to the watch of the client certificate
+	// issued by Vault for linked services configured with VaultPKI.
+	WatchedVaultClientCerts map[structs.ServiceName]context.CancelFunc
+
+	// VaultClientCerts is a map of service name to the client certificate
+	// the gateway presents when originating TLS to the linked service.
+	VaultClientCerts map[structs.ServiceName]*structs.IssuedCert
 }
 
 // ValidServices returns the list of service keys that have enough data to be emitted.
@@ -312,6 +321,12 @@ func (c *configSnapshotTerminatingGateway) ValidServices() []structs.ServiceName
 			continue
 		}
 
+		// Skip the service if we don't have the client cert issued by Vault
+		// to present when originating TLS.
+		if !c.hasVaultClientCert(svc) {
+			continue
+		}
+
 		// Skip the service if we haven't gotten our intentions yet.
 		if _, intentionsSet := c.Intentions[svc]; !intentionsSet {
 			continue
@@ -328,6 +343,19 @@ func (c *configSnapshotTerminatingGateway) ValidServices() []structs.ServiceName
 	return out
 }
 
+// hasVaultClientCert returns whether the client certificate issued by Vault
+// for the given service is available, or the service does not need one.
+func (c *configSnapshotTerminatingGateway) hasVaultClientCert(svc structs.ServiceName) bool {
+	mapping, ok := c.GatewayServices[svc]
+	if !ok {
+		mapping, ok = c.DestinationServices[svc]
+	}
+	if !ok || mapping.VaultPKI == nil {
+		return true
+	}
+	return c.VaultClientCerts[svc] != nil
+}
+
 // ValidDestinations returns the list of service keys (that represent exclusively endpoints) that have enough data to be emitted.
 func (c *configSnapshotTerminatingGateway) ValidDestinations() []structs.ServiceName {
 	out := make([]structs.ServiceName, 0, len(c.DestinationServices))
@@ -339,6 +367,12 @@ func (c *configSnapshotTerminatingGateway) ValidDestinations() []structs.Service
 			continue
 		}
 
+		// Skip the service if we don't have the client cert issued by Vault
+		// to present when originating TLS.
+		if !c.hasVaultClientCert(svc) {
+			continue
+		}
+
 		// Skip the service if we haven't gotten our intentions yet.
 		if _, intentionsSet := c.Intentions[svc]; !intentionsSet {
 			continue
@@ -1110,6 +1144,12 @@ func (s *ConfigSnapshot) RootPEMs() string {
 	return rootPEMs
 }
 
+// RevocationListPEMs returns the certificate revocation lists of all the
+// datacenters, when the leaf certs of peers need to be checked against them.
+func (s *ConfigSnapshot) RevocationListPEMs() string {
+	return s.Roots.RevocationList
+}
+
 func (s *ConfigSnapshot) MeshConfig() *structs.MeshConfigEntry {
 	switch s.Kind {
 	case structs.ServiceKindConnectProxy:
@@ -47,6 +47,7 @@ const (
 	serviceConfigIDPrefix              = "service-config:"
 	serviceResolverIDPrefix            = "service-resolver:"
 	serviceIntentionsIDPrefix          = "service-intentions:"
+	vaultClientCertIDPrefix            = "vault-client-cert:"
 	intentionUpstreamsID               = "intention-upstreams"
 	jwtProviderID                      = "jwt-provider"
 	peerServersWatchID                 = "peer-servers"
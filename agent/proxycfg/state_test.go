@@ -148,6 +148,7 @@ func recordWatches(sc *stateConfig) *watchRecorder {
 		TrustBundle:                     typedWatchRecorder[*cachetype.TrustBundleReadRequest]{wr},
 		TrustBundleList:                 typedWatchRecorder[*cachetype.TrustBundleListRequest]{wr},
 		ExportedPeeredServices:          typedWatchRecorder[*structs.DCSpecificRequest]{wr},
+		VaultClientCertificate:          typedWatchRecorder[*cachetype.VaultClientCertificateRequest]{wr},
 	}
 	recordWatchesEnterprise(sc, wr)
 
@@ -291,6 +292,15 @@ func genVerifyResolvedConfigWatch(expectedService string, expectedDatacenter str
 	}
 }
 
+func genVerifyVaultClientCertWatch(expectedPath, expectedCommonName string) verifyWatchRequest {
+	return func(t testing.TB, request any) {
+		reqReal, ok := request.(*cachetype.VaultClientCertificateRequest)
+		require.True(t, ok)
+		require.Equal(t, expectedPath, reqReal.Path)
+		require.Equal(t, expectedCommonName, reqReal.CommonName)
+	}
+}
+
 func genVerifyIntentionWatch(expectedService string, expectedDatacenter string) verifyWatchRequest {
 	return func(t testing.TB, request any) {
 		reqReal, ok := request.(*structs.ServiceSpecificRequest)
@@ -2449,6 +2459,148 @@ func TestState_WatchesAndUpdates(t *testing.T) {
 				},
 			},
 		},
+		"terminating-gateway-vault-client-cert": {
+			ns: structs.NodeService{
+				Kind:    structs.ServiceKindTerminatingGateway,
+				ID:      "terminating-gateway",
+				Service: "terminating-gateway",
+				Address: "10.0.1.1",
+			},
+			sourceDC: "dc1",
+			stages: []verificationStage{
+				{
+					events: []UpdateEvent{
+						rootWatchEvent(),
+						{
+							CorrelationID: meshConfigEntryID,
+							Result:        &structs.ConfigEntryResponse{},
+						},
+						{
+							CorrelationID: gatewayServicesWatchID,
+							Result: &structs.IndexedGatewayServices{
+								Services: structs.GatewayServices{
+									{
+										Service: db,
+										Gateway: structs.NewServiceName("terminating-gateway", nil),
+										CAFile:  "ca.pem",
+										VaultPKI: &structs.LinkedServiceVaultPKI{
+											Path:       "pki/issue/gateway",
+											CommonName: "gateway.example.com",
+										},
+									},
+								},
+							},
+						},
+						{
+							CorrelationID: "external-service:" + db.String(),
+							Result: &structs.IndexedCheckServiceNodes{
+								Nodes: structs.CheckServiceNodes{
+									{
+										Node: &structs.Node{
+											Node:    "node1",
+											Address: "127.0.0.1",
+										},
+										Service: &structs.NodeService{
+											ID:      "db",
+											Service: "db",
+										},
+									},
+								},
+							},
+						},
+						{
+							CorrelationID: "service-leaf:" + db.String(),
+							Result:        issuedCert,
+						},
+						{
+							CorrelationID: serviceIntentionsIDPrefix + db.String(),
+							Result:        dbIxnMatch,
+						},
+						{
+							CorrelationID: serviceConfigIDPrefix + db.String(),
+							Result:        dbConfig,
+						},
+						{
+							CorrelationID: "service-resolver:" + db.String(),
+							Result:        &structs.ConfigEntryResponse{},
+						},
+					},
+					verifySnapshot: func(t testing.TB, snap *ConfigSnapshot) {
+						require.True(t, snap.Valid(), "gateway with service list is valid")
+						// The service is not valid until its client cert was issued by Vault
+						require.Len(t, snap.TerminatingGateway.ValidServices(), 0)
+
+						require.Len(t, snap.TerminatingGateway.WatchedVaultClientCerts, 1)
+						require.Contains(t, snap.TerminatingGateway.WatchedVaultClientCerts, db)
+					},
+				},
+				{
+					requiredWatches: map[string]verifyWatchRequest{
+						vaultClientCertIDPrefix + db.String(): genVerifyVaultClientCertWatch("pki/issue/gateway", "gateway.example.com"),
+					},
+					events: []UpdateEvent{
+						{
+							CorrelationID: vaultClientCertIDPrefix + db.String(),
+							Result:        issuedCert,
+						},
+					},
+					verifySnapshot: func(t testing.TB, snap *ConfigSnapshot) {
+						require.Equal(t, []structs.ServiceName{db}, snap.TerminatingGateway.ValidServices())
+						require.Equal(t, issuedCert, snap.TerminatingGateway.VaultClientCerts[db])
+					},
+				},
+				{
+					events: []UpdateEvent{
+						{
+							CorrelationID: gatewayServicesWatchID,
+							Result: &structs.IndexedGatewayServices{
+								Services: structs.GatewayServices{
+									{
+										Service: db,
+										Gateway: structs.NewServiceName("terminating-gateway", nil),
+										CAFile:  "ca.pem",
+										VaultPKI: &structs.LinkedServiceVaultPKI{
+											Path:       "pki/issue/gateway",
+											CommonName: "db.example.com",
+										},
+									},
+								},
+							},
+						},
+					},
+					verifySnapshot: func(t testing.TB, snap *ConfigSnapshot) {
+						// The watch was restarted for the new configuration
+						require.Len(t, snap.TerminatingGateway.ValidServices(), 0)
+						require.Contains(t, snap.TerminatingGateway.WatchedVaultClientCerts, db)
+						require.NotContains(t, snap.TerminatingGateway.VaultClientCerts, db)
+					},
+				},
+				{
+					requiredWatches: map[string]verifyWatchRequest{
+						vaultClientCertIDPrefix + db.String(): genVerifyVaultClientCertWatch("pki/issue/gateway", "db.example.com"),
+					},
+					events: []UpdateEvent{
+						{
+							CorrelationID: gatewayServicesWatchID,
+							Result: &structs.IndexedGatewayServices{
+								Services: structs.GatewayServices{
+									{
+										Service: db,
+										Gateway: structs.NewServiceName("terminating-gateway", nil),
+									},
+								},
+							},
+						},
+					},
+					verifySnapshot: func(t testing.TB, snap *ConfigSnapshot) {
+						// The client cert is not needed anymore
+						require.Equal(t, []structs.ServiceName{db}, snap.TerminatingGateway.ValidServices())
+						require.Len(t, snap.TerminatingGateway.WatchedVaultClientCerts, 0)
+						require.Len(t, snap.TerminatingGateway.VaultClientCerts, 0)
+					},
+				},
+			},
+		},
 		"transparent-proxy-initial": {
 			ns: structs.NodeService{
 				Kind:    structs.ServiceKindConnectProxy,
@@ -3765,12 +3917,12 @@ func TestState_WatchesAndUpdates(t *testing.T) {
 								Token: aclToken,
 							},
 						}),
-						rootsWatchID:                                   genVerifyDCSpecificWatch("dc1"),
-						leafWatchID:                                    genVerifyLeafWatch("web", "dc1"),
-						peeringTrustBundlesWatchID:                     genVerifyTrustBundleListWatch("web"),
-						peerTrustBundleIDPrefix + "peer-a":             genVerifyTrustBundleReadWatch("peer-a"),
+						rootsWatchID:                       genVerifyDCSpecificWatch("dc1"),
+						leafWatchID:                        genVerifyLeafWatch("web", "dc1"),
+						peeringTrustBundlesWatchID:         genVerifyTrustBundleListWatch("web"),
+						peerTrustBundleIDPrefix + "peer-a": genVerifyTrustBundleReadWatch("peer-a"),
 						upstreamPeerWatchIDPrefix + extApiUID.String(): genVerifyServiceSpecificPeeredRequest("api-a", "", "dc1", "peer-a", true),
-						"mesh-gateway:dc1":                             genVerifyGatewayWatch("dc1"),
+						"mesh-gateway:dc1": genVerifyGatewayWatch("dc1"),
 					},
 					verifySnapshot: func(t testing.TB, snap *ConfigSnapshot) {
 						require.False(t, snap.Valid(), "should not be valid")
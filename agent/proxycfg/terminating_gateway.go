@@ -72,6 +72,8 @@ func (s *handlerTerminatingGateway) initialize(ctx context.Context) (ConfigSnaps
 	snap.TerminatingGateway.HostnameServices = make(map[structs.ServiceName]structs.CheckServiceNodes)
 	snap.TerminatingGateway.WatchedInboundPeerTrustBundles = make(map[structs.ServiceName]context.CancelFunc)
 	snap.TerminatingGateway.InboundPeerTrustBundles = make(map[structs.ServiceName][]*pbpeering.PeeringTrustBundle)
+	snap.TerminatingGateway.WatchedVaultClientCerts = make(map[structs.ServiceName]context.CancelFunc)
+	snap.TerminatingGateway.VaultClientCerts = make(map[structs.ServiceName]*structs.IssuedCert)
 	return snap, nil
 }
 
@@ -118,6 +120,21 @@ func (s *handlerTerminatingGateway) handleUpdate(ctx context.Context, u UpdateEv
 			// Make sure to add every service to this map, we use it to cancel watches below.
 			svcMap[svc.Service] = struct{}{}
 
+			// Restart the watch of the client cert issued by Vault if its
+			// configuration changed.
+			if cancelFn, ok := snap.TerminatingGateway.WatchedVaultClientCerts[svc.Service]; ok {
+				prev, ok := snap.TerminatingGateway.GatewayServices[svc.Service]
+				if !ok {
+					prev = snap.TerminatingGateway.DestinationServices[svc.Service]
+				}
+				if !prev.VaultPKI.Equal(svc.VaultPKI) {
+					logger.Debug("canceling watch for vault client cert", "service", svc.Service.String())
+					delete(snap.TerminatingGateway.WatchedVaultClientCerts, svc.Service)
+					delete(snap.TerminatingGateway.VaultClientCerts, svc.Service)
+					cancelFn()
+				}
+			}
+
 			// Store the gateway <-> service mapping for TLS origination
 			if svc.ServiceKind == structs.GatewayServiceKindDestination {
 				snap.TerminatingGateway.DestinationServices[svc.Service] = *svc
@@ -217,6 +234,25 @@ func (s *handlerTerminatingGateway) handleUpdate(ctx context.Context, u UpdateEv
 				snap.TerminatingGateway.WatchedLeaves[svc.Service] = cancel
 			}
 
+			// Watch the client cert issued by Vault for the service
+			// This cert is presented when originating TLS to the service
+			if _, ok := snap.TerminatingGateway.WatchedVaultClientCerts[svc.Service]; !ok && svc.VaultPKI != nil {
+				ctx, cancel := context.WithCancel(ctx)
+				err := s.dataSources.VaultClientCertificate.Notify(ctx, &cachetype.VaultClientCertificateRequest{
+					LinkedServiceVaultPKI: *svc.VaultPKI,
+				}, vaultClientCertIDPrefix+svc.Service.String(), s.ch)
+
+				if err != nil {
+					logger.Error("failed to register watch for a vault client cert",
+						"service", svc.Service.String(),
+						"error", err,
+					)
+					cancel()
+					return err
+				}
+				snap.TerminatingGateway.WatchedVaultClientCerts[svc.Service] = cancel
+			}
+
 			// Watch service configs for the service.
 			// These are used to determine the protocol for the target service.
 			if _, ok := snap.TerminatingGateway.WatchedConfigs[svc.Service]; !ok {
@@ -305,6 +341,16 @@ func (s *handlerTerminatingGateway) handleUpdate(ctx context.Context, u UpdateEv
 			}
 		}
 
+		// Cancel vault client cert watches for services that were not in the update
+		for sn, cancelFn := range snap.TerminatingGateway.WatchedVaultClientCerts {
+			if _, ok := svcMap[sn]; !ok {
+				logger.Debug("canceling watch for vault client cert", "service", sn.String())
+				delete(snap.TerminatingGateway.WatchedVaultClientCerts, sn)
+				delete(snap.TerminatingGateway.VaultClientCerts, sn)
+				cancelFn()
+			}
+		}
+
 		// Cancel service config watches for services that were not in the update
 		for sn, cancelFn := range snap.TerminatingGateway.WatchedConfigs {
 			if _, ok := svcMap[sn]; !ok {
@@ -375,6 +421,16 @@ func (s *handlerTerminatingGateway) handleUpdate(ctx context.Context, u UpdateEv
 		sn := structs.ServiceNameFromString(strings.TrimPrefix(u.CorrelationID, serviceLeafIDPrefix))
 		snap.TerminatingGateway.ServiceLeaves[sn] = leaf
 
+	// Store the vault client cert for watched service
+	case strings.HasPrefix(u.CorrelationID, vaultClientCertIDPrefix):
+		cert, ok := u.Result.(*structs.IssuedCert)
+		if !ok {
+			return fmt.Errorf("invalid type for response: %T", u.Result)
+		}
+
+		sn := structs.ServiceNameFromString(strings.TrimPrefix(u.CorrelationID, vaultClientCertIDPrefix))
+		snap.TerminatingGateway.VaultClientCerts[sn] = cert
+
 	case strings.HasPrefix(u.CorrelationID, serviceConfigIDPrefix):
 		serviceConfig, ok := u.Result.(*structs.ServiceConfigResponse)
 		if !ok {
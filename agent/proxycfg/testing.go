@@ -798,6 +798,7 @@ func testConfigSnapshotFixture(
 			ServiceList:                     &noopDataSource[*structs.DCSpecificRequest]{},
 			TrustBundle:                     &noopDataSource[*cachetype.TrustBundleReadRequest]{},
 			TrustBundleList:                 &noopDataSource[*cachetype.TrustBundleListRequest]{},
+			VaultClientCertificate:          &noopDataSource[*cachetype.VaultClientCertificateRequest]{},
 			ExportedPeeredServices:          &noopDataSource[*structs.DCSpecificRequest]{},
 		},
 		dnsConfig: DNSConfig{ // TODO: make configurable
@@ -1002,6 +1003,7 @@ func NewTestDataSources() *TestDataSources {
 		ServiceList:                     NewTestDataSource[*structs.DCSpecificRequest, *structs.IndexedServiceList](),
 		TrustBundle:                     NewTestDataSource[*cachetype.TrustBundleReadRequest, *pbpeering.TrustBundleReadResponse](),
 		TrustBundleList:                 NewTestDataSource[*cachetype.TrustBundleListRequest, *pbpeering.TrustBundleListByServiceResponse](),
+		VaultClientCertificate:          NewTestDataSource[*cachetype.VaultClientCertificateRequest, *structs.IssuedCert](),
 	}
 	srcs.buildEnterpriseSources()
 	return srcs
@@ -1030,6 +1032,7 @@ type TestDataSources struct {
 	ServiceList                     *TestDataSource[*structs.DCSpecificRequest, *structs.IndexedServiceList]
 	TrustBundle                     *TestDataSource[*cachetype.TrustBundleReadRequest, *pbpeering.TrustBundleReadResponse]
 	TrustBundleList                 *TestDataSource[*cachetype.TrustBundleListRequest, *pbpeering.TrustBundleListByServiceResponse]
+	VaultClientCertificate          *TestDataSource[*cachetype.VaultClientCertificateRequest, *structs.IssuedCert]
 
 	TestDataSourcesEnterprise
 }
@@ -1057,6 +1060,7 @@ func (t *TestDataSources) ToDataSources() DataSources {
 		ServiceList:                   t.ServiceList,
 		TrustBundle:                   t.TrustBundle,
 		TrustBundleList:               t.TrustBundleList,
+		VaultClientCertificate:        t.VaultClientCertificate,
 	}
 	t.fillEnterpriseDataSources(&ds)
 	return ds
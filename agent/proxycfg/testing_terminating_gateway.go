@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/types"
 )
 
 func TestConfigSnapshotTerminatingGateway(t testing.T, populateServices bool, nsFn func(ns *structs.NodeService), extraUpdates []UpdateEvent) *ConfigSnapshot {
@@ -741,6 +742,58 @@ func TestConfigSnapshotTerminatingGatewaySNI(t testing.T) *ConfigSnapshot {
 	})
 }
 
+func TestConfigSnapshotTerminatingGatewayTLSOrigination(t testing.T) *ConfigSnapshot {
+	var (
+		web = structs.NewServiceName("web", nil)
+		api = structs.NewServiceName("api", nil)
+	)
+
+	return TestConfigSnapshotTerminatingGateway(t, true, nil, []UpdateEvent{
+		{
+			CorrelationID: gatewayServicesWatchID,
+			Result: &structs.IndexedGatewayServices{
+				Services: []*structs.GatewayService{
+					{
+						Service:       web,
+						CAPEM:         "web-ca-pem",
+						TLSMinVersion: types.TLSv1_2,
+						TLSMaxVersion: types.TLSv1_3,
+						CipherSuites: []types.TLSCipherSuite{
+							types.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+							types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+						},
+						SNI:             "web.example.com",
+						HostRewrite:     "web.example.com",
+						AutoHostRewrite: true,
+					},
+					{
+						Service: api,
+						CAFile:  "ca.cert.pem",
+						VaultPKI: &structs.LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+						},
+						SNI: "api.example.com",
+					},
+				},
+			},
+		},
+		{
+			CorrelationID: vaultClientCertIDPrefix + api.String(),
+			Result: &structs.IssuedCert{
+				CertPEM:       "api-vault-cert-pem",
+				PrivateKeyPEM: "api-vault-key-pem",
+			},
+		},
+		{
+			CorrelationID: serviceConfigIDPrefix + web.String(),
+			Result: &structs.ServiceConfigResponse{
+				ProxyConfig: map[string]interface{}{"protocol": "http"},
+			},
+		},
+	})
+}
+
 func TestConfigSnapshotTerminatingGatewayHTTP2(t testing.T) *ConfigSnapshot {
 	web := structs.NewServiceName("web", nil)
 
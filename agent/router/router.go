@@ -538,6 +538,40 @@ func (r *Router) GetDatacentersByDistance() ([]string, error) {
 	r.RLock()
 	defer r.RUnlock()
 
+	dcs, err := r.datacenterDistances()
+	if err != nil {
+		return nil, err
+	}
+
+	// First sort by DC name, since we do a stable sort later.
+	names := make([]string, 0, len(dcs))
+	for dc := range dcs {
+		names = append(names, dc)
+	}
+	sort.Strings(names)
+
+	// Then stable sort by median RTT.
+	rtts := make([]float64, 0, len(dcs))
+	for _, dc := range names {
+		rtts = append(rtts, dcs[dc])
+	}
+	sort.Stable(&datacenterSorter{names, rtts})
+	return names, nil
+}
+
+// GetDatacenterDistances returns the median RTT in seconds from this server to
+// the servers in each datacenter known to the router. Datacenters whose
+// servers have no known coordinate are at positive infinity.
+func (r *Router) GetDatacenterDistances() (map[string]float64, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.datacenterDistances()
+}
+
+// datacenterDistances computes the median RTT to each known datacenter. The
+// caller must hold the read lock.
+func (r *Router) datacenterDistances() (map[string]float64, error) {
 	// Go through each area and aggregate the median RTT from the current
 	// server to the other servers in each datacenter.
 	dcs := make(map[string]float64)
@@ -555,7 +589,7 @@ func (r *Router) GetDatacentersByDistance() ([]string, error) {
 					r.logger.Warn("Non-server in server-only area",
 						"non_server", m.Name,
 						"area", areaID,
-						"func", "GetDatacentersByDistance",
+						"func", "datacenterDistances",
 					)
 				}
 				continue
@@ -565,7 +599,7 @@ func (r *Router) GetDatacentersByDistance() ([]string, error) {
 				r.logger.Debug("server in area left, skipping",
 					"server", m.Name,
 					"area", areaID,
-					"func", "GetDatacentersByDistance",
+					"func", "datacenterDistances",
 				)
 				continue
 			}
@@ -597,21 +631,7 @@ func (r *Router) GetDatacentersByDistance() ([]string, error) {
 			}
 		}
 	}
-
-	// First sort by DC name, since we do a stable sort later.
-	names := make([]string, 0, len(dcs))
-	for dc := range dcs {
-		names = append(names, dc)
-	}
-	sort.Strings(names)
-
-	// Then stable sort by median RTT.
-	rtts := make([]float64, 0, len(dcs))
-	for _, dc := range names {
-		rtts = append(rtts, dcs[dc])
-	}
-	sort.Stable(&datacenterSorter{names, rtts})
-	return names, nil
+	return dcs, nil
 }
 
 // GetDatacenterMaps returns a structure with the raw network coordinates of
@@ -18,7 +18,11 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"ACL.BindingRuleList":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.BindingRuleRead":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.BindingRuleSet":    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
+	"ACL.BreakGlassInit":    {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
+	"ACL.BreakGlassStatus":  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
+	"ACL.BreakGlassUnseal":  {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.BootstrapTokens":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
+	"ACL.ClientCertLogin":   {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.Login":             {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.Logout":            {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.PolicyBatchRead":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
@@ -28,18 +32,22 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"ACL.PolicyResolve":     {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.PolicySet":         {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.ReplicationStatus": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
+	"ACL.ReportTokenUsage":  {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.RoleBatchRead":     {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.RoleDelete":        {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.RoleList":          {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.RoleRead":          {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.RoleResolve":       {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.RoleSet":           {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
+	"ACL.SocketCredLogin":   {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
+	"ACL.StaleTokens":       {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.TokenBatchRead":    {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.TokenClone":        {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.TokenDelete":       {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
 	"ACL.TokenList":         {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.TokenRead":         {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 	"ACL.TokenSet":          {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryACL},
+	"ACL.TokenUsage":        {Type: rate.OperationTypeRead, Category: rate.OperationCategoryACL},
 
 	"AutoConfig.InitialConfiguration": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryAutoConfig},
 
@@ -60,12 +68,18 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"ConfigEntry.Apply":                {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConfigEntry},
 	"ConfigEntry.Delete":               {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConfigEntry},
 	"ConfigEntry.Get":                  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
+	"ConfigEntry.Lint":                 {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
 	"ConfigEntry.List":                 {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
 	"ConfigEntry.ListAll":              {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
 	"ConfigEntry.ResolveServiceConfig": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConfigEntry},
 
 	"ConnectCA.ConfigurationGet": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
 	"ConnectCA.ConfigurationSet": {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConnectCA},
+	"ConnectCA.Inventory":        {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
+	"ConnectCA.OCSP":             {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
+	"ConnectCA.Revoke":           {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConnectCA},
+	"ConnectCA.RevocationList":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
+	"ConnectCA.RevokedCerts":     {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
 	"ConnectCA.Roots":            {Type: rate.OperationTypeRead, Category: rate.OperationCategoryConnectCA},
 	"ConnectCA.Sign":             {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConnectCA},
 	"ConnectCA.SignIntermediate": {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryConnectCA},
@@ -87,12 +101,15 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"Health.NodeChecks":    {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
 	"Health.ServiceChecks": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
 	"Health.ServiceNodes":  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
+	"Health.ServicesNodes": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
+	"Health.Summary":       {Type: rate.OperationTypeRead, Category: rate.OperationCategoryHealth},
 
-	"Intention.Apply": {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryIntention},
-	"Intention.Check": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
-	"Intention.Get":   {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
-	"Intention.List":  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
-	"Intention.Match": {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
+	"Intention.Apply":           {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryIntention},
+	"Intention.Check":           {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
+	"Intention.Get":             {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
+	"Intention.List":            {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
+	"Intention.Match":           {Type: rate.OperationTypeRead, Category: rate.OperationCategoryIntention},
+	"Intention.ReportDecisions": {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryIntention},
 
 	"Internal.CatalogOverview":               {Type: rate.OperationTypeRead, Category: rate.OperationCategoryInternal},
 	"Internal.EventFire":                     {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryInternal},
@@ -118,6 +135,7 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"Operator.AutopilotGetConfiguration": {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"Operator.AutopilotSetConfiguration": {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"Operator.AutopilotState":            {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
+	"Operator.ClusterFeatures":           {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"Operator.RaftGetConfiguration":      {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"Operator.RaftRemovePeerByAddress":   {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
 	"Operator.RaftRemovePeerByID":        {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryOperator},
@@ -137,10 +155,11 @@ var rpcRateLimitSpecs = map[string]rate.OperationSpec{
 	"Session.NodeSessions": {Type: rate.OperationTypeRead, Category: rate.OperationCategorySession},
 	"Session.Renew":        {Type: rate.OperationTypeWrite, Category: rate.OperationCategorySession},
 
-	"Status.Leader":    {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
-	"Status.Peers":     {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
-	"Status.Ping":      {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
-	"Status.RaftStats": {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
+	"Status.Leader":      {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
+	"Status.Peers":       {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
+	"Status.Ping":        {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
+	"Status.RaftStats":   {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
+	"Status.XDSSessions": {Type: rate.OperationTypeExempt, Category: rate.OperationCategoryStatus},
 
 	"Txn.Apply": {Type: rate.OperationTypeWrite, Category: rate.OperationCategoryTxn},
 	"Txn.Read":  {Type: rate.OperationTypeRead, Category: rate.OperationCategoryTxn},
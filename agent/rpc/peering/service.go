@@ -221,6 +221,13 @@ func (s *Server) GenerateToken(
 		return nil, fmt.Errorf("meta tags failed validation: %w", err)
 	}
 
+	if err := validateACLPolicyNames(req.ACLPolicies); err != nil {
+		return nil, fmt.Errorf("ACL policies failed validation: %w", err)
+	}
+	if req.ClearACLPolicies && len(req.ACLPolicies) > 0 {
+		return nil, fmt.Errorf("ACL policies cannot be both set and cleared")
+	}
+
 	resp := &pbpeering.GenerateTokenResponse{}
 	handled, err := s.ForwardRPC(&writeRequest, func(conn *grpc.ClientConn) error {
 		ctx := external.ForwardMetadataContext(ctx)
@@ -273,9 +280,10 @@ func (s *Server) GenerateToken(
 				return resp, err
 			}
 			peering = &pbpeering.Peering{
-				ID:   id,
-				Name: req.PeerName,
-				Meta: req.Meta,
+				ID:          id,
+				Name:        req.PeerName,
+				Meta:        req.Meta,
+				ACLPolicies: req.ACLPolicies,
 
 				// PartitionOrEmpty is used to avoid writing "default" in CE.
 				Partition: entMeta.PartitionOrEmpty(),
@@ -285,6 +293,17 @@ func (s *Server) GenerateToken(
 			if err := validatePeer(peering, false); err != nil {
 				return nil, err
 			}
+
+			// The ACL policies given replace the ones of the peering, so that
+			// what is exported to an existing peer can be changed.
+			switch {
+			case req.ClearACLPolicies:
+				peering = proto.Clone(peering).(*pbpeering.Peering)
+				peering.ACLPolicies = nil
+			case len(req.ACLPolicies) > 0:
+				peering = proto.Clone(peering).(*pbpeering.Peering)
+				peering.ACLPolicies = req.ACLPolicies
+			}
 		}
 
 		// A new establishment secret is generated on every GenerateToken request.
@@ -377,6 +396,10 @@ func (s *Server) Establish(
 		return nil, fmt.Errorf("meta tags failed validation: %w", err)
 	}
 
+	if err := validateACLPolicyNames(req.ACLPolicies); err != nil {
+		return nil, fmt.Errorf("ACL policies failed validation: %w", err)
+	}
+
 	resp := &pbpeering.EstablishResponse{}
 	handled, err := s.ForwardRPC(&writeRequest, func(conn *grpc.ClientConn) error {
 		ctx := external.ForwardMetadataContext(ctx)
@@ -445,6 +468,7 @@ func (s *Server) Establish(
 		PeerServerName:        tok.ServerName,
 		PeerID:                tok.PeerID,
 		Meta:                  req.Meta,
+		ACLPolicies:           req.ACLPolicies,
 
 		// State is intentionally not set until after the secret exchange succeeds.
 		// This is to prevent a scenario where an active peering is re-established,
@@ -182,6 +182,43 @@ func TestPeeringService_GenerateToken(t *testing.T) {
 		require.Equal(t, token.EstablishmentSecret, s.GetEstablishment().GetSecretID())
 	})
 
+	testutil.RunStep(t, "re-generating a peering token updates the ACL policies", func(t *testing.T) {
+		req := pbpeering.GenerateTokenRequest{PeerName: "peer-b", ACLPolicies: []string{"peer-b-exports"}}
+		_, err := client.GenerateToken(ctx, &req)
+		require.NoError(t, err)
+
+		_, peering, err := s.Server.FSM().State().PeeringRead(nil, state.Query{Value: "peer-b"})
+		require.NoError(t, err)
+		require.Equal(t, peerID, peering.ID)
+		require.Equal(t, []string{"peer-b-exports"}, peering.ACLPolicies)
+		require.Equal(t, map[string]string{"foo": "bar"}, peering.Meta)
+
+		// The policies are kept when none are given.
+		req = pbpeering.GenerateTokenRequest{PeerName: "peer-b"}
+		_, err = client.GenerateToken(ctx, &req)
+		require.NoError(t, err)
+
+		_, peering, err = s.Server.FSM().State().PeeringRead(nil, state.Query{Value: "peer-b"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"peer-b-exports"}, peering.ACLPolicies)
+
+		// The policies can't be both set and cleared.
+		req = pbpeering.GenerateTokenRequest{PeerName: "peer-b", ACLPolicies: []string{"other"}, ClearACLPolicies: true}
+		_, err = client.GenerateToken(ctx, &req)
+		require.ErrorContains(t, err, "ACL policies cannot be both set and cleared")
+	})
+
+	testutil.RunStep(t, "re-generating a peering token can clear the ACL policies", func(t *testing.T) {
+		req := pbpeering.GenerateTokenRequest{PeerName: "peer-b", ClearACLPolicies: true}
+		_, err := client.GenerateToken(ctx, &req)
+		require.NoError(t, err)
+
+		_, peering, err := s.Server.FSM().State().PeeringRead(nil, state.Query{Value: "peer-b"})
+		require.NoError(t, err)
+		require.Equal(t, peerID, peering.ID)
+		require.Empty(t, peering.ACLPolicies)
+		require.Equal(t, map[string]string{"foo": "bar"}, peering.Meta)
+	})
 }
 
 func TestPeeringService_GenerateTokenExternalAddress(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
 )
@@ -30,6 +31,18 @@ func validatePeerName(name string) error {
 	return nil
 }
 
+// validateACLPolicyNames ensures the names of the ACL policies attached to a
+// peering are valid. The policies aren't required to exist, a missing policy
+// grants nothing.
+func validateACLPolicyNames(names []string) error {
+	for _, name := range names {
+		if !acl.IsValidPolicyName(name) {
+			return fmt.Errorf("%q is not a valid ACL policy name", name)
+		}
+	}
+	return nil
+}
+
 // validatePeeringToken ensures that the token has valid values.
 func validatePeeringToken(tok *structs.PeeringToken) error {
 	// the CA values here should be valid x509 certs
@@ -110,7 +110,13 @@ func (c *Client) useStreaming(req structs.ServiceSpecificRequest) bool {
 		// the subscribe functionality maps to queries based on the service name and tenancy information
 		// it does not support the ability to subscribe to the same service in different partitions or peers
 		// and materialize the results into a single view with the first healthy sameness group member.
-		req.SamenessGroup == ""
+		req.SamenessGroup == "" &&
+		// Streaming is incompatible with sorted queries, since the materialized
+		// views don't keep the results in order.
+		req.Sort == "" &&
+		// The materialized views are maintained by the agent, so the server cannot
+		// enforce the staleness bound of bounded-stale queries.
+		req.QueryOptions.BoundedStaleness == 0
 }
 
 func (c *Client) newServiceRequest(req structs.ServiceSpecificRequest) serviceRequest {
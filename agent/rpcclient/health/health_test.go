@@ -88,6 +88,16 @@ func TestClient_ServiceNodes_BackendRouting(t *testing.T) {
 			},
 			expected: useCache,
 		},
+		{
+			name: "use cache for sorted request",
+			req: structs.ServiceSpecificRequest{
+				Datacenter:   "dc1",
+				ServiceName:  "web1",
+				QueryOptions: structs.QueryOptions{UseCache: true},
+				Sort:         structs.SortByModifyIndex,
+			},
+			expected: useCache,
+		},
 		{
 			name: "rpc if merge-central-config",
 			req: structs.ServiceSpecificRequest{
@@ -45,6 +45,7 @@ import (
 	"github.com/hashicorp/consul/agent/xds"
 	"github.com/hashicorp/consul/ipaddr"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/lib/hoststats"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/tlsutil"
@@ -65,6 +66,10 @@ type BaseDeps struct {
 	WatchedFiles    []string
 	NetRPC          *LazyNetRPC
 
+	// LogSubsystems controls the log levels of the subsystems of Logger at
+	// runtime. It is nil when Logger was provided by the caller.
+	LogSubsystems *logging.Subsystems
+
 	deregisterBalancer, deregisterResolver func()
 	stopHostCollector                      context.CancelFunc
 }
@@ -112,7 +117,8 @@ func NewBaseDeps(configLoader ConfigLoader, logOut io.Writer, providedLogger hcl
 	if providedLogger != nil {
 		d.Logger = providedLogger
 	} else {
-		d.Logger, err = logging.Setup(logConf, logOut)
+		d.LogSubsystems = logging.NewSubsystems()
+		d.Logger, err = logging.SetupWithSubsystems(logConf, logOut, d.LogSubsystems)
 		if err != nil {
 			return d, err
 		}
@@ -126,6 +132,8 @@ func NewBaseDeps(configLoader ConfigLoader, logOut io.Writer, providedLogger hcl
 		d.Logger.Warn(w)
 	}
 
+	egressproxy.SetDefault(cfg.EgressProxy)
+
 	cfg.NodeID, err = newNodeIDFromConfig(cfg, d.Logger)
 	if err != nil {
 		return d, fmt.Errorf("failed to setup node ID: %w", err)
@@ -309,6 +317,8 @@ func newConnPool(config *config.RuntimeConfig, logger hclog.Logger, tls *tlsutil
 	if config.ServerMode {
 		pool.MaxTime = 2 * time.Minute
 		pool.MaxStreams = 64
+		pool.Compression = config.RPCConfig.Compression
+		pool.CompressionThreshold = config.RPCConfig.CompressionThreshold
 	} else {
 		// MaxTime controls how long we keep an idle connection open to a server.
 		// 127s was chosen as the first prime above 120s
@@ -368,7 +378,9 @@ func getPrometheusDefs(cfg *config.RuntimeConfig, isServer bool) ([]prometheus.G
 	if isServer {
 		gauges = append(gauges,
 			consul.AutopilotGauges,
+			consul.IntentionGraphGauges,
 			consul.LeaderCertExpirationGauges,
+			consul.LeaderIntentionExpiryGauges,
 			consul.LeaderPeeringMetrics,
 			xdscapacity.StatsGauges,
 		)
@@ -432,17 +444,24 @@ func getPrometheusDefs(cfg *config.RuntimeConfig, isServer bool) ([]prometheus.G
 
 	var counters = [][]prometheus.CounterDefinition{
 		CatalogCounters,
+		ConnectAuthorizeCounters,
 		cache.Counters,
 		consul.ACLCounters,
 		consul.CatalogCounters,
 		consul.ClientCounters,
+		consul.IntentionCounters,
+		consul.LeafCertCounters,
 		consul.RPCCounters,
+		consul.WebhookCounters,
+		consul.AdmissionCounters,
+		consul.RaftArchiveCounters,
 		discovery.DNSCounters,
 		grpcWare.StatsCounters,
 		local.StateCounters,
 		xds.StatsCounters,
 		raftCounters,
 		rate.Counters,
+		xdscapacity.StatsCounters,
 	}
 
 	// For some unknown reason, we seem to add the raft counters above without
@@ -509,10 +528,14 @@ func getPrometheusDefs(cfg *config.RuntimeConfig, isServer bool) ([]prometheus.G
 	var summaries = [][]prometheus.SummaryDefinition{
 		HTTPSummaries,
 		consul.ACLSummaries,
+		consul.AdmissionSummaries,
 		consul.ACLEndpointSummaries,
 		consul.CatalogSummaries,
+		consul.CatalogProviderSummaries,
+		consul.CheckUpdateBatcherSummaries,
 		consul.FederationStateSummaries,
 		consul.IntentionSummaries,
+		consul.IntentionGraphSummaries,
 		consul.KVSummaries,
 		consul.LeaderSummaries,
 		consul.PreparedQuerySummaries,
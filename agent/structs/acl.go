@@ -91,7 +91,7 @@ session_prefix "" {
 
 var (
 	ACLPolicyGlobalReadOnlyRules   = fmt.Sprintf(aclPolicyGlobalRulesTemplate, "read") + EnterpriseACLPolicyGlobalReadOnly
-	ACLPolicyGlobalManagementRules = fmt.Sprintf(aclPolicyGlobalRulesTemplate, "write") + EnterpriseACLPolicyGlobalManagement
+	ACLPolicyGlobalManagementRules = fmt.Sprintf(aclPolicyGlobalRulesTemplate, "write") + "\nsecrets = \"read\"" + EnterpriseACLPolicyGlobalManagement
 
 	ACLBuiltinPolicies = map[string]ACLPolicy{
 		ACLPolicyGlobalManagementID: {
@@ -1834,6 +1834,59 @@ func (r *ACLLoginRequest) RequestDatacenter() string {
 	return r.Datacenter
 }
 
+// ACLClientCertLoginRequest is used by an agent to log in on behalf of an
+// HTTPS API client which authenticated with a client certificate.
+type ACLClientCertLoginRequest struct {
+	// Auth holds the login parameters. The BearerToken is the PEM encoded
+	// certificate chain the client presented, leaf first.
+	Auth *ACLLoginParams
+
+	// Node is the name of the agent which verified the certificate. The
+	// request token must have node:write permission on it.
+	Node string
+
+	Datacenter string // The datacenter to perform the request within
+	WriteRequest
+}
+
+func (r *ACLClientCertLoginRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLSocketCredentials are the peer credentials of a process connected to an
+// agent's Unix domain socket, as reported by the kernel.
+type ACLSocketCredentials struct {
+	UID uint32
+	GID uint32
+
+	// User and Group are the names of the user and group, when the agent
+	// could resolve them.
+	User  string
+	Group string
+}
+
+// ACLSocketCredLoginRequest is used by an agent to log in on behalf of an HTTP
+// API client connected to one of its Unix domain sockets.
+type ACLSocketCredLoginRequest struct {
+	// Auth holds the login parameters. The BearerToken is ignored, the login
+	// token is built from the Credentials instead.
+	Auth *ACLLoginParams
+
+	// Credentials are the peer credentials of the client.
+	Credentials ACLSocketCredentials
+
+	// Node is the name of the agent which accepted the connection. The
+	// request token must have node:write permission on it.
+	Node string
+
+	Datacenter string // The datacenter to perform the request within
+	WriteRequest
+}
+
+func (r *ACLSocketCredLoginRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
 type ACLLogoutRequest struct {
 	Datacenter string // The datacenter to perform the request within
 	WriteRequest
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ACLBreakGlassInitRequest is used to initialize the break-glass seal,
+// replacing the previous one if any.
+type ACLBreakGlassInitRequest struct {
+	Datacenter string
+
+	// Shares is the number of key shares to hand to the operators.
+	Shares int
+
+	// Threshold is the number of shares required to activate the token.
+	Threshold int
+
+	// TTL is how long the activated token is valid.
+	TTL time.Duration
+
+	WriteRequest
+}
+
+func (r *ACLBreakGlassInitRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLBreakGlassInitResponse holds the key shares of the new seal. They are
+// only returned once and never stored.
+type ACLBreakGlassInitResponse struct {
+	Shares []string
+}
+
+// ACLBreakGlassUnsealRequest is used to submit a key share to activate the
+// break-glass token. It doesn't require any ACL token.
+type ACLBreakGlassUnsealRequest struct {
+	Datacenter string
+	Share      string
+	WriteRequest
+}
+
+func (r *ACLBreakGlassUnsealRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLBreakGlassStatusRequest is used to read the status of the break-glass
+// seal. It doesn't require any ACL token.
+type ACLBreakGlassStatusRequest struct {
+	Datacenter string
+	QueryOptions
+}
+
+func (r *ACLBreakGlassStatusRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLBreakGlassStatus is the status of the break-glass seal.
+type ACLBreakGlassStatus struct {
+	// Initialized is whether the seal was initialized, the other fields are
+	// empty otherwise.
+	Initialized bool
+
+	Shares     int
+	Threshold  int
+	TTL        time.Duration
+	CreateTime time.Time
+
+	// Progress is the number of shares submitted towards the threshold.
+	Progress int
+}
+
+func (s *ACLBreakGlassStatus) MarshalJSON() ([]byte, error) {
+	type Alias ACLBreakGlassStatus
+	exported := &struct {
+		TTL string `json:",omitempty"`
+		*Alias
+	}{
+		TTL:   s.TTL.String(),
+		Alias: (*Alias)(s),
+	}
+	if s.TTL == 0 {
+		exported.TTL = ""
+	}
+
+	return json.Marshal(exported)
+}
+
+type ACLBreakGlassStatusResponse struct {
+	ACLBreakGlassStatus
+	QueryMeta
+}
+
+// ACLBreakGlassUnsealResponse holds the status of the seal after a share was
+// submitted, and the activated token once the threshold was reached.
+type ACLBreakGlassUnsealResponse struct {
+	Status ACLBreakGlassStatus
+	Token  *ACLToken `json:",omitempty"`
+}
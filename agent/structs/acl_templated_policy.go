@@ -42,6 +42,7 @@ const (
 	ACLTemplatedPolicyWorkloadIdentityID = "00000000-0000-0000-0000-000000000007"
 	ACLTemplatedPolicyAPIGatewayID       = "00000000-0000-0000-0000-000000000008"
 	ACLTemplatedPolicyNomadClientID      = "00000000-0000-0000-0000-000000000009"
+	ACLTemplatedPolicyNamespaceAdminID   = "00000000-0000-0000-0000-000000000010"
 
 	ACLTemplatedPolicyServiceDescription          = "Gives the token or role permissions to register a service and discover services in the Consul catalog. It also gives the specified service's sidecar proxy the permission to discover and route traffic to other services."
 	ACLTemplatedPolicyNodeDescription             = "Gives the token or role permissions for a register an agent/node into the catalog. A node is typically a consul agent but can also be a physical server, cloud instance or a container."
@@ -50,6 +51,7 @@ const (
 	ACLTemplatedPolicyWorkloadIdentityDescription = "Gives the token or role permissions for a specific workload identity."
 	ACLTemplatedPolicyAPIGatewayDescription       = "Gives the token or role permissions for a Consul api gateway"
 	ACLTemplatedPolicyNomadClientDescription      = "Gives the token or role permissions required for integration with a nomad client."
+	ACLTemplatedPolicyNamespaceAdminDescription   = "Gives the token or role full control within its namespace, including managing the namespace's tokens, roles and policies, without allowing it to grant permissions outside of the namespace."
 
 	ACLTemplatedPolicyNoRequiredVariablesSchema = "" // catch-all schema for all templated policy that don't require a schema
 )
@@ -117,6 +119,13 @@ var (
 			Template:     ACLTemplatedPolicyNomadClient,
 			Description:  ACLTemplatedPolicyNomadClientDescription,
 		},
+		api.ACLTemplatedPolicyNamespaceAdminName: {
+			TemplateID:   ACLTemplatedPolicyNamespaceAdminID,
+			TemplateName: api.ACLTemplatedPolicyNamespaceAdminName,
+			Schema:       ACLTemplatedPolicyNoRequiredVariablesSchema,
+			Template:     ACLTemplatedPolicyNamespaceAdmin,
+			Description:  ACLTemplatedPolicyNamespaceAdminDescription,
+		},
 	}
 )
 
@@ -28,6 +28,9 @@ var ACLTemplatedPolicyAPIGateway string
 //go:embed acltemplatedpolicy/policies/ce/nomad-client.hcl
 var ACLTemplatedPolicyNomadClient string
 
+//go:embed acltemplatedpolicy/policies/ce/namespace-admin.hcl
+var ACLTemplatedPolicyNamespaceAdmin string
+
 func (t *ACLToken) TemplatedPolicyList() []*ACLTemplatedPolicy {
 	if len(t.TemplatedPolicies) == 0 {
 		return nil
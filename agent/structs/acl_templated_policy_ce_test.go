@@ -117,6 +117,33 @@ service_prefix "" {
 }
 service "api-gateway" {
 	policy = "write"
+}`,
+			},
+		},
+		"namespace-admin-template": {
+			templatedPolicy: &ACLTemplatedPolicy{
+				TemplateID:   ACLTemplatedPolicyNamespaceAdminID,
+				TemplateName: api.ACLTemplatedPolicyNamespaceAdminName,
+			},
+			expectedPolicy: &ACLPolicy{
+				Description: "synthetic policy generated from templated policy: builtin/namespace-admin",
+				Rules: `acl = "write"
+service_prefix "" {
+	policy = "write"
+	intentions = "write"
+}
+identity_prefix "" {
+	policy = "write"
+	intentions = "write"
+}
+key_prefix "" {
+	policy = "write"
+}
+session_prefix "" {
+	policy = "write"
+}
+node_prefix "" {
+	policy = "read"
 }`,
 			},
 		},
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"time"
+
+	"github.com/hashicorp/consul/acl"
+)
+
+// ACLTokenUsage is the usage of a token over the HTTP API of the agents of a
+// datacenter.
+type ACLTokenUsage struct {
+	AccessorID string
+
+	// Count is the number of requests made with the token.
+	Count uint64
+
+	// LastUsed is when the token was last used, zero if it was not used.
+	LastUsed time.Time
+
+	// SourceAddrs are the addresses the requests were made from. Only the
+	// first few addresses are kept, to bound the memory used by the tracking.
+	SourceAddrs []string `json:",omitempty"`
+}
+
+// ACLTokenUsageReportRequest is used by the agents to report the usage of the
+// tokens since their last report to the leader.
+type ACLTokenUsageReportRequest struct {
+	Datacenter string
+	Node       string
+	Usage      []ACLTokenUsage
+	acl.EnterpriseMeta
+	WriteRequest
+}
+
+func (r *ACLTokenUsageReportRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLTokenUsageRequest is used to read the usage of a token.
+type ACLTokenUsageRequest struct {
+	Datacenter string
+	AccessorID string
+	acl.EnterpriseMeta
+	QueryOptions
+}
+
+func (r *ACLTokenUsageRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLTokenUsageReport holds the usage of a token since TrackingSince, when
+// the leader of the datacenter started tracking it.
+type ACLTokenUsageReport struct {
+	ACLTokenUsage
+	TrackingSince time.Time
+}
+
+type ACLTokenUsageResponse struct {
+	ACLTokenUsageReport
+	QueryMeta
+}
+
+// ACLStaleTokensRequest is used to list the tokens which were not used
+// recently.
+type ACLStaleTokensRequest struct {
+	Datacenter string
+
+	// UnusedFor is how long a token must have gone unused to be stale.
+	UnusedFor time.Duration
+
+	acl.EnterpriseMeta
+	QueryOptions
+}
+
+func (r *ACLStaleTokensRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// ACLStaleToken is a token which was not used recently.
+type ACLStaleToken struct {
+	AccessorID  string
+	Description string
+	Local       bool
+	CreateTime  time.Time
+
+	// LastUsed is when the token was last used, or nil if it was not used
+	// since the tracking started.
+	LastUsed *time.Time `json:",omitempty"`
+
+	acl.EnterpriseMeta
+}
+
+// ACLStaleTokensReport lists the stale tokens. Tokens not used since
+// TrackingSince are reported as never used.
+type ACLStaleTokensReport struct {
+	Tokens        []ACLStaleToken
+	TrackingSince time.Time
+}
+
+type ACLStaleTokensResponse struct {
+	ACLStaleTokensReport
+	QueryMeta
+}
@@ -0,0 +1,18 @@
+acl = "write"
+service_prefix "" {
+	policy = "write"
+	intentions = "write"
+}
+identity_prefix "" {
+	policy = "write"
+	intentions = "write"
+}
+key_prefix "" {
+	policy = "write"
+}
+session_prefix "" {
+	policy = "write"
+}
+node_prefix "" {
+	policy = "read"
+}
\ No newline at end of file
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "time"
+
+// CatalogProviderStatus describes the sync of the catalog providers, as seen
+// by the leader of the datacenter.
+type CatalogProviderStatus struct {
+	Providers []CatalogProviderSyncStatus
+
+	QueryMeta
+}
+
+// CatalogProviderSyncStatus describes the sync of a catalog provider.
+type CatalogProviderSyncStatus struct {
+	Name string
+	Type string
+
+	// Node is the node the instances of the provider are registered on.
+	Node      string
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+
+	// Services and Instances are the number of services and instances
+	// registered by the last successful sync.
+	Services  int
+	Instances int
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+}
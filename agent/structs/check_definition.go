@@ -44,6 +44,8 @@ type CheckDefinition struct {
 	GRPC                           string
 	GRPCUseTLS                     bool
 	OSService                      string
+	Plugin                         string
+	PluginConfig                   map[string]string
 	TLSServerName                  string
 	TLSSkipVerify                  bool
 	AliasNode                      string
@@ -71,17 +73,18 @@ func (t *CheckDefinition) UnmarshalJSON(data []byte) (err error) {
 		// Translate fields
 
 		// "args" -> ScriptArgs
-		Args                                []string    `json:"args"`
-		ScriptArgsSnake                     []string    `json:"script_args"`
-		DeregisterCriticalServiceAfterSnake interface{} `json:"deregister_critical_service_after"`
-		DockerContainerIDSnake              string      `json:"docker_container_id"`
-		TLSServerNameSnake                  string      `json:"tls_server_name"`
-		TLSSkipVerifySnake                  bool        `json:"tls_skip_verify"`
-		TCPUseTLSSnake                      bool        `json:"tcp_use_tls"`
-		GRPCUseTLSSnake                     bool        `json:"grpc_use_tls"`
-		ServiceIDSnake                      string      `json:"service_id"`
-		H2PingUseTLSSnake                   bool        `json:"h2ping_use_tls"`
-		DisableRedirectsSnake               bool        `json:"disable_redirects"`
+		Args                                []string          `json:"args"`
+		ScriptArgsSnake                     []string          `json:"script_args"`
+		DeregisterCriticalServiceAfterSnake interface{}       `json:"deregister_critical_service_after"`
+		DockerContainerIDSnake              string            `json:"docker_container_id"`
+		TLSServerNameSnake                  string            `json:"tls_server_name"`
+		TLSSkipVerifySnake                  bool              `json:"tls_skip_verify"`
+		TCPUseTLSSnake                      bool              `json:"tcp_use_tls"`
+		GRPCUseTLSSnake                     bool              `json:"grpc_use_tls"`
+		ServiceIDSnake                      string            `json:"service_id"`
+		H2PingUseTLSSnake                   bool              `json:"h2ping_use_tls"`
+		DisableRedirectsSnake               bool              `json:"disable_redirects"`
+		PluginConfigSnake                   map[string]string `json:"plugin_config"`
 
 		*Alias
 	}{
@@ -133,6 +136,9 @@ func (t *CheckDefinition) UnmarshalJSON(data []byte) (err error) {
 	if aux.DisableRedirectsSnake {
 		t.DisableRedirects = aux.DisableRedirectsSnake
 	}
+	if len(t.PluginConfig) == 0 {
+		t.PluginConfig = aux.PluginConfigSnake
+	}
 
 	if (aux.H2PING != "" && !aux.H2PingUseTLSSnake) || (aux.H2PING == "" && aux.H2PingUseTLSSnake) {
 		t.H2PingUseTLS = aux.H2PingUseTLSSnake
@@ -231,6 +237,8 @@ func (c *CheckDefinition) CheckType() *CheckType {
 		DockerContainerID:              c.DockerContainerID,
 		Shell:                          c.Shell,
 		OSService:                      c.OSService,
+		Plugin:                         c.Plugin,
+		PluginConfig:                   c.PluginConfig,
 		TLSServerName:                  c.TLSServerName,
 		TLSSkipVerify:                  c.TLSSkipVerify,
 		Timeout:                        c.Timeout,
@@ -15,10 +15,10 @@ import (
 type CheckTypes []*CheckType
 
 // CheckType is used to create either the CheckMonitor or the CheckTTL.
-// The following types are supported: Script, HTTP, TCP, Docker, TTL, GRPC, Alias, H2PING. Script,
-// HTTP, Docker, TCP, GRPC, and H2PING all require Interval. Only one of the types may
+// The following types are supported: Script, HTTP, TCP, Docker, TTL, GRPC, Alias, H2PING, Plugin. Script,
+// HTTP, Docker, TCP, GRPC, H2PING and Plugin all require Interval. Only one of the types may
 // to be provided: TTL or Script/Interval or HTTP/Interval or TCP/Interval or
-// Docker/Interval or GRPC/Interval or AliasService or H2PING/Interval.
+// Docker/Interval or GRPC/Interval or AliasService or H2PING/Interval or Plugin/Interval.
 // Since types like CheckHTTP and CheckGRPC derive from CheckType, there are
 // helper conversion methods that do the reverse conversion. ie. checkHTTP.CheckType()
 type CheckType struct {
@@ -52,6 +52,8 @@ type CheckType struct {
 	GRPC                   string
 	GRPCUseTLS             bool
 	OSService              string
+	Plugin                 string
+	PluginConfig           map[string]string
 	TLSServerName          string
 	TLSSkipVerify          bool
 	Timeout                time.Duration
@@ -189,7 +191,7 @@ func (t *CheckType) UnmarshalJSON(data []byte) (err error) {
 
 // Validate returns an error message if the check is invalid
 func (c *CheckType) Validate() error {
-	intervalCheck := c.IsScript() || c.HTTP != "" || c.TCP != "" || c.UDP != "" || c.GRPC != "" || c.H2PING != "" || c.OSService != ""
+	intervalCheck := c.IsScript() || c.HTTP != "" || c.TCP != "" || c.UDP != "" || c.GRPC != "" || c.H2PING != "" || c.OSService != "" || c.Plugin != ""
 
 	if c.Interval > 0 && c.TTL > 0 {
 		return fmt.Errorf("Interval and TTL cannot both be specified")
@@ -197,6 +199,9 @@ func (c *CheckType) Validate() error {
 	if intervalCheck && c.Interval <= 0 {
 		return fmt.Errorf("Interval must be > 0 for Script, HTTP, H2PING, TCP, UDP or OSService checks")
 	}
+	if c.Plugin == "" && len(c.PluginConfig) > 0 {
+		return fmt.Errorf("PluginConfig can only be set for Plugin checks")
+	}
 	if intervalCheck && c.IsAlias() {
 		return fmt.Errorf("Interval cannot be set for Alias checks")
 	}
@@ -275,6 +280,11 @@ func (c *CheckType) IsOSService() bool {
 	return c.OSService != "" && c.Interval > 0
 }
 
+// IsPlugin checks if this is a check run by a check plugin.
+func (c *CheckType) IsPlugin() bool {
+	return c.Plugin != "" && c.Interval > 0
+}
+
 func (c *CheckType) Type() string {
 	switch {
 	case c.IsGRPC():
@@ -297,6 +307,8 @@ func (c *CheckType) Type() string {
 		return "h2ping"
 	case c.IsOSService():
 		return "os_service"
+	case c.IsPlugin():
+		return "plugin"
 	default:
 		return ""
 	}
@@ -47,6 +47,7 @@ const (
 	// TODO: decide if we want to highlight 'ip' keyword in the name of RateLimitIPConfig
 	RateLimitIPConfig string = "control-plane-request-limit"
 	JWTProvider       string = "jwt-provider"
+	AgentDefaults     string = "agent-defaults"
 
 	ProxyConfigGlobal string = "global"
 	MeshConfigMesh    string = "mesh"
@@ -76,6 +77,7 @@ var AllConfigEntryKinds = []string{
 	InlineCertificate,
 	RateLimitIPConfig,
 	JWTProvider,
+	AgentDefaults,
 }
 
 // ConfigEntry is the interface for centralized configuration stored in Raft.
@@ -109,6 +111,18 @@ func HashConfigEntry(conf ConfigEntry) (uint64, error) {
 	return hash, nil
 }
 
+// SecretConfigEntry is an optional interface implemented by a ConfigEntry
+// holding secrets, which the servers encrypt before writing them to Raft when
+// KV encryption is enabled.
+type SecretConfigEntry interface {
+	ConfigEntry
+
+	// CopySecrets returns a shallow copy of the entry, along with pointers to
+	// its secret fields keyed by field name so that they can be replaced
+	// without modifying the entry.
+	CopySecrets() (SecretConfigEntry, map[string]*string)
+}
+
 // ControlledConfigEntry is an optional interface implemented by a ConfigEntry
 // if it is reconciled via a controller and needs to respond with Status values.
 type ControlledConfigEntry interface {
@@ -670,6 +684,94 @@ func (e *ProxyConfigEntry) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// MergeProxyConfigEntries merges the proxy-defaults of nested scopes into the
+// effective proxy-defaults. The layers must be ordered from the least to the
+// most specific scope, as returned by ProxyDefaultsScopes, and nil layers are
+// skipped.
+//
+// Fields set in a more specific layer take precedence over those of the less
+// specific ones. The Config and Meta maps are merged key by key, and the
+// EnvoyExtensions of all layers are applied in order. The merged entry takes
+// the enterprise meta and raft index of the most specific layer. The layers
+// are not modified, and when there is a single layer it is returned as is.
+func MergeProxyConfigEntries(layers ...*ProxyConfigEntry) *ProxyConfigEntry {
+	var present []*ProxyConfigEntry
+	for _, layer := range layers {
+		if layer != nil {
+			present = append(present, layer)
+		}
+	}
+	switch len(present) {
+	case 0:
+		return nil
+	case 1:
+		return present[0]
+	}
+
+	merged := &ProxyConfigEntry{
+		Kind: ProxyDefaults,
+		Name: ProxyConfigGlobal,
+	}
+	for _, layer := range present {
+		if len(layer.Config) > 0 {
+			if merged.Config == nil {
+				merged.Config = make(map[string]interface{})
+			}
+			for k, v := range layer.Config {
+				merged.Config[k] = v
+			}
+		}
+		if layer.Protocol != "" {
+			merged.Protocol = layer.Protocol
+		}
+		if layer.Mode != ProxyModeDefault {
+			merged.Mode = layer.Mode
+		}
+		if layer.TransparentProxy.OutboundListenerPort != 0 {
+			merged.TransparentProxy.OutboundListenerPort = layer.TransparentProxy.OutboundListenerPort
+		}
+		if layer.TransparentProxy.DialedDirectly {
+			merged.TransparentProxy.DialedDirectly = true
+		}
+		if layer.MutualTLSMode != MutualTLSModeDefault {
+			merged.MutualTLSMode = layer.MutualTLSMode
+		}
+		if layer.MeshGateway.Mode != MeshGatewayModeDefault {
+			merged.MeshGateway.Mode = layer.MeshGateway.Mode
+		}
+		if layer.Expose.Checks {
+			merged.Expose.Checks = true
+		}
+		if len(layer.Expose.Paths) > 0 {
+			merged.Expose.Paths = layer.Expose.Paths
+		}
+		if layer.AccessLogs != (AccessLogsConfig{}) {
+			merged.AccessLogs = layer.AccessLogs
+		}
+		merged.EnvoyExtensions = append(merged.EnvoyExtensions, layer.EnvoyExtensions...)
+		if layer.FailoverPolicy != nil {
+			merged.FailoverPolicy = layer.FailoverPolicy
+		}
+		if layer.PrioritizeByLocality != nil {
+			merged.PrioritizeByLocality = layer.PrioritizeByLocality
+		}
+		if len(layer.Meta) > 0 {
+			if merged.Meta == nil {
+				merged.Meta = make(map[string]string)
+			}
+			for k, v := range layer.Meta {
+				merged.Meta[k] = v
+			}
+		}
+		merged.EnterpriseMeta = layer.EnterpriseMeta
+		merged.RaftIndex = layer.RaftIndex
+	}
+	// The hash only identifies the merged contents, so an error computing it
+	// leaves it unset rather than failing the lookup.
+	merged.Hash, _ = HashConfigEntry(merged)
+	return merged
+}
+
 // DecodeConfigEntry can be used to decode a ConfigEntry from a raw map value.
 // Currently its used in the HTTP API to decode ConfigEntry structs coming from
 // JSON. Unlike some of our custom binary encodings we don't have a preamble including
@@ -803,6 +905,51 @@ func (c *ConfigEntryRequest) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// ConfigEntryACLName returns the name config_entry ACL rules match the given
+// config entry against, of the form "<kind>/<name>".
+func ConfigEntryACLName(entry ConfigEntry) string {
+	return entry.GetKind() + "/" + entry.GetName()
+}
+
+// CanReadConfigEntry returns whether or not the given Authorizer has
+// permission to read the config entry. A config_entry rule matching the entry
+// takes precedence over the permissions its kind otherwise requires.
+func CanReadConfigEntry(entry ConfigEntry, authz acl.Authorizer) error {
+	var authzContext acl.AuthorizerContext
+	entry.GetEnterpriseMeta().FillAuthzContext(&authzContext)
+
+	name := ConfigEntryACLName(entry)
+	switch authz.ConfigEntryRead(name, &authzContext) {
+	case acl.Allow:
+		return nil
+	case acl.Deny:
+		return acl.PermissionDeniedByACL(authz, &authzContext, acl.ResourceConfig, acl.AccessRead, name)
+	}
+	return entry.CanRead(authz)
+}
+
+// CanWriteConfigEntry returns whether or not the given Authorizer has
+// permission to write the config entry. A config_entry rule matching the entry
+// takes precedence over the permissions its kind otherwise requires, except
+// for the kinds only written by the controllers.
+func CanWriteConfigEntry(entry ConfigEntry, authz acl.Authorizer) error {
+	if entry.GetKind() == BoundAPIGateway {
+		return entry.CanWrite(authz)
+	}
+
+	var authzContext acl.AuthorizerContext
+	entry.GetEnterpriseMeta().FillAuthzContext(&authzContext)
+
+	name := ConfigEntryACLName(entry)
+	switch authz.ConfigEntryWrite(name, &authzContext) {
+	case acl.Allow:
+		return nil
+	case acl.Deny:
+		return acl.PermissionDeniedByACL(authz, &authzContext, acl.ResourceConfig, acl.AccessWrite, name)
+	}
+	return entry.CanWrite(authz)
+}
+
 func MakeConfigEntry(kind, name string) (ConfigEntry, error) {
 	if configEntry := makeEnterpriseConfigEntry(kind, name); configEntry != nil {
 		return configEntry, nil
@@ -844,6 +991,8 @@ func MakeConfigEntry(kind, name string) (ConfigEntry, error) {
 		return &TCPRouteConfigEntry{Name: name}, nil
 	case JWTProvider:
 		return &JWTProviderConfigEntry{Name: name}, nil
+	case AgentDefaults:
+		return &AgentDefaultsConfigEntry{Name: name}, nil
 	default:
 		return nil, fmt.Errorf("invalid config entry kind: %s", kind)
 	}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/logging"
+)
+
+// AgentDefaultsGlobal is the only name of the agent-defaults config entry.
+const AgentDefaultsGlobal string = "global"
+
+// AgentDefaultsConfigEntry holds runtime settings applied by every agent of
+// the partition. The settings set in the local configuration of an agent take
+// precedence over the ones of the config entry.
+type AgentDefaultsConfigEntry struct {
+	Kind string
+	Name string
+
+	// LogLevel is the level of the logs of the agents.
+	LogLevel string `json:",omitempty" alias:"log_level"`
+
+	// DNSRecursors are the upstream DNS servers the agents forward the
+	// queries outside of the Consul domain to.
+	DNSRecursors []string `json:",omitempty" alias:"dns_recursors"`
+
+	// Telemetry holds the metrics sinks the agents send their metrics to.
+	Telemetry AgentDefaultsTelemetry
+
+	Meta               map[string]string `json:",omitempty"`
+	Hash               uint64            `json:",omitempty" hash:"ignore"`
+	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
+	RaftIndex          `hash:"ignore"`
+}
+
+// AgentDefaultsTelemetry holds the addresses of the metrics sinks of the
+// agents, matching the corresponding keys of the telemetry stanza of the
+// agent configuration.
+type AgentDefaultsTelemetry struct {
+	StatsdAddr    string   `json:",omitempty" alias:"statsd_address"`
+	StatsiteAddr  string   `json:",omitempty" alias:"statsite_address"`
+	DogstatsdAddr string   `json:",omitempty" alias:"dogstatsd_addr"`
+	DogstatsdTags []string `json:",omitempty" alias:"dogstatsd_tags"`
+}
+
+func (e *AgentDefaultsConfigEntry) SetHash(h uint64) {
+	e.Hash = h
+}
+
+func (e *AgentDefaultsConfigEntry) GetHash() uint64 {
+	return e.Hash
+}
+
+func (e *AgentDefaultsConfigEntry) GetKind() string {
+	return AgentDefaults
+}
+
+func (e *AgentDefaultsConfigEntry) GetName() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.Name
+}
+
+func (e *AgentDefaultsConfigEntry) GetMeta() map[string]string {
+	if e == nil {
+		return nil
+	}
+	return e.Meta
+}
+
+func (e *AgentDefaultsConfigEntry) Normalize() error {
+	if e == nil {
+		return fmt.Errorf("config entry is nil")
+	}
+
+	e.Kind = AgentDefaults
+
+	// this check is replicated in normalize() and validate(),
+	// since validate is not called by all the endpoints (e.g., delete)
+	if e.Name != "" && e.Name != AgentDefaultsGlobal {
+		return fmt.Errorf("invalid name (%q), only %q is supported", e.Name, AgentDefaultsGlobal)
+	}
+	e.Name = AgentDefaultsGlobal
+
+	e.EnterpriseMeta.Normalize()
+
+	h, err := HashConfigEntry(e)
+	if err != nil {
+		return err
+	}
+	e.Hash = h
+
+	return nil
+}
+
+func (e *AgentDefaultsConfigEntry) Validate() error {
+	if e == nil {
+		return fmt.Errorf("config entry is nil")
+	}
+
+	if e.Name != AgentDefaultsGlobal {
+		return fmt.Errorf("invalid name (%q), only %q is supported", e.Name, AgentDefaultsGlobal)
+	}
+
+	if err := validateConfigEntryMeta(e.Meta); err != nil {
+		return err
+	}
+
+	if e.LogLevel != "" && !logging.ValidateLogLevel(e.LogLevel) {
+		return fmt.Errorf("invalid LogLevel %q", e.LogLevel)
+	}
+
+	for i, r := range e.DNSRecursors {
+		if r == "" {
+			return fmt.Errorf("DNSRecursors[%d] cannot be empty", i)
+		}
+	}
+
+	for _, addr := range []struct {
+		name  string
+		value string
+	}{
+		{"Telemetry.StatsdAddr", e.Telemetry.StatsdAddr},
+		{"Telemetry.StatsiteAddr", e.Telemetry.StatsiteAddr},
+		{"Telemetry.DogstatsdAddr", e.Telemetry.DogstatsdAddr},
+	} {
+		if addr.value == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr.value); err != nil {
+			return fmt.Errorf("invalid %s %q: %v", addr.name, addr.value, err)
+		}
+	}
+
+	return e.validateEnterpriseMeta()
+}
+
+func (e *AgentDefaultsConfigEntry) CanRead(authz acl.Authorizer) error {
+	return nil
+}
+
+func (e *AgentDefaultsConfigEntry) CanWrite(authz acl.Authorizer) error {
+	var authzContext acl.AuthorizerContext
+	e.FillAuthzContext(&authzContext)
+	return authz.ToAllowAuthorizer().OperatorWriteAllowed(&authzContext)
+}
+
+func (e *AgentDefaultsConfigEntry) GetRaftIndex() *RaftIndex {
+	if e == nil {
+		return &RaftIndex{}
+	}
+
+	return &e.RaftIndex
+}
+
+func (e *AgentDefaultsConfigEntry) GetEnterpriseMeta() *acl.EnterpriseMeta {
+	if e == nil {
+		return nil
+	}
+
+	return &e.EnterpriseMeta
+}
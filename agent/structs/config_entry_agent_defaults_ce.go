@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !consulent
+
+package structs
+
+func (e *AgentDefaultsConfigEntry) validateEnterpriseMeta() error {
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentDefaultsConfigEntry_ValidateAndNormalize(t *testing.T) {
+	cases := map[string]configEntryTestcase{
+		"valid": {
+			entry: &AgentDefaultsConfigEntry{
+				LogLevel:     "debug",
+				DNSRecursors: []string{"8.8.8.8"},
+				Telemetry: AgentDefaultsTelemetry{
+					StatsdAddr:    "statsd.example.com:8125",
+					DogstatsdAddr: "127.0.0.1:8125",
+					DogstatsdTags: []string{"env:prod"},
+				},
+			},
+			check: func(t *testing.T, entry ConfigEntry) {
+				require.Equal(t, AgentDefaults, entry.GetKind())
+				require.Equal(t, AgentDefaultsGlobal, entry.GetName())
+			},
+		},
+		"invalid name": {
+			entry:        &AgentDefaultsConfigEntry{Name: "web"},
+			normalizeErr: `invalid name ("web"), only "global" is supported`,
+		},
+		"invalid log level": {
+			entry:       &AgentDefaultsConfigEntry{LogLevel: "verbose"},
+			validateErr: `invalid LogLevel "verbose"`,
+		},
+		"empty recursor": {
+			entry:       &AgentDefaultsConfigEntry{DNSRecursors: []string{"8.8.8.8", ""}},
+			validateErr: "DNSRecursors[1] cannot be empty",
+		},
+		"invalid statsd address": {
+			entry: &AgentDefaultsConfigEntry{
+				Telemetry: AgentDefaultsTelemetry{StatsdAddr: "statsd.example.com"},
+			},
+			validateErr: `invalid Telemetry.StatsdAddr "statsd.example.com"`,
+		},
+	}
+
+	testConfigEntryNormalizeAndValidate(t, cases)
+}
+
+func TestAgentDefaultsConfigEntry_ACLs(t *testing.T) {
+	entry := &AgentDefaultsConfigEntry{}
+
+	require.NoError(t, entry.CanRead(newTestAuthz(t, ``)))
+	require.Error(t, entry.CanWrite(newTestAuthz(t, `operator = "read"`)))
+	require.NoError(t, entry.CanWrite(newTestAuthz(t, `operator = "write"`)))
+}
@@ -18,6 +18,19 @@ func (e *ProxyConfigEntry) validateEnterpriseMeta() error {
 	return nil
 }
 
+// ProxyDefaultsScopes returns the scopes of the proxy-defaults that apply to
+// services in entMeta, from the least to the most specific. In CE there is
+// only the global proxy-defaults.
+func ProxyDefaultsScopes(_ *acl.EnterpriseMeta) []*acl.EnterpriseMeta {
+	return []*acl.EnterpriseMeta{DefaultEnterpriseMetaInDefaultPartition()}
+}
+
+// ProxyDefaultsScopeKey returns the key identifying the scope of the
+// proxy-defaults in entMeta.
+func ProxyDefaultsScopeKey(entMeta *acl.EnterpriseMeta) string {
+	return entMeta.PartitionOrDefault()
+}
+
 func validateUnusedKeys(unused []string) error {
 	var err error
 
@@ -1519,7 +1519,8 @@ func (f *ServiceResolverFailover) isEmpty() bool {
 
 type ServiceResolverFailoverPolicy struct {
 	// Mode specifies the type of failover that will be performed. Valid values are
-	// "sequential", "" (equivalent to "sequential") and "order-by-locality".
+	// "sequential", "" (equivalent to "sequential"), "order-by-locality" and
+	// "order-by-health".
 	Mode    string   `json:",omitempty"`
 	Regions []string `json:",omitempty"`
 }
@@ -1533,8 +1534,9 @@ func (fp *ServiceResolverFailoverPolicy) validate() error {
 	case "":
 	case "sequential":
 	case "order-by-locality":
+	case "order-by-health":
 	default:
-		return fmt.Errorf("Failover-policy mode must be one of '', 'sequential', or 'order-by-locality'")
+		return fmt.Errorf("Failover-policy mode must be one of '', 'sequential', 'order-by-locality', or 'order-by-health'")
 	}
 	return nil
 }
@@ -1729,6 +1731,10 @@ type DiscoveryChainRequest struct {
 	// overridden for any resolver in the compiled chain.
 	OverrideConnectTimeout time.Duration
 
+	// Explain requests that the compiled chain is annotated with the config
+	// entries and defaults that produced it.
+	Explain bool
+
 	Datacenter string // where to route the RPC
 	QueryOptions
 }
@@ -1755,6 +1761,7 @@ func (r *DiscoveryChainRequest) CacheInfo() cache.RequestInfo {
 		OverrideMeshGateway    MeshGatewayConfig
 		OverrideProtocol       string
 		OverrideConnectTimeout time.Duration
+		Explain                bool
 		Filter                 string
 	}{
 		Name:                   r.Name,
@@ -1764,6 +1771,7 @@ func (r *DiscoveryChainRequest) CacheInfo() cache.RequestInfo {
 		OverrideMeshGateway:    r.OverrideMeshGateway,
 		OverrideProtocol:       r.OverrideProtocol,
 		OverrideConnectTimeout: r.OverrideConnectTimeout,
+		Explain:                r.Explain,
 		Filter:                 r.QueryOptions.Filter,
 	}, nil)
 	if err == nil {
@@ -106,7 +106,7 @@ func (f *ServiceResolverFailoverPolicy) ValidateEnterprise() error {
 		return nil
 	}
 
-	if f.Mode != "" {
+	if f.Mode != "" && f.Mode != "order-by-health" {
 		return fmt.Errorf("Setting failover policies requires Consul Enterprise")
 	}
 
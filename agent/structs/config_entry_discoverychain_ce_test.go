@@ -98,6 +98,16 @@ func TestServiceResolverConfigEntry_CE(t *testing.T) {
 			},
 			validateErr: `Bad Failover["*"]: Setting failover policies requires Consul Enterprise`,
 		},
+		{
+			name: "setting failover order-by-health policy on CE",
+			entry: &ServiceResolverConfigEntry{
+				Kind: ServiceResolver,
+				Name: "test",
+				Failover: map[string]ServiceResolverFailover{
+					"*": {Service: "s1", Policy: &ServiceResolverFailoverPolicy{Mode: "order-by-health"}},
+				},
+			},
+		},
 		{
 			name: "setting redirect SamenessGroup on CE",
 			entry: &ServiceResolverConfigEntry{
@@ -42,6 +42,11 @@ type ExportedService struct {
 	// Namespace is the namespace to export the service from.
 	Namespace string `json:",omitempty"`
 
+	// Selector restricts a wildcard export to the services that have at
+	// least one instance whose service metadata contains every key and value
+	// in the selector. It can only be set when Name is the wildcard.
+	Selector map[string]string `json:",omitempty"`
+
 	// Consumers is a list of downstream consumers of the service to be exported.
 	Consumers []ServiceConsumer `json:",omitempty"`
 }
@@ -57,6 +62,43 @@ type ServiceConsumer struct {
 
 	// SamenessGroup is the name of the sameness group to export the service to.
 	SamenessGroup string `json:",omitempty" alias:"sameness_group"`
+
+	// Selector overrides the Selector of a wildcard export for this consumer.
+	Selector map[string]string `json:",omitempty"`
+}
+
+// SelectorFor returns the service metadata selector that applies to the
+// consumer: its own Selector if set, otherwise the export's Selector.
+func (s *ExportedService) SelectorFor(c ServiceConsumer) map[string]string {
+	if len(c.Selector) > 0 {
+		return c.Selector
+	}
+	return s.Selector
+}
+
+// HasSelector returns true if the export or any of its consumers restricts
+// the services it exports with a service metadata selector.
+func (s *ExportedService) HasSelector() bool {
+	if len(s.Selector) > 0 {
+		return true
+	}
+	for _, c := range s.Consumers {
+		if len(c.Selector) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectorMatches returns true if meta contains every key and value in the
+// selector.
+func SelectorMatches(selector, meta map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := meta[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
 }
 
 func (e *ExportedServicesConfigEntry) GetKind() string {
@@ -121,6 +163,12 @@ func (e *ExportedServicesConfigEntry) validateServices() error {
 		if svc.Namespace == WildcardSpecifier && svc.Name != WildcardSpecifier {
 			return fmt.Errorf("Services[%d]: service name must be wildcard if namespace is wildcard", i)
 		}
+		if svc.HasSelector() && svc.Name != WildcardSpecifier {
+			return fmt.Errorf("Services[%d]: selectors can only be used when the service name is wildcard", i)
+		}
+		if err := validateExportSelector(svc.Selector); err != nil {
+			return fmt.Errorf("Services[%d]: %w", i, err)
+		}
 		if len(svc.Consumers) == 0 {
 			return fmt.Errorf("Services[%d]: must have at least one consumer", i)
 		}
@@ -144,6 +192,18 @@ func (e *ExportedServicesConfigEntry) validateServices() error {
 			if consumer.Peer == WildcardSpecifier {
 				return fmt.Errorf("Services[%d].Consumers[%d]: exporting to all peers (wildcard) is not supported", i, j)
 			}
+			if err := validateExportSelector(consumer.Selector); err != nil {
+				return fmt.Errorf("Services[%d].Consumers[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateExportSelector(selector map[string]string) error {
+	for k := range selector {
+		if k == "" {
+			return fmt.Errorf("selector keys cannot be empty")
 		}
 	}
 	return nil
@@ -91,6 +91,77 @@ func TestExportedServicesConfigEntry(t *testing.T) {
 			},
 			validateErr: `Services[0].Consumers[0]: must define at most one of Peer, Partition, or SamenessGroup`,
 		},
+		"validate: selector requires wildcard service name": {
+			entry: &ExportedServicesConfigEntry{
+				Name: "default",
+				Services: []ExportedService{
+					{
+						Name:     "web",
+						Selector: map[string]string{"team": "payments"},
+						Consumers: []ServiceConsumer{
+							{
+								Peer: "foo",
+							},
+						},
+					},
+				},
+			},
+			validateErr: `Services[0]: selectors can only be used when the service name is wildcard`,
+		},
+		"validate: consumer selector requires wildcard service name": {
+			entry: &ExportedServicesConfigEntry{
+				Name: "default",
+				Services: []ExportedService{
+					{
+						Name: "web",
+						Consumers: []ServiceConsumer{
+							{
+								Peer:     "foo",
+								Selector: map[string]string{"team": "payments"},
+							},
+						},
+					},
+				},
+			},
+			validateErr: `Services[0]: selectors can only be used when the service name is wildcard`,
+		},
+		"validate: empty selector key": {
+			entry: &ExportedServicesConfigEntry{
+				Name: "default",
+				Services: []ExportedService{
+					{
+						Name: "*",
+						Consumers: []ServiceConsumer{
+							{
+								Peer:     "foo",
+								Selector: map[string]string{"": "payments"},
+							},
+						},
+					},
+				},
+			},
+			validateErr: `Services[0].Consumers[0]: selector keys cannot be empty`,
+		},
+		"selectors on wildcard": {
+			entry: &ExportedServicesConfigEntry{
+				Name: "default",
+				Services: []ExportedService{
+					{
+						Name:     "*",
+						Selector: map[string]string{"team": "payments"},
+						Consumers: []ServiceConsumer{
+							{
+								Peer: "foo",
+							},
+							{
+								Peer:     "bar",
+								Selector: map[string]string{"tier": "public"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	testConfigEntryNormalizeAndValidate(t, cases)
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 	"golang.org/x/exp/slices"
@@ -515,15 +516,63 @@ type LinkedService struct {
 	// from the gateway to the linked service
 	KeyFile string `json:",omitempty" alias:"key_file"`
 
+	// CAPEM is an optional PEM encoded CA bundle to use for TLS connections
+	// from the gateway to the linked service, as an alternative to CAFile.
+	CAPEM string `json:",omitempty" alias:"ca_pem"`
+
+	// VaultPKI optionally configures the gateway to present a client
+	// certificate issued by a Vault PKI secrets engine instead of CertFile
+	// and KeyFile.
+	VaultPKI *LinkedServiceVaultPKI `json:",omitempty" alias:"vault_pki"`
+
+	// TLSMinVersion sets the minimum TLS version supported for connections
+	// from the gateway to the linked service.
+	TLSMinVersion types.TLSVersion `json:",omitempty" alias:"tls_min_version"`
+
+	// TLSMaxVersion sets the maximum TLS version supported for connections
+	// from the gateway to the linked service.
+	TLSMaxVersion types.TLSVersion `json:",omitempty" alias:"tls_max_version"`
+
+	// CipherSuites sets the cipher suites supported for connections from the
+	// gateway to the linked service when TLS 1.2 or earlier is negotiated.
+	CipherSuites []types.TLSCipherSuite `json:",omitempty" alias:"cipher_suites"`
+
 	// SNI is the optional name to specify during the TLS handshake with a linked service
 	SNI string `json:",omitempty"`
 
+	// HostRewrite is the optional value of the Host header of the HTTP
+	// requests sent to the linked service. It takes precedence over the auto
+	// host rewrite feature.
+	HostRewrite string `json:",omitempty" alias:"host_rewrite"`
+
 	//DisableAutoHostRewrite disables terminating gateways auto host rewrite feature when set to true.
 	DisableAutoHostRewrite bool `json:",omitempty"`
 
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 }
 
+// LinkedServiceVaultPKI configures the client certificate a terminating
+// gateway presents to a linked service, issued by a Vault PKI secrets engine.
+// The agent serving the gateway's configuration connects to Vault with the
+// standard VAULT_* environment variables.
+type LinkedServiceVaultPKI struct {
+	// Path is the path of the issue endpoint of the PKI secrets engine, such
+	// as "pki/issue/gateway".
+	Path string `json:",omitempty"`
+
+	// CommonName is the common name of the requested certificates.
+	CommonName string `json:",omitempty" alias:"common_name"`
+
+	// TTL is the requested lifetime of the certificates. The role's default
+	// is used when it is not set.
+	TTL time.Duration `json:",omitempty"`
+}
+
+// HasTLS returns whether the gateway originates TLS to the linked service.
+func (s *LinkedService) HasTLS() bool {
+	return s.CAFile != "" || s.CAPEM != ""
+}
+
 func (e *TerminatingGatewayConfigEntry) GetKind() string {
 	return TerminatingGateway
 }
@@ -593,13 +642,44 @@ func (e *TerminatingGatewayConfigEntry) Validate() error {
 		}
 		seen[cid] = true
 
+		if svc.CAFile != "" && svc.CAPEM != "" {
+			return fmt.Errorf("Service %q cannot have both a CAFile and a CAPEM specified", svc.Name)
+		}
+
 		// If either client cert config file was specified then the CA file, client cert, and key file must be specified
 		// Specifying only a CAFile is allowed for one-way TLS
 		if (svc.CertFile != "" || svc.KeyFile != "") &&
-			!(svc.CAFile != "" && svc.CertFile != "" && svc.KeyFile != "") {
+			!(svc.HasTLS() && svc.CertFile != "" && svc.KeyFile != "") {
 
 			return fmt.Errorf("Service %q must have a CertFile, CAFile, and KeyFile specified for TLS origination", svc.Name)
 		}
+
+		if svc.VaultPKI != nil {
+			if svc.CertFile != "" || svc.KeyFile != "" {
+				return fmt.Errorf("Service %q cannot have both a VaultPKI and a CertFile or KeyFile specified", svc.Name)
+			}
+			if !svc.HasTLS() {
+				return fmt.Errorf("Service %q must have a CAFile or CAPEM specified to use a VaultPKI client certificate", svc.Name)
+			}
+			if svc.VaultPKI.Path == "" {
+				return fmt.Errorf("Service %q must have a VaultPKI Path specified", svc.Name)
+			}
+			if svc.VaultPKI.CommonName == "" {
+				return fmt.Errorf("Service %q must have a VaultPKI CommonName specified", svc.Name)
+			}
+			if svc.VaultPKI.TTL < 0 {
+				return fmt.Errorf("Service %q VaultPKI TTL cannot be negative", svc.Name)
+			}
+		}
+
+		if svc.TLSMinVersion != "" || svc.TLSMaxVersion != "" || len(svc.CipherSuites) != 0 {
+			if !svc.HasTLS() {
+				return fmt.Errorf("Service %q must have a CAFile or CAPEM specified to configure TLS versions or cipher suites", svc.Name)
+			}
+			if err := validateTLSConfig(svc.TLSMinVersion, svc.TLSMaxVersion, svc.CipherSuites); err != nil {
+				return fmt.Errorf("Service %q: %w", svc.Name, err)
+			}
+		}
 	}
 	return nil
 }
@@ -639,7 +719,7 @@ func (e *TerminatingGatewayConfigEntry) Warnings() []string {
 
 	warnings := make([]string, 0)
 	for _, svc := range e.Services {
-		if (svc.CAFile != "" || svc.CertFile != "" || svc.KeyFile != "") && svc.SNI == "" {
+		if (svc.HasTLS() || svc.CertFile != "" || svc.KeyFile != "") && svc.SNI == "" {
 			warning := fmt.Sprintf("TLS is configured but SNI is not set for service %q. Enabling SNI is strongly recommended when using TLS.", svc.Name)
 			warnings = append(warnings, warning)
 		}
@@ -671,7 +751,18 @@ type GatewayService struct {
 	FromWildcard bool               `json:",omitempty"`
 	ServiceKind  GatewayServiceKind `json:",omitempty"`
 	RaftIndex
-	AutoHostRewrite bool `json:",omitempty"`
+	AutoHostRewrite bool                   `json:",omitempty"`
+	CAPEM           string                 `json:",omitempty"`
+	VaultPKI        *LinkedServiceVaultPKI `json:",omitempty"`
+	TLSMinVersion   types.TLSVersion       `json:",omitempty"`
+	TLSMaxVersion   types.TLSVersion       `json:",omitempty"`
+	CipherSuites    []types.TLSCipherSuite `json:",omitempty"`
+	HostRewrite     string                 `json:",omitempty"`
+}
+
+// HasTLS returns whether the gateway originates TLS to the service.
+func (g *GatewayService) HasTLS() bool {
+	return g.CAFile != "" || g.CAPEM != ""
 }
 
 type GatewayServices []*GatewayService
@@ -708,7 +799,14 @@ func (g *GatewayService) IsSame(o *GatewayService) bool {
 		g.KeyFile == o.KeyFile &&
 		g.SNI == o.SNI &&
 		g.ServiceKind == o.ServiceKind &&
-		g.FromWildcard == o.FromWildcard
+		g.FromWildcard == o.FromWildcard &&
+		g.AutoHostRewrite == o.AutoHostRewrite &&
+		g.CAPEM == o.CAPEM &&
+		g.VaultPKI.Equal(o.VaultPKI) &&
+		g.TLSMinVersion == o.TLSMinVersion &&
+		g.TLSMaxVersion == o.TLSMaxVersion &&
+		slices.Equal(g.CipherSuites, o.CipherSuites) &&
+		g.HostRewrite == o.HostRewrite
 }
 
 func (g *GatewayService) Clone() *GatewayService {
@@ -728,9 +826,32 @@ func (g *GatewayService) Clone() *GatewayService {
 		RaftIndex:       g.RaftIndex,
 		ServiceKind:     g.ServiceKind,
 		AutoHostRewrite: g.AutoHostRewrite,
+		CAPEM:           g.CAPEM,
+		VaultPKI:        g.VaultPKI.Clone(),
+		TLSMinVersion:   g.TLSMinVersion,
+		TLSMaxVersion:   g.TLSMaxVersion,
+		CipherSuites:    append(g.CipherSuites[:0:0], g.CipherSuites...),
+		HostRewrite:     g.HostRewrite,
 	}
 }
 
+// Clone returns a copy of v unless v is nil.
+func (v *LinkedServiceVaultPKI) Clone() *LinkedServiceVaultPKI {
+	if v == nil {
+		return nil
+	}
+	cp := *v
+	return &cp
+}
+
+// Equal returns whether v and o are both nil or hold the same values.
+func (v *LinkedServiceVaultPKI) Equal(o *LinkedServiceVaultPKI) bool {
+	if v == nil || o == nil {
+		return v == o
+	}
+	return *v == *o
+}
+
 // APIGatewayConfigEntry manages the configuration for an API gateway service
 // with the given name.
 type APIGatewayConfigEntry struct {
@@ -1131,6 +1252,23 @@ func (e *BoundAPIGatewayConfigEntry) IsInitializedForGateway(gateway *APIGateway
 	return true
 }
 
+// ACMEChallenges returns the responses to the pending ACME HTTP-01
+// challenges the gateway must serve, by token.
+func (e *BoundAPIGatewayConfigEntry) ACMEChallenges() map[string]string {
+	var challenges map[string]string
+	for k, v := range e.Meta {
+		token, ok := strings.CutPrefix(k, MetaACMEChallengePrefix)
+		if !ok || token == "" {
+			continue
+		}
+		if challenges == nil {
+			challenges = make(map[string]string)
+		}
+		challenges[token] = v
+	}
+	return challenges
+}
+
 func (e *BoundAPIGatewayConfigEntry) GetKind() string            { return BoundAPIGateway }
 func (e *BoundAPIGatewayConfigEntry) GetName() string            { return e.Name }
 func (e *BoundAPIGatewayConfigEntry) GetMeta() map[string]string { return e.Meta }
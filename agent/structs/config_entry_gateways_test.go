@@ -5,10 +5,12 @@ package structs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/types"
 )
 
 func TestIngressGatewayConfigEntry(t *testing.T) {
@@ -1068,6 +1070,194 @@ func TestTerminatingGatewayConfigEntry(t *testing.T) {
 				},
 			},
 		},
+		"ca pem and client cert": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:     "web",
+						CAPEM:    "-----BEGIN CERTIFICATE-----",
+						CertFile: "client.crt",
+						KeyFile:  "tls.key",
+					},
+				},
+			},
+		},
+		"ca file and ca pem": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:   "web",
+						CAFile: "ca.crt",
+						CAPEM:  "-----BEGIN CERTIFICATE-----",
+					},
+				},
+			},
+			validateErr: `cannot have both a CAFile and a CAPEM`,
+		},
+		"vault pki": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:   "web",
+						CAFile: "ca.crt",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+							TTL:        24 * time.Hour,
+						},
+					},
+				},
+			},
+		},
+		"vault pki and cert file": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:     "web",
+						CAFile:   "ca.crt",
+						CertFile: "client.crt",
+						KeyFile:  "tls.key",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+						},
+					},
+				},
+			},
+			validateErr: `cannot have both a VaultPKI and a CertFile or KeyFile`,
+		},
+		"vault pki without ca": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name: "web",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+						},
+					},
+				},
+			},
+			validateErr: `must have a CAFile or CAPEM specified to use a VaultPKI client certificate`,
+		},
+		"vault pki without path": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:   "web",
+						CAFile: "ca.crt",
+						VaultPKI: &LinkedServiceVaultPKI{
+							CommonName: "gateway.example.com",
+						},
+					},
+				},
+			},
+			validateErr: `must have a VaultPKI Path`,
+		},
+		"vault pki without common name": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:   "web",
+						CAFile: "ca.crt",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path: "pki/issue/gateway",
+						},
+					},
+				},
+			},
+			validateErr: `must have a VaultPKI CommonName`,
+		},
+		"tls versions and cipher suites": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:          "web",
+						CAFile:        "ca.crt",
+						TLSMinVersion: types.TLSv1_2,
+						TLSMaxVersion: types.TLSv1_3,
+						CipherSuites: []types.TLSCipherSuite{
+							types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+						},
+					},
+				},
+			},
+		},
+		"tls versions without ca": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:          "web",
+						TLSMinVersion: types.TLSv1_2,
+					},
+				},
+			},
+			validateErr: `must have a CAFile or CAPEM specified to configure TLS versions or cipher suites`,
+		},
+		"invalid tls version": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:          "web",
+						CAFile:        "ca.crt",
+						TLSMinVersion: "TLSv1_9",
+					},
+				},
+			},
+			validateErr: `Service "web": no matching TLS version found for TLSv1_9`,
+		},
+		"max tls version less than min": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:          "web",
+						CAFile:        "ca.crt",
+						TLSMinVersion: types.TLSv1_3,
+						TLSMaxVersion: types.TLSv1_2,
+					},
+				},
+			},
+			validateErr: `configuring max version TLSv1_2 less than the configured min version TLSv1_3 is invalid`,
+		},
+		"cipher suites with tls 1.3": {
+			entry: &TerminatingGatewayConfigEntry{
+				Kind: "terminating-gateway",
+				Name: "terminating-gw-west",
+				Services: []LinkedService{
+					{
+						Name:          "web",
+						CAFile:        "ca.crt",
+						TLSMinVersion: types.TLSv1_3,
+						CipherSuites: []types.TLSCipherSuite{
+							types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+						},
+					},
+				},
+			},
+			validateErr: `configuring CipherSuites is only applicable to connections negotiated with TLS 1.2 or earlier`,
+		},
 	}
 	testConfigEntryNormalizeAndValidate(t, cases)
 }
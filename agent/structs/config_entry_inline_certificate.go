@@ -60,6 +60,13 @@ func (e *InlineCertificateConfigEntry) GetEnterpriseMeta() *acl.EnterpriseMeta {
 }
 func (e *InlineCertificateConfigEntry) GetRaftIndex() *RaftIndex { return &e.RaftIndex }
 
+var _ SecretConfigEntry = (*InlineCertificateConfigEntry)(nil)
+
+func (e *InlineCertificateConfigEntry) CopySecrets() (SecretConfigEntry, map[string]*string) {
+	c := *e
+	return &c, map[string]*string{"PrivateKey": &c.PrivateKey}
+}
+
 // Envoy will silently reject any RSA keys that are less than 2048 bytes long
 // https://github.com/envoyproxy/envoy/blob/main/source/extensions/transport_sockets/tls/context_impl.cc#L238
 const MinKeyLength = 2048
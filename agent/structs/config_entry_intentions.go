@@ -165,6 +165,13 @@ func (e *ServiceIntentionsConfigEntry) ToIntention(src *SourceIntention) *Intent
 	if src.LegacyUpdateTime != nil {
 		ixn.UpdatedAt = *src.LegacyUpdateTime
 	}
+	if src.ReviewBy != nil {
+		ixn.ReviewBy = timePointer(*src.ReviewBy)
+	}
+	if src.ExpiresAt != nil {
+		ixn.ExpiresAt = timePointer(*src.ExpiresAt)
+	}
+	ixn.Disabled = src.Disabled
 
 	if src.LegacyID != "" {
 		// Ensure that pre-1.9.0 secondaries can still replicate legacy
@@ -287,6 +294,19 @@ type SourceIntention struct {
 
 	// SamenessGroup is the name of the sameness group, if applicable.
 	SamenessGroup string `json:",omitempty" alias:"sameness_group"`
+
+	// ReviewBy is the time by which the intention should be reviewed. Past
+	// this time the intention is reported as overdue for review, and is
+	// disabled if connect.disable_intentions_after_review is set.
+	ReviewBy *time.Time `json:",omitempty" alias:"review_by"`
+
+	// ExpiresAt is the time at which the intention expires. Past this time
+	// the intention is reported as expired, and is disabled if
+	// connect.disable_expired_intentions is set.
+	ExpiresAt *time.Time `json:",omitempty" alias:"expires_at"`
+
+	// Disabled intentions are kept in the config entry but aren't enforced.
+	Disabled bool `json:",omitempty"`
 }
 
 type IntentionJWTRequirement struct {
@@ -450,10 +470,28 @@ func (x *SourceIntention) SourceServiceName() ServiceName {
 	return NewServiceName(x.Name, &x.EnterpriseMeta)
 }
 
+// IsExpired returns whether the intention has an expiry time which has
+// passed.
+func (x *SourceIntention) IsExpired(now time.Time) bool {
+	return x.ExpiresAt != nil && !now.Before(*x.ExpiresAt)
+}
+
+// IsReviewOverdue returns whether the intention has a review time which has
+// passed.
+func (x *SourceIntention) IsReviewOverdue(now time.Time) bool {
+	return x.ReviewBy != nil && !now.Before(*x.ReviewBy)
+}
+
 func (x *SourceIntention) Clone() *SourceIntention {
 	x2 := *x
 
 	x2.LegacyMeta = cloneStringStringMap(x.LegacyMeta)
+	if x.ReviewBy != nil {
+		x2.ReviewBy = timePointer(*x.ReviewBy)
+	}
+	if x.ExpiresAt != nil {
+		x2.ExpiresAt = timePointer(*x.ExpiresAt)
+	}
 
 	if len(x.Permissions) > 0 {
 		x2.Permissions = make([]*IntentionPermission, 0, len(x.Permissions))
@@ -731,6 +769,10 @@ func (e *ServiceIntentionsConfigEntry) validate(legacyWrite bool) error {
 			return fmt.Errorf("Sources[%d].SamenessGroup: cannot set SamenessGroup and Peer at the same time", i)
 		}
 
+		if src.ReviewBy != nil && src.ExpiresAt != nil && src.ReviewBy.After(*src.ExpiresAt) {
+			return fmt.Errorf("Sources[%d].ReviewBy cannot be after Sources[%d].ExpiresAt", i, i)
+		}
+
 		// Length of opaque values
 		if len(src.Description) > metaValueMaxLength {
 			return fmt.Errorf(
@@ -131,6 +131,35 @@ func TestServiceIntentionsConfigEntry(t *testing.T) {
 			},
 			validateErr: `Sources[0].Description exceeds maximum length 512`,
 		},
+		"review after expiry": {
+			entry: &ServiceIntentionsConfigEntry{
+				Kind: ServiceIntentions,
+				Name: "test",
+				Sources: []*SourceIntention{
+					{
+						Name:      "foo",
+						Action:    IntentionActionAllow,
+						ReviewBy:  &testTimeC,
+						ExpiresAt: &testTimeB,
+					},
+				},
+			},
+			validateErr: `Sources[0].ReviewBy cannot be after Sources[0].ExpiresAt`,
+		},
+		"review before expiry": {
+			entry: &ServiceIntentionsConfigEntry{
+				Kind: ServiceIntentions,
+				Name: "test",
+				Sources: []*SourceIntention{
+					{
+						Name:      "foo",
+						Action:    IntentionActionAllow,
+						ReviewBy:  &testTimeB,
+						ExpiresAt: &testTimeC,
+					},
+				},
+			},
+		},
 		"config entry meta not allowed on legacy writes": {
 			legacy: true,
 			entry: &ServiceIntentionsConfigEntry{
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "github.com/hashicorp/consul/acl"
+
+// Severities of the findings reported by the mesh config linter.
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+)
+
+// Rules checked by the mesh config linter.
+const (
+	// LintRuleUnreachableSubset reports resolver subsets no traffic is sent
+	// to.
+	LintRuleUnreachableSubset = "unreachable-subset"
+
+	// LintRuleUndefinedSubset reports references to subsets the resolver of
+	// the service does not define.
+	LintRuleUndefinedSubset = "undefined-subset"
+
+	// LintRuleSplitWeights reports splitters whose weights do not sum to 100.
+	LintRuleSplitWeights = "split-weights"
+
+	// LintRuleMissingFailoverService reports failover targets for services
+	// which do not exist.
+	LintRuleMissingFailoverService = "missing-failover-service"
+
+	// LintRuleMissingService reports routes, splits, redirects and exports
+	// of services which do not exist.
+	LintRuleMissingService = "missing-service"
+
+	// LintRuleIntentionAllowAll reports a wildcard intention allowing every
+	// source to reach every destination.
+	LintRuleIntentionAllowAll = "intention-allow-all"
+
+	// LintRuleWildcardIntentionOverlap reports intentions which have no
+	// effect because a wildcard intention already takes the same action.
+	LintRuleWildcardIntentionOverlap = "wildcard-intention-overlap"
+)
+
+// ConfigEntryLintFinding is a problem found in the mesh configuration.
+type ConfigEntryLintFinding struct {
+	Rule     string
+	Severity string
+
+	// Kind and Name identify the config entry the finding is about.
+	Kind string
+	Name string
+
+	Message string
+
+	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
+}
+
+// ConfigEntryLintResponse is the response of ConfigEntry.Lint.
+type ConfigEntryLintResponse struct {
+	Findings []ConfigEntryLintFinding
+	QueryMeta
+}
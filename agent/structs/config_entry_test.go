@@ -1814,6 +1814,22 @@ func TestDecodeConfigEntry(t *testing.T) {
 						key_file = "/etc/all/tls.key",
 						sni = "my-alt-domain",
 					},
+					{
+						name = "billing",
+						ca_pem = "-----BEGIN CERTIFICATE-----",
+						vault_pki {
+							path = "pki/issue/gateway"
+							common_name = "gateway.example.com"
+							ttl = "24h"
+						}
+						tls_min_version = "TLSv1_2"
+						tls_max_version = "TLSv1_3"
+						cipher_suites = [
+							"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+						]
+						sni = "billing.example.com",
+						host_rewrite = "billing.example.com",
+					},
 				]
 			`,
 			camel: `
@@ -1838,6 +1854,22 @@ func TestDecodeConfigEntry(t *testing.T) {
 						KeyFile = "/etc/all/tls.key",
 						SNI = "my-alt-domain",
 					},
+					{
+						Name = "billing",
+						CAPEM = "-----BEGIN CERTIFICATE-----",
+						VaultPKI {
+							Path = "pki/issue/gateway"
+							CommonName = "gateway.example.com"
+							TTL = "24h"
+						}
+						TLSMinVersion = "TLSv1_2"
+						TLSMaxVersion = "TLSv1_3"
+						CipherSuites = [
+							"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+						]
+						SNI = "billing.example.com",
+						HostRewrite = "billing.example.com",
+					},
 				]
 			`,
 			expect: &TerminatingGatewayConfigEntry{
@@ -1862,6 +1894,22 @@ func TestDecodeConfigEntry(t *testing.T) {
 						KeyFile:  "/etc/all/tls.key",
 						SNI:      "my-alt-domain",
 					},
+					{
+						Name:  "billing",
+						CAPEM: "-----BEGIN CERTIFICATE-----",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+							TTL:        24 * time.Hour,
+						},
+						TLSMinVersion: types.TLSv1_2,
+						TLSMaxVersion: types.TLSv1_3,
+						CipherSuites: []types.TLSCipherSuite{
+							types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+						},
+						SNI:         "billing.example.com",
+						HostRewrite: "billing.example.com",
+					},
 				},
 			},
 		},
@@ -3593,7 +3641,7 @@ func TestProxyConfigEntry(t *testing.T) {
 				Name:           "global",
 				FailoverPolicy: &ServiceResolverFailoverPolicy{Mode: "bad"},
 			},
-			validateErr: `Failover-policy mode must be one of '', 'sequential', or 'order-by-locality'`,
+			validateErr: `Failover-policy mode must be one of '', 'sequential', 'order-by-locality', or 'order-by-health'`,
 		},
 		"proxy config with valid failover policy": {
 			entry: &ProxyConfigEntry{
@@ -3703,6 +3751,90 @@ func TestProxyConfigEntry_ComputeProtocol(t *testing.T) {
 	})
 }
 
+func TestMergeProxyConfigEntries(t *testing.T) {
+	global := &ProxyConfigEntry{
+		Kind:     ProxyDefaults,
+		Name:     ProxyConfigGlobal,
+		Protocol: "http",
+		Config: map[string]interface{}{
+			"protocol":                 "http",
+			"local_connect_timeout_ms": 1000,
+		},
+		MeshGateway: MeshGatewayConfig{Mode: MeshGatewayModeLocal},
+		Expose:      ExposeConfig{Checks: true},
+		EnvoyExtensions: EnvoyExtensions{
+			{Name: "builtin/lua"},
+		},
+		Meta:      map[string]string{"team": "platform", "tier": "1"},
+		RaftIndex: RaftIndex{CreateIndex: 1, ModifyIndex: 1},
+	}
+	namespace := &ProxyConfigEntry{
+		Kind:     ProxyDefaults,
+		Name:     ProxyConfigGlobal,
+		Protocol: "grpc",
+		Config: map[string]interface{}{
+			"protocol": "grpc",
+		},
+		Mode: ProxyModeTransparent,
+		FailoverPolicy: &ServiceResolverFailoverPolicy{
+			Mode: "order-by-locality",
+		},
+		EnvoyExtensions: EnvoyExtensions{
+			{Name: "builtin/aws/lambda"},
+		},
+		Meta:      map[string]string{"tier": "2"},
+		RaftIndex: RaftIndex{CreateIndex: 5, ModifyIndex: 7},
+	}
+
+	t.Run("no layers", func(t *testing.T) {
+		require.Nil(t, MergeProxyConfigEntries())
+		require.Nil(t, MergeProxyConfigEntries(nil, nil))
+	})
+
+	t.Run("single layer is returned as is", func(t *testing.T) {
+		require.Same(t, global, MergeProxyConfigEntries(nil, global))
+	})
+
+	t.Run("more specific layers take precedence", func(t *testing.T) {
+		merged := MergeProxyConfigEntries(global, nil, namespace)
+		merged.Hash = 0
+		require.Equal(t, &ProxyConfigEntry{
+			Kind:     ProxyDefaults,
+			Name:     ProxyConfigGlobal,
+			Protocol: "grpc",
+			Config: map[string]interface{}{
+				"protocol":                 "grpc",
+				"local_connect_timeout_ms": 1000,
+			},
+			Mode:        ProxyModeTransparent,
+			MeshGateway: MeshGatewayConfig{Mode: MeshGatewayModeLocal},
+			Expose:      ExposeConfig{Checks: true},
+			EnvoyExtensions: EnvoyExtensions{
+				{Name: "builtin/lua"},
+				{Name: "builtin/aws/lambda"},
+			},
+			FailoverPolicy: &ServiceResolverFailoverPolicy{
+				Mode: "order-by-locality",
+			},
+			Meta:      map[string]string{"team": "platform", "tier": "2"},
+			RaftIndex: RaftIndex{CreateIndex: 5, ModifyIndex: 7},
+		}, merged)
+
+		// The layers are left untouched.
+		require.Equal(t, "http", global.Config["protocol"])
+		require.Equal(t, "1", global.Meta["tier"])
+		require.Len(t, global.EnvoyExtensions, 1)
+	})
+
+	t.Run("merge is deterministic", func(t *testing.T) {
+		a := MergeProxyConfigEntries(global, namespace)
+		b := MergeProxyConfigEntries(global, namespace)
+		require.NotZero(t, a.Hash)
+		require.Equal(t, a.Hash, b.Hash)
+		require.NotEqual(t, a.Hash, MergeProxyConfigEntries(namespace, global).Hash)
+	})
+}
+
 func requireContainsLower(t *testing.T, haystack, needle string) {
 	t.Helper()
 	require.Contains(t, strings.ToLower(haystack), strings.ToLower(needle))
@@ -56,11 +56,33 @@ type IndexedCARoots struct {
 	// Roots is a list of root CA certs to trust.
 	Roots []*CARoot
 
+	// RevokedSerialNumbers are the serial numbers of the certificates issued
+	// in this datacenter that were revoked before they expired. They are
+	// encoded in standard hex separated by :.
+	RevokedSerialNumbers []string `json:",omitempty"`
+
+	// RevocationList holds the PEM encoded certificate revocation lists of
+	// all the datacenters, which proxies check the leaf certs of their peers
+	// against. It is only set while certificates are revoked in any of the
+	// datacenters and the lists of all of them are known.
+	RevocationList string `json:",omitempty"`
+
 	// QueryMeta contains the meta sent via a header. We ignore for JSON
 	// so this whole structure can be returned.
 	QueryMeta `json:"-"`
 }
 
+// IsRevoked reports whether the certificate with the given serial number,
+// encoded in standard hex separated by :, was revoked.
+func (r IndexedCARoots) IsRevoked(serialNumber string) bool {
+	for _, sn := range r.RevokedSerialNumbers {
+		if sn == serialNumber {
+			return true
+		}
+	}
+	return false
+}
+
 func (r IndexedCARoots) Active() *CARoot {
 	for _, root := range r.Roots {
 		if root.ID == r.ActiveRootID {
@@ -258,6 +280,241 @@ func (i *IssuedCert) Key() string {
 	)
 }
 
+// SVIDIntrospectRequest is the request to introspect a workload's SVID.
+type SVIDIntrospectRequest struct {
+	// SVID is a PEM encoded bundle of a leaf certificate, optionally followed
+	// by the intermediate certificates forming its chain of trust.
+	SVID string
+}
+
+// SVIDIntrospection describes the identity carried by an SVID, and whether it
+// is currently trusted by the Connect CA.
+type SVIDIntrospection struct {
+	// Kind is the kind of identity carried by the SVID: "service",
+	// "workload-identity", "agent", "server" or "mesh-gateway". It is empty if
+	// the certificate's URI is not a Consul SPIFFE ID.
+	Kind string `json:",omitempty"`
+
+	// SpiffeID is the certificate's URI.
+	SpiffeID string `json:",omitempty"`
+
+	// TrustDomain is the trust domain of the SPIFFE ID, and TrustDomainMatches
+	// reports whether it is the trust domain of this cluster or of one of the
+	// roots still trusted after a trust domain migration.
+	TrustDomain        string `json:",omitempty"`
+	TrustDomainMatches bool
+
+	// WorkloadIdentity, Service, Agent, Datacenter, Partition and Namespace
+	// are the claims of the SPIFFE ID. Only the ones encoded by its kind are
+	// set.
+	WorkloadIdentity string `json:",omitempty"`
+	Service          string `json:",omitempty"`
+	Agent            string `json:",omitempty"`
+	Datacenter       string `json:",omitempty"`
+	Partition        string `json:",omitempty"`
+	Namespace        string `json:",omitempty"`
+
+	// SerialNumber and SigningKeyID are encoded in standard hex separated by
+	// :, like the ones of the CA roots.
+	SerialNumber string
+	SigningKeyID string   `json:",omitempty"`
+	Subject      string   `json:",omitempty"`
+	Issuer       string   `json:",omitempty"`
+	DNSNames     []string `json:",omitempty"`
+
+	// ValidAfter and ValidBefore are the validity periods for the
+	// certificate.
+	ValidAfter  time.Time
+	ValidBefore time.Time
+
+	// SignedByRootID is the ID of the CA root the certificate chains up to,
+	// and SignedByActiveRoot reports whether it is the active root.
+	SignedByRootID     string `json:",omitempty"`
+	SignedByActiveRoot bool
+
+	// Valid reports whether the certificate is currently valid and trusted by
+	// the Connect CA, and carries a Consul SPIFFE ID. Otherwise, InvalidReason
+	// explains why it isn't.
+	Valid         bool
+	InvalidReason string `json:",omitempty"`
+
+	// Revoked reports whether the certificate was revoked before it expired.
+	Revoked bool
+}
+
+// CARevokedCert is a certificate issued by the Connect CA that was revoked
+// before it expired. Revoked certificates are listed in the certificate
+// revocation list and the OCSP responses of the datacenter until they expire.
+type CARevokedCert struct {
+	// SerialNumber is encoded in standard hex separated by :, like the ones
+	// of the issued certificates.
+	SerialNumber string
+
+	// SigningKeyID is the ID of the key that signed the certificate. It is
+	// only known when the certificate itself was given to revoke it.
+	SigningKeyID string `json:",omitempty"`
+
+	// Reason is the RFC 5280 CRLReason code for the revocation.
+	Reason int
+
+	// RevokedAt is the time the certificate was revoked.
+	RevokedAt time.Time
+
+	// NotAfter is the time the certificate expires, after which it is removed
+	// from the revocation list. When only the serial number was given, it is
+	// the longest lifetime of a certificate issued by the CA.
+	NotAfter time.Time
+
+	RaftIndex
+}
+
+// CARevokedCerts is a list of revoked certificates.
+type CARevokedCerts []*CARevokedCert
+
+// IndexedCARevokedCerts is the list of certificates revoked in a datacenter.
+type IndexedCARevokedCerts struct {
+	RevokedCerts CARevokedCerts
+	QueryMeta
+}
+
+// CARevokeRequest is the request to revoke a certificate issued by the
+// Connect CA.
+type CARevokeRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// Certificate is the PEM encoded certificate to revoke. Either it or
+	// SerialNumber must be set.
+	Certificate string `json:",omitempty"`
+
+	// SerialNumber is the serial number of the certificate to revoke, encoded
+	// in standard hex separated by :.
+	SerialNumber string `json:",omitempty"`
+
+	// Reason is the RFC 5280 CRLReason code for the revocation. It defaults
+	// to unspecified.
+	Reason int `json:",omitempty"`
+
+	// WriteRequest is a common struct containing ACL tokens and other
+	// write-related common elements for requests.
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CARevokeRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CARevocationList is a certificate revocation list signed by the active
+// leaf signing certificate of a datacenter.
+type CARevocationList struct {
+	// CRL is the DER encoded certificate revocation list.
+	CRL []byte
+
+	// Number is the CRL number, which increases with every revocation.
+	Number uint64
+
+	ThisUpdate time.Time
+	NextUpdate time.Time
+
+	QueryMeta
+}
+
+// CARevocationLists are the certificate revocation lists of all the
+// datacenters. The leader gathers them while certificates are revoked in any
+// of the datacenters, so that they can be distributed along with the roots.
+type CARevocationLists struct {
+	Lists []*CADatacenterRevocationList
+
+	RaftIndex
+}
+
+// CADatacenterRevocationList is the certificate revocation list of a
+// datacenter, as signed by one of its leaf signing certificates.
+type CADatacenterRevocationList struct {
+	Datacenter string
+
+	// SigningKeyID is the ID of the key that signed the list.
+	SigningKeyID string
+
+	// CRL is the DER encoded certificate revocation list.
+	CRL []byte
+
+	// Revoked reports whether the list contains any certificate.
+	Revoked bool
+
+	NextUpdate time.Time
+}
+
+// CAOCSPRequest is the request for the OCSP status of a certificate.
+type CAOCSPRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// Request is the DER encoded OCSP request.
+	Request []byte
+
+	QueryOptions
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CAOCSPRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CAOCSPResponse is a DER encoded OCSP response.
+type CAOCSPResponse struct {
+	Response []byte
+}
+
+// CALeafInventory summarizes the leaf certificates signed by the leader of a
+// datacenter since it was elected, for each workload identity.
+type CALeafInventory struct {
+	// Since is the time the leader started tracking the signed certificates.
+	Since time.Time
+
+	Identities []*CALeafInventoryEntry
+
+	QueryMeta
+}
+
+// CALeafInventoryEntry summarizes the leaf certificates signed for a service,
+// a workload identity or the mesh gateways.
+type CALeafInventoryEntry struct {
+	// Kind is "service", "workload-identity" or "mesh-gateway".
+	Kind string
+
+	// Name is the name of the service or workload identity. It is empty for
+	// mesh gateways.
+	Name string `json:",omitempty"`
+
+	// ActiveCerts is the number of signed certificates which didn't expire
+	// yet.
+	ActiveCerts int
+
+	// Signed is the number of certificates signed since the inventory
+	// started, and SignedLastHour the number signed during the last hour.
+	Signed         uint64
+	SignedLastHour int
+
+	// LastSigned is the time the latest certificate was signed.
+	LastSigned time.Time
+
+	// EarliestExpiry is the expiration time of the active certificate which
+	// expires first. LatestExpiry is the expiration time of the latest
+	// certificate: when it approaches, certificates stopped being rotated.
+	EarliestExpiry time.Time
+	LatestExpiry   time.Time
+
+	// SignErrors is the number of requests to sign a certificate which
+	// failed, and LastSignError the error of the latest one.
+	SignErrors      uint64
+	LastSignError   string `json:",omitempty"`
+	LastSignErrorAt time.Time
+
+	acl.EnterpriseMeta
+}
+
 // CAOp is the operation for a request related to intentions.
 type CAOp string
 
@@ -268,6 +525,8 @@ const (
 	CAOpDeleteProviderState           CAOp = "delete-provider-state"
 	CAOpSetRootsAndConfig             CAOp = "set-roots-config"
 	CAOpIncrementProviderSerialNumber CAOp = "increment-provider-serial"
+	CAOpRevokeCertificates            CAOp = "revoke-certificates"
+	CAOpSetRevocationLists            CAOp = "set-revocation-lists"
 )
 
 // CARequest is used to modify connect CA data. This is used by the
@@ -293,6 +552,15 @@ type CARequest struct {
 	// ProviderState is the state for the builtin CA provider.
 	ProviderState *CAConsulProviderState
 
+	// RevokedCerts are the certificates to revoke. This is used for
+	// CAOpRevokeCertificates.
+	RevokedCerts []*CARevokedCert
+
+	// RevocationLists are the certificate revocation lists of all the
+	// datacenters, or nil to remove them. This is used for
+	// CAOpSetRevocationLists.
+	RevocationLists *CARevocationLists
+
 	// WriteRequest is a common struct containing ACL tokens and other
 	// write-related common elements for requests.
 	WriteRequest
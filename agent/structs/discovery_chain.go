@@ -61,6 +61,44 @@ type CompiledDiscoveryChain struct {
 	// VirtualIPs is a list of virtual IPs associated with the service.
 	AutoVirtualIPs   []string
 	ManualVirtualIPs []string
+
+	// Explanation describes which config entries contributed to the chain
+	// and where defaults applied. It is only set when requested.
+	Explanation *DiscoveryChainExplanation `json:",omitempty"`
+}
+
+// DiscoveryChainExplanation annotates a compiled discovery chain with the
+// reasons it was compiled the way it was, to help debug surprising routing.
+type DiscoveryChainExplanation struct {
+	// Chain holds the reasons that apply to the chain as a whole, such as how
+	// its protocol was chosen.
+	Chain []string `json:",omitempty"`
+
+	// Nodes is keyed by the same keys as CompiledDiscoveryChain.Nodes.
+	Nodes map[string]*DiscoveryExplanation `json:",omitempty"`
+
+	// Targets is keyed by the same keys as CompiledDiscoveryChain.Targets.
+	Targets map[string]*DiscoveryExplanation `json:",omitempty"`
+}
+
+// DiscoveryExplanation describes where a node or target of a discovery chain
+// came from.
+type DiscoveryExplanation struct {
+	// ConfigEntry is the config entry that the node or target was compiled
+	// from. It is nil when only defaults applied.
+	ConfigEntry *DiscoveryExplanationConfigEntry `json:",omitempty"`
+
+	// Reasons lists, in order, the decisions the compiler made and the config
+	// entries or defaults that drove them.
+	Reasons []string `json:",omitempty"`
+}
+
+// DiscoveryExplanationConfigEntry identifies a config entry in an explanation.
+type DiscoveryExplanationConfigEntry struct {
+	Kind      string
+	Name      string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
 }
 
 // ID returns an ID that encodes the service, namespace, partition, and datacenter.
@@ -188,6 +226,30 @@ type DiscoveryFailover struct {
 	Targets []string                       `json:",omitempty"`
 	Policy  *ServiceResolverFailoverPolicy `json:",omitempty"`
 	Regions []string                       `json:",omitempty"`
+
+	// TargetHealth is the observed health of each failover target, in the
+	// same order as Targets. It is only set when the failover policy mode is
+	// "order-by-health", in which case Targets is sorted by it. It is excluded
+	// from the hash so that the chain is only considered changed when the
+	// order of the targets changes.
+	TargetHealth []DiscoveryFailoverTargetHealth `json:",omitempty" hash:"ignore"`
+}
+
+// DiscoveryFailoverTargetHealth is the health and latency observed for a
+// failover target when ordering targets with the "order-by-health" failover
+// policy mode.
+type DiscoveryFailoverTargetHealth struct {
+	Target string
+
+	// HealthPercent is the percentage of the target's instances whose health
+	// checks are all passing. It is zero if the target has no instances or
+	// its health could not be determined.
+	HealthPercent int
+
+	// RTT is the estimated round trip time to the servers of the target's
+	// datacenter, computed from network coordinates. It is zero for targets in
+	// the local datacenter and for peered targets, which have no coordinates.
+	RTT time.Duration `json:",omitempty"`
 }
 
 // compiled form of ServiceResolverPrioritizeByLocality
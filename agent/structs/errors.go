@@ -25,6 +25,7 @@ const (
 	errStateReadOnly                         = "CA Provider State is read-only"
 	errUsingV2CatalogExperiment              = "V1 catalog is disabled when V2 is enabled"
 	errSamenessGroupMustBeDefaultForFailover = "Sameness Group must have DefaultForFailover set to true in order to use this endpoint"
+	errQuotaExceeded                         = "Quota exceeded"
 )
 
 var (
@@ -43,6 +44,7 @@ var (
 	ErrStateReadOnly                         = errors.New(errStateReadOnly)
 	ErrUsingV2CatalogExperiment              = errors.New(errUsingV2CatalogExperiment)
 	ErrSamenessGroupMustBeDefaultForFailover = errors.New(errSamenessGroupMustBeDefaultForFailover)
+	ErrQuotaExceeded                         = errors.New(errQuotaExceeded)
 )
 
 func IsErrNoDCPath(err error) bool {
@@ -68,3 +70,7 @@ func IsErrUsingV2CatalogExperiment(err error) bool {
 func IsErrSamenessGroupMustBeDefaultForFailover(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errSamenessGroupMustBeDefaultForFailover)
 }
+
+func IsErrQuotaExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errQuotaExceeded)
+}
@@ -109,6 +109,27 @@ type Intention struct {
 	// or modified.
 	CreatedAt, UpdatedAt time.Time `mapstructure:"-" bexpr:"-"`
 
+	// ReviewBy is the time by which the intention should be reviewed.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	ReviewBy *time.Time `bexpr:"-" json:",omitempty"`
+
+	// ExpiresAt is the time at which the intention expires.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	ExpiresAt *time.Time `bexpr:"-" json:",omitempty"`
+
+	// Disabled intentions aren't enforced.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	Disabled bool `bexpr:"-" json:",omitempty"`
+
 	// Hash of the contents of the intention. This is only necessary for legacy
 	// intention replication purposes.
 	//
@@ -129,10 +150,29 @@ func (t *Intention) Clone() *Intention {
 		}
 	}
 	t2.Meta = cloneStringStringMap(t.Meta)
+	if t.ReviewBy != nil {
+		t2.ReviewBy = timePointer(*t.ReviewBy)
+	}
+	if t.ExpiresAt != nil {
+		t2.ExpiresAt = timePointer(*t.ExpiresAt)
+	}
 	t2.Hash = nil
 	return &t2
 }
 
+// DueWithin returns whether the intention has to be reviewed, or expires,
+// before the given duration has elapsed. Intentions which are already overdue
+// or expired are included.
+func (t *Intention) DueWithin(now time.Time, d time.Duration) bool {
+	deadline := now.Add(d)
+	for _, due := range []*time.Time{t.ReviewBy, t.ExpiresAt} {
+		if due != nil && due.Before(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Intention) ToExact() *IntentionQueryExact {
 	return &IntentionQueryExact{
 		SourcePartition:      t.SourcePartition,
@@ -503,6 +543,9 @@ func (x *Intention) ToSourceIntention(legacy bool) *SourceIntention {
 	}
 	if !legacy {
 		src.Permissions = x.Permissions
+		src.ReviewBy = x.ReviewBy
+		src.ExpiresAt = x.ExpiresAt
+		src.Disabled = x.Disabled
 	}
 	return src
 }
@@ -724,6 +767,37 @@ type IntentionQueryCheckResponse struct {
 	Allowed bool
 }
 
+// IntentionDecisionsRequest is used by agents to report the authorization
+// decisions they made since their previous report, so that the servers can
+// aggregate them.
+type IntentionDecisionsRequest struct {
+	// Datacenter is the target this request is intended for.
+	Datacenter string
+
+	// Node is the name of the reporting node.
+	Node string
+
+	// Decisions holds the number of decisions per destination service.
+	Decisions []IntentionDecisionCount
+
+	// EnterpriseMeta is the partition of the reporting node.
+	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *IntentionDecisionsRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// IntentionDecisionCount is the number of connections allowed and denied to
+// a destination service.
+type IntentionDecisionCount struct {
+	Destination ServiceName
+	Allowed     uint64
+	Denied      uint64
+}
+
 // IntentionDecisionSummary contains a summary of a set of intentions between two services
 // Currently contains:
 // - Whether all actions are allowed
@@ -773,8 +847,13 @@ func (q *IntentionQueryExact) Validate() error {
 
 // TODO(peering): add support for listing peer
 type IntentionListRequest struct {
-	Datacenter         string
-	Legacy             bool `json:"-"`
+	Datacenter string
+	Legacy     bool `json:"-"`
+
+	// ExpiringWithin limits the results to the intentions which have to be
+	// reviewed, or expire, within this duration. Zero means no limit.
+	ExpiringWithin time.Duration
+
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 	QueryOptions
 }
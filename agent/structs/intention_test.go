@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -533,3 +534,30 @@ func TestIntention_String(t *testing.T) {
 func TestIntentionQueryRequest_CacheInfoKey(t *testing.T) {
 	assertCacheInfoKeyIsComplete(t, &IntentionQueryRequest{})
 }
+
+func TestIntention_DueWithin(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	cases := map[string]struct {
+		ixn  *Intention
+		want bool
+	}{
+		"no deadline":      {ixn: &Intention{}, want: false},
+		"review soon":      {ixn: &Intention{ReviewBy: at(time.Hour)}, want: true},
+		"review later":     {ixn: &Intention{ReviewBy: at(48 * time.Hour)}, want: false},
+		"review overdue":   {ixn: &Intention{ReviewBy: at(-time.Hour)}, want: true},
+		"expires soon":     {ixn: &Intention{ReviewBy: at(48 * time.Hour), ExpiresAt: at(time.Hour)}, want: true},
+		"expires later":    {ixn: &Intention{ExpiresAt: at(48 * time.Hour)}, want: false},
+		"already expired":  {ixn: &Intention{ExpiresAt: at(-time.Minute)}, want: true},
+		"exactly deadline": {ixn: &Intention{ExpiresAt: at(24 * time.Hour)}, want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.ixn.DueWithin(now, 24*time.Hour))
+		})
+	}
+}
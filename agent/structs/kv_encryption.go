@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "time"
+
+// KVEncryptionRequest is used to rotate the KV encryption key or to re-encrypt
+// the KV values.
+type KVEncryptionRequest struct {
+	Datacenter string
+	WriteRequest
+}
+
+func (r *KVEncryptionRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// KVEncryptionKey describes a data encryption key used to encrypt KV values.
+type KVEncryptionKey struct {
+	ID         string
+	Provider   string
+	CreateTime time.Time
+	Active     bool
+
+	// Entries is the number of KV entries encrypted with the key.
+	Entries int
+}
+
+// KVEncryptionStatus describes the encryption of the KV values.
+type KVEncryptionStatus struct {
+	// Enabled is whether the server that handled the request encrypts values.
+	Enabled bool
+
+	// Provider is the key provider configured on the server.
+	Provider string `json:",omitempty"`
+
+	// ActiveKeyID is the ID of the key that new values are encrypted with.
+	ActiveKeyID string `json:",omitempty"`
+
+	Keys []KVEncryptionKey
+
+	// PlaintextEntries is the number of KV entries that are not encrypted,
+	// e.g. because they were written before encryption was enabled.
+	PlaintextEntries int
+
+	// Rewrapped is the number of KV entries that were re-encrypted with the
+	// active key by a rewrap.
+	Rewrapped int `json:",omitempty"`
+
+	QueryMeta
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "time"
+
+// KVReplicationStatus describes the replication of the KV prefixes from the
+// primary datacenter, as seen by the leader of the datacenter.
+type KVReplicationStatus struct {
+	// Enabled is whether KV prefixes are configured to be replicated.
+	Enabled bool
+
+	// Running is whether the leader is replicating the prefixes, which is
+	// only the case in secondary datacenters.
+	Running bool
+
+	SourceDatacenter string `json:",omitempty"`
+
+	Prefixes []KVReplicationPrefixStatus
+
+	QueryMeta
+}
+
+// KVReplicationPrefixStatus describes the replication of a KV prefix.
+type KVReplicationPrefixStatus struct {
+	Prefix string
+
+	// ReplicatedIndex is the index in the primary datacenter the prefix was
+	// last replicated at.
+	ReplicatedIndex uint64
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+}
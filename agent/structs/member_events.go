@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"time"
+
+	"github.com/hashicorp/serf/coordinate"
+)
+
+// The types of the changes of the membership of the LAN gossip pool.
+const (
+	MemberEventJoin   = "join"
+	MemberEventLeave  = "leave"
+	MemberEventFailed = "failed"
+	MemberEventReap   = "reap"
+)
+
+// The reasons of the changes of the membership of the LAN gossip pool.
+const (
+	// MemberEventReasonJoined is the join of a node which was not known.
+	MemberEventReasonJoined = "joined"
+
+	// MemberEventReasonRecovered is the join of a node which had failed.
+	MemberEventReasonRecovered = "recovered"
+
+	// MemberEventReasonRejoined is the join of a node which had left.
+	MemberEventReasonRejoined = "rejoined"
+
+	// MemberEventReasonLeft is the graceful leave of a node.
+	MemberEventReasonLeft = "left"
+
+	// MemberEventReasonForceLeft is the leave of a failed node forced by an
+	// operator.
+	MemberEventReasonForceLeft = "force-left"
+
+	// MemberEventReasonFailureDetected is the failure of a node detected by
+	// the failed probes of the gossip protocol.
+	MemberEventReasonFailureDetected = "failure-detected"
+
+	// MemberEventReasonReconnectTimeout is the removal of a failed node which
+	// did not come back before the reconnect timeout.
+	MemberEventReasonReconnectTimeout = "reconnect-timeout"
+
+	// MemberEventReasonTombstoneTimeout is the removal of a node which left
+	// once the tombstone timeout expired.
+	MemberEventReasonTombstoneTimeout = "tombstone-timeout"
+
+	// MemberEventReasonPruned is the removal of a node which left, before the
+	// tombstone timeout, by a forced leave with pruning.
+	MemberEventReasonPruned = "pruned"
+)
+
+// MemberEvent is a change of the membership of the LAN gossip pool observed
+// by an agent.
+type MemberEvent struct {
+	// Index is the position of the event in the events observed by the
+	// agent since it started.
+	Index uint64
+
+	// Time is when the agent observed the event.
+	Time time.Time
+
+	// Type is one of the MemberEvent* types, and Reason one of the
+	// MemberEventReason* reasons.
+	Type   string
+	Reason string
+
+	// Name, Addr, Port, Tags and Status describe the member as of the event.
+	Name   string
+	Addr   string
+	Port   uint16
+	Tags   map[string]string
+	Status string
+
+	// Coordinate is the last network coordinate of the member known by the
+	// agent, if any.
+	Coordinate *coordinate.Coordinate `json:",omitempty" bexpr:"-"`
+
+	// DetectedBy is the name of the agent which observed the event, and
+	// DetectedByCoordinate its network coordinate at the time, if the
+	// coordinates are enabled.
+	DetectedBy           string
+	DetectedByCoordinate *coordinate.Coordinate `json:",omitempty" bexpr:"-"`
+}
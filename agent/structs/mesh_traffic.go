@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "time"
+
+// MeshTrafficRollup summarizes the requests made by a service to one of its
+// upstreams over a recent window, as reported by the dataplanes of the
+// service.
+type MeshTrafficRollup struct {
+	Source       ServiceName
+	Upstream     ServiceName
+	UpstreamPeer string `json:",omitempty"`
+
+	// RequestsPerSecond is the rate of the requests over the window.
+	RequestsPerSecond float64
+
+	// ErrorRate is the share of the requests which failed, between 0 and 1.
+	ErrorRate float64
+
+	// P50Latency and P99Latency are percentiles of the latencies of the
+	// sampled requests.
+	P50Latency time.Duration
+	P99Latency time.Duration
+}
+
+// MeshTrafficMetrics holds the rollups of the requests made by a service to
+// its upstreams and by its downstreams to the service.
+type MeshTrafficMetrics struct {
+	Upstreams   []MeshTrafficRollup
+	Downstreams []MeshTrafficRollup
+}
+
+type IndexedMeshTrafficMetrics struct {
+	MeshTrafficMetrics
+	QueryMeta
+}
@@ -5,6 +5,7 @@ package structs
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/raft"
 )
@@ -91,7 +92,7 @@ func (op *AutopilotSetConfigRequest) RequestDatacenter() string {
 	return op.Datacenter
 }
 
-// (Enterprise-only) NetworkSegment is the configuration for a network segment, which is an
+// NetworkSegment is the configuration for a network segment, which is an
 // isolated serf group on the LAN.
 type NetworkSegment struct {
 	// Name is the name of the segment.
@@ -106,4 +107,62 @@ type NetworkSegment struct {
 	// RPCListener is whether to bind a separate RPC listener on the bind address
 	// for this segment.
 	RPCListener bool
+
+	// The gossip settings below override the gossip_lan settings for this
+	// segment when they are not zero.
+	GossipNodes    int
+	GossipInterval time.Duration
+	ProbeInterval  time.Duration
+	ProbeTimeout   time.Duration
+	SuspicionMult  int
+	RetransmitMult int
+}
+
+const (
+	// ClusterFeatureFederationStates is the replication of federation states
+	// between datacenters.
+	ClusterFeatureFederationStates = "federation-states"
+
+	// ClusterFeatureServiceIntentions is the storage of intentions as
+	// service-intentions config entries.
+	ClusterFeatureServiceIntentions = "service-intentions"
+
+	// ClusterFeatureStreaming is the streaming backend for the health and
+	// catalog endpoints (rpc.enable_streaming).
+	ClusterFeatureStreaming = "streaming"
+
+	// ClusterFeaturePeering is cluster peering (peering.enabled).
+	ClusterFeaturePeering = "peering"
+
+	// ClusterFeatureResourceAPIs is the v2 catalog and resource APIs (the
+	// resource-apis experiment).
+	ClusterFeatureResourceAPIs = "resource-apis"
+)
+
+// ClusterFeature reports whether a protocol feature is active in a
+// datacenter. A feature is only active once every server in the datacenter
+// supports and enables it, so during a rolling upgrade clients should not rely
+// on a feature until it is reported as active.
+type ClusterFeature struct {
+	// Name is the name of the feature.
+	Name string
+
+	// Active is true when every server in the datacenter advertises the
+	// feature.
+	Active bool
+
+	// MissingServers lists the servers which do not advertise the feature,
+	// either because they run an older version or because the feature is not
+	// enabled in their configuration.
+	MissingServers []string `json:",omitempty"`
+}
+
+// ClusterFeaturesResponse is returned when querying for the protocol features
+// which are active in a datacenter.
+type ClusterFeaturesResponse struct {
+	// Datacenter is the datacenter the features were computed for.
+	Datacenter string
+
+	// Features holds the known features, sorted by name.
+	Features []ClusterFeature
 }
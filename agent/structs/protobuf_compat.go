@@ -87,6 +87,14 @@ func (m *QueryOptions) GetStaleIfError() (time.Duration, error) {
 	return 0, nil
 }
 
+// GetBoundedStaleness helps implement the QueryOptionsCompat interface
+func (m *QueryOptions) GetBoundedStaleness() (time.Duration, error) {
+	if m != nil {
+		return m.BoundedStaleness, nil
+	}
+	return 0, nil
+}
+
 // GetFilter helps implement the QueryOptionsCompat interface
 func (m *QueryOptions) GetFilter() string {
 	if m != nil {
@@ -145,6 +153,11 @@ func (q *QueryOptions) SetStaleIfError(staleIfError time.Duration) {
 	q.StaleIfError = staleIfError
 }
 
+// SetBoundedStaleness is needed to implement the structs.QueryOptionsCompat interface
+func (q *QueryOptions) SetBoundedStaleness(boundedStaleness time.Duration) {
+	q.BoundedStaleness = boundedStaleness
+}
+
 // SetFilter is needed to implement the structs.QueryOptionsCompat interface
 func (q *QueryOptions) SetFilter(filter string) {
 	q.Filter = filter
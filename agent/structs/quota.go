@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul/acl"
+)
+
+// QuotaLimits are the maximum amount of objects which may be stored in a
+// namespace (or partition). A limit of zero means unlimited.
+type QuotaLimits struct {
+	// MaxServices is the maximum number of unique service names.
+	MaxServices int
+
+	// MaxKVBytes is the maximum total size, in bytes, of the values of the
+	// KV entries.
+	MaxKVBytes int
+
+	// MaxIntentions is the maximum number of intentions, across all of the
+	// service-intentions config entries.
+	MaxIntentions int
+
+	// MaxACLTokens is the maximum number of ACL tokens.
+	MaxACLTokens int
+}
+
+// QuotaOverride overrides the default quota limits for a single namespace.
+type QuotaOverride struct {
+	Partition string
+	Namespace string
+	QuotaLimits
+}
+
+// QuotaConfig configures the quotas which are enforced when objects are written.
+type QuotaConfig struct {
+	// QuotaLimits are the default limits, which apply to every namespace
+	// without an override.
+	QuotaLimits
+
+	// Overrides replace the default limits for specific namespaces.
+	Overrides []QuotaOverride
+}
+
+// Enabled returns whether any quota is configured.
+func (c *QuotaConfig) Enabled() bool {
+	if c == nil {
+		return false
+	}
+	if c.QuotaLimits != (QuotaLimits{}) {
+		return true
+	}
+	for _, o := range c.Overrides {
+		if o.QuotaLimits != (QuotaLimits{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// LimitsFor returns the quota limits which apply to the given namespace.
+func (c *QuotaConfig) LimitsFor(entMeta *acl.EnterpriseMeta) QuotaLimits {
+	if c == nil {
+		return QuotaLimits{}
+	}
+	for _, o := range c.Overrides {
+		if acl.EqualPartitions(o.Partition, entMeta.PartitionOrDefault()) &&
+			acl.EqualNamespaces(o.Namespace, entMeta.NamespaceOrDefault()) {
+			return o.QuotaLimits
+		}
+	}
+	return c.QuotaLimits
+}
+
+// QuotaUsage is the amount of quota limited objects stored in a namespace.
+type QuotaUsage struct {
+	Services   int
+	KVBytes    int
+	Intentions int
+	ACLTokens  int
+}
+
+// Check returns an error if a write which changes the usage of a namespace from
+// current to proposed would exceed any of the limits. Only the kinds of objects
+// which grow are checked, so that namespaces which are already over a lowered
+// quota can still be cleaned up.
+func (l QuotaLimits) Check(current, proposed QuotaUsage, entMeta *acl.EnterpriseMeta) error {
+	check := func(name string, current, proposed, limit int) error {
+		if limit > 0 && proposed > current && proposed > limit {
+			return fmt.Errorf("%w: %s quota of %d for %s would be exceeded (%d)",
+				ErrQuotaExceeded, name, limit, quotaScope(entMeta), proposed)
+		}
+		return nil
+	}
+	if err := check("services", current.Services, proposed.Services, l.MaxServices); err != nil {
+		return err
+	}
+	if err := check("KV bytes", current.KVBytes, proposed.KVBytes, l.MaxKVBytes); err != nil {
+		return err
+	}
+	if err := check("intentions", current.Intentions, proposed.Intentions, l.MaxIntentions); err != nil {
+		return err
+	}
+	return check("ACL tokens", current.ACLTokens, proposed.ACLTokens, l.MaxACLTokens)
+}
+
+func quotaScope(entMeta *acl.EnterpriseMeta) string {
+	return fmt.Sprintf("partition %q namespace %q", entMeta.PartitionOrDefault(), entMeta.NamespaceOrDefault())
+}
+
+// QuotaRequest is used to request the quota limits and usage of a namespace.
+type QuotaRequest struct {
+	Datacenter         string
+	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
+	QueryOptions
+}
+
+func (r *QuotaRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
+// QuotaResponse contains the quota limits and usage of a namespace.
+type QuotaResponse struct {
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+	Limits    QuotaLimits
+	Usage     QuotaUsage
+	QueryMeta
+}
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the values hidden by the redaction settings.
+const RedactedValue = "<hidden>"
+
+// RedactionConfig configures the values which are hidden from the list
+// responses of the callers without secrets:read access.
+type RedactionConfig struct {
+	// KVPrefixes are the key prefixes whose values are hidden.
+	KVPrefixes []string
+
+	// ConfigEntryFields are the config entry fields which are hidden, in the
+	// form "<kind>.<field>", e.g. "inline-certificate.PrivateKey". Nested
+	// fields and map keys are separated with dots, e.g.
+	// "service-defaults.Meta.password". When the path goes through a list,
+	// the field is hidden in every element of the list.
+	ConfigEntryFields []string
+}
+
+// Enabled returns whether any value is hidden.
+func (c RedactionConfig) Enabled() bool {
+	return len(c.KVPrefixes) > 0 || len(c.ConfigEntryFields) > 0
+}
+
+// Validate checks that the config entry fields exist and hold strings.
+func (c RedactionConfig) Validate() error {
+	for i, field := range c.ConfigEntryFields {
+		kind, path, err := splitRedactedField(field)
+		if err != nil {
+			return fmt.Errorf("config_entry_fields[%d]: %w", i, err)
+		}
+		entry, err := MakeConfigEntry(kind, "")
+		if err != nil {
+			return fmt.Errorf("config_entry_fields[%d]: %w", i, err)
+		}
+		if err := validateRedactedPath(reflect.TypeOf(entry), path); err != nil {
+			return fmt.Errorf("config_entry_fields[%d]: invalid field %q for %s: %w", i, field, kind, err)
+		}
+	}
+	return nil
+}
+
+// RedactDirEntries returns the entries with the values under the redacted
+// prefixes replaced. The entries are copied before being modified, since they
+// usually belong to the state store. It also returns whether any value was
+// hidden.
+func (c RedactionConfig) RedactDirEntries(ents DirEntries) (DirEntries, bool) {
+	if len(c.KVPrefixes) == 0 {
+		return ents, false
+	}
+	var redacted DirEntries
+	for i, ent := range ents {
+		if !c.redactsKey(ent) {
+			continue
+		}
+		if redacted == nil {
+			redacted = make(DirEntries, len(ents))
+			copy(redacted, ents)
+		}
+		clone := ent.Clone()
+		clone.Value = []byte(RedactedValue)
+		redacted[i] = clone
+	}
+	if redacted == nil {
+		return ents, false
+	}
+	return redacted, true
+}
+
+func (c RedactionConfig) redactsKey(ent *DirEntry) bool {
+	if ent == nil || len(ent.Value) == 0 {
+		return false
+	}
+	for _, prefix := range c.KVPrefixes {
+		if strings.HasPrefix(ent.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactConfigEntry returns the config entry with the redacted fields
+// replaced. The entry is copied before being modified, since it usually
+// belongs to the state store. It also returns whether any value was hidden.
+func (c RedactionConfig) RedactConfigEntry(entry ConfigEntry) (ConfigEntry, bool) {
+	if entry == nil {
+		return entry, false
+	}
+	v := reflect.ValueOf(entry)
+	redacted := false
+	for _, field := range c.ConfigEntryFields {
+		kind, path, err := splitRedactedField(field)
+		if err != nil || kind != entry.GetKind() {
+			continue
+		}
+		if rv, ok := redactValue(v, path); ok {
+			v = rv
+			redacted = true
+		}
+	}
+	if !redacted {
+		return entry, false
+	}
+	return v.Interface().(ConfigEntry), true
+}
+
+// RedactConfigEntries redacts each of the config entries, see
+// RedactConfigEntry.
+func (c RedactionConfig) RedactConfigEntries(entries []ConfigEntry) ([]ConfigEntry, bool) {
+	if len(c.ConfigEntryFields) == 0 {
+		return entries, false
+	}
+	var redacted []ConfigEntry
+	for i, entry := range entries {
+		r, ok := c.RedactConfigEntry(entry)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]ConfigEntry, len(entries))
+			copy(redacted, entries)
+		}
+		redacted[i] = r
+	}
+	if redacted == nil {
+		return entries, false
+	}
+	return redacted, true
+}
+
+func splitRedactedField(field string) (string, []string, error) {
+	kind, path, ok := strings.Cut(field, ".")
+	if !ok || kind == "" || path == "" {
+		return "", nil, fmt.Errorf("field %q must be of the form <kind>.<field>", field)
+	}
+	return kind, strings.Split(path, "."), nil
+}
+
+// redactedStructField returns the exported field of the struct type matching
+// the name, ignoring the case. Fields of embedded structs aren't matched.
+func redactedStructField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && !f.Anonymous && strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func validateRedactedPath(t reflect.Type, path []string) error {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice:
+			t = t.Elem()
+			continue
+		case reflect.Interface:
+			// The type of the value is only known at runtime, e.g. for the
+			// opaque proxy configuration.
+			return nil
+		}
+		if len(path) == 0 {
+			if t.Kind() != reflect.String {
+				return fmt.Errorf("field is a %s, only strings can be hidden", t.Kind())
+			}
+			return nil
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := redactedStructField(t, path[0])
+			if !ok {
+				return fmt.Errorf("unknown field %q", path[0])
+			}
+			t = f.Type
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return fmt.Errorf("map keys must be strings")
+			}
+			t = t.Elem()
+		default:
+			return fmt.Errorf("%q is a %s, which has no fields", path[0], t.Kind())
+		}
+		path = path[1:]
+	}
+}
+
+// redactValue returns a copy of v with the string at the given path replaced
+// with RedactedValue. Only the values along the path are copied. It returns
+// false if there is no value to hide at the path.
+func redactValue(v reflect.Value, path []string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, ok := redactValue(v.Elem(), path)
+		if !ok {
+			return v, false
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(elem)
+		return cp, true
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		return redactValue(v.Elem(), path)
+
+	case reflect.Slice:
+		var cp reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elem, ok := redactValue(v.Index(i), path)
+			if !ok {
+				continue
+			}
+			if !cp.IsValid() {
+				cp = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+				reflect.Copy(cp, v)
+			}
+			cp.Index(i).Set(elem)
+		}
+		if !cp.IsValid() {
+			return v, false
+		}
+		return cp, true
+	}
+
+	if len(path) == 0 {
+		if v.Kind() != reflect.String || v.Len() == 0 {
+			return v, false
+		}
+		return reflect.ValueOf(RedactedValue).Convert(v.Type()), true
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		f, ok := redactedStructField(v.Type(), path[0])
+		if !ok {
+			return v, false
+		}
+		field, ok := redactValue(v.Field(f.Index[0]), path[1:])
+		if !ok {
+			return v, false
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		cp.Field(f.Index[0]).Set(field)
+		return cp, true
+
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v, false
+		}
+		key := reflect.ValueOf(path[0]).Convert(v.Type().Key())
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return v, false
+		}
+		elem, ok := redactValue(elem, path[1:])
+		if !ok {
+			return v, false
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), iter.Value())
+		}
+		cp.SetMapIndex(key, elem)
+		return cp, true
+	}
+	return v, false
+}
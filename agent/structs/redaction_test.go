@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactionConfig_Validate(t *testing.T) {
+	cases := map[string]struct {
+		fields []string
+		err    string
+	}{
+		"valid": {
+			fields: []string{
+				"inline-certificate.PrivateKey",
+				"service-defaults.meta.password",
+				"proxy-defaults.Config.secret",
+				"service-defaults.UpstreamConfig.Overrides.Name",
+			},
+		},
+		"missing field": {
+			fields: []string{"inline-certificate"},
+			err:    "must be of the form <kind>.<field>",
+		},
+		"unknown kind": {
+			fields: []string{"magic.Field"},
+			err:    "invalid config entry kind",
+		},
+		"unknown field": {
+			fields: []string{"inline-certificate.Password"},
+			err:    `unknown field "Password"`,
+		},
+		"not a string": {
+			fields: []string{"service-defaults.MaxInboundConnections"},
+			err:    "only strings can be hidden",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := RedactionConfig{ConfigEntryFields: tc.fields}.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRedactionConfig_RedactDirEntries(t *testing.T) {
+	ents := DirEntries{
+		{Key: "public/a", Value: []byte("a")},
+		{Key: "secret/b", Value: []byte("b")},
+		{Key: "secret/empty"},
+	}
+	c := RedactionConfig{KVPrefixes: []string{"secret/"}}
+
+	redacted, ok := c.RedactDirEntries(ents)
+	require.True(t, ok)
+	require.Same(t, ents[0], redacted[0])
+	require.Equal(t, RedactedValue, string(redacted[1].Value))
+	require.Empty(t, redacted[2].Value)
+
+	// The original entries are left untouched.
+	require.Equal(t, "b", string(ents[1].Value))
+
+	redacted, ok = RedactionConfig{KVPrefixes: []string{"other/"}}.RedactDirEntries(ents)
+	require.False(t, ok)
+	require.Equal(t, ents, redacted)
+}
+
+func TestRedactionConfig_RedactConfigEntry(t *testing.T) {
+	entry := &ServiceConfigEntry{
+		Kind: ServiceDefaults,
+		Name: "web",
+		Meta: map[string]string{"password": "hunter2", "owner": "team"},
+		UpstreamConfig: &UpstreamConfiguration{
+			Overrides: []*UpstreamConfig{
+				{Name: "db", Protocol: "tcp"},
+				{Name: "cache"},
+			},
+		},
+	}
+	c := RedactionConfig{ConfigEntryFields: []string{
+		"service-defaults.Meta.password",
+		"service-defaults.upstreamconfig.overrides.protocol",
+		"inline-certificate.PrivateKey",
+	}}
+
+	out, ok := c.RedactConfigEntry(entry)
+	require.True(t, ok)
+	redacted := out.(*ServiceConfigEntry)
+	require.Equal(t, map[string]string{"password": RedactedValue, "owner": "team"}, redacted.Meta)
+	require.Equal(t, RedactedValue, redacted.UpstreamConfig.Overrides[0].Protocol)
+	require.Empty(t, redacted.UpstreamConfig.Overrides[1].Protocol)
+	require.Same(t, entry.UpstreamConfig.Overrides[1], redacted.UpstreamConfig.Overrides[1])
+
+	// The original entry is left untouched.
+	require.Equal(t, "hunter2", entry.Meta["password"])
+	require.Equal(t, "tcp", entry.UpstreamConfig.Overrides[0].Protocol)
+
+	proxy := &ProxyConfigEntry{
+		Kind:   ProxyDefaults,
+		Name:   ProxyConfigGlobal,
+		Config: map[string]interface{}{"secret": "hunter2", "protocol": "http"},
+	}
+	out, ok = RedactionConfig{ConfigEntryFields: []string{"proxy-defaults.Config.secret"}}.RedactConfigEntry(proxy)
+	require.True(t, ok)
+	require.Equal(t, RedactedValue, out.(*ProxyConfigEntry).Config["secret"])
+	require.Equal(t, "hunter2", proxy.Config["secret"])
+
+	out, ok = c.RedactConfigEntry(&ServiceConfigEntry{Kind: ServiceDefaults, Name: "api"})
+	require.False(t, ok)
+	require.Equal(t, "api", out.GetName())
+}
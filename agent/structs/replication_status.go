@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "time"
+
+// ReplicationStatus describes the replication of a kind of data into the
+// datacenter, from the primary datacenter or from a peer, as seen by the
+// leader of the datacenter.
+type ReplicationStatus struct {
+	// Kind is the kind of data replicated: "config-entries",
+	// "federation-states", "acl-policies", "acl-roles", "acl-tokens" or
+	// "peering".
+	Kind string
+
+	// SourceDatacenter is the datacenter the data is replicated from, it is
+	// empty for the replication from a peer.
+	SourceDatacenter string `json:",omitempty"`
+
+	// SourcePeer is the name of the peer the data is replicated from, it is
+	// empty for the replication from the primary datacenter.
+	SourcePeer      string `json:",omitempty"`
+	SourcePartition string `json:",omitempty"`
+
+	// Running is whether the replication is running, or for a peer, whether
+	// the peering stream is connected.
+	Running bool
+
+	// ReplicatedIndex is the index in the source datacenter the data was last
+	// replicated at. It is not tracked for peers.
+	ReplicatedIndex uint64 `json:",omitempty"`
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+
+	// LagSeconds is how long the data may have been behind the source. It is
+	// zero while the replication is healthy, and the time since the last
+	// success (or since the replication started) otherwise.
+	LagSeconds float64
+}
+
+// IndexedReplicationStatuses holds the status of the replication of each kind
+// of data into a datacenter.
+type IndexedReplicationStatuses struct {
+	Datacenter   string
+	Replications []ReplicationStatus
+	QueryMeta
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// The keys the results of the catalog and health list endpoints can be sorted
+// by. Prefixing a key with "-" sorts the results in descending order.
+const (
+	SortByNode        = "node"
+	SortByModifyIndex = "modify-index"
+	SortByCreateIndex = "create-index"
+	SortByStatus      = "status"
+)
+
+// ParseSort parses a sort specification as given in the sort query parameter,
+// returning the key to sort by and whether the order is descending.
+func ParseSort(spec string) (key string, desc bool, err error) {
+	key = strings.TrimPrefix(spec, "-")
+	desc = key != spec
+	switch key {
+	case SortByNode, SortByModifyIndex, SortByCreateIndex, SortByStatus:
+		return key, desc, nil
+	default:
+		return "", false, fmt.Errorf("invalid sort %q, must be one of %q, %q, %q or %q, optionally prefixed with \"-\"",
+			spec, SortByNode, SortByModifyIndex, SortByCreateIndex, SortByStatus)
+	}
+}
+
+// SortResults sorts the results of a catalog or health list endpoint according
+// to the sort specification. The sort is stable, so results with the same key
+// keep their previous order, for example by distance from the source node. It
+// is a no-op when spec is empty.
+func SortResults(spec string, subj interface{}) error {
+	if spec == "" {
+		return nil
+	}
+	key, desc, err := ParseSort(spec)
+	if err != nil {
+		return err
+	}
+
+	var less func(i, j int) bool
+	switch v := subj.(type) {
+	case Nodes:
+		switch key {
+		case SortByNode:
+			less = lessBy(func(i int) string { return v[i].Node })
+		case SortByModifyIndex:
+			less = lessBy(func(i int) uint64 { return v[i].ModifyIndex })
+		case SortByCreateIndex:
+			less = lessBy(func(i int) uint64 { return v[i].CreateIndex })
+		}
+	case ServiceNodes:
+		switch key {
+		case SortByNode:
+			less = lessBy(func(i int) string { return v[i].Node })
+		case SortByModifyIndex:
+			less = lessBy(func(i int) uint64 { return v[i].ModifyIndex })
+		case SortByCreateIndex:
+			less = lessBy(func(i int) uint64 { return v[i].CreateIndex })
+		}
+	case HealthChecks:
+		switch key {
+		case SortByNode:
+			less = lessBy(func(i int) string { return v[i].Node })
+		case SortByModifyIndex:
+			less = lessBy(func(i int) uint64 { return v[i].ModifyIndex })
+		case SortByCreateIndex:
+			less = lessBy(func(i int) uint64 { return v[i].CreateIndex })
+		case SortByStatus:
+			less = lessBy(func(i int) int { return healthStatusRank(v[i].Status) })
+		}
+	case CheckServiceNodes:
+		switch key {
+		case SortByNode:
+			less = lessBy(func(i int) string { return v[i].Node.Node })
+		case SortByModifyIndex:
+			less = lessBy(func(i int) uint64 { return checkServiceNodeModifyIndex(&v[i]) })
+		case SortByCreateIndex:
+			less = lessBy(func(i int) uint64 { return checkServiceNodeCreateIndex(&v[i]) })
+		case SortByStatus:
+			less = lessBy(func(i int) int { return healthStatusRank(v[i].AggregatedStatus()) })
+		}
+	default:
+		return fmt.Errorf("sorting is not supported for %T", subj)
+	}
+	if less == nil {
+		return fmt.Errorf("sorting by %q is only supported for health results", key)
+	}
+
+	sort.SliceStable(subj, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+	return nil
+}
+
+// lessBy returns a function comparing the results by the key returned by fn.
+func lessBy[T string | uint64 | int](fn func(i int) T) func(i, j int) bool {
+	return func(i, j int) bool {
+		return fn(i) < fn(j)
+	}
+}
+
+// healthStatusRank orders the health statuses from the healthiest to the least
+// healthy.
+func healthStatusRank(status string) int {
+	switch status {
+	case api.HealthPassing:
+		return 0
+	case api.HealthWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// checkServiceNodeModifyIndex returns the index the node, the service or any
+// of the checks of the instance was last modified at.
+func checkServiceNodeModifyIndex(csn *CheckServiceNode) uint64 {
+	var index uint64
+	if csn.Node != nil {
+		index = csn.Node.ModifyIndex
+	}
+	if csn.Service != nil && csn.Service.ModifyIndex > index {
+		index = csn.Service.ModifyIndex
+	}
+	for _, check := range csn.Checks {
+		if check.ModifyIndex > index {
+			index = check.ModifyIndex
+		}
+	}
+	return index
+}
+
+// checkServiceNodeCreateIndex returns the index the service instance was
+// registered at.
+func checkServiceNodeCreateIndex(csn *CheckServiceNode) uint64 {
+	if csn.Service == nil {
+		return 0
+	}
+	return csn.Service.CreateIndex
+}
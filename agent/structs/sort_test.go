@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestParseSort(t *testing.T) {
+	key, desc, err := ParseSort("node")
+	require.NoError(t, err)
+	require.Equal(t, SortByNode, key)
+	require.False(t, desc)
+
+	key, desc, err = ParseSort("-modify-index")
+	require.NoError(t, err)
+	require.Equal(t, SortByModifyIndex, key)
+	require.True(t, desc)
+
+	_, _, err = ParseSort("address")
+	require.Error(t, err)
+	_, _, err = ParseSort("-")
+	require.Error(t, err)
+}
+
+func TestSortResults(t *testing.T) {
+	t.Run("nodes", func(t *testing.T) {
+		nodes := Nodes{
+			{Node: "b", RaftIndex: RaftIndex{CreateIndex: 1, ModifyIndex: 5}},
+			{Node: "c", RaftIndex: RaftIndex{CreateIndex: 3, ModifyIndex: 3}},
+			{Node: "a", RaftIndex: RaftIndex{CreateIndex: 2, ModifyIndex: 4}},
+		}
+		names := func() []string {
+			var out []string
+			for _, n := range nodes {
+				out = append(out, n.Node)
+			}
+			return out
+		}
+
+		require.NoError(t, SortResults("", nodes))
+		require.Equal(t, []string{"b", "c", "a"}, names())
+
+		require.NoError(t, SortResults(SortByNode, nodes))
+		require.Equal(t, []string{"a", "b", "c"}, names())
+
+		require.NoError(t, SortResults("-"+SortByModifyIndex, nodes))
+		require.Equal(t, []string{"b", "a", "c"}, names())
+
+		require.NoError(t, SortResults(SortByCreateIndex, nodes))
+		require.Equal(t, []string{"b", "a", "c"}, names())
+
+		require.Error(t, SortResults(SortByStatus, nodes))
+	})
+
+	t.Run("health checks", func(t *testing.T) {
+		checks := HealthChecks{
+			{Node: "a", CheckID: "1", Status: api.HealthCritical},
+			{Node: "b", CheckID: "2", Status: api.HealthPassing},
+			{Node: "c", CheckID: "3", Status: api.HealthCritical},
+			{Node: "d", CheckID: "4", Status: api.HealthWarning},
+		}
+		require.NoError(t, SortResults("-"+SortByStatus, checks))
+
+		var ids []string
+		for _, c := range checks {
+			ids = append(ids, string(c.CheckID))
+		}
+		// The sort is stable in both orders.
+		require.Equal(t, []string{"1", "3", "4", "2"}, ids)
+	})
+
+	t.Run("check service nodes", func(t *testing.T) {
+		nodes := CheckServiceNodes{
+			{
+				Node:    &Node{Node: "a", RaftIndex: RaftIndex{ModifyIndex: 1}},
+				Service: &NodeService{ID: "web1", RaftIndex: RaftIndex{CreateIndex: 2, ModifyIndex: 2}},
+				Checks:  HealthChecks{{Status: api.HealthPassing, RaftIndex: RaftIndex{ModifyIndex: 9}}},
+			},
+			{
+				Node:    &Node{Node: "b", RaftIndex: RaftIndex{ModifyIndex: 7}},
+				Service: &NodeService{ID: "web2", RaftIndex: RaftIndex{CreateIndex: 1, ModifyIndex: 3}},
+				Checks:  HealthChecks{{Status: api.HealthWarning, RaftIndex: RaftIndex{ModifyIndex: 4}}},
+			},
+			{
+				Node:    &Node{Node: "c", RaftIndex: RaftIndex{ModifyIndex: 8}},
+				Service: &NodeService{ID: "web3", RaftIndex: RaftIndex{CreateIndex: 3, ModifyIndex: 8}},
+				Checks:  HealthChecks{{Status: api.HealthCritical, RaftIndex: RaftIndex{ModifyIndex: 5}}},
+			},
+		}
+		ids := func() []string {
+			var out []string
+			for _, n := range nodes {
+				out = append(out, n.Service.ID)
+			}
+			return out
+		}
+
+		// The instance was modified when the node, the service or a check was.
+		require.NoError(t, SortResults("-"+SortByModifyIndex, nodes))
+		require.Equal(t, []string{"web1", "web3", "web2"}, ids())
+
+		require.NoError(t, SortResults(SortByCreateIndex, nodes))
+		require.Equal(t, []string{"web2", "web1", "web3"}, ids())
+
+		require.NoError(t, SortResults("-"+SortByStatus, nodes))
+		require.Equal(t, []string{"web3", "web2", "web1"}, ids())
+
+		require.NoError(t, SortResults(SortByNode, nodes))
+		require.Equal(t, []string{"web1", "web2", "web3"}, ids())
+	})
+}
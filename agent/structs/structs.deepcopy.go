@@ -98,6 +98,12 @@ func (o *CheckType) DeepCopy() *CheckType {
 			cp.Header[k2] = cp_Header_v2
 		}
 	}
+	if o.PluginConfig != nil {
+		cp.PluginConfig = make(map[string]string, len(o.PluginConfig))
+		for k2, v2 := range o.PluginConfig {
+			cp.PluginConfig[k2] = v2
+		}
+	}
 	return &cp
 }
 
@@ -159,6 +165,52 @@ func (o *CompiledDiscoveryChain) DeepCopy() *CompiledDiscoveryChain {
 		cp.ManualVirtualIPs = make([]string, len(o.ManualVirtualIPs))
 		copy(cp.ManualVirtualIPs, o.ManualVirtualIPs)
 	}
+	if o.Explanation != nil {
+		cp.Explanation = new(DiscoveryChainExplanation)
+		*cp.Explanation = *o.Explanation
+		if o.Explanation.Chain != nil {
+			cp.Explanation.Chain = make([]string, len(o.Explanation.Chain))
+			copy(cp.Explanation.Chain, o.Explanation.Chain)
+		}
+		if o.Explanation.Nodes != nil {
+			cp.Explanation.Nodes = make(map[string]*DiscoveryExplanation, len(o.Explanation.Nodes))
+			for k4, v4 := range o.Explanation.Nodes {
+				var cp_Explanation_Nodes_v4 *DiscoveryExplanation
+				if v4 != nil {
+					cp_Explanation_Nodes_v4 = new(DiscoveryExplanation)
+					*cp_Explanation_Nodes_v4 = *v4
+					if v4.ConfigEntry != nil {
+						cp_Explanation_Nodes_v4.ConfigEntry = new(DiscoveryExplanationConfigEntry)
+						*cp_Explanation_Nodes_v4.ConfigEntry = *v4.ConfigEntry
+					}
+					if v4.Reasons != nil {
+						cp_Explanation_Nodes_v4.Reasons = make([]string, len(v4.Reasons))
+						copy(cp_Explanation_Nodes_v4.Reasons, v4.Reasons)
+					}
+				}
+				cp.Explanation.Nodes[k4] = cp_Explanation_Nodes_v4
+			}
+		}
+		if o.Explanation.Targets != nil {
+			cp.Explanation.Targets = make(map[string]*DiscoveryExplanation, len(o.Explanation.Targets))
+			for k4, v4 := range o.Explanation.Targets {
+				var cp_Explanation_Targets_v4 *DiscoveryExplanation
+				if v4 != nil {
+					cp_Explanation_Targets_v4 = new(DiscoveryExplanation)
+					*cp_Explanation_Targets_v4 = *v4
+					if v4.ConfigEntry != nil {
+						cp_Explanation_Targets_v4.ConfigEntry = new(DiscoveryExplanationConfigEntry)
+						*cp_Explanation_Targets_v4.ConfigEntry = *v4.ConfigEntry
+					}
+					if v4.Reasons != nil {
+						cp_Explanation_Targets_v4.Reasons = make([]string, len(v4.Reasons))
+						copy(cp_Explanation_Targets_v4.Reasons, v4.Reasons)
+					}
+				}
+				cp.Explanation.Targets[k4] = cp_Explanation_Targets_v4
+			}
+		}
+	}
 	return &cp
 }
 
@@ -219,6 +271,10 @@ func (o *DiscoveryFailover) DeepCopy() *DiscoveryFailover {
 		cp.Regions = make([]string, len(o.Regions))
 		copy(cp.Regions, o.Regions)
 	}
+	if o.TargetHealth != nil {
+		cp.TargetHealth = make([]DiscoveryFailoverTargetHealth, len(o.TargetHealth))
+		copy(cp.TargetHealth, o.TargetHealth)
+	}
 	return &cp
 }
 
@@ -303,9 +359,23 @@ func (o *ExportedServicesConfigEntry) DeepCopy() *ExportedServicesConfigEntry {
 		cp.Services = make([]ExportedService, len(o.Services))
 		copy(cp.Services, o.Services)
 		for i2 := range o.Services {
+			if o.Services[i2].Selector != nil {
+				cp.Services[i2].Selector = make(map[string]string, len(o.Services[i2].Selector))
+				for k4, v4 := range o.Services[i2].Selector {
+					cp.Services[i2].Selector[k4] = v4
+				}
+			}
 			if o.Services[i2].Consumers != nil {
 				cp.Services[i2].Consumers = make([]ServiceConsumer, len(o.Services[i2].Consumers))
 				copy(cp.Services[i2].Consumers, o.Services[i2].Consumers)
+				for i4 := range o.Services[i2].Consumers {
+					if o.Services[i2].Consumers[i4].Selector != nil {
+						cp.Services[i2].Consumers[i4].Selector = make(map[string]string, len(o.Services[i2].Consumers[i4].Selector))
+						for k6, v6 := range o.Services[i2].Consumers[i4].Selector {
+							cp.Services[i2].Consumers[i4].Selector[k6] = v6
+						}
+					}
+				}
 			}
 		}
 	}
@@ -337,6 +407,14 @@ func (o *GatewayService) DeepCopy() *GatewayService {
 		cp.Hosts = make([]string, len(o.Hosts))
 		copy(cp.Hosts, o.Hosts)
 	}
+	if o.VaultPKI != nil {
+		cp.VaultPKI = new(LinkedServiceVaultPKI)
+		*cp.VaultPKI = *o.VaultPKI
+	}
+	if o.CipherSuites != nil {
+		cp.CipherSuites = make([]types.TLSCipherSuite, len(o.CipherSuites))
+		copy(cp.CipherSuites, o.CipherSuites)
+	}
 	return &cp
 }
 
@@ -603,6 +681,10 @@ func (o *IndexedCARoots) DeepCopy() *IndexedCARoots {
 			}
 		}
 	}
+	if o.RevokedSerialNumbers != nil {
+		cp.RevokedSerialNumbers = make([]string, len(o.RevokedSerialNumbers))
+		copy(cp.RevokedSerialNumbers, o.RevokedSerialNumbers)
+	}
 	return &cp
 }
 
@@ -723,6 +805,14 @@ func (o *Intention) DeepCopy() *Intention {
 			cp.Meta[k2] = v2
 		}
 	}
+	if o.ReviewBy != nil {
+		cp.ReviewBy = new(time.Time)
+		*cp.ReviewBy = *o.ReviewBy
+	}
+	if o.ExpiresAt != nil {
+		cp.ExpiresAt = new(time.Time)
+		*cp.ExpiresAt = *o.ExpiresAt
+	}
 	if o.Hash != nil {
 		cp.Hash = make([]byte, len(o.Hash))
 		copy(cp.Hash, o.Hash)
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net"
 	"os"
 	"reflect"
 	"regexp"
@@ -91,6 +92,9 @@ const (
 	RaftLogVerifierCheckpoint                   = 41 // Only used for log verifier, no-op on FSM.
 	ResourceOperationType                       = 42
 	UpdateVirtualIPRequestType                  = 43
+	ConnectCARevocationType                     = 44 // FSM snapshots only.
+	RegisterBatchRequestType                    = 45
+	ConnectCARevocationListsType                = 46 // FSM snapshots only.
 )
 
 const (
@@ -160,6 +164,9 @@ var requestTypeStrings = map[MessageType]string{
 	RaftLogVerifierCheckpoint:       "RaftLogVerifierCheckpoint",
 	ResourceOperationType:           "Resource",
 	UpdateVirtualIPRequestType:      "UpdateManualVirtualIPRequestType",
+	ConnectCARevocationType:         "ConnectCARevocation", // FSM snapshots only.
+	RegisterBatchRequestType:        "RegisterBatch",
+	ConnectCARevocationListsType:    "ConnectCARevocationLists", // FSM snapshots only.
 }
 
 const (
@@ -198,6 +205,21 @@ const (
 	// MetaExternalSource is the metadata key used when a resource is managed by a source outside Consul like nomad/k8s
 	MetaExternalSource = "external-source"
 
+	// MetaCatalogProvider is the node metadata key holding the name of the
+	// catalog provider whose instances are registered on the node. These
+	// nodes are managed by the leader and can't be written to.
+	MetaCatalogProvider = "consul-catalog-provider"
+
+	// MetaACMEManaged is the inline-certificate config entry metadata key
+	// marking the certificates obtained and renewed by the servers' ACME
+	// client.
+	MetaACMEManaged = "consul-acme-managed"
+
+	// MetaACMEChallengePrefix prefixes the bound-api-gateway config entry
+	// metadata keys holding the responses to the pending ACME HTTP-01
+	// challenges, by token.
+	MetaACMEChallengePrefix = "consul-acme-challenge-"
+
 	// TaggedAddressVirtualIP is the key used to store tagged virtual IPs generated by Consul.
 	TaggedAddressVirtualIP = "consul-virtual"
 
@@ -245,6 +267,13 @@ const (
 	TaggedAddressLANIPv6 = "lan_ipv6"
 )
 
+const (
+	// AddressFamilyIPv4 and AddressFamilyIPv6 are the values of the settings
+	// choosing which address of a dual-stack node or service comes first.
+	AddressFamilyIPv4 = "ipv4"
+	AddressFamilyIPv6 = "ipv6"
+)
+
 // metaKeyFormat checks if a metadata key string is valid
 var metaKeyFormat = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`).MatchString
 
@@ -328,6 +357,13 @@ type QueryOptions struct {
 	// QueryMeta.Index, the response can be left empty and QueryMeta.NotModified
 	// will be set to true to indicate the result of the query has not changed.
 	AllowNotModifiedResponse bool `mapstructure:"allow-not-modified-response,omitempty"`
+
+	// If set and AllowStale is true, a follower only serves the request if its
+	// data is at most this old: it was in contact with the leader within the
+	// duration, and has applied the entries committed as of that contact.
+	// Otherwise the request is forwarded to the leader. Unlike
+	// MaxStaleDuration, the bound is enforced by the server.
+	BoundedStaleness time.Duration `mapstructure:"bounded-staleness,omitempty"`
 }
 
 // IsRead is always true for QueryOption.
@@ -339,6 +375,8 @@ func (q QueryOptions) IsRead() bool {
 func (q QueryOptions) ConsistencyLevel() string {
 	if q.RequireConsistent {
 		return "consistent"
+	} else if q.AllowStale && q.BoundedStaleness > 0 {
+		return "bounded-stale"
 	} else if q.AllowStale {
 		return "stale"
 	} else {
@@ -350,6 +388,15 @@ func (q QueryOptions) AllowStaleRead() bool {
 	return q.AllowStale
 }
 
+// MaxStaleness returns the bound on the staleness of the data a follower may
+// serve the request from, or zero if unbounded.
+func (q QueryOptions) MaxStaleness() time.Duration {
+	if !q.AllowStale {
+		return 0
+	}
+	return q.BoundedStaleness
+}
+
 func (q QueryOptions) TokenSecret() string {
 	return q.Token
 }
@@ -499,6 +546,11 @@ type RegisterRequest struct {
 
 	PeerName string
 
+	// NodeAttestation is the encoded attestation evidence of the machine the
+	// agent of the node runs on. It is verified by the servers when they
+	// enforce node attestation, and never stored.
+	NodeAttestation string `json:",omitempty" bexpr:"-"`
+
 	// EnterpriseMeta is the embedded enterprise metadata
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 
@@ -510,6 +562,13 @@ func (r *RegisterRequest) RequestDatacenter() string {
 	return r.Datacenter
 }
 
+// RegisterBatchRequest is used by the leader to apply many register requests
+// with a single Raft log entry. Each request is applied independently, so the
+// failure of one does not prevent the others from being applied.
+type RegisterBatchRequest struct {
+	Requests []*RegisterRequest
+}
+
 // ChangesNode returns true if the given register request changes the given
 // node, which can be nil. This only looks for changes to the node record itself,
 // not any of the health checks.
@@ -616,9 +675,13 @@ func (r *DatacentersRequest) CacheInfo() cache.RequestInfo {
 
 // DCSpecificRequest is used to query about a specific DC
 type DCSpecificRequest struct {
-	Datacenter         string
-	NodeMetaFilters    map[string]string
-	Source             QuerySource
+	Datacenter      string
+	NodeMetaFilters map[string]string
+	Source          QuerySource
+
+	// Sort is the key the results are sorted by, see ParseSort.
+	Sort string
+
 	PeerName           string
 	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
 	QueryOptions
@@ -646,6 +709,7 @@ func (r *DCSpecificRequest) CacheInfo() cache.RequestInfo {
 		r.NodeMetaFilters,
 		r.Filter,
 		r.EnterpriseMeta,
+		r.Sort,
 	}, nil)
 	if err == nil {
 		// If there is an error, we don't set the key. A blank key forces
@@ -768,6 +832,9 @@ type ServiceSpecificRequest struct {
 	HealthFilterType HealthFilterType
 	Source           QuerySource
 
+	// Sort is the key the results are sorted by, see ParseSort.
+	Sort string
+
 	// Connect if true will only search for Connect-compatible services.
 	Connect bool
 
@@ -788,6 +855,29 @@ func (r *ServiceSpecificRequest) RequestDatacenter() string {
 	return r.Datacenter
 }
 
+// ServicesSpecificRequest is used to query the instances of several services
+// with a single blocking query.
+type ServicesSpecificRequest struct {
+	Datacenter string
+
+	// The name of the peer that the requested services were imported from.
+	PeerName string
+
+	// ServiceNames lists the services to query. When empty, the instances of
+	// all the services are queried, usually narrowed down by a filter.
+	ServiceNames     []string
+	NodeMetaFilters  map[string]string
+	HealthFilterType HealthFilterType
+	Source           QuerySource
+
+	acl.EnterpriseMeta `hcl:",squash" mapstructure:",squash"`
+	QueryOptions
+}
+
+func (r *ServicesSpecificRequest) RequestDatacenter() string {
+	return r.Datacenter
+}
+
 func (r *ServiceSpecificRequest) CacheInfo() cache.RequestInfo {
 	info := cache.RequestInfo{
 		Token:          r.Token,
@@ -829,6 +919,7 @@ func (r *ServiceSpecificRequest) CacheInfo() cache.RequestInfo {
 		r.ServiceKind,
 		r.MergeCentralConfig,
 		r.HealthFilterType,
+		r.Sort,
 	}, nil)
 	if err == nil {
 		// If there is an error, we don't set the key. A blank key forces
@@ -896,6 +987,9 @@ type ChecksInStateRequest struct {
 	State           string
 	Source          QuerySource
 
+	// Sort is the key the results are sorted by, see ParseSort.
+	Sort string
+
 	PeerName           string
 	acl.EnterpriseMeta `mapstructure:",squash"`
 	QueryOptions
@@ -1055,6 +1149,14 @@ func SatisfiesMetaFilters(meta map[string]string, filters map[string]string) boo
 	return true
 }
 
+// MetaIndexConfig limits the node and service metadata indexes of the
+// catalog to the declared keys. A nil list indexes every key, while queries
+// on keys that are not indexed scan the catalog instead.
+type MetaIndexConfig struct {
+	NodeKeys    []string
+	ServiceKeys []string
+}
+
 // Used to return information about a provided services.
 // Maps service name to available tags
 type Services map[string][]string
@@ -2099,6 +2201,50 @@ func (csn *CheckServiceNode) BestAddress(wan bool) (uint64, string, int) {
 	return idx, addr, port
 }
 
+// DualStackAddresses returns the IPv4 and IPv6 addresses of the instance, taken
+// from the tagged addresses of the service, or of the node when the service
+// has no address of its own. Either is empty when the instance has no address
+// of that family. The ports default to the port of the best address.
+func (csn *CheckServiceNode) DualStackAddresses(wan bool) (v4, v6 ServiceAddress) {
+	addr, port := csn.Service.BestAddress(wan)
+	var tagged map[string]ServiceAddress
+	if addr != "" {
+		tagged = csn.Service.TaggedAddresses
+	} else if csn.Node != nil {
+		tagged = make(map[string]ServiceAddress, len(csn.Node.TaggedAddresses))
+		for k, a := range csn.Node.TaggedAddresses {
+			tagged[k] = ServiceAddress{Address: a}
+		}
+	}
+
+	v4, v6 = tagged[TaggedAddressLANIPv4], tagged[TaggedAddressLANIPv6]
+
+	// The WAN addresses of the families default to the LAN ones, so they are
+	// only used when they differ and the instance has a WAN address.
+	if _, ok := tagged[TaggedAddressWAN]; wan && ok {
+		wan4, wan6 := tagged[TaggedAddressWANIPv4], tagged[TaggedAddressWANIPv6]
+		if wan4.Address == v4.Address {
+			wan4 = ServiceAddress{}
+		}
+		if wan6.Address == v6.Address {
+			wan6 = ServiceAddress{}
+		}
+		v4, v6 = wan4, wan6
+	}
+
+	if ip := net.ParseIP(v4.Address); ip == nil || ip.To4() == nil {
+		v4 = ServiceAddress{}
+	} else if v4.Port == 0 {
+		v4.Port = port
+	}
+	if ip := net.ParseIP(v6.Address); ip == nil || ip.To4() != nil {
+		v6 = ServiceAddress{}
+	} else if v6.Port == 0 {
+		v6.Port = port
+	}
+	return v4, v6
+}
+
 func (csn *CheckServiceNode) CanRead(authz acl.Authorizer) acl.EnforcementDecision {
 	if csn.Node == nil || csn.Service == nil {
 		return acl.Deny
@@ -2142,6 +2288,21 @@ func (csn *CheckServiceNode) ExcludeBasedOnChecks(opts CheckServiceNodeFilterOpt
 	return false
 }
 
+// AggregatedStatus returns the worst status of the node and service checks of
+// the instance, or passing if it has no checks.
+func (csn *CheckServiceNode) AggregatedStatus() string {
+	status := api.HealthPassing
+	for _, check := range csn.Checks {
+		switch check.Status {
+		case api.HealthCritical:
+			return api.HealthCritical
+		case api.HealthWarning:
+			status = api.HealthWarning
+		}
+	}
+	return status
+}
+
 type CheckServiceNodes []CheckServiceNode
 
 func (csns CheckServiceNodes) DeepCopy() CheckServiceNodes {
@@ -2342,6 +2503,30 @@ type IndexedNodes struct {
 	QueryMeta
 }
 
+// ServiceHealthSummary holds the number of instances of a service in each
+// health state.
+type ServiceHealthSummary struct {
+	Name     string
+	Kind     ServiceKind `json:",omitempty"`
+	Passing  int
+	Warning  int
+	Critical int
+
+	acl.EnterpriseMeta
+}
+
+type IndexedServiceHealthSummaries struct {
+	Summaries []*ServiceHealthSummary
+	QueryMeta
+}
+
+// IndexedServicesCheckServiceNodes holds the instances of several services,
+// keyed by service name.
+type IndexedServicesCheckServiceNodes struct {
+	Services map[string]CheckServiceNodes
+	QueryMeta
+}
+
 type IndexedServices struct {
 	Services Services
 	// In various situations we need to know the meta that the services are for - in particular
@@ -2363,6 +2548,10 @@ type ServiceUsage struct {
 	ConnectServiceInstances  map[string]int
 	BillableServiceInstances int
 	Nodes                    int
+	KVEntries                int
+	ConfigEntries            map[string]int
+	Peerings                 int
+	ACLTokens                int
 	EnterpriseServiceUsage
 }
 
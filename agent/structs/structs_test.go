@@ -2952,6 +2952,17 @@ func TestGatewayService_IsSame(t *testing.T) {
 	check(func() { other.KeyFile = "/certs/cert.pem" }, func() { other.KeyFile = key })
 	check(func() { other.SNI = "alt-domain" }, func() { other.SNI = sni })
 	check(func() { other.FromWildcard = true }, func() { other.FromWildcard = wildcard })
+	check(func() { other.AutoHostRewrite = true }, func() { other.AutoHostRewrite = false })
+	check(func() { other.CAPEM = "-----BEGIN CERTIFICATE-----" }, func() { other.CAPEM = "" })
+	check(func() { other.TLSMinVersion = types.TLSv1_2 }, func() { other.TLSMinVersion = "" })
+	check(func() { other.TLSMaxVersion = types.TLSv1_3 }, func() { other.TLSMaxVersion = "" })
+	check(func() {
+		other.CipherSuites = []types.TLSCipherSuite{types.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+	}, func() { other.CipherSuites = nil })
+	check(func() {
+		other.VaultPKI = &LinkedServiceVaultPKI{Path: "pki/issue/gateway", CommonName: "gateway"}
+	}, func() { other.VaultPKI = nil })
+	check(func() { other.HostRewrite = "mydomain" }, func() { other.HostRewrite = "" })
 
 	if !g.IsSame(other) {
 		t.Fatalf("should be equal, was %#v VS %#v", g, other)
@@ -33,6 +33,9 @@ const (
 	SystemMetadataIntentionFormatLegacyValue   = "legacy"
 	SystemMetadataVirtualIPsEnabled            = "virtual-ips"
 	SystemMetadataTermGatewayVirtualIPsEnabled = "virtual-ips-term-gateway"
+	SystemMetadataKVEncryptionKeyring          = "kv-encryption-keyring"
+	SystemMetadataACLBreakGlassSeal            = "acl-break-glass-seal"
+	SystemMetadataRaftArchiveCheckpoint        = "raft-archive-checkpoint"
 )
 
 type SystemMetadataEntry struct {
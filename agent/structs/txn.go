@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/consul-net-rpc/go-msgpack/codec"
 	"github.com/hashicorp/consul/api"
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -63,6 +64,55 @@ type TxnSessionOp struct {
 	Session Session
 }
 
+// TxnConfigEntryOp is used to define a single operation on a config entry
+// inside a transaction. Index is compared with the ModifyIndex of the
+// existing entry by the CAS verbs.
+type TxnConfigEntryOp struct {
+	Verb  api.ConfigEntryOp
+	Entry ConfigEntry
+	Index uint64
+}
+
+// MarshalBinary encodes the kind of the config entry ahead of the operation,
+// so that UnmarshalBinary can decode the entry into the right type.
+func (o *TxnConfigEntryOp) MarshalBinary() (data []byte, err error) {
+	bs := make([]byte, 128)
+	enc := codec.NewEncoderBytes(&bs, MsgpackHandle)
+	var kind string
+	if o.Entry != nil {
+		kind = o.Entry.GetKind()
+	}
+	if err := enc.Encode(kind); err != nil {
+		return nil, err
+	}
+	// Alias juggling to prevent infinite recursive calls back to this encode
+	// method.
+	type Alias TxnConfigEntryOp
+	if err := enc.Encode(struct{ *Alias }{Alias: (*Alias)(o)}); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+func (o *TxnConfigEntryOp) UnmarshalBinary(data []byte) error {
+	var kind string
+	dec := codec.NewDecoderBytes(data, MsgpackHandle)
+	if err := dec.Decode(&kind); err != nil {
+		return err
+	}
+
+	if kind != "" {
+		entry, err := MakeConfigEntry(kind, "")
+		if err != nil {
+			return err
+		}
+		o.Entry = entry
+	}
+
+	type Alias TxnConfigEntryOp
+	return dec.Decode(&struct{ *Alias }{Alias: (*Alias)(o)})
+}
+
 // TxnIntentionOp is used to define a single operation on an Intention inside a
 // transaction.
 //
@@ -78,6 +128,10 @@ type TxnOp struct {
 	Check   *TxnCheckOp
 	Session *TxnSessionOp
 
+	// ConfigEntry operations are only accepted by the primary datacenter,
+	// from which config entries are replicated.
+	ConfigEntry *TxnConfigEntryOp
+
 	// Intention was an internal-only (not exposed in API or RPC)
 	// implementation detail of legacy intention replication. This is
 	// deprecated but retained for backwards compatibility with versions
@@ -101,6 +155,12 @@ type TxnOps []*TxnOp
 type TxnRequest struct {
 	Datacenter string
 	Ops        TxnOps
+
+	// NodeAttestation is the encoded attestation evidence of the node whose
+	// catalog entries are written by the transaction. It is verified by the
+	// servers when they enforce node attestation, and never stored.
+	NodeAttestation string `json:",omitempty"`
+
 	WriteRequest
 }
 
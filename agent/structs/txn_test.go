@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestTxnRequest_ConfigEntryEncoding(t *testing.T) {
+	req := TxnRequest{
+		Datacenter: "dc1",
+		Ops: TxnOps{
+			{
+				KV: &TxnKVOp{
+					Verb:   api.KVSet,
+					DirEnt: DirEntry{Key: "foo", Value: []byte("bar")},
+				},
+			},
+			{
+				ConfigEntry: &TxnConfigEntryOp{
+					Verb: api.ConfigEntryCAS,
+					Entry: &ServiceIntentionsConfigEntry{
+						Kind: ServiceIntentions,
+						Name: "web",
+						Sources: []*SourceIntention{
+							{Name: "api", Action: IntentionActionAllow},
+						},
+					},
+					Index: 5,
+				},
+			},
+			{
+				ConfigEntry: &TxnConfigEntryOp{
+					Verb:  api.ConfigEntryDelete,
+					Entry: &ServiceConfigEntry{Kind: ServiceDefaults, Name: "db"},
+				},
+			},
+			{
+				// Invalid operations are rejected by the server, after decoding.
+				ConfigEntry: &TxnConfigEntryOp{Verb: api.ConfigEntryDelete},
+			},
+		},
+	}
+
+	buf, err := Encode(TxnRequestType, &req)
+	require.NoError(t, err)
+
+	var out TxnRequest
+	require.NoError(t, Decode(buf[1:], &out))
+	require.Equal(t, req, out)
+}
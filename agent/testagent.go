@@ -30,6 +30,7 @@ import (
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
@@ -200,12 +201,16 @@ func (a *TestAgent) Start(t testutil.TestingTB) error {
 		a.LogLevel = testutil.TestLogLevel
 	}
 
-	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+	logOpts := &hclog.LoggerOptions{
 		Level:      a.LogLevel,
 		Output:     logOutput,
 		TimeFormat: "04:05.000",
 		Name:       name,
-	})
+	}
+	logSubsystems := logging.NewSubsystems()
+	logSubsystems.Configure(logOpts)
+	logger := hclog.NewInterceptLogger(logOpts)
+	logSubsystems.Attach(logger)
 
 	portsConfig := randomPortsSource(t, a.UseHTTPS)
 
@@ -245,6 +250,7 @@ func (a *TestAgent) Start(t testutil.TestingTB) error {
 	}
 
 	bd.Logger = logger
+	bd.LogSubsystems = logSubsystems
 	// if we are not testing telemetry things, let's use a "mock" sink for metrics
 	if bd.RuntimeConfig.Telemetry.Disable {
 		bd.MetricsConfig = &lib.MetricsConfig{
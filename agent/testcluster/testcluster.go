@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package testcluster starts clusters of Consul agents inside the test
+// process, for the integration tests of Go programs built on Consul. It is
+// faster and more reliable than running consul binaries with
+// sdk/testutil.TestServer, and allows clusters of several servers.
+//
+// It lives in the main module rather than in the sdk module since it runs the
+// agents of this module in process, which the sdk module cannot depend on.
+package testcluster
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/tlsutil"
+)
+
+// Config is the configuration of a cluster.
+type Config struct {
+	// Datacenter is the name of the datacenter of the cluster. Defaults to
+	// dc1.
+	Datacenter string
+
+	// Servers is the number of servers of the cluster. Defaults to 3.
+	Servers int
+
+	// Clients are the client agents joined to the cluster.
+	Clients []ClientConfig
+
+	// ACLs enables ACLs with a deny default policy. Requests can be made with
+	// the ManagementToken of the cluster.
+	ACLs bool
+
+	// TLS secures the RPC between the agents with certificates signed by a CA
+	// generated for the cluster, and serves the HTTP API over HTTPS only.
+	TLS bool
+
+	// HCL is additional configuration given to all the agents.
+	HCL string
+}
+
+// ClientConfig is the configuration of a client agent of a cluster.
+type ClientConfig struct {
+	// Partition is the admin partition the client joins. Admin partitions are
+	// only supported by Consul Enterprise.
+	Partition string
+
+	// HCL is additional configuration given to the client.
+	HCL string
+}
+
+// Cluster is a running cluster of agents. The agents are shut down when the
+// test ends.
+type Cluster struct {
+	Servers []*agent.TestAgent
+	Clients []*agent.TestAgent
+
+	// ManagementToken is the secret ID of the initial management token, if
+	// ACLs are enabled.
+	ManagementToken string
+
+	// CACert is the PEM encoded certificate of the CA of the cluster, if TLS
+	// is enabled.
+	CACert string
+
+	config Config
+}
+
+// New starts a cluster and waits for it to elect a leader. It fails the test
+// if the cluster cannot be started.
+func New(t *testing.T, config Config) *Cluster {
+	t.Helper()
+
+	if config.Datacenter == "" {
+		config.Datacenter = "dc1"
+	}
+	if config.Servers <= 0 {
+		config.Servers = 3
+	}
+	require.NoError(t, validatePartitions(config.Clients))
+
+	c := &Cluster{config: config}
+	var tlsHCL string
+	if config.TLS {
+		tlsHCL = c.generateTLS(t)
+	}
+	if config.ACLs {
+		token, err := uuid.GenerateUUID()
+		require.NoError(t, err)
+		c.ManagementToken = token
+	}
+
+	for i := 0; i < config.Servers; i++ {
+		hcl := fmt.Sprintf(`
+			bootstrap = false
+			bootstrap_expect = %d
+		`, config.Servers)
+		c.Servers = append(c.Servers, c.start(t, fmt.Sprintf("server-%d", i), hcl, tlsHCL, config.HCL))
+	}
+	for i, client := range config.Clients {
+		hcl := `
+			bootstrap = false
+			server = false
+		`
+		if client.Partition != "" {
+			hcl += fmt.Sprintf("\npartition = %q", client.Partition)
+		}
+		c.Clients = append(c.Clients, c.start(t, fmt.Sprintf("client-%d", i), hcl, tlsHCL, config.HCL, client.HCL))
+	}
+
+	c.WaitForLeader(t)
+	return c
+}
+
+// start starts an agent with the common configuration of the cluster and the
+// given fragments, joining the first server if it is not the first server.
+func (c *Cluster) start(t *testing.T, name string, hcl ...string) *agent.TestAgent {
+	t.Helper()
+
+	hcl = append([]string{fmt.Sprintf(`datacenter = %q`, c.config.Datacenter)}, hcl...)
+	if len(c.Servers) > 0 {
+		hcl = append(hcl, fmt.Sprintf(`retry_join = ["127.0.0.1:%d"]`, c.Servers[0].Config.SerfPortLAN))
+	}
+	if c.config.ACLs {
+		hcl = append(hcl, fmt.Sprintf(`
+			primary_datacenter = %q
+			acl {
+				enabled = true
+				default_policy = "deny"
+				tokens {
+					initial_management = %[2]q
+					agent = %[2]q
+				}
+			}
+		`, c.config.Datacenter, c.ManagementToken))
+	}
+
+	a := agent.StartTestAgent(t, agent.TestAgent{
+		Name:     name,
+		HCL:      strings.Join(hcl, "\n"),
+		UseHTTPS: c.config.TLS,
+	})
+	t.Cleanup(func() { a.Shutdown() })
+	return a
+}
+
+// generateTLS writes the certificates of the agents to a temporary directory
+// and returns the TLS configuration using them.
+func (c *Cluster) generateTLS(t *testing.T) string {
+	t.Helper()
+
+	signer, _, err := tlsutil.GeneratePrivateKey()
+	require.NoError(t, err)
+	ca, _, err := tlsutil.GenerateCA(tlsutil.CAOpts{Signer: signer})
+	require.NoError(t, err)
+
+	// All the agents share a certificate valid for the name the servers are
+	// verified with.
+	cert, key, err := tlsutil.GenerateCert(tlsutil.CertOpts{
+		Signer:      signer,
+		CA:          ca,
+		Name:        "testcluster",
+		Days:        365,
+		DNSNames:    []string{"server." + c.config.Datacenter + ".consul", "localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	require.NoError(t, err)
+	c.CACert = ca
+
+	dir := t.TempDir()
+	files := map[string]string{"ca.pem": ca, "cert.pem": cert, "key.pem": key}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	// Convert windows style path to posix style path to avoid illegal char
+	// escape error when hcl parsing.
+	dir = filepath.ToSlash(dir)
+	return fmt.Sprintf(`
+		tls {
+			defaults {
+				ca_file = "%[1]s/ca.pem"
+				cert_file = "%[1]s/cert.pem"
+				key_file = "%[1]s/key.pem"
+				verify_incoming = true
+				verify_outgoing = true
+			}
+			internal_rpc {
+				verify_server_hostname = true
+			}
+			https {
+				verify_incoming = false
+			}
+		}
+	`, dir)
+}
+
+// WaitForLeader waits for all the agents of the cluster to know the same
+// leader and, if ACLs are enabled, for the ACLs to be bootstrapped.
+func (c *Cluster) WaitForLeader(t *testing.T) {
+	t.Helper()
+
+	timer := &retry.Timer{Timeout: 30 * time.Second, Wait: 100 * time.Millisecond}
+	retry.RunWith(timer, t, func(r *retry.R) {
+		var leader string
+		for _, a := range c.agents() {
+			l, err := c.APIClient(r, a).Status().Leader()
+			require.NoError(r, err)
+			require.NotEmpty(r, l, "%s has no leader", a.Name)
+			if leader == "" {
+				leader = l
+			}
+			require.Equal(r, leader, l, "%s has a different leader", a.Name)
+		}
+
+		peers, err := c.APIClient(r, c.Servers[0]).Status().Peers()
+		require.NoError(r, err)
+		require.Len(r, peers, len(c.Servers))
+
+		if c.config.ACLs {
+			_, _, err := c.APIClient(r, c.Servers[0]).ACL().TokenReadSelf(nil)
+			require.NoError(r, err)
+		}
+	})
+}
+
+// Leader returns the server which is the leader of the cluster.
+func (c *Cluster) Leader(t require.TestingT) *agent.TestAgent {
+	leader, err := c.APIClient(t, c.Servers[0]).Status().Leader()
+	require.NoError(t, err)
+	for _, s := range c.Servers {
+		if s.Config.RPCAdvertiseAddr.String() == leader {
+			return s
+		}
+	}
+	require.FailNow(t, "no leader", "the cluster has no leader")
+	return nil
+}
+
+// APIClient returns an API client making requests to the agent with the
+// management token, if ACLs are enabled.
+func (c *Cluster) APIClient(t require.TestingT, a *agent.TestAgent) *api.Client {
+	config := api.DefaultConfig()
+	config.Token = c.ManagementToken
+	if c.config.TLS {
+		config.Address = a.Config.HTTPSAddrs[0].String()
+		config.Scheme = "https"
+		config.TLSConfig.CAPem = []byte(c.CACert)
+	} else {
+		config.Address = a.Config.HTTPAddrs[0].String()
+	}
+
+	client, err := api.NewClient(config)
+	require.NoError(t, err)
+	return client
+}
+
+func (c *Cluster) agents() []*agent.TestAgent {
+	return append(append([]*agent.TestAgent(nil), c.Servers...), c.Clients...)
+}
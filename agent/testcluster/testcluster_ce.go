@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !consulent
+
+package testcluster
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/consul/acl"
+)
+
+func validatePartitions(clients []ClientConfig) error {
+	for _, client := range clients {
+		if client.Partition != "" && client.Partition != acl.NonEmptyDefaultPartitionName {
+			return fmt.Errorf("admin partitions are only supported by Consul Enterprise, got partition %q", client.Partition)
+		}
+	}
+	return nil
+}
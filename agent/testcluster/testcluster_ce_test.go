@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !consulent
+
+package testcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePartitions(t *testing.T) {
+	require.NoError(t, validatePartitions([]ClientConfig{{}, {Partition: "default"}}))
+	require.ErrorContains(t, validatePartitions([]ClientConfig{{Partition: "part1"}}),
+		"admin partitions are only supported by Consul Enterprise")
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package testcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestCluster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	c := New(t, Config{
+		Clients: []ClientConfig{{}},
+		ACLs:    true,
+		TLS:     true,
+	})
+	require.Len(t, c.Servers, 3)
+	require.Len(t, c.Clients, 1)
+	require.NotEmpty(t, c.ManagementToken)
+	require.NotEmpty(t, c.CACert)
+
+	leader := c.Leader(t)
+	require.Contains(t, c.Servers, leader)
+
+	// Writes made through the client are visible from all the servers.
+	_, err := c.APIClient(t, c.Clients[0]).KV().Put(&api.KVPair{Key: "foo", Value: []byte("bar")}, nil)
+	require.NoError(t, err)
+	for _, s := range c.Servers {
+		pair, _, err := c.APIClient(t, s).KV().Get("foo", &api.QueryOptions{RequireConsistent: true})
+		require.NoError(t, err)
+		require.Equal(t, "bar", string(pair.Value))
+	}
+
+	// Requests without the management token are denied.
+	_, _, err = c.APIClient(t, leader).KV().Get("foo", &api.QueryOptions{Token: "anonymous"})
+	require.ErrorContains(t, err, "Permission denied")
+}
@@ -44,7 +44,7 @@ func (a *Agent) TranslateServiceAddress(dc string, addr string, taggedAddresses
 		}
 	}
 
-	return translateAddressAccept(accept, def, v4, v6)
+	return translateAddressAccept(accept, def, v4, v6, a.config.AddressFamilyPreference)
 }
 
 // TranslateAddress is used to provide the final, translated address for a node,
@@ -68,10 +68,17 @@ func (a *Agent) TranslateAddress(dc string, addr string, taggedAddresses map[str
 		}
 	}
 
-	return translateAddressAccept(accept, def, v4, v6)
+	return translateAddressAccept(accept, def, v4, v6, a.config.AddressFamilyPreference)
 }
 
-func translateAddressAccept(accept dnsutil.TranslateAddressAccept, def, v4, v6 string) string {
+// translateAddressAccept returns the address of the accepted families. The
+// IPv6 address is returned before the IPv4 one when both are accepted, unless
+// the preference is IPv4.
+func translateAddressAccept(accept dnsutil.TranslateAddressAccept, def, v4, v6, preference string) string {
+	if preference == structs.AddressFamilyIPv4 && accept&dnsutil.TranslateAddressAcceptIPv4 > 0 && v4 != "" {
+		return v4
+	}
+
 	switch {
 	case accept&dnsutil.TranslateAddressAcceptIPv6 > 0 && v6 != "":
 		return v6
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -332,12 +333,46 @@ func (s *HTTPHandlers) convertOps(resp http.ResponseWriter, req *http.Request) (
 				},
 			}
 			opsRPC = append(opsRPC, out)
+
+		case in.ConfigEntry != nil:
+			writes++
+
+			if in.ConfigEntry.Entry == nil {
+				return nil, 0, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Config entry operation is missing the entry"}
+			}
+			entry, err := decodeTxnConfigEntry(in.ConfigEntry.Entry)
+			if err != nil {
+				return nil, 0, HTTPError{StatusCode: http.StatusBadRequest, Reason: fmt.Sprintf("Failed to decode config entry: %v", err)}
+			}
+
+			out := &structs.TxnOp{
+				ConfigEntry: &structs.TxnConfigEntryOp{
+					Verb:  in.ConfigEntry.Verb,
+					Entry: entry,
+					Index: in.ConfigEntry.Index,
+				},
+			}
+			opsRPC = append(opsRPC, out)
 		}
 	}
 
 	return opsRPC, writes, nil
 }
 
+// decodeTxnConfigEntry converts a config entry from the API format to the
+// internal format, the same way the config endpoint decodes request bodies.
+func decodeTxnConfigEntry(in api.ConfigEntry) (structs.ConfigEntry, error) {
+	buf, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return nil, err
+	}
+	return structs.DecodeConfigEntry(raw)
+}
+
 // Txn handles requests to apply multiple operations in a single, atomic
 // transaction. A transaction consisting of only read operations will be fast-
 // pathed to an endpoint that supports consistency modes (but not blocking),
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -802,6 +803,98 @@ func TestTxnEndpoint_NodeService(t *testing.T) {
 	assert.Equal(t, expected, txnResp)
 }
 
+func TestTxnEndpoint_ConfigEntry(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	buf := bytes.NewBuffer([]byte(`
+[
+	{
+		"ConfigEntry": {
+			"Verb": "cas",
+			"Index": 0,
+			"Entry": {
+				"Kind": "service-intentions",
+				"Name": "db",
+				"Sources": [
+					{
+						"Name": "web",
+						"Action": "allow"
+					}
+				]
+			}
+		}
+	},
+	{
+		"ConfigEntry": {
+			"Verb": "set",
+			"Entry": {
+				"Kind": "service-defaults",
+				"Name": "db",
+				"Protocol": "http"
+			}
+		}
+	}
+]
+`))
+	req, _ := http.NewRequest("PUT", "/v1/txn", buf)
+	resp := httptest.NewRecorder()
+	obj, err := a.srv.Txn(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.Code)
+	require.Empty(t, obj.(structs.TxnResponse).Errors)
+
+	args := structs.ConfigEntryQuery{
+		Kind:       structs.ServiceIntentions,
+		Name:       "db",
+		Datacenter: "dc1",
+	}
+	var out structs.ConfigEntryResponse
+	require.NoError(t, a.RPC(context.Background(), "ConfigEntry.Get", &args, &out))
+	require.NotNil(t, out.Entry)
+	require.Equal(t, "web", out.Entry.(*structs.ServiceIntentionsConfigEntry).Sources[0].Name)
+
+	// Creating the entry again fails the CAS.
+	buf = bytes.NewBuffer([]byte(`
+[
+	{
+		"ConfigEntry": {
+			"Verb": "cas",
+			"Entry": {
+				"Kind": "service-intentions",
+				"Name": "db",
+				"Sources": [
+					{
+						"Name": "web",
+						"Action": "deny"
+					}
+				]
+			}
+		}
+	}
+]
+`))
+	req, _ = http.NewRequest("PUT", "/v1/txn", buf)
+	resp = httptest.NewRecorder()
+	_, err = a.srv.Txn(resp, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, resp.Code)
+	require.Contains(t, resp.Body.String(), "index is stale")
+
+	// An operation without an entry is rejected.
+	buf = bytes.NewBuffer([]byte(`[{"ConfigEntry": {"Verb": "delete"}}]`))
+	req, _ = http.NewRequest("PUT", "/v1/txn", buf)
+	resp = httptest.NewRecorder()
+	_, err = a.srv.Txn(resp, req)
+	require.True(t, isHTTPBadRequest(err), fmt.Sprintf("Expected bad request HTTP error but got %v", err))
+}
+
 func TestTxnEndpoint_OperationsSize(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -513,6 +513,37 @@ RPC:
 	return topo, nil
 }
 
+// UIServiceTopologyMetrics returns the request rate, error rate and latency
+// percentiles between a service and its upstreams and downstreams, as
+// reported by the dataplanes over the last few minutes.
+func (s *HTTPHandlers) UIServiceTopologyMetrics(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.ServiceSpecificRequest{}
+	if done := s.parse(resp, req, &args.Datacenter, &args.QueryOptions); done {
+		return nil, nil
+	}
+	if err := s.parseEntMeta(req, &args.EnterpriseMeta); err != nil {
+		return nil, err
+	}
+
+	args.ServiceName = strings.TrimPrefix(req.URL.Path, "/v1/internal/ui/service-topology-metrics/")
+	if args.ServiceName == "" {
+		return nil, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing service name"}
+	}
+
+	var out structs.IndexedMeshTrafficMetrics
+	defer setMeta(resp, &out.QueryMeta)
+	if err := s.agent.RPC(req.Context(), "Internal.MeshTrafficMetrics", &args, &out); err != nil {
+		return nil, err
+	}
+	if out.Upstreams == nil {
+		out.Upstreams = make([]structs.MeshTrafficRollup, 0)
+	}
+	if out.Downstreams == nil {
+		out.Downstreams = make([]structs.MeshTrafficRollup, 0)
+	}
+	return out.MeshTrafficMetrics, nil
+}
+
 func summarizeServices(dump structs.ServiceDump, cfg *config.RuntimeConfig, dc string) (map[structs.PeeredServiceName]*ServiceSummary, map[structs.PeeredServiceName]bool) {
 	var (
 		summary  = make(map[structs.PeeredServiceName]*ServiceSummary)
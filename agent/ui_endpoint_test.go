@@ -2231,6 +2231,36 @@ func clearUnexportedFields(result ServiceTopology) {
 	}
 }
 
+func TestUIServiceTopologyMetrics(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := NewTestAgent(t, "")
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	t.Run("missing service", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/internal/ui/service-topology-metrics/", nil)
+		resp := httptest.NewRecorder()
+		_, err := a.srv.UIServiceTopologyMetrics(resp, req)
+		require.Equal(t, HTTPError{StatusCode: http.StatusBadRequest, Reason: "Missing service name"}, err)
+	})
+
+	t.Run("no reports", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/v1/internal/ui/service-topology-metrics/web", nil)
+		resp := httptest.NewRecorder()
+		obj, err := a.srv.UIServiceTopologyMetrics(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, structs.MeshTrafficMetrics{
+			Upstreams:   []structs.MeshTrafficRollup{},
+			Downstreams: []structs.MeshTrafficRollup{},
+		}, obj)
+	})
+}
+
 func TestUIServiceTopology_RoutingConfigs(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
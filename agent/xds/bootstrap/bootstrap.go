@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package bootstrap generates the Envoy bootstrap configuration used by
+// `consul connect envoy` and the dataplane GetEnvoyBootstrap RPC.
+package bootstrap
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/agent/xds/accesslogs"
+)
+
+// DefaultAdminAccessLogPath is where Envoy writes the admin access log when
+// no access logs are configured.
+const DefaultAdminAccessLogPath = os.DevNull
+
+// ParseAgentAddress parses the address of the xDS server Envoy connects to.
+// It accepts host:port with an optional http:// or https:// scheme, where
+// https:// enables TLS, or unix:///path/to/socket.
+//
+// Envoy uses a STATIC cluster for the xDS server, so host names are resolved
+// to an IP address here.
+func ParseAgentAddress(addr string) (GRPC, error) {
+	// We use STATIC for agent which means we need to resolve DNS names like
+	// `localhost` ourselves. We could use STRICT_DNS or LOGICAL_DNS with envoy
+	// but Envoy resolves `localhost` differently to go on macOS at least which
+	// causes paper cuts like default dev agent (which binds specifically to
+	// 127.0.0.1) isn't reachable since Envoy resolves localhost to `[::]` and
+	// can't connect.
+	return parseAgentAddress(addr, func(host string) (string, error) {
+		agentIP, err := net.ResolveIPAddr("ip", host)
+		if err != nil {
+			return "", fmt.Errorf("Failed to resolve agent address: %s", err)
+		}
+		return agentIP.String(), nil
+	})
+}
+
+// ParseAgentIPAddress is like ParseAgentAddress but does not resolve host
+// names, the host must already be an IP address. It is used where the address
+// comes from a remote caller, which must resolve it itself.
+func ParseAgentIPAddress(addr string) (GRPC, error) {
+	return parseAgentAddress(addr, func(host string) (string, error) {
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return "", fmt.Errorf("agent address %q is not an IP address", host)
+		}
+		return ip.String(), nil
+	})
+}
+
+func parseAgentAddress(addr string, resolve func(host string) (string, error)) (GRPC, error) {
+	g := GRPC{}
+
+	// TODO: parse addr as a url instead of strings.HasPrefix/TrimPrefix
+	if strings.HasPrefix(strings.ToLower(addr), "https://") {
+		g.AgentTLS = true
+	}
+
+	// We want to allow addr set as host:port with no scheme but if the host
+	// is an IP this will fail to parse as a URL with "parse 127.0.0.1:8500: first
+	// path segment in URL cannot contain colon". On the other hand we also
+	// support both http(s)://host:port and unix:///path/to/file.
+	if grpcAddr := strings.TrimPrefix(addr, "unix://"); grpcAddr != addr {
+		// Path to unix socket
+		g.AgentSocket = grpcAddr
+		return g, nil
+	}
+
+	// Parse as host:port with option http prefix
+	grpcAddr := strings.TrimPrefix(addr, "http://")
+	grpcAddr = strings.TrimPrefix(grpcAddr, "https://")
+
+	var err error
+	var host string
+	host, g.AgentPort, err = net.SplitHostPort(grpcAddr)
+	if err != nil {
+		return g, fmt.Errorf("Invalid Consul HTTP address: %s", err)
+	}
+
+	g.AgentAddress, err = resolve(host)
+	if err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+// EscapeCAPEM formats PEM encoded CA certificates for inclusion in the
+// bootstrap template as BootstrapTplArgs.AgentCAPEM.
+func EscapeCAPEM(pems ...string) string {
+	return strings.Replace(strings.Join(pems, ""), "\n", "\\n", -1)
+}
+
+// AccessLogConfigs converts the access logs configured in proxy-defaults into
+// the JSON encoded Envoy access log configurations expected by
+// BootstrapTplArgs.AdminAccessLogConfig.
+func AccessLogConfigs(logs structs.AccessLogs) ([]string, error) {
+	envoyLoggers, err := accesslogs.MakeAccessLogs(logs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failure generating Envoy access log configuration: %w", err)
+	}
+
+	// Convert individual proto messages to JSON here
+	configs := make([]string, 0, len(envoyLoggers))
+	for _, msg := range envoyLoggers {
+		logConfig, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal Envoy access log configuration: %w", err)
+		}
+		configs = append(configs, string(logConfig))
+	}
+	return configs, nil
+}
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: BUSL-1.1
 
-package envoy
+package bootstrap
 
 import (
 	"bytes"
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/hashicorp/consul/agent/xds/config"
 	"github.com/hashicorp/consul/api"
 )
 
@@ -160,6 +161,24 @@ type BootstrapConfig struct {
 	// the bootstrap config. It's format may vary based on Envoy version used.
 	// See https://www.envoyproxy.io/docs/envoy/v1.9.0/api-v2/config/trace/v2/trace.proto.
 	TracingConfigJSON string `mapstructure:"envoy_tracing_json"`
+
+	// ResourcePreset is the name of a resource preset ("small", "medium" or
+	// "large") which sets the Envoy concurrency, the buffer limits and the
+	// overload manager thresholds coherently. The overload manager is rendered
+	// into the bootstrap, the concurrency is passed on Envoy's command line
+	// and the buffer limits are delivered by xDS. See
+	// agent/xds/config/resource_presets.go.
+	ResourcePreset string `mapstructure:"envoy_resource_preset"`
+
+	// MaxHeapSizeBytes is the heap size watched by the overload manager, which
+	// shrinks the heap and then stops accepting requests as it fills up. It
+	// overrides the heap size of the ResourcePreset.
+	MaxHeapSizeBytes uint64 `mapstructure:"envoy_max_heap_size_bytes"`
+
+	// MaxDownstreamConnections is the maximum number of active downstream
+	// connections across all the listeners of the proxy, enforced by the
+	// overload manager. Envoy rejects new connections once it is reached.
+	MaxDownstreamConnections uint64 `mapstructure:"envoy_max_downstream_connections"`
 }
 
 // Template returns the bootstrap template to use as a base.
@@ -249,6 +268,10 @@ func (c *BootstrapConfig) ConfigureArgs(args *BootstrapTplArgs, omitDeprecatedTa
 		args.StatsFlushInterval = c.StatsFlushInterval
 	}
 
+	if err := c.generateOverloadManager(args); err != nil {
+		return err
+	}
+
 	// Setup telemetry collector if needed. This MUST happen after the Static*JSON is set above
 	if c.TelemetryCollectorBindSocketDir != "" {
 		// Override StatsFlushInterval as 60 seconds (1 minute) to reduce number of metric flushes.
@@ -262,6 +285,83 @@ func (c *BootstrapConfig) ConfigureArgs(args *BootstrapTplArgs, omitDeprecatedTa
 	return nil
 }
 
+// Concurrency returns the number of Envoy worker threads set by the resource
+// preset, or zero to keep the Envoy default.
+func (c *BootstrapConfig) Concurrency() int {
+	preset, err := config.GetResourcePreset(c.ResourcePreset)
+	if err != nil || preset == nil {
+		return 0
+	}
+	return preset.Concurrency
+}
+
+// generateOverloadManager renders the overload manager protecting the heap
+// size and the downstream connections of the proxy, if any is limited.
+func (c *BootstrapConfig) generateOverloadManager(args *BootstrapTplArgs) error {
+	preset, err := config.GetResourcePreset(c.ResourcePreset)
+	if err != nil {
+		return err
+	}
+
+	heap := config.ResourcePreset{
+		ShrinkHeapThreshold:            config.DefaultShrinkHeapThreshold,
+		StopAcceptingRequestsThreshold: config.DefaultStopAcceptingRequestsThreshold,
+	}
+	if preset != nil {
+		heap = *preset
+	}
+	if c.MaxHeapSizeBytes > 0 {
+		heap.MaxHeapSizeBytes = c.MaxHeapSizeBytes
+	}
+
+	var monitors, actions []string
+	if heap.MaxHeapSizeBytes > 0 {
+		monitors = append(monitors, fmt.Sprintf(`{
+			"name": "envoy.resource_monitors.fixed_heap",
+			"typed_config": {
+				"@type": "type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig",
+				"max_heap_size_bytes": %d
+			}
+		}`, heap.MaxHeapSizeBytes))
+		actions = append(actions, fmt.Sprintf(`{
+			"name": "envoy.overload_actions.shrink_heap",
+			"triggers": [
+				{
+					"name": "envoy.resource_monitors.fixed_heap",
+					"threshold": { "value": %g }
+				}
+			]
+		}`, heap.ShrinkHeapThreshold), fmt.Sprintf(`{
+			"name": "envoy.overload_actions.stop_accepting_requests",
+			"triggers": [
+				{
+					"name": "envoy.resource_monitors.fixed_heap",
+					"threshold": { "value": %g }
+				}
+			]
+		}`, heap.StopAcceptingRequestsThreshold))
+	}
+	if c.MaxDownstreamConnections > 0 {
+		monitors = append(monitors, fmt.Sprintf(`{
+			"name": "envoy.resource_monitors.global_downstream_max_connections",
+			"typed_config": {
+				"@type": "type.googleapis.com/envoy.extensions.resource_monitors.downstream_connections.v3.DownstreamConnectionsConfig",
+				"max_active_downstream_connections": %d
+			}
+		}`, c.MaxDownstreamConnections))
+	}
+	if len(monitors) == 0 {
+		return nil
+	}
+
+	args.OverloadManagerJSON = fmt.Sprintf(`{
+		"refresh_interval": "0.25s",
+		"resource_monitors": [%s],
+		"actions": [%s]
+	}`, strings.Join(monitors, ",\n"), strings.Join(actions, ",\n"))
+	return nil
+}
+
 func (c *BootstrapConfig) generateStatsSinks(args *BootstrapTplArgs) error {
 	var stats_sinks []string
 
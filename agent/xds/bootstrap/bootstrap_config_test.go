@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: BUSL-1.1
 
-package envoy
+package bootstrap
 
 import (
 	"encoding/json"
@@ -1129,6 +1129,13 @@ func TestBootstrapConfig_ConfigureArgs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "err-bad-resource-preset",
+			input: BootstrapConfig{
+				ResourcePreset: "huge",
+			},
+			wantErr: true,
+		},
 		{
 			name: "err-bad-prometheus-addr",
 			input: BootstrapConfig{
@@ -1258,7 +1265,10 @@ func TestBootstrapConfig_ConfigureArgs(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			args := tt.baseArgs
 
-			defer testSetAndResetEnv(t, tt.env)()
+			for _, e := range tt.env {
+				pair := strings.SplitN(e, "=", 2)
+				t.Setenv(pair[0], pair[1])
+			}
 
 			err := tt.input.ConfigureArgs(&args, tt.omitDeprecatedTags)
 			if tt.wantErr {
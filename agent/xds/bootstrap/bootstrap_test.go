@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgentAddress(t *testing.T) {
+	cases := map[string]struct {
+		addr    string
+		want    GRPC
+		wantErr string
+	}{
+		"host and port": {
+			addr: "127.0.0.1:8502",
+			want: GRPC{AgentAddress: "127.0.0.1", AgentPort: "8502"},
+		},
+		"http scheme": {
+			addr: "http://127.0.0.1:8502",
+			want: GRPC{AgentAddress: "127.0.0.1", AgentPort: "8502"},
+		},
+		"https scheme enables TLS": {
+			addr: "https://127.0.0.1:8503",
+			want: GRPC{AgentAddress: "127.0.0.1", AgentPort: "8503", AgentTLS: true},
+		},
+		"unix socket": {
+			addr: "unix:///var/run/consul.sock",
+			want: GRPC{AgentSocket: "/var/run/consul.sock"},
+		},
+		"missing port": {
+			addr:    "127.0.0.1",
+			wantErr: "Invalid Consul HTTP address: address 127.0.0.1: missing port in address",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseAgentAddress(tc.addr)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseAgentIPAddress(t *testing.T) {
+	got, err := ParseAgentIPAddress("https://127.0.0.1:8503")
+	require.NoError(t, err)
+	require.Equal(t, GRPC{AgentAddress: "127.0.0.1", AgentPort: "8503", AgentTLS: true}, got)
+
+	got, err = ParseAgentIPAddress("unix:///var/run/consul.sock")
+	require.NoError(t, err)
+	require.Equal(t, GRPC{AgentSocket: "/var/run/consul.sock"}, got)
+
+	_, err = ParseAgentIPAddress("localhost:8502")
+	require.EqualError(t, err, `agent address "localhost" is not an IP address`)
+}
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: BUSL-1.1
 
-package envoy
+package bootstrap
 
 // BootstrapTplArgs is the set of arguments that may be interpolated into the
 // Envoy bootstrap template.
@@ -101,6 +101,11 @@ type BootstrapTplArgs struct {
 	// See https://www.envoyproxy.io/docs/envoy/v1.9.0/api-v2/config/trace/v2/trace.proto.
 	TracingConfigJSON string
 
+	// OverloadManagerJSON is a JSON string containing an object in the right
+	// format to be rendered as the body of the `overload_manager` field at the
+	// top level of the bootstrap config.
+	OverloadManagerJSON string
+
 	// Namespace is the Consul Enterprise Namespace of the proxy service instance
 	// as registered with the Consul agent.
 	Namespace string
@@ -293,6 +298,9 @@ const bootstrapTemplate = `{
   {{- if .TracingConfigJSON }}
   "tracing": {{ .TracingConfigJSON }},
   {{- end }}
+  {{- if .OverloadManagerJSON }}
+  "overload_manager": {{ .OverloadManagerJSON }},
+  {{- end }}
   "dynamic_resources": {
     "lds_config": {
       "ads": {},
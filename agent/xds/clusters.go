@@ -34,6 +34,7 @@ import (
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/xds/response"
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
+	"github.com/hashicorp/consul/lib"
 	"github.com/hashicorp/consul/proto/private/pbpeering"
 )
 
@@ -485,6 +486,7 @@ func makeMTLSTransportSocket(cfgSnap *proxycfg.ConfigSnapshot, uid proxycfg.Upst
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 	}
+	injectRevocationList(commonTLSContext, cfgSnap.RevocationListPEMs())
 	tlsContext := envoy_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
 		Sni:              sni,
@@ -877,15 +879,10 @@ func (s *ResourceGenerator) injectGatewayServiceAddons(cfgSnap *proxycfg.ConfigS
 		}
 	case structs.ServiceKindTerminatingGateway:
 		// Context used for TLS origination to the cluster
-		if mapping, ok := cfgSnap.TerminatingGateway.GatewayServices[svc]; ok && mapping.CAFile != "" {
-			tlsContext := &envoy_tls_v3.UpstreamTlsContext{
-				CommonTlsContext: makeCommonTLSContextFromFiles(mapping.CAFile, mapping.CertFile, mapping.KeyFile),
-			}
-			if mapping.SNI != "" {
-				tlsContext.Sni = mapping.SNI
-				if err := injectSANMatcher(tlsContext.CommonTlsContext, true, mapping.SNI); err != nil {
-					return fmt.Errorf("failed to inject SNI matcher into TLS context: %v", err)
-				}
+		if mapping, ok := cfgSnap.TerminatingGateway.GatewayServices[svc]; ok && mapping.HasTLS() {
+			tlsContext, err := makeTerminatingGatewayUpstreamTLSContext(mapping, cfgSnap.TerminatingGateway.VaultClientCerts[svc])
+			if err != nil {
+				return err
 			}
 
 			transportSocket, err := makeUpstreamTLSTransportSocket(tlsContext)
@@ -906,15 +903,10 @@ func (s *ResourceGenerator) injectGatewayDestinationAddons(cfgSnap *proxycfg.Con
 	switch cfgSnap.Kind {
 	case structs.ServiceKindTerminatingGateway:
 		// Context used for TLS origination to the cluster
-		if mapping, ok := cfgSnap.TerminatingGateway.DestinationServices[svc]; ok && mapping.CAFile != "" {
-			tlsContext := &envoy_tls_v3.UpstreamTlsContext{
-				CommonTlsContext: makeCommonTLSContextFromFiles(mapping.CAFile, mapping.CertFile, mapping.KeyFile),
-			}
-			if mapping.SNI != "" {
-				tlsContext.Sni = mapping.SNI
-				if err := injectSANMatcher(tlsContext.CommonTlsContext, true, mapping.SNI); err != nil {
-					return fmt.Errorf("failed to inject SNI matcher into TLS context: %v", err)
-				}
+		if mapping, ok := cfgSnap.TerminatingGateway.DestinationServices[svc]; ok && mapping.HasTLS() {
+			tlsContext, err := makeTerminatingGatewayUpstreamTLSContext(mapping, cfgSnap.TerminatingGateway.VaultClientCerts[svc])
+			if err != nil {
+				return err
 			}
 
 			transportSocket, err := makeUpstreamTLSTransportSocket(tlsContext)
@@ -928,6 +920,59 @@ func (s *ResourceGenerator) injectGatewayDestinationAddons(cfgSnap *proxycfg.Con
 	return nil
 }
 
+// makeTerminatingGatewayUpstreamTLSContext returns the context used by a
+// terminating gateway to originate TLS to a linked service. vaultCert is the
+// client certificate issued by Vault when the service is configured with
+// VaultPKI.
+func makeTerminatingGatewayUpstreamTLSContext(mapping structs.GatewayService, vaultCert *structs.IssuedCert) (*envoy_tls_v3.UpstreamTlsContext, error) {
+	commonTLSContext := makeCommonTLSContextFromFiles(mapping.CAFile, mapping.CertFile, mapping.KeyFile)
+
+	if mapping.CAPEM != "" {
+		commonTLSContext.ValidationContextType = &envoy_tls_v3.CommonTlsContext_ValidationContext{
+			ValidationContext: &envoy_tls_v3.CertificateValidationContext{
+				TrustedCa: &envoy_core_v3.DataSource{
+					Specifier: &envoy_core_v3.DataSource_InlineString{
+						InlineString: lib.EnsureTrailingNewline(mapping.CAPEM),
+					},
+				},
+			},
+		}
+	}
+
+	if mapping.VaultPKI != nil && vaultCert != nil {
+		commonTLSContext.TlsCertificates = []*envoy_tls_v3.TlsCertificate{
+			{
+				CertificateChain: &envoy_core_v3.DataSource{
+					Specifier: &envoy_core_v3.DataSource_InlineString{
+						InlineString: lib.EnsureTrailingNewline(vaultCert.CertPEM),
+					},
+				},
+				PrivateKey: &envoy_core_v3.DataSource{
+					Specifier: &envoy_core_v3.DataSource_InlineString{
+						InlineString: lib.EnsureTrailingNewline(vaultCert.PrivateKeyPEM),
+					},
+				},
+			},
+		}
+	}
+
+	if err := validateListenerTLSConfig(mapping.TLSMinVersion, mapping.CipherSuites); err != nil {
+		return nil, err
+	}
+	commonTLSContext.TlsParams = makeTLSParametersFromTLSConfig(mapping.TLSMinVersion, mapping.TLSMaxVersion, mapping.CipherSuites)
+
+	tlsContext := &envoy_tls_v3.UpstreamTlsContext{
+		CommonTlsContext: commonTLSContext,
+	}
+	if mapping.SNI != "" {
+		tlsContext.Sni = mapping.SNI
+		if err := injectSANMatcher(tlsContext.CommonTlsContext, true, mapping.SNI); err != nil {
+			return nil, fmt.Errorf("failed to inject SNI matcher into TLS context: %v", err)
+		}
+	}
+	return tlsContext, nil
+}
+
 func (s *ResourceGenerator) clustersFromSnapshotIngressGateway(cfgSnap *proxycfg.ConfigSnapshot) ([]proto.Message, error) {
 	var clusters []proto.Message
 	createdClusters := make(map[proxycfg.UpstreamID]bool)
@@ -1121,6 +1166,9 @@ func (s *ResourceGenerator) makeAppCluster(cfgSnap *proxycfg.ConfigSnapshot, nam
 			},
 		}
 	}
+	if preset := cfg.GetResourcePreset(); preset != nil {
+		c.PerConnectionBufferLimitBytes = response.MakeUint32Value(int(preset.PerConnectionBufferLimitBytes))
+	}
 
 	return c, err
 }
@@ -1341,6 +1389,7 @@ func (s *ResourceGenerator) makeUpstreamClusterForPreparedQuery(upstream structs
 	if err != nil {
 		return nil, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 	}
+	injectRevocationList(commonTLSContext, cfgSnap.RevocationListPEMs())
 
 	tlsContext := &envoy_tls_v3.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
@@ -37,6 +37,12 @@ type XDSCommonConfig struct {
 	// XDSFetchTimeoutMs specifies the amount of milliseconds to wait for dynamically configured Envoy data (EDS, RDS).
 	// Uses the Envoy default value if not specified or negative. A value of zero disables the timeout.
 	XDSFetchTimeoutMs *int `mapstructure:"xds_fetch_timeout_ms"`
+
+	// AddressFamilyPreference is the IP family, "ipv4" or "ipv6", of the address
+	// Envoy connects to first for the dual-stack upstream instances. The other
+	// address is given to Envoy as an additional address of the endpoint. If
+	// not set, the registered address of the instance comes first.
+	AddressFamilyPreference string `mapstructure:"address_family_preference"`
 }
 
 // ParseXDSCommonConfig returns the XDSCommonConfig parsed from an opaque map. If an
@@ -119,9 +125,29 @@ type ProxyConfig struct {
 	// the proxy. If not set, the default is 0 (no limit).
 	MaxInboundConnections int `mapstructure:"max_inbound_connections"`
 
+	// InboundConnectionRateLimit is the maximum number of new inbound
+	// connections per second accepted by the proxy's public listener. If not
+	// set, the default is 0 (no limit).
+	InboundConnectionRateLimit int `mapstructure:"inbound_connection_rate_limit"`
+
 	// BalanceInboundConnections indicates how the proxy should attempt to distribute
 	// connections across worker threads. Only used by envoy proxies.
 	BalanceInboundConnections string `json:",omitempty" alias:"balance_inbound_connections"`
+
+	// ResourcePreset is the name of the ResourcePreset to configure the proxy
+	// with: "small", "medium" or "large". If not set, the Envoy defaults are
+	// respected.
+	ResourcePreset string `mapstructure:"envoy_resource_preset"`
+}
+
+// GetResourcePreset returns the configured resource preset, or nil if none or
+// an unknown one is configured.
+func (c *ProxyConfig) GetResourcePreset() *ResourcePreset {
+	preset, err := GetResourcePreset(c.ResourcePreset)
+	if err != nil {
+		return nil
+	}
+	return preset
 }
 
 // ParseProxyConfig returns the ProxyConfig parsed from the an opaque map. If an
@@ -141,6 +167,9 @@ func ParseProxyConfig(m map[string]interface{}) (ProxyConfig, error) {
 	if cfg.LocalConnectTimeoutMs < 1 {
 		cfg.LocalConnectTimeoutMs = 5000
 	}
+	if _, err := GetResourcePreset(cfg.ResourcePreset); err != nil {
+		return cfg, err
+	}
 
 	return cfg, nil
 }
@@ -207,6 +207,28 @@ func TestParseProxyConfig(t *testing.T) {
 				BalanceInboundConnections: "exact_balance",
 			},
 		},
+		{
+			name: "inbound connection rate limit override, string",
+			input: map[string]interface{}{
+				"inbound_connection_rate_limit": "50",
+			},
+			want: ProxyConfig{
+				LocalConnectTimeoutMs:      5000,
+				Protocol:                   "tcp",
+				InboundConnectionRateLimit: 50,
+			},
+		},
+		{
+			name: "resource preset override",
+			input: map[string]interface{}{
+				"envoy_resource_preset": "Medium",
+			},
+			want: ProxyConfig{
+				LocalConnectTimeoutMs: 5000,
+				Protocol:              "tcp",
+				ResourcePreset:        "Medium",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -217,6 +239,28 @@ func TestParseProxyConfig(t *testing.T) {
 	}
 }
 
+func TestParseProxyConfig_unknownResourcePreset(t *testing.T) {
+	got, err := ParseProxyConfig(map[string]interface{}{
+		"envoy_resource_preset": "huge",
+	})
+	require.ErrorContains(t, err, `unknown envoy_resource_preset "huge"`)
+	require.Nil(t, got.GetResourcePreset())
+}
+
+func TestGetResourcePreset(t *testing.T) {
+	preset, err := GetResourcePreset("")
+	require.NoError(t, err)
+	require.Nil(t, preset)
+
+	preset, err = GetResourcePreset("LARGE")
+	require.NoError(t, err)
+	require.Equal(t, 4, preset.Concurrency)
+	require.Equal(t, uint32(1024*1024), preset.PerConnectionBufferLimitBytes)
+
+	_, err = GetResourcePreset("huge")
+	require.Error(t, err)
+}
+
 func TestParseGatewayConfig(t *testing.T) {
 	tests := []struct {
 		name  string
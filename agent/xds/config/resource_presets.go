@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ResourcePresetSmall  = "small"
+	ResourcePresetMedium = "medium"
+	ResourcePresetLarge  = "large"
+)
+
+const (
+	// DefaultShrinkHeapThreshold is the fraction of the max heap size from
+	// which Envoy releases free memory to the system.
+	DefaultShrinkHeapThreshold = 0.90
+
+	// DefaultStopAcceptingRequestsThreshold is the fraction of the max heap
+	// size from which Envoy stops accepting requests.
+	DefaultStopAcceptingRequestsThreshold = 0.95
+)
+
+// ResourcePreset is a coherent set of Envoy resource settings, selected with
+// the envoy_resource_preset key of a proxy's config. The settings are rendered
+// into the bootstrap config and into the listeners and clusters delivered by
+// xDS.
+type ResourcePreset struct {
+	// Concurrency is the number of Envoy worker threads.
+	Concurrency int
+
+	// PerConnectionBufferLimitBytes is the soft limit on the size of the read
+	// and write buffers of the inbound listener and the local app cluster.
+	PerConnectionBufferLimitBytes uint32
+
+	// MaxHeapSizeBytes is the heap size watched by the overload manager.
+	MaxHeapSizeBytes uint64
+
+	// ShrinkHeapThreshold is the fraction of MaxHeapSizeBytes from which Envoy
+	// releases free memory to the system.
+	ShrinkHeapThreshold float64
+
+	// StopAcceptingRequestsThreshold is the fraction of MaxHeapSizeBytes from
+	// which Envoy stops accepting requests.
+	StopAcceptingRequestsThreshold float64
+}
+
+var resourcePresets = map[string]ResourcePreset{
+	ResourcePresetSmall: {
+		Concurrency:                    1,
+		PerConnectionBufferLimitBytes:  32 * 1024,
+		MaxHeapSizeBytes:               128 * 1024 * 1024,
+		ShrinkHeapThreshold:            DefaultShrinkHeapThreshold,
+		StopAcceptingRequestsThreshold: DefaultStopAcceptingRequestsThreshold,
+	},
+	ResourcePresetMedium: {
+		Concurrency:                    2,
+		PerConnectionBufferLimitBytes:  256 * 1024,
+		MaxHeapSizeBytes:               512 * 1024 * 1024,
+		ShrinkHeapThreshold:            DefaultShrinkHeapThreshold,
+		StopAcceptingRequestsThreshold: DefaultStopAcceptingRequestsThreshold,
+	},
+	ResourcePresetLarge: {
+		Concurrency:                    4,
+		PerConnectionBufferLimitBytes:  1024 * 1024,
+		MaxHeapSizeBytes:               2 * 1024 * 1024 * 1024,
+		ShrinkHeapThreshold:            DefaultShrinkHeapThreshold,
+		StopAcceptingRequestsThreshold: DefaultStopAcceptingRequestsThreshold,
+	},
+}
+
+// GetResourcePreset returns the preset with the given name, or nil if the name
+// is empty.
+func GetResourcePreset(name string) (*ResourcePreset, error) {
+	if name == "" {
+		return nil, nil
+	}
+	preset, ok := resourcePresets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown envoy_resource_preset %q, must be one of %q, %q or %q",
+			name, ResourcePresetSmall, ResourcePresetMedium, ResourcePresetLarge)
+	}
+	return &preset, nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package xds
+
+import (
+	"sort"
+	"sync"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	goversion "github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/consul/agent/proxycfg"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/envoyextensions/xdscommon"
+	proxysnapshot "github.com/hashicorp/consul/internal/mesh/proxy-snapshot"
+)
+
+const (
+	// EnvoyVersionSupported is the status of a proxy running an Envoy version
+	// that Consul supports.
+	EnvoyVersionSupported = "supported"
+
+	// EnvoyVersionUntested is the status of a proxy running an Envoy version
+	// that is more recent than the versions Consul supports.
+	EnvoyVersionUntested = "untested"
+
+	// EnvoyVersionUnknown is the status of a proxy whose Envoy version could
+	// not be determined, such as custom Envoy builds.
+	EnvoyVersionUnknown = "unknown"
+)
+
+// ConnectedProxy describes a proxy with an open xDS stream to this server.
+type ConnectedProxy struct {
+	// ProxyID is the ID of the proxy service instance.
+	ProxyID string
+
+	// Service is the name of the service the proxy represents: the destination
+	// service of a sidecar proxy, or the gateway service itself.
+	Service   string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
+
+	// Kind is the kind of the proxy service.
+	Kind string
+
+	// EnvoyVersion is the version reported by the proxy, if any.
+	EnvoyVersion string `json:",omitempty"`
+
+	// EnvoyVersionStatus is one of EnvoyVersionSupported,
+	// EnvoyVersionUntested or EnvoyVersionUnknown.
+	EnvoyVersionStatus string
+}
+
+// newConnectedProxy describes the proxy of the given node and snapshot.
+func newConnectedProxy(node *envoy_config_core_v3.Node, proxySnapshot proxysnapshot.ProxySnapshot) ConnectedProxy {
+	entMeta := parseEnterpriseMeta(node)
+	proxy := ConnectedProxy{
+		ProxyID:   node.Id,
+		Service:   node.Id,
+		Namespace: entMeta.NamespaceOrEmpty(),
+		Partition: entMeta.PartitionOrEmpty(),
+		Kind:      string(structs.ServiceKindConnectProxy),
+	}
+	if snap, ok := proxySnapshot.(*proxycfg.ConfigSnapshot); ok {
+		proxy.Kind = string(snap.Kind)
+		proxy.Service = snap.Service
+		if snap.Kind == structs.ServiceKindConnectProxy && snap.Proxy.DestinationServiceName != "" {
+			proxy.Service = snap.Proxy.DestinationServiceName
+		}
+	}
+	return proxy
+}
+
+// connectedProxies tracks the proxies with an open xDS stream.
+type connectedProxies struct {
+	lock    sync.Mutex
+	nextID  uint64
+	proxies map[uint64]ConnectedProxy
+}
+
+func newConnectedProxies() *connectedProxies {
+	return &connectedProxies{proxies: make(map[uint64]ConnectedProxy)}
+}
+
+// add records a connected proxy and returns a function to call once its
+// stream is closed.
+func (c *connectedProxies) add(proxy ConnectedProxy, envoyVersion *goversion.Version) func() {
+	switch {
+	case envoyVersion == nil:
+		proxy.EnvoyVersionStatus = EnvoyVersionUnknown
+	case xdscommon.IsEnvoyVersionNewerThanSupported(envoyVersion):
+		proxy.EnvoyVersion = envoyVersion.String()
+		proxy.EnvoyVersionStatus = EnvoyVersionUntested
+	default:
+		proxy.EnvoyVersion = envoyVersion.String()
+		proxy.EnvoyVersionStatus = EnvoyVersionSupported
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	id := c.nextID
+	c.nextID++
+	c.proxies[id] = proxy
+
+	return func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		delete(c.proxies, id)
+	}
+}
+
+func (c *connectedProxies) list() []ConnectedProxy {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	result := make([]ConnectedProxy, 0, len(c.proxies))
+	for _, proxy := range c.proxies {
+		result = append(result, proxy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Partition != b.Partition {
+			return a.Partition < b.Partition
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.ProxyID < b.ProxyID
+	})
+	return result
+}
+
+// ConnectedProxies returns the proxies with an open xDS stream to this server,
+// sorted by service.
+func (s *Server) ConnectedProxies() []ConnectedProxy {
+	return s.connectedProxies.list()
+}
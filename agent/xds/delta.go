@@ -251,8 +251,16 @@ func (s *Server) processDelta(stream ADSDeltaStream, reqCh <-chan *envoy_discove
 				var err error
 				proxyFeatures, err = xdscommon.DetermineSupportedProxyFeatures(req.Node)
 				if err != nil {
+					logger.Error("rejecting proxy with an unsupported Envoy version", "proxy", req.Node.Id, "error", err)
 					return status.Errorf(codes.InvalidArgument, err.Error())
 				}
+				if v := xdscommon.DetermineEnvoyVersionFromNode(req.Node); xdscommon.IsEnvoyVersionNewerThanSupported(v) {
+					logger.Warn("proxy runs an Envoy version more recent than the supported versions",
+						"proxy", req.Node.Id,
+						"envoy_version", v.String(),
+						"max_supported_version", xdscommon.GetMaxEnvoyMajorVersion(),
+					)
+				}
 			}
 
 			if handler, ok := handlers[req.TypeUrl]; ok {
@@ -380,6 +388,9 @@ func (s *Server) processDelta(stream ADSDeltaStream, reqCh <-chan *envoy_discove
 
 			logger.Trace("Got initial config snapshot")
 
+			untrack := s.connectedProxies.add(newConnectedProxy(node, proxySnapshot), xdscommon.DetermineEnvoyVersionFromNode(node))
+			defer untrack()
+
 			// Let's actually process the config we just got, or we'll miss responding
 			fallthrough
 		case stateDeltaRunning:
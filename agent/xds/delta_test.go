@@ -1580,6 +1580,67 @@ func TestServer_DeltaAggregatedResources_v3_IngressEmptyResponse(t *testing.T) {
 	}
 }
 
+func TestServer_DeltaAggregatedResources_v3_ConnectedProxies(t *testing.T) {
+	aclResolve := func(id string) (acl.Authorizer, error) { return acl.ManageAll(), nil }
+
+	scenario := newTestServerDeltaScenario(t, aclResolve, "ingress-gateway", "", 0)
+	mgr, errCh, envoy := scenario.mgr, scenario.errCh, scenario.envoy
+
+	sid := structs.NewServiceID("ingress-gateway", nil)
+	mgr.RegisterProxy(t, sid)
+
+	envoy.SendDeltaReq(t, xdscommon.ClusterType, nil)
+	require.Empty(t, scenario.server.ConnectedProxies())
+
+	snap := proxycfg.TestConfigSnapshotIngressGateway(t, false, "tcp", "default", nil, nil, nil)
+	mgr.DeliverConfig(t, sid, snap)
+
+	envoy.SendDeltaReq(t, xdscommon.ClusterType, nil)
+	assertDeltaResponseSent(t, envoy.deltaStream.sendCh, &envoy_discovery_v3.DeltaDiscoveryResponse{
+		TypeUrl: xdscommon.ClusterType,
+		Nonce:   hexString(1),
+	})
+
+	require.Equal(t, []ConnectedProxy{{
+		ProxyID:            "ingress-gateway",
+		Service:            "ingress-gateway",
+		Kind:               string(structs.ServiceKindIngressGateway),
+		EnvoyVersion:       xdscommon.EnvoyVersions[0],
+		EnvoyVersionStatus: EnvoyVersionSupported,
+	}}, scenario.server.ConnectedProxies())
+
+	envoy.Close()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(50 * time.Millisecond):
+		t.Fatalf("timed out waiting for handler to finish")
+	}
+	require.Empty(t, scenario.server.ConnectedProxies())
+}
+
+func TestServer_DeltaAggregatedResources_v3_UnsupportedEnvoyVersion(t *testing.T) {
+	aclResolve := func(id string) (acl.Authorizer, error) { return acl.ManageAll(), nil }
+
+	scenario := newTestServerDeltaScenario(t, aclResolve, "web-sidecar-proxy", "", 0)
+	mgr, errCh, envoy := scenario.mgr, scenario.errCh, scenario.envoy
+
+	mgr.RegisterProxy(t, structs.NewServiceID("web-sidecar-proxy", nil))
+
+	envoy.EnvoyVersion = "1.20.0"
+	envoy.SendDeltaReq(t, xdscommon.ClusterType, nil)
+
+	select {
+	case err := <-errCh:
+		require.Equal(t, codes.InvalidArgument.String(), status.Code(err).String())
+		require.Contains(t, err.Error(), "Envoy 1.20.0 is too old")
+		require.Contains(t, err.Error(), "Supported Envoy versions are "+xdscommon.GetMinEnvoyMajorVersion())
+	case <-time.After(50 * time.Millisecond):
+		t.Fatalf("timed out waiting for handler to finish")
+	}
+	require.Empty(t, scenario.server.ConnectedProxies())
+}
+
 func TestServer_DeltaAggregatedResources_v3_CapacityReached(t *testing.T) {
 	aclResolve := func(id string) (acl.Authorizer, error) { return acl.ManageAll(), nil }
 
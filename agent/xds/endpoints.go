@@ -6,6 +6,7 @@ package xds
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 
 	"github.com/hashicorp/go-hclog"
@@ -881,6 +882,7 @@ func makeLoadAssignment(logger hclog.Logger, cfgSnap *proxycfg.ConfigSnapshot, c
 	}
 
 	setFullFailoverProvisioningFactor := len(endpointGroups) > 1
+	addressFamilyPreference := cfgSnap.GetXDSCommonConfig(logger).AddressFamilyPreference
 
 	var priority uint32
 
@@ -900,16 +902,13 @@ func makeLoadAssignment(logger hclog.Logger, cfgSnap *proxycfg.ConfigSnapshot, c
 
 			for _, ep := range endpoints {
 				// TODO (mesh-gateway) - should we respect the translate_wan_addrs configuration here or just always use the wan for cross-dc?
-				_, addr, port := ep.BestAddress(!localKey.Matches(ep.Node.Datacenter, ep.Node.PartitionOrDefault()))
+				endpoint := makeDualStackEndpoint(ep, !localKey.Matches(ep.Node.Datacenter, ep.Node.PartitionOrDefault()), addressFamilyPreference)
 				healthStatus, weight := calculateEndpointHealthAndWeight(ep, endpointGroup.OnlyPassing)
 
 				if endpointGroup.OverrideHealth != envoy_core_v3.HealthStatus_UNKNOWN {
 					healthStatus = endpointGroup.OverrideHealth
 				}
 
-				endpoint := &envoy_endpoint_v3.Endpoint{
-					Address: response.MakeAddress(addr, port),
-				}
 				es = append(es, &envoy_endpoint_v3.LbEndpoint{
 					HostIdentifier: &envoy_endpoint_v3.LbEndpoint_Endpoint{
 						Endpoint: endpoint,
@@ -939,6 +938,42 @@ func makeLoadAssignment(logger hclog.Logger, cfgSnap *proxycfg.ConfigSnapshot, c
 	return cla
 }
 
+// makeDualStackEndpoint returns the endpoint of a service instance. The
+// instances registered with both an IPv4 and an IPv6 address get the address
+// of the preferred family, and the other one as an additional address so that
+// Envoy can fall back to it.
+func makeDualStackEndpoint(ep structs.CheckServiceNode, wan bool, preference string) *envoy_endpoint_v3.Endpoint {
+	_, addr, port := ep.BestAddress(wan)
+	v4, v6 := ep.DualStackAddresses(wan)
+
+	primary := structs.ServiceAddress{Address: addr, Port: port}
+	switch {
+	case preference == structs.AddressFamilyIPv4 && v4.Address != "":
+		primary = v4
+	case preference == structs.AddressFamilyIPv6 && v6.Address != "":
+		primary = v6
+	}
+
+	endpoint := &envoy_endpoint_v3.Endpoint{
+		Address: response.MakeAddress(primary.Address, primary.Port),
+	}
+
+	// Hostnames are resolved by Envoy, which picks the family on its own.
+	primaryIP := net.ParseIP(primary.Address)
+	if primaryIP == nil {
+		return endpoint
+	}
+	for _, alt := range []structs.ServiceAddress{v4, v6} {
+		if alt.Address == "" || net.ParseIP(alt.Address).Equal(primaryIP) {
+			continue
+		}
+		endpoint.AdditionalAddresses = append(endpoint.AdditionalAddresses, &envoy_endpoint_v3.Endpoint_AdditionalAddress{
+			Address: response.MakeAddress(alt.Address, alt.Port),
+		})
+	}
+	return endpoint
+}
+
 func makeLoadAssignmentEndpointGroup(
 	targets map[string]*structs.DiscoveryTarget,
 	targetHealth map[string]structs.CheckServiceNodes,
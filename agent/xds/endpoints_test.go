@@ -229,3 +229,127 @@ func Test_makeLoadAssignment(t *testing.T) {
 		})
 	}
 }
+
+func Test_makeDualStackEndpoint(t *testing.T) {
+	dualStack := structs.CheckServiceNode{
+		Node: &structs.Node{
+			Node:    "node1",
+			Address: "10.10.10.10",
+		},
+		Service: &structs.NodeService{
+			Service: "web",
+			Address: "10.10.10.11",
+			Port:    1234,
+			TaggedAddresses: map[string]structs.ServiceAddress{
+				structs.TaggedAddressLANIPv4: {Address: "10.10.10.11", Port: 1234},
+				structs.TaggedAddressLANIPv6: {Address: "2001:db8::11", Port: 1234},
+				structs.TaggedAddressWAN:     {Address: "198.18.0.11", Port: 443},
+				structs.TaggedAddressWANIPv4: {Address: "198.18.0.11", Port: 443},
+				structs.TaggedAddressWANIPv6: {Address: "2001:db8:ffff::11", Port: 443},
+			},
+		},
+	}
+	nodeDualStack := structs.CheckServiceNode{
+		Node: &structs.Node{
+			Node:    "node1",
+			Address: "10.10.10.10",
+			TaggedAddresses: map[string]string{
+				structs.TaggedAddressLANIPv4: "10.10.10.10",
+				structs.TaggedAddressLANIPv6: "2001:db8::10",
+			},
+		},
+		Service: &structs.NodeService{
+			Service: "web",
+			Port:    1234,
+		},
+	}
+	hostname := structs.CheckServiceNode{
+		Node: &structs.Node{Node: "node1", Address: "10.10.10.10"},
+		Service: &structs.NodeService{
+			Service: "web",
+			Address: "web.example.com",
+			Port:    1234,
+			TaggedAddresses: map[string]structs.ServiceAddress{
+				structs.TaggedAddressLANIPv6: {Address: "2001:db8::11"},
+			},
+		},
+	}
+
+	additional := func(addr string, port int) []*envoy_endpoint_v3.Endpoint_AdditionalAddress {
+		return []*envoy_endpoint_v3.Endpoint_AdditionalAddress{{Address: response.MakeAddress(addr, port)}}
+	}
+
+	tests := map[string]struct {
+		ep         structs.CheckServiceNode
+		wan        bool
+		preference string
+		want       *envoy_endpoint_v3.Endpoint
+	}{
+		"registered address first": {
+			ep: dualStack,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address:             response.MakeAddress("10.10.10.11", 1234),
+				AdditionalAddresses: additional("2001:db8::11", 1234),
+			},
+		},
+		"ipv6 first": {
+			ep:         dualStack,
+			preference: structs.AddressFamilyIPv6,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address:             response.MakeAddress("2001:db8::11", 1234),
+				AdditionalAddresses: additional("10.10.10.11", 1234),
+			},
+		},
+		"wan ipv6 first": {
+			ep:         dualStack,
+			wan:        true,
+			preference: structs.AddressFamilyIPv6,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address:             response.MakeAddress("2001:db8:ffff::11", 443),
+				AdditionalAddresses: additional("198.18.0.11", 443),
+			},
+		},
+		"wan family addresses default to lan": {
+			ep: structs.CheckServiceNode{
+				Node: dualStack.Node,
+				Service: &structs.NodeService{
+					Service: "web",
+					Address: "10.10.10.11",
+					Port:    1234,
+					TaggedAddresses: map[string]structs.ServiceAddress{
+						structs.TaggedAddressLANIPv4: {Address: "10.10.10.11", Port: 1234},
+						structs.TaggedAddressLANIPv6: {Address: "2001:db8::11", Port: 1234},
+						structs.TaggedAddressWAN:     {Address: "198.18.0.11", Port: 443},
+						structs.TaggedAddressWANIPv4: {Address: "10.10.10.11", Port: 1234},
+						structs.TaggedAddressWANIPv6: {Address: "2001:db8:ffff::11", Port: 443},
+					},
+				},
+			},
+			wan: true,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address:             response.MakeAddress("198.18.0.11", 443),
+				AdditionalAddresses: additional("2001:db8:ffff::11", 443),
+			},
+		},
+		"node addresses": {
+			ep:         nodeDualStack,
+			preference: structs.AddressFamilyIPv6,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address:             response.MakeAddress("2001:db8::10", 1234),
+				AdditionalAddresses: additional("10.10.10.10", 1234),
+			},
+		},
+		"hostname": {
+			ep:         hostname,
+			preference: structs.AddressFamilyIPv4,
+			want: &envoy_endpoint_v3.Endpoint{
+				Address: response.MakeAddress("web.example.com", 1234),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, makeDualStackEndpoint(tc.ep, tc.wan, tc.preference))
+		})
+	}
+}
@@ -50,6 +50,10 @@ func (ft discoChainTargets) groupedTargets() ([]discoChainTargetGroup, error) {
 	switch ft.failoverPolicy.Mode {
 	case "sequential", "":
 		return ft.sequential()
+	case "order-by-health":
+		// The targets were already sorted by health when the discovery chain
+		// was served, so they are tried in the order given.
+		return ft.sequential()
 	case "order-by-locality":
 		return ft.orderByLocality()
 	default:
@@ -136,6 +140,9 @@ func (s *ResourceGenerator) mapDiscoChainTargets(cfgSnap *proxycfg.ConfigSnapsho
 		if err != nil {
 			return failoverTargets, fmt.Errorf("failed to inject SAN matcher rules for cluster %q: %v", sni, err)
 		}
+		if targetUID.Peer == "" {
+			injectRevocationList(commonTLSContext, cfgSnap.RevocationListPEMs())
+		}
 
 		tlsContext := &envoy_tls_v3.UpstreamTlsContext{
 			CommonTlsContext: commonTLSContext,
@@ -25,6 +25,7 @@ import (
 	envoy_tls_inspector_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	envoy_connection_limit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/connection_limit/v3"
 	envoy_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoy_network_local_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
 	envoy_sni_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/sni_cluster/v3"
 	envoy_tcp_proxy_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	envoy_tls_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -1176,6 +1177,12 @@ func createDownstreamTransportSocketForConnectTLS(cfgSnap *proxycfg.ConfigSnapsh
 	if tlsContext != nil {
 		// Configure alpn protocols on CommonTLSContext
 		tlsContext.AlpnProtocols = getAlpnProtocols(proxyCfg.Protocol)
+
+		// The peers of other trust domains are verified by the SPIFFE
+		// validator, and there are no revocation lists for their signers.
+		if len(peerBundles) == 0 {
+			injectRevocationList(tlsContext, cfgSnap.RevocationListPEMs())
+		}
 	}
 
 	// Inject peering trust bundles if this service is exported to peered clusters.
@@ -1338,6 +1345,9 @@ func (s *ResourceGenerator) makeInboundListener(cfgSnap *proxycfg.ConfigSnapshot
 	}
 	l = makeListener(opts)
 	s.injectConnectionBalanceConfig(cfg.BalanceInboundConnections, l)
+	if preset := cfg.GetResourcePreset(); preset != nil {
+		l.PerConnectionBufferLimitBytes = response.MakeUint32Value(int(preset.PerConnectionBufferLimitBytes))
+	}
 
 	var tracing *envoy_http_v3.HttpConnectionManager_Tracing
 	if cfg.ListenerTracingJSON != "" {
@@ -1413,6 +1423,26 @@ func (s *ResourceGenerator) makeInboundListener(cfgSnap *proxycfg.ConfigSnapshot
 		}
 	}
 
+	// If an inbound connection rate limit is set, inject a local rate limit
+	// filter closing the connections in excess of the rate.
+	if cfg.InboundConnectionRateLimit > 0 {
+		connectionRateLimitFilter, err := makeConnectionRateLimitFilter(cfg.InboundConnectionRateLimit)
+		if err != nil {
+			return nil, err
+		}
+		if len(l.FilterChains) > 0 {
+			l.FilterChains[0].Filters = append(l.FilterChains[0].Filters, connectionRateLimitFilter)
+		} else {
+			l.FilterChains = []*envoy_listener_v3.FilterChain{
+				{
+					Filters: []*envoy_listener_v3.Filter{
+						connectionRateLimitFilter,
+					},
+				},
+			}
+		}
+	}
+
 	filter, err := makeListenerFilter(filterOpts)
 	if err != nil {
 		return nil, err
@@ -1770,6 +1800,7 @@ func (s *ResourceGenerator) makeFilterChainTerminatingGateway(cfgSnap *proxycfg.
 		),
 		RequireClientCertificate: &wrapperspb.BoolValue{Value: true},
 	}
+	injectRevocationList(tlsContext.CommonTlsContext, cfgSnap.RevocationListPEMs())
 
 	// For TCP connections, TLS is not terminated at the mesh gateway but is instead proxied through;
 	// therefore, we need to account for callers from other datacenters when setting up our filter chain.
@@ -2436,6 +2467,20 @@ func makeConnectionLimitFilter(limit int) (*envoy_listener_v3.Filter, error) {
 	return makeFilter("envoy.filters.network.connection_limit", cfg)
 }
 
+// makeConnectionRateLimitFilter returns a local rate limit filter accepting
+// up to perSecond new connections per second.
+func makeConnectionRateLimitFilter(perSecond int) (*envoy_listener_v3.Filter, error) {
+	cfg := &envoy_network_local_ratelimit_v3.LocalRateLimit{
+		StatPrefix: "inbound_connection_rate_limit",
+		TokenBucket: &envoy_type_v3.TokenBucket{
+			MaxTokens:     uint32(perSecond),
+			TokensPerFill: wrapperspb.UInt32(uint32(perSecond)),
+			FillInterval:  durationpb.New(time.Second),
+		},
+	}
+	return makeFilter("envoy.filters.network.local_ratelimit", cfg)
+}
+
 func makeStatPrefix(prefix, filterName string) string {
 	// Replace colons here because Envoy does that in the metrics for the actual
 	// clusters but doesn't in the stat prefix here while dashboards assume they
@@ -2696,6 +2741,26 @@ func makeCommonTLSContext(
 	}
 }
 
+// injectRevocationList makes the validation context of tlsContext check the
+// leaf certs of peers against the given PEM encoded certificate revocation
+// lists. The lists are signed by the leaf signing certs, so only the leaf
+// certs are checked.
+func injectRevocationList(tlsContext *envoy_tls_v3.CommonTlsContext, crlPEMs string) {
+	if tlsContext == nil || crlPEMs == "" {
+		return
+	}
+	validationCtx, ok := tlsContext.ValidationContextType.(*envoy_tls_v3.CommonTlsContext_ValidationContext)
+	if !ok {
+		return
+	}
+	validationCtx.ValidationContext.Crl = &envoy_core_v3.DataSource{
+		Specifier: &envoy_core_v3.DataSource_InlineString{
+			InlineString: crlPEMs,
+		},
+	}
+	validationCtx.ValidationContext.OnlyVerifyLeafCertCrl = true
+}
+
 func makeDownstreamTLSTransportSocket(tlsContext *envoy_tls_v3.DownstreamTlsContext) (*envoy_core_v3.TransportSocket, error) {
 	if tlsContext == nil {
 		return nil, nil
@@ -50,6 +50,10 @@ func (ft discoChainTargets) groupedTargets() ([]discoChainTargetGroup, error) {
 	switch ft.failoverPolicy.Mode {
 	case "sequential", "":
 		return ft.sequential()
+	case "order-by-health":
+		// The targets were already sorted by health when the discovery chain
+		// was served, so they are tried in the order given.
+		return ft.sequential()
 	case "order-by-locality":
 		return ft.orderByLocality()
 	default:
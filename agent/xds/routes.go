@@ -164,7 +164,7 @@ func (s *ResourceGenerator) routesForTerminatingGateway(cfgSnap *proxycfg.Config
 			// Routes can only be defined for HTTP services
 			continue
 		}
-		routes, err := s.makeRoutes(cfgSnap, svc, clusterName, autoHostRewrite)
+		routes, err := s.makeRoutes(cfgSnap, svc, clusterName, autoHostRewrite, service.HostRewrite)
 		if err != nil {
 			return nil, err
 		}
@@ -192,7 +192,7 @@ func (s *ResourceGenerator) routesForTerminatingGateway(cfgSnap *proxycfg.Config
 				// Routes can only be defined for HTTP services
 				continue
 			}
-			routes, err := s.makeRoutes(cfgSnap, svc, clusterName, false)
+			routes, err := s.makeRoutes(cfgSnap, svc, clusterName, false, cfgSnap.TerminatingGateway.DestinationServices[svc].HostRewrite)
 			if err != nil {
 				return nil, err
 			}
@@ -210,6 +210,7 @@ func (s *ResourceGenerator) makeRoutes(
 	svc structs.ServiceName,
 	clusterName string,
 	autoHostRewrite bool,
+	hostRewrite string,
 ) ([]proto.Message, error) {
 	resolver, hasResolver := cfgSnap.TerminatingGateway.ServiceResolvers[svc]
 
@@ -223,7 +224,7 @@ func (s *ResourceGenerator) makeRoutes(
 	if resolver.LoadBalancer != nil {
 		lb = resolver.LoadBalancer
 	}
-	route, err := makeNamedDefaultRouteWithLB(clusterName, lb, resolver.RequestTimeout, autoHostRewrite)
+	route, err := makeNamedDefaultRouteWithLB(clusterName, lb, resolver.RequestTimeout, autoHostRewrite, hostRewrite)
 	if err != nil {
 		s.Logger.Error("failed to make route", "cluster", clusterName, "error", err)
 		return nil, err
@@ -233,7 +234,7 @@ func (s *ResourceGenerator) makeRoutes(
 	// If there is a service-resolver for this service then also setup routes for each subset
 	for name := range resolver.Subsets {
 		clusterName = connect.ServiceSNI(svc.Name, name, svc.NamespaceOrDefault(), svc.PartitionOrDefault(), cfgSnap.Datacenter, cfgSnap.Roots.TrustDomain)
-		route, err := makeNamedDefaultRouteWithLB(clusterName, lb, resolver.RequestTimeout, autoHostRewrite)
+		route, err := makeNamedDefaultRouteWithLB(clusterName, lb, resolver.RequestTimeout, autoHostRewrite, hostRewrite)
 		if err != nil {
 			s.Logger.Error("failed to make route", "cluster", clusterName, "error", err)
 			return nil, err
@@ -287,7 +288,7 @@ func (s *ResourceGenerator) routesForMeshGateway(cfgSnap *proxycfg.ConfigSnapsho
 	return resources, nil
 }
 
-func makeNamedDefaultRouteWithLB(clusterName string, lb *structs.LoadBalancer, timeout time.Duration, autoHostRewrite bool) (*envoy_route_v3.RouteConfiguration, error) {
+func makeNamedDefaultRouteWithLB(clusterName string, lb *structs.LoadBalancer, timeout time.Duration, autoHostRewrite bool, hostRewrite string) (*envoy_route_v3.RouteConfiguration, error) {
 	action := makeRouteActionFromName(clusterName)
 
 	if err := injectLBToRouteAction(lb, action.Route); err != nil {
@@ -295,7 +296,11 @@ func makeNamedDefaultRouteWithLB(clusterName string, lb *structs.LoadBalancer, t
 	}
 
 	// Configure Envoy to rewrite Host header
-	if autoHostRewrite {
+	if hostRewrite != "" {
+		action.Route.HostRewriteSpecifier = &envoy_route_v3.RouteAction_HostRewriteLiteral{
+			HostRewriteLiteral: hostRewrite,
+		}
+	} else if autoHostRewrite {
 		action.Route.HostRewriteSpecifier = &envoy_route_v3.RouteAction_AutoHostRewrite{
 			AutoHostRewrite: response.MakeBoolValue(true),
 		}
@@ -501,6 +506,16 @@ func (s *ResourceGenerator) routesForAPIGateway(cfgSnap *proxycfg.ConfigSnapshot
 			listenerRoute.VirtualHosts = append(listenerRoute.VirtualHosts, virtualHost)
 		}
 
+		// Plain HTTP listeners answer the pending ACME HTTP-01 challenges
+		// of the certificates the servers obtain for the gateway, ahead of
+		// the routes of the virtual hosts.
+		if len(readyListener.listenerCfg.TLS.Certificates) == 0 {
+			challengeRoutes := makeACMEChallengeRoutes(cfgSnap.APIGateway.BoundGatewayConfig)
+			for _, virtualHost := range listenerRoute.VirtualHosts {
+				virtualHost.Routes = append(slices.Clone(challengeRoutes), virtualHost.Routes...)
+			}
+		}
+
 		if len(listenerRoute.VirtualHosts) > 0 {
 			// Build up the virtual hosts in a deterministic way
 			slices.SortStableFunc(listenerRoute.VirtualHosts, func(a, b *envoy_route_v3.VirtualHost) int {
@@ -516,6 +531,39 @@ func (s *ResourceGenerator) routesForAPIGateway(cfgSnap *proxycfg.ConfigSnapshot
 	return result, nil
 }
 
+// makeACMEChallengeRoutes returns the routes answering the pending ACME
+// HTTP-01 challenges recorded on the bound API gateway.
+func makeACMEChallengeRoutes(bound *structs.BoundAPIGatewayConfigEntry) []*envoy_route_v3.Route {
+	if bound == nil {
+		return nil
+	}
+	challenges := bound.ACMEChallenges()
+	tokens := maps.Keys(challenges)
+	sort.Strings(tokens)
+
+	routes := make([]*envoy_route_v3.Route, 0, len(tokens))
+	for _, token := range tokens {
+		routes = append(routes, &envoy_route_v3.Route{
+			Match: &envoy_route_v3.RouteMatch{
+				PathSpecifier: &envoy_route_v3.RouteMatch_Path{
+					Path: "/.well-known/acme-challenge/" + token,
+				},
+			},
+			Action: &envoy_route_v3.Route_DirectResponse{
+				DirectResponse: &envoy_route_v3.DirectResponseAction{
+					Status: 200,
+					Body: &envoy_core_v3.DataSource{
+						Specifier: &envoy_core_v3.DataSource_InlineString{
+							InlineString: challenges[token],
+						},
+					},
+				},
+			},
+		})
+	}
+	return routes
+}
+
 func buildHTTPRouteUpstream(route structs.HTTPRouteConfigEntry, listener structs.APIGatewayListener) structs.Upstream {
 	return structs.Upstream{
 		DestinationName:      route.GetName(),
@@ -7,11 +7,13 @@ import (
 	"testing"
 	"time"
 
+	envoy_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_route_v3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/private/prototest"
 )
 
 func TestEnvoyLBConfig_InjectToRouteAction(t *testing.T) {
@@ -280,3 +282,38 @@ func TestEnvoyLBConfig_InjectToRouteAction(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeACMEChallengeRoutes(t *testing.T) {
+	require.Empty(t, makeACMEChallengeRoutes(nil))
+	require.Empty(t, makeACMEChallengeRoutes(&structs.BoundAPIGatewayConfigEntry{
+		Meta: map[string]string{"owner": "team-a"},
+	}))
+
+	routes := makeACMEChallengeRoutes(&structs.BoundAPIGatewayConfigEntry{
+		Meta: map[string]string{
+			"owner":                                  "team-a",
+			structs.MetaACMEChallengePrefix + "tok2": "tok2.thumbprint",
+			structs.MetaACMEChallengePrefix + "tok1": "tok1.thumbprint",
+		},
+	})
+	require.Len(t, routes, 2)
+	for i, token := range []string{"tok1", "tok2"} {
+		prototest.AssertDeepEqual(t, &envoy_route_v3.Route{
+			Match: &envoy_route_v3.RouteMatch{
+				PathSpecifier: &envoy_route_v3.RouteMatch_Path{
+					Path: "/.well-known/acme-challenge/" + token,
+				},
+			},
+			Action: &envoy_route_v3.Route_DirectResponse{
+				DirectResponse: &envoy_route_v3.DirectResponseAction{
+					Status: 200,
+					Body: &envoy_core_v3.DataSource{
+						Specifier: &envoy_core_v3.DataSource_InlineString{
+							InlineString: token + ".thumbprint",
+						},
+					},
+				},
+			},
+		}, routes[i])
+	}
+}
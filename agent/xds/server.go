@@ -108,7 +108,8 @@ type Server struct {
 	// ResourceMapMutateFn exclusively exists for testing purposes.
 	ResourceMapMutateFn func(resourceMap *xdscommon.IndexedResources)
 
-	activeStreams *activeStreamCounters
+	activeStreams    *activeStreamCounters
+	connectedProxies *connectedProxies
 }
 
 // activeStreamCounters tracks various stream-related metrics.
@@ -158,6 +159,7 @@ func NewServer(
 		CfgFetcher:         cfgFetcher,
 		AuthCheckFrequency: DefaultAuthCheckFrequency,
 		activeStreams:      &activeStreamCounters{},
+		connectedProxies:   newConnectedProxies(),
 	}
 }
 
@@ -28,6 +28,7 @@ const (
 	ACLTemplatedPolicyWorkloadIdentityName = "builtin/workload-identity"
 	ACLTemplatedPolicyAPIGatewayName       = "builtin/api-gateway"
 	ACLTemplatedPolicyNomadClientName      = "builtin/nomad-client"
+	ACLTemplatedPolicyNamespaceAdminName   = "builtin/namespace-admin"
 )
 
 type ACLLink struct {
@@ -119,6 +120,157 @@ type ACLTokenListEntry struct {
 	AuthMethodNamespace string `json:",omitempty"`
 }
 
+// ACLTokenUsage is the usage of a token over the HTTP API of the agents of a
+// datacenter, tracked when acl.enable_token_usage_tracking is set.
+type ACLTokenUsage struct {
+	AccessorID string
+
+	// Count is the number of requests made with the token.
+	Count uint64
+
+	// LastUsed is when the token was last used, zero if it was not used.
+	LastUsed time.Time
+
+	// SourceAddrs are the first few addresses the requests were made from.
+	SourceAddrs []string `json:",omitempty"`
+
+	// TrackingSince is when the leader of the datacenter started tracking
+	// the usage.
+	TrackingSince time.Time
+}
+
+// ACLStaleToken is a token which was not used recently.
+type ACLStaleToken struct {
+	AccessorID  string
+	Description string
+	Local       bool
+	CreateTime  time.Time
+
+	// LastUsed is when the token was last used, or nil if it was not used
+	// since the tracking started.
+	LastUsed *time.Time `json:",omitempty"`
+
+	// Namespace is the namespace the token is associated with.
+	// Namespacing is a Consul Enterprise feature.
+	Namespace string `json:",omitempty"`
+
+	// Partition is the partition the token is associated with.
+	// Partitions are a Consul Enterprise feature.
+	Partition string `json:",omitempty"`
+}
+
+// ACLStaleTokens lists the tokens which were not used recently.
+type ACLStaleTokens struct {
+	Tokens []*ACLStaleToken
+
+	// TrackingSince is when the leader of the datacenter started tracking
+	// the usage. Tokens not used since then are reported as never used.
+	TrackingSince time.Time
+}
+
+// ACLBreakGlassInitRequest is used to initialize the break-glass seal.
+type ACLBreakGlassInitRequest struct {
+	// Shares is the number of key shares to hand to the operators.
+	Shares int
+
+	// Threshold is the number of shares required to activate the token.
+	Threshold int
+
+	// TTL is how long the activated token is valid.
+	TTL time.Duration
+}
+
+func (r *ACLBreakGlassInitRequest) MarshalJSON() ([]byte, error) {
+	type Alias ACLBreakGlassInitRequest
+	exported := &struct {
+		TTL string `json:",omitempty"`
+		*Alias
+	}{
+		TTL:   r.TTL.String(),
+		Alias: (*Alias)(r),
+	}
+	if r.TTL == 0 {
+		exported.TTL = ""
+	}
+
+	return json.Marshal(exported)
+}
+
+func (r *ACLBreakGlassInitRequest) UnmarshalJSON(data []byte) error {
+	type Alias ACLBreakGlassInitRequest
+	aux := &struct {
+		TTL string
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if aux.TTL != "" {
+		if r.TTL, err = time.ParseDuration(aux.TTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ACLBreakGlassInitResponse holds the key shares of the break-glass seal,
+// which are only returned once.
+type ACLBreakGlassInitResponse struct {
+	Shares []string
+}
+
+// ACLBreakGlassUnsealRequest is used to submit a key share of the break-glass
+// seal.
+type ACLBreakGlassUnsealRequest struct {
+	Share string
+}
+
+// ACLBreakGlassStatus is the status of the break-glass seal.
+type ACLBreakGlassStatus struct {
+	// Initialized is whether the seal was initialized, the other fields are
+	// empty otherwise.
+	Initialized bool
+
+	Shares     int
+	Threshold  int
+	TTL        time.Duration
+	CreateTime time.Time
+
+	// Progress is the number of shares submitted towards the threshold.
+	Progress int
+}
+
+func (s *ACLBreakGlassStatus) UnmarshalJSON(data []byte) error {
+	type Alias ACLBreakGlassStatus
+	aux := &struct {
+		TTL string
+		*Alias
+	}{
+		Alias: (*Alias)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if aux.TTL != "" {
+		if s.TTL, err = time.ParseDuration(aux.TTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ACLBreakGlassUnsealResponse holds the status of the break-glass seal after
+// a share was submitted, and the break-glass token once a threshold of
+// shares was submitted.
+type ACLBreakGlassUnsealResponse struct {
+	Status ACLBreakGlassStatus
+	Token  *ACLToken `json:",omitempty"`
+}
+
 // ACLEntry is used to represent a legacy ACL token
 // The legacy tokens are deprecated.
 type ACLEntry struct {
@@ -946,6 +1098,59 @@ func (a *ACL) TokenList(q *QueryOptions) ([]*ACLTokenListEntry, *QueryMeta, erro
 	return entries, qm, nil
 }
 
+// TokenUsage returns the usage of a token in the datacenter. The accessorID
+// parameter must be a valid Accessor ID of an existing token.
+func (a *ACL) TokenUsage(accessorID string, q *QueryOptions) (*ACLTokenUsage, *QueryMeta, error) {
+	r := a.c.newRequest("GET", "/v1/acl/token/"+accessorID+"/usage")
+	r.setQueryOptions(q)
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out ACLTokenUsage
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+
+	return &out, qm, nil
+}
+
+// StaleTokens lists the tokens which were not used for the given duration.
+// The server default of 30 days is used when unusedFor is zero.
+func (a *ACL) StaleTokens(unusedFor time.Duration, q *QueryOptions) (*ACLStaleTokens, *QueryMeta, error) {
+	r := a.c.newRequest("GET", "/v1/acl/tokens/stale")
+	r.setQueryOptions(q)
+	if unusedFor > 0 {
+		r.params.Set("unused-for", unusedFor.String())
+	}
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out ACLStaleTokens
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+
+	return &out, qm, nil
+}
+
 // TokenListFiltered lists all tokens that match the given filter options.
 // The listing does not contain any SecretIDs as those may only be retrieved by a call to TokenRead.
 func (a *ACL) TokenListFiltered(t ACLTokenFilterOptions, q *QueryOptions) ([]*ACLTokenListEntry, *QueryMeta, error) {
@@ -1742,3 +1947,73 @@ func (a *ACL) TemplatedPolicyPreview(tp *ACLTemplatedPolicy, q *WriteOptions) (*
 	}
 	return &out, wm, nil
 }
+
+// BreakGlassInit initializes the break-glass seal, replacing the previous one
+// if any, and returns the key shares to hand to the operators. This requires
+// acl:write.
+func (a *ACL) BreakGlassInit(req *ACLBreakGlassInitRequest, q *WriteOptions) (*ACLBreakGlassInitResponse, *WriteMeta, error) {
+	r := a.c.newRequest("PUT", "/v1/acl/break-glass/init")
+	r.setWriteOptions(q)
+	r.obj = req
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	wm := &WriteMeta{RequestTime: rtt}
+	var out ACLBreakGlassInitResponse
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// BreakGlassUnseal submits a key share of the break-glass seal. The response
+// holds the break-glass token once a threshold of shares was submitted. This
+// doesn't require any ACL token.
+func (a *ACL) BreakGlassUnseal(share string, q *WriteOptions) (*ACLBreakGlassUnsealResponse, *WriteMeta, error) {
+	r := a.c.newRequest("PUT", "/v1/acl/break-glass/unseal")
+	r.setWriteOptions(q)
+	r.obj = &ACLBreakGlassUnsealRequest{Share: share}
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	wm := &WriteMeta{RequestTime: rtt}
+	var out ACLBreakGlassUnsealResponse
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// BreakGlassStatus returns the status of the break-glass seal. This doesn't
+// require any ACL token.
+func (a *ACL) BreakGlassStatus(q *QueryOptions) (*ACLBreakGlassStatus, *QueryMeta, error) {
+	r := a.c.newRequest("GET", "/v1/acl/break-glass")
+	r.setQueryOptions(q)
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out ACLBreakGlassStatus
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, qm, nil
+}
@@ -10,6 +10,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
+
+	"github.com/hashicorp/serf/coordinate"
 )
 
 // ServiceKind is the kind of service being registered.
@@ -241,6 +244,45 @@ type AgentMember struct {
 	DelegateCur uint8
 }
 
+// The types of AgentMemberEvent.
+const (
+	MemberEventJoin   = "join"
+	MemberEventLeave  = "leave"
+	MemberEventFailed = "failed"
+	MemberEventReap   = "reap"
+)
+
+// AgentMemberEvent is a change of the membership of the LAN gossip pool
+// observed by an agent.
+type AgentMemberEvent struct {
+	// Index is the position of the event in the events observed by the
+	// agent since it started.
+	Index uint64
+	Time  time.Time
+
+	// Type is one of the MemberEvent* types. Reason details it, as one of
+	// joined, recovered, rejoined, left, force-left, failure-detected,
+	// reconnect-timeout, tombstone-timeout or pruned.
+	Type   string
+	Reason string
+
+	// Name, Addr, Port, Tags and Status describe the member as of the event.
+	Name   string
+	Addr   string
+	Port   uint16
+	Tags   map[string]string
+	Status string
+
+	// Coordinate is the last network coordinate of the member known by the
+	// agent, if any.
+	Coordinate *coordinate.Coordinate `json:",omitempty"`
+
+	// DetectedBy is the name of the agent which observed the event, and
+	// DetectedByCoordinate its network coordinate at the time.
+	DetectedBy           string
+	DetectedByCoordinate *coordinate.Coordinate `json:",omitempty"`
+}
+
 // ACLMode returns the ACL mode this agent is operating in.
 func (m *AgentMember) ACLMode() MemberACLMode {
 	mode := m.Tags[MemberTagKeyACLMode]
@@ -359,6 +401,8 @@ type AgentServiceCheck struct {
 	GRPCUseTLS             bool                `json:",omitempty"`
 	H2PING                 string              `json:",omitempty"`
 	H2PingUseTLS           bool                `json:",omitempty"`
+	Plugin                 string              `json:",omitempty"`
+	PluginConfig           map[string]string   `json:",omitempty"`
 	AliasNode              string              `json:",omitempty"`
 	AliasService           string              `json:",omitempty"`
 	SuccessBeforePassing   int                 `json:",omitempty"`
@@ -429,6 +473,94 @@ type AgentAuthorize struct {
 	Reason     string
 }
 
+// AgentConnectProxies is the response of the agent's connect proxies endpoint.
+type AgentConnectProxies struct {
+	// SupportedVersions lists the supported Envoy versions, most recent first.
+	SupportedVersions []string
+
+	// RecommendedVersion is the Envoy version new proxies should run, for
+	// example to pick the matching Envoy image.
+	RecommendedVersion string
+
+	// Proxies are the proxies connected to the agent, sorted by service.
+	Proxies []*AgentConnectProxy
+}
+
+// AgentConnectProxy is a proxy connected to the agent's xDS server.
+type AgentConnectProxy struct {
+	ProxyID   string
+	Service   string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
+	Kind      ServiceKind
+
+	// EnvoyVersion is the version reported by the proxy, if any.
+	EnvoyVersion string `json:",omitempty"`
+
+	// EnvoyVersionStatus is "supported", "untested" for Envoy versions more
+	// recent than the supported ones, or "unknown".
+	EnvoyVersionStatus string
+}
+
+// AgentCacheType describes the usage of one of the agent's cache types.
+type AgentCacheType struct {
+	Name    string
+	Entries int
+	Hits    uint64
+	Misses  uint64
+	HitRate float64
+
+	// AgeDistribution counts the entries by the time since they were last
+	// fetched, keyed by the upper bound of each bucket ("1m", "10m", "1h"
+	// and "+Inf").
+	AgeDistribution map[string]int
+
+	// OldestAge is the time since the least recently fetched entry was
+	// fetched.
+	OldestAge time.Duration
+}
+
+// AgentCacheInvalidateResponse is the response structure for invalidating
+// agent cache entries.
+type AgentCacheInvalidateResponse struct {
+	Invalidated int
+}
+
+// AgentLogLevel describes the log level of the agent and the levels set at
+// runtime for its subsystems.
+type AgentLogLevel struct {
+	Level string
+
+	// Subsystems is keyed by the name of the subsystem, such as "dns",
+	// "raft", "xds" or "controller/<name>".
+	Subsystems map[string]string
+}
+
+// AgentLogLevelRequest sets the log level of a subsystem of the agent. An
+// empty Subsystem sets the level of the agent itself, and an empty Level
+// resets the subsystem to the level of the agent.
+type AgentLogLevelRequest struct {
+	Subsystem string
+	Level     string
+}
+
+// MonitorOptions are the options of MonitorWithOptions.
+type MonitorOptions struct {
+	// LogLevel is the minimum level of the streamed logs. It defaults to
+	// INFO.
+	LogLevel string
+
+	// LogJSON streams the logs in JSON format.
+	LogJSON bool
+
+	// Subsystem limits the stream to the logs of a subsystem, such as "dns",
+	// "raft", "xds" or "controller/<name>".
+	Subsystem string
+
+	// Recent streams the recent logs the agent kept before the new ones.
+	Recent bool
+}
+
 // ConnectProxyConfig is the response structure for agent-local proxy
 // configuration.
 type ConnectProxyConfig struct {
@@ -577,6 +709,85 @@ func (a *Agent) Reload() error {
 	return nil
 }
 
+// Cache returns the usage of each of the agent's cache types.
+func (a *Agent) Cache() ([]*AgentCacheType, error) {
+	r := a.c.newRequest("GET", "/v1/agent/cache")
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+	var out []*AgentCacheType
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CacheInvalidate evicts the agent's cache entries of the given type so they
+// are fetched again from the servers on their next use. If datacenter or
+// peer are non-empty, only the entries for that datacenter or peer are
+// evicted. It returns the number of evicted entries.
+func (a *Agent) CacheInvalidate(cacheType, datacenter, peer string) (int, error) {
+	r := a.c.newRequest("DELETE", "/v1/agent/cache/"+cacheType)
+	// The client's default datacenter must not narrow the invalidation.
+	r.params.Del("dc")
+	if datacenter != "" {
+		r.params.Set("dc", datacenter)
+	}
+	if peer != "" {
+		r.params.Set("peer", peer)
+	}
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return 0, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return 0, err
+	}
+	var out AgentCacheInvalidateResponse
+	if err := decodeBody(resp, &out); err != nil {
+		return 0, err
+	}
+	return out.Invalidated, nil
+}
+
+// LogLevel returns the log level of the agent and the levels set at runtime
+// for its subsystems.
+func (a *Agent) LogLevel() (*AgentLogLevel, error) {
+	r := a.c.newRequest("GET", "/v1/agent/log-level")
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+	var out AgentLogLevel
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetLogLevel sets the log level of a subsystem of the agent at runtime,
+// without reloading its configuration.
+func (a *Agent) SetLogLevel(req *AgentLogLevelRequest) error {
+	r := a.c.newRequest("PUT", "/v1/agent/log-level")
+	r.obj = req
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return err
+	}
+	defer closeResponseBody(resp)
+	return requireOK(resp)
+}
+
 // NodeName is used to get the node name of the agent
 func (a *Agent) NodeName() (string, error) {
 	if a.nodeName != "" {
@@ -816,6 +1027,31 @@ func (a *Agent) MembersOpts(opts MembersOpts) ([]*AgentMember, error) {
 	return out, nil
 }
 
+// MemberEvents returns the changes of the membership of the LAN gossip pool
+// observed by the agent. It supports blocking queries on the index of the
+// events.
+func (a *Agent) MemberEvents(q *QueryOptions) ([]*AgentMemberEvent, *QueryMeta, error) {
+	r := a.c.newRequest("GET", "/v1/agent/members/events")
+	r.setQueryOptions(q)
+	rtt, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out []*AgentMemberEvent
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
 // ServiceRegister is used to register a new service with
 // the local agent
 func (a *Agent) ServiceRegister(service *AgentServiceRegistration) error {
@@ -1142,6 +1378,27 @@ func (a *Agent) ConnectAuthorize(auth *AgentAuthorizeParams) (*AgentAuthorize, e
 	return &out, nil
 }
 
+// ConnectProxies returns the proxies connected to the agent along with their
+// Envoy version.
+func (a *Agent) ConnectProxies(q *QueryOptions) (*AgentConnectProxies, error) {
+	r := a.c.newRequest("GET", "/v1/agent/connect/proxies")
+	r.setQueryOptions(q)
+	_, resp, err := a.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+
+	var out AgentConnectProxies
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // ConnectCARoots returns the list of roots.
 func (a *Agent) ConnectCARoots(q *QueryOptions) (*CARootList, *QueryMeta, error) {
 	r := a.c.newRequest("GET", "/v1/agent/connect/ca/roots")
@@ -1270,23 +1527,31 @@ func (a *Agent) DisableNodeMaintenance() error {
 // log stream. An empty string will be sent down the given channel when there's
 // nothing left to stream, after which the caller should close the stopCh.
 func (a *Agent) Monitor(loglevel string, stopCh <-chan struct{}, q *QueryOptions) (chan string, error) {
-	return a.monitor(loglevel, false, stopCh, q)
+	return a.MonitorWithOptions(&MonitorOptions{LogLevel: loglevel}, stopCh, q)
 }
 
 // MonitorJSON is like Monitor except it returns logs in JSON format.
 func (a *Agent) MonitorJSON(loglevel string, stopCh <-chan struct{}, q *QueryOptions) (chan string, error) {
-	return a.monitor(loglevel, true, stopCh, q)
+	return a.MonitorWithOptions(&MonitorOptions{LogLevel: loglevel, LogJSON: true}, stopCh, q)
 }
 
-func (a *Agent) monitor(loglevel string, logJSON bool, stopCh <-chan struct{}, q *QueryOptions) (chan string, error) {
+// MonitorWithOptions is like Monitor, with the options to filter the logs by
+// subsystem and to include the recent logs of the agent.
+func (a *Agent) MonitorWithOptions(opts *MonitorOptions, stopCh <-chan struct{}, q *QueryOptions) (chan string, error) {
 	r := a.c.newRequest("GET", "/v1/agent/monitor")
 	r.setQueryOptions(q)
-	if loglevel != "" {
-		r.params.Add("loglevel", loglevel)
+	if opts.LogLevel != "" {
+		r.params.Add("loglevel", opts.LogLevel)
 	}
-	if logJSON {
+	if opts.LogJSON {
 		r.params.Set("logjson", "true")
 	}
+	if opts.Subsystem != "" {
+		r.params.Set("subsystem", opts.Subsystem)
+	}
+	if opts.Recent {
+		r.params.Set("recent", "true")
+	}
 	_, resp, err := a.c.doRequest(r)
 	if err != nil {
 		return nil, err
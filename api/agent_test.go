@@ -1766,6 +1766,21 @@ func TestAPI_AgentUpdateToken(t *testing.T) {
 	})
 }
 
+func TestAPI_AgentConnectProxies(t *testing.T) {
+	t.Parallel()
+
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	agent := c.Agent()
+	s.WaitForSerfCheck(t)
+	out, err := agent.ConnectProxies(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, out.SupportedVersions)
+	require.Equal(t, out.SupportedVersions[0], out.RecommendedVersion)
+	require.Empty(t, out.Proxies)
+}
+
 func TestAPI_AgentConnectCARoots_empty(t *testing.T) {
 	t.Parallel()
 
@@ -141,6 +141,11 @@ type QueryOptions struct {
 	// read.
 	RequireConsistent bool
 
+	// BoundedStaleness allows any Consul server (non-leader) to service a read
+	// as long as its data is at most this old, otherwise the read is serviced
+	// by the leader. It implies AllowStale.
+	BoundedStaleness time.Duration
+
 	// UseCache requests that the agent cache results locally. See
 	// https://www.consul.io/api/features/caching.html for more details on the
 	// semantics.
@@ -186,6 +191,13 @@ type QueryOptions struct {
 	// for the sort.
 	Near string
 
+	// Sort is used to sort the results of the catalog and health list
+	// endpoints by "node", "modify-index", "create-index" or, for the health
+	// endpoints, "status". Prefixing the key with "-" sorts the results in
+	// descending order. When Near is also set, the results with the same key
+	// stay sorted by round trip time.
+	Sort string
+
 	// NodeMeta is used to filter results by nodes with the given
 	// metadata key/value pairs. Currently, only one key/value pair can
 	// be provided for filtering.
@@ -218,6 +230,12 @@ type QueryOptions struct {
 	// especially when the service might not be written into the catalog that way.
 	MergeCentralConfig bool
 
+	// IncludeSidecarProxy joins each instance returned by the health service
+	// endpoint with the health and xDS status of its sidecar proxy. Combined
+	// with passing only, the instances whose sidecar proxy is not passing are
+	// left out.
+	IncludeSidecarProxy bool
+
 	// Global is used to request information from all datacenters. Currently only
 	// used for operator usage requests.
 	Global bool
@@ -744,6 +762,19 @@ func NewClient(config *Config) (*Client, error) {
 				return nil, err
 			}
 			config.HttpClient = httpClient
+		case "npipe":
+			// Named pipe paths are written with forward slashes in addresses,
+			// e.g. npipe:////./pipe/consul for \\.\pipe\consul.
+			path := strings.ReplaceAll(parts[1], "/", `\`)
+			trans := cleanhttp.DefaultTransport()
+			trans.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialNamedPipe(ctx, path)
+			}
+			httpClient, err := NewHttpClient(trans, config.TLSConfig)
+			if err != nil {
+				return nil, err
+			}
+			config.HttpClient = httpClient
 		default:
 			return nil, fmt.Errorf("Unknown protocol scheme: %s", parts[0])
 		}
@@ -876,6 +907,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.RequireConsistent {
 		r.params.Set("consistent", "")
 	}
+	if q.BoundedStaleness != 0 {
+		r.params.Set("bounded-stale", durToMsec(q.BoundedStaleness))
+	}
 	if q.WaitIndex != 0 {
 		r.params.Set("index", strconv.FormatUint(q.WaitIndex, 10))
 	}
@@ -894,6 +928,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.Filter != "" {
 		r.params.Set("filter", q.Filter)
 	}
+	if q.Sort != "" {
+		r.params.Set("sort", q.Sort)
+	}
 	if len(q.NodeMeta) > 0 {
 		for key, value := range q.NodeMeta {
 			r.params.Add("node-meta", key+":"+value)
@@ -925,6 +962,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.MergeCentralConfig {
 		r.params.Set("merge-central-config", "")
 	}
+	if q.IncludeSidecarProxy {
+		r.params.Set("sidecar-proxy", "")
+	}
 	if q.Global {
 		r.params.Set("global", "")
 	}
@@ -806,10 +806,12 @@ func TestAPI_SetQueryOptions(t *testing.T) {
 		Peer:              "dc10",
 		AllowStale:        true,
 		RequireConsistent: true,
+		BoundedStaleness:  5 * time.Second,
 		WaitIndex:         1000,
 		WaitTime:          100 * time.Second,
 		Token:             "12345",
 		Near:              "nodex",
+		Sort:              "-modify-index",
 		LocalOnly:         true,
 	}
 	r.setQueryOptions(q)
@@ -832,6 +834,9 @@ func TestAPI_SetQueryOptions(t *testing.T) {
 	if _, ok := r.params["consistent"]; !ok {
 		t.Fatalf("bad: %v", r.params)
 	}
+	if r.params.Get("bounded-stale") != "5000ms" {
+		t.Fatalf("bad: %v", r.params)
+	}
 	if r.params.Get("index") != "1000" {
 		t.Fatalf("bad: %v", r.params)
 	}
@@ -844,6 +849,9 @@ func TestAPI_SetQueryOptions(t *testing.T) {
 	if r.params.Get("near") != "nodex" {
 		t.Fatalf("bad: %v", r.params)
 	}
+	if r.params.Get("sort") != "-modify-index" {
+		t.Fatalf("bad: %v", r.params)
+	}
 	if r.params.Get("local-only") != "true" {
 		t.Fatalf("bad: %v", r.params)
 	}
@@ -1060,6 +1068,19 @@ func TestAPI_UnixSocket(t *testing.T) {
 	}
 }
 
+func TestAPI_NamedPipe(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient(&Config{Address: "npipe:////./pipe/consul"})
+	require.NoError(t, err)
+	require.Equal(t, "//./pipe/consul", c.config.Address)
+
+	if runtime.GOOS != "windows" {
+		_, err = c.Agent().Self()
+		require.ErrorContains(t, err, "named pipes are only supported on Windows")
+	}
+}
+
 func TestAPI_durToMsec(t *testing.T) {
 	t.Parallel()
 	if ms := durToMsec(0); ms != "0ms" {
@@ -115,6 +115,13 @@ type GatewayService struct {
 	KeyFile      string   `json:",omitempty"`
 	SNI          string   `json:",omitempty"`
 	FromWildcard bool     `json:",omitempty"`
+
+	CAPEM         string                 `json:",omitempty"`
+	VaultPKI      *LinkedServiceVaultPKI `json:",omitempty"`
+	TLSMinVersion string                 `json:",omitempty"`
+	TLSMaxVersion string                 `json:",omitempty"`
+	CipherSuites  []string               `json:",omitempty"`
+	HostRewrite   string                 `json:",omitempty"`
 }
 
 // Catalog can be used to query the Catalog endpoints
@@ -37,6 +37,8 @@ const (
 	InlineCertificate     string = "inline-certificate"
 	HTTPRoute             string = "http-route"
 	JWTProvider           string = "jwt-provider"
+	AgentDefaults         string = "agent-defaults"
+	AgentDefaultsGlobal   string = "global"
 )
 
 const (
@@ -458,6 +460,8 @@ func makeConfigEntry(kind, name string) (ConfigEntry, error) {
 		return &RateLimitIPConfigEntry{Kind: kind, Name: name}, nil
 	case JWTProvider:
 		return &JWTProviderConfigEntry{Kind: kind, Name: name}, nil
+	case AgentDefaults:
+		return &AgentDefaultsConfigEntry{Kind: kind, Name: name}, nil
 	default:
 		return nil, fmt.Errorf("invalid config entry kind: %s", kind)
 	}
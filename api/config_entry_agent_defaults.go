@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// AgentDefaultsConfigEntry holds runtime settings applied by every agent of
+// the partition. The settings set in the local configuration of an agent take
+// precedence over the ones of the config entry.
+type AgentDefaultsConfigEntry struct {
+	// Kind of the config entry. This will be set to AgentDefaults.
+	Kind string
+
+	// Name of the config entry. It must be set to AgentDefaultsGlobal.
+	Name string
+
+	// Partition is the partition the AgentDefaultsConfigEntry applies to.
+	// Partitioning is a Consul Enterprise feature.
+	Partition string `json:",omitempty"`
+
+	// Namespace is the namespace the AgentDefaultsConfigEntry applies to.
+	// Namespacing is a Consul Enterprise feature.
+	Namespace string `json:",omitempty"`
+
+	// LogLevel is the level of the logs of the agents.
+	LogLevel string `json:",omitempty" alias:"log_level"`
+
+	// DNSRecursors are the upstream DNS servers the agents forward the
+	// queries outside of the Consul domain to.
+	DNSRecursors []string `json:",omitempty" alias:"dns_recursors"`
+
+	// Telemetry holds the metrics sinks the agents send their metrics to.
+	Telemetry AgentDefaultsTelemetry
+
+	Meta map[string]string `json:",omitempty"`
+
+	// CreateIndex is the Raft index this entry was created at. This is a
+	// read-only field.
+	CreateIndex uint64
+
+	// ModifyIndex is used for the Check-And-Set operations and can also be fed
+	// back into the WaitIndex of the QueryOptions in order to perform blocking
+	// queries.
+	ModifyIndex uint64
+}
+
+// AgentDefaultsTelemetry holds the addresses of the metrics sinks of the
+// agents.
+type AgentDefaultsTelemetry struct {
+	StatsdAddr    string   `json:",omitempty" alias:"statsd_address"`
+	StatsiteAddr  string   `json:",omitempty" alias:"statsite_address"`
+	DogstatsdAddr string   `json:",omitempty" alias:"dogstatsd_addr"`
+	DogstatsdTags []string `json:",omitempty" alias:"dogstatsd_tags"`
+}
+
+func (e *AgentDefaultsConfigEntry) GetKind() string            { return AgentDefaults }
+func (e *AgentDefaultsConfigEntry) GetName() string            { return e.Name }
+func (e *AgentDefaultsConfigEntry) GetPartition() string       { return e.Partition }
+func (e *AgentDefaultsConfigEntry) GetNamespace() string       { return e.Namespace }
+func (e *AgentDefaultsConfigEntry) GetMeta() map[string]string { return e.Meta }
+func (e *AgentDefaultsConfigEntry) GetCreateIndex() uint64     { return e.CreateIndex }
+func (e *AgentDefaultsConfigEntry) GetModifyIndex() uint64     { return e.ModifyIndex }
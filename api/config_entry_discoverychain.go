@@ -281,7 +281,8 @@ type ServiceResolverFailoverTarget struct {
 
 type ServiceResolverFailoverPolicy struct {
 	// Mode specifies the type of failover that will be performed. Valid values are
-	// "sequential", "" (equivalent to "sequential") and "order-by-locality".
+	// "sequential", "" (equivalent to "sequential"), "order-by-locality" and
+	// "order-by-health".
 	Mode    string   `json:",omitempty"`
 	Regions []string `json:",omitempty"`
 }
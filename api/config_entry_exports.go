@@ -44,6 +44,11 @@ type ExportedService struct {
 	// Namespace is the namespace to export the service from.
 	Namespace string `json:",omitempty"`
 
+	// Selector restricts a wildcard export to the services that have at
+	// least one instance whose service metadata contains every key and value
+	// in the selector. It can only be set when Name is the wildcard.
+	Selector map[string]string `json:",omitempty"`
+
 	// Consumers is a list of downstream consumers of the service to be exported.
 	Consumers []ServiceConsumer `json:",omitempty"`
 }
@@ -59,6 +64,9 @@ type ServiceConsumer struct {
 
 	// SamenessGroup is the name of the sameness group to export the service to.
 	SamenessGroup string `json:",omitempty" alias:"sameness_group"`
+
+	// Selector overrides the Selector of a wildcard export for this consumer.
+	Selector map[string]string `json:",omitempty"`
 }
 
 func (e *ExportedServicesConfigEntry) GetKind() string            { return ExportedServices }
@@ -3,6 +3,8 @@
 
 package api
 
+import "time"
+
 // IngressGatewayConfigEntry manages the configuration for an ingress service
 // with the given name.
 type IngressGatewayConfigEntry struct {
@@ -216,8 +218,47 @@ type LinkedService struct {
 	// from the gateway to the linked service.
 	KeyFile string `json:",omitempty" alias:"key_file"`
 
+	// CAPEM is an optional PEM encoded CA bundle to use for TLS connections
+	// from the gateway to the linked service, as an alternative to CAFile.
+	CAPEM string `json:",omitempty" alias:"ca_pem"`
+
+	// VaultPKI optionally configures the gateway to present a client
+	// certificate issued by a Vault PKI secrets engine instead of CertFile
+	// and KeyFile.
+	VaultPKI *LinkedServiceVaultPKI `json:",omitempty" alias:"vault_pki"`
+
+	// TLSMinVersion sets the minimum TLS version supported for connections
+	// from the gateway to the linked service.
+	TLSMinVersion string `json:",omitempty" alias:"tls_min_version"`
+
+	// TLSMaxVersion sets the maximum TLS version supported for connections
+	// from the gateway to the linked service.
+	TLSMaxVersion string `json:",omitempty" alias:"tls_max_version"`
+
+	// CipherSuites sets the cipher suites supported for connections from the
+	// gateway to the linked service when TLS 1.2 or earlier is negotiated.
+	CipherSuites []string `json:",omitempty" alias:"cipher_suites"`
+
 	// SNI is the optional name to specify during the TLS handshake with a linked service.
 	SNI string `json:",omitempty"`
+
+	// HostRewrite is the optional value of the Host header of the HTTP
+	// requests sent to the linked service.
+	HostRewrite string `json:",omitempty" alias:"host_rewrite"`
+}
+
+// LinkedServiceVaultPKI configures the client certificate a terminating
+// gateway presents to a linked service, issued by a Vault PKI secrets engine.
+type LinkedServiceVaultPKI struct {
+	// Path is the path of the issue endpoint of the PKI secrets engine, such
+	// as "pki/issue/gateway".
+	Path string `json:",omitempty"`
+
+	// CommonName is the common name of the requested certificates.
+	CommonName string `json:",omitempty" alias:"common_name"`
+
+	// TTL is the requested lifetime of the certificates.
+	TTL time.Duration `json:",omitempty"`
 }
 
 func (g *TerminatingGatewayConfigEntry) GetKind() string            { return g.Kind }
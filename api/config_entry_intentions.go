@@ -36,6 +36,19 @@ type SourceIntention struct {
 	LegacyMeta       map[string]string `json:",omitempty" alias:"legacy_meta"`
 	LegacyCreateTime *time.Time        `json:",omitempty" alias:"legacy_create_time"`
 	LegacyUpdateTime *time.Time        `json:",omitempty" alias:"legacy_update_time"`
+
+	// ReviewBy is the time by which the intention should be reviewed. Past
+	// this time the intention is reported as overdue for review, and is
+	// disabled if connect.disable_intentions_after_review is set.
+	ReviewBy *time.Time `json:",omitempty" alias:"review_by"`
+
+	// ExpiresAt is the time at which the intention expires. Past this time
+	// the intention is reported as expired, and is disabled if
+	// connect.disable_expired_intentions is set.
+	ExpiresAt *time.Time `json:",omitempty" alias:"expires_at"`
+
+	// Disabled intentions are kept in the config entry but aren't enforced.
+	Disabled bool `json:",omitempty"`
 }
 
 func (e *ServiceIntentionsConfigEntry) GetKind() string            { return e.Kind }
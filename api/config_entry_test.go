@@ -1135,7 +1135,17 @@ func TestDecodeConfigEntry(t *testing.T) {
 						"SNI": "mydomain"
 					},
 					{
-						"Name": "api"
+						"Name": "api",
+						"CAPEM": "-----BEGIN CERTIFICATE-----",
+						"VaultPKI": {
+							"Path": "pki/issue/gateway",
+							"CommonName": "gateway.example.com",
+							"TTL": "24h"
+						},
+						"TLSMinVersion": "TLSv1_2",
+						"TLSMaxVersion": "TLSv1_3",
+						"CipherSuites": ["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"],
+						"HostRewrite": "api.example.com"
 					},
 					{
 						"Namespace": "bar",
@@ -1160,7 +1170,17 @@ func TestDecodeConfigEntry(t *testing.T) {
 						SNI:       "mydomain",
 					},
 					{
-						Name: "api",
+						Name:  "api",
+						CAPEM: "-----BEGIN CERTIFICATE-----",
+						VaultPKI: &LinkedServiceVaultPKI{
+							Path:       "pki/issue/gateway",
+							CommonName: "gateway.example.com",
+							TTL:        24 * time.Hour,
+						},
+						TLSMinVersion: "TLSv1_2",
+						TLSMaxVersion: "TLSv1_3",
+						CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+						HostRewrite:   "api.example.com",
 					},
 					{
 						Namespace: "bar",
@@ -5,6 +5,7 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -83,6 +84,15 @@ type CARootList struct {
 	ActiveRootID string
 	TrustDomain  string
 	Roots        []*CARoot
+
+	// RevokedSerialNumbers are the serial numbers of the certificates
+	// revoked in the datacenter before they expired.
+	RevokedSerialNumbers []string `json:",omitempty"`
+
+	// RevocationList holds the PEM encoded certificate revocation lists of
+	// all the datacenters, which the leaf certs of peers are checked against.
+	// It is only set while certificates are revoked in any of the datacenters.
+	RevocationList string `json:",omitempty"`
 }
 
 // CARoot represents a root CA certificate that is trusted.
@@ -133,6 +143,155 @@ type LeafCert struct {
 	ModifyIndex uint64
 }
 
+// SVIDIntrospection describes the identity carried by an SVID, and whether it
+// is currently trusted by the Connect CA.
+type SVIDIntrospection struct {
+	// Kind is the kind of identity carried by the SVID: "service",
+	// "workload-identity", "agent", "server" or "mesh-gateway".
+	Kind string `json:",omitempty"`
+
+	// SpiffeID is the certificate's URI.
+	SpiffeID string `json:",omitempty"`
+
+	// TrustDomain is the trust domain of the SPIFFE ID, and TrustDomainMatches
+	// reports whether it is trusted by this cluster.
+	TrustDomain        string `json:",omitempty"`
+	TrustDomainMatches bool
+
+	// WorkloadIdentity, Service, Agent, Datacenter, Partition and Namespace
+	// are the claims of the SPIFFE ID.
+	WorkloadIdentity string `json:",omitempty"`
+	Service          string `json:",omitempty"`
+	Agent            string `json:",omitempty"`
+	Datacenter       string `json:",omitempty"`
+	Partition        string `json:",omitempty"`
+	Namespace        string `json:",omitempty"`
+
+	SerialNumber string
+	SigningKeyID string   `json:",omitempty"`
+	Subject      string   `json:",omitempty"`
+	Issuer       string   `json:",omitempty"`
+	DNSNames     []string `json:",omitempty"`
+
+	// ValidAfter and ValidBefore are the validity periods for the
+	// certificate.
+	ValidAfter  time.Time
+	ValidBefore time.Time
+
+	// SignedByRootID is the ID of the CA root the certificate chains up to.
+	SignedByRootID     string `json:",omitempty"`
+	SignedByActiveRoot bool
+
+	// Valid reports whether the certificate is currently valid and trusted.
+	// Otherwise, InvalidReason explains why it isn't.
+	Valid         bool
+	InvalidReason string `json:",omitempty"`
+
+	// Revoked reports whether the certificate was revoked before it expired.
+	Revoked bool
+}
+
+// CARevokedCert is a certificate issued by the Connect CA that was revoked
+// before it expired.
+type CARevokedCert struct {
+	// SerialNumber is encoded in standard hex separated by :.
+	SerialNumber string
+
+	// SigningKeyID is the ID of the key that signed the certificate. It is
+	// only known when the certificate itself was given to revoke it.
+	SigningKeyID string `json:",omitempty"`
+
+	// Reason is the RFC 5280 CRLReason code for the revocation.
+	Reason int
+
+	// RevokedAt is the time the certificate was revoked, and NotAfter the
+	// time it is removed from the revocation list.
+	RevokedAt time.Time
+	NotAfter  time.Time
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// CARevocation describes a certificate to revoke. Either Certificate or
+// SerialNumber must be set.
+type CARevocation struct {
+	// Certificate is the PEM encoded certificate to revoke.
+	Certificate string `json:",omitempty"`
+
+	// SerialNumber is the serial number of the certificate to revoke,
+	// encoded in standard hex separated by :.
+	SerialNumber string `json:",omitempty"`
+
+	// Reason is the RFC 5280 CRLReason code for the revocation.
+	Reason int `json:",omitempty"`
+}
+
+// CALeafInventory summarizes the leaf certificates signed by the leader of a
+// datacenter since it was elected, for each workload identity.
+type CALeafInventory struct {
+	// Since is the time the leader started tracking the signed certificates.
+	Since time.Time
+
+	// Identities are sorted by LatestExpiry, so the ones closest to running
+	// out of valid certificates come first.
+	Identities []*CALeafInventoryEntry
+}
+
+// CALeafInventoryEntry summarizes the leaf certificates signed for a service,
+// a workload identity or the mesh gateways.
+type CALeafInventoryEntry struct {
+	// Kind is "service", "workload-identity" or "mesh-gateway".
+	Kind      string
+	Name      string `json:",omitempty"`
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+
+	// ActiveCerts is the number of signed certificates which didn't expire
+	// yet.
+	ActiveCerts int
+
+	Signed         uint64
+	SignedLastHour int
+	LastSigned     time.Time
+
+	// EarliestExpiry is the expiration time of the active certificate which
+	// expires first. LatestExpiry is the expiration time of the latest
+	// certificate.
+	EarliestExpiry time.Time
+	LatestExpiry   time.Time
+
+	SignErrors      uint64
+	LastSignError   string `json:",omitempty"`
+	LastSignErrorAt time.Time
+}
+
+// CAIntrospect describes the identity carried by the given PEM encoded SVID,
+// and whether it is currently trusted by the Connect CA.
+func (h *Connect) CAIntrospect(svid string, q *QueryOptions) (*SVIDIntrospection, *QueryMeta, error) {
+	r := h.c.newRequest("POST", "/v1/connect/ca/introspect")
+	r.setQueryOptions(q)
+	r.obj = map[string]string{"SVID": svid}
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out SVIDIntrospection
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, qm, nil
+}
+
 // CARoots queries the list of available roots.
 func (h *Connect) CARoots(q *QueryOptions) (*CARootList, *QueryMeta, error) {
 	r := h.c.newRequest("GET", "/v1/connect/ca/roots")
@@ -199,3 +358,98 @@ func (h *Connect) CASetConfig(conf *CAConfig, q *WriteOptions) (*WriteMeta, erro
 	wm.RequestTime = rtt
 	return wm, nil
 }
+
+// CARevoke revokes a certificate issued by the Connect CA, so that it is
+// listed in the certificate revocation list and the OCSP responses of the
+// datacenter until it expires.
+func (h *Connect) CARevoke(revocation *CARevocation, q *WriteOptions) (*WriteMeta, error) {
+	r := h.c.newRequest("PUT", "/v1/connect/ca/revocations")
+	r.setWriteOptions(q)
+	r.obj = revocation
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+
+	wm := &WriteMeta{}
+	wm.RequestTime = rtt
+	return wm, nil
+}
+
+// CARevokedCerts returns the certificates revoked in the datacenter.
+func (h *Connect) CARevokedCerts(q *QueryOptions) ([]*CARevokedCert, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/connect/ca/revocations")
+	r.setQueryOptions(q)
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out []*CARevokedCert
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
+// CAInventory returns the summary of the leaf certificates signed in the
+// datacenter since its leader was elected.
+func (h *Connect) CAInventory(q *QueryOptions) (*CALeafInventory, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/connect/ca/inventory")
+	r.setQueryOptions(q)
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out CALeafInventory
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, qm, nil
+}
+
+// CARevocationList returns the DER encoded certificate revocation list of the
+// datacenter.
+func (h *Connect) CARevocationList(q *QueryOptions) ([]byte, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/connect/ca/crl")
+	r.setQueryOptions(q)
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	crl, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crl, qm, nil
+}
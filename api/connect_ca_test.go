@@ -59,6 +59,33 @@ func TestAPI_ConnectCARoots_list(t *testing.T) {
 
 }
 
+func TestAPI_ConnectCAIntrospect(t *testing.T) {
+	t.Parallel()
+
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	s.WaitForSerfCheck(t)
+
+	var leaf *LeafCert
+	retry.Run(t, func(r *retry.R) {
+		var err error
+		leaf, _, err = c.Agent().ConnectCALeaf("web", nil)
+		r.Check(err)
+	})
+
+	out, _, err := c.Connect().CAIntrospect(leaf.CertPEM, nil)
+	require.NoError(t, err)
+	require.True(t, out.Valid, out.InvalidReason)
+	require.Equal(t, "service", out.Kind)
+	require.Equal(t, "web", out.Service)
+	require.Equal(t, leaf.SerialNumber, out.SerialNumber)
+	require.True(t, out.SignedByActiveRoot)
+
+	_, _, err = c.Connect().CAIntrospect("not a certificate", nil)
+	require.ErrorContains(t, err, "failed to parse SVID")
+}
+
 func TestAPI_ConnectCAConfig_get_set(t *testing.T) {
 	t.Parallel()
 
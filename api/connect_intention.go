@@ -80,6 +80,27 @@ type Intention struct {
 	// or modified.
 	CreatedAt, UpdatedAt time.Time
 
+	// ReviewBy is the time by which the intention should be reviewed.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	ReviewBy *time.Time `json:",omitempty"`
+
+	// ExpiresAt is the time at which the intention expires.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	ExpiresAt *time.Time `json:",omitempty"`
+
+	// Disabled intentions aren't enforced.
+	//
+	// NOTE: This field is only editable through the underlying
+	// service-intentions config entry or the intention endpoints addressing
+	// intentions by name, not through the ones using the legacy ID.
+	Disabled bool `json:",omitempty"`
+
 	// Hash of the contents of the intention
 	//
 	// This is needed mainly for replication purposes. When replicating from
@@ -203,6 +224,33 @@ func (h *Connect) Intentions(q *QueryOptions) ([]*Intention, *QueryMeta, error)
 	return out, qm, nil
 }
 
+// IntentionsExpiring returns the list of intentions which have to be reviewed,
+// or expire, within the given duration. The intentions which are already
+// overdue for review are included.
+func (h *Connect) IntentionsExpiring(within time.Duration, q *QueryOptions) ([]*Intention, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/connect/intentions")
+	r.setQueryOptions(q)
+	r.params.Set("expiring-within", within.String())
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out []*Intention
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
 // IntentionGetExact retrieves a single intention by its unique name instead of
 // its ID.
 func (h *Connect) IntentionGetExact(source, destination string, q *QueryOptions) (*Intention, *QueryMeta, error) {
@@ -5,6 +5,7 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -69,6 +70,39 @@ func TestAPI_ConnectIntentionCreateListGetUpdateDelete(t *testing.T) {
 	require.Nil(t, actual)
 }
 
+func TestAPI_ConnectIntentionsExpiring(t *testing.T) {
+	t.Parallel()
+
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	s.WaitForServiceIntentions(t)
+
+	soon := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	later := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	_, _, err := c.ConfigEntries().Set(&ServiceIntentionsConfigEntry{
+		Kind: ServiceIntentions,
+		Name: "web",
+		Sources: []*SourceIntention{
+			{Name: "soon", Action: IntentionActionAllow, ExpiresAt: &soon},
+			{Name: "later", Action: IntentionActionAllow, ReviewBy: &later},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	list, _, err := c.Connect().IntentionsExpiring(24*time.Hour, nil)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "soon", list[0].SourceName)
+	require.NotNil(t, list[0].ExpiresAt)
+	require.True(t, soon.Equal(*list[0].ExpiresAt))
+	require.Nil(t, list[0].ReviewBy)
+
+	list, _, err = c.Connect().Intentions(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+}
+
 func TestAPI_ConnectIntentionGet_invalidId(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// Severities of the findings reported by the mesh config linter.
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+	LintSeverityInfo    = "info"
+)
+
+// LintFinding is a problem found in the service mesh configuration.
+type LintFinding struct {
+	// Rule is the lint rule which reported the finding, such as
+	// "undefined-subset".
+	Rule string
+
+	// Severity is one of "error", "warning" or "info".
+	Severity string
+
+	// Kind and Name identify the config entry the finding is about.
+	Kind      string
+	Name      string
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+
+	Message string
+}
+
+// Lint evaluates the service resolver, splitter, router, intentions and
+// exported services config entries against the rules of the mesh config
+// linter and returns the findings, the most severe first.
+func (h *Connect) Lint(q *QueryOptions) ([]LintFinding, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/connect/lint")
+	r.setQueryOptions(q)
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out []LintFinding
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
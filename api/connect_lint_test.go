@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ConnectLint(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	s.WaitForSerfCheck(t)
+
+	findings, _, err := c.Connect().Lint(nil)
+	require.NoError(t, err)
+	require.Empty(t, findings)
+
+	_, _, err = c.ConfigEntries().Set(&ServiceResolverConfigEntry{
+		Kind: ServiceResolver,
+		Name: "web",
+		Subsets: map[string]ServiceResolverSubset{
+			"v1": {Filter: "Service.Meta.version == v1"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	findings, qm, err := c.Connect().Lint(nil)
+	require.NoError(t, err)
+	require.NotZero(t, qm.LastIndex)
+	require.Equal(t, []LintFinding{{
+		Rule:     "unreachable-subset",
+		Severity: LintSeverityWarning,
+		Kind:     ServiceResolver,
+		Name:     "web",
+		Message:  `Subset "v1" is not the default subset and no route, split, redirect or failover targets it`,
+	}}, findings)
+}
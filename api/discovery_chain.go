@@ -36,6 +36,9 @@ func (d *DiscoveryChain) Get(name string, opts *DiscoveryChainOptions, q *QueryO
 		if opts.EvaluateInDatacenter != "" {
 			r.params.Set("compile-dc", opts.EvaluateInDatacenter)
 		}
+		if opts.Explain {
+			r.params.Set("explain", "")
+		}
 	}
 
 	if method == "POST" {
@@ -66,6 +69,10 @@ func (d *DiscoveryChain) Get(name string, opts *DiscoveryChainOptions, q *QueryO
 type DiscoveryChainOptions struct {
 	EvaluateInDatacenter string `json:"-"`
 
+	// Explain requests that the compiled chain is annotated with the config
+	// entries and defaults that produced each node and target.
+	Explain bool `json:"-"`
+
 	// OverrideMeshGateway allows for the mesh gateway setting to be overridden
 	// for any resolver in the compiled chain.
 	OverrideMeshGateway MeshGatewayConfig `json:",omitempty"`
@@ -139,6 +146,43 @@ type CompiledDiscoveryChain struct {
 	// NOTE: The names should be treated as opaque values and are only
 	// guaranteed to be consistent within a single compilation.
 	Targets map[string]*DiscoveryTarget
+
+	// Explanation describes which config entries contributed to the chain
+	// and where defaults applied. It is only set when requested with
+	// DiscoveryChainOptions.Explain.
+	Explanation *DiscoveryChainExplanation `json:",omitempty"`
+}
+
+// DiscoveryChainExplanation annotates a compiled discovery chain with the
+// reasons it was compiled the way it was.
+type DiscoveryChainExplanation struct {
+	// Chain holds the reasons that apply to the chain as a whole.
+	Chain []string
+
+	// Nodes is keyed by the same keys as CompiledDiscoveryChain.Nodes.
+	Nodes map[string]*DiscoveryExplanation
+
+	// Targets is keyed by the same keys as CompiledDiscoveryChain.Targets.
+	Targets map[string]*DiscoveryExplanation
+}
+
+// DiscoveryExplanation describes where a node or target of a discovery chain
+// came from.
+type DiscoveryExplanation struct {
+	// ConfigEntry is the config entry that the node or target was compiled
+	// from. It is nil when only defaults applied.
+	ConfigEntry *DiscoveryExplanationConfigEntry
+
+	// Reasons lists the decisions made while compiling the node or target.
+	Reasons []string
+}
+
+// DiscoveryExplanationConfigEntry identifies a config entry in an explanation.
+type DiscoveryExplanationConfigEntry struct {
+	Kind      string
+	Name      string
+	Namespace string `json:",omitempty"`
+	Partition string `json:",omitempty"`
 }
 
 const (
@@ -225,6 +269,19 @@ func (r *DiscoveryResolver) UnmarshalJSON(data []byte) error {
 type DiscoveryFailover struct {
 	Targets []string
 	Policy  ServiceResolverFailoverPolicy `json:",omitempty"`
+
+	// TargetHealth is the observed health of each failover target, in the
+	// same order as Targets. It is only set when the failover policy mode is
+	// "order-by-health".
+	TargetHealth []DiscoveryFailoverTargetHealth `json:",omitempty"`
+}
+
+// DiscoveryFailoverTargetHealth is the health and latency used to order a
+// failover target with the "order-by-health" failover policy mode.
+type DiscoveryFailoverTargetHealth struct {
+	Target        string
+	HealthPercent int
+	RTT           time.Duration `json:",omitempty"`
 }
 
 // DiscoveryTarget represents all of the inputs necessary to use a resolver
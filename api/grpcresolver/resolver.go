@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package grpcresolver provides a gRPC name resolver which resolves the
+// healthy instances of a Consul service.
+//
+// The resolver subscribes to the instances of the service through the local
+// agent's HealthSubscriptionService, so it is notified of every change as soon
+// as the agent's streaming cache sees it, and feeds the addresses to the
+// channel's load balancer. Targets take the form:
+//
+//	consul://[agent-address]/service-name[?options]
+//
+// where the agent address defaults to the Config's Address, and the options
+// are query parameters named after those of the /v1/health/service HTTP
+// endpoint: passing, filter, connect, dc, ns, partition and peer.
+package grpcresolver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+const (
+	// Scheme is the scheme of the targets resolved by this package.
+	Scheme = "consul"
+
+	// DefaultAddress is the default address of the agent's gRPC port.
+	DefaultAddress = "127.0.0.1:8502"
+
+	// tokenHeader is the metadata key carrying the ACL token.
+	tokenHeader = "x-consul-token"
+
+	// maxRetryWait caps the wait between attempts to subscribe again after
+	// the stream failed.
+	maxRetryWait = 30 * time.Second
+)
+
+// Config is used to configure the resolvers created by a Builder.
+type Config struct {
+	// Address is the address of the agent's gRPC port, used when the target
+	// does not name one.
+	Address string
+
+	// Token is the ACL token used to read the services.
+	Token string
+
+	// DialOptions are used to connect to the agent. The connection is
+	// insecure if they are empty.
+	DialOptions []grpc.DialOption
+
+	// LoadBalancingPolicy is the load balancing policy of the channels using
+	// the resolvers, e.g. "round_robin". The channel's own policy is used if
+	// it is empty.
+	LoadBalancingPolicy string
+}
+
+// DefaultConfig returns a default configuration for the resolvers, which
+// reads the agent's address from the CONSUL_GRPC_ADDR environment variable and
+// the ACL token from CONSUL_HTTP_TOKEN, and balances the requests across the
+// instances in a round-robin fashion.
+func DefaultConfig() *Config {
+	config := &Config{
+		Address:             DefaultAddress,
+		Token:               os.Getenv(api.HTTPTokenEnvName),
+		LoadBalancingPolicy: "round_robin",
+	}
+
+	if addr := os.Getenv(api.GRPCAddrEnvName); addr != "" {
+		lower := strings.ToLower(addr)
+		switch {
+		case strings.HasPrefix(lower, "https://"):
+			config.DialOptions = []grpc.DialOption{
+				grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})),
+			}
+			addr = addr[len("https://"):]
+		case strings.HasPrefix(lower, "http://"):
+			addr = addr[len("http://"):]
+		}
+		config.Address = addr
+	}
+	return config
+}
+
+// Builder creates resolvers for the targets with the "consul" scheme. It can
+// be registered globally with resolver.Register, or passed to a single channel
+// with grpc.WithResolvers.
+type Builder struct {
+	config Config
+}
+
+var _ resolver.Builder = (*Builder)(nil)
+
+// NewBuilder returns a Builder creating resolvers with the given
+// configuration, or with DefaultConfig if it is nil.
+func NewBuilder(config *Config) *Builder {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Builder{config: *config}
+}
+
+// Scheme returns the scheme of the targets resolved by the Builder.
+func (b *Builder) Scheme() string {
+	return Scheme
+}
+
+// Build creates a resolver for the given target, and starts watching the
+// instances of its service.
+func (b *Builder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	req, err := parseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceConfig *serviceconfig.ParseResult
+	if b.config.LoadBalancingPolicy != "" {
+		serviceConfig = cc.ParseServiceConfig(fmt.Sprintf(`{"loadBalancingConfig":[{%q:{}}]}`, b.config.LoadBalancingPolicy))
+		if serviceConfig.Err != nil {
+			return nil, fmt.Errorf("invalid load balancing policy %q: %w", b.config.LoadBalancingPolicy, serviceConfig.Err)
+		}
+	}
+
+	addr := target.URL.Host
+	if addr == "" {
+		addr = b.config.Address
+	}
+	if addr == "" {
+		addr = DefaultAddress
+	}
+	opts := b.config.DialOptions
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the agent at %s: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if b.config.Token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tokenHeader, b.config.Token)
+	}
+	r := &consulResolver{
+		cc:            cc,
+		conn:          conn,
+		client:        pbhealth.NewHealthSubscriptionServiceClient(conn),
+		req:           req,
+		serviceConfig: serviceConfig,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+// parseTarget returns the subscription request for the given target.
+func parseTarget(target resolver.Target) (*pbhealth.SubscribeServicesRequest, error) {
+	service := target.Endpoint()
+	if service == "" || strings.Contains(service, "/") {
+		return nil, fmt.Errorf("invalid target %q: expected consul://[agent-address]/service-name", target.URL.String())
+	}
+
+	query := target.URL.Query()
+	req := &pbhealth.SubscribeServicesRequest{
+		Services:   []string{service},
+		Filter:     query.Get("filter"),
+		Datacenter: query.Get("dc"),
+		Namespace:  query.Get("ns"),
+		Partition:  query.Get("partition"),
+		PeerName:   query.Get("peer"),
+	}
+	for param, value := range map[string]*bool{"passing": &req.PassingOnly, "connect": &req.Connect} {
+		if !query.Has(param) {
+			continue
+		}
+		// A parameter without a value is set, as for the HTTP API.
+		if query.Get(param) == "" {
+			*value = true
+			continue
+		}
+		v, err := strconv.ParseBool(query.Get(param))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q in target %q: %w", param, target.URL.String(), err)
+		}
+		*value = v
+	}
+	return req, nil
+}
+
+type consulResolver struct {
+	cc            resolver.ClientConn
+	conn          *grpc.ClientConn
+	client        pbhealth.HealthSubscriptionServiceClient
+	req           *pbhealth.SubscribeServicesRequest
+	serviceConfig *serviceconfig.ParseResult
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// ResolveNow is a no-op, since the resolver is notified of every change of the
+// instances of the service.
+func (r *consulResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+// Close stops watching the instances of the service.
+func (r *consulResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+	r.conn.Close()
+}
+
+// watch subscribes to the instances of the service until the resolver is
+// closed, subscribing again with an exponential backoff whenever the stream
+// fails.
+func (r *consulResolver) watch() {
+	defer r.wg.Done()
+
+	var attempt uint
+	for {
+		received, err := r.subscribe()
+		if r.ctx.Err() != nil {
+			return
+		}
+		r.cc.ReportError(fmt.Errorf("failed to watch service %q: %w", r.req.Services[0], err))
+
+		if received {
+			attempt = 0
+		}
+		select {
+		case <-time.After(retryWait(attempt)):
+		case <-r.ctx.Done():
+			return
+		}
+		attempt++
+	}
+}
+
+// subscribe updates the addresses of the channel with the instances of the
+// service until the stream fails. It returns whether any update was received.
+func (r *consulResolver) subscribe() (bool, error) {
+	stream, err := r.client.SubscribeServices(r.ctx, r.req)
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+
+		// The resolver is notified of every change, so there is no point
+		// resolving again if the balancer rejects the addresses.
+		_ = r.cc.UpdateState(resolver.State{
+			Addresses:     addressesFromEntries(rsp.Entries),
+			ServiceConfig: r.serviceConfig,
+		})
+	}
+}
+
+// addressesFromEntries returns the addresses of the instances of a service
+// none of whose checks are critical or in maintenance.
+func addressesFromEntries(entries []*pbhealth.ServiceEntry) []resolver.Address {
+	addrs := make([]resolver.Address, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Service == nil || !healthy(entry.Checks) {
+			continue
+		}
+
+		host := entry.Service.Address
+		if host == "" && entry.Node != nil {
+			host = entry.Node.Address
+		}
+		if host == "" {
+			continue
+		}
+
+		addrs = append(addrs, resolver.Address{
+			Addr: net.JoinHostPort(host, strconv.Itoa(int(entry.Service.Port))),
+		})
+	}
+	return addrs
+}
+
+func healthy(checks []*pbhealth.Check) bool {
+	for _, check := range checks {
+		switch check.Status {
+		case pbhealth.Health_HEALTH_CRITICAL, pbhealth.Health_HEALTH_MAINTENANCE:
+			return false
+		}
+	}
+	return true
+}
+
+// retryWait returns how long to wait before the given attempt to subscribe
+// again.
+func retryWait(attempt uint) time.Duration {
+	if attempt > 6 {
+		return maxRetryWait
+	}
+	wait := (500 * time.Millisecond) << attempt
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	return wait
+}
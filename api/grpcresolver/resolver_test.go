@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package grpcresolver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbhealth"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func testTarget(t *testing.T, target string) resolver.Target {
+	t.Helper()
+
+	u, err := url.Parse(target)
+	require.NoError(t, err)
+	return resolver.Target{URL: *u}
+}
+
+func TestParseTarget(t *testing.T) {
+	req, err := parseTarget(testTarget(t, "consul:///web"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, req.Services)
+	require.False(t, req.PassingOnly)
+
+	req, err = parseTarget(testTarget(t, `consul://127.0.0.1:8502/web?passing&connect=false&filter=Service.Meta.version+%3D%3D+v1&dc=dc2&ns=ns1&partition=ap1&peer=peer1`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"web"}, req.Services)
+	require.True(t, req.PassingOnly)
+	require.False(t, req.Connect)
+	require.Equal(t, "Service.Meta.version == v1", req.Filter)
+	require.Equal(t, "dc2", req.Datacenter)
+	require.Equal(t, "ns1", req.Namespace)
+	require.Equal(t, "ap1", req.Partition)
+	require.Equal(t, "peer1", req.PeerName)
+
+	for _, target := range []string{"consul:///", "consul:///web/extra", "consul:///web?passing=maybe"} {
+		_, err := parseTarget(testTarget(t, target))
+		require.Error(t, err, target)
+	}
+}
+
+func TestAddressesFromEntries(t *testing.T) {
+	entries := []*pbhealth.ServiceEntry{
+		{
+			Node:    &pbhealth.Node{Address: "10.0.0.1"},
+			Service: &pbhealth.Service{Port: 8080},
+			Checks:  []*pbhealth.Check{{Status: pbhealth.Health_HEALTH_PASSING}},
+		},
+		{
+			Node:    &pbhealth.Node{Address: "10.0.0.2"},
+			Service: &pbhealth.Service{Address: "::1", Port: 8081},
+			Checks:  []*pbhealth.Check{{Status: pbhealth.Health_HEALTH_WARNING}},
+		},
+		{
+			Node:    &pbhealth.Node{Address: "10.0.0.3"},
+			Service: &pbhealth.Service{Port: 8080},
+			Checks:  []*pbhealth.Check{{Status: pbhealth.Health_HEALTH_CRITICAL}},
+		},
+		{
+			Node:    &pbhealth.Node{Address: "10.0.0.4"},
+			Service: &pbhealth.Service{Port: 8080},
+			Checks:  []*pbhealth.Check{{Status: pbhealth.Health_HEALTH_MAINTENANCE}},
+		},
+	}
+	require.Equal(t, []resolver.Address{
+		{Addr: "10.0.0.1:8080"},
+		{Addr: "[::1]:8081"},
+	}, addressesFromEntries(entries))
+}
+
+// fakeSubscriptionServer sends the responses it receives on its channel to
+// the stream, which it closes with the error it receives on its other
+// channel.
+type fakeSubscriptionServer struct {
+	responses chan *pbhealth.SubscribeServicesResponse
+	errs      chan error
+
+	mu     sync.Mutex
+	tokens []string
+	reqs   []*pbhealth.SubscribeServicesRequest
+}
+
+func (s *fakeSubscriptionServer) SubscribeServices(req *pbhealth.SubscribeServicesRequest, stream pbhealth.HealthSubscriptionService_SubscribeServicesServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.mu.Lock()
+	s.tokens = append(s.tokens, md.Get(tokenHeader)...)
+	s.reqs = append(s.reqs, req)
+	s.mu.Unlock()
+
+	for {
+		select {
+		case rsp := <-s.responses:
+			if err := stream.Send(rsp); err != nil {
+				return err
+			}
+		case err := <-s.errs:
+			return err
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// fakeClientConn records the states and errors reported by the resolver.
+type fakeClientConn struct {
+	resolver.ClientConn
+
+	states chan resolver.State
+	errs   chan error
+}
+
+func (cc *fakeClientConn) UpdateState(state resolver.State) error {
+	cc.states <- state
+	return nil
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.errs <- err
+}
+
+func (cc *fakeClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult {
+	return &serviceconfig.ParseResult{}
+}
+
+func runFakeSubscriptionServer(t *testing.T, server *fakeSubscriptionServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	pbhealth.RegisterHealthSubscriptionServiceServer(s, server)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestResolver(t *testing.T) {
+	server := &fakeSubscriptionServer{
+		responses: make(chan *pbhealth.SubscribeServicesResponse),
+		errs:      make(chan error),
+	}
+	addr := runFakeSubscriptionServer(t, server)
+
+	cc := &fakeClientConn{
+		states: make(chan resolver.State, 1),
+		errs:   make(chan error, 1),
+	}
+	builder := NewBuilder(&Config{
+		Address:             addr,
+		Token:               "secret",
+		LoadBalancingPolicy: "round_robin",
+	})
+	r, err := builder.Build(testTarget(t, "consul:///web?passing"), cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	entry := &pbhealth.ServiceEntry{
+		Node:    &pbhealth.Node{Address: "10.0.0.1"},
+		Service: &pbhealth.Service{Port: 8080},
+	}
+	server.responses <- &pbhealth.SubscribeServicesResponse{Service: "web", Entries: []*pbhealth.ServiceEntry{entry}}
+
+	state := <-cc.states
+	require.Equal(t, []resolver.Address{{Addr: "10.0.0.1:8080"}}, state.Addresses)
+	require.NotNil(t, state.ServiceConfig)
+
+	server.mu.Lock()
+	require.Equal(t, []string{"secret"}, server.tokens)
+	require.Equal(t, []string{"web"}, server.reqs[0].Services)
+	require.True(t, server.reqs[0].PassingOnly)
+	server.mu.Unlock()
+
+	// The resolver reports the error and subscribes again when the stream
+	// fails.
+	server.errs <- errors.New("boom")
+	require.Error(t, <-cc.errs)
+
+	select {
+	case server.responses <- &pbhealth.SubscribeServicesResponse{Service: "web"}:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the resolver did not subscribe again")
+	}
+	state = <-cc.states
+	require.Empty(t, state.Addresses)
+}
+
+func TestResolver_Agent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	server, err := testutil.NewTestServerConfigT(t, nil)
+	require.NoError(t, err)
+	defer server.Stop()
+	server.WaitForLeader(t)
+
+	client, err := api.NewClient(&api.Config{Address: server.HTTPAddr})
+	require.NoError(t, err)
+
+	for i, status := range []string{api.HealthPassing, api.HealthCritical} {
+		require.NoError(t, client.Agent().ServiceRegister(&api.AgentServiceRegistration{
+			ID:      fmt.Sprintf("web%d", i+1),
+			Name:    "web",
+			Address: "10.0.0.1",
+			Port:    8080 + i,
+			Check: &api.AgentServiceCheck{
+				TTL:    "10m",
+				Status: status,
+			},
+		}))
+	}
+
+	cc := &fakeClientConn{
+		states: make(chan resolver.State, 10),
+		errs:   make(chan error, 10),
+	}
+	r, err := NewBuilder(&Config{Address: server.GRPCAddr}).Build(testTarget(t, "consul:///web"), cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	retry.Run(t, func(r *retry.R) {
+		select {
+		case state := <-cc.states:
+			require.Equal(r, []resolver.Address{{Addr: "10.0.0.1:8080"}}, state.Addresses)
+		case err := <-cc.errs:
+			r.Fatal(err)
+		case <-time.After(time.Second):
+			r.Fatal("no update")
+		}
+	})
+}
@@ -219,11 +219,37 @@ func (c HealthChecks) AggregatedStatus() string {
 	}
 }
 
+const (
+	// XDSStatusConnected and XDSStatusDisconnected are the xDS status of the
+	// sidecar proxies registered with the agent answering a health query,
+	// which serves their xDS stream.
+	XDSStatusConnected    = "connected"
+	XDSStatusDisconnected = "disconnected"
+
+	// XDSStatusUnknown is the xDS status of the sidecar proxies registered
+	// with other agents.
+	XDSStatusUnknown = "unknown"
+)
+
 // ServiceEntry is used for the health service endpoint
 type ServiceEntry struct {
 	Node    *Node
 	Service *AgentService
 	Checks  HealthChecks
+
+	// SidecarProxy is the sidecar proxy of the service instance, only set
+	// when QueryOptions.IncludeSidecarProxy is set and the instance has one.
+	SidecarProxy *SidecarProxyEntry `json:",omitempty"`
+}
+
+// SidecarProxyEntry is the health of the sidecar proxy of a service instance.
+type SidecarProxyEntry struct {
+	Service *AgentService
+	Checks  HealthChecks
+
+	// XDSStatus is one of XDSStatusConnected, XDSStatusDisconnected or
+	// XDSStatusUnknown.
+	XDSStatus string
 }
 
 // Health can be used to query the Health endpoints
@@ -364,6 +390,75 @@ func (h *Health) service(service string, tags []string, passingOnly bool, q *Que
 	return out, qm, nil
 }
 
+// Services is used to query the instances of several services with a single
+// blocking query. The instances are keyed by service name. When no services
+// are given, all the services are queried and q.Filter must be set.
+func (h *Health) Services(services []string, passingOnly bool, q *QueryOptions) (map[string][]*ServiceEntry, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/health/services")
+	r.setQueryOptions(q)
+	for _, service := range services {
+		r.params.Add("service", service)
+	}
+	if passingOnly {
+		r.params.Set(HealthPassing, "1")
+	}
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out map[string][]*ServiceEntry
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
+// ServiceHealthSummary holds the number of instances of a service in each
+// health state.
+type ServiceHealthSummary struct {
+	Name      string
+	Kind      ServiceKind `json:",omitempty"`
+	Namespace string      `json:",omitempty"`
+	Partition string      `json:",omitempty"`
+	Passing   int
+	Warning   int
+	Critical  int
+}
+
+// Summary is used to retrieve the number of passing, warning and critical
+// instances of each service in a namespace or partition.
+func (h *Health) Summary(q *QueryOptions) ([]*ServiceHealthSummary, *QueryMeta, error) {
+	r := h.c.newRequest("GET", "/v1/health/summary")
+	r.setQueryOptions(q)
+	rtt, resp, err := h.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out []*ServiceHealthSummary
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
 // State is used to retrieve all the checks in a given state.
 // The wildcard "any" state can also be used for all checks.
 func (h *Health) State(state string, q *QueryOptions) (HealthChecks, *QueryMeta, error) {
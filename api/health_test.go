@@ -339,6 +339,46 @@ func TestAPI_HealthService(t *testing.T) {
 	})
 }
 
+func TestAPI_HealthServices(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	health := c.Health()
+	retry.Run(t, func(r *retry.R) {
+		services, meta, err := health.Services([]string{"consul", "missing"}, true, nil)
+		require.NoError(r, err)
+		require.NotZero(r, meta.LastIndex)
+		require.Len(r, services, 2)
+		require.NotEmpty(r, services["consul"])
+		require.Empty(r, services["missing"])
+	})
+
+	services, _, err := health.Services(nil, false, &QueryOptions{Filter: "Service.Service == consul"})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.NotEmpty(t, services["consul"])
+
+	_, _, err = health.Services(nil, false, nil)
+	require.Error(t, err)
+}
+
+func TestAPI_HealthSummary(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+
+	health := c.Health()
+	retry.Run(t, func(r *retry.R) {
+		summaries, meta, err := health.Summary(nil)
+		require.NoError(r, err)
+		require.NotZero(r, meta.LastIndex)
+		require.Len(r, summaries, 1)
+		require.Equal(r, "consul", summaries[0].Name)
+		require.Equal(r, 1, summaries[0].Passing)
+	})
+}
+
 func TestAPI_HealthService_SingleTag(t *testing.T) {
 	t.Parallel()
 	c, s := makeClientWithConfig(t, nil, func(conf *testutil.TestServerConfig) {
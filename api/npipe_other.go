@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+func dialNamedPipe(context.Context, string) (net.Conn, error) {
+	return nil, errors.New("named pipes are only supported on Windows")
+}
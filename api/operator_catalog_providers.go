@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"net/url"
+	"time"
+)
+
+// CatalogProviderStatus describes the sync of the catalog providers.
+type CatalogProviderStatus struct {
+	Providers []CatalogProviderSyncStatus
+}
+
+// CatalogProviderSyncStatus describes the sync of a catalog provider.
+type CatalogProviderSyncStatus struct {
+	Name string
+	Type string
+
+	// Node is the node the instances of the provider are registered on.
+	Node      string
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+
+	// Services and Instances are the number of services and instances
+	// registered by the last successful sync.
+	Services  int
+	Instances int
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+}
+
+// CatalogProviderStatus is used to query the status of the sync of the
+// catalog providers.
+func (op *Operator) CatalogProviderStatus(q *QueryOptions) (*CatalogProviderStatus, *QueryMeta, error) {
+	var out *CatalogProviderStatus
+	qm, err := op.getCatalogProviderStatus("/v1/operator/catalog-providers", q, &out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
+// CatalogProviderSyncStatus is used to query the status of the sync of a
+// single catalog provider.
+func (op *Operator) CatalogProviderSyncStatus(name string, q *QueryOptions) (*CatalogProviderSyncStatus, *QueryMeta, error) {
+	var out *CatalogProviderSyncStatus
+	qm, err := op.getCatalogProviderStatus("/v1/operator/catalog-provider/"+url.PathEscape(name), q, &out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
+func (op *Operator) getCatalogProviderStatus(path string, q *QueryOptions, out interface{}) (*QueryMeta, error) {
+	r := op.c.newRequest("GET", path)
+	r.setQueryOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	if err := decodeBody(resp, out); err != nil {
+		return nil, err
+	}
+	return qm, nil
+}
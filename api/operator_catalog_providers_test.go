@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorCatalogProviders(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForSerfCheck(t)
+
+	status, _, err := c.Operator().CatalogProviderStatus(nil)
+	require.NoError(t, err)
+	require.Empty(t, status.Providers)
+
+	_, _, err = c.Operator().CatalogProviderSyncStatus("legacy", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Catalog provider "legacy" not found`)
+}
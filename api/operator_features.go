@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+const (
+	// ClusterFeatureFederationStates is the replication of federation states
+	// between datacenters.
+	ClusterFeatureFederationStates = "federation-states"
+
+	// ClusterFeatureServiceIntentions is the storage of intentions as
+	// service-intentions config entries.
+	ClusterFeatureServiceIntentions = "service-intentions"
+
+	// ClusterFeatureStreaming is the streaming backend for the health and
+	// catalog endpoints.
+	ClusterFeatureStreaming = "streaming"
+
+	// ClusterFeaturePeering is cluster peering.
+	ClusterFeaturePeering = "peering"
+
+	// ClusterFeatureResourceAPIs is the v2 catalog and resource APIs.
+	ClusterFeatureResourceAPIs = "resource-apis"
+)
+
+// ClusterFeature reports whether a protocol feature is active in a
+// datacenter.
+type ClusterFeature struct {
+	// Name is the name of the feature.
+	Name string
+
+	// Active is true when every server in the datacenter supports and
+	// enables the feature.
+	Active bool
+
+	// MissingServers lists the servers which do not support the feature yet
+	// or do not enable it.
+	MissingServers []string `json:",omitempty"`
+}
+
+// ClusterFeatures is returned when querying for the protocol features which
+// are active in a datacenter.
+type ClusterFeatures struct {
+	// Datacenter is the datacenter the features were computed for.
+	Datacenter string
+
+	// Features holds the known features, sorted by name.
+	Features []ClusterFeature
+}
+
+// Active returns whether the named feature is active. Features unknown to
+// the servers are reported as inactive.
+func (f *ClusterFeatures) Active(name string) bool {
+	for _, feature := range f.Features {
+		if feature.Name == name {
+			return feature.Active
+		}
+	}
+	return false
+}
+
+// ClusterFeatures is used to query which protocol features are active in the
+// datacenter given the versions and configuration of its servers. Clients can
+// use it to gate behavior during rolling upgrades.
+func (op *Operator) ClusterFeatures(q *QueryOptions) (*ClusterFeatures, error) {
+	r := op.c.newRequest("GET", "/v1/operator/features")
+	r.setQueryOptions(q)
+	_, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, err
+	}
+
+	var out ClusterFeatures
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorClusterFeatures(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForLeader(t)
+
+	out, err := c.Operator().ClusterFeatures(nil)
+	require.NoError(t, err)
+	require.Equal(t, "dc1", out.Datacenter)
+	require.True(t, out.Active(ClusterFeatureFederationStates))
+	require.True(t, out.Active(ClusterFeatureServiceIntentions))
+}
+
+func TestAPI_ClusterFeaturesActive(t *testing.T) {
+	features := &ClusterFeatures{
+		Features: []ClusterFeature{
+			{Name: ClusterFeaturePeering, Active: true},
+			{Name: ClusterFeatureStreaming, MissingServers: []string{"server-2"}},
+		},
+	}
+	require.True(t, features.Active(ClusterFeaturePeering))
+	require.False(t, features.Active(ClusterFeatureStreaming))
+	require.False(t, features.Active("unknown"))
+}
@@ -3,6 +3,13 @@
 
 package api
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
 // keyringRequest is used for performing Keyring operations
 type keyringRequest struct {
 	Key string
@@ -108,3 +115,229 @@ func (op *Operator) KeyringUse(key string, q *WriteOptions) error {
 	}
 	return nil
 }
+
+// KeyringRotateStep is a step of an automated gossip encryption key rotation.
+type KeyringRotateStep string
+
+const (
+	// KeyringRotateStepInstall installs the new key on all members.
+	KeyringRotateStepInstall KeyringRotateStep = "install"
+
+	// KeyringRotateStepUse makes the new key the primary key of all members.
+	KeyringRotateStepUse KeyringRotateStep = "use"
+
+	// KeyringRotateStepRemove removes the previous keys from all members.
+	KeyringRotateStepRemove KeyringRotateStep = "remove"
+)
+
+const (
+	defaultKeyringRotateTimeout      = 5 * time.Minute
+	defaultKeyringRotatePollInterval = 2 * time.Second
+)
+
+// KeyringRotateOptions configures an automated gossip encryption key rotation.
+type KeyringRotateOptions struct {
+	// Key is the new gossip encryption key.
+	Key string
+
+	// Timeout is the longest time to wait for each step of the rotation to
+	// propagate to all members. Defaults to 5 minutes.
+	Timeout time.Duration
+
+	// PollInterval is the time between checks of the keyring while waiting
+	// for a step to propagate. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// KeepOldKeys skips removing the previous keys once the new key is the
+	// primary key of all members.
+	KeepOldKeys bool
+
+	// Progress, if not nil, is called with a description of the progress of
+	// the rotation as it goes.
+	Progress func(KeyringRotateProgress)
+}
+
+// KeyringRotateProgress describes the progress of a key rotation.
+type KeyringRotateProgress struct {
+	Step    KeyringRotateStep
+	Message string
+}
+
+// KeyringRotateResult is returned when a key rotation completes.
+type KeyringRotateResult struct {
+	// PrimaryKey is the new primary key.
+	PrimaryKey string
+
+	// RemovedKeys are the previous keys that were removed from the keyrings.
+	RemovedKeys []string
+}
+
+// KeyringRotate rotates the gossip encryption key of the cluster. It installs
+// the new key, waits until all members of all keyrings have it, makes it the
+// primary key, waits again until all members use it, and finally removes the
+// previous keys. Each step is verified before the next one starts, so a
+// member that is missing an update stops the rotation before any key it still
+// relies on is removed.
+//
+// The WriteOptions are used for the keyring operations, and their context for
+// the whole rotation.
+func (op *Operator) KeyringRotate(opts *KeyringRotateOptions, q *WriteOptions) (*KeyringRotateResult, error) {
+	if opts == nil || opts.Key == "" {
+		return nil, fmt.Errorf("a new key is required")
+	}
+	r := &keyringRotation{op: op, opts: *opts, q: q}
+	if r.opts.Timeout <= 0 {
+		r.opts.Timeout = defaultKeyringRotateTimeout
+	}
+	if r.opts.PollInterval <= 0 {
+		r.opts.PollInterval = defaultKeyringRotatePollInterval
+	}
+	return r.run()
+}
+
+type keyringRotation struct {
+	op   *Operator
+	opts KeyringRotateOptions
+	q    *WriteOptions
+}
+
+func (r *keyringRotation) run() (*KeyringRotateResult, error) {
+	key := r.opts.Key
+
+	r.progress(KeyringRotateStepInstall, "Installing the new key")
+	if err := r.op.KeyringInstall(key, r.q); err != nil {
+		return nil, fmt.Errorf("failed to install the new key: %w", err)
+	}
+	err := r.waitFor(KeyringRotateStepInstall, func(resp *KeyringResponse) (int, bool) {
+		n := resp.Keys[key]
+		return n, n == resp.NumNodes
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.progress(KeyringRotateStepUse, "Making the new key the primary key")
+	if err := r.op.KeyringUse(key, r.q); err != nil {
+		return nil, fmt.Errorf("failed to make the new key the primary key: %w", err)
+	}
+	err = r.waitFor(KeyringRotateStepUse, func(resp *KeyringResponse) (int, bool) {
+		n := resp.PrimaryKeys[key]
+		return n, n == resp.NumNodes
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &KeyringRotateResult{PrimaryKey: key}
+	if r.opts.KeepOldKeys {
+		r.progress(KeyringRotateStepRemove, "Keeping the previous keys")
+		return result, nil
+	}
+
+	responses, err := r.list()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the previous keys: %w", err)
+	}
+	var oldKeys []string
+	seen := make(map[string]bool)
+	for _, resp := range responses {
+		for k := range resp.Keys {
+			if k != key && !seen[k] {
+				seen[k] = true
+				oldKeys = append(oldKeys, k)
+			}
+		}
+	}
+	sort.Strings(oldKeys)
+
+	for _, old := range oldKeys {
+		r.progress(KeyringRotateStepRemove, fmt.Sprintf("Removing key %s", old))
+		if err := r.op.KeyringRemove(old, r.q); err != nil {
+			return nil, fmt.Errorf("failed to remove key %s: %w", old, err)
+		}
+	}
+	err = r.waitFor(KeyringRotateStepRemove, func(resp *KeyringResponse) (int, bool) {
+		for _, old := range oldKeys {
+			if resp.Keys[old] > 0 {
+				return resp.NumNodes - resp.Keys[old], false
+			}
+		}
+		return resp.NumNodes, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.RemovedKeys = oldKeys
+	return result, nil
+}
+
+// waitFor polls the keyrings until done returns true for all of them. done
+// returns the number of members of the keyring that completed the step.
+func (r *keyringRotation) waitFor(step KeyringRotateStep, done func(*KeyringResponse) (int, bool)) error {
+	ctx := r.q.Context()
+	deadline := time.NewTimer(r.opts.Timeout)
+	defer deadline.Stop()
+
+	var pending []string
+	for {
+		responses, err := r.list()
+		if err == nil {
+			pending = pending[:0]
+			for _, resp := range responses {
+				if n, ok := done(resp); !ok {
+					pending = append(pending, fmt.Sprintf("%s %d/%d", keyringName(resp), n, resp.NumNodes))
+				}
+			}
+			if len(pending) == 0 {
+				r.progress(step, "Verified on all members")
+				return nil
+			}
+			r.progress(step, "Waiting for "+strings.Join(pending, ", "))
+		} else {
+			// Members that are restarting or unreachable cause errors; they
+			// are retried until the timeout.
+			r.progress(step, fmt.Sprintf("Waiting for the keyring: %s", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			if err != nil {
+				return fmt.Errorf("timed out verifying the %s step: %w", step, err)
+			}
+			return fmt.Errorf("timed out verifying the %s step, still waiting for %s", step, strings.Join(pending, ", "))
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
+
+func (r *keyringRotation) list() ([]*KeyringResponse, error) {
+	q := &QueryOptions{}
+	if r.q != nil {
+		q.Datacenter = r.q.Datacenter
+		q.Token = r.q.Token
+		q.RelayFactor = r.q.RelayFactor
+	}
+	return r.op.KeyringList(q.WithContext(r.q.Context()))
+}
+
+func (r *keyringRotation) progress(step KeyringRotateStep, msg string) {
+	if r.opts.Progress != nil {
+		r.opts.Progress(KeyringRotateProgress{Step: step, Message: msg})
+	}
+}
+
+// keyringName describes the keyring a response is for.
+func keyringName(resp *KeyringResponse) string {
+	name := resp.Datacenter + " (LAN)"
+	if resp.WAN {
+		name = "WAN"
+	}
+	if resp.Segment != "" {
+		name += " [segment: " + resp.Segment + "]"
+	} else if resp.Partition != "" && resp.Partition != "default" {
+		name += " [partition: " + resp.Partition + "]"
+	}
+	return name
+}
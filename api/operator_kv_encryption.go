@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "time"
+
+// KVEncryptionKey describes a data encryption key used to encrypt KV values.
+type KVEncryptionKey struct {
+	ID         string
+	Provider   string
+	CreateTime time.Time
+	Active     bool
+
+	// Entries is the number of KV entries encrypted with the key.
+	Entries int
+}
+
+// KVEncryptionStatus describes the encryption of the KV values.
+type KVEncryptionStatus struct {
+	// Enabled is whether the server that handled the request encrypts values.
+	Enabled bool
+
+	// Provider is the key provider configured on the server.
+	Provider string `json:",omitempty"`
+
+	// ActiveKeyID is the ID of the key that new values are encrypted with.
+	ActiveKeyID string `json:",omitempty"`
+
+	Keys []KVEncryptionKey
+
+	// PlaintextEntries is the number of KV entries that are not encrypted.
+	PlaintextEntries int
+
+	// Rewrapped is the number of KV entries that were re-encrypted with the
+	// active key by KVEncryptionRewrap.
+	Rewrapped int `json:",omitempty"`
+}
+
+// KVEncryptionStatus is used to query the status of the KV encryption.
+func (op *Operator) KVEncryptionStatus(q *QueryOptions) (*KVEncryptionStatus, *QueryMeta, error) {
+	r := op.c.newRequest("GET", "/v1/operator/kv-encryption")
+	r.setQueryOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out *KVEncryptionStatus
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
+
+// KVEncryptionRotate is used to generate a new KV encryption key, which is
+// used to encrypt the values written from now on.
+func (op *Operator) KVEncryptionRotate(q *WriteOptions) (*KVEncryptionStatus, *WriteMeta, error) {
+	return op.kvEncryptionWrite("/v1/operator/kv-encryption/rotate", q)
+}
+
+// KVEncryptionRewrap is used to re-wrap the KV encryption keys with the key
+// provider, and to re-encrypt the KV values which are not encrypted with the
+// active KV encryption key.
+func (op *Operator) KVEncryptionRewrap(q *WriteOptions) (*KVEncryptionStatus, *WriteMeta, error) {
+	return op.kvEncryptionWrite("/v1/operator/kv-encryption/rewrap", q)
+}
+
+func (op *Operator) kvEncryptionWrite(endpoint string, q *WriteOptions) (*KVEncryptionStatus, *WriteMeta, error) {
+	r := op.c.newRequest("PUT", endpoint)
+	r.setWriteOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	wm := &WriteMeta{}
+	wm.RequestTime = rtt
+
+	var out *KVEncryptionStatus
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, wm, nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorKVEncryption(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForSerfCheck(t)
+
+	_, err := c.KV().Put(&KVPair{Key: "foo", Value: []byte("bar")}, nil)
+	require.NoError(t, err)
+
+	status, qm, err := c.Operator().KVEncryptionStatus(nil)
+	require.NoError(t, err)
+	require.NotZero(t, qm.LastIndex)
+	require.False(t, status.Enabled)
+	require.Empty(t, status.Keys)
+	require.Equal(t, 1, status.PlaintextEntries)
+
+	_, _, err = c.Operator().KVEncryptionRotate(nil)
+	require.ErrorContains(t, err, "KV encryption is not enabled")
+}
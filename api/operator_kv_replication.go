@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "time"
+
+// KVReplicationStatus describes the replication of the KV prefixes from the
+// primary datacenter.
+type KVReplicationStatus struct {
+	// Enabled is whether KV prefixes are configured to be replicated.
+	Enabled bool
+
+	// Running is whether the leader is replicating the prefixes, which is
+	// only the case in secondary datacenters.
+	Running bool
+
+	SourceDatacenter string `json:",omitempty"`
+
+	Prefixes []KVReplicationPrefixStatus
+}
+
+// KVReplicationPrefixStatus describes the replication of a KV prefix.
+type KVReplicationPrefixStatus struct {
+	Prefix string
+
+	// ReplicatedIndex is the index in the primary datacenter the prefix was
+	// last replicated at.
+	ReplicatedIndex uint64
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+}
+
+// KVReplicationStatus is used to query the status of the replication of the
+// KV prefixes from the primary datacenter.
+func (op *Operator) KVReplicationStatus(q *QueryOptions) (*KVReplicationStatus, *QueryMeta, error) {
+	r := op.c.newRequest("GET", "/v1/operator/kv-replication")
+	r.setQueryOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out *KVReplicationStatus
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorKVReplication(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForSerfCheck(t)
+
+	status, _, err := c.Operator().KVReplicationStatus(nil)
+	require.NoError(t, err)
+	require.False(t, status.Enabled)
+	require.False(t, status.Running)
+	require.Empty(t, status.Prefixes)
+}
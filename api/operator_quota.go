@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// QuotaLimits are the maximum amount of objects which may be stored in a
+// namespace. A limit of zero means unlimited.
+type QuotaLimits struct {
+	MaxServices   int
+	MaxKVBytes    int
+	MaxIntentions int
+	MaxACLTokens  int
+}
+
+// QuotaUsage is the amount of quota limited objects stored in a namespace.
+type QuotaUsage struct {
+	Services   int
+	KVBytes    int
+	Intentions int
+	ACLTokens  int
+}
+
+// Quota contains the quota limits and usage of a namespace.
+type Quota struct {
+	Partition string `json:",omitempty"`
+	Namespace string `json:",omitempty"`
+	Limits    QuotaLimits
+	Usage     QuotaUsage
+}
+
+// Quota is used to query for the quota limits and usage of the namespace given
+// in the query options.
+func (op *Operator) Quota(q *QueryOptions) (*Quota, *QueryMeta, error) {
+	r := op.c.newRequest("GET", "/v1/operator/quota")
+	r.setQueryOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out *Quota
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
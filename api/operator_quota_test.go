@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorQuota(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForSerfCheck(t)
+
+	_, err := c.KV().Put(&KVPair{Key: "foo", Value: []byte("bar")}, nil)
+	require.NoError(t, err)
+	_, err = c.KV().Put(&KVPair{Key: "baz", Value: []byte("quux")}, nil)
+	require.NoError(t, err)
+
+	quota, qm, err := c.Operator().Quota(nil)
+	require.NoError(t, err)
+	require.NotZero(t, qm.LastIndex)
+	require.Equal(t, QuotaLimits{}, quota.Limits)
+	require.Equal(t, 7, quota.Usage.KVBytes)
+	require.Equal(t, 1, quota.Usage.Services)
+}
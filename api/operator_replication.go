@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "time"
+
+// ReplicationStatus describes the replication of a kind of data into a
+// datacenter, from the primary datacenter or from a peer.
+type ReplicationStatus struct {
+	// Kind is the kind of data replicated: "config-entries",
+	// "federation-states", "acl-policies", "acl-roles", "acl-tokens" or
+	// "peering".
+	Kind string
+
+	// SourceDatacenter is the datacenter the data is replicated from, it is
+	// empty for the replication from a peer.
+	SourceDatacenter string `json:",omitempty"`
+
+	// SourcePeer is the name of the peer the data is replicated from, it is
+	// empty for the replication from the primary datacenter.
+	SourcePeer      string `json:",omitempty"`
+	SourcePartition string `json:",omitempty"`
+
+	// Running is whether the replication is running, or for a peer, whether
+	// the peering stream is connected.
+	Running bool
+
+	// ReplicatedIndex is the index in the source datacenter the data was last
+	// replicated at. It is not tracked for peers.
+	ReplicatedIndex uint64 `json:",omitempty"`
+
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string `json:",omitempty"`
+
+	// LagSeconds is how long the data may have been behind the source. It is
+	// zero while the replication is healthy.
+	LagSeconds float64
+}
+
+// ReplicationStatuses holds the status of the replication of each kind of
+// data into a datacenter.
+type ReplicationStatuses struct {
+	Datacenter   string
+	Replications []ReplicationStatus
+}
+
+// ReplicationStatus is used to query the status and the lag of the
+// replication from the primary datacenter and from the peers.
+func (op *Operator) ReplicationStatus(q *QueryOptions) (*ReplicationStatuses, *QueryMeta, error) {
+	r := op.c.newRequest("GET", "/v1/operator/replication")
+	r.setQueryOptions(q)
+	rtt, resp, err := op.c.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeResponseBody(resp)
+	if err := requireOK(resp); err != nil {
+		return nil, nil, err
+	}
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	var out *ReplicationStatuses
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return out, qm, nil
+}
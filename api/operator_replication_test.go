@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_OperatorReplication(t *testing.T) {
+	t.Parallel()
+	c, s := makeClient(t)
+	defer s.Stop()
+	s.WaitForSerfCheck(t)
+
+	status, _, err := c.Operator().ReplicationStatus(nil)
+	require.NoError(t, err)
+	require.Equal(t, "dc1", status.Datacenter)
+	require.Empty(t, status.Replications)
+}
@@ -8,7 +8,8 @@ type Usage struct {
 	Usage map[string]ServiceUsage
 }
 
-// ServiceUsage contains information about the number of services and service instances for a datacenter.
+// ServiceUsage contains information about the number of services and service instances for a datacenter,
+// as well as the number of nodes, KV entries, config entries, peerings and ACL tokens.
 type ServiceUsage struct {
 	Nodes                   int
 	Services                int
@@ -30,6 +31,18 @@ type ServiceUsage struct {
 
 	// A map of partition+namespace to number of billable instances registered in that namespace
 	PartitionNamespaceBillableServiceInstances map[string]map[string]int
+
+	// Number of KV entries
+	KVEntries int
+
+	// A map of config entry kind to number of config entries of that kind
+	ConfigEntries map[string]int
+
+	// Number of cluster peerings
+	Peerings int
+
+	// Number of ACL tokens
+	ACLTokens int
 }
 
 // Usage is used to query for usage information in the given datacenter.
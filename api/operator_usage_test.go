@@ -50,6 +50,9 @@ func TestAPI_OperatorUsage(t *testing.T) {
 		Connect: &AgentServiceConnect{Native: true},
 	})
 
+	_, err := c.KV().Put(&KVPair{Key: "foo", Value: []byte("bar")}, nil)
+	require.NoError(t, err)
+
 	usage, _, err := c.Operator().Usage(nil)
 	require.NoError(t, err)
 	require.Contains(t, usage.Usage, "dc1")
@@ -64,4 +67,6 @@ func TestAPI_OperatorUsage(t *testing.T) {
 		"terminating-gateway": 0,
 	}, usage.Usage["dc1"].ConnectServiceInstances)
 	require.Equal(t, 3, usage.Usage["dc1"].BillableServiceInstances)
+	require.Equal(t, 1, usage.Usage["dc1"].KVEntries)
+	require.Equal(t, 0, usage.Usage["dc1"].ConfigEntries["service-defaults"])
 }
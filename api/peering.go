@@ -90,6 +90,9 @@ type Peering struct {
 	ModifyIndex uint64
 	// Remote contains metadata for the remote peer.
 	Remote PeeringRemoteInfo
+	// ACLPolicies are the names of the ACL policies constraining the services
+	// exported to the peer.
+	ACLPolicies []string `json:",omitempty"`
 }
 
 type PeeringStreamStatus struct {
@@ -120,6 +123,13 @@ type PeeringGenerateTokenRequest struct {
 	// load balancer(s) or external IPs to reach the servers from the dialing side, and will override any server
 	// addresses obtained from the "consul" service.
 	ServerExternalAddresses []string `json:",omitempty"`
+	// ACLPolicies are the names of the ACL policies constraining the services
+	// exported to the peer. When set for an existing peering, they replace its
+	// policies.
+	ACLPolicies []string `json:",omitempty"`
+	// ClearACLPolicies removes the ACL policies of an existing peering. It
+	// can't be combined with ACLPolicies.
+	ClearACLPolicies bool `json:",omitempty"`
 }
 
 type PeeringGenerateTokenResponse struct {
@@ -137,6 +147,9 @@ type PeeringEstablishRequest struct {
 	Partition string `json:",omitempty"`
 	// Meta is a mapping of some string value to any other string value
 	Meta map[string]string `json:",omitempty"`
+	// ACLPolicies are the names of the ACL policies constraining the services
+	// exported to the peer.
+	ACLPolicies []string `json:",omitempty"`
 }
 
 type PeeringEstablishResponse struct {
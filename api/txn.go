@@ -5,6 +5,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,10 +24,11 @@ func (c *Client) Txn() *Txn {
 // TxnOp is the internal format we send to Consul. Currently only K/V and
 // check operations are supported.
 type TxnOp struct {
-	KV      *KVTxnOp
-	Node    *NodeTxnOp
-	Service *ServiceTxnOp
-	Check   *CheckTxnOp
+	KV          *KVTxnOp
+	Node        *NodeTxnOp
+	Service     *ServiceTxnOp
+	Check       *CheckTxnOp
+	ConfigEntry *ConfigEntryTxnOp `json:",omitempty"`
 }
 
 // TxnOps is a list of transaction operations.
@@ -164,6 +166,54 @@ type CheckTxnOp struct {
 	Check HealthCheck
 }
 
+// ConfigEntryOp constants give possible operations available in a transaction.
+type ConfigEntryOp string
+
+const (
+	ConfigEntrySet       ConfigEntryOp = "set"
+	ConfigEntryCAS       ConfigEntryOp = "cas"
+	ConfigEntryDelete    ConfigEntryOp = "delete"
+	ConfigEntryDeleteCAS ConfigEntryOp = "delete-cas"
+)
+
+// ConfigEntryTxnOp defines a single operation on a config entry inside a
+// transaction. Transactions with config entry operations must be sent to the
+// primary datacenter.
+//
+// The CAS operations compare Index with the ModifyIndex of the existing
+// entry. An Index of 0 with ConfigEntryCAS only creates the entry if it does
+// not exist yet.
+type ConfigEntryTxnOp struct {
+	Verb  ConfigEntryOp
+	Entry ConfigEntry
+	Index uint64
+}
+
+// UnmarshalJSON decodes the config entry of the operation according to its
+// kind.
+func (o *ConfigEntryTxnOp) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Verb  ConfigEntryOp
+		Entry json.RawMessage
+		Index uint64
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	o.Verb = raw.Verb
+	o.Index = raw.Index
+	o.Entry = nil
+	if len(raw.Entry) > 0 && !bytes.Equal(raw.Entry, []byte("null")) {
+		entry, err := DecodeConfigEntryFromJSON(raw.Entry)
+		if err != nil {
+			return err
+		}
+		o.Entry = entry
+	}
+	return nil
+}
+
 // Txn is used to apply multiple Consul operations in a single, atomic transaction.
 //
 // Note that Go will perform the required base64 encoding on the values
@@ -29,6 +29,7 @@ func init() {
 		"connect_roots": connectRootsWatch,
 		"connect_leaf":  connectLeafWatch,
 		"agent_service": agentServiceWatch,
+		"member_events": memberEventsWatch,
 	}
 }
 
@@ -337,6 +338,34 @@ func agentServiceWatch(params map[string]interface{}) (WatcherFunc, error) {
 	return fn, nil
 }
 
+// memberEventsWatch is used to watch the changes of the membership of the LAN
+// gossip pool observed by the agent. The handler is invoked with the new
+// events only.
+func memberEventsWatch(params map[string]interface{}) (WatcherFunc, error) {
+	// We don't support consistency modes since it's agent local data
+
+	filter := ""
+	if err := assignValue(params, "filter", &filter); err != nil {
+		return nil, err
+	}
+
+	fn := func(p *Plan) (BlockingParamVal, interface{}, error) {
+		agent := p.client.Agent()
+		opts := makeQueryOptionsWithContext(p, false)
+		if filter != "" {
+			opts.Filter = filter
+		}
+		defer p.cancelFunc()
+
+		events, meta, err := agent.MemberEvents(&opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return WaitIndexVal(meta.LastIndex), events, err
+	}
+	return fn, nil
+}
+
 func makeQueryOptionsWithContext(p *Plan, stale bool) consulapi.QueryOptions {
 	ctx, cancel := context.WithCancel(context.Background())
 	p.setCancelFunc(cancel)
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglass
+
+import (
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+)
+
+func New() *cmd {
+	return &cmd{}
+}
+
+type cmd struct{}
+
+func (c *cmd) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(help, nil)
+}
+
+const synopsis = "Manage Consul's ACL break-glass token"
+const help = `
+Usage: consul acl break-glass <subcommand> [options] [args]
+
+  This command has subcommands for managing the ACL break-glass token, a
+  sealed management token used to recover from an ACL lockout. The key of the
+  seal is split into shares handed to the operators, and a threshold of them
+  must be submitted to activate a time-limited management token.
+
+  Initialize the seal with 5 key shares, 3 of them activating the token:
+
+      $ consul acl break-glass init -shares 5 -threshold 3 -ttl 1h
+
+  Submit a key share:
+
+      $ consul acl break-glass unseal <share>
+
+  Show the status of the seal:
+
+      $ consul acl break-glass status
+
+  For more examples, ask for subcommand help or view the documentation.
+`
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglassinit
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	shares    int
+	threshold int
+	ttl       time.Duration
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.IntVar(&c.shares, "shares", 5, "Number of key shares to split the key of the seal into.")
+	c.flags.IntVar(&c.threshold, "threshold", 3, "Number of key shares required to activate the break-glass token.")
+	c.flags.DurationVar(&c.ttl, "ttl", time.Hour, "How long the activated break-glass token is valid.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	out, _, err := client.ACL().BreakGlassInit(&api.ACLBreakGlassInitRequest{
+		Shares:    c.shares,
+		Threshold: c.threshold,
+		TTL:       c.ttl,
+	}, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to initialize the break-glass seal: %v", err))
+		return 1
+	}
+
+	for i, share := range out.Shares {
+		c.UI.Output(fmt.Sprintf("Key Share %d: %s", i+1, share))
+	}
+	c.UI.Output("")
+	c.UI.Info(fmt.Sprintf("The break-glass seal was initialized with %d key shares, %d of them are required to\n"+
+		"activate a management token valid for %s. Distribute the key shares to different\n"+
+		"operators, they are not stored by Consul and cannot be shown again.", len(out.Shares), c.threshold, c.ttl))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Initialize the ACL break-glass seal"
+	help     = `
+Usage: consul acl break-glass init [options]
+
+    Initializes the ACL break-glass seal and prints its key shares, which must
+    be distributed to different operators. A threshold of the shares must be
+    submitted with "consul acl break-glass unseal" to activate a management
+    token valid for the TTL. Initializing the seal again replaces it, the
+    shares of the previous seal can no longer activate the token. This
+    requires acl:write and operator:write.
+
+    Example:
+
+        $ consul acl break-glass init -shares 5 -threshold 3 -ttl 1h
+`
+)
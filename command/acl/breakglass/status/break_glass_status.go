@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglassstatus
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	status, _, err := client.ACL().BreakGlassStatus(nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to read the break-glass status: %v", err))
+		return 1
+	}
+
+	if !status.Initialized {
+		c.UI.Info("The break-glass seal is not initialized.")
+		return 0
+	}
+	c.UI.Info(fmt.Sprintf("Initialized: %s", status.CreateTime.Format("2006-01-02 15:04:05 -0700 MST")))
+	c.UI.Info(fmt.Sprintf("Key Shares:  %d", status.Shares))
+	c.UI.Info(fmt.Sprintf("Threshold:   %d", status.Threshold))
+	c.UI.Info(fmt.Sprintf("Token TTL:   %s", status.TTL))
+	c.UI.Info(fmt.Sprintf("Progress:    %d/%d", status.Progress, status.Threshold))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Show the status of the ACL break-glass seal"
+	help     = `
+Usage: consul acl break-glass status [options]
+
+    Shows whether the ACL break-glass seal is initialized, its threshold and
+    the number of key shares submitted so far. This doesn't require any ACL
+    token.
+
+    Example:
+
+        $ consul acl break-glass status
+`
+)
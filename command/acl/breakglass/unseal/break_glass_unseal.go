@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglassunseal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/acl/token"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	format string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(
+		&c.format,
+		"format",
+		token.PrettyFormat,
+		fmt.Sprintf("Output format of the activated token {%s}", strings.Join(token.GetSupportedFormats(), "|")),
+	)
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = c.flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("This command requires exactly one argument: the key share")
+		return 1
+	}
+	share, err := helpers.LoadDataSource(args[0], os.Stdin)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error reading the key share: %v", err))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	out, _, err := client.ACL().BreakGlassUnseal(strings.TrimSpace(share), nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to submit the key share: %v", err))
+		return 1
+	}
+
+	if out.Token == nil {
+		c.UI.Info(fmt.Sprintf("Key share accepted, %d of %d submitted.", out.Status.Progress, out.Status.Threshold))
+		return 0
+	}
+
+	formatter, err := token.NewFormatter(c.format, false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	formatted, err := formatter.FormatToken(out.Token)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Warn("The break-glass token was activated. Use it to recover the ACL system and delete it once done.")
+	c.UI.Info(formatted)
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Submit a key share of the ACL break-glass seal"
+	help     = `
+Usage: consul acl break-glass unseal [options] SHARE
+
+    Submits a key share of the ACL break-glass seal. Once a threshold of the
+    shares is submitted within 15 minutes, a management token valid for the
+    TTL of the seal is created and printed. This doesn't require any ACL
+    token. The share can be read from stdin with "-" or from a file with "@".
+
+    Example:
+
+        $ consul acl break-glass unseal @share.txt
+`
+)
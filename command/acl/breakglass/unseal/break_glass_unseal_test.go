@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package breakglassunseal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestBreakGlassUnsealCommand_noTabs(t *testing.T) {
+	t.Parallel()
+
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestBreakGlassUnsealCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := agent.NewTestAgent(t, `
+	primary_datacenter = "dc1"
+	acl {
+		enabled = true
+		tokens {
+			initial_management = "root"
+		}
+	}`)
+
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	client := a.Client()
+	initOut, _, err := client.ACL().BreakGlassInit(&api.ACLBreakGlassInitRequest{
+		Shares:    3,
+		Threshold: 2,
+		TTL:       time.Hour,
+	}, &api.WriteOptions{Token: "root"})
+	require.NoError(t, err)
+	require.Len(t, initOut.Shares, 3)
+
+	ui := cli.NewMockUi()
+	code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), initOut.Shares[1]})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "1 of 2 submitted")
+
+	ui = cli.NewMockUi()
+	code = New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), initOut.Shares[2]})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.ErrorWriter.String(), "break-glass token was activated")
+	require.Contains(t, ui.OutputWriter.String(), "Break-Glass Token")
+
+	status, _, err := client.ACL().BreakGlassStatus(nil)
+	require.NoError(t, err)
+	require.True(t, status.Initialized)
+	require.Equal(t, time.Hour, status.TTL)
+	require.Equal(t, 0, status.Progress)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/consul/command/cli"
 	"github.com/hashicorp/consul/command/flags"
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/lib/egressproxy"
 	"github.com/hashicorp/consul/logging"
 	"github.com/hashicorp/consul/service_os"
 	consulversion "github.com/hashicorp/consul/version"
@@ -48,6 +49,8 @@ func New(ui cli.Ui) *cmd {
 		flags:             flag.NewFlagSet("", flag.ContinueOnError),
 	}
 	config.AddFlags(c.flags, &c.configLoadOpts)
+	c.flags.StringVar(&c.fixturesDir, "dev-fixtures", "", "Path to a directory of services, KV entries, config "+
+		"entries, intentions and ACL data to load into the agent once started. Requires -dev.")
 	c.help = flags.Usage(help, c.flags)
 	return c
 }
@@ -68,6 +71,10 @@ type cmd struct {
 	buildDate         time.Time
 	configLoadOpts    config.LoadOpts
 	logger            hclog.InterceptLogger
+
+	// fixturesDir is the directory of the fixtures loaded into a dev agent
+	// once it started.
+	fixturesDir string
 }
 
 func (c *cmd) Run(args []string) int {
@@ -138,6 +145,10 @@ func (c *cmd) run(args []string) int {
 		ui.Error(fmt.Sprintf("Unexpected extra arguments: %v", c.flags.Args()))
 		return 1
 	}
+	if c.fixturesDir != "" && (c.configLoadOpts.DevMode == nil || !*c.configLoadOpts.DevMode) {
+		ui.Error("The -dev-fixtures flag can only be used with -dev")
+		return 1
+	}
 
 	// FIXME: logs should always go to stderr, but previously they were sent to
 	// stdout, so continue to use Stdout for now, and fix this in a future release.
@@ -168,6 +179,9 @@ func (c *cmd) run(args []string) int {
 		ui.Error(err.Error())
 		return 1
 	}
+	// Requests to HCP made while bootstrapping must already use the configured
+	// egress proxy.
+	egressproxy.SetDefault(res.RuntimeConfig.EgressProxy)
 	if res.RuntimeConfig.IsCloudEnabled() {
 		client, err := hcpclient.NewClient(res.RuntimeConfig.Cloud)
 		if err != nil {
@@ -267,7 +281,20 @@ func (c *cmd) run(args []string) int {
 	// Let the agent know we've finished registration
 	agent.StartSync()
 
+	if c.fixturesDir != "" {
+		apiConfig, err := fixtureAPIConfig(config.HTTPAddrs)
+		if err == nil {
+			err = loadFixtures(context.Background(), c.fixturesDir, apiConfig, ui, c.logger)
+		}
+		if err != nil {
+			c.logger.Error("Error loading fixtures", "error", err)
+			return 1
+		}
+		c.logger.Info("Loaded fixtures", "dir", c.fixturesDir)
+	}
+
 	c.logger.Info("Consul agent running!")
+	service_os.Ready()
 
 	// wait for signal
 	signalCh = make(chan os.Signal, 10)
@@ -0,0 +1,309 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	mcli "github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/helpers"
+	"github.com/hashicorp/consul/command/kv/impexp"
+	"github.com/hashicorp/consul/command/services"
+)
+
+// The files and directories of a fixtures directory. All of them are
+// optional, and they are loaded in this order.
+const (
+	// fixtureACL holds the ACL bootstrap token and the policies, roles and
+	// tokens to create, in the format of aclFixtures.
+	fixtureACL = "acl.json"
+
+	// fixtureKV holds KV entries in the format of `consul kv export`.
+	fixtureKV = "kv.json"
+
+	// fixtureConfigEntries holds config entries in the format of
+	// `consul config write`, one per file, written in alphabetical order.
+	fixtureConfigEntries = "config-entries"
+
+	// fixtureIntentions holds a JSON list of intentions in the format of the
+	// /v1/connect/intentions endpoint.
+	fixtureIntentions = "intentions.json"
+
+	// fixtureServices holds service definitions in the format of
+	// `consul services register`.
+	fixtureServices = "services"
+)
+
+// fixtureLeaderTimeout is how long loading the fixtures waits for the agent
+// to elect itself as leader.
+const fixtureLeaderTimeout = 30 * time.Second
+
+// aclFixtures is the format of the acl.json fixture.
+type aclFixtures struct {
+	// BootstrapToken is the secret ID of the initial management token. A
+	// random one is generated if empty.
+	BootstrapToken string
+
+	// Policies, Roles and Tokens are created in this order, so the roles and
+	// tokens can link the policies and roles by name.
+	Policies []*api.ACLPolicy
+	Roles    []*api.ACLRole
+	Tokens   []*api.ACLToken
+}
+
+// fixtureLoader seeds a freshly started dev agent with the content of a
+// fixtures directory through its HTTP API.
+type fixtureLoader struct {
+	dir    string
+	config *api.Config
+	ui     mcli.Ui
+	logger hclog.Logger
+
+	client *api.Client
+}
+
+// loadFixtures loads the fixtures of dir into the agent reachable with
+// config.
+func loadFixtures(ctx context.Context, dir string, config *api.Config, ui mcli.Ui, logger hclog.Logger) error {
+	client, err := api.NewClient(config)
+	if err != nil {
+		return err
+	}
+	l := &fixtureLoader{dir: dir, config: config, ui: ui, logger: logger, client: client}
+
+	if err := l.waitForLeader(ctx); err != nil {
+		return err
+	}
+
+	steps := []struct {
+		name string
+		load func() error
+	}{
+		{fixtureACL, l.loadACL},
+		{fixtureKV, l.loadKV},
+		{fixtureConfigEntries, l.loadConfigEntries},
+		{fixtureIntentions, l.loadIntentions},
+		{fixtureServices, l.loadServices},
+	}
+	for _, step := range steps {
+		if err := step.load(); err != nil {
+			return fmt.Errorf("failed to load %s: %w", filepath.Join(dir, step.name), err)
+		}
+	}
+	return nil
+}
+
+// fixtureAPIConfig returns the API client config reaching the agent on the
+// first of its HTTP addresses.
+func fixtureAPIConfig(httpAddrs []net.Addr) (*api.Config, error) {
+	if len(httpAddrs) == 0 {
+		return nil, fmt.Errorf("loading fixtures requires the HTTP API to be enabled")
+	}
+	config := api.DefaultConfig()
+	config.Address = httpAddrs[0].String()
+	if httpAddrs[0].Network() == "unix" {
+		config.Address = "unix://" + config.Address
+	}
+	return config, nil
+}
+
+func (l *fixtureLoader) waitForLeader(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, fixtureLeaderTimeout)
+	defer cancel()
+
+	for {
+		leader, err := l.client.Status().Leader()
+		if err == nil && leader != "" {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for a leader to load the fixtures")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// readFixture returns the content of the fixture file, or nil if it does not
+// exist.
+func (l *fixtureLoader) readFixture(name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(l.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (l *fixtureLoader) loadACL() error {
+	data, err := l.readFixture(fixtureACL)
+	if err != nil || data == nil {
+		return err
+	}
+	var fixtures aclFixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return err
+	}
+
+	var token *api.ACLToken
+	if fixtures.BootstrapToken != "" {
+		token, _, err = l.client.ACL().BootstrapWithToken(fixtures.BootstrapToken)
+	} else {
+		token, _, err = l.client.ACL().Bootstrap()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap ACLs: %w", err)
+	}
+	if fixtures.BootstrapToken == "" {
+		l.ui.Info(fmt.Sprintf("Bootstrap token: %s", token.SecretID))
+	}
+
+	// The remaining fixtures are written with the management token.
+	l.config.Token = token.SecretID
+	if l.client, err = api.NewClient(l.config); err != nil {
+		return err
+	}
+
+	for _, policy := range fixtures.Policies {
+		if _, _, err := l.client.ACL().PolicyCreate(policy, nil); err != nil {
+			return fmt.Errorf("failed to create policy %q: %w", policy.Name, err)
+		}
+	}
+	for _, role := range fixtures.Roles {
+		if _, _, err := l.client.ACL().RoleCreate(role, nil); err != nil {
+			return fmt.Errorf("failed to create role %q: %w", role.Name, err)
+		}
+	}
+	for _, token := range fixtures.Tokens {
+		if _, _, err := l.client.ACL().TokenCreate(token, nil); err != nil {
+			return fmt.Errorf("failed to create token %q: %w", token.Description, err)
+		}
+	}
+	l.logger.Debug("loaded ACL fixtures",
+		"policies", len(fixtures.Policies),
+		"roles", len(fixtures.Roles),
+		"tokens", len(fixtures.Tokens),
+	)
+	return nil
+}
+
+func (l *fixtureLoader) loadKV() error {
+	data, err := l.readFixture(fixtureKV)
+	if err != nil || data == nil {
+		return err
+	}
+	var entries []*impexp.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		value, err := base64.StdEncoding.DecodeString(entry.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decode the value of key %q: %w", entry.Key, err)
+		}
+		pair := &api.KVPair{Key: entry.Key, Flags: entry.Flags, Value: value}
+		opts := &api.WriteOptions{Namespace: entry.Namespace, Partition: entry.Partition}
+		if _, err := l.client.KV().Put(pair, opts); err != nil {
+			return fmt.Errorf("failed to write key %q: %w", entry.Key, err)
+		}
+	}
+	l.logger.Debug("loaded KV fixtures", "keys", len(entries))
+	return nil
+}
+
+func (l *fixtureLoader) loadConfigEntries() error {
+	files, err := l.fixtureFiles(fixtureConfigEntries)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		entry, err := helpers.ParseConfigEntry(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", file, err)
+		}
+		opts := &api.WriteOptions{Namespace: entry.GetNamespace(), Partition: entry.GetPartition()}
+		if _, _, err := l.client.ConfigEntries().Set(entry, opts); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	l.logger.Debug("loaded config entry fixtures", "entries", len(files))
+	return nil
+}
+
+func (l *fixtureLoader) loadIntentions() error {
+	data, err := l.readFixture(fixtureIntentions)
+	if err != nil || data == nil {
+		return err
+	}
+	var intentions []*api.Intention
+	if err := json.Unmarshal(data, &intentions); err != nil {
+		return err
+	}
+
+	for _, ixn := range intentions {
+		if _, err := l.client.Connect().IntentionUpsert(ixn, nil); err != nil {
+			return fmt.Errorf("failed to write intention %q -> %q: %w", ixn.SourceName, ixn.DestinationName, err)
+		}
+	}
+	l.logger.Debug("loaded intention fixtures", "intentions", len(intentions))
+	return nil
+}
+
+func (l *fixtureLoader) loadServices() error {
+	files, err := l.fixtureFiles(fixtureServices)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	svcs, err := services.ServicesFromFiles(l.ui, files)
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range svcs {
+		if err := l.client.Agent().ServiceRegister(svc); err != nil {
+			return fmt.Errorf("failed to register service %q: %w", svc.Name, err)
+		}
+	}
+	l.logger.Debug("loaded service fixtures", "services", len(svcs))
+	return nil
+}
+
+// fixtureFiles returns the HCL and JSON files of the fixture directory, sorted
+// by name as returned by os.ReadDir.
+func (l *fixtureLoader) fixtureFiles(name string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(l.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".hcl" && ext != ".json") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		files = append(files, filepath.Join(l.dir, name, entry.Name()))
+	}
+	return files, nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	mcli "github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+)
+
+func writeFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadFixtures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, `
+		primary_datacenter = "dc1"
+		acl {
+			enabled = true
+			default_policy = "deny"
+		}
+	`)
+	defer a.Shutdown()
+
+	const bootstrapToken = "3a6a3c4b-7c12-4e4c-9c3c-0d8a5c1e2f10"
+
+	dir := t.TempDir()
+	writeFixture(t, dir, "acl.json", `{
+		"BootstrapToken": "`+bootstrapToken+`",
+		"Policies": [{"Name": "web-read", "Rules": "service \"web\" { policy = \"read\" }"}],
+		"Tokens": [{
+			"SecretID": "7c2f3f42-4d1b-4c8e-8f0e-6f6d2f0c9a11",
+			"Description": "web reader",
+			"Policies": [{"Name": "web-read"}]
+		}]
+	}`)
+	writeFixture(t, dir, "kv.json", `[{"key": "app/config", "flags": 0, "value": "aGVsbG8="}]`)
+	writeFixture(t, dir, "config-entries/web-defaults.hcl", `
+		Kind = "service-defaults"
+		Name = "web"
+		Protocol = "http"
+	`)
+	writeFixture(t, dir, "config-entries/README.md", `ignored`)
+	writeFixture(t, dir, "intentions.json", `[{"SourceName": "api", "DestinationName": "web", "Action": "allow"}]`)
+	writeFixture(t, dir, "services/web.hcl", `
+		service {
+			name = "web"
+			port = 8080
+		}
+	`)
+
+	config, err := fixtureAPIConfig(a.Config.HTTPAddrs)
+	require.NoError(t, err)
+	err = loadFixtures(context.Background(), dir, config, mcli.NewMockUi(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	apiConfig := api.DefaultConfig()
+	apiConfig.Address = a.HTTPAddr()
+	apiConfig.Token = bootstrapToken
+	client, err := api.NewClient(apiConfig)
+	require.NoError(t, err)
+
+	token, _, err := client.ACL().TokenReadSelf(nil)
+	require.NoError(t, err)
+	require.Equal(t, bootstrapToken, token.SecretID)
+
+	tokens, _, err := client.ACL().TokenList(nil)
+	require.NoError(t, err)
+	var found bool
+	for _, token := range tokens {
+		if token.Description == "web reader" {
+			found = true
+			require.Equal(t, "web-read", token.Policies[0].Name)
+		}
+	}
+	require.True(t, found)
+
+	pair, _, err := client.KV().Get("app/config", nil)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(pair.Value))
+
+	entry, _, err := client.ConfigEntries().Get(api.ServiceDefaults, "web", nil)
+	require.NoError(t, err)
+	require.Equal(t, "http", entry.(*api.ServiceConfigEntry).Protocol)
+
+	ixn, _, err := client.Connect().IntentionGetExact("api", "web", nil)
+	require.NoError(t, err)
+	require.Equal(t, api.IntentionActionAllow, ixn.Action)
+
+	svcs, err := client.Agent().Services()
+	require.NoError(t, err)
+	require.Contains(t, svcs, "web")
+	require.Equal(t, 8080, svcs["web"].Port)
+}
+
+func TestLoadFixtures_InvalidFixture(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, "")
+	defer a.Shutdown()
+
+	dir := t.TempDir()
+	writeFixture(t, dir, "kv.json", `{"key": "not a list"}`)
+
+	config, err := fixtureAPIConfig(a.Config.HTTPAddrs)
+	require.NoError(t, err)
+	err = loadFixtures(context.Background(), dir, config, mcli.NewMockUi(), hclog.NewNullLogger())
+	require.ErrorContains(t, err, "failed to load "+filepath.Join(dir, "kv.json"))
+}
+
+func TestAgentCommand_FixturesRequireDevMode(t *testing.T) {
+	t.Parallel()
+	ui := newCaptureUI()
+	cmd := New(ui)
+	require.Equal(t, 1, cmd.Run([]string{"-dev-fixtures=" + t.TempDir()}))
+	require.Contains(t, ui.ErrorWriter.String(), "The -dev-fixtures flag can only be used with -dev")
+}
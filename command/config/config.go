@@ -51,6 +51,10 @@ Usage: consul config <subcommand> [options] [args]
 
     $ consul config delete -kind service-defaults -name web
 
+  Check the service mesh configs for problems:
+
+    $ consul config lint
+
   For more examples, ask for subcommand help or view the documentation.
 `
 
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package lint
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/ryanuber/columnize"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+const (
+	formatPretty = "pretty"
+	formatJSON   = "json"
+)
+
+var severityRank = map[string]int{
+	api.LintSeverityInfo:    0,
+	api.LintSeverityWarning: 1,
+	api.LintSeverityError:   2,
+}
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	format   string
+	severity string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.format, "format", formatPretty,
+		"Output format {pretty|json}.")
+	c.flags.StringVar(&c.severity, "severity", api.LintSeverityInfo,
+		"Only report the findings of this severity or higher {info|warning|error}.")
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if c.format != formatPretty && c.format != formatJSON {
+		c.UI.Error(fmt.Sprintf("Invalid format %q, must be one of %q or %q", c.format, formatPretty, formatJSON))
+		return 1
+	}
+	minRank, ok := severityRank[c.severity]
+	if !ok {
+		c.UI.Error(fmt.Sprintf("Invalid severity %q, must be one of %q, %q or %q",
+			c.severity, api.LintSeverityInfo, api.LintSeverityWarning, api.LintSeverityError))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connect to Consul agent: %s", err))
+		return 1
+	}
+
+	res, _, err := client.Connect().Lint(nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error linting config entries: %s", err))
+		return 1
+	}
+
+	findings := make([]api.LintFinding, 0, len(res))
+	hasErrors := false
+	for _, finding := range res {
+		if severityRank[finding.Severity] < minRank {
+			continue
+		}
+		findings = append(findings, finding)
+		hasErrors = hasErrors || finding.Severity == api.LintSeverityError
+	}
+
+	if c.format == formatJSON {
+		output, err := json.MarshalIndent(findings, "", "    ")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error marshalling JSON: %s", err))
+			return 1
+		}
+		c.UI.Output(string(output))
+	} else {
+		c.UI.Output(formatFindings(findings))
+	}
+
+	if hasErrors {
+		return 2
+	}
+	return 0
+}
+
+func formatFindings(findings []api.LintFinding) string {
+	if len(findings) == 0 {
+		return "No problems found."
+	}
+
+	counts := make(map[string]int)
+	result := []string{"Severity\x1fRule\x1fConfig Entry\x1fMessage"}
+	for _, f := range findings {
+		counts[f.Severity]++
+		result = append(result, fmt.Sprintf("%s\x1f%s\x1f%s/%s\x1f%s", f.Severity, f.Rule, f.Kind, f.Name, f.Message))
+	}
+
+	var summary []string
+	for _, severity := range []string{api.LintSeverityError, api.LintSeverityWarning, api.LintSeverityInfo} {
+		switch n := counts[severity]; {
+		case n == 1, n > 0 && severity == api.LintSeverityInfo:
+			summary = append(summary, fmt.Sprintf("%d %s", n, severity))
+		case n > 1:
+			summary = append(summary, fmt.Sprintf("%d %ss", n, severity))
+		}
+	}
+
+	return columnize.Format(result, &columnize.Config{Delim: string([]byte{0x1f})}) +
+		fmt.Sprintf("\n\nFound %s.", strings.Join(summary, ", "))
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(c.help, nil)
+}
+
+const (
+	synopsis = "Check the service mesh config entries for problems"
+	help     = `
+Usage: consul config lint [options]
+
+  Evaluates the service-resolver, service-splitter, service-router,
+  service-intentions and exported-services config entries together and
+  reports the problems found, such as subsets no traffic is sent to, splits
+  whose weights do not sum to 100, failover to services which do not exist and
+  intentions made redundant by wildcard intentions.
+
+  The command exits with status 2 when an error is found.
+
+  Example:
+
+    $ consul config lint -severity warning
+
+`
+)
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+)
+
+func TestConfigLint_noTabs(t *testing.T) {
+	t.Parallel()
+
+	require.NotContains(t, New(cli.NewMockUi()).Help(), "\t")
+}
+
+func TestConfigLint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	run := func(t *testing.T, args ...string) (int, *cli.MockUi) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run(append([]string{"-http-addr=" + a.HTTPAddr()}, args...))
+		return code, ui
+	}
+
+	t.Run("no findings", func(t *testing.T) {
+		code, ui := run(t)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, "No problems found.\n", ui.OutputWriter.String())
+	})
+
+	_, _, err := client.ConfigEntries().Set(&api.ServiceResolverConfigEntry{
+		Kind:          api.ServiceResolver,
+		Name:          "web",
+		DefaultSubset: "v1",
+		Subsets: map[string]api.ServiceResolverSubset{
+			"v1": {Filter: "Service.Meta.version == v1"},
+			"v2": {Filter: "Service.Meta.version == v2"},
+		},
+	}, nil)
+	require.NoError(t, err)
+	_, _, err = client.ConfigEntries().Set(&api.ServiceIntentionsConfigEntry{
+		Kind: api.ServiceIntentions,
+		Name: "*",
+		Sources: []*api.SourceIntention{
+			{Name: "*", Action: api.IntentionActionAllow},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("pretty", func(t *testing.T) {
+		code, ui := run(t)
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		output := ui.OutputWriter.String()
+		require.Contains(t, output, "intention-allow-all")
+		require.Contains(t, output, "service-resolver/web")
+		require.Contains(t, output, "Found 2 warnings.")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		code, ui := run(t, "-format=json")
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var findings []api.LintFinding
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &findings))
+		require.Len(t, findings, 2)
+	})
+
+	t.Run("severity", func(t *testing.T) {
+		code, ui := run(t, "-severity=error")
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Equal(t, "No problems found.\n", ui.OutputWriter.String())
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		code, ui := run(t, "-severity=fatal")
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), `Invalid severity "fatal"`)
+	})
+}
@@ -5,13 +5,21 @@ package read
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
 	"github.com/mitchellh/cli"
 )
 
+const (
+	formatText      = "text"
+	formatJSONLines = "json-lines"
+)
+
 func New(ui cli.Ui) *cmd {
 	c := &cmd{UI: ui}
 	c.init()
@@ -24,8 +32,13 @@ type cmd struct {
 	http  *flags.HTTPFlags
 	help  string
 
-	kind string
-	name string
+	kind   string
+	name   string
+	watch  bool
+	format string
+
+	// shutdownCh stops the watch, it defaults to the interrupt signals.
+	shutdownCh <-chan struct{}
 }
 
 func (c *cmd) init() {
@@ -34,6 +47,12 @@ func (c *cmd) init() {
 	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
 	c.flags.StringVar(&c.kind, "kind", "", "The kind of configuration to read.")
 	c.flags.StringVar(&c.name, "name", "", "The name of configuration to read.")
+	c.flags.BoolVar(&c.watch, "watch", false,
+		"Watch the config entry with blocking queries, outputting it again each "+
+			"time it changes, until the command is interrupted.")
+	c.flags.StringVar(&c.format, "format", formatText,
+		"Output format, either \"text\" for indented JSON or \"json-lines\" "+
+			"to output each version of the config entry as JSON on a single line.")
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
 	flags.Merge(c.flags, c.http.ServerFlags())
@@ -56,26 +75,64 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	if c.format != formatText && c.format != formatJSONLines {
+		c.UI.Error(fmt.Sprintf("Invalid format %q, must be %q or %q", c.format, formatText, formatJSONLines))
+		return 1
+	}
+
 	client, err := c.http.APIClient()
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("Error connect to Consul agent: %s", err))
 		return 1
 	}
 
+	if c.watch {
+		ctx, cancel := helpers.ShutdownContext(c.shutdownCh)
+		defer cancel()
+
+		var entry api.ConfigEntry
+		err := helpers.WatchQuery(ctx, nil, func(q *api.QueryOptions) (*api.QueryMeta, error) {
+			var meta *api.QueryMeta
+			var err error
+			entry, meta, err = client.ConfigEntries().Get(c.kind, c.name, q)
+			return meta, err
+		}, func() error {
+			return c.output(entry)
+		})
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading config entry %s/%s: %v", c.kind, c.name, err))
+			return 1
+		}
+		return 0
+	}
+
 	entry, _, err := client.ConfigEntries().Get(c.kind, c.name, nil)
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("Error reading config entry %s/%s: %v", c.kind, c.name, err))
 		return 1
 	}
 
-	b, err := json.MarshalIndent(entry, "", "    ")
-	if err != nil {
-		c.UI.Error("Failed to encode output data")
+	if err := c.output(entry); err != nil {
+		c.UI.Error(err.Error())
 		return 1
 	}
+	return 0
+}
+
+func (c *cmd) output(entry api.ConfigEntry) error {
+	var b []byte
+	var err error
+	if c.format == formatJSONLines {
+		b, err = json.Marshal(entry)
+	} else {
+		b, err = json.MarshalIndent(entry, "", "    ")
+	}
+	if err != nil {
+		return errors.New("Failed to encode output data")
+	}
 
 	c.UI.Info(string(b))
-	return 0
+	return nil
 }
 
 func (c *cmd) Synopsis() string {
@@ -97,5 +154,10 @@ Usage: consul config read [options] -kind <config kind> -name <config name>
   Example:
 
     $ consul config read -kind proxy-defaults -name global
+
+  To output the config entry again each time it changes, as JSON on a single
+  line:
+
+    $ consul config read -kind proxy-defaults -name global -watch -format=json-lines
 `
 )
@@ -61,8 +61,9 @@ func TestConfigRead_InvalidArgs(t *testing.T) {
 	t.Parallel()
 
 	cases := map[string][]string{
-		"no kind": {},
-		"no name": {"-kind", "service-defaults"},
+		"no kind":        {},
+		"no name":        {"-kind", "service-defaults"},
+		"invalid format": {"-kind", "service-defaults", "-name", "web", "-format", "yaml"},
 	}
 
 	for name, tcase := range cases {
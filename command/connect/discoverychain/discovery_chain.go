@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package discoverychain
+
+import (
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+)
+
+func New() *cmd {
+	return &cmd{}
+}
+
+type cmd struct{}
+
+func (c *cmd) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(help, nil)
+}
+
+const synopsis = "Inspect compiled discovery chains"
+const help = `
+Usage: consul connect discovery-chain <subcommand> [options] [args]
+
+  This command has subcommands for inspecting the discovery chains that
+  Consul compiles from service-router, service-splitter and service-resolver
+  config entries.
+
+  Explain how the discovery chain for a service was compiled:
+
+      $ consul connect discovery-chain explain web
+
+  For more examples, ask for subcommand help or view the documentation.
+`
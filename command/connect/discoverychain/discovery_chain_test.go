@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package discoverychain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiscoveryChainCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New().Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package explain
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+const (
+	formatPretty = "pretty"
+	formatJSON   = "json"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	compileDC string
+	format    string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.compileDC, "compile-dc", "",
+		"The datacenter to compile the discovery chain in. Defaults to the datacenter of the agent.")
+	c.flags.StringVar(&c.format, "format", formatPretty,
+		fmt.Sprintf("Output format {%s|%s} (default: %s)", formatPretty, formatJSON, formatPretty))
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	args = c.flags.Args()
+	if len(args) != 1 {
+		c.UI.Error(fmt.Sprintf("Expected exactly one argument, the service name, got %d", len(args)))
+		return 1
+	}
+
+	if c.format != formatPretty && c.format != formatJSON {
+		c.UI.Error(fmt.Sprintf("Invalid format, valid formats are {%s|%s}", formatPretty, formatJSON))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	opts := &api.DiscoveryChainOptions{
+		EvaluateInDatacenter: c.compileDC,
+		Explain:              true,
+	}
+	resp, _, err := client.DiscoveryChain().Get(args[0], opts, &api.QueryOptions{AllowStale: c.http.Stale()})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error compiling discovery chain for %q: %s", args[0], err))
+		return 1
+	}
+
+	if c.format == formatJSON {
+		output, err := json.MarshalIndent(resp.Chain, "", "    ")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error marshalling JSON: %s", err))
+			return 1
+		}
+		c.UI.Output(string(output))
+		return 0
+	}
+
+	c.UI.Output(formatChain(resp.Chain))
+	return 0
+}
+
+// formatChain renders the nodes of the chain in the order they are walked
+// from the start node, each followed by the reasons it was compiled that way.
+func formatChain(chain *api.CompiledDiscoveryChain) string {
+	explanation := chain.Explanation
+	if explanation == nil {
+		explanation = &api.DiscoveryChainExplanation{}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Discovery chain for %q in datacenter %s (protocol %s)\n", chain.ServiceName, chain.Datacenter, chain.Protocol)
+	writeReasons(&buf, "  ", explanation.Chain)
+
+	for _, key := range walkNodes(chain) {
+		node := chain.Nodes[key]
+		buf.WriteString("\n")
+		fmt.Fprintf(&buf, "%s%s\n", key, describeSource(explanation.Nodes[key]))
+		if e := explanation.Nodes[key]; e != nil {
+			writeReasons(&buf, "  ", e.Reasons)
+		}
+
+		switch node.Type {
+		case api.DiscoveryGraphNodeTypeRouter:
+			for i, route := range node.Routes {
+				fmt.Fprintf(&buf, "  route %d -> %s\n", i, route.NextNode)
+			}
+		case api.DiscoveryGraphNodeTypeSplitter:
+			for _, split := range node.Splits {
+				fmt.Fprintf(&buf, "  %.2f%% -> %s\n", split.Weight, split.NextNode)
+			}
+		case api.DiscoveryGraphNodeTypeResolver:
+			writeTarget(&buf, "target", node.Resolver.Target, explanation)
+			if node.Resolver.Failover != nil {
+				for _, target := range node.Resolver.Failover.Targets {
+					writeTarget(&buf, "failover target", target, explanation)
+				}
+			}
+		}
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// walkNodes returns the keys of the nodes of the chain in breadth first order
+// from the start node.
+func walkNodes(chain *api.CompiledDiscoveryChain) []string {
+	var (
+		keys    []string
+		visited = make(map[string]bool)
+		todo    = []string{chain.StartNode}
+	)
+	for len(todo) > 0 {
+		key := todo[0]
+		todo = todo[1:]
+		node, ok := chain.Nodes[key]
+		if visited[key] || !ok {
+			continue
+		}
+		visited[key] = true
+		keys = append(keys, key)
+
+		for _, route := range node.Routes {
+			todo = append(todo, route.NextNode)
+		}
+		for _, split := range node.Splits {
+			todo = append(todo, split.NextNode)
+		}
+	}
+	return keys
+}
+
+func writeTarget(buf *bytes.Buffer, label, id string, explanation *api.DiscoveryChainExplanation) {
+	e := explanation.Targets[id]
+	fmt.Fprintf(buf, "  %s %s%s\n", label, id, describeSource(e))
+	if e != nil {
+		writeReasons(buf, "    ", e.Reasons)
+	}
+}
+
+func writeReasons(buf *bytes.Buffer, indent string, reasons []string) {
+	for _, reason := range reasons {
+		fmt.Fprintf(buf, "%s- %s\n", indent, reason)
+	}
+}
+
+func describeSource(e *api.DiscoveryExplanation) string {
+	if e == nil || e.ConfigEntry == nil {
+		return " (defaults)"
+	}
+	entry := e.ConfigEntry
+	name := entry.Name
+	if entry.Namespace != "" {
+		name = entry.Namespace + "/" + name
+	}
+	if entry.Partition != "" {
+		name = entry.Partition + "/" + name
+	}
+	return fmt.Sprintf(" (from %s %q)", entry.Kind, name)
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Explain how the discovery chain for a service is compiled"
+const help = `
+Usage: consul connect discovery-chain explain [options] <service>
+
+  Compiles the discovery chain for a service and annotates each router,
+  splitter and resolver node, and each target, with the config entry it came
+  from and the reasons defaults were applied. Use it to debug routing that
+  does not behave as expected.
+
+  Explain the discovery chain for the "web" service:
+
+      $ consul connect discovery-chain explain web
+
+  Explain the chain as it is compiled in another datacenter, as JSON:
+
+      $ consul connect discovery-chain explain -compile-dc dc2 -format json web
+`
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package explain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestDiscoveryChainExplainCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestDiscoveryChainExplainCommand_Validation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args   []string
+		output string
+	}{
+		"no service": {
+			args:   []string{},
+			output: "Expected exactly one argument",
+		},
+		"bad format": {
+			args:   []string{"-format", "yaml", "web"},
+			output: "Invalid format",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+
+			require.Equal(t, 1, c.Run(tc.args))
+			require.Contains(t, ui.ErrorWriter.String(), tc.output)
+		})
+	}
+}
+
+func TestDiscoveryChainExplainCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	client := a.Client()
+	_, _, err := client.ConfigEntries().Set(&api.ServiceResolverConfigEntry{
+		Kind:           api.ServiceResolver,
+		Name:           "web",
+		ConnectTimeout: 10 * time.Second,
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("pretty", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "web"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		expect := `Discovery chain for "web" in datacenter dc1 (protocol tcp)
+  - service "web" uses protocol "tcp" because no service-defaults or proxy-defaults sets a protocol
+
+resolver:web.default.default.dc1 (from service-resolver "web")
+  - resolved by service-resolver "web"
+  - connect timeout 10s from service-resolver "web"
+  target web.default.default.dc1 (from service-resolver "web")
+    - mesh gateway mode is unset because no service-defaults or proxy-defaults sets one`
+		require.Equal(t, expect, strings.TrimSpace(ui.OutputWriter.String()))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-format", "json", "web"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+		var chain api.CompiledDiscoveryChain
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &chain))
+		require.NotNil(t, chain.Explanation)
+		require.Equal(t, &api.DiscoveryExplanationConfigEntry{
+			Kind: api.ServiceResolver,
+			Name: "web",
+		}, chain.Explanation.Nodes["resolver:web.default.default.dc1"].ConfigEntry)
+	})
+}
@@ -18,12 +18,11 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/mapstructure"
-	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/xds"
-	"github.com/hashicorp/consul/agent/xds/accesslogs"
+	"github.com/hashicorp/consul/agent/xds/bootstrap"
 	"github.com/hashicorp/consul/api"
 	proxyCmd "github.com/hashicorp/consul/command/connect/proxy"
 	"github.com/hashicorp/consul/command/flags"
@@ -38,7 +37,7 @@ func New(ui cli.Ui) *cmd {
 	return c
 }
 
-const DefaultAdminAccessLogPath = os.DevNull
+const DefaultAdminAccessLogPath = bootstrap.DefaultAdminAccessLogPath
 
 type cmd struct {
 	UI     cli.Ui
@@ -88,6 +87,10 @@ type cmd struct {
 	gatewayKind    api.ServiceKind
 
 	dialFunc func(network string, address string) (net.Conn, error)
+
+	// concurrency is the number of Envoy worker threads set by the resource
+	// preset of the proxy, zero to keep the Envoy default.
+	concurrency int
 }
 
 const meshGatewayVal = "mesh"
@@ -464,7 +467,7 @@ func (c *cmd) run(args []string) int {
 	}
 
 	c.logger.Debug("Executing envoy binary")
-	err = execEnvoy(binary, nil, args, bootstrapJson)
+	err = execEnvoy(binary, concurrencyArgs(c.concurrency, args), args, bootstrapJson)
 	if err == errUnsupportedOS {
 		c.UI.Error("Directly running Envoy is only supported on linux and macOS " +
 			"since envoy itself doesn't build on other platforms currently.")
@@ -567,7 +570,7 @@ func (c *cmd) findBinary() (string, error) {
 	return exec.LookPath("envoy")
 }
 
-func (c *cmd) templateArgs() (*BootstrapTplArgs, error) {
+func (c *cmd) templateArgs() (*bootstrap.BootstrapTplArgs, error) {
 	httpCfg := api.DefaultConfig()
 	c.http.MergeOntoConfig(httpCfg)
 
@@ -633,9 +636,9 @@ func (c *cmd) templateArgs() (*BootstrapTplArgs, error) {
 	if err != nil {
 		return nil, err
 	}
-	caPEM = strings.Replace(strings.Join(pems, ""), "\n", "\\n", -1)
+	caPEM = bootstrap.EscapeCAPEM(pems...)
 
-	return &BootstrapTplArgs{
+	return &bootstrap.BootstrapTplArgs{
 		GRPC:                  xdsAddr,
 		ProxyCluster:          cluster,
 		ProxyID:               c.proxyID,
@@ -666,7 +669,7 @@ func (c *cmd) generateConfig() ([]byte, error) {
 	}
 	c.logger.Debug("Generated template args")
 
-	var bsCfg BootstrapConfig
+	var bsCfg bootstrap.BootstrapConfig
 
 	// Make a call to an arbitrary ACL endpoint. If we get back an ErrNotFound
 	// (meaning ACLs are enabled) check that the token is not empty.
@@ -771,13 +774,28 @@ func (c *cmd) generateConfig() ([]byte, error) {
 			return nil, fmt.Errorf("failed parsing Proxy.Config: %s", err)
 		}
 	}
+	c.concurrency = bsCfg.Concurrency()
 
 	return bsCfg.GenerateJSON(args, c.omitDeprecatedTags)
 }
 
+// concurrencyArgs returns the Envoy arguments setting the number of worker
+// threads, unless it is already set by the passthrough arguments.
+func concurrencyArgs(concurrency int, passthroughArgs []string) []string {
+	if concurrency <= 0 {
+		return nil
+	}
+	for _, arg := range passthroughArgs {
+		if arg == "--concurrency" || strings.HasPrefix(arg, "--concurrency=") {
+			return nil
+		}
+	}
+	return []string{"--concurrency", strconv.Itoa(concurrency)}
+}
+
 // generateAccessLogs checks if there is any access log customization from proxy-defaults.
 // If available, access log parameters are marshaled to JSON and added to the bootstrap template args.
-func generateAccessLogs(c *cmd, args *BootstrapTplArgs) error {
+func generateAccessLogs(c *cmd, args *bootstrap.BootstrapTplArgs) error {
 	configEntry, _, err := c.client.ConfigEntries().Get(api.ProxyDefaults, api.ProxyConfigGlobal, &api.QueryOptions{}) // Always assume the default partition
 
 	// We don't necessarily want to fail here if there isn't a proxy-defaults defined or if there
@@ -802,21 +820,11 @@ func generateAccessLogs(c *cmd, args *BootstrapTplArgs) error {
 				TextFormat:          proxyDefaults.AccessLogs.TextFormat,
 				Path:                proxyDefaults.AccessLogs.Path,
 			}
-			envoyLoggers, err := accesslogs.MakeAccessLogs(AccessLogsConfig, false)
+			logConfigs, err := bootstrap.AccessLogConfigs(AccessLogsConfig)
 			if err != nil {
-				return fmt.Errorf("failure generating Envoy access log configuration: %w", err)
-			}
-
-			// Convert individual proto messages to JSON here
-			args.AdminAccessLogConfig = make([]string, 0, len(envoyLoggers))
-
-			for _, msg := range envoyLoggers {
-				logConfig, err := protojson.Marshal(msg)
-				if err != nil {
-					return fmt.Errorf("could not marshal Envoy access log configuration: %w", err)
-				}
-				args.AdminAccessLogConfig = append(args.AdminAccessLogConfig, string(logConfig))
+				return err
 			}
+			args.AdminAccessLogConfig = logConfigs
 		}
 
 		if proxyDefaults.AccessLogs != nil && c.adminAccessLogPath != DefaultAdminAccessLogPath {
@@ -826,9 +834,7 @@ func generateAccessLogs(c *cmd, args *BootstrapTplArgs) error {
 	return nil
 }
 
-func (c *cmd) xdsAddress() (GRPC, error) {
-	g := GRPC{}
-
+func (c *cmd) xdsAddress() (bootstrap.GRPC, error) {
 	addr := c.grpcAddr
 	if addr == "" {
 		// This lookup is a UX optimization and requires acl policy agent:read,
@@ -840,7 +846,7 @@ func (c *cmd) xdsAddress() (GRPC, error) {
 			} else {
 				// If not a permission denied error, gRPC is explicitly disabled
 				// or something went fatally wrong.
-				return g, fmt.Errorf("Error looking up xDS port: %s", err)
+				return bootstrap.GRPC{}, fmt.Errorf("Error looking up xDS port: %s", err)
 			}
 		}
 		if port <= 0 {
@@ -852,45 +858,14 @@ func (c *cmd) xdsAddress() (GRPC, error) {
 		addr = fmt.Sprintf("%vlocalhost:%v", protocol, port)
 	}
 
-	// TODO: parse addr as a url instead of strings.HasPrefix/TrimPrefix
-	if strings.HasPrefix(strings.ToLower(addr), "https://") {
-		g.AgentTLS = true
+	g, err := bootstrap.ParseAgentAddress(addr)
+	if err != nil {
+		return g, err
 	}
 
-	// We want to allow grpcAddr set as host:port with no scheme but if the host
-	// is an IP this will fail to parse as a URL with "parse 127.0.0.1:8500: first
-	// path segment in URL cannot contain colon". On the other hand we also
-	// support both http(s)://host:port and unix:///path/to/file.
-	if grpcAddr := strings.TrimPrefix(addr, "unix://"); grpcAddr != addr {
-		// Path to unix socket
-		g.AgentSocket = grpcAddr
-		// Configure unix sockets to encrypt traffic whenever a certificate is explicitly defined.
-		if c.grpcCAFile != "" || c.grpcCAPath != "" {
-			g.AgentTLS = true
-		}
-	} else {
-		// Parse as host:port with option http prefix
-		grpcAddr = strings.TrimPrefix(addr, "http://")
-		grpcAddr = strings.TrimPrefix(grpcAddr, "https://")
-
-		var err error
-		var host string
-		host, g.AgentPort, err = net.SplitHostPort(grpcAddr)
-		if err != nil {
-			return g, fmt.Errorf("Invalid Consul HTTP address: %s", err)
-		}
-
-		// We use STATIC for agent which means we need to resolve DNS names like
-		// `localhost` ourselves. We could use STRICT_DNS or LOGICAL_DNS with envoy
-		// but Envoy resolves `localhost` differently to go on macOS at least which
-		// causes paper cuts like default dev agent (which binds specifically to
-		// 127.0.0.1) isn't reachable since Envoy resolves localhost to `[::]` and
-		// can't connect.
-		agentIP, err := net.ResolveIPAddr("ip", host)
-		if err != nil {
-			return g, fmt.Errorf("Failed to resolve agent address: %s", err)
-		}
-		g.AgentAddress = agentIP.String()
+	// Configure unix sockets to encrypt traffic whenever a certificate is explicitly defined.
+	if g.AgentSocket != "" && (c.grpcCAFile != "" || c.grpcCAPath != "") {
+		g.AgentTLS = true
 	}
 	return g, nil
 }
@@ -954,7 +929,7 @@ func (c *cmd) lookupXDSPort() (int, string, error) {
 	return int(portN), "", nil
 }
 
-func checkDial(g GRPC, dial func(string, string) (net.Conn, error)) error {
+func checkDial(g bootstrap.GRPC, dial func(string, string) (net.Conn, error)) error {
 	var (
 		conn net.Conn
 		err  error
@@ -23,6 +23,7 @@ import (
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent"
 	"github.com/hashicorp/consul/agent/xds"
+	"github.com/hashicorp/consul/agent/xds/bootstrap"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/envoyextensions/xdscommon"
 	"github.com/hashicorp/consul/sdk/testutil"
@@ -127,7 +128,7 @@ type generateConfigTestCase struct {
 	XDSPorts          agent.GRPCPorts // used to mock an agent's configured gRPC ports. Plaintext defaults to 8502 and TLS defaults to 8503.
 	AgentSelf110      bool            // fake the agent API from versions v1.10 and earlier
 	GRPCDisabled      bool
-	WantArgs          BootstrapTplArgs
+	WantArgs          bootstrap.BootstrapTplArgs
 	WantErr           string
 	WantWarn          string
 }
@@ -174,13 +175,13 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "defaults",
 			Flags: []string{"-proxy-id", "test-proxy"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -195,14 +196,14 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "defaults-nodemeta",
 			Flags: []string{"-proxy-id", "test-proxy", "-node-name", "test-node"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				NodeName:     "test-node",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -220,13 +221,13 @@ func TestGenerateConfig(t *testing.T) {
 			ProxyConfig: map[string]interface{}{
 				"envoy_telemetry_collector_bind_socket_dir": "/tmp/consul/telemetry-collector",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -247,13 +248,13 @@ func TestGenerateConfig(t *testing.T) {
 				// "prometheus_backend" cluster in the Envoy configuration.
 				"envoy_prometheus_bind_addr": "0.0.0.0:9000",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -277,13 +278,13 @@ func TestGenerateConfig(t *testing.T) {
 				// "prometheus_backend" cluster in the Envoy configuration.
 				"envoy_prometheus_bind_addr": "0.0.0.0:9000",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -310,13 +311,13 @@ func TestGenerateConfig(t *testing.T) {
 				// "prometheus_backend" cluster in the Envoy configuration.
 				"envoy_prometheus_bind_addr": "0.0.0.0:9000",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -335,13 +336,13 @@ func TestGenerateConfig(t *testing.T) {
 			Name: "token-arg",
 			Flags: []string{"-proxy-id", "test-proxy",
 				"-token", "c9a52720-bf6c-4aa6-b8bc-66881a5ade95"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -359,13 +360,13 @@ func TestGenerateConfig(t *testing.T) {
 			Env: []string{
 				"CONSUL_HTTP_TOKEN=c9a52720-bf6c-4aa6-b8bc-66881a5ade95",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -385,13 +386,13 @@ func TestGenerateConfig(t *testing.T) {
 			Files: map[string]string{
 				"token.txt": "c9a52720-bf6c-4aa6-b8bc-66881a5ade95",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -412,13 +413,13 @@ func TestGenerateConfig(t *testing.T) {
 			Files: map[string]string{
 				"token.txt": "c9a52720-bf6c-4aa6-b8bc-66881a5ade95",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -434,7 +435,7 @@ func TestGenerateConfig(t *testing.T) {
 			Name: "grpc-addr-flag",
 			Flags: []string{"-proxy-id", "test-proxy",
 				"-grpc-addr", "localhost:9999"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -443,7 +444,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "9999",
 				},
@@ -460,7 +461,7 @@ func TestGenerateConfig(t *testing.T) {
 			Env: []string{
 				"CONSUL_GRPC_ADDR=localhost:9999",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -469,7 +470,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "9999",
 				},
@@ -484,13 +485,13 @@ func TestGenerateConfig(t *testing.T) {
 			Name: "grpc-addr-unix",
 			Flags: []string{"-proxy-id", "test-proxy",
 				"-grpc-addr", "unix:///var/run/consul.sock"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentSocket: "/var/run/consul.sock",
 				},
 				AdminAccessLogPath:    "/dev/null",
@@ -505,10 +506,10 @@ func TestGenerateConfig(t *testing.T) {
 			Flags: []string{"-proxy-id", "test-proxy",
 				"-grpc-ca-file", "../../../test/ca/root.cer",
 				"-grpc-addr", "unix:///var/run/consul.sock"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentSocket: "/var/run/consul.sock",
 					AgentTLS:    true,
 				},
@@ -524,7 +525,7 @@ func TestGenerateConfig(t *testing.T) {
 			Name:     "xds-addr-config",
 			Flags:    []string{"-proxy-id", "test-proxy"},
 			XDSPorts: agent.GRPCPorts{Plaintext: 9999, TLS: 0},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -533,7 +534,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "9999",
 				},
@@ -549,7 +550,7 @@ func TestGenerateConfig(t *testing.T) {
 			Flags:        []string{"-proxy-id", "test-proxy"},
 			XDSPorts:     agent.GRPCPorts{Plaintext: 9997, TLS: 9998},
 			AgentSelf110: false,
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -558,7 +559,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "9998",
 					AgentTLS:     true,
@@ -575,7 +576,7 @@ func TestGenerateConfig(t *testing.T) {
 			Flags:        []string{"-proxy-id", "test-proxy"},
 			XDSPorts:     agent.GRPCPorts{Plaintext: 9999, TLS: 0},
 			AgentSelf110: true,
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -584,7 +585,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "9999",
 				},
@@ -599,7 +600,7 @@ func TestGenerateConfig(t *testing.T) {
 			Name:     "access-log-path",
 			Flags:    []string{"-proxy-id", "test-proxy", "-admin-access-log-path", "/some/path/access.log"},
 			WantWarn: "-admin-access-log-path is deprecated",
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -608,7 +609,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -629,7 +630,7 @@ func TestGenerateConfig(t *testing.T) {
 			TLSServer: true,
 			Flags:     []string{"-proxy-id", "test-proxy", "-grpc-ca-file", "../../../test/ca/root.cer"},
 			Env:       []string{"CONSUL_GRPC_ADDR=https://127.0.0.1:8502"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -638,7 +639,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     true,
@@ -661,7 +662,7 @@ func TestGenerateConfig(t *testing.T) {
 			TLSServer: true,
 			Flags:     []string{"-proxy-id", "test-proxy", "-grpc-ca-path", "../../../test/ca_path/"},
 			Env:       []string{"CONSUL_GRPC_ADDR=https://127.0.0.1:8502"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -670,7 +671,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     true,
@@ -716,13 +717,13 @@ func TestGenerateConfig(t *testing.T) {
 					"custom_field": "foo"
 				}`,
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -753,13 +754,13 @@ func TestGenerateConfig(t *testing.T) {
 					"name": "fake_sink_1"
 				}`,
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -795,13 +796,13 @@ func TestGenerateConfig(t *testing.T) {
 					"name": "fake_sink_1"
 				} , { "name": "fake_sink_2" }`,
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -824,13 +825,13 @@ func TestGenerateConfig(t *testing.T) {
 					"name": "fake_config"
 				}`,
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -883,13 +884,61 @@ func TestGenerateConfig(t *testing.T) {
 					}
 				}`,
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
+					AgentAddress: "127.0.0.1",
+					AgentPort:    "8502",
+				},
+				AdminAccessLogPath:    "/dev/null",
+				AdminBindAddress:      "127.0.0.1",
+				AdminBindPort:         "19000",
+				LocalAgentClusterName: xds.LocalAgentClusterName,
+				PrometheusScrapePath:  "/metrics",
+			},
+		},
+		{
+			Name:  "resource-preset",
+			Flags: []string{"-proxy-id", "test-proxy"},
+			ProxyConfig: map[string]interface{}{
+				"envoy_resource_preset": "medium",
+			},
+			WantArgs: bootstrap.BootstrapTplArgs{
+				ProxyCluster: "test-proxy",
+				ProxyID:      "test-proxy",
+				// We don't know this til after the lookup so it will be empty in the
+				// initial args call we are testing here.
+				ProxySourceService: "",
+				GRPC: bootstrap.GRPC{
+					AgentAddress: "127.0.0.1",
+					AgentPort:    "8502",
+				},
+				AdminAccessLogPath:    "/dev/null",
+				AdminBindAddress:      "127.0.0.1",
+				AdminBindPort:         "19000",
+				LocalAgentClusterName: xds.LocalAgentClusterName,
+				PrometheusScrapePath:  "/metrics",
+			},
+		},
+		{
+			Name:  "overload-manager",
+			Flags: []string{"-proxy-id", "test-proxy"},
+			ProxyConfig: map[string]interface{}{
+				"envoy_resource_preset":            "small",
+				"envoy_max_heap_size_bytes":        268435456,
+				"envoy_max_downstream_connections": 10000,
+			},
+			WantArgs: bootstrap.BootstrapTplArgs{
+				ProxyCluster: "test-proxy",
+				ProxyID:      "test-proxy",
+				// We don't know this til after the lookup so it will be empty in the
+				// initial args call we are testing here.
+				ProxySourceService: "",
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -904,7 +953,7 @@ func TestGenerateConfig(t *testing.T) {
 			Name:  "CONSUL_HTTP_ADDR-with-https-scheme-does-not-affect-grpc-tls",
 			Flags: []string{"-proxy-id", "test-proxy", "-ca-file", "../../../test/ca/root.cer"},
 			Env:   []string{"CONSUL_HTTP_ADDR=https://127.0.0.1:8500"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -913,7 +962,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     false,
@@ -929,7 +978,7 @@ func TestGenerateConfig(t *testing.T) {
 			Name:  "CONSUL_GRPC_ADDR-with-https-scheme-enables-tls",
 			Flags: []string{"-proxy-id", "test-proxy", "-ca-file", "../../../test/ca/root.cer"},
 			Env:   []string{"CONSUL_GRPC_ADDR=https://127.0.0.1:8502"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -938,7 +987,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     true,
@@ -958,7 +1007,7 @@ func TestGenerateConfig(t *testing.T) {
 				"CONSUL_HTTP_ADDR=https://127.0.0.1:8500",
 				"CONSUL_GRPC_ADDR=http://127.0.0.1:8502",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -967,7 +1016,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     false,
@@ -986,7 +1035,7 @@ func TestGenerateConfig(t *testing.T) {
 				"CONSUL_HTTP_ADDR=http://127.0.0.1:8500",
 				"CONSUL_GRPC_ADDR=https://127.0.0.1:8502",
 			},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
@@ -995,7 +1044,7 @@ func TestGenerateConfig(t *testing.T) {
 				// Should resolve IP, note this might not resolve the same way
 				// everywhere which might make this test brittle but not sure what else
 				// to do.
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 					AgentTLS:     true,
@@ -1011,11 +1060,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway",
 			Flags: []string{"-proxy-id", "ingress-gateway-1", "-gateway", "ingress"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "ingress-gateway",
 				ProxyID:            "ingress-gateway-1",
 				ProxySourceService: "ingress-gateway",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1029,11 +1078,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway-nodemeta",
 			Flags: []string{"-proxy-id", "ingress-gateway-1", "-node-name", "test-node"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "ingress-gateway-1",
 				ProxyID:      "ingress-gateway-1",
 				NodeName:     "test-node",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1048,13 +1097,13 @@ func TestGenerateConfig(t *testing.T) {
 			Name: "envoy-readiness-probe",
 			Flags: []string{"-proxy-id", "test-proxy",
 				"-envoy-ready-bind-address", "127.0.0.1", "-envoy-ready-bind-port", "21000"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -1069,11 +1118,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway-address-specified",
 			Flags: []string{"-proxy-id", "ingress-gateway", "-gateway", "ingress", "-address", "1.2.3.4:7777"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "ingress-gateway",
 				ProxyID:            "ingress-gateway",
 				ProxySourceService: "ingress-gateway",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1087,11 +1136,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway-register-with-service-without-proxy-id",
 			Flags: []string{"-gateway", "ingress", "-register", "-service", "my-gateway", "-address", "127.0.0.1:7777"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "my-gateway",
 				ProxyID:            "my-gateway",
 				ProxySourceService: "my-gateway",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1105,11 +1154,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway-register-with-service-and-proxy-id",
 			Flags: []string{"-gateway", "ingress", "-register", "-service", "my-gateway", "-proxy-id", "my-gateway-123", "-address", "127.0.0.1:7777"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "my-gateway",
 				ProxyID:            "my-gateway-123",
 				ProxySourceService: "my-gateway",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1123,11 +1172,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "ingress-gateway-no-auto-register",
 			Flags: []string{"-gateway", "ingress", "-address", "127.0.0.1:7777"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "ingress-gateway",
 				ProxyID:            "ingress-gateway",
 				ProxySourceService: "ingress-gateway",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1141,11 +1190,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "access-logs-enabled",
 			Flags: []string{"-proxy-id", "test-proxy"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "test-proxy",
 				ProxyID:            "test-proxy",
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1165,11 +1214,11 @@ func TestGenerateConfig(t *testing.T) {
 		{
 			Name:  "access-logs-enabled-custom",
 			Flags: []string{"-proxy-id", "test-proxy"},
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster:       "test-proxy",
 				ProxyID:            "test-proxy",
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502",
 				},
@@ -1195,13 +1244,13 @@ func TestGenerateConfig(t *testing.T) {
 			Flags:      []string{"-proxy-id", "test-proxy"},
 			ACLEnabled: true,
 			WantWarn:   "No ACL token was provided to Envoy.",
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -1217,13 +1266,13 @@ func TestGenerateConfig(t *testing.T) {
 			Name:       "acl-enabled-and-token",
 			Flags:      []string{"-proxy-id", "test-proxy", "-token", "foo"},
 			ACLEnabled: true,
-			WantArgs: BootstrapTplArgs{
+			WantArgs: bootstrap.BootstrapTplArgs{
 				ProxyCluster: "test-proxy",
 				ProxyID:      "test-proxy",
 				// We don't know this til after the lookup so it will be empty in the
 				// initial args call we are testing here.
 				ProxySourceService: "",
-				GRPC: GRPC{
+				GRPC: bootstrap.GRPC{
 					AgentAddress: "127.0.0.1",
 					AgentPort:    "8502", // Note this is the gRPC port
 				},
@@ -1787,7 +1836,7 @@ func TestEnvoyCommand_canBindInternal(t *testing.T) {
 	}
 }
 
-// testMockAgentSelf returns an empty /v1/agent/self response except GRPC
+// testMockAgentSelf returns an empty /v1/agent/self response except bootstrap.GRPC
 // port is filled in to match the given wantXDSPort argument.
 func testMockAgentSelf(
 	wantXDSPorts agent.GRPCPorts,
@@ -1913,6 +1962,13 @@ func TestCheckEnvoyVersionCompatibility(t *testing.T) {
 	}
 }
 
+func TestConcurrencyArgs(t *testing.T) {
+	require.Nil(t, concurrencyArgs(0, nil))
+	require.Equal(t, []string{"--concurrency", "2"}, concurrencyArgs(2, []string{"--log-level", "debug"}))
+	require.Nil(t, concurrencyArgs(2, []string{"--concurrency", "8"}))
+	require.Nil(t, concurrencyArgs(2, []string{"--concurrency=8"}))
+}
+
 func addNPatchVersion(s string, n int) string {
 	splitS := strings.Split(s, ".")
 	minor, _ := strconv.Atoi(splitS[2])
@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-uuid"
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	proxyImpl "github.com/hashicorp/consul/connect/proxy"
+	"github.com/hashicorp/consul/logging"
+)
+
+const (
+	// checkTTL is the TTL of the health check of the exposed service. The
+	// check is kept alive three times per period while the command runs.
+	checkTTL = 30 * time.Second
+
+	// devTag is the tag of the services registered by the command, so the
+	// temporary services can be told apart from the real ones.
+	devTag = "dev-tunnel"
+)
+
+func New(ui cli.Ui, shutdownCh <-chan struct{}) *cmd {
+	ui = &cli.PrefixedUi{
+		OutputPrefix: "==> ",
+		InfoPrefix:   "    ",
+		ErrorPrefix:  "==> ",
+		Ui:           ui,
+	}
+
+	c := &cmd{UI: ui, shutdownCh: shutdownCh}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	shutdownCh <-chan struct{}
+
+	// flags
+	service         string
+	address         string
+	port            int
+	proxyPort       int
+	ttl             time.Duration
+	deregisterAfter time.Duration
+	logLevel        string
+
+	// testNoStart is used by tests to register the service without running
+	// the proxy.
+	testNoStart bool
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.service, "service", "",
+		"(Required) The name of the mesh service to register the local port as.")
+
+	c.flags.StringVar(&c.address, "address", "127.0.0.1",
+		"The address the local service listens on.")
+
+	c.flags.IntVar(&c.port, "port", 0,
+		"(Required) The port the local service listens on.")
+
+	c.flags.IntVar(&c.proxyPort, "proxy-port", 0,
+		"The port of the public listener of the proxy. Defaults to a port "+
+			"assigned by the agent from its sidecar port range.")
+
+	c.flags.DurationVar(&c.ttl, "ttl", time.Hour,
+		"How long the service stays exposed. The ACL token created for the service "+
+			"expires after the same duration.")
+
+	c.flags.DurationVar(&c.deregisterAfter, "deregister-after", time.Minute,
+		"How long after the command stops heartbeating, e.g. because the machine "+
+			"went to sleep, the agent deregisters the service.")
+
+	c.flags.StringVar(&c.logLevel, "log-level", "INFO",
+		"Specifies the log level.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	if c.service == "" {
+		c.UI.Error("A service name must be given via the -service flag.")
+		return 1
+	}
+	if c.port <= 0 || c.port > 65535 {
+		c.UI.Error("A valid port must be given via the -port flag.")
+		return 1
+	}
+	if c.ttl <= 0 {
+		c.UI.Error("The -ttl flag must be positive.")
+		return 1
+	}
+
+	logger, err := logging.Setup(logging.Config{LogLevel: c.logLevel, Name: logging.Proxy}, &cli.UiWriter{Ui: c.UI})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	suffix, err := uuid.GenerateUUID()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error generating service ID: %s", err))
+		return 1
+	}
+	serviceID := fmt.Sprintf("%s-%s-%s", c.service, devTag, suffix[:8])
+	expiresAt := time.Now().Add(c.ttl)
+
+	// Register the service with a token limited to its identity which expires
+	// with the tunnel, so a forgotten tunnel cannot be used past its TTL.
+	token, err := c.createToken(client, serviceID)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating token for the service: %s", err))
+		return 1
+	}
+	if token != nil {
+		defer c.deleteToken(client, token)
+
+		config := api.DefaultConfig()
+		c.http.MergeOntoConfig(config)
+		config.Token = token.SecretID
+		if client, err = api.NewClient(config); err != nil {
+			c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+			return 1
+		}
+	}
+
+	checkID := "service:" + serviceID
+	err = client.Agent().ServiceRegister(&api.AgentServiceRegistration{
+		ID:      serviceID,
+		Name:    c.service,
+		Address: c.address,
+		Port:    c.port,
+		Tags:    []string{devTag},
+		Meta:    map[string]string{"dev_tunnel_expires_at": expiresAt.UTC().Format(time.RFC3339)},
+		Check: &api.AgentServiceCheck{
+			CheckID:                        checkID,
+			Name:                           "Dev tunnel heartbeat",
+			TTL:                            checkTTL.String(),
+			DeregisterCriticalServiceAfter: c.deregisterAfter.String(),
+		},
+		Connect: &api.AgentServiceConnect{
+			SidecarService: &api.AgentServiceRegistration{Port: c.proxyPort},
+		},
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error registering service: %s", err))
+		return 1
+	}
+	defer func() {
+		if err := client.Agent().ServiceDeregister(serviceID); err != nil {
+			c.UI.Error(fmt.Sprintf("Error deregistering service %q: %s", serviceID, err))
+		}
+	}()
+
+	proxyID := serviceID + "-sidecar-proxy"
+	proxySvc, _, err := client.Agent().Service(proxyID, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error reading proxy registration: %s", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Exposing %s:%d as service %q", c.address, c.port, c.service))
+	c.UI.Info(fmt.Sprintf("Service ID: %s", serviceID))
+	c.UI.Info(fmt.Sprintf("Proxy port: %d", proxySvc.Port))
+	c.UI.Info(fmt.Sprintf("Expires at: %s", expiresAt.Format(time.RFC3339)))
+	c.UI.Info("")
+
+	if !c.testNoStart {
+		watcher, err := proxyImpl.NewAgentConfigWatcher(client, proxyID, logger)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error preparing proxy configuration: %s", err))
+			return 1
+		}
+		p, err := proxyImpl.New(client, watcher, logger)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed initializing proxy: %s", err))
+			return 1
+		}
+		defer p.Close()
+		go func() {
+			if err := p.Serve(); err != nil {
+				logger.Error("proxy stopped", "error", err)
+			}
+		}()
+	}
+
+	c.heartbeat(client, checkID, logger, time.NewTimer(c.ttl).C)
+	c.UI.Output(fmt.Sprintf("Removing service %q", c.service))
+	return 0
+}
+
+// createToken creates a token with the service identity of the exposed
+// service, expiring after the TTL. It returns nil if ACLs are disabled.
+func (c *cmd) createToken(client *api.Client, serviceID string) (*api.ACLToken, error) {
+	token, _, err := client.ACL().TokenCreate(&api.ACLToken{
+		Description:       fmt.Sprintf("Dev tunnel token for %q", serviceID),
+		ServiceIdentities: []*api.ACLServiceIdentity{{ServiceName: c.service}},
+		ExpirationTTL:     c.ttl,
+		Local:             true,
+	}, nil)
+	if acl.IsErrDisabled(err) {
+		return nil, nil
+	}
+	return token, err
+}
+
+func (c *cmd) deleteToken(client *api.Client, token *api.ACLToken) {
+	if _, err := client.ACL().TokenDelete(token.AccessorID, nil); err != nil {
+		c.UI.Error(fmt.Sprintf("Error deleting token %q: %s", token.AccessorID, err))
+	}
+}
+
+// heartbeat keeps the TTL check of the service passing until the command is
+// interrupted or expired fires.
+func (c *cmd) heartbeat(client *api.Client, checkID string, logger hclog.Logger, expired <-chan time.Time) {
+	ticker := time.NewTicker(checkTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		err := client.Agent().UpdateTTL(checkID, "Dev tunnel is running", api.HealthPassing)
+		if err != nil {
+			logger.Warn("failed to update the service health check", "error", err)
+		}
+
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-expired:
+			c.UI.Output("The TTL of the tunnel expired")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Temporarily expose a local port as a mesh service"
+const help = `
+Usage: consul connect expose local [options]
+
+  Registers a port of the local machine as a mesh service with the local agent
+  and runs the built-in proxy for it, so the service can be reached from the
+  mesh during development.
+
+  The service is registered with a TTL health check kept alive while the
+  command runs, so the agent deregisters it if the command stops unexpectedly.
+  When ACLs are enabled, it is registered with a token limited to the identity
+  of the service which expires after -ttl. The service and the token are
+  removed when the command is interrupted or the TTL expires.
+
+      $ consul connect expose local -service=web -port=8080 -ttl=2h
+`
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestConnectExposeLocal_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(cli.NewMockUi(), nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestConnectExposeLocal_InvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args   []string
+		expect string
+	}{
+		"missing service": {
+			args:   []string{"-port=8080"},
+			expect: "A service name must be given",
+		},
+		"missing port": {
+			args:   []string{"-service=web"},
+			expect: "A valid port must be given",
+		},
+		"invalid ttl": {
+			args:   []string{"-service=web", "-port=8080", "-ttl=0s"},
+			expect: "The -ttl flag must be positive",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui, nil)
+			require.Equal(t, 1, c.Run(tc.args))
+			require.Contains(t, ui.ErrorWriter.String(), tc.expect)
+		})
+	}
+}
+
+func TestConnectExposeLocal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	devServices := func(r require.TestingT) map[string]*api.AgentService {
+		services, err := client.Agent().ServicesWithFilter(`"dev-tunnel" in Tags or Service == "web-sidecar-proxy"`)
+		require.NoError(r, err)
+		return services
+	}
+
+	t.Run("interrupted", func(t *testing.T) {
+		shutdownCh := make(chan struct{})
+		ui := cli.NewMockUi()
+		c := New(ui, shutdownCh)
+		c.testNoStart = true
+
+		codeCh := make(chan int, 1)
+		go func() {
+			codeCh <- c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-service=web", "-port=8080"})
+		}()
+
+		var checkID string
+		retry.Run(t, func(r *retry.R) {
+			services := devServices(r)
+			require.Len(r, services, 2)
+			for id, svc := range services {
+				switch svc.Kind {
+				case api.ServiceKindConnectProxy:
+					require.Equal(r, "web", svc.Proxy.DestinationServiceName)
+					require.Equal(r, "127.0.0.1", svc.Proxy.LocalServiceAddress)
+					require.Equal(r, 8080, svc.Proxy.LocalServicePort)
+				default:
+					require.Equal(r, "web", svc.Service)
+					require.Equal(r, 8080, svc.Port)
+					checkID = "service:" + id
+				}
+			}
+
+			checks, err := client.Agent().Checks()
+			require.NoError(r, err)
+			require.Contains(r, checks, checkID)
+			require.Equal(r, api.HealthPassing, checks[checkID].Status)
+		})
+
+		close(shutdownCh)
+		require.Equal(t, 0, <-codeCh, ui.ErrorWriter.String())
+		require.Empty(t, devServices(t))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := New(ui, make(chan struct{}))
+		c.testNoStart = true
+
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-service=web", "-port=8080", "-ttl=100ms"})
+		require.Equal(t, 0, code, ui.ErrorWriter.String())
+		require.Contains(t, ui.OutputWriter.String(), "The TTL of the tunnel expired")
+		require.Empty(t, devServices(t))
+	})
+}
@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package meshaccess
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	proxyImpl "github.com/hashicorp/consul/connect/proxy"
+	"github.com/hashicorp/consul/logging"
+)
+
+func New(ui cli.Ui, shutdownCh <-chan struct{}) *cmd {
+	ui = &cli.PrefixedUi{
+		OutputPrefix: "==> ",
+		InfoPrefix:   "    ",
+		ErrorPrefix:  "==> ",
+		Ui:           ui,
+	}
+
+	c := &cmd{UI: ui, shutdownCh: shutdownCh}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	shutdownCh <-chan struct{}
+
+	logger hclog.Logger
+
+	// flags
+	logLevel string
+	logJSON  bool
+	cfgFile  string
+
+	// test flags
+	testNoStart bool // don't start the proxy, just exit 0
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	c.flags.StringVar(&c.cfgFile, "config-file", "",
+		"Path to the HCL or JSON file defining the workloads and upstreams "+
+			"of the proxy. Files ending in .json are parsed as JSON.")
+
+	c.flags.StringVar(&c.logLevel, "log-level", "INFO",
+		"Specifies the log level.")
+
+	c.flags.BoolVar(&c.logJSON, "log-json", false,
+		"Output logs in JSON format.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+	if len(c.flags.Args()) > 0 {
+		c.UI.Error("Should have no non-flag arguments.")
+		return 1
+	}
+	if c.cfgFile == "" {
+		c.UI.Error("-config-file is required")
+		return 1
+	}
+
+	data, err := os.ReadFile(c.cfgFile)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error reading config file: %s", err))
+		return 1
+	}
+	format := "hcl"
+	if filepath.Ext(c.cfgFile) == ".json" {
+		format = "json"
+	}
+	cfg, err := proxyImpl.ParseMeshAccessConfig(data, format)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid config file %s: %s", c.cfgFile, err))
+		return 1
+	}
+
+	// Setup the log outputs
+	logConfig := logging.Config{
+		LogLevel: c.logLevel,
+		Name:     logging.Proxy,
+		LogJSON:  c.logJSON,
+	}
+
+	logGate := logging.GatedWriter{Writer: &cli.UiWriter{Ui: c.UI}}
+
+	logger, err := logging.Setup(logConfig, &logGate)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.logger = logger
+
+	if c.testNoStart {
+		return 0
+	}
+
+	// Setup Consul client
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	c.UI.Output("Consul Connect mesh access proxy starting...")
+
+	p, err := proxyImpl.NewMeshAccess(client, cfg, c.logger)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed initializing proxy: %s", err))
+		return 1
+	}
+
+	// Hook the shutdownCh up to close the proxy
+	go func() {
+		<-c.shutdownCh
+		p.Close()
+	}()
+
+	c.UI.Info("")
+	c.UI.Output("Log data will now stream in as it occurs:\n")
+	logGate.Flush()
+
+	if err := p.Serve(); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed running proxy: %s", err))
+		return 1
+	}
+
+	c.UI.Output("Consul Connect mesh access proxy shutdown")
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Runs a per-node mesh access proxy for workloads without a sidecar"
+const help = `
+Usage: consul connect mesh-access [options] -config-file <path>
+
+  Starts a mesh access proxy and runs until an interrupt is received. A
+  single mesh access proxy per node gives the workloads which can't run a
+  sidecar mutual TLS access to their upstreams on local ports, presenting
+  the identity of the workload that made each connection.
+
+  The identity is selected either by port mapping, with upstreams bound to
+  local ports dedicated to a workload, or on Linux by the user of the
+  connecting process (SO_PEERCRED), with upstreams shared by the workloads on
+  Unix domain sockets.
+
+  The proxy requires service:write permissions for the services of all the
+  workloads. The token may be passed via the CLI or the CONSUL_HTTP_TOKEN
+  environment variable.
+
+  The example below gives the "billing" workload access to "db" on port 10001,
+  and the processes of the users 1001 and 1002 access to "payments" on a
+  socket, identified as "billing":
+
+    workload {
+      service = "billing"
+      uids    = [1001, 1002]
+
+      upstream {
+        destination_name = "db"
+        local_bind_port  = 10001
+      }
+    }
+
+    upstream {
+      destination_name       = "payments"
+      local_bind_socket_path = "/run/consul/payments.sock"
+      local_bind_socket_mode = "0666"
+    }
+
+    $ consul connect mesh-access -config-file mesh-access.hcl
+`
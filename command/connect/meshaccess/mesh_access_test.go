@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package meshaccess
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeshAccessCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(cli.NewMockUi(), nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestMeshAccessCommand_ConfigFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	cases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"missing config file": {
+			wantErr: "-config-file is required",
+		},
+		"unreadable config file": {
+			args:    []string{"-config-file", filepath.Join(dir, "missing.hcl")},
+			wantErr: "Error reading config file",
+		},
+		"invalid config": {
+			args:    []string{"-config-file", write("invalid.hcl", `upstream { destination_name = "db" }`)},
+			wantErr: "at least one workload is required",
+		},
+		"hcl": {
+			args: []string{"-config-file", write("valid.hcl", `
+				workload {
+					service = "billing"
+					upstream {
+						destination_name = "db"
+						local_bind_port  = 10001
+					}
+				}
+			`)},
+		},
+		"json": {
+			args: []string{"-config-file", write("valid.json", `{
+				"workload": [{
+					"service": "billing",
+					"uids": [1001],
+					"upstream": [{"destination_name": "db", "local_bind_port": 10001}]
+				}],
+				"upstream": [{"destination_name": "payments", "local_bind_socket_path": "/run/consul/payments.sock"}]
+			}`)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui, make(chan struct{}))
+			c.testNoStart = true
+
+			code := c.Run(tc.args)
+			if tc.wantErr != "" {
+				require.Equal(t, 1, code)
+				require.Contains(t, ui.ErrorWriter.String(), tc.wantErr)
+				return
+			}
+			require.Equal(t, 0, code, ui.ErrorWriter.String())
+		})
+	}
+}
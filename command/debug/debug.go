@@ -783,7 +783,8 @@ Usage: consul debug [options]
   to be highly sensitive. Sensitive material such as ACL tokens and
   other commonly secret material are redacted automatically, but we
   strongly recommend review of the data within the archive prior to
-  transmitting it.
+  transmitting it. The config entry fields configured in the agent's
+  redaction block are also hidden, unless the token has 'secrets:read'.
 
   To get information from past, -since flag can be used. It internally uses
   hcdiag -consul -since
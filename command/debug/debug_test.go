@@ -451,6 +451,61 @@ func TestDebugCommand_CaptureTargets(t *testing.T) {
 	}
 }
 
+func TestDebugCommand_Redaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	a := agent.NewTestAgent(t, agent.TestACLConfig()+`
+		config_entries {
+			bootstrap {
+				kind = "service-defaults"
+				name = "web"
+				meta {
+					password = "hunter2"
+				}
+			}
+		}
+		redaction {
+			config_entry_fields = ["service-defaults.Meta.password"]
+		}
+	`)
+
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	capture := func(t *testing.T, token string) string {
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+		cmd.validateTiming = false
+
+		outputPath := filepath.Join(testutil.TempDir(t, "debug"), "debug")
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-token=" + token,
+			"-output=" + outputPath,
+			"-archive=false",
+			"-capture=agent",
+		}
+		require.Equal(t, 0, cmd.Run(args), ui.ErrorWriter.String())
+
+		content, err := os.ReadFile(filepath.Join(outputPath, "agent.json"))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("hidden without secrets read", func(t *testing.T) {
+		content := capture(t, "towel")
+		require.NotContains(t, content, "hunter2")
+		require.Contains(t, content, `"password": "\u003chidden\u003e"`)
+	})
+
+	t.Run("shown with secrets read", func(t *testing.T) {
+		content := capture(t, "root")
+		require.Contains(t, content, "hunter2")
+	})
+}
+
 func validateLogLine(content []byte) bool {
 	fields := strings.SplitN(string(content), " ", 2)
 	if len(fields) != 2 {
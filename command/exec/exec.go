@@ -59,6 +59,22 @@ func (c *cmd) init() {
 		"Period to wait for replication before firing event. This is an optimization to allow stale reads to be performed.")
 	c.flags.BoolVar(&c.conf.verbose, "verbose", false,
 		"Enables verbose output.")
+	c.flags.BoolVar(&c.conf.grpc, "grpc", false,
+		"Run the command on each target agent over gRPC instead of through the KV "+
+			"store and user events. The targets are looked up in the catalog, and their "+
+			"gRPC port must be reachable from where the command is run.")
+	c.flags.StringVar(&c.conf.filter, "filter", "",
+		"Filter expression evaluated against the catalog nodes to select the targets. "+
+			"Requires -grpc.")
+	c.flags.IntVar(&c.conf.grpcPort, "grpc-port", rExecGRPCPort,
+		"The gRPC TLS port of the target agents. Requires -grpc.")
+	c.flags.BoolVar(&c.conf.grpcTLS, "grpc-tls", true,
+		"Connect to the target agents with TLS, using the CA and client certificates "+
+			"of the HTTP client. Disabling TLS is only allowed when no ACL token is "+
+			"used, since the token would be sent in plaintext. Requires -grpc.")
+	c.flags.DurationVar(&c.conf.timeout, "timeout", rExecGRPCTimeout,
+		"Maximum time the command may run on each target before it is killed. "+
+			"Requires -grpc.")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -123,6 +139,10 @@ func (c *cmd) Run(args []string) int {
 	}
 	c.apiclient = client
 
+	if c.conf.grpc {
+		return c.runGRPC()
+	}
+
 	// Check if this is a foreign datacenter
 	if c.http.Datacenter() != "" && c.http.Datacenter() != info["Config"]["Datacenter"] {
 		if c.conf.verbose {
@@ -201,6 +221,12 @@ Usage: consul exec [options] [-|command...]
   be filtered using regular expressions on node name, service, and tag
   definitions. If a command is '-', stdin will be read until EOF
   and used as a script input.
+
+  With -grpc, the targets are looked up in the catalog and the command is
+  run on each of them over gRPC, which requires agent:write on every target
+  node. The targets can also be selected with a filter expression:
+
+      $ consul exec -grpc -filter 'Meta.rack == "r1"' uptime
 `
 
 // waitForJob is used to poll for results and wait until the job is terminated
@@ -394,6 +420,12 @@ func (conf *rExecConf) validate() error {
 	if conf.tag != "" && conf.service == "" {
 		return fmt.Errorf("Cannot provide tag filter without service filter.")
 	}
+	if !conf.grpc && conf.filter != "" {
+		return fmt.Errorf("Cannot provide filter expression without -grpc.")
+	}
+	if conf.grpc && conf.timeout <= 0 {
+		return fmt.Errorf("Timeout must be positive.")
+	}
 	return nil
 }
 
@@ -589,6 +621,13 @@ const (
 	// rExecRenewInterval is how often we renew the session TTL
 	// when doing an exec in a foreign DC.
 	rExecRenewInterval = 5 * time.Second
+
+	// rExecGRPCPort is the default gRPC TLS port of the target agents.
+	rExecGRPCPort = 8503
+
+	// rExecGRPCTimeout is how long the command may run on each target
+	// by default when using gRPC.
+	rExecGRPCTimeout = 5 * time.Minute
 )
 
 // rExecConf is used to pass around configuration
@@ -612,6 +651,12 @@ type rExecConf struct {
 	script []byte
 
 	verbose bool
+
+	grpc     bool
+	filter   string
+	grpcPort int
+	grpcTLS  bool
+	timeout  time.Duration
 }
 
 // rExecEvent is the event we broadcast using a user-event
@@ -4,6 +4,7 @@
 package exec
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -77,6 +78,88 @@ func TestExecCommand_NoShell(t *testing.T) {
 	}
 }
 
+func TestExecCommand_GRPC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, `
+		disable_remote_exec = false
+		node_meta {
+			rack = "r1"
+		}
+	`)
+	defer a.Shutdown()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	// Wait for the node meta to be synced to the catalog.
+	retry.Run(t, func(r *retry.R) {
+		nodes, _, err := a.Client().Catalog().Nodes(&consulapi.QueryOptions{Filter: `Meta.rack == "r1"`})
+		if err != nil {
+			r.Fatalf("err: %v", err)
+		}
+		if len(nodes) != 1 {
+			r.Fatalf("bad: %#v", nodes)
+		}
+	})
+
+	grpcPort := "-grpc-port=" + strconv.Itoa(a.Config.GRPCPort)
+
+	ui := cli.NewMockUi()
+	c := New(ui, nil)
+	args := []string{"-http-addr=" + a.HTTPAddr(), "-grpc", grpcPort, "-grpc-tls=false", "-filter", `Meta.rack == "r1"`,
+		"echo hello; echo world 1>&2; exit 3"}
+
+	code := c.Run(args)
+	if code != 2 {
+		t.Fatalf("bad: %d. Error:%#v  (std)Output:%#v", code, ui.ErrorWriter.String(), ui.OutputWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	node := a.Config.NodeName
+	for _, want := range []string{
+		"    " + node + ": hello\n",
+		"    " + node + ": world\n",
+		"==> " + node + ": finished with exit code 3\n",
+		"1 / 1 node(s) completed\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("missing %q in output: %#v", want, output)
+		}
+	}
+
+	// No nodes match the filter.
+	ui = cli.NewMockUi()
+	c = New(ui, nil)
+	args = []string{"-http-addr=" + a.HTTPAddr(), "-grpc", grpcPort, "-grpc-tls=false", "-filter", `Meta.rack == "r2"`, "uptime"}
+
+	code = c.Run(args)
+	if code != 1 {
+		t.Fatalf("bad: %d. Error:%#v  (std)Output:%#v", code, ui.ErrorWriter.String(), ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "No nodes matched the targets") {
+		t.Fatalf("bad: %#v", ui.ErrorWriter.String())
+	}
+
+	// The token is never sent in plaintext.
+	ui = cli.NewMockUi()
+	c = New(ui, nil)
+	args = []string{"-http-addr=" + a.HTTPAddr(), "-token=secret", "-grpc", grpcPort, "-grpc-tls=false", "-filter", `Meta.rack == "r1"`, "uptime"}
+
+	code = c.Run(args)
+	if code != 1 {
+		t.Fatalf("bad: %d. Error:%#v  (std)Output:%#v", code, ui.ErrorWriter.String(), ui.OutputWriter.String())
+	}
+	if !strings.Contains(ui.ErrorWriter.String(), "Refusing to send the ACL token over a plaintext gRPC connection") {
+		t.Fatalf("bad: %#v", ui.ErrorWriter.String())
+	}
+	if strings.Contains(ui.OutputWriter.String(), "completed") {
+		t.Fatalf("bad: %#v", ui.OutputWriter.String())
+	}
+}
+
 func TestExecCommand_CrossDC(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -161,6 +244,25 @@ func TestExecCommand_Validate(t *testing.T) {
 	if err == nil {
 		t.Fatalf("err: %v", err)
 	}
+
+	conf.tag = ""
+	conf.filter = "Meta.rack == r1"
+	err = conf.validate()
+	if err == nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	conf.grpc = true
+	err = conf.validate()
+	if err == nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	conf.timeout = time.Minute
+	err = conf.validate()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
 }
 
 func TestExecCommand_Sessions(t *testing.T) {
@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+// grpcResult is a message received from one of the targets, or the error
+// that ended its stream.
+type grpcResult struct {
+	Node   string
+	Output *pbexec.Output
+	Exit   *pbexec.Exit
+	Err    error
+}
+
+// runGRPC runs the command on each of the targets over gRPC, printing their
+// output as it is received.
+func (c *cmd) runGRPC() int {
+	targets, err := c.grpcTargets()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to look up targets: %s", err))
+		return 1
+	}
+	if len(targets) == 0 {
+		c.UI.Error("No nodes matched the targets")
+		return 1
+	}
+
+	cfg := api.DefaultConfig()
+	c.http.MergeOntoConfig(cfg)
+	token := cfg.Token
+	if cfg.TokenFile != "" {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to read token file: %s", err))
+			return 1
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if c.conf.grpcTLS {
+		tlsConfig, err := api.SetupTLSConfig(&cfg.TLSConfig)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to set up TLS: %s", err))
+			return 1
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	} else if token != "" {
+		c.UI.Error("Refusing to send the ACL token over a plaintext gRPC connection, remove -grpc-tls=false")
+		return 1
+	}
+
+	req := &pbexec.ExecRequest{
+		Timeout: durationpb.New(c.conf.timeout),
+	}
+	switch {
+	case len(c.conf.args) > 0:
+		req.Args = c.conf.args
+	case len(c.conf.script) > 0:
+		req.Command = string(c.conf.script)
+	default:
+		req.Command = c.conf.cmd
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-consul-token", token)
+	}
+
+	start := time.Now()
+	resultCh := make(chan grpcResult)
+	target := &TargetedUI{UI: c.UI}
+	for _, node := range targets {
+		if c.conf.verbose {
+			target.Target = node.Node
+			target.Info(fmt.Sprintf("connecting to %s", node.Address))
+		}
+		go c.execOn(ctx, node, req, dialOpts, resultCh)
+	}
+
+	// Output is printed a line at a time, so that the lines of different
+	// targets aren't mixed together.
+	partial := make(map[string]*bytes.Buffer)
+	flush := func(node string, all bool) {
+		for _, stream := range []pbexec.Stream{pbexec.Stream_STREAM_STDOUT, pbexec.Stream_STREAM_STDERR} {
+			buf, ok := partial[node+"/"+stream.String()]
+			if !ok {
+				continue
+			}
+			end := bytes.LastIndexByte(buf.Bytes(), '\n') + 1
+			if all {
+				end = buf.Len()
+			}
+			if end > 0 {
+				target.Output(string(buf.Next(end)))
+			}
+		}
+	}
+
+	var completed, badExit int
+	for remaining := len(targets); remaining > 0; {
+		select {
+		case res := <-resultCh:
+			target.Target = res.Node
+			switch {
+			case res.Output != nil:
+				key := res.Node + "/" + res.Output.Stream.String()
+				if partial[key] == nil {
+					partial[key] = &bytes.Buffer{}
+				}
+				partial[key].Write(res.Output.Data)
+				flush(res.Node, false)
+				continue
+
+			case res.Exit != nil:
+				flush(res.Node, true)
+				if res.Exit.Error != "" {
+					target.Error(res.Exit.Error)
+				}
+				target.Info(fmt.Sprintf("finished with exit code %d", res.Exit.Code))
+				completed++
+				if res.Exit.Code != 0 {
+					badExit++
+				}
+
+			default:
+				flush(res.Node, true)
+				target.Error(fmt.Sprintf("failed: %s", res.Err))
+				badExit++
+			}
+			remaining--
+
+		case <-c.shutdownCh:
+			return 1
+		}
+	}
+
+	c.UI.Info(fmt.Sprintf("%d / %d node(s) completed", completed, len(targets)))
+	if c.conf.verbose {
+		c.UI.Info(fmt.Sprintf("Completed in %0.2f seconds",
+			float64(time.Since(start))/float64(time.Second)))
+	}
+	if badExit > 0 {
+		return 2
+	}
+	return 0
+}
+
+// execOn runs the command on a single target, sending the messages it
+// receives to resultCh. The last result sent holds either the exit code or
+// an error.
+func (c *cmd) execOn(ctx context.Context, node *api.Node, req *pbexec.ExecRequest, dialOpts []grpc.DialOption, resultCh chan<- grpcResult) {
+	send := func(res grpcResult) {
+		res.Node = node.Node
+		select {
+		case resultCh <- res:
+		case <-ctx.Done():
+		}
+	}
+
+	addr := net.JoinHostPort(node.Address, strconv.Itoa(c.conf.grpcPort))
+	conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+	if err != nil {
+		send(grpcResult{Err: err})
+		return
+	}
+	defer conn.Close()
+
+	stream, err := pbexec.NewExecServiceClient(conn).Exec(ctx, req)
+	if err != nil {
+		send(grpcResult{Err: err})
+		return
+	}
+	for {
+		rsp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			send(grpcResult{Err: errors.New("stream ended without an exit code")})
+			return
+		}
+		if err != nil {
+			send(grpcResult{Err: err})
+			return
+		}
+
+		switch r := rsp.Result.(type) {
+		case *pbexec.ExecResponse_Output:
+			send(grpcResult{Output: r.Output})
+		case *pbexec.ExecResponse_Exit:
+			send(grpcResult{Exit: r.Exit})
+			return
+		}
+	}
+}
+
+// grpcTargets returns the nodes matching the filter expression and the node,
+// service and tag regular expressions, sorted by name.
+func (c *cmd) grpcTargets() ([]*api.Node, error) {
+	catalog := c.apiclient.Catalog()
+	nodes, _, err := catalog.Nodes(&api.QueryOptions{Filter: c.conf.filter})
+	if err != nil {
+		return nil, err
+	}
+
+	// The expressions were validated already.
+	var nodeRe, serviceRe, tagRe *regexp.Regexp
+	if c.conf.node != "" {
+		nodeRe = regexp.MustCompile(c.conf.node)
+	}
+	if c.conf.service != "" {
+		serviceRe = regexp.MustCompile(c.conf.service)
+	}
+	if c.conf.tag != "" {
+		tagRe = regexp.MustCompile(c.conf.tag)
+	}
+
+	// As with the user event filters, a node is targeted if it has an
+	// instance of a matching service with a matching tag.
+	var withService map[string]struct{}
+	if serviceRe != nil {
+		withService = make(map[string]struct{})
+		services, _, err := catalog.Services(nil)
+		if err != nil {
+			return nil, err
+		}
+		for name := range services {
+			if !serviceRe.MatchString(name) {
+				continue
+			}
+			instances, _, err := catalog.Service(name, "", nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, inst := range instances {
+				if tagRe == nil || anyTagMatches(tagRe, inst.ServiceTags) {
+					withService[inst.Node] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var targets []*api.Node
+	for _, node := range nodes {
+		if nodeRe != nil && !nodeRe.MatchString(node.Node) {
+			continue
+		}
+		if withService != nil {
+			if _, ok := withService[node.Node]; !ok {
+				continue
+			}
+		}
+		targets = append(targets, node)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Node < targets[j].Node
+	})
+	return targets, nil
+}
+
+func anyTagMatches(re *regexp.Regexp, tags []string) bool {
+	for _, tag := range tags {
+		if re.MatchString(tag) {
+			return true
+		}
+	}
+	return false
+}
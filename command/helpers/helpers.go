@@ -80,6 +80,53 @@ func ParseConfigEntry(data string) (api.ConfigEntry, error) {
 	return newDecodeConfigEntry(raw)
 }
 
+// ParseConfigEntries parses the list of config entries held under key in an
+// HCL or JSON document. Entries without a kind are decoded as defaultKind.
+func ParseConfigEntries(data, key, defaultKind string) ([]api.ConfigEntry, error) {
+	var raw map[string]interface{}
+	if err := hclDecode(&raw, data); err != nil {
+		return nil, fmt.Errorf("Failed to decode config entries input: %v", err)
+	}
+
+	var list []map[string]interface{}
+	switch v := raw[key].(type) {
+	case nil:
+	case []map[string]interface{}:
+		// HCL blocks
+		list = v
+	case []interface{}:
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: unexpected entry type %T", key, item)
+			}
+			list = append(list, m)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unexpected type %T", key, v)
+	}
+	for k := range raw {
+		if k != key {
+			return nil, fmt.Errorf("invalid config key %q", k)
+		}
+	}
+
+	entries := make([]api.ConfigEntry, 0, len(list))
+	for i, m := range list {
+		if _, ok := m["Kind"]; !ok {
+			if _, ok := m["kind"]; !ok {
+				m["Kind"] = defaultKind
+			}
+		}
+		entry, err := newDecodeConfigEntry(m)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %v", key, i, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
 // There is a 'structs' variation of this in
 // agent/structs/config_entry.go:DecodeConfigEntry
 func newDecodeConfigEntry(raw map[string]interface{}) (api.ConfigEntry, error) {
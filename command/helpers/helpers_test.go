@@ -3040,6 +3040,123 @@ func TestParseConfigEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "exported-services with selectors",
+			snake: `
+				kind = "exported-services"
+				name = "foo"
+				services = [
+					{
+						name = "*"
+						selector {
+							"team" = "payments"
+						}
+						consumers = [
+							{
+								peer_name = "flarm"
+							},
+							{
+								peer_name = "blarg"
+								selector {
+									"tier" = "public"
+								}
+							}
+						]
+					}
+				]
+			`,
+			camel: `
+				Kind = "exported-services"
+				Name = "foo"
+				Services = [
+					{
+						Name = "*"
+						Selector {
+							"team" = "payments"
+						}
+						Consumers = [
+							{
+								Peer = "flarm"
+							},
+							{
+								Peer = "blarg"
+								Selector {
+									"tier" = "public"
+								}
+							}
+						]
+					}
+				]
+			`,
+			snakeJSON: `
+			{
+				"kind": "exported-services",
+				"name": "foo",
+				"services": [
+					{
+						"name": "*",
+						"selector": {
+							"team": "payments"
+						},
+						"consumers": [
+							{
+								"peer_name": "flarm"
+							},
+							{
+								"peer_name": "blarg",
+								"selector": {
+									"tier": "public"
+								}
+							}
+						]
+					}
+				]
+			}
+			`,
+			camelJSON: `
+			{
+				"Kind": "exported-services",
+				"Name": "foo",
+				"Services": [
+					{
+						"Name": "*",
+						"Selector": {
+							"team": "payments"
+						},
+						"Consumers": [
+							{
+								"Peer": "flarm"
+							},
+							{
+								"Peer": "blarg",
+								"Selector": {
+									"tier": "public"
+								}
+							}
+						]
+					}
+				]
+			}
+			`,
+			expect: &api.ExportedServicesConfigEntry{
+				Name: "foo",
+				Services: []api.ExportedService{
+					{
+						Name:     "*",
+						Selector: map[string]string{"team": "payments"},
+						Consumers: []api.ServiceConsumer{
+							{
+								Peer: "flarm",
+							},
+							{
+								Peer:     "blarg",
+								Selector: map[string]string{"tier": "public"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "api-gateway",
 			snake: `
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// WatchQuery runs a blocking query repeatedly until ctx is done. The output
+// function is called after the first query, and then each time the index of
+// the results changes, so a query timing out without changes doesn't output
+// the same results again. It returns nil when ctx is done, or the first error
+// returned by query or output.
+func WatchQuery(ctx context.Context, opts *api.QueryOptions, query func(*api.QueryOptions) (*api.QueryMeta, error), output func() error) error {
+	if opts == nil {
+		opts = &api.QueryOptions{}
+	}
+
+	var index uint64
+	first := true
+	for {
+		q := *opts
+		q.WaitIndex = index
+		meta, err := query(q.WithContext(ctx))
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if first || meta.LastIndex != index {
+			if err := output(); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		// Start over if the index went backwards, as the blocking queries
+		// would otherwise return immediately.
+		index = meta.LastIndex
+		if index < q.WaitIndex {
+			index = 0
+		}
+	}
+}
+
+// ShutdownContext returns a context that is canceled when shutdownCh is
+// closed or receives a value, or when shutdownCh is nil, when the command is
+// interrupted.
+func ShutdownContext(shutdownCh <-chan struct{}) (context.Context, context.CancelFunc) {
+	if shutdownCh == nil {
+		return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
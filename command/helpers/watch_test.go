@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func TestWatchQuery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The index is unchanged when a blocking query times out, and may go
+	// backwards when the servers are restored from a snapshot.
+	indexes := []uint64{5, 5, 7, 3, 3, 4}
+	var waitIndexes []uint64
+	var current uint64
+	var outputs []uint64
+
+	err := WatchQuery(ctx, &api.QueryOptions{AllowStale: true}, func(q *api.QueryOptions) (*api.QueryMeta, error) {
+		require.True(t, q.AllowStale)
+		require.NotNil(t, q.Context())
+		waitIndexes = append(waitIndexes, q.WaitIndex)
+
+		if len(indexes) == 0 {
+			cancel()
+			return nil, context.Canceled
+		}
+		current, indexes = indexes[0], indexes[1:]
+		return &api.QueryMeta{LastIndex: current}, nil
+	}, func() error {
+		outputs = append(outputs, current)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 5, 5, 7, 0, 3, 4}, waitIndexes)
+	require.Equal(t, []uint64{5, 7, 3, 3, 4}, outputs)
+}
+
+func TestWatchQuery_Error(t *testing.T) {
+	queryErr := errors.New("boom")
+	err := WatchQuery(context.Background(), nil, func(q *api.QueryOptions) (*api.QueryMeta, error) {
+		return nil, queryErr
+	}, func() error {
+		t.Fatal("unexpected output")
+		return nil
+	})
+	require.Equal(t, queryErr, err)
+
+	outputErr := errors.New("bad output")
+	err = WatchQuery(context.Background(), nil, func(q *api.QueryOptions) (*api.QueryMeta, error) {
+		return &api.QueryMeta{LastIndex: 1}, nil
+	}, func() error {
+		return outputErr
+	})
+	require.Equal(t, outputErr, err)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exp
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/intention/impexp"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	format string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.format, "format", impexp.FormatHCL,
+		"Output format, one of \"hcl\", \"json\" or \"yaml\".")
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(c.flags.Args()) > 0 {
+		c.UI.Error(fmt.Sprintf("Too many arguments (expected 0, got %d)", len(c.flags.Args())))
+		return 1
+	}
+
+	switch c.format {
+	case impexp.FormatHCL, impexp.FormatJSON, impexp.FormatYAML:
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid format %q, must be one of %q, %q or %q",
+			c.format, impexp.FormatHCL, impexp.FormatJSON, impexp.FormatYAML))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	list, _, err := client.ConfigEntries().List(api.ServiceIntentions, &api.QueryOptions{
+		AllowStale: c.http.Stale(),
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error listing intentions: %s", err))
+		return 1
+	}
+
+	entries := make([]*api.ServiceIntentionsConfigEntry, 0, len(list))
+	for _, entry := range list {
+		if ixn, ok := entry.(*api.ServiceIntentionsConfigEntry); ok {
+			entries = append(entries, ixn)
+		}
+	}
+
+	data, err := impexp.Format(entries, c.format)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error encoding intentions: %s", err))
+		return 1
+	}
+
+	c.UI.Output(string(data))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Exports the intention graph as a document"
+	help     = `
+Usage: consul intention export [options]
+
+  Exports all the service intentions as a single HCL, JSON or YAML document,
+  which can be edited and applied back with the "consul intention import"
+  command. The document holds one Intentions block per destination service,
+  in the format of a service-intentions config entry.
+
+  Export the intentions as HCL:
+
+      $ consul intention export > intentions.hcl
+
+  Export the intentions as YAML:
+
+      $ consul intention export -format=yaml > intentions.yaml
+
+  For a full list of options and examples, please see the Consul documentation.
+`
+)
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/intention/impexp"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestIntentionExportCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestIntentionExportCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	entries := []*api.ServiceIntentionsConfigEntry{
+		{
+			Kind: api.ServiceIntentions,
+			Name: "db",
+			Sources: []*api.SourceIntention{
+				{Name: "web", Action: api.IntentionActionAllow},
+			},
+		},
+		{
+			Kind: api.ServiceIntentions,
+			Name: "web",
+			Sources: []*api.SourceIntention{
+				{Name: "*", Action: api.IntentionActionDeny},
+			},
+		},
+	}
+	for _, entry := range entries {
+		_, _, err := client.ConfigEntries().Set(entry, nil)
+		require.NoError(t, err)
+	}
+
+	for _, format := range []string{impexp.FormatHCL, impexp.FormatJSON, impexp.FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+
+			code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-format=" + format})
+			require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+			exported, err := impexp.Parse(ui.OutputWriter.Bytes(), format)
+			require.NoError(t, err)
+			changes, err := impexp.Diff(entries, exported, true)
+			require.NoError(t, err)
+			require.Empty(t, changes)
+		})
+	}
+
+	t.Run("invalid format", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-format=toml"})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), `Invalid format "toml"`)
+	})
+}
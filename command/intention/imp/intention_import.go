@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package imp
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
+	"github.com/hashicorp/consul/command/intention/impexp"
+)
+
+// maxTxnOps is the limit of operations in a transaction enforced by the
+// agents, each changed destination is one operation.
+const maxTxnOps = 128
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	format string
+	dryRun bool
+	prune  bool
+
+	// testStdin is the input for testing.
+	testStdin io.Reader
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.format, "format", "",
+		"Format of the document, one of \"hcl\", \"json\" or \"yaml\". Defaults "+
+			"to YAML for files ending in .yaml or .yml, and to HCL or JSON otherwise.")
+	c.flags.BoolVar(&c.dryRun, "dry-run", false,
+		"Validate the document and output the changes it makes to the intentions "+
+			"without applying them.")
+	c.flags.BoolVar(&c.prune, "prune", false,
+		"Delete the intentions of the destinations missing from the document.")
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	flags.Merge(c.flags, c.http.MultiTenancyFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = c.flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("Must provide exactly one positional argument to specify the document to import")
+		return 1
+	}
+
+	format := c.format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(args[0])) {
+		case ".yaml", ".yml":
+			format = impexp.FormatYAML
+		default:
+			format = impexp.FormatHCL
+		}
+	}
+
+	data, err := helpers.LoadDataSourceNoRaw(args[0], c.testStdin)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to load data: %v", err))
+		return 1
+	}
+
+	desired, err := impexp.Parse([]byte(data), format)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid document: %v", err))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	list, _, err := client.ConfigEntries().List(api.ServiceIntentions, &api.QueryOptions{RequireConsistent: true})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error listing intentions: %s", err))
+		return 1
+	}
+	existing := make([]*api.ServiceIntentionsConfigEntry, 0, len(list))
+	for _, entry := range list {
+		if ixn, ok := entry.(*api.ServiceIntentionsConfigEntry); ok {
+			existing = append(existing, ixn)
+		}
+	}
+
+	changes, err := impexp.Diff(existing, desired, c.prune)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error comparing intentions: %s", err))
+		return 1
+	}
+	if len(changes) == 0 {
+		c.UI.Info("No changes to apply")
+		return 0
+	}
+	c.outputChanges(changes)
+
+	if c.dryRun {
+		c.UI.Info("Dry run, no changes were applied")
+		return 0
+	}
+
+	if len(changes) > maxTxnOps {
+		c.UI.Error(fmt.Sprintf("Too many destinations changed to apply atomically (%d > %d)", len(changes), maxTxnOps))
+		return 1
+	}
+
+	// The changes are applied in a single transaction, which fails as a whole
+	// if any of the destinations changed since they were listed.
+	ops := make(api.TxnOps, 0, len(changes))
+	for _, change := range changes {
+		verb := api.ConfigEntryCAS
+		if change.Op == impexp.ChangeDelete {
+			verb = api.ConfigEntryDeleteCAS
+		}
+		ops = append(ops, &api.TxnOp{
+			ConfigEntry: &api.ConfigEntryTxnOp{
+				Verb:  verb,
+				Entry: change.Entry,
+				Index: change.ModifyIndex,
+			},
+		})
+	}
+
+	ok, resp, _, err := client.Txn().Txn(ops, nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error applying intentions: %s", err))
+		return 1
+	}
+	if !ok {
+		for _, txnErr := range resp.Errors {
+			if txnErr.OpIndex >= 0 && txnErr.OpIndex < len(changes) {
+				c.UI.Error(fmt.Sprintf("Error applying intentions of %q: %s", impexp.Key(changes[txnErr.OpIndex].Entry), txnErr.What))
+			} else {
+				c.UI.Error(fmt.Sprintf("Error applying intentions: %s", txnErr.What))
+			}
+		}
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("Applied the intentions of %d destinations", len(changes)))
+	return 0
+}
+
+// outputChanges lists the changed destinations and their sources, prefixed
+// with "+" when created, "~" when updated and "-" when deleted.
+func (c *cmd) outputChanges(changes []impexp.Change) {
+	prefix := map[impexp.ChangeOp]string{
+		impexp.ChangeCreate: "+",
+		impexp.ChangeUpdate: "~",
+		impexp.ChangeDelete: "-",
+	}
+	for _, change := range changes {
+		c.UI.Output(fmt.Sprintf("%s %s", prefix[change.Op], impexp.Key(change.Entry)))
+		for _, src := range change.Sources {
+			c.UI.Output(fmt.Sprintf("    %s %s", prefix[src.Op], src.Source))
+		}
+	}
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Applies an intention graph document"
+	help     = `
+Usage: consul intention import [options] <document>
+
+  Makes the intentions of the destinations of a document, in the format
+  generated by the "consul intention export" command, match the document.
+  The document argument is either a file path or '-' to indicate that the
+  document should be read from stdin.
+
+  The changes to the intentions are output, then applied atomically. The
+  import fails without applying any change if the intentions of a changed
+  destination were modified since they were read.
+
+  Preview the changes of a document:
+
+      $ consul intention import -dry-run intentions.hcl
+
+  Apply a document, deleting the intentions of the other destinations:
+
+      $ consul intention import -prune intentions.yaml
+
+  For a full list of options and examples, please see the Consul documentation.
+`
+)
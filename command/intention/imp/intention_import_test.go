@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package imp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestIntentionImportCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestIntentionImportCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	for _, entry := range []*api.ServiceIntentionsConfigEntry{
+		{
+			Kind:    api.ServiceIntentions,
+			Name:    "db",
+			Sources: []*api.SourceIntention{{Name: "web", Action: api.IntentionActionAllow}},
+		},
+		{
+			Kind:    api.ServiceIntentions,
+			Name:    "cache",
+			Sources: []*api.SourceIntention{{Name: "web", Action: api.IntentionActionAllow}},
+		},
+	} {
+		_, _, err := client.ConfigEntries().Set(entry, nil)
+		require.NoError(t, err)
+	}
+
+	const document = `
+Intentions:
+- Name: db
+  Sources:
+  - Name: web
+    Action: allow
+  - Name: api
+    Action: deny
+- Name: payments
+  Sources:
+  - Name: billing
+    Action: allow
+`
+	run := func(t *testing.T, args ...string) (*cli.MockUi, int) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+		c.testStdin = strings.NewReader(document)
+		args = append([]string{"-http-addr=" + a.HTTPAddr(), "-format=yaml"}, args...)
+		return ui, c.Run(append(args, "-"))
+	}
+	sources := func(t *testing.T, name string) []string {
+		entry, _, err := client.ConfigEntries().Get(api.ServiceIntentions, name, nil)
+		if err != nil && strings.Contains(err.Error(), "404") {
+			return nil
+		}
+		require.NoError(t, err)
+		var out []string
+		for _, src := range entry.(*api.ServiceIntentionsConfigEntry).Sources {
+			out = append(out, src.Name+":"+string(src.Action))
+		}
+		return out
+	}
+
+	// A dry run outputs the changes without applying them.
+	ui, code := run(t, "-dry-run", "-prune")
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Equal(t, strings.Join([]string{
+		"- default/default/cache",
+		"    - default/default/web",
+		"~ default/default/db",
+		"    + default/default/api",
+		"+ default/default/payments",
+		"    + default/default/billing",
+		"Dry run, no changes were applied",
+		"",
+	}, "\n"), ui.OutputWriter.String())
+	require.Nil(t, sources(t, "payments"))
+
+	// The destinations missing from the document are only deleted with -prune.
+	ui, code = run(t)
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.ElementsMatch(t, []string{"web:allow", "api:deny"}, sources(t, "db"))
+	require.Equal(t, []string{"billing:allow"}, sources(t, "payments"))
+	require.Equal(t, []string{"web:allow"}, sources(t, "cache"))
+
+	ui, code = run(t, "-prune")
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Nil(t, sources(t, "cache"))
+
+	// Importing the document again changes nothing.
+	ui, code = run(t, "-prune")
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "No changes to apply")
+}
+
+func TestIntentionImportCommand_Invalid(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	testrpc.WaitForTestAgent(t, a.RPC, "dc1")
+
+	cases := map[string]struct {
+		document string
+		err      string
+	}{
+		"invalid document": {
+			document: `Intentions { Sources { Name = "web" } }`,
+			err:      "Name is required",
+		},
+		"rejected by the servers": {
+			document: `
+				Intentions {
+					Name = "db"
+					Sources {
+						Name   = "web"
+						Action = "allow"
+					}
+				}
+				Intentions {
+					Name = "cache"
+					Sources {
+						Name   = "web"
+					}
+				}
+			`,
+			err: `Error applying intentions of "default/default/cache"`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := New(ui)
+			c.testStdin = strings.NewReader(tc.document)
+
+			code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-"})
+			require.Equal(t, 1, code)
+			require.Contains(t, ui.ErrorWriter.String(), tc.err)
+
+			// Nothing was applied.
+			entries, _, err := client.ConfigEntries().List(api.ServiceIntentions, nil)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+		})
+	}
+}
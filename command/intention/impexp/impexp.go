@@ -0,0 +1,449 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package impexp holds the document format of the intention graph shared by
+// the intention export and import commands.
+package impexp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/helpers"
+)
+
+const (
+	FormatHCL  = "hcl"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// documentKey is the key holding the service-intentions config entries in a
+// document.
+const documentKey = "Intentions"
+
+// serverEntryFields and serverSourceFields are set by the servers on the
+// config entries and their sources, they are left out of documents and diffs
+// along with the kind, which is implied by the document.
+var (
+	serverEntryFields  = []string{"Kind", "CreateIndex", "ModifyIndex"}
+	serverSourceFields = []string{"Precedence", "Type", "LegacyID", "LegacyMeta", "LegacyCreateTime", "LegacyUpdateTime"}
+)
+
+// Parse decodes and validates the service-intentions config entries of a
+// document in the given format. The HCL format also accepts JSON.
+func Parse(data []byte, format string) ([]*api.ServiceIntentionsConfigEntry, error) {
+	switch format {
+	case FormatYAML:
+		var err error
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode YAML: %v", err)
+		}
+	case FormatHCL, FormatJSON:
+	default:
+		return nil, fmt.Errorf("Invalid format %q", format)
+	}
+
+	decoded, err := helpers.ParseConfigEntries(string(data), documentKey, api.ServiceIntentions)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*api.ServiceIntentionsConfigEntry, 0, len(decoded))
+	for _, entry := range decoded {
+		ixn, ok := entry.(*api.ServiceIntentionsConfigEntry)
+		if !ok {
+			return nil, fmt.Errorf("%s: config entry %s/%s is not of kind %s", documentKey, entry.GetKind(), entry.GetName(), api.ServiceIntentions)
+		}
+		entries = append(entries, ixn)
+	}
+
+	if err := Validate(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Validate checks that the entries are complete and that no destination or
+// source is defined more than once. The other checks are left to the
+// servers, which validate the entries like any other config entry write.
+func Validate(entries []*api.ServiceIntentionsConfigEntry) error {
+	seen := make(map[string]struct{})
+	for _, entry := range entries {
+		if entry.Name == "" {
+			return fmt.Errorf("%s: Name is required", documentKey)
+		}
+		key := Key(entry)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%s: destination %q is defined more than once", documentKey, key)
+		}
+		seen[key] = struct{}{}
+
+		sources := make(map[string]struct{})
+		for _, src := range entry.Sources {
+			if src == nil || src.Name == "" {
+				return fmt.Errorf("destination %q: source Name is required", key)
+			}
+			srcKey := SourceKey(src)
+			if _, ok := sources[srcKey]; ok {
+				return fmt.Errorf("destination %q: source %q is defined more than once", key, srcKey)
+			}
+			sources[srcKey] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Format encodes the entries as a document in the given format, leaving out
+// the fields set by the servers.
+func Format(entries []*api.ServiceIntentionsConfigEntry, format string) ([]byte, error) {
+	sorted := make([]*api.ServiceIntentionsConfigEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return Key(sorted[i]) < Key(sorted[j]) })
+
+	list := make([]interface{}, 0, len(sorted))
+	for _, entry := range sorted {
+		raw, err := canonical(entry)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, raw)
+	}
+	doc := map[string]interface{}{documentKey: list}
+
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML:
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.JSONToYAML(data)
+	case FormatHCL:
+		var buf bytes.Buffer
+		writeHCLBody(&buf, doc, 0)
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("Invalid format %q", format)
+	}
+}
+
+// Key returns the partition/namespace/name of the destination of the entry.
+func Key(entry *api.ServiceIntentionsConfigEntry) string {
+	return tenancyString(entry.Partition, entry.Namespace, entry.Name)
+}
+
+// SourceKey returns the peer or sameness group, and the
+// partition/namespace/name of the source.
+func SourceKey(src *api.SourceIntention) string {
+	key := tenancyString(src.Partition, src.Namespace, src.Name)
+	switch {
+	case src.Peer != "":
+		key = "peer:" + src.Peer + "/" + key
+	case src.SamenessGroup != "":
+		key = "sameness-group:" + src.SamenessGroup + "/" + key
+	}
+	return key
+}
+
+func tenancyString(partition, namespace, name string) string {
+	if partition == "" {
+		partition = "default"
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	return partition + "/" + namespace + "/" + name
+}
+
+// canonical returns the entry as generic JSON values without the fields set
+// by the servers, and with its sources sorted, for comparison and output.
+func canonical(entry *api.ServiceIntentionsConfigEntry) (map[string]interface{}, error) {
+	sources := make([]*api.SourceIntention, len(entry.Sources))
+	copy(sources, entry.Sources)
+	sort.SliceStable(sources, func(i, j int) bool { return SourceKey(sources[i]) < SourceKey(sources[j]) })
+	sorted := *entry
+	sorted.Sources = sources
+
+	data, err := json.Marshal(&sorted)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	for _, k := range serverEntryFields {
+		delete(raw, k)
+	}
+	if list, ok := raw["Sources"].([]interface{}); ok {
+		for _, src := range list {
+			if m, ok := src.(map[string]interface{}); ok {
+				for _, k := range serverSourceFields {
+					delete(m, k)
+				}
+			}
+		}
+	} else {
+		delete(raw, "Sources")
+	}
+	return raw, nil
+}
+
+// ChangeOp is the operation a change applies to a destination.
+type ChangeOp string
+
+const (
+	ChangeCreate ChangeOp = "create"
+	ChangeUpdate ChangeOp = "update"
+	ChangeDelete ChangeOp = "delete"
+)
+
+// Change is a difference between the intention graph of the cluster and the
+// one of a document, for a single destination.
+type Change struct {
+	Op ChangeOp
+
+	// Entry is the entry of the document, or the existing entry for deletes.
+	Entry *api.ServiceIntentionsConfigEntry
+
+	// ModifyIndex is the index of the existing entry, 0 for creates.
+	ModifyIndex uint64
+
+	// Sources are the changes to the sources of the destination.
+	Sources []SourceChange
+}
+
+// SourceChange is a difference in the sources of a destination.
+type SourceChange struct {
+	Op     ChangeOp
+	Source string
+}
+
+// Diff returns the changes which turn the existing entries into the desired
+// ones, sorted by destination. Existing entries missing from desired are only
+// deleted if prune is set.
+func Diff(existing, desired []*api.ServiceIntentionsConfigEntry, prune bool) ([]Change, error) {
+	current := make(map[string]*api.ServiceIntentionsConfigEntry, len(existing))
+	for _, entry := range existing {
+		current[Key(entry)] = entry
+	}
+
+	var changes []Change
+	wanted := make(map[string]struct{}, len(desired))
+	for _, entry := range desired {
+		key := Key(entry)
+		wanted[key] = struct{}{}
+
+		old, ok := current[key]
+		if !ok {
+			var sources []SourceChange
+			for _, src := range entry.Sources {
+				sources = append(sources, SourceChange{Op: ChangeCreate, Source: SourceKey(src)})
+			}
+			sortSourceChanges(sources)
+			changes = append(changes, Change{Op: ChangeCreate, Entry: entry, Sources: sources})
+			continue
+		}
+
+		sources, changed, err := diffSources(old, entry)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, Change{Op: ChangeUpdate, Entry: entry, ModifyIndex: old.ModifyIndex, Sources: sources})
+		}
+	}
+
+	if prune {
+		for _, entry := range existing {
+			if _, ok := wanted[Key(entry)]; ok {
+				continue
+			}
+			var sources []SourceChange
+			for _, src := range entry.Sources {
+				sources = append(sources, SourceChange{Op: ChangeDelete, Source: SourceKey(src)})
+			}
+			sortSourceChanges(sources)
+			changes = append(changes, Change{Op: ChangeDelete, Entry: entry, ModifyIndex: entry.ModifyIndex, Sources: sources})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return Key(changes[i].Entry) < Key(changes[j].Entry) })
+	return changes, nil
+}
+
+// diffSources compares two entries of the same destination. It returns the
+// changes to their sources and whether the entries differ at all, including
+// outside of the sources.
+func diffSources(old, new *api.ServiceIntentionsConfigEntry) ([]SourceChange, bool, error) {
+	oldRaw, err := canonical(old)
+	if err != nil {
+		return nil, false, err
+	}
+	newRaw, err := canonical(new)
+	if err != nil {
+		return nil, false, err
+	}
+	oldJSON, err := json.Marshal(oldRaw)
+	if err != nil {
+		return nil, false, err
+	}
+	newJSON, err := json.Marshal(newRaw)
+	if err != nil {
+		return nil, false, err
+	}
+	if bytes.Equal(oldJSON, newJSON) {
+		return nil, false, nil
+	}
+
+	oldSources, err := sourcesByKey(old.Sources)
+	if err != nil {
+		return nil, false, err
+	}
+	newSources, err := sourcesByKey(new.Sources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var changes []SourceChange
+	for key, data := range newSources {
+		oldData, ok := oldSources[key]
+		switch {
+		case !ok:
+			changes = append(changes, SourceChange{Op: ChangeCreate, Source: key})
+		case oldData != data:
+			changes = append(changes, SourceChange{Op: ChangeUpdate, Source: key})
+		}
+	}
+	for key := range oldSources {
+		if _, ok := newSources[key]; !ok {
+			changes = append(changes, SourceChange{Op: ChangeDelete, Source: key})
+		}
+	}
+	sortSourceChanges(changes)
+	return changes, true, nil
+}
+
+// sourcesByKey returns the JSON encoding of the sources without the fields
+// set by the servers, keyed by SourceKey.
+func sourcesByKey(sources []*api.SourceIntention) (map[string]string, error) {
+	out := make(map[string]string, len(sources))
+	for _, src := range sources {
+		data, err := json.Marshal(src)
+		if err != nil {
+			return nil, err
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		for _, k := range serverSourceFields {
+			delete(raw, k)
+		}
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, err
+		}
+		out[SourceKey(src)] = string(data)
+	}
+	return out, nil
+}
+
+func sortSourceChanges(changes []SourceChange) {
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Source < changes[j].Source })
+}
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_\-]*$`)
+
+// writeHCLBody writes the generic JSON values of m as an HCL body, with maps
+// and lists of maps as blocks. Name and Kind come first, the other keys are
+// sorted.
+func writeHCLBody(buf *bytes.Buffer, m map[string]interface{}, depth int) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	rank := func(k string) int {
+		switch k {
+		case "Kind":
+			return 0
+		case "Name":
+			return 1
+		}
+		return 2
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if ri, rj := rank(keys[i]), rank(keys[j]); ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+
+	indent := strings.Repeat("  ", depth)
+	for _, k := range keys {
+		name := k
+		if !hclIdentifier.MatchString(k) {
+			name = strconv.Quote(k)
+		}
+
+		switch v := m[k].(type) {
+		case nil:
+		case map[string]interface{}:
+			fmt.Fprintf(buf, "%s%s {\n", indent, name)
+			writeHCLBody(buf, v, depth+1)
+			fmt.Fprintf(buf, "%s}\n", indent)
+		case []interface{}:
+			if isBlockList(v) {
+				for _, item := range v {
+					fmt.Fprintf(buf, "%s%s {\n", indent, name)
+					writeHCLBody(buf, item.(map[string]interface{}), depth+1)
+					fmt.Fprintf(buf, "%s}\n", indent)
+				}
+				continue
+			}
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, hclValue(item))
+			}
+			fmt.Fprintf(buf, "%s%s = [%s]\n", indent, name, strings.Join(items, ", "))
+		default:
+			fmt.Fprintf(buf, "%s%s = %s\n", indent, name, hclValue(v))
+		}
+	}
+}
+
+func isBlockList(list []interface{}) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func hclValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
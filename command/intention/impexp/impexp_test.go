@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package impexp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func testEntries() []*api.ServiceIntentionsConfigEntry {
+	return []*api.ServiceIntentionsConfigEntry{
+		{
+			Kind: api.ServiceIntentions,
+			Name: "web",
+			Sources: []*api.SourceIntention{
+				{Name: "*", Action: api.IntentionActionDeny},
+			},
+		},
+		{
+			Kind: api.ServiceIntentions,
+			Name: "db",
+			Meta: map[string]string{"owner": "team-db"},
+			Sources: []*api.SourceIntention{
+				{Name: "web", Action: api.IntentionActionAllow, Description: "web reads \"db\""},
+				{
+					Name: "api",
+					Permissions: []*api.IntentionPermission{
+						{
+							Action: api.IntentionActionAllow,
+							HTTP: &api.IntentionHTTPPermission{
+								PathPrefix: "/v1",
+								Methods:    []string{"GET", "HEAD"},
+								Header: []api.IntentionHTTPHeaderPermission{
+									{Name: "x-env", Exact: "prod"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		format string
+		data   string
+	}{
+		"hcl": {
+			format: FormatHCL,
+			data: `
+				Intentions {
+					Name = "db"
+					Sources {
+						Name   = "web"
+						Action = "allow"
+					}
+					Sources {
+						Name   = "api"
+						Action = "deny"
+					}
+				}
+				Intentions {
+					Name = "web"
+				}
+			`,
+		},
+		"json": {
+			format: FormatJSON,
+			data: `{"Intentions": [
+				{"Name": "db", "Sources": [{"Name": "web", "Action": "allow"}, {"Name": "api", "Action": "deny"}]},
+				{"Kind": "service-intentions", "Name": "web"}
+			]}`,
+		},
+		"yaml": {
+			format: FormatYAML,
+			data: `
+Intentions:
+- Name: db
+  Sources:
+  - Name: web
+    Action: allow
+  - Name: api
+    Action: deny
+- Name: web
+`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			entries, err := Parse([]byte(tc.data), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, []*api.ServiceIntentionsConfigEntry{
+				{
+					Kind: api.ServiceIntentions,
+					Name: "db",
+					Sources: []*api.SourceIntention{
+						{Name: "web", Action: api.IntentionActionAllow},
+						{Name: "api", Action: api.IntentionActionDeny},
+					},
+				},
+				{Kind: api.ServiceIntentions, Name: "web"},
+			}, entries)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]struct {
+		data string
+		err  string
+	}{
+		"other kind": {
+			data: `Intentions { Kind = "service-defaults" Name = "web" }`,
+			err:  "is not of kind service-intentions",
+		},
+		"unknown key": {
+			data: `Intention { Name = "web" }`,
+			err:  `invalid config key "Intention"`,
+		},
+		"missing name": {
+			data: `Intentions { Sources { Name = "web" Action = "allow" } }`,
+			err:  "Name is required",
+		},
+		"duplicate destination": {
+			data: `Intentions { Name = "db" } Intentions { Name = "db" }`,
+			err:  `destination "default/default/db" is defined more than once`,
+		},
+		"duplicate source": {
+			data: `Intentions {
+				Name = "db"
+				Sources { Name = "web" Action = "allow" }
+				Sources { Name = "web" Action = "deny" }
+			}`,
+			err: `source "default/default/web" is defined more than once`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.data), FormatHCL)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	entries := testEntries()
+	// The fields set by the servers are left out.
+	entries[0].ModifyIndex = 10
+	entries[0].Sources[0].Precedence = 5
+	entries[0].Sources[0].Type = api.IntentionSourceConsul
+
+	for _, format := range []string{FormatHCL, FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			data, err := Format(entries, format)
+			require.NoError(t, err)
+			require.NotContains(t, string(data), "Precedence")
+			require.NotContains(t, string(data), "ModifyIndex")
+
+			parsed, err := Parse(data, format)
+			require.NoError(t, err)
+			changes, err := Diff(entries, parsed, true)
+			require.NoError(t, err)
+			require.Empty(t, changes, string(data))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	existing := testEntries()
+	existing[0].ModifyIndex = 10
+	existing[1].ModifyIndex = 11
+	existing = append(existing, &api.ServiceIntentionsConfigEntry{
+		Kind:        api.ServiceIntentions,
+		Name:        "cache",
+		ModifyIndex: 12,
+		Sources:     []*api.SourceIntention{{Name: "web", Action: api.IntentionActionAllow}},
+	})
+
+	desired := testEntries()
+	desired[1].Sources[0].Action = api.IntentionActionDeny
+	desired[1].Sources[1] = &api.SourceIntention{Name: "billing", Action: api.IntentionActionAllow}
+	desired = append(desired, &api.ServiceIntentionsConfigEntry{
+		Kind:    api.ServiceIntentions,
+		Name:    "payments",
+		Sources: []*api.SourceIntention{{Name: "billing", Action: api.IntentionActionAllow}},
+	})
+
+	changes, err := Diff(existing, desired, false)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{
+			Op:          ChangeUpdate,
+			Entry:       desired[1],
+			ModifyIndex: 11,
+			Sources: []SourceChange{
+				{Op: ChangeDelete, Source: "default/default/api"},
+				{Op: ChangeCreate, Source: "default/default/billing"},
+				{Op: ChangeUpdate, Source: "default/default/web"},
+			},
+		},
+		{
+			Op:    ChangeCreate,
+			Entry: desired[2],
+			Sources: []SourceChange{
+				{Op: ChangeCreate, Source: "default/default/billing"},
+			},
+		},
+	}, changes)
+
+	// Pruning deletes the destinations missing from the document.
+	changes, err = Diff(existing, desired, true)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Equal(t, Change{
+		Op:          ChangeDelete,
+		Entry:       existing[2],
+		ModifyIndex: 12,
+		Sources:     []SourceChange{{Op: ChangeDelete, Source: "default/default/web"}},
+	}, changes[0])
+}
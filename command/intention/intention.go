@@ -51,5 +51,10 @@ Usage: consul intention <subcommand> [options] [args]
 
       $ consul intention match db
 
+  Export all intentions to a file, then apply the edited file:
+
+      $ consul intention export > intentions.hcl
+      $ consul intention import intentions.hcl
+
   For more examples, ask for subcommand help or view the documentation.
 `
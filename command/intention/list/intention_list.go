@@ -6,7 +6,9 @@ package list
 import (
 	"flag"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
 	"github.com/mitchellh/cli"
 	"github.com/ryanuber/columnize"
@@ -23,10 +25,17 @@ type cmd struct {
 	flags *flag.FlagSet
 	http  *flags.HTTPFlags
 	help  string
+
+	// flags
+	expiringWithin time.Duration
 }
 
 func (c *cmd) init() {
 	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.DurationVar(&c.expiringWithin, "expiring-within", 0,
+		"Only list the intentions which have to be reviewed, or expire, within "+
+			"this duration, including the ones already overdue for review. The "+
+			"output then includes the review and expiry times.")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -46,7 +55,12 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
-	ixns, _, err := client.Connect().Intentions(nil)
+	var ixns []*api.Intention
+	if c.expiringWithin > 0 {
+		ixns, _, err = client.Connect().IntentionsExpiring(c.expiringWithin, nil)
+	} else {
+		ixns, _, err = client.Connect().Intentions(nil)
+	}
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("Failed to retrieve the intentions list: %s", err))
 		return 1
@@ -59,10 +73,16 @@ func (c *cmd) Run(args []string) int {
 
 	result := make([]string, 0, len(ixns))
 	header := "ID\x1fSource\x1fAction\x1fDestination\x1fPrecedence"
+	if c.expiringWithin > 0 {
+		header += "\x1fReview By\x1fExpires At"
+	}
 	result = append(result, header)
 	for _, ixn := range ixns {
 		line := fmt.Sprintf("%s\x1f%s\x1f%s\x1f%s\x1f%d",
 			ixn.ID, ixn.SourceName, ixn.Action, ixn.DestinationName, ixn.Precedence)
+		if c.expiringWithin > 0 {
+			line += fmt.Sprintf("\x1f%s\x1f%s", formatTime(ixn.ReviewBy), formatTime(ixn.ExpiresAt))
+		}
 		result = append(result, line)
 	}
 
@@ -72,6 +92,13 @@ func (c *cmd) Run(args []string) int {
 	return 0
 }
 
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
 func (c *cmd) Synopsis() string {
 	return synopsis
 }
@@ -83,8 +110,12 @@ func (c *cmd) Help() string {
 const (
 	synopsis = "List intentions."
 	help     = `
-Usage: consul intention list
+Usage: consul intention list [options]
 
   List all intentions.
+
+  List the intentions which have to be reviewed, or expire, within a week:
+
+      $ consul intention list -expiring-within=168h
 `
 )
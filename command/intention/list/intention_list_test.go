@@ -6,6 +6,7 @@ package list
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/require"
@@ -53,3 +54,34 @@ func TestIntentionListCommand(t *testing.T) {
 	require.Equal(t, 0, cmd.Run(args), ui.ErrorWriter.String())
 	require.Contains(t, ui.OutputWriter.String(), id)
 }
+
+func TestIntentionListCommand_expiringWithin(t *testing.T) {
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	soon := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	retry.Run(t, func(r *retry.R) {
+		_, _, err := client.ConfigEntries().Set(&api.ServiceIntentionsConfigEntry{
+			Kind: api.ServiceIntentions,
+			Name: "db",
+			Sources: []*api.SourceIntention{
+				{Name: "web", Action: api.IntentionActionAllow, ExpiresAt: &soon},
+				{Name: "api", Action: api.IntentionActionAllow},
+			},
+		}, nil)
+		require.NoError(r, err)
+	})
+
+	ui := cli.NewMockUi()
+	cmd := New(ui)
+	args := []string{"-http-addr=" + a.HTTPAddr(), "-expiring-within=24h"}
+
+	require.Equal(t, 0, cmd.Run(args), ui.ErrorWriter.String())
+	output := ui.OutputWriter.String()
+	require.Contains(t, output, "Expires At")
+	require.Contains(t, output, soon.Format(time.RFC3339))
+	require.Contains(t, output, "web")
+	require.NotContains(t, output, "api")
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package rotate
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	// flags
+	auto         bool
+	key          string
+	timeout      time.Duration
+	pollInterval time.Duration
+	keepOldKeys  bool
+	relay        int
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.BoolVar(&c.auto, "auto", false,
+		"Run all the steps of the rotation: install the new key, make it the "+
+			"primary key and remove the previous keys, verifying that each step "+
+			"reached all members before starting the next one. Required.")
+	c.flags.StringVar(&c.key, "key", "",
+		"The new encryption key. A new 32-byte key is generated if not set.")
+	c.flags.DurationVar(&c.timeout, "timeout", 5*time.Minute,
+		"The longest time to wait for each step to reach all members.")
+	c.flags.DurationVar(&c.pollInterval, "poll-interval", 2*time.Second,
+		"The time between checks of the keyrings while waiting for a step to "+
+			"reach all members.")
+	c.flags.BoolVar(&c.keepOldKeys, "keep-old-keys", false,
+		"Keep the previous keys installed once the new key is the primary key.")
+	c.flags.IntVar(&c.relay, "relay-factor", 0,
+		"Setting this to a non-zero value will cause nodes to relay their response "+
+			"to the operation through this many randomly-chosen other nodes in the "+
+			"cluster. The maximum allowed value is 5.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if !c.auto {
+		c.UI.Error("The -auto flag is required. Use the -install, -use and -remove " +
+			"flags of \"consul keyring\" to rotate keys step by step instead.")
+		return 1
+	}
+
+	relayFactor, err := agent.ParseRelayFactor(c.relay)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error parsing relay factor: %s", err))
+		return 1
+	}
+
+	key := c.key
+	if key == "" {
+		key, err = generateKey()
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error generating a new key: %s", err))
+			return 1
+		}
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error connecting to Consul agent: %s", err))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("==> Rotating gossip encryption key to %s", key))
+	opts := &api.KeyringRotateOptions{
+		Key:          key,
+		Timeout:      c.timeout,
+		PollInterval: c.pollInterval,
+		KeepOldKeys:  c.keepOldKeys,
+		Progress: func(p api.KeyringRotateProgress) {
+			c.UI.Info(fmt.Sprintf("    [%s] %s", p.Step, p.Message))
+		},
+	}
+	result, err := client.Operator().KeyringRotate(opts, &api.WriteOptions{RelayFactor: relayFactor})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error rotating gossip encryption key: %s", err))
+		return 1
+	}
+
+	c.UI.Info(fmt.Sprintf("==> Gossip encryption key rotated, the primary key is %s", result.PrimaryKey))
+	for _, old := range result.RemovedKeys {
+		c.UI.Info(fmt.Sprintf("    Removed key %s", old))
+	}
+	return 0
+}
+
+// generateKey returns a new 32-byte key in the format the agent expects.
+func generateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Rotates the gossip encryption key"
+const help = `
+Usage: consul keyring rotate -auto [options]
+
+  Rotates the gossip encryption key of the cluster. The new key is installed on
+  all members, made the primary key once every member has it, and the previous
+  keys are removed once every member uses it. Each step is verified on all
+  members of the LAN and WAN keyrings before the next one starts, so a member
+  that misses an update stops the rotation before a key it still relies on is
+  removed.
+
+  Rotate to a newly generated key:
+
+      $ consul keyring rotate -auto
+
+  Rotate to a given key, keeping the previous keys installed:
+
+      $ consul keyring rotate -auto -key=HS5lJ+XuTlYKWaeGYyG+/A== -keep-old-keys
+
+  Rotation must be performed against server nodes. The exit code is 1 if any
+  step fails or does not reach all members within the timeout; the rotation
+  can be resumed by running the command again with the same key.
+`
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package rotate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+)
+
+func TestKeyringRotateCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(nil).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestKeyringRotateCommand_requiresAuto(t *testing.T) {
+	t.Parallel()
+	ui := cli.NewMockUi()
+	c := New(ui)
+	require.Equal(t, 1, c.Run([]string{"-key=kZyFABeAmc64UMTrm9XuKA=="}))
+	require.Contains(t, ui.ErrorWriter.String(), "The -auto flag is required")
+}
+
+func TestKeyringRotateCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	key1 := "HS5lJ+XuTlYKWaeGYyG+/A=="
+	key2 := "kZyFABeAmc64UMTrm9XuKA=="
+
+	a := agent.NewTestAgent(t, `
+		encrypt = "`+key1+`"
+	`)
+	defer a.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-auto",
+		"-key=" + key2,
+		"-poll-interval=50ms",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "[install] Verified on all members")
+	require.Contains(t, out, "[use] Verified on all members")
+	require.Contains(t, out, "[remove] Removing key "+key1)
+	require.Contains(t, out, "the primary key is "+key2)
+
+	responses, err := a.Client().Operator().KeyringList(nil)
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	for _, resp := range responses {
+		require.Equal(t, map[string]int{key2: 1}, resp.Keys)
+		require.Equal(t, map[string]int{key2: 1}, resp.PrimaryKeys)
+	}
+}
+
+func TestKeyringRotateCommand_keepOldKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	key1 := "HS5lJ+XuTlYKWaeGYyG+/A=="
+
+	a := agent.NewTestAgent(t, `
+		encrypt = "`+key1+`"
+	`)
+	defer a.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	code := c.Run([]string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-auto",
+		"-keep-old-keys",
+		"-poll-interval=50ms",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	responses, err := a.Client().Operator().KeyringList(nil)
+	require.NoError(t, err)
+	for _, resp := range responses {
+		require.Len(t, resp.Keys, 2)
+		require.Contains(t, resp.Keys, key1)
+		require.Len(t, resp.PrimaryKeys, 1)
+		require.NotContains(t, resp.PrimaryKeys, key1)
+	}
+}
@@ -6,6 +6,7 @@ package get
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -13,9 +14,15 @@ import (
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/helpers"
 	"github.com/mitchellh/cli"
 )
 
+const (
+	formatText      = "text"
+	formatJSONLines = "json-lines"
+)
+
 func New(ui cli.Ui) *cmd {
 	c := &cmd{UI: ui}
 	c.init()
@@ -32,6 +39,11 @@ type cmd struct {
 	keys         bool
 	recurse      bool
 	separator    string
+	watch        bool
+	format       string
+
+	// shutdownCh stops the watch, it defaults to the interrupt signals.
+	shutdownCh <-chan struct{}
 }
 
 func (c *cmd) init() {
@@ -53,6 +65,12 @@ func (c *cmd) init() {
 	c.flags.StringVar(&c.separator, "separator", "/",
 		"String to use as a separator between keys. The default value is \"/\", "+
 			"but this option is only taken into account when paired with the -keys flag.")
+	c.flags.BoolVar(&c.watch, "watch", false,
+		"Watch the key or prefix with blocking queries, outputting the result "+
+			"again each time it changes, until the command is interrupted.")
+	c.flags.StringVar(&c.format, "format", formatText,
+		"Output format, either \"text\" or \"json-lines\". With \"json-lines\", "+
+			"each result is output as JSON on a single line.")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -94,6 +112,11 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	if c.format != formatText && c.format != formatJSONLines {
+		c.UI.Error(fmt.Sprintf("Invalid format %q, must be %q or %q", c.format, formatText, formatJSONLines))
+		return 1
+	}
+
 	// Create and test the HTTP client
 	client, err := c.http.APIClient()
 	if err != nil {
@@ -101,22 +124,81 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
-	switch {
-	case c.keys && c.recurse:
-		pairs, _, err := client.KV().List(key, &api.QueryOptions{
-			AllowStale: c.http.Stale(),
+	opts := &api.QueryOptions{
+		AllowStale: c.http.Stale(),
+	}
+
+	if c.watch {
+		ctx, cancel := helpers.ShutdownContext(c.shutdownCh)
+		defer cancel()
+
+		var result interface{}
+		err := helpers.WatchQuery(ctx, opts, func(q *api.QueryOptions) (*api.QueryMeta, error) {
+			var meta *api.QueryMeta
+			var err error
+			result, meta, err = c.query(client, key, q)
+			return meta, err
+		}, func() error {
+			return c.output(key, result)
 		})
 		if err != nil {
 			c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
 			return 1
 		}
+		return 0
+	}
+
+	result, _, err := c.query(client, key, opts)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
+		return 1
+	}
+	if err := c.output(key, result); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// query returns the keys, the pairs or the pair requested by the flags.
+func (c *cmd) query(client *api.Client, key string, q *api.QueryOptions) (interface{}, *api.QueryMeta, error) {
+	switch {
+	case c.recurse:
+		return client.KV().List(key, q)
+	case c.keys:
+		return client.KV().Keys(key, c.separator, q)
+	default:
+		return client.KV().Get(key, q)
+	}
+}
 
+// output renders the result of query. When watching, a missing key is
+// reported without stopping the watch.
+func (c *cmd) output(key string, result interface{}) error {
+	if c.format == formatJSONLines {
+		if c.keys && c.recurse {
+			var keys []string
+			for _, pair := range result.(api.KVPairs) {
+				keys = append(keys, pair.Key)
+			}
+			result = keys
+		}
+		b, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("Error encoding the output: %s", err)
+		}
+		c.UI.Output(string(b))
+		return nil
+	}
+
+	switch {
+	case c.keys && c.recurse:
+		pairs := result.(api.KVPairs)
 		for i, pair := range pairs {
 			if c.detailed {
 				var b bytes.Buffer
 				if err := prettyKVPair(&b, pair, false, true); err != nil {
-					c.UI.Error(fmt.Sprintf("Error rendering KV key: %s", err))
-					return 1
+					return fmt.Errorf("Error rendering KV key: %s", err)
 				}
 				c.UI.Info(b.String())
 
@@ -127,36 +209,17 @@ func (c *cmd) Run(args []string) int {
 				c.UI.Info(fmt.Sprintf("%s", pair.Key))
 			}
 		}
-		return 0
 	case c.keys:
-		keys, _, err := client.KV().Keys(key, c.separator, &api.QueryOptions{
-			AllowStale: c.http.Stale(),
-		})
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
-			return 1
-		}
-
-		for _, k := range keys {
+		for _, k := range result.([]string) {
 			c.UI.Info(k)
 		}
-
-		return 0
 	case c.recurse:
-		pairs, _, err := client.KV().List(key, &api.QueryOptions{
-			AllowStale: c.http.Stale(),
-		})
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
-			return 1
-		}
-
+		pairs := result.(api.KVPairs)
 		for i, pair := range pairs {
 			if c.detailed {
 				var b bytes.Buffer
 				if err := prettyKVPair(&b, pair, c.base64encode, false); err != nil {
-					c.UI.Error(fmt.Sprintf("Error rendering KV pair: %s", err))
-					return 1
+					return fmt.Errorf("Error rendering KV pair: %s", err)
 				}
 
 				c.UI.Info(b.String())
@@ -172,31 +235,24 @@ func (c *cmd) Run(args []string) int {
 				}
 			}
 		}
-
-		return 0
 	default:
-		pair, _, err := client.KV().Get(key, &api.QueryOptions{
-			AllowStale: c.http.Stale(),
-		})
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error querying Consul agent: %s", err))
-			return 1
-		}
-
+		pair := result.(*api.KVPair)
 		if pair == nil {
-			c.UI.Error(fmt.Sprintf("Error! No key exists at: %s", key))
-			return 1
+			if c.watch {
+				c.UI.Error(fmt.Sprintf("No key exists at: %s", key))
+				return nil
+			}
+			return fmt.Errorf("Error! No key exists at: %s", key)
 		}
 
 		if c.detailed {
 			var b bytes.Buffer
 			if err := prettyKVPair(&b, pair, c.base64encode, false); err != nil {
-				c.UI.Error(fmt.Sprintf("Error rendering KV pair: %s", err))
-				return 1
+				return fmt.Errorf("Error rendering KV pair: %s", err)
 			}
 
 			c.UI.Info(b.String())
-			return 0
+			return nil
 		}
 
 		if c.base64encode {
@@ -204,8 +260,8 @@ func (c *cmd) Run(args []string) int {
 		} else {
 			c.UI.Info(string(pair.Value))
 		}
-		return 0
 	}
+	return nil
 }
 
 func (c *cmd) Synopsis() string {
@@ -273,6 +329,12 @@ Usage: consul kv get [options] [KEY_OR_PREFIX]
 
       $ consul kv get -keys foo
 
+  To output the value again each time it changes, specify the "-watch" flag,
+  optionally with "-format=json-lines" to output each result as JSON on a
+  single line:
+
+      $ consul kv get -watch -format=json-lines foo
+
   For a full list of options and examples, please see the Consul documentation.
 `
 )
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/consul/agent"
 	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 	"github.com/mitchellh/cli"
 )
 
@@ -37,6 +38,10 @@ func TestKVGetCommand_Validation(t *testing.T) {
 			[]string{"foo", "bar", "baz"},
 			"Too many arguments",
 		},
+		"invalid format": {
+			[]string{"-format", "yaml", "foo"},
+			"Invalid format",
+		},
 	}
 
 	for name, tc := range cases {
@@ -98,6 +103,68 @@ func TestKVGetCommand(t *testing.T) {
 	}
 }
 
+func TestKVGetCommand_Watch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	ui := cli.NewMockUi()
+	c := New(ui)
+	shutdownCh := make(chan struct{})
+	c.shutdownCh = shutdownCh
+
+	put := func(value string) {
+		_, err := client.KV().Put(&api.KVPair{Key: "foo", Value: []byte(value)}, nil)
+		if err != nil {
+			t.Fatalf("err: %#v", err)
+		}
+	}
+	put("bar")
+
+	args := []string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-watch",
+		"-format=json-lines",
+		"foo",
+	}
+
+	codeCh := make(chan int, 1)
+	go func() {
+		codeCh <- c.Run(args)
+	}()
+
+	// Each version of the pair is output as JSON on its own line.
+	waitForLines := func(values ...string) {
+		t.Helper()
+		retry.Run(t, func(r *retry.R) {
+			lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+			if len(lines) != len(values) {
+				r.Fatalf("expected %d lines, got %#v", len(values), lines)
+			}
+			for i, value := range values {
+				encoded := base64.StdEncoding.EncodeToString([]byte(value))
+				if !strings.Contains(lines[i], `"Key":"foo"`) || !strings.Contains(lines[i], encoded) {
+					r.Fatalf("bad line %d: %s", i, lines[i])
+				}
+			}
+		})
+	}
+	waitForLines("bar")
+
+	put("baz")
+	waitForLines("bar", "baz")
+
+	close(shutdownCh)
+	if code := <-codeCh; code != 0 {
+		t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+	}
+}
+
 func TestKVGetCommand_Base64(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
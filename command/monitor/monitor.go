@@ -27,8 +27,10 @@ type cmd struct {
 	quitting bool
 
 	// flags
-	logLevel string
-	logJSON  bool
+	logLevel  string
+	logJSON   bool
+	subsystem string
+	recent    bool
 }
 
 func New(ui cli.Ui, shutdownCh <-chan struct{}) *cmd {
@@ -43,6 +45,11 @@ func (c *cmd) init() {
 		"Log level of the agent.")
 	c.flags.BoolVar(&c.logJSON, "log-json", false,
 		"Output logs in JSON format.")
+	c.flags.StringVar(&c.subsystem, "subsystem", "",
+		"Only output the logs of a subsystem of the agent, such as \"dns\", "+
+			"\"raft\", \"xds\" or \"controller/<name>\".")
+	c.flags.BoolVar(&c.recent, "recent", false,
+		"Output the recent logs kept by the agent before streaming new logs.")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -65,18 +72,20 @@ func (c *cmd) Run(args []string) int {
 	}
 
 	eventDoneCh := make(chan struct{})
-	if c.logJSON {
-		logCh, err = client.Agent().MonitorJSON(c.logLevel, eventDoneCh, nil)
-		if err != nil {
+	opts := &api.MonitorOptions{
+		LogLevel:  c.logLevel,
+		LogJSON:   c.logJSON,
+		Subsystem: c.subsystem,
+		Recent:    c.recent,
+	}
+	logCh, err = client.Agent().MonitorWithOptions(opts, eventDoneCh, nil)
+	if err != nil {
+		if c.logJSON {
 			c.UI.Error(fmt.Sprintf("Error starting JSON monitor: %s", err))
-			return 1
-		}
-	} else {
-		logCh, err = client.Agent().Monitor(c.logLevel, eventDoneCh, nil)
-		if err != nil {
+		} else {
 			c.UI.Error(fmt.Sprintf("Error starting monitor: %s", err))
-			return 1
 		}
+		return 1
 	}
 
 	go func() {
@@ -130,4 +139,9 @@ Usage: consul monitor [options]
   listen for log levels that may be filtered out of the Consul agent. For
   example your agent may only be logging at INFO level, but with the monitor
   you can see the DEBUG level logs.
+
+  Stream the DEBUG logs of the DNS server, starting with the recent logs the
+  agent kept:
+
+      $ consul monitor -log-level debug -subsystem dns -recent
 `
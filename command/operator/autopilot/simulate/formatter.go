@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	PrettyFormat string = "pretty"
+	JSONFormat   string = "json"
+)
+
+// Formatter defines methods provided by a simulation output formatter
+type Formatter interface {
+	FormatSimulation(sim *Simulation) (string, error)
+}
+
+// GetSupportedFormats returns supported formats
+func GetSupportedFormats() []string {
+	return []string{PrettyFormat, JSONFormat}
+}
+
+// NewFormatter returns Formatter implementation
+func NewFormatter(format string) (formatter Formatter, err error) {
+	switch format {
+	case PrettyFormat:
+		formatter = newPrettyFormatter()
+	case JSONFormat:
+		formatter = newJSONFormatter()
+	default:
+		err = fmt.Errorf("Unknown format: %s", format)
+	}
+
+	return formatter, err
+}
+
+func newPrettyFormatter() Formatter {
+	return &prettyFormatter{}
+}
+
+type prettyFormatter struct {
+}
+
+func outputStringSlice(buffer *bytes.Buffer, indent string, values []string) {
+	for _, val := range values {
+		buffer.WriteString(fmt.Sprintf("%s%s\n", indent, val))
+	}
+}
+
+func (f *prettyFormatter) FormatSimulation(sim *Simulation) (string, error) {
+	var buffer bytes.Buffer
+
+	cur, prop := sim.Current, sim.Proposed
+	buffer.WriteString("                   Current  Proposed\n")
+	buffer.WriteString(fmt.Sprintf("Voters:            %-8d %d\n", len(cur.Voters), len(prop.Voters)))
+	buffer.WriteString(fmt.Sprintf("Quorum Size:       %-8d %d\n", cur.QuorumSize, prop.QuorumSize))
+	buffer.WriteString(fmt.Sprintf("Failure Tolerance: %-8d %d\n", cur.FailureTolerance, prop.FailureTolerance))
+
+	buffer.WriteString("Proposed Voters:\n")
+	outputStringSlice(&buffer, "   ", prop.Voters)
+	if len(prop.NonVoters) > 0 {
+		buffer.WriteString("Proposed Non-Voters:\n")
+		outputStringSlice(&buffer, "   ", prop.NonVoters)
+	}
+	if len(prop.ReadReplicas) > 0 {
+		buffer.WriteString("Proposed Read Replicas:\n")
+		outputStringSlice(&buffer, "   ", prop.ReadReplicas)
+	}
+
+	if len(prop.RedundancyZones) > 0 {
+		var names []string
+		for name := range prop.RedundancyZones {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		buffer.WriteString("Proposed Redundancy Zones:\n")
+		for _, name := range names {
+			zone := prop.RedundancyZones[name]
+			buffer.WriteString(fmt.Sprintf("   %s:\n", name))
+			buffer.WriteString(fmt.Sprintf("      Failure Tolerance: %d\n", zone.FailureTolerance))
+			buffer.WriteString("      Voters:\n")
+			outputStringSlice(&buffer, "         ", zone.Voters)
+			buffer.WriteString("      Servers:\n")
+			outputStringSlice(&buffer, "         ", zone.Servers)
+		}
+	}
+
+	if len(sim.Warnings) > 0 {
+		buffer.WriteString("Warnings:\n")
+		outputStringSlice(&buffer, "   ", sim.Warnings)
+	}
+
+	return buffer.String(), nil
+}
+
+func newJSONFormatter() Formatter {
+	return &jsonFormatter{}
+}
+
+type jsonFormatter struct {
+}
+
+func (f *jsonFormatter) FormatSimulation(sim *Simulation) (string, error) {
+	b, err := json.MarshalIndent(sim, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal simulation: %v", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	format string
+	remove flags.AppendSliceValue
+	add    flags.AppendSliceValue
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.Var(&c.remove, "remove-server",
+		"The ID, name or address of a server to remove. This flag can be "+
+			"specified multiple times.")
+	c.flags.Var(&c.add, "add-server",
+		"A server to add, in the form <name>[:<redundancy zone>]. This flag "+
+			"can be specified multiple times.")
+	c.flags.StringVar(
+		&c.format,
+		"format",
+		PrettyFormat,
+		fmt.Sprintf("Output format {%s}", strings.Join(GetSupportedFormats(), "|")),
+	)
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	if len(c.remove) == 0 && len(c.add) == 0 {
+		c.UI.Error("At least one of -remove-server or -add-server must be specified")
+		return 1
+	}
+
+	change := Change{Remove: c.remove}
+	for _, value := range c.add {
+		srv, err := ParseServer(value)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Invalid -add-server: %s", err))
+			return 1
+		}
+		change.Add = append(change.Add, srv)
+	}
+
+	formatter, err := NewFormatter(c.format)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch the current state.
+	opts := &api.QueryOptions{
+		AllowStale: c.http.Stale(),
+	}
+	state, err := client.Operator().AutopilotState(opts)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Autopilot state: %s", err))
+		return 1
+	}
+
+	sim, err := Simulate(state, change)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error simulating change: %s", err))
+		return 1
+	}
+
+	out, err := formatter.FormatSimulation(sim)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if out != "" {
+		c.UI.Info(out)
+	}
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Simulate the effect of adding or removing servers"
+const help = `
+Usage: consul operator autopilot simulate [options]
+
+  Reports the voter layout, quorum size and failure tolerance that Autopilot
+  would arrive at after adding or removing servers, without making any change.
+
+  Simulate removing a server:
+
+      $ consul operator autopilot simulate -remove-server=consul-server-3
+
+  Simulate adding a server to the redundancy zone "us-east-1c":
+
+      $ consul operator autopilot simulate -add-server=consul-server-4:us-east-1c
+`
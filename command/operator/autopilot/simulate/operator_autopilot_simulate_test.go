@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/testrpc"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateCommand_noTabs(t *testing.T) {
+	t.Parallel()
+
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestSimulateCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, `node_name = "server1"`)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	t.Run("no change", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr()})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "At least one of -remove-server or -add-server")
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), "-remove-server=nope"})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), `server "nope" not found`)
+	})
+
+	t.Run("add servers", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-add-server=server2",
+			"-add-server=server3",
+			"-format=json",
+		})
+		require.Empty(t, ui.ErrorWriter.String())
+		require.Equal(t, 0, code)
+
+		var sim Simulation
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &sim))
+		require.Equal(t, []string{"server1"}, sim.Current.Voters)
+		require.Equal(t, []string{"server1", "server2", "server3"}, sim.Proposed.Voters)
+		require.Equal(t, 2, sim.Proposed.QuorumSize)
+		require.Equal(t, 1, sim.Proposed.FailureTolerance)
+	})
+}
+
+func testState() *api.AutopilotState {
+	server := func(id, name, zone string, status api.AutopilotServerStatus) api.AutopilotServer {
+		return api.AutopilotServer{
+			ID:             id,
+			Name:           name,
+			Address:        "198.18.0." + id + ":8300",
+			RedundancyZone: zone,
+			Status:         status,
+			Healthy:        true,
+		}
+	}
+	return &api.AutopilotState{
+		Leader: "1",
+		Servers: map[string]api.AutopilotServer{
+			"1": server("1", "node1", "", api.AutopilotServerLeader),
+			"2": server("2", "node2", "", api.AutopilotServerVoter),
+			"3": server("3", "node3", "", api.AutopilotServerVoter),
+		},
+	}
+}
+
+func testZonedState() *api.AutopilotState {
+	state := testState()
+	for id, zone := range map[string]string{"1": "zone1", "2": "zone2", "3": "zone3"} {
+		srv := state.Servers[id]
+		srv.RedundancyZone = zone
+		state.Servers[id] = srv
+	}
+	state.Servers["4"] = api.AutopilotServer{
+		ID:             "4",
+		Name:           "node4",
+		Address:        "198.18.0.4:8300",
+		RedundancyZone: "zone1",
+		Status:         api.AutopilotServerNonVoter,
+		Healthy:        true,
+	}
+	state.RedundancyZones = map[string]api.AutopilotZone{
+		"zone1": {Servers: []string{"1", "4"}, Voters: []string{"1"}, FailureTolerance: 1},
+		"zone2": {Servers: []string{"2"}, Voters: []string{"2"}},
+		"zone3": {Servers: []string{"3"}, Voters: []string{"3"}},
+	}
+	return state
+}
+
+func TestSimulate(t *testing.T) {
+	type testCase struct {
+		state    *api.AutopilotState
+		change   Change
+		voters   []string
+		quorum   int
+		ft       int
+		warnings []string
+		err      string
+	}
+
+	run := func(t *testing.T, tc testCase) {
+		sim, err := Simulate(tc.state, tc.change)
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.voters, sim.Proposed.Voters)
+		require.Equal(t, tc.quorum, sim.Proposed.QuorumSize)
+		require.Equal(t, tc.ft, sim.Proposed.FailureTolerance)
+		require.Equal(t, tc.warnings, sim.Warnings)
+	}
+
+	tcs := map[string]testCase{
+		"remove by name": {
+			state:  testState(),
+			change: Change{Remove: []string{"node3"}},
+			voters: []string{"node1", "node2"},
+			quorum: 2,
+			ft:     0,
+			warnings: []string{
+				"The failure tolerance decreases from 1 to 0.",
+				"The proposed layout has an even number of voters (2), which tolerates no more failures than one voter fewer.",
+			},
+		},
+		"remove leader by address": {
+			state:  testState(),
+			change: Change{Remove: []string{"198.18.0.1"}},
+			voters: []string{"node2", "node3"},
+			quorum: 2,
+			ft:     0,
+			warnings: []string{
+				"The failure tolerance decreases from 1 to 0.",
+				"The leader node1 will be removed and a new leader will be elected.",
+				"The proposed layout has an even number of voters (2), which tolerates no more failures than one voter fewer.",
+			},
+		},
+		"add servers": {
+			state:  testState(),
+			change: Change{Add: []Server{{Name: "node4", Healthy: true}, {Name: "node5", Healthy: true}}},
+			voters: []string{"node1", "node2", "node3", "node4", "node5"},
+			quorum: 3,
+			ft:     2,
+		},
+		"add existing server": {
+			state:  testState(),
+			change: Change{Add: []Server{{Name: "node2"}}},
+			err:    `server "node2" already exists`,
+		},
+		"add to zone without zones": {
+			state:  testState(),
+			change: Change{Add: []Server{{Name: "node4", RedundancyZone: "zone1"}}},
+			err:    `cannot add server "node4" to redundancy zone "zone1": redundancy zones are not in use`,
+		},
+		"remove unknown server": {
+			state:  testState(),
+			change: Change{Remove: []string{"node9"}},
+			err:    `server "node9" not found`,
+		},
+		"remove zone voter promotes standby": {
+			state:  testZonedState(),
+			change: Change{Remove: []string{"node1"}},
+			voters: []string{"node2", "node3", "node4"},
+			quorum: 2,
+			ft:     1,
+			warnings: []string{
+				"The leader node1 will be removed and a new leader will be elected.",
+			},
+		},
+		"add zone": {
+			state:  testZonedState(),
+			change: Change{Add: []Server{{Name: "node5", RedundancyZone: "zone4", Healthy: true}}},
+			voters: []string{"node1", "node2", "node3", "node5"},
+			quorum: 3,
+			ft:     1,
+			warnings: []string{
+				"The proposed layout has an even number of voters (4), which tolerates no more failures than one voter fewer.",
+			},
+		},
+		"add standby to zone": {
+			state:  testZonedState(),
+			change: Change{Add: []Server{{Name: "node5", RedundancyZone: "zone2", Healthy: true}}},
+			voters: []string{"node1", "node2", "node3"},
+			quorum: 2,
+			ft:     1,
+		},
+		"empty zone": {
+			state:  testZonedState(),
+			change: Change{Remove: []string{"node3"}},
+			voters: []string{"node1", "node2"},
+			quorum: 2,
+			ft:     0,
+			warnings: []string{
+				"Redundancy zone zone3 will have no servers left.",
+				"The failure tolerance decreases from 1 to 0.",
+				"The proposed layout has an even number of voters (2), which tolerates no more failures than one voter fewer.",
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			run(t, tc)
+		})
+	}
+}
+
+func TestParseServer(t *testing.T) {
+	srv, err := ParseServer("node4:zone2")
+	require.NoError(t, err)
+	require.Equal(t, Server{Name: "node4", RedundancyZone: "zone2", Healthy: true}, srv)
+
+	srv, err = ParseServer("node4")
+	require.NoError(t, err)
+	require.Equal(t, Server{Name: "node4", Healthy: true}, srv)
+
+	_, err = ParseServer(":zone2")
+	require.EqualError(t, err, `server name is required in ":zone2"`)
+}
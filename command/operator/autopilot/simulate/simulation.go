@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package simulate
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// Layout describes the voting configuration of a set of servers as
+// Autopilot would arrange it.
+type Layout struct {
+	Voters           []string
+	NonVoters        []string              `json:",omitempty"`
+	ReadReplicas     []string              `json:",omitempty"`
+	RedundancyZones  map[string]ZoneLayout `json:",omitempty"`
+	QuorumSize       int
+	FailureTolerance int
+}
+
+// ZoneLayout describes the servers of a single redundancy zone.
+type ZoneLayout struct {
+	Servers          []string
+	Voters           []string
+	FailureTolerance int
+}
+
+// Simulation is the result of applying a hypothetical change to the
+// current set of servers.
+type Simulation struct {
+	Current  *Layout
+	Proposed *Layout
+	Warnings []string `json:",omitempty"`
+}
+
+// Change is a hypothetical change to the set of servers.
+type Change struct {
+	// Remove holds the IDs, names or addresses of servers to remove.
+	Remove []string
+
+	// Add holds the servers to add.
+	Add []Server
+}
+
+// Server is a server taking part in the simulation. Servers are identified
+// by name in the resulting layouts.
+type Server struct {
+	Name           string
+	RedundancyZone string
+	ReadReplica    bool
+	Voter          bool
+	Healthy        bool
+}
+
+// ParseServer parses an -add-server value of the form <name>[:<zone>].
+func ParseServer(value string) (Server, error) {
+	name, zone, _ := strings.Cut(value, ":")
+	if name == "" {
+		return Server{}, fmt.Errorf("server name is required in %q", value)
+	}
+	return Server{Name: name, RedundancyZone: zone, Healthy: true}, nil
+}
+
+// Simulate computes the voter layout of the servers in state before and
+// after applying change.
+func Simulate(state *api.AutopilotState, change Change) (*Simulation, error) {
+	zoned := len(state.RedundancyZones) > 0
+
+	var (
+		current []Server
+		leader  string
+	)
+	ids := make(map[string]string)
+	for id, srv := range state.Servers {
+		current = append(current, Server{
+			Name:           srv.Name,
+			RedundancyZone: srv.RedundancyZone,
+			ReadReplica:    srv.ReadReplica,
+			Voter:          srv.Status == api.AutopilotServerVoter || srv.Status == api.AutopilotServerLeader,
+			Healthy:        srv.Healthy,
+		})
+		ids[srv.Name] = id
+		if id == state.Leader {
+			leader = srv.Name
+		}
+	}
+
+	removed := make(map[string]struct{})
+	for _, target := range change.Remove {
+		name, err := findServer(state, target)
+		if err != nil {
+			return nil, err
+		}
+		removed[name] = struct{}{}
+	}
+
+	var proposed []Server
+	for _, srv := range current {
+		if _, ok := removed[srv.Name]; !ok {
+			proposed = append(proposed, srv)
+		}
+	}
+	for _, srv := range change.Add {
+		if _, ok := ids[srv.Name]; ok {
+			return nil, fmt.Errorf("server %q already exists", srv.Name)
+		}
+		if srv.RedundancyZone != "" && !zoned {
+			return nil, fmt.Errorf("cannot add server %q to redundancy zone %q: redundancy zones are not in use", srv.Name, srv.RedundancyZone)
+		}
+		ids[srv.Name] = ""
+		proposed = append(proposed, srv)
+	}
+
+	sim := &Simulation{
+		Current:  computeLayout(current, zoned),
+		Proposed: computeLayout(proposed, zoned),
+	}
+
+	if _, ok := removed[leader]; ok && leader != "" {
+		sim.Warnings = append(sim.Warnings, fmt.Sprintf("The leader %s will be removed and a new leader will be elected.", leader))
+	}
+	switch n := len(sim.Proposed.Voters); {
+	case n == 0:
+		sim.Warnings = append(sim.Warnings, "No voters remain, the cluster will be unable to elect a leader.")
+	case n%2 == 0:
+		sim.Warnings = append(sim.Warnings, fmt.Sprintf("The proposed layout has an even number of voters (%d), which tolerates no more failures than one voter fewer.", n))
+	}
+	if sim.Proposed.FailureTolerance < sim.Current.FailureTolerance {
+		sim.Warnings = append(sim.Warnings, fmt.Sprintf("The failure tolerance decreases from %d to %d.", sim.Current.FailureTolerance, sim.Proposed.FailureTolerance))
+	}
+	for name, zone := range sim.Current.RedundancyZones {
+		if _, ok := sim.Proposed.RedundancyZones[name]; !ok && len(zone.Servers) > 0 {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf("Redundancy zone %s will have no servers left.", name))
+		}
+	}
+	sort.Strings(sim.Warnings)
+
+	return sim, nil
+}
+
+// findServer returns the name of the server in state matching target by ID,
+// name or address.
+func findServer(state *api.AutopilotState, target string) (string, error) {
+	for id, srv := range state.Servers {
+		if target == id || target == srv.Name || target == srv.Address {
+			return srv.Name, nil
+		}
+		if host, _, err := net.SplitHostPort(srv.Address); err == nil && target == host {
+			return srv.Name, nil
+		}
+	}
+	return "", fmt.Errorf("server %q not found", target)
+}
+
+// computeLayout arranges servers the way Autopilot does. Without redundancy
+// zones every server other than a read replica is a voter. With redundancy
+// zones each zone gets a single voter, preferring the server that is already
+// the voter in that zone, and the other servers in the zone are non-voters.
+// Servers outside any zone are voters.
+func computeLayout(servers []Server, zoned bool) *Layout {
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	layout := &Layout{}
+	zones := make(map[string][]Server)
+	for _, srv := range servers {
+		switch {
+		case srv.ReadReplica:
+			layout.ReadReplicas = append(layout.ReadReplicas, srv.Name)
+		case zoned && srv.RedundancyZone != "":
+			zones[srv.RedundancyZone] = append(zones[srv.RedundancyZone], srv)
+		default:
+			layout.Voters = append(layout.Voters, srv.Name)
+		}
+	}
+
+	if len(zones) > 0 {
+		layout.RedundancyZones = make(map[string]ZoneLayout)
+	}
+	for name, members := range zones {
+		voter := pickZoneVoter(members)
+		zone := ZoneLayout{
+			Voters:           []string{voter},
+			FailureTolerance: len(members) - 1,
+		}
+		for _, srv := range members {
+			zone.Servers = append(zone.Servers, srv.Name)
+			if srv.Name == voter {
+				layout.Voters = append(layout.Voters, srv.Name)
+			} else {
+				layout.NonVoters = append(layout.NonVoters, srv.Name)
+			}
+		}
+		layout.RedundancyZones[name] = zone
+	}
+	sort.Strings(layout.Voters)
+	sort.Strings(layout.NonVoters)
+
+	if n := len(layout.Voters); n > 0 {
+		layout.QuorumSize = n/2 + 1
+		layout.FailureTolerance = n - layout.QuorumSize
+	}
+	return layout
+}
+
+// pickZoneVoter returns the name of the server that votes for a zone. The
+// members must be sorted by name.
+func pickZoneVoter(members []Server) string {
+	for _, srv := range members {
+		if srv.Voter && srv.Healthy {
+			return srv.Name
+		}
+	}
+	for _, srv := range members {
+		if srv.Healthy {
+			return srv.Name
+		}
+	}
+	return members[0].Name
+}
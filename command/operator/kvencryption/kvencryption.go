@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package kvencryption
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/ryanuber/columnize"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+)
+
+func New() *cmd {
+	return &cmd{}
+}
+
+type cmd struct{}
+
+func (c *cmd) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(help, nil)
+}
+
+// FormatStatus formats the KV encryption status as a table of keys.
+func FormatStatus(status *api.KVEncryptionStatus) string {
+	if !status.Enabled {
+		return fmt.Sprintf("KV encryption is not enabled (%d plaintext entries)", status.PlaintextEntries)
+	}
+
+	result := []string{"ID\x1fProvider\x1fCreated\x1fActive\x1fEntries"}
+	for _, key := range status.Keys {
+		result = append(result, fmt.Sprintf("%s\x1f%s\x1f%s\x1f%v\x1f%d",
+			key.ID, key.Provider, key.CreateTime.Format(time.RFC3339), key.Active, key.Entries))
+	}
+	out := fmt.Sprintf("Provider:          %s\nActive Key:        %s\nPlaintext Entries: %d\n\n",
+		status.Provider, status.ActiveKeyID, status.PlaintextEntries)
+	return out + columnize.Format(result, &columnize.Config{Delim: string([]byte{0x1f})})
+}
+
+const synopsis = "Manage the encryption of KV values"
+const help = `
+Usage: consul operator kv-encryption <subcommand> [options]
+
+  The KV encryption operator command is used to inspect and manage the data
+  encryption keys used to encrypt the KV values stored by the servers.
+
+  Display the encryption keys and the number of entries using each of them:
+
+      $ consul operator kv-encryption status
+
+  Generate a new data encryption key for the new writes:
+
+      $ consul operator kv-encryption rotate
+
+  Re-encrypt the existing entries with the active key:
+
+      $ consul operator kv-encryption rewrap
+
+  For more examples, ask for subcommand help or view the documentation.
+`
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package rewrap
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/operator/kvencryption"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Operator().KVEncryptionRewrap(nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error rewrapping KV encryption keys: %s", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Re-encrypted %d entries with %s\n", status.Rewrapped, status.ActiveKeyID))
+	c.UI.Output(kvencryption.FormatStatus(status))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Re-encrypt the KV values with the active key"
+const help = `
+Usage: consul operator kv-encryption rewrap [options]
+
+  Wraps every data encryption key again with the configured key provider, and
+  re-encrypts the KV values which are not encrypted with the active data
+  encryption key, including the values written before encryption was enabled.
+
+  Run this command after rotating the key of the provider, after switching to
+  another provider, or after "consul operator kv-encryption rotate" to stop
+  using the previous data encryption keys. Re-encrypted entries get a new
+  ModifyIndex.
+`
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package rotate
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/operator/kvencryption"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	status, _, err := client.Operator().KVEncryptionRotate(nil)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error rotating KV encryption key: %s", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Rotated KV encryption key, new values are encrypted with %s\n", status.ActiveKeyID))
+	c.UI.Output(kvencryption.FormatStatus(status))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Generate a new KV encryption key"
+const help = `
+Usage: consul operator kv-encryption rotate [options]
+
+  Generates a new data encryption key and makes it the active key. Values
+  written from now on are encrypted with the new key; existing values keep
+  using their key until they are rewritten or re-encrypted with
+  "consul operator kv-encryption rewrap".
+`
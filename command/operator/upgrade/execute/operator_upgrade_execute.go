@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package execute
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/mitchellh/cli"
+	"github.com/rboyer/safeio"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/operator/upgrade"
+	"github.com/hashicorp/consul/snapshot"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui, interval: 5 * time.Second}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	planFile string
+	timeout  time.Duration
+
+	// interval is how often the Autopilot state is polled while waiting.
+	interval time.Duration
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.planFile, "plan-file", upgrade.DefaultPlanFile,
+		"The upgrade plan file created by \"consul operator upgrade plan\".")
+	c.flags.DurationVar(&c.timeout, "timeout", 30*time.Minute,
+		"How long to wait for a server to be upgraded or for the cluster to "+
+			"become healthy before stopping the upgrade.")
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	plan, err := upgrade.LoadPlan(c.planFile)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error loading upgrade plan: %s", err))
+		return 1
+	}
+	target, err := version.NewVersion(plan.TargetVersion)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid target version in upgrade plan: %s", err))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	e := &executor{
+		cmd:    c,
+		client: client,
+		plan:   plan,
+		target: target,
+	}
+	for i, step := range plan.Steps {
+		if step.Completed() {
+			continue
+		}
+		c.UI.Output(fmt.Sprintf("==> Step %d/%d: %s", i+1, len(plan.Steps), step))
+		if err := e.run(step); err != nil {
+			c.UI.Error(fmt.Sprintf("Error in step %d: %s", i+1, err))
+			c.UI.Error("Fix the problem and run \"consul operator upgrade execute\" again to resume the upgrade.")
+			return 1
+		}
+
+		now := time.Now().UTC()
+		step.CompletedAt = &now
+		if err := plan.Save(c.planFile); err != nil {
+			c.UI.Error(fmt.Sprintf("Error saving upgrade progress: %s", err))
+			return 1
+		}
+	}
+
+	c.UI.Info(fmt.Sprintf("All servers in datacenter %s run version %s or later.", plan.Datacenter, plan.TargetVersion))
+	return 0
+}
+
+type executor struct {
+	*cmd
+	client *api.Client
+	plan   *upgrade.Plan
+	target *version.Version
+}
+
+func (e *executor) run(step *upgrade.Step) error {
+	switch step.Kind {
+	case upgrade.StepSnapshot:
+		return e.saveSnapshot()
+	case upgrade.StepVerifyHealth:
+		return e.waitFor("the cluster to be healthy", func(state *api.AutopilotState) (bool, error) {
+			return state.Healthy, nil
+		})
+	case upgrade.StepUpgradeServer:
+		e.UI.Info(fmt.Sprintf("    Upgrade server %s to version %s and restart it.", step.ServerName, e.plan.TargetVersion))
+		return e.waitFor(fmt.Sprintf("server %s to rejoin running version %s", step.ServerName, e.plan.TargetVersion),
+			func(state *api.AutopilotState) (bool, error) {
+				srv, ok := state.Servers[step.ServerID]
+				if !ok || !srv.Healthy {
+					return false, nil
+				}
+				return upgrade.RunsVersion(srv, e.target)
+			})
+	case upgrade.StepTransferLeadership:
+		return e.transferLeadership(step)
+	default:
+		return fmt.Errorf("unknown step %q", step.Kind)
+	}
+}
+
+func (e *executor) queryOptions() *api.QueryOptions {
+	return &api.QueryOptions{Datacenter: e.plan.Datacenter}
+}
+
+func (e *executor) saveSnapshot() error {
+	snap, qm, err := e.client.Snapshot().Save(e.queryOptions())
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	defer snap.Close()
+
+	file := e.plan.SnapshotPath
+	unverifiedFile := file + ".unverified"
+	if _, err := safeio.WriteToFile(snap, unverifiedFile, 0600); err != nil {
+		return fmt.Errorf("failed to write unverified snapshot file: %w", err)
+	}
+	defer os.Remove(unverifiedFile)
+
+	f, err := os.Open(unverifiedFile)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file for verify: %w", err)
+	}
+	_, err = snapshot.Verify(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to verify snapshot file: %w", err)
+	}
+
+	if err := safeio.Rename(unverifiedFile, file); err != nil {
+		return fmt.Errorf("failed to rename %q to %q: %w", unverifiedFile, file, err)
+	}
+
+	e.UI.Info(fmt.Sprintf("    Saved and verified snapshot to index %d in %s", qm.LastIndex, file))
+	return nil
+}
+
+func (e *executor) transferLeadership(step *upgrade.Step) error {
+	state, err := e.client.Operator().AutopilotState(e.queryOptions())
+	if err != nil {
+		return fmt.Errorf("failed to query Autopilot state: %w", err)
+	}
+	if state.Leader != step.ServerID {
+		e.UI.Info(fmt.Sprintf("    Server %s is no longer the leader.", step.ServerName))
+		return nil
+	}
+
+	var candidates []api.AutopilotServer
+	for _, srv := range state.Servers {
+		if srv.Status != api.AutopilotServerVoter || !srv.Healthy {
+			continue
+		}
+		if ok, err := upgrade.RunsVersion(srv, e.target); err != nil || !ok {
+			continue
+		}
+		candidates = append(candidates, srv)
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("no healthy voter runs version %s", e.plan.TargetVersion)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	next := candidates[0]
+
+	reply, err := e.client.Operator().RaftLeaderTransfer(next.ID, e.queryOptions())
+	if err != nil {
+		return fmt.Errorf("failed to transfer leadership to %s: %w", next.Name, err)
+	}
+	if !reply.Success {
+		return fmt.Errorf("failed to transfer leadership to %s", next.Name)
+	}
+
+	return e.waitFor(fmt.Sprintf("server %s to give up leadership", step.ServerName),
+		func(state *api.AutopilotState) (bool, error) {
+			return state.Leader != "" && state.Leader != step.ServerID, nil
+		})
+}
+
+// waitFor polls the Autopilot state until done returns true, it returns an
+// error, or the timeout expires. Errors querying the state are retried since
+// the servers are expected to restart during the upgrade.
+func (e *executor) waitFor(desc string, done func(*api.AutopilotState) (bool, error)) error {
+	e.UI.Info(fmt.Sprintf("    Waiting for %s...", desc))
+
+	deadline := time.Now().Add(e.timeout)
+	for {
+		state, err := e.client.Operator().AutopilotState(e.queryOptions())
+		if err == nil {
+			ok, err := done(state)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("timed out waiting for %s: %w", desc, err)
+			}
+			return fmt.Errorf("timed out waiting for %s", desc)
+		}
+		time.Sleep(e.interval)
+	}
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Execute a rolling upgrade of the Consul servers"
+const help = `
+Usage: consul operator upgrade execute [options]
+
+  Executes the upgrade plan created by "consul operator upgrade plan". The
+  command takes a snapshot, then waits for each server in turn to be upgraded
+  and to rejoin the cluster running the target version, checking the health of
+  the cluster between steps. Before the leader is upgraded, leadership is
+  transferred to a server that has already been upgraded.
+
+  The command does not install Consul; upgrade each server when asked to, for
+  example with your configuration management tooling. Progress is saved to the
+  plan file after every step, so an interrupted upgrade resumes where it
+  stopped when the command is run again.
+
+      $ consul operator upgrade execute
+`
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package execute
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/command/operator/upgrade"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestOperatorUpgradeExecuteCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestOperatorUpgradeExecuteCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	dir := t.TempDir()
+	planFile := filepath.Join(dir, upgrade.DefaultPlanFile)
+	snapshotPath := filepath.Join(dir, "backup.snap")
+	nodeID := string(a.Config.NodeID)
+
+	plan := &upgrade.Plan{
+		Datacenter:    "dc1",
+		TargetVersion: "1.0.0",
+		SnapshotPath:  snapshotPath,
+		Steps: []*upgrade.Step{
+			{Kind: upgrade.StepSnapshot},
+			{Kind: upgrade.StepVerifyHealth},
+			{Kind: upgrade.StepUpgradeServer, ServerID: nodeID, ServerName: a.Config.NodeName},
+		},
+	}
+	require.NoError(t, plan.Save(planFile))
+
+	run := func(t *testing.T, planFile string) (int, *cli.MockUi) {
+		ui := cli.NewMockUi()
+		c := New(ui)
+		c.interval = 100 * time.Millisecond
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile, "-timeout=10s"})
+		return code, ui
+	}
+
+	t.Run("execute", func(t *testing.T) {
+		code, ui := run(t, planFile)
+		require.Empty(t, ui.ErrorWriter.String())
+		require.Equal(t, 0, code)
+		require.FileExists(t, snapshotPath)
+
+		plan, err := upgrade.LoadPlan(planFile)
+		require.NoError(t, err)
+		for _, step := range plan.Steps {
+			require.True(t, step.Completed(), step.String())
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		code, ui := run(t, planFile)
+		require.Empty(t, ui.ErrorWriter.String())
+		require.Equal(t, 0, code)
+		require.NotContains(t, ui.OutputWriter.String(), "==> Step")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		planFile := filepath.Join(dir, "timeout.json")
+		plan := &upgrade.Plan{
+			Datacenter:    "dc1",
+			TargetVersion: "99.0.0",
+			Steps: []*upgrade.Step{
+				{Kind: upgrade.StepUpgradeServer, ServerID: nodeID, ServerName: a.Config.NodeName},
+			},
+		}
+		require.NoError(t, plan.Save(planFile))
+
+		ui := cli.NewMockUi()
+		c := New(ui)
+		c.interval = 100 * time.Millisecond
+		code := c.Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile, "-timeout=500ms"})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "timed out waiting for server "+a.Config.NodeName)
+
+		plan, err := upgrade.LoadPlan(planFile)
+		require.NoError(t, err)
+		require.False(t, plan.Steps[0].Completed())
+	})
+
+	t.Run("missing plan", func(t *testing.T) {
+		code, ui := run(t, filepath.Join(dir, "missing.json"))
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "Error loading upgrade plan")
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package upgrade
+
+import (
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/mitchellh/cli"
+)
+
+func New() *cmd {
+	return &cmd{}
+}
+
+type cmd struct{}
+
+func (c *cmd) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return flags.Usage(help, nil)
+}
+
+const synopsis = "Plan and execute rolling upgrades of Consul servers"
+const help = `
+Usage: consul operator upgrade <subcommand> [options]
+
+  The upgrade operator command sequences the upgrade of the Consul servers in
+  a datacenter. "plan" records the steps to take in a plan file, and "execute"
+  takes a snapshot, waits for each server to be upgraded, transfers leadership
+  away from the leader before it is upgraded, and verifies the health of the
+  cluster between steps. Progress is recorded in the plan file so that an
+  interrupted upgrade can be resumed by running "execute" again.
+`
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package upgrade
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/rboyer/safeio"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// DefaultPlanFile is the file the plan is stored in when none is given.
+const DefaultPlanFile = "consul-upgrade.json"
+
+// StepKind identifies the action a step of an upgrade plan takes.
+type StepKind string
+
+const (
+	// StepSnapshot saves a snapshot of the cluster state.
+	StepSnapshot StepKind = "snapshot"
+
+	// StepVerifyHealth waits until Autopilot reports the cluster as healthy.
+	StepVerifyHealth StepKind = "verify-health"
+
+	// StepUpgradeServer waits until a server rejoins the cluster running the
+	// target version.
+	StepUpgradeServer StepKind = "upgrade-server"
+
+	// StepTransferLeadership transfers leadership to a server already running
+	// the target version.
+	StepTransferLeadership StepKind = "transfer-leadership"
+)
+
+// Plan is the ordered list of steps to upgrade the servers of a datacenter
+// to a target version. It is stored as JSON so that an interrupted upgrade can
+// be resumed.
+type Plan struct {
+	Datacenter    string
+	TargetVersion string
+	SnapshotPath  string
+	CreatedAt     time.Time
+	Steps         []*Step
+}
+
+// Step is a single step of a Plan.
+type Step struct {
+	Kind        StepKind
+	ServerID    string     `json:",omitempty"`
+	ServerName  string     `json:",omitempty"`
+	CompletedAt *time.Time `json:",omitempty"`
+}
+
+func (s *Step) String() string {
+	switch s.Kind {
+	case StepSnapshot:
+		return "Save a snapshot"
+	case StepVerifyHealth:
+		return "Verify cluster health"
+	case StepUpgradeServer:
+		return fmt.Sprintf("Upgrade server %s (%s)", s.ServerName, s.ServerID)
+	case StepTransferLeadership:
+		return fmt.Sprintf("Transfer leadership away from %s (%s)", s.ServerName, s.ServerID)
+	default:
+		return string(s.Kind)
+	}
+}
+
+// Completed returns whether the step has been completed.
+func (s *Step) Completed() bool {
+	return s.CompletedAt != nil
+}
+
+// NewPlan returns a plan to upgrade the servers in state which run a version
+// older than target. Read replicas and non-voters are upgraded first, then the
+// voters, and the leader last after leadership has been transferred to an
+// upgraded voter.
+func NewPlan(state *api.AutopilotState, target, snapshotPath string) (*Plan, error) {
+	targetVersion, err := version.NewVersion(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version %q: %w", target, err)
+	}
+
+	var (
+		pending       []api.AutopilotServer
+		leader        *api.AutopilotServer
+		upgradedVoter bool
+	)
+	for _, srv := range state.Servers {
+		srv := srv
+		upgraded, err := RunsVersion(srv, targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		voter := srv.Status == api.AutopilotServerVoter || srv.Status == api.AutopilotServerLeader
+		switch {
+		case upgraded:
+			upgradedVoter = upgradedVoter || voter
+		case srv.ID == state.Leader:
+			leader = &srv
+		default:
+			pending = append(pending, srv)
+		}
+	}
+	if len(pending) == 0 && leader == nil {
+		return nil, fmt.Errorf("all servers already run version %s or later", target)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		vi := pending[i].Status == api.AutopilotServerVoter
+		vj := pending[j].Status == api.AutopilotServerVoter
+		if vi != vj {
+			return vj
+		}
+		return pending[i].Name < pending[j].Name
+	})
+
+	plan := &Plan{
+		TargetVersion: targetVersion.String(),
+		SnapshotPath:  snapshotPath,
+		CreatedAt:     time.Now().UTC(),
+		Steps: []*Step{
+			{Kind: StepSnapshot},
+			{Kind: StepVerifyHealth},
+		},
+	}
+	for _, srv := range pending {
+		upgradedVoter = upgradedVoter || srv.Status == api.AutopilotServerVoter
+		plan.Steps = append(plan.Steps,
+			&Step{Kind: StepUpgradeServer, ServerID: srv.ID, ServerName: srv.Name},
+			&Step{Kind: StepVerifyHealth},
+		)
+	}
+	if leader != nil {
+		// A single server cannot hand over leadership and is upgraded in
+		// place, which makes the cluster unavailable until it rejoins.
+		if upgradedVoter {
+			plan.Steps = append(plan.Steps,
+				&Step{Kind: StepTransferLeadership, ServerID: leader.ID, ServerName: leader.Name},
+				&Step{Kind: StepVerifyHealth},
+			)
+		}
+		plan.Steps = append(plan.Steps,
+			&Step{Kind: StepUpgradeServer, ServerID: leader.ID, ServerName: leader.Name},
+			&Step{Kind: StepVerifyHealth},
+		)
+	}
+
+	return plan, nil
+}
+
+// RunsVersion returns whether srv runs target or a later version.
+func RunsVersion(srv api.AutopilotServer, target *version.Version) (bool, error) {
+	v, err := version.NewVersion(srv.Version)
+	if err != nil {
+		return false, fmt.Errorf("server %s reports an invalid version %q: %w", srv.Name, srv.Version, err)
+	}
+	return coreVersion(v).GreaterThanOrEqual(coreVersion(target)), nil
+}
+
+// coreVersion strips the prerelease and metadata from v, so that development
+// and enterprise builds compare equal to the release they are based on.
+func coreVersion(v *version.Version) *version.Version {
+	s := v.Segments()
+	return version.Must(version.NewVersion(fmt.Sprintf("%d.%d.%d", s[0], s[1], s[2])))
+}
+
+// LoadPlan reads a plan from path.
+func LoadPlan(path string) (*Plan, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	var plan Plan
+	if err := json.Unmarshal(b, &plan); err != nil {
+		return nil, fmt.Errorf("failed to decode plan file %q: %w", path, err)
+	}
+	return &plan, nil
+}
+
+// Save atomically writes the plan to path.
+func (p *Plan) Save(path string) error {
+	b, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if _, err := safeio.WriteToFile(bytes.NewReader(b), path, 0600); err != nil {
+		return fmt.Errorf("failed to write plan file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plan
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/command/operator/upgrade"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	planFile     string
+	snapshotPath string
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+	c.flags.StringVar(&c.planFile, "plan-file", upgrade.DefaultPlanFile,
+		"The file to write the upgrade plan to.")
+	c.flags.StringVar(&c.snapshotPath, "snapshot", "",
+		"The file the snapshot taken before the upgrade is saved to. Defaults "+
+			"to a file named after the datacenter and target version.")
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	args = c.flags.Args()
+	if len(args) != 1 {
+		c.UI.Error(fmt.Sprintf("Expected exactly one argument, the target version (got %d)", len(args)))
+		return 1
+	}
+	target := args[0]
+
+	if _, err := os.Stat(c.planFile); err == nil {
+		c.UI.Error(fmt.Sprintf("Plan file %q already exists. Run \"consul operator upgrade execute\" to resume it, or remove it to create a new plan.", c.planFile))
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	dc := c.http.Datacenter()
+	if dc == "" {
+		self, err := client.Agent().Self()
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error querying agent: %s", err))
+			return 1
+		}
+		dc, _ = self["Config"]["Datacenter"].(string)
+	}
+
+	state, err := client.Operator().AutopilotState(&api.QueryOptions{
+		Datacenter: dc,
+		AllowStale: c.http.Stale(),
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error querying Autopilot state: %s", err))
+		return 1
+	}
+
+	snapshotPath := c.snapshotPath
+	if snapshotPath == "" {
+		snapshotPath = fmt.Sprintf("pre-upgrade-%s-%s.snap", dc, target)
+	}
+
+	plan, err := upgrade.NewPlan(state, target, snapshotPath)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating upgrade plan: %s", err))
+		return 1
+	}
+	plan.Datacenter = dc
+
+	if err := plan.Save(c.planFile); err != nil {
+		c.UI.Error(fmt.Sprintf("Error saving upgrade plan: %s", err))
+		return 1
+	}
+
+	c.UI.Output(formatPlan(plan))
+	c.UI.Info(fmt.Sprintf("Saved upgrade plan to %s. Run \"consul operator upgrade execute\" to start the upgrade.", c.planFile))
+	return 0
+}
+
+func formatPlan(plan *upgrade.Plan) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Upgrade plan for datacenter %s to version %s:\n", plan.Datacenter, plan.TargetVersion))
+	for i, step := range plan.Steps {
+		buffer.WriteString(fmt.Sprintf("   %2d. %s\n", i+1, step))
+	}
+	return buffer.String()
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const synopsis = "Plan a rolling upgrade of the Consul servers"
+const help = `
+Usage: consul operator upgrade plan [options] VERSION
+
+  Creates a plan to upgrade the Consul servers of a datacenter to VERSION and
+  saves it to a plan file. Servers already running VERSION or later are left
+  out of the plan. Non-voting servers are upgraded first, then the voters, and
+  the leader last after leadership has been transferred to an upgraded voter.
+
+  Plan the upgrade to 1.18.0:
+
+      $ consul operator upgrade plan 1.18.0
+`
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent"
+	"github.com/hashicorp/consul/command/operator/upgrade"
+	"github.com/hashicorp/consul/testrpc"
+)
+
+func TestOperatorUpgradePlanCommand_noTabs(t *testing.T) {
+	t.Parallel()
+	if strings.ContainsRune(New(cli.NewMockUi()).Help(), '\t') {
+		t.Fatal("help has tabs")
+	}
+}
+
+func TestOperatorUpgradePlanCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	testrpc.WaitForLeader(t, a.RPC, "dc1")
+
+	dir := t.TempDir()
+	planFile := filepath.Join(dir, upgrade.DefaultPlanFile)
+
+	t.Run("missing version", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "Expected exactly one argument")
+	})
+
+	t.Run("already upgraded", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile, "1.0.0"})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "all servers already run version 1.0.0 or later")
+		require.NoFileExists(t, planFile)
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile, "99.0.0"})
+		require.Empty(t, ui.ErrorWriter.String())
+		require.Equal(t, 0, code)
+		require.Contains(t, ui.OutputWriter.String(), "Upgrade plan for datacenter dc1 to version 99.0.0")
+		require.Contains(t, ui.OutputWriter.String(), "Upgrade server "+a.Config.NodeName)
+
+		plan, err := upgrade.LoadPlan(planFile)
+		require.NoError(t, err)
+		require.Equal(t, "dc1", plan.Datacenter)
+		require.Equal(t, "pre-upgrade-dc1-99.0.0.snap", plan.SnapshotPath)
+		require.Len(t, plan.Steps, 4)
+	})
+
+	t.Run("existing plan", func(t *testing.T) {
+		before, err := os.ReadFile(planFile)
+		require.NoError(t, err)
+
+		ui := cli.NewMockUi()
+		code := New(ui).Run([]string{"-http-addr=" + a.HTTPAddr(), "-plan-file=" + planFile, "99.0.0"})
+		require.Equal(t, 1, code)
+		require.Contains(t, ui.ErrorWriter.String(), "already exists")
+
+		after, err := os.ReadFile(planFile)
+		require.NoError(t, err)
+		require.Equal(t, before, after)
+	})
+}
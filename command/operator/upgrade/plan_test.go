@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package upgrade
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func testServer(id, name, version string, status api.AutopilotServerStatus) api.AutopilotServer {
+	return api.AutopilotServer{
+		ID:      id,
+		Name:    name,
+		Version: version,
+		Status:  status,
+		Healthy: true,
+	}
+}
+
+func stepNames(plan *Plan) []string {
+	var names []string
+	for _, step := range plan.Steps {
+		names = append(names, step.String())
+	}
+	return names
+}
+
+func TestNewPlan(t *testing.T) {
+	state := &api.AutopilotState{
+		Leader: "1",
+		Servers: map[string]api.AutopilotServer{
+			"1": testServer("1", "node1", "1.17.2", api.AutopilotServerLeader),
+			"2": testServer("2", "node2", "1.17.2", api.AutopilotServerVoter),
+			"3": testServer("3", "node3", "1.18.0-dev", api.AutopilotServerVoter),
+			"4": testServer("4", "node4", "1.17.2", api.AutopilotServerNonVoter),
+		},
+	}
+
+	plan, err := NewPlan(state, "1.18.0", "backup.snap")
+	require.NoError(t, err)
+	require.Equal(t, "1.18.0", plan.TargetVersion)
+	require.Equal(t, "backup.snap", plan.SnapshotPath)
+	require.Equal(t, []string{
+		"Save a snapshot",
+		"Verify cluster health",
+		"Upgrade server node4 (4)",
+		"Verify cluster health",
+		"Upgrade server node2 (2)",
+		"Verify cluster health",
+		"Transfer leadership away from node1 (1)",
+		"Verify cluster health",
+		"Upgrade server node1 (1)",
+		"Verify cluster health",
+	}, stepNames(plan))
+}
+
+func TestNewPlan_SingleServer(t *testing.T) {
+	state := &api.AutopilotState{
+		Leader: "1",
+		Servers: map[string]api.AutopilotServer{
+			"1": testServer("1", "node1", "1.17.2", api.AutopilotServerLeader),
+		},
+	}
+
+	plan, err := NewPlan(state, "1.18.0", "backup.snap")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Save a snapshot",
+		"Verify cluster health",
+		"Upgrade server node1 (1)",
+		"Verify cluster health",
+	}, stepNames(plan))
+}
+
+func TestNewPlan_Errors(t *testing.T) {
+	state := &api.AutopilotState{
+		Leader: "1",
+		Servers: map[string]api.AutopilotServer{
+			"1": testServer("1", "node1", "1.18.1", api.AutopilotServerLeader),
+		},
+	}
+
+	_, err := NewPlan(state, "1.18.0", "backup.snap")
+	require.EqualError(t, err, "all servers already run version 1.18.0 or later")
+
+	_, err = NewPlan(state, "latest", "backup.snap")
+	require.ErrorContains(t, err, `invalid target version "latest"`)
+}
+
+func TestPlan_SaveLoad(t *testing.T) {
+	state := &api.AutopilotState{
+		Leader: "1",
+		Servers: map[string]api.AutopilotServer{
+			"1": testServer("1", "node1", "1.17.2", api.AutopilotServerLeader),
+		},
+	}
+	plan, err := NewPlan(state, "1.18.0", "backup.snap")
+	require.NoError(t, err)
+	plan.Datacenter = "dc1"
+
+	path := filepath.Join(t.TempDir(), DefaultPlanFile)
+	require.NoError(t, plan.Save(path))
+
+	loaded, err := LoadPlan(path)
+	require.NoError(t, err)
+	require.Equal(t, plan, loaded)
+}
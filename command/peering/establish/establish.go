@@ -29,6 +29,7 @@ type cmd struct {
 	name         string
 	peeringToken string
 	meta         map[string]string
+	aclPolicies  []string
 }
 
 func (c *cmd) init() {
@@ -42,6 +43,10 @@ func (c *cmd) init() {
 		"Metadata to associate with the peering, formatted as key=value. This flag "+
 			"may be specified multiple times to set multiple meta fields.")
 
+	c.flags.Var((*flags.AppendSliceValue)(&c.aclPolicies), "acl-policy-name",
+		"Name of an ACL policy constraining the services exported to the peer. "+
+			"This flag may be specified multiple times to attach multiple policies.")
+
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
 	flags.Merge(c.flags, c.http.PartitionFlag())
@@ -76,6 +81,7 @@ func (c *cmd) Run(args []string) int {
 		PeeringToken: c.peeringToken,
 		Partition:    c.http.Partition(),
 		Meta:         c.meta,
+		ACLPolicies:  c.aclPolicies,
 	}
 
 	_, _, err = peerings.Establish(context.Background(), req, &api.WriteOptions{})
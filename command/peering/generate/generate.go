@@ -32,6 +32,8 @@ type cmd struct {
 	name              string
 	externalAddresses string
 	meta              map[string]string
+	aclPolicies       []string
+	clearACLPolicies  bool
 	format            string
 }
 
@@ -44,6 +46,14 @@ func (c *cmd) init() {
 		"Metadata to associate with the peering, formatted as key=value. This flag "+
 			"may be specified multiple times to set multiple metadata fields.")
 
+	c.flags.Var((*flags.AppendSliceValue)(&c.aclPolicies), "acl-policy-name",
+		"Name of an ACL policy constraining the services exported to the peer. "+
+			"This flag may be specified multiple times to attach multiple policies.")
+
+	c.flags.BoolVar(&c.clearACLPolicies, "clear-acl-policies", false,
+		"Remove the ACL policies of an existing peering, so that the services exported "+
+			"to the peer are no longer constrained by policies. Cannot be combined with -acl-policy-name.")
+
 	c.flags.StringVar(&c.externalAddresses, "server-external-addresses", "",
 		"A list of addresses to put into the generated token, formatted as a comma-separate list. "+
 			"Addresses are the form of <host or IP>:port. "+
@@ -73,6 +83,11 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	if c.clearACLPolicies && len(c.aclPolicies) > 0 {
+		c.UI.Error("The -clear-acl-policies and -acl-policy-name flags cannot be combined")
+		return 1
+	}
+
 	if !peering.FormatIsValid(c.format) {
 		c.UI.Error(fmt.Sprintf("Invalid format, valid formats are {%s}", strings.Join(peering.GetSupportedFormats(), "|")))
 		return 1
@@ -94,6 +109,8 @@ func (c *cmd) Run(args []string) int {
 		PeerName:                c.name,
 		Partition:               c.http.Partition(),
 		Meta:                    c.meta,
+		ACLPolicies:             c.aclPolicies,
+		ClearACLPolicies:        c.clearACLPolicies,
 		ServerExternalAddresses: addresses,
 	}
 
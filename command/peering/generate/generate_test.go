@@ -68,6 +68,22 @@ func TestGenerateCommand(t *testing.T) {
 		require.Contains(t, output, "Invalid format")
 	})
 
+	t.Run("clearing and setting ACL policies", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := New(ui)
+
+		args := []string{
+			"-http-addr=" + a.HTTPAddr(),
+			"-name=foo",
+			"-acl-policy-name=foo-exports",
+			"-clear-acl-policies",
+		}
+
+		code := cmd.Run(args)
+		require.Equal(t, 1, code, "exited successfully when it should have failed")
+		require.Contains(t, ui.ErrorWriter.String(), "cannot be combined")
+	})
+
 	t.Run("generate token", func(t *testing.T) {
 		ui := cli.NewMockUi()
 		cmd := New(ui)
@@ -120,6 +120,12 @@ func formatPeering(peering *api.Peering) string {
 			buffer.WriteString(fmt.Sprintf("    %s=%s\n", k, v))
 		}
 	}
+	if len(peering.ACLPolicies) > 0 {
+		buffer.WriteString("ACL Policies:\n")
+		for _, name := range peering.ACLPolicies {
+			buffer.WriteString(fmt.Sprintf("    %s\n", name))
+		}
+	}
 
 	buffer.WriteString("\n")
 	buffer.WriteString(fmt.Sprintf("Peer ID:               %s\n", peering.PeerID))
@@ -24,6 +24,10 @@ import (
 	aclbrread "github.com/hashicorp/consul/command/acl/bindingrule/read"
 	aclbrupdate "github.com/hashicorp/consul/command/acl/bindingrule/update"
 	aclbootstrap "github.com/hashicorp/consul/command/acl/bootstrap"
+	aclbg "github.com/hashicorp/consul/command/acl/breakglass"
+	aclbginit "github.com/hashicorp/consul/command/acl/breakglass/init"
+	aclbgstatus "github.com/hashicorp/consul/command/acl/breakglass/status"
+	aclbgunseal "github.com/hashicorp/consul/command/acl/breakglass/unseal"
 	aclpolicy "github.com/hashicorp/consul/command/acl/policy"
 	aclpcreate "github.com/hashicorp/consul/command/acl/policy/create"
 	aclpdelete "github.com/hashicorp/consul/command/acl/policy/delete"
@@ -54,6 +58,7 @@ import (
 	catlistsvc "github.com/hashicorp/consul/command/catalog/list/services"
 	"github.com/hashicorp/consul/command/config"
 	configdelete "github.com/hashicorp/consul/command/config/delete"
+	configlint "github.com/hashicorp/consul/command/config/lint"
 	configlist "github.com/hashicorp/consul/command/config/list"
 	configread "github.com/hashicorp/consul/command/config/read"
 	configwrite "github.com/hashicorp/consul/command/config/write"
@@ -61,9 +66,13 @@ import (
 	"github.com/hashicorp/consul/command/connect/ca"
 	caget "github.com/hashicorp/consul/command/connect/ca/get"
 	caset "github.com/hashicorp/consul/command/connect/ca/set"
+	"github.com/hashicorp/consul/command/connect/discoverychain"
+	discoverychainexplain "github.com/hashicorp/consul/command/connect/discoverychain/explain"
 	"github.com/hashicorp/consul/command/connect/envoy"
 	pipebootstrap "github.com/hashicorp/consul/command/connect/envoy/pipe-bootstrap"
 	"github.com/hashicorp/consul/command/connect/expose"
+	exposelocal "github.com/hashicorp/consul/command/connect/expose/local"
+	"github.com/hashicorp/consul/command/connect/meshaccess"
 	"github.com/hashicorp/consul/command/connect/proxy"
 	"github.com/hashicorp/consul/command/connect/redirecttraffic"
 	"github.com/hashicorp/consul/command/debug"
@@ -75,12 +84,15 @@ import (
 	ixncheck "github.com/hashicorp/consul/command/intention/check"
 	ixncreate "github.com/hashicorp/consul/command/intention/create"
 	ixndelete "github.com/hashicorp/consul/command/intention/delete"
+	ixnexp "github.com/hashicorp/consul/command/intention/exp"
 	ixnget "github.com/hashicorp/consul/command/intention/get"
+	ixnimp "github.com/hashicorp/consul/command/intention/imp"
 	ixnlist "github.com/hashicorp/consul/command/intention/list"
 	ixnmatch "github.com/hashicorp/consul/command/intention/match"
 	"github.com/hashicorp/consul/command/join"
 	"github.com/hashicorp/consul/command/keygen"
 	"github.com/hashicorp/consul/command/keyring"
+	keyringrotate "github.com/hashicorp/consul/command/keyring/rotate"
 	"github.com/hashicorp/consul/command/kv"
 	kvdel "github.com/hashicorp/consul/command/kv/del"
 	kvexp "github.com/hashicorp/consul/command/kv/exp"
@@ -98,11 +110,19 @@ import (
 	operauto "github.com/hashicorp/consul/command/operator/autopilot"
 	operautoget "github.com/hashicorp/consul/command/operator/autopilot/get"
 	operautoset "github.com/hashicorp/consul/command/operator/autopilot/set"
+	operautosimulate "github.com/hashicorp/consul/command/operator/autopilot/simulate"
 	operautostate "github.com/hashicorp/consul/command/operator/autopilot/state"
+	operkvenc "github.com/hashicorp/consul/command/operator/kvencryption"
+	operkvencrewrap "github.com/hashicorp/consul/command/operator/kvencryption/rewrap"
+	operkvencrotate "github.com/hashicorp/consul/command/operator/kvencryption/rotate"
+	operkvencstatus "github.com/hashicorp/consul/command/operator/kvencryption/status"
 	operraft "github.com/hashicorp/consul/command/operator/raft"
 	operraftlist "github.com/hashicorp/consul/command/operator/raft/listpeers"
 	operraftremove "github.com/hashicorp/consul/command/operator/raft/removepeer"
 	"github.com/hashicorp/consul/command/operator/raft/transferleader"
+	operupgrade "github.com/hashicorp/consul/command/operator/upgrade"
+	operupgradeexecute "github.com/hashicorp/consul/command/operator/upgrade/execute"
+	operupgradeplan "github.com/hashicorp/consul/command/operator/upgrade/plan"
 	"github.com/hashicorp/consul/command/operator/usage"
 	"github.com/hashicorp/consul/command/operator/usage/instances"
 	"github.com/hashicorp/consul/command/peering"
@@ -139,6 +159,7 @@ import (
 	tlscert "github.com/hashicorp/consul/command/tls/cert"
 	tlscertcreate "github.com/hashicorp/consul/command/tls/cert/create"
 	"github.com/hashicorp/consul/command/troubleshoot"
+	troubleshootpath "github.com/hashicorp/consul/command/troubleshoot/path"
 	troubleshootports "github.com/hashicorp/consul/command/troubleshoot/ports"
 	troubleshootproxy "github.com/hashicorp/consul/command/troubleshoot/proxy"
 	troubleshootupstreams "github.com/hashicorp/consul/command/troubleshoot/upstreams"
@@ -158,6 +179,10 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 	registerCommands(ui, registry,
 		entry{"acl", func(cli.Ui) (cli.Command, error) { return acl.New(), nil }},
 		entry{"acl bootstrap", func(ui cli.Ui) (cli.Command, error) { return aclbootstrap.New(ui), nil }},
+		entry{"acl break-glass", func(cli.Ui) (cli.Command, error) { return aclbg.New(), nil }},
+		entry{"acl break-glass init", func(ui cli.Ui) (cli.Command, error) { return aclbginit.New(ui), nil }},
+		entry{"acl break-glass status", func(ui cli.Ui) (cli.Command, error) { return aclbgstatus.New(ui), nil }},
+		entry{"acl break-glass unseal", func(ui cli.Ui) (cli.Command, error) { return aclbgunseal.New(ui), nil }},
 		entry{"acl policy", func(cli.Ui) (cli.Command, error) { return aclpolicy.New(), nil }},
 		entry{"acl policy create", func(ui cli.Ui) (cli.Command, error) { return aclpcreate.New(ui), nil }},
 		entry{"acl policy list", func(ui cli.Ui) (cli.Command, error) { return aclplist.New(ui), nil }},
@@ -201,6 +226,7 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"catalog services", func(ui cli.Ui) (cli.Command, error) { return catlistsvc.New(ui), nil }},
 		entry{"config", func(ui cli.Ui) (cli.Command, error) { return config.New(), nil }},
 		entry{"config delete", func(ui cli.Ui) (cli.Command, error) { return configdelete.New(ui), nil }},
+		entry{"config lint", func(ui cli.Ui) (cli.Command, error) { return configlint.New(ui), nil }},
 		entry{"config list", func(ui cli.Ui) (cli.Command, error) { return configlist.New(ui), nil }},
 		entry{"config read", func(ui cli.Ui) (cli.Command, error) { return configread.New(ui), nil }},
 		entry{"config write", func(ui cli.Ui) (cli.Command, error) { return configwrite.New(ui), nil }},
@@ -208,10 +234,14 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"connect ca", func(ui cli.Ui) (cli.Command, error) { return ca.New(), nil }},
 		entry{"connect ca get-config", func(ui cli.Ui) (cli.Command, error) { return caget.New(ui), nil }},
 		entry{"connect ca set-config", func(ui cli.Ui) (cli.Command, error) { return caset.New(ui), nil }},
+		entry{"connect discovery-chain", func(ui cli.Ui) (cli.Command, error) { return discoverychain.New(), nil }},
+		entry{"connect discovery-chain explain", func(ui cli.Ui) (cli.Command, error) { return discoverychainexplain.New(ui), nil }},
+		entry{"connect mesh-access", func(ui cli.Ui) (cli.Command, error) { return meshaccess.New(ui, MakeShutdownCh()), nil }},
 		entry{"connect proxy", func(ui cli.Ui) (cli.Command, error) { return proxy.New(ui, MakeShutdownCh()), nil }},
 		entry{"connect envoy", func(ui cli.Ui) (cli.Command, error) { return envoy.New(ui), nil }},
 		entry{"connect envoy pipe-bootstrap", func(ui cli.Ui) (cli.Command, error) { return pipebootstrap.New(ui), nil }},
 		entry{"connect expose", func(ui cli.Ui) (cli.Command, error) { return expose.New(ui), nil }},
+		entry{"connect expose local", func(ui cli.Ui) (cli.Command, error) { return exposelocal.New(ui, MakeShutdownCh()), nil }},
 		entry{"connect redirect-traffic", func(ui cli.Ui) (cli.Command, error) { return redirecttraffic.New(ui), nil }},
 		entry{"debug", func(ui cli.Ui) (cli.Command, error) { return debug.New(ui), nil }},
 		entry{"event", func(ui cli.Ui) (cli.Command, error) { return event.New(ui), nil }},
@@ -222,12 +252,15 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"intention check", func(ui cli.Ui) (cli.Command, error) { return ixncheck.New(ui), nil }},
 		entry{"intention create", func(ui cli.Ui) (cli.Command, error) { return ixncreate.New(ui), nil }},
 		entry{"intention delete", func(ui cli.Ui) (cli.Command, error) { return ixndelete.New(ui), nil }},
+		entry{"intention export", func(ui cli.Ui) (cli.Command, error) { return ixnexp.New(ui), nil }},
 		entry{"intention get", func(ui cli.Ui) (cli.Command, error) { return ixnget.New(ui), nil }},
+		entry{"intention import", func(ui cli.Ui) (cli.Command, error) { return ixnimp.New(ui), nil }},
 		entry{"intention list", func(ui cli.Ui) (cli.Command, error) { return ixnlist.New(ui), nil }},
 		entry{"intention match", func(ui cli.Ui) (cli.Command, error) { return ixnmatch.New(ui), nil }},
 		entry{"join", func(ui cli.Ui) (cli.Command, error) { return join.New(ui), nil }},
 		entry{"keygen", func(ui cli.Ui) (cli.Command, error) { return keygen.New(ui), nil }},
 		entry{"keyring", func(ui cli.Ui) (cli.Command, error) { return keyring.New(ui), nil }},
+		entry{"keyring rotate", func(ui cli.Ui) (cli.Command, error) { return keyringrotate.New(ui), nil }},
 		entry{"kv", func(cli.Ui) (cli.Command, error) { return kv.New(), nil }},
 		entry{"kv delete", func(ui cli.Ui) (cli.Command, error) { return kvdel.New(ui), nil }},
 		entry{"kv export", func(ui cli.Ui) (cli.Command, error) { return kvexp.New(ui), nil }},
@@ -246,10 +279,18 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"operator autopilot get-config", func(ui cli.Ui) (cli.Command, error) { return operautoget.New(ui), nil }},
 		entry{"operator autopilot set-config", func(ui cli.Ui) (cli.Command, error) { return operautoset.New(ui), nil }},
 		entry{"operator autopilot state", func(ui cli.Ui) (cli.Command, error) { return operautostate.New(ui), nil }},
+		entry{"operator autopilot simulate", func(ui cli.Ui) (cli.Command, error) { return operautosimulate.New(ui), nil }},
+		entry{"operator kv-encryption", func(cli.Ui) (cli.Command, error) { return operkvenc.New(), nil }},
+		entry{"operator kv-encryption rewrap", func(ui cli.Ui) (cli.Command, error) { return operkvencrewrap.New(ui), nil }},
+		entry{"operator kv-encryption rotate", func(ui cli.Ui) (cli.Command, error) { return operkvencrotate.New(ui), nil }},
+		entry{"operator kv-encryption status", func(ui cli.Ui) (cli.Command, error) { return operkvencstatus.New(ui), nil }},
 		entry{"operator raft", func(cli.Ui) (cli.Command, error) { return operraft.New(), nil }},
 		entry{"operator raft list-peers", func(ui cli.Ui) (cli.Command, error) { return operraftlist.New(ui), nil }},
 		entry{"operator raft remove-peer", func(ui cli.Ui) (cli.Command, error) { return operraftremove.New(ui), nil }},
 		entry{"operator raft transfer-leader", func(ui cli.Ui) (cli.Command, error) { return transferleader.New(ui), nil }},
+		entry{"operator upgrade", func(cli.Ui) (cli.Command, error) { return operupgrade.New(), nil }},
+		entry{"operator upgrade execute", func(ui cli.Ui) (cli.Command, error) { return operupgradeexecute.New(ui), nil }},
+		entry{"operator upgrade plan", func(ui cli.Ui) (cli.Command, error) { return operupgradeplan.New(ui), nil }},
 		entry{"operator usage", func(ui cli.Ui) (cli.Command, error) { return usage.New(), nil }},
 		entry{"operator usage instances", func(ui cli.Ui) (cli.Command, error) { return instances.New(ui), nil }},
 		entry{"peering", func(cli.Ui) (cli.Command, error) { return peering.New(), nil }},
@@ -287,6 +328,7 @@ func RegisteredCommands(ui cli.Ui) map[string]mcli.CommandFactory {
 		entry{"tls cert", func(ui cli.Ui) (cli.Command, error) { return tlscert.New(), nil }},
 		entry{"tls cert create", func(ui cli.Ui) (cli.Command, error) { return tlscertcreate.New(ui), nil }},
 		entry{"troubleshoot", func(ui cli.Ui) (cli.Command, error) { return troubleshoot.New(), nil }},
+		entry{"troubleshoot path", func(ui cli.Ui) (cli.Command, error) { return troubleshootpath.New(ui), nil }},
 		entry{"troubleshoot proxy", func(ui cli.Ui) (cli.Command, error) { return troubleshootproxy.New(ui), nil }},
 		entry{"troubleshoot upstreams", func(ui cli.Ui) (cli.Command, error) { return troubleshootupstreams.New(ui), nil }},
 		entry{"troubleshoot ports", func(ui cli.Ui) (cli.Command, error) { return troubleshootports.New(ui), nil }},
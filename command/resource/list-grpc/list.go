@@ -33,6 +33,7 @@ type cmd struct {
 
 	filePath string
 	prefix   string
+	filter   string
 }
 
 func (c *cmd) init() {
@@ -41,6 +42,8 @@ func (c *cmd) init() {
 		"File path with resource definition")
 	c.flags.StringVar(&c.prefix, "p", "",
 		"Name prefix for listing resources if you need ambiguous match")
+	c.flags.StringVar(&c.filter, "filter", "",
+		"Filter to use with the request, matched against the metadata of the resources (e.g. 'metadata.team == \"payments\"')")
 
 	c.grpcFlags = &client.GRPCFlags{}
 	c.resourceFlags = &client.ResourceFlags{}
@@ -126,7 +129,7 @@ func (c *cmd) Run(args []string) int {
 
 	// list resource
 	res := resource.ResourceGRPC{C: resourceClient}
-	entry, err := res.List(resourceType, resourceTenancy, c.prefix, c.resourceFlags.Stale())
+	entry, err := res.List(resourceType, resourceTenancy, c.prefix, c.filter, c.resourceFlags.Stale())
 	if err != nil {
 		c.UI.Error(fmt.Sprintf("Error listing resource %s/%s: %v", resourceType, c.prefix, err))
 		return 1
@@ -179,6 +182,8 @@ $ consul resource list catalog.v2beta1.Service -p=card -partition=billing -names
 
 $ consul resource list -f=demo.hcl -p=card
 
+$ consul resource list catalog.v2beta1.Service -filter='metadata.team == "payments"'
+
 Sample demo.hcl:
 
 ID {
@@ -68,6 +68,26 @@ func TestResourceListCommand(t *testing.T) {
 				"-namespace=default",
 			},
 		},
+		{
+			name:   "sample output with filter",
+			output: "\"name\": \"korn\"",
+			extraArgs: []string{
+				"demo.v2.Artist",
+				`-filter=metadata.foo == "bar"`,
+				"-partition=default",
+				"-namespace=default",
+			},
+		},
+		{
+			name:   "sample output with non-matching filter",
+			output: "null",
+			extraArgs: []string{
+				"demo.v2.Artist",
+				`-filter=metadata.foo == "baz"`,
+				"-partition=default",
+				"-namespace=default",
+			},
+		},
 		{
 			name:   "file input",
 			output: "\"name\": \"korn\"",
@@ -69,7 +69,7 @@ func (resource *ResourceGRPC) Read(resourceType *pbresource.Type, resourceTenanc
 	return readRsp.Resource, err
 }
 
-func (resource *ResourceGRPC) List(resourceType *pbresource.Type, resourceTenancy *pbresource.Tenancy, prefix string, stale bool) ([]*pbresource.Resource, error) {
+func (resource *ResourceGRPC) List(resourceType *pbresource.Type, resourceTenancy *pbresource.Tenancy, prefix string, filter string, stale bool) ([]*pbresource.Resource, error) {
 	token, err := resource.C.Config.GetToken()
 	if err != nil {
 		return nil, err
@@ -87,6 +87,7 @@ func (resource *ResourceGRPC) List(resourceType *pbresource.Type, resourceTenanc
 		Type:       resourceType,
 		Tenancy:    resourceTenancy,
 		NamePrefix: prefix,
+		Filter:     filter,
 	})
 
 	if err != nil {
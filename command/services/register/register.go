@@ -6,6 +6,7 @@ package register
 import (
 	"flag"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/command/flags"
@@ -35,8 +36,14 @@ type cmd struct {
 	flagTags            []string
 	flagMeta            map[string]string
 	flagTaggedAddresses map[string]string
+	flagWaitHealthy     bool
+	flagWaitTimeout     time.Duration
 }
 
+// waitHealthyInterval is how often the health of the registered services is
+// checked when waiting for them to be healthy.
+const waitHealthyInterval = time.Second
+
 func (c *cmd) init() {
 	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
 	c.flags.StringVar(&c.flagId, "id", "",
@@ -60,6 +67,13 @@ func (c *cmd) init() {
 		"Tagged address to set on the service, formatted as key=value. This flag "+
 			"may be specified multiple times to set multiple addresses.")
 	c.flags.StringVar(&c.flagKind, "kind", "", "The services 'kind'")
+	c.flags.BoolVar(&c.flagWaitHealthy, "wait-healthy", false,
+		"Wait for the checks of the registered services to be passing before "+
+			"returning. The command fails if they are not passing within "+
+			"-wait-timeout.")
+	c.flags.DurationVar(&c.flagWaitTimeout, "wait-timeout", time.Minute,
+		"How long to wait for the registered services to be healthy with "+
+			"-wait-healthy.")
 
 	c.http = &flags.HTTPFlags{}
 	flags.Merge(c.flags, c.http.ClientFlags())
@@ -107,6 +121,10 @@ func (c *cmd) Run(args []string) int {
 		c.UI.Error("Service registration requires arguments or -id, not both.")
 		return 1
 	}
+	if c.flagWaitHealthy && c.flagWaitTimeout <= 0 {
+		c.UI.Error("The -wait-timeout value must be positive.")
+		return 1
+	}
 
 	if len(args) > 0 {
 		var err error
@@ -135,9 +153,50 @@ func (c *cmd) Run(args []string) int {
 		c.UI.Output(fmt.Sprintf("Registered service: %s", svc.Name))
 	}
 
+	if c.flagWaitHealthy {
+		if err := c.waitHealthy(client, svcs); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+	}
+
 	return 0
 }
 
+// waitHealthy waits for the checks of the services to be passing, until
+// -wait-timeout elapses. The local agent doesn't support blocking queries on
+// the health of its services, so their health is polled.
+func (c *cmd) waitHealthy(client *api.Client, svcs []*api.AgentServiceRegistration) error {
+	deadline := time.Now().Add(c.flagWaitTimeout)
+	for _, svc := range svcs {
+		id := svc.ID
+		if id == "" {
+			id = svc.Name
+		}
+
+		for {
+			status, _, err := client.Agent().AgentHealthServiceByID(id)
+			if err != nil {
+				return fmt.Errorf("Error checking the health of service %q: %s", svc.Name, err)
+			}
+			if status == api.HealthPassing {
+				c.UI.Output(fmt.Sprintf("Service is healthy: %s", svc.Name))
+				break
+			}
+
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return fmt.Errorf("Timed out waiting for service %q to be healthy, its checks are %s", svc.Name, status)
+			}
+			if remaining > waitHealthyInterval {
+				remaining = waitHealthyInterval
+			}
+			time.Sleep(remaining)
+		}
+	}
+	return nil
+}
+
 func (c *cmd) Synopsis() string {
 	return synopsis
 }
@@ -159,6 +218,11 @@ Usage: consul services register [options] [FILE...]
 
       $ consul services register web.json
 
+  To wait for the checks of the services to be passing before returning,
+  for example in deploy scripts, use -wait-healthy:
+
+      $ consul services register -wait-healthy -wait-timeout=2m web.json
+
   Additional flags and more advanced use cases are detailed below.
 `
 )
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/agent"
 	"github.com/hashicorp/consul/api"
@@ -40,6 +41,10 @@ func TestCommand_Validation(t *testing.T) {
 			[]string{"-name", "web", "foo.json"},
 			"not both",
 		},
+		"negative -wait-timeout": {
+			[]string{"-name", "web", "-wait-healthy", "-wait-timeout", "-1s"},
+			"must be positive",
+		},
 	}
 
 	for name, tc := range cases {
@@ -168,6 +173,59 @@ func TestCommand_Flags_TaggedAddresses(t *testing.T) {
 	require.Equal(t, svc.TaggedAddresses["v6"].Port, 1234)
 }
 
+func TestCommand_WaitHealthy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	a := agent.NewTestAgent(t, ``)
+	defer a.Shutdown()
+	client := a.Client()
+
+	contents := `{ "Service": { "Name": "web", "Check": { "TTL": "10s" } } }`
+	f := testFile(t, "json")
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(contents); err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+
+	// The TTL check is critical until it is updated.
+	ui := cli.NewMockUi()
+	args := []string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-wait-healthy",
+		"-wait-timeout=1s",
+		f.Name(),
+	}
+	require.Equal(t, 1, New(ui).Run(args))
+	require.Contains(t, ui.ErrorWriter.String(), `Timed out waiting for service "web" to be healthy`)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(200 * time.Millisecond):
+				_ = client.Agent().UpdateTTL("service:web", "", api.HealthPassing)
+			}
+		}
+	}()
+
+	ui = cli.NewMockUi()
+	args = []string{
+		"-http-addr=" + a.HTTPAddr(),
+		"-wait-healthy",
+		"-wait-timeout=30s",
+		f.Name(),
+	}
+	require.Equal(t, 0, New(ui).Run(args), ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Service is healthy: web")
+}
+
 func TestCommand_FileWithUnnamedCheck(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -38,6 +38,8 @@ var requestTypeZeroValues = map[structs.MessageType]func() any{
 	structs.ConnectCARequestType:         func() any { return new(structs.CARequest) },
 	structs.ConnectCAProviderStateType:   func() any { return new(structs.CAConsulProviderState) },
 	structs.ConnectCAConfigType:          func() any { return new(structs.CAConfiguration) },
+	structs.ConnectCARevocationType:      func() any { return new(structs.CARevokedCert) },
+	structs.ConnectCARevocationListsType: func() any { return new(structs.CARevocationLists) },
 	structs.IndexRequestType:             func() any { return new(state.IndexEntry) },
 	structs.ACLTokenSetRequestType:       func() any { return new(structs.ACLToken) },
 	structs.ACLPolicySetRequestType:      func() any { return new(structs.ACLPolicy) },
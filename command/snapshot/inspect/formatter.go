@@ -72,6 +72,33 @@ func (_ *prettyFormatter) Format(info *OutputFormat) (string, error) {
 		fmt.Fprintf(tw, "\n Total\t\t%s", ByteSize(uint64(info.TotalSizeKV)))
 	}
 
+	if info.StatsKVTop != nil {
+		fmt.Fprintf(tw, "\n")
+		fmt.Fprintln(tw, "\n Largest Keys\tSize")
+		fmt.Fprintf(tw, " %s\t%s", "----", "----")
+		for _, s := range info.StatsKVTop {
+			fmt.Fprintf(tw, "\n %s\t%s", s.Name, ByteSize(uint64(s.Sum)))
+		}
+	}
+
+	if info.StatsConfigEntries != nil {
+		fmt.Fprintf(tw, "\n")
+		fmt.Fprintln(tw, "\n Config Entry Kind\tCount\tSize")
+		fmt.Fprintf(tw, " %s\t%s\t%s", "----", "----", "----")
+		for _, s := range info.StatsConfigEntries {
+			fmt.Fprintf(tw, "\n %s\t%d\t%s", s.Name, s.Count, ByteSize(uint64(s.Sum)))
+		}
+	}
+
+	if info.StatsResources != nil {
+		fmt.Fprintf(tw, "\n")
+		fmt.Fprintln(tw, "\n Resource Type\tCount\tSize")
+		fmt.Fprintf(tw, " %s\t%s\t%s", "----", "----", "----")
+		for _, s := range info.StatsResources {
+			fmt.Fprintf(tw, "\n %s\t%d\t%s", s.Name, s.Count, ByteSize(uint64(s.Sum)))
+		}
+	}
+
 	if err := tw.Flush(); err != nil {
 		return b.String(), err
 	}
@@ -21,6 +21,21 @@ func TestFormat(t *testing.T) {
 		Sum:   1,
 		Count: 2,
 	}}
+	mtop := []typeStats{{
+		Name:  "msgKV/key",
+		Sum:   1,
+		Count: 1,
+	}}
+	mce := []typeStats{{
+		Name:  "service-defaults",
+		Sum:   1,
+		Count: 2,
+	}}
+	mres := []typeStats{{
+		Name:  "demo.v2.Artist",
+		Sum:   1,
+		Count: 2,
+	}}
 	info := OutputFormat{
 		Meta: &MetadataInfo{
 			ID:      "one",
@@ -29,10 +44,13 @@ func TestFormat(t *testing.T) {
 			Term:    4,
 			Version: 1,
 		},
-		Stats:       m,
-		StatsKV:     mkv,
-		TotalSize:   1,
-		TotalSizeKV: 1,
+		Stats:              m,
+		StatsKV:            mkv,
+		StatsKVTop:         mtop,
+		StatsConfigEntries: mce,
+		StatsResources:     mres,
+		TotalSize:          1,
+		TotalSizeKV:        1,
 	}
 
 	formatters := map[string]Formatter{
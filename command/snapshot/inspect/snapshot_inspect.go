@@ -17,10 +17,13 @@ import (
 	"github.com/hashicorp/consul/agent/consul/fsm"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/command/flags"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/proto-public/pbresource"
 	"github.com/hashicorp/consul/snapshot"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/raft"
 	"github.com/mitchellh/cli"
+	"google.golang.org/protobuf/proto"
 )
 
 func New(ui cli.Ui) *cmd {
@@ -36,9 +39,12 @@ type cmd struct {
 	format string
 
 	// flags
-	kvDetails bool
-	kvDepth   int
-	kvFilter  string
+	kvDetails   bool
+	kvDepth     int
+	kvFilter    string
+	kvRedact    []string
+	kvTop       int
+	typeDetails bool
 }
 
 func (c *cmd) init() {
@@ -49,6 +55,14 @@ func (c *cmd) init() {
 		"Can only be used with -kvdetails. The key prefix depth used to breakdown KV store data. Defaults to 2.")
 	c.flags.StringVar(&c.kvFilter, "kvfilter", "",
 		"Can only be used with -kvdetails. Limits KV key breakdown using this prefix filter.")
+	c.flags.Var((*flags.AppendSliceValue)(&c.kvRedact), "kvredact",
+		"Can only be used with -kvdetails. Hides the names of the keys under this prefix, "+
+			"which are reported as the prefix followed by \"<hidden>\". This flag may be "+
+			"specified multiple times.")
+	c.flags.IntVar(&c.kvTop, "kvtop", 0,
+		"Can only be used with -kvdetails. Lists the given number of largest KV entries. Defaults to 0 (disabled).")
+	c.flags.BoolVar(&c.typeDetails, "typedetails", false,
+		"Provides a space usage breakdown of config entries by kind and of resources by type.")
 	c.flags.StringVar(
 		&c.format,
 		"format",
@@ -71,21 +85,27 @@ type MetadataInfo struct {
 // SnapshotInfo is used for passing snapshot stat
 // information between functions
 type SnapshotInfo struct {
-	Meta        MetadataInfo
-	Stats       map[structs.MessageType]typeStats
-	StatsKV     map[string]typeStats
-	TotalSize   int
-	TotalSizeKV int
+	Meta               MetadataInfo
+	Stats              map[structs.MessageType]typeStats
+	StatsKV            map[string]typeStats
+	StatsKVTop         []typeStats
+	StatsConfigEntries map[string]typeStats
+	StatsResources     map[string]typeStats
+	TotalSize          int
+	TotalSizeKV        int
 }
 
 // OutputFormat is used for passing information
 // through the formatter
 type OutputFormat struct {
-	Meta        *MetadataInfo
-	Stats       []typeStats
-	StatsKV     []typeStats
-	TotalSize   int
-	TotalSizeKV int
+	Meta               *MetadataInfo
+	Stats              []typeStats
+	StatsKV            []typeStats
+	StatsKVTop         []typeStats `json:",omitempty"`
+	StatsConfigEntries []typeStats `json:",omitempty"`
+	StatsResources     []typeStats `json:",omitempty"`
+	TotalSize          int
+	TotalSizeKV        int
 }
 
 func (c *cmd) Run(args []string) int {
@@ -94,6 +114,11 @@ func (c *cmd) Run(args []string) int {
 		return 1
 	}
 
+	if c.kvTop < 0 {
+		c.UI.Error("-kvtop must not be negative")
+		return 1
+	}
+
 	var file string
 	args = c.flags.Args()
 
@@ -178,11 +203,14 @@ func (c *cmd) Run(args []string) int {
 	formattedStatsKV := generateKVStats(info)
 
 	in := &OutputFormat{
-		Meta:        metaformat,
-		Stats:       formattedStats,
-		StatsKV:     formattedStatsKV,
-		TotalSize:   info.TotalSize,
-		TotalSizeKV: info.TotalSizeKV,
+		Meta:               metaformat,
+		Stats:              formattedStats,
+		StatsKV:            formattedStatsKV,
+		StatsKVTop:         info.StatsKVTop,
+		StatsConfigEntries: generateNamedStats(info.StatsConfigEntries),
+		StatsResources:     generateNamedStats(info.StatsResources),
+		TotalSize:          info.TotalSize,
+		TotalSizeKV:        info.TotalSizeKV,
 	}
 
 	out, err := formatter.Format(in)
@@ -235,6 +263,21 @@ func generateKVStats(info SnapshotInfo) []typeStats {
 	return nil
 }
 
+// generateNamedStats reformats a breakdown keyed by name (such
+// as config entry kinds or resource types) into a sorted slice.
+func generateNamedStats(stats map[string]typeStats) []typeStats {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	ss := make([]typeStats, 0, len(stats))
+	for _, s := range stats {
+		ss = append(ss, s)
+	}
+
+	return sortTypeStats(ss)
+}
+
 // sortTypeStats sorts the stat slice by size and then
 // alphabetically in the case the size is identical
 func sortTypeStats(stats []typeStats) []typeStats {
@@ -269,10 +312,12 @@ func (r *countingReader) Read(p []byte) (n int, err error) {
 // all of the snapshot's itemized data
 func (c *cmd) enhance(file io.Reader) (SnapshotInfo, error) {
 	info := SnapshotInfo{
-		Stats:       make(map[structs.MessageType]typeStats),
-		StatsKV:     make(map[string]typeStats),
-		TotalSize:   0,
-		TotalSizeKV: 0,
+		Stats:              make(map[structs.MessageType]typeStats),
+		StatsKV:            make(map[string]typeStats),
+		StatsConfigEntries: make(map[string]typeStats),
+		StatsResources:     make(map[string]typeStats),
+		TotalSize:          0,
+		TotalSizeKV:        0,
 	}
 	cr := &countingReader{wrappedReader: file}
 	handler := func(header *fsm.SnapshotHeader, msg structs.MessageType, dec *codec.Decoder) error {
@@ -295,6 +340,11 @@ func (c *cmd) enhance(file io.Reader) (SnapshotInfo, error) {
 		info.Stats[msg] = s
 
 		c.kvEnhance(s.Name, val, size, &info)
+		if c.typeDetails {
+			if err := typeEnhance(msg, val, size, &info); err != nil {
+				return fmt.Errorf("failed to inspect msg type %v, error %v", name, err)
+			}
+		}
 
 		return nil
 	}
@@ -328,7 +378,8 @@ func (c *cmd) kvEnhance(keyType string, val interface{}, size int, info *Snapsho
 				break
 			}
 
-			split := strings.Split(v.(string), "/")
+			key := c.redactKey(v.(string))
+			split := strings.Split(key, "/")
 
 			// handle the situation where the key is shorter than
 			// the specified depth.
@@ -346,8 +397,102 @@ func (c *cmd) kvEnhance(keyType string, val interface{}, size int, info *Snapsho
 			kvs.Count++
 			info.TotalSizeKV += size
 			info.StatsKV[prefix] = kvs
+
+			c.kvTopEnhance(key, size, info)
+		}
+	}
+}
+
+// redactKey hides the part of the key name after any of the
+// -kvredact prefixes.
+func (c *cmd) redactKey(key string) string {
+	for _, prefix := range c.kvRedact {
+		if strings.HasPrefix(key, prefix) {
+			return prefix + structs.RedactedValue
 		}
 	}
+	return key
+}
+
+// kvTopEnhance records the key if it is amongst the largest
+// -kvtop entries seen so far. StatsKVTop is kept sorted.
+func (c *cmd) kvTopEnhance(key string, size int, info *SnapshotInfo) {
+	if c.kvTop == 0 {
+		return
+	}
+
+	top := info.StatsKVTop
+	if len(top) == c.kvTop && size <= top[len(top)-1].Sum {
+		return
+	}
+
+	entry := typeStats{Name: key, Sum: size, Count: 1}
+	idx := sort.Search(len(top), func(i int) bool {
+		if top[i].Sum == size {
+			return top[i].Name > key
+		}
+		return top[i].Sum < size
+	})
+	top = append(top, typeStats{})
+	copy(top[idx+1:], top[idx:])
+	top[idx] = entry
+	if len(top) > c.kvTop {
+		top = top[:c.kvTop]
+	}
+	info.StatsKVTop = top
+}
+
+// typeEnhance populates the struct with the size of config
+// entries by kind and of resources by type.
+func typeEnhance(msg structs.MessageType, val interface{}, size int, info *SnapshotInfo) error {
+	var name string
+	var stats map[string]typeStats
+
+	switch msg {
+	case structs.ConfigEntryRequestType:
+		// Config entries are persisted as a ConfigEntryRequest whose
+		// binary encoding is prefixed with the entry's kind.
+		raw, ok := rawBytes(val)
+		if !ok {
+			return fmt.Errorf("unexpected config entry encoding %T", val)
+		}
+		if err := codec.NewDecoderBytes(raw, structs.MsgpackHandle).Decode(&name); err != nil {
+			return err
+		}
+		stats = info.StatsConfigEntries
+	case structs.ResourceOperationType:
+		raw, ok := rawBytes(val)
+		if !ok {
+			return fmt.Errorf("unexpected resource encoding %T", val)
+		}
+		var res pbresource.Resource
+		if err := proto.Unmarshal(raw, &res); err != nil {
+			return err
+		}
+		name = resource.ToGVK(res.GetId().GetType())
+		stats = info.StatsResources
+	default:
+		return nil
+	}
+
+	s := stats[name]
+	s.Name = name
+	s.Sum += size
+	s.Count++
+	stats[name] = s
+	return nil
+}
+
+// rawBytes returns the bytes of a binary value decoded into an
+// interface, which may be a string given the RawToString option.
+func rawBytes(val interface{}) ([]byte, bool) {
+	switch v := val.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	}
+	return nil, false
 }
 
 func (c *cmd) Synopsis() string {
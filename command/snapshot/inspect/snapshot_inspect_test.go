@@ -4,14 +4,21 @@
 package inspect
 
 import (
+	"bytes"
 	"flag"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/consul-net-rpc/go-msgpack/codec"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/consul/agent/consul/fsm"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto-public/pbresource"
 )
 
 // update allows golden files to be updated based on the current output.
@@ -153,6 +160,87 @@ func TestSnapshotInspectKVDetailsDepthFilterCommand(t *testing.T) {
 	require.Equal(t, want, ui.OutputWriter.String())
 }
 
+func TestSnapshotInspectKVTopCommand(t *testing.T) {
+
+	filepath := "./testdata/backupWithKV.snap"
+
+	// Inspect the snapshot
+	ui := cli.NewMockUi()
+	c := New(ui)
+	args := []string{"-kvdetails", "-kvtop", "3", filepath}
+
+	code := c.Run(args)
+	if code != 0 {
+		t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+	}
+
+	want := golden(t, t.Name(), ui.OutputWriter.String())
+	require.Equal(t, want, ui.OutputWriter.String())
+}
+
+func TestSnapshotInspectKVRedactCommand(t *testing.T) {
+
+	filepath := "./testdata/backupWithKV.snap"
+
+	// Inspect the snapshot
+	ui := cli.NewMockUi()
+	c := New(ui)
+	args := []string{"-kvdetails", "-kvdepth", "3", "-kvtop", "3", "-kvredact", "vault/logical/", "-kvredact", "vault/core/leader/", filepath}
+
+	code := c.Run(args)
+	if code != 0 {
+		t.Fatalf("bad: %d. %#v", code, ui.ErrorWriter.String())
+	}
+
+	want := golden(t, t.Name(), ui.OutputWriter.String())
+	require.Equal(t, want, ui.OutputWriter.String())
+	require.NotContains(t, ui.OutputWriter.String(), "0989e79e")
+}
+
+func TestSnapshotInspect_TypeDetails(t *testing.T) {
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf, structs.MsgpackHandle)
+	require.NoError(t, enc.Encode(&fsm.SnapshotHeader{LastIndex: 1}))
+
+	for _, entry := range []structs.ConfigEntry{
+		&structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "web"},
+		&structs.ServiceConfigEntry{Kind: structs.ServiceDefaults, Name: "api"},
+		&structs.ProxyConfigEntry{Kind: structs.ProxyDefaults, Name: structs.ProxyConfigGlobal},
+	} {
+		buf.WriteByte(byte(structs.ConfigEntryRequestType))
+		require.NoError(t, enc.Encode(&structs.ConfigEntryRequest{Entry: entry}))
+	}
+
+	res, err := proto.Marshal(&pbresource.Resource{
+		Id: &pbresource.ID{
+			Type: &pbresource.Type{Group: "demo", GroupVersion: "v2", Kind: "Artist"},
+			Name: "keith-urban",
+		},
+	})
+	require.NoError(t, err)
+	buf.WriteByte(byte(structs.ResourceOperationType))
+	require.NoError(t, enc.Encode(res))
+
+	c := New(cli.NewMockUi())
+	require.NoError(t, c.flags.Parse([]string{"-typedetails"}))
+
+	info, err := c.enhance(&buf)
+	require.NoError(t, err)
+
+	entries := generateNamedStats(info.StatsConfigEntries)
+	require.Len(t, entries, 2)
+	require.Equal(t, structs.ServiceDefaults, entries[0].Name)
+	require.Equal(t, 2, entries[0].Count)
+	require.Equal(t, structs.ProxyDefaults, entries[1].Name)
+	require.Equal(t, 1, entries[1].Count)
+
+	resources := generateNamedStats(info.StatsResources)
+	require.Len(t, resources, 1)
+	require.Equal(t, "demo.v2.Artist", resources[0].Name)
+	require.Equal(t, 1, resources[0].Count)
+	require.Equal(t, info.Stats[structs.ResourceOperationType].Sum, resources[0].Sum)
+}
+
 // TestSnapshotInspectCommandRaw test reading a snaphost directly from a raft
 // data dir.
 func TestSnapshotInspectCommandRaw(t *testing.T) {
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package path
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/hashicorp/consul/command/cli"
+	"github.com/hashicorp/consul/command/flags"
+	troubleshoot "github.com/hashicorp/consul/troubleshoot/proxy"
+)
+
+func New(ui cli.Ui) *cmd {
+	c := &cmd{UI: ui}
+	c.init()
+	return c
+}
+
+type cmd struct {
+	UI    cli.Ui
+	flags *flag.FlagSet
+	http  *flags.HTTPFlags
+	help  string
+
+	// flags
+	source             string
+	destination        string
+	upstreamEnvoyID    string
+	upstreamIP         string
+	envoyAdminEndpoint string
+	skipSourceProxy    bool
+}
+
+func (c *cmd) init() {
+	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	c.flags.StringVar(&c.source, "source", "", "The name of the service sending the request.")
+	c.flags.StringVar(&c.destination, "destination", "", "The name of the upstream service receiving the request.")
+	c.flags.StringVar(&c.upstreamEnvoyID, "upstream-envoy-id", os.Getenv("UPSTREAM_ENVOY_ID"), "The envoy identifier of the upstream in the source proxy. Defaults to the destination. (explicit upstreams only)")
+	c.flags.StringVar(&c.upstreamIP, "upstream-ip", os.Getenv("UPSTREAM_IP"), "The IP address of the upstream in the source proxy. (transparent proxy only)")
+	c.flags.BoolVar(&c.skipSourceProxy, "skip-source-proxy", false, "Skip validating the source's Envoy configuration, for example when not running next to the source proxy.")
+
+	defaultEnvoyAdminEndpoint := "localhost:19000"
+	if envoyAdminEndpoint := os.Getenv("ENVOY_ADMIN_ENDPOINT"); envoyAdminEndpoint != "" {
+		defaultEnvoyAdminEndpoint = envoyAdminEndpoint
+	}
+	c.flags.StringVar(&c.envoyAdminEndpoint, "envoy-admin-endpoint", defaultEnvoyAdminEndpoint, "The address:port that the source envoy's admin endpoint is on.")
+
+	c.http = &flags.HTTPFlags{}
+	flags.Merge(c.flags, c.http.ClientFlags())
+	flags.Merge(c.flags, c.http.ServerFlags())
+	c.help = flags.Usage(help, c.flags)
+}
+
+func (c *cmd) Run(args []string) int {
+	if err := c.flags.Parse(args); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to parse args: %v", err))
+		return 1
+	}
+
+	if c.source == "" || c.destination == "" {
+		c.UI.Error("-source and -destination are required.")
+		return 1
+	}
+
+	adminAddr, adminPort, err := net.SplitHostPort(c.envoyAdminEndpoint)
+	if err != nil {
+		c.UI.Error("Invalid Envoy Admin endpoint: " + err.Error())
+		return 1
+	}
+
+	// Envoy requires IP addresses to bind too when using static so resolve DNS or
+	// localhost here.
+	adminBindIP, err := net.ResolveIPAddr("ip", adminAddr)
+	if err != nil {
+		c.UI.Error("Failed to resolve Envoy admin endpoint: " + err.Error())
+		c.UI.Error("Please make sure Envoy's Admin API is enabled.")
+		return 1
+	}
+
+	client, err := c.http.APIClient()
+	if err != nil {
+		c.UI.Error("Error connecting to Consul agent: " + err.Error())
+		return 1
+	}
+
+	t, err := troubleshoot.NewTroubleshootWithClient(client, adminBindIP, adminPort)
+	if err != nil {
+		c.UI.Error("Error generating troubleshoot client: " + err.Error())
+		return 1
+	}
+	path, err := t.TracePath(troubleshoot.PathRequest{
+		Source:          c.source,
+		Destination:     c.destination,
+		UpstreamEnvoyID: c.upstreamEnvoyID,
+		UpstreamIP:      c.upstreamIP,
+		SkipSourceProxy: c.skipSourceProxy,
+	})
+	if err != nil {
+		c.UI.Error("Error tracing the request path: " + err.Error())
+		return 1
+	}
+
+	for _, hop := range path {
+		c.UI.HeaderOutput(hop.Name)
+		for _, o := range hop.Messages {
+			if o.Success {
+				c.UI.SuccessOutput(o.Message)
+			} else {
+				c.UI.ErrorOutput(o.Message)
+				for _, action := range o.PossibleActions {
+					c.UI.UnchangedOutput("-> " + action)
+				}
+			}
+		}
+	}
+
+	if failure := path.FirstFailure(); failure != nil {
+		c.UI.Output("")
+		c.UI.ErrorOutput("The request path is broken at: " + failure.Name)
+		return 2
+	}
+	c.UI.Output("")
+	c.UI.SuccessOutput(fmt.Sprintf("Every hop from %s to %s is valid", c.source, c.destination))
+	return 0
+}
+
+func (c *cmd) Synopsis() string {
+	return synopsis
+}
+
+func (c *cmd) Help() string {
+	return c.help
+}
+
+const (
+	synopsis = "Traces the request path between two services in the service mesh"
+	help     = `
+Usage: consul troubleshoot path -source <service> -destination <service> [options]
+
+  Traces a request from the source service's proxy through the destination's
+  discovery chain, any mesh gateways and intentions, to the healthy instances
+  of each target the chain resolves to. Each hop is validated and the first
+  misconfigured hop is reported.
+
+  The source proxy is validated through its Envoy admin endpoint, so run this
+  command next to the source proxy or pass -skip-source-proxy.
+
+  Example:
+
+    $ consul troubleshoot path -source web -destination db
+`
+)
@@ -43,5 +43,9 @@ Usage: consul troubleshoot <subcommand> [options]
 
     $ consul troubleshoot proxy -upstream [options]
 
+  Troubleshoot Request Path
+
+    $ consul troubleshoot path -source web -destination db
+
   For more examples, ask for subcommand help or view the documentation.
 `
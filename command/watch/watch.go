@@ -52,7 +52,7 @@ func (c *cmd) init() {
 	c.flags = flag.NewFlagSet("", flag.ContinueOnError)
 	c.flags.StringVar(&c.watchType, "type", "",
 		"Specifies the watch type. One of key, keyprefix, services, nodes, "+
-			"service, checks, or event.")
+			"service, checks, event, or member_events.")
 	c.flags.StringVar(&c.key, "key", "",
 		"Specifies the key to watch. Only for 'key' type.")
 	c.flags.StringVar(&c.prefix, "prefix", "",
@@ -41,12 +41,31 @@ type Config struct {
 	// Upstreams configures outgoing proxies for remote connect services.
 	Upstreams []UpstreamConfig `json:"upstreams" hcl:"upstreams"`
 
+	// ReusePort binds listeners with SO_REUSEPORT so that a listener can be
+	// bound while another socket is still bound to the same address, for
+	// example while an upstream listener is replaced or when a new proxy
+	// process starts before the old one exits.
+	ReusePort bool `json:"reuse_port" hcl:"reuse_port" mapstructure:"reuse_port"`
+
+	// DrainTimeoutMs is how long listeners that are removed or replaced by a
+	// configuration change wait for active connections to finish before
+	// closing them. Defaults to 5000 (5s).
+	DrainTimeoutMs int `json:"drain_timeout_ms" hcl:"drain_timeout_ms" mapstructure:"drain_timeout_ms"`
+
 	// Telemetry stores configuration for go-metrics. It is typically populated
 	// from the agent's runtime config via the proxy config endpoint so that the
 	// proxy will log metrics to the same location(s) as the agent.
 	Telemetry lib.TelemetryConfig
 }
 
+// DrainTimeout returns the drain timeout or the default value.
+func (c *Config) DrainTimeout() time.Duration {
+	if c.DrainTimeoutMs > 0 {
+		return time.Duration(c.DrainTimeoutMs) * time.Millisecond
+	}
+	return 5000 * time.Millisecond
+}
+
 // Service returns the *connect.Service structure represented by this config.
 func (c *Config) Service(client *api.Client, logger hclog.Logger) (*connect.Service, error) {
 	return connect.NewServiceWithConfig(c.ProxiedServiceName, connect.Config{Client: client, Logger: logger, ServerNextProtos: []string{}})
@@ -253,6 +272,15 @@ func (w *AgentConfigWatcher) handler(blockVal watch.BlockingParamVal,
 	if err != nil {
 		w.logger.Error("failed to parse public listener config", "error", err)
 	}
+	var listenerCfg struct {
+		ReusePort      bool `mapstructure:"reuse_port"`
+		DrainTimeoutMs int  `mapstructure:"drain_timeout_ms"`
+	}
+	if err := mapstructure.Decode(resp.Proxy.Config, &listenerCfg); err != nil {
+		w.logger.Error("failed to parse listener config", "error", err)
+	}
+	cfg.ReusePort = listenerCfg.ReusePort
+	cfg.DrainTimeoutMs = listenerCfg.DrainTimeoutMs
 	cfg.PublicListener.BindAddress = resp.Address
 	cfg.PublicListener.BindPort = resp.Port
 	if resp.Proxy.LocalServiceSocketPath != "" {
@@ -106,6 +106,8 @@ func TestAgentConfigWatcherSidecarProxy(t *testing.T) {
 				Proxy: &api.AgentServiceConnectProxyConfig{
 					Config: map[string]interface{}{
 						"handshake_timeout_ms": 999,
+						"reuse_port":           true,
+						"drain_timeout_ms":     2000,
 					},
 					Upstreams: []api.Upstream{
 						{
@@ -146,6 +148,8 @@ func TestAgentConfigWatcherSidecarProxy(t *testing.T) {
 				LocalBindAddress:     "127.0.0.1",
 			},
 		},
+		ReusePort:      true,
+		DrainTimeoutMs: 2000,
 	}
 	require.Equal(t, expectCfg, cfg)
 
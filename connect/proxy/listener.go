@@ -7,7 +7,10 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
+	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,10 +36,15 @@ type Listener struct {
 	Service *connect.Service
 
 	// listenFunc, dialFunc, and bindAddr are set by type-specific constructors.
-	listenFunc func() (net.Listener, error)
-	dialFunc   func() (net.Conn, error)
+	listenFunc func(lc *net.ListenConfig) (net.Listener, error)
+	dialFunc   func(src net.Conn) (net.Conn, error)
 	bindAddr   string
 
+	// reusePort binds the listener with SO_REUSEPORT so that it can be bound
+	// while another socket, such as the listener it replaces, is still bound
+	// to the same address.
+	reusePort bool
+
 	stopFlag int32
 	stopChan chan struct{}
 
@@ -69,10 +77,14 @@ func NewPublicListener(svc *connect.Service, cfg PublicListenerConfig,
 	bindAddr := ipaddr.FormatAddressPort(cfg.BindAddress, cfg.BindPort)
 	return &Listener{
 		Service: svc,
-		listenFunc: func() (net.Listener, error) {
-			return tls.Listen("tcp", bindAddr, svc.ServerTLSConfig())
+		listenFunc: func(lc *net.ListenConfig) (net.Listener, error) {
+			l, err := lc.Listen(context.Background(), "tcp", bindAddr)
+			if err != nil {
+				return nil, err
+			}
+			return tls.NewListener(l, svc.ServerTLSConfig()), nil
 		},
-		dialFunc: func() (net.Conn, error) {
+		dialFunc: func(net.Conn) (net.Conn, error) {
 			return net.DialTimeout("tcp", cfg.LocalServiceAddress,
 				time.Duration(cfg.LocalConnectTimeoutMs)*time.Millisecond)
 		},
@@ -107,10 +119,10 @@ func newUpstreamListenerWithResolver(svc *connect.Service, cfg UpstreamConfig,
 	bindAddr := ipaddr.FormatAddressPort(cfg.LocalBindAddress, cfg.LocalBindPort)
 	return &Listener{
 		Service: svc,
-		listenFunc: func() (net.Listener, error) {
-			return net.Listen("tcp", bindAddr)
+		listenFunc: func(lc *net.ListenConfig) (net.Listener, error) {
+			return lc.Listen(context.Background(), "tcp", bindAddr)
 		},
-		dialFunc: func() (net.Conn, error) {
+		dialFunc: func(net.Conn) (net.Conn, error) {
 			rf, err := resolverFunc(cfg)
 			if err != nil {
 				return nil, err
@@ -134,6 +146,73 @@ func newUpstreamListenerWithResolver(svc *connect.Service, cfg UpstreamConfig,
 	}
 }
 
+// newPeerCredUpstreamListener returns a Listener setup to listen on a Unix
+// domain socket for connections that are proxied to a discovered Connect
+// service instance. The identity presented upstream is chosen for each
+// connection by serviceForConn, from the credentials of the connecting
+// process.
+func newPeerCredUpstreamListener(serviceForConn func(net.Conn) (*connect.Service, error),
+	cfg UpstreamConfig, resolverFunc func(UpstreamConfig) (connect.Resolver, error),
+	logger hclog.Logger) *Listener {
+	return &Listener{
+		listenFunc: func(lc *net.ListenConfig) (net.Listener, error) {
+			return listenUnixSocket(lc, cfg.LocalBindSocketPath, cfg.LocalBindSocketMode)
+		},
+		dialFunc: func(src net.Conn) (net.Conn, error) {
+			svc, err := serviceForConn(src)
+			if err != nil {
+				return nil, err
+			}
+			rf, err := resolverFunc(cfg)
+			if err != nil {
+				return nil, err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(),
+				cfg.ConnectTimeout())
+			defer cancel()
+			return svc.Dial(ctx, rf)
+		},
+		bindAddr:      cfg.LocalBindSocketPath,
+		stopChan:      make(chan struct{}),
+		listeningChan: make(chan struct{}),
+		logger:        logger.Named(upstreamListenerPrefix),
+		metricPrefix:  upstreamListenerPrefix,
+		metricLabels: []metrics.Label{
+			{Name: "dst_type", Value: string(cfg.DestinationType)},
+			{Name: "dst", Value: cfg.DestinationName},
+		},
+	}
+}
+
+// listenUnixSocket listens on a Unix domain socket at path, replacing a stale
+// socket left by a previous run, and sets its permissions to the octal mode
+// if one is given.
+func listenUnixSocket(lc *net.ListenConfig, path, mode string) (net.Listener, error) {
+	var perm os.FileMode
+	if mode != "" {
+		m, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket mode %q: %w", mode, err)
+		}
+		perm = os.FileMode(m)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l, err := lc.Listen(context.Background(), "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if perm != 0 {
+		if err := os.Chmod(path, perm); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
 // Serve runs the listener until it is stopped. It is an error to call Serve
 // more than once for any given Listener instance.
 func (l *Listener) Serve() error {
@@ -144,7 +223,11 @@ func (l *Listener) Serve() error {
 		return errors.New("serve called on a closed listener")
 	}
 
-	listener, err := l.listenFunc()
+	lc := &net.ListenConfig{}
+	if l.reusePort {
+		lc.Control = setReusePort
+	}
+	listener, err := l.listenFunc(lc)
 	if err != nil {
 		return err
 	}
@@ -175,7 +258,7 @@ func (l *Listener) handleConn(src net.Conn) {
 		l.connWG.Done()
 	}()
 
-	dst, err := l.dialFunc()
+	dst, err := l.dialFunc(src)
 	if err != nil {
 		l.logger.Error("failed to dial", "error", err)
 		return
@@ -252,17 +335,10 @@ func (l *Listener) trackConn() func() {
 
 // Close terminates the listener and all active connections.
 func (l *Listener) Close() error {
-	// Prevent the listener from being started.
-	oldFlag := atomic.SwapInt32(&l.stopFlag, 1)
-	if oldFlag != 0 {
+	if !l.stopAccepting() {
 		return nil
 	}
 
-	// Stop the current listener and stop accepting new requests.
-	if listener := l.getListener(); listener != nil {
-		listener.Close()
-	}
-
 	// Stop outstanding requests.
 	close(l.stopChan)
 
@@ -272,6 +348,64 @@ func (l *Listener) Close() error {
 	return nil
 }
 
+// Drain gracefully terminates the listener. It stops accepting new
+// connections before returning, so that a replacement listener can be bound
+// to the same address, and then gives active connections up to timeout to
+// finish before closing the remaining ones. The returned channel is closed
+// once all connections are closed.
+func (l *Listener) Drain(timeout time.Duration) <-chan struct{} {
+	doneCh := make(chan struct{})
+	if !l.stopAccepting() {
+		close(doneCh)
+		return doneCh
+	}
+
+	go func() {
+		defer close(doneCh)
+
+		drainedCh := make(chan struct{})
+		go func() {
+			l.connWG.Wait()
+			close(drainedCh)
+		}()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-drainedCh:
+		case <-timer.C:
+			l.logger.Warn("closing connections still active after drain timeout",
+				"bind_addr", l.bindAddr,
+				"active_conns", atomic.LoadInt32(&l.activeConns),
+				"drain_timeout", timeout,
+			)
+		}
+
+		// Stop outstanding requests.
+		close(l.stopChan)
+		<-drainedCh
+	}()
+	return doneCh
+}
+
+// stopAccepting marks the listener stopped and closes the listening socket so
+// that no new connections are accepted. It returns false if the listener was
+// already stopped.
+func (l *Listener) stopAccepting() bool {
+	// Prevent the listener from being started.
+	oldFlag := atomic.SwapInt32(&l.stopFlag, 1)
+	if oldFlag != 0 {
+		return false
+	}
+
+	// Stop the current listener and stop accepting new requests.
+	if listener := l.getListener(); listener != nil {
+		listener.Close()
+	}
+	return true
+}
+
 // Wait for the listener to be ready to accept connections.
 func (l *Listener) Wait() {
 	<-l.listeningChan
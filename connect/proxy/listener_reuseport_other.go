@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package proxy
+
+import (
+	"errors"
+	"syscall"
+)
+
+// setReusePort is a net.ListenConfig Control function that enables
+// SO_REUSEPORT on the listening socket, which this platform does not support.
+func setReusePort(network, address string, c syscall.RawConn) error {
+	return errors.New("reuse_port is not supported on this platform")
+}
@@ -6,7 +6,9 @@ package proxy
 import (
 	"context"
 	"net"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/connect"
 
@@ -134,3 +136,128 @@ func TestUpstreamListener(t *testing.T) {
 	agMetrics.AssertCounter(t, sink, "consul.proxy.test.upstream.tx_bytes;src=web;dst_type=service;dst=db", 11)
 	agMetrics.AssertCounter(t, sink, "consul.proxy.test.upstream.rx_bytes;src=web;dst_type=service;dst=db", 11)
 }
+
+func testServePublicListener(t *testing.T, svc *connect.Service, port int, reusePort bool) *Listener {
+	testApp := NewTestTCPServer(t)
+	t.Cleanup(testApp.Close)
+
+	cfg := PublicListenerConfig{
+		BindAddress:           "127.0.0.1",
+		BindPort:              port,
+		LocalServiceAddress:   testApp.Addr().String(),
+		HandshakeTimeoutMs:    100,
+		LocalConnectTimeoutMs: 100,
+	}
+	l := NewPublicListener(svc, cfg, testutil.Logger(t))
+	l.reusePort = reusePort
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- l.Serve()
+	}()
+	t.Cleanup(func() { l.Close() })
+
+	select {
+	case <-l.listeningChan:
+	case err := <-errCh:
+		t.Fatalf("failed to listen: %v", err)
+	}
+	return l
+}
+
+func TestListener_Drain(t *testing.T) {
+	ca := agConnect.TestCA(t, nil)
+	svc := connect.TestService(t, "db", ca)
+
+	dial := func(port int) (net.Conn, error) {
+		return svc.Dial(context.Background(), &connect.StaticResolver{
+			Addr:    TestLocalAddr(port),
+			CertURI: agConnect.TestSpiffeIDService(t, "db"),
+		})
+	}
+
+	t.Run("active connections finish", func(t *testing.T) {
+		port := freeport.GetOne(t)
+		l := testServePublicListener(t, svc, port, false)
+
+		conn, err := dial(port)
+		require.NoError(t, err)
+		TestEchoConn(t, conn, "")
+
+		doneCh := l.Drain(time.Minute)
+
+		// New connections are refused but the active one keeps working.
+		_, err = net.DialTimeout("tcp", TestLocalAddr(port), 100*time.Millisecond)
+		require.Error(t, err)
+		TestEchoConn(t, conn, "")
+
+		select {
+		case <-doneCh:
+			t.Fatal("drain finished with an active connection")
+		default:
+		}
+
+		require.NoError(t, conn.Close())
+		select {
+		case <-doneCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("drain did not finish after the connection closed")
+		}
+	})
+
+	t.Run("timeout closes active connections", func(t *testing.T) {
+		port := freeport.GetOne(t)
+		l := testServePublicListener(t, svc, port, false)
+
+		conn, err := dial(port)
+		require.NoError(t, err)
+		defer conn.Close()
+		TestEchoConn(t, conn, "")
+
+		select {
+		case <-l.Drain(50 * time.Millisecond):
+		case <-time.After(5 * time.Second):
+			t.Fatal("drain did not finish after the timeout")
+		}
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, err = conn.Read(make([]byte, 1))
+		require.Error(t, err)
+	})
+
+	t.Run("closed listener", func(t *testing.T) {
+		port := freeport.GetOne(t)
+		l := testServePublicListener(t, svc, port, false)
+		require.NoError(t, l.Close())
+
+		select {
+		case <-l.Drain(time.Minute):
+		default:
+			t.Fatal("drain of a closed listener should finish immediately")
+		}
+	})
+}
+
+func TestListener_ReusePort(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SO_REUSEPORT is not supported on windows")
+	}
+
+	ca := agConnect.TestCA(t, nil)
+	svc := connect.TestService(t, "db", ca)
+	port := freeport.GetOne(t)
+
+	old := testServePublicListener(t, svc, port, true)
+
+	// The replacement can be bound before the old listener is drained.
+	testServePublicListener(t, svc, port, true)
+	<-old.Drain(time.Second)
+
+	conn, err := svc.Dial(context.Background(), &connect.StaticResolver{
+		Addr:    TestLocalAddr(port),
+		CertURI: agConnect.TestSpiffeIDService(t, "db"),
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	TestEchoConn(t, conn, "")
+}
@@ -0,0 +1,316 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/connect"
+	"github.com/hashicorp/consul/lib/decode"
+)
+
+// MeshAccessConfig is the configuration of a mesh access proxy: a single proxy
+// per node that gives the workloads which can't run a sidecar mTLS access to
+// their upstreams on local ports. Each connection presents the identity of
+// the workload it was made by, which is selected either by the port the
+// workload connects to or by the credentials of the connecting process.
+type MeshAccessConfig struct {
+	// Workloads are the identities the proxy presents upstream.
+	Workloads []MeshAccessWorkload `json:"workload" mapstructure:"workload"`
+
+	// Upstreams are shared by the workloads. They are bound to Unix domain
+	// sockets and each connection presents the identity of the workload whose
+	// UIDs include the user of the connecting process (SO_PEERCRED). This is
+	// only supported on Linux.
+	Upstreams []MeshAccessUpstream `json:"upstream" mapstructure:"upstream"`
+}
+
+// MeshAccessWorkload is a workload the mesh access proxy connects upstream
+// on behalf of.
+type MeshAccessWorkload struct {
+	// Service is the name of the service whose identity the workload uses.
+	// The token of the proxy needs service:write on it to obtain its leaf
+	// certificate.
+	Service string `json:"service" mapstructure:"service"`
+
+	// UIDs are the users whose connections to the shared upstreams present
+	// the identity of this workload.
+	UIDs []int `json:"uids" mapstructure:"uids"`
+
+	// Upstreams are bound to local ports dedicated to this workload, all
+	// connections to them present its identity.
+	Upstreams []MeshAccessUpstream `json:"upstream" mapstructure:"upstream"`
+}
+
+// MeshAccessUpstream is an upstream of the mesh access proxy.
+type MeshAccessUpstream struct {
+	DestinationName      string `json:"destination_name" mapstructure:"destination_name"`
+	DestinationType      string `json:"destination_type" mapstructure:"destination_type"`
+	DestinationNamespace string `json:"destination_namespace" mapstructure:"destination_namespace"`
+	DestinationPartition string `json:"destination_partition" mapstructure:"destination_partition"`
+	Datacenter           string `json:"datacenter" mapstructure:"datacenter"`
+
+	// LocalBindAddress and LocalBindPort are the address of the listener of
+	// the upstreams of a workload.
+	LocalBindAddress string `json:"local_bind_address" mapstructure:"local_bind_address"`
+	LocalBindPort    int    `json:"local_bind_port" mapstructure:"local_bind_port"`
+
+	// LocalBindSocketPath and LocalBindSocketMode are the path and octal
+	// permissions of the socket of the shared upstreams.
+	LocalBindSocketPath string `json:"local_bind_socket_path" mapstructure:"local_bind_socket_path"`
+	LocalBindSocketMode string `json:"local_bind_socket_mode" mapstructure:"local_bind_socket_mode"`
+
+	// ConnectTimeoutMs is the timeout for establishing upstream connections.
+	// Defaults to 10000 (10s).
+	ConnectTimeoutMs int `json:"connect_timeout_ms" mapstructure:"connect_timeout_ms"`
+}
+
+// upstreamConfig returns the UpstreamConfig of the listener of the upstream.
+func (u *MeshAccessUpstream) upstreamConfig() UpstreamConfig {
+	uc := UpstreamConfig{
+		DestinationType:      api.UpstreamDestType(u.DestinationType),
+		DestinationNamespace: u.DestinationNamespace,
+		DestinationPartition: u.DestinationPartition,
+		DestinationName:      u.DestinationName,
+		Datacenter:           u.Datacenter,
+		LocalBindAddress:     u.LocalBindAddress,
+		LocalBindPort:        u.LocalBindPort,
+		LocalBindSocketPath:  u.LocalBindSocketPath,
+		LocalBindSocketMode:  u.LocalBindSocketMode,
+	}
+	if u.ConnectTimeoutMs > 0 {
+		uc.Config = map[string]interface{}{"connect_timeout_ms": u.ConnectTimeoutMs}
+	}
+	uc.applyDefaults()
+	return uc
+}
+
+// ParseMeshAccessConfig parses and validates a mesh access proxy
+// configuration in the given format, "hcl" or "json".
+func ParseMeshAccessConfig(data []byte, format string) (*MeshAccessConfig, error) {
+	var raw map[string]interface{}
+	var err error
+	switch format {
+	case "json":
+		err = json.Unmarshal(data, &raw)
+	case "hcl":
+		err = hcl.Decode(&raw, string(data))
+	default:
+		err = fmt.Errorf("invalid format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the mesh access configuration: %w", err)
+	}
+
+	var cfg MeshAccessConfig
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  decode.HookWeakDecodeFromSlice,
+		ErrorUnused: true,
+		Result:      &cfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Decode(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode the mesh access configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks that the configuration is complete and that the workload
+// of every connection can be told apart.
+func (c *MeshAccessConfig) Validate() error {
+	if len(c.Workloads) == 0 {
+		return errors.New("at least one workload is required")
+	}
+
+	services := make(map[string]struct{})
+	uids := make(map[int]string)
+	binds := make(map[string]struct{})
+	checkBind := func(bind string) error {
+		if _, ok := binds[bind]; ok {
+			return fmt.Errorf("%s is bound by more than one upstream", bind)
+		}
+		binds[bind] = struct{}{}
+		return nil
+	}
+
+	for _, w := range c.Workloads {
+		if w.Service == "" {
+			return errors.New("workload: service is required")
+		}
+		if _, ok := services[w.Service]; ok {
+			return fmt.Errorf("workload %q: defined more than once", w.Service)
+		}
+		services[w.Service] = struct{}{}
+
+		for _, uid := range w.UIDs {
+			if uid < 0 {
+				return fmt.Errorf("workload %q: invalid UID %d", w.Service, uid)
+			}
+			if other, ok := uids[uid]; ok {
+				return fmt.Errorf("workload %q: UID %d is already used by workload %q", w.Service, uid, other)
+			}
+			uids[uid] = w.Service
+		}
+
+		for _, u := range w.Upstreams {
+			if u.DestinationName == "" {
+				return fmt.Errorf("workload %q: upstream destination_name is required", w.Service)
+			}
+			if u.LocalBindSocketPath != "" || u.LocalBindPort < 1 {
+				return fmt.Errorf("workload %q: upstream %q requires a local_bind_port, shared upstreams are bound to sockets", w.Service, u.DestinationName)
+			}
+			uc := u.upstreamConfig()
+			if err := checkBind(fmt.Sprintf("%s:%d", uc.LocalBindAddress, uc.LocalBindPort)); err != nil {
+				return fmt.Errorf("workload %q: %w", w.Service, err)
+			}
+		}
+	}
+
+	if len(c.Upstreams) > 0 && len(uids) == 0 {
+		return errors.New("shared upstreams require at least one workload with uids")
+	}
+	for _, u := range c.Upstreams {
+		if u.DestinationName == "" {
+			return errors.New("upstream: destination_name is required")
+		}
+		if u.LocalBindSocketPath == "" {
+			return fmt.Errorf("upstream %q: local_bind_socket_path is required", u.DestinationName)
+		}
+		if err := checkBind(u.LocalBindSocketPath); err != nil {
+			return fmt.Errorf("upstream %q: %w", u.DestinationName, err)
+		}
+	}
+	return nil
+}
+
+// MeshAccess implements the mesh access proxy.
+type MeshAccess struct {
+	cfg          *MeshAccessConfig
+	resolverFunc func(UpstreamConfig) (connect.Resolver, error)
+	logger       hclog.Logger
+
+	// services holds the identity of each workload, keyed by service name,
+	// and servicesByUID the identity selected for each peer UID.
+	services      map[string]*connect.Service
+	servicesByUID map[uint32]*connect.Service
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMeshAccess returns a mesh access proxy for the given configuration. The
+// leaf certificates of the workloads are obtained from the agent of client.
+func NewMeshAccess(client *api.Client, cfg *MeshAccessConfig, logger hclog.Logger) (*MeshAccess, error) {
+	services := make(map[string]*connect.Service, len(cfg.Workloads))
+	for _, w := range cfg.Workloads {
+		svc, err := connect.NewServiceWithConfig(w.Service, connect.Config{Client: client, Logger: logger, ServerNextProtos: []string{}})
+		if err != nil {
+			for _, svc := range services {
+				svc.Close()
+			}
+			return nil, fmt.Errorf("failed to set up workload %q: %w", w.Service, err)
+		}
+		services[w.Service] = svc
+	}
+	return newMeshAccess(cfg, services, UpstreamResolverFuncFromClient(client), logger), nil
+}
+
+func newMeshAccess(cfg *MeshAccessConfig, services map[string]*connect.Service,
+	resolverFunc func(UpstreamConfig) (connect.Resolver, error), logger hclog.Logger) *MeshAccess {
+	m := &MeshAccess{
+		cfg:           cfg,
+		resolverFunc:  resolverFunc,
+		logger:        logger,
+		services:      services,
+		servicesByUID: make(map[uint32]*connect.Service),
+		stopChan:      make(chan struct{}),
+	}
+	for _, w := range cfg.Workloads {
+		for _, uid := range w.UIDs {
+			m.servicesByUID[uint32(uid)] = services[w.Service]
+		}
+	}
+	return m
+}
+
+// Serve runs the listeners of the upstreams until the proxy is closed.
+func (m *MeshAccess) Serve() error {
+	var listeners []*Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+		for _, svc := range m.services {
+			svc.Close()
+		}
+	}()
+
+	failCh := make(chan error, 1)
+	start := func(name string, l *Listener) {
+		listeners = append(listeners, l)
+		m.logger.Info("Starting listener", "listener", name, "bind_addr", l.BindAddr())
+		go func() {
+			if err := l.Serve(); err != nil {
+				m.logger.Error("listener stopped with error", "listener", name, "error", err)
+				select {
+				case failCh <- fmt.Errorf("listener %s failed: %w", name, err):
+				default:
+				}
+			}
+		}()
+	}
+
+	for _, w := range m.cfg.Workloads {
+		svc := m.services[w.Service]
+		for _, u := range w.Upstreams {
+			uc := u.upstreamConfig()
+			start(uc.String(), newUpstreamListenerWithResolver(svc, uc, m.resolverFunc, m.logger.With("workload", w.Service)))
+		}
+	}
+	for _, u := range m.cfg.Upstreams {
+		uc := u.upstreamConfig()
+		start(uc.String(), newPeerCredUpstreamListener(m.serviceForConn, uc, m.resolverFunc, m.logger))
+	}
+
+	select {
+	case err := <-failCh:
+		return err
+	case <-m.stopChan:
+		return nil
+	}
+}
+
+// serviceForConn returns the identity of the workload whose UIDs include the
+// user of the process which made conn.
+func (m *MeshAccess) serviceForConn(conn net.Conn) (*connect.Service, error) {
+	uid, err := peerUID(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify the workload: %w", err)
+	}
+	svc, ok := m.servicesByUID[uid]
+	if !ok {
+		return nil, fmt.Errorf("no workload is configured for UID %d", uid)
+	}
+	return svc, nil
+}
+
+// Close stops the proxy and terminates all active connections.
+func (m *MeshAccess) Close() {
+	m.closeOnce.Do(func() { close(m.stopChan) })
+}
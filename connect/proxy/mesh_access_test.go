@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package proxy
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	agConnect "github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/connect"
+	"github.com/hashicorp/consul/sdk/freeport"
+	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func TestParseMeshAccessConfig(t *testing.T) {
+	cfg, err := ParseMeshAccessConfig([]byte(`
+		workload {
+			service = "billing"
+			uids    = [1001, 1002]
+
+			upstream {
+				destination_name = "db"
+				local_bind_port  = 10001
+			}
+		}
+
+		workload {
+			service = "reports"
+		}
+
+		upstream {
+			destination_name       = "payments"
+			local_bind_socket_path = "/run/consul/payments.sock"
+			local_bind_socket_mode = "0660"
+			connect_timeout_ms     = 500
+		}
+	`), "hcl")
+	require.NoError(t, err)
+	require.Equal(t, &MeshAccessConfig{
+		Workloads: []MeshAccessWorkload{
+			{
+				Service: "billing",
+				UIDs:    []int{1001, 1002},
+				Upstreams: []MeshAccessUpstream{
+					{DestinationName: "db", LocalBindPort: 10001},
+				},
+			},
+			{Service: "reports"},
+		},
+		Upstreams: []MeshAccessUpstream{
+			{
+				DestinationName:     "payments",
+				LocalBindSocketPath: "/run/consul/payments.sock",
+				LocalBindSocketMode: "0660",
+				ConnectTimeoutMs:    500,
+			},
+		},
+	}, cfg)
+
+	uc := cfg.Upstreams[0].upstreamConfig()
+	require.Equal(t, "/run/consul/payments.sock->service:default/default/payments", uc.String())
+	require.Equal(t, int64(500), uc.ConnectTimeout().Milliseconds())
+
+	_, err = ParseMeshAccessConfig([]byte(`{"workload": [{"service": "billing", "upstream": [{"destination_name": "db", "local_bind_port": 10001}]}]}`), "json")
+	require.NoError(t, err)
+
+	_, err = ParseMeshAccessConfig([]byte(`workload { service = "billing" port = 1 }`), "hcl")
+	require.ErrorContains(t, err, "invalid keys: port")
+}
+
+func TestMeshAccessConfig_Validate(t *testing.T) {
+	cases := map[string]struct {
+		cfg MeshAccessConfig
+		err string
+	}{
+		"no workloads": {
+			cfg: MeshAccessConfig{},
+			err: "at least one workload is required",
+		},
+		"duplicate workload": {
+			cfg: MeshAccessConfig{Workloads: []MeshAccessWorkload{{Service: "web"}, {Service: "web"}}},
+			err: `workload "web": defined more than once`,
+		},
+		"duplicate uid": {
+			cfg: MeshAccessConfig{Workloads: []MeshAccessWorkload{
+				{Service: "web", UIDs: []int{1001}},
+				{Service: "api", UIDs: []int{1001}},
+			}},
+			err: `workload "api": UID 1001 is already used by workload "web"`,
+		},
+		"workload upstream on socket": {
+			cfg: MeshAccessConfig{Workloads: []MeshAccessWorkload{{
+				Service:   "web",
+				Upstreams: []MeshAccessUpstream{{DestinationName: "db", LocalBindSocketPath: "/tmp/db.sock"}},
+			}}},
+			err: `workload "web": upstream "db" requires a local_bind_port`,
+		},
+		"port bound twice": {
+			cfg: MeshAccessConfig{Workloads: []MeshAccessWorkload{
+				{Service: "web", Upstreams: []MeshAccessUpstream{{DestinationName: "db", LocalBindPort: 10001}}},
+				{Service: "api", Upstreams: []MeshAccessUpstream{{DestinationName: "cache", LocalBindPort: 10001}}},
+			}},
+			err: `workload "api": 127.0.0.1:10001 is bound by more than one upstream`,
+		},
+		"shared upstream without uids": {
+			cfg: MeshAccessConfig{
+				Workloads: []MeshAccessWorkload{{Service: "web"}},
+				Upstreams: []MeshAccessUpstream{{DestinationName: "db", LocalBindSocketPath: "/tmp/db.sock"}},
+			},
+			err: "shared upstreams require at least one workload with uids",
+		},
+		"shared upstream on port": {
+			cfg: MeshAccessConfig{
+				Workloads: []MeshAccessWorkload{{Service: "web", UIDs: []int{1001}}},
+				Upstreams: []MeshAccessUpstream{{DestinationName: "db", LocalBindPort: 10001}},
+			},
+			err: `upstream "db": local_bind_socket_path is required`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorContains(t, tc.cfg.Validate(), tc.err)
+		})
+	}
+}
+
+func TestMeshAccess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+
+	ca := agConnect.TestCA(t, nil)
+	testSvr := connect.NewTestServer(t, "db", ca)
+	go func() {
+		err := testSvr.Serve()
+		require.NoError(t, err)
+	}()
+	defer testSvr.Close()
+	<-testSvr.Listening
+
+	port := freeport.GetOne(t)
+	socketPath := filepath.Join(t.TempDir(), "db.sock")
+	cfg := &MeshAccessConfig{
+		Workloads: []MeshAccessWorkload{
+			{
+				Service:   "web",
+				UIDs:      []int{os.Getuid()},
+				Upstreams: []MeshAccessUpstream{{DestinationName: "db", LocalBindPort: port}},
+			},
+			{Service: "batch", UIDs: []int{os.Getuid() + 1}},
+		},
+		Upstreams: []MeshAccessUpstream{
+			{DestinationName: "db", LocalBindSocketPath: socketPath, LocalBindSocketMode: "0600"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	services := map[string]*connect.Service{
+		"web":   connect.TestService(t, "web", ca),
+		"batch": connect.TestService(t, "batch", ca),
+	}
+	rf := TestStaticUpstreamResolverFunc(&connect.StaticResolver{
+		Addr:    testSvr.Addr,
+		CertURI: agConnect.TestSpiffeIDService(t, "db"),
+	})
+	m := newMeshAccess(cfg, services, rf, testutil.Logger(t))
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- m.Serve() }()
+
+	// The upstream of the workload is served on its port.
+	var conn net.Conn
+	retry.Run(t, func(r *retry.R) {
+		var err error
+		conn, err = net.Dial("tcp", TestLocalAddr(port))
+		require.NoError(r, err)
+	})
+	TestEchoConn(t, conn, "")
+	conn.Close()
+
+	// The shared upstream is served on the socket, with the identity of the
+	// workload of the connecting user.
+	retry.Run(t, func(r *retry.R) {
+		var err error
+		conn, err = net.Dial("unix", socketPath)
+		require.NoError(r, err)
+	})
+	TestEchoConn(t, conn, "")
+	conn.Close()
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	m.Close()
+	require.NoError(t, <-errCh)
+}
+
+func TestMeshAccess_serviceForConn(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+
+	ca := agConnect.TestCA(t, nil)
+	services := map[string]*connect.Service{
+		"web":   connect.TestService(t, "web", ca),
+		"batch": connect.TestService(t, "batch", ca),
+	}
+	cfg := &MeshAccessConfig{Workloads: []MeshAccessWorkload{
+		{Service: "web", UIDs: []int{os.Getuid()}},
+		{Service: "batch", UIDs: []int{os.Getuid() + 1}},
+	}}
+
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	accept := func(t *testing.T) net.Conn {
+		client, err := net.Dial("unix", l.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		conn, err := l.Accept()
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	m := newMeshAccess(cfg, services, nil, testutil.Logger(t))
+	svc, err := m.serviceForConn(accept(t))
+	require.NoError(t, err)
+	require.Equal(t, "web", svc.Name())
+
+	// Connections by users without a workload are refused.
+	cfg.Workloads[0].UIDs = nil
+	m = newMeshAccess(cfg, services, nil, testutil.Logger(t))
+	_, err = m.serviceForConn(accept(t))
+	require.ErrorContains(t, err, "no workload is configured for UID")
+}
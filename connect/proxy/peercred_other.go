@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package proxy
+
+import (
+	"errors"
+	"net"
+)
+
+// peerUID returns the user of the process which connected to conn, which
+// this platform does not support.
+func peerUID(conn net.Conn) (uint32, error) {
+	return 0, errors.New("identifying workloads by peer credentials is only supported on Linux")
+}
@@ -20,6 +20,10 @@ type Proxy struct {
 	stopChan   chan struct{}
 	logger     hclog.Logger
 	service    *connect.Service
+
+	// upstreams holds the running upstream listeners keyed by
+	// UpstreamConfig.String(). It is only accessed from Serve.
+	upstreams map[string]*Listener
 }
 
 // New returns a proxy with the given configuration source.
@@ -32,6 +36,7 @@ func New(client *api.Client, cw ConfigWatcher, logger hclog.Logger) (*Proxy, err
 		cfgWatcher: cw,
 		stopChan:   make(chan struct{}),
 		logger:     logger,
+		upstreams:  make(map[string]*Listener),
 	}, nil
 }
 
@@ -85,6 +90,7 @@ func (p *Proxy) Serve() error {
 					if newCfg.PublicListener.BindPort != 0 {
 						newCfg.PublicListener.applyDefaults()
 						l := NewPublicListener(p.service, newCfg.PublicListener, p.logger)
+						l.reusePort = newCfg.ReusePort
 						err = p.startListener("public listener", l)
 						if err != nil {
 							// This should probably be fatal.
@@ -96,33 +102,7 @@ func (p *Proxy) Serve() error {
 				}()
 			}
 
-			// TODO(banks) update/remove upstreams properly based on a diff with current. Can
-			// store a map of uc.String() to Listener here and then use it to only
-			// start one of each and stop/modify if changes occur.
-			for _, uc := range newCfg.Upstreams {
-				uc.applyDefaults()
-
-				if uc.LocalBindSocketPath != "" {
-					p.logger.Error("local_bind_socket_path is not supported with this proxy implementation. "+
-						"Can't start upstream.", "upstream", uc.String())
-					continue
-				}
-
-				if uc.LocalBindPort < 1 {
-					p.logger.Error("upstream has no local_bind_port. "+
-						"Can't start upstream.", "upstream", uc.String())
-					continue
-				}
-
-				l := NewUpstreamListener(p.service, p.client, uc, p.logger)
-				err := p.startListener(uc.String(), l)
-				if err != nil {
-					p.logger.Error("failed to start upstream",
-						"upstream", uc.String(),
-						"error", err,
-					)
-				}
-			}
+			p.updateUpstreams(newCfg)
 			cfg = newCfg
 
 		case <-p.stopChan:
@@ -134,6 +114,57 @@ func (p *Proxy) Serve() error {
 	}
 }
 
+// updateUpstreams starts listeners for upstreams added by the configuration
+// and drains the listeners of upstreams that were removed or changed. Removed
+// listeners stop accepting connections before new ones are started so that a
+// changed upstream can be bound to the same address again.
+func (p *Proxy) updateUpstreams(cfg *Config) {
+	wanted := make(map[string]UpstreamConfig, len(cfg.Upstreams))
+	for _, uc := range cfg.Upstreams {
+		uc.applyDefaults()
+		wanted[uc.String()] = uc
+	}
+
+	for name, l := range p.upstreams {
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		p.logger.Info("Draining listener", "listener", name, "drain_timeout", cfg.DrainTimeout())
+		l.Drain(cfg.DrainTimeout())
+		delete(p.upstreams, name)
+	}
+
+	for name, uc := range wanted {
+		if _, ok := p.upstreams[name]; ok {
+			continue
+		}
+
+		if uc.LocalBindSocketPath != "" {
+			p.logger.Error("local_bind_socket_path is not supported with this proxy implementation. "+
+				"Can't start upstream.", "upstream", name)
+			continue
+		}
+
+		if uc.LocalBindPort < 1 {
+			p.logger.Error("upstream has no local_bind_port. "+
+				"Can't start upstream.", "upstream", name)
+			continue
+		}
+
+		l := NewUpstreamListener(p.service, p.client, uc, p.logger)
+		l.reusePort = cfg.ReusePort
+		err := p.startListener(name, l)
+		if err != nil {
+			p.logger.Error("failed to start upstream",
+				"upstream", name,
+				"error", err,
+			)
+			continue
+		}
+		p.upstreams[name] = l
+	}
+}
+
 // startPublicListener is run from the internal state machine loop
 func (p *Proxy) startListener(name string, l *Listener) error {
 	p.logger.Info("Starting listener", "listener", name, "bind_addr", l.BindAddr())
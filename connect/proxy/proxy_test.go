@@ -129,3 +129,66 @@ func TestProxy_public(t *testing.T) {
 		require.NoFileExists(t, unixSocket)
 	})
 }
+
+type testConfigWatcher chan *Config
+
+func (w testConfigWatcher) Watch() <-chan *Config {
+	return w
+}
+
+func TestProxy_updateUpstreams(t *testing.T) {
+	ports := freeport.GetN(t, 2)
+
+	client, err := api.NewClient(&api.Config{Address: "127.0.0.1:1"})
+	require.NoError(t, err)
+
+	watcher := make(testConfigWatcher)
+	p, err := New(client, watcher, testutil.Logger(t))
+	require.NoError(t, err)
+	defer p.Close()
+	go p.Serve()
+
+	upstream := func(name string, port int) UpstreamConfig {
+		return UpstreamConfig{DestinationName: name, LocalBindPort: port}
+	}
+	requireListening := func(t *testing.T, port int, listening bool) {
+		t.Helper()
+		addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+		retry.Run(t, func(r *retry.R) {
+			conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+			if listening && err != nil {
+				r.Fatalf("expected %s to be listening: %v", addr, err)
+			}
+			if !listening && err == nil {
+				conn.Close()
+				r.Fatalf("expected %s not to be listening", addr)
+			}
+			if conn != nil {
+				conn.Close()
+			}
+		})
+	}
+
+	watcher <- &Config{
+		ProxiedServiceName: "web",
+		Upstreams:          []UpstreamConfig{upstream("db", ports[0])},
+	}
+	requireListening(t, ports[0], true)
+
+	// Point the same port at a different destination and add an upstream.
+	watcher <- &Config{
+		ProxiedServiceName: "web",
+		Upstreams: []UpstreamConfig{
+			upstream("cache", ports[0]),
+			upstream("db", ports[1]),
+		},
+		DrainTimeoutMs: 10,
+	}
+	requireListening(t, ports[1], true)
+	requireListening(t, ports[0], true)
+
+	// Remove every upstream.
+	watcher <- &Config{ProxiedServiceName: "web"}
+	requireListening(t, ports[0], false)
+	requireListening(t, ports[1], false)
+}
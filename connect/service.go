@@ -309,6 +309,13 @@ func (s *Service) rootsWatchHandler(blockParam watch.BlockingParamVal, raw inter
 	}
 
 	s.tlsCfg.SetRoots(roots)
+
+	crls, err := parseRevocationLists(v.RevocationList)
+	if err != nil {
+		s.logger.Error("failed to parse certificate revocation lists", "error", err)
+		return
+	}
+	s.tlsCfg.SetRevocationLists(crls)
 }
 
 func (s *Service) leafWatchHandler(blockParam watch.BlockingParamVal, raw interface{}) {
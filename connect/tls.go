@@ -4,8 +4,10 @@
 package connect
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
@@ -286,6 +288,10 @@ type dynamicTLSConfig struct {
 	sync.RWMutex
 	leaf  *tls.Certificate
 	roots *x509.CertPool
+	// crls are the certificate revocation lists the leaf certs of peers are
+	// checked against. They are delivered by the local agent along with the
+	// roots.
+	crls []*x509.RevocationList
 	// readyCh is closed when the config first gets both leaf and roots set.
 	// Watchers can wait on this via ReadyWait.
 	readyCh chan struct{}
@@ -335,6 +341,9 @@ func (cfg *dynamicTLSConfig) Get(v verifierFunc) *tls.Config {
 	copy.ClientCAs = cfg.roots
 	if v != nil {
 		copy.VerifyPeerCertificate = func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+			if err := cfg.verifyNotRevoked(rawCerts); err != nil {
+				return err
+			}
 			return v(cfg.Get(nil), rawCerts)
 		}
 	}
@@ -367,6 +376,62 @@ func (cfg *dynamicTLSConfig) SetRoots(roots *x509.CertPool) error {
 	return nil
 }
 
+// SetRevocationLists sets new certificate revocation lists.
+func (cfg *dynamicTLSConfig) SetRevocationLists(crls []*x509.RevocationList) {
+	cfg.Lock()
+	defer cfg.Unlock()
+	cfg.crls = crls
+}
+
+// verifyNotRevoked checks that the leaf cert of the peer is not listed in the
+// revocation list of its signer.
+func (cfg *dynamicTLSConfig) verifyNotRevoked(rawCerts [][]byte) error {
+	cfg.RLock()
+	crls := cfg.crls
+	cfg.RUnlock()
+
+	if len(crls) == 0 || len(rawCerts) < 1 {
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return errors.New("tls: failed to parse certificate from peer: " + err.Error())
+	}
+
+	for _, crl := range crls {
+		if !bytes.Equal(crl.RawIssuer, leaf.RawIssuer) || !bytes.Equal(crl.AuthorityKeyId, leaf.AuthorityKeyId) {
+			continue
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+				return errors.New("connect: peer certificate was revoked")
+			}
+		}
+	}
+	return nil
+}
+
+// parseRevocationLists parses the PEM encoded certificate revocation lists.
+func parseRevocationLists(pemValue string) ([]*x509.RevocationList, error) {
+	var crls []*x509.RevocationList
+	rest := []byte(pemValue)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return crls, nil
+		}
+		if block.Type != "X509 CRL" {
+			continue
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, crl)
+	}
+}
+
 // SetLeaf sets a new leaf.
 func (cfg *dynamicTLSConfig) SetLeaf(leaf *tls.Certificate) error {
 	cfg.Lock()
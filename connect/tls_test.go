@@ -4,13 +4,18 @@
 package connect
 
 import (
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"math/big"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/consul/agent"
@@ -420,6 +425,87 @@ func TestDynamicTLSConfig_Ready(t *testing.T) {
 	require.True(t, c.Ready(), "should be ready")
 }
 
+func TestDynamicTLSConfig_RevocationLists(t *testing.T) {
+	ca := connect.TestCA(t, nil)
+	server := newDynamicTLSConfig(TestTLSConfig(t, "db", ca), nil)
+
+	revoked := TestTLSConfig(t, "web", ca)
+	valid := TestTLSConfig(t, "web", ca)
+
+	// handshake connects a client using the given config to the server.
+	handshake := func(t *testing.T, clientCfg *tls.Config) error {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+
+		errCh := make(chan error, 1)
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			defer conn.Close()
+			errCh <- tls.Server(conn, server.Get(newServerSideVerifier(hclog.NewNullLogger(), nil, "db"))).Handshake()
+		}()
+
+		conn, err := tls.Dial("tcp", l.Addr().String(), clientCfg)
+		if err == nil {
+			defer conn.Close()
+		}
+		return <-errCh
+	}
+
+	require.NoError(t, handshake(t, revoked))
+
+	issuer, err := connect.ParseCert(ca.RootCert)
+	require.NoError(t, err)
+	signer, err := connect.ParseSigner(ca.SigningKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(revoked.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	now := time.Now()
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: now.Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: leaf.SerialNumber, RevocationTime: now},
+		},
+	}, issuer, signer)
+	require.NoError(t, err)
+
+	crls, err := parseRevocationLists(string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})))
+	require.NoError(t, err)
+	require.Len(t, crls, 1)
+	server.SetRevocationLists(crls)
+
+	require.ErrorContains(t, handshake(t, revoked), "peer certificate was revoked")
+	require.NoError(t, handshake(t, valid))
+
+	// The lists of other signers don't apply.
+	other := connect.TestCA(t, nil)
+	otherIssuer, err := connect.ParseCert(other.RootCert)
+	require.NoError(t, err)
+	otherSigner, err := connect.ParseSigner(other.SigningKey)
+	require.NoError(t, err)
+	der, err = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now,
+		NextUpdate: now.Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: leaf.SerialNumber, RevocationTime: now},
+		},
+	}, otherIssuer, otherSigner)
+	require.NoError(t, err)
+	crls, err = parseRevocationLists(string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})))
+	require.NoError(t, err)
+	server.SetRevocationLists(crls)
+
+	require.NoError(t, handshake(t, revoked))
+}
+
 func assertBlocked(t *testing.T, ch <-chan struct{}) {
 	t.Helper()
 	select {
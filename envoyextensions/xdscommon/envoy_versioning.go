@@ -15,6 +15,10 @@ var (
 	// the zero'th point release of the last element of xdscommon.EnvoyVersions.
 	minSupportedVersion = version.Must(version.NewVersion(GetMinEnvoyMajorVersion()))
 
+	// maxSupportedVersion is the most recent mainline version we support. Any
+	// point release of it is supported as well.
+	maxSupportedVersion = version.Must(version.NewVersion(GetMaxEnvoyMajorVersion()))
+
 	specificUnsupportedVersions = []unsupportedVersion{}
 )
 
@@ -47,7 +51,12 @@ func determineSupportedProxyFeaturesFromVersion(version *version.Version) (Suppo
 	}
 
 	if version.LessThan(minSupportedVersion) {
-		return SupportedProxyFeatures{}, fmt.Errorf("Envoy %s is too old and is not supported by Consul", version)
+		return SupportedProxyFeatures{}, fmt.Errorf(
+			"Envoy %s is too old and is not supported by Consul. Supported Envoy versions are %s through %s.x",
+			version,
+			GetMinEnvoyMajorVersion(),
+			GetMaxEnvoyMajorVersion(),
+		)
 	}
 
 	for _, uv := range specificUnsupportedVersions {
@@ -69,6 +78,21 @@ func determineSupportedProxyFeaturesFromVersion(version *version.Version) (Suppo
 	return sf, nil
 }
 
+// IsEnvoyVersionNewerThanSupported returns whether the Envoy version is more
+// recent than the most recent supported mainline version. Such proxies are
+// accepted, but Consul is not tested against them.
+func IsEnvoyVersionNewerThanSupported(v *version.Version) bool {
+	if v == nil {
+		return false
+	}
+	segments := v.Segments()
+	maxSegments := maxSupportedVersion.Segments()
+	if segments[0] != maxSegments[0] {
+		return segments[0] > maxSegments[0]
+	}
+	return segments[1] > maxSegments[1]
+}
+
 func DetermineEnvoyVersionFromNode(node *envoy_core_v3.Node) *version.Version {
 	if node == nil {
 		return nil
@@ -128,3 +128,20 @@ func TestDetermineSupportedProxyFeaturesFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestIsEnvoyVersionNewerThanSupported(t *testing.T) {
+	maxVersion := version.Must(version.NewVersion(getMaxEnvoyVersion()))
+	segments := maxVersion.Segments()
+
+	cases := map[string]bool{
+		getMaxEnvoyVersion():                               false,
+		fmt.Sprintf("%d.%d.99", segments[0], segments[1]):  false,
+		fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1): true,
+		fmt.Sprintf("%d.0.0", segments[0]+1):               true,
+		getMinEnvoyVersion():                               false,
+	}
+	for v, expect := range cases {
+		require.Equal(t, expect, IsEnvoyVersionNewerThanSupported(version.Must(version.NewVersion(v))), v)
+	}
+	require.False(t, IsEnvoyVersionNewerThanSupported(nil))
+}
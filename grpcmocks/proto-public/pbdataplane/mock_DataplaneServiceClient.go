@@ -25,6 +25,80 @@ func (_m *DataplaneServiceClient) EXPECT() *DataplaneServiceClient_Expecter {
 	return &DataplaneServiceClient_Expecter{mock: &_m.Mock}
 }
 
+// GetEnvoyBootstrap provides a mock function with given fields: ctx, in, opts
+func (_m *DataplaneServiceClient) GetEnvoyBootstrap(ctx context.Context, in *pbdataplane.GetEnvoyBootstrapRequest, opts ...grpc.CallOption) (*pbdataplane.GetEnvoyBootstrapResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvoyBootstrap")
+	}
+
+	var r0 *pbdataplane.GetEnvoyBootstrapResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest, ...grpc.CallOption) (*pbdataplane.GetEnvoyBootstrapResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest, ...grpc.CallOption) *pbdataplane.GetEnvoyBootstrapResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbdataplane.GetEnvoyBootstrapResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataplaneServiceClient_GetEnvoyBootstrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnvoyBootstrap'
+type DataplaneServiceClient_GetEnvoyBootstrap_Call struct {
+	*mock.Call
+}
+
+// GetEnvoyBootstrap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbdataplane.GetEnvoyBootstrapRequest
+//   - opts ...grpc.CallOption
+func (_e *DataplaneServiceClient_Expecter) GetEnvoyBootstrap(ctx interface{}, in interface{}, opts ...interface{}) *DataplaneServiceClient_GetEnvoyBootstrap_Call {
+	return &DataplaneServiceClient_GetEnvoyBootstrap_Call{Call: _e.mock.On("GetEnvoyBootstrap",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *DataplaneServiceClient_GetEnvoyBootstrap_Call) Run(run func(ctx context.Context, in *pbdataplane.GetEnvoyBootstrapRequest, opts ...grpc.CallOption)) *DataplaneServiceClient_GetEnvoyBootstrap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbdataplane.GetEnvoyBootstrapRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *DataplaneServiceClient_GetEnvoyBootstrap_Call) Return(_a0 *pbdataplane.GetEnvoyBootstrapResponse, _a1 error) *DataplaneServiceClient_GetEnvoyBootstrap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataplaneServiceClient_GetEnvoyBootstrap_Call) RunAndReturn(run func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest, ...grpc.CallOption) (*pbdataplane.GetEnvoyBootstrapResponse, error)) *DataplaneServiceClient_GetEnvoyBootstrap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEnvoyBootstrapParams provides a mock function with given fields: ctx, in, opts
 func (_m *DataplaneServiceClient) GetEnvoyBootstrapParams(ctx context.Context, in *pbdataplane.GetEnvoyBootstrapParamsRequest, opts ...grpc.CallOption) (*pbdataplane.GetEnvoyBootstrapParamsResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -173,6 +247,80 @@ func (_c *DataplaneServiceClient_GetSupportedDataplaneFeatures_Call) RunAndRetur
 	return _c
 }
 
+// ReportUpstreamMetrics provides a mock function with given fields: ctx, in, opts
+func (_m *DataplaneServiceClient) ReportUpstreamMetrics(ctx context.Context, in *pbdataplane.ReportUpstreamMetricsRequest, opts ...grpc.CallOption) (*pbdataplane.ReportUpstreamMetricsResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportUpstreamMetrics")
+	}
+
+	var r0 *pbdataplane.ReportUpstreamMetricsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest, ...grpc.CallOption) (*pbdataplane.ReportUpstreamMetricsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest, ...grpc.CallOption) *pbdataplane.ReportUpstreamMetricsResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbdataplane.ReportUpstreamMetricsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataplaneServiceClient_ReportUpstreamMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportUpstreamMetrics'
+type DataplaneServiceClient_ReportUpstreamMetrics_Call struct {
+	*mock.Call
+}
+
+// ReportUpstreamMetrics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbdataplane.ReportUpstreamMetricsRequest
+//   - opts ...grpc.CallOption
+func (_e *DataplaneServiceClient_Expecter) ReportUpstreamMetrics(ctx interface{}, in interface{}, opts ...interface{}) *DataplaneServiceClient_ReportUpstreamMetrics_Call {
+	return &DataplaneServiceClient_ReportUpstreamMetrics_Call{Call: _e.mock.On("ReportUpstreamMetrics",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *DataplaneServiceClient_ReportUpstreamMetrics_Call) Run(run func(ctx context.Context, in *pbdataplane.ReportUpstreamMetricsRequest, opts ...grpc.CallOption)) *DataplaneServiceClient_ReportUpstreamMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbdataplane.ReportUpstreamMetricsRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *DataplaneServiceClient_ReportUpstreamMetrics_Call) Return(_a0 *pbdataplane.ReportUpstreamMetricsResponse, _a1 error) *DataplaneServiceClient_ReportUpstreamMetrics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataplaneServiceClient_ReportUpstreamMetrics_Call) RunAndReturn(run func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest, ...grpc.CallOption) (*pbdataplane.ReportUpstreamMetricsResponse, error)) *DataplaneServiceClient_ReportUpstreamMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDataplaneServiceClient creates a new instance of DataplaneServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDataplaneServiceClient(t interface {
@@ -22,6 +22,65 @@ func (_m *DataplaneServiceServer) EXPECT() *DataplaneServiceServer_Expecter {
 	return &DataplaneServiceServer_Expecter{mock: &_m.Mock}
 }
 
+// GetEnvoyBootstrap provides a mock function with given fields: _a0, _a1
+func (_m *DataplaneServiceServer) GetEnvoyBootstrap(_a0 context.Context, _a1 *pbdataplane.GetEnvoyBootstrapRequest) (*pbdataplane.GetEnvoyBootstrapResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnvoyBootstrap")
+	}
+
+	var r0 *pbdataplane.GetEnvoyBootstrapResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest) (*pbdataplane.GetEnvoyBootstrapResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest) *pbdataplane.GetEnvoyBootstrapResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbdataplane.GetEnvoyBootstrapResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataplaneServiceServer_GetEnvoyBootstrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnvoyBootstrap'
+type DataplaneServiceServer_GetEnvoyBootstrap_Call struct {
+	*mock.Call
+}
+
+// GetEnvoyBootstrap is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbdataplane.GetEnvoyBootstrapRequest
+func (_e *DataplaneServiceServer_Expecter) GetEnvoyBootstrap(_a0 interface{}, _a1 interface{}) *DataplaneServiceServer_GetEnvoyBootstrap_Call {
+	return &DataplaneServiceServer_GetEnvoyBootstrap_Call{Call: _e.mock.On("GetEnvoyBootstrap", _a0, _a1)}
+}
+
+func (_c *DataplaneServiceServer_GetEnvoyBootstrap_Call) Run(run func(_a0 context.Context, _a1 *pbdataplane.GetEnvoyBootstrapRequest)) *DataplaneServiceServer_GetEnvoyBootstrap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbdataplane.GetEnvoyBootstrapRequest))
+	})
+	return _c
+}
+
+func (_c *DataplaneServiceServer_GetEnvoyBootstrap_Call) Return(_a0 *pbdataplane.GetEnvoyBootstrapResponse, _a1 error) *DataplaneServiceServer_GetEnvoyBootstrap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataplaneServiceServer_GetEnvoyBootstrap_Call) RunAndReturn(run func(context.Context, *pbdataplane.GetEnvoyBootstrapRequest) (*pbdataplane.GetEnvoyBootstrapResponse, error)) *DataplaneServiceServer_GetEnvoyBootstrap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEnvoyBootstrapParams provides a mock function with given fields: _a0, _a1
 func (_m *DataplaneServiceServer) GetEnvoyBootstrapParams(_a0 context.Context, _a1 *pbdataplane.GetEnvoyBootstrapParamsRequest) (*pbdataplane.GetEnvoyBootstrapParamsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -140,6 +199,65 @@ func (_c *DataplaneServiceServer_GetSupportedDataplaneFeatures_Call) RunAndRetur
 	return _c
 }
 
+// ReportUpstreamMetrics provides a mock function with given fields: _a0, _a1
+func (_m *DataplaneServiceServer) ReportUpstreamMetrics(_a0 context.Context, _a1 *pbdataplane.ReportUpstreamMetricsRequest) (*pbdataplane.ReportUpstreamMetricsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportUpstreamMetrics")
+	}
+
+	var r0 *pbdataplane.ReportUpstreamMetricsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest) (*pbdataplane.ReportUpstreamMetricsResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest) *pbdataplane.ReportUpstreamMetricsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbdataplane.ReportUpstreamMetricsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataplaneServiceServer_ReportUpstreamMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportUpstreamMetrics'
+type DataplaneServiceServer_ReportUpstreamMetrics_Call struct {
+	*mock.Call
+}
+
+// ReportUpstreamMetrics is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbdataplane.ReportUpstreamMetricsRequest
+func (_e *DataplaneServiceServer_Expecter) ReportUpstreamMetrics(_a0 interface{}, _a1 interface{}) *DataplaneServiceServer_ReportUpstreamMetrics_Call {
+	return &DataplaneServiceServer_ReportUpstreamMetrics_Call{Call: _e.mock.On("ReportUpstreamMetrics", _a0, _a1)}
+}
+
+func (_c *DataplaneServiceServer_ReportUpstreamMetrics_Call) Run(run func(_a0 context.Context, _a1 *pbdataplane.ReportUpstreamMetricsRequest)) *DataplaneServiceServer_ReportUpstreamMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbdataplane.ReportUpstreamMetricsRequest))
+	})
+	return _c
+}
+
+func (_c *DataplaneServiceServer_ReportUpstreamMetrics_Call) Return(_a0 *pbdataplane.ReportUpstreamMetricsResponse, _a1 error) *DataplaneServiceServer_ReportUpstreamMetrics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataplaneServiceServer_ReportUpstreamMetrics_Call) RunAndReturn(run func(context.Context, *pbdataplane.ReportUpstreamMetricsRequest) (*pbdataplane.ReportUpstreamMetricsResponse, error)) *DataplaneServiceServer_ReportUpstreamMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDataplaneServiceServer creates a new instance of DataplaneServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDataplaneServiceServer(t interface {
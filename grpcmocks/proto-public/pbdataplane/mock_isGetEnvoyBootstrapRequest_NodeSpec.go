@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbdataplane
+
+import mock "github.com/stretchr/testify/mock"
+
+// isGetEnvoyBootstrapRequest_NodeSpec is an autogenerated mock type for the isGetEnvoyBootstrapRequest_NodeSpec type
+type isGetEnvoyBootstrapRequest_NodeSpec struct {
+	mock.Mock
+}
+
+type isGetEnvoyBootstrapRequest_NodeSpec_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *isGetEnvoyBootstrapRequest_NodeSpec) EXPECT() *isGetEnvoyBootstrapRequest_NodeSpec_Expecter {
+	return &isGetEnvoyBootstrapRequest_NodeSpec_Expecter{mock: &_m.Mock}
+}
+
+// isGetEnvoyBootstrapRequest_NodeSpec provides a mock function with given fields:
+func (_m *isGetEnvoyBootstrapRequest_NodeSpec) isGetEnvoyBootstrapRequest_NodeSpec() {
+	_m.Called()
+}
+
+// isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'isGetEnvoyBootstrapRequest_NodeSpec'
+type isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call struct {
+	*mock.Call
+}
+
+// isGetEnvoyBootstrapRequest_NodeSpec is a helper method to define mock.On call
+func (_e *isGetEnvoyBootstrapRequest_NodeSpec_Expecter) isGetEnvoyBootstrapRequest_NodeSpec() *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call {
+	return &isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call{Call: _e.mock.On("isGetEnvoyBootstrapRequest_NodeSpec")}
+}
+
+func (_c *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call) Run(run func()) *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call) Return() *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call) RunAndReturn(run func()) *isGetEnvoyBootstrapRequest_NodeSpec_isGetEnvoyBootstrapRequest_NodeSpec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newIsGetEnvoyBootstrapRequest_NodeSpec creates a new instance of isGetEnvoyBootstrapRequest_NodeSpec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newIsGetEnvoyBootstrapRequest_NodeSpec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *isGetEnvoyBootstrapRequest_NodeSpec {
+	mock := &isGetEnvoyBootstrapRequest_NodeSpec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbdataplane
+
+import mock "github.com/stretchr/testify/mock"
+
+// isReportUpstreamMetricsRequest_NodeSpec is an autogenerated mock type for the isReportUpstreamMetricsRequest_NodeSpec type
+type isReportUpstreamMetricsRequest_NodeSpec struct {
+	mock.Mock
+}
+
+type isReportUpstreamMetricsRequest_NodeSpec_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *isReportUpstreamMetricsRequest_NodeSpec) EXPECT() *isReportUpstreamMetricsRequest_NodeSpec_Expecter {
+	return &isReportUpstreamMetricsRequest_NodeSpec_Expecter{mock: &_m.Mock}
+}
+
+// isReportUpstreamMetricsRequest_NodeSpec provides a mock function with given fields:
+func (_m *isReportUpstreamMetricsRequest_NodeSpec) isReportUpstreamMetricsRequest_NodeSpec() {
+	_m.Called()
+}
+
+// isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'isReportUpstreamMetricsRequest_NodeSpec'
+type isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call struct {
+	*mock.Call
+}
+
+// isReportUpstreamMetricsRequest_NodeSpec is a helper method to define mock.On call
+func (_e *isReportUpstreamMetricsRequest_NodeSpec_Expecter) isReportUpstreamMetricsRequest_NodeSpec() *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call {
+	return &isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call{Call: _e.mock.On("isReportUpstreamMetricsRequest_NodeSpec")}
+}
+
+func (_c *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call) Run(run func()) *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call) Return() *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call) RunAndReturn(run func()) *isReportUpstreamMetricsRequest_NodeSpec_isReportUpstreamMetricsRequest_NodeSpec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newIsReportUpstreamMetricsRequest_NodeSpec creates a new instance of isReportUpstreamMetricsRequest_NodeSpec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newIsReportUpstreamMetricsRequest_NodeSpec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *isReportUpstreamMetricsRequest_NodeSpec {
+	mock := &isReportUpstreamMetricsRequest_NodeSpec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
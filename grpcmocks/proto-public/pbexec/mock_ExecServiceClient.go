@@ -0,0 +1,114 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbexec
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pbexec "github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+// ExecServiceClient is an autogenerated mock type for the ExecServiceClient type
+type ExecServiceClient struct {
+	mock.Mock
+}
+
+type ExecServiceClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExecServiceClient) EXPECT() *ExecServiceClient_Expecter {
+	return &ExecServiceClient_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, in, opts
+func (_m *ExecServiceClient) Exec(ctx context.Context, in *pbexec.ExecRequest, opts ...grpc.CallOption) (pbexec.ExecService_ExecClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 pbexec.ExecService_ExecClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbexec.ExecRequest, ...grpc.CallOption) (pbexec.ExecService_ExecClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbexec.ExecRequest, ...grpc.CallOption) pbexec.ExecService_ExecClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pbexec.ExecService_ExecClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbexec.ExecRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecServiceClient_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type ExecServiceClient_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbexec.ExecRequest
+//   - opts ...grpc.CallOption
+func (_e *ExecServiceClient_Expecter) Exec(ctx interface{}, in interface{}, opts ...interface{}) *ExecServiceClient_Exec_Call {
+	return &ExecServiceClient_Exec_Call{Call: _e.mock.On("Exec",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ExecServiceClient_Exec_Call) Run(run func(ctx context.Context, in *pbexec.ExecRequest, opts ...grpc.CallOption)) *ExecServiceClient_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbexec.ExecRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ExecServiceClient_Exec_Call) Return(_a0 pbexec.ExecService_ExecClient, _a1 error) *ExecServiceClient_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExecServiceClient_Exec_Call) RunAndReturn(run func(context.Context, *pbexec.ExecRequest, ...grpc.CallOption) (pbexec.ExecService_ExecClient, error)) *ExecServiceClient_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExecServiceClient creates a new instance of ExecServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExecServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExecServiceClient {
+	mock := &ExecServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbexec
+
+import (
+	pbexec "github.com/hashicorp/consul/proto-public/pbexec"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExecServiceServer is an autogenerated mock type for the ExecServiceServer type
+type ExecServiceServer struct {
+	mock.Mock
+}
+
+type ExecServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExecServiceServer) EXPECT() *ExecServiceServer_Expecter {
+	return &ExecServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: _a0, _a1
+func (_m *ExecServiceServer) Exec(_a0 *pbexec.ExecRequest, _a1 pbexec.ExecService_ExecServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbexec.ExecRequest, pbexec.ExecService_ExecServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecServiceServer_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type ExecServiceServer_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - _a0 *pbexec.ExecRequest
+//   - _a1 pbexec.ExecService_ExecServer
+func (_e *ExecServiceServer_Expecter) Exec(_a0 interface{}, _a1 interface{}) *ExecServiceServer_Exec_Call {
+	return &ExecServiceServer_Exec_Call{Call: _e.mock.On("Exec", _a0, _a1)}
+}
+
+func (_c *ExecServiceServer_Exec_Call) Run(run func(_a0 *pbexec.ExecRequest, _a1 pbexec.ExecService_ExecServer)) *ExecServiceServer_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbexec.ExecRequest), args[1].(pbexec.ExecService_ExecServer))
+	})
+	return _c
+}
+
+func (_c *ExecServiceServer_Exec_Call) Return(_a0 error) *ExecServiceServer_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecServiceServer_Exec_Call) RunAndReturn(run func(*pbexec.ExecRequest, pbexec.ExecService_ExecServer) error) *ExecServiceServer_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExecServiceServer creates a new instance of ExecServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExecServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExecServiceServer {
+	mock := &ExecServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,384 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbexec
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbexec "github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+// ExecService_ExecClient is an autogenerated mock type for the ExecService_ExecClient type
+type ExecService_ExecClient struct {
+	mock.Mock
+}
+
+type ExecService_ExecClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExecService_ExecClient) EXPECT() *ExecService_ExecClient_Expecter {
+	return &ExecService_ExecClient_Expecter{mock: &_m.Mock}
+}
+
+// CloseSend provides a mock function with given fields:
+func (_m *ExecService_ExecClient) CloseSend() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecClient_CloseSend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSend'
+type ExecService_ExecClient_CloseSend_Call struct {
+	*mock.Call
+}
+
+// CloseSend is a helper method to define mock.On call
+func (_e *ExecService_ExecClient_Expecter) CloseSend() *ExecService_ExecClient_CloseSend_Call {
+	return &ExecService_ExecClient_CloseSend_Call{Call: _e.mock.On("CloseSend")}
+}
+
+func (_c *ExecService_ExecClient_CloseSend_Call) Run(run func()) *ExecService_ExecClient_CloseSend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_CloseSend_Call) Return(_a0 error) *ExecService_ExecClient_CloseSend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_CloseSend_Call) RunAndReturn(run func() error) *ExecService_ExecClient_CloseSend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Context provides a mock function with given fields:
+func (_m *ExecService_ExecClient) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// ExecService_ExecClient_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type ExecService_ExecClient_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *ExecService_ExecClient_Expecter) Context() *ExecService_ExecClient_Context_Call {
+	return &ExecService_ExecClient_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *ExecService_ExecClient_Context_Call) Run(run func()) *ExecService_ExecClient_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Context_Call) Return(_a0 context.Context) *ExecService_ExecClient_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Context_Call) RunAndReturn(run func() context.Context) *ExecService_ExecClient_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Header provides a mock function with given fields:
+func (_m *ExecService_ExecClient) Header() (metadata.MD, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Header")
+	}
+
+	var r0 metadata.MD
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (metadata.MD, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecService_ExecClient_Header_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Header'
+type ExecService_ExecClient_Header_Call struct {
+	*mock.Call
+}
+
+// Header is a helper method to define mock.On call
+func (_e *ExecService_ExecClient_Expecter) Header() *ExecService_ExecClient_Header_Call {
+	return &ExecService_ExecClient_Header_Call{Call: _e.mock.On("Header")}
+}
+
+func (_c *ExecService_ExecClient_Header_Call) Run(run func()) *ExecService_ExecClient_Header_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Header_Call) Return(_a0 metadata.MD, _a1 error) *ExecService_ExecClient_Header_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Header_Call) RunAndReturn(run func() (metadata.MD, error)) *ExecService_ExecClient_Header_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recv provides a mock function with given fields:
+func (_m *ExecService_ExecClient) Recv() (*pbexec.ExecResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 *pbexec.ExecResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pbexec.ExecResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pbexec.ExecResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbexec.ExecResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecService_ExecClient_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type ExecService_ExecClient_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *ExecService_ExecClient_Expecter) Recv() *ExecService_ExecClient_Recv_Call {
+	return &ExecService_ExecClient_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *ExecService_ExecClient_Recv_Call) Run(run func()) *ExecService_ExecClient_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Recv_Call) Return(_a0 *pbexec.ExecResponse, _a1 error) *ExecService_ExecClient_Recv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Recv_Call) RunAndReturn(run func() (*pbexec.ExecResponse, error)) *ExecService_ExecClient_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *ExecService_ExecClient) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecClient_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type ExecService_ExecClient_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *ExecService_ExecClient_Expecter) RecvMsg(m interface{}) *ExecService_ExecClient_RecvMsg_Call {
+	return &ExecService_ExecClient_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *ExecService_ExecClient_RecvMsg_Call) Run(run func(m interface{})) *ExecService_ExecClient_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_RecvMsg_Call) Return(_a0 error) *ExecService_ExecClient_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *ExecService_ExecClient_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *ExecService_ExecClient) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecClient_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type ExecService_ExecClient_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *ExecService_ExecClient_Expecter) SendMsg(m interface{}) *ExecService_ExecClient_SendMsg_Call {
+	return &ExecService_ExecClient_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *ExecService_ExecClient_SendMsg_Call) Run(run func(m interface{})) *ExecService_ExecClient_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_SendMsg_Call) Return(_a0 error) *ExecService_ExecClient_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_SendMsg_Call) RunAndReturn(run func(interface{}) error) *ExecService_ExecClient_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trailer provides a mock function with given fields:
+func (_m *ExecService_ExecClient) Trailer() metadata.MD {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trailer")
+	}
+
+	var r0 metadata.MD
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	return r0
+}
+
+// ExecService_ExecClient_Trailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trailer'
+type ExecService_ExecClient_Trailer_Call struct {
+	*mock.Call
+}
+
+// Trailer is a helper method to define mock.On call
+func (_e *ExecService_ExecClient_Expecter) Trailer() *ExecService_ExecClient_Trailer_Call {
+	return &ExecService_ExecClient_Trailer_Call{Call: _e.mock.On("Trailer")}
+}
+
+func (_c *ExecService_ExecClient_Trailer_Call) Run(run func()) *ExecService_ExecClient_Trailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Trailer_Call) Return(_a0 metadata.MD) *ExecService_ExecClient_Trailer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecClient_Trailer_Call) RunAndReturn(run func() metadata.MD) *ExecService_ExecClient_Trailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExecService_ExecClient creates a new instance of ExecService_ExecClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExecService_ExecClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExecService_ExecClient {
+	mock := &ExecService_ExecClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
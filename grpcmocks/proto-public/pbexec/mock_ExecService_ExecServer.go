@@ -0,0 +1,349 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbexec
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbexec "github.com/hashicorp/consul/proto-public/pbexec"
+)
+
+// ExecService_ExecServer is an autogenerated mock type for the ExecService_ExecServer type
+type ExecService_ExecServer struct {
+	mock.Mock
+}
+
+type ExecService_ExecServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExecService_ExecServer) EXPECT() *ExecService_ExecServer_Expecter {
+	return &ExecService_ExecServer_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with given fields:
+func (_m *ExecService_ExecServer) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type ExecService_ExecServer_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *ExecService_ExecServer_Expecter) Context() *ExecService_ExecServer_Context_Call {
+	return &ExecService_ExecServer_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *ExecService_ExecServer_Context_Call) Run(run func()) *ExecService_ExecServer_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_Context_Call) Return(_a0 context.Context) *ExecService_ExecServer_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_Context_Call) RunAndReturn(run func() context.Context) *ExecService_ExecServer_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *ExecService_ExecServer) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type ExecService_ExecServer_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *ExecService_ExecServer_Expecter) RecvMsg(m interface{}) *ExecService_ExecServer_RecvMsg_Call {
+	return &ExecService_ExecServer_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *ExecService_ExecServer_RecvMsg_Call) Run(run func(m interface{})) *ExecService_ExecServer_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_RecvMsg_Call) Return(_a0 error) *ExecService_ExecServer_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *ExecService_ExecServer_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *ExecService_ExecServer) Send(_a0 *pbexec.ExecResponse) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbexec.ExecResponse) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type ExecService_ExecServer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbexec.ExecResponse
+func (_e *ExecService_ExecServer_Expecter) Send(_a0 interface{}) *ExecService_ExecServer_Send_Call {
+	return &ExecService_ExecServer_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *ExecService_ExecServer_Send_Call) Run(run func(_a0 *pbexec.ExecResponse)) *ExecService_ExecServer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbexec.ExecResponse))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_Send_Call) Return(_a0 error) *ExecService_ExecServer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_Send_Call) RunAndReturn(run func(*pbexec.ExecResponse) error) *ExecService_ExecServer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendHeader provides a mock function with given fields: _a0
+func (_m *ExecService_ExecServer) SendHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_SendHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendHeader'
+type ExecService_ExecServer_SendHeader_Call struct {
+	*mock.Call
+}
+
+// SendHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *ExecService_ExecServer_Expecter) SendHeader(_a0 interface{}) *ExecService_ExecServer_SendHeader_Call {
+	return &ExecService_ExecServer_SendHeader_Call{Call: _e.mock.On("SendHeader", _a0)}
+}
+
+func (_c *ExecService_ExecServer_SendHeader_Call) Run(run func(_a0 metadata.MD)) *ExecService_ExecServer_SendHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SendHeader_Call) Return(_a0 error) *ExecService_ExecServer_SendHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SendHeader_Call) RunAndReturn(run func(metadata.MD) error) *ExecService_ExecServer_SendHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *ExecService_ExecServer) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type ExecService_ExecServer_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *ExecService_ExecServer_Expecter) SendMsg(m interface{}) *ExecService_ExecServer_SendMsg_Call {
+	return &ExecService_ExecServer_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *ExecService_ExecServer_SendMsg_Call) Run(run func(m interface{})) *ExecService_ExecServer_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SendMsg_Call) Return(_a0 error) *ExecService_ExecServer_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SendMsg_Call) RunAndReturn(run func(interface{}) error) *ExecService_ExecServer_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHeader provides a mock function with given fields: _a0
+func (_m *ExecService_ExecServer) SetHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExecService_ExecServer_SetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHeader'
+type ExecService_ExecServer_SetHeader_Call struct {
+	*mock.Call
+}
+
+// SetHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *ExecService_ExecServer_Expecter) SetHeader(_a0 interface{}) *ExecService_ExecServer_SetHeader_Call {
+	return &ExecService_ExecServer_SetHeader_Call{Call: _e.mock.On("SetHeader", _a0)}
+}
+
+func (_c *ExecService_ExecServer_SetHeader_Call) Run(run func(_a0 metadata.MD)) *ExecService_ExecServer_SetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SetHeader_Call) Return(_a0 error) *ExecService_ExecServer_SetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SetHeader_Call) RunAndReturn(run func(metadata.MD) error) *ExecService_ExecServer_SetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrailer provides a mock function with given fields: _a0
+func (_m *ExecService_ExecServer) SetTrailer(_a0 metadata.MD) {
+	_m.Called(_a0)
+}
+
+// ExecService_ExecServer_SetTrailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrailer'
+type ExecService_ExecServer_SetTrailer_Call struct {
+	*mock.Call
+}
+
+// SetTrailer is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *ExecService_ExecServer_Expecter) SetTrailer(_a0 interface{}) *ExecService_ExecServer_SetTrailer_Call {
+	return &ExecService_ExecServer_SetTrailer_Call{Call: _e.mock.On("SetTrailer", _a0)}
+}
+
+func (_c *ExecService_ExecServer_SetTrailer_Call) Run(run func(_a0 metadata.MD)) *ExecService_ExecServer_SetTrailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SetTrailer_Call) Return() *ExecService_ExecServer_SetTrailer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ExecService_ExecServer_SetTrailer_Call) RunAndReturn(run func(metadata.MD)) *ExecService_ExecServer_SetTrailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExecService_ExecServer creates a new instance of ExecService_ExecServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExecService_ExecServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExecService_ExecServer {
+	mock := &ExecService_ExecServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
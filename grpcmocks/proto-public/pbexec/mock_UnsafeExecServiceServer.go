@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbexec
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeExecServiceServer is an autogenerated mock type for the UnsafeExecServiceServer type
+type UnsafeExecServiceServer struct {
+	mock.Mock
+}
+
+type UnsafeExecServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsafeExecServiceServer) EXPECT() *UnsafeExecServiceServer_Expecter {
+	return &UnsafeExecServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// mustEmbedUnimplementedExecServiceServer provides a mock function with given fields:
+func (_m *UnsafeExecServiceServer) mustEmbedUnimplementedExecServiceServer() {
+	_m.Called()
+}
+
+// UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'mustEmbedUnimplementedExecServiceServer'
+type UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call struct {
+	*mock.Call
+}
+
+// mustEmbedUnimplementedExecServiceServer is a helper method to define mock.On call
+func (_e *UnsafeExecServiceServer_Expecter) mustEmbedUnimplementedExecServiceServer() *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call {
+	return &UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call{Call: _e.mock.On("mustEmbedUnimplementedExecServiceServer")}
+}
+
+func (_c *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call) Run(run func()) *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call) Return() *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call) RunAndReturn(run func()) *UnsafeExecServiceServer_mustEmbedUnimplementedExecServiceServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsafeExecServiceServer creates a new instance of UnsafeExecServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeExecServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeExecServiceServer {
+	mock := &UnsafeExecServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,410 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// HealthServiceClient is an autogenerated mock type for the HealthServiceClient type
+type HealthServiceClient struct {
+	mock.Mock
+}
+
+type HealthServiceClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthServiceClient) EXPECT() *HealthServiceClient_Expecter {
+	return &HealthServiceClient_Expecter{mock: &_m.Mock}
+}
+
+// ListNodes provides a mock function with given fields: ctx, in, opts
+func (_m *HealthServiceClient) ListNodes(ctx context.Context, in *pbhealth.ListNodesRequest, opts ...grpc.CallOption) (*pbhealth.ListNodesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNodes")
+	}
+
+	var r0 *pbhealth.ListNodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListNodesRequest, ...grpc.CallOption) (*pbhealth.ListNodesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListNodesRequest, ...grpc.CallOption) *pbhealth.ListNodesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ListNodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ListNodesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceClient_ListNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNodes'
+type HealthServiceClient_ListNodes_Call struct {
+	*mock.Call
+}
+
+// ListNodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.ListNodesRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthServiceClient_Expecter) ListNodes(ctx interface{}, in interface{}, opts ...interface{}) *HealthServiceClient_ListNodes_Call {
+	return &HealthServiceClient_ListNodes_Call{Call: _e.mock.On("ListNodes",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthServiceClient_ListNodes_Call) Run(run func(ctx context.Context, in *pbhealth.ListNodesRequest, opts ...grpc.CallOption)) *HealthServiceClient_ListNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.ListNodesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthServiceClient_ListNodes_Call) Return(_a0 *pbhealth.ListNodesResponse, _a1 error) *HealthServiceClient_ListNodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceClient_ListNodes_Call) RunAndReturn(run func(context.Context, *pbhealth.ListNodesRequest, ...grpc.CallOption) (*pbhealth.ListNodesResponse, error)) *HealthServiceClient_ListNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServices provides a mock function with given fields: ctx, in, opts
+func (_m *HealthServiceClient) ListServices(ctx context.Context, in *pbhealth.ListServicesRequest, opts ...grpc.CallOption) (*pbhealth.ListServicesResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServices")
+	}
+
+	var r0 *pbhealth.ListServicesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListServicesRequest, ...grpc.CallOption) (*pbhealth.ListServicesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListServicesRequest, ...grpc.CallOption) *pbhealth.ListServicesResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ListServicesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ListServicesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceClient_ListServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServices'
+type HealthServiceClient_ListServices_Call struct {
+	*mock.Call
+}
+
+// ListServices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.ListServicesRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthServiceClient_Expecter) ListServices(ctx interface{}, in interface{}, opts ...interface{}) *HealthServiceClient_ListServices_Call {
+	return &HealthServiceClient_ListServices_Call{Call: _e.mock.On("ListServices",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthServiceClient_ListServices_Call) Run(run func(ctx context.Context, in *pbhealth.ListServicesRequest, opts ...grpc.CallOption)) *HealthServiceClient_ListServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.ListServicesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthServiceClient_ListServices_Call) Return(_a0 *pbhealth.ListServicesResponse, _a1 error) *HealthServiceClient_ListServices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceClient_ListServices_Call) RunAndReturn(run func(context.Context, *pbhealth.ListServicesRequest, ...grpc.CallOption) (*pbhealth.ListServicesResponse, error)) *HealthServiceClient_ListServices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NodeChecks provides a mock function with given fields: ctx, in, opts
+func (_m *HealthServiceClient) NodeChecks(ctx context.Context, in *pbhealth.NodeChecksRequest, opts ...grpc.CallOption) (*pbhealth.NodeChecksResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NodeChecks")
+	}
+
+	var r0 *pbhealth.NodeChecksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.NodeChecksRequest, ...grpc.CallOption) (*pbhealth.NodeChecksResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.NodeChecksRequest, ...grpc.CallOption) *pbhealth.NodeChecksResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.NodeChecksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.NodeChecksRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceClient_NodeChecks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NodeChecks'
+type HealthServiceClient_NodeChecks_Call struct {
+	*mock.Call
+}
+
+// NodeChecks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.NodeChecksRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthServiceClient_Expecter) NodeChecks(ctx interface{}, in interface{}, opts ...interface{}) *HealthServiceClient_NodeChecks_Call {
+	return &HealthServiceClient_NodeChecks_Call{Call: _e.mock.On("NodeChecks",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthServiceClient_NodeChecks_Call) Run(run func(ctx context.Context, in *pbhealth.NodeChecksRequest, opts ...grpc.CallOption)) *HealthServiceClient_NodeChecks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.NodeChecksRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthServiceClient_NodeChecks_Call) Return(_a0 *pbhealth.NodeChecksResponse, _a1 error) *HealthServiceClient_NodeChecks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceClient_NodeChecks_Call) RunAndReturn(run func(context.Context, *pbhealth.NodeChecksRequest, ...grpc.CallOption) (*pbhealth.NodeChecksResponse, error)) *HealthServiceClient_NodeChecks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServiceHealth provides a mock function with given fields: ctx, in, opts
+func (_m *HealthServiceClient) ServiceHealth(ctx context.Context, in *pbhealth.ServiceHealthRequest, opts ...grpc.CallOption) (*pbhealth.ServiceHealthResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServiceHealth")
+	}
+
+	var r0 *pbhealth.ServiceHealthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) (*pbhealth.ServiceHealthResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) *pbhealth.ServiceHealthResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ServiceHealthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceClient_ServiceHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServiceHealth'
+type HealthServiceClient_ServiceHealth_Call struct {
+	*mock.Call
+}
+
+// ServiceHealth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.ServiceHealthRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthServiceClient_Expecter) ServiceHealth(ctx interface{}, in interface{}, opts ...interface{}) *HealthServiceClient_ServiceHealth_Call {
+	return &HealthServiceClient_ServiceHealth_Call{Call: _e.mock.On("ServiceHealth",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthServiceClient_ServiceHealth_Call) Run(run func(ctx context.Context, in *pbhealth.ServiceHealthRequest, opts ...grpc.CallOption)) *HealthServiceClient_ServiceHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.ServiceHealthRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthServiceClient_ServiceHealth_Call) Return(_a0 *pbhealth.ServiceHealthResponse, _a1 error) *HealthServiceClient_ServiceHealth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceClient_ServiceHealth_Call) RunAndReturn(run func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) (*pbhealth.ServiceHealthResponse, error)) *HealthServiceClient_ServiceHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchServiceHealth provides a mock function with given fields: ctx, in, opts
+func (_m *HealthServiceClient) WatchServiceHealth(ctx context.Context, in *pbhealth.ServiceHealthRequest, opts ...grpc.CallOption) (pbhealth.HealthService_WatchServiceHealthClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchServiceHealth")
+	}
+
+	var r0 pbhealth.HealthService_WatchServiceHealthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) (pbhealth.HealthService_WatchServiceHealthClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) pbhealth.HealthService_WatchServiceHealthClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pbhealth.HealthService_WatchServiceHealthClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceClient_WatchServiceHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchServiceHealth'
+type HealthServiceClient_WatchServiceHealth_Call struct {
+	*mock.Call
+}
+
+// WatchServiceHealth is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.ServiceHealthRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthServiceClient_Expecter) WatchServiceHealth(ctx interface{}, in interface{}, opts ...interface{}) *HealthServiceClient_WatchServiceHealth_Call {
+	return &HealthServiceClient_WatchServiceHealth_Call{Call: _e.mock.On("WatchServiceHealth",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthServiceClient_WatchServiceHealth_Call) Run(run func(ctx context.Context, in *pbhealth.ServiceHealthRequest, opts ...grpc.CallOption)) *HealthServiceClient_WatchServiceHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.ServiceHealthRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthServiceClient_WatchServiceHealth_Call) Return(_a0 pbhealth.HealthService_WatchServiceHealthClient, _a1 error) *HealthServiceClient_WatchServiceHealth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceClient_WatchServiceHealth_Call) RunAndReturn(run func(context.Context, *pbhealth.ServiceHealthRequest, ...grpc.CallOption) (pbhealth.HealthService_WatchServiceHealthClient, error)) *HealthServiceClient_WatchServiceHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthServiceClient creates a new instance of HealthServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthServiceClient {
+	mock := &HealthServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
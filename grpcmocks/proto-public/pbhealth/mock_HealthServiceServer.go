@@ -0,0 +1,320 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthServiceServer is an autogenerated mock type for the HealthServiceServer type
+type HealthServiceServer struct {
+	mock.Mock
+}
+
+type HealthServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthServiceServer) EXPECT() *HealthServiceServer_Expecter {
+	return &HealthServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// ListNodes provides a mock function with given fields: _a0, _a1
+func (_m *HealthServiceServer) ListNodes(_a0 context.Context, _a1 *pbhealth.ListNodesRequest) (*pbhealth.ListNodesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNodes")
+	}
+
+	var r0 *pbhealth.ListNodesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListNodesRequest) (*pbhealth.ListNodesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListNodesRequest) *pbhealth.ListNodesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ListNodesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ListNodesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceServer_ListNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNodes'
+type HealthServiceServer_ListNodes_Call struct {
+	*mock.Call
+}
+
+// ListNodes is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbhealth.ListNodesRequest
+func (_e *HealthServiceServer_Expecter) ListNodes(_a0 interface{}, _a1 interface{}) *HealthServiceServer_ListNodes_Call {
+	return &HealthServiceServer_ListNodes_Call{Call: _e.mock.On("ListNodes", _a0, _a1)}
+}
+
+func (_c *HealthServiceServer_ListNodes_Call) Run(run func(_a0 context.Context, _a1 *pbhealth.ListNodesRequest)) *HealthServiceServer_ListNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbhealth.ListNodesRequest))
+	})
+	return _c
+}
+
+func (_c *HealthServiceServer_ListNodes_Call) Return(_a0 *pbhealth.ListNodesResponse, _a1 error) *HealthServiceServer_ListNodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceServer_ListNodes_Call) RunAndReturn(run func(context.Context, *pbhealth.ListNodesRequest) (*pbhealth.ListNodesResponse, error)) *HealthServiceServer_ListNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServices provides a mock function with given fields: _a0, _a1
+func (_m *HealthServiceServer) ListServices(_a0 context.Context, _a1 *pbhealth.ListServicesRequest) (*pbhealth.ListServicesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServices")
+	}
+
+	var r0 *pbhealth.ListServicesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListServicesRequest) (*pbhealth.ListServicesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ListServicesRequest) *pbhealth.ListServicesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ListServicesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ListServicesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceServer_ListServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServices'
+type HealthServiceServer_ListServices_Call struct {
+	*mock.Call
+}
+
+// ListServices is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbhealth.ListServicesRequest
+func (_e *HealthServiceServer_Expecter) ListServices(_a0 interface{}, _a1 interface{}) *HealthServiceServer_ListServices_Call {
+	return &HealthServiceServer_ListServices_Call{Call: _e.mock.On("ListServices", _a0, _a1)}
+}
+
+func (_c *HealthServiceServer_ListServices_Call) Run(run func(_a0 context.Context, _a1 *pbhealth.ListServicesRequest)) *HealthServiceServer_ListServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbhealth.ListServicesRequest))
+	})
+	return _c
+}
+
+func (_c *HealthServiceServer_ListServices_Call) Return(_a0 *pbhealth.ListServicesResponse, _a1 error) *HealthServiceServer_ListServices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceServer_ListServices_Call) RunAndReturn(run func(context.Context, *pbhealth.ListServicesRequest) (*pbhealth.ListServicesResponse, error)) *HealthServiceServer_ListServices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NodeChecks provides a mock function with given fields: _a0, _a1
+func (_m *HealthServiceServer) NodeChecks(_a0 context.Context, _a1 *pbhealth.NodeChecksRequest) (*pbhealth.NodeChecksResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NodeChecks")
+	}
+
+	var r0 *pbhealth.NodeChecksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.NodeChecksRequest) (*pbhealth.NodeChecksResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.NodeChecksRequest) *pbhealth.NodeChecksResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.NodeChecksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.NodeChecksRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceServer_NodeChecks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NodeChecks'
+type HealthServiceServer_NodeChecks_Call struct {
+	*mock.Call
+}
+
+// NodeChecks is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbhealth.NodeChecksRequest
+func (_e *HealthServiceServer_Expecter) NodeChecks(_a0 interface{}, _a1 interface{}) *HealthServiceServer_NodeChecks_Call {
+	return &HealthServiceServer_NodeChecks_Call{Call: _e.mock.On("NodeChecks", _a0, _a1)}
+}
+
+func (_c *HealthServiceServer_NodeChecks_Call) Run(run func(_a0 context.Context, _a1 *pbhealth.NodeChecksRequest)) *HealthServiceServer_NodeChecks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbhealth.NodeChecksRequest))
+	})
+	return _c
+}
+
+func (_c *HealthServiceServer_NodeChecks_Call) Return(_a0 *pbhealth.NodeChecksResponse, _a1 error) *HealthServiceServer_NodeChecks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceServer_NodeChecks_Call) RunAndReturn(run func(context.Context, *pbhealth.NodeChecksRequest) (*pbhealth.NodeChecksResponse, error)) *HealthServiceServer_NodeChecks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ServiceHealth provides a mock function with given fields: _a0, _a1
+func (_m *HealthServiceServer) ServiceHealth(_a0 context.Context, _a1 *pbhealth.ServiceHealthRequest) (*pbhealth.ServiceHealthResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServiceHealth")
+	}
+
+	var r0 *pbhealth.ServiceHealthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest) (*pbhealth.ServiceHealthResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.ServiceHealthRequest) *pbhealth.ServiceHealthResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ServiceHealthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.ServiceHealthRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthServiceServer_ServiceHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServiceHealth'
+type HealthServiceServer_ServiceHealth_Call struct {
+	*mock.Call
+}
+
+// ServiceHealth is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbhealth.ServiceHealthRequest
+func (_e *HealthServiceServer_Expecter) ServiceHealth(_a0 interface{}, _a1 interface{}) *HealthServiceServer_ServiceHealth_Call {
+	return &HealthServiceServer_ServiceHealth_Call{Call: _e.mock.On("ServiceHealth", _a0, _a1)}
+}
+
+func (_c *HealthServiceServer_ServiceHealth_Call) Run(run func(_a0 context.Context, _a1 *pbhealth.ServiceHealthRequest)) *HealthServiceServer_ServiceHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbhealth.ServiceHealthRequest))
+	})
+	return _c
+}
+
+func (_c *HealthServiceServer_ServiceHealth_Call) Return(_a0 *pbhealth.ServiceHealthResponse, _a1 error) *HealthServiceServer_ServiceHealth_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthServiceServer_ServiceHealth_Call) RunAndReturn(run func(context.Context, *pbhealth.ServiceHealthRequest) (*pbhealth.ServiceHealthResponse, error)) *HealthServiceServer_ServiceHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchServiceHealth provides a mock function with given fields: _a0, _a1
+func (_m *HealthServiceServer) WatchServiceHealth(_a0 *pbhealth.ServiceHealthRequest, _a1 pbhealth.HealthService_WatchServiceHealthServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchServiceHealth")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbhealth.ServiceHealthRequest, pbhealth.HealthService_WatchServiceHealthServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthServiceServer_WatchServiceHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchServiceHealth'
+type HealthServiceServer_WatchServiceHealth_Call struct {
+	*mock.Call
+}
+
+// WatchServiceHealth is a helper method to define mock.On call
+//   - _a0 *pbhealth.ServiceHealthRequest
+//   - _a1 pbhealth.HealthService_WatchServiceHealthServer
+func (_e *HealthServiceServer_Expecter) WatchServiceHealth(_a0 interface{}, _a1 interface{}) *HealthServiceServer_WatchServiceHealth_Call {
+	return &HealthServiceServer_WatchServiceHealth_Call{Call: _e.mock.On("WatchServiceHealth", _a0, _a1)}
+}
+
+func (_c *HealthServiceServer_WatchServiceHealth_Call) Run(run func(_a0 *pbhealth.ServiceHealthRequest, _a1 pbhealth.HealthService_WatchServiceHealthServer)) *HealthServiceServer_WatchServiceHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbhealth.ServiceHealthRequest), args[1].(pbhealth.HealthService_WatchServiceHealthServer))
+	})
+	return _c
+}
+
+func (_c *HealthServiceServer_WatchServiceHealth_Call) Return(_a0 error) *HealthServiceServer_WatchServiceHealth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthServiceServer_WatchServiceHealth_Call) RunAndReturn(run func(*pbhealth.ServiceHealthRequest, pbhealth.HealthService_WatchServiceHealthServer) error) *HealthServiceServer_WatchServiceHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthServiceServer creates a new instance of HealthServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthServiceServer {
+	mock := &HealthServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,384 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// HealthService_WatchServiceHealthClient is an autogenerated mock type for the HealthService_WatchServiceHealthClient type
+type HealthService_WatchServiceHealthClient struct {
+	mock.Mock
+}
+
+type HealthService_WatchServiceHealthClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthService_WatchServiceHealthClient) EXPECT() *HealthService_WatchServiceHealthClient_Expecter {
+	return &HealthService_WatchServiceHealthClient_Expecter{mock: &_m.Mock}
+}
+
+// CloseSend provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthClient) CloseSend() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthClient_CloseSend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSend'
+type HealthService_WatchServiceHealthClient_CloseSend_Call struct {
+	*mock.Call
+}
+
+// CloseSend is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthClient_Expecter) CloseSend() *HealthService_WatchServiceHealthClient_CloseSend_Call {
+	return &HealthService_WatchServiceHealthClient_CloseSend_Call{Call: _e.mock.On("CloseSend")}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_CloseSend_Call) Run(run func()) *HealthService_WatchServiceHealthClient_CloseSend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_CloseSend_Call) Return(_a0 error) *HealthService_WatchServiceHealthClient_CloseSend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_CloseSend_Call) RunAndReturn(run func() error) *HealthService_WatchServiceHealthClient_CloseSend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Context provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthClient) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthClient_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type HealthService_WatchServiceHealthClient_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthClient_Expecter) Context() *HealthService_WatchServiceHealthClient_Context_Call {
+	return &HealthService_WatchServiceHealthClient_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Context_Call) Run(run func()) *HealthService_WatchServiceHealthClient_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Context_Call) Return(_a0 context.Context) *HealthService_WatchServiceHealthClient_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Context_Call) RunAndReturn(run func() context.Context) *HealthService_WatchServiceHealthClient_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Header provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthClient) Header() (metadata.MD, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Header")
+	}
+
+	var r0 metadata.MD
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (metadata.MD, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthService_WatchServiceHealthClient_Header_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Header'
+type HealthService_WatchServiceHealthClient_Header_Call struct {
+	*mock.Call
+}
+
+// Header is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthClient_Expecter) Header() *HealthService_WatchServiceHealthClient_Header_Call {
+	return &HealthService_WatchServiceHealthClient_Header_Call{Call: _e.mock.On("Header")}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Header_Call) Run(run func()) *HealthService_WatchServiceHealthClient_Header_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Header_Call) Return(_a0 metadata.MD, _a1 error) *HealthService_WatchServiceHealthClient_Header_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Header_Call) RunAndReturn(run func() (metadata.MD, error)) *HealthService_WatchServiceHealthClient_Header_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recv provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthClient) Recv() (*pbhealth.ServiceHealthResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 *pbhealth.ServiceHealthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pbhealth.ServiceHealthResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pbhealth.ServiceHealthResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbhealth.ServiceHealthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthService_WatchServiceHealthClient_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type HealthService_WatchServiceHealthClient_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthClient_Expecter) Recv() *HealthService_WatchServiceHealthClient_Recv_Call {
+	return &HealthService_WatchServiceHealthClient_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Recv_Call) Run(run func()) *HealthService_WatchServiceHealthClient_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Recv_Call) Return(_a0 *pbhealth.ServiceHealthResponse, _a1 error) *HealthService_WatchServiceHealthClient_Recv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Recv_Call) RunAndReturn(run func() (*pbhealth.ServiceHealthResponse, error)) *HealthService_WatchServiceHealthClient_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *HealthService_WatchServiceHealthClient) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthClient_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type HealthService_WatchServiceHealthClient_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthService_WatchServiceHealthClient_Expecter) RecvMsg(m interface{}) *HealthService_WatchServiceHealthClient_RecvMsg_Call {
+	return &HealthService_WatchServiceHealthClient_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_RecvMsg_Call) Run(run func(m interface{})) *HealthService_WatchServiceHealthClient_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_RecvMsg_Call) Return(_a0 error) *HealthService_WatchServiceHealthClient_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *HealthService_WatchServiceHealthClient_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *HealthService_WatchServiceHealthClient) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthClient_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type HealthService_WatchServiceHealthClient_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthService_WatchServiceHealthClient_Expecter) SendMsg(m interface{}) *HealthService_WatchServiceHealthClient_SendMsg_Call {
+	return &HealthService_WatchServiceHealthClient_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_SendMsg_Call) Run(run func(m interface{})) *HealthService_WatchServiceHealthClient_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_SendMsg_Call) Return(_a0 error) *HealthService_WatchServiceHealthClient_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_SendMsg_Call) RunAndReturn(run func(interface{}) error) *HealthService_WatchServiceHealthClient_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trailer provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthClient) Trailer() metadata.MD {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trailer")
+	}
+
+	var r0 metadata.MD
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthClient_Trailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trailer'
+type HealthService_WatchServiceHealthClient_Trailer_Call struct {
+	*mock.Call
+}
+
+// Trailer is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthClient_Expecter) Trailer() *HealthService_WatchServiceHealthClient_Trailer_Call {
+	return &HealthService_WatchServiceHealthClient_Trailer_Call{Call: _e.mock.On("Trailer")}
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Trailer_Call) Run(run func()) *HealthService_WatchServiceHealthClient_Trailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Trailer_Call) Return(_a0 metadata.MD) *HealthService_WatchServiceHealthClient_Trailer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthClient_Trailer_Call) RunAndReturn(run func() metadata.MD) *HealthService_WatchServiceHealthClient_Trailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthService_WatchServiceHealthClient creates a new instance of HealthService_WatchServiceHealthClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthService_WatchServiceHealthClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthService_WatchServiceHealthClient {
+	mock := &HealthService_WatchServiceHealthClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,349 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// HealthService_WatchServiceHealthServer is an autogenerated mock type for the HealthService_WatchServiceHealthServer type
+type HealthService_WatchServiceHealthServer struct {
+	mock.Mock
+}
+
+type HealthService_WatchServiceHealthServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthService_WatchServiceHealthServer) EXPECT() *HealthService_WatchServiceHealthServer_Expecter {
+	return &HealthService_WatchServiceHealthServer_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with given fields:
+func (_m *HealthService_WatchServiceHealthServer) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type HealthService_WatchServiceHealthServer_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *HealthService_WatchServiceHealthServer_Expecter) Context() *HealthService_WatchServiceHealthServer_Context_Call {
+	return &HealthService_WatchServiceHealthServer_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Context_Call) Run(run func()) *HealthService_WatchServiceHealthServer_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Context_Call) Return(_a0 context.Context) *HealthService_WatchServiceHealthServer_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Context_Call) RunAndReturn(run func() context.Context) *HealthService_WatchServiceHealthServer_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *HealthService_WatchServiceHealthServer) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type HealthService_WatchServiceHealthServer_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthService_WatchServiceHealthServer_Expecter) RecvMsg(m interface{}) *HealthService_WatchServiceHealthServer_RecvMsg_Call {
+	return &HealthService_WatchServiceHealthServer_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_RecvMsg_Call) Run(run func(m interface{})) *HealthService_WatchServiceHealthServer_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_RecvMsg_Call) Return(_a0 error) *HealthService_WatchServiceHealthServer_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *HealthService_WatchServiceHealthServer_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *HealthService_WatchServiceHealthServer) Send(_a0 *pbhealth.ServiceHealthResponse) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbhealth.ServiceHealthResponse) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type HealthService_WatchServiceHealthServer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbhealth.ServiceHealthResponse
+func (_e *HealthService_WatchServiceHealthServer_Expecter) Send(_a0 interface{}) *HealthService_WatchServiceHealthServer_Send_Call {
+	return &HealthService_WatchServiceHealthServer_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Send_Call) Run(run func(_a0 *pbhealth.ServiceHealthResponse)) *HealthService_WatchServiceHealthServer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbhealth.ServiceHealthResponse))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Send_Call) Return(_a0 error) *HealthService_WatchServiceHealthServer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_Send_Call) RunAndReturn(run func(*pbhealth.ServiceHealthResponse) error) *HealthService_WatchServiceHealthServer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendHeader provides a mock function with given fields: _a0
+func (_m *HealthService_WatchServiceHealthServer) SendHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_SendHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendHeader'
+type HealthService_WatchServiceHealthServer_SendHeader_Call struct {
+	*mock.Call
+}
+
+// SendHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthService_WatchServiceHealthServer_Expecter) SendHeader(_a0 interface{}) *HealthService_WatchServiceHealthServer_SendHeader_Call {
+	return &HealthService_WatchServiceHealthServer_SendHeader_Call{Call: _e.mock.On("SendHeader", _a0)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendHeader_Call) Run(run func(_a0 metadata.MD)) *HealthService_WatchServiceHealthServer_SendHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendHeader_Call) Return(_a0 error) *HealthService_WatchServiceHealthServer_SendHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendHeader_Call) RunAndReturn(run func(metadata.MD) error) *HealthService_WatchServiceHealthServer_SendHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *HealthService_WatchServiceHealthServer) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type HealthService_WatchServiceHealthServer_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthService_WatchServiceHealthServer_Expecter) SendMsg(m interface{}) *HealthService_WatchServiceHealthServer_SendMsg_Call {
+	return &HealthService_WatchServiceHealthServer_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendMsg_Call) Run(run func(m interface{})) *HealthService_WatchServiceHealthServer_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendMsg_Call) Return(_a0 error) *HealthService_WatchServiceHealthServer_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SendMsg_Call) RunAndReturn(run func(interface{}) error) *HealthService_WatchServiceHealthServer_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHeader provides a mock function with given fields: _a0
+func (_m *HealthService_WatchServiceHealthServer) SetHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthService_WatchServiceHealthServer_SetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHeader'
+type HealthService_WatchServiceHealthServer_SetHeader_Call struct {
+	*mock.Call
+}
+
+// SetHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthService_WatchServiceHealthServer_Expecter) SetHeader(_a0 interface{}) *HealthService_WatchServiceHealthServer_SetHeader_Call {
+	return &HealthService_WatchServiceHealthServer_SetHeader_Call{Call: _e.mock.On("SetHeader", _a0)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetHeader_Call) Run(run func(_a0 metadata.MD)) *HealthService_WatchServiceHealthServer_SetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetHeader_Call) Return(_a0 error) *HealthService_WatchServiceHealthServer_SetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetHeader_Call) RunAndReturn(run func(metadata.MD) error) *HealthService_WatchServiceHealthServer_SetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrailer provides a mock function with given fields: _a0
+func (_m *HealthService_WatchServiceHealthServer) SetTrailer(_a0 metadata.MD) {
+	_m.Called(_a0)
+}
+
+// HealthService_WatchServiceHealthServer_SetTrailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrailer'
+type HealthService_WatchServiceHealthServer_SetTrailer_Call struct {
+	*mock.Call
+}
+
+// SetTrailer is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthService_WatchServiceHealthServer_Expecter) SetTrailer(_a0 interface{}) *HealthService_WatchServiceHealthServer_SetTrailer_Call {
+	return &HealthService_WatchServiceHealthServer_SetTrailer_Call{Call: _e.mock.On("SetTrailer", _a0)}
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetTrailer_Call) Run(run func(_a0 metadata.MD)) *HealthService_WatchServiceHealthServer_SetTrailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetTrailer_Call) Return() *HealthService_WatchServiceHealthServer_SetTrailer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HealthService_WatchServiceHealthServer_SetTrailer_Call) RunAndReturn(run func(metadata.MD)) *HealthService_WatchServiceHealthServer_SetTrailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthService_WatchServiceHealthServer creates a new instance of HealthService_WatchServiceHealthServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthService_WatchServiceHealthServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthService_WatchServiceHealthServer {
+	mock := &HealthService_WatchServiceHealthServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
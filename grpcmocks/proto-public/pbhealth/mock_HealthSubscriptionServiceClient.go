@@ -0,0 +1,114 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// HealthSubscriptionServiceClient is an autogenerated mock type for the HealthSubscriptionServiceClient type
+type HealthSubscriptionServiceClient struct {
+	mock.Mock
+}
+
+type HealthSubscriptionServiceClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthSubscriptionServiceClient) EXPECT() *HealthSubscriptionServiceClient_Expecter {
+	return &HealthSubscriptionServiceClient_Expecter{mock: &_m.Mock}
+}
+
+// SubscribeServices provides a mock function with given fields: ctx, in, opts
+func (_m *HealthSubscriptionServiceClient) SubscribeServices(ctx context.Context, in *pbhealth.SubscribeServicesRequest, opts ...grpc.CallOption) (pbhealth.HealthSubscriptionService_SubscribeServicesClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeServices")
+	}
+
+	var r0 pbhealth.HealthSubscriptionService_SubscribeServicesClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.SubscribeServicesRequest, ...grpc.CallOption) (pbhealth.HealthSubscriptionService_SubscribeServicesClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbhealth.SubscribeServicesRequest, ...grpc.CallOption) pbhealth.HealthSubscriptionService_SubscribeServicesClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pbhealth.HealthSubscriptionService_SubscribeServicesClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbhealth.SubscribeServicesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HealthSubscriptionServiceClient_SubscribeServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeServices'
+type HealthSubscriptionServiceClient_SubscribeServices_Call struct {
+	*mock.Call
+}
+
+// SubscribeServices is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbhealth.SubscribeServicesRequest
+//   - opts ...grpc.CallOption
+func (_e *HealthSubscriptionServiceClient_Expecter) SubscribeServices(ctx interface{}, in interface{}, opts ...interface{}) *HealthSubscriptionServiceClient_SubscribeServices_Call {
+	return &HealthSubscriptionServiceClient_SubscribeServices_Call{Call: _e.mock.On("SubscribeServices",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *HealthSubscriptionServiceClient_SubscribeServices_Call) Run(run func(ctx context.Context, in *pbhealth.SubscribeServicesRequest, opts ...grpc.CallOption)) *HealthSubscriptionServiceClient_SubscribeServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbhealth.SubscribeServicesRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionServiceClient_SubscribeServices_Call) Return(_a0 pbhealth.HealthSubscriptionService_SubscribeServicesClient, _a1 error) *HealthSubscriptionServiceClient_SubscribeServices_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HealthSubscriptionServiceClient_SubscribeServices_Call) RunAndReturn(run func(context.Context, *pbhealth.SubscribeServicesRequest, ...grpc.CallOption) (pbhealth.HealthSubscriptionService_SubscribeServicesClient, error)) *HealthSubscriptionServiceClient_SubscribeServices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthSubscriptionServiceClient creates a new instance of HealthSubscriptionServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthSubscriptionServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthSubscriptionServiceClient {
+	mock := &HealthSubscriptionServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
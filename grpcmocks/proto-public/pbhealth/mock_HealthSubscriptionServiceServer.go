@@ -0,0 +1,82 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthSubscriptionServiceServer is an autogenerated mock type for the HealthSubscriptionServiceServer type
+type HealthSubscriptionServiceServer struct {
+	mock.Mock
+}
+
+type HealthSubscriptionServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthSubscriptionServiceServer) EXPECT() *HealthSubscriptionServiceServer_Expecter {
+	return &HealthSubscriptionServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// SubscribeServices provides a mock function with given fields: _a0, _a1
+func (_m *HealthSubscriptionServiceServer) SubscribeServices(_a0 *pbhealth.SubscribeServicesRequest, _a1 pbhealth.HealthSubscriptionService_SubscribeServicesServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeServices")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbhealth.SubscribeServicesRequest, pbhealth.HealthSubscriptionService_SubscribeServicesServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionServiceServer_SubscribeServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeServices'
+type HealthSubscriptionServiceServer_SubscribeServices_Call struct {
+	*mock.Call
+}
+
+// SubscribeServices is a helper method to define mock.On call
+//   - _a0 *pbhealth.SubscribeServicesRequest
+//   - _a1 pbhealth.HealthSubscriptionService_SubscribeServicesServer
+func (_e *HealthSubscriptionServiceServer_Expecter) SubscribeServices(_a0 interface{}, _a1 interface{}) *HealthSubscriptionServiceServer_SubscribeServices_Call {
+	return &HealthSubscriptionServiceServer_SubscribeServices_Call{Call: _e.mock.On("SubscribeServices", _a0, _a1)}
+}
+
+func (_c *HealthSubscriptionServiceServer_SubscribeServices_Call) Run(run func(_a0 *pbhealth.SubscribeServicesRequest, _a1 pbhealth.HealthSubscriptionService_SubscribeServicesServer)) *HealthSubscriptionServiceServer_SubscribeServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbhealth.SubscribeServicesRequest), args[1].(pbhealth.HealthSubscriptionService_SubscribeServicesServer))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionServiceServer_SubscribeServices_Call) Return(_a0 error) *HealthSubscriptionServiceServer_SubscribeServices_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionServiceServer_SubscribeServices_Call) RunAndReturn(run func(*pbhealth.SubscribeServicesRequest, pbhealth.HealthSubscriptionService_SubscribeServicesServer) error) *HealthSubscriptionServiceServer_SubscribeServices_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthSubscriptionServiceServer creates a new instance of HealthSubscriptionServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthSubscriptionServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthSubscriptionServiceServer {
+	mock := &HealthSubscriptionServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
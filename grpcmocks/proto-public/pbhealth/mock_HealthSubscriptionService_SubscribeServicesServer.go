@@ -0,0 +1,349 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbhealth "github.com/hashicorp/consul/proto-public/pbhealth"
+)
+
+// HealthSubscriptionService_SubscribeServicesServer is an autogenerated mock type for the HealthSubscriptionService_SubscribeServicesServer type
+type HealthSubscriptionService_SubscribeServicesServer struct {
+	mock.Mock
+}
+
+type HealthSubscriptionService_SubscribeServicesServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthSubscriptionService_SubscribeServicesServer) EXPECT() *HealthSubscriptionService_SubscribeServicesServer_Expecter {
+	return &HealthSubscriptionService_SubscribeServicesServer_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with given fields:
+func (_m *HealthSubscriptionService_SubscribeServicesServer) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type HealthSubscriptionService_SubscribeServicesServer_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) Context() *HealthSubscriptionService_SubscribeServicesServer_Context_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Context_Call) Run(run func()) *HealthSubscriptionService_SubscribeServicesServer_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Context_Call) Return(_a0 context.Context) *HealthSubscriptionService_SubscribeServicesServer_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Context_Call) RunAndReturn(run func() context.Context) *HealthSubscriptionService_SubscribeServicesServer_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *HealthSubscriptionService_SubscribeServicesServer) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) RecvMsg(m interface{}) *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call) Run(run func(m interface{})) *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call) Return(_a0 error) *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *HealthSubscriptionService_SubscribeServicesServer_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *HealthSubscriptionService_SubscribeServicesServer) Send(_a0 *pbhealth.SubscribeServicesResponse) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbhealth.SubscribeServicesResponse) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type HealthSubscriptionService_SubscribeServicesServer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbhealth.SubscribeServicesResponse
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) Send(_a0 interface{}) *HealthSubscriptionService_SubscribeServicesServer_Send_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Send_Call) Run(run func(_a0 *pbhealth.SubscribeServicesResponse)) *HealthSubscriptionService_SubscribeServicesServer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbhealth.SubscribeServicesResponse))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Send_Call) Return(_a0 error) *HealthSubscriptionService_SubscribeServicesServer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_Send_Call) RunAndReturn(run func(*pbhealth.SubscribeServicesResponse) error) *HealthSubscriptionService_SubscribeServicesServer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendHeader provides a mock function with given fields: _a0
+func (_m *HealthSubscriptionService_SubscribeServicesServer) SendHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendHeader'
+type HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call struct {
+	*mock.Call
+}
+
+// SendHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) SendHeader(_a0 interface{}) *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call{Call: _e.mock.On("SendHeader", _a0)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call) Run(run func(_a0 metadata.MD)) *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call) Return(_a0 error) *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call) RunAndReturn(run func(metadata.MD) error) *HealthSubscriptionService_SubscribeServicesServer_SendHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *HealthSubscriptionService_SubscribeServicesServer) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) SendMsg(m interface{}) *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call) Run(run func(m interface{})) *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call) Return(_a0 error) *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call) RunAndReturn(run func(interface{}) error) *HealthSubscriptionService_SubscribeServicesServer_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHeader provides a mock function with given fields: _a0
+func (_m *HealthSubscriptionService_SubscribeServicesServer) SetHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHeader'
+type HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call struct {
+	*mock.Call
+}
+
+// SetHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) SetHeader(_a0 interface{}) *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call{Call: _e.mock.On("SetHeader", _a0)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call) Run(run func(_a0 metadata.MD)) *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call) Return(_a0 error) *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call) RunAndReturn(run func(metadata.MD) error) *HealthSubscriptionService_SubscribeServicesServer_SetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrailer provides a mock function with given fields: _a0
+func (_m *HealthSubscriptionService_SubscribeServicesServer) SetTrailer(_a0 metadata.MD) {
+	_m.Called(_a0)
+}
+
+// HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrailer'
+type HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call struct {
+	*mock.Call
+}
+
+// SetTrailer is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *HealthSubscriptionService_SubscribeServicesServer_Expecter) SetTrailer(_a0 interface{}) *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call {
+	return &HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call{Call: _e.mock.On("SetTrailer", _a0)}
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call) Run(run func(_a0 metadata.MD)) *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call) Return() *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call) RunAndReturn(run func(metadata.MD)) *HealthSubscriptionService_SubscribeServicesServer_SetTrailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthSubscriptionService_SubscribeServicesServer creates a new instance of HealthSubscriptionService_SubscribeServicesServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthSubscriptionService_SubscribeServicesServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthSubscriptionService_SubscribeServicesServer {
+	mock := &HealthSubscriptionService_SubscribeServicesServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
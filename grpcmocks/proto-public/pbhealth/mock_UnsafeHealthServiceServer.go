@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeHealthServiceServer is an autogenerated mock type for the UnsafeHealthServiceServer type
+type UnsafeHealthServiceServer struct {
+	mock.Mock
+}
+
+type UnsafeHealthServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsafeHealthServiceServer) EXPECT() *UnsafeHealthServiceServer_Expecter {
+	return &UnsafeHealthServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// mustEmbedUnimplementedHealthServiceServer provides a mock function with given fields:
+func (_m *UnsafeHealthServiceServer) mustEmbedUnimplementedHealthServiceServer() {
+	_m.Called()
+}
+
+// UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'mustEmbedUnimplementedHealthServiceServer'
+type UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call struct {
+	*mock.Call
+}
+
+// mustEmbedUnimplementedHealthServiceServer is a helper method to define mock.On call
+func (_e *UnsafeHealthServiceServer_Expecter) mustEmbedUnimplementedHealthServiceServer() *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call {
+	return &UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call{Call: _e.mock.On("mustEmbedUnimplementedHealthServiceServer")}
+}
+
+func (_c *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call) Run(run func()) *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call) Return() *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call) RunAndReturn(run func()) *UnsafeHealthServiceServer_mustEmbedUnimplementedHealthServiceServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsafeHealthServiceServer creates a new instance of UnsafeHealthServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeHealthServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeHealthServiceServer {
+	mock := &UnsafeHealthServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
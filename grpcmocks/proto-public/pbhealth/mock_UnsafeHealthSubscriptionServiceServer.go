@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbhealth
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeHealthSubscriptionServiceServer is an autogenerated mock type for the UnsafeHealthSubscriptionServiceServer type
+type UnsafeHealthSubscriptionServiceServer struct {
+	mock.Mock
+}
+
+type UnsafeHealthSubscriptionServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsafeHealthSubscriptionServiceServer) EXPECT() *UnsafeHealthSubscriptionServiceServer_Expecter {
+	return &UnsafeHealthSubscriptionServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// mustEmbedUnimplementedHealthSubscriptionServiceServer provides a mock function with given fields:
+func (_m *UnsafeHealthSubscriptionServiceServer) mustEmbedUnimplementedHealthSubscriptionServiceServer() {
+	_m.Called()
+}
+
+// UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'mustEmbedUnimplementedHealthSubscriptionServiceServer'
+type UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call struct {
+	*mock.Call
+}
+
+// mustEmbedUnimplementedHealthSubscriptionServiceServer is a helper method to define mock.On call
+func (_e *UnsafeHealthSubscriptionServiceServer_Expecter) mustEmbedUnimplementedHealthSubscriptionServiceServer() *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call {
+	return &UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call{Call: _e.mock.On("mustEmbedUnimplementedHealthSubscriptionServiceServer")}
+}
+
+func (_c *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call) Run(run func()) *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call) Return() *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call) RunAndReturn(run func()) *UnsafeHealthSubscriptionServiceServer_mustEmbedUnimplementedHealthSubscriptionServiceServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsafeHealthSubscriptionServiceServer creates a new instance of UnsafeHealthSubscriptionServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeHealthSubscriptionServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeHealthSubscriptionServiceServer {
+	mock := &UnsafeHealthSubscriptionServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,484 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbkv
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pbkv "github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// KVServiceClient is an autogenerated mock type for the KVServiceClient type
+type KVServiceClient struct {
+	mock.Mock
+}
+
+type KVServiceClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KVServiceClient) EXPECT() *KVServiceClient_Expecter {
+	return &KVServiceClient_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) Delete(ctx context.Context, in *pbkv.DeleteRequest, opts ...grpc.CallOption) (*pbkv.DeleteResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *pbkv.DeleteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.DeleteRequest, ...grpc.CallOption) (*pbkv.DeleteResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.DeleteRequest, ...grpc.CallOption) *pbkv.DeleteResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.DeleteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.DeleteRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type KVServiceClient_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.DeleteRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) Delete(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_Delete_Call {
+	return &KVServiceClient_Delete_Call{Call: _e.mock.On("Delete",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_Delete_Call) Run(run func(ctx context.Context, in *pbkv.DeleteRequest, opts ...grpc.CallOption)) *KVServiceClient_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.DeleteRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_Delete_Call) Return(_a0 *pbkv.DeleteResponse, _a1 error) *KVServiceClient_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_Delete_Call) RunAndReturn(run func(context.Context, *pbkv.DeleteRequest, ...grpc.CallOption) (*pbkv.DeleteResponse, error)) *KVServiceClient_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) Get(ctx context.Context, in *pbkv.GetRequest, opts ...grpc.CallOption) (*pbkv.GetResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *pbkv.GetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.GetRequest, ...grpc.CallOption) (*pbkv.GetResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.GetRequest, ...grpc.CallOption) *pbkv.GetResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.GetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.GetRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type KVServiceClient_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.GetRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) Get(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_Get_Call {
+	return &KVServiceClient_Get_Call{Call: _e.mock.On("Get",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_Get_Call) Run(run func(ctx context.Context, in *pbkv.GetRequest, opts ...grpc.CallOption)) *KVServiceClient_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.GetRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_Get_Call) Return(_a0 *pbkv.GetResponse, _a1 error) *KVServiceClient_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_Get_Call) RunAndReturn(run func(context.Context, *pbkv.GetRequest, ...grpc.CallOption) (*pbkv.GetResponse, error)) *KVServiceClient_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) List(ctx context.Context, in *pbkv.ListRequest, opts ...grpc.CallOption) (*pbkv.ListResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *pbkv.ListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.ListRequest, ...grpc.CallOption) (*pbkv.ListResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.ListRequest, ...grpc.CallOption) *pbkv.ListResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.ListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.ListRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type KVServiceClient_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.ListRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) List(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_List_Call {
+	return &KVServiceClient_List_Call{Call: _e.mock.On("List",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_List_Call) Run(run func(ctx context.Context, in *pbkv.ListRequest, opts ...grpc.CallOption)) *KVServiceClient_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.ListRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_List_Call) Return(_a0 *pbkv.ListResponse, _a1 error) *KVServiceClient_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_List_Call) RunAndReturn(run func(context.Context, *pbkv.ListRequest, ...grpc.CallOption) (*pbkv.ListResponse, error)) *KVServiceClient_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) Put(ctx context.Context, in *pbkv.PutRequest, opts ...grpc.CallOption) (*pbkv.PutResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 *pbkv.PutResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.PutRequest, ...grpc.CallOption) (*pbkv.PutResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.PutRequest, ...grpc.CallOption) *pbkv.PutResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.PutResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.PutRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type KVServiceClient_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.PutRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) Put(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_Put_Call {
+	return &KVServiceClient_Put_Call{Call: _e.mock.On("Put",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_Put_Call) Run(run func(ctx context.Context, in *pbkv.PutRequest, opts ...grpc.CallOption)) *KVServiceClient_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.PutRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_Put_Call) Return(_a0 *pbkv.PutResponse, _a1 error) *KVServiceClient_Put_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_Put_Call) RunAndReturn(run func(context.Context, *pbkv.PutRequest, ...grpc.CallOption) (*pbkv.PutResponse, error)) *KVServiceClient_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Txn provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) Txn(ctx context.Context, in *pbkv.TxnRequest, opts ...grpc.CallOption) (*pbkv.TxnResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Txn")
+	}
+
+	var r0 *pbkv.TxnResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.TxnRequest, ...grpc.CallOption) (*pbkv.TxnResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.TxnRequest, ...grpc.CallOption) *pbkv.TxnResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.TxnResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.TxnRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_Txn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Txn'
+type KVServiceClient_Txn_Call struct {
+	*mock.Call
+}
+
+// Txn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.TxnRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) Txn(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_Txn_Call {
+	return &KVServiceClient_Txn_Call{Call: _e.mock.On("Txn",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_Txn_Call) Run(run func(ctx context.Context, in *pbkv.TxnRequest, opts ...grpc.CallOption)) *KVServiceClient_Txn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.TxnRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_Txn_Call) Return(_a0 *pbkv.TxnResponse, _a1 error) *KVServiceClient_Txn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_Txn_Call) RunAndReturn(run func(context.Context, *pbkv.TxnRequest, ...grpc.CallOption) (*pbkv.TxnResponse, error)) *KVServiceClient_Txn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: ctx, in, opts
+func (_m *KVServiceClient) Watch(ctx context.Context, in *pbkv.WatchRequest, opts ...grpc.CallOption) (pbkv.KVService_WatchClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 pbkv.KVService_WatchClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.WatchRequest, ...grpc.CallOption) (pbkv.KVService_WatchClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.WatchRequest, ...grpc.CallOption) pbkv.KVService_WatchClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pbkv.KVService_WatchClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.WatchRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceClient_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type KVServiceClient_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *pbkv.WatchRequest
+//   - opts ...grpc.CallOption
+func (_e *KVServiceClient_Expecter) Watch(ctx interface{}, in interface{}, opts ...interface{}) *KVServiceClient_Watch_Call {
+	return &KVServiceClient_Watch_Call{Call: _e.mock.On("Watch",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *KVServiceClient_Watch_Call) Run(run func(ctx context.Context, in *pbkv.WatchRequest, opts ...grpc.CallOption)) *KVServiceClient_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*pbkv.WatchRequest), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *KVServiceClient_Watch_Call) Return(_a0 pbkv.KVService_WatchClient, _a1 error) *KVServiceClient_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceClient_Watch_Call) RunAndReturn(run func(context.Context, *pbkv.WatchRequest, ...grpc.CallOption) (pbkv.KVService_WatchClient, error)) *KVServiceClient_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKVServiceClient creates a new instance of KVServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKVServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KVServiceClient {
+	mock := &KVServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
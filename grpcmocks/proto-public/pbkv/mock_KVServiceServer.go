@@ -0,0 +1,379 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbkv
+
+import (
+	context "context"
+
+	pbkv "github.com/hashicorp/consul/proto-public/pbkv"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KVServiceServer is an autogenerated mock type for the KVServiceServer type
+type KVServiceServer struct {
+	mock.Mock
+}
+
+type KVServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KVServiceServer) EXPECT() *KVServiceServer_Expecter {
+	return &KVServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) Delete(_a0 context.Context, _a1 *pbkv.DeleteRequest) (*pbkv.DeleteResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 *pbkv.DeleteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.DeleteRequest) (*pbkv.DeleteResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.DeleteRequest) *pbkv.DeleteResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.DeleteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.DeleteRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceServer_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type KVServiceServer_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbkv.DeleteRequest
+func (_e *KVServiceServer_Expecter) Delete(_a0 interface{}, _a1 interface{}) *KVServiceServer_Delete_Call {
+	return &KVServiceServer_Delete_Call{Call: _e.mock.On("Delete", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_Delete_Call) Run(run func(_a0 context.Context, _a1 *pbkv.DeleteRequest)) *KVServiceServer_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbkv.DeleteRequest))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_Delete_Call) Return(_a0 *pbkv.DeleteResponse, _a1 error) *KVServiceServer_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceServer_Delete_Call) RunAndReturn(run func(context.Context, *pbkv.DeleteRequest) (*pbkv.DeleteResponse, error)) *KVServiceServer_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) Get(_a0 context.Context, _a1 *pbkv.GetRequest) (*pbkv.GetResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *pbkv.GetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.GetRequest) (*pbkv.GetResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.GetRequest) *pbkv.GetResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.GetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.GetRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceServer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type KVServiceServer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbkv.GetRequest
+func (_e *KVServiceServer_Expecter) Get(_a0 interface{}, _a1 interface{}) *KVServiceServer_Get_Call {
+	return &KVServiceServer_Get_Call{Call: _e.mock.On("Get", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_Get_Call) Run(run func(_a0 context.Context, _a1 *pbkv.GetRequest)) *KVServiceServer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbkv.GetRequest))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_Get_Call) Return(_a0 *pbkv.GetResponse, _a1 error) *KVServiceServer_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceServer_Get_Call) RunAndReturn(run func(context.Context, *pbkv.GetRequest) (*pbkv.GetResponse, error)) *KVServiceServer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) List(_a0 context.Context, _a1 *pbkv.ListRequest) (*pbkv.ListResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *pbkv.ListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.ListRequest) (*pbkv.ListResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.ListRequest) *pbkv.ListResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.ListResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.ListRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceServer_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type KVServiceServer_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbkv.ListRequest
+func (_e *KVServiceServer_Expecter) List(_a0 interface{}, _a1 interface{}) *KVServiceServer_List_Call {
+	return &KVServiceServer_List_Call{Call: _e.mock.On("List", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_List_Call) Run(run func(_a0 context.Context, _a1 *pbkv.ListRequest)) *KVServiceServer_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbkv.ListRequest))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_List_Call) Return(_a0 *pbkv.ListResponse, _a1 error) *KVServiceServer_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceServer_List_Call) RunAndReturn(run func(context.Context, *pbkv.ListRequest) (*pbkv.ListResponse, error)) *KVServiceServer_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) Put(_a0 context.Context, _a1 *pbkv.PutRequest) (*pbkv.PutResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 *pbkv.PutResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.PutRequest) (*pbkv.PutResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.PutRequest) *pbkv.PutResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.PutResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.PutRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceServer_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type KVServiceServer_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbkv.PutRequest
+func (_e *KVServiceServer_Expecter) Put(_a0 interface{}, _a1 interface{}) *KVServiceServer_Put_Call {
+	return &KVServiceServer_Put_Call{Call: _e.mock.On("Put", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_Put_Call) Run(run func(_a0 context.Context, _a1 *pbkv.PutRequest)) *KVServiceServer_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbkv.PutRequest))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_Put_Call) Return(_a0 *pbkv.PutResponse, _a1 error) *KVServiceServer_Put_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceServer_Put_Call) RunAndReturn(run func(context.Context, *pbkv.PutRequest) (*pbkv.PutResponse, error)) *KVServiceServer_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Txn provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) Txn(_a0 context.Context, _a1 *pbkv.TxnRequest) (*pbkv.TxnResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Txn")
+	}
+
+	var r0 *pbkv.TxnResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.TxnRequest) (*pbkv.TxnResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pbkv.TxnRequest) *pbkv.TxnResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.TxnResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pbkv.TxnRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVServiceServer_Txn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Txn'
+type KVServiceServer_Txn_Call struct {
+	*mock.Call
+}
+
+// Txn is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *pbkv.TxnRequest
+func (_e *KVServiceServer_Expecter) Txn(_a0 interface{}, _a1 interface{}) *KVServiceServer_Txn_Call {
+	return &KVServiceServer_Txn_Call{Call: _e.mock.On("Txn", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_Txn_Call) Run(run func(_a0 context.Context, _a1 *pbkv.TxnRequest)) *KVServiceServer_Txn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*pbkv.TxnRequest))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_Txn_Call) Return(_a0 *pbkv.TxnResponse, _a1 error) *KVServiceServer_Txn_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVServiceServer_Txn_Call) RunAndReturn(run func(context.Context, *pbkv.TxnRequest) (*pbkv.TxnResponse, error)) *KVServiceServer_Txn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Watch provides a mock function with given fields: _a0, _a1
+func (_m *KVServiceServer) Watch(_a0 *pbkv.WatchRequest, _a1 pbkv.KVService_WatchServer) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbkv.WatchRequest, pbkv.KVService_WatchServer) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVServiceServer_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type KVServiceServer_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - _a0 *pbkv.WatchRequest
+//   - _a1 pbkv.KVService_WatchServer
+func (_e *KVServiceServer_Expecter) Watch(_a0 interface{}, _a1 interface{}) *KVServiceServer_Watch_Call {
+	return &KVServiceServer_Watch_Call{Call: _e.mock.On("Watch", _a0, _a1)}
+}
+
+func (_c *KVServiceServer_Watch_Call) Run(run func(_a0 *pbkv.WatchRequest, _a1 pbkv.KVService_WatchServer)) *KVServiceServer_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbkv.WatchRequest), args[1].(pbkv.KVService_WatchServer))
+	})
+	return _c
+}
+
+func (_c *KVServiceServer_Watch_Call) Return(_a0 error) *KVServiceServer_Watch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVServiceServer_Watch_Call) RunAndReturn(run func(*pbkv.WatchRequest, pbkv.KVService_WatchServer) error) *KVServiceServer_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKVServiceServer creates a new instance of KVServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKVServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KVServiceServer {
+	mock := &KVServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,384 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbkv
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbkv "github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// KVService_WatchClient is an autogenerated mock type for the KVService_WatchClient type
+type KVService_WatchClient struct {
+	mock.Mock
+}
+
+type KVService_WatchClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KVService_WatchClient) EXPECT() *KVService_WatchClient_Expecter {
+	return &KVService_WatchClient_Expecter{mock: &_m.Mock}
+}
+
+// CloseSend provides a mock function with given fields:
+func (_m *KVService_WatchClient) CloseSend() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchClient_CloseSend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSend'
+type KVService_WatchClient_CloseSend_Call struct {
+	*mock.Call
+}
+
+// CloseSend is a helper method to define mock.On call
+func (_e *KVService_WatchClient_Expecter) CloseSend() *KVService_WatchClient_CloseSend_Call {
+	return &KVService_WatchClient_CloseSend_Call{Call: _e.mock.On("CloseSend")}
+}
+
+func (_c *KVService_WatchClient_CloseSend_Call) Run(run func()) *KVService_WatchClient_CloseSend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_CloseSend_Call) Return(_a0 error) *KVService_WatchClient_CloseSend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchClient_CloseSend_Call) RunAndReturn(run func() error) *KVService_WatchClient_CloseSend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Context provides a mock function with given fields:
+func (_m *KVService_WatchClient) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// KVService_WatchClient_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type KVService_WatchClient_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *KVService_WatchClient_Expecter) Context() *KVService_WatchClient_Context_Call {
+	return &KVService_WatchClient_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *KVService_WatchClient_Context_Call) Run(run func()) *KVService_WatchClient_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_Context_Call) Return(_a0 context.Context) *KVService_WatchClient_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchClient_Context_Call) RunAndReturn(run func() context.Context) *KVService_WatchClient_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Header provides a mock function with given fields:
+func (_m *KVService_WatchClient) Header() (metadata.MD, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Header")
+	}
+
+	var r0 metadata.MD
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (metadata.MD, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVService_WatchClient_Header_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Header'
+type KVService_WatchClient_Header_Call struct {
+	*mock.Call
+}
+
+// Header is a helper method to define mock.On call
+func (_e *KVService_WatchClient_Expecter) Header() *KVService_WatchClient_Header_Call {
+	return &KVService_WatchClient_Header_Call{Call: _e.mock.On("Header")}
+}
+
+func (_c *KVService_WatchClient_Header_Call) Run(run func()) *KVService_WatchClient_Header_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_Header_Call) Return(_a0 metadata.MD, _a1 error) *KVService_WatchClient_Header_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVService_WatchClient_Header_Call) RunAndReturn(run func() (metadata.MD, error)) *KVService_WatchClient_Header_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recv provides a mock function with given fields:
+func (_m *KVService_WatchClient) Recv() (*pbkv.WatchResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 *pbkv.WatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pbkv.WatchResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pbkv.WatchResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbkv.WatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KVService_WatchClient_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type KVService_WatchClient_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *KVService_WatchClient_Expecter) Recv() *KVService_WatchClient_Recv_Call {
+	return &KVService_WatchClient_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *KVService_WatchClient_Recv_Call) Run(run func()) *KVService_WatchClient_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_Recv_Call) Return(_a0 *pbkv.WatchResponse, _a1 error) *KVService_WatchClient_Recv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *KVService_WatchClient_Recv_Call) RunAndReturn(run func() (*pbkv.WatchResponse, error)) *KVService_WatchClient_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *KVService_WatchClient) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchClient_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type KVService_WatchClient_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *KVService_WatchClient_Expecter) RecvMsg(m interface{}) *KVService_WatchClient_RecvMsg_Call {
+	return &KVService_WatchClient_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *KVService_WatchClient_RecvMsg_Call) Run(run func(m interface{})) *KVService_WatchClient_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_RecvMsg_Call) Return(_a0 error) *KVService_WatchClient_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchClient_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *KVService_WatchClient_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *KVService_WatchClient) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchClient_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type KVService_WatchClient_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *KVService_WatchClient_Expecter) SendMsg(m interface{}) *KVService_WatchClient_SendMsg_Call {
+	return &KVService_WatchClient_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *KVService_WatchClient_SendMsg_Call) Run(run func(m interface{})) *KVService_WatchClient_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_SendMsg_Call) Return(_a0 error) *KVService_WatchClient_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchClient_SendMsg_Call) RunAndReturn(run func(interface{}) error) *KVService_WatchClient_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trailer provides a mock function with given fields:
+func (_m *KVService_WatchClient) Trailer() metadata.MD {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trailer")
+	}
+
+	var r0 metadata.MD
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	return r0
+}
+
+// KVService_WatchClient_Trailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trailer'
+type KVService_WatchClient_Trailer_Call struct {
+	*mock.Call
+}
+
+// Trailer is a helper method to define mock.On call
+func (_e *KVService_WatchClient_Expecter) Trailer() *KVService_WatchClient_Trailer_Call {
+	return &KVService_WatchClient_Trailer_Call{Call: _e.mock.On("Trailer")}
+}
+
+func (_c *KVService_WatchClient_Trailer_Call) Run(run func()) *KVService_WatchClient_Trailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchClient_Trailer_Call) Return(_a0 metadata.MD) *KVService_WatchClient_Trailer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchClient_Trailer_Call) RunAndReturn(run func() metadata.MD) *KVService_WatchClient_Trailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKVService_WatchClient creates a new instance of KVService_WatchClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKVService_WatchClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KVService_WatchClient {
+	mock := &KVService_WatchClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,349 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbkv
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbkv "github.com/hashicorp/consul/proto-public/pbkv"
+)
+
+// KVService_WatchServer is an autogenerated mock type for the KVService_WatchServer type
+type KVService_WatchServer struct {
+	mock.Mock
+}
+
+type KVService_WatchServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *KVService_WatchServer) EXPECT() *KVService_WatchServer_Expecter {
+	return &KVService_WatchServer_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with given fields:
+func (_m *KVService_WatchServer) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type KVService_WatchServer_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *KVService_WatchServer_Expecter) Context() *KVService_WatchServer_Context_Call {
+	return &KVService_WatchServer_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *KVService_WatchServer_Context_Call) Run(run func()) *KVService_WatchServer_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_Context_Call) Return(_a0 context.Context) *KVService_WatchServer_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_Context_Call) RunAndReturn(run func() context.Context) *KVService_WatchServer_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *KVService_WatchServer) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type KVService_WatchServer_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *KVService_WatchServer_Expecter) RecvMsg(m interface{}) *KVService_WatchServer_RecvMsg_Call {
+	return &KVService_WatchServer_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *KVService_WatchServer_RecvMsg_Call) Run(run func(m interface{})) *KVService_WatchServer_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_RecvMsg_Call) Return(_a0 error) *KVService_WatchServer_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *KVService_WatchServer_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *KVService_WatchServer) Send(_a0 *pbkv.WatchResponse) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbkv.WatchResponse) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type KVService_WatchServer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbkv.WatchResponse
+func (_e *KVService_WatchServer_Expecter) Send(_a0 interface{}) *KVService_WatchServer_Send_Call {
+	return &KVService_WatchServer_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *KVService_WatchServer_Send_Call) Run(run func(_a0 *pbkv.WatchResponse)) *KVService_WatchServer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbkv.WatchResponse))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_Send_Call) Return(_a0 error) *KVService_WatchServer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_Send_Call) RunAndReturn(run func(*pbkv.WatchResponse) error) *KVService_WatchServer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendHeader provides a mock function with given fields: _a0
+func (_m *KVService_WatchServer) SendHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_SendHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendHeader'
+type KVService_WatchServer_SendHeader_Call struct {
+	*mock.Call
+}
+
+// SendHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *KVService_WatchServer_Expecter) SendHeader(_a0 interface{}) *KVService_WatchServer_SendHeader_Call {
+	return &KVService_WatchServer_SendHeader_Call{Call: _e.mock.On("SendHeader", _a0)}
+}
+
+func (_c *KVService_WatchServer_SendHeader_Call) Run(run func(_a0 metadata.MD)) *KVService_WatchServer_SendHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_SendHeader_Call) Return(_a0 error) *KVService_WatchServer_SendHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_SendHeader_Call) RunAndReturn(run func(metadata.MD) error) *KVService_WatchServer_SendHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *KVService_WatchServer) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type KVService_WatchServer_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *KVService_WatchServer_Expecter) SendMsg(m interface{}) *KVService_WatchServer_SendMsg_Call {
+	return &KVService_WatchServer_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *KVService_WatchServer_SendMsg_Call) Run(run func(m interface{})) *KVService_WatchServer_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_SendMsg_Call) Return(_a0 error) *KVService_WatchServer_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_SendMsg_Call) RunAndReturn(run func(interface{}) error) *KVService_WatchServer_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHeader provides a mock function with given fields: _a0
+func (_m *KVService_WatchServer) SetHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// KVService_WatchServer_SetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHeader'
+type KVService_WatchServer_SetHeader_Call struct {
+	*mock.Call
+}
+
+// SetHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *KVService_WatchServer_Expecter) SetHeader(_a0 interface{}) *KVService_WatchServer_SetHeader_Call {
+	return &KVService_WatchServer_SetHeader_Call{Call: _e.mock.On("SetHeader", _a0)}
+}
+
+func (_c *KVService_WatchServer_SetHeader_Call) Run(run func(_a0 metadata.MD)) *KVService_WatchServer_SetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_SetHeader_Call) Return(_a0 error) *KVService_WatchServer_SetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *KVService_WatchServer_SetHeader_Call) RunAndReturn(run func(metadata.MD) error) *KVService_WatchServer_SetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrailer provides a mock function with given fields: _a0
+func (_m *KVService_WatchServer) SetTrailer(_a0 metadata.MD) {
+	_m.Called(_a0)
+}
+
+// KVService_WatchServer_SetTrailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrailer'
+type KVService_WatchServer_SetTrailer_Call struct {
+	*mock.Call
+}
+
+// SetTrailer is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *KVService_WatchServer_Expecter) SetTrailer(_a0 interface{}) *KVService_WatchServer_SetTrailer_Call {
+	return &KVService_WatchServer_SetTrailer_Call{Call: _e.mock.On("SetTrailer", _a0)}
+}
+
+func (_c *KVService_WatchServer_SetTrailer_Call) Run(run func(_a0 metadata.MD)) *KVService_WatchServer_SetTrailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *KVService_WatchServer_SetTrailer_Call) Return() *KVService_WatchServer_SetTrailer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *KVService_WatchServer_SetTrailer_Call) RunAndReturn(run func(metadata.MD)) *KVService_WatchServer_SetTrailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewKVService_WatchServer creates a new instance of KVService_WatchServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewKVService_WatchServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *KVService_WatchServer {
+	mock := &KVService_WatchServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbkv
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeKVServiceServer is an autogenerated mock type for the UnsafeKVServiceServer type
+type UnsafeKVServiceServer struct {
+	mock.Mock
+}
+
+type UnsafeKVServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsafeKVServiceServer) EXPECT() *UnsafeKVServiceServer_Expecter {
+	return &UnsafeKVServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// mustEmbedUnimplementedKVServiceServer provides a mock function with given fields:
+func (_m *UnsafeKVServiceServer) mustEmbedUnimplementedKVServiceServer() {
+	_m.Called()
+}
+
+// UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'mustEmbedUnimplementedKVServiceServer'
+type UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call struct {
+	*mock.Call
+}
+
+// mustEmbedUnimplementedKVServiceServer is a helper method to define mock.On call
+func (_e *UnsafeKVServiceServer_Expecter) mustEmbedUnimplementedKVServiceServer() *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call {
+	return &UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call{Call: _e.mock.On("mustEmbedUnimplementedKVServiceServer")}
+}
+
+func (_c *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call) Run(run func()) *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call) Return() *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call) RunAndReturn(run func()) *UnsafeKVServiceServer_mustEmbedUnimplementedKVServiceServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsafeKVServiceServer creates a new instance of UnsafeKVServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeKVServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeKVServiceServer {
+	mock := &UnsafeKVServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pbregistration "github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+// RegistrationServiceClient is an autogenerated mock type for the RegistrationServiceClient type
+type RegistrationServiceClient struct {
+	mock.Mock
+}
+
+type RegistrationServiceClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RegistrationServiceClient) EXPECT() *RegistrationServiceClient_Expecter {
+	return &RegistrationServiceClient_Expecter{mock: &_m.Mock}
+}
+
+// RegisterWorkload provides a mock function with given fields: ctx, opts
+func (_m *RegistrationServiceClient) RegisterWorkload(ctx context.Context, opts ...grpc.CallOption) (pbregistration.RegistrationService_RegisterWorkloadClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterWorkload")
+	}
+
+	var r0 pbregistration.RegistrationService_RegisterWorkloadClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (pbregistration.RegistrationService_RegisterWorkloadClient, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) pbregistration.RegistrationService_RegisterWorkloadClient); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pbregistration.RegistrationService_RegisterWorkloadClient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegistrationServiceClient_RegisterWorkload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterWorkload'
+type RegistrationServiceClient_RegisterWorkload_Call struct {
+	*mock.Call
+}
+
+// RegisterWorkload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...grpc.CallOption
+func (_e *RegistrationServiceClient_Expecter) RegisterWorkload(ctx interface{}, opts ...interface{}) *RegistrationServiceClient_RegisterWorkload_Call {
+	return &RegistrationServiceClient_RegisterWorkload_Call{Call: _e.mock.On("RegisterWorkload",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *RegistrationServiceClient_RegisterWorkload_Call) Run(run func(ctx context.Context, opts ...grpc.CallOption)) *RegistrationServiceClient_RegisterWorkload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *RegistrationServiceClient_RegisterWorkload_Call) Return(_a0 pbregistration.RegistrationService_RegisterWorkloadClient, _a1 error) *RegistrationServiceClient_RegisterWorkload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RegistrationServiceClient_RegisterWorkload_Call) RunAndReturn(run func(context.Context, ...grpc.CallOption) (pbregistration.RegistrationService_RegisterWorkloadClient, error)) *RegistrationServiceClient_RegisterWorkload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRegistrationServiceClient creates a new instance of RegistrationServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRegistrationServiceClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RegistrationServiceClient {
+	mock := &RegistrationServiceClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import (
+	pbregistration "github.com/hashicorp/consul/proto-public/pbregistration"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RegistrationServiceServer is an autogenerated mock type for the RegistrationServiceServer type
+type RegistrationServiceServer struct {
+	mock.Mock
+}
+
+type RegistrationServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RegistrationServiceServer) EXPECT() *RegistrationServiceServer_Expecter {
+	return &RegistrationServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// RegisterWorkload provides a mock function with given fields: _a0
+func (_m *RegistrationServiceServer) RegisterWorkload(_a0 pbregistration.RegistrationService_RegisterWorkloadServer) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterWorkload")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(pbregistration.RegistrationService_RegisterWorkloadServer) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationServiceServer_RegisterWorkload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterWorkload'
+type RegistrationServiceServer_RegisterWorkload_Call struct {
+	*mock.Call
+}
+
+// RegisterWorkload is a helper method to define mock.On call
+//   - _a0 pbregistration.RegistrationService_RegisterWorkloadServer
+func (_e *RegistrationServiceServer_Expecter) RegisterWorkload(_a0 interface{}) *RegistrationServiceServer_RegisterWorkload_Call {
+	return &RegistrationServiceServer_RegisterWorkload_Call{Call: _e.mock.On("RegisterWorkload", _a0)}
+}
+
+func (_c *RegistrationServiceServer_RegisterWorkload_Call) Run(run func(_a0 pbregistration.RegistrationService_RegisterWorkloadServer)) *RegistrationServiceServer_RegisterWorkload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(pbregistration.RegistrationService_RegisterWorkloadServer))
+	})
+	return _c
+}
+
+func (_c *RegistrationServiceServer_RegisterWorkload_Call) Return(_a0 error) *RegistrationServiceServer_RegisterWorkload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationServiceServer_RegisterWorkload_Call) RunAndReturn(run func(pbregistration.RegistrationService_RegisterWorkloadServer) error) *RegistrationServiceServer_RegisterWorkload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRegistrationServiceServer creates a new instance of RegistrationServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRegistrationServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RegistrationServiceServer {
+	mock := &RegistrationServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,430 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbregistration "github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+// RegistrationService_RegisterWorkloadClient is an autogenerated mock type for the RegistrationService_RegisterWorkloadClient type
+type RegistrationService_RegisterWorkloadClient struct {
+	mock.Mock
+}
+
+type RegistrationService_RegisterWorkloadClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RegistrationService_RegisterWorkloadClient) EXPECT() *RegistrationService_RegisterWorkloadClient_Expecter {
+	return &RegistrationService_RegisterWorkloadClient_Expecter{mock: &_m.Mock}
+}
+
+// CloseSend provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadClient) CloseSend() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSend")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_CloseSend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSend'
+type RegistrationService_RegisterWorkloadClient_CloseSend_Call struct {
+	*mock.Call
+}
+
+// CloseSend is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) CloseSend() *RegistrationService_RegisterWorkloadClient_CloseSend_Call {
+	return &RegistrationService_RegisterWorkloadClient_CloseSend_Call{Call: _e.mock.On("CloseSend")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_CloseSend_Call) Run(run func()) *RegistrationService_RegisterWorkloadClient_CloseSend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_CloseSend_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadClient_CloseSend_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_CloseSend_Call) RunAndReturn(run func() error) *RegistrationService_RegisterWorkloadClient_CloseSend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Context provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadClient) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type RegistrationService_RegisterWorkloadClient_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) Context() *RegistrationService_RegisterWorkloadClient_Context_Call {
+	return &RegistrationService_RegisterWorkloadClient_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Context_Call) Run(run func()) *RegistrationService_RegisterWorkloadClient_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Context_Call) Return(_a0 context.Context) *RegistrationService_RegisterWorkloadClient_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Context_Call) RunAndReturn(run func() context.Context) *RegistrationService_RegisterWorkloadClient_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Header provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadClient) Header() (metadata.MD, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Header")
+	}
+
+	var r0 metadata.MD
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (metadata.MD, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegistrationService_RegisterWorkloadClient_Header_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Header'
+type RegistrationService_RegisterWorkloadClient_Header_Call struct {
+	*mock.Call
+}
+
+// Header is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) Header() *RegistrationService_RegisterWorkloadClient_Header_Call {
+	return &RegistrationService_RegisterWorkloadClient_Header_Call{Call: _e.mock.On("Header")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Header_Call) Run(run func()) *RegistrationService_RegisterWorkloadClient_Header_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Header_Call) Return(_a0 metadata.MD, _a1 error) *RegistrationService_RegisterWorkloadClient_Header_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Header_Call) RunAndReturn(run func() (metadata.MD, error)) *RegistrationService_RegisterWorkloadClient_Header_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recv provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadClient) Recv() (*pbregistration.RegisterWorkloadResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 *pbregistration.RegisterWorkloadResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pbregistration.RegisterWorkloadResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pbregistration.RegisterWorkloadResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbregistration.RegisterWorkloadResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegistrationService_RegisterWorkloadClient_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type RegistrationService_RegisterWorkloadClient_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) Recv() *RegistrationService_RegisterWorkloadClient_Recv_Call {
+	return &RegistrationService_RegisterWorkloadClient_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Recv_Call) Run(run func()) *RegistrationService_RegisterWorkloadClient_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Recv_Call) Return(_a0 *pbregistration.RegisterWorkloadResponse, _a1 error) *RegistrationService_RegisterWorkloadClient_Recv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Recv_Call) RunAndReturn(run func() (*pbregistration.RegisterWorkloadResponse, error)) *RegistrationService_RegisterWorkloadClient_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *RegistrationService_RegisterWorkloadClient) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type RegistrationService_RegisterWorkloadClient_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) RecvMsg(m interface{}) *RegistrationService_RegisterWorkloadClient_RecvMsg_Call {
+	return &RegistrationService_RegisterWorkloadClient_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_RecvMsg_Call) Run(run func(m interface{})) *RegistrationService_RegisterWorkloadClient_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_RecvMsg_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadClient_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *RegistrationService_RegisterWorkloadClient_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *RegistrationService_RegisterWorkloadClient) Send(_a0 *pbregistration.RegisterWorkloadRequest) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbregistration.RegisterWorkloadRequest) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type RegistrationService_RegisterWorkloadClient_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbregistration.RegisterWorkloadRequest
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) Send(_a0 interface{}) *RegistrationService_RegisterWorkloadClient_Send_Call {
+	return &RegistrationService_RegisterWorkloadClient_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Send_Call) Run(run func(_a0 *pbregistration.RegisterWorkloadRequest)) *RegistrationService_RegisterWorkloadClient_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbregistration.RegisterWorkloadRequest))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Send_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadClient_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Send_Call) RunAndReturn(run func(*pbregistration.RegisterWorkloadRequest) error) *RegistrationService_RegisterWorkloadClient_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *RegistrationService_RegisterWorkloadClient) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type RegistrationService_RegisterWorkloadClient_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) SendMsg(m interface{}) *RegistrationService_RegisterWorkloadClient_SendMsg_Call {
+	return &RegistrationService_RegisterWorkloadClient_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_SendMsg_Call) Run(run func(m interface{})) *RegistrationService_RegisterWorkloadClient_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_SendMsg_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadClient_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_SendMsg_Call) RunAndReturn(run func(interface{}) error) *RegistrationService_RegisterWorkloadClient_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Trailer provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadClient) Trailer() metadata.MD {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Trailer")
+	}
+
+	var r0 metadata.MD
+	if rf, ok := ret.Get(0).(func() metadata.MD); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(metadata.MD)
+		}
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadClient_Trailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Trailer'
+type RegistrationService_RegisterWorkloadClient_Trailer_Call struct {
+	*mock.Call
+}
+
+// Trailer is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadClient_Expecter) Trailer() *RegistrationService_RegisterWorkloadClient_Trailer_Call {
+	return &RegistrationService_RegisterWorkloadClient_Trailer_Call{Call: _e.mock.On("Trailer")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Trailer_Call) Run(run func()) *RegistrationService_RegisterWorkloadClient_Trailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Trailer_Call) Return(_a0 metadata.MD) *RegistrationService_RegisterWorkloadClient_Trailer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadClient_Trailer_Call) RunAndReturn(run func() metadata.MD) *RegistrationService_RegisterWorkloadClient_Trailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRegistrationService_RegisterWorkloadClient creates a new instance of RegistrationService_RegisterWorkloadClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRegistrationService_RegisterWorkloadClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RegistrationService_RegisterWorkloadClient {
+	mock := &RegistrationService_RegisterWorkloadClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,406 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	metadata "google.golang.org/grpc/metadata"
+
+	pbregistration "github.com/hashicorp/consul/proto-public/pbregistration"
+)
+
+// RegistrationService_RegisterWorkloadServer is an autogenerated mock type for the RegistrationService_RegisterWorkloadServer type
+type RegistrationService_RegisterWorkloadServer struct {
+	mock.Mock
+}
+
+type RegistrationService_RegisterWorkloadServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RegistrationService_RegisterWorkloadServer) EXPECT() *RegistrationService_RegisterWorkloadServer_Expecter {
+	return &RegistrationService_RegisterWorkloadServer_Expecter{mock: &_m.Mock}
+}
+
+// Context provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadServer) Context() context.Context {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Context")
+	}
+
+	var r0 context.Context
+	if rf, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_Context_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Context'
+type RegistrationService_RegisterWorkloadServer_Context_Call struct {
+	*mock.Call
+}
+
+// Context is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) Context() *RegistrationService_RegisterWorkloadServer_Context_Call {
+	return &RegistrationService_RegisterWorkloadServer_Context_Call{Call: _e.mock.On("Context")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Context_Call) Run(run func()) *RegistrationService_RegisterWorkloadServer_Context_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Context_Call) Return(_a0 context.Context) *RegistrationService_RegisterWorkloadServer_Context_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Context_Call) RunAndReturn(run func() context.Context) *RegistrationService_RegisterWorkloadServer_Context_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Recv provides a mock function with given fields:
+func (_m *RegistrationService_RegisterWorkloadServer) Recv() (*pbregistration.RegisterWorkloadRequest, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 *pbregistration.RegisterWorkloadRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*pbregistration.RegisterWorkloadRequest, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *pbregistration.RegisterWorkloadRequest); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pbregistration.RegisterWorkloadRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegistrationService_RegisterWorkloadServer_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type RegistrationService_RegisterWorkloadServer_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) Recv() *RegistrationService_RegisterWorkloadServer_Recv_Call {
+	return &RegistrationService_RegisterWorkloadServer_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Recv_Call) Run(run func()) *RegistrationService_RegisterWorkloadServer_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Recv_Call) Return(_a0 *pbregistration.RegisterWorkloadRequest, _a1 error) *RegistrationService_RegisterWorkloadServer_Recv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Recv_Call) RunAndReturn(run func() (*pbregistration.RegisterWorkloadRequest, error)) *RegistrationService_RegisterWorkloadServer_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecvMsg provides a mock function with given fields: m
+func (_m *RegistrationService_RegisterWorkloadServer) RecvMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecvMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_RecvMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecvMsg'
+type RegistrationService_RegisterWorkloadServer_RecvMsg_Call struct {
+	*mock.Call
+}
+
+// RecvMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) RecvMsg(m interface{}) *RegistrationService_RegisterWorkloadServer_RecvMsg_Call {
+	return &RegistrationService_RegisterWorkloadServer_RecvMsg_Call{Call: _e.mock.On("RecvMsg", m)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_RecvMsg_Call) Run(run func(m interface{})) *RegistrationService_RegisterWorkloadServer_RecvMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_RecvMsg_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadServer_RecvMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_RecvMsg_Call) RunAndReturn(run func(interface{}) error) *RegistrationService_RegisterWorkloadServer_RecvMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Send provides a mock function with given fields: _a0
+func (_m *RegistrationService_RegisterWorkloadServer) Send(_a0 *pbregistration.RegisterWorkloadResponse) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*pbregistration.RegisterWorkloadResponse) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type RegistrationService_RegisterWorkloadServer_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - _a0 *pbregistration.RegisterWorkloadResponse
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) Send(_a0 interface{}) *RegistrationService_RegisterWorkloadServer_Send_Call {
+	return &RegistrationService_RegisterWorkloadServer_Send_Call{Call: _e.mock.On("Send", _a0)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Send_Call) Run(run func(_a0 *pbregistration.RegisterWorkloadResponse)) *RegistrationService_RegisterWorkloadServer_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*pbregistration.RegisterWorkloadResponse))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Send_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadServer_Send_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_Send_Call) RunAndReturn(run func(*pbregistration.RegisterWorkloadResponse) error) *RegistrationService_RegisterWorkloadServer_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendHeader provides a mock function with given fields: _a0
+func (_m *RegistrationService_RegisterWorkloadServer) SendHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_SendHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendHeader'
+type RegistrationService_RegisterWorkloadServer_SendHeader_Call struct {
+	*mock.Call
+}
+
+// SendHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) SendHeader(_a0 interface{}) *RegistrationService_RegisterWorkloadServer_SendHeader_Call {
+	return &RegistrationService_RegisterWorkloadServer_SendHeader_Call{Call: _e.mock.On("SendHeader", _a0)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendHeader_Call) Run(run func(_a0 metadata.MD)) *RegistrationService_RegisterWorkloadServer_SendHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendHeader_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadServer_SendHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendHeader_Call) RunAndReturn(run func(metadata.MD) error) *RegistrationService_RegisterWorkloadServer_SendHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMsg provides a mock function with given fields: m
+func (_m *RegistrationService_RegisterWorkloadServer) SendMsg(m interface{}) error {
+	ret := _m.Called(m)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMsg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
+		r0 = rf(m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_SendMsg_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMsg'
+type RegistrationService_RegisterWorkloadServer_SendMsg_Call struct {
+	*mock.Call
+}
+
+// SendMsg is a helper method to define mock.On call
+//   - m interface{}
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) SendMsg(m interface{}) *RegistrationService_RegisterWorkloadServer_SendMsg_Call {
+	return &RegistrationService_RegisterWorkloadServer_SendMsg_Call{Call: _e.mock.On("SendMsg", m)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendMsg_Call) Run(run func(m interface{})) *RegistrationService_RegisterWorkloadServer_SendMsg_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendMsg_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadServer_SendMsg_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SendMsg_Call) RunAndReturn(run func(interface{}) error) *RegistrationService_RegisterWorkloadServer_SendMsg_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetHeader provides a mock function with given fields: _a0
+func (_m *RegistrationService_RegisterWorkloadServer) SetHeader(_a0 metadata.MD) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHeader")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(metadata.MD) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegistrationService_RegisterWorkloadServer_SetHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetHeader'
+type RegistrationService_RegisterWorkloadServer_SetHeader_Call struct {
+	*mock.Call
+}
+
+// SetHeader is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) SetHeader(_a0 interface{}) *RegistrationService_RegisterWorkloadServer_SetHeader_Call {
+	return &RegistrationService_RegisterWorkloadServer_SetHeader_Call{Call: _e.mock.On("SetHeader", _a0)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetHeader_Call) Run(run func(_a0 metadata.MD)) *RegistrationService_RegisterWorkloadServer_SetHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetHeader_Call) Return(_a0 error) *RegistrationService_RegisterWorkloadServer_SetHeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetHeader_Call) RunAndReturn(run func(metadata.MD) error) *RegistrationService_RegisterWorkloadServer_SetHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTrailer provides a mock function with given fields: _a0
+func (_m *RegistrationService_RegisterWorkloadServer) SetTrailer(_a0 metadata.MD) {
+	_m.Called(_a0)
+}
+
+// RegistrationService_RegisterWorkloadServer_SetTrailer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTrailer'
+type RegistrationService_RegisterWorkloadServer_SetTrailer_Call struct {
+	*mock.Call
+}
+
+// SetTrailer is a helper method to define mock.On call
+//   - _a0 metadata.MD
+func (_e *RegistrationService_RegisterWorkloadServer_Expecter) SetTrailer(_a0 interface{}) *RegistrationService_RegisterWorkloadServer_SetTrailer_Call {
+	return &RegistrationService_RegisterWorkloadServer_SetTrailer_Call{Call: _e.mock.On("SetTrailer", _a0)}
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetTrailer_Call) Run(run func(_a0 metadata.MD)) *RegistrationService_RegisterWorkloadServer_SetTrailer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(metadata.MD))
+	})
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetTrailer_Call) Return() *RegistrationService_RegisterWorkloadServer_SetTrailer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *RegistrationService_RegisterWorkloadServer_SetTrailer_Call) RunAndReturn(run func(metadata.MD)) *RegistrationService_RegisterWorkloadServer_SetTrailer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRegistrationService_RegisterWorkloadServer creates a new instance of RegistrationService_RegisterWorkloadServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRegistrationService_RegisterWorkloadServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RegistrationService_RegisterWorkloadServer {
+	mock := &RegistrationService_RegisterWorkloadServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
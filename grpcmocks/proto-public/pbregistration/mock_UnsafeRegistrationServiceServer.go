@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import mock "github.com/stretchr/testify/mock"
+
+// UnsafeRegistrationServiceServer is an autogenerated mock type for the UnsafeRegistrationServiceServer type
+type UnsafeRegistrationServiceServer struct {
+	mock.Mock
+}
+
+type UnsafeRegistrationServiceServer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UnsafeRegistrationServiceServer) EXPECT() *UnsafeRegistrationServiceServer_Expecter {
+	return &UnsafeRegistrationServiceServer_Expecter{mock: &_m.Mock}
+}
+
+// mustEmbedUnimplementedRegistrationServiceServer provides a mock function with given fields:
+func (_m *UnsafeRegistrationServiceServer) mustEmbedUnimplementedRegistrationServiceServer() {
+	_m.Called()
+}
+
+// UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'mustEmbedUnimplementedRegistrationServiceServer'
+type UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call struct {
+	*mock.Call
+}
+
+// mustEmbedUnimplementedRegistrationServiceServer is a helper method to define mock.On call
+func (_e *UnsafeRegistrationServiceServer_Expecter) mustEmbedUnimplementedRegistrationServiceServer() *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call {
+	return &UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call{Call: _e.mock.On("mustEmbedUnimplementedRegistrationServiceServer")}
+}
+
+func (_c *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call) Run(run func()) *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call) Return() *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call) RunAndReturn(run func()) *UnsafeRegistrationServiceServer_mustEmbedUnimplementedRegistrationServiceServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUnsafeRegistrationServiceServer creates a new instance of UnsafeRegistrationServiceServer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUnsafeRegistrationServiceServer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UnsafeRegistrationServiceServer {
+	mock := &UnsafeRegistrationServiceServer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v2.41.0. DO NOT EDIT.
+
+package mockpbregistration
+
+import mock "github.com/stretchr/testify/mock"
+
+// isRegisterWorkloadRequest_Request is an autogenerated mock type for the isRegisterWorkloadRequest_Request type
+type isRegisterWorkloadRequest_Request struct {
+	mock.Mock
+}
+
+type isRegisterWorkloadRequest_Request_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *isRegisterWorkloadRequest_Request) EXPECT() *isRegisterWorkloadRequest_Request_Expecter {
+	return &isRegisterWorkloadRequest_Request_Expecter{mock: &_m.Mock}
+}
+
+// isRegisterWorkloadRequest_Request provides a mock function with given fields:
+func (_m *isRegisterWorkloadRequest_Request) isRegisterWorkloadRequest_Request() {
+	_m.Called()
+}
+
+// isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'isRegisterWorkloadRequest_Request'
+type isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call struct {
+	*mock.Call
+}
+
+// isRegisterWorkloadRequest_Request is a helper method to define mock.On call
+func (_e *isRegisterWorkloadRequest_Request_Expecter) isRegisterWorkloadRequest_Request() *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call {
+	return &isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call{Call: _e.mock.On("isRegisterWorkloadRequest_Request")}
+}
+
+func (_c *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call) Run(run func()) *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call) Return() *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call) RunAndReturn(run func()) *isRegisterWorkloadRequest_Request_isRegisterWorkloadRequest_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newIsRegisterWorkloadRequest_Request creates a new instance of isRegisterWorkloadRequest_Request. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newIsRegisterWorkloadRequest_Request(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *isRegisterWorkloadRequest_Request {
+	mock := &isRegisterWorkloadRequest_Request{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
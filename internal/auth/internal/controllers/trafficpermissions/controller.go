@@ -5,6 +5,7 @@ package trafficpermissions
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -178,6 +179,13 @@ func (r *reconciler) Reconcile(ctx context.Context, rt controller.Runtime, req c
 	trafficPermissionBuilder := newTrafficPermissionsBuilder(r.sgExpander, sgMap, brc)
 	var tpResources []*pbresource.Resource
 
+	// The traffic permissions which expired are left out of the computation
+	// and, like the ones overdue for review, get an extra status condition.
+	// The request is requeued for the next of those deadlines.
+	now := time.Now()
+	var requeueAfter time.Duration
+	reviewed := make(map[resource.ReferenceKey][]*pbresource.Condition)
+
 	// Part 2: Recompute a CTP from TP create / modify / delete, or create a new CTP from existing TPs:
 	trackedTPs := r.mapper.GetTrafficPermissionsForCTP(ctpID)
 	if len(trackedTPs) > 0 {
@@ -198,8 +206,19 @@ func (r *reconciler) Reconcile(ctx context.Context, rt controller.Runtime, req c
 			r.mapper.UntrackTrafficPermissions(resource.IDFromReference(t))
 			continue
 		}
-		track(trafficPermissionBuilder, rsp)
+		conditions, next := reviewConditions(rsp.Data, now)
+		if len(conditions) > 0 {
+			reviewed[resource.NewReferenceKey(rsp.Id)] = conditions
+		}
+		if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
 		tpResources = append(tpResources, rsp.Resource)
+		if isExpired(rsp.Data, now) {
+			rt.Logger.Trace("skipping expired traffic permissions", "traffic-permissions-name", t.Name)
+			continue
+		}
+		track(trafficPermissionBuilder, rsp)
 	}
 
 	// Fetch partition traffic permissions for ctp(workload identity)'s tenancy
@@ -271,17 +290,25 @@ func (r *reconciler) Reconcile(ctx context.Context, rt controller.Runtime, req c
 	}
 
 	if len(allMissing) > 0 {
-		return writeMissingSgStatuses(ctx, rt, req, allMissing, newCTPResource, missing, tpResources)
+		err = writeMissingSgStatuses(ctx, rt, req, allMissing, newCTPResource, missing, tpResources, reviewed)
+	} else {
+		err = writeComputedStatuses(ctx, rt, req, newCTPResource, latestComputedTrafficPermissions.IsDefault, tpResources, reviewed)
+	}
+	if err != nil {
+		return err
 	}
 
-	return writeComputedStatuses(ctx, rt, req, newCTPResource, latestComputedTrafficPermissions.IsDefault, tpResources)
+	if requeueAfter > 0 {
+		return controller.RequeueAfter(requeueAfter)
+	}
+	return nil
 }
 
 func writeComputedStatuses(ctx context.Context, rt controller.Runtime, req controller.Request, ctpResource *pbresource.Resource, isDefault bool,
-	trackedTPs []*pbresource.Resource) error {
+	trackedTPs []*pbresource.Resource, reviewed map[resource.ReferenceKey][]*pbresource.Condition) error {
 	for _, tp := range trackedTPs {
 		err := writeStatusWithConditions(ctx, rt, tp,
-			[]*pbresource.Condition{ConditionComputedTrafficPermission()})
+			withReviewConditions(tp, reviewed, ConditionComputedTrafficPermission()))
 		if err != nil {
 			return err
 		}
@@ -291,7 +318,8 @@ func writeComputedStatuses(ctx context.Context, rt controller.Runtime, req contr
 }
 
 func writeMissingSgStatuses(ctx context.Context, rt controller.Runtime, req controller.Request, allMissing []string, newCTPResource *pbresource.Resource,
-	missing map[resource.ReferenceKey]missingSamenessGroupReferences, tpResources []*pbresource.Resource) error {
+	missing map[resource.ReferenceKey]missingSamenessGroupReferences, tpResources []*pbresource.Resource,
+	reviewed map[resource.ReferenceKey][]*pbresource.Condition) error {
 
 	condition := ConditionMissingSamenessGroup(req.ID.Tenancy.Partition, allMissing)
 
@@ -304,14 +332,14 @@ func writeMissingSgStatuses(ctx context.Context, rt controller.Runtime, req cont
 	for _, sgRefs := range missing {
 		if len(sgRefs.samenessGroups) == 0 {
 			err := writeStatusWithConditions(ctx, rt, sgRefs.resource,
-				[]*pbresource.Condition{ConditionComputedTrafficPermission()})
+				withReviewConditions(sgRefs.resource, reviewed, ConditionComputedTrafficPermission()))
 			if err != nil {
 				return err
 			}
 			continue
 		}
 		conditionTp := ConditionMissingSamenessGroup(req.ID.Tenancy.Partition, sgRefs.samenessGroups)
-		err := writeStatusWithConditions(ctx, rt, sgRefs.resource, []*pbresource.Condition{conditionTp})
+		err := writeStatusWithConditions(ctx, rt, sgRefs.resource, withReviewConditions(sgRefs.resource, reviewed, conditionTp))
 		if err != nil {
 			return err
 		}
@@ -321,7 +349,7 @@ func writeMissingSgStatuses(ctx context.Context, rt controller.Runtime, req cont
 			continue
 		}
 		err := writeStatusWithConditions(ctx, rt, trackedTp,
-			[]*pbresource.Condition{ConditionComputedTrafficPermission()})
+			withReviewConditions(trackedTp, reviewed, ConditionComputedTrafficPermission()))
 		if err != nil {
 			return err
 		}
@@ -329,6 +357,13 @@ func writeMissingSgStatuses(ctx context.Context, rt controller.Runtime, req cont
 	return nil
 }
 
+// withReviewConditions appends the expiry and review conditions of the
+// traffic permissions, if any, to the given condition.
+func withReviewConditions(tp *pbresource.Resource, reviewed map[resource.ReferenceKey][]*pbresource.Condition,
+	condition *pbresource.Condition) []*pbresource.Condition {
+	return append([]*pbresource.Condition{condition}, reviewed[resource.NewReferenceKey(tp.Id)]...)
+}
+
 func writeStatusWithConditions(ctx context.Context, rt controller.Runtime, res *pbresource.Resource,
 	conditions []*pbresource.Condition) error {
 
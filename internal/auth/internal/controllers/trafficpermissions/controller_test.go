@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/consul/internal/auth/internal/controllers/trafficpermissions/expander"
 	"github.com/hashicorp/consul/internal/auth/internal/mappers/trafficpermissionsmapper"
@@ -580,6 +582,75 @@ func (suite *controllerSuite) TestReconcile_TrafficPermissionsCreate_Destination
 	})
 }
 
+func (suite *controllerSuite) TestReconcile_TrafficPermissionsReviewAndExpiry() {
+	suite.runTestCaseWithTenancies(func(tenancy *pbresource.Tenancy) {
+		wi := rtest.Resource(pbauth.WorkloadIdentityType, "wi1").WithTenancy(tenancy).Write(suite.T(), suite.client)
+		id := resource.ReplaceType(pbauth.ComputedTrafficPermissionsType, wi.Id)
+
+		now := time.Now()
+		reviewBy := now.Add(-time.Hour)
+		expiresAt := now.Add(-time.Minute)
+
+		p1 := &pbauth.Permission{
+			Sources: []*pbauth.Source{
+				{
+					IdentityName: "foo",
+					Namespace:    "default",
+					Partition:    "default",
+					Peer:         resource.DefaultPeerName,
+				}},
+		}
+		tp1 := rtest.Resource(pbauth.TrafficPermissionsType, "tp1").WithTenancy(tenancy).WithData(suite.T(), &pbauth.TrafficPermissions{
+			Destination: &pbauth.Destination{
+				IdentityName: "wi1",
+			},
+			Action:      pbauth.Action_ACTION_ALLOW,
+			Permissions: []*pbauth.Permission{p1},
+			ReviewBy:    timestamppb.New(reviewBy),
+			ExpiresAt:   timestamppb.New(now.Add(time.Hour)),
+		}).Write(suite.T(), suite.client)
+		suite.requireTrafficPermissionsTracking(tp1, id)
+
+		p2 := &pbauth.Permission{
+			Sources: []*pbauth.Source{
+				{
+					IdentityName: "wi2",
+					Namespace:    "default",
+					Partition:    "default",
+					Peer:         resource.DefaultPeerName,
+				}},
+		}
+		tp2 := rtest.Resource(pbauth.TrafficPermissionsType, "tp2").WithTenancy(tenancy).WithData(suite.T(), &pbauth.TrafficPermissions{
+			Destination: &pbauth.Destination{
+				IdentityName: "wi1",
+			},
+			Action:      pbauth.Action_ACTION_ALLOW,
+			Permissions: []*pbauth.Permission{p2},
+			ExpiresAt:   timestamppb.New(expiresAt),
+		}).Write(suite.T(), suite.client)
+		suite.requireTrafficPermissionsTracking(tp2, id)
+
+		// The request is requeued for when the first traffic permissions expire.
+		err := suite.reconciler.Reconcile(suite.ctx, suite.rt, controller.Request{ID: id})
+		var requeue controller.RequeueAfterError
+		require.ErrorAs(suite.T(), err, &requeue)
+		require.InDelta(suite.T(), time.Hour, time.Duration(requeue), float64(time.Minute))
+
+		// The expired traffic permissions are left out.
+		ctpResource := suite.client.RequireResourceExists(suite.T(), id)
+		suite.requireCTP(ctpResource, []*pbauth.Permission{p1}, []*pbauth.Permission{})
+
+		suite.client.RequireStatusConditionsForCurrentGen(suite.T(), tp1.Id, StatusKey, []*pbresource.Condition{
+			ConditionComputedTrafficPermission(),
+			ConditionReviewOverdue(reviewBy),
+		})
+		suite.client.RequireStatusConditionsForCurrentGen(suite.T(), tp2.Id, StatusKey, []*pbresource.Condition{
+			ConditionComputedTrafficPermission(),
+			ConditionExpired(expiresAt),
+		})
+	})
+}
+
 func (suite *controllerSuite) TestReconcile_TrafficPermissionsDelete_DestinationWorkloadIdentityExists() {
 	suite.runTestCaseWithTenancies(func(tenancy *pbresource.Tenancy) {
 		// create the workload identity to be referenced
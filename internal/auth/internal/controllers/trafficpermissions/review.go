@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package trafficpermissions
+
+import (
+	"time"
+
+	pbauth "github.com/hashicorp/consul/proto-public/pbauth/v2beta1"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// isExpired returns whether the traffic permissions have an expiry time which
+// has passed, in which case they are no longer enforced.
+func isExpired(tp *pbauth.TrafficPermissions, now time.Time) bool {
+	return tp.GetExpiresAt() != nil && !now.Before(tp.GetExpiresAt().AsTime())
+}
+
+// reviewConditions returns the conditions reporting that the traffic
+// permissions expired or are overdue for review, along with the time left
+// until the next of those deadlines, which is zero when there is none.
+func reviewConditions(tp *pbauth.TrafficPermissions, now time.Time) ([]*pbresource.Condition, time.Duration) {
+	var (
+		conditions []*pbresource.Condition
+		next       time.Duration
+	)
+	if isExpired(tp, now) {
+		return []*pbresource.Condition{ConditionExpired(tp.GetExpiresAt().AsTime())}, 0
+	}
+	if expiresAt := tp.GetExpiresAt(); expiresAt != nil {
+		next = expiresAt.AsTime().Sub(now)
+	}
+	if reviewBy := tp.GetReviewBy(); reviewBy != nil {
+		if left := reviewBy.AsTime().Sub(now); left <= 0 {
+			conditions = append(conditions, ConditionReviewOverdue(reviewBy.AsTime()))
+		} else if next == 0 || left < next {
+			next = left
+		}
+	}
+	return conditions, next
+}
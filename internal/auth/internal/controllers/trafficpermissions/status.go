@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul/proto-public/pbresource"
 )
@@ -20,6 +21,10 @@ const (
 	ConditionNoPermissionsMsg                = "Workload identity %s has no permissions"
 	ConditionPermissionsFailedMsg            = "Unable to calculate new permission set for Workload identity %s"
 	ConditionMissingSamenessGroupInPartition = "Missing Sameness Groups names in partition(%s) - %s"
+	StatusTrafficPermissionsExpired          = "Traffic permissions have expired"
+	StatusTrafficPermissionsReviewOverdue    = "Traffic permissions are overdue for review"
+	ConditionExpiredMsg                      = "Traffic permissions expired at %s and are no longer enforced"
+	ConditionReviewOverdueMsg                = "Traffic permissions were due for review at %s"
 )
 
 func ConditionComputed(workloadIdentity string, isDefault bool) *pbresource.Condition {
@@ -66,3 +71,19 @@ func ConditionMissingSamenessGroup(partition string, missingSamenessGroups []str
 		Message: message,
 	}
 }
+
+func ConditionExpired(expiresAt time.Time) *pbresource.Condition {
+	return &pbresource.Condition{
+		Type:    StatusTrafficPermissionsExpired,
+		State:   pbresource.Condition_STATE_TRUE,
+		Message: fmt.Sprintf(ConditionExpiredMsg, expiresAt.UTC().Format(time.RFC3339)),
+	}
+}
+
+func ConditionReviewOverdue(reviewBy time.Time) *pbresource.Condition {
+	return &pbresource.Condition{
+		Type:    StatusTrafficPermissionsReviewOverdue,
+		State:   pbresource.Condition_STATE_TRUE,
+		Message: fmt.Sprintf(ConditionReviewOverdueMsg, reviewBy.UTC().Format(time.RFC3339)),
+	}
+}
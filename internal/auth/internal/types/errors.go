@@ -14,4 +14,5 @@ var (
 	errExclValuesMustBeSubset = errors.New("exclude permission rules must select a subset of ports and methods defined in the destination rule")
 	errHeaderRulesInvalid     = errors.New("header rule must contain header name")
 	ErrWildcardNotSupported   = errors.New("traffic permissions without explicit destinations are not yet supported")
+	errReviewAfterExpiry      = errors.New("must not be after expires_at")
 )
@@ -136,6 +136,14 @@ func validateTrafficPermissions(res *DecodedTrafficPermissions) error {
 		merr = multierror.Append(merr, err)
 	}
 
+	if res.Data.ReviewBy != nil && res.Data.ExpiresAt != nil &&
+		res.Data.ReviewBy.AsTime().After(res.Data.ExpiresAt.AsTime()) {
+		merr = multierror.Append(merr, resource.ErrInvalidField{
+			Name:    "review_by",
+			Wrapped: errReviewAfterExpiry,
+		})
+	}
+
 	return merr
 }
 
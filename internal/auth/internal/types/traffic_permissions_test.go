@@ -5,9 +5,11 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/consul/internal/resource"
 	"github.com/hashicorp/consul/internal/resource/resourcetest"
@@ -597,6 +599,52 @@ func TestValidateTrafficPermissions_Permissions(t *testing.T) {
 	}
 }
 
+func TestValidateTrafficPermissions_ReviewBy(t *testing.T) {
+	now := time.Now()
+	cases := map[string]struct {
+		reviewBy, expiresAt *timestamppb.Timestamp
+		expectErr           string
+	}{
+		"review-only": {
+			reviewBy: timestamppb.New(now),
+		},
+		"expiry-only": {
+			expiresAt: timestamppb.New(now),
+		},
+		"review-before-expiry": {
+			reviewBy:  timestamppb.New(now),
+			expiresAt: timestamppb.New(now.Add(time.Hour)),
+		},
+		"review-after-expiry": {
+			reviewBy:  timestamppb.New(now.Add(time.Hour)),
+			expiresAt: timestamppb.New(now),
+			expectErr: `invalid "review_by" field: must not be after expires_at`,
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			tp := &pbauth.TrafficPermissions{
+				Action:      pbauth.Action_ACTION_ALLOW,
+				Destination: &pbauth.Destination{IdentityName: "w1"},
+				ReviewBy:    tc.reviewBy,
+				ExpiresAt:   tc.expiresAt,
+			}
+
+			res := resourcetest.Resource(pbauth.TrafficPermissionsType, "tp").
+				WithTenancy(resource.DefaultNamespacedTenancy()).
+				WithData(t, tp).
+				Build()
+
+			err := ValidateTrafficPermissions(res)
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				testutil.RequireErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
 type mutationTestCase struct {
 	tenancy     *pbresource.Tenancy
 	permissions []*pbauth.Permission
@@ -54,6 +54,19 @@ func ServiceEndpointsController() *controller.Controller {
 				workloadselector.MapWorkloadsToSelectors(pbcatalog.ServiceType, selectedWorkloadsIndexName),
 			),
 		).
+		WithWatch(pbcatalog.HealthChecksType,
+			// Health checks are used to infer the protocols of the workload ports. The
+			// HealthChecks are first transformed into the workloads they select and then
+			// mapped to the ServiceEndpoints of the Services selecting those workloads.
+			dependency.MapperWithTransform(
+				dependency.WrapAndReplaceType(
+					pbcatalog.ServiceEndpointsType,
+					workloadselector.MapWorkloadsToSelectors(pbcatalog.ServiceType, selectedWorkloadsIndexName),
+				),
+				healthChecksToWorkloads,
+			),
+			// This cache index allows for finding all the HealthChecks selecting a workload.
+			workloadselector.Index[*pbcatalog.HealthChecks](selectingHealthChecksIndexName)).
 		WithReconciler(newServiceEndpointsReconciler())
 }
 
@@ -118,6 +131,16 @@ func (r *serviceEndpointsReconciler) Reconcile(ctx context.Context, rt controlle
 			return err
 		}
 
+		// Infer the protocols of the service ports from the workloads and their
+		// health checks. This must happen before calculating the endpoints as that
+		// fills in the unspecified workload port protocols.
+		checks, err := getSelectingHealthChecks(rt.Cache, workloads)
+		if err != nil {
+			rt.Logger.Error("error retrieving health checks of the selected workloads", "error", err)
+			return err
+		}
+		inferred := inferPortProtocols(service.Data, workloads, checks)
+
 		// Calculate the latest endpoints from the already gathered workloads
 		latestEndpoints := workloadsToEndpoints(service.Data, workloads)
 
@@ -127,6 +150,12 @@ func (r *serviceEndpointsReconciler) Reconcile(ctx context.Context, rt controlle
 				workloadIdentityStatusFromEndpoints(latestEndpoints))
 		}
 
+		// Let the user know about any inferred protocols which conflict with the
+		// declared protocols.
+		if conflicts := protocolConflicts(service.Data, inferred); len(conflicts) > 0 {
+			statusConditions = append(statusConditions, ConditionProtocolConflict(conflicts))
+		}
+
 		// Before writing the endpoints actually check to see if they are changed
 		if endpoints == nil || !proto.Equal(endpoints.Data, latestEndpoints) {
 			rt.Logger.Trace("endpoints have changed")
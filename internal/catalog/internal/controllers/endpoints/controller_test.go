@@ -858,6 +858,89 @@ func (suite *controllerSuite) TestController() {
 	})
 }
 
+func (suite *controllerSuite) TestController_ProtocolConflict() {
+	// Run the controller manager
+	mgr := controller.NewManager(suite.client, suite.rt.Logger)
+	mgr.Register(ServiceEndpointsController())
+	mgr.SetRaftLeader(true)
+	go mgr.Run(suite.ctx)
+
+	suite.runTestCaseWithTenancies(func(tenancy *pbresource.Tenancy) {
+		service := rtest.Resource(pbcatalog.ServiceType, "api").
+			WithTenancy(tenancy).
+			WithData(suite.T(), &pbcatalog.Service{
+				Workloads: &pbcatalog.WorkloadSelector{
+					Prefixes: []string{"api-"},
+				},
+				Ports: []*pbcatalog.ServicePort{
+					{TargetPort: "http", Protocol: pbcatalog.Protocol_PROTOCOL_TCP},
+				},
+			}).
+			Write(suite.T(), suite.client)
+
+		rtest.Resource(pbcatalog.WorkloadType, "api-1").
+			WithTenancy(tenancy).
+			WithData(suite.T(), &pbcatalog.Workload{
+				Addresses: []*pbcatalog.WorkloadAddress{{Host: "127.0.0.1"}},
+				Ports: map[string]*pbcatalog.WorkloadPort{
+					"http": {Port: 8080},
+				},
+				Identity: "api",
+			}).
+			Write(suite.T(), suite.client)
+
+		suite.client.WaitForStatusCondition(suite.T(), service.Id, ControllerID,
+			ConditionIdentitiesFound([]string{"api"}))
+
+		// Registering an HTTP health check against the port should surface
+		// the conflict with the declared protocol.
+		rtest.Resource(pbcatalog.HealthChecksType, "api-checks").
+			WithTenancy(tenancy).
+			WithData(suite.T(), &pbcatalog.HealthChecks{
+				Workloads: &pbcatalog.WorkloadSelector{
+					Prefixes: []string{"api-"},
+				},
+				HealthChecks: []*pbcatalog.HealthCheck{
+					{
+						Name: "ready",
+						Definition: &pbcatalog.HealthCheck_Http{
+							Http: &pbcatalog.HTTPCheck{Url: "http://127.0.0.1:8080/ready"},
+						},
+					},
+				},
+			}).
+			Write(suite.T(), suite.client)
+
+		suite.client.WaitForStatusCondition(suite.T(), service.Id, ControllerID,
+			ConditionProtocolConflict([]string{
+				`port "http" declares protocol "tcp" but "http" was inferred from health check "ready" on workload "api-1"`,
+			}))
+
+		// Declaring the inferred protocol resolves the conflict.
+		rtest.Resource(pbcatalog.ServiceType, "api").
+			WithTenancy(tenancy).
+			WithData(suite.T(), &pbcatalog.Service{
+				Workloads: &pbcatalog.WorkloadSelector{
+					Prefixes: []string{"api-"},
+				},
+				Ports: []*pbcatalog.ServicePort{
+					{TargetPort: "http", Protocol: pbcatalog.Protocol_PROTOCOL_HTTP},
+				},
+			}).
+			Write(suite.T(), suite.client)
+
+		retry.Run(suite.T(), func(r *retry.R) {
+			res := suite.client.RequireResourceExists(r, service.Id)
+			status := res.Status[ControllerID]
+			require.NotNil(r, status)
+			require.Equal(r, res.Generation, status.ObservedGeneration)
+			for _, condition := range status.Conditions {
+				require.NotEqual(r, StatusConditionProtocolConflict, condition.Type)
+			}
+		})
+	})
+}
+
 func TestController(t *testing.T) {
 	suite.Run(t, new(controllerSuite))
 }
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul/internal/catalog/workloadselector"
+	"github.com/hashicorp/consul/internal/controller"
+	"github.com/hashicorp/consul/internal/controller/cache"
+	"github.com/hashicorp/consul/internal/resource"
+	pbcatalog "github.com/hashicorp/consul/proto-public/pbcatalog/v2beta1"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+const selectingHealthChecksIndexName = "selecting-health-checks"
+
+type DecodedHealthChecks = resource.DecodedResource[*pbcatalog.HealthChecks]
+
+// inferredProtocol is a protocol inferred for a service port along with a
+// description of where it was inferred from.
+type inferredProtocol struct {
+	protocol pbcatalog.Protocol
+	source   string
+
+	// fromHealthCheck is true when the protocol was inferred from a health
+	// check. Health checks only tell us one protocol the port is able to
+	// speak, so they are also compatible with protocols able to carry it.
+	fromHealthCheck bool
+}
+
+// compatibleWith returns whether the inferred protocol is compatible with the
+// protocol declared by the service port.
+func (p inferredProtocol) compatibleWith(declared pbcatalog.Protocol) bool {
+	if p.protocol == declared {
+		return true
+	}
+	if !p.fromHealthCheck {
+		return false
+	}
+
+	// HTTP and gRPC health checks can be served over HTTP/2.
+	switch p.protocol {
+	case pbcatalog.Protocol_PROTOCOL_HTTP, pbcatalog.Protocol_PROTOCOL_GRPC:
+		return declared == pbcatalog.Protocol_PROTOCOL_HTTP2
+	}
+	return false
+}
+
+// getSelectingHealthChecks retrieves the HealthChecks resources that select each
+// of the workloads, keyed by workload name.
+func getSelectingHealthChecks(c cache.ReadOnlyCache, workloads []*DecodedWorkload) (map[string][]*DecodedHealthChecks, error) {
+	checks := make(map[string][]*DecodedHealthChecks)
+	for _, workload := range workloads {
+		selecting, err := cache.ParentsDecoded[*pbcatalog.HealthChecks](c, pbcatalog.HealthChecksType, selectingHealthChecksIndexName, workload.Id)
+		if err != nil {
+			return nil, err
+		}
+		if len(selecting) > 0 {
+			checks[workload.Id.Name] = selecting
+		}
+	}
+	return checks, nil
+}
+
+// inferPortProtocols infers the protocol of each of the service's target ports
+// from the selected workloads. Protocols explicitly declared on the workload
+// ports are treated as hints and take precedence over protocols inferred from
+// the health checks of the workloads. The first inference found for a port wins.
+func inferPortProtocols(svc *pbcatalog.Service, workloads []*DecodedWorkload, checks map[string][]*DecodedHealthChecks) map[string]inferredProtocol {
+	inferred := make(map[string]inferredProtocol)
+
+	for _, svcPort := range svc.GetPorts() {
+		for _, workload := range workloads {
+			workloadPort, found := workload.Data.Ports[svcPort.TargetPort]
+			if !found {
+				continue
+			}
+
+			if workloadPort.Protocol != pbcatalog.Protocol_PROTOCOL_UNSPECIFIED {
+				inferred[svcPort.TargetPort] = inferredProtocol{
+					protocol: workloadPort.Protocol,
+					source:   fmt.Sprintf("workload %q", workload.Id.Name),
+				}
+				break
+			}
+
+			if _, found := inferred[svcPort.TargetPort]; found {
+				continue
+			}
+
+			// The mesh port is the port of the sidecar proxy, health checks
+			// against it say nothing about the application.
+			if svcPort.Protocol == pbcatalog.Protocol_PROTOCOL_MESH {
+				continue
+			}
+
+			for _, hc := range checks[workload.Id.Name] {
+				for _, check := range hc.Data.HealthChecks {
+					protocol, port, ok := healthCheckProtocol(check)
+					if !ok || port != workloadPort.Port {
+						continue
+					}
+					inferred[svcPort.TargetPort] = inferredProtocol{
+						protocol:        protocol,
+						source:          fmt.Sprintf("health check %q on workload %q", check.Name, workload.Id.Name),
+						fromHealthCheck: true,
+					}
+					break
+				}
+				if _, found := inferred[svcPort.TargetPort]; found {
+					break
+				}
+			}
+		}
+	}
+
+	return inferred
+}
+
+// protocolConflicts returns a description of each service port whose declared
+// protocol conflicts with the protocol inferred for it.
+func protocolConflicts(svc *pbcatalog.Service, inferred map[string]inferredProtocol) []string {
+	var conflicts []string
+	for _, svcPort := range svc.GetPorts() {
+		protocol, found := inferred[svcPort.TargetPort]
+		if !found || protocol.compatibleWith(svcPort.Protocol) {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("port %q declares protocol %q but %q was inferred from %s",
+			svcPort.TargetPort, protocolName(svcPort.Protocol), protocolName(protocol.protocol), protocol.source))
+	}
+	return conflicts
+}
+
+// healthCheckProtocol returns the protocol spoken by the given health check
+// along with the port it checks. TCP, UDP and OS service checks don't say
+// anything about the application protocol and so are ignored.
+func healthCheckProtocol(check *pbcatalog.HealthCheck) (pbcatalog.Protocol, uint32, bool) {
+	switch def := check.GetDefinition().(type) {
+	case *pbcatalog.HealthCheck_Http:
+		u, err := url.Parse(def.Http.GetUrl())
+		if err != nil {
+			return pbcatalog.Protocol_PROTOCOL_UNSPECIFIED, 0, false
+		}
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "http":
+				port = "80"
+			case "https":
+				port = "443"
+			}
+		}
+		return parseCheckPort(pbcatalog.Protocol_PROTOCOL_HTTP, port)
+	case *pbcatalog.HealthCheck_Grpc:
+		// gRPC check addresses may be suffixed with the name of the service to check.
+		addr, _, _ := strings.Cut(def.Grpc.GetAddress(), "/")
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return pbcatalog.Protocol_PROTOCOL_UNSPECIFIED, 0, false
+		}
+		return parseCheckPort(pbcatalog.Protocol_PROTOCOL_GRPC, port)
+	}
+	return pbcatalog.Protocol_PROTOCOL_UNSPECIFIED, 0, false
+}
+
+func parseCheckPort(protocol pbcatalog.Protocol, port string) (pbcatalog.Protocol, uint32, bool) {
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || p == 0 {
+		return pbcatalog.Protocol_PROTOCOL_UNSPECIFIED, 0, false
+	}
+	return protocol, uint32(p), true
+}
+
+func protocolName(protocol pbcatalog.Protocol) string {
+	return strings.ToLower(strings.TrimPrefix(protocol.String(), "PROTOCOL_"))
+}
+
+// healthChecksToWorkloads is a dependency transform which transforms HealthChecks
+// into the workloads they select.
+func healthChecksToWorkloads(_ context.Context, rt controller.Runtime, res *pbresource.Resource) ([]*pbresource.Resource, error) {
+	hc, err := resource.Decode[*pbcatalog.HealthChecks](res)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := workloadselector.GetWorkloadsWithSelector(rt.Cache, hc)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*pbresource.Resource, len(workloads))
+	for i, workload := range workloads {
+		out[i] = workload.Resource
+	}
+	return out, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rtest "github.com/hashicorp/consul/internal/resource/resourcetest"
+	pbcatalog "github.com/hashicorp/consul/proto-public/pbcatalog/v2beta1"
+)
+
+func TestHealthCheckProtocol(t *testing.T) {
+	cases := map[string]struct {
+		check       *pbcatalog.HealthCheck
+		expProtocol pbcatalog.Protocol
+		expPort     uint32
+		expOk       bool
+	}{
+		"http": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Http{
+				Http: &pbcatalog.HTTPCheck{Url: "http://127.0.0.1:8080/health"},
+			}},
+			expProtocol: pbcatalog.Protocol_PROTOCOL_HTTP,
+			expPort:     8080,
+			expOk:       true,
+		},
+		"http default port": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Http{
+				Http: &pbcatalog.HTTPCheck{Url: "https://localhost/health"},
+			}},
+			expProtocol: pbcatalog.Protocol_PROTOCOL_HTTP,
+			expPort:     443,
+			expOk:       true,
+		},
+		"grpc": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Grpc{
+				Grpc: &pbcatalog.GRPCCheck{Address: "127.0.0.1:9090"},
+			}},
+			expProtocol: pbcatalog.Protocol_PROTOCOL_GRPC,
+			expPort:     9090,
+			expOk:       true,
+		},
+		"grpc with service": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Grpc{
+				Grpc: &pbcatalog.GRPCCheck{Address: "127.0.0.1:9090/my.Service"},
+			}},
+			expProtocol: pbcatalog.Protocol_PROTOCOL_GRPC,
+			expPort:     9090,
+			expOk:       true,
+		},
+		"grpc without port": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Grpc{
+				Grpc: &pbcatalog.GRPCCheck{Address: "127.0.0.1"},
+			}},
+		},
+		"tcp": {
+			check: &pbcatalog.HealthCheck{Definition: &pbcatalog.HealthCheck_Tcp{
+				Tcp: &pbcatalog.TCPCheck{Address: "127.0.0.1:8080"},
+			}},
+		},
+		"no definition": {
+			check: &pbcatalog.HealthCheck{},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			protocol, port, ok := healthCheckProtocol(c.check)
+			require.Equal(t, c.expProtocol, protocol)
+			require.Equal(t, c.expPort, port)
+			require.Equal(t, c.expOk, ok)
+		})
+	}
+}
+
+func TestInferPortProtocols(t *testing.T) {
+	service := &pbcatalog.Service{
+		Ports: []*pbcatalog.ServicePort{
+			{TargetPort: "http", Protocol: pbcatalog.Protocol_PROTOCOL_TCP},
+			{TargetPort: "grpc", Protocol: pbcatalog.Protocol_PROTOCOL_HTTP2},
+			{TargetPort: "admin", Protocol: pbcatalog.Protocol_PROTOCOL_HTTP},
+			{TargetPort: "metrics", Protocol: pbcatalog.Protocol_PROTOCOL_HTTP},
+			{TargetPort: "mesh", Protocol: pbcatalog.Protocol_PROTOCOL_MESH},
+		},
+	}
+
+	workload := func(name string, ports map[string]*pbcatalog.WorkloadPort) *DecodedWorkload {
+		return rtest.MustDecode[*pbcatalog.Workload](t,
+			rtest.Resource(pbcatalog.WorkloadType, name).
+				WithData(t, &pbcatalog.Workload{
+					Addresses: []*pbcatalog.WorkloadAddress{{Host: "127.0.0.1"}},
+					Ports:     ports,
+				}).
+				Build())
+	}
+
+	workloads := []*DecodedWorkload{
+		workload("api-1", map[string]*pbcatalog.WorkloadPort{
+			"http":    {Port: 8080},
+			"grpc":    {Port: 9090},
+			"admin":   {Port: 8081},
+			"metrics": {Port: 9102},
+			"mesh":    {Port: 20000},
+		}),
+		workload("api-2", map[string]*pbcatalog.WorkloadPort{
+			"admin": {Port: 8081, Protocol: pbcatalog.Protocol_PROTOCOL_TCP},
+		}),
+	}
+
+	checks := map[string][]*DecodedHealthChecks{
+		"api-1": {
+			rtest.MustDecode[*pbcatalog.HealthChecks](t,
+				rtest.Resource(pbcatalog.HealthChecksType, "api-checks").
+					WithData(t, &pbcatalog.HealthChecks{
+						Workloads: &pbcatalog.WorkloadSelector{Prefixes: []string{"api-"}},
+						HealthChecks: []*pbcatalog.HealthCheck{
+							{Name: "ready", Definition: &pbcatalog.HealthCheck_Http{
+								Http: &pbcatalog.HTTPCheck{Url: "http://127.0.0.1:8080/ready"},
+							}},
+							{Name: "grpc", Definition: &pbcatalog.HealthCheck_Grpc{
+								Grpc: &pbcatalog.GRPCCheck{Address: "127.0.0.1:9090"},
+							}},
+							{Name: "admin", Definition: &pbcatalog.HealthCheck_Http{
+								Http: &pbcatalog.HTTPCheck{Url: "http://127.0.0.1:8081/health"},
+							}},
+							{Name: "metrics", Definition: &pbcatalog.HealthCheck_Tcp{
+								Tcp: &pbcatalog.TCPCheck{Address: "127.0.0.1:9102"},
+							}},
+							{Name: "proxy", Definition: &pbcatalog.HealthCheck_Http{
+								Http: &pbcatalog.HTTPCheck{Url: "http://127.0.0.1:20000/ready"},
+							}},
+						},
+					}).
+					Build()),
+		},
+	}
+
+	inferred := inferPortProtocols(service, workloads, checks)
+	require.Equal(t, map[string]inferredProtocol{
+		"http": {
+			protocol:        pbcatalog.Protocol_PROTOCOL_HTTP,
+			source:          `health check "ready" on workload "api-1"`,
+			fromHealthCheck: true,
+		},
+		"grpc": {
+			protocol:        pbcatalog.Protocol_PROTOCOL_GRPC,
+			source:          `health check "grpc" on workload "api-1"`,
+			fromHealthCheck: true,
+		},
+		// The protocol declared on the workload port takes precedence over
+		// the health check.
+		"admin": {
+			protocol: pbcatalog.Protocol_PROTOCOL_TCP,
+			source:   `workload "api-2"`,
+		},
+	}, inferred)
+
+	require.Equal(t, []string{
+		`port "http" declares protocol "tcp" but "http" was inferred from health check "ready" on workload "api-1"`,
+		`port "admin" declares protocol "http" but "tcp" was inferred from workload "api-2"`,
+	}, protocolConflicts(service, inferred))
+}
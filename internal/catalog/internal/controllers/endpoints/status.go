@@ -24,6 +24,10 @@ const (
 
 	StatusReasonWorkloadIdentitiesFound   = "WorkloadIdentitiesFound"
 	StatusReasonNoWorkloadIdentitiesFound = "NoWorkloadIdentitiesFound"
+
+	StatusConditionProtocolConflict = "ProtocolConflict"
+
+	StatusReasonInferredProtocolConflict = "InferredProtocolConflict"
 )
 
 var (
@@ -59,3 +63,15 @@ func ConditionIdentitiesFound(identities []string) *pbresource.Condition {
 		Message: strings.Join(identities, ","),
 	}
 }
+
+// ConditionProtocolConflict is added when the protocol inferred for one or more
+// of the service's ports, from either the workload ports or the health checks
+// of the selected workloads, conflicts with the protocol the service declares.
+func ConditionProtocolConflict(conflicts []string) *pbresource.Condition {
+	return &pbresource.Condition{
+		Type:    StatusConditionProtocolConflict,
+		State:   pbresource.Condition_STATE_TRUE,
+		Reason:  StatusReasonInferredProtocolConflict,
+		Message: strings.Join(conflicts, "; "),
+	}
+}
@@ -5,6 +5,7 @@ package failover
 
 import (
 	"context"
+	"sort"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -207,7 +208,7 @@ func computeNewConditions(
 	}
 
 	for _, pc := range fp.GetPortConfigs() {
-		for _, dest := range pc.Destinations {
+		for _, dest := range pc.GetAllDestinations() {
 			// We know from validation that a Ref must be set, and the type it
 			// points to is a Service.
 			//
@@ -339,7 +340,7 @@ func makeComputedFailoverPolicy(ctx context.Context, rt controller.Runtime, sgEx
 
 	// Filter missing destinations
 	for port, fc := range cfp.PortConfigs {
-		if len(fc.Destinations) == 0 {
+		if len(fc.Destinations) == 0 && len(fc.Tiers) == 0 {
 			continue
 		}
 
@@ -349,7 +350,12 @@ func makeComputedFailoverPolicy(ctx context.Context, rt controller.Runtime, sgEx
 			return nil, nil, nil, err
 		}
 
-		if len(fc.GetDestinations()) == 0 {
+		fc.Tiers, err = filterInvalidTiers(ctx, rt, fc.Tiers, destServices)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(fc.GetDestinations()) == 0 && len(fc.GetTiers()) == 0 {
 			delete(cfp.GetPortConfigs(), port)
 
 		}
@@ -358,6 +364,10 @@ func makeComputedFailoverPolicy(ctx context.Context, rt controller.Runtime, sgEx
 	for ref := range destServices {
 		cfp.BoundReferences = append(cfp.BoundReferences, ref.ToReference())
 	}
+	// Keep the references stable to avoid unnecessary rewrites.
+	sort.Slice(cfp.BoundReferences, func(i, j int) bool {
+		return resource.LessReference(cfp.BoundReferences[i], cfp.BoundReferences[j])
+	})
 
 	return cfp, destServices, missingSamenessGroups, nil
 }
@@ -386,6 +396,24 @@ func filterInvalidDests(ctx context.Context, rt controller.Runtime, dests []*pbc
 	return out, nil
 }
 
+// filterInvalidTiers filters the missing destinations out of each of the tiers,
+// dropping the tiers which are left without any destinations.
+func filterInvalidTiers(ctx context.Context, rt controller.Runtime, tiers []*pbcatalog.FailoverTier, destServices map[resource.ReferenceKey]*resource.DecodedResource[*pbcatalog.Service]) ([]*pbcatalog.FailoverTier, error) {
+	var out []*pbcatalog.FailoverTier
+	for _, tier := range tiers {
+		dests, err := filterInvalidDests(ctx, rt, tier.Destinations, destServices)
+		if err != nil {
+			return nil, err
+		}
+		if len(dests) == 0 {
+			continue
+		}
+		tier.Destinations = dests
+		out = append(out, tier)
+	}
+	return out, nil
+}
+
 func writeStatus(ctx context.Context, rt controller.Runtime, res *pbresource.Resource, conditions []*pbresource.Condition) error {
 	newStatus := &pbresource.Status{
 		ObservedGeneration: res.GetGeneration(),
@@ -464,7 +464,7 @@ func TestController(t *testing.T) {
 		Run(t)
 
 	for _, tenancy := range resourcetest.TestTenancies() {
-		t.Run(tenancySubTestName(tenancy), func(t *testing.T) {
+		t.Run(controllertest.TenancySubTestName(tenancy), func(t *testing.T) {
 			tenancy := tenancy
 
 			node := injectNodeWithStatus(t, client, "test-node", pbcatalog.Health_HEALTH_PASSING, tenancy)
@@ -769,12 +769,8 @@ func TestGetNodeHealth(t *testing.T) {
 
 func (suite *controllerSuite) runTestCaseWithTenancies(testFunc func(*pbresource.Tenancy)) {
 	for _, tenancy := range suite.tenancies {
-		suite.Run(tenancySubTestName(tenancy), func() {
+		suite.Run(controllertest.TenancySubTestName(tenancy), func() {
 			testFunc(tenancy)
 		})
 	}
 }
-
-func tenancySubTestName(tenancy *pbresource.Tenancy) string {
-	return fmt.Sprintf("%s_Namespace_%s_Partition", tenancy.Namespace, tenancy.Partition)
-}
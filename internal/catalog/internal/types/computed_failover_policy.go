@@ -56,7 +56,7 @@ func aclWriteHookComputedFailoverPolicy(authorizer acl.Authorizer, authzContext
 	// Ensure you have service:read on any destination that may be affected by
 	// traffic FROM this config change.
 	if res.Data.Config != nil {
-		for _, dest := range res.Data.Config.Destinations {
+		for _, dest := range res.Data.Config.GetAllDestinations() {
 			destAuthzContext := resource.AuthorizerContext(dest.Ref.GetTenancy())
 			destServiceName := dest.Ref.GetName()
 			if err := authorizer.ToAllowAuthorizer().ServiceReadAllowed(destServiceName, destAuthzContext); err != nil {
@@ -65,7 +65,7 @@ func aclWriteHookComputedFailoverPolicy(authorizer acl.Authorizer, authzContext
 		}
 	}
 	for _, pc := range res.Data.PortConfigs {
-		for _, dest := range pc.Destinations {
+		for _, dest := range pc.GetAllDestinations() {
 			destAuthzContext := resource.AuthorizerContext(dest.Ref.GetTenancy())
 			destServiceName := dest.Ref.GetName()
 			if err := authorizer.ToAllowAuthorizer().ServiceReadAllowed(destServiceName, destAuthzContext); err != nil {
@@ -55,6 +55,15 @@ func (err errVirtualPortReused) Error() string {
 	return fmt.Sprintf("virtual port %d was previously assigned at index %d", err.Value, err.Index)
 }
 
+type errPortNameReused struct {
+	Index int
+	Value string
+}
+
+func (err errPortNameReused) Error() string {
+	return fmt.Sprintf("port name %q was previously used at index %d", err.Value, err.Index)
+}
+
 type errTooMuchMesh struct {
 	Ports []string
 }
@@ -70,7 +70,7 @@ func mutateFailoverPolicy(res *DecodedFailoverPolicy) (bool, error) {
 func mutateFailoverConfig(policyTenancy *pbresource.Tenancy, config *pbcatalog.FailoverConfig) (changed bool) {
 	// TODO(peering/v2): Add something here when we know what to do with non-local peer references
 
-	for _, dest := range config.Destinations {
+	for _, dest := range config.GetAllDestinations() {
 		if dest.Ref == nil {
 			continue
 		}
@@ -165,10 +165,16 @@ func validateFailoverConfig(config *pbcatalog.FailoverConfig, ported bool, wrapE
 
 	// TODO(peering/v2): remove this bypass when we know what to do with
 
-	if (len(config.Destinations) > 0) == (config.SamenessGroup != "") {
+	set := 0
+	for _, isSet := range []bool{len(config.Destinations) > 0, len(config.Tiers) > 0, config.SamenessGroup != ""} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
 		merr = multierror.Append(merr, wrapErr(resource.ErrInvalidField{
 			Name:    "destinations",
-			Wrapped: fmt.Errorf("exactly one of destinations or sameness_group should be set"),
+			Wrapped: fmt.Errorf("exactly one of destinations, tiers or sameness_group should be set"),
 		}))
 	}
 	for i, dest := range config.Destinations {
@@ -184,6 +190,34 @@ func validateFailoverConfig(config *pbcatalog.FailoverConfig, ported bool, wrapE
 		}
 	}
 
+	for i, tier := range config.Tiers {
+		wrapTierErr := func(err error) error {
+			return wrapErr(resource.ErrInvalidListElement{
+				Name:    "tiers",
+				Index:   i,
+				Wrapped: err,
+			})
+		}
+		if len(tier.Destinations) == 0 {
+			merr = multierror.Append(merr, wrapTierErr(resource.ErrInvalidField{
+				Name:    "destinations",
+				Wrapped: resource.ErrEmpty,
+			}))
+		}
+		for j, dest := range tier.Destinations {
+			wrapDestErr := func(err error) error {
+				return wrapTierErr(resource.ErrInvalidListElement{
+					Name:    "destinations",
+					Index:   j,
+					Wrapped: err,
+				})
+			}
+			if destErr := validateFailoverPolicyDestination(dest, ported, wrapDestErr); destErr != nil {
+				merr = multierror.Append(merr, destErr)
+			}
+		}
+	}
+
 	if config.Mode != pbcatalog.FailoverMode_FAILOVER_MODE_UNSPECIFIED {
 		merr = multierror.Append(merr, wrapErr(resource.ErrInvalidField{
 			Name:    "mode",
@@ -289,11 +323,10 @@ func SimplifyFailoverPolicy(svc *pbcatalog.Service, failover *pbcatalog.Failover
 		}
 
 		if pc, ok := failover.PortConfigs[port.TargetPort]; ok {
-			for i, dest := range pc.Destinations {
+			for _, dest := range pc.GetAllDestinations() {
 				// Assume port alignment.
 				if dest.Port == "" {
 					dest.Port = port.TargetPort
-					pc.Destinations[i] = dest
 				}
 			}
 			continue
@@ -302,7 +335,7 @@ func SimplifyFailoverPolicy(svc *pbcatalog.Service, failover *pbcatalog.Failover
 		if failover.Config != nil {
 			// Duplicate because each port will get this uniquely.
 			pc2 := proto.Clone(failover.Config).(*pbcatalog.FailoverConfig)
-			for _, dest := range pc2.Destinations {
+			for _, dest := range pc2.GetAllDestinations() {
 				dest.Port = port.TargetPort
 			}
 			failover.PortConfigs[port.TargetPort] = pc2
@@ -336,7 +369,7 @@ func aclWriteHookFailoverPolicy(authorizer acl.Authorizer, authzContext *acl.Aut
 	// Ensure you have service:read on any destination that may be affected by
 	// traffic FROM this config change.
 	if res.Data.Config != nil {
-		for _, dest := range res.Data.Config.Destinations {
+		for _, dest := range res.Data.Config.GetAllDestinations() {
 			destAuthzContext := resource.AuthorizerContext(dest.Ref.GetTenancy())
 			destServiceName := dest.Ref.GetName()
 			if err := authorizer.ToAllowAuthorizer().ServiceReadAllowed(destServiceName, destAuthzContext); err != nil {
@@ -345,7 +378,7 @@ func aclWriteHookFailoverPolicy(authorizer acl.Authorizer, authzContext *acl.Aut
 		}
 	}
 	for _, pc := range res.Data.PortConfigs {
-		for _, dest := range pc.Destinations {
+		for _, dest := range pc.GetAllDestinations() {
 			destAuthzContext := resource.AuthorizerContext(dest.Ref.GetTenancy())
 			destServiceName := dest.Ref.GetName()
 			if err := authorizer.ToAllowAuthorizer().ServiceReadAllowed(destServiceName, destAuthzContext); err != nil {
@@ -215,7 +215,7 @@ func addFailoverConfigSamenessGroupCases(fpcases map[string]failoverTestcase) {
 			},
 			SamenessGroup: "blah",
 		},
-		expectErr: `invalid "destinations" field: exactly one of destinations or sameness_group should be set`,
+		expectErr: `invalid "destinations" field: exactly one of destinations, tiers or sameness_group should be set`,
 	}
 	configCases["sameness without dest"] = configTestcase{
 		config: &pbcatalog.FailoverConfig{
@@ -379,6 +379,57 @@ func getCommonConfigCases() map[string]configTestcase {
 		// 	},
 		// 	expectErr: `invalid "mode" field: not a supported enum value: 99`,
 		// },
+		"tiers": {
+			config: &pbcatalog.FailoverConfig{
+				Tiers: []*pbcatalog.FailoverTier{
+					{
+						Name: "same-zone",
+						Destinations: []*pbcatalog.FailoverDestination{
+							{Ref: newRef(pbcatalog.ServiceType, "api-zone")},
+						},
+					},
+					{
+						Name: "same-region",
+						Destinations: []*pbcatalog.FailoverDestination{
+							{Ref: newRef(pbcatalog.ServiceType, "api-region-1")},
+							{Ref: newRef(pbcatalog.ServiceType, "api-region-2")},
+						},
+					},
+				},
+			},
+		},
+		"tiers with dest": {
+			config: &pbcatalog.FailoverConfig{
+				Destinations: []*pbcatalog.FailoverDestination{
+					{Ref: newRef(pbcatalog.ServiceType, "api-backup")},
+				},
+				Tiers: []*pbcatalog.FailoverTier{
+					{Destinations: []*pbcatalog.FailoverDestination{
+						{Ref: newRef(pbcatalog.ServiceType, "api-zone")},
+					}},
+				},
+			},
+			expectErr: `invalid "destinations" field: exactly one of destinations, tiers or sameness_group should be set`,
+		},
+		"tiers: empty tier": {
+			config: &pbcatalog.FailoverConfig{
+				Tiers: []*pbcatalog.FailoverTier{
+					{Name: "same-zone"},
+				},
+			},
+			expectErr: `invalid element at index 0 of list "tiers": invalid "destinations" field: cannot be empty`,
+		},
+		"tiers: dest no ref": {
+			config: &pbcatalog.FailoverConfig{
+				Tiers: []*pbcatalog.FailoverTier{
+					{Destinations: []*pbcatalog.FailoverDestination{
+						{Ref: newRef(pbcatalog.ServiceType, "api-zone")},
+						{},
+					}},
+				},
+			},
+			expectErr: `invalid element at index 0 of list "tiers": invalid element at index 1 of list "destinations": invalid "ref" field: missing required field`,
+		},
 		"dest: no ref": {
 			config: &pbcatalog.FailoverConfig{
 				Destinations: []*pbcatalog.FailoverDestination{
@@ -538,6 +589,57 @@ func TestSimplifyFailoverPolicy(t *testing.T) {
 				}).
 				Build(),
 		},
+		"implicit tiers with port defaulting": {
+			svc: resourcetest.Resource(pbcatalog.ServiceType, "api").
+				WithData(t, &pbcatalog.Service{
+					Ports: []*pbcatalog.ServicePort{
+						newPort("http", 8080, pbcatalog.Protocol_PROTOCOL_HTTP),
+					},
+				}).
+				Build(),
+			failover: resourcetest.Resource(pbcatalog.FailoverPolicyType, "api").
+				WithData(t, &pbcatalog.FailoverPolicy{
+					Config: &pbcatalog.FailoverConfig{
+						Tiers: []*pbcatalog.FailoverTier{
+							{
+								Name: "same-zone",
+								Destinations: []*pbcatalog.FailoverDestination{
+									{Ref: newRef(pbcatalog.ServiceType, "api-zone")},
+								},
+							},
+							{
+								Name: "same-region",
+								Destinations: []*pbcatalog.FailoverDestination{
+									{Ref: newRef(pbcatalog.ServiceType, "api-region")},
+								},
+							},
+						},
+					},
+				}).
+				Build(),
+			expect: resourcetest.Resource(pbcatalog.FailoverPolicyType, "api").
+				WithData(t, &pbcatalog.FailoverPolicy{
+					PortConfigs: map[string]*pbcatalog.FailoverConfig{
+						"http": {
+							Tiers: []*pbcatalog.FailoverTier{
+								{
+									Name: "same-zone",
+									Destinations: []*pbcatalog.FailoverDestination{
+										{Ref: newRef(pbcatalog.ServiceType, "api-zone"), Port: "http"},
+									},
+								},
+								{
+									Name: "same-region",
+									Destinations: []*pbcatalog.FailoverDestination{
+										{Ref: newRef(pbcatalog.ServiceType, "api-region"), Port: "http"},
+									},
+								},
+							},
+						},
+					},
+				}).
+				Build(),
+		},
 		"explicit with port aligned defaulting": {
 			svc: resourcetest.Resource(pbcatalog.ServiceType, "api").
 				WithData(t, &pbcatalog.Service{
@@ -59,6 +59,15 @@ func validateService(res *DecodedService) error {
 
 	usedVirtualPorts := make(map[uint32]int)
 
+	// Aliases share a namespace with the target ports, so gather up all the
+	// target port names up front.
+	usedPortNames := make(map[string]int)
+	for idx, port := range res.Data.Ports {
+		if _, found := usedPortNames[port.TargetPort]; !found {
+			usedPortNames[port.TargetPort] = idx
+		}
+	}
+
 	// Validate each port
 	for idx, port := range res.Data.Ports {
 		if usedIdx, found := usedVirtualPorts[port.VirtualPort]; found {
@@ -89,6 +98,33 @@ func validateService(res *DecodedService) error {
 			})
 		}
 
+		// validate the aliases
+		for aliasIdx, alias := range port.Aliases {
+			var aliasErr error
+			if nameErr := ValidatePortName(alias); nameErr != nil {
+				aliasErr = nameErr
+			} else if usedIdx, found := usedPortNames[alias]; found {
+				aliasErr = errPortNameReused{
+					Index: usedIdx,
+					Value: alias,
+				}
+			} else {
+				usedPortNames[alias] = idx
+			}
+
+			if aliasErr != nil {
+				err = multierror.Append(err, resource.ErrInvalidListElement{
+					Name:  "ports",
+					Index: idx,
+					Wrapped: resource.ErrInvalidListElement{
+						Name:    "aliases",
+						Index:   aliasIdx,
+						Wrapped: aliasErr,
+					},
+				})
+			}
+		}
+
 		if protoErr := ValidateProtocol(port.Protocol); protoErr != nil {
 			err = multierror.Append(err, resource.ErrInvalidListElement{
 				Name:  "ports",
@@ -78,6 +78,7 @@ func TestValidateService_Ok(t *testing.T) {
 				TargetPort:  "http-internal",
 				VirtualPort: 42,
 				Protocol:    pbcatalog.Protocol_PROTOCOL_HTTP,
+				Aliases:     []string{"http", "web"},
 			},
 			{
 				TargetPort: "other",
@@ -205,6 +206,90 @@ func TestValidateService_VirtualPortReused(t *testing.T) {
 	require.EqualValues(t, 42, actual.Value)
 }
 
+func TestValidateService_InvalidAlias(t *testing.T) {
+	data := &pbcatalog.Service{
+		Workloads: &pbcatalog.WorkloadSelector{
+			Prefixes: []string{""},
+		},
+		Ports: []*pbcatalog.ServicePort{
+			{
+				TargetPort: "foo",
+				Aliases:    []string{"8080"},
+			},
+		},
+	}
+
+	res := createServiceResource(t, data)
+
+	err := ValidateService(res)
+	require.Error(t, err)
+	var actual resource.ErrInvalidListElement
+	require.ErrorAs(t, err, &actual)
+	require.ErrorAs(t, actual.Wrapped, &actual)
+	require.Equal(t, "aliases", actual.Name)
+	require.Equal(t, errNotPortName, actual.Wrapped)
+}
+
+func TestValidateService_PortNameReused(t *testing.T) {
+	cases := map[string]struct {
+		ports    []*pbcatalog.ServicePort
+		expIndex int
+		expValue string
+	}{
+		"alias of target port": {
+			ports: []*pbcatalog.ServicePort{
+				{TargetPort: "foo"},
+				{TargetPort: "bar", Aliases: []string{"foo"}},
+			},
+			expIndex: 0,
+			expValue: "foo",
+		},
+		"alias of later target port": {
+			ports: []*pbcatalog.ServicePort{
+				{TargetPort: "foo", Aliases: []string{"bar"}},
+				{TargetPort: "bar"},
+			},
+			expIndex: 1,
+			expValue: "bar",
+		},
+		"alias of own target port": {
+			ports: []*pbcatalog.ServicePort{
+				{TargetPort: "foo", Aliases: []string{"foo"}},
+			},
+			expIndex: 0,
+			expValue: "foo",
+		},
+		"alias reused": {
+			ports: []*pbcatalog.ServicePort{
+				{TargetPort: "foo", Aliases: []string{"web"}},
+				{TargetPort: "bar", Aliases: []string{"web"}},
+			},
+			expIndex: 0,
+			expValue: "web",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := &pbcatalog.Service{
+				Workloads: &pbcatalog.WorkloadSelector{
+					Prefixes: []string{""},
+				},
+				Ports: tc.ports,
+			}
+
+			res := createServiceResource(t, data)
+
+			err := ValidateService(res)
+			require.Error(t, err)
+			var actual errPortNameReused
+			require.ErrorAs(t, err, &actual)
+			require.Equal(t, tc.expIndex, actual.Index)
+			require.Equal(t, tc.expValue, actual.Value)
+		})
+	}
+}
+
 func TestValidateService_InvalidPortProtocol(t *testing.T) {
 	data := &pbcatalog.Service{
 		Workloads: &pbcatalog.WorkloadSelector{
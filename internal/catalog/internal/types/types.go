@@ -16,7 +16,7 @@ func Register(r resource.Registry) {
 	RegisterFailoverPolicy(r)
 	RegisterNodeHealthStatus(r)
 	RegisterComputedFailoverPolicy(r)
+	RegisterHealthChecks(r)
 	// todo (v2): re-register once these resources are implemented.
-	//RegisterHealthChecks(r)
 	//RegisterVirtualIPs(r)
 }
@@ -15,6 +15,7 @@ import (
 type Builder struct {
 	serviceBuilder        *svctest.Builder
 	controllerRegisterFns []func(*controller.Manager)
+	tenancies             []*pbresource.Tenancy
 }
 
 // NewControllerTestBuilder starts to build out out the necessary controller testing
@@ -58,6 +59,18 @@ func (b *Builder) WithControllerRegisterFns(registerFns ...func(*controller.Mana
 	return b
 }
 
+// WithControllers allows configuring a set of controllers that should be registered
+// with the controller manager and executed during Run.
+func (b *Builder) WithControllers(ctrls ...*controller.Controller) *Builder {
+	for _, ctrl := range ctrls {
+		ctrl := ctrl
+		b.controllerRegisterFns = append(b.controllerRegisterFns, func(mgr *controller.Manager) {
+			mgr.Register(ctrl)
+		})
+	}
+	return b
+}
+
 // WithACLResolver is used to provide an ACLResolver implementation to the internal resource service.
 func (b *Builder) WithACLResolver(aclResolver svc.ACLResolver) *Builder {
 	b.serviceBuilder = b.serviceBuilder.WithACLResolver(aclResolver)
@@ -67,6 +80,7 @@ func (b *Builder) WithACLResolver(aclResolver svc.ACLResolver) *Builder {
 // WithTenancies adds additional tenancies if default/default is not sufficient.
 func (b *Builder) WithTenancies(tenancies ...*pbresource.Tenancy) *Builder {
 	b.serviceBuilder = b.serviceBuilder.WithTenancies(tenancies...)
+	b.tenancies = append(b.tenancies, tenancies...)
 	return b
 }
 
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/resourcetest"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+// Harness is a running controller integration testing environment. It embeds
+// a resourcetest.Client connected to the in-mem resource service so all of the
+// usual helpers for writing resources and awaiting status conditions are
+// available directly on the harness.
+type Harness struct {
+	*resourcetest.Client
+
+	tenancies []*pbresource.Tenancy
+}
+
+// RunHarness is like Run but wraps the resource service client in a Harness
+// to avoid every controller test having to set up the same helpers.
+func (b *Builder) RunHarness(t testutil.TestingTB, opts ...resourcetest.ClientOption) *Harness {
+	t.Helper()
+
+	tenancies := b.tenancies
+	if len(tenancies) == 0 {
+		tenancies = []*pbresource.Tenancy{resource.DefaultNamespacedTenancy()}
+	}
+
+	return &Harness{
+		Client:    resourcetest.NewClient(b.Run(t), opts...),
+		tenancies: tenancies,
+	}
+}
+
+// Tenancies returns the tenancies the harness was configured with, or just the
+// default tenancy if none were configured.
+func (h *Harness) Tenancies() []*pbresource.Tenancy {
+	return h.tenancies
+}
+
+// RunWithTenancies runs the given function as a subtest once for each of the
+// tenancies returned by Tenancies.
+func (h *Harness) RunWithTenancies(t *testing.T, fn func(t *testing.T, tenancy *pbresource.Tenancy)) {
+	t.Helper()
+
+	for _, tenancy := range h.tenancies {
+		tenancy := tenancy
+		t.Run(TenancySubTestName(tenancy), func(t *testing.T) {
+			fn(t, tenancy)
+		})
+	}
+}
+
+// WaitForStatusConditionAbsent waits until the resource has been reconciled by the
+// controller for its current generation and the status no longer contains a
+// condition of the given type.
+func (h *Harness) WaitForStatusConditionAbsent(t resourcetest.T, id *pbresource.ID, statusKey string, conditionType string) *pbresource.Resource {
+	t.Helper()
+
+	return h.WaitForResourceState(t, id, func(t resourcetest.T, res *pbresource.Resource) {
+		status, found := res.Status[statusKey]
+		require.True(t, found, "status %q not found", statusKey)
+		require.Equal(t, res.Generation, status.ObservedGeneration)
+		for _, condition := range status.Conditions {
+			require.NotEqual(t, conditionType, condition.Type, "unexpected condition %q", conditionType)
+		}
+	})
+}
+
+// TenancySubTestName returns a subtest name describing the given tenancy.
+func TenancySubTestName(tenancy *pbresource.Tenancy) string {
+	return fmt.Sprintf("%s_Namespace_%s_Partition", tenancy.Namespace, tenancy.Partition)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package controllertest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/internal/controller/controllertest"
+	"github.com/hashicorp/consul/internal/resource"
+	"github.com/hashicorp/consul/internal/resource/demo"
+	"github.com/hashicorp/consul/internal/resource/resourcetest"
+	"github.com/hashicorp/consul/proto-public/pbresource"
+	pbdemov2 "github.com/hashicorp/consul/proto/private/pbdemo/v2"
+)
+
+func TestHarness(t *testing.T) {
+	h := controllertest.NewControllerTestBuilder().
+		WithResourceRegisterFns(demo.RegisterTypes).
+		WithControllerRegisterFns(demo.RegisterControllers).
+		RunHarness(t)
+
+	require.Len(t, h.Tenancies(), 1)
+	require.Equal(t, resource.DefaultNamespacedTenancy(), h.Tenancies()[0])
+
+	var ran []string
+	h.RunWithTenancies(t, func(t *testing.T, tenancy *pbresource.Tenancy) {
+		ran = append(ran, t.Name())
+
+		artist := resourcetest.Resource(pbdemov2.ArtistType, "the-cure").
+			WithTenancy(tenancy).
+			WithData(t, &pbdemov2.Artist{Name: "The Cure"}).
+			Write(t, h)
+
+		h.WaitForStatusCondition(t, artist.Id, "consul.io/artist-controller", &pbresource.Condition{
+			Type:    "Accepted",
+			State:   pbresource.Condition_STATE_TRUE,
+			Reason:  "Accepted",
+			Message: "Artist 'The Cure' accepted",
+		})
+		h.WaitForStatusConditionAbsent(t, artist.Id, "consul.io/artist-controller", "Rejected")
+	})
+	require.Equal(t, []string{"TestHarness/default_Namespace_default_Partition"}, ran)
+}
+
+func TestTenancySubTestName(t *testing.T) {
+	require.Equal(t, "bar_Namespace_foo_Partition",
+		controllertest.TenancySubTestName(&pbresource.Tenancy{Partition: "foo", Namespace: "bar"}))
+}
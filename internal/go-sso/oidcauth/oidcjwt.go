@@ -14,12 +14,12 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-hclog"
 	"github.com/mitchellh/pointerstructure"
 	"golang.org/x/oauth2"
 
 	"github.com/hashicorp/consul/internal/go-sso/oidcauth/internal/strutil"
+	"github.com/hashicorp/consul/lib/egressproxy"
 )
 
 func contextWithHttpClient(ctx context.Context, client *http.Client) context.Context {
@@ -27,7 +27,7 @@ func contextWithHttpClient(ctx context.Context, client *http.Client) context.Con
 }
 
 func createHTTPClient(caCert string) (*http.Client, error) {
-	tr := cleanhttp.DefaultPooledTransport()
+	tr := egressproxy.PooledTransport()
 
 	if caCert != "" {
 		certPool := x509.NewCertPool()
@@ -0,0 +1,426 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package tcptransport provides a memberlist transport that does not depend on
+// UDP, for networks where UDP traffic between the agents is blocked.
+package tcptransport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	// packetStreamMagic is the first byte sent on the TCP connections that
+	// carry gossip packets. It is not a valid memberlist message type, which
+	// is how these connections are told apart from the memberlist streams
+	// sharing the same port.
+	packetStreamMagic byte = 0xf1
+
+	// MaxPacketSize is the maximum allowed size of a packet. Memberlist
+	// limits packets to the size of an UDP datagram, so this should never be
+	// hit in practice.
+	MaxPacketSize = 4 * 1024 * 1024
+
+	// connMaxIdleTime controls how long an idle packet connection to another
+	// node is kept open.
+	connMaxIdleTime = 2 * time.Minute
+
+	// dialTimeout bounds the time spent connecting to another node to send
+	// it a packet.
+	dialTimeout = 5 * time.Second
+
+	// handshakeTimeout bounds the time to wait for the first byte of an
+	// incoming connection.
+	handshakeTimeout = 10 * time.Second
+
+	// MinProbeInterval and MinProbeTimeout are the lowest probe settings used
+	// in TCP mode. Probes cost a round trip on a TCP connection, and a new
+	// connection when the previous one was idle, so the UDP defaults are too
+	// aggressive.
+	MinProbeInterval = 2 * time.Second
+	MinProbeTimeout  = time.Second
+)
+
+// Transport is a memberlist.NodeAwareTransport that sends gossip packets over
+// TCP connections instead of UDP. Each packet is framed with its length, and
+// the connections to each node are reused. Streams such as push/pull syncs
+// are left to the wrapped transport.
+//
+// The wrapped transport still receives UDP packets, but this transport never
+// sends any, so all the members of a gossip pool must use it.
+type Transport struct {
+	memberlist.NodeAwareTransport
+
+	logger *log.Logger
+
+	packetCh chan *memberlist.Packet
+	streamCh chan net.Conn
+
+	// mu protects conns and shutdown.
+	mu       sync.Mutex
+	conns    map[string]*packetConn
+	shutdown bool
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+var _ memberlist.NodeAwareTransport = (*Transport)(nil)
+
+// New returns a Transport that sends packets over TCP, relying on transport
+// to listen and to handle the streams.
+func New(transport memberlist.NodeAwareTransport, logger *log.Logger) *Transport {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	t := &Transport{
+		NodeAwareTransport: transport,
+		logger:             logger,
+		packetCh:           make(chan *memberlist.Packet),
+		streamCh:           make(chan net.Conn),
+		conns:              make(map[string]*packetConn),
+		shutdownCh:         make(chan struct{}),
+	}
+
+	t.wg.Add(3)
+	go t.forwardPackets()
+	go t.acceptStreams()
+	go t.reap()
+	return t
+}
+
+// NewNetTransport returns a Transport wrapping a memberlist.NetTransport that
+// listens on the bind address and port of conf, and adjusts the probe timing
+// of conf for TCP.
+func NewNetTransport(conf *memberlist.Config, labels []metrics.Label) (*Transport, error) {
+	nt, err := memberlist.NewNetTransport(&memberlist.NetTransportConfig{
+		BindAddrs:    []string{conf.BindAddr},
+		BindPort:     conf.BindPort,
+		Logger:       conf.Logger,
+		MetricLabels: labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conf.BindPort == 0 {
+		port := nt.GetAutoBindPort()
+		conf.BindPort = port
+		conf.AdvertisePort = port
+	}
+
+	ConfigureTiming(conf)
+	return New(nt, conf.Logger), nil
+}
+
+// ConfigureTiming adjusts the probe settings of conf for TCP. The fallback
+// TCP ping is disabled since the probes already use TCP.
+func ConfigureTiming(conf *memberlist.Config) {
+	if conf.ProbeInterval < MinProbeInterval {
+		conf.ProbeInterval = MinProbeInterval
+	}
+	if conf.ProbeTimeout < MinProbeTimeout {
+		conf.ProbeTimeout = MinProbeTimeout
+	}
+	conf.DisableTcpPings = true
+}
+
+// WriteTo implements memberlist.Transport.
+func (t *Transport) WriteTo(b []byte, addr string) (time.Time, error) {
+	return t.WriteToAddress(b, memberlist.Address{Addr: addr})
+}
+
+// WriteToAddress implements memberlist.NodeAwareTransport.
+func (t *Transport) WriteToAddress(b []byte, addr memberlist.Address) (time.Time, error) {
+	if len(b) > MaxPacketSize {
+		return time.Time{}, fmt.Errorf("packet too large (%d bytes)", len(b))
+	}
+
+	conn, err := t.acquire(addr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := conn.write(b); err != nil {
+		t.release(addr.Addr, conn)
+		return time.Time{}, err
+	}
+	return time.Now(), nil
+}
+
+// PacketCh implements memberlist.Transport.
+func (t *Transport) PacketCh() <-chan *memberlist.Packet {
+	return t.packetCh
+}
+
+// StreamCh implements memberlist.Transport.
+func (t *Transport) StreamCh() <-chan net.Conn {
+	return t.streamCh
+}
+
+// IngestPacket implements memberlist.IngestionAwareTransport when the wrapped
+// transport does.
+func (t *Transport) IngestPacket(conn net.Conn, addr net.Addr, now time.Time, shouldClose bool) error {
+	ingester, ok := t.NodeAwareTransport.(memberlist.IngestionAwareTransport)
+	if !ok {
+		return errors.New("wrapped transport does not support packet ingestion")
+	}
+	return ingester.IngestPacket(conn, addr, now, shouldClose)
+}
+
+// IngestStream implements memberlist.IngestionAwareTransport when the wrapped
+// transport does.
+func (t *Transport) IngestStream(conn net.Conn) error {
+	ingester, ok := t.NodeAwareTransport.(memberlist.IngestionAwareTransport)
+	if !ok {
+		return errors.New("wrapped transport does not support stream ingestion")
+	}
+	return ingester.IngestStream(conn)
+}
+
+// Shutdown implements memberlist.Transport.
+func (t *Transport) Shutdown() error {
+	t.mu.Lock()
+	if t.shutdown {
+		t.mu.Unlock()
+		return nil
+	}
+	t.shutdown = true
+	for addr, conn := range t.conns {
+		conn.Close()
+		delete(t.conns, addr)
+	}
+	t.mu.Unlock()
+
+	// The wrapped transport waits for its listeners, which may be blocked on
+	// the channels drained by forwardPackets and acceptStreams, so it must be
+	// shut down before them.
+	err := t.NodeAwareTransport.Shutdown()
+	close(t.shutdownCh)
+	t.wg.Wait()
+	return err
+}
+
+// acquire returns the connection used to send packets to addr, dialing it if
+// needed.
+func (t *Transport) acquire(addr memberlist.Address) (*packetConn, error) {
+	t.mu.Lock()
+	if t.shutdown {
+		t.mu.Unlock()
+		return nil, errors.New("transport is shut down")
+	}
+	if conn, ok := t.conns[addr.Addr]; ok {
+		t.mu.Unlock()
+		return conn, nil
+	}
+	t.mu.Unlock()
+
+	// Dial without holding the lock, so that a node that can't be reached
+	// does not delay the packets to the other nodes.
+	c, err := t.NodeAwareTransport.DialAddressTimeout(addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &packetConn{Conn: c, lastUsed: time.Now()}
+	if _, err := conn.Write([]byte{packetStreamMagic}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.shutdown {
+		conn.Close()
+		return nil, errors.New("transport is shut down")
+	}
+	if existing, ok := t.conns[addr.Addr]; ok {
+		// Another packet raced us to the same node.
+		conn.Close()
+		return existing, nil
+	}
+	t.conns[addr.Addr] = conn
+	return conn, nil
+}
+
+// release closes a failed connection, so that the next packet dials again.
+func (t *Transport) release(addr string, conn *packetConn) {
+	t.mu.Lock()
+	if t.conns[addr] == conn {
+		delete(t.conns, addr)
+	}
+	t.mu.Unlock()
+	conn.Close()
+}
+
+// reap closes the connections that have been idle for too long.
+func (t *Transport) reap() {
+	defer t.wg.Done()
+
+	ticker := time.NewTicker(connMaxIdleTime / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case now := <-ticker.C:
+			t.mu.Lock()
+			for addr, conn := range t.conns {
+				if now.Sub(conn.idleSince()) > connMaxIdleTime {
+					conn.Close()
+					delete(t.conns, addr)
+				}
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// forwardPackets forwards the packets received by the wrapped transport.
+func (t *Transport) forwardPackets() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case p := <-t.NodeAwareTransport.PacketCh():
+			select {
+			case t.packetCh <- p:
+			case <-t.shutdownCh:
+				return
+			}
+		}
+	}
+}
+
+// acceptStreams sorts the connections received by the wrapped transport into
+// packet connections and memberlist streams.
+func (t *Transport) acceptStreams() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.shutdownCh:
+			return
+		case conn := <-t.NodeAwareTransport.StreamCh():
+			t.wg.Add(1)
+			go t.handleConn(conn)
+		}
+	}
+}
+
+func (t *Transport) handleConn(conn net.Conn) {
+	defer t.wg.Done()
+
+	// Close the connection on shutdown to unblock the reads below.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-t.shutdownCh:
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	r := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	first, err := r.Peek(1)
+	if err != nil {
+		t.logger.Printf("[DEBUG] memberlist: Failed to read the first byte of the connection from %s: %v", memberlist.LogConn(conn), err)
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	if first[0] != packetStreamMagic {
+		select {
+		case t.streamCh <- &bufferedConn{Conn: conn, r: r}:
+		case <-t.shutdownCh:
+			conn.Close()
+		}
+		return
+	}
+
+	r.Discard(1)
+	t.readPackets(conn, r)
+}
+
+// readPackets reads the packets from a packet connection until it is closed.
+func (t *Transport) readPackets(conn net.Conn, r *bufio.Reader) {
+	defer conn.Close()
+
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				t.logger.Printf("[DEBUG] memberlist: Failed to read packet from %s: %v", memberlist.LogConn(conn), err)
+			}
+			return
+		}
+		if size == 0 || size > MaxPacketSize {
+			t.logger.Printf("[WARN] memberlist: Invalid packet size %d from %s", size, memberlist.LogConn(conn))
+			return
+		}
+
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.logger.Printf("[DEBUG] memberlist: Failed to read packet from %s: %v", memberlist.LogConn(conn), err)
+			return
+		}
+
+		select {
+		case t.packetCh <- &memberlist.Packet{Buf: buf, From: conn.RemoteAddr(), Timestamp: time.Now()}:
+		case <-t.shutdownCh:
+			return
+		}
+	}
+}
+
+// packetConn is a connection used to send packets to a node.
+type packetConn struct {
+	net.Conn
+
+	// mu serializes the writes, and protects lastUsed.
+	mu       sync.Mutex
+	lastUsed time.Time
+}
+
+func (c *packetConn) write(b []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastUsed = time.Now()
+	c.SetWriteDeadline(c.lastUsed.Add(dialTimeout))
+
+	buf := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	copy(buf[4:], b)
+	_, err := c.Write(buf)
+	return err
+}
+
+func (c *packetConn) idleSince() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastUsed
+}
+
+// bufferedConn is a connection whose first bytes were already read into r.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package tcptransport
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/sdk/testutil/retry"
+)
+
+func newTestConfig(t *testing.T, name string) *memberlist.Config {
+	conf := memberlist.DefaultLANConfig()
+	conf.Name = name
+	conf.BindAddr = "127.0.0.1"
+	conf.BindPort = 0
+	conf.Logger = log.New(io.Discard, "", 0)
+	return conf
+}
+
+func newTestTransport(t *testing.T, name string) (*Transport, *memberlist.Config) {
+	conf := newTestConfig(t, name)
+	tr, err := NewNetTransport(conf, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { tr.Shutdown() })
+	return tr, conf
+}
+
+func TestTransport_Packets(t *testing.T) {
+	t1, _ := newTestTransport(t, "node1")
+	t2, conf2 := newTestTransport(t, "node2")
+
+	addr := memberlist.Address{
+		Addr: net.JoinHostPort("127.0.0.1", fmt.Sprintf("%d", conf2.BindPort)),
+		Name: "node2",
+	}
+	for i := 0; i < 3; i++ {
+		_, err := t1.WriteToAddress([]byte(fmt.Sprintf("packet %d", i)), addr)
+		require.NoError(t, err)
+	}
+
+	// The packets are received in order, over a single connection.
+	for i := 0; i < 3; i++ {
+		select {
+		case p := <-t2.PacketCh():
+			require.Equal(t, fmt.Sprintf("packet %d", i), string(p.Buf))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for packet")
+		}
+	}
+	require.Len(t, t1.conns, 1)
+
+	// A failed connection is dialed again.
+	t1.conns[addr.Addr].Close()
+	_, err := t1.WriteToAddress([]byte("lost"), addr)
+	require.Error(t, err)
+	require.Empty(t, t1.conns)
+
+	_, err = t1.WriteToAddress([]byte("again"), addr)
+	require.NoError(t, err)
+	select {
+	case p := <-t2.PacketCh():
+		require.Equal(t, "again", string(p.Buf))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for packet")
+	}
+
+	_, err = t1.WriteToAddress(make([]byte, MaxPacketSize+1), addr)
+	require.Error(t, err)
+}
+
+func TestTransport_Streams(t *testing.T) {
+	t1, _ := newTestTransport(t, "node1")
+	t2, conf2 := newTestTransport(t, "node2")
+
+	addr := memberlist.Address{
+		Addr: net.JoinHostPort("127.0.0.1", fmt.Sprintf("%d", conf2.BindPort)),
+		Name: "node2",
+	}
+	conn, err := t1.DialAddressTimeout(addr, time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("stream"))
+	require.NoError(t, err)
+
+	// The stream is passed on with its first byte.
+	select {
+	case in := <-t2.StreamCh():
+		defer in.Close()
+		buf := make([]byte, len("stream"))
+		_, err := io.ReadFull(in, buf)
+		require.NoError(t, err)
+		require.Equal(t, "stream", string(buf))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for stream")
+	}
+}
+
+func TestTransport_Memberlist(t *testing.T) {
+	create := func(name string) *memberlist.Memberlist {
+		conf := newTestConfig(t, name)
+		conf.ProbeInterval = 100 * time.Millisecond
+		tr, err := NewNetTransport(conf, nil)
+		require.NoError(t, err)
+		conf.Transport = tr
+
+		require.Equal(t, MinProbeInterval, conf.ProbeInterval)
+		require.Equal(t, MinProbeTimeout, conf.ProbeTimeout)
+		require.True(t, conf.DisableTcpPings)
+
+		m, err := memberlist.Create(conf)
+		require.NoError(t, err)
+		t.Cleanup(func() { m.Shutdown() })
+		return m
+	}
+
+	m1 := create("node1")
+	m2 := create("node2")
+	m3 := create("node3")
+
+	for _, m := range []*memberlist.Memberlist{m2, m3} {
+		_, err := m.Join([]string{m1.LocalNode().Address()})
+		require.NoError(t, err)
+	}
+
+	retry.Run(t, func(r *retry.R) {
+		for _, m := range []*memberlist.Memberlist{m1, m2, m3} {
+			require.Equal(r, 3, m.NumMembers())
+		}
+	})
+
+	// The members stay healthy while probing each other over TCP.
+	time.Sleep(3 * MinProbeInterval)
+	for _, m := range []*memberlist.Memberlist{m1, m2, m3} {
+		require.Equal(t, 3, m.NumMembers())
+		require.Zero(t, m.GetHealthScore())
+	}
+}
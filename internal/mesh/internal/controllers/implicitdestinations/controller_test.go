@@ -5,7 +5,6 @@ package implicitdestinations
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"testing"
 
@@ -1277,7 +1276,7 @@ func TestController_DefaultDeny(t *testing.T) {
 	client := rtest.NewClient(clientRaw)
 
 	for _, tenancy := range resourcetest.TestTenancies() {
-		t.Run(tenancySubTestName(tenancy), func(t *testing.T) {
+		t.Run(controllertest.TenancySubTestName(tenancy), func(t *testing.T) {
 			tenancy := tenancy
 
 			// Add some workload identities and services.
@@ -1530,11 +1529,7 @@ func (suite *controllerSuite) runTestCaseWithTenancies(testFunc func(*pbresource
 }
 
 func (suite *controllerSuite) appendTenancyInfo(tenancy *pbresource.Tenancy) string {
-	return tenancySubTestName(tenancy)
-}
-
-func tenancySubTestName(tenancy *pbresource.Tenancy) string {
-	return fmt.Sprintf("%s_Namespace_%s_Partition", tenancy.Namespace, tenancy.Partition)
+	return controllertest.TenancySubTestName(tenancy)
 }
 
 func TestController(t *testing.T) {
@@ -448,13 +448,33 @@ func compileFailoverConfig(
 	}
 
 	cfc := &pbmesh.ComputedFailoverConfig{
-		Destinations:  make([]*pbmesh.ComputedFailoverDestination, 0, len(failoverConfig.Destinations)),
+		Destinations:  compileFailoverDestinations(related, failoverConfig.Destinations, targets, brc),
 		Mode:          failoverConfig.Mode,
 		Regions:       failoverConfig.Regions,
 		SamenessGroup: failoverConfig.SamenessGroup,
 	}
 
-	for _, dest := range failoverConfig.Destinations {
+	for _, tier := range failoverConfig.Tiers {
+		dests := compileFailoverDestinations(related, tier.Destinations, targets, brc)
+		if len(dests) == 0 {
+			continue // skip tiers without any usable destinations
+		}
+		cfc.Tiers = append(cfc.Tiers, &pbmesh.ComputedFailoverTier{
+			Name:         tier.Name,
+			Destinations: dests,
+		})
+	}
+	return cfc
+}
+
+func compileFailoverDestinations(
+	related *loader.RelatedResources,
+	dests []*pbcatalog.FailoverDestination,
+	targets map[string]*pbmesh.BackendTargetDetails,
+	brc *resource.BoundReferenceCollector,
+) []*pbmesh.ComputedFailoverDestination {
+	out := make([]*pbmesh.ComputedFailoverDestination, 0, len(dests))
+	for _, dest := range dests {
 		backendRef := &pbmesh.BackendReference{
 			Ref:        dest.Ref,
 			Port:       dest.Port,
@@ -488,11 +508,11 @@ func compileFailoverConfig(
 		}
 		backendTargetName = destTargetName
 
-		cfc.Destinations = append(cfc.Destinations, &pbmesh.ComputedFailoverDestination{
+		out = append(out, &pbmesh.ComputedFailoverDestination{
 			BackendTarget: backendTargetName,
 		})
 	}
-	return cfc
+	return out
 }
 
 func fillInDefaultDestConfig(target *pbmesh.DestinationConfig) *pbmesh.DestinationConfig {
@@ -306,7 +306,7 @@ func (b *Builder) buildDestination(
 			failover := details.FailoverConfig
 			// TODO(v2): handle other forms of failover (regions/locality/etc)
 
-			for i, dest := range failover.Destinations {
+			for i, dest := range failoverDestinations(failover) {
 				if dest.BackendTarget == types.NullRouteBackend {
 					continue // not possible
 				}
@@ -344,6 +344,35 @@ func (b *Builder) buildDestination(
 	return b
 }
 
+// failoverDestinations returns the destinations of the failover config in the
+// order they are tried. The endpoint groups of a failover group are tried in
+// order, so listing the destinations of each tier after the previous tier's
+// means every destination of a tier is tried before moving on to the next.
+// A destination is only ever tried once.
+func failoverDestinations(failover *pbmesh.ComputedFailoverConfig) []*pbmesh.ComputedFailoverDestination {
+	if len(failover.Tiers) == 0 {
+		return failover.Destinations
+	}
+
+	seen := make(map[string]struct{})
+	var out []*pbmesh.ComputedFailoverDestination
+	add := func(dests []*pbmesh.ComputedFailoverDestination) {
+		for _, dest := range dests {
+			if _, ok := seen[dest.BackendTarget]; ok {
+				continue
+			}
+			seen[dest.BackendTarget] = struct{}{}
+			out = append(out, dest)
+		}
+	}
+
+	add(failover.Destinations)
+	for _, tier := range failover.Tiers {
+		add(tier.Destinations)
+	}
+	return out
+}
+
 const NullRouteClusterName = "null_route_cluster"
 
 func (b *Builder) addNullRouteCluster() *Builder {
@@ -574,3 +574,33 @@ func TestBuildImplicitDestinations(t *testing.T) {
 		}
 	}, t)
 }
+
+func TestFailoverDestinations(t *testing.T) {
+	dest := func(target string) *pbmesh.ComputedFailoverDestination {
+		return &pbmesh.ComputedFailoverDestination{BackendTarget: target}
+	}
+
+	t.Run("destinations", func(t *testing.T) {
+		failover := &pbmesh.ComputedFailoverConfig{
+			Destinations: []*pbmesh.ComputedFailoverDestination{dest("a"), dest("b")},
+		}
+		require.Equal(t, failover.Destinations, failoverDestinations(failover))
+	})
+
+	t.Run("tiers", func(t *testing.T) {
+		failover := &pbmesh.ComputedFailoverConfig{
+			Tiers: []*pbmesh.ComputedFailoverTier{
+				{Name: "same-zone", Destinations: []*pbmesh.ComputedFailoverDestination{dest("zone")}},
+				{Name: "same-region", Destinations: []*pbmesh.ComputedFailoverDestination{dest("region-1"), dest("region-2")}},
+				// Destinations are only tried once.
+				{Name: "global", Destinations: []*pbmesh.ComputedFailoverDestination{dest("region-1"), dest("global")}},
+			},
+		}
+
+		var targets []string
+		for _, d := range failoverDestinations(failover) {
+			targets = append(targets, d.BackendTarget)
+		}
+		require.Equal(t, []string{"zone", "region-1", "region-2", "global"}, targets)
+	})
+}
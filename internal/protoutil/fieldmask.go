@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package protoutil
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ValidateFieldMask checks that every path in the mask refers to a field of
+// the given message type. A nil or empty mask is always valid.
+func ValidateFieldMask(mask *fieldmaskpb.FieldMask, m proto.Message) error {
+	for _, path := range mask.GetPaths() {
+		if !(&fieldmaskpb.FieldMask{Paths: []string{path}}).IsValid(m) {
+			return fmt.Errorf("invalid field mask path %q for %s", path, m.ProtoReflect().Descriptor().FullName())
+		}
+	}
+	return nil
+}
+
+// ApplyFieldMask clears every field of m which is not selected by the mask.
+// Paths follow the google.protobuf.FieldMask conventions: they are
+// dot-separated proto field names which may only descend into singular
+// message fields. A nil or empty mask selects all fields, leaving m untouched.
+func ApplyFieldMask(mask *fieldmaskpb.FieldMask, m proto.Message) error {
+	if len(mask.GetPaths()) == 0 {
+		return nil
+	}
+	if err := ValidateFieldMask(mask, m); err != nil {
+		return err
+	}
+	pruneMessage(newMaskTree(mask.GetPaths()), m.ProtoReflect())
+	return nil
+}
+
+// maskTree is the parsed form of a field mask. A node with no children
+// selects the whole field.
+type maskTree map[protoreflect.Name]maskTree
+
+func newMaskTree(paths []string) maskTree {
+	root := maskTree{}
+	for _, path := range paths {
+		node := root
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			name := protoreflect.Name(part)
+			child, ok := node[name]
+			if ok && len(child) == 0 {
+				// The field has already been selected in its entirety.
+				break
+			}
+			if !ok {
+				child = maskTree{}
+				node[name] = child
+			}
+			if i == len(parts)-1 {
+				// A shorter path selects everything beneath it.
+				for k := range child {
+					delete(child, k)
+				}
+			}
+			node = child
+		}
+	}
+	return root
+}
+
+func pruneMessage(tree maskTree, m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		child, ok := tree[fd.Name()]
+		switch {
+		case !ok:
+			m.Clear(fd)
+		case len(child) > 0:
+			pruneMessage(child, v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package protoutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+func TestApplyFieldMask(t *testing.T) {
+	newResource := func() *pbresource.Resource {
+		return &pbresource.Resource{
+			Id: &pbresource.ID{
+				Name: "web",
+				Uid:  "abc",
+				Type: &pbresource.Type{Group: "demo", GroupVersion: "v2", Kind: "Artist"},
+			},
+			Version: "1",
+			Status: map[string]*pbresource.Status{
+				"consul.io/controller": {ObservedGeneration: "1"},
+			},
+			Metadata: map[string]string{"foo": "bar"},
+		}
+	}
+
+	testCases := map[string]struct {
+		paths  []string
+		expect *pbresource.Resource
+	}{
+		"empty mask": {
+			paths:  nil,
+			expect: newResource(),
+		},
+		"top-level fields": {
+			paths: []string{"version", "metadata"},
+			expect: &pbresource.Resource{
+				Version:  "1",
+				Metadata: map[string]string{"foo": "bar"},
+			},
+		},
+		"nested fields": {
+			paths: []string{"id.name", "id.type.kind"},
+			expect: &pbresource.Resource{
+				Id: &pbresource.ID{
+					Name: "web",
+					Type: &pbresource.Type{Kind: "Artist"},
+				},
+			},
+		},
+		"shorter path wins": {
+			paths: []string{"id.name", "id", "id.uid"},
+			expect: &pbresource.Resource{
+				Id: newResource().Id,
+			},
+		},
+		"map field": {
+			paths: []string{"status"},
+			expect: &pbresource.Resource{
+				Status: newResource().Status,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res := newResource()
+			require.NoError(t, ApplyFieldMask(&fieldmaskpb.FieldMask{Paths: tc.paths}, res))
+			require.True(t, proto.Equal(tc.expect, res), "got %v", res)
+		})
+	}
+}
+
+func TestApplyFieldMask_Invalid(t *testing.T) {
+	for _, path := range []string{"nope", "id.nope", "status.observed_generation"} {
+		t.Run(path, func(t *testing.T) {
+			err := ApplyFieldMask(&fieldmaskpb.FieldMask{Paths: []string{path}}, &pbresource.Resource{})
+			require.ErrorContains(t, err, "invalid field mask path")
+		})
+	}
+}
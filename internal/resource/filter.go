@@ -93,6 +93,41 @@ func ValidateMetadataFilter(filter string) error {
 	return err
 }
 
+// MetadataFilter is a go-bexpr based filter over resource metadata which can be
+// evaluated against many resources without being re-parsed.
+type MetadataFilter struct {
+	eval *bexpr.Evaluator
+}
+
+// NewMetadataFilter parses the provided go-bexpr based filter.
+//
+// The only variables usable in the expressions are the metadata keys prefixed
+// by "metadata."
+//
+// If no filter is provided, then a nil filter which matches all resources is
+// returned.
+func NewMetadataFilter(filter string) (*MetadataFilter, error) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	eval, err := createMetadataFilterEvaluator(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &MetadataFilter{eval: eval}, nil
+}
+
+// Matches returns whether the provided resource's metadata matches the filter.
+func (f *MetadataFilter) Matches(res MetadataFilterableResources) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	return f.eval.Evaluate(&metadataFilterFieldDetails{
+		Meta: res.GetMetadata(),
+	})
+}
+
 func createMetadataFilterEvaluator(filter string) (*bexpr.Evaluator, error) {
 	sampleVars := &metadataFilterFieldDetails{
 		Meta: make(map[string]string),
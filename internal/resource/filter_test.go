@@ -193,3 +193,30 @@ func TestFilterMatchesResourceMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataFilter(t *testing.T) {
+	res := &pbresource.Resource{
+		Metadata: map[string]string{"team": "payments"},
+	}
+
+	// No filter matches everything.
+	filter, err := NewMetadataFilter("")
+	require.NoError(t, err)
+	require.Nil(t, filter)
+	match, err := filter.Matches(res)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	filter, err = NewMetadataFilter(`metadata.team == "payments"`)
+	require.NoError(t, err)
+	match, err = filter.Matches(res)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	match, err = filter.Matches(&pbresource.Resource{})
+	require.NoError(t, err)
+	require.False(t, match)
+
+	_, err = NewMetadataFilter(`metadata.team ==`)
+	testutil.RequireErrorContains(t, err, `filter "metadata.team ==" is invalid`)
+}
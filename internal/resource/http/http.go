@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -124,6 +125,8 @@ func (h *resourceHandler) handleWrite(w http.ResponseWriter, r *http.Request, ct
 			Metadata: req.Metadata,
 			Data:     anyProtoMsg,
 		},
+		FieldManager: params["fieldManager"],
+		Force:        params["force"] == "true",
 	})
 	if err != nil {
 		handleResponseError(err, w, h.logger)
@@ -151,6 +154,7 @@ func (h *resourceHandler) handleRead(w http.ResponseWriter, r *http.Request, ctx
 			Tenancy: tenancyInfo,
 			Name:    params["resourceName"],
 		},
+		ReadMask: readMaskFromParams(params),
 	})
 	if err != nil {
 		handleResponseError(err, w, h.logger)
@@ -208,6 +212,12 @@ func parseParams(r *http.Request) (tenancy *pbresource.Tenancy, params map[strin
 	params["resourceName"] = resourceName
 	params["version"] = query.Get("version")
 	params["namePrefix"] = query.Get("name_prefix")
+	params["fields"] = query.Get("fields")
+	params["filter"] = query.Get("filter")
+	params["fieldManager"] = query.Get("field_manager")
+	if _, ok := query["force"]; ok {
+		params["force"] = "true"
+	}
 	// coming from command line
 	params["consistent"] = query.Get("RequireConsistent")
 	// coming from http client
@@ -218,6 +228,16 @@ func parseParams(r *http.Request) (tenancy *pbresource.Tenancy, params map[strin
 	return tenancy, params
 }
 
+// readMaskFromParams builds the read mask from the comma-separated "fields"
+// query parameter (e.g. ?fields=id,status). It returns nil when no fields
+// were requested so the whole resource is returned.
+func readMaskFromParams(params map[string]string) *fieldmaskpb.FieldMask {
+	if params["fields"] == "" {
+		return nil
+	}
+	return &fieldmaskpb.FieldMask{Paths: strings.Split(params["fields"], ",")}
+}
+
 func jsonMarshal(res *pbresource.Resource) ([]byte, error) {
 	output, err := protojson.Marshal(res)
 	if err != nil {
@@ -229,7 +249,10 @@ func jsonMarshal(res *pbresource.Resource) ([]byte, error) {
 		return nil, err
 	}
 
-	delete(stuff["data"].(map[string]any), "@type")
+	// Data may have been omitted by a read mask.
+	if data, ok := stuff["data"].(map[string]any); ok {
+		delete(data, "@type")
+	}
 	return json.MarshalIndent(stuff, "", "  ")
 }
 
@@ -248,6 +271,9 @@ func handleResponseError(err error, w http.ResponseWriter, logger hclog.Logger)
 		case codes.Aborted:
 			w.WriteHeader(http.StatusConflict)
 			logger.Info("Received error from resource service: the request conflict with the current state of the target resource", "error", err)
+		case codes.FailedPrecondition:
+			w.WriteHeader(http.StatusConflict)
+			logger.Info("Received error from resource service: the request conflicts with fields owned by other field managers", "error", err)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 			logger.Error("Received error from resource service", "error", err)
@@ -285,6 +311,8 @@ func (h *listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Type:       h.reg.Type,
 		Tenancy:    tenancyInfo,
 		NamePrefix: params["namePrefix"],
+		ReadMask:   readMaskFromParams(params),
+		Filter:     params["filter"],
 	})
 	if err != nil {
 		handleResponseError(err, w, h.logger)
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -250,6 +251,42 @@ func TestResourceWriteHandler(t *testing.T) {
 		require.Equal(t, http.StatusConflict, rsp.Result().StatusCode)
 	})
 
+	t.Run("should reject an apply which conflicts with another field manager", func(t *testing.T) {
+		apply := func(manager, genre string, force bool) *httptest.ResponseRecorder {
+			url := fmt.Sprintf("/demo/v2/artist/keith-urban?partition=default&peer_name=local&namespace=default&field_manager=%s", manager)
+			if force {
+				url += "&force"
+			}
+			rsp := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", url, strings.NewReader(fmt.Sprintf(`
+				{
+					"data": {
+						"genre": %q
+					}
+				}
+			`, genre)))
+
+			req.Header.Add("x-consul-token", testACLTokenArtistWritePolicy)
+
+			handler.ServeHTTP(rsp, req)
+			return rsp
+		}
+
+		require.Equal(t, http.StatusOK, apply("gitops", "GENRE_COUNTRY", false).Result().StatusCode)
+
+		rsp := apply("controller", "GENRE_POP", false)
+		require.Equal(t, http.StatusConflict, rsp.Result().StatusCode)
+		require.Contains(t, rsp.Body.String(), `field "genre" is owned by field manager "gitops"`)
+
+		rsp = apply("controller", "GENRE_POP", true)
+		require.Equal(t, http.StatusOK, rsp.Result().StatusCode)
+
+		var result map[string]any
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&result))
+		require.Equal(t, "GENRE_POP", result["data"].(map[string]any)["genre"])
+		require.Equal(t, "Keith Urban Two", result["data"].(map[string]any)["name"])
+	})
+
 	t.Run("should write to the resource backend with owner", func(t *testing.T) {
 		rsp := httptest.NewRecorder()
 		req := httptest.NewRequest("PUT", "/demo/v1/artist/keith-urban-v1?partition=default&peer_name=local&namespace=default", strings.NewReader(`
@@ -387,6 +424,35 @@ func TestResourceReadHandler(t *testing.T) {
 		require.Equal(t, result, createdResource)
 	})
 
+	t.Run("Read resource with fields", func(t *testing.T) {
+		rsp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/demo/v2/artist/keith-urban?partition=default&peer_name=local&namespace=default&fields=id,version", nil)
+
+		req.Header.Add("x-consul-token", testACLTokenArtistReadPolicy)
+
+		handler.ServeHTTP(rsp, req)
+
+		require.Equal(t, http.StatusOK, rsp.Result().StatusCode)
+
+		var result map[string]any
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&result))
+		require.Equal(t, map[string]any{
+			"id":      createdResource["id"],
+			"version": createdResource["version"],
+		}, result)
+	})
+
+	t.Run("should be a bad request if fields are invalid", func(t *testing.T) {
+		rsp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/demo/v2/artist/keith-urban?partition=default&peer_name=local&namespace=default&fields=bogus", nil)
+
+		req.Header.Add("x-consul-token", testACLTokenArtistReadPolicy)
+
+		handler.ServeHTTP(rsp, req)
+
+		require.Equal(t, http.StatusBadRequest, rsp.Result().StatusCode)
+	})
+
 	t.Run("should not be found if resource not exist", func(t *testing.T) {
 		rsp := httptest.NewRecorder()
 		req := httptest.NewRequest("GET", "/demo/v2/artist/keith-not-exist?partition=default&peer_name=local&namespace=default&consistent", nil)
@@ -590,6 +656,42 @@ func TestResourceListHandler(t *testing.T) {
 		deleteResource(t, handler, nil)
 	})
 
+	t.Run("should return list of resources matching filter", func(t *testing.T) {
+		resourceUri := &ResourceUri{group: "demo", version: "v2", kind: "artist", resourceName: "steve"}
+		artist := createResource(t, handler, resourceUri)
+
+		list := func(filter string) *httptest.ResponseRecorder {
+			rsp := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/demo/v2/artist?partition=default&peer_name=local&namespace=default&filter="+url.QueryEscape(filter), strings.NewReader(""))
+
+			req.Header.Add("x-consul-token", testACLTokenArtistListPolicy)
+
+			handler.ServeHTTP(rsp, req)
+			return rsp
+		}
+
+		rsp := list(`metadata.foo == "bar"`)
+		require.Equal(t, http.StatusOK, rsp.Result().StatusCode)
+
+		var result map[string]any
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&result))
+		resources, _ := result["resources"].([]any)
+		require.Len(t, resources, 1)
+		require.Equal(t, artist, resources[0])
+
+		rsp = list(`metadata.foo == "baz"`)
+		require.Equal(t, http.StatusOK, rsp.Result().StatusCode)
+		require.NoError(t, json.NewDecoder(rsp.Body).Decode(&result))
+		resources, _ = result["resources"].([]any)
+		require.Empty(t, resources)
+
+		rsp = list(`metadata.foo ==`)
+		require.Equal(t, http.StatusBadRequest, rsp.Result().StatusCode)
+
+		// clean up
+		deleteResource(t, handler, resourceUri)
+	})
+
 	t.Run("should return list of resources matching name prefix", func(t *testing.T) {
 		resourceUri1 := &ResourceUri{group: "demo", version: "v2", kind: "artist", resourceName: "steve"}
 		resource1 := createResource(t, handler, resourceUri1)
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/consul/proto-public/pbresource"
+)
+
+// ManagedFieldsKey is the key in a resource's metadata that stores the JSON encoded
+// ManagedFields of the resource. It is maintained by the resource service when
+// resources are written with a field manager and must not be set by users.
+const ManagedFieldsKey = "managedFields"
+
+// ManagedFields maps the name of each field manager to the paths of the fields
+// in the resource's data that it owns. Paths are made of the dot separated proto
+// field names (e.g. "config.destinations").
+type ManagedFields map[string][]string
+
+// GetManagedFields returns the fields owned by each field manager of the given
+// resource.
+func GetManagedFields(res *pbresource.Resource) (ManagedFields, error) {
+	encoded, ok := res.GetMetadata()[ManagedFieldsKey]
+	if !ok {
+		return ManagedFields{}, nil
+	}
+
+	managed := make(ManagedFields)
+	if err := json.Unmarshal([]byte(encoded), &managed); err != nil {
+		return nil, fmt.Errorf("failed to decode resource.metadata.%s: %w", ManagedFieldsKey, err)
+	}
+	return managed, nil
+}
+
+// SetManagedFields stores the fields owned by each field manager on the given
+// resource. Field managers which no longer own any fields are dropped, and the
+// metadata key is removed entirely when no fields are owned.
+func SetManagedFields(res *pbresource.Resource, managed ManagedFields) error {
+	normalized := make(ManagedFields, len(managed))
+	for manager, paths := range managed {
+		if len(paths) == 0 {
+			continue
+		}
+		paths = append([]string(nil), paths...)
+		sort.Strings(paths)
+		normalized[manager] = paths
+	}
+
+	if len(normalized) == 0 {
+		// Remove key if no fields are owned to prevent dual representations of
+		// the same state.
+		delete(res.Metadata, ManagedFieldsKey)
+		return nil
+	}
+
+	// Map keys are sorted by encoding/json so the encoding is deterministic.
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("failed to encode resource.metadata.%s: %w", ManagedFieldsKey, err)
+	}
+	if res.Metadata == nil {
+		res.Metadata = map[string]string{}
+	}
+	res.Metadata[ManagedFieldsKey] = string(encoded)
+	return nil
+}
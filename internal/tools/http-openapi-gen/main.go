@@ -0,0 +1,975 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Command http-openapi-gen generates the description of the operations of the
+// agent HTTP API, from which the agent builds the OpenAPI document it serves at
+// /v1/openapi.json.
+//
+// It parses the endpoints registered in agent/http_register.go, and follows the
+// methods and functions their handlers call to find, for each HTTP method:
+//
+//   - the query parameters read from the request URL,
+//   - the name of the parameter taken from the path of the prefix endpoints,
+//   - the value the request body is decoded into,
+//   - the value returned as the response.
+//
+// The request and response values are written as Go expressions, so that the
+// compiler resolves their types and the agent describes them with reflection.
+//
+// Usage:
+//
+//	go run ./internal/tools/http-openapi-gen -agent ./agent
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	flagAgent = flag.String("agent", "./agent", "path of the agent package")
+)
+
+// outputFile is the name of the file generated in the agent package.
+const outputFile = "http_openapi_gen.go"
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	out, err := generate(*flagAgent)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*flagAgent, outputFile), out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the content of outputFile for the agent package in dir.
+func generate(dir string) ([]byte, error) {
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := pkg.registeredEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make(map[string]string)
+	var body bytes.Buffer
+	ids := make(map[string]int)
+	for _, ep := range endpoints {
+		handler, ok := pkg.methods[ep.handler]
+		if !ok {
+			return nil, fmt.Errorf("handler %s of %s not found", ep.handler, ep.pattern)
+		}
+
+		fmt.Fprintf(&body, "%q: {\n", ep.pattern)
+		if strings.HasSuffix(ep.pattern, "/") {
+			fmt.Fprintf(&body, "PathParam: %q,\n", pkg.pathParam(handler, ep.pattern))
+		}
+		fmt.Fprintf(&body, "Operations: map[string]openAPIOperation{\n")
+		for _, method := range ep.methods {
+			op := pkg.operation(handler, method)
+
+			id := ep.handler
+			if len(ep.methods) > 1 {
+				id += strings.Title(strings.ToLower(method))
+			}
+			if n := ids[id]; n > 0 {
+				id += strconv.Itoa(n + 1)
+			}
+			ids[id]++
+
+			fmt.Fprintf(&body, "%q: {\n", method)
+			fmt.Fprintf(&body, "ID: %q,\n", id)
+			if len(op.queryParams) > 0 {
+				fmt.Fprintf(&body, "QueryParams: []string{")
+				for i, p := range op.queryParams {
+					if i > 0 {
+						body.WriteString(", ")
+					}
+					fmt.Fprintf(&body, "%q", p)
+				}
+				body.WriteString("},\n")
+			}
+			if op.request != nil {
+				fmt.Fprintf(&body, "Request: func() reflect.Type { return openAPITypeOf(%s) },\n", op.request.expr)
+				addImports(imports, op.request.imports)
+			}
+			if op.response != nil {
+				fmt.Fprintf(&body, "Response: func() reflect.Type { return openAPITypeOf(%s) },\n", op.response.expr)
+				addImports(imports, op.response.imports)
+			}
+			body.WriteString("},\n")
+		}
+		body.WriteString("},\n},\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Copyright (c) HashiCorp, Inc.\n// SPDX-License-Identifier: BUSL-1.1\n\n")
+	out.WriteString("// Code generated by http-openapi-gen. DO NOT EDIT.\n\n")
+	out.WriteString("package agent\n\n")
+	out.WriteString("import (\n\"reflect\"\n\n")
+	paths := make([]string, 0, len(imports))
+	for _, path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		name := ""
+		for n, p := range imports {
+			if p == path {
+				name = n
+			}
+		}
+		if name == filepath.Base(path) {
+			fmt.Fprintf(&out, "%q\n", path)
+		} else {
+			fmt.Fprintf(&out, "%s %q\n", name, path)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("// openAPIEndpoints describes the operations of the endpoints registered in\n")
+	out.WriteString("// http_register.go, keyed by their pattern.\n")
+	out.WriteString("var openAPIEndpoints = map[string]openAPIEndpoint{\n")
+	out.Write(body.Bytes())
+	out.WriteString("}\n")
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w\n%s", err, out.String())
+	}
+	return formatted, nil
+}
+
+func addImports(dst, src map[string]string) {
+	for name, path := range src {
+		dst[name] = path
+	}
+}
+
+// pkg is the parsed agent package.
+type pkg struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+
+	// funcs are the package level functions by name.
+	funcs map[string]*function
+
+	// methods are the methods of HTTPHandlers by name.
+	methods map[string]*function
+}
+
+type function struct {
+	decl *ast.FuncDecl
+
+	// imports maps the names of the packages imported by the file of the
+	// function to their path.
+	imports map[string]string
+}
+
+func parsePackage(dir string) (*pkg, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{
+		dir:     dir,
+		fset:    token.NewFileSet(),
+		funcs:   make(map[string]*function),
+		methods: make(map[string]*function),
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outputFile {
+			continue
+		}
+		// Describe the API of the default build.
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(p.fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		p.files = append(p.files, file)
+
+		imports := make(map[string]string)
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = path
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			f := &function{decl: fn, imports: imports}
+			if fn.Recv == nil {
+				p.funcs[fn.Name.Name] = f
+			} else if receiverType(fn) == "HTTPHandlers" {
+				p.methods[fn.Name.Name] = f
+			}
+		}
+	}
+	return p, nil
+}
+
+func receiverType(fn *ast.FuncDecl) string {
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+type endpoint struct {
+	pattern string
+	methods []string
+	handler string
+}
+
+// registeredEndpoints returns the endpoints registered with registerEndpoint,
+// sorted by pattern. The methods of the endpoints registered without methods
+// are found in their handler.
+func (p *pkg) registeredEndpoints() ([]endpoint, error) {
+	var endpoints []endpoint
+	var err error
+	for _, file := range p.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || err != nil {
+				return err == nil
+			}
+			if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "registerEndpoint" || len(call.Args) != 3 {
+				return true
+			}
+
+			var ep endpoint
+			ep.pattern, ok = stringLit(call.Args[0])
+			if !ok {
+				err = fmt.Errorf("%s: the pattern of registerEndpoint must be a string literal", p.fset.Position(call.Pos()))
+				return false
+			}
+			methods, ok := call.Args[1].(*ast.CompositeLit)
+			if !ok {
+				err = fmt.Errorf("%s: the methods of registerEndpoint must be a literal", p.fset.Position(call.Pos()))
+				return false
+			}
+			for _, elt := range methods.Elts {
+				method, ok := stringLit(elt)
+				if !ok {
+					err = fmt.Errorf("%s: the methods of registerEndpoint must be string literals", p.fset.Position(call.Pos()))
+					return false
+				}
+				ep.methods = append(ep.methods, method)
+			}
+			handler, ok := call.Args[2].(*ast.SelectorExpr)
+			if !ok {
+				err = fmt.Errorf("%s: the handler of registerEndpoint must be a method expression", p.fset.Position(call.Pos()))
+				return false
+			}
+			ep.handler = handler.Sel.Name
+			// The endpoints registered without methods check them in the
+			// handler.
+			if fn, ok := p.methods[ep.handler]; ok && len(ep.methods) == 0 {
+				ep.methods = p.handlerMethods(fn)
+			}
+			endpoints = append(endpoints, ep)
+			return true
+		})
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].pattern < endpoints[j].pattern
+	})
+	return endpoints, err
+}
+
+// operation is what a handler does for an HTTP method.
+type operation struct {
+	queryParams []string
+	request     *value
+	response    *value
+}
+
+// value is a Go expression of the type of a value.
+type value struct {
+	expr string
+	// imports maps the package names used by expr to their path.
+	imports map[string]string
+}
+
+// operation returns the operation of the handler for the HTTP method.
+func (p *pkg) operation(handler *function, method string) operation {
+	var op operation
+	params := make(map[string]bool)
+
+	// Visit the functions reachable from the handler for the method, in
+	// breadth first order so that the request body decoded the closest to the
+	// handler wins.
+	visited := map[*function]bool{handler: true}
+	queue := []*function{handler}
+	for len(queue) > 0 {
+		fn := queue[0]
+		queue = queue[1:]
+
+		for _, param := range p.queryParams(fn, method) {
+			params[param] = true
+		}
+		if op.request == nil && method != "GET" {
+			op.request = p.requestBody(fn, method)
+		}
+		for _, callee := range p.callees(fn, method) {
+			if !visited[callee] {
+				visited[callee] = true
+				queue = append(queue, callee)
+			}
+		}
+	}
+
+	for param := range params {
+		op.queryParams = append(op.queryParams, param)
+	}
+	sort.Strings(op.queryParams)
+	op.response = p.result(handler, method, 0)
+	return op
+}
+
+// httpMethods maps the constants of net/http to the methods.
+var httpMethods = map[string]string{
+	"MethodGet":    "GET",
+	"MethodHead":   "HEAD",
+	"MethodPost":   "POST",
+	"MethodPut":    "PUT",
+	"MethodPatch":  "PATCH",
+	"MethodDelete": "DELETE",
+}
+
+// methodLit returns the HTTP method of a literal or a net/http constant.
+func methodLit(expr ast.Expr) (string, bool) {
+	if s, ok := stringLit(expr); ok {
+		return s, true
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "http" {
+			m, ok := httpMethods[sel.Sel.Name]
+			return m, ok
+		}
+	}
+	return "", false
+}
+
+// handlerMethods returns the HTTP methods the handler compares the method of
+// the request with, in the order of httpMethods.
+func (p *pkg) handlerMethods(handler *function) []string {
+	found := make(map[string]bool)
+	ast.Inspect(handler.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SwitchStmt:
+			if n.Tag != nil && isMethodExpr(n.Tag) {
+				for _, stmt := range n.Body.List {
+					for _, expr := range stmt.(*ast.CaseClause).List {
+						if m, ok := methodLit(expr); ok {
+							found[m] = true
+						}
+					}
+				}
+			}
+		case *ast.BinaryExpr:
+			if (n.Op == token.EQL || n.Op == token.NEQ) && isMethodExpr(n.X) {
+				if m, ok := methodLit(n.Y); ok {
+					found[m] = true
+				}
+			}
+		}
+		return true
+	})
+
+	var methods []string
+	for _, m := range []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"} {
+		if found[m] {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// isMethodExpr returns whether expr is the method of a request, as in
+// req.Method.
+func isMethodExpr(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Method"
+}
+
+// inspect walks the body of the function like ast.Inspect, skipping the
+// branches of the switch and if statements on the request method that don't
+// apply to the method, and the bodies of function literals.
+func inspect(fn *function, method string, visit func(ast.Node) bool) {
+	var walk func(ast.Node) bool
+	// walkStmts walks the statements of a block until a guard on the request
+	// method returning for the method, as in:
+	//
+	//	if req.Method != "GET" {
+	//		return nil, MethodNotAllowedError{...}
+	//	}
+	walkStmts := func(stmts []ast.Stmt) {
+		for _, stmt := range stmts {
+			ast.Inspect(stmt, walk)
+			if ifStmt, ok := stmt.(*ast.IfStmt); ok {
+				if branch, ok := methodBranch(ifStmt, method); ok && returns(branch) {
+					return
+				}
+			}
+		}
+	}
+	walk = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+
+		case *ast.SwitchStmt:
+			if n.Tag == nil || !isMethodExpr(n.Tag) {
+				return visit(n)
+			}
+			var matched, fallback *ast.CaseClause
+			for _, stmt := range n.Body.List {
+				clause := stmt.(*ast.CaseClause)
+				if clause.List == nil {
+					fallback = clause
+				}
+				for _, expr := range clause.List {
+					if m, ok := methodLit(expr); ok && m == method {
+						matched = clause
+					}
+				}
+			}
+			if matched == nil {
+				matched = fallback
+			}
+			if matched != nil {
+				for _, stmt := range matched.Body {
+					ast.Inspect(stmt, walk)
+				}
+			}
+			return false
+
+		case *ast.BlockStmt:
+			if !visit(n) {
+				return false
+			}
+			walkStmts(n.List)
+			return false
+
+		case *ast.CaseClause:
+			if !visit(n) {
+				return false
+			}
+			for _, expr := range n.List {
+				ast.Inspect(expr, walk)
+			}
+			walkStmts(n.Body)
+			return false
+
+		case *ast.IfStmt:
+			branch, ok := methodBranch(n, method)
+			if !ok {
+				return visit(n)
+			}
+			if n.Init != nil {
+				ast.Inspect(n.Init, walk)
+			}
+			if branch != nil {
+				ast.Inspect(branch, walk)
+			}
+			return false
+		}
+		if n == nil {
+			return false
+		}
+		return visit(n)
+	}
+	ast.Inspect(fn.decl.Body, walk)
+}
+
+// methodBranch returns the branch of an if statement on the request method
+// taken for the method, which is nil if there is none, and false if the
+// condition is not on the request method.
+func methodBranch(n *ast.IfStmt, method string) (ast.Stmt, bool) {
+	cond, ok := n.Cond.(*ast.BinaryExpr)
+	if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) || !isMethodExpr(cond.X) {
+		return nil, false
+	}
+	m, ok := methodLit(cond.Y)
+	if !ok {
+		return nil, false
+	}
+	if (m == method) == (cond.Op == token.EQL) {
+		return n.Body, true
+	}
+	return n.Else, true
+}
+
+// returns returns whether the statement ends with a return statement.
+func returns(stmt ast.Stmt) bool {
+	block, ok := stmt.(*ast.BlockStmt)
+	if !ok || len(block.List) == 0 {
+		return false
+	}
+	_, ok = block.List[len(block.List)-1].(*ast.ReturnStmt)
+	return ok
+}
+
+// receiverName returns the name of the receiver of a method, or "".
+func receiverName(fn *function) string {
+	if fn.decl.Recv == nil || len(fn.decl.Recv.List[0].Names) == 0 {
+		return ""
+	}
+	return fn.decl.Recv.List[0].Names[0].Name
+}
+
+// callees returns the HTTPHandlers methods and the package functions
+// referenced by the function for the method.
+func (p *pkg) callees(fn *function, method string) []*function {
+	recv := receiverName(fn)
+	var callees []*function
+	inspect(fn, method, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && recv != "" && x.Name == recv {
+				if callee, ok := p.methods[n.Sel.Name]; ok {
+					callees = append(callees, callee)
+				}
+				return false
+			}
+			// Only the left side of a selector can be a package function.
+			ast.Inspect(n.X, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok {
+					if callee, ok := p.funcs[ident.Name]; ok {
+						callees = append(callees, callee)
+					}
+				}
+				return true
+			})
+			return false
+		case *ast.Ident:
+			if callee, ok := p.funcs[n.Name]; ok {
+				callees = append(callees, callee)
+			}
+		}
+		return true
+	})
+	return callees
+}
+
+// isQueryCall returns whether expr is the query of a request URL, as in
+// req.URL.Query().
+func isQueryCall(expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Query" {
+		return false
+	}
+	url, ok := sel.X.(*ast.SelectorExpr)
+	return ok && url.Sel.Name == "URL"
+}
+
+// queryParams returns the query parameters read by the function for the
+// method, from req.URL.Query() or a url.Values assigned from it or passed as
+// an argument.
+func (p *pkg) queryParams(fn *function, method string) []string {
+	values := make(map[string]bool)
+	for _, field := range fn.decl.Type.Params.List {
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Values" {
+			for _, name := range field.Names {
+				values[name.Name] = true
+			}
+		}
+	}
+	ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 && isQueryCall(assign.Rhs[0]) {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				values[ident.Name] = true
+			}
+		}
+		return true
+	})
+	isValues := func(expr ast.Expr) bool {
+		if ident, ok := expr.(*ast.Ident); ok {
+			return values[ident.Name]
+		}
+		return isQueryCall(expr)
+	}
+
+	var params []string
+	inspect(fn, method, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IndexExpr:
+			if s, ok := stringLit(n.Index); ok && isValues(n.X) {
+				params = append(params, s)
+			}
+		case *ast.CallExpr:
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && isValues(sel.X) {
+				if len(n.Args) > 0 {
+					if s, ok := stringLit(n.Args[0]); ok {
+						params = append(params, s)
+					}
+				}
+				return true
+			}
+			// A helper reading the parameters named by its other arguments,
+			// as in getBoolQueryParam(params, "passing").
+			passed := false
+			for _, arg := range n.Args {
+				passed = passed || isValues(arg)
+			}
+			if passed {
+				for _, arg := range n.Args {
+					if s, ok := stringLit(arg); ok {
+						params = append(params, s)
+					}
+				}
+			}
+		}
+		return true
+	})
+	return params
+}
+
+// pathParam returns the name of the parameter a prefix endpoint takes from the
+// rest of the path, from the variable assigned with
+// strings.TrimPrefix(req.URL.Path, pattern).
+func (p *pkg) pathParam(handler *function, pattern string) string {
+	name := ""
+	visited := map[*function]bool{handler: true}
+	queue := []*function{handler}
+	for len(queue) > 0 && name == "" {
+		fn := queue[0]
+		queue = queue[1:]
+
+		ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || name != "" || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				return name == ""
+			}
+			call, ok := assign.Rhs[0].(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "TrimPrefix" {
+				return true
+			}
+			if prefix, ok := stringLit(call.Args[1]); !ok || prefix != pattern {
+				return true
+			}
+			switch lhs := assign.Lhs[0].(type) {
+			case *ast.Ident:
+				name = lhs.Name
+			case *ast.SelectorExpr:
+				name = lhs.Sel.Name
+			}
+			return false
+		})
+
+		for _, method := range []string{"GET", "PUT", "POST", "DELETE"} {
+			for _, callee := range p.callees(fn, method) {
+				if !visited[callee] {
+					visited[callee] = true
+					queue = append(queue, callee)
+				}
+			}
+		}
+	}
+	if name == "" || name == "_" {
+		return "path"
+	}
+	// Lower the first letter of the fields, as in args.Key.
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// requestBody returns the value the function decodes the request body into.
+func (p *pkg) requestBody(fn *function, method string) *value {
+	var body *value
+	inspect(fn, method, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || body != nil {
+			return body == nil
+		}
+		var arg ast.Expr
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			// decodeBody(req.Body, &args) and decodeBodyDeprecated(req, &args, cb)
+			if (fun.Name == "decodeBody" || fun.Name == "decodeBodyDeprecated") && len(call.Args) >= 2 {
+				arg = call.Args[1]
+			}
+		case *ast.SelectorExpr:
+			if x, ok := fun.X.(*ast.Ident); ok && x.Name == "lib" && fun.Sel.Name == "DecodeJSON" && len(call.Args) == 2 {
+				// lib.DecodeJSON(req.Body, &args)
+				arg = call.Args[1]
+			} else if decoder, ok := fun.X.(*ast.CallExpr); ok && fun.Sel.Name == "Decode" && len(call.Args) == 1 {
+				// json.NewDecoder(req.Body).Decode(&args)
+				if sel, ok := decoder.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "NewDecoder" {
+					arg = call.Args[0]
+				}
+			}
+		}
+		if arg != nil {
+			body = p.resolve(fn, method, arg, 0)
+		}
+		return true
+	})
+	return body
+}
+
+// maxDepth bounds the calls followed to resolve the result of a function.
+const maxDepth = 8
+
+// result returns the value of the first result the function returns for the
+// method, preferring the last return statement since it is usually the
+// success path.
+func (p *pkg) result(fn *function, method string, depth int) *value {
+	if depth > maxDepth {
+		return nil
+	}
+	var returns []*ast.ReturnStmt
+	inspect(fn, method, func(n ast.Node) bool {
+		if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) > 0 {
+			returns = append(returns, ret)
+		}
+		return true
+	})
+	for i := len(returns) - 1; i >= 0; i-- {
+		if v := p.resolve(fn, method, returns[i].Results[0], depth); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// resolve returns the value of the expression evaluated in the function, or
+// nil if its type cannot be determined.
+func (p *pkg) resolve(fn *function, method string, expr ast.Expr, depth int) *value {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+			continue
+		case *ast.UnaryExpr:
+			if e.Op == token.AND {
+				expr = e.X
+				continue
+			}
+		case *ast.StarExpr:
+			expr = e.X
+			continue
+		}
+		break
+	}
+
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return p.typeValue(fn, e.Type, nil)
+		}
+
+	case *ast.Ident, *ast.SelectorExpr:
+		root, path := selectorPath(e)
+		if root == nil || root.Name == "nil" || root.Name == receiverName(fn) {
+			return nil
+		}
+		if typ := localType(fn, root.Name, root.Pos()); typ != nil {
+			return p.typeValue(fn, typ, path)
+		}
+
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return p.typeValue(fn, e.Args[0], nil)
+		}
+		if callee := p.callee(fn, method, e.Fun); callee != nil {
+			return p.result(callee, method, depth+1)
+		}
+	}
+	return nil
+}
+
+// callee returns the HTTPHandlers method or package function called by fun,
+// which may be a variable assigned with one of them.
+func (p *pkg) callee(fn *function, method string, fun ast.Expr) *function {
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := f.X.(*ast.Ident); ok && x.Name == receiverName(fn) {
+			return p.methods[f.Sel.Name]
+		}
+	case *ast.Ident:
+		if callee, ok := p.funcs[f.Name]; ok {
+			return callee
+		}
+		// A variable assigned for the method, as in fn = s.ACLPolicyReadByID.
+		var callee *function
+		inspect(fn, method, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				return true
+			}
+			if lhs, ok := assign.Lhs[0].(*ast.Ident); ok && lhs.Name == f.Name {
+				if c := p.callee(fn, method, assign.Rhs[0]); c != nil {
+					callee = c
+				}
+			}
+			return true
+		})
+		return callee
+	}
+	return nil
+}
+
+// selectorPath splits a chain of selectors, as in out.Nodes, into its root
+// identifier and the selected names.
+func selectorPath(expr ast.Expr) (*ast.Ident, []string) {
+	var path []string
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e, path
+		case *ast.SelectorExpr:
+			path = append([]string{e.Sel.Name}, path...)
+			expr = e.X
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// localType returns the declared type of a parameter or variable of the
+// function used at pos, or nil. The last declaration before pos wins, for the
+// variables declared again in the branches of the function.
+func localType(fn *function, name string, pos token.Pos) ast.Expr {
+	for _, field := range fn.decl.Type.Params.List {
+		for _, n := range field.Names {
+			if n.Name == name {
+				return field.Type
+			}
+		}
+	}
+
+	var typ ast.Expr
+	ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+		if n == nil || n.Pos() >= pos {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for _, n2 := range n.Names {
+				if n2.Name == name && n.Type != nil {
+					typ = n.Type
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				return true
+			}
+			if lhs, ok := n.Lhs[0].(*ast.Ident); !ok || lhs.Name != name {
+				return true
+			}
+			rhs := n.Rhs[0]
+			if u, ok := rhs.(*ast.UnaryExpr); ok && u.Op == token.AND {
+				rhs = u.X
+			}
+			switch r := rhs.(type) {
+			case *ast.CompositeLit:
+				typ = r.Type
+			case *ast.CallExpr:
+				if ident, ok := r.Fun.(*ast.Ident); ok && ident.Name == "new" && len(r.Args) == 1 {
+					typ = r.Args[0]
+				}
+			}
+		}
+		return true
+	})
+	return typ
+}
+
+// typeValue returns the value of the zero value of the type with the selected
+// fields.
+func (p *pkg) typeValue(fn *function, typ ast.Expr, path []string) *value {
+	// Selecting the fields dereferences the pointers anyway, and describing a
+	// pointer is the same as describing its element.
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	v := &value{imports: make(map[string]string)}
+	ok := true
+	ast.Inspect(typ, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			pkg, isIdent := n.X.(*ast.Ident)
+			if !isIdent {
+				ok = false
+				return false
+			}
+			path, found := fn.imports[pkg.Name]
+			if !found {
+				ok = false
+				return false
+			}
+			v.imports[pkg.Name] = path
+			return false
+		case *ast.Ellipsis, *ast.FuncType, *ast.ChanType:
+			ok = false
+			return false
+		}
+		return true
+	})
+	if !ok {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, p.fset, typ); err != nil {
+		return nil
+	}
+	v.expr = "*new(" + buf.String() + ")"
+	if len(path) > 0 {
+		v.expr = "(" + v.expr + ")." + strings.Join(path, ".")
+	}
+	return v
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agentDir = "../../../agent"
+
+// TestGenerate fails when the generated descriptions of the endpoints are out
+// of date, run go generate ./agent to update them.
+func TestGenerate(t *testing.T) {
+	expected, err := generate(agentDir)
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(filepath.Join(agentDir, outputFile))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "%s is out of date, run go generate ./agent", outputFile)
+}
+
+// TestAPIClientPaths checks that the requests made by the api package are for
+// the endpoints registered by the agent, and so described by its OpenAPI
+// document.
+func TestAPIClientPaths(t *testing.T) {
+	pkg, err := parsePackage(agentDir)
+	require.NoError(t, err)
+	endpoints, err := pkg.registeredEndpoints()
+	require.NoError(t, err)
+
+	requests := apiRequests(t, "../../../api")
+	require.NotEmpty(t, requests)
+
+	for _, r := range requests {
+		if isUnregisteredAPIPath(r.path) {
+			continue
+		}
+
+		var found bool
+		for _, ep := range endpoints {
+			if r.path != ep.pattern && !(strings.HasSuffix(ep.pattern, "/") && strings.HasPrefix(r.path, ep.pattern)) {
+				continue
+			}
+			for _, method := range ep.methods {
+				found = found || method == r.method
+			}
+		}
+		assert.True(t, found, "%s: %s %s is not a registered endpoint", r.pos, r.method, r.path)
+	}
+}
+
+// unregisteredAPIPaths are the prefixes of the paths requested by the api
+// package that are not registered with registerEndpoint, and why.
+var unregisteredAPIPaths = map[string]string{
+	"/v1/acl/create":       "legacy ACL endpoint removed from the agent",
+	"/v1/acl/update":       "legacy ACL endpoint removed from the agent",
+	"/v1/acl/destroy/":     "legacy ACL endpoint removed from the agent",
+	"/v1/acl/clone/":       "legacy ACL endpoint removed from the agent",
+	"/v1/acl/info/":        "legacy ACL endpoint removed from the agent",
+	"/v1/acl/list":         "legacy ACL endpoint removed from the agent",
+	"/v1/acl/oidc/":        "Enterprise endpoint",
+	"/v1/namespace":        "Enterprise endpoint",
+	"/v1/partition":        "Enterprise endpoint",
+	"/v1/operator/area":    "Enterprise endpoint",
+	"/v1/operator/audit-":  "Enterprise endpoint",
+	"/v1/operator/license": "Enterprise endpoint",
+	"/debug/pprof/":        "served by the pprof handlers when enable_debug is set",
+}
+
+func isUnregisteredAPIPath(path string) bool {
+	for prefix := range unregisteredAPIPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type apiRequest struct {
+	pos    string
+	method string
+	path   string
+}
+
+// apiRequests returns the requests made with newRequest by the package in dir
+// whose method and path are known statically. The arguments of the formatted
+// paths are replaced by a placeholder.
+func apiRequests(t *testing.T, dir string) []apiRequest {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+
+	var requests []apiRequest
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "newRequest" {
+				return true
+			}
+			method, ok := stringLit(call.Args[0])
+			if !ok {
+				return true
+			}
+			path, ok := pathPrefix(call.Args[1])
+			if !ok {
+				return true
+			}
+			requests = append(requests, apiRequest{
+				pos:    fset.Position(call.Pos()).String(),
+				method: method,
+				path:   path,
+			})
+			return true
+		})
+	}
+	return requests
+}
+
+// pathPrefix returns the known part of a path built from a literal, a
+// concatenation starting with a literal, or fmt.Sprint and fmt.Sprintf.
+func pathPrefix(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return stringLit(e)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		prefix, ok := pathPrefix(e.X)
+		if !ok {
+			return "", false
+		}
+		// The rest of the path is unknown.
+		return prefix + "x", true
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || len(e.Args) == 0 {
+			return "", false
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "fmt" {
+			return "", false
+		}
+		format, ok := stringLit(e.Args[0])
+		if !ok {
+			return "", false
+		}
+		switch sel.Sel.Name {
+		case "Sprint":
+			if len(e.Args) > 1 {
+				format += "x"
+			}
+			return format, true
+		case "Sprintf":
+			return strings.NewReplacer("%s", "x", "%d", "1", "%v", "x").Replace(format), true
+		}
+	}
+	return "", false
+}
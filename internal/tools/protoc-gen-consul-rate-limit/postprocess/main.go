@@ -142,6 +142,16 @@ func (s spec) GoOperationCategory() string {
 		return "rate.OperationCategoryOperator"
 	case "OPERATION_CATEGORY_RESOURCE":
 		return "rate.OperationCategoryResource"
+	case "OPERATION_CATEGORY_KV":
+		return "rate.OperationCategoryKV"
+	case "OPERATION_CATEGORY_TXN":
+		return "rate.OperationCategoryTxn"
+	case "OPERATION_CATEGORY_HEALTH":
+		return "rate.OperationCategoryHealth"
+	case "OPERATION_CATEGORY_CATALOG":
+		return "rate.OperationCategoryCatalog"
+	case "OPERATION_CATEGORY_EXEC":
+		return "rate.OperationCategoryExec"
 	}
 	panic(fmt.Sprintf("unknown rate limit operation category: %s found in method: %s", s.OperationCategory, s.MethodName))
 }
@@ -62,10 +62,23 @@ func (g *generator) generateFile(file *protogen.File) error {
 		PackageName: string(file.GoPackageName),
 	}
 
+	// Cloning clients for client streams are not implemented, so services
+	// with client streaming methods are skipped. They are only served over
+	// the network.
+	var services []*protogen.Service
+	for _, svc := range file.Services {
+		if !hasClientStreams(svc) {
+			services = append(services, svc)
+		}
+	}
+	if len(services) < 1 {
+		return nil
+	}
+
 	filename := file.GeneratedFilenamePrefix + "_cloning_grpc.pb.go"
 	genFile := g.p.NewGeneratedFile(filename, file.GoImportPath)
 
-	for _, svc := range file.Services {
+	for _, svc := range services {
 		svcTypes := &cloningServiceTypes{
 			ClientTypeName:        genFile.QualifiedGoIdent(protogen.GoIdent{GoName: svc.GoName + "Client", GoImportPath: file.GoImportPath}),
 			ServerTypeName:        genFile.QualifiedGoIdent(protogen.GoIdent{GoName: svc.GoName + "Server", GoImportPath: file.GoImportPath}),
@@ -78,11 +91,6 @@ func (g *generator) generateFile(file *protogen.File) error {
 		}
 
 		for _, method := range svc.Methods {
-			if method.Desc.IsStreamingClient() {
-				// when we need these we can implement this
-				panic("client streams are unsupported")
-			}
-
 			if method.Desc.IsStreamingServer() {
 				tsvc.ServerStreamMethods = append(tsvc.ServerStreamMethods, &inmemMethod{
 					cloningServiceTypes: svcTypes,
@@ -112,6 +120,15 @@ func (g *generator) generateFile(file *protogen.File) error {
 
 }
 
+func hasClientStreams(svc *protogen.Service) bool {
+	for _, method := range svc.Methods {
+		if method.Desc.IsStreamingClient() {
+			return true
+		}
+	}
+	return false
+}
+
 type templateData struct {
 	PackageName   string
 	Services      []*cloningService
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package compression implements the compression of the RPC connections and
+// gRPC streams between servers of different datacenters or clusters.
+//
+// The data is sent as frames, each holding either the raw bytes or the bytes
+// compressed with the negotiated algorithm. Only the frames at least as large
+// as a threshold are compressed, since compressing small RPC headers costs more
+// CPU than it saves bandwidth.
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Algorithm is a compression algorithm. Its value is sent on the wire during
+// the negotiation and in the header of the frames, so it must not change.
+type Algorithm byte
+
+const (
+	// None disables the compression.
+	None Algorithm = 0
+
+	// Snappy is fast and cheap on CPU, with a moderate compression ratio.
+	Snappy Algorithm = 1
+
+	// Zstd compresses better than Snappy, at a higher CPU cost.
+	Zstd Algorithm = 2
+)
+
+// DefaultThreshold is the minimum size, in bytes, of the compressed frames
+// when no threshold is configured.
+const DefaultThreshold = 1024
+
+// maxFrameSize bounds the size of a frame, compressed or not, so that a
+// corrupted or malicious frame cannot make the reader allocate unbounded
+// memory.
+const maxFrameSize = 64 * 1024 * 1024
+
+// Parse returns the algorithm with the given name, the empty string and
+// "none" disable the compression.
+func Parse(name string) (Algorithm, error) {
+	switch name {
+	case "", "none":
+		return None, nil
+	case "snappy":
+		return Snappy, nil
+	case "zstd":
+		return Zstd, nil
+	default:
+		return None, fmt.Errorf("unknown compression algorithm %q, must be one of \"none\", \"snappy\" or \"zstd\"", name)
+	}
+}
+
+func (a Algorithm) String() string {
+	switch a {
+	case None:
+		return "none"
+	case Snappy:
+		return "snappy"
+	case Zstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(a))
+	}
+}
+
+// Supported returns whether the algorithm is implemented, None included.
+func (a Algorithm) Supported() bool {
+	return a == None || a == Snappy || a == Zstd
+}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// zstdCodec returns the zstd encoder and decoder shared by all the
+// connections, since they hold large buffers. Their EncodeAll and DecodeAll
+// methods are safe for concurrent use.
+func zstdCodec() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil,
+			zstd.WithEncoderLevel(zstd.SpeedDefault),
+			zstd.WithEncoderConcurrency(1),
+		)
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(0),
+			zstd.WithDecoderMaxMemory(maxFrameSize),
+		)
+	})
+	return zstdEncoder, zstdDecoder, zstdErr
+}
+
+// compress appends src compressed with the algorithm to dst.
+func compress(alg Algorithm, dst, src []byte) ([]byte, error) {
+	switch alg {
+	case Snappy:
+		// snappy.Encode writes at the start of dst, so the compressed bytes
+		// are written after the ones dst already holds.
+		n := len(dst)
+		need := n + snappy.MaxEncodedLen(len(src))
+		if cap(dst) < need {
+			grown := make([]byte, n, need)
+			copy(grown, dst)
+			dst = grown
+		}
+		encoded := snappy.Encode(dst[n:need], src)
+		return append(dst[:n], encoded...), nil
+	case Zstd:
+		enc, _, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(src, dst), nil
+	default:
+		return nil, fmt.Errorf("cannot compress with algorithm %s", alg)
+	}
+}
+
+// decompress returns src decompressed with the algorithm, reusing dst when it
+// is large enough.
+func decompress(alg Algorithm, dst, src []byte) ([]byte, error) {
+	switch alg {
+	case Snappy:
+		n, err := snappy.DecodedLen(src)
+		if err != nil {
+			return nil, err
+		}
+		if n > maxFrameSize {
+			return nil, errFrameTooLarge
+		}
+		return snappy.Decode(dst[:cap(dst)], src)
+	case Zstd:
+		_, dec, err := zstdCodec()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(src, dst[:0])
+	default:
+		return nil, fmt.Errorf("cannot decompress with algorithm %s", alg)
+	}
+}
+
+var errFrameTooLarge = errors.New("compressed frame too large")
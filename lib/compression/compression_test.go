@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package compression
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestParse(t *testing.T) {
+	for name, expected := range map[string]Algorithm{"": None, "none": None, "snappy": Snappy, "zstd": Zstd} {
+		alg, err := Parse(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, alg)
+	}
+	_, err := Parse("gzip")
+	require.EqualError(t, err, `unknown compression algorithm "gzip", must be one of "none", "snappy" or "zstd"`)
+}
+
+// countingConn counts the bytes written to the connection.
+type countingConn struct {
+	net.Conn
+	written int
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	c.written += len(p)
+	return c.Conn.Write(p)
+}
+
+func TestConn(t *testing.T) {
+	random := make([]byte, 4096)
+	_, err := rand.Read(random)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		data []byte
+		// compressed is whether the data is sent compressed, when the
+		// algorithm is not None.
+		compressed bool
+	}{
+		"below threshold": {
+			data: bytes.Repeat([]byte("a"), 100),
+		},
+		"compressible": {
+			data:       bytes.Repeat([]byte("consul catalog "), 1000),
+			compressed: true,
+		},
+		"incompressible": {
+			data: random,
+		},
+	}
+	for _, alg := range []Algorithm{None, Snappy, Zstd} {
+		for name, tc := range cases {
+			t.Run(alg.String()+"/"+name, func(t *testing.T) {
+				client, server := net.Pipe()
+				defer client.Close()
+				defer server.Close()
+
+				counting := &countingConn{Conn: client}
+				w := NewConn(counting, alg, 0)
+				r := NewConn(server, alg, 0)
+
+				errCh := make(chan error, 1)
+				go func() {
+					// Write twice to check the frames are read in order.
+					_, err := w.Write(tc.data)
+					if err == nil {
+						_, err = w.Write(tc.data)
+					}
+					errCh <- err
+				}()
+
+				read := make([]byte, 2*len(tc.data))
+				_, err := io.ReadFull(r, read)
+				require.NoError(t, err)
+				require.NoError(t, <-errCh)
+				require.Equal(t, append(tc.data, tc.data...), read)
+
+				if alg != None && tc.compressed {
+					require.Less(t, counting.written, len(tc.data))
+				} else {
+					require.Greater(t, counting.written, 2*len(tc.data))
+				}
+			})
+		}
+	}
+}
+
+func TestConn_Invalid(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	go client.Write([]byte{42, 1, 0})
+	_, err := NewConn(server, Snappy, 0).Read(make([]byte, 10))
+	require.EqualError(t, err, "unsupported compression algorithm 42")
+}
+
+func TestGRPCCompressor(t *testing.T) {
+	data := bytes.Repeat([]byte("consul catalog "), 1000)
+	for _, alg := range []Algorithm{Snappy, Zstd} {
+		t.Run(alg.String(), func(t *testing.T) {
+			c := encoding.GetCompressor(GRPCName(alg))
+			require.NotNil(t, c)
+
+			for _, msg := range [][]byte{data, []byte("small")} {
+				var buf bytes.Buffer
+				w, err := c.Compress(&buf)
+				require.NoError(t, err)
+				_, err = w.Write(msg)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				if len(msg) > DefaultThreshold {
+					require.Less(t, buf.Len(), len(msg))
+				}
+
+				r, err := c.Decompress(&buf)
+				require.NoError(t, err)
+				read, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, msg, read)
+			}
+		})
+	}
+	require.Empty(t, GRPCName(None))
+}
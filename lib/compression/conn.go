@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package compression
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Conn is a connection whose writes are sent as frames, compressed when they
+// are at least as large as the threshold. Both ends of the connection must be
+// wrapped in a Conn.
+//
+// Each frame is made of the algorithm used to compress it, None for the raw
+// bytes, the uvarint encoded length of its payload, and the payload.
+type Conn struct {
+	net.Conn
+
+	alg       Algorithm
+	threshold int
+
+	writeLock sync.Mutex
+	writeBuf  []byte
+
+	reader  *bufio.Reader
+	readBuf []byte
+	pending []byte
+}
+
+// NewConn wraps the connection to compress the writes of at least threshold
+// bytes with the algorithm, and to decompress the frames read. A threshold of
+// 0 or less uses DefaultThreshold.
+func NewConn(conn net.Conn, alg Algorithm, threshold int) *Conn {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &Conn{
+		Conn:      conn,
+		alg:       alg,
+		threshold: threshold,
+		reader:    bufio.NewReader(conn),
+	}
+}
+
+// Algorithm returns the algorithm the writes are compressed with.
+func (c *Conn) Algorithm() Algorithm {
+	return c.alg
+}
+
+// Write sends p as a single frame.
+func (c *Conn) Write(p []byte) (int, error) {
+	if len(p) > maxFrameSize {
+		// Split the writes larger than the reader accepts.
+		var written int
+		for len(p) > 0 {
+			n := len(p)
+			if n > maxFrameSize {
+				n = maxFrameSize
+			}
+			m, err := c.Write(p[:n])
+			written += m
+			if err != nil {
+				return written, err
+			}
+			p = p[n:]
+		}
+		return written, nil
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	frame, err := appendFrame(c.writeBuf[:0], p, c.alg, c.threshold)
+	if err != nil {
+		return 0, err
+	}
+	c.writeBuf = frame
+	if _, err := c.Conn.Write(frame); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// appendFrame appends the frame holding p to dst, compressed with the
+// algorithm if it is at least threshold long and that makes it smaller.
+func appendFrame(dst, p []byte, alg Algorithm, threshold int) ([]byte, error) {
+	if alg != None && len(p) >= threshold {
+		// Reserve the room of the largest header, the payload is moved next to
+		// the actual header once its length is known.
+		const maxHeader = 1 + binary.MaxVarintLen64
+		compressed, err := compress(alg, append(dst, make([]byte, maxHeader)...), p)
+		if err != nil {
+			return nil, err
+		}
+		payload := compressed[len(dst)+maxHeader:]
+		if len(payload) < len(p) {
+			frame := append(dst, byte(alg))
+			frame = binary.AppendUvarint(frame, uint64(len(payload)))
+			// The header is never longer than the reserved room, so the copy
+			// moves the payload backwards within the same buffer.
+			return append(frame, payload...), nil
+		}
+		dst = compressed[:len(dst)]
+	}
+
+	frame := append(dst, byte(None))
+	frame = binary.AppendUvarint(frame, uint64(len(p)))
+	return append(frame, p...), nil
+}
+
+// Read returns the bytes of the frames read from the connection, after
+// decompressing them. It is not safe for concurrent use.
+func (c *Conn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		if err := c.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// readFrame reads the next frame into pending.
+func (c *Conn) readFrame() error {
+	alg, size, err := readFrameHeader(c.reader)
+	if err != nil {
+		return err
+	}
+
+	// The raw frames are read in the buffer returned to the caller, the
+	// compressed ones in a scratch buffer before being decompressed.
+	if alg == None {
+		c.readBuf = grow(c.readBuf, int(size))
+		if _, err := io.ReadFull(c.reader, c.readBuf); err != nil {
+			return unexpectedEOF(err)
+		}
+		c.pending = c.readBuf
+		return nil
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return unexpectedEOF(err)
+	}
+	decompressed, err := decompress(alg, c.readBuf, payload)
+	if err != nil {
+		return fmt.Errorf("failed to decompress %s frame: %w", alg, err)
+	}
+	c.readBuf = decompressed
+	c.pending = decompressed
+	return nil
+}
+
+// readFrameHeader reads the algorithm and payload size of a frame.
+func readFrameHeader(r io.ByteReader) (Algorithm, uint64, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return None, 0, err
+	}
+	alg := Algorithm(header)
+	if !alg.Supported() {
+		return None, 0, fmt.Errorf("unsupported compression algorithm %d", header)
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return None, 0, unexpectedEOF(err)
+	}
+	if size > maxFrameSize {
+		return None, 0, errFrameTooLarge
+	}
+	return alg, size, nil
+}
+
+// grow returns a slice of length n, reusing buf if it is large enough.
+func grow(buf []byte, n int) []byte {
+	if cap(buf) >= n {
+		return buf[:n]
+	}
+	return make([]byte, n)
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync/atomic"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// grpcThreshold is the minimum size of the gRPC messages that are compressed.
+var grpcThreshold atomic.Int64
+
+func init() {
+	grpcThreshold.Store(DefaultThreshold)
+
+	// The compressors must be registered with the servers before any stream
+	// is opened, the clients opt in to them per stream with GRPCName.
+	encoding.RegisterCompressor(&grpcCompressor{alg: Snappy})
+	encoding.RegisterCompressor(&grpcCompressor{alg: Zstd})
+}
+
+// SetGRPCThreshold sets the minimum size of the gRPC messages compressed with
+// the compressors of this package, 0 or less sets DefaultThreshold.
+func SetGRPCThreshold(threshold int) {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	grpcThreshold.Store(int64(threshold))
+}
+
+// GRPCName returns the name of the gRPC compressor of the algorithm, to be
+// passed to grpc.UseCompressor. It is empty for None.
+func GRPCName(alg Algorithm) string {
+	if alg == None || !alg.Supported() {
+		return ""
+	}
+	return "consul-" + alg.String()
+}
+
+// grpcCompressor compresses each gRPC message as a single frame, so the small
+// messages are sent raw like on the RPC connections.
+type grpcCompressor struct {
+	alg Algorithm
+}
+
+func (c *grpcCompressor) Name() string {
+	return GRPCName(c.alg)
+}
+
+func (c *grpcCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &grpcWriter{w: w, alg: c.alg}, nil
+}
+
+func (c *grpcCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	alg, size, err := readFrameHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if alg == None {
+		return bytes.NewReader(payload), nil
+	}
+	decompressed, err := decompress(alg, nil, payload)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(decompressed), nil
+}
+
+// grpcWriter buffers a message and writes it as a frame when closed.
+type grpcWriter struct {
+	w   io.Writer
+	alg Algorithm
+	buf bytes.Buffer
+}
+
+func (w *grpcWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *grpcWriter) Close() error {
+	frame, err := appendFrame(nil, w.buf.Bytes(), w.alg, int(grpcThreshold.Load()))
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(frame)
+	return err
+}
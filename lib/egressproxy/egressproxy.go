@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+/*
+Package egressproxy holds the HTTP proxy settings used for requests Consul
+makes to systems outside of the cluster, such as a Vault CA provider, an OIDC
+identity provider or HCP.
+
+The settings follow the semantics of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+environment variables. When no settings have been configured the environment
+variables are used, so behavior is unchanged for agents that do not opt in.
+*/
+package egressproxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Config is the proxy configuration for outbound requests.
+type Config struct {
+	// HTTPProxy is the proxy used for requests to http URLs.
+	HTTPProxy string
+
+	// HTTPSProxy is the proxy used for requests to https URLs.
+	HTTPSProxy string
+
+	// NoProxy lists the destinations that are reached directly rather than
+	// through a proxy. Entries are host names, domain suffixes (".example.com"),
+	// IP addresses or CIDR blocks, optionally with a port, or "*" to bypass the
+	// proxy for all destinations.
+	NoProxy []string
+}
+
+// IsZero returns true if no proxy settings are present.
+func (c *Config) IsZero() bool {
+	return c == nil || (c.HTTPProxy == "" && c.HTTPSProxy == "" && len(c.NoProxy) == 0)
+}
+
+// Validate checks that the proxy addresses are usable.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateProxyURL(c.HTTPProxy); err != nil {
+		return fmt.Errorf("invalid http_proxy: %w", err)
+	}
+	if err := validateProxyURL(c.HTTPSProxy); err != nil {
+		return fmt.Errorf("invalid https_proxy: %w", err)
+	}
+	for _, np := range c.NoProxy {
+		if strings.TrimSpace(np) == "" {
+			return fmt.Errorf("invalid no_proxy: entries cannot be empty")
+		}
+	}
+	return nil
+}
+
+func validateProxyURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		// Like the environment variables, allow the scheme to be omitted.
+		u, err = url.Parse("http://" + raw)
+		if err != nil {
+			return err
+		}
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("proxy address %q has no host", raw)
+	}
+	return nil
+}
+
+// ProxyFunc returns a function that selects the proxy for a request URL. A nil
+// Config uses the proxy environment variables.
+func (c *Config) ProxyFunc() func(*url.URL) (*url.URL, error) {
+	if c == nil {
+		return httpproxy.FromEnvironment().ProxyFunc()
+	}
+	cfg := &httpproxy.Config{
+		HTTPProxy:  c.HTTPProxy,
+		HTTPSProxy: c.HTTPSProxy,
+		NoProxy:    strings.Join(c.NoProxy, ","),
+	}
+	return cfg.ProxyFunc()
+}
+
+var defaultProxy atomic.Pointer[func(*url.URL) (*url.URL, error)]
+
+// SetDefault sets the process wide configuration used by Proxy. Passing nil
+// reverts to using the proxy environment variables.
+func SetDefault(c *Config) {
+	if c.IsZero() {
+		defaultProxy.Store(nil)
+		return
+	}
+	fn := c.ProxyFunc()
+	defaultProxy.Store(&fn)
+}
+
+// Proxy returns the proxy to use for the request according to the
+// configuration given to SetDefault. It can be used as the Proxy of an
+// http.Transport. The configuration is looked up for each request so
+// transports created before SetDefault is called still use it.
+func Proxy(req *http.Request) (*url.URL, error) {
+	if fn := defaultProxy.Load(); fn != nil {
+		return (*fn)(req.URL)
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// PooledTransport returns a new cleanhttp pooled transport that uses Proxy.
+func PooledTransport() *http.Transport {
+	tr := cleanhttp.DefaultPooledTransport()
+	tr.Proxy = Proxy
+	return tr
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package egressproxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	cases := map[string]struct {
+		cfg *Config
+		err string
+	}{
+		"nil": {},
+		"full": {
+			cfg: &Config{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "https://proxy.example.com:3129",
+				NoProxy:    []string{"10.0.0.0/8", ".internal"},
+			},
+		},
+		"no scheme": {
+			cfg: &Config{HTTPSProxy: "proxy.example.com:3128"},
+		},
+		"socks": {
+			cfg: &Config{HTTPSProxy: "socks5://127.0.0.1:1080"},
+		},
+		"bad scheme": {
+			cfg: &Config{HTTPProxy: "ftp://proxy.example.com"},
+			err: `invalid http_proxy: unsupported proxy scheme "ftp"`,
+		},
+		"empty no_proxy entry": {
+			cfg: &Config{NoProxy: []string{"a.example.com", " "}},
+			err: "invalid no_proxy: entries cannot be empty",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestProxy(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	proxyFor := func(t *testing.T, rawURL string) string {
+		req, err := http.NewRequest("GET", rawURL, nil)
+		require.NoError(t, err)
+		u, err := Proxy(req)
+		require.NoError(t, err)
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+
+	SetDefault(&Config{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    []string{"vault.internal", "10.0.0.0/8"},
+	})
+	require.Equal(t, "http://proxy.example.com:3128", proxyFor(t, "https://auth.idp.example.com/"))
+	require.Equal(t, "", proxyFor(t, "https://vault.internal:8200/v1/sys/health"))
+	require.Equal(t, "", proxyFor(t, "https://10.1.2.3/"))
+
+	// Only an https proxy is configured so plain http requests go direct
+	// rather than falling back to the environment.
+	require.Equal(t, "", proxyFor(t, "http://plain.example.com/"))
+}
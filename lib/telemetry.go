@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
@@ -214,6 +215,20 @@ type TelemetryConfig struct {
 	// hcl: telemetry { enable_host_metrics = (true|false) }
 	EnableHostMetrics bool `json:"enable_host_metrics,omitempty" mapstructure:"enable_host_metrics"`
 
+	// EnvoyMetricsAllowlist holds the prefixes of the names of the Envoy
+	// metrics scraped from the sidecar proxies registered with the agent and
+	// exposed on its Prometheus endpoint. Envoy metrics are not scraped if it
+	// is empty.
+	//
+	// hcl: telemetry { envoy_metrics_allowlist = []string }
+	EnvoyMetricsAllowlist []string `json:"envoy_metrics_allowlist,omitempty" mapstructure:"envoy_metrics_allowlist"`
+
+	// EnvoyMetricsScrapeInterval is how often the Envoy metrics of the
+	// sidecar proxies are scraped.
+	//
+	// hcl: telemetry { envoy_metrics_scrape_interval = "duration" }
+	EnvoyMetricsScrapeInterval time.Duration `json:"envoy_metrics_scrape_interval,omitempty" mapstructure:"envoy_metrics_scrape_interval"`
+
 	// PrometheusOpts provides configuration for the PrometheusSink. Currently the only configuration
 	// we acquire from hcl is the retention time. We also use definition slices that are set in agent setup
 	// before being passed to InitTelemmetry.
@@ -229,9 +244,10 @@ type MetricsHandler interface {
 }
 
 type MetricsConfig struct {
-	Handler  MetricsHandler
-	mu       sync.Mutex
-	cancelFn context.CancelFunc
+	Handler   MetricsHandler
+	mu        sync.Mutex
+	cancelFn  context.CancelFunc
+	addrSinks *addrSinks
 }
 
 func (cfg *MetricsConfig) Cancel() {
@@ -243,6 +259,133 @@ func (cfg *MetricsConfig) Cancel() {
 	}
 }
 
+// ReloadAddrSinks replaces the sinks sending the metrics to the statsite,
+// statsd and dogstatsd addresses when they differ from the ones of tcfg.
+func (cfg *MetricsConfig) ReloadAddrSinks(tcfg TelemetryConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	cfg.mu.Lock()
+	sinks := cfg.addrSinks
+	cfg.mu.Unlock()
+
+	if sinks == nil {
+		return nil
+	}
+	return sinks.reload(tcfg)
+}
+
+func (cfg *MetricsConfig) setSinks(sinks metrics.FanoutSink) {
+	for _, sink := range sinks {
+		if s, ok := sink.(*addrSinks); ok {
+			cfg.mu.Lock()
+			cfg.addrSinks = s
+			cfg.mu.Unlock()
+		}
+	}
+}
+
+// addrSinks holds the sinks sending the metrics to the statsite, statsd and
+// dogstatsd addresses of the configuration. Unlike the other sinks they can
+// be replaced at runtime, since the addresses can be set centrally.
+type addrSinks struct {
+	hostname string
+
+	mu            sync.RWMutex
+	statsiteAddr  string
+	statsdAddr    string
+	dogstatsdAddr string
+	dogstatsdTags []string
+	sinks         metrics.FanoutSink
+}
+
+func (s *addrSinks) reload(cfg TelemetryConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sinks != nil &&
+		s.statsiteAddr == cfg.StatsiteAddr &&
+		s.statsdAddr == cfg.StatsdAddr &&
+		s.dogstatsdAddr == cfg.DogstatsdAddr &&
+		slices.Equal(s.dogstatsdTags, cfg.DogstatsdTags) {
+		return nil
+	}
+
+	sinks := metrics.FanoutSink{}
+	var errs error
+	for _, fn := range []func(TelemetryConfig, string) (metrics.MetricSink, error){
+		statsiteSink,
+		statsdSink,
+		dogstatdSink,
+	} {
+		sink, err := fn(cfg, s.hostname)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		if sink != nil {
+			sinks = append(sinks, sink)
+		}
+	}
+
+	old := s.sinks
+	s.sinks = sinks
+	s.statsiteAddr = cfg.StatsiteAddr
+	s.statsdAddr = cfg.StatsdAddr
+	s.dogstatsdAddr = cfg.DogstatsdAddr
+	s.dogstatsdTags = slices.Clone(cfg.DogstatsdTags)
+
+	for _, sink := range old {
+		if sd, ok := sink.(metrics.ShutdownSink); ok {
+			sd.Shutdown()
+		}
+	}
+	return errs
+}
+
+func (s *addrSinks) SetGauge(key []string, val float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.SetGauge(key, val)
+}
+
+func (s *addrSinks) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.SetGaugeWithLabels(key, val, labels)
+}
+
+func (s *addrSinks) EmitKey(key []string, val float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.EmitKey(key, val)
+}
+
+func (s *addrSinks) IncrCounter(key []string, val float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.IncrCounter(key, val)
+}
+
+func (s *addrSinks) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.IncrCounterWithLabels(key, val, labels)
+}
+
+func (s *addrSinks) AddSample(key []string, val float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.AddSample(key, val)
+}
+
+func (s *addrSinks) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.sinks.AddSampleWithLabels(key, val, labels)
+}
+
 func statsiteSink(cfg TelemetryConfig, hostname string) (metrics.MetricSink, error) {
 	addr := cfg.StatsiteAddr
 	if addr == "" {
@@ -354,9 +497,11 @@ func configureSinks(cfg TelemetryConfig, memSink metrics.MetricSink, extraSinks
 		}
 	}
 
-	addSink(statsiteSink)
-	addSink(statsdSink)
-	addSink(dogstatdSink)
+	addr := &addrSinks{hostname: metricsConf.HostName}
+	if err := addr.reload(cfg); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+	sinks = append(sinks, addr)
 	addSink(circonusSink)
 	addSink(prometheusSink)
 	for _, sink := range extraSinks {
@@ -395,7 +540,8 @@ func InitTelemetry(cfg TelemetryConfig, logger hclog.Logger, extraSinks ...metri
 		}
 		for {
 			logger.Warn("retrying configure metric sinks", "retries", waiter.Failures())
-			_, err := configureSinks(cfg, memSink, extraSinks)
+			sinks, err := configureSinks(cfg, memSink, extraSinks)
+			metricsConfig.setSinks(sinks)
 			if err == nil {
 				logger.Info("successfully configured metrics sinks")
 				return
@@ -408,7 +554,9 @@ func InitTelemetry(cfg TelemetryConfig, logger hclog.Logger, extraSinks ...metri
 		}
 	}
 
-	if _, errs := configureSinks(cfg, memSink, extraSinks); errs != nil {
+	sinks, errs := configureSinks(cfg, memSink, extraSinks)
+	metricsConfig.setSinks(sinks)
+	if errs != nil {
 		if isRetriableError(errs) && cfg.RetryFailedConfiguration {
 			logger.Warn("failed configure sinks", "error", multierror.Flatten(errs))
 			ctx, cancel = context.WithCancel(context.Background())
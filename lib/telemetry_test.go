@@ -29,8 +29,9 @@ func TestConfigureSinks(t *testing.T) {
 	extraSinks := []metrics.MetricSink{&metrics.BlackholeSink{}}
 	sinks, err := configureSinks(cfg, nil, extraSinks)
 	require.Error(t, err)
-	// 4 sinks: statsd, statsite, inmem, extra sink (blackhole)
-	require.Equal(t, 4, len(sinks))
+	// 3 sinks: address sinks (statsd, statsite), inmem, extra sink (blackhole)
+	require.Equal(t, 3, len(sinks))
+	require.Len(t, sinks[0].(*addrSinks).sinks, 2)
 
 	cfg = TelemetryConfig{
 		DogstatsdAddr: "",
@@ -70,3 +71,32 @@ func TestInitTelemetryRetrySuccess(t *testing.T) {
 	// fanoutSink := metrics.Default()}
 	metricsCfg.cancelFn()
 }
+
+func TestMetricsConfig_ReloadAddrSinks(t *testing.T) {
+	cfg := &MetricsConfig{}
+	sinks, err := configureSinks(TelemetryConfig{StatsiteAddr: "127.0.0.1:8125"}, nil, nil)
+	require.NoError(t, err)
+	cfg.setSinks(sinks)
+	addr := cfg.addrSinks
+	require.NotNil(t, addr)
+	require.Len(t, addr.sinks, 1)
+	first := addr.sinks[0]
+
+	// Unchanged addresses keep the sinks.
+	require.NoError(t, cfg.ReloadAddrSinks(TelemetryConfig{StatsiteAddr: "127.0.0.1:8125"}))
+	require.Same(t, first, addr.sinks[0])
+
+	require.NoError(t, cfg.ReloadAddrSinks(TelemetryConfig{
+		StatsiteAddr: "127.0.0.1:8125",
+		StatsdAddr:   "127.0.0.1:8126",
+	}))
+	require.Len(t, addr.sinks, 2)
+	require.NotSame(t, first, addr.sinks[0])
+
+	require.NoError(t, cfg.ReloadAddrSinks(TelemetryConfig{}))
+	require.Empty(t, addr.sinks)
+
+	// A nil config is a no-op, as when telemetry is disabled.
+	var disabled *MetricsConfig
+	require.NoError(t, disabled.ReloadAddrSinks(TelemetryConfig{StatsdAddr: "127.0.0.1:8126"}))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBatchTimeout is the longest time log lines are buffered before they
+// are sent to Kafka.
+const kafkaBatchTimeout = time.Second
+
+// kafkaMessageWriter is the part of kafka.Writer used by KafkaSink.
+type kafkaMessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaSink forwards each log line as a message to a Kafka topic. Implements
+// the io.Writer interface.
+//
+// Messages are sent asynchronously in batches, so writes never block on the
+// brokers, and lines are dropped while the brokers can't be reached.
+type KafkaSink struct {
+	w kafkaMessageWriter
+}
+
+// NewKafkaSink returns a KafkaSink that writes to topic on the given brokers.
+// TLS is used if tlsConfig is not nil.
+func NewKafkaSink(brokers []string, topic string, tlsConfig *tls.Config) (*KafkaSink, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("at least one Kafka broker is required")
+	}
+	if topic == "" {
+		return nil, errors.New("a Kafka topic is required")
+	}
+
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.LeastBytes{},
+		BatchTimeout: kafkaBatchTimeout,
+		RequiredAcks: kafka.RequireOne,
+		Async:        true,
+		Transport: &kafka.Transport{
+			TLS: tlsConfig,
+		},
+	}
+	return &KafkaSink{w: w}, nil
+}
+
+// Write is used to implement io.Writer
+func (k *KafkaSink) Write(p []byte) (int, error) {
+	// The writer keeps the message until it is sent, and p is reused by the
+	// caller, so it must be copied.
+	value := bytes.Clone(bytes.TrimRight(p, "\n"))
+	_ = k.w.WriteMessages(context.Background(), kafka.Message{Value: value})
+	return len(p), nil
+}
+
+// Close flushes the buffered log lines and closes the connections to the
+// brokers.
+func (k *KafkaSink) Close() error {
+	return k.w.Close()
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+type testKafkaWriter struct {
+	msgs []kafka.Message
+}
+
+func (w *testKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+
+func (w *testKafkaWriter) Close() error {
+	return nil
+}
+
+func TestKafkaSink_Write(t *testing.T) {
+	w := &testKafkaWriter{}
+	sink := &KafkaSink{w: w}
+
+	buf := []byte("[INFO]  agent: first\n")
+	n, err := sink.Write(buf)
+	require.NoError(t, err)
+	require.Equal(t, len(buf), n)
+
+	// The caller reuses its buffer, which must not change the message.
+	copy(buf, bytes.Repeat([]byte("x"), len(buf)))
+
+	require.Len(t, w.msgs, 1)
+	require.Equal(t, "[INFO]  agent: first", string(w.msgs[0].Value))
+}
+
+func TestNewKafkaSink_Invalid(t *testing.T) {
+	_, err := NewKafkaSink(nil, "consul", nil)
+	require.ErrorContains(t, err, "at least one Kafka broker is required")
+
+	_, err = NewKafkaSink([]string{"localhost:9092"}, "", nil)
+	require.ErrorContains(t, err, "a Kafka topic is required")
+}
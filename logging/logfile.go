@@ -4,7 +4,9 @@
 package logging
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -44,6 +46,9 @@ type LogFile struct {
 	// Max rotated files to keep before removing them.
 	MaxFiles int
 
+	// Compress controls compressing rotated files with gzip.
+	Compress bool
+
 	//acquire is the mutex utilized to ensure we have no concurrency issues
 	acquire sync.Mutex
 }
@@ -82,7 +87,7 @@ func (l *LogFile) openNew() error {
 	return nil
 }
 
-func (l *LogFile) renameCurrentFile() error {
+func (l *LogFile) renameCurrentFile() (string, error) {
 	fileNamePattern := l.fileNamePattern()
 
 	createTime := now()
@@ -92,7 +97,7 @@ func (l *LogFile) renameCurrentFile() error {
 	oldFileName := fmt.Sprintf(fileNamePattern, strconv.FormatInt(createTime.UnixNano(), 10))
 	oldFilePath := filepath.Join(l.logPath, oldFileName)
 
-	return os.Rename(currentFilePath, oldFilePath)
+	return oldFilePath, os.Rename(currentFilePath, oldFilePath)
 }
 
 func (l *LogFile) rotate() error {
@@ -101,9 +106,15 @@ func (l *LogFile) rotate() error {
 	// Rotate if we hit the byte file limit or the time limit
 	if (l.BytesWritten >= int64(l.MaxBytes) && (l.MaxBytes > 0)) || timeElapsed >= l.duration {
 		l.FileInfo.Close()
-		if err := l.renameCurrentFile(); err != nil {
+		oldFilePath, err := l.renameCurrentFile()
+		if err != nil {
 			return err
 		}
+		if l.Compress {
+			// Compressing can take a while for large files, so it is done
+			// in the background to not block logging.
+			go compressFile(oldFilePath)
+		}
 		if err := l.pruneFiles(); err != nil {
 			return err
 		}
@@ -122,17 +133,79 @@ func (l *LogFile) pruneFiles() error {
 	if err != nil {
 		return err
 	}
+	compressed, err := filepath.Glob(pattern + compressedExt)
+	if err != nil {
+		return err
+	}
+
+	// A file that is being compressed can exist both with and without the
+	// compressed extension, but it is still a single rotated file.
+	rotated := make(map[string][]string)
+	for _, match := range append(matches, compressed...) {
+		name := strings.TrimSuffix(match, compressedExt)
+		rotated[name] = append(rotated[name], match)
+	}
+	names := make([]string, 0, len(rotated))
+	for name := range rotated {
+		names = append(names, name)
+	}
 
 	switch {
 	case l.MaxFiles < 0:
-		return removeFiles(matches)
-	case len(matches) < l.MaxFiles:
+	case len(names) < l.MaxFiles:
 		return nil
+	default:
+		sort.Strings(names)
+		names = names[:len(names)-l.MaxFiles]
+	}
+
+	for _, name := range names {
+		if err := removeFiles(rotated[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compressedExt is the extension added to rotated files when they are
+// compressed.
+const compressedExt = ".gz"
+
+// compressFile replaces the file at path with a gzip compressed copy. The
+// original file is only removed once the copy is complete, so a failure leaves
+// the uncompressed file in place.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
 	}
+	defer src.Close()
 
-	sort.Strings(matches)
-	last := len(matches) - l.MaxFiles
-	return removeFiles(matches[:last])
+	tmpPath := path + compressedExt + ".tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path+compressedExt); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Remove(path)
 }
 
 func removeFiles(files []string) error {
@@ -4,15 +4,19 @@
 package logging
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 )
 
 func TestLogFile_Rotation_MaxDuration(t *testing.T) {
@@ -60,7 +64,7 @@ func TestLogFile_renameCurrentFile(t *testing.T) {
 	err := logFile.openNew()
 	require.NoError(t, err)
 
-	err = logFile.renameCurrentFile()
+	_, err = logFile.renameCurrentFile()
 	require.NoError(t, err)
 
 	_, err = os.ReadFile(logFile.FileInfo.Name())
@@ -135,6 +139,61 @@ func TestLogFile_FileRotation_Disabled(t *testing.T) {
 	require.Len(t, listDir(t, tempDir), 1)
 }
 
+func TestLogFile_Rotation_Compress(t *testing.T) {
+	tempDir := testutil.TempDir(t, t.Name())
+	logFile := LogFile{
+		fileName: "consul.log",
+		logPath:  tempDir,
+		MaxBytes: 10,
+		duration: defaultRotateDuration,
+		Compress: true,
+	}
+	logFile.Write([]byte("[INFO] Hello World"))
+	logFile.Write([]byte("[INFO] Second File"))
+
+	var compressed string
+	retry.Run(t, func(r *retry.R) {
+		logFiles := listDir(t, tempDir)
+		sort.Strings(logFiles)
+		require.Len(r, logFiles, 2)
+		require.True(r, strings.HasSuffix(logFiles[0], ".log.gz"), logFiles[0])
+		compressed = logFiles[0]
+	})
+
+	f, err := os.Open(filepath.Join(tempDir, compressed))
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	require.Equal(t, "[INFO] Hello World", string(content))
+}
+
+func TestLogFile_PruneFiles_Compressed(t *testing.T) {
+	tempDir := testutil.TempDir(t, t.Name())
+	for _, name := range []string{
+		"consul-1.log.gz",
+		"consul-2.log",
+		// A file that is being compressed counts once.
+		"consul-2.log.gz",
+		"consul-3.log.gz",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), nil, 0640))
+	}
+
+	logFile := LogFile{
+		fileName: "consul.log",
+		logPath:  tempDir,
+		MaxFiles: 2,
+	}
+	require.NoError(t, logFile.pruneFiles())
+
+	logFiles := listDir(t, tempDir)
+	sort.Strings(logFiles)
+	require.Equal(t, []string{"consul-2.log", "consul-2.log.gz", "consul-3.log.gz"}, logFiles)
+}
+
 func listDir(t *testing.T, name string) []string {
 	t.Helper()
 	fh, err := os.Open(name)
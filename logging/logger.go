@@ -4,8 +4,11 @@
 package logging
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"time"
 
@@ -30,6 +33,23 @@ type Config struct {
 	// SyslogFacility is the destination for syslog forwarding.
 	SyslogFacility string
 
+	// SyslogAddress is the address of a remote syslog server, as host:port,
+	// to forward to over TCP instead of the local syslog daemon.
+	SyslogAddress string
+
+	// SyslogTLS configures TLS for forwarding to the remote syslog server.
+	SyslogTLS TLSConfig
+
+	// KafkaBrokers are the addresses of the Kafka brokers to forward logs to.
+	// Forwarding to Kafka is disabled when empty.
+	KafkaBrokers []string
+
+	// KafkaTopic is the Kafka topic logs are forwarded to.
+	KafkaTopic string
+
+	// KafkaTLS configures TLS for forwarding to the Kafka brokers.
+	KafkaTLS TLSConfig
+
 	// LogFilePath is the path to write the logs to the user specified file.
 	LogFilePath string
 
@@ -41,6 +61,62 @@ type Config struct {
 
 	// LogRotateMaxFiles is the maximum number of past archived log files to keep
 	LogRotateMaxFiles int
+
+	// LogRotateCompress controls compressing archived log files with gzip
+	LogRotateCompress bool
+}
+
+// TLSConfig is used to configure TLS for forwarding logs over the network.
+type TLSConfig struct {
+	// Enabled controls using TLS.
+	Enabled bool
+
+	// CAFile is the path to the PEM encoded CA certificates used to verify
+	// the server. The system CA certificates are used when empty.
+	CAFile string
+
+	// CertFile and KeyFile are the paths to the PEM encoded client certificate
+	// and key presented to the server, if any.
+	CertFile string
+	KeyFile  string
+
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName string
+
+	// InsecureSkipVerify disables verifying the server certificate.
+	InsecureSkipVerify bool
+}
+
+// tlsConfig returns the *tls.Config for c, or nil if TLS is not enabled.
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+
+	conf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA file %q", c.CAFile)
+		}
+		conf.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
 }
 
 // defaultRotateDuration is the default time taken by the agent to rotate logs
@@ -63,6 +139,13 @@ func (w noErrorWriter) Write(p []byte) (n int, err error) {
 //
 // Logs may be written to out, and optionally to syslog, and a file.
 func Setup(config Config, out io.Writer) (hclog.InterceptLogger, error) {
+	return SetupWithSubsystems(config, out, nil)
+}
+
+// SetupWithSubsystems is like Setup, but the log levels of the subsystems of
+// the returned logger can be changed at runtime through subsystems, if it is
+// not nil.
+func SetupWithSubsystems(config Config, out io.Writer, subsystems *Subsystems) (hclog.InterceptLogger, error) {
 	if !ValidateLogLevel(config.LogLevel) {
 		return nil, fmt.Errorf("Invalid log level: %s. Valid log levels are: %v",
 			config.LogLevel,
@@ -74,7 +157,17 @@ func Setup(config Config, out io.Writer) (hclog.InterceptLogger, error) {
 	// noErrorWriter is used as a wrapper to suppress any errors when writing to out.
 	writers := []io.Writer{noErrorWriter{w: out}}
 
-	if config.EnableSyslog {
+	if config.EnableSyslog && config.SyslogAddress != "" {
+		tlsConfig, err := config.SyslogTLS.tlsConfig()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to setup syslog TLS: %w", err)
+		}
+		syslog, err := NewRemoteSyslog(config.SyslogAddress, config.SyslogFacility, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to setup syslog: %w", err)
+		}
+		writers = append(writers, syslog)
+	} else if config.EnableSyslog {
 		retries := 12
 		delay := 5 * time.Second
 		for i := 0; i <= retries; i++ {
@@ -108,6 +201,7 @@ func Setup(config Config, out io.Writer) (hclog.InterceptLogger, error) {
 			duration: config.LogRotateDuration,
 			MaxBytes: config.LogRotateBytes,
 			MaxFiles: config.LogRotateMaxFiles,
+			Compress: config.LogRotateCompress,
 		}
 		if err := logFile.pruneFiles(); err != nil {
 			return nil, fmt.Errorf("Failed to prune log files: %w", err)
@@ -118,11 +212,30 @@ func Setup(config Config, out io.Writer) (hclog.InterceptLogger, error) {
 		writers = append(writers, logFile)
 	}
 
-	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+	if len(config.KafkaBrokers) > 0 {
+		tlsConfig, err := config.KafkaTLS.tlsConfig()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to setup Kafka TLS: %w", err)
+		}
+		kafka, err := NewKafkaSink(config.KafkaBrokers, config.KafkaTopic, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to setup Kafka logging: %w", err)
+		}
+		writers = append(writers, kafka)
+	}
+
+	opts := &hclog.LoggerOptions{
 		Level:      LevelFromString(config.LogLevel),
 		Name:       config.Name,
 		Output:     io.MultiWriter(writers...),
 		JSONFormat: config.LogJSON,
-	})
+	}
+	if subsystems != nil {
+		subsystems.Configure(opts)
+	}
+	logger := hclog.NewInterceptLogger(opts)
+	if subsystems != nil {
+		subsystems.Attach(logger)
+	}
 	return logger, nil
 }
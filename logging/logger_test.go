@@ -174,3 +174,33 @@ func TestLogger_SetupLoggerWithInValidLogPathPermission(t *testing.T) {
 	require.True(t, errors.Is(err, os.ErrPermission))
 	require.Nil(t, logger)
 }
+
+func TestLogger_SetupLoggerWithInvalidSyslogTLS(t *testing.T) {
+	cfg := Config{
+		LogLevel:      "INFO",
+		EnableSyslog:  true,
+		SyslogAddress: "localhost:6514",
+		SyslogTLS: TLSConfig{
+			Enabled: true,
+			CAFile:  "/tmp/" + t.Name() + "/ca.pem",
+		},
+	}
+	var buf bytes.Buffer
+
+	logger, err := Setup(cfg, &buf)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+	require.Nil(t, logger)
+}
+
+func TestLogger_SetupLoggerWithKafka(t *testing.T) {
+	cfg := Config{
+		LogLevel:     "INFO",
+		KafkaBrokers: []string{"localhost:9092"},
+	}
+	var buf bytes.Buffer
+
+	logger, err := Setup(cfg, &buf)
+	require.ErrorContains(t, err, "a Kafka topic is required")
+	require.Nil(t, logger)
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	log "github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/consul/logging"
 )
 
 // Monitor provides a mechanism to stream logs using go-hclog
@@ -49,6 +51,10 @@ type Config struct {
 	BufferSize    int
 	Logger        log.InterceptLogger
 	LoggerOptions *log.LoggerOptions
+
+	// Subsystem limits the stream to the logs of a subsystem, as matched by
+	// logging.MatchesSubsystem. All logs are streamed when it is empty.
+	Subsystem string
 }
 
 // New creates a new Monitor. Start must be called in order to actually start
@@ -68,6 +74,9 @@ func New(cfg Config) Monitor {
 
 	cfg.LoggerOptions.Output = sw
 	sink := log.NewSinkAdapter(cfg.LoggerOptions)
+	if cfg.Subsystem != "" {
+		sink = &subsystemSink{SinkAdapter: sink, subsystem: cfg.Subsystem}
+	}
 	sw.sink = sink
 
 	return sw
@@ -134,3 +143,15 @@ func (d *monitor) Write(p []byte) (int, error) {
 
 	return len(p), nil
 }
+
+// subsystemSink only accepts the logs of a subsystem.
+type subsystemSink struct {
+	log.SinkAdapter
+	subsystem string
+}
+
+func (s *subsystemSink) Accept(name string, level log.Level, msg string, args ...interface{}) {
+	if logging.MatchesSubsystem(name, args, s.subsystem) {
+		s.SinkAdapter.Accept(name, level, msg, args...)
+	}
+}
@@ -41,6 +41,35 @@ func TestMonitor_Start(t *testing.T) {
 	}
 }
 
+func TestMonitor_Subsystem(t *testing.T) {
+	logger := log.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Error,
+		Name:  "agent",
+	})
+
+	m := New(Config{
+		BufferSize: 512,
+		Logger:     logger,
+		LoggerOptions: &log.LoggerOptions{
+			Level: log.Debug,
+		},
+		Subsystem: "dns",
+	})
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	logger.Named("http").Debug("http log")
+	logger.Named("dns").Debug("dns log")
+
+	select {
+	case log := <-logCh:
+		require.Contains(t, string(log), "agent.dns: dns log")
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected to receive from log channel")
+	}
+}
+
 func TestMonitor_Stop(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
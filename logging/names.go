@@ -5,7 +5,10 @@ package logging
 
 const (
 	ACL                   string = "acl"
+	ACME                  string = "acme"
+	Admission             string = "admission"
 	Agent                 string = "agent"
+	AgentDefaults         string = "agent_defaults"
 	AntiEntropy           string = "anti_entropy"
 	AutoEncrypt           string = "auto_encrypt"
 	AutoConfig            string = "auto_config"
@@ -14,6 +17,8 @@ const (
 	Azure                 string = "azure"
 	CA                    string = "ca"
 	Catalog               string = "catalog"
+	CatalogProviders      string = "catalog_providers"
+	CheckPlugins          string = "check_plugins"
 	CentralConfig         string = "central_config"
 	ConfigEntry           string = "config_entry"
 	Connect               string = "connect"
@@ -44,11 +49,13 @@ const (
 	MeshGateway           string = "mesh_gateway"
 	Namespace             string = "namespace"
 	NetworkAreas          string = "network_areas"
+	NodeAttestation       string = "node_attestation"
 	Operator              string = "operator"
 	PreparedQuery         string = "prepared_query"
 	Proxy                 string = "proxy"
 	ProxyConfig           string = "proxycfg"
 	Raft                  string = "raft"
+	RaftArchive           string = "raft_archive"
 	Replication           string = "replication"
 	Router                string = "router"
 	RPC                   string = "rpc"
@@ -69,6 +76,7 @@ const (
 	UIMetricsProxy        string = "ui_metrics_proxy"
 	WAN                   string = "wan"
 	Watch                 string = "watch"
+	Webhooks              string = "webhooks"
 	XDS                   string = "xds"
 	XDSCapacityController string = "xds_capacity_controller"
 	Vault                 string = "vault"
@@ -78,4 +86,5 @@ const (
 	Resource              string = "resource"
 	Dataplane             string = "dataplane"
 	ServerDiscovery       string = "server-discovery"
+	Registration          string = "registration"
 )
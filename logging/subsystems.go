@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// subsystemRecentLogs is the number of recent log lines kept for each logger.
+const subsystemRecentLogs = 256
+
+// controllerKey is the implied argument that names the controller a logger
+// belongs to. It makes the logger part of the "controller/<name>" subsystem.
+const controllerKey = "controller"
+
+// Subsystems sets the log levels of named subsystems at runtime, and keeps the
+// recent logs of each of them.
+//
+// A subsystem is any segment of a logger's name, such as "dns" for the
+// "agent.dns" logger or "raft" for the "agent.server.raft" logger, or
+// "controller/<name>" for the loggers of a controller. A level set for a
+// subsystem overrides the level of the root logger for all loggers in it.
+// When several subsystems of a logger have a level, the one named last in the
+// logger's name wins, and a controller wins over all of them.
+type Subsystems struct {
+	root hclog.InterceptLogger
+
+	// gen is bumped whenever levels changes so that the loggers know to
+	// recompute their level.
+	gen    atomic.Uint64
+	mu     sync.RWMutex
+	levels map[string]hclog.Level
+
+	timeFormat string
+
+	recentLock sync.Mutex
+	recent     map[string]*recentLogs
+	recentSeq  uint64
+}
+
+// recentLogs is a ring buffer of the recent log lines of a logger.
+type recentLogs struct {
+	subsystems []string
+	entries    []recentLog
+	next       int
+}
+
+type recentLog struct {
+	seq   uint64
+	time  time.Time
+	name  string
+	level hclog.Level
+	msg   string
+	args  []interface{}
+}
+
+// NewSubsystems returns a Subsystems with no subsystem levels set.
+func NewSubsystems() *Subsystems {
+	s := &Subsystems{
+		levels: make(map[string]hclog.Level),
+		recent: make(map[string]*recentLogs),
+	}
+	s.gen.Store(1)
+	return s
+}
+
+// Configure sets the options used to create the root logger so that the
+// loggers created from it honour the subsystem levels.
+func (s *Subsystems) Configure(opts *hclog.LoggerOptions) {
+	opts.IndependentLevels = true
+	opts.SubloggerHook = s.wrap
+	s.timeFormat = opts.TimeFormat
+}
+
+// Attach must be called with the root logger created with the options passed
+// to Configure. The level of the root logger applies to all subsystems that
+// do not have a level of their own.
+func (s *Subsystems) Attach(root hclog.InterceptLogger) {
+	s.root = root
+	root.RegisterSink(s)
+}
+
+// Levels returns the level of the root logger and the levels set for each
+// subsystem.
+func (s *Subsystems) Levels() (hclog.Level, map[string]hclog.Level) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	levels := make(map[string]hclog.Level, len(s.levels))
+	for name, level := range s.levels {
+		levels[name] = level
+	}
+	return s.rootLevel(), levels
+}
+
+// SetLevel sets the level of a subsystem. An empty subsystem sets the level of
+// the root logger instead, and hclog.NoLevel resets the subsystem to the level
+// of the root logger.
+func (s *Subsystems) SetLevel(subsystem string, level hclog.Level) {
+	if subsystem == "" {
+		if s.root != nil {
+			s.root.SetLevel(level)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if level == hclog.NoLevel {
+		delete(s.levels, subsystem)
+	} else {
+		s.levels[subsystem] = level
+	}
+	s.gen.Add(1)
+}
+
+// Recent returns the recent log lines at or above minLevel of the loggers in
+// the subsystem, or of all loggers if subsystem is empty, oldest first. The
+// lines are formatted as JSON if logJSON is true.
+func (s *Subsystems) Recent(subsystem string, minLevel hclog.Level, logJSON bool) [][]byte {
+	s.recentLock.Lock()
+	var entries []recentLog
+	for _, r := range s.recent {
+		if subsystem != "" && !containsSubsystem(r.subsystems, subsystem) {
+			continue
+		}
+		for _, e := range r.entries {
+			if e.level >= minLevel {
+				entries = append(entries, e)
+			}
+		}
+	}
+	s.recentLock.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+
+	var (
+		buf     bytes.Buffer
+		logTime time.Time
+	)
+	formatter := hclog.NewSinkAdapter(&hclog.LoggerOptions{
+		Level:      hclog.Trace,
+		Output:     &buf,
+		JSONFormat: logJSON,
+		TimeFormat: s.timeFormat,
+		TimeFn:     func() time.Time { return logTime },
+	})
+	lines := make([][]byte, 0, len(entries))
+	for _, e := range entries {
+		buf.Reset()
+		logTime = e.time
+		formatter.Accept(e.name, e.level, e.msg, e.args...)
+		lines = append(lines, bytes.Clone(buf.Bytes()))
+	}
+	return lines
+}
+
+// Accept implements hclog.SinkAdapter to record the recent logs of each
+// logger. Only the logs at or above the level of the logger are recorded.
+func (s *Subsystems) Accept(name string, level hclog.Level, msg string, args ...interface{}) {
+	subsystems := subsystemsOf(name, args)
+	if level < s.levelOf(subsystems) {
+		return
+	}
+
+	key := strings.Join(subsystems, "/")
+	now := time.Now()
+
+	s.recentLock.Lock()
+	defer s.recentLock.Unlock()
+
+	r, ok := s.recent[key]
+	if !ok {
+		r = &recentLogs{subsystems: subsystems}
+		s.recent[key] = r
+	}
+	s.recentSeq++
+	e := recentLog{
+		seq:   s.recentSeq,
+		time:  now,
+		name:  name,
+		level: level,
+		msg:   msg,
+		args:  args,
+	}
+	if len(r.entries) < subsystemRecentLogs {
+		r.entries = append(r.entries, e)
+	} else {
+		r.entries[r.next] = e
+		r.next = (r.next + 1) % subsystemRecentLogs
+	}
+}
+
+// MatchesSubsystem returns true if the logs of the logger with the given name
+// and arguments are part of subsystem.
+func MatchesSubsystem(name string, args []interface{}, subsystem string) bool {
+	return containsSubsystem(subsystemsOf(name, args), subsystem)
+}
+
+func (s *Subsystems) rootLevel() hclog.Level {
+	if s.root == nil {
+		return hclog.Info
+	}
+	return s.root.GetLevel()
+}
+
+// override returns the level set for the most specific of the subsystems, if
+// any.
+func (s *Subsystems) override(subsystems []string) (hclog.Level, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := len(subsystems) - 1; i >= 0; i-- {
+		if level, ok := s.levels[subsystems[i]]; ok {
+			return level, true
+		}
+	}
+	return hclog.NoLevel, false
+}
+
+func (s *Subsystems) levelOf(subsystems []string) hclog.Level {
+	if level, ok := s.override(subsystems); ok {
+		return level
+	}
+	return s.rootLevel()
+}
+
+// wrap is the hclog.LoggerOptions.SubloggerHook that makes each logger honour
+// the level of its subsystems.
+func (s *Subsystems) wrap(sub hclog.Logger) hclog.Logger {
+	// The wrapper does all the level checks, so the wrapped logger must
+	// not drop anything. Its level is independent of the other loggers.
+	sub.SetLevel(hclog.Trace)
+	return &subsystemLogger{
+		Logger:     sub,
+		subsystems: s,
+		names:      subsystemsOf(sub.Name(), sub.ImpliedArgs()),
+	}
+}
+
+// subsystemsOf returns the subsystems of a logger, from the least to the most
+// specific.
+func subsystemsOf(name string, args []interface{}) []string {
+	subsystems := strings.Split(name, ".")
+	for i := 0; i+1 < len(args); i += 2 {
+		if key, ok := args[i].(string); ok && key == controllerKey {
+			if controller, ok := args[i+1].(string); ok {
+				subsystems = append(subsystems, controllerKey+"/"+controller)
+			}
+		}
+	}
+	return subsystems
+}
+
+func containsSubsystem(subsystems []string, subsystem string) bool {
+	for _, s := range subsystems {
+		if strings.EqualFold(s, subsystem) {
+			return true
+		}
+	}
+	return false
+}
+
+// subsystemLogger filters the logs of a logger by the level of its
+// subsystems. It is always wrapped by an hclog.InterceptLogger, which routes
+// the standard loggers it creates through it.
+type subsystemLogger struct {
+	hclog.Logger
+
+	subsystems *Subsystems
+	names      []string
+
+	// gen is the generation of the subsystem levels that override was
+	// computed for.
+	gen      atomic.Uint64
+	override atomic.Int32
+
+	// own is the level set with SetLevel.
+	own atomic.Int32
+}
+
+func (l *subsystemLogger) level() hclog.Level {
+	if gen := l.subsystems.gen.Load(); gen != l.gen.Load() {
+		level, _ := l.subsystems.override(l.names)
+		l.override.Store(int32(level))
+		l.gen.Store(gen)
+	}
+	if level := hclog.Level(l.override.Load()); level != hclog.NoLevel {
+		return level
+	}
+	if level := hclog.Level(l.own.Load()); level != hclog.NoLevel {
+		return level
+	}
+	return l.subsystems.rootLevel()
+}
+
+func (l *subsystemLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	if level >= l.level() {
+		l.Logger.Log(level, msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Trace(msg string, args ...interface{}) {
+	l.Log(hclog.Trace, msg, args...)
+}
+
+func (l *subsystemLogger) Debug(msg string, args ...interface{}) {
+	l.Log(hclog.Debug, msg, args...)
+}
+
+func (l *subsystemLogger) Info(msg string, args ...interface{}) {
+	l.Log(hclog.Info, msg, args...)
+}
+
+func (l *subsystemLogger) Warn(msg string, args ...interface{}) {
+	l.Log(hclog.Warn, msg, args...)
+}
+
+func (l *subsystemLogger) Error(msg string, args ...interface{}) {
+	l.Log(hclog.Error, msg, args...)
+}
+
+func (l *subsystemLogger) IsTrace() bool { return l.level() <= hclog.Trace }
+func (l *subsystemLogger) IsDebug() bool { return l.level() <= hclog.Debug }
+func (l *subsystemLogger) IsInfo() bool  { return l.level() <= hclog.Info }
+func (l *subsystemLogger) IsWarn() bool  { return l.level() <= hclog.Warn }
+func (l *subsystemLogger) IsError() bool { return l.level() <= hclog.Error }
+
+func (l *subsystemLogger) GetLevel() hclog.Level {
+	return l.level()
+}
+
+func (l *subsystemLogger) SetLevel(level hclog.Level) {
+	l.own.Store(int32(level))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsystems_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	subsystems := NewSubsystems()
+	logger, err := SetupWithSubsystems(Config{LogLevel: "INFO", Name: Agent}, &buf, subsystems)
+	require.NoError(t, err)
+
+	dns := logger.Named(DNS)
+	raft := logger.Named(ConsulServer).Named(Raft)
+	server := logger.Named(ConsulServer)
+	controller := logger.Named(ControllerRuntime).With("controller", "mesh", "managed_type", "v1")
+
+	logAll := func() string {
+		buf.Reset()
+		dns.Debug("dns debug")
+		raft.Debug("raft debug")
+		server.Debug("server debug")
+		controller.Debug("controller debug")
+		logger.Debug("agent debug")
+		return buf.String()
+	}
+
+	require.Empty(t, logAll())
+	require.False(t, dns.IsDebug())
+
+	subsystems.SetLevel(DNS, hclog.Debug)
+	require.True(t, dns.IsDebug())
+	out := logAll()
+	require.Contains(t, out, "dns debug")
+	require.NotContains(t, out, "raft debug")
+
+	// A level set for a more specific subsystem wins.
+	subsystems.SetLevel(ConsulServer, hclog.Debug)
+	subsystems.SetLevel(Raft, hclog.Error)
+	out = logAll()
+	require.Contains(t, out, "server debug")
+	require.NotContains(t, out, "raft debug")
+
+	subsystems.SetLevel("controller/mesh", hclog.Debug)
+	require.Contains(t, logAll(), "controller debug")
+
+	// Loggers created after the level was set honour it too.
+	require.True(t, logger.Named(DNS).With("key", "value").IsDebug())
+
+	global, levels := subsystems.Levels()
+	require.Equal(t, hclog.Info, global)
+	require.Equal(t, map[string]hclog.Level{
+		DNS:               hclog.Debug,
+		ConsulServer:      hclog.Debug,
+		Raft:              hclog.Error,
+		"controller/mesh": hclog.Debug,
+	}, levels)
+
+	// Resetting a subsystem falls back to the root level.
+	subsystems.SetLevel(DNS, hclog.NoLevel)
+	require.NotContains(t, logAll(), "dns debug")
+
+	// Changing the root level applies to subsystems without a level.
+	subsystems.SetLevel("", hclog.Debug)
+	out = logAll()
+	require.Contains(t, out, "dns debug")
+	require.Contains(t, out, "agent debug")
+	require.NotContains(t, out, "raft debug")
+}
+
+func TestSubsystems_Recent(t *testing.T) {
+	subsystems := NewSubsystems()
+	logger, err := SetupWithSubsystems(Config{LogLevel: "INFO", Name: Agent}, &bytes.Buffer{}, subsystems)
+	require.NoError(t, err)
+
+	dns := logger.Named(DNS)
+	xds := logger.Named(Envoy).Named(XDS)
+
+	dns.Info("dns info")
+	dns.Debug("dns debug")
+	xds.Warn("xds warn")
+	for i := 0; i < subsystemRecentLogs+10; i++ {
+		xds.Info("xds info")
+	}
+
+	lines := subsystems.Recent(DNS, hclog.Trace, false)
+	require.Len(t, lines, 1)
+	require.Contains(t, string(lines[0]), "agent.dns: dns info")
+
+	// The ring buffer of each logger is bounded, so the noisy xds logger
+	// does not evict the dns logs.
+	lines = subsystems.Recent(XDS, hclog.Trace, false)
+	require.Len(t, lines, subsystemRecentLogs)
+	for _, line := range lines {
+		require.Contains(t, string(line), "xds info")
+	}
+
+	lines = subsystems.Recent("", hclog.Trace, false)
+	require.Len(t, lines, subsystemRecentLogs+1)
+	require.True(t, strings.Contains(string(lines[0]), "dns info"))
+
+	require.Empty(t, subsystems.Recent(DNS, hclog.Warn, false))
+
+	lines = subsystems.Recent(DNS, hclog.Trace, true)
+	require.Len(t, lines, 1)
+	require.Contains(t, string(lines[0]), `"@message":"dns info"`)
+}
+
+func TestMatchesSubsystem(t *testing.T) {
+	require.True(t, MatchesSubsystem("agent.server.raft", nil, "raft"))
+	require.True(t, MatchesSubsystem("agent.server.raft", nil, "SERVER"))
+	require.False(t, MatchesSubsystem("agent.server.raft", nil, "dns"))
+	require.True(t, MatchesSubsystem("agent.controller-runtime", []interface{}{"controller", "mesh"}, "controller/mesh"))
+	require.False(t, MatchesSubsystem("agent.controller-runtime", []interface{}{"controller", "mesh"}, "controller/other"))
+}
@@ -29,6 +29,16 @@ type SyslogWrapper struct {
 
 // Write is used to implement io.Writer
 func (s *SyslogWrapper) Write(p []byte) (int, error) {
+	priority, afterLevel := syslogPriority(p)
+
+	// Attempt the write
+	err := s.l.WriteLevel(priority, afterLevel)
+	return len(p), err
+}
+
+// syslogPriority extracts the log level of a log line and returns the syslog
+// priority it is handled with, and the rest of the line after the level.
+func syslogPriority(p []byte) (gsyslog.Priority, []byte) {
 	// Extract log level
 	var level string
 	afterLevel := p
@@ -46,8 +56,5 @@ func (s *SyslogWrapper) Write(p []byte) (int, error) {
 	if !ok {
 		priority = gsyslog.LOG_NOTICE
 	}
-
-	// Attempt the write
-	err := s.l.WriteLevel(priority, afterLevel)
-	return len(p), err
+	return priority, afterLevel
 }
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// remoteSyslogTimeout bounds the time spent connecting and writing to a
+	// remote syslog server, so that a slow server does not block logging.
+	remoteSyslogTimeout = 5 * time.Second
+
+	// remoteSyslogBackoff is the time to wait before reconnecting to a remote
+	// syslog server after a failure. Logs are dropped in the meantime.
+	remoteSyslogBackoff = 5 * time.Second
+)
+
+// syslogFacilities maps the names of the syslog facilities to their codes.
+var syslogFacilities = map[string]int{
+	"KERN":     0,
+	"USER":     1,
+	"MAIL":     2,
+	"DAEMON":   3,
+	"AUTH":     4,
+	"SYSLOG":   5,
+	"LPR":      6,
+	"NEWS":     7,
+	"UUCP":     8,
+	"CRON":     9,
+	"AUTHPRIV": 10,
+	"FTP":      11,
+	"LOCAL0":   16,
+	"LOCAL1":   17,
+	"LOCAL2":   18,
+	"LOCAL3":   19,
+	"LOCAL4":   20,
+	"LOCAL5":   21,
+	"LOCAL6":   22,
+	"LOCAL7":   23,
+}
+
+// RemoteSyslog forwards log lines to a remote syslog server over TCP,
+// optionally with TLS. Messages are formatted as RFC 5424 and framed with
+// octet counting as described in RFC 5425. Implements the io.Writer interface.
+//
+// Writes never fail: lines are dropped while the server can't be reached, so
+// that the other log outputs are not affected.
+type RemoteSyslog struct {
+	address   string
+	facility  int
+	hostname  string
+	tlsConfig *tls.Config
+
+	// dial is overridden in tests.
+	dial func() (net.Conn, error)
+
+	lock      sync.Mutex
+	conn      net.Conn
+	nextRetry time.Time
+}
+
+// NewRemoteSyslog returns a RemoteSyslog that forwards to the syslog server at
+// address with the given facility. TLS is used if tlsConfig is not nil.
+func NewRemoteSyslog(address, facility string, tlsConfig *tls.Config) (*RemoteSyslog, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("invalid syslog address %q: %w", address, err)
+	}
+	if facility == "" {
+		facility = "LOCAL0"
+	}
+	code, ok := syslogFacilities[strings.ToUpper(facility)]
+	if !ok {
+		return nil, fmt.Errorf("invalid syslog facility %q", facility)
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	s := &RemoteSyslog{
+		address:   address,
+		facility:  code,
+		hostname:  hostname,
+		tlsConfig: tlsConfig,
+	}
+	s.dial = s.dialServer
+	return s, nil
+}
+
+func (s *RemoteSyslog) dialServer() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: remoteSyslogTimeout}
+	if s.tlsConfig != nil {
+		return tls.DialWithDialer(dialer, "tcp", s.address, s.tlsConfig)
+	}
+	return dialer.Dial("tcp", s.address)
+}
+
+// Write is used to implement io.Writer
+func (s *RemoteSyslog) Write(p []byte) (int, error) {
+	msg := s.format(p, time.Now())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		if time.Now().Before(s.nextRetry) {
+			return len(p), nil
+		}
+		conn, err := s.dial()
+		if err != nil {
+			s.nextRetry = time.Now().Add(remoteSyslogBackoff)
+			return len(p), nil
+		}
+		s.conn = conn
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(remoteSyslogTimeout))
+	if _, err := s.conn.Write(msg); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		s.nextRetry = time.Now().Add(remoteSyslogBackoff)
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to the syslog server.
+func (s *RemoteSyslog) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format returns the log line p as an RFC 5424 syslog message framed with its
+// length.
+func (s *RemoteSyslog) format(p []byte, now time.Time) []byte {
+	priority, msg := syslogPriority(p)
+	msg = bytes.TrimRight(msg, "\n")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s consul %d - - ",
+		s.facility*8+int(priority),
+		now.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		os.Getpid())
+	buf.Write(msg)
+
+	return append([]byte(fmt.Sprintf("%d ", buf.Len())), buf.Bytes()...)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSyslog_Format(t *testing.T) {
+	s, err := NewRemoteSyslog("127.0.0.1:6514", "local1", nil)
+	require.NoError(t, err)
+	s.hostname = "node1"
+
+	now := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	msg := string(s.format([]byte("2023-05-01T12:30:00.000Z [WARN]  agent: something happened\n"), now))
+
+	// LOCAL1 is facility 17 and WARN is severity 4.
+	expected := fmt.Sprintf("<140>1 2023-05-01T12:30:00Z node1 consul %d - - agent: something happened", os.Getpid())
+	require.Equal(t, fmt.Sprintf("%d %s", len(expected), expected), msg)
+}
+
+func TestRemoteSyslog_Write(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(size))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	s, err := NewRemoteSyslog(l.Addr().String(), "", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	_, err = s.Write([]byte("[INFO]  agent: first\n"))
+	require.NoError(t, err)
+	_, err = s.Write([]byte("[ERROR] agent: second\n"))
+	require.NoError(t, err)
+
+	// LOCAL0 is facility 16, INFO is logged as NOTICE (5) and ERROR as ERR (3).
+	require.Regexp(t, `^<133>1 \S+ \S+ consul \d+ - - agent: first$`, <-received)
+	require.Regexp(t, `^<131>1 \S+ \S+ consul \d+ - - agent: second$`, <-received)
+}
+
+func TestRemoteSyslog_WriteUnreachable(t *testing.T) {
+	s, err := NewRemoteSyslog("127.0.0.1:6514", "", nil)
+	require.NoError(t, err)
+
+	var dials int
+	s.dial = func() (net.Conn, error) {
+		dials++
+		return nil, errors.New("connection refused")
+	}
+
+	// Lines are dropped without an error while the server is unreachable, and
+	// the agent does not try to reconnect on every line.
+	for i := 0; i < 3; i++ {
+		n, err := s.Write([]byte("[INFO] agent: dropped\n"))
+		require.NoError(t, err)
+		require.Equal(t, 22, n)
+	}
+	require.Equal(t, 1, dials)
+}
+
+func TestNewRemoteSyslog_Invalid(t *testing.T) {
+	_, err := NewRemoteSyslog("localhost", "", nil)
+	require.ErrorContains(t, err, "invalid syslog address")
+
+	_, err = NewRemoteSyslog("localhost:6514", "nope", nil)
+	require.ErrorContains(t, err, `invalid syslog facility "nope"`)
+}
@@ -14,11 +14,13 @@ import (
 	"github.com/hashicorp/consul/command"
 	"github.com/hashicorp/consul/command/cli"
 	"github.com/hashicorp/consul/command/version"
-	_ "github.com/hashicorp/consul/service_os"
+	"github.com/hashicorp/consul/service_os"
 )
 
 func main() {
-	os.Exit(realMain())
+	code := realMain()
+	service_os.Exit(code)
+	os.Exit(code)
 }
 
 func realMain() int {
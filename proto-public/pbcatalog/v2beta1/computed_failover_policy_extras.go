@@ -14,14 +14,9 @@ func (x *ComputedFailoverPolicy) GetUnderlyingDestinations() []*FailoverDestinat
 		return nil
 	}
 
-	estimate := 0
+	var out []*FailoverDestination
 	for _, pc := range x.PortConfigs {
-		estimate += len(pc.Destinations)
-	}
-
-	out := make([]*FailoverDestination, 0, estimate)
-	for _, pc := range x.PortConfigs {
-		out = append(out, pc.Destinations...)
+		out = append(out, pc.GetAllDestinations()...)
 	}
 	return out
 }
@@ -60,6 +60,33 @@ func TestComputedFailoverPolicy_GetUnderlyingDestinations_AndRefs(t *testing.T)
 				newFailoverRef("www"),
 			},
 		},
+		"tiered dests": {
+			failover: &ComputedFailoverPolicy{
+				PortConfigs: map[string]*FailoverConfig{
+					"http": {
+						Tiers: []*FailoverTier{
+							{Destinations: []*FailoverDestination{
+								newFailoverDestination("zone"),
+							}},
+							{Destinations: []*FailoverDestination{
+								newFailoverDestination("region"),
+								newFailoverDestination("global"),
+							}},
+						},
+					},
+				},
+			},
+			expectDests: []*FailoverDestination{
+				newFailoverDestination("zone"),
+				newFailoverDestination("region"),
+				newFailoverDestination("global"),
+			},
+			expectRefs: []*pbresource.Reference{
+				newFailoverRef("zone"),
+				newFailoverRef("region"),
+				newFailoverRef("global"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -47,6 +47,27 @@ func (in *FailoverConfig) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using FailoverTier within kubernetes types, where deepcopy-gen is used.
+func (in *FailoverTier) DeepCopyInto(out *FailoverTier) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverTier. Required by controller-gen.
+func (in *FailoverTier) DeepCopy() *FailoverTier {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new FailoverTier. Required by controller-gen.
+func (in *FailoverTier) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using FailoverDestination within kubernetes types, where deepcopy-gen is used.
 func (in *FailoverDestination) DeepCopyInto(out *FailoverDestination) {
 	proto.Reset(out)
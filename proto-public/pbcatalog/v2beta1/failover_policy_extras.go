@@ -11,5 +11,31 @@ func (x *FailoverConfig) IsEmpty() bool {
 	return len(x.Destinations) == 0 &&
 		x.Mode == 0 &&
 		len(x.Regions) == 0 &&
-		x.SamenessGroup == ""
+		x.SamenessGroup == "" &&
+		len(x.Tiers) == 0
+}
+
+// GetAllDestinations returns the Destinations of the config followed by the
+// destinations of each of its Tiers, in the order they will be tried.
+//
+// NOTE: no deduplication occurs.
+func (x *FailoverConfig) GetAllDestinations() []*FailoverDestination {
+	if x == nil {
+		return nil
+	}
+	if len(x.Tiers) == 0 {
+		return x.Destinations
+	}
+
+	estimate := len(x.Destinations)
+	for _, tier := range x.Tiers {
+		estimate += len(tier.Destinations)
+	}
+
+	out := make([]*FailoverDestination, 0, estimate)
+	out = append(out, x.Destinations...)
+	for _, tier := range x.Tiers {
+		out = append(out, tier.Destinations...)
+	}
+	return out
 }
@@ -42,6 +42,44 @@ func TestFailoverPolicy_IsEmpty(t *testing.T) {
 		}
 		require.False(t, fc.IsEmpty())
 	})
+	t.Run("tiers", func(t *testing.T) {
+		fc := &FailoverConfig{
+			Tiers: []*FailoverTier{{
+				Destinations: []*FailoverDestination{
+					newFailoverDestination("foo"),
+				},
+			}},
+		}
+		require.False(t, fc.IsEmpty())
+	})
+}
+
+func TestFailoverConfig_GetAllDestinations(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var fc *FailoverConfig
+		require.Nil(t, fc.GetAllDestinations())
+	})
+	t.Run("destinations", func(t *testing.T) {
+		fc := &FailoverConfig{
+			Destinations: []*FailoverDestination{
+				newFailoverDestination("foo"),
+				newFailoverDestination("bar"),
+			},
+		}
+		require.Equal(t, fc.Destinations, fc.GetAllDestinations())
+	})
+	t.Run("tiers", func(t *testing.T) {
+		zone := newFailoverDestination("zone")
+		region1 := newFailoverDestination("region-1")
+		region2 := newFailoverDestination("region-2")
+		fc := &FailoverConfig{
+			Tiers: []*FailoverTier{
+				{Name: "same-zone", Destinations: []*FailoverDestination{zone}},
+				{Name: "same-region", Destinations: []*FailoverDestination{region1, region2}},
+			},
+		}
+		require.Equal(t, []*FailoverDestination{zone, region1, region2}, fc.GetAllDestinations())
+	})
 }
 
 func assertSliceEquals[V proto.Message](t *testing.T, expect, got []V) {
@@ -27,6 +27,17 @@ func (this *FailoverConfig) UnmarshalJSON(b []byte) error {
 	return FailoverPolicyUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for FailoverTier
+func (this *FailoverTier) MarshalJSON() ([]byte, error) {
+	str, err := FailoverPolicyMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for FailoverTier
+func (this *FailoverTier) UnmarshalJSON(b []byte) error {
+	return FailoverPolicyUnmarshaler.Unmarshal(b, this)
+}
+
 // MarshalJSON is a custom marshaler for FailoverDestination
 func (this *FailoverDestination) MarshalJSON() ([]byte, error) {
 	str, err := FailoverPolicyMarshaler.Marshal(this)
@@ -36,7 +36,7 @@ func (s *Service) FindTargetPort(targetPort string) *ServicePort {
 	return nil
 }
 
-// FindPortByID finds a ServicePort by its VirtualPort or TargetPort value.
+// FindPortByID finds a ServicePort by its VirtualPort, TargetPort or one of its Aliases.
 //
 // Note that this will not match a target port if the given value is numeric.
 // See Service.ServicePort doc for more information on how port IDs are matched.
@@ -55,7 +55,7 @@ func (s *Service) FindPortByID(id string) *ServicePort {
 		}
 	} else {
 		for _, port := range s.GetPorts() {
-			if port.TargetPort == id {
+			if port.matchesName(id) {
 				return port
 			}
 		}
@@ -65,7 +65,7 @@ func (s *Service) FindPortByID(id string) *ServicePort {
 }
 
 // MatchesPortId returns true if the given port ID is non-empty and matches the virtual
-// or target port of the given ServicePort. See ServicePort doc for more information on
+// port, target port or one of the aliases of the given ServicePort. See ServicePort doc for more information on
 // how port IDs are matched.
 //
 // Note that this function does not validate the provided port ID. Configured service
@@ -83,7 +83,7 @@ func (sp *ServicePort) MatchesPortId(id string) bool {
 			return true
 		}
 	} else {
-		if sp.TargetPort == id {
+		if sp.matchesName(id) {
 			return true
 		}
 	}
@@ -91,6 +91,20 @@ func (sp *ServicePort) MatchesPortId(id string) bool {
 	return false
 }
 
+// matchesName returns true if the given name is the target port or one of the
+// aliases of the ServicePort.
+func (sp *ServicePort) matchesName(name string) bool {
+	if sp.TargetPort == name {
+		return true
+	}
+	for _, alias := range sp.Aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
 // VirtualPortStr is a convenience helper for checking the virtual port against a port ID in config
 // (e.g. keys in FailoverPolicy.PortConfigs). It returns the string representation of the virtual port.
 func (sp *ServicePort) VirtualPortStr() string {
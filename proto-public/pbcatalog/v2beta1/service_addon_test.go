@@ -148,6 +148,28 @@ func TestFindPort(t *testing.T) {
 			},
 			expByTargetPort: nil,
 		},
+		"existing port by alias": {
+			service: &Service{
+				Ports: []*ServicePort{
+					{
+						TargetPort: "foo",
+						Protocol:   Protocol_PROTOCOL_HTTP,
+					},
+					{
+						TargetPort: "bar",
+						Protocol:   Protocol_PROTOCOL_TCP,
+						Aliases:    []string{"legacy-bar"},
+					},
+				},
+			},
+			port: "legacy-bar",
+			expById: &ServicePort{
+				TargetPort: "bar",
+				Protocol:   Protocol_PROTOCOL_TCP,
+				Aliases:    []string{"legacy-bar"},
+			},
+			expByTargetPort: nil,
+		},
 	}
 
 	for name, c := range cases {
@@ -159,7 +181,7 @@ func TestFindPort(t *testing.T) {
 }
 
 func TestMatchesPortId(t *testing.T) {
-	testPort := &ServicePort{VirtualPort: 8080, TargetPort: "http"}
+	testPort := &ServicePort{VirtualPort: 8080, TargetPort: "http", Aliases: []string{"web"}}
 
 	cases := map[string]struct {
 		port     *ServicePort
@@ -188,6 +210,11 @@ func TestMatchesPortId(t *testing.T) {
 			id:       "http",
 			expected: true,
 		},
+		"existing alias": {
+			port:     testPort,
+			id:       "web",
+			expected: true,
+		},
 		"virtual and target mismatch": {
 			port:     &ServicePort{VirtualPort: 8080, TargetPort: "9090"},
 			id:       "9090",
@@ -67,3 +67,25 @@ func (c CloningDataplaneServiceClient) GetEnvoyBootstrapParams(ctx context.Conte
 
 	return proto.Clone(out).(*GetEnvoyBootstrapParamsResponse), nil
 }
+
+func (c CloningDataplaneServiceClient) GetEnvoyBootstrap(ctx context.Context, in *GetEnvoyBootstrapRequest, opts ...grpc.CallOption) (*GetEnvoyBootstrapResponse, error) {
+	in = proto.Clone(in).(*GetEnvoyBootstrapRequest)
+
+	out, err := c.DataplaneServiceClient.GetEnvoyBootstrap(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*GetEnvoyBootstrapResponse), nil
+}
+
+func (c CloningDataplaneServiceClient) ReportUpstreamMetrics(ctx context.Context, in *ReportUpstreamMetricsRequest, opts ...grpc.CallOption) (*ReportUpstreamMetricsResponse, error) {
+	in = proto.Clone(in).(*ReportUpstreamMetricsRequest)
+
+	out, err := c.DataplaneServiceClient.ReportUpstreamMetrics(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*ReportUpstreamMetricsResponse), nil
+}
@@ -109,3 +109,108 @@ func (in *GetEnvoyBootstrapParamsResponse) DeepCopy() *GetEnvoyBootstrapParamsRe
 func (in *GetEnvoyBootstrapParamsResponse) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
+
+// DeepCopyInto supports using GetEnvoyBootstrapRequest within kubernetes types, where deepcopy-gen is used.
+func (in *GetEnvoyBootstrapRequest) DeepCopyInto(out *GetEnvoyBootstrapRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetEnvoyBootstrapRequest. Required by controller-gen.
+func (in *GetEnvoyBootstrapRequest) DeepCopy() *GetEnvoyBootstrapRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GetEnvoyBootstrapRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new GetEnvoyBootstrapRequest. Required by controller-gen.
+func (in *GetEnvoyBootstrapRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using GetEnvoyBootstrapResponse within kubernetes types, where deepcopy-gen is used.
+func (in *GetEnvoyBootstrapResponse) DeepCopyInto(out *GetEnvoyBootstrapResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetEnvoyBootstrapResponse. Required by controller-gen.
+func (in *GetEnvoyBootstrapResponse) DeepCopy() *GetEnvoyBootstrapResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(GetEnvoyBootstrapResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new GetEnvoyBootstrapResponse. Required by controller-gen.
+func (in *GetEnvoyBootstrapResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ReportUpstreamMetricsRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ReportUpstreamMetricsRequest) DeepCopyInto(out *ReportUpstreamMetricsRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportUpstreamMetricsRequest. Required by controller-gen.
+func (in *ReportUpstreamMetricsRequest) DeepCopy() *ReportUpstreamMetricsRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportUpstreamMetricsRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ReportUpstreamMetricsRequest. Required by controller-gen.
+func (in *ReportUpstreamMetricsRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using UpstreamMetrics within kubernetes types, where deepcopy-gen is used.
+func (in *UpstreamMetrics) DeepCopyInto(out *UpstreamMetrics) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamMetrics. Required by controller-gen.
+func (in *UpstreamMetrics) DeepCopy() *UpstreamMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamMetrics. Required by controller-gen.
+func (in *UpstreamMetrics) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ReportUpstreamMetricsResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ReportUpstreamMetricsResponse) DeepCopyInto(out *ReportUpstreamMetricsResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportUpstreamMetricsResponse. Required by controller-gen.
+func (in *ReportUpstreamMetricsResponse) DeepCopy() *ReportUpstreamMetricsResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportUpstreamMetricsResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ReportUpstreamMetricsResponse. Required by controller-gen.
+func (in *ReportUpstreamMetricsResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
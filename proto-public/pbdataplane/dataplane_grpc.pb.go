@@ -24,6 +24,8 @@ const _ = grpc.SupportPackageIsVersion7
 type DataplaneServiceClient interface {
 	GetSupportedDataplaneFeatures(ctx context.Context, in *GetSupportedDataplaneFeaturesRequest, opts ...grpc.CallOption) (*GetSupportedDataplaneFeaturesResponse, error)
 	GetEnvoyBootstrapParams(ctx context.Context, in *GetEnvoyBootstrapParamsRequest, opts ...grpc.CallOption) (*GetEnvoyBootstrapParamsResponse, error)
+	GetEnvoyBootstrap(ctx context.Context, in *GetEnvoyBootstrapRequest, opts ...grpc.CallOption) (*GetEnvoyBootstrapResponse, error)
+	ReportUpstreamMetrics(ctx context.Context, in *ReportUpstreamMetricsRequest, opts ...grpc.CallOption) (*ReportUpstreamMetricsResponse, error)
 }
 
 type dataplaneServiceClient struct {
@@ -52,12 +54,32 @@ func (c *dataplaneServiceClient) GetEnvoyBootstrapParams(ctx context.Context, in
 	return out, nil
 }
 
+func (c *dataplaneServiceClient) GetEnvoyBootstrap(ctx context.Context, in *GetEnvoyBootstrapRequest, opts ...grpc.CallOption) (*GetEnvoyBootstrapResponse, error) {
+	out := new(GetEnvoyBootstrapResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.dataplane.DataplaneService/GetEnvoyBootstrap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataplaneServiceClient) ReportUpstreamMetrics(ctx context.Context, in *ReportUpstreamMetricsRequest, opts ...grpc.CallOption) (*ReportUpstreamMetricsResponse, error) {
+	out := new(ReportUpstreamMetricsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.dataplane.DataplaneService/ReportUpstreamMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataplaneServiceServer is the server API for DataplaneService service.
 // All implementations should embed UnimplementedDataplaneServiceServer
 // for forward compatibility
 type DataplaneServiceServer interface {
 	GetSupportedDataplaneFeatures(context.Context, *GetSupportedDataplaneFeaturesRequest) (*GetSupportedDataplaneFeaturesResponse, error)
 	GetEnvoyBootstrapParams(context.Context, *GetEnvoyBootstrapParamsRequest) (*GetEnvoyBootstrapParamsResponse, error)
+	GetEnvoyBootstrap(context.Context, *GetEnvoyBootstrapRequest) (*GetEnvoyBootstrapResponse, error)
+	ReportUpstreamMetrics(context.Context, *ReportUpstreamMetricsRequest) (*ReportUpstreamMetricsResponse, error)
 }
 
 // UnimplementedDataplaneServiceServer should be embedded to have forward compatible implementations.
@@ -70,6 +92,12 @@ func (UnimplementedDataplaneServiceServer) GetSupportedDataplaneFeatures(context
 func (UnimplementedDataplaneServiceServer) GetEnvoyBootstrapParams(context.Context, *GetEnvoyBootstrapParamsRequest) (*GetEnvoyBootstrapParamsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEnvoyBootstrapParams not implemented")
 }
+func (UnimplementedDataplaneServiceServer) GetEnvoyBootstrap(context.Context, *GetEnvoyBootstrapRequest) (*GetEnvoyBootstrapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnvoyBootstrap not implemented")
+}
+func (UnimplementedDataplaneServiceServer) ReportUpstreamMetrics(context.Context, *ReportUpstreamMetricsRequest) (*ReportUpstreamMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportUpstreamMetrics not implemented")
+}
 
 // UnsafeDataplaneServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DataplaneServiceServer will
@@ -118,6 +146,42 @@ func _DataplaneService_GetEnvoyBootstrapParams_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataplaneService_GetEnvoyBootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnvoyBootstrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataplaneServiceServer).GetEnvoyBootstrap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.dataplane.DataplaneService/GetEnvoyBootstrap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataplaneServiceServer).GetEnvoyBootstrap(ctx, req.(*GetEnvoyBootstrapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataplaneService_ReportUpstreamMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportUpstreamMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataplaneServiceServer).ReportUpstreamMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.dataplane.DataplaneService/ReportUpstreamMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataplaneServiceServer).ReportUpstreamMetrics(ctx, req.(*ReportUpstreamMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataplaneService_ServiceDesc is the grpc.ServiceDesc for DataplaneService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -133,6 +197,14 @@ var DataplaneService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEnvoyBootstrapParams",
 			Handler:    _DataplaneService_GetEnvoyBootstrapParams_Handler,
 		},
+		{
+			MethodName: "GetEnvoyBootstrap",
+			Handler:    _DataplaneService_GetEnvoyBootstrap_Handler,
+		},
+		{
+			MethodName: "ReportUpstreamMetrics",
+			Handler:    _DataplaneService_ReportUpstreamMetrics_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pbdataplane/dataplane.proto",
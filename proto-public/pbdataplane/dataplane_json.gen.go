@@ -60,6 +60,61 @@ func (this *GetEnvoyBootstrapParamsResponse) UnmarshalJSON(b []byte) error {
 	return DataplaneUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for GetEnvoyBootstrapRequest
+func (this *GetEnvoyBootstrapRequest) MarshalJSON() ([]byte, error) {
+	str, err := DataplaneMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for GetEnvoyBootstrapRequest
+func (this *GetEnvoyBootstrapRequest) UnmarshalJSON(b []byte) error {
+	return DataplaneUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for GetEnvoyBootstrapResponse
+func (this *GetEnvoyBootstrapResponse) MarshalJSON() ([]byte, error) {
+	str, err := DataplaneMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for GetEnvoyBootstrapResponse
+func (this *GetEnvoyBootstrapResponse) UnmarshalJSON(b []byte) error {
+	return DataplaneUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ReportUpstreamMetricsRequest
+func (this *ReportUpstreamMetricsRequest) MarshalJSON() ([]byte, error) {
+	str, err := DataplaneMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ReportUpstreamMetricsRequest
+func (this *ReportUpstreamMetricsRequest) UnmarshalJSON(b []byte) error {
+	return DataplaneUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for UpstreamMetrics
+func (this *UpstreamMetrics) MarshalJSON() ([]byte, error) {
+	str, err := DataplaneMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for UpstreamMetrics
+func (this *UpstreamMetrics) UnmarshalJSON(b []byte) error {
+	return DataplaneUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ReportUpstreamMetricsResponse
+func (this *ReportUpstreamMetricsResponse) MarshalJSON() ([]byte, error) {
+	str, err := DataplaneMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ReportUpstreamMetricsResponse
+func (this *ReportUpstreamMetricsResponse) UnmarshalJSON(b []byte) error {
+	return DataplaneUnmarshaler.Unmarshal(b, this)
+}
+
 var (
 	DataplaneMarshaler   = &protojson.MarshalOptions{}
 	DataplaneUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
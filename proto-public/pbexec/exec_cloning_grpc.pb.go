@@ -0,0 +1,58 @@
+// Code generated by protoc-gen-grpc-inmem. DO NOT EDIT.
+
+package pbexec
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// compile-time check to ensure that the generator is implementing all
+// of the grpc client interfaces methods.
+var _ ExecServiceClient = CloningExecServiceClient{}
+
+// IsCloningExecServiceClient is an interface that can be used to detect
+// that a ExecServiceClient is using the in-memory transport and has already
+// been wrapped with a with a CloningExecServiceClient.
+type IsCloningExecServiceClient interface {
+	IsCloningExecServiceClient() bool
+}
+
+// CloningExecServiceClient implements the ExecServiceClient interface by wrapping
+// another implementation and copying all protobuf messages that pass through the client.
+// This is mainly useful to wrap the an in-process client to insulate users of that
+// client from having to care about potential immutability of data they receive or having
+// the server implementation mutate their internal memory.
+type CloningExecServiceClient struct {
+	ExecServiceClient
+}
+
+func NewCloningExecServiceClient(client ExecServiceClient) ExecServiceClient {
+	if cloner, ok := client.(IsCloningExecServiceClient); ok && cloner.IsCloningExecServiceClient() {
+		// prevent a double clone if the underlying client is already the cloning client.
+		return client
+	}
+
+	return CloningExecServiceClient{
+		ExecServiceClient: client,
+	}
+}
+
+// IsCloningExecServiceClient implements the IsCloningExecServiceClient interface. This
+// is only used to detect wrapped clients that would be double cloning data and prevent that.
+func (c CloningExecServiceClient) IsCloningExecServiceClient() bool {
+	return true
+}
+
+func (c CloningExecServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExecService_ExecClient, error) {
+	in = proto.Clone(in).(*ExecRequest)
+
+	st, err := c.ExecServiceClient.Exec(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCloningStream[*ExecResponse](st), nil
+}
@@ -0,0 +1,90 @@
+// Code generated by protoc-gen-deepcopy. DO NOT EDIT.
+package pbexec
+
+import (
+	proto "google.golang.org/protobuf/proto"
+)
+
+// DeepCopyInto supports using ExecRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ExecRequest) DeepCopyInto(out *ExecRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecRequest. Required by controller-gen.
+func (in *ExecRequest) DeepCopy() *ExecRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ExecRequest. Required by controller-gen.
+func (in *ExecRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ExecResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ExecResponse) DeepCopyInto(out *ExecResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecResponse. Required by controller-gen.
+func (in *ExecResponse) DeepCopy() *ExecResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ExecResponse. Required by controller-gen.
+func (in *ExecResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Output within kubernetes types, where deepcopy-gen is used.
+func (in *Output) DeepCopyInto(out *Output) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output. Required by controller-gen.
+func (in *Output) DeepCopy() *Output {
+	if in == nil {
+		return nil
+	}
+	out := new(Output)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Output. Required by controller-gen.
+func (in *Output) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Exit within kubernetes types, where deepcopy-gen is used.
+func (in *Exit) DeepCopyInto(out *Exit) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exit. Required by controller-gen.
+func (in *Exit) DeepCopy() *Exit {
+	if in == nil {
+		return nil
+	}
+	out := new(Exit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Exit. Required by controller-gen.
+func (in *Exit) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
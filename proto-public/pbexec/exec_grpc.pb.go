@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: pbexec/exec.proto
+
+package pbexec
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExecServiceClient is the client API for ExecService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExecServiceClient interface {
+	// Exec runs a command and streams its output as it is produced. The last
+	// message on the stream holds the command's exit code.
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExecService_ExecClient, error)
+}
+
+type execServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecServiceClient(cc grpc.ClientConnInterface) ExecServiceClient {
+	return &execServiceClient{cc}
+}
+
+func (c *execServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (ExecService_ExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExecService_ServiceDesc.Streams[0], "/hashicorp.consul.exec.ExecService/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execServiceExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExecService_ExecClient interface {
+	Recv() (*ExecResponse, error)
+	grpc.ClientStream
+}
+
+type execServiceExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *execServiceExecClient) Recv() (*ExecResponse, error) {
+	m := new(ExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExecServiceServer is the server API for ExecService service.
+// All implementations should embed UnimplementedExecServiceServer
+// for forward compatibility
+type ExecServiceServer interface {
+	// Exec runs a command and streams its output as it is produced. The last
+	// message on the stream holds the command's exit code.
+	Exec(*ExecRequest, ExecService_ExecServer) error
+}
+
+// UnimplementedExecServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedExecServiceServer struct {
+}
+
+func (UnimplementedExecServiceServer) Exec(*ExecRequest, ExecService_ExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeServices not implemented")
+}
+
+// UnsafeExecServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecServiceServer will
+// result in compilation errors.
+type UnsafeExecServiceServer interface {
+	mustEmbedUnimplementedExecServiceServer()
+}
+
+func RegisterExecServiceServer(s grpc.ServiceRegistrar, srv ExecServiceServer) {
+	s.RegisterService(&ExecService_ServiceDesc, srv)
+}
+
+func _ExecService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServiceServer).Exec(m, &execServiceExecServer{stream})
+}
+
+type ExecService_ExecServer interface {
+	Send(*ExecResponse) error
+	grpc.ServerStream
+}
+
+type execServiceExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *execServiceExecServer) Send(m *ExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ExecService_ServiceDesc is the grpc.ServiceDesc for ExecService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.consul.exec.ExecService",
+	HandlerType: (*ExecServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Exec",
+			Handler:       _ExecService_Exec_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pbexec/exec.proto",
+}
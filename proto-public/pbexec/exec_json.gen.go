@@ -0,0 +1,55 @@
+// Code generated by protoc-json-shim. DO NOT EDIT.
+package pbexec
+
+import (
+	protojson "google.golang.org/protobuf/encoding/protojson"
+)
+
+// MarshalJSON is a custom marshaler for ExecRequest
+func (this *ExecRequest) MarshalJSON() ([]byte, error) {
+	str, err := ExecMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ExecRequest
+func (this *ExecRequest) UnmarshalJSON(b []byte) error {
+	return ExecUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ExecResponse
+func (this *ExecResponse) MarshalJSON() ([]byte, error) {
+	str, err := ExecMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ExecResponse
+func (this *ExecResponse) UnmarshalJSON(b []byte) error {
+	return ExecUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Output
+func (this *Output) MarshalJSON() ([]byte, error) {
+	str, err := ExecMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Output
+func (this *Output) UnmarshalJSON(b []byte) error {
+	return ExecUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Exit
+func (this *Exit) MarshalJSON() ([]byte, error) {
+	str, err := ExecMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Exit
+func (this *Exit) UnmarshalJSON(b []byte) error {
+	return ExecUnmarshaler.Unmarshal(b, this)
+}
+
+var (
+	ExecMarshaler   = &protojson.MarshalOptions{}
+	ExecUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
+)
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-grpc-inmem. DO NOT EDIT.
+
+package pbhealth
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// compile-time check to ensure that the generator is implementing all
+// of the grpc client interfaces methods.
+var _ HealthServiceClient = CloningHealthServiceClient{}
+
+// IsCloningHealthServiceClient is an interface that can be used to detect
+// that a HealthServiceClient is using the in-memory transport and has already
+// been wrapped with a with a CloningHealthServiceClient.
+type IsCloningHealthServiceClient interface {
+	IsCloningHealthServiceClient() bool
+}
+
+// CloningHealthServiceClient implements the HealthServiceClient interface by wrapping
+// another implementation and copying all protobuf messages that pass through the client.
+// This is mainly useful to wrap the an in-process client to insulate users of that
+// client from having to care about potential immutability of data they receive or having
+// the server implementation mutate their internal memory.
+type CloningHealthServiceClient struct {
+	HealthServiceClient
+}
+
+func NewCloningHealthServiceClient(client HealthServiceClient) HealthServiceClient {
+	if cloner, ok := client.(IsCloningHealthServiceClient); ok && cloner.IsCloningHealthServiceClient() {
+		// prevent a double clone if the underlying client is already the cloning client.
+		return client
+	}
+
+	return CloningHealthServiceClient{
+		HealthServiceClient: client,
+	}
+}
+
+// IsCloningHealthServiceClient implements the IsCloningHealthServiceClient interface. This
+// is only used to detect wrapped clients that would be double cloning data and prevent that.
+func (c CloningHealthServiceClient) IsCloningHealthServiceClient() bool {
+	return true
+}
+
+func (c CloningHealthServiceClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	in = proto.Clone(in).(*ListServicesRequest)
+
+	out, err := c.HealthServiceClient.ListServices(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*ListServicesResponse), nil
+}
+
+func (c CloningHealthServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
+	in = proto.Clone(in).(*ListNodesRequest)
+
+	out, err := c.HealthServiceClient.ListNodes(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*ListNodesResponse), nil
+}
+
+func (c CloningHealthServiceClient) NodeChecks(ctx context.Context, in *NodeChecksRequest, opts ...grpc.CallOption) (*NodeChecksResponse, error) {
+	in = proto.Clone(in).(*NodeChecksRequest)
+
+	out, err := c.HealthServiceClient.NodeChecks(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*NodeChecksResponse), nil
+}
+
+func (c CloningHealthServiceClient) ServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (*ServiceHealthResponse, error) {
+	in = proto.Clone(in).(*ServiceHealthRequest)
+
+	out, err := c.HealthServiceClient.ServiceHealth(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*ServiceHealthResponse), nil
+}
+
+func (c CloningHealthServiceClient) WatchServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (HealthService_WatchServiceHealthClient, error) {
+	in = proto.Clone(in).(*ServiceHealthRequest)
+
+	st, err := c.HealthServiceClient.WatchServiceHealth(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCloningStream[*ServiceHealthResponse](st), nil
+}
+
+// compile-time check to ensure that the generator is implementing all
+// of the grpc client interfaces methods.
+var _ HealthSubscriptionServiceClient = CloningHealthSubscriptionServiceClient{}
+
+// IsCloningHealthSubscriptionServiceClient is an interface that can be used to detect
+// that a HealthSubscriptionServiceClient is using the in-memory transport and has already
+// been wrapped with a with a CloningHealthSubscriptionServiceClient.
+type IsCloningHealthSubscriptionServiceClient interface {
+	IsCloningHealthSubscriptionServiceClient() bool
+}
+
+// CloningHealthSubscriptionServiceClient implements the HealthSubscriptionServiceClient interface by wrapping
+// another implementation and copying all protobuf messages that pass through the client.
+// This is mainly useful to wrap the an in-process client to insulate users of that
+// client from having to care about potential immutability of data they receive or having
+// the server implementation mutate their internal memory.
+type CloningHealthSubscriptionServiceClient struct {
+	HealthSubscriptionServiceClient
+}
+
+func NewCloningHealthSubscriptionServiceClient(client HealthSubscriptionServiceClient) HealthSubscriptionServiceClient {
+	if cloner, ok := client.(IsCloningHealthSubscriptionServiceClient); ok && cloner.IsCloningHealthSubscriptionServiceClient() {
+		// prevent a double clone if the underlying client is already the cloning client.
+		return client
+	}
+
+	return CloningHealthSubscriptionServiceClient{
+		HealthSubscriptionServiceClient: client,
+	}
+}
+
+// IsCloningHealthSubscriptionServiceClient implements the IsCloningHealthSubscriptionServiceClient interface. This
+// is only used to detect wrapped clients that would be double cloning data and prevent that.
+func (c CloningHealthSubscriptionServiceClient) IsCloningHealthSubscriptionServiceClient() bool {
+	return true
+}
+
+func (c CloningHealthSubscriptionServiceClient) SubscribeServices(ctx context.Context, in *SubscribeServicesRequest, opts ...grpc.CallOption) (HealthSubscriptionService_SubscribeServicesClient, error) {
+	in = proto.Clone(in).(*SubscribeServicesRequest)
+
+	st, err := c.HealthSubscriptionServiceClient.SubscribeServices(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCloningStream[*SubscribeServicesResponse](st), nil
+}
@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-deepcopy. DO NOT EDIT.
+package pbhealth
+
+import (
+	proto "google.golang.org/protobuf/proto"
+)
+
+// DeepCopyInto supports using Node within kubernetes types, where deepcopy-gen is used.
+func (in *Node) DeepCopyInto(out *Node) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node. Required by controller-gen.
+func (in *Node) DeepCopy() *Node {
+	if in == nil {
+		return nil
+	}
+	out := new(Node)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Node. Required by controller-gen.
+func (in *Node) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Service within kubernetes types, where deepcopy-gen is used.
+func (in *Service) DeepCopyInto(out *Service) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service. Required by controller-gen.
+func (in *Service) DeepCopy() *Service {
+	if in == nil {
+		return nil
+	}
+	out := new(Service)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Service. Required by controller-gen.
+func (in *Service) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Check within kubernetes types, where deepcopy-gen is used.
+func (in *Check) DeepCopyInto(out *Check) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Check. Required by controller-gen.
+func (in *Check) DeepCopy() *Check {
+	if in == nil {
+		return nil
+	}
+	out := new(Check)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Check. Required by controller-gen.
+func (in *Check) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ServiceEntry within kubernetes types, where deepcopy-gen is used.
+func (in *ServiceEntry) DeepCopyInto(out *ServiceEntry) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEntry. Required by controller-gen.
+func (in *ServiceEntry) DeepCopy() *ServiceEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEntry. Required by controller-gen.
+func (in *ServiceEntry) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListServicesRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ListServicesRequest) DeepCopyInto(out *ListServicesRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListServicesRequest. Required by controller-gen.
+func (in *ListServicesRequest) DeepCopy() *ListServicesRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ListServicesRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListServicesRequest. Required by controller-gen.
+func (in *ListServicesRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ServiceSummary within kubernetes types, where deepcopy-gen is used.
+func (in *ServiceSummary) DeepCopyInto(out *ServiceSummary) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSummary. Required by controller-gen.
+func (in *ServiceSummary) DeepCopy() *ServiceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSummary. Required by controller-gen.
+func (in *ServiceSummary) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListServicesResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ListServicesResponse) DeepCopyInto(out *ListServicesResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListServicesResponse. Required by controller-gen.
+func (in *ListServicesResponse) DeepCopy() *ListServicesResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ListServicesResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListServicesResponse. Required by controller-gen.
+func (in *ListServicesResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListNodesRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ListNodesRequest) DeepCopyInto(out *ListNodesRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListNodesRequest. Required by controller-gen.
+func (in *ListNodesRequest) DeepCopy() *ListNodesRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ListNodesRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListNodesRequest. Required by controller-gen.
+func (in *ListNodesRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListNodesResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ListNodesResponse) DeepCopyInto(out *ListNodesResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListNodesResponse. Required by controller-gen.
+func (in *ListNodesResponse) DeepCopy() *ListNodesResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ListNodesResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListNodesResponse. Required by controller-gen.
+func (in *ListNodesResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using NodeChecksRequest within kubernetes types, where deepcopy-gen is used.
+func (in *NodeChecksRequest) DeepCopyInto(out *NodeChecksRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeChecksRequest. Required by controller-gen.
+func (in *NodeChecksRequest) DeepCopy() *NodeChecksRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeChecksRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new NodeChecksRequest. Required by controller-gen.
+func (in *NodeChecksRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using NodeChecksResponse within kubernetes types, where deepcopy-gen is used.
+func (in *NodeChecksResponse) DeepCopyInto(out *NodeChecksResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeChecksResponse. Required by controller-gen.
+func (in *NodeChecksResponse) DeepCopy() *NodeChecksResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeChecksResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new NodeChecksResponse. Required by controller-gen.
+func (in *NodeChecksResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ServiceHealthRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ServiceHealthRequest) DeepCopyInto(out *ServiceHealthRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHealthRequest. Required by controller-gen.
+func (in *ServiceHealthRequest) DeepCopy() *ServiceHealthRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceHealthRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHealthRequest. Required by controller-gen.
+func (in *ServiceHealthRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ServiceHealthResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ServiceHealthResponse) DeepCopyInto(out *ServiceHealthResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHealthResponse. Required by controller-gen.
+func (in *ServiceHealthResponse) DeepCopy() *ServiceHealthResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceHealthResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHealthResponse. Required by controller-gen.
+func (in *ServiceHealthResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using SubscribeServicesRequest within kubernetes types, where deepcopy-gen is used.
+func (in *SubscribeServicesRequest) DeepCopyInto(out *SubscribeServicesRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscribeServicesRequest. Required by controller-gen.
+func (in *SubscribeServicesRequest) DeepCopy() *SubscribeServicesRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscribeServicesRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new SubscribeServicesRequest. Required by controller-gen.
+func (in *SubscribeServicesRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using SubscribeServicesResponse within kubernetes types, where deepcopy-gen is used.
+func (in *SubscribeServicesResponse) DeepCopyInto(out *SubscribeServicesResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscribeServicesResponse. Required by controller-gen.
+func (in *SubscribeServicesResponse) DeepCopy() *SubscribeServicesResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscribeServicesResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new SubscribeServicesResponse. Required by controller-gen.
+func (in *SubscribeServicesResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
@@ -0,0 +1,410 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: pbhealth/health.proto
+
+package pbhealth
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HealthServiceClient is the client API for HealthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HealthServiceClient interface {
+	// ListServices returns the names and tags of the services in the catalog.
+	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	// ListNodes returns the nodes in the catalog.
+	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
+	// NodeChecks returns the health checks registered on a node.
+	NodeChecks(ctx context.Context, in *NodeChecksRequest, opts ...grpc.CallOption) (*NodeChecksResponse, error)
+	// ServiceHealth returns the instances of a service along with their node
+	// and health checks.
+	ServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (*ServiceHealthResponse, error)
+	// WatchServiceHealth provides a stream on which you can receive the
+	// instances of a service. The current instances are sent immediately at the
+	// start of the stream, and the full set is sent again whenever it changes.
+	WatchServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (HealthService_WatchServiceHealthClient, error)
+}
+
+type healthServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealthServiceClient(cc grpc.ClientConnInterface) HealthServiceClient {
+	return &healthServiceClient{cc}
+}
+
+func (c *healthServiceClient) ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.health.HealthService/ListServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
+	out := new(ListNodesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.health.HealthService/ListNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthServiceClient) NodeChecks(ctx context.Context, in *NodeChecksRequest, opts ...grpc.CallOption) (*NodeChecksResponse, error) {
+	out := new(NodeChecksResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.health.HealthService/NodeChecks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthServiceClient) ServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (*ServiceHealthResponse, error) {
+	out := new(ServiceHealthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.consul.health.HealthService/ServiceHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healthServiceClient) WatchServiceHealth(ctx context.Context, in *ServiceHealthRequest, opts ...grpc.CallOption) (HealthService_WatchServiceHealthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &HealthService_ServiceDesc.Streams[0], "/hashicorp.consul.health.HealthService/WatchServiceHealth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &healthServiceWatchServiceHealthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HealthService_WatchServiceHealthClient interface {
+	Recv() (*ServiceHealthResponse, error)
+	grpc.ClientStream
+}
+
+type healthServiceWatchServiceHealthClient struct {
+	grpc.ClientStream
+}
+
+func (x *healthServiceWatchServiceHealthClient) Recv() (*ServiceHealthResponse, error) {
+	m := new(ServiceHealthResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HealthServiceServer is the server API for HealthService service.
+// All implementations should embed UnimplementedHealthServiceServer
+// for forward compatibility
+type HealthServiceServer interface {
+	// ListServices returns the names and tags of the services in the catalog.
+	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
+	// ListNodes returns the nodes in the catalog.
+	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
+	// NodeChecks returns the health checks registered on a node.
+	NodeChecks(context.Context, *NodeChecksRequest) (*NodeChecksResponse, error)
+	// ServiceHealth returns the instances of a service along with their node
+	// and health checks.
+	ServiceHealth(context.Context, *ServiceHealthRequest) (*ServiceHealthResponse, error)
+	// WatchServiceHealth provides a stream on which you can receive the
+	// instances of a service. The current instances are sent immediately at the
+	// start of the stream, and the full set is sent again whenever it changes.
+	WatchServiceHealth(*ServiceHealthRequest, HealthService_WatchServiceHealthServer) error
+}
+
+// UnimplementedHealthServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedHealthServiceServer struct {
+}
+
+func (UnimplementedHealthServiceServer) ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
+}
+func (UnimplementedHealthServiceServer) ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNodes not implemented")
+}
+func (UnimplementedHealthServiceServer) NodeChecks(context.Context, *NodeChecksRequest) (*NodeChecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeChecks not implemented")
+}
+func (UnimplementedHealthServiceServer) ServiceHealth(context.Context, *ServiceHealthRequest) (*ServiceHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceHealth not implemented")
+}
+func (UnimplementedHealthServiceServer) WatchServiceHealth(*ServiceHealthRequest, HealthService_WatchServiceHealthServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchServiceHealth not implemented")
+}
+
+// UnsafeHealthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HealthServiceServer will
+// result in compilation errors.
+type UnsafeHealthServiceServer interface {
+	mustEmbedUnimplementedHealthServiceServer()
+}
+
+func RegisterHealthServiceServer(s grpc.ServiceRegistrar, srv HealthServiceServer) {
+	s.RegisterService(&HealthService_ServiceDesc, srv)
+}
+
+func _HealthService_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).ListServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.health.HealthService/ListServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).ListServices(ctx, req.(*ListServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).ListNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.health.HealthService/ListNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).ListNodes(ctx, req.(*ListNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthService_NodeChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeChecksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).NodeChecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.health.HealthService/NodeChecks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).NodeChecks(ctx, req.(*NodeChecksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthService_ServiceHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).ServiceHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.consul.health.HealthService/ServiceHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).ServiceHealth(ctx, req.(*ServiceHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealthService_WatchServiceHealth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ServiceHealthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HealthServiceServer).WatchServiceHealth(m, &healthServiceWatchServiceHealthServer{stream})
+}
+
+type HealthService_WatchServiceHealthServer interface {
+	Send(*ServiceHealthResponse) error
+	grpc.ServerStream
+}
+
+type healthServiceWatchServiceHealthServer struct {
+	grpc.ServerStream
+}
+
+func (x *healthServiceWatchServiceHealthServer) Send(m *ServiceHealthResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// HealthService_ServiceDesc is the grpc.ServiceDesc for HealthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HealthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.consul.health.HealthService",
+	HandlerType: (*HealthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListServices",
+			Handler:    _HealthService_ListServices_Handler,
+		},
+		{
+			MethodName: "ListNodes",
+			Handler:    _HealthService_ListNodes_Handler,
+		},
+		{
+			MethodName: "NodeChecks",
+			Handler:    _HealthService_NodeChecks_Handler,
+		},
+		{
+			MethodName: "ServiceHealth",
+			Handler:    _HealthService_ServiceHealth_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchServiceHealth",
+			Handler:       _HealthService_WatchServiceHealth_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pbhealth/health.proto",
+}
+
+// HealthSubscriptionServiceClient is the client API for HealthSubscriptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HealthSubscriptionServiceClient interface {
+	// SubscribeServices provides a stream on which you can receive the
+	// instances of several services. The current instances of each service are
+	// sent at the start of the stream, and the full set of instances of a
+	// service is sent again whenever it changes.
+	SubscribeServices(ctx context.Context, in *SubscribeServicesRequest, opts ...grpc.CallOption) (HealthSubscriptionService_SubscribeServicesClient, error)
+}
+
+type healthSubscriptionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealthSubscriptionServiceClient(cc grpc.ClientConnInterface) HealthSubscriptionServiceClient {
+	return &healthSubscriptionServiceClient{cc}
+}
+
+func (c *healthSubscriptionServiceClient) SubscribeServices(ctx context.Context, in *SubscribeServicesRequest, opts ...grpc.CallOption) (HealthSubscriptionService_SubscribeServicesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &HealthSubscriptionService_ServiceDesc.Streams[0], "/hashicorp.consul.health.HealthSubscriptionService/SubscribeServices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &healthSubscriptionServiceSubscribeServicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HealthSubscriptionService_SubscribeServicesClient interface {
+	Recv() (*SubscribeServicesResponse, error)
+	grpc.ClientStream
+}
+
+type healthSubscriptionServiceSubscribeServicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *healthSubscriptionServiceSubscribeServicesClient) Recv() (*SubscribeServicesResponse, error) {
+	m := new(SubscribeServicesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HealthSubscriptionServiceServer is the server API for HealthSubscriptionService service.
+// All implementations should embed UnimplementedHealthSubscriptionServiceServer
+// for forward compatibility
+type HealthSubscriptionServiceServer interface {
+	// SubscribeServices provides a stream on which you can receive the
+	// instances of several services. The current instances of each service are
+	// sent at the start of the stream, and the full set of instances of a
+	// service is sent again whenever it changes.
+	SubscribeServices(*SubscribeServicesRequest, HealthSubscriptionService_SubscribeServicesServer) error
+}
+
+// UnimplementedHealthSubscriptionServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedHealthSubscriptionServiceServer struct {
+}
+
+func (UnimplementedHealthSubscriptionServiceServer) SubscribeServices(*SubscribeServicesRequest, HealthSubscriptionService_SubscribeServicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeServices not implemented")
+}
+
+// UnsafeHealthSubscriptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HealthSubscriptionServiceServer will
+// result in compilation errors.
+type UnsafeHealthSubscriptionServiceServer interface {
+	mustEmbedUnimplementedHealthSubscriptionServiceServer()
+}
+
+func RegisterHealthSubscriptionServiceServer(s grpc.ServiceRegistrar, srv HealthSubscriptionServiceServer) {
+	s.RegisterService(&HealthSubscriptionService_ServiceDesc, srv)
+}
+
+func _HealthSubscriptionService_SubscribeServices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeServicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HealthSubscriptionServiceServer).SubscribeServices(m, &healthSubscriptionServiceSubscribeServicesServer{stream})
+}
+
+type HealthSubscriptionService_SubscribeServicesServer interface {
+	Send(*SubscribeServicesResponse) error
+	grpc.ServerStream
+}
+
+type healthSubscriptionServiceSubscribeServicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *healthSubscriptionServiceSubscribeServicesServer) Send(m *SubscribeServicesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// HealthSubscriptionService_ServiceDesc is the grpc.ServiceDesc for HealthSubscriptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HealthSubscriptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.consul.health.HealthSubscriptionService",
+	HandlerType: (*HealthSubscriptionServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeServices",
+			Handler:       _HealthSubscriptionService_SubscribeServices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pbhealth/health.proto",
+}
@@ -0,0 +1,176 @@
+// Code generated by protoc-json-shim. DO NOT EDIT.
+package pbhealth
+
+import (
+	protojson "google.golang.org/protobuf/encoding/protojson"
+)
+
+// MarshalJSON is a custom marshaler for Node
+func (this *Node) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Node
+func (this *Node) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Service
+func (this *Service) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Service
+func (this *Service) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Check
+func (this *Check) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Check
+func (this *Check) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ServiceEntry
+func (this *ServiceEntry) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ServiceEntry
+func (this *ServiceEntry) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListServicesRequest
+func (this *ListServicesRequest) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListServicesRequest
+func (this *ListServicesRequest) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ServiceSummary
+func (this *ServiceSummary) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ServiceSummary
+func (this *ServiceSummary) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListServicesResponse
+func (this *ListServicesResponse) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListServicesResponse
+func (this *ListServicesResponse) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListNodesRequest
+func (this *ListNodesRequest) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListNodesRequest
+func (this *ListNodesRequest) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListNodesResponse
+func (this *ListNodesResponse) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListNodesResponse
+func (this *ListNodesResponse) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for NodeChecksRequest
+func (this *NodeChecksRequest) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for NodeChecksRequest
+func (this *NodeChecksRequest) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for NodeChecksResponse
+func (this *NodeChecksResponse) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for NodeChecksResponse
+func (this *NodeChecksResponse) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ServiceHealthRequest
+func (this *ServiceHealthRequest) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ServiceHealthRequest
+func (this *ServiceHealthRequest) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ServiceHealthResponse
+func (this *ServiceHealthResponse) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ServiceHealthResponse
+func (this *ServiceHealthResponse) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for SubscribeServicesRequest
+func (this *SubscribeServicesRequest) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for SubscribeServicesRequest
+func (this *SubscribeServicesRequest) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for SubscribeServicesResponse
+func (this *SubscribeServicesResponse) MarshalJSON() ([]byte, error) {
+	str, err := HealthMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for SubscribeServicesResponse
+func (this *SubscribeServicesResponse) UnmarshalJSON(b []byte) error {
+	return HealthUnmarshaler.Unmarshal(b, this)
+}
+
+var (
+	HealthMarshaler   = &protojson.MarshalOptions{}
+	HealthUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
+)
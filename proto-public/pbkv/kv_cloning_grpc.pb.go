@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-grpc-inmem. DO NOT EDIT.
+
+package pbkv
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// compile-time check to ensure that the generator is implementing all
+// of the grpc client interfaces methods.
+var _ KVServiceClient = CloningKVServiceClient{}
+
+// IsCloningKVServiceClient is an interface that can be used to detect
+// that a KVServiceClient is using the in-memory transport and has already
+// been wrapped with a with a CloningKVServiceClient.
+type IsCloningKVServiceClient interface {
+	IsCloningKVServiceClient() bool
+}
+
+// CloningKVServiceClient implements the KVServiceClient interface by wrapping
+// another implementation and copying all protobuf messages that pass through the client.
+// This is mainly useful to wrap the an in-process client to insulate users of that
+// client from having to care about potential immutability of data they receive or having
+// the server implementation mutate their internal memory.
+type CloningKVServiceClient struct {
+	KVServiceClient
+}
+
+func NewCloningKVServiceClient(client KVServiceClient) KVServiceClient {
+	if cloner, ok := client.(IsCloningKVServiceClient); ok && cloner.IsCloningKVServiceClient() {
+		// prevent a double clone if the underlying client is already the cloning client.
+		return client
+	}
+
+	return CloningKVServiceClient{
+		KVServiceClient: client,
+	}
+}
+
+// IsCloningKVServiceClient implements the IsCloningKVServiceClient interface. This
+// is only used to detect wrapped clients that would be double cloning data and prevent that.
+func (c CloningKVServiceClient) IsCloningKVServiceClient() bool {
+	return true
+}
+
+func (c CloningKVServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	in = proto.Clone(in).(*GetRequest)
+
+	out, err := c.KVServiceClient.Get(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*GetResponse), nil
+}
+
+func (c CloningKVServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	in = proto.Clone(in).(*ListRequest)
+
+	out, err := c.KVServiceClient.List(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*ListResponse), nil
+}
+
+func (c CloningKVServiceClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
+	in = proto.Clone(in).(*PutRequest)
+
+	out, err := c.KVServiceClient.Put(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*PutResponse), nil
+}
+
+func (c CloningKVServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	in = proto.Clone(in).(*DeleteRequest)
+
+	out, err := c.KVServiceClient.Delete(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*DeleteResponse), nil
+}
+
+func (c CloningKVServiceClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	in = proto.Clone(in).(*TxnRequest)
+
+	out, err := c.KVServiceClient.Txn(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(out).(*TxnResponse), nil
+}
+
+func (c CloningKVServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (KVService_WatchClient, error) {
+	in = proto.Clone(in).(*WatchRequest)
+
+	st, err := c.KVServiceClient.Watch(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCloningStream[*WatchResponse](st), nil
+}
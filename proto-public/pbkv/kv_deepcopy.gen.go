@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-deepcopy. DO NOT EDIT.
+package pbkv
+
+import (
+	proto "google.golang.org/protobuf/proto"
+)
+
+// DeepCopyInto supports using Entry within kubernetes types, where deepcopy-gen is used.
+func (in *Entry) DeepCopyInto(out *Entry) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Entry. Required by controller-gen.
+func (in *Entry) DeepCopy() *Entry {
+	if in == nil {
+		return nil
+	}
+	out := new(Entry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Entry. Required by controller-gen.
+func (in *Entry) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using GetRequest within kubernetes types, where deepcopy-gen is used.
+func (in *GetRequest) DeepCopyInto(out *GetRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetRequest. Required by controller-gen.
+func (in *GetRequest) DeepCopy() *GetRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GetRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new GetRequest. Required by controller-gen.
+func (in *GetRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using GetResponse within kubernetes types, where deepcopy-gen is used.
+func (in *GetResponse) DeepCopyInto(out *GetResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GetResponse. Required by controller-gen.
+func (in *GetResponse) DeepCopy() *GetResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(GetResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new GetResponse. Required by controller-gen.
+func (in *GetResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListRequest within kubernetes types, where deepcopy-gen is used.
+func (in *ListRequest) DeepCopyInto(out *ListRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListRequest. Required by controller-gen.
+func (in *ListRequest) DeepCopy() *ListRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ListRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListRequest. Required by controller-gen.
+func (in *ListRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using ListResponse within kubernetes types, where deepcopy-gen is used.
+func (in *ListResponse) DeepCopyInto(out *ListResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListResponse. Required by controller-gen.
+func (in *ListResponse) DeepCopy() *ListResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ListResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ListResponse. Required by controller-gen.
+func (in *ListResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using PutRequest within kubernetes types, where deepcopy-gen is used.
+func (in *PutRequest) DeepCopyInto(out *PutRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutRequest. Required by controller-gen.
+func (in *PutRequest) DeepCopy() *PutRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PutRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new PutRequest. Required by controller-gen.
+func (in *PutRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using PutResponse within kubernetes types, where deepcopy-gen is used.
+func (in *PutResponse) DeepCopyInto(out *PutResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutResponse. Required by controller-gen.
+func (in *PutResponse) DeepCopy() *PutResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(PutResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new PutResponse. Required by controller-gen.
+func (in *PutResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using DeleteRequest within kubernetes types, where deepcopy-gen is used.
+func (in *DeleteRequest) DeepCopyInto(out *DeleteRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteRequest. Required by controller-gen.
+func (in *DeleteRequest) DeepCopy() *DeleteRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new DeleteRequest. Required by controller-gen.
+func (in *DeleteRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using DeleteResponse within kubernetes types, where deepcopy-gen is used.
+func (in *DeleteResponse) DeepCopyInto(out *DeleteResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeleteResponse. Required by controller-gen.
+func (in *DeleteResponse) DeepCopy() *DeleteResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(DeleteResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new DeleteResponse. Required by controller-gen.
+func (in *DeleteResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TxnOp within kubernetes types, where deepcopy-gen is used.
+func (in *TxnOp) DeepCopyInto(out *TxnOp) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TxnOp. Required by controller-gen.
+func (in *TxnOp) DeepCopy() *TxnOp {
+	if in == nil {
+		return nil
+	}
+	out := new(TxnOp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TxnOp. Required by controller-gen.
+func (in *TxnOp) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TxnRequest within kubernetes types, where deepcopy-gen is used.
+func (in *TxnRequest) DeepCopyInto(out *TxnRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TxnRequest. Required by controller-gen.
+func (in *TxnRequest) DeepCopy() *TxnRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(TxnRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TxnRequest. Required by controller-gen.
+func (in *TxnRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TxnError within kubernetes types, where deepcopy-gen is used.
+func (in *TxnError) DeepCopyInto(out *TxnError) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TxnError. Required by controller-gen.
+func (in *TxnError) DeepCopy() *TxnError {
+	if in == nil {
+		return nil
+	}
+	out := new(TxnError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TxnError. Required by controller-gen.
+func (in *TxnError) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using TxnResponse within kubernetes types, where deepcopy-gen is used.
+func (in *TxnResponse) DeepCopyInto(out *TxnResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TxnResponse. Required by controller-gen.
+func (in *TxnResponse) DeepCopy() *TxnResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(TxnResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new TxnResponse. Required by controller-gen.
+func (in *TxnResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using WatchRequest within kubernetes types, where deepcopy-gen is used.
+func (in *WatchRequest) DeepCopyInto(out *WatchRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchRequest. Required by controller-gen.
+func (in *WatchRequest) DeepCopy() *WatchRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new WatchRequest. Required by controller-gen.
+func (in *WatchRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using WatchResponse within kubernetes types, where deepcopy-gen is used.
+func (in *WatchResponse) DeepCopyInto(out *WatchResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchResponse. Required by controller-gen.
+func (in *WatchResponse) DeepCopy() *WatchResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new WatchResponse. Required by controller-gen.
+func (in *WatchResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
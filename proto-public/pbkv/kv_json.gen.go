@@ -0,0 +1,176 @@
+// Code generated by protoc-json-shim. DO NOT EDIT.
+package pbkv
+
+import (
+	protojson "google.golang.org/protobuf/encoding/protojson"
+)
+
+// MarshalJSON is a custom marshaler for Entry
+func (this *Entry) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Entry
+func (this *Entry) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for GetRequest
+func (this *GetRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for GetRequest
+func (this *GetRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for GetResponse
+func (this *GetResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for GetResponse
+func (this *GetResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListRequest
+func (this *ListRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListRequest
+func (this *ListRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for ListResponse
+func (this *ListResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ListResponse
+func (this *ListResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for PutRequest
+func (this *PutRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for PutRequest
+func (this *PutRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for PutResponse
+func (this *PutResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for PutResponse
+func (this *PutResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for DeleteRequest
+func (this *DeleteRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for DeleteRequest
+func (this *DeleteRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for DeleteResponse
+func (this *DeleteResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for DeleteResponse
+func (this *DeleteResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for TxnOp
+func (this *TxnOp) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TxnOp
+func (this *TxnOp) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for TxnRequest
+func (this *TxnRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TxnRequest
+func (this *TxnRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for TxnError
+func (this *TxnError) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TxnError
+func (this *TxnError) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for TxnResponse
+func (this *TxnResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for TxnResponse
+func (this *TxnResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for WatchRequest
+func (this *WatchRequest) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for WatchRequest
+func (this *WatchRequest) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for WatchResponse
+func (this *WatchResponse) MarshalJSON() ([]byte, error) {
+	str, err := KvMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for WatchResponse
+func (this *WatchResponse) UnmarshalJSON(b []byte) error {
+	return KvUnmarshaler.Unmarshal(b, this)
+}
+
+var (
+	KvMarshaler   = &protojson.MarshalOptions{}
+	KvUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
+)
@@ -152,7 +152,6 @@ type ComputedPortRoutes struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Config:
-	//
 	//	*ComputedPortRoutes_Http
 	//	*ComputedPortRoutes_Grpc
 	//	*ComputedPortRoutes_Tcp
@@ -934,6 +933,8 @@ type ComputedFailoverConfig struct {
 	Regions      []string                       `protobuf:"bytes,3,rep,name=regions,proto3" json:"regions,omitempty"`
 	// SamenessGroup specifies the sameness group to failover to.
 	SamenessGroup string `protobuf:"bytes,4,opt,name=sameness_group,json=samenessGroup,proto3" json:"sameness_group,omitempty"`
+	// Tiers are groups of failover destinations which are tried in order.
+	Tiers []*ComputedFailoverTier `protobuf:"bytes,5,rep,name=tiers,proto3" json:"tiers,omitempty"`
 }
 
 func (x *ComputedFailoverConfig) Reset() {
@@ -996,6 +997,68 @@ func (x *ComputedFailoverConfig) GetSamenessGroup() string {
 	return ""
 }
 
+func (x *ComputedFailoverConfig) GetTiers() []*ComputedFailoverTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type ComputedFailoverTier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string                         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Destinations []*ComputedFailoverDestination `protobuf:"bytes,2,rep,name=destinations,proto3" json:"destinations,omitempty"`
+}
+
+func (x *ComputedFailoverTier) Reset() {
+	*x = ComputedFailoverTier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComputedFailoverTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputedFailoverTier) ProtoMessage() {}
+
+func (x *ComputedFailoverTier) ProtoReflect() protoreflect.Message {
+	mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputedFailoverTier.ProtoReflect.Descriptor instead.
+func (*ComputedFailoverTier) Descriptor() ([]byte, []int) {
+	return file_pbmesh_v2beta1_computed_routes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ComputedFailoverTier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ComputedFailoverTier) GetDestinations() []*ComputedFailoverDestination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
 type ComputedFailoverDestination struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1008,7 +1071,7 @@ type ComputedFailoverDestination struct {
 func (x *ComputedFailoverDestination) Reset() {
 	*x = ComputedFailoverDestination{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[13]
+		mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1021,7 +1084,7 @@ func (x *ComputedFailoverDestination) String() string {
 func (*ComputedFailoverDestination) ProtoMessage() {}
 
 func (x *ComputedFailoverDestination) ProtoReflect() protoreflect.Message {
-	mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[13]
+	mi := &file_pbmesh_v2beta1_computed_routes_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,7 +1097,7 @@ func (x *ComputedFailoverDestination) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ComputedFailoverDestination.ProtoReflect.Descriptor instead.
 func (*ComputedFailoverDestination) Descriptor() ([]byte, []int) {
-	return file_pbmesh_v2beta1_computed_routes_proto_rawDescGZIP(), []int{13}
+	return file_pbmesh_v2beta1_computed_routes_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ComputedFailoverDestination) GetBackendTarget() string {
@@ -1278,8 +1341,8 @@ var file_pbmesh_v2beta1_computed_routes_proto_rawDesc = []byte{
 	0x2e, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75,
 	0x6c, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72,
 	0x65, 0x6e, 0x63, 0x65, 0x52, 0x0c, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65,
-	0x66, 0x73, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x15, 0x4a, 0x04, 0x08, 0x16, 0x10, 0x17, 0x22, 0xfd,
-	0x01, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f,
+	0x66, 0x73, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x15, 0x4a, 0x04, 0x08, 0x16, 0x10, 0x17, 0x22, 0xc8,
+	0x02, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f,
 	0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x5e, 0x0a, 0x0c, 0x64, 0x65, 0x73,
 	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x3a, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73,
@@ -1294,39 +1357,53 @@ var file_pbmesh_v2beta1_computed_routes_proto_rawDesc = []byte{
 	0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
 	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x65, 0x6e,
 	0x65, 0x73, 0x73, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x73, 0x61, 0x6d, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x44,
-	0x0a, 0x1b, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76,
-	0x65, 0x72, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
-	0x0e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x2a, 0x99, 0x01, 0x0a, 0x18, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
-	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x2b, 0x0a, 0x27, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x41, 0x52,
-	0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26,
-	0x0a, 0x22, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
-	0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49,
-	0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x28, 0x0a, 0x24, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e,
+	0x0d, 0x73, 0x61, 0x6d, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x49,
+	0x0a, 0x05, 0x74, 0x69, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e,
+	0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6c,
+	0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x54, 0x69,
+	0x65, 0x72, 0x52, 0x05, 0x74, 0x69, 0x65, 0x72, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x54, 0x69,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5e, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x68,
+	0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x2e,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x44, 0x0a, 0x1b, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2a, 0x99, 0x01, 0x0a,
+	0x18, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x27, 0x42, 0x41, 0x43,
+	0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x54, 0x41,
+	0x49, 0x4c, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26, 0x0a, 0x22, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e,
 	0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x02,
-	0x42, 0x94, 0x02, 0x0a, 0x21, 0x63, 0x6f, 0x6d, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f,
-	0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76,
-	0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x42, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64,
-	0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x43, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63,
-	0x6f, 0x72, 0x70, 0x2f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2f, 0x70, 0x62, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x76,
-	0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x3b, 0x6d, 0x65, 0x73, 0x68, 0x76, 0x32, 0x62, 0x65, 0x74,
-	0x61, 0x31, 0xa2, 0x02, 0x03, 0x48, 0x43, 0x4d, 0xaa, 0x02, 0x1d, 0x48, 0x61, 0x73, 0x68, 0x69,
-	0x63, 0x6f, 0x72, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x68,
-	0x2e, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0xca, 0x02, 0x1d, 0x48, 0x61, 0x73, 0x68, 0x69,
-	0x63, 0x6f, 0x72, 0x70, 0x5c, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x5c, 0x4d, 0x65, 0x73, 0x68,
-	0x5c, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0xe2, 0x02, 0x29, 0x48, 0x61, 0x73, 0x68, 0x69,
-	0x63, 0x6f, 0x72, 0x70, 0x5c, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x5c, 0x4d, 0x65, 0x73, 0x68,
-	0x5c, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x20, 0x48, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70,
-	0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c, 0x3a, 0x3a, 0x4d, 0x65, 0x73, 0x68, 0x3a, 0x3a,
-	0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12, 0x28,
+	0x0a, 0x24, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
+	0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e,
+	0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x02, 0x42, 0x94, 0x02, 0x0a, 0x21, 0x63, 0x6f, 0x6d,
+	0x2e, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6c, 0x2e, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x76, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x42, 0x13,
+	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x63, 0x6f, 0x6e, 0x73,
+	0x75, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x2f,
+	0x70, 0x62, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x76, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x3b, 0x6d,
+	0x65, 0x73, 0x68, 0x76, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x48, 0x43, 0x4d,
+	0xaa, 0x02, 0x1d, 0x48, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2e, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x68, 0x2e, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0xca, 0x02, 0x1d, 0x48, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x5c, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6c, 0x5c, 0x4d, 0x65, 0x73, 0x68, 0x5c, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0xe2, 0x02, 0x29, 0x48, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x5c, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6c, 0x5c, 0x4d, 0x65, 0x73, 0x68, 0x5c, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x20, 0x48,
+	0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6c,
+	0x3a, 0x3a, 0x4d, 0x65, 0x73, 0x68, 0x3a, 0x3a, 0x56, 0x32, 0x62, 0x65, 0x74, 0x61, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1342,7 +1419,7 @@ func file_pbmesh_v2beta1_computed_routes_proto_rawDescGZIP() []byte {
 }
 
 var file_pbmesh_v2beta1_computed_routes_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pbmesh_v2beta1_computed_routes_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_pbmesh_v2beta1_computed_routes_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_pbmesh_v2beta1_computed_routes_proto_goTypes = []interface{}{
 	(BackendTargetDetailsType)(0),       // 0: hashicorp.consul.mesh.v2beta1.BackendTargetDetailsType
 	(*ComputedRoutes)(nil),              // 1: hashicorp.consul.mesh.v2beta1.ComputedRoutes
@@ -1358,63 +1435,66 @@ var file_pbmesh_v2beta1_computed_routes_proto_goTypes = []interface{}{
 	(*ComputedTCPBackendRef)(nil),       // 11: hashicorp.consul.mesh.v2beta1.ComputedTCPBackendRef
 	(*BackendTargetDetails)(nil),        // 12: hashicorp.consul.mesh.v2beta1.BackendTargetDetails
 	(*ComputedFailoverConfig)(nil),      // 13: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig
-	(*ComputedFailoverDestination)(nil), // 14: hashicorp.consul.mesh.v2beta1.ComputedFailoverDestination
-	nil,                                 // 15: hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry
-	nil,                                 // 16: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry
-	(*pbresource.Reference)(nil),        // 17: hashicorp.consul.resource.Reference
-	(*ParentReference)(nil),             // 18: hashicorp.consul.mesh.v2beta1.ParentReference
-	(v2beta1.Protocol)(0),               // 19: hashicorp.consul.catalog.v2beta1.Protocol
-	(*HTTPRouteMatch)(nil),              // 20: hashicorp.consul.mesh.v2beta1.HTTPRouteMatch
-	(*HTTPRouteFilter)(nil),             // 21: hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
-	(*HTTPRouteTimeouts)(nil),           // 22: hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
-	(*HTTPRouteRetries)(nil),            // 23: hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
-	(*GRPCRouteMatch)(nil),              // 24: hashicorp.consul.mesh.v2beta1.GRPCRouteMatch
-	(*GRPCRouteFilter)(nil),             // 25: hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
-	(*BackendReference)(nil),            // 26: hashicorp.consul.mesh.v2beta1.BackendReference
-	(*DestinationConfig)(nil),           // 27: hashicorp.consul.mesh.v2beta1.DestinationConfig
-	(*pbproxystate.EndpointRef)(nil),    // 28: hashicorp.consul.mesh.v2beta1.pbproxystate.EndpointRef
-	(v2beta1.FailoverMode)(0),           // 29: hashicorp.consul.catalog.v2beta1.FailoverMode
+	(*ComputedFailoverTier)(nil),        // 14: hashicorp.consul.mesh.v2beta1.ComputedFailoverTier
+	(*ComputedFailoverDestination)(nil), // 15: hashicorp.consul.mesh.v2beta1.ComputedFailoverDestination
+	nil,                                 // 16: hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry
+	nil,                                 // 17: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry
+	(*pbresource.Reference)(nil),        // 18: hashicorp.consul.resource.Reference
+	(*ParentReference)(nil),             // 19: hashicorp.consul.mesh.v2beta1.ParentReference
+	(v2beta1.Protocol)(0),               // 20: hashicorp.consul.catalog.v2beta1.Protocol
+	(*HTTPRouteMatch)(nil),              // 21: hashicorp.consul.mesh.v2beta1.HTTPRouteMatch
+	(*HTTPRouteFilter)(nil),             // 22: hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
+	(*HTTPRouteTimeouts)(nil),           // 23: hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
+	(*HTTPRouteRetries)(nil),            // 24: hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
+	(*GRPCRouteMatch)(nil),              // 25: hashicorp.consul.mesh.v2beta1.GRPCRouteMatch
+	(*GRPCRouteFilter)(nil),             // 26: hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
+	(*BackendReference)(nil),            // 27: hashicorp.consul.mesh.v2beta1.BackendReference
+	(*DestinationConfig)(nil),           // 28: hashicorp.consul.mesh.v2beta1.DestinationConfig
+	(*pbproxystate.EndpointRef)(nil),    // 29: hashicorp.consul.mesh.v2beta1.pbproxystate.EndpointRef
+	(v2beta1.FailoverMode)(0),           // 30: hashicorp.consul.catalog.v2beta1.FailoverMode
 }
 var file_pbmesh_v2beta1_computed_routes_proto_depIdxs = []int32{
-	15, // 0: hashicorp.consul.mesh.v2beta1.ComputedRoutes.ported_configs:type_name -> hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry
-	17, // 1: hashicorp.consul.mesh.v2beta1.ComputedRoutes.bound_references:type_name -> hashicorp.consul.resource.Reference
+	16, // 0: hashicorp.consul.mesh.v2beta1.ComputedRoutes.ported_configs:type_name -> hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry
+	18, // 1: hashicorp.consul.mesh.v2beta1.ComputedRoutes.bound_references:type_name -> hashicorp.consul.resource.Reference
 	3,  // 2: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.http:type_name -> hashicorp.consul.mesh.v2beta1.ComputedHTTPRoute
 	6,  // 3: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.grpc:type_name -> hashicorp.consul.mesh.v2beta1.ComputedGRPCRoute
 	9,  // 4: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.tcp:type_name -> hashicorp.consul.mesh.v2beta1.ComputedTCPRoute
-	18, // 5: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.parent_ref:type_name -> hashicorp.consul.mesh.v2beta1.ParentReference
-	19, // 6: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.protocol:type_name -> hashicorp.consul.catalog.v2beta1.Protocol
-	16, // 7: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.targets:type_name -> hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry
+	19, // 5: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.parent_ref:type_name -> hashicorp.consul.mesh.v2beta1.ParentReference
+	20, // 6: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.protocol:type_name -> hashicorp.consul.catalog.v2beta1.Protocol
+	17, // 7: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.targets:type_name -> hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry
 	4,  // 8: hashicorp.consul.mesh.v2beta1.ComputedHTTPRoute.rules:type_name -> hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule
-	20, // 9: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.matches:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteMatch
-	21, // 10: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.filters:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
+	21, // 9: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.matches:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteMatch
+	22, // 10: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.filters:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
 	5,  // 11: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.backend_refs:type_name -> hashicorp.consul.mesh.v2beta1.ComputedHTTPBackendRef
-	22, // 12: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.timeouts:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
-	23, // 13: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.retries:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
-	21, // 14: hashicorp.consul.mesh.v2beta1.ComputedHTTPBackendRef.filters:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
+	23, // 12: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.timeouts:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
+	24, // 13: hashicorp.consul.mesh.v2beta1.ComputedHTTPRouteRule.retries:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
+	22, // 14: hashicorp.consul.mesh.v2beta1.ComputedHTTPBackendRef.filters:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteFilter
 	7,  // 15: hashicorp.consul.mesh.v2beta1.ComputedGRPCRoute.rules:type_name -> hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule
-	24, // 16: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.matches:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteMatch
-	25, // 17: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.filters:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
+	25, // 16: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.matches:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteMatch
+	26, // 17: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.filters:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
 	8,  // 18: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.backend_refs:type_name -> hashicorp.consul.mesh.v2beta1.ComputedGRPCBackendRef
-	22, // 19: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.timeouts:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
-	23, // 20: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.retries:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
-	25, // 21: hashicorp.consul.mesh.v2beta1.ComputedGRPCBackendRef.filters:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
+	23, // 19: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.timeouts:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteTimeouts
+	24, // 20: hashicorp.consul.mesh.v2beta1.ComputedGRPCRouteRule.retries:type_name -> hashicorp.consul.mesh.v2beta1.HTTPRouteRetries
+	26, // 21: hashicorp.consul.mesh.v2beta1.ComputedGRPCBackendRef.filters:type_name -> hashicorp.consul.mesh.v2beta1.GRPCRouteFilter
 	10, // 22: hashicorp.consul.mesh.v2beta1.ComputedTCPRoute.rules:type_name -> hashicorp.consul.mesh.v2beta1.ComputedTCPRouteRule
 	11, // 23: hashicorp.consul.mesh.v2beta1.ComputedTCPRouteRule.backend_refs:type_name -> hashicorp.consul.mesh.v2beta1.ComputedTCPBackendRef
 	0,  // 24: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.type:type_name -> hashicorp.consul.mesh.v2beta1.BackendTargetDetailsType
-	26, // 25: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.backend_ref:type_name -> hashicorp.consul.mesh.v2beta1.BackendReference
+	27, // 25: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.backend_ref:type_name -> hashicorp.consul.mesh.v2beta1.BackendReference
 	13, // 26: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.failover_config:type_name -> hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig
-	27, // 27: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.destination_config:type_name -> hashicorp.consul.mesh.v2beta1.DestinationConfig
-	28, // 28: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.service_endpoints_ref:type_name -> hashicorp.consul.mesh.v2beta1.pbproxystate.EndpointRef
-	17, // 29: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.identity_refs:type_name -> hashicorp.consul.resource.Reference
-	14, // 30: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig.destinations:type_name -> hashicorp.consul.mesh.v2beta1.ComputedFailoverDestination
-	29, // 31: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig.mode:type_name -> hashicorp.consul.catalog.v2beta1.FailoverMode
-	2,  // 32: hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry.value:type_name -> hashicorp.consul.mesh.v2beta1.ComputedPortRoutes
-	12, // 33: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry.value:type_name -> hashicorp.consul.mesh.v2beta1.BackendTargetDetails
-	34, // [34:34] is the sub-list for method output_type
-	34, // [34:34] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
+	28, // 27: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.destination_config:type_name -> hashicorp.consul.mesh.v2beta1.DestinationConfig
+	29, // 28: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.service_endpoints_ref:type_name -> hashicorp.consul.mesh.v2beta1.pbproxystate.EndpointRef
+	18, // 29: hashicorp.consul.mesh.v2beta1.BackendTargetDetails.identity_refs:type_name -> hashicorp.consul.resource.Reference
+	15, // 30: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig.destinations:type_name -> hashicorp.consul.mesh.v2beta1.ComputedFailoverDestination
+	30, // 31: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig.mode:type_name -> hashicorp.consul.catalog.v2beta1.FailoverMode
+	14, // 32: hashicorp.consul.mesh.v2beta1.ComputedFailoverConfig.tiers:type_name -> hashicorp.consul.mesh.v2beta1.ComputedFailoverTier
+	15, // 33: hashicorp.consul.mesh.v2beta1.ComputedFailoverTier.destinations:type_name -> hashicorp.consul.mesh.v2beta1.ComputedFailoverDestination
+	2,  // 34: hashicorp.consul.mesh.v2beta1.ComputedRoutes.PortedConfigsEntry.value:type_name -> hashicorp.consul.mesh.v2beta1.ComputedPortRoutes
+	12, // 35: hashicorp.consul.mesh.v2beta1.ComputedPortRoutes.TargetsEntry.value:type_name -> hashicorp.consul.mesh.v2beta1.BackendTargetDetails
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_pbmesh_v2beta1_computed_routes_proto_init() }
@@ -1586,6 +1666,18 @@ func file_pbmesh_v2beta1_computed_routes_proto_init() {
 			}
 		}
 		file_pbmesh_v2beta1_computed_routes_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComputedFailoverTier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pbmesh_v2beta1_computed_routes_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ComputedFailoverDestination); i {
 			case 0:
 				return &v.state
@@ -1609,7 +1701,7 @@ func file_pbmesh_v2beta1_computed_routes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pbmesh_v2beta1_computed_routes_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   16,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
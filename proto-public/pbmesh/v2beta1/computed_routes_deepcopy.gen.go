@@ -278,6 +278,27 @@ func (in *ComputedFailoverConfig) DeepCopyInterface() interface{} {
 	return in.DeepCopy()
 }
 
+// DeepCopyInto supports using ComputedFailoverTier within kubernetes types, where deepcopy-gen is used.
+func (in *ComputedFailoverTier) DeepCopyInto(out *ComputedFailoverTier) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComputedFailoverTier. Required by controller-gen.
+func (in *ComputedFailoverTier) DeepCopy() *ComputedFailoverTier {
+	if in == nil {
+		return nil
+	}
+	out := new(ComputedFailoverTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new ComputedFailoverTier. Required by controller-gen.
+func (in *ComputedFailoverTier) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
 // DeepCopyInto supports using ComputedFailoverDestination within kubernetes types, where deepcopy-gen is used.
 func (in *ComputedFailoverDestination) DeepCopyInto(out *ComputedFailoverDestination) {
 	proto.Reset(out)
@@ -148,6 +148,17 @@ func (this *ComputedFailoverConfig) UnmarshalJSON(b []byte) error {
 	return ComputedRoutesUnmarshaler.Unmarshal(b, this)
 }
 
+// MarshalJSON is a custom marshaler for ComputedFailoverTier
+func (this *ComputedFailoverTier) MarshalJSON() ([]byte, error) {
+	str, err := ComputedRoutesMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for ComputedFailoverTier
+func (this *ComputedFailoverTier) UnmarshalJSON(b []byte) error {
+	return ComputedRoutesUnmarshaler.Unmarshal(b, this)
+}
+
 // MarshalJSON is a custom marshaler for ComputedFailoverDestination
 func (this *ComputedFailoverDestination) MarshalJSON() ([]byte, error) {
 	str, err := ComputedRoutesMarshaler.Marshal(this)
@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-deepcopy. DO NOT EDIT.
+package pbregistration
+
+import (
+	proto "google.golang.org/protobuf/proto"
+)
+
+// DeepCopyInto supports using RegisterWorkloadRequest within kubernetes types, where deepcopy-gen is used.
+func (in *RegisterWorkloadRequest) DeepCopyInto(out *RegisterWorkloadRequest) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisterWorkloadRequest. Required by controller-gen.
+func (in *RegisterWorkloadRequest) DeepCopy() *RegisterWorkloadRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisterWorkloadRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new RegisterWorkloadRequest. Required by controller-gen.
+func (in *RegisterWorkloadRequest) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Registration within kubernetes types, where deepcopy-gen is used.
+func (in *Registration) DeepCopyInto(out *Registration) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Registration. Required by controller-gen.
+func (in *Registration) DeepCopy() *Registration {
+	if in == nil {
+		return nil
+	}
+	out := new(Registration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Registration. Required by controller-gen.
+func (in *Registration) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Service within kubernetes types, where deepcopy-gen is used.
+func (in *Service) DeepCopyInto(out *Service) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service. Required by controller-gen.
+func (in *Service) DeepCopy() *Service {
+	if in == nil {
+		return nil
+	}
+	out := new(Service)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Service. Required by controller-gen.
+func (in *Service) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using Renew within kubernetes types, where deepcopy-gen is used.
+func (in *Renew) DeepCopyInto(out *Renew) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Renew. Required by controller-gen.
+func (in *Renew) DeepCopy() *Renew {
+	if in == nil {
+		return nil
+	}
+	out := new(Renew)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new Renew. Required by controller-gen.
+func (in *Renew) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto supports using RegisterWorkloadResponse within kubernetes types, where deepcopy-gen is used.
+func (in *RegisterWorkloadResponse) DeepCopyInto(out *RegisterWorkloadResponse) {
+	proto.Reset(out)
+	proto.Merge(out, proto.Clone(in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisterWorkloadResponse. Required by controller-gen.
+func (in *RegisterWorkloadResponse) DeepCopy() *RegisterWorkloadResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisterWorkloadResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInterface is an autogenerated deepcopy function, copying the receiver, creating a new RegisterWorkloadResponse. Required by controller-gen.
+func (in *RegisterWorkloadResponse) DeepCopyInterface() interface{} {
+	return in.DeepCopy()
+}
@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: pbregistration/registration.proto
+
+package pbregistration
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RegistrationServiceClient is the client API for RegistrationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RegistrationServiceClient interface {
+	// RegisterWorkload registers a node and its services. The first message on
+	// the stream must be a registration, and each following message renews the
+	// lease. Each registration and renewal is acknowledged with a response.
+	//
+	// Sending a new registration on the same stream replaces the previous one.
+	// If the stream breaks, the registration can be taken over by a new stream
+	// registering the same node before the lease expires.
+	RegisterWorkload(ctx context.Context, opts ...grpc.CallOption) (RegistrationService_RegisterWorkloadClient, error)
+}
+
+type registrationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRegistrationServiceClient(cc grpc.ClientConnInterface) RegistrationServiceClient {
+	return &registrationServiceClient{cc}
+}
+
+func (c *registrationServiceClient) RegisterWorkload(ctx context.Context, opts ...grpc.CallOption) (RegistrationService_RegisterWorkloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RegistrationService_ServiceDesc.Streams[0], "/hashicorp.consul.registration.RegistrationService/RegisterWorkload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registrationServiceRegisterWorkloadClient{stream}
+	return x, nil
+}
+
+type RegistrationService_RegisterWorkloadClient interface {
+	Send(*RegisterWorkloadRequest) error
+	Recv() (*RegisterWorkloadResponse, error)
+	grpc.ClientStream
+}
+
+type registrationServiceRegisterWorkloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *registrationServiceRegisterWorkloadClient) Send(m *RegisterWorkloadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *registrationServiceRegisterWorkloadClient) Recv() (*RegisterWorkloadResponse, error) {
+	m := new(RegisterWorkloadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegistrationServiceServer is the server API for RegistrationService service.
+// All implementations should embed UnimplementedRegistrationServiceServer
+// for forward compatibility
+type RegistrationServiceServer interface {
+	// RegisterWorkload registers a node and its services. The first message on
+	// the stream must be a registration, and each following message renews the
+	// lease. Each registration and renewal is acknowledged with a response.
+	//
+	// Sending a new registration on the same stream replaces the previous one.
+	// If the stream breaks, the registration can be taken over by a new stream
+	// registering the same node before the lease expires.
+	RegisterWorkload(RegistrationService_RegisterWorkloadServer) error
+}
+
+// UnimplementedRegistrationServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedRegistrationServiceServer struct {
+}
+
+func (UnimplementedRegistrationServiceServer) RegisterWorkload(RegistrationService_RegisterWorkloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method RegisterWorkload not implemented")
+}
+
+// UnsafeRegistrationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RegistrationServiceServer will
+// result in compilation errors.
+type UnsafeRegistrationServiceServer interface {
+	mustEmbedUnimplementedRegistrationServiceServer()
+}
+
+func RegisterRegistrationServiceServer(s grpc.ServiceRegistrar, srv RegistrationServiceServer) {
+	s.RegisterService(&RegistrationService_ServiceDesc, srv)
+}
+
+func _RegistrationService_RegisterWorkload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RegistrationServiceServer).RegisterWorkload(&registrationServiceRegisterWorkloadServer{stream})
+}
+
+type RegistrationService_RegisterWorkloadServer interface {
+	Send(*RegisterWorkloadResponse) error
+	Recv() (*RegisterWorkloadRequest, error)
+	grpc.ServerStream
+}
+
+type registrationServiceRegisterWorkloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *registrationServiceRegisterWorkloadServer) Send(m *RegisterWorkloadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *registrationServiceRegisterWorkloadServer) Recv() (*RegisterWorkloadRequest, error) {
+	m := new(RegisterWorkloadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegistrationService_ServiceDesc is the grpc.ServiceDesc for RegistrationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RegistrationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.consul.registration.RegistrationService",
+	HandlerType: (*RegistrationServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RegisterWorkload",
+			Handler:       _RegistrationService_RegisterWorkload_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pbregistration/registration.proto",
+}
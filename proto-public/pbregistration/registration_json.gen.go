@@ -0,0 +1,66 @@
+// Code generated by protoc-json-shim. DO NOT EDIT.
+package pbregistration
+
+import (
+	protojson "google.golang.org/protobuf/encoding/protojson"
+)
+
+// MarshalJSON is a custom marshaler for RegisterWorkloadRequest
+func (this *RegisterWorkloadRequest) MarshalJSON() ([]byte, error) {
+	str, err := RegistrationMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for RegisterWorkloadRequest
+func (this *RegisterWorkloadRequest) UnmarshalJSON(b []byte) error {
+	return RegistrationUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Registration
+func (this *Registration) MarshalJSON() ([]byte, error) {
+	str, err := RegistrationMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Registration
+func (this *Registration) UnmarshalJSON(b []byte) error {
+	return RegistrationUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Service
+func (this *Service) MarshalJSON() ([]byte, error) {
+	str, err := RegistrationMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Service
+func (this *Service) UnmarshalJSON(b []byte) error {
+	return RegistrationUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for Renew
+func (this *Renew) MarshalJSON() ([]byte, error) {
+	str, err := RegistrationMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for Renew
+func (this *Renew) UnmarshalJSON(b []byte) error {
+	return RegistrationUnmarshaler.Unmarshal(b, this)
+}
+
+// MarshalJSON is a custom marshaler for RegisterWorkloadResponse
+func (this *RegisterWorkloadResponse) MarshalJSON() ([]byte, error) {
+	str, err := RegistrationMarshaler.Marshal(this)
+	return []byte(str), err
+}
+
+// UnmarshalJSON is a custom unmarshaler for RegisterWorkloadResponse
+func (this *RegisterWorkloadResponse) UnmarshalJSON(b []byte) error {
+	return RegistrationUnmarshaler.Unmarshal(b, this)
+}
+
+var (
+	RegistrationMarshaler   = &protojson.MarshalOptions{}
+	RegistrationUnmarshaler = &protojson.UnmarshalOptions{DiscardUnknown: false}
+)
@@ -72,6 +72,7 @@ func QueryOptionsToStructs(s *QueryOptions, t *structs.QueryOptions) {
 	t.MaxAge = structs.DurationFromProto(s.MaxAge)
 	t.MustRevalidate = s.MustRevalidate
 	t.Filter = s.Filter
+	t.BoundedStaleness = structs.DurationFromProto(s.BoundedStaleness)
 }
 func QueryOptionsFromStructs(t *structs.QueryOptions, s *QueryOptions) {
 	if s == nil {
@@ -87,6 +88,7 @@ func QueryOptionsFromStructs(t *structs.QueryOptions, s *QueryOptions) {
 	s.MaxAge = structs.DurationToProto(t.MaxAge)
 	s.MustRevalidate = t.MustRevalidate
 	s.Filter = t.Filter
+	s.BoundedStaleness = structs.DurationToProto(t.BoundedStaleness)
 }
 func RaftIndexToStructs(s *RaftIndex, t *structs.RaftIndex) {
 	if s == nil {
@@ -78,6 +78,20 @@ func (q *QueryOptions) SetStaleIfError(staleIfError time.Duration) {
 	q.StaleIfError = durationpb.New(staleIfError)
 }
 
+// MaxStaleness returns the bound on the staleness of the data a follower may
+// serve the request from, or zero if unbounded.
+func (q *QueryOptions) MaxStaleness() time.Duration {
+	if !q.AllowStale {
+		return 0
+	}
+	return structs.DurationFromProto(q.BoundedStaleness)
+}
+
+// SetBoundedStaleness is needed to implement the structs.QueryOptionsCompat interface
+func (q *QueryOptions) SetBoundedStaleness(boundedStaleness time.Duration) {
+	q.BoundedStaleness = durationpb.New(boundedStaleness)
+}
+
 func (q *QueryOptions) HasTimedOut(start time.Time, rpcHoldTimeout, maxQueryTime, defaultQueryTime time.Duration) (bool, error) {
 	// In addition to BlockingTimeout, allow for an additional rpcHoldTimeout buffer
 	// in case we need to wait for a leader election.
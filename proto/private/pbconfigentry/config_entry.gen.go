@@ -317,6 +317,7 @@ func ExportedServicesConsumerToStructs(s *ExportedServicesConsumer, t *structs.S
 	t.Partition = s.Partition
 	t.Peer = s.Peer
 	t.SamenessGroup = s.SamenessGroup
+	t.Selector = s.Selector
 }
 func ExportedServicesConsumerFromStructs(t *structs.ServiceConsumer, s *ExportedServicesConsumer) {
 	if s == nil {
@@ -325,6 +326,7 @@ func ExportedServicesConsumerFromStructs(t *structs.ServiceConsumer, s *Exported
 	s.Partition = t.Partition
 	s.Peer = t.Peer
 	s.SamenessGroup = t.SamenessGroup
+	s.Selector = t.Selector
 }
 func ExportedServicesServiceToStructs(s *ExportedServicesService, t *structs.ExportedService) {
 	if s == nil {
@@ -332,6 +334,7 @@ func ExportedServicesServiceToStructs(s *ExportedServicesService, t *structs.Exp
 	}
 	t.Name = s.Name
 	t.Namespace = s.Namespace
+	t.Selector = s.Selector
 	{
 		t.Consumers = make([]structs.ServiceConsumer, len(s.Consumers))
 		for i := range s.Consumers {
@@ -347,6 +350,7 @@ func ExportedServicesServiceFromStructs(t *structs.ExportedService, s *ExportedS
 	}
 	s.Name = t.Name
 	s.Namespace = t.Namespace
+	s.Selector = t.Selector
 	{
 		s.Consumers = make([]*ExportedServicesConsumer, len(t.Consumers))
 		for i := range t.Consumers {
@@ -2416,6 +2420,9 @@ func SourceIntentionToStructs(s *SourceIntention, t *structs.SourceIntention) {
 	t.EnterpriseMeta = enterpriseMetaToStructs(s.EnterpriseMeta)
 	t.Peer = s.Peer
 	t.SamenessGroup = s.SamenessGroup
+	t.ReviewBy = timeToStructs(s.ReviewBy)
+	t.ExpiresAt = timeToStructs(s.ExpiresAt)
+	t.Disabled = s.Disabled
 }
 func SourceIntentionFromStructs(t *structs.SourceIntention, s *SourceIntention) {
 	if s == nil {
@@ -2443,6 +2450,9 @@ func SourceIntentionFromStructs(t *structs.SourceIntention, s *SourceIntention)
 	s.EnterpriseMeta = enterpriseMetaFromStructs(t.EnterpriseMeta)
 	s.Peer = t.Peer
 	s.SamenessGroup = t.SamenessGroup
+	s.ReviewBy = timeFromStructs(t.ReviewBy)
+	s.ExpiresAt = timeFromStructs(t.ExpiresAt)
+	s.Disabled = t.Disabled
 }
 func StatusToStructs(s *Status, t *structs.Status) {
 	if s == nil {
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pbconfigentry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestConfigEntry_ServiceIntentions_RoundTrip(t *testing.T) {
+	reviewBy := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := time.Date(2031, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	entry := &structs.ServiceIntentionsConfigEntry{
+		Kind: structs.ServiceIntentions,
+		Name: "web",
+		Sources: []*structs.SourceIntention{
+			{
+				Name:      "api",
+				Action:    structs.IntentionActionAllow,
+				Type:      structs.IntentionSourceConsul,
+				ReviewBy:  &reviewBy,
+				ExpiresAt: &expiresAt,
+				Disabled:  true,
+			},
+			{
+				Name:   "db",
+				Action: structs.IntentionActionDeny,
+				Type:   structs.IntentionSourceConsul,
+			},
+		},
+	}
+
+	out, ok := ConfigEntryToStructs(ConfigEntryFromStructs(entry)).(*structs.ServiceIntentionsConfigEntry)
+	require.True(t, ok)
+	require.Len(t, out.Sources, 2)
+
+	src := out.Sources[0]
+	require.NotNil(t, src.ReviewBy)
+	require.True(t, reviewBy.Equal(*src.ReviewBy))
+	require.NotNil(t, src.ExpiresAt)
+	require.True(t, expiresAt.Equal(*src.ExpiresAt))
+	require.True(t, src.Disabled)
+
+	src = out.Sources[1]
+	require.Nil(t, src.ReviewBy)
+	require.Nil(t, src.ExpiresAt)
+	require.False(t, src.Disabled)
+}
@@ -62,6 +62,8 @@ func CARootsToStructsIndexedCARoots(s *CARoots, t *structs.IndexedCARoots) {
 			}
 		}
 	}
+	t.RevokedSerialNumbers = s.RevokedSerialNumbers
+	t.RevocationList = s.RevocationList
 	t.QueryMeta = QueryMetaTo(s.QueryMeta)
 }
 func CARootsFromStructsIndexedCARoots(t *structs.IndexedCARoots, s *CARoots) {
@@ -80,6 +82,8 @@ func CARootsFromStructsIndexedCARoots(t *structs.IndexedCARoots, s *CARoots) {
 			}
 		}
 	}
+	s.RevokedSerialNumbers = t.RevokedSerialNumbers
+	s.RevocationList = t.RevocationList
 	s.QueryMeta = QueryMetaFrom(t.QueryMeta)
 }
 func IssuedCertToStructsIssuedCert(s *IssuedCert, t *structs.IssuedCert) {
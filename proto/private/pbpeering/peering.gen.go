@@ -12,6 +12,7 @@ func EstablishRequestToAPI(s *EstablishRequest, t *api.PeeringEstablishRequest)
 	t.PeeringToken = s.PeeringToken
 	t.Partition = s.Partition
 	t.Meta = s.Meta
+	t.ACLPolicies = s.ACLPolicies
 }
 func EstablishRequestFromAPI(t *api.PeeringEstablishRequest, s *EstablishRequest) {
 	if s == nil {
@@ -21,6 +22,7 @@ func EstablishRequestFromAPI(t *api.PeeringEstablishRequest, s *EstablishRequest
 	s.PeeringToken = t.PeeringToken
 	s.Partition = t.Partition
 	s.Meta = t.Meta
+	s.ACLPolicies = t.ACLPolicies
 }
 func EstablishResponseToAPI(s *EstablishResponse, t *api.PeeringEstablishResponse) {
 	if s == nil {
@@ -40,6 +42,8 @@ func GenerateTokenRequestToAPI(s *GenerateTokenRequest, t *api.PeeringGenerateTo
 	t.Partition = s.Partition
 	t.Meta = s.Meta
 	t.ServerExternalAddresses = s.ServerExternalAddresses
+	t.ACLPolicies = s.ACLPolicies
+	t.ClearACLPolicies = s.ClearACLPolicies
 }
 func GenerateTokenRequestFromAPI(t *api.PeeringGenerateTokenRequest, s *GenerateTokenRequest) {
 	if s == nil {
@@ -49,6 +53,8 @@ func GenerateTokenRequestFromAPI(t *api.PeeringGenerateTokenRequest, s *Generate
 	s.Partition = t.Partition
 	s.Meta = t.Meta
 	s.ServerExternalAddresses = t.ServerExternalAddresses
+	s.ACLPolicies = t.ACLPolicies
+	s.ClearACLPolicies = t.ClearACLPolicies
 }
 func GenerateTokenResponseToAPI(s *GenerateTokenResponse, t *api.PeeringGenerateTokenResponse) {
 	if s == nil {
@@ -82,6 +88,7 @@ func PeeringToAPI(s *Peering, t *api.Peering) {
 	if s.Remote != nil {
 		RemoteInfoToAPI(s.Remote, &t.Remote)
 	}
+	t.ACLPolicies = s.ACLPolicies
 }
 func PeeringFromAPI(t *api.Peering, s *Peering) {
 	if s == nil {
@@ -105,6 +112,7 @@ func PeeringFromAPI(t *api.Peering, s *Peering) {
 		RemoteInfoFromAPI(&t.Remote, &x)
 		s.Remote = &x
 	}
+	s.ACLPolicies = t.ACLPolicies
 }
 func RemoteInfoToAPI(s *RemoteInfo, t *api.PeeringRemoteInfo) {
 	if s == nil {
@@ -31,6 +31,8 @@ func CheckTypeToStructs(s *CheckType, t *structs.CheckType) {
 	t.GRPC = s.GRPC
 	t.GRPCUseTLS = s.GRPCUseTLS
 	t.OSService = s.OSService
+	t.Plugin = s.Plugin
+	t.PluginConfig = s.PluginConfig
 	t.TLSServerName = s.TLSServerName
 	t.TLSSkipVerify = s.TLSSkipVerify
 	t.Timeout = structs.DurationFromProto(s.Timeout)
@@ -70,6 +72,8 @@ func CheckTypeFromStructs(t *structs.CheckType, s *CheckType) {
 	s.GRPC = t.GRPC
 	s.GRPCUseTLS = t.GRPCUseTLS
 	s.OSService = t.OSService
+	s.Plugin = t.Plugin
+	s.PluginConfig = t.PluginConfig
 	s.TLSServerName = t.TLSServerName
 	s.TLSSkipVerify = t.TLSSkipVerify
 	s.Timeout = structs.DurationToProto(t.Timeout)
@@ -3,8 +3,33 @@
 
 package service_os
 
+import "sync"
+
 var chanGraceExit = make(chan int)
 
+var (
+	chanReady = make(chan struct{})
+	readyOnce sync.Once
+
+	chanExit = make(chan int, 1)
+	exitOnce sync.Once
+)
+
 func Shutdown_Channel() <-chan int {
 	return chanGraceExit
 }
+
+// Ready reports to the service manager that the agent has started. Until then
+// the service is reported as starting, and does not accept stop requests.
+func Ready() {
+	readyOnce.Do(func() { close(chanReady) })
+}
+
+// Exit reports the exit code of the process to the service manager, and waits
+// for the service to be reported as stopped. A non-zero code is reported as a
+// service specific error, so that the service manager applies the recovery
+// actions configured for the service. It returns immediately when the process
+// is not running as a service.
+func Exit(code int) {
+	exitOnce.Do(func() { exit(code) })
+}
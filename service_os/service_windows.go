@@ -6,11 +6,33 @@
 package service_os
 
 import (
+	"time"
+
 	wsvc "golang.org/x/sys/windows/svc"
 )
 
+const (
+	// startCheckpointInterval is how often the progress of the start is
+	// reported to the service manager, which considers a service hung when
+	// it doesn't report progress within the wait hint.
+	startCheckpointInterval = 5 * time.Second
+	startWaitHint           = 30 * time.Second
+
+	// stopWaitHint leaves time for the agent to gracefully leave the
+	// cluster.
+	stopWaitHint = 30 * time.Second
+
+	// exitTimeout bounds how long Exit waits for the service manager.
+	exitTimeout = 10 * time.Second
+)
+
 type serviceWindows struct{}
 
+var (
+	isService   bool
+	chanStopped = make(chan struct{})
+)
+
 func init() {
 	interactive, err := wsvc.IsAnInteractiveSession()
 	if err != nil {
@@ -19,27 +41,82 @@ func init() {
 	if interactive {
 		return
 	}
+	isService = true
 	go func() {
 		_ = wsvc.Run("", serviceWindows{})
+		close(chanStopped)
 	}()
 }
 
+func exit(code int) {
+	if !isService {
+		return
+	}
+	chanExit <- code
+	select {
+	case <-chanStopped:
+	case <-time.After(exitTimeout):
+	}
+}
+
 func (serviceWindows) Execute(args []string, r <-chan wsvc.ChangeRequest, s chan<- wsvc.Status) (svcSpecificEC bool, exitCode uint32) {
 	const accCommands = wsvc.AcceptStop | wsvc.AcceptShutdown
-	s <- wsvc.Status{State: wsvc.StartPending}
+
+	status := wsvc.Status{State: wsvc.StartPending, WaitHint: uint32(startWaitHint / time.Millisecond)}
+	s <- status
+
+	ticker := time.NewTicker(startCheckpointInterval)
+	defer ticker.Stop()
+
+starting:
+	for {
+		select {
+		case <-chanReady:
+			break starting
+		case <-ticker.C:
+			status.CheckPoint++
+			s <- status
+		case c := <-r:
+			if c.Cmd == wsvc.Interrogate {
+				s <- status
+			}
+		case code := <-chanExit:
+			// The agent failed to start.
+			return exitStatus(code)
+		}
+	}
 
 	s <- wsvc.Status{State: wsvc.Running, Accepts: accCommands}
 	for {
-		c := <-r
-		switch c.Cmd {
-		case wsvc.Interrogate:
-			s <- c.CurrentStatus
-		case wsvc.Stop, wsvc.Shutdown:
-			chanGraceExit <- 1
-			s <- wsvc.Status{State: wsvc.StopPending}
-			return false, 0
+		select {
+		case c := <-r:
+			switch c.Cmd {
+			case wsvc.Interrogate:
+				s <- c.CurrentStatus
+			case wsvc.Stop, wsvc.Shutdown:
+				s <- wsvc.Status{State: wsvc.StopPending, WaitHint: uint32(stopWaitHint / time.Millisecond)}
+				select {
+				case chanGraceExit <- 1:
+					<-chanExit
+				case <-chanExit:
+				}
+				// The exit code of a requested stop is not a failure, which
+				// would make the service manager restart the service.
+				return false, 0
+			}
+		case code := <-chanExit:
+			// The agent stopped on its own, like when it failed to join
+			// the cluster.
+			return exitStatus(code)
 		}
 	}
+}
 
-	return false, 0
+// exitStatus returns the exit status of the service for the exit code of the
+// agent.
+func exitStatus(code int) (bool, uint32) {
+	if code == 0 {
+		return false, 0
+	}
+	return true, uint32(code)
 }
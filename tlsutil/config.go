@@ -84,6 +84,10 @@ type ProtocolConfig struct {
 	// the likelihood of an operator inadvertently setting an insecure configuration
 	CipherSuites []types.TLSCipherSuite
 
+	// CurvePreferences is the list of elliptic curves used in ECDHE key
+	// exchange, in preference order. When empty the Go defaults are used.
+	CurvePreferences []types.TLSCurve
+
 	// VerifyOutgoing is used to verify the authenticity of outgoing
 	// connections.  This means that TLS requests are used, and TCP
 	// requests are not made. TLS connections must match a provided
@@ -600,6 +604,12 @@ func (c *Configurator) commonTLSConfig(state protocolConfig, cfg ProtocolConfig,
 		tlsConfig.CipherSuites = cipherSuites
 	}
 
+	// Curves are also validated in the agent config builder.
+	if len(cfg.CurvePreferences) != 0 {
+		curves, _ := curveLookup(cfg.CurvePreferences)
+		tlsConfig.CurvePreferences = curves
+	}
+
 	// GetCertificate is used when acting as a server and responding to
 	// client requests. Default to the manually configured cert, but allow
 	// autoEncrypt cert too so that a client can encrypt incoming
@@ -1178,6 +1188,27 @@ func cipherSuiteLookup(ciphers []types.TLSCipherSuite) ([]uint16, error) {
 	return suites, nil
 }
 
+var goTLSCurves = map[types.TLSCurve]tls.CurveID{
+	types.TLSCurveX25519: tls.X25519,
+	types.TLSCurveP256:   tls.CurveP256,
+	types.TLSCurveP384:   tls.CurveP384,
+	types.TLSCurveP521:   tls.CurveP521,
+}
+
+func curveLookup(curves []types.TLSCurve) ([]tls.CurveID, error) {
+	ids := make([]tls.CurveID, 0, len(curves))
+
+	for _, curve := range curves {
+		if v, ok := goTLSCurves[curve]; ok {
+			ids = append(ids, v)
+		} else {
+			return ids, fmt.Errorf("unsupported curve %q", curve)
+		}
+	}
+
+	return ids, nil
+}
+
 // CipherString performs the inverse operation of types.ParseCiphers
 func CipherString(ciphers []types.TLSCipherSuite) (string, error) {
 	err := types.ValidateConsulAgentCipherSuites(ciphers)
@@ -94,6 +94,28 @@ func TestConfigurator_IncomingConfig_Common(t *testing.T) {
 				require.Equal(t, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, cipherSuite)
 			})
 
+			t.Run("CurvePreferences", func(t *testing.T) {
+				cfg := ProtocolConfig{
+					CurvePreferences: []types.TLSCurve{types.TLSCurveP384},
+					CertFile:         "../test/hostname/Alice.crt",
+					KeyFile:          "../test/hostname/Alice.key",
+				}
+				c := makeConfigurator(t, tc.setupFn(cfg))
+
+				client, errc, _ := startTLSServer(tc.configFn(c))
+				if client == nil {
+					t.Fatalf("startTLSServer err: %v", <-errc)
+				}
+
+				tlsClient := tls.Client(client, &tls.Config{
+					InsecureSkipVerify: true,
+					CurvePreferences:   []tls.CurveID{tls.X25519},
+				})
+				err := tlsClient.Handshake()
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "handshake failure")
+			})
+
 			t.Run("manually configured certificate is preferred over AutoTLS", func(t *testing.T) {
 				// Manually configure Alice's certifcate.
 				cfg := ProtocolConfig{
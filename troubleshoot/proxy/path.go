@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package troubleshoot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/consul/troubleshoot/validate"
+)
+
+// PathRequest identifies the request path to trace, from a source service's
+// proxy to every target its discovery chain for the destination resolves to.
+type PathRequest struct {
+	// Source is the name of the service sending the request.
+	Source string
+
+	// Destination is the name of the upstream service the source calls.
+	Destination string
+
+	// UpstreamEnvoyID and UpstreamIP identify the upstream in the source's
+	// Envoy configuration, as for RunAllTests. UpstreamEnvoyID defaults to
+	// Destination when neither is set.
+	UpstreamEnvoyID string
+	UpstreamIP      string
+
+	// SkipSourceProxy skips validating the source's Envoy configuration, for
+	// when the command is not run next to the source proxy.
+	SkipSourceProxy bool
+}
+
+// PathHop is one step of a request path and the results of validating it.
+type PathHop struct {
+	Name     string
+	Messages validate.Messages
+}
+
+// Path is the ordered list of hops a request takes from the source proxy to
+// the destination.
+type Path []PathHop
+
+// Success returns true if every hop of the path is valid.
+func (p Path) Success() bool {
+	return p.FirstFailure() == nil
+}
+
+// FirstFailure returns the first hop along the path which failed validation,
+// or nil if every hop is valid.
+func (p Path) FirstFailure() *PathHop {
+	for i := range p {
+		if !p[i].Messages.Success() {
+			return &p[i]
+		}
+	}
+	return nil
+}
+
+// TracePath validates each hop of the request path from the source proxy to
+// the destination: the source's Envoy resources for the upstream, the
+// discovery chain for the destination, the mesh gateways used to reach other
+// datacenters, intentions, and the healthy instances of each target.
+func (t *Troubleshoot) TracePath(req PathRequest) (Path, error) {
+	if req.Source == "" || req.Destination == "" {
+		return nil, fmt.Errorf("source and destination are required")
+	}
+	if req.UpstreamEnvoyID == "" && req.UpstreamIP == "" {
+		req.UpstreamEnvoyID = req.Destination
+	}
+
+	var path Path
+	if !req.SkipSourceProxy {
+		hop := PathHop{Name: fmt.Sprintf("Source proxy (%s)", req.Source)}
+		messages, err := t.RunAllTests(req.UpstreamEnvoyID, req.UpstreamIP)
+		if err != nil {
+			messages = validate.Messages{{
+				Message: err.Error(),
+				PossibleActions: []string{
+					"Run this command on the host of the source proxy, or pass -skip-source-proxy",
+				},
+			}}
+		}
+		hop.Messages = messages
+		path = append(path, hop)
+	}
+
+	consulPath, err := tracePathInConsul(t.client, req)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, consulPath...), nil
+}
+
+// tracePathInConsul validates the hops of the path which are determined by
+// the Consul catalog and configuration rather than the source's Envoy.
+func tracePathInConsul(client *api.Client, req PathRequest) (Path, error) {
+	var path Path
+
+	chainHop := PathHop{Name: fmt.Sprintf("Discovery chain (%s)", req.Destination)}
+	resp, _, err := client.DiscoveryChain().Get(req.Destination, nil, nil)
+	if err != nil {
+		chainHop.Messages = validate.Messages{{
+			Message: fmt.Sprintf("unable to compile the discovery chain for %s: %v", req.Destination, err),
+			PossibleActions: []string{
+				"Check the service-router, service-splitter and service-resolver config entries for " + req.Destination,
+			},
+		}}
+		return append(path, chainHop), nil
+	}
+	chain := resp.Chain
+
+	targetIDs := make([]string, 0, len(chain.Targets))
+	for id := range chain.Targets {
+		targetIDs = append(targetIDs, id)
+	}
+	sort.Strings(targetIDs)
+
+	if len(targetIDs) == 0 {
+		chainHop.Messages = validate.Messages{{
+			Message: fmt.Sprintf("the discovery chain for %s does not resolve to any targets", req.Destination),
+		}}
+		return append(path, chainHop), nil
+	}
+	chainHop.Messages = validate.Messages{{
+		Success: true,
+		Message: fmt.Sprintf("Discovery chain for %s resolves to %s", req.Destination, strings.Join(targetIDs, ", ")),
+	}}
+	path = append(path, chainHop)
+
+	for _, id := range targetIDs {
+		target := chain.Targets[id]
+
+		if target.Datacenter != "" && target.Datacenter != chain.Datacenter {
+			hop, err := meshGatewayHop(client, chain.Datacenter, target)
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, hop)
+		}
+
+		hop, err := intentionsHop(client, req.Source, target)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, hop)
+
+		hop, err = destinationHop(client, target)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, hop)
+	}
+
+	return path, nil
+}
+
+func meshGatewayHop(client *api.Client, localDC string, target *api.DiscoveryTarget) (PathHop, error) {
+	hop := PathHop{Name: fmt.Sprintf("Mesh gateway (%s)", target.ID)}
+
+	var gatewayDC string
+	switch target.MeshGateway.Mode {
+	case api.MeshGatewayModeLocal:
+		gatewayDC = localDC
+	case api.MeshGatewayModeRemote:
+		gatewayDC = target.Datacenter
+	default:
+		hop.Messages = validate.Messages{{
+			Success: true,
+			Message: fmt.Sprintf("%s in datacenter %s is dialed directly because mesh gateway mode is none", target.ID, target.Datacenter),
+		}}
+		return hop, nil
+	}
+
+	count, err := healthyMeshGateways(client, gatewayDC)
+	if err != nil {
+		return hop, fmt.Errorf("unable to look up mesh gateways in datacenter %s: %w", gatewayDC, err)
+	}
+	if count == 0 {
+		hop.Messages = validate.Messages{{
+			Message: fmt.Sprintf("mesh gateway mode is %s but there are no healthy mesh gateways in datacenter %s", target.MeshGateway.Mode, gatewayDC),
+			PossibleActions: []string{
+				"Check that a mesh gateway is registered and healthy in datacenter " + gatewayDC,
+				"Check the mesh gateway mode in proxy-defaults, service-defaults and the upstream configuration",
+			},
+		}}
+		return hop, nil
+	}
+	hop.Messages = validate.Messages{{
+		Success: true,
+		Message: fmt.Sprintf("%d healthy mesh gateway(s) in datacenter %s (mode %s)", count, gatewayDC, target.MeshGateway.Mode),
+	}}
+	return hop, nil
+}
+
+func healthyMeshGateways(client *api.Client, dc string) (int, error) {
+	q := &api.QueryOptions{
+		Datacenter: dc,
+		Filter:     fmt.Sprintf("ServiceKind == %q", api.ServiceKindMeshGateway),
+	}
+	services, _, err := client.Catalog().Services(q)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for name := range services {
+		entries, _, err := client.Health().Service(name, "", true, &api.QueryOptions{Datacenter: dc})
+		if err != nil {
+			return 0, err
+		}
+		count += len(entries)
+	}
+	return count, nil
+}
+
+func intentionsHop(client *api.Client, source string, target *api.DiscoveryTarget) (PathHop, error) {
+	hop := PathHop{Name: fmt.Sprintf("Intentions (%s -> %s)", source, target.Service)}
+
+	q := &api.QueryOptions{Datacenter: target.Datacenter, Namespace: target.Namespace}
+	allowed, _, err := client.Connect().IntentionCheck(&api.IntentionCheck{
+		Source:      source,
+		Destination: target.Service,
+		SourceType:  api.IntentionSourceConsul,
+	}, q)
+	if err != nil {
+		return hop, fmt.Errorf("unable to check intentions from %s to %s: %w", source, target.Service, err)
+	}
+	if !allowed {
+		hop.Messages = validate.Messages{{
+			Message: fmt.Sprintf("intentions deny traffic from %s to %s in datacenter %s", source, target.Service, target.Datacenter),
+			PossibleActions: []string{
+				fmt.Sprintf("Add a service-intentions config entry for %s that allows %s", target.Service, source),
+			},
+		}}
+		return hop, nil
+	}
+	hop.Messages = validate.Messages{{
+		Success: true,
+		Message: fmt.Sprintf("Intentions allow traffic from %s to %s", source, target.Service),
+	}}
+	return hop, nil
+}
+
+func destinationHop(client *api.Client, target *api.DiscoveryTarget) (PathHop, error) {
+	hop := PathHop{Name: fmt.Sprintf("Destination (%s)", target.ID)}
+
+	q := &api.QueryOptions{
+		Datacenter: target.Datacenter,
+		Namespace:  target.Namespace,
+		Filter:     target.Subset.Filter,
+	}
+	entries, _, err := client.Health().Connect(target.Service, "", true, q)
+	if err != nil {
+		return hop, fmt.Errorf("unable to look up instances of %s: %w", target.ID, err)
+	}
+	if len(entries) == 0 {
+		hop.Messages = validate.Messages{{
+			Message: fmt.Sprintf("there are no healthy mesh-enabled instances of %s in datacenter %s", target.ID, target.Datacenter),
+			PossibleActions: []string{
+				"Check that " + target.Service + " is registered with a sidecar proxy and its health checks are passing",
+				"Check the subset filter of the service-resolver for " + target.Service,
+			},
+		}}
+		return hop, nil
+	}
+	hop.Messages = validate.Messages{{
+		Success: true,
+		Message: fmt.Sprintf("%d healthy instance(s) of %s", len(entries), target.ID),
+	}}
+	return hop, nil
+}
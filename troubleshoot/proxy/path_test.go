@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package troubleshoot
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// fakeConsul serves the subset of the Consul HTTP API used by TracePath.
+type fakeConsul struct {
+	chain    *api.CompiledDiscoveryChain
+	denied   map[string]bool
+	healthy  map[string]int
+	gateways map[string]int
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	dc := r.URL.Query().Get("dc")
+	if dc == "" {
+		dc = "dc1"
+	}
+
+	var resp interface{}
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/discovery-chain/"):
+		if f.chain == nil {
+			http.Error(w, "no chain", http.StatusInternalServerError)
+			return
+		}
+		resp = api.DiscoveryChainResponse{Chain: f.chain}
+	case r.URL.Path == "/v1/connect/intentions/check":
+		key := r.URL.Query().Get("source") + "->" + r.URL.Query().Get("destination")
+		resp = map[string]bool{"Allowed": !f.denied[key]}
+	case strings.HasPrefix(r.URL.Path, "/v1/health/connect/"):
+		svc := strings.TrimPrefix(r.URL.Path, "/v1/health/connect/")
+		resp = make([]api.ServiceEntry, f.healthy[svc+"."+dc])
+	case r.URL.Path == "/v1/catalog/services":
+		services := map[string][]string{}
+		if f.gateways[dc] > 0 {
+			services["mesh-gateway"] = nil
+		}
+		resp = services
+	case r.URL.Path == "/v1/health/service/mesh-gateway":
+		resp = make([]api.ServiceEntry, f.gateways[dc])
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestTracePath(t *testing.T) {
+	localTarget := &api.DiscoveryTarget{
+		ID:         "db.default.default.dc1",
+		Service:    "db",
+		Namespace:  "default",
+		Datacenter: "dc1",
+	}
+	remoteTarget := &api.DiscoveryTarget{
+		ID:          "db.default.default.dc2",
+		Service:     "db",
+		Namespace:   "default",
+		Datacenter:  "dc2",
+		MeshGateway: api.MeshGatewayConfig{Mode: api.MeshGatewayModeLocal},
+	}
+	chain := func(targets ...*api.DiscoveryTarget) *api.CompiledDiscoveryChain {
+		c := &api.CompiledDiscoveryChain{
+			ServiceName: "db",
+			Datacenter:  "dc1",
+			Targets:     map[string]*api.DiscoveryTarget{},
+		}
+		for _, target := range targets {
+			c.Targets[target.ID] = target
+		}
+		return c
+	}
+
+	cases := map[string]struct {
+		consul      *fakeConsul
+		expectHops  []string
+		expectFails string
+	}{
+		"healthy local path": {
+			consul: &fakeConsul{
+				chain:   chain(localTarget),
+				healthy: map[string]int{"db.dc1": 2},
+			},
+			expectHops: []string{
+				"Discovery chain (db)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc1)",
+			},
+		},
+		"missing discovery chain": {
+			consul:      &fakeConsul{},
+			expectHops:  []string{"Discovery chain (db)"},
+			expectFails: "Discovery chain (db)",
+		},
+		"intention denies": {
+			consul: &fakeConsul{
+				chain:   chain(localTarget),
+				denied:  map[string]bool{"web->db": true},
+				healthy: map[string]int{"db.dc1": 1},
+			},
+			expectHops: []string{
+				"Discovery chain (db)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc1)",
+			},
+			expectFails: "Intentions (web -> db)",
+		},
+		"no healthy instances": {
+			consul: &fakeConsul{
+				chain: chain(localTarget),
+			},
+			expectHops: []string{
+				"Discovery chain (db)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc1)",
+			},
+			expectFails: "Destination (db.default.default.dc1)",
+		},
+		"failover through local mesh gateway": {
+			consul: &fakeConsul{
+				chain:    chain(localTarget, remoteTarget),
+				healthy:  map[string]int{"db.dc1": 1, "db.dc2": 1},
+				gateways: map[string]int{"dc1": 1},
+			},
+			expectHops: []string{
+				"Discovery chain (db)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc1)",
+				"Mesh gateway (db.default.default.dc2)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc2)",
+			},
+		},
+		"no mesh gateway": {
+			consul: &fakeConsul{
+				chain:    chain(remoteTarget),
+				healthy:  map[string]int{"db.dc2": 1},
+				gateways: map[string]int{"dc2": 1},
+			},
+			expectHops: []string{
+				"Discovery chain (db)",
+				"Mesh gateway (db.default.default.dc2)",
+				"Intentions (web -> db)",
+				"Destination (db.default.default.dc2)",
+			},
+			expectFails: "Mesh gateway (db.default.default.dc2)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(tc.consul)
+			defer srv.Close()
+
+			client, err := api.NewClient(&api.Config{Address: srv.URL})
+			require.NoError(t, err)
+			ts, err := NewTroubleshootWithClient(client, &net.IPAddr{IP: net.ParseIP("127.0.0.1")}, "19000")
+			require.NoError(t, err)
+
+			path, err := ts.TracePath(PathRequest{
+				Source:          "web",
+				Destination:     "db",
+				SkipSourceProxy: true,
+			})
+			require.NoError(t, err)
+
+			var hops []string
+			for _, hop := range path {
+				hops = append(hops, hop.Name)
+			}
+			require.Equal(t, tc.expectHops, hops)
+
+			if tc.expectFails == "" {
+				require.True(t, path.Success())
+				require.Nil(t, path.FirstFailure())
+				return
+			}
+			require.False(t, path.Success())
+			require.Equal(t, tc.expectFails, path.FirstFailure().Name)
+		})
+	}
+}
+
+func TestTracePath_SourceProxyUnreachable(t *testing.T) {
+	consul := httptest.NewServer(&fakeConsul{})
+	defer consul.Close()
+
+	// Grab a free port and close it so that connecting to Envoy fails.
+	envoy := httptest.NewServer(http.NotFoundHandler())
+	_, port, err := net.SplitHostPort(envoy.Listener.Addr().String())
+	require.NoError(t, err)
+	envoy.Close()
+
+	client, err := api.NewClient(&api.Config{Address: consul.URL})
+	require.NoError(t, err)
+	ts, err := NewTroubleshootWithClient(client, &net.IPAddr{IP: net.ParseIP("127.0.0.1")}, port)
+	require.NoError(t, err)
+
+	path, err := ts.TracePath(PathRequest{Source: "web", Destination: "db"})
+	require.NoError(t, err)
+	require.Len(t, path, 2)
+	require.Equal(t, "Source proxy (web)", path.FirstFailure().Name)
+	require.Contains(t, path[0].Messages[0].Message, "cannot connect to Envoy")
+}
+
+func TestTracePath_RequiresSourceAndDestination(t *testing.T) {
+	client, err := api.NewClient(api.DefaultConfig())
+	require.NoError(t, err)
+	ts, err := NewTroubleshootWithClient(client, &net.IPAddr{IP: net.ParseIP("127.0.0.1")}, "19000")
+	require.NoError(t, err)
+
+	_, err = ts.TracePath(PathRequest{Source: "web"})
+	require.EqualError(t, err, "source and destination are required")
+}
@@ -35,12 +35,18 @@ func NewTroubleshoot(envoyIP *net.IPAddr, envoyPort string) (*Troubleshoot, erro
 		return nil, err
 	}
 
+	return NewTroubleshootWithClient(c, envoyIP, envoyPort)
+}
+
+// NewTroubleshootWithClient is like NewTroubleshoot but uses the given client
+// to query Consul rather than one built from the environment.
+func NewTroubleshootWithClient(client *api.Client, envoyIP *net.IPAddr, envoyPort string) (*Troubleshoot, error) {
 	if envoyIP == nil {
 		return nil, fmt.Errorf("envoy address is empty")
 	}
 
 	return &Troubleshoot{
-		client:         c,
+		client:         client,
 		envoyAddr:      *envoyIP,
 		envoyAdminPort: envoyPort,
 	}, nil
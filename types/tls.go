@@ -229,3 +229,102 @@ func MarshalEnvoyTLSCipherSuiteStrings(cipherSuites []TLSCipherSuite) []string {
 
 	return cipherSuiteStrings
 }
+
+// TLSCurve is a strongly-typed string for the elliptic curves used in ECDHE
+// key exchange.
+type TLSCurve string
+
+const (
+	TLSCurveX25519 TLSCurve = "X25519"
+	TLSCurveP256   TLSCurve = "P256"
+	TLSCurveP384   TLSCurve = "P384"
+	TLSCurveP521   TLSCurve = "P521"
+)
+
+var (
+	tlsCurves = map[TLSCurve]struct{}{
+		TLSCurveX25519: {},
+		TLSCurveP256:   {},
+		TLSCurveP384:   {},
+		TLSCurveP521:   {},
+	}
+
+	// The subset of settings which are approved for use in FIPS 140
+	// environments.
+	fipsTLSVersions = map[TLSVersion]struct{}{
+		// The Consul agent treats TLS_AUTO as TLS 1.2.
+		TLSVersionAuto: {},
+		TLSv1_2:        {},
+		TLSv1_3:        {},
+	}
+	fipsTLSCipherSuites = map[TLSCipherSuite]struct{}{
+		TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: {},
+		TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: {},
+		TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   {},
+		TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   {},
+	}
+	fipsTLSCurves = map[TLSCurve]struct{}{
+		TLSCurveP256: {},
+		TLSCurveP384: {},
+		TLSCurveP521: {},
+	}
+)
+
+func (c *TLSCurve) String() string {
+	return string(*c)
+}
+
+func TLSCurves() string {
+	curves := []string{}
+	for c := range tlsCurves {
+		curves = append(curves, string(c))
+	}
+	sort.Strings(curves)
+	return strings.Join(curves, ", ")
+}
+
+func ValidateTLSCurves(curves []TLSCurve) error {
+	var unmatched []string
+
+	for _, c := range curves {
+		if _, ok := tlsCurves[c]; !ok {
+			unmatched = append(unmatched, c.String())
+		}
+	}
+
+	if len(unmatched) > 0 {
+		return fmt.Errorf("no matching TLS curve found for %s, please specify one of [%s]", strings.Join(unmatched, ","), TLSCurves())
+	}
+	return nil
+}
+
+// ValidateFIPSTLSSettings checks that a TLS minimum version, cipher suites
+// and curve preferences only allow algorithms approved for FIPS 140. An empty
+// list of cipher suites or curves means the implementation defaults, which
+// are restricted to approved algorithms in FIPS builds.
+func ValidateFIPSTLSSettings(minVersion TLSVersion, cipherSuites []TLSCipherSuite, curves []TLSCurve) error {
+	if _, ok := fipsTLSVersions[minVersion]; !ok {
+		return fmt.Errorf("TLS version %s is not allowed in FIPS mode, the minimum version must be at least %s", minVersion.String(), TLSv1_2)
+	}
+
+	var unmatched []string
+	for _, c := range cipherSuites {
+		if _, ok := fipsTLSCipherSuites[c]; !ok {
+			unmatched = append(unmatched, c.String())
+		}
+	}
+	if len(unmatched) > 0 {
+		return fmt.Errorf("TLS cipher suites %s are not allowed in FIPS mode", strings.Join(unmatched, ","))
+	}
+
+	unmatched = nil
+	for _, c := range curves {
+		if _, ok := fipsTLSCurves[c]; !ok {
+			unmatched = append(unmatched, c.String())
+		}
+	}
+	if len(unmatched) > 0 {
+		return fmt.Errorf("TLS curves %s are not allowed in FIPS mode", strings.Join(unmatched, ","))
+	}
+	return nil
+}
@@ -51,3 +51,29 @@ func TestTLSVersion_ToJSON(t *testing.T) {
 		require.Equal(t, tlsVersion, version)
 	}
 }
+
+func TestValidateTLSCurves(t *testing.T) {
+	require.NoError(t, ValidateTLSCurves(nil))
+	require.NoError(t, ValidateTLSCurves([]TLSCurve{"X25519", "P256", "P384", "P521"}))
+	require.EqualError(t, ValidateTLSCurves([]TLSCurve{"P256", "P224", "secp256r1"}),
+		"no matching TLS curve found for P224,secp256r1, please specify one of [P256, P384, P521, X25519]")
+}
+
+func TestValidateFIPSTLSSettings(t *testing.T) {
+	require.NoError(t, ValidateFIPSTLSSettings(TLSVersionAuto, nil, nil))
+	require.NoError(t, ValidateFIPSTLSSettings(
+		TLSv1_2,
+		[]TLSCipherSuite{TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		[]TLSCurve{TLSCurveP256, TLSCurveP384},
+	))
+
+	require.EqualError(t, ValidateFIPSTLSSettings(TLSv1_1, nil, nil),
+		"TLS version TLSv1_1 is not allowed in FIPS mode, the minimum version must be at least TLSv1_2")
+	require.EqualError(t, ValidateFIPSTLSSettings(
+		TLSv1_2,
+		[]TLSCipherSuite{TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256, TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA},
+		nil,
+	), "TLS cipher suites TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA are not allowed in FIPS mode")
+	require.EqualError(t, ValidateFIPSTLSSettings(TLSv1_3, nil, []TLSCurve{TLSCurveX25519, TLSCurveP256}),
+		"TLS curves X25519 are not allowed in FIPS mode")
+}